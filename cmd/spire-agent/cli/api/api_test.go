@@ -185,6 +185,135 @@ func TestFetchJWTCommand(t *testing.T) {
 	}
 }
 
+func TestFetchWITCommandHelp(t *testing.T) {
+	test := setupTest(t, newFetchWITCommandWithEnv)
+	test.cmd.Help()
+	require.Equal(t, fetchWITUsage, test.stderr.String())
+}
+
+func TestFetchWITCommandSynopsis(t *testing.T) {
+	test := setupTest(t, newFetchWITCommandWithEnv)
+	require.Equal(t, "Fetches WIT-SVIDs from the Workload API", test.cmd.Synopsis())
+}
+
+func TestFetchWITCommand(t *testing.T) {
+	bundleJWKS := `{"keys":[]}`
+	witSVIDKey := `{"kty":"EC"}`
+
+	tests := []struct {
+		name                 string
+		args                 []string
+		fakeRequests         []*fakeworkloadapi.FakeRequest
+		expectedStderr       string
+		expectedStdoutPretty []string
+		expectedStdoutJSON   string
+	}{
+		{
+			name: "success fetching wit with bundles",
+			args: []string{"-spiffeID", "spiffe://domain1.test"},
+			fakeRequests: []*fakeworkloadapi.FakeRequest{
+				{
+					Req: &workload.WITBundlesRequest{},
+					Resp: &workload.WITBundlesResponse{
+						Bundles: map[string]string{
+							"spiffe://domain1.test": bundleJWKS,
+						},
+					},
+				},
+				{
+					Req: &workload.WITSVIDRequest{
+						SpiffeId: "spiffe://domain1.test",
+					},
+					Resp: &workload.WITSVIDResponse{
+						Svids: []*workload.WITSVID{
+							{
+								SpiffeId:   "spiffe://domain1.test",
+								WitSvid:    "token1",
+								WitSvidKey: witSVIDKey,
+								Hint:       "external",
+							},
+						},
+					},
+				},
+			},
+			expectedStdoutPretty: []string{
+				"token(spiffe://domain1.test):\n\ttoken1",
+				"hint(spiffe://domain1.test):\n\texternal",
+				fmt.Sprintf("bundle(spiffe://domain1.test):\n\t%s", bundleJWKS),
+			},
+			expectedStdoutJSON: `[
+  {
+    "svids": [
+      {
+        "hint": "external",
+        "spiffe_id": "spiffe://domain1.test",
+        "wit_svid": "token1",
+        "wit_svid_key": "{\"kty\":\"EC\"}"
+      }
+    ]
+  },
+  {
+    "bundles": {
+      "spiffe://domain1.test": "{\"keys\":[]}"
+    }
+  }
+]`,
+		},
+		{
+			name: "fail with error fetching bundles",
+			fakeRequests: []*fakeworkloadapi.FakeRequest{
+				{
+					Req:  &workload.WITBundlesRequest{},
+					Resp: &workload.WITBundlesResponse{},
+					Err:  status.Error(codes.Unimplemented, "WIT functionality is disabled"),
+				},
+			},
+			expectedStderr: "rpc error: code = Unimplemented desc = WIT functionality is disabled\n",
+		},
+		{
+			name: "fail with error fetching svid",
+			fakeRequests: []*fakeworkloadapi.FakeRequest{
+				{
+					Req: &workload.WITBundlesRequest{},
+					Resp: &workload.WITBundlesResponse{
+						Bundles: map[string]string{
+							"spiffe://domain1.test": bundleJWKS,
+						},
+					},
+				},
+				{
+					Req:  &workload.WITSVIDRequest{},
+					Resp: &workload.WITSVIDResponse{},
+					Err:  errors.New("error fetching svid"),
+				},
+			},
+			expectedStderr: "rpc error: code = Unknown desc = error fetching svid\n",
+		},
+	}
+
+	for _, tt := range tests {
+		for _, format := range availableFormats {
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, newFetchWITCommandWithEnv, tt.fakeRequests...)
+				args := tt.args
+				args = append(args, "-output", format)
+
+				rc := test.cmd.Run(test.args(args...))
+
+				if tt.expectedStderr != "" {
+					assert.Equal(t, 1, rc)
+					assert.Equal(t, tt.expectedStderr, test.stderr.String())
+					return
+				}
+
+				assertOutputBasedOnFormat(t, format, test.stdout.String(), tt.expectedStdoutJSON, tt.expectedStdoutPretty...)
+				assert.Empty(t, test.stderr.String())
+				assert.Equal(t, 0, rc)
+			})
+		}
+	}
+}
+
 func TestFetchX509CommandHelp(t *testing.T) {
 	test := setupTest(t, newFetchX509Command)
 	test.cmd.Help()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/spiffe/go-spiffe/v2/exp/svid/witsvid"
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 )
 
@@ -56,3 +57,23 @@ func printX509FederatedBundle(env *commoncli.Env, trustDomain string, bundle []*
 		env.Printf("[%s] CA #%v Valid Until:\t%v\n", trustDomain, num, ca.NotAfter)
 	}
 }
+
+func printWITSVIDs(env *commoncli.Env, svids []*witsvid.SVID, respTime time.Duration) {
+	lenMsg := fmt.Sprintf("Received %d WIT-SVID", len(svids))
+	if len(svids) != 1 {
+		lenMsg += "s"
+	}
+	lenMsg += fmt.Sprintf(" after %s", respTime)
+
+	env.Println(lenMsg)
+	for _, svid := range svids {
+		env.Println()
+		env.Printf("SPIFFE ID:\t\t%s\n", svid.ID)
+		if svid.Hint != "" {
+			env.Printf("Hint:\t\t\t%s\n", svid.Hint)
+		}
+		env.Printf("WIT-SVID Valid Until:\t%v\n", svid.Expiry)
+	}
+
+	env.Println()
+}
@@ -9,6 +9,7 @@ import (
 	"os/signal"
 	"time"
 
+	"github.com/spiffe/go-spiffe/v2/exp/svid/witsvid"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/common"
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
@@ -17,6 +18,7 @@ import (
 
 type WatchCLI struct {
 	config *common.ConfigOS
+	wit    bool
 }
 
 func (WatchCLI) Synopsis() string {
@@ -50,7 +52,12 @@ func (w *WatchCLI) Run(args []string) int {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 
-	if err := workloadapi.WatchX509Context(ctx, newWatcher(), clientOption); err != nil {
+	if w.wit {
+		err = watchWITSVIDs(ctx, clientOption)
+	} else {
+		err = workloadapi.WatchX509Context(ctx, newWatcher(), clientOption)
+	}
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return 1
 	}
@@ -62,6 +69,7 @@ func (w *WatchCLI) parseConfig(args []string) error {
 	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
 	c := &common.ConfigOS{}
 	c.AddOSFlags(fs)
+	fs.BoolVar(&w.wit, "wit", false, "Watch WIT-SVIDs instead of X509-SVIDs")
 
 	w.config = c
 	return fs.Parse(args)
@@ -106,3 +114,32 @@ func (w *watcher) OnX509ContextUpdate(x509Context *workloadapi.X509Context) {
 func (w *watcher) OnX509ContextWatchError(err error) {
 	fmt.Fprintln(os.Stderr, err)
 }
+
+func watchWITSVIDs(ctx context.Context, clientOption workloadapi.ClientOption) error {
+	client, err := workloadapi.New(ctx, clientOption)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return client.WatchWITSVIDs(ctx, newWITWatcher(), "")
+}
+
+type witWatcher struct {
+	updateTime time.Time
+}
+
+func newWITWatcher() *witWatcher {
+	return &witWatcher{
+		updateTime: time.Now(),
+	}
+}
+
+func (w *witWatcher) OnWITSVIDsUpdate(svids []*witsvid.SVID) {
+	printWITSVIDs(commoncli.DefaultEnv, svids, time.Since(w.updateTime))
+	w.updateTime = time.Now()
+}
+
+func (w *witWatcher) OnWITSVIDsWatchError(err error) {
+	fmt.Fprintln(os.Stderr, err)
+}
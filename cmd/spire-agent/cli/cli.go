@@ -6,6 +6,7 @@ import (
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/api"
+	"github.com/spiffe/spire/cmd/spire-agent/cli/debug"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/logger"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/run"
@@ -32,12 +33,27 @@ func (cc *CLI) Run(ctx context.Context, args []string) int {
 		"api fetch jwt": func() (cli.Command, error) {
 			return api.NewFetchJWTCommand(), nil
 		},
+		"api fetch wit": func() (cli.Command, error) {
+			return api.NewFetchWITCommand(), nil
+		},
 		"api validate jwt": func() (cli.Command, error) {
 			return api.NewValidateJWTCommand(), nil
 		},
 		"api watch": func() (cli.Command, error) {
 			return &api.WatchCLI{}, nil
 		},
+		"debug entries": func() (cli.Command, error) {
+			return debug.NewEntriesCommand(), nil
+		},
+		"debug stale": func() (cli.Command, error) {
+			return debug.NewStaleCommand(), nil
+		},
+		"debug sync": func() (cli.Command, error) {
+			return debug.NewSyncCommand(), nil
+		},
+		"debug workload": func() (cli.Command, error) {
+			return debug.NewWorkloadCommand(), nil
+		},
 		"run": func() (cli.Command, error) {
 			return run.NewRunCommand(ctx, cc.LogOptions, cc.AllowUnknownConfig), nil
 		},
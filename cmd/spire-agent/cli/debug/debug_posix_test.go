@@ -0,0 +1,36 @@
+//go:build !windows
+
+package debug_test
+
+const (
+	addrArg = "-socketPath"
+)
+
+var (
+	entriesUsage = `Usage of debug entries:
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+  -socketPath string
+    	Path to the SPIRE Agent admin API socket (default "/tmp/spire-agent/private/admin.sock")
+`
+	staleUsage = `Usage of debug stale:
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+  -socketPath string
+    	Path to the SPIRE Agent admin API socket (default "/tmp/spire-agent/private/admin.sock")
+`
+	syncUsage = `Usage of debug sync:
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+  -socketPath string
+    	Path to the SPIRE Agent admin API socket (default "/tmp/spire-agent/private/admin.sock")
+`
+	workloadUsage = `Usage of debug workload:
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+  -pid int
+    	The PID of the workload to attest
+  -socketPath string
+    	Path to the SPIRE Agent admin API socket (default "/tmp/spire-agent/private/admin.sock")
+`
+)
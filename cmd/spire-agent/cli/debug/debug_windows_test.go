@@ -0,0 +1,36 @@
+//go:build windows
+
+package debug_test
+
+const (
+	addrArg = "-namedPipeName"
+)
+
+var (
+	entriesUsage = `Usage of debug entries:
+  -namedPipeName string
+    	Pipe name of the SPIRE Agent admin API named pipe (default "\\spire-agent\\private\\admin")
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+`
+	staleUsage = `Usage of debug stale:
+  -namedPipeName string
+    	Pipe name of the SPIRE Agent admin API named pipe (default "\\spire-agent\\private\\admin")
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+`
+	syncUsage = `Usage of debug sync:
+  -namedPipeName string
+    	Pipe name of the SPIRE Agent admin API named pipe (default "\\spire-agent\\private\\admin")
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+`
+	workloadUsage = `Usage of debug workload:
+  -namedPipeName string
+    	Pipe name of the SPIRE Agent admin API named pipe (default "\\spire-agent\\private\\admin")
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+  -pid int
+    	The PID of the workload to attest
+`
+)
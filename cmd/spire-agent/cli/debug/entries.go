@@ -0,0 +1,85 @@
+package debug
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-agent/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+)
+
+type entriesCommand struct {
+	env     *commoncli.Env
+	printer cliprinter.Printer
+}
+
+// NewEntriesCommand returns a cli.Command that lists the cached entries using
+// the default cli environment.
+func NewEntriesCommand() cli.Command {
+	return NewEntriesCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewEntriesCommandWithEnv returns a cli.Command that lists the registration
+// entries in the agent cache.
+func NewEntriesCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &entriesCommand{env: env})
+}
+
+func (*entriesCommand) Name() string {
+	return "debug entries"
+}
+
+func (*entriesCommand) Synopsis() string {
+	return "Lists the registration entries in the agent cache"
+}
+
+func (c *entriesCommand) AppendFlags(fs *flag.FlagSet) {
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintEntries)
+}
+
+func (c *entriesCommand) Run(ctx context.Context, _ *commoncli.Env, agentClient util.AgentClient) error {
+	resp, err := agentClient.NewDebugClient().ListCachedEntries(ctx, &spiredebug.ListCachedEntriesRequest{})
+	if err != nil {
+		return fmt.Errorf("error listing cached entries: %w", err)
+	}
+
+	return c.printer.PrintProto(resp)
+}
+
+func prettyPrintEntries(env *commoncli.Env, results ...any) error {
+	resp, ok := results[0].(*spiredebug.ListCachedEntriesResponse)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	if len(resp.Entries) == 0 {
+		return env.Printf("No cached entries found\n")
+	}
+
+	if err := env.Printf("Found %d cached entries\n\n", len(resp.Entries)); err != nil {
+		return err
+	}
+	for _, entry := range resp.Entries {
+		_ = env.Printf("Entry ID         : %s\n", entry.EntryId)
+		_ = env.Printf("SPIFFE ID        : %s\n", entry.SpiffeId)
+		_ = env.Printf("Parent ID        : %s\n", entry.ParentId)
+		printSelectors(env.Printf, "Selector         ", entry.Selectors)
+		if entry.EntryExpiresAt != 0 {
+			_ = env.Printf("Expiration time  : %s\n", printableTime(entry.EntryExpiresAt, ""))
+		}
+		_ = env.Printf("SVID status      : %s\n", strings.ToLower(entry.SvidStatus.String()))
+		if entry.SvidExpiresAt != 0 {
+			_ = env.Printf("SVID expires at  : %s\n", printableTime(entry.SvidExpiresAt, ""))
+		}
+		if err := env.Println(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package debug_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/cmd/spire-agent/cli/debug"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntriesHelp(t *testing.T) {
+	test := setupCliTest(t, &mockDebugService{}, debug.NewEntriesCommandWithEnv)
+	test.client.Help()
+	require.Equal(t, "", test.stdout.String())
+	require.Equal(t, entriesUsage, test.stderr.String())
+}
+
+func TestEntriesSynopsis(t *testing.T) {
+	cmd := debug.NewEntriesCommand()
+	require.Equal(t, "Lists the registration entries in the agent cache", cmd.Synopsis())
+}
+
+func TestEntries(t *testing.T) {
+	resp := &spiredebug.ListCachedEntriesResponse{
+		Entries: []*spiredebug.CachedEntry{
+			{
+				EntryId:        "entry-1",
+				SpiffeId:       "spiffe://example.org/one",
+				ParentId:       "spiffe://example.org/spire/agent/foo",
+				Selectors:      []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				EntryExpiresAt: 1800000000,
+				SvidStatus:     spiredebug.CachedEntry_MISSING,
+			},
+			{
+				EntryId:       "entry-2",
+				SpiffeId:      "spiffe://example.org/two",
+				ParentId:      "spiffe://example.org/spire/agent/foo",
+				Selectors:     []*common.Selector{{Type: "unix", Value: "uid:1000"}, {Type: "!k8s", Value: "ns:kube-system"}},
+				SvidStatus:    spiredebug.CachedEntry_STALE,
+				SvidExpiresAt: 1700000000,
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name             string
+		server           *mockDebugService
+		args             []string
+		expectReturnCode int
+		expectStdout     string
+		expectStderr     string
+	}{
+		{
+			name:   "pretty output",
+			args:   []string{"-output", "pretty"},
+			server: &mockDebugService{cachedEntriesResp: resp},
+			expectStdout: `Found 2 cached entries
+
+Entry ID         : entry-1
+SPIFFE ID        : spiffe://example.org/one
+Parent ID        : spiffe://example.org/spire/agent/foo
+Selector         : unix:uid:1000
+Expiration time  : 2027-01-15 08:00:00 +0000 UTC
+SVID status      : missing
+
+Entry ID         : entry-2
+SPIFFE ID        : spiffe://example.org/two
+Parent ID        : spiffe://example.org/spire/agent/foo
+Selector         : unix:uid:1000
+Selector         : !k8s:ns:kube-system
+SVID status      : stale
+SVID expires at  : 2023-11-14 22:13:20 +0000 UTC
+
+`,
+		},
+		{
+			name:         "no entries",
+			args:         []string{"-output", "pretty"},
+			server:       &mockDebugService{cachedEntriesResp: &spiredebug.ListCachedEntriesResponse{}},
+			expectStdout: "No cached entries found\n",
+		},
+		{
+			name:   "json output",
+			args:   []string{"-output", "json"},
+			server: &mockDebugService{cachedEntriesResp: resp},
+			expectStdout: `{"entries":[{"entry_expires_at":"1800000000","entry_id":"entry-1","parent_id":"spiffe://example.org/spire/agent/foo","selectors":[{"type":"unix","value":"uid:1000"}],"spiffe_id":"spiffe://example.org/one","svid_expires_at":"0","svid_status":"MISSING"},{"entry_expires_at":"0","entry_id":"entry-2","parent_id":"spiffe://example.org/spire/agent/foo","selectors":[{"type":"unix","value":"uid:1000"},{"type":"!k8s","value":"ns:kube-system"}],"spiffe_id":"spiffe://example.org/two","svid_expires_at":"1700000000","svid_status":"STALE"}]}
+`,
+		},
+		{
+			name:             "server will error",
+			args:             []string{"-output", "pretty"},
+			server:           &mockDebugService{returnErr: errors.New("server is unavailable")},
+			expectReturnCode: 1,
+			expectStderr: `Error: error listing cached entries: rpc error: code = Unknown desc = server is unavailable
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupCliTest(t, tt.server, debug.NewEntriesCommandWithEnv)
+			returnCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectReturnCode, returnCode)
+		})
+	}
+}
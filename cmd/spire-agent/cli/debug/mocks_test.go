@@ -0,0 +1,93 @@
+package debug_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+	"github.com/spiffe/spire/test/clitest"
+	"github.com/spiffe/spire/test/spiretest"
+	"google.golang.org/grpc"
+)
+
+// an input/output capture struct
+type debugTest struct {
+	stdin  *bytes.Buffer
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	args   []string
+	server *mockDebugService
+	client cli.Command
+}
+
+// serialization of capture
+func (d *debugTest) afterTest(t *testing.T) {
+	t.Logf("TEST:%s", t.Name())
+	t.Logf("STDOUT:\n%s", d.stdout.String())
+	t.Logf("STDIN:\n%s", d.stdin.String())
+	t.Logf("STDERR:\n%s", d.stderr.String())
+}
+
+// setup of input/output capture
+func setupCliTest(t *testing.T, server *mockDebugService, newClient func(*commoncli.Env) cli.Command) *debugTest {
+	addr := spiretest.StartGRPCServer(t, func(s *grpc.Server) {
+		spiredebug.RegisterAPIServer(s, server)
+	})
+
+	stdin := new(bytes.Buffer)
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	client := newClient(&commoncli.Env{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	test := &debugTest{
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+		args:   []string{addrArg, clitest.GetAddr(addr)},
+		server: server,
+		client: client,
+	}
+
+	t.Cleanup(func() {
+		test.afterTest(t)
+	})
+
+	return test
+}
+
+// a mock grpc debug extension server
+type mockDebugService struct {
+	spiredebug.UnimplementedAPIServer
+
+	receivedPID       int32
+	cachedEntriesResp *spiredebug.ListCachedEntriesResponse
+	staleEntriesResp  *spiredebug.ListStaleEntriesResponse
+	syncStatusResp    *spiredebug.GetSyncStatusResponse
+	explainResp       *spiredebug.ExplainWorkloadResponse
+	returnErr         error
+}
+
+func (s *mockDebugService) ListCachedEntries(context.Context, *spiredebug.ListCachedEntriesRequest) (*spiredebug.ListCachedEntriesResponse, error) {
+	return s.cachedEntriesResp, s.returnErr
+}
+
+func (s *mockDebugService) ListStaleEntries(context.Context, *spiredebug.ListStaleEntriesRequest) (*spiredebug.ListStaleEntriesResponse, error) {
+	return s.staleEntriesResp, s.returnErr
+}
+
+func (s *mockDebugService) GetSyncStatus(context.Context, *spiredebug.GetSyncStatusRequest) (*spiredebug.GetSyncStatusResponse, error) {
+	return s.syncStatusResp, s.returnErr
+}
+
+func (s *mockDebugService) ExplainWorkload(_ context.Context, req *spiredebug.ExplainWorkloadRequest) (*spiredebug.ExplainWorkloadResponse, error) {
+	s.receivedPID = req.Pid
+	return s.explainResp, s.returnErr
+}
@@ -0,0 +1,76 @@
+package debug
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-agent/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+)
+
+type staleCommand struct {
+	env     *commoncli.Env
+	printer cliprinter.Printer
+}
+
+// NewStaleCommand returns a cli.Command that lists the stale entries using
+// the default cli environment.
+func NewStaleCommand() cli.Command {
+	return NewStaleCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewStaleCommandWithEnv returns a cli.Command that lists the cached entries
+// waiting for a new X509-SVID.
+func NewStaleCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &staleCommand{env: env})
+}
+
+func (*staleCommand) Name() string {
+	return "debug stale"
+}
+
+func (*staleCommand) Synopsis() string {
+	return "Lists the cached entries waiting for a new X509-SVID"
+}
+
+func (c *staleCommand) AppendFlags(fs *flag.FlagSet) {
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintStaleEntries)
+}
+
+func (c *staleCommand) Run(ctx context.Context, _ *commoncli.Env, agentClient util.AgentClient) error {
+	resp, err := agentClient.NewDebugClient().ListStaleEntries(ctx, &spiredebug.ListStaleEntriesRequest{})
+	if err != nil {
+		return fmt.Errorf("error listing stale entries: %w", err)
+	}
+
+	return c.printer.PrintProto(resp)
+}
+
+func prettyPrintStaleEntries(env *commoncli.Env, results ...any) error {
+	resp, ok := results[0].(*spiredebug.ListStaleEntriesResponse)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	if len(resp.Entries) == 0 {
+		return env.Printf("No stale entries found\n")
+	}
+
+	if err := env.Printf("Found %d stale entries\n\n", len(resp.Entries)); err != nil {
+		return err
+	}
+	for _, entry := range resp.Entries {
+		_ = env.Printf("Entry ID         : %s\n", entry.EntryId)
+		_ = env.Printf("SPIFFE ID        : %s\n", entry.SpiffeId)
+		_ = env.Printf("SVID expires at  : %s\n", printableTime(entry.SvidExpiresAt, "no SVID cached"))
+		if err := env.Println(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
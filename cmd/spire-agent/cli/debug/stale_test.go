@@ -0,0 +1,93 @@
+package debug_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/cmd/spire-agent/cli/debug"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleHelp(t *testing.T) {
+	test := setupCliTest(t, &mockDebugService{}, debug.NewStaleCommandWithEnv)
+	test.client.Help()
+	require.Equal(t, "", test.stdout.String())
+	require.Equal(t, staleUsage, test.stderr.String())
+}
+
+func TestStaleSynopsis(t *testing.T) {
+	cmd := debug.NewStaleCommand()
+	require.Equal(t, "Lists the cached entries waiting for a new X509-SVID", cmd.Synopsis())
+}
+
+func TestStale(t *testing.T) {
+	resp := &spiredebug.ListStaleEntriesResponse{
+		Entries: []*spiredebug.StaleEntry{
+			{
+				EntryId:       "entry-1",
+				SpiffeId:      "spiffe://example.org/one",
+				SvidExpiresAt: 1700000000,
+			},
+			{
+				EntryId:  "entry-2",
+				SpiffeId: "spiffe://example.org/two",
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name             string
+		server           *mockDebugService
+		args             []string
+		expectReturnCode int
+		expectStdout     string
+		expectStderr     string
+	}{
+		{
+			name:   "pretty output",
+			args:   []string{"-output", "pretty"},
+			server: &mockDebugService{staleEntriesResp: resp},
+			expectStdout: `Found 2 stale entries
+
+Entry ID         : entry-1
+SPIFFE ID        : spiffe://example.org/one
+SVID expires at  : 2023-11-14 22:13:20 +0000 UTC
+
+Entry ID         : entry-2
+SPIFFE ID        : spiffe://example.org/two
+SVID expires at  : no SVID cached
+
+`,
+		},
+		{
+			name:         "no entries",
+			args:         []string{"-output", "pretty"},
+			server:       &mockDebugService{staleEntriesResp: &spiredebug.ListStaleEntriesResponse{}},
+			expectStdout: "No stale entries found\n",
+		},
+		{
+			name:   "json output",
+			args:   []string{"-output", "json"},
+			server: &mockDebugService{staleEntriesResp: resp},
+			expectStdout: `{"entries":[{"entry_id":"entry-1","spiffe_id":"spiffe://example.org/one","svid_expires_at":"1700000000"},{"entry_id":"entry-2","spiffe_id":"spiffe://example.org/two","svid_expires_at":"0"}]}
+`,
+		},
+		{
+			name:             "server will error",
+			args:             []string{"-output", "pretty"},
+			server:           &mockDebugService{returnErr: errors.New("server is unavailable")},
+			expectReturnCode: 1,
+			expectStderr: `Error: error listing stale entries: rpc error: code = Unknown desc = server is unavailable
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupCliTest(t, tt.server, debug.NewStaleCommandWithEnv)
+			returnCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectReturnCode, returnCode)
+		})
+	}
+}
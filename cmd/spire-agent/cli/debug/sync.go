@@ -0,0 +1,71 @@
+package debug
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-agent/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+)
+
+type syncCommand struct {
+	env     *commoncli.Env
+	printer cliprinter.Printer
+}
+
+// NewSyncCommand returns a cli.Command that gets the sync status using the
+// default cli environment.
+func NewSyncCommand() cli.Command {
+	return NewSyncCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewSyncCommandWithEnv returns a cli.Command that gets the time and result
+// of the last synchronization of the agent cache with SPIRE Server.
+func NewSyncCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &syncCommand{env: env})
+}
+
+func (*syncCommand) Name() string {
+	return "debug sync"
+}
+
+func (*syncCommand) Synopsis() string {
+	return "Gets the status of the last sync with SPIRE Server"
+}
+
+func (c *syncCommand) AppendFlags(fs *flag.FlagSet) {
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintSyncStatus)
+}
+
+func (c *syncCommand) Run(ctx context.Context, _ *commoncli.Env, agentClient util.AgentClient) error {
+	resp, err := agentClient.NewDebugClient().GetSyncStatus(ctx, &spiredebug.GetSyncStatusRequest{})
+	if err != nil {
+		return fmt.Errorf("error fetching sync status: %w", err)
+	}
+
+	return c.printer.PrintProto(resp)
+}
+
+func prettyPrintSyncStatus(env *commoncli.Env, results ...any) error {
+	resp, ok := results[0].(*spiredebug.GetSyncStatusResponse)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	_ = env.Printf("Last sync         : %s\n", printableTime(resp.LastSync, "never"))
+	_ = env.Printf("Last sync attempt : %s\n", printableTime(resp.LastSyncAttempt, "never"))
+	switch {
+	case resp.LastSyncAttempt == 0:
+	case resp.LastError == "":
+		_ = env.Printf("Last sync result  : success\n")
+	default:
+		_ = env.Printf("Last sync result  : failure\n")
+		_ = env.Printf("Last sync error   : %s\n", resp.LastError)
+	}
+
+	return env.Println()
+}
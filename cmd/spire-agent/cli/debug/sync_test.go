@@ -0,0 +1,98 @@
+package debug_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/cmd/spire-agent/cli/debug"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncHelp(t *testing.T) {
+	test := setupCliTest(t, &mockDebugService{}, debug.NewSyncCommandWithEnv)
+	test.client.Help()
+	require.Equal(t, "", test.stdout.String())
+	require.Equal(t, syncUsage, test.stderr.String())
+}
+
+func TestSyncSynopsis(t *testing.T) {
+	cmd := debug.NewSyncCommand()
+	require.Equal(t, "Gets the status of the last sync with SPIRE Server", cmd.Synopsis())
+}
+
+func TestSync(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		server           *mockDebugService
+		args             []string
+		expectReturnCode int
+		expectStdout     string
+		expectStderr     string
+	}{
+		{
+			name:   "never synced",
+			args:   []string{"-output", "pretty"},
+			server: &mockDebugService{syncStatusResp: &spiredebug.GetSyncStatusResponse{}},
+			expectStdout: `Last sync         : never
+Last sync attempt : never
+
+`,
+		},
+		{
+			name: "last sync succeeded",
+			args: []string{"-output", "pretty"},
+			server: &mockDebugService{syncStatusResp: &spiredebug.GetSyncStatusResponse{
+				LastSync:        1700000000,
+				LastSyncAttempt: 1700000000,
+			}},
+			expectStdout: `Last sync         : 2023-11-14 22:13:20 +0000 UTC
+Last sync attempt : 2023-11-14 22:13:20 +0000 UTC
+Last sync result  : success
+
+`,
+		},
+		{
+			name: "last sync failed",
+			args: []string{"-output", "pretty"},
+			server: &mockDebugService{syncStatusResp: &spiredebug.GetSyncStatusResponse{
+				LastSync:        1700000000,
+				LastSyncAttempt: 1700000060,
+				LastError:       "failed to fetch authorized entries: oh no",
+			}},
+			expectStdout: `Last sync         : 2023-11-14 22:13:20 +0000 UTC
+Last sync attempt : 2023-11-14 22:14:20 +0000 UTC
+Last sync result  : failure
+Last sync error   : failed to fetch authorized entries: oh no
+
+`,
+		},
+		{
+			name: "json output",
+			args: []string{"-output", "json"},
+			server: &mockDebugService{syncStatusResp: &spiredebug.GetSyncStatusResponse{
+				LastSync:        1700000000,
+				LastSyncAttempt: 1700000060,
+				LastError:       "oh no",
+			}},
+			expectStdout: `{"last_error":"oh no","last_sync":"1700000000","last_sync_attempt":"1700000060"}
+`,
+		},
+		{
+			name:             "server will error",
+			args:             []string{"-output", "pretty"},
+			server:           &mockDebugService{returnErr: errors.New("server is unavailable")},
+			expectReturnCode: 1,
+			expectStderr: `Error: error fetching sync status: rpc error: code = Unknown desc = server is unavailable
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupCliTest(t, tt.server, debug.NewSyncCommandWithEnv)
+			returnCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectReturnCode, returnCode)
+		})
+	}
+}
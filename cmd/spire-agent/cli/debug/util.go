@@ -0,0 +1,22 @@
+package debug
+
+import (
+	"time"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// printableTime returns the UTC time of the given Unix timestamp, or the
+// given placeholder when it is not set
+func printableTime(unix int64, placeholder string) string {
+	if unix == 0 {
+		return placeholder
+	}
+	return time.Unix(unix, 0).UTC().String()
+}
+
+func printSelectors(printf func(string, ...any) error, label string, selectors []*common.Selector) {
+	for _, s := range selectors {
+		_ = printf("%s: %s:%s\n", label, s.Type, s.Value)
+	}
+}
@@ -0,0 +1,106 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-agent/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	commonutil "github.com/spiffe/spire/pkg/common/util"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+)
+
+type workloadCommand struct {
+	env     *commoncli.Env
+	pid     int
+	printer cliprinter.Printer
+}
+
+// NewWorkloadCommand returns a cli.Command that explains the identities of a
+// workload using the default cli environment.
+func NewWorkloadCommand() cli.Command {
+	return NewWorkloadCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewWorkloadCommandWithEnv returns a cli.Command that attests a workload and
+// reports which cached entries match, or miss, its selectors.
+func NewWorkloadCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &workloadCommand{env: env})
+}
+
+func (*workloadCommand) Name() string {
+	return "debug workload"
+}
+
+func (*workloadCommand) Synopsis() string {
+	return "Explains which cached entries match the selectors of a workload"
+}
+
+func (c *workloadCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.pid, "pid", 0, "The PID of the workload to attest")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintWorkload)
+}
+
+func (c *workloadCommand) Run(ctx context.Context, _ *commoncli.Env, agentClient util.AgentClient) error {
+	if c.pid <= 0 {
+		return errors.New("a positive value (-pid) must be set")
+	}
+	pid, err := commonutil.CheckedCast[int32](c.pid)
+	if err != nil {
+		return fmt.Errorf("invalid value for pid: %w", err)
+	}
+
+	resp, err := agentClient.NewDebugClient().ExplainWorkload(ctx, &spiredebug.ExplainWorkloadRequest{
+		Pid: pid,
+	})
+	if err != nil {
+		return fmt.Errorf("error explaining workload: %w", err)
+	}
+
+	return c.printer.PrintProto(resp)
+}
+
+func prettyPrintWorkload(env *commoncli.Env, results ...any) error {
+	resp, ok := results[0].(*spiredebug.ExplainWorkloadResponse)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	if len(resp.Selectors) == 0 {
+		_ = env.Printf("No selectors attested\n\n")
+	} else {
+		_ = env.Printf("Attested selectors:\n")
+		printSelectors(env.Printf, "  Selector              ", resp.Selectors)
+		_ = env.Println()
+	}
+
+	if len(resp.MatchedEntries) == 0 {
+		_ = env.Printf("No matched entries\n\n")
+	} else {
+		_ = env.Printf("Matched entries:\n")
+		for _, match := range resp.MatchedEntries {
+			_ = env.Printf("  Entry ID              : %s\n", match.EntryId)
+			_ = env.Printf("  SPIFFE ID             : %s\n", match.SpiffeId)
+			_ = env.Println()
+		}
+	}
+
+	if len(resp.UnmatchedEntries) == 0 {
+		return env.Printf("No unmatched entries\n")
+	}
+	_ = env.Printf("Unmatched entries:\n")
+	for _, match := range resp.UnmatchedEntries {
+		_ = env.Printf("  Entry ID              : %s\n", match.EntryId)
+		_ = env.Printf("  SPIFFE ID             : %s\n", match.SpiffeId)
+		printSelectors(env.Printf, "  Unsatisfied selector  ", match.UnsatisfiedSelectors)
+		if err := env.Println(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
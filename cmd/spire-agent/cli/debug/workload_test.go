@@ -0,0 +1,129 @@
+package debug_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/cmd/spire-agent/cli/debug"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadHelp(t *testing.T) {
+	test := setupCliTest(t, &mockDebugService{}, debug.NewWorkloadCommandWithEnv)
+	test.client.Help()
+	require.Equal(t, "", test.stdout.String())
+	require.Equal(t, workloadUsage, test.stderr.String())
+}
+
+func TestWorkloadSynopsis(t *testing.T) {
+	cmd := debug.NewWorkloadCommand()
+	require.Equal(t, "Explains which cached entries match the selectors of a workload", cmd.Synopsis())
+}
+
+func TestWorkload(t *testing.T) {
+	resp := &spiredebug.ExplainWorkloadResponse{
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "uid:1000"},
+			{Type: "unix", Value: "gid:1000"},
+		},
+		MatchedEntries: []*spiredebug.EntryMatch{
+			{EntryId: "entry-1", SpiffeId: "spiffe://example.org/one"},
+		},
+		UnmatchedEntries: []*spiredebug.EntryMatch{
+			{
+				EntryId:  "entry-2",
+				SpiffeId: "spiffe://example.org/two",
+				UnsatisfiedSelectors: []*common.Selector{
+					{Type: "unix", Value: "uid:0"},
+					{Type: "!unix", Value: "gid:1000"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name             string
+		server           *mockDebugService
+		args             []string
+		expectPID        int32
+		expectReturnCode int
+		expectStdout     string
+		expectStderr     string
+	}{
+		{
+			name:             "missing pid",
+			args:             []string{"-output", "pretty"},
+			server:           &mockDebugService{},
+			expectReturnCode: 1,
+			expectStderr:     "Error: a positive value (-pid) must be set\n",
+		},
+		{
+			name:             "pid out of range",
+			args:             []string{"-pid", "4294967296"},
+			server:           &mockDebugService{},
+			expectReturnCode: 1,
+			expectStderr:     "Error: invalid value for pid: overflow converting int(4294967296) to int32\n",
+		},
+		{
+			name:      "pretty output",
+			args:      []string{"-pid", "1234", "-output", "pretty"},
+			server:    &mockDebugService{explainResp: resp},
+			expectPID: 1234,
+			expectStdout: `Attested selectors:
+  Selector              : unix:uid:1000
+  Selector              : unix:gid:1000
+
+Matched entries:
+  Entry ID              : entry-1
+  SPIFFE ID             : spiffe://example.org/one
+
+Unmatched entries:
+  Entry ID              : entry-2
+  SPIFFE ID             : spiffe://example.org/two
+  Unsatisfied selector  : unix:uid:0
+  Unsatisfied selector  : !unix:gid:1000
+
+`,
+		},
+		{
+			name:      "nothing attested or cached",
+			args:      []string{"-pid", "1234", "-output", "pretty"},
+			server:    &mockDebugService{explainResp: &spiredebug.ExplainWorkloadResponse{}},
+			expectPID: 1234,
+			expectStdout: `No selectors attested
+
+No matched entries
+
+No unmatched entries
+`,
+		},
+		{
+			name:      "json output",
+			args:      []string{"-pid", "1234", "-output", "json"},
+			server:    &mockDebugService{explainResp: resp},
+			expectPID: 1234,
+			expectStdout: `{"matched_entries":[{"entry_id":"entry-1","spiffe_id":"spiffe://example.org/one","unsatisfied_selectors":[]}],"selectors":[{"type":"unix","value":"uid:1000"},{"type":"unix","value":"gid:1000"}],"unmatched_entries":[{"entry_id":"entry-2","spiffe_id":"spiffe://example.org/two","unsatisfied_selectors":[{"type":"unix","value":"uid:0"},{"type":"!unix","value":"gid:1000"}]}]}
+`,
+		},
+		{
+			name:             "server will error",
+			args:             []string{"-pid", "1234"},
+			server:           &mockDebugService{returnErr: errors.New("server is unavailable")},
+			expectPID:        1234,
+			expectReturnCode: 1,
+			expectStderr: `Error: error explaining workload: rpc error: code = Unknown desc = server is unavailable
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupCliTest(t, tt.server, debug.NewWorkloadCommandWithEnv)
+			returnCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectReturnCode, returnCode)
+			require.Equal(t, tt.expectPID, tt.server.receivedPID)
+		})
+	}
+}
@@ -690,6 +690,8 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 		}
 	}
 
+	ac.DisableWITSVIDs = !fflag.IsSet(fflag.FlagWITSVID)
+
 	ac.ProfilingEnabled = c.Agent.ProfilingEnabled
 	ac.ProfilingPort = c.Agent.ProfilingPort
 	ac.ProfilingFreq = c.Agent.ProfilingFreq
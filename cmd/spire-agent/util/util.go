@@ -7,6 +7,7 @@ import (
 
 	loggerv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/agent/logger/v1"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -22,6 +23,7 @@ func NewGRPCClient(addr string) (*grpc.ClientConn, error) {
 type AgentClient interface {
 	Release()
 	NewLoggerClient() loggerv1.LoggerClient
+	NewDebugClient() spiredebug.APIClient
 }
 
 func NewAgentClient(addr string) (AgentClient, error) {
@@ -44,6 +46,10 @@ func (c *agentClient) NewLoggerClient() loggerv1.LoggerClient {
 	return loggerv1.NewLoggerClient(c.conn)
 }
 
+func (c *agentClient) NewDebugClient() spiredebug.APIClient {
+	return spiredebug.NewAPIClient(c.conn)
+}
+
 // Command is a common interface for commands in this package. The adapter
 // adapts this interface to the Command interface from github.com/mitchellh/cli.
 type Command interface {
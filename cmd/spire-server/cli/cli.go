@@ -10,6 +10,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
 	"github.com/spiffe/spire/cmd/spire-server/cli/bundle"
+	"github.com/spiffe/spire/cmd/spire-server/cli/datastore"
 	"github.com/spiffe/spire/cmd/spire-server/cli/entry"
 	"github.com/spiffe/spire/cmd/spire-server/cli/federation"
 	"github.com/spiffe/spire/cmd/spire-server/cli/healthcheck"
@@ -18,6 +19,7 @@ import (
 	localauthority_x509 "github.com/spiffe/spire/cmd/spire-server/cli/localauthority/x509"
 	"github.com/spiffe/spire/cmd/spire-server/cli/logger"
 	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	"github.com/spiffe/spire/cmd/spire-server/cli/snapshot"
 	"github.com/spiffe/spire/cmd/spire-server/cli/token"
 	"github.com/spiffe/spire/cmd/spire-server/cli/upstreamauthority"
 	"github.com/spiffe/spire/cmd/spire-server/cli/validate"
@@ -87,6 +89,12 @@ func (cc *CLI) Run(ctx context.Context, args []string) int {
 		"entry show": func() (cli.Command, error) {
 			return entry.NewShowCommand(), nil
 		},
+		"entry apply": func() (cli.Command, error) {
+			return entry.NewApplyCommand(), nil
+		},
+		"entry history": func() (cli.Command, error) {
+			return entry.NewHistoryCommand(), nil
+		},
 		"federation create": func() (cli.Command, error) {
 			return federation.NewCreateCommand(), nil
 		},
@@ -120,6 +128,15 @@ func (cc *CLI) Run(ctx context.Context, args []string) int {
 		"token generate": func() (cli.Command, error) {
 			return token.NewGenerateCommand(), nil
 		},
+		"datastore export": func() (cli.Command, error) {
+			return datastore.NewExportCommand(), nil
+		},
+		"datastore import": func() (cli.Command, error) {
+			return datastore.NewImportCommand(), nil
+		},
+		"datastore migrate": func() (cli.Command, error) {
+			return datastore.NewMigrateCommand(), nil
+		},
 		"healthcheck": func() (cli.Command, error) {
 			return healthcheck.NewHealthCheckCommand(), nil
 		},
@@ -129,6 +146,12 @@ func (cc *CLI) Run(ctx context.Context, args []string) int {
 		"jwt mint": func() (cli.Command, error) {
 			return jwt.NewMintCommand(), nil
 		},
+		"snapshot create": func() (cli.Command, error) {
+			return snapshot.NewCreateCommand(), nil
+		},
+		"snapshot restore": func() (cli.Command, error) {
+			return snapshot.NewRestoreCommand(), nil
+		},
 		"validate": func() (cli.Command, error) {
 			return validate.NewValidateCommand(), nil
 		},
@@ -0,0 +1,74 @@
+package datastore
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/datastore/archive"
+)
+
+// serverConfigFlags are the flags used by the commands that load the
+// DataStore from a SPIRE server configuration file.
+type serverConfigFlags struct {
+	configPath string
+	expandEnv  bool
+}
+
+func (f *serverConfigFlags) addFlags(flags *flag.FlagSet) {
+	flags.StringVar(&f.configPath, "config", "conf/server/server.conf", "Path to the SPIRE server configuration file")
+	flags.BoolVar(&f.expandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+}
+
+// loadDataStore loads the DataStore configured in the SPIRE server
+// configuration file. The server must not be running against the same
+// datastore while it is in use.
+func (f *serverConfigFlags) loadDataStore(ctx context.Context, name string, env *commoncli.Env) (*server.Config, datastore.DataStore, io.Closer, error) {
+	args := []string{"-config", f.configPath}
+	if f.expandEnv {
+		args = append(args, "-expandEnv")
+	}
+
+	config, err := run.LoadConfig(name, args, []log.Option{log.WithOutputWriter(io.Discard)}, env.Stderr, false)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to load server configuration: %w", err)
+	}
+
+	ds, closer, err := catalog.LoadDataStore(ctx, catalog.Config{
+		Log:           config.Log,
+		Metrics:       telemetry.Blackhole{},
+		TrustDomain:   config.TrustDomain,
+		PluginConfigs: config.PluginConfigs,
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to load datastore: %w", err)
+	}
+	return config, ds, closer, nil
+}
+
+// recordKinds lists the archive record kinds in the order they are printed.
+var recordKinds = []string{
+	archive.KindBundle,
+	archive.KindFederationRelationship,
+	archive.KindRegistrationEntry,
+	archive.KindAttestedNode,
+	archive.KindJoinToken,
+	archive.KindCAJournal,
+}
+
+func printStats(env *commoncli.Env, stats *archive.Stats) error {
+	for _, kind := range recordKinds {
+		if err := env.Printf("%-25s %d\n", kind+":", stats.Records[kind]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mitchellh/cli"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/datastore/archive"
+)
+
+func NewExportCommand() cli.Command {
+	return newExportCommand(commoncli.DefaultEnv)
+}
+
+func newExportCommand(env *commoncli.Env) *exportCommand {
+	c := &exportCommand{
+		env: env,
+	}
+
+	c.flags = flag.NewFlagSet("datastore export", flag.ContinueOnError)
+	c.flags.SetOutput(env.Stderr)
+	c.serverConfig.addFlags(c.flags)
+	c.flags.StringVar(&c.output, "output", "", "Path of the archive to write; it must not exist")
+	return c
+}
+
+type exportCommand struct {
+	env   *commoncli.Env
+	flags *flag.FlagSet
+
+	serverConfig serverConfigFlags
+	output       string
+}
+
+func (c *exportCommand) Help() string {
+	return c.flags.Parse([]string{"-h"}).Error()
+}
+
+func (c *exportCommand) Synopsis() string {
+	return "Exports the contents of the server datastore to an archive"
+}
+
+func (c *exportCommand) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if err := c.run(context.Background()); err != nil {
+		_ = c.env.ErrPrintln("Error: " + err.Error())
+		return 1
+	}
+	return 0
+}
+
+func (c *exportCommand) run(ctx context.Context) (err error) {
+	if c.output == "" {
+		return errors.New("an output path is required")
+	}
+
+	config, ds, closer, err := c.serverConfig.loadDataStore(ctx, "datastore export", c.env)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	// The archive holds join tokens and CA journals, so it is only made
+	// readable by the owner.
+	f, err := os.OpenFile(c.output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to create archive: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("unable to close archive: %w", closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(c.output)
+		}
+	}()
+
+	stats, err := archive.Export(ctx, ds, config.TrustDomain, f)
+	if err != nil {
+		return fmt.Errorf("unable to export datastore: %w", err)
+	}
+
+	if err := c.env.Printf("Exported datastore to %q:\n", c.output); err != nil {
+		return err
+	}
+	return printStats(c.env, stats)
+}
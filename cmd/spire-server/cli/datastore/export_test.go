@@ -0,0 +1,135 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/spiffe/spire/pkg/server/datastore/kvstore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportHelp(t *testing.T) {
+	cmd, _, stderr := setupExportCommand()
+
+	require.Equal(t, "flag: help requested", cmd.Help())
+	require.Contains(t, stderr.String(), "Usage of datastore export:")
+}
+
+func TestExportSynopsis(t *testing.T) {
+	cmd, _, _ := setupExportCommand()
+	require.Equal(t, "Exports the contents of the server datastore to an archive", cmd.Synopsis())
+}
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+	kvPath := filepath.Join(dir, "datastore.db")
+	configPath := writeServerConfig(t, dir, kvPath)
+	archivePath := filepath.Join(dir, "archive.gz")
+
+	log, _ := test.NewNullLogger()
+	ds := kvstore.New(log)
+	require.NoError(t, ds.Configure(context.Background(), fmt.Sprintf(`database_path = %q`, kvPath)))
+	_, err := ds.CreateRegistrationEntry(context.Background(), &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/workload",
+		ParentId:  "spiffe://example.org/agent",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ds.Close())
+
+	for _, tt := range []struct {
+		name         string
+		args         []string
+		expectCode   int
+		expectStdout string
+		expectStderr string
+	}{
+		{
+			name:         "missing output",
+			args:         []string{"-config", configPath},
+			expectCode:   1,
+			expectStderr: "Error: an output path is required\n",
+		},
+		{
+			name:         "missing config",
+			args:         []string{"-config", filepath.Join(dir, "missing.conf"), "-output", archivePath},
+			expectCode:   1,
+			expectStderr: "Error: unable to load server configuration: ",
+		},
+		{
+			name:       "success",
+			args:       []string{"-config", configPath, "-output", archivePath},
+			expectCode: 0,
+			expectStdout: fmt.Sprintf(`Exported datastore to %q:
+bundle:                   0
+federation_relationship:  0
+registration_entry:       1
+attested_node:            0
+join_token:               0
+ca_journal:               0
+`, archivePath),
+		},
+		{
+			name:         "output exists",
+			args:         []string{"-config", configPath, "-output", archivePath},
+			expectCode:   1,
+			expectStderr: "Error: unable to create archive: ",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, stdout, stderr := setupExportCommand()
+
+			code := cmd.Run(tt.args)
+			_ = fflag.Unload()
+			require.Equal(t, tt.expectCode, code)
+			require.Equal(t, tt.expectStdout, stdout.String())
+			if tt.expectStderr == "" {
+				require.Empty(t, stderr.String())
+			} else {
+				require.Contains(t, stderr.String(), tt.expectStderr)
+			}
+		})
+	}
+
+	info, err := os.Stat(archivePath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func writeServerConfig(t *testing.T, dir, kvPath string) string {
+	configPath := filepath.Join(dir, "server.conf")
+	require.NoError(t, os.WriteFile(configPath, fmt.Appendf(nil, `
+server {
+	trust_domain = "example.org"
+	data_dir = %q
+}
+
+plugins {
+	DataStore "kv" {
+		plugin_data {
+			database_path = %q
+		}
+	}
+}
+`, dir, kvPath), 0o600))
+	return configPath
+}
+
+func setupExportCommand() (*exportCommand, *bytes.Buffer, *bytes.Buffer) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newExportCommand(&commoncli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	return cmd, stdout, stderr
+}
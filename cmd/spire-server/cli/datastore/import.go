@@ -0,0 +1,85 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mitchellh/cli"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/datastore/archive"
+)
+
+func NewImportCommand() cli.Command {
+	return newImportCommand(commoncli.DefaultEnv)
+}
+
+func newImportCommand(env *commoncli.Env) *importCommand {
+	c := &importCommand{
+		env: env,
+	}
+
+	c.flags = flag.NewFlagSet("datastore import", flag.ContinueOnError)
+	c.flags.SetOutput(env.Stderr)
+	c.serverConfig.addFlags(c.flags)
+	c.flags.StringVar(&c.input, "input", "", "Path of the archive to import")
+	return c
+}
+
+type importCommand struct {
+	env   *commoncli.Env
+	flags *flag.FlagSet
+
+	serverConfig serverConfigFlags
+	input        string
+}
+
+func (c *importCommand) Help() string {
+	return c.flags.Parse([]string{"-h"}).Error()
+}
+
+func (c *importCommand) Synopsis() string {
+	return "Imports an archive into the server datastore"
+}
+
+func (c *importCommand) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if err := c.run(context.Background()); err != nil {
+		_ = c.env.ErrPrintln("Error: " + err.Error())
+		return 1
+	}
+	return 0
+}
+
+func (c *importCommand) run(ctx context.Context) error {
+	if c.input == "" {
+		return errors.New("an input path is required")
+	}
+
+	f, err := os.Open(c.input)
+	if err != nil {
+		return fmt.Errorf("unable to open archive: %w", err)
+	}
+	defer f.Close()
+
+	config, ds, closer, err := c.serverConfig.loadDataStore(ctx, "datastore import", c.env)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	stats, err := archive.Import(ctx, ds, config.TrustDomain, f)
+	if err != nil {
+		return fmt.Errorf("unable to import datastore: %w", err)
+	}
+
+	if err := c.env.Printf("Imported %q into the datastore (%d records unchanged):\n", c.input, stats.Unchanged); err != nil {
+		return err
+	}
+	return printStats(c.env, stats)
+}
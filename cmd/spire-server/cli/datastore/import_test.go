@@ -0,0 +1,144 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/datastore/archive"
+	"github.com/spiffe/spire/pkg/server/datastore/kvstore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportHelp(t *testing.T) {
+	cmd, _, stderr := setupImportCommand()
+
+	require.Equal(t, "flag: help requested", cmd.Help())
+	require.Contains(t, stderr.String(), "Usage of datastore import:")
+}
+
+func TestImportSynopsis(t *testing.T) {
+	cmd, _, _ := setupImportCommand()
+	require.Equal(t, "Imports an archive into the server datastore", cmd.Synopsis())
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	kvPath := filepath.Join(dir, "datastore.db")
+	configPath := writeServerConfig(t, dir, kvPath)
+	archivePath := filepath.Join(dir, "archive.gz")
+
+	log, _ := test.NewNullLogger()
+	src := kvstore.New(log)
+	require.NoError(t, src.Configure(context.Background(), fmt.Sprintf(`database_path = %q`, filepath.Join(dir, "source.db"))))
+	defer src.Close()
+	entry, err := src.CreateRegistrationEntry(context.Background(), &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/workload",
+		ParentId:  "spiffe://example.org/agent",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	_, err = archive.Export(context.Background(), src, spiffeid.RequireTrustDomainFromString("example.org"), f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		expectCode     int
+		expectStdout   string
+		expectStderr   string
+		expectImported bool
+	}{
+		{
+			name:         "missing input",
+			args:         []string{"-config", configPath},
+			expectCode:   1,
+			expectStderr: "Error: an input path is required\n",
+		},
+		{
+			name:         "input does not exist",
+			args:         []string{"-config", configPath, "-input", filepath.Join(dir, "missing.gz")},
+			expectCode:   1,
+			expectStderr: "Error: unable to open archive: ",
+		},
+		{
+			name:       "success",
+			args:       []string{"-config", configPath, "-input", archivePath},
+			expectCode: 0,
+			expectStdout: fmt.Sprintf(`Imported %q into the datastore (0 records unchanged):
+bundle:                   0
+federation_relationship:  0
+registration_entry:       1
+attested_node:            0
+join_token:               0
+ca_journal:               0
+`, archivePath),
+			expectImported: true,
+		},
+		{
+			name:       "import again",
+			args:       []string{"-config", configPath, "-input", archivePath},
+			expectCode: 0,
+			expectStdout: fmt.Sprintf(`Imported %q into the datastore (1 records unchanged):
+bundle:                   0
+federation_relationship:  0
+registration_entry:       1
+attested_node:            0
+join_token:               0
+ca_journal:               0
+`, archivePath),
+			expectImported: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, stdout, stderr := setupImportCommand()
+
+			code := cmd.Run(tt.args)
+			_ = fflag.Unload()
+			require.Equal(t, tt.expectCode, code)
+			require.Equal(t, tt.expectStdout, stdout.String())
+			if tt.expectStderr == "" {
+				require.Empty(t, stderr.String())
+			} else {
+				require.Contains(t, stderr.String(), tt.expectStderr)
+			}
+
+			if !tt.expectImported {
+				return
+			}
+
+			ds := kvstore.New(log)
+			require.NoError(t, ds.Configure(context.Background(), fmt.Sprintf(`database_path = %q`, kvPath)))
+			defer ds.Close()
+
+			resp, err := ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+			require.NoError(t, err)
+			require.Len(t, resp.Entries, 1)
+			require.Equal(t, entry.EntryId, resp.Entries[0].EntryId)
+		})
+	}
+}
+
+func setupImportCommand() (*importCommand, *bytes.Buffer, *bytes.Buffer) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newImportCommand(&commoncli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	return cmd, stdout, stderr
+}
@@ -0,0 +1,94 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/mitchellh/cli"
+	"github.com/sirupsen/logrus"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/datastore/kvstore"
+	"github.com/spiffe/spire/pkg/server/datastore/sqlstore"
+)
+
+func NewMigrateCommand() cli.Command {
+	return newMigrateCommand(commoncli.DefaultEnv)
+}
+
+func newMigrateCommand(env *commoncli.Env) *migrateCommand {
+	c := &migrateCommand{
+		env: env,
+	}
+
+	c.flags = flag.NewFlagSet("datastore migrate", flag.ContinueOnError)
+	c.flags.SetOutput(env.Stderr)
+	c.flags.StringVar(&c.sqlDatabaseType, "sqlDatabaseType", "sqlite3", "Database type of the source SQL datastore")
+	c.flags.StringVar(&c.sqlConnectionString, "sqlConnectionString", "", "Connection string of the source SQL datastore")
+	c.flags.StringVar(&c.kvDatabasePath, "kvDatabasePath", "", "Path to the destination KV datastore database; it must be empty or not exist")
+	return c
+}
+
+type migrateCommand struct {
+	env   *commoncli.Env
+	flags *flag.FlagSet
+
+	sqlDatabaseType     string
+	sqlConnectionString string
+	kvDatabasePath      string
+}
+
+func (c *migrateCommand) Help() string {
+	return c.flags.Parse([]string{"-h"}).Error()
+}
+
+func (c *migrateCommand) Synopsis() string {
+	return "Migrates the contents of a SQL datastore into a KV datastore"
+}
+
+func (c *migrateCommand) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if err := c.run(context.Background()); err != nil {
+		_ = c.env.ErrPrintln("Error: " + err.Error())
+		return 1
+	}
+	return 0
+}
+
+func (c *migrateCommand) run(ctx context.Context) error {
+	if c.sqlConnectionString == "" {
+		return errors.New("a SQL connection string is required")
+	}
+	if c.kvDatabasePath == "" {
+		return errors.New("a KV database path is required")
+	}
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	src := sqlstore.New(log)
+	if err := src.Configure(ctx, fmt.Sprintf(`
+		database_type = %q
+		connection_string = %q
+	`, c.sqlDatabaseType, c.sqlConnectionString)); err != nil {
+		return fmt.Errorf("unable to open SQL datastore: %w", err)
+	}
+	defer src.Close()
+
+	dst := kvstore.New(log)
+	if err := dst.Configure(ctx, fmt.Sprintf(`database_path = %q`, c.kvDatabasePath)); err != nil {
+		return fmt.Errorf("unable to open KV datastore: %w", err)
+	}
+	defer dst.Close()
+
+	if err := dst.MigrateFrom(ctx, src); err != nil {
+		return fmt.Errorf("unable to migrate datastore: %w", err)
+	}
+
+	return c.env.Printf("Migrated datastore to %q.\n", c.kvDatabasePath)
+}
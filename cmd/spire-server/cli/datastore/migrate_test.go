@@ -0,0 +1,122 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/datastore/kvstore"
+	"github.com/spiffe/spire/pkg/server/datastore/sqlstore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateHelp(t *testing.T) {
+	cmd, _, stderr := setupMigrateCommand()
+
+	require.Equal(t, "flag: help requested", cmd.Help())
+	require.Contains(t, stderr.String(), "Usage of datastore migrate:")
+}
+
+func TestMigrateSynopsis(t *testing.T) {
+	cmd, _, _ := setupMigrateCommand()
+	require.Equal(t, "Migrates the contents of a SQL datastore into a KV datastore", cmd.Synopsis())
+}
+
+func TestMigrate(t *testing.T) {
+	dir := t.TempDir()
+	sqlPath := filepath.Join(dir, "datastore.sqlite3")
+	kvPath := filepath.Join(dir, "datastore.db")
+
+	log, _ := test.NewNullLogger()
+	src := sqlstore.New(log)
+	require.NoError(t, src.Configure(context.Background(), fmt.Sprintf(`
+		database_type = "sqlite3"
+		connection_string = %q
+	`, sqlPath)))
+	entry, err := src.CreateRegistrationEntry(context.Background(), &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/workload",
+		ParentId:  "spiffe://example.org/agent",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, src.Close())
+
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		expectCode     int
+		expectStdout   string
+		expectStderr   string
+		expectMigrated bool
+	}{
+		{
+			name:         "missing connection string",
+			args:         []string{"-kvDatabasePath", kvPath},
+			expectCode:   1,
+			expectStderr: "Error: a SQL connection string is required\n",
+		},
+		{
+			name:         "missing KV database path",
+			args:         []string{"-sqlConnectionString", sqlPath},
+			expectCode:   1,
+			expectStderr: "Error: a KV database path is required\n",
+		},
+		{
+			name:         "unsupported database type",
+			args:         []string{"-sqlDatabaseType", "oracle", "-sqlConnectionString", sqlPath, "-kvDatabasePath", kvPath},
+			expectCode:   1,
+			expectStderr: "Error: unable to open SQL datastore: datastore-sql: unsupported database_type: oracle\n",
+		},
+		{
+			name:           "success",
+			args:           []string{"-sqlConnectionString", sqlPath, "-kvDatabasePath", kvPath},
+			expectStdout:   fmt.Sprintf("Migrated datastore to %q.\n", kvPath),
+			expectMigrated: true,
+		},
+		{
+			name:         "target not empty",
+			args:         []string{"-sqlConnectionString", sqlPath, "-kvDatabasePath", kvPath},
+			expectCode:   1,
+			expectStderr: "Error: unable to migrate datastore: rpc error: code = Unknown desc = datastore-kv: target datastore is not empty\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, stdout, stderr := setupMigrateCommand()
+
+			code := cmd.Run(tt.args)
+			require.Equal(t, tt.expectCode, code)
+			require.Equal(t, tt.expectStdout, stdout.String())
+			require.Equal(t, tt.expectStderr, stderr.String())
+
+			if !tt.expectMigrated {
+				return
+			}
+
+			ds := kvstore.New(log)
+			require.NoError(t, ds.Configure(context.Background(), fmt.Sprintf(`database_path = %q`, kvPath)))
+			defer ds.Close()
+
+			resp, err := ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+			require.NoError(t, err)
+			require.Len(t, resp.Entries, 1)
+			require.Equal(t, entry.EntryId, resp.Entries[0].EntryId)
+		})
+	}
+}
+
+func setupMigrateCommand() (*migrateCommand, *bytes.Buffer, *bytes.Buffer) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newMigrateCommand(&commoncli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	return cmd, stdout, stderr
+}
@@ -0,0 +1,424 @@
+package entry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/cli"
+	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	serverutil "github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	applyActionCreate = "create"
+	applyActionUpdate = "update"
+	applyActionDelete = "delete"
+)
+
+// ownerRE restricts owner labels to characters that are valid in entry IDs,
+// excluding the dot that separates the label from the rest of the ID.
+var ownerRE = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// NewApplyCommand creates a new "apply" subcommand for "entry" command.
+func NewApplyCommand() cli.Command {
+	return newApplyCommand(commoncli.DefaultEnv)
+}
+
+func newApplyCommand(env *commoncli.Env) cli.Command {
+	return serverutil.AdaptCommand(env, &applyCommand{env: env})
+}
+
+type applyCommand struct {
+	// Path to the file holding the desired entries
+	file string
+
+	// Label owning the entries created by this command
+	owner string
+
+	// Whether owned entries missing from the file are deleted
+	prune bool
+
+	// Whether to only print the changes without applying them
+	dryRun bool
+
+	env     *commoncli.Env
+	printer cliprinter.Printer
+}
+
+func (*applyCommand) Name() string {
+	return "entry apply"
+}
+
+func (*applyCommand) Synopsis() string {
+	return "Reconciles registration entries with the entries in a file"
+}
+
+func (c *applyCommand) AppendFlags(f *flag.FlagSet) {
+	f.StringVar(&c.file, "file", "", "Path to a YAML or JSON file containing the desired registration entries. If set to '-', read from stdin.")
+	f.StringVar(&c.file, "f", "", "Alias of -file")
+	f.StringVar(&c.owner, "owner", "", "Label identifying the entries managed from the file. Entries created by this command get IDs prefixed with the label")
+	f.BoolVar(&c.prune, "prune", false, "If set, entries owned by the label that are not in the file are deleted. Requires -owner")
+	f.BoolVar(&c.dryRun, "dryRun", false, "If set, print the changes that would be made without applying them")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Alias of -dryRun")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, f, c.env, prettyPrintApply)
+}
+
+type applyResult struct {
+	DryRun    bool           `json:"dry_run"`
+	Changes   []*entryChange `json:"changes"`
+	Unchanged int            `json:"unchanged"`
+}
+
+type entryChange struct {
+	Action     string                    `json:"action"`
+	Entry      *types.Entry              `json:"entry"`
+	Extensions *entryext.EntryExtensions `json:"extensions,omitempty"`
+	Applied    bool                      `json:"applied"`
+	Error      string                    `json:"error,omitempty"`
+}
+
+// Run diffs the desired entries against the server and then creates,
+// updates and deletes entries to reconcile them
+func (c *applyCommand) Run(ctx context.Context, env *commoncli.Env, serverClient serverutil.ServerClient) error {
+	if c.file == "" {
+		return errors.New("a file is required")
+	}
+	if c.owner != "" && !ownerRE.MatchString(c.owner) {
+		return errors.New("the owner may only contain letters, numbers, underscores and dashes")
+	}
+	if c.prune && c.owner == "" {
+		return errors.New("an owner is required to prune entries")
+	}
+
+	desired, err := parseEntryYAML(env.Stdin, c.file)
+	if err != nil {
+		return err
+	}
+
+	client := serverClient.NewEntryClient()
+	extClient := serverClient.NewEntryExtClient()
+	current, err := listAllEntries(ctx, client, extClient)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.plan(desired, current)
+	if err != nil {
+		return err
+	}
+
+	if !c.dryRun {
+		if err := applyChanges(ctx, client, extClient, result.Changes); err != nil {
+			return err
+		}
+	}
+
+	if err := c.printer.PrintStruct(result); err != nil {
+		return err
+	}
+
+	for _, change := range result.Changes {
+		if change.Error != "" {
+			return errors.New("failed to apply one or more changes")
+		}
+	}
+	return nil
+}
+
+// plan matches the desired entries to the current ones, first by ID and then
+// by SPIFFE ID, parent ID and selectors, and works out the changes needed.
+func (c *applyCommand) plan(desired, current []*entryext.ExtendedEntry) (*applyResult, error) {
+	currentByID := make(map[string]*entryext.ExtendedEntry, len(current))
+	currentByKey := make(map[string]*entryext.ExtendedEntry, len(current))
+	for _, extended := range current {
+		currentByID[extended.Entry.Id] = extended
+		currentByKey[entryMatchKey(extended.Entry)] = extended
+	}
+
+	result := &applyResult{
+		DryRun:  c.dryRun,
+		Changes: []*entryChange{},
+	}
+	matched := make(map[string]bool)
+	seenKeys := make(map[string]bool)
+	for _, extended := range desired {
+		entry := extended.Entry
+		key := entryMatchKey(entry)
+		if seenKeys[key] {
+			return nil, fmt.Errorf("entry with SPIFFE ID %q, parent ID %q and selectors %q is listed more than once",
+				protoToIDString(entry.SpiffeId), protoToIDString(entry.ParentId), selectorStrings(entry.Selectors))
+		}
+		seenKeys[key] = true
+
+		var existing *entryext.ExtendedEntry
+		if entry.Id != "" {
+			existing = currentByID[entry.Id]
+		} else {
+			existing = currentByKey[key]
+			switch {
+			case existing != nil:
+				entry.Id = existing.Entry.Id
+			case c.owner != "":
+				entry.Id = ownedEntryID(c.owner, key)
+			}
+		}
+
+		if entry.Id != "" {
+			if matched[entry.Id] {
+				return nil, fmt.Errorf("entry %q is listed more than once", entry.Id)
+			}
+			matched[entry.Id] = true
+		}
+
+		switch {
+		case existing == nil:
+			result.Changes = append(result.Changes, &entryChange{Action: applyActionCreate, Entry: entry, Extensions: extended.Extensions})
+		case !entriesEqual(extended, existing):
+			result.Changes = append(result.Changes, &entryChange{Action: applyActionUpdate, Entry: entry, Extensions: extensionsUpdate(extended, existing)})
+		default:
+			result.Unchanged++
+		}
+	}
+
+	if c.prune {
+		ownedPrefix := c.owner + "."
+		for _, extended := range current {
+			if strings.HasPrefix(extended.Entry.Id, ownedPrefix) && !matched[extended.Entry.Id] {
+				result.Changes = append(result.Changes, &entryChange{Action: applyActionDelete, Entry: extended.Entry})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func applyChanges(ctx context.Context, client entryv1.EntryClient, extClient entryext.EntryClient, changes []*entryChange) error {
+	byAction := make(map[string][]*entryChange)
+	for _, change := range changes {
+		byAction[change.Action] = append(byAction[change.Action], change)
+	}
+
+	if toCreate := byAction[applyActionCreate]; len(toCreate) > 0 {
+		entries := changeEntries(toCreate)
+		if hasExtensions(entries) {
+			resp, err := extClient.BatchCreateEntry(ctx, &entryext.BatchCreateEntryRequest{Entries: entries})
+			if err != nil {
+				return fmt.Errorf("error creating entries: %w", err)
+			}
+			for i, r := range resp.Results {
+				setChangeResult(toCreate[i], r.Status, r.Entry)
+			}
+		} else {
+			resp, err := client.BatchCreateEntry(ctx, &entryv1.BatchCreateEntryRequest{Entries: plainEntries(entries)})
+			if err != nil {
+				return fmt.Errorf("error creating entries: %w", err)
+			}
+			for i, r := range resp.Results {
+				setChangeResult(toCreate[i], r.Status, &entryext.ExtendedEntry{Entry: r.Entry})
+			}
+		}
+	}
+
+	if toUpdate := byAction[applyActionUpdate]; len(toUpdate) > 0 {
+		entries := changeEntries(toUpdate)
+		if hasExtensions(entries) {
+			resp, err := extClient.BatchUpdateEntry(ctx, &entryext.BatchUpdateEntryRequest{Entries: entries})
+			if err != nil {
+				return fmt.Errorf("error updating entries: %w", err)
+			}
+			for i, r := range resp.Results {
+				setChangeResult(toUpdate[i], r.Status, r.Entry)
+			}
+		} else {
+			resp, err := client.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{Entries: plainEntries(entries)})
+			if err != nil {
+				return fmt.Errorf("error updating entries: %w", err)
+			}
+			for i, r := range resp.Results {
+				setChangeResult(toUpdate[i], r.Status, &entryext.ExtendedEntry{Entry: r.Entry})
+			}
+		}
+	}
+
+	if toDelete := byAction[applyActionDelete]; len(toDelete) > 0 {
+		ids := make([]string, 0, len(toDelete))
+		for _, change := range toDelete {
+			ids = append(ids, change.Entry.Id)
+		}
+		resp, err := client.BatchDeleteEntry(ctx, &entryv1.BatchDeleteEntryRequest{Ids: ids})
+		if err != nil {
+			return fmt.Errorf("error deleting entries: %w", err)
+		}
+		for i, r := range resp.Results {
+			setChangeResult(toDelete[i], r.Status, nil)
+		}
+	}
+
+	return nil
+}
+
+func listAllEntries(ctx context.Context, client entryv1.EntryClient, extClient entryext.EntryClient) ([]*entryext.ExtendedEntry, error) {
+	var entries []*types.Entry
+	pageToken := ""
+	for {
+		resp, err := client.ListEntries(ctx, &entryv1.ListEntriesRequest{
+			PageSize:  listEntriesRequestPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetching entries: %w", err)
+		}
+		entries = append(entries, resp.Entries...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+
+	extensions, err := fetchExtensions(ctx, extClient, entries)
+	if err != nil {
+		return nil, err
+	}
+	extended := make([]*entryext.ExtendedEntry, 0, len(entries))
+	for _, entry := range entries {
+		extended = append(extended, &entryext.ExtendedEntry{
+			Entry:      entry,
+			Extensions: extensions[entry.Id],
+		})
+	}
+	return extended, nil
+}
+
+func changeEntries(changes []*entryChange) []*entryext.ExtendedEntry {
+	entries := make([]*entryext.ExtendedEntry, 0, len(changes))
+	for _, change := range changes {
+		entries = append(entries, &entryext.ExtendedEntry{
+			Entry:      change.Entry,
+			Extensions: change.Extensions,
+		})
+	}
+	return entries
+}
+
+// setChangeResult records the status of the change and, if the server
+// returned it, the resulting entry.
+func setChangeResult(change *entryChange, status *types.Status, entry *entryext.ExtendedEntry) {
+	if entry.GetEntry() != nil {
+		change.Entry = entry.Entry
+		change.Extensions = entry.Extensions
+	}
+	if status.Code == int32(codes.OK) {
+		change.Applied = true
+		return
+	}
+	change.Error = fmt.Sprintf("%s: %s", codes.Code(status.Code), status.Message)
+}
+
+// entryMatchKey identifies an entry by its SPIFFE ID, parent ID and
+// selectors, regardless of the selector order.
+func entryMatchKey(entry *types.Entry) string {
+	selectors := selectorStrings(entry.Selectors)
+	slices.Sort(selectors)
+	return strings.Join(append([]string{protoToIDString(entry.SpiffeId), protoToIDString(entry.ParentId)}, selectors...), "\n")
+}
+
+// ownedEntryID derives the ID of an entry created for the owner from the
+// entry match key, so that reapplying the same file is idempotent.
+func ownedEntryID(owner, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return owner + "." + hex.EncodeToString(sum[:16])
+}
+
+// entriesEqual compares the fields of the entries that can be set through the
+// Entry API, along with their not-before times and credential profiles,
+// ignoring the order of selectors and federated trust domains.
+func entriesEqual(a, b *entryext.ExtendedEntry) bool {
+	return a.Extensions.GetNotBefore() == b.Extensions.GetNotBefore() &&
+		proto.Equal(a.Extensions.GetCredentialProfile(), b.Extensions.GetCredentialProfile()) &&
+		proto.Equal(normalizeEntry(a.Entry), normalizeEntry(b.Entry))
+}
+
+// extensionsUpdate returns the extensions to update an existing entry with,
+// or nil if they are unchanged. The file holds the desired state, so an entry
+// without a not-before time or a credential profile clears the current one.
+func extensionsUpdate(desired, existing *entryext.ExtendedEntry) *entryext.EntryExtensions {
+	var ext *entryext.EntryExtensions
+	if notBefore := desired.Extensions.GetNotBefore(); notBefore != existing.Extensions.GetNotBefore() {
+		ext = &entryext.EntryExtensions{NotBefore: proto.Int64(notBefore)}
+	}
+	if profile := desired.Extensions.GetCredentialProfile(); !proto.Equal(profile, existing.Extensions.GetCredentialProfile()) {
+		if ext == nil {
+			ext = &entryext.EntryExtensions{}
+		}
+		ext.CredentialProfile = profile
+		if profile == nil {
+			ext.CredentialProfile = &common.CredentialProfile{}
+		}
+	}
+	return ext
+}
+
+func normalizeEntry(entry *types.Entry) *types.Entry {
+	e := proto.Clone(entry).(*types.Entry)
+	e.Id = ""
+	e.RevisionNumber = 0
+	e.CreatedAt = 0
+	slices.SortFunc(e.Selectors, func(a, b *types.Selector) int {
+		return strings.Compare(a.Type+":"+a.Value, b.Type+":"+b.Value)
+	})
+	slices.Sort(e.FederatesWith)
+	if proto.Equal(e.AdditionalAttributes, &types.Entry_AdditionalAttributes{}) {
+		e.AdditionalAttributes = nil
+	}
+	return e
+}
+
+func selectorStrings(selectors []*types.Selector) []string {
+	s := make([]string, 0, len(selectors))
+	for _, selector := range selectors {
+		s = append(s, selector.Type+":"+selector.Value)
+	}
+	return s
+}
+
+func prettyPrintApply(env *commoncli.Env, results ...any) error {
+	result, ok := results[0].(*applyResult)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	counts := make(map[string]int)
+	for _, change := range result.Changes {
+		counts[change.Action]++
+	}
+	env.Printf("Plan: %d to create, %d to update, %d to delete, %d unchanged\n\n",
+		counts[applyActionCreate], counts[applyActionUpdate], counts[applyActionDelete], result.Unchanged)
+
+	for _, change := range result.Changes {
+		switch {
+		case result.DryRun:
+			env.Printf("Would %s:\n", change.Action)
+			printEntry(change.Entry, change.Extensions, env.Printf)
+		case change.Applied:
+			env.Printf("Applied %s:\n", change.Action)
+			printEntry(change.Entry, change.Extensions, env.Printf)
+		default:
+			env.ErrPrintf("Failed to %s the following entry (%s):\n", change.Action, change.Error)
+			printEntry(change.Entry, change.Extensions, env.ErrPrintf)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,398 @@
+package entry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+const applyEntriesYAML = `entries:
+  - spiffe_id: spiffe://example.org/updated
+    parent_id: spiffe://example.org/agent
+    selectors:
+      - type: unix
+        value: uid:1000
+    x509_svid_ttl: 300
+  - spiffe_id: spiffe://example.org/created
+    parent_id: spiffe://example.org/agent
+    selectors:
+      - type: unix
+        value: uid:2000
+  - spiffe_id: spiffe://example.org/unchanged
+    parent_id: spiffe://example.org/agent
+    selectors:
+      - type: unix
+        value: uid:3000
+      - type: unix
+        value: gid:3000
+`
+
+func TestApplyHelp(t *testing.T) {
+	test := setupTest(t, newApplyCommand)
+	test.client.Help()
+
+	require.Equal(t, applyUsage, test.stderr.String())
+}
+
+func TestApplySynopsis(t *testing.T) {
+	test := setupTest(t, newApplyCommand)
+	require.Equal(t, "Reconciles registration entries with the entries in a file", test.client.Synopsis())
+}
+
+func TestApply(t *testing.T) {
+	entriesPath := filepath.Join(t.TempDir(), "entries.yaml")
+	require.NoError(t, os.WriteFile(entriesPath, []byte(applyEntriesYAML), 0o600))
+
+	agentID := &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"}
+	toUpdate := &types.Entry{
+		Id:          "manual",
+		SpiffeId:    &types.SPIFFEID{TrustDomain: "example.org", Path: "/updated"},
+		ParentId:    agentID,
+		Selectors:   []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		X509SvidTtl: 60,
+	}
+	unchanged := &types.Entry{
+		Id:        "team-a.unchanged",
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/unchanged"},
+		ParentId:  agentID,
+		Selectors: []*types.Selector{{Type: "unix", Value: "gid:3000"}, {Type: "unix", Value: "uid:3000"}},
+	}
+	toDelete := &types.Entry{
+		Id:        "team-a.deleted",
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/deleted"},
+		ParentId:  agentID,
+		Selectors: []*types.Selector{{Type: "unix", Value: "uid:4000"}},
+	}
+	unowned := &types.Entry{
+		Id:        "team-b.other",
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/other"},
+		ParentId:  agentID,
+		Selectors: []*types.Selector{{Type: "unix", Value: "uid:5000"}},
+	}
+	listResp := &entryv1.ListEntriesResponse{
+		Entries: []*types.Entry{toUpdate, unchanged, toDelete, unowned},
+	}
+
+	createdID := ownedEntryID("team-a", "spiffe://example.org/created\nspiffe://example.org/agent\nunix:uid:2000")
+	expCreated := &types.Entry{
+		Id:        createdID,
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/created"},
+		ParentId:  agentID,
+		Selectors: []*types.Selector{{Type: "unix", Value: "uid:2000"}},
+	}
+	expUpdated := &types.Entry{
+		Id:          "manual",
+		SpiffeId:    &types.SPIFFEID{TrustDomain: "example.org", Path: "/updated"},
+		ParentId:    agentID,
+		Selectors:   []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		X509SvidTtl: 300,
+	}
+	okStatus := &types.Status{Code: int32(codes.OK), Message: "OK"}
+
+	createdPretty := fmt.Sprintf(`Entry ID                : %s
+SPIFFE ID               : spiffe://example.org/created
+Parent ID               : spiffe://example.org/agent
+Revision                : 0
+X509-SVID TTL           : default
+JWT-SVID TTL            : default
+Selector                : unix:uid:2000
+
+`, createdID)
+	updatedPretty := `Entry ID                : manual
+SPIFFE ID               : spiffe://example.org/updated
+Parent ID               : spiffe://example.org/agent
+Revision                : 0
+X509-SVID TTL           : 300
+JWT-SVID TTL            : default
+Selector                : unix:uid:1000
+
+`
+	deletedPretty := `Entry ID                : team-a.deleted
+SPIFFE ID               : spiffe://example.org/deleted
+Parent ID               : spiffe://example.org/agent
+Revision                : 0
+X509-SVID TTL           : default
+JWT-SVID TTL            : default
+Selector                : unix:uid:4000
+
+`
+
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		expCreateReq *entryv1.BatchCreateEntryRequest
+		expUpdateReq *entryv1.BatchUpdateEntryRequest
+		expDeleteReq *entryv1.BatchDeleteEntryRequest
+		createResp   *entryv1.BatchCreateEntryResponse
+		updateResp   *entryv1.BatchUpdateEntryResponse
+		deleteResp   *entryv1.BatchDeleteEntryResponse
+
+		expOut string
+		expErr string
+	}{
+		{
+			name:   "Missing file",
+			expErr: "Error: a file is required\n",
+		},
+		{
+			name:   "Prune without owner",
+			args:   []string{"-file", entriesPath, "-prune"},
+			expErr: "Error: an owner is required to prune entries\n",
+		},
+		{
+			name:   "Invalid owner",
+			args:   []string{"-file", entriesPath, "-owner", "team.a"},
+			expErr: "Error: the owner may only contain letters, numbers, underscores and dashes\n",
+		},
+		{
+			name: "Dry run",
+			args: []string{"-file", entriesPath, "-owner", "team-a", "-prune", "-dryRun"},
+			expOut: "Plan: 1 to create, 1 to update, 1 to delete, 1 unchanged\n\n" +
+				"Would update:\n" + updatedPretty +
+				"Would create:\n" + createdPretty +
+				"Would delete:\n" + deletedPretty,
+		},
+		{
+			name: "Dry run without pruning",
+			args: []string{"-file", entriesPath, "-owner", "team-a", "-dryRun"},
+			expOut: "Plan: 1 to create, 1 to update, 0 to delete, 1 unchanged\n\n" +
+				"Would update:\n" + updatedPretty +
+				"Would create:\n" + createdPretty,
+		},
+		{
+			name:         "Apply",
+			args:         []string{"-file", entriesPath, "-owner", "team-a", "-prune"},
+			expCreateReq: &entryv1.BatchCreateEntryRequest{Entries: []*types.Entry{expCreated}},
+			expUpdateReq: &entryv1.BatchUpdateEntryRequest{Entries: []*types.Entry{expUpdated}},
+			expDeleteReq: &entryv1.BatchDeleteEntryRequest{Ids: []string{"team-a.deleted"}},
+			createResp: &entryv1.BatchCreateEntryResponse{
+				Results: []*entryv1.BatchCreateEntryResponse_Result{{Status: okStatus, Entry: expCreated}},
+			},
+			updateResp: &entryv1.BatchUpdateEntryResponse{
+				Results: []*entryv1.BatchUpdateEntryResponse_Result{{Status: okStatus, Entry: expUpdated}},
+			},
+			deleteResp: &entryv1.BatchDeleteEntryResponse{
+				Results: []*entryv1.BatchDeleteEntryResponse_Result{{Status: okStatus, Id: "team-a.deleted"}},
+			},
+			expOut: "Plan: 1 to create, 1 to update, 1 to delete, 1 unchanged\n\n" +
+				"Applied update:\n" + updatedPretty +
+				"Applied create:\n" + createdPretty +
+				"Applied delete:\n" + deletedPretty,
+		},
+		{
+			name:         "Apply partially failed",
+			args:         []string{"-file", entriesPath, "-owner", "team-a"},
+			expCreateReq: &entryv1.BatchCreateEntryRequest{Entries: []*types.Entry{expCreated}},
+			expUpdateReq: &entryv1.BatchUpdateEntryRequest{Entries: []*types.Entry{expUpdated}},
+			createResp: &entryv1.BatchCreateEntryResponse{
+				Results: []*entryv1.BatchCreateEntryResponse_Result{{
+					Status: &types.Status{Code: int32(codes.AlreadyExists), Message: "similar entry already exists"},
+				}},
+			},
+			updateResp: &entryv1.BatchUpdateEntryResponse{
+				Results: []*entryv1.BatchUpdateEntryResponse_Result{{Status: okStatus, Entry: expUpdated}},
+			},
+			expOut: "Plan: 1 to create, 1 to update, 0 to delete, 1 unchanged\n\n" +
+				"Applied update:\n" + updatedPretty,
+			expErr: "Failed to create the following entry (AlreadyExists: similar entry already exists):\n" + createdPretty +
+				"Error: failed to apply one or more changes\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newApplyCommand)
+			test.server.expListEntriesReq = &entryv1.ListEntriesRequest{PageSize: listEntriesRequestPageSize}
+			test.server.listEntriesResp = listResp
+			test.server.expBatchCreateEntryReq = tt.expCreateReq
+			test.server.expBatchUpdateEntryReq = tt.expUpdateReq
+			test.server.expBatchDeleteEntryReq = tt.expDeleteReq
+			test.server.batchCreateEntryResp = tt.createResp
+			test.server.batchUpdateEntryResp = tt.updateResp
+			test.server.batchDeleteEntryResp = tt.deleteResp
+
+			rc := test.client.Run(test.args(tt.args...))
+			if tt.expErr != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expErr, test.stderr.String())
+				require.Equal(t, tt.expOut, test.stdout.String())
+				return
+			}
+
+			require.Equal(t, 0, rc)
+			require.Empty(t, test.stderr.String())
+			require.Equal(t, tt.expOut, test.stdout.String())
+		})
+	}
+}
+
+func TestApplyNotBefore(t *testing.T) {
+	agentID := &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"}
+	newEntry := func(id string) *types.Entry {
+		return &types.Entry{
+			Id:        id,
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/" + id},
+			ParentId:  agentID,
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		}
+	}
+
+	entriesPath := filepath.Join(t.TempDir(), "entries.yaml")
+	require.NoError(t, os.WriteFile(entriesPath, []byte(`entries:
+  - entry_id: changed
+    spiffe_id: spiffe://example.org/changed
+    parent_id: spiffe://example.org/agent
+    selectors:
+      - type: unix
+        value: uid:1000
+    not_before: 2000
+  - entry_id: cleared
+    spiffe_id: spiffe://example.org/cleared
+    parent_id: spiffe://example.org/agent
+    selectors:
+      - type: unix
+        value: uid:1000
+  - entry_id: unchanged
+    spiffe_id: spiffe://example.org/unchanged
+    parent_id: spiffe://example.org/agent
+    selectors:
+      - type: unix
+        value: uid:1000
+    not_before: 1000
+`), 0o600))
+
+	expUpdated := []*entryext.ExtendedEntry{
+		{Entry: newEntry("changed"), Extensions: &entryext.EntryExtensions{NotBefore: proto.Int64(2000)}},
+		{Entry: newEntry("cleared"), Extensions: &entryext.EntryExtensions{NotBefore: proto.Int64(0)}},
+	}
+
+	test := setupTest(t, newApplyCommand)
+	test.server.expListEntriesReq = &entryv1.ListEntriesRequest{PageSize: listEntriesRequestPageSize}
+	test.server.listEntriesResp = &entryv1.ListEntriesResponse{
+		Entries: []*types.Entry{newEntry("changed"), newEntry("cleared"), newEntry("unchanged")},
+	}
+	test.extServer.extensions = map[string]*entryext.EntryExtensions{
+		"changed":   {NotBefore: proto.Int64(1000)},
+		"cleared":   {NotBefore: proto.Int64(1000)},
+		"unchanged": {NotBefore: proto.Int64(1000)},
+	}
+	test.extServer.expBatchUpdateEntryReq = &entryext.BatchUpdateEntryRequest{Entries: expUpdated}
+	test.extServer.batchUpdateEntryResp = &entryext.BatchUpdateEntryResponse{
+		Results: []*entryext.BatchUpdateEntryResponse_Result{
+			{Status: &types.Status{Code: int32(codes.OK)}, Entry: expUpdated[0]},
+			{Status: &types.Status{Code: int32(codes.OK)}, Entry: &entryext.ExtendedEntry{Entry: newEntry("cleared")}},
+		},
+	}
+
+	rc := test.client.Run(test.args("-file", entriesPath))
+	require.Equal(t, 0, rc, test.stderr.String())
+	require.Contains(t, test.stdout.String(), "Plan: 0 to create, 2 to update, 0 to delete, 1 unchanged\n")
+	require.Contains(t, test.stdout.String(), "Not before              : 1970-01-01 00:33:20 +0000 UTC\n")
+}
+
+func TestApplyCredentialProfile(t *testing.T) {
+	agentID := &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"}
+	newEntry := func(id string) *types.Entry {
+		return &types.Entry{
+			Id:        id,
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/" + id},
+			ParentId:  agentID,
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		}
+	}
+	withProfile := func(org ...string) *entryext.EntryExtensions {
+		return &entryext.EntryExtensions{CredentialProfile: &common.CredentialProfile{SubjectOrganization: org}}
+	}
+
+	entriesPath := filepath.Join(t.TempDir(), "entries.yaml")
+	require.NoError(t, os.WriteFile(entriesPath, []byte(`entries:
+  - entry_id: changed
+    spiffe_id: spiffe://example.org/changed
+    parent_id: spiffe://example.org/agent
+    selectors:
+      - type: unix
+        value: uid:1000
+    additional_attributes:
+      credential_profile:
+        subject_organization: ["Globex"]
+  - entry_id: cleared
+    spiffe_id: spiffe://example.org/cleared
+    parent_id: spiffe://example.org/agent
+    selectors:
+      - type: unix
+        value: uid:1000
+  - entry_id: unchanged
+    spiffe_id: spiffe://example.org/unchanged
+    parent_id: spiffe://example.org/agent
+    selectors:
+      - type: unix
+        value: uid:1000
+    additional_attributes:
+      credential_profile:
+        subject_organization: ["ACME"]
+`), 0o600))
+
+	expUpdated := []*entryext.ExtendedEntry{
+		{Entry: newEntry("changed"), Extensions: withProfile("Globex")},
+		{Entry: newEntry("cleared"), Extensions: withProfile()},
+	}
+	expUpdated[0].Entry.AdditionalAttributes = &types.Entry_AdditionalAttributes{}
+
+	test := setupTest(t, newApplyCommand)
+	test.server.expListEntriesReq = &entryv1.ListEntriesRequest{PageSize: listEntriesRequestPageSize}
+	test.server.listEntriesResp = &entryv1.ListEntriesResponse{
+		Entries: []*types.Entry{newEntry("changed"), newEntry("cleared"), newEntry("unchanged")},
+	}
+	test.extServer.extensions = map[string]*entryext.EntryExtensions{
+		"changed":   withProfile("ACME"),
+		"cleared":   withProfile("ACME"),
+		"unchanged": withProfile("ACME"),
+	}
+	test.extServer.expBatchUpdateEntryReq = &entryext.BatchUpdateEntryRequest{Entries: expUpdated}
+	test.extServer.batchUpdateEntryResp = &entryext.BatchUpdateEntryResponse{
+		Results: []*entryext.BatchUpdateEntryResponse_Result{
+			{Status: &types.Status{Code: int32(codes.OK)}, Entry: expUpdated[0]},
+			{Status: &types.Status{Code: int32(codes.OK)}, Entry: &entryext.ExtendedEntry{Entry: newEntry("cleared")}},
+		},
+	}
+
+	rc := test.client.Run(test.args("-file", entriesPath))
+	require.Equal(t, 0, rc, test.stderr.String())
+	require.Contains(t, test.stdout.String(), "Plan: 0 to create, 2 to update, 0 to delete, 1 unchanged\n")
+	require.Contains(t, test.stdout.String(), "Subject O               : Globex\n")
+}
+
+func TestApplyJSON(t *testing.T) {
+	entriesPath := filepath.Join(t.TempDir(), "entries.json")
+	require.NoError(t, os.WriteFile(entriesPath, []byte(`{"entries":[{"entry_id":"entry-1","spiffe_id":"spiffe://example.org/foo","parent_id":"spiffe://example.org/agent","selectors":[{"type":"unix","value":"uid:1000"}]}]}`), 0o600))
+
+	test := setupTest(t, newApplyCommand)
+	test.server.expListEntriesReq = &entryv1.ListEntriesRequest{PageSize: listEntriesRequestPageSize}
+	test.server.listEntriesResp = &entryv1.ListEntriesResponse{}
+
+	// The flag aliases are accepted as well
+	rc := test.client.Run(test.args("-f", entriesPath, "--dry-run", "-output", "json"))
+	require.Equal(t, 0, rc, test.stderr.String())
+	require.JSONEq(t, `{
+		"dry_run": true,
+		"changes": [{
+			"action": "create",
+			"entry": {
+				"id": "entry-1",
+				"spiffe_id": {"trust_domain": "example.org", "path": "/foo"},
+				"parent_id": {"trust_domain": "example.org", "path": "/agent"},
+				"selectors": [{"type": "unix", "value": "uid:1000"}]
+			},
+			"applied": false
+		}],
+		"unchanged": 0
+	}`, test.stdout.String())
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/cliprinter"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
 	"google.golang.org/grpc/codes"
 )
 
@@ -68,6 +69,12 @@ type createCommand struct {
 	// Expiry of entry
 	entryExpiry int64
 
+	// Time before which the entry is not active
+	notBefore int64
+
+	// Path to a JSON file with the credential profile of the entry
+	credentialProfilePath string
+
 	// DNSNames entries for SVIDs based on this entry
 	dnsNames StringsFlag
 
@@ -109,6 +116,8 @@ func (c *createCommand) AppendFlags(f *flag.FlagSet) {
 	f.BoolVar(&c.storeSVID, "storeSVID", false, "A boolean value that, when set, indicates that the resulting issued SVID from this entry must be stored through an SVIDStore plugin")
 	f.BoolVar(&c.downstream, "downstream", false, "A boolean value that, when set, indicates that the entry describes a downstream SPIRE server")
 	f.Int64Var(&c.entryExpiry, "entryExpiry", 0, "An expiry, from epoch in seconds, for the resulting registration entry to be pruned")
+	f.Int64Var(&c.notBefore, "notBefore", 0, "A time, from epoch in seconds, before which the resulting registration entry is not active")
+	f.StringVar(&c.credentialProfilePath, "credentialProfile", "", "Path to a JSON file with the credential profile of the resulting registration entry, customizing its X509-SVIDs")
 	f.Var(&c.dnsNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
 	f.StringVar(&c.hint, "hint", "", "The entry hint, used to disambiguate entries with the same SPIFFE ID")
 	f.BoolVar(&c.disableX509SVIDPrefetch, "disableX509SVIDPrefetch", false, "A boolean value that, when set, disables prefetching X509 SVID for this entry")
@@ -121,7 +130,7 @@ func (c *createCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient
 		return err
 	}
 
-	var entries []*types.Entry
+	var entries []*entryext.ExtendedEntry
 	var err error
 	if c.path != "" {
 		entries, err = parseFile(c.path)
@@ -132,7 +141,25 @@ func (c *createCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient
 		return err
 	}
 
-	resp, err := createEntries(ctx, serverClient.NewEntryClient(), entries)
+	if c.notBefore != 0 {
+		setNotBefore(entries, c.notBefore)
+	}
+
+	if c.credentialProfilePath != "" {
+		if err := setCredentialProfile(entries, c.credentialProfilePath); err != nil {
+			return err
+		}
+	}
+
+	if hasExtensions(entries) {
+		resp, err := createExtendedEntries(ctx, serverClient.NewEntryExtClient(), entries)
+		if err != nil {
+			return err
+		}
+		return c.printer.PrintProto(resp)
+	}
+
+	resp, err := createEntries(ctx, serverClient.NewEntryClient(), plainEntries(entries))
 	if err != nil {
 		return err
 	}
@@ -172,11 +199,15 @@ func (c *createCommand) validate() (err error) {
 		return errors.New("a positive JWT-SVID TTL is required")
 	}
 
+	if c.notBefore < 0 {
+		return errors.New("a positive not-before time is required")
+	}
+
 	return nil
 }
 
 // parseConfig builds a registration entry from the given config
-func (c *createCommand) parseConfig() ([]*types.Entry, error) {
+func (c *createCommand) parseConfig() ([]*entryext.ExtendedEntry, error) {
 	spiffeID, err := idStringToProto(c.spiffeID)
 	if err != nil {
 		return nil, err
@@ -230,7 +261,7 @@ func (c *createCommand) parseConfig() ([]*types.Entry, error) {
 
 	e.FederatesWith = c.federatesWith
 	e.Admin = c.admin
-	return []*types.Entry{e}, nil
+	return []*entryext.ExtendedEntry{{Entry: e}}, nil
 }
 
 func createEntries(ctx context.Context, c entryv1.EntryClient, entries []*types.Entry) (resp *entryv1.BatchCreateEntryResponse, err error) {
@@ -250,6 +281,23 @@ func createEntries(ctx context.Context, c entryv1.EntryClient, entries []*types.
 	return
 }
 
+func createExtendedEntries(ctx context.Context, c entryext.EntryClient, entries []*entryext.ExtendedEntry) (resp *entryext.BatchCreateEntryResponse, err error) {
+	resp, err = c.BatchCreateEntry(ctx, &entryext.BatchCreateEntryRequest{Entries: entries})
+	if err != nil {
+		return
+	}
+
+	for i, r := range resp.Results {
+		if r.Status.Code != int32(codes.OK) {
+			// The results do not include the entries that failed to be
+			// created, so we populate them from the request data.
+			r.Entry = entries[i]
+		}
+	}
+
+	return
+}
+
 func getParentID(config *createCommand, td string) (*types.SPIFFEID, error) {
 	// If the node flag is set, then set the Parent ID to the server's expected SPIFFE ID
 	if config.node {
@@ -262,35 +310,19 @@ func getParentID(config *createCommand, td string) (*types.SPIFFEID, error) {
 }
 
 func prettyPrintCreate(env *commoncli.Env, results ...any) error {
-	var succeeded, failed []*entryv1.BatchCreateEntryResponse_Result
-	createResp, ok := results[0].(*entryv1.BatchCreateEntryResponse)
-	if !ok {
-		return cliprinter.ErrInternalCustomPrettyFunc
-	}
-
-	for _, r := range createResp.Results {
-		switch r.Status.Code {
-		case int32(codes.OK):
-			succeeded = append(succeeded, r)
-		default:
-			failed = append(failed, r)
+	var createResults []entryResult
+	switch createResp := results[0].(type) {
+	case *entryv1.BatchCreateEntryResponse:
+		for _, r := range createResp.Results {
+			createResults = append(createResults, entryResult{status: r.Status, entry: r.Entry})
 		}
+	case *entryext.BatchCreateEntryResponse:
+		for _, r := range createResp.Results {
+			createResults = append(createResults, entryResult{status: r.Status, entry: r.Entry.GetEntry(), ext: r.Entry.GetExtensions()})
+		}
+	default:
+		return cliprinter.ErrInternalCustomPrettyFunc
 	}
 
-	for _, r := range succeeded {
-		printEntry(r.Entry, env.Printf)
-	}
-
-	for _, r := range failed {
-		env.ErrPrintf("Failed to create the following entry (code: %s, msg: %q):\n",
-			util.MustCast[codes.Code](r.Status.Code),
-			r.Status.Message)
-		printEntry(r.Entry, env.ErrPrintf)
-	}
-
-	if len(failed) > 0 {
-		return errors.New("failed to create one or more entries")
-	}
-
-	return nil
+	return printEntryResults(env, "create", createResults)
 }
@@ -3,13 +3,18 @@ package entry
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestCreateHelp(t *testing.T) {
@@ -808,3 +813,98 @@ Error: failed to create one or more entries
 		}
 	}
 }
+
+func TestCreateNotBefore(t *testing.T) {
+	entry := &entryext.ExtendedEntry{
+		Entry: &types.Entry{
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1"}},
+		},
+		Extensions: &entryext.EntryExtensions{NotBefore: proto.Int64(1700000000)},
+	}
+
+	test := setupTest(t, newCreateCommand)
+	test.extServer.expBatchCreateEntryReq = &entryext.BatchCreateEntryRequest{Entries: []*entryext.ExtendedEntry{entry}}
+	test.extServer.batchCreateEntryResp = &entryext.BatchCreateEntryResponse{
+		Results: []*entryext.BatchCreateEntryResponse_Result{
+			{
+				Entry: &entryext.ExtendedEntry{
+					Entry:      &types.Entry{Id: "entry-id"},
+					Extensions: &entryext.EntryExtensions{NotBefore: proto.Int64(1700000000)},
+				},
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+			},
+		},
+	}
+
+	rc := test.client.Run(test.args(
+		"-spiffeID", "spiffe://example.org/workload",
+		"-parentID", "spiffe://example.org/parent",
+		"-selector", "unix:uid:1",
+		"-notBefore", "1700000000",
+	))
+	require.Equal(t, 0, rc, test.stderr.String())
+	require.Contains(t, test.stdout.String(), "Not before              : 2023-11-14 22:13:20 +0000 UTC\n")
+
+	rc = test.client.Run(test.args(
+		"-spiffeID", "spiffe://example.org/workload",
+		"-parentID", "spiffe://example.org/parent",
+		"-selector", "unix:uid:1",
+		"-notBefore", "-1",
+	))
+	require.Equal(t, 1, rc)
+	require.Equal(t, "Error: a positive not-before time is required\n", test.stderr.String())
+}
+
+func TestCreateCredentialProfile(t *testing.T) {
+	profilePath := filepath.Join(t.TempDir(), "profile.json")
+	require.NoError(t, os.WriteFile(profilePath, []byte(`{
+		"subject_organization": ["acme"],
+		"extended_key_usages": ["serverAuth"]
+	}`), 0o600))
+
+	expEntry := &entryext.ExtendedEntry{
+		Entry: &types.Entry{
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1"}},
+		},
+		Extensions: &entryext.EntryExtensions{
+			CredentialProfile: &common.CredentialProfile{
+				SubjectOrganization: []string{"acme"},
+				ExtendedKeyUsages:   []string{"serverAuth"},
+			},
+		},
+	}
+
+	test := setupTest(t, newCreateCommand)
+	test.extServer.expBatchCreateEntryReq = &entryext.BatchCreateEntryRequest{Entries: []*entryext.ExtendedEntry{expEntry}}
+	test.extServer.batchCreateEntryResp = &entryext.BatchCreateEntryResponse{
+		Results: []*entryext.BatchCreateEntryResponse_Result{
+			{
+				Entry:  &entryext.ExtendedEntry{Entry: &types.Entry{Id: "entry-id"}, Extensions: expEntry.Extensions},
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+			},
+		},
+	}
+
+	rc := test.client.Run(test.args(
+		"-spiffeID", "spiffe://example.org/workload",
+		"-parentID", "spiffe://example.org/parent",
+		"-selector", "unix:uid:1",
+		"-credentialProfile", profilePath,
+	))
+	require.Equal(t, 0, rc, test.stderr.String())
+
+	badPath := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(badPath, []byte(`{"unknown": true}`), 0o600))
+	rc = test.client.Run(test.args(
+		"-spiffeID", "spiffe://example.org/workload",
+		"-parentID", "spiffe://example.org/parent",
+		"-selector", "unix:uid:1",
+		"-credentialProfile", badPath,
+	))
+	require.Equal(t, 1, rc)
+	require.Contains(t, test.stderr.String(), "Error: failed to parse credential profile:")
+}
@@ -0,0 +1,121 @@
+package entry
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/proto/spire/common"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/server/entryhistory"
+)
+
+const listEntryHistoryRequestPageSize = 500
+
+type historyCommand struct {
+	// ID of the entry to show the history of
+	entryID string
+
+	printer cliprinter.Printer
+	env     *commoncli.Env
+}
+
+// NewHistoryCommand creates a new "history" subcommand for "entry" command.
+func NewHistoryCommand() cli.Command {
+	return NewHistoryCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewHistoryCommandWithEnv creates a new "history" subcommand for "entry"
+// command using the environment specified.
+func NewHistoryCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &historyCommand{env: env})
+}
+
+func (*historyCommand) Name() string {
+	return "entry history"
+}
+
+func (*historyCommand) Synopsis() string {
+	return "Lists the change history of a registration entry"
+}
+
+func (c *historyCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.entryID, "id", "", "The ID of the registration entry to list the history of")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintHistory)
+}
+
+// Run lists the recorded changes of a registration entry, oldest first
+func (c *historyCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient util.ServerClient) error {
+	if c.entryID == "" {
+		return errors.New("an entry ID is required")
+	}
+
+	client := serverClient.NewEntryHistoryClient()
+	historyResp := &entryhistoryv1.ListEntryHistoryResponse{}
+	pageToken := ""
+	for {
+		resp, err := client.ListEntryHistory(ctx, &entryhistoryv1.ListEntryHistoryRequest{
+			EntryId:   c.entryID,
+			PageSize:  listEntryHistoryRequestPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return fmt.Errorf("error fetching entry history: %w", err)
+		}
+		historyResp.Changes = append(historyResp.Changes, resp.Changes...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+
+	return c.printer.PrintProto(historyResp)
+}
+
+func prettyPrintHistory(env *commoncli.Env, results ...any) error {
+	historyResp, ok := results[0].(*entryhistoryv1.ListEntryHistoryResponse)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	msg := fmt.Sprintf("Found %v ", len(historyResp.Changes))
+	msg = util.Pluralizer(msg, "change", "changes", len(historyResp.Changes))
+	env.Println(msg)
+
+	for _, change := range historyResp.Changes {
+		callerID := change.CallerId
+		if callerID == "" {
+			callerID = "local"
+		}
+		env.Printf("Operation               : %s\n", strings.ToLower(change.Operation.String()))
+		env.Printf("Changed at              : %s\n", time.Unix(change.ChangedAt, 0).UTC().Format(time.RFC3339))
+		env.Printf("Caller                  : %s\n", callerID)
+		env.Println()
+		if err := printHistoryEntry(env, "Before", change.Before); err != nil {
+			return err
+		}
+		if err := printHistoryEntry(env, "After", change.After); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printHistoryEntry(env *commoncli.Env, label string, e *common.RegistrationEntry) error {
+	if e == nil {
+		return nil
+	}
+	entry, err := api.RegistrationEntryToProto(e)
+	if err != nil {
+		return fmt.Errorf("invalid entry in history: %w", err)
+	}
+	env.Printf("%s:\n", label)
+	printEntry(entry, api.RegistrationEntryExtensions(e), env.Printf)
+	return nil
+}
@@ -0,0 +1,172 @@
+package entry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/server/entryhistory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryHelp(t *testing.T) {
+	test := setupTest(t, NewHistoryCommandWithEnv)
+	test.client.Help()
+
+	require.Equal(t, historyUsage, test.stderr.String())
+}
+
+func TestHistorySynopsis(t *testing.T) {
+	test := setupTest(t, NewHistoryCommandWithEnv)
+	require.Equal(t, "Lists the change history of a registration entry", test.client.Synopsis())
+}
+
+func TestHistory(t *testing.T) {
+	before := &common.RegistrationEntry{
+		EntryId:   "entry-id",
+		SpiffeId:  "spiffe://example.org/workload",
+		ParentId:  "spiffe://example.org/agent",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+	after := &common.RegistrationEntry{
+		EntryId:        "entry-id",
+		SpiffeId:       "spiffe://example.org/workload",
+		ParentId:       "spiffe://example.org/agent",
+		Selectors:      []*common.Selector{{Type: "unix", Value: "uid:1001"}},
+		RevisionNumber: 1,
+	}
+	created := &entryhistoryv1.EntryChange{
+		Operation: entryhistoryv1.EntryChange_CREATE,
+		After:     before,
+		ChangedAt: 1767323045,
+	}
+	updated := &entryhistoryv1.EntryChange{
+		Operation: entryhistoryv1.EntryChange_UPDATE,
+		Before:    before,
+		After:     after,
+		CallerId:  "spiffe://example.org/admin",
+		ChangedAt: 1767323046,
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		expReqs   []*entryhistoryv1.ListEntryHistoryRequest
+		fakeResps []*entryhistoryv1.ListEntryHistoryResponse
+		serverErr error
+
+		expOutPretty string
+		expOutJSON   string
+		expErrPretty string
+		expErrJSON   string
+	}{
+		{
+			name:         "Missing entry ID",
+			expErrPretty: "Error: an entry ID is required\n",
+			expErrJSON:   "Error: an entry ID is required\n",
+		},
+		{
+			name: "Server error",
+			args: []string{"-id", "entry-id"},
+			expReqs: []*entryhistoryv1.ListEntryHistoryRequest{
+				{EntryId: "entry-id", PageSize: listEntryHistoryRequestPageSize},
+			},
+			serverErr:    errors.New("server-error"),
+			expErrPretty: "Error: error fetching entry history: rpc error: code = Unknown desc = server-error\n",
+			expErrJSON:   "Error: error fetching entry history: rpc error: code = Unknown desc = server-error\n",
+		},
+		{
+			name: "No changes",
+			args: []string{"-id", "entry-id"},
+			expReqs: []*entryhistoryv1.ListEntryHistoryRequest{
+				{EntryId: "entry-id", PageSize: listEntryHistoryRequestPageSize},
+			},
+			fakeResps: []*entryhistoryv1.ListEntryHistoryResponse{
+				{},
+			},
+			expOutPretty: "Found 0 changes\n",
+			expOutJSON:   `{"changes":[],"next_page_token":""}`,
+		},
+		{
+			name: "Changes across pages",
+			args: []string{"-id", "entry-id"},
+			expReqs: []*entryhistoryv1.ListEntryHistoryRequest{
+				{EntryId: "entry-id", PageSize: listEntryHistoryRequestPageSize},
+				{EntryId: "entry-id", PageSize: listEntryHistoryRequestPageSize, PageToken: "1"},
+			},
+			fakeResps: []*entryhistoryv1.ListEntryHistoryResponse{
+				{Changes: []*entryhistoryv1.EntryChange{created}, NextPageToken: "1"},
+				{Changes: []*entryhistoryv1.EntryChange{updated}},
+			},
+			expOutPretty: `Found 2 changes
+Operation               : create
+Changed at              : 2026-01-02T03:04:05Z
+Caller                  : local
+
+After:
+Entry ID                : entry-id
+SPIFFE ID               : spiffe://example.org/workload
+Parent ID               : spiffe://example.org/agent
+Revision                : 0
+X509-SVID TTL           : default
+JWT-SVID TTL            : default
+Selector                : unix:uid:1000
+
+Operation               : update
+Changed at              : 2026-01-02T03:04:06Z
+Caller                  : spiffe://example.org/admin
+
+Before:
+Entry ID                : entry-id
+SPIFFE ID               : spiffe://example.org/workload
+Parent ID               : spiffe://example.org/agent
+Revision                : 0
+X509-SVID TTL           : default
+JWT-SVID TTL            : default
+Selector                : unix:uid:1000
+
+After:
+Entry ID                : entry-id
+SPIFFE ID               : spiffe://example.org/workload
+Parent ID               : spiffe://example.org/agent
+Revision                : 1
+X509-SVID TTL           : default
+JWT-SVID TTL            : default
+Selector                : unix:uid:1001
+
+`,
+			expOutJSON: `{"changes":[` +
+				`{"after":{"admin":false,"created_at":"0","dns_names":[],"downstream":false,"entryExpiry":"0","entry_id":"entry-id","federates_with":[],"hint":"","jwt_svid_ttl":0,"not_before":"0","parent_id":"spiffe://example.org/agent","revision_number":"0","selectors":[{"type":"unix","value":"uid:1000"}],"spiffe_id":"spiffe://example.org/workload","store_svid":false,"x509_svid_ttl":0},"caller_id":"","changed_at":"1767323045","operation":"CREATE"},` +
+				`{"after":{"admin":false,"created_at":"0","dns_names":[],"downstream":false,"entryExpiry":"0","entry_id":"entry-id","federates_with":[],"hint":"","jwt_svid_ttl":0,"not_before":"0","parent_id":"spiffe://example.org/agent","revision_number":"1","selectors":[{"type":"unix","value":"uid:1001"}],"spiffe_id":"spiffe://example.org/workload","store_svid":false,"x509_svid_ttl":0},"before":{"admin":false,"created_at":"0","dns_names":[],"downstream":false,"entryExpiry":"0","entry_id":"entry-id","federates_with":[],"hint":"","jwt_svid_ttl":0,"not_before":"0","parent_id":"spiffe://example.org/agent","revision_number":"0","selectors":[{"type":"unix","value":"uid:1000"}],"spiffe_id":"spiffe://example.org/workload","store_svid":false,"x509_svid_ttl":0},"caller_id":"spiffe://example.org/admin","changed_at":"1767323046","operation":"UPDATE"}` +
+				`],"next_page_token":""}`,
+		},
+	} {
+		for _, format := range availableFormats {
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, NewHistoryCommandWithEnv)
+				test.historyServer.err = tt.serverErr
+				test.historyServer.expListEntryHistoryReqs = tt.expReqs
+				test.historyServer.listEntryHistoryResps = tt.fakeResps
+				args := tt.args
+				args = append(args, "-output", format)
+
+				rc := test.client.Run(test.args(args...))
+
+				if tt.expErrJSON != "" && format == "json" {
+					require.Equal(t, 1, rc)
+					require.Equal(t, tt.expErrJSON, test.stderr.String())
+					return
+				}
+				if tt.expErrPretty != "" && format == "pretty" {
+					require.Equal(t, 1, rc)
+					require.Equal(t, tt.expErrPretty, test.stderr.String())
+					return
+				}
+				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expOutPretty, tt.expOutJSON)
+				require.Equal(t, 0, rc)
+			})
+		}
+	}
+}
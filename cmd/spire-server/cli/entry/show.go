@@ -5,6 +5,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"slices"
 
 	"github.com/mitchellh/cli"
 	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
@@ -13,6 +14,9 @@ import (
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/cliprinter"
 	commonutil "github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -56,6 +60,9 @@ type showCommand struct {
 	// Match used when filtering by selectors
 	matchSelectorsOn string
 
+	// Extensions of the entries shown, by entry ID
+	extensions map[string]*entryext.EntryExtensions
+
 	printer cliprinter.Printer
 
 	env *commoncli.Env
@@ -79,7 +86,7 @@ func (c *showCommand) AppendFlags(f *flag.FlagSet) {
 	f.StringVar(&c.matchFederatesWithOn, "matchFederatesWithOn", "superset", "The match mode used when filtering by federates with. Options: exact, any, superset and subset")
 	f.StringVar(&c.matchSelectorsOn, "matchSelectorsOn", "superset", "The match mode used when filtering by selectors. Options: exact, any, superset and subset")
 	f.StringVar(&c.hint, "hint", "", "The Hint of the records to show (optional)")
-	cliprinter.AppendFlagWithCustomPretty(&c.printer, f, c.env, prettyPrintShow)
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, f, c.env, c.prettyPrintShow)
 }
 
 // Run executes all logic associated with a single invocation of the
@@ -94,6 +101,11 @@ func (c *showCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient ut
 		return err
 	}
 
+	c.extensions, err = fetchExtensions(ctx, serverClient.NewEntryExtClient(), resp.Entries)
+	if err != nil {
+		return err
+	}
+
 	commonutil.SortTypesEntries(resp.Entries)
 	return c.printer.PrintProto(resp)
 }
@@ -207,13 +219,36 @@ func (c *showCommand) fetchByEntryID(ctx context.Context, id string, client entr
 	return entry, nil
 }
 
-func printEntries(entries []*types.Entry, env *commoncli.Env) {
+// fetchExtensions fetches the extensions of the entries. Servers without the
+// entry extension service have no extensions to return.
+func fetchExtensions(ctx context.Context, client entryext.EntryClient, entries []*types.Entry) (map[string]*entryext.EntryExtensions, error) {
+	extensions := make(map[string]*entryext.EntryExtensions, len(entries))
+	for batch := range slices.Chunk(entries, listEntriesRequestPageSize) {
+		ids := make([]string, 0, len(batch))
+		for _, entry := range batch {
+			ids = append(ids, entry.Id)
+		}
+		resp, err := client.BatchGetEntryExtensions(ctx, &entryext.BatchGetEntryExtensionsRequest{Ids: ids})
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			return nil, nil
+		case err != nil:
+			return nil, fmt.Errorf("error fetching entry extensions: %w", err)
+		}
+		for _, r := range resp.Results {
+			extensions[r.Id] = r.Extensions
+		}
+	}
+	return extensions, nil
+}
+
+func printEntries(entries []*types.Entry, extensions map[string]*entryext.EntryExtensions, env *commoncli.Env) {
 	msg := fmt.Sprintf("Found %v ", len(entries))
 	msg = util.Pluralizer(msg, "entry", "entries", len(entries))
 
 	env.Println(msg)
 	for _, e := range entries {
-		printEntry(e, env.Printf)
+		printEntry(e, extensions[e.Id], env.Printf)
 	}
 }
 
@@ -247,11 +282,11 @@ func parseToFederatesWithMatch(match string) (types.FederatesWithMatch_MatchBeha
 	}
 }
 
-func prettyPrintShow(env *commoncli.Env, results ...any) error {
+func (c *showCommand) prettyPrintShow(env *commoncli.Env, results ...any) error {
 	listResp, ok := results[0].(*entryv1.ListEntriesResponse)
 	if !ok {
 		return cliprinter.ErrInternalCustomPrettyFunc
 	}
-	printEntries(listResp.Entries, env)
+	printEntries(listResp.Entries, c.extensions, env)
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"strconv"
 
 	"github.com/mitchellh/cli"
 	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
@@ -13,6 +14,7 @@ import (
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/cliprinter"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 )
@@ -62,6 +64,15 @@ type updateCommand struct {
 	// Expiry of entry
 	entryExpiry int64
 
+	// Time before which the entry is not active. It is only sent when set on
+	// the command line, so the current value is kept otherwise.
+	notBefore    int64
+	notBeforeSet bool
+
+	// Path to a JSON file with the credential profile of the entry. It is
+	// only sent when set, so the current profile is kept otherwise.
+	credentialProfilePath string
+
 	// DNSNames entries for SVIDs based on this entry
 	dnsNames StringsFlag
 
@@ -112,6 +123,20 @@ func (c *updateCommand) AppendFlags(f *flag.FlagSet) {
 	f.BoolVar(&c.downstream, "downstream", false, "A boolean value that, when set, indicates that the entry describes a downstream SPIRE server")
 	f.BoolVar(&c.storeSVID, "storeSVID", false, "A boolean value that, when set, indicates that the resulting issued SVID from this entry must be stored through an SVIDStore plugin")
 	f.Int64Var(&c.entryExpiry, "entryExpiry", 0, "An expiry, from epoch in seconds, for the resulting registration entry to be pruned")
+	f.Func("notBefore", "A time, from epoch in seconds, before which the resulting registration entry is not active. Set to 0 to clear it",
+		func(value string) error {
+			notBefore, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return err
+			}
+			if notBefore < 0 {
+				return errors.New("a positive not-before time is required")
+			}
+			c.notBefore = notBefore
+			c.notBeforeSet = true
+			return nil
+		})
+	f.StringVar(&c.credentialProfilePath, "credentialProfile", "", "Path to a JSON file with the credential profile of the resulting registration entry, customizing its X509-SVIDs. An empty profile clears it")
 	f.Var(&c.dnsNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
 	f.StringVar(&c.hint, "hint", "", "The entry hint, used to disambiguate entries with the same SPIFFE ID")
 	f.BoolFunc("disableX509SVIDPrefetch", "A boolean value that, when set, disables prefetching X509 SVID for this entry",
@@ -136,7 +161,7 @@ func (c *updateCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient
 		return err
 	}
 
-	var entries []*types.Entry
+	var entries []*entryext.ExtendedEntry
 	var err error
 	if c.path != "" {
 		entries, err = parseFile(c.path)
@@ -159,10 +184,28 @@ func (c *updateCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient
 		if err != nil {
 			return fmt.Errorf("failed to fetch existing entry to merge additional attributes: %w", err)
 		}
-		mergeAdditionalAttributes(entries[0], existing, c.disableX509SVIDPrefetchSet, c.jwtSVIDIncludeJTISet)
+		mergeAdditionalAttributes(entries[0].Entry, existing, c.disableX509SVIDPrefetchSet, c.jwtSVIDIncludeJTISet)
+	}
+
+	if c.notBeforeSet {
+		setNotBefore(entries, c.notBefore)
+	}
+
+	if c.credentialProfilePath != "" {
+		if err := setCredentialProfile(entries, c.credentialProfilePath); err != nil {
+			return err
+		}
+	}
+
+	if hasExtensions(entries) {
+		resp, err := updateExtendedEntries(ctx, serverClient.NewEntryExtClient(), entries)
+		if err != nil {
+			return err
+		}
+		return c.printer.PrintProto(resp)
 	}
 
-	resp, err := updateEntries(ctx, client, entries)
+	resp, err := updateEntries(ctx, client, plainEntries(entries))
 	if err != nil {
 		return err
 	}
@@ -227,7 +270,7 @@ func (c *updateCommand) validate() (err error) {
 }
 
 // parseConfig builds a registration entry from the given config
-func (c *updateCommand) parseConfig() ([]*types.Entry, error) {
+func (c *updateCommand) parseConfig() ([]*entryext.ExtendedEntry, error) {
 	parentID, err := idStringToProto(c.parentID)
 	if err != nil {
 		return nil, err
@@ -281,7 +324,7 @@ func (c *updateCommand) parseConfig() ([]*types.Entry, error) {
 	e.FederatesWith = c.federatesWith
 	e.Admin = c.admin
 	e.StoreSvid = c.storeSVID
-	return []*types.Entry{e}, nil
+	return []*entryext.ExtendedEntry{{Entry: e}}, nil
 }
 
 func updateEntries(ctx context.Context, c entryv1.EntryClient, entries []*types.Entry) (resp *entryv1.BatchUpdateEntryResponse, err error) {
@@ -303,37 +346,39 @@ func updateEntries(ctx context.Context, c entryv1.EntryClient, entries []*types.
 	return
 }
 
-func prettyPrintUpdate(env *commoncli.Env, results ...any) error {
-	var succeeded, failed []*entryv1.BatchUpdateEntryResponse_Result
-	updateResp, ok := results[0].(*entryv1.BatchUpdateEntryResponse)
-	if !ok {
-		return cliprinter.ErrInternalCustomPrettyFunc
+func updateExtendedEntries(ctx context.Context, c entryext.EntryClient, entries []*entryext.ExtendedEntry) (resp *entryext.BatchUpdateEntryResponse, err error) {
+	resp, err = c.BatchUpdateEntry(ctx, &entryext.BatchUpdateEntryRequest{
+		Entries: entries,
+	})
+	if err != nil {
+		return
 	}
 
-	for _, r := range updateResp.Results {
-		switch r.Status.Code {
-		case int32(codes.OK):
-			succeeded = append(succeeded, r)
-		default:
-			failed = append(failed, r)
+	for i, r := range resp.Results {
+		if r.Status.Code != int32(codes.OK) {
+			// The results do not include the entries that failed to be
+			// updated, so we populate them from the request data.
+			r.Entry = entries[i]
 		}
 	}
-	// Print entries that succeeded to be updated
-	for _, e := range succeeded {
-		printEntry(e.Entry, env.Printf)
-	}
 
-	// Print entries that failed to be updated
-	for _, r := range failed {
-		env.ErrPrintf("Failed to update the following entry (code: %s, msg: %q):\n",
-			util.MustCast[codes.Code](r.Status.Code),
-			r.Status.Message)
-		printEntry(r.Entry, env.ErrPrintf)
-	}
+	return
+}
 
-	if len(failed) > 0 {
-		return errors.New("failed to update one or more entries")
+func prettyPrintUpdate(env *commoncli.Env, results ...any) error {
+	var updateResults []entryResult
+	switch updateResp := results[0].(type) {
+	case *entryv1.BatchUpdateEntryResponse:
+		for _, r := range updateResp.Results {
+			updateResults = append(updateResults, entryResult{status: r.Status, entry: r.Entry})
+		}
+	case *entryext.BatchUpdateEntryResponse:
+		for _, r := range updateResp.Results {
+			updateResults = append(updateResults, entryResult{status: r.Status, entry: r.Entry.GetEntry(), ext: r.Entry.GetExtensions()})
+		}
+	default:
+		return cliprinter.ErrInternalCustomPrettyFunc
 	}
 
-	return nil
+	return printEntryResults(env, "update", updateResults)
 }
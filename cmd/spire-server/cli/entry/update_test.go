@@ -3,11 +3,15 @@ package entry
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/encoding/protowire"
@@ -1053,3 +1057,117 @@ Error: failed to update one or more entries
 		}
 	}
 }
+
+func TestUpdateNotBefore(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		args          []string
+		expExtensions *entryext.EntryExtensions
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:          "set",
+			args:          []string{"-notBefore", "1700000000"},
+			expExtensions: &entryext.EntryExtensions{NotBefore: proto.Int64(1700000000)},
+		},
+		{
+			name:          "cleared",
+			args:          []string{"-notBefore", "0"},
+			expExtensions: &entryext.EntryExtensions{NotBefore: proto.Int64(0)},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newUpdateCommand)
+			expectUpdate(test, tt.expExtensions)
+
+			args := append([]string{
+				"-entryID", "entry-id",
+				"-spiffeID", "spiffe://example.org/workload",
+				"-parentID", "spiffe://example.org/parent",
+				"-selector", "unix:uid:1",
+			}, tt.args...)
+			rc := test.client.Run(test.args(args...))
+			require.Equal(t, 0, rc, test.stderr.String())
+		})
+	}
+}
+
+func TestUpdateCredentialProfile(t *testing.T) {
+	dir := t.TempDir()
+	profilePath := filepath.Join(dir, "profile.json")
+	require.NoError(t, os.WriteFile(profilePath, []byte(`{"subject_organizational_unit": ["payments"]}`), 0o600))
+	emptyPath := filepath.Join(dir, "empty.json")
+	require.NoError(t, os.WriteFile(emptyPath, []byte(`{}`), 0o600))
+
+	for _, tt := range []struct {
+		name          string
+		args          []string
+		expExtensions *entryext.EntryExtensions
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "set",
+			args: []string{"-credentialProfile", profilePath},
+			expExtensions: &entryext.EntryExtensions{
+				CredentialProfile: &common.CredentialProfile{
+					SubjectOrganizationalUnit: []string{"payments"},
+				},
+			},
+		},
+		{
+			name:          "cleared",
+			args:          []string{"-credentialProfile", emptyPath},
+			expExtensions: &entryext.EntryExtensions{CredentialProfile: &common.CredentialProfile{}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newUpdateCommand)
+			expectUpdate(test, tt.expExtensions)
+
+			args := append([]string{
+				"-entryID", "entry-id",
+				"-spiffeID", "spiffe://example.org/workload",
+				"-parentID", "spiffe://example.org/parent",
+				"-selector", "unix:uid:1",
+			}, tt.args...)
+			rc := test.client.Run(test.args(args...))
+			require.Equal(t, 0, rc, test.stderr.String())
+		})
+	}
+}
+
+// expectUpdate sets up the update of the test entry, through the entry
+// extension service if extensions are expected and through the Entry API
+// otherwise.
+func expectUpdate(test *entryTest, expExtensions *entryext.EntryExtensions) {
+	entry := &types.Entry{
+		Id:        "entry-id",
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+		ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+		Selectors: []*types.Selector{{Type: "unix", Value: "uid:1"}},
+	}
+	status := &types.Status{Code: int32(codes.OK), Message: "OK"}
+
+	if expExtensions == nil {
+		test.server.expBatchUpdateEntryReq = &entryv1.BatchUpdateEntryRequest{Entries: []*types.Entry{entry}}
+		test.server.batchUpdateEntryResp = &entryv1.BatchUpdateEntryResponse{
+			Results: []*entryv1.BatchUpdateEntryResponse_Result{
+				{Entry: &types.Entry{Id: "entry-id"}, Status: status},
+			},
+		}
+		return
+	}
+
+	test.extServer.expBatchUpdateEntryReq = &entryext.BatchUpdateEntryRequest{
+		Entries: []*entryext.ExtendedEntry{{Entry: entry, Extensions: expExtensions}},
+	}
+	test.extServer.batchUpdateEntryResp = &entryext.BatchUpdateEntryResponse{
+		Results: []*entryext.BatchUpdateEntryResponse_Result{
+			{Entry: &entryext.ExtendedEntry{Entry: &types.Entry{Id: "entry-id"}, Extensions: expExtensions}, Status: status},
+		},
+	}
+}
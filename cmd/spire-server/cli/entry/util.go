@@ -9,11 +9,108 @@ import (
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
 )
 
-func printEntry(e *types.Entry, printf func(string, ...any) error) {
+// setNotBefore sets the not-before time of the entries.
+func setNotBefore(entries []*entryext.ExtendedEntry, notBefore int64) {
+	for _, entry := range entries {
+		extensions(entry).NotBefore = proto.Int64(notBefore)
+	}
+}
+
+// setCredentialProfile sets the credential profile read from the given file,
+// as JSON, on the entries. An empty profile clears the credential profile of
+// the entries on update.
+func setCredentialProfile(entries []*entryext.ExtendedEntry, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read credential profile: %w", err)
+	}
+	profile := new(common.CredentialProfile)
+	if err := protojson.Unmarshal(data, profile); err != nil {
+		return fmt.Errorf("failed to parse credential profile: %w", err)
+	}
+	for _, entry := range entries {
+		extensions(entry).CredentialProfile = profile
+	}
+	return nil
+}
+
+// extensions returns the extensions of the entry, which are allocated if
+// needed.
+func extensions(entry *entryext.ExtendedEntry) *entryext.EntryExtensions {
+	if entry.Extensions == nil {
+		entry.Extensions = &entryext.EntryExtensions{}
+	}
+	return entry.Extensions
+}
+
+// hasExtensions returns true if any of the entries sets an extension. Only
+// then are the entries sent through the entry extension service, so that the
+// commands keep working with servers that do not provide it.
+func hasExtensions(entries []*entryext.ExtendedEntry) bool {
+	for _, entry := range entries {
+		if proto.Size(entry.Extensions) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// plainEntries returns the entries without their extensions.
+func plainEntries(entries []*entryext.ExtendedEntry) []*types.Entry {
+	plain := make([]*types.Entry, 0, len(entries))
+	for _, entry := range entries {
+		plain = append(plain, entry.Entry)
+	}
+	return plain
+}
+
+// entryResult is the result of creating or updating an entry, through either
+// the Entry API or the entry extension service.
+type entryResult struct {
+	status *types.Status
+	entry  *types.Entry
+	ext    *entryext.EntryExtensions
+}
+
+// printEntryResults prints the entries that succeeded and then those that
+// failed to be created or updated, as told by the action.
+func printEntryResults(env *commoncli.Env, action string, results []entryResult) error {
+	var failed []entryResult
+	for _, r := range results {
+		if r.status.Code != int32(codes.OK) {
+			failed = append(failed, r)
+			continue
+		}
+		printEntry(r.entry, r.ext, env.Printf)
+	}
+
+	for _, r := range failed {
+		env.ErrPrintf("Failed to %s the following entry (code: %s, msg: %q):\n",
+			action,
+			util.MustCast[codes.Code](r.status.Code),
+			r.status.Message)
+		printEntry(r.entry, r.ext, env.ErrPrintf)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to %s one or more entries", action)
+	}
+
+	return nil
+}
+
+func printEntry(e *types.Entry, ext *entryext.EntryExtensions, printf func(string, ...any) error) {
 	_ = printf("Entry ID                : %s\n", printableEntryID(e.Id))
 	_ = printf("SPIFFE ID               : %s\n", protoToIDString(e.SpiffeId))
 	_ = printf("Parent ID               : %s\n", protoToIDString(e.ParentId))
@@ -39,6 +136,10 @@ func printEntry(e *types.Entry, printf func(string, ...any) error) {
 		_ = printf("Expiration time         : %s\n", time.Unix(e.ExpiresAt, 0).UTC())
 	}
 
+	if notBefore := ext.GetNotBefore(); notBefore != 0 {
+		_ = printf("Not before              : %s\n", time.Unix(notBefore, 0).UTC())
+	}
+
 	for _, s := range e.Selectors {
 		_ = printf("Selector                : %s:%s\n", s.Type, s.Value)
 	}
@@ -72,6 +173,25 @@ func printEntry(e *types.Entry, printf func(string, ...any) error) {
 		}
 	}
 
+	if profile := ext.GetCredentialProfile(); profile != nil {
+		for _, o := range profile.SubjectOrganization {
+			_ = printf("Subject O               : %s\n", o)
+		}
+		for _, ou := range profile.SubjectOrganizationalUnit {
+			_ = printf("Subject OU              : %s\n", ou)
+		}
+		for _, eku := range profile.ExtendedKeyUsages {
+			_ = printf("Extended key usage      : %s\n", eku)
+		}
+		for _, ext := range profile.Extensions {
+			if ext.Critical {
+				_ = printf("Extension               : %s (critical)\n", ext.Oid)
+			} else {
+				_ = printf("Extension               : %s\n", ext.Oid)
+			}
+		}
+	}
+
 	_ = printf("\n")
 }
 
@@ -104,13 +224,34 @@ func protoToIDString(id *types.SPIFFEID) string {
 
 // parseFile parses JSON represented RegistrationEntries
 // if path is "-" read JSON from STDIN
-func parseFile(path string) ([]*types.Entry, error) {
+func parseFile(path string) ([]*entryext.ExtendedEntry, error) {
 	return parseEntryJSON(os.Stdin, path)
 }
 
-func parseEntryJSON(in io.Reader, path string) ([]*types.Entry, error) {
-	entries := &common.RegistrationEntries{}
+func parseEntryJSON(in io.Reader, path string) ([]*entryext.ExtendedEntry, error) {
+	dat, err := readEntryData(in, path)
+	if err != nil {
+		return nil, err
+	}
+	return entriesFromJSON(dat)
+}
+
+// parseEntryYAML parses YAML represented RegistrationEntries, using the same
+// structure as the JSON format. If path is "-" read YAML from in.
+func parseEntryYAML(in io.Reader, path string) ([]*entryext.ExtendedEntry, error) {
+	dat, err := readEntryData(in, path)
+	if err != nil {
+		return nil, err
+	}
+
+	dat, err = yaml.YAMLToJSON(dat)
+	if err != nil {
+		return nil, err
+	}
+	return entriesFromJSON(dat)
+}
 
+func readEntryData(in io.Reader, path string) ([]byte, error) {
 	r := in
 	if path != "-" {
 		f, err := os.Open(path)
@@ -121,15 +262,32 @@ func parseEntryJSON(in io.Reader, path string) ([]*types.Entry, error) {
 		r = f
 	}
 
-	dat, err := io.ReadAll(r)
-	if err != nil {
-		return nil, err
-	}
+	return io.ReadAll(r)
+}
 
+// entriesFromJSON parses the entries, along with the not-before times and
+// credential profiles that the Entry type does not define.
+func entriesFromJSON(dat []byte) ([]*entryext.ExtendedEntry, error) {
+	entries := &common.RegistrationEntries{}
 	if err := json.Unmarshal(dat, &entries); err != nil {
 		return nil, err
 	}
-	return api.RegistrationEntriesToProto(entries.Entries)
+	extended := make([]*entryext.ExtendedEntry, 0, len(entries.Entries))
+	for _, e := range entries.Entries {
+		entry, err := api.RegistrationEntryToProto(e)
+		if err != nil {
+			return nil, err
+		}
+		var ext *entryext.EntryExtensions
+		if e.NotBefore != 0 || e.GetAdditionalAttributes().GetCredentialProfile() != nil {
+			ext = api.RegistrationEntryExtensions(e)
+		}
+		extended = append(extended, &entryext.ExtendedEntry{
+			Entry:      entry,
+			Extensions: ext,
+		})
+	}
+	return extended, nil
 }
 
 // StringsFlag defines a custom type for string lists. Doing
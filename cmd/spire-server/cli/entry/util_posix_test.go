@@ -6,6 +6,8 @@ const (
 	createUsage = `Usage of entry create:
   -admin
     	If set, the SPIFFE ID in this entry will be granted access to the SPIRE Server's management APIs
+  -credentialProfile string
+    	Path to a JSON file with the credential profile of the resulting registration entry, customizing its X509-SVIDs
   -data string
     	Path to a file containing registration JSON (optional). If set to '-', read the JSON from stdin.
   -disableX509SVIDPrefetch
@@ -29,6 +31,8 @@ const (
     	The lifetime, in seconds, for JWT-SVIDs issued based on this registration entry.
   -node
     	If set, this entry will be applied to matching nodes rather than workloads
+  -notBefore int
+    	A time, from epoch in seconds, before which the resulting registration entry is not active
   -output value
     	Desired output format (pretty, json); default: pretty.
   -parentID string
@@ -73,6 +77,8 @@ const (
 	updateUsage = `Usage of entry update:
   -admin
     	If set, the SPIFFE ID in this entry will be granted access to the SPIRE Server's management APIs
+  -credentialProfile string
+    	Path to a JSON file with the credential profile of the resulting registration entry, customizing its X509-SVIDs. An empty profile clears it
   -data string
     	Path to a file containing registration JSON (optional). If set to '-', read the JSON from stdin.
   -disableX509SVIDPrefetch
@@ -94,6 +100,8 @@ const (
   -jwtSVIDIncludeJTI
 ` + "    \tA boolean value that, when set, includes a unique 'jti' claim in JWT-SVIDs issued for this entry and bypasses the agent JWT-SVID cache\n" + `  -jwtSVIDTTL int
     	The lifetime, in seconds, for JWT-SVIDs issued based on this registration entry.
+  -notBefore value
+    	A time, from epoch in seconds, before which the resulting registration entry is not active. Set to 0 to clear it
   -output value
     	Desired output format (pretty, json); default: pretty.
   -parentID string
@@ -144,5 +152,35 @@ const (
     	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
   -spiffeID string
     	The SPIFFE ID of the records to count
+`
+	historyUsage = `Usage of entry history:
+  -id string
+    	The ID of the registration entry to list the history of
+  -instance string
+    	Instance name to substitute into socket templates (env SPIRE_SERVER_PRIVATE_SOCKET_TEMPLATE).
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+  -socketPath string
+    	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+`
+	applyUsage = `Usage of entry apply:
+  -dry-run
+    	Alias of -dryRun
+  -dryRun
+    	If set, print the changes that would be made without applying them
+  -f string
+    	Alias of -file
+  -file string
+    	Path to a YAML or JSON file containing the desired registration entries. If set to '-', read from stdin.
+  -instance string
+    	Instance name to substitute into socket templates (env SPIRE_SERVER_PRIVATE_SOCKET_TEMPLATE).
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+  -owner string
+    	Label identifying the entries managed from the file. Entries created by this command get IDs prefixed with the label
+  -prune
+    	If set, entries owned by the label that are not in the file are deleted. Requires -owner
+  -socketPath string
+    	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
 `
 )
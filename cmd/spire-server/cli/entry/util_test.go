@@ -11,12 +11,15 @@ import (
 	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/server/entryhistory"
 	"github.com/spiffe/spire/test/clitest"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 var availableFormats = []string{"pretty", "json"}
@@ -129,7 +132,7 @@ func TestParseEntryJSON(t *testing.T) {
 				entry3,
 				entry4,
 			}
-			spiretest.RequireProtoListEqual(t, expectedEntries, entries)
+			spiretest.RequireProtoListEqual(t, expectedEntries, plainEntries(entries))
 		})
 	}
 }
@@ -157,8 +160,10 @@ type entryTest struct {
 	stdout *bytes.Buffer
 	stderr *bytes.Buffer
 
-	addr   string
-	server *fakeEntryServer
+	addr          string
+	server        *fakeEntryServer
+	extServer     *fakeEntryExtServer
+	historyServer *fakeEntryHistoryServer
 
 	client cli.Command
 }
@@ -253,17 +258,23 @@ func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *entry
 	})
 
 	server := &fakeEntryServer{t: t}
+	extServer := &fakeEntryExtServer{t: t}
+	historyServer := &fakeEntryHistoryServer{t: t}
 	addr := spiretest.StartGRPCServer(t, func(s *grpc.Server) {
 		entryv1.RegisterEntryServer(s, server)
+		entryext.RegisterEntryServer(s, extServer)
+		entryhistoryv1.RegisterEntryHistoryServer(s, historyServer)
 	})
 
 	test := &entryTest{
-		addr:   clitest.GetAddr(addr),
-		stdin:  stdin,
-		stdout: stdout,
-		stderr: stderr,
-		server: server,
-		client: client,
+		addr:          clitest.GetAddr(addr),
+		stdin:         stdin,
+		stdout:        stdout,
+		stderr:        stderr,
+		server:        server,
+		extServer:     extServer,
+		historyServer: historyServer,
+		client:        client,
 	}
 
 	t.Cleanup(func() {
@@ -285,3 +296,77 @@ func requireOutputBasedOnFormat(t *testing.T, format, stdoutString string, expec
 		}
 	}
 }
+
+type fakeEntryExtServer struct {
+	entryext.UnimplementedEntryServer
+
+	t   *testing.T
+	err error
+
+	expBatchCreateEntryReq *entryext.BatchCreateEntryRequest
+	expBatchUpdateEntryReq *entryext.BatchUpdateEntryRequest
+
+	batchCreateEntryResp *entryext.BatchCreateEntryResponse
+	batchUpdateEntryResp *entryext.BatchUpdateEntryResponse
+
+	// Extensions returned by BatchGetEntryExtensions, by entry ID. Entries
+	// without extensions get empty ones.
+	extensions map[string]*entryext.EntryExtensions
+}
+
+func (f *fakeEntryExtServer) BatchCreateEntry(_ context.Context, req *entryext.BatchCreateEntryRequest) (*entryext.BatchCreateEntryResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	spiretest.AssertProtoEqual(f.t, f.expBatchCreateEntryReq, req)
+	return f.batchCreateEntryResp, nil
+}
+
+func (f *fakeEntryExtServer) BatchUpdateEntry(_ context.Context, req *entryext.BatchUpdateEntryRequest) (*entryext.BatchUpdateEntryResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	spiretest.AssertProtoEqual(f.t, f.expBatchUpdateEntryReq, req)
+	return f.batchUpdateEntryResp, nil
+}
+
+func (f *fakeEntryExtServer) BatchGetEntryExtensions(_ context.Context, req *entryext.BatchGetEntryExtensionsRequest) (*entryext.BatchGetEntryExtensionsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	resp := &entryext.BatchGetEntryExtensionsResponse{}
+	for _, id := range req.Ids {
+		ext := f.extensions[id]
+		if ext == nil {
+			ext = &entryext.EntryExtensions{}
+		}
+		resp.Results = append(resp.Results, &entryext.BatchGetEntryExtensionsResponse_Result{
+			Status:     &types.Status{Code: int32(codes.OK), Message: "OK"},
+			Id:         id,
+			Extensions: ext,
+		})
+	}
+	return resp, nil
+}
+
+type fakeEntryHistoryServer struct {
+	entryhistoryv1.UnimplementedEntryHistoryServer
+
+	t   *testing.T
+	err error
+
+	expListEntryHistoryReqs []*entryhistoryv1.ListEntryHistoryRequest
+	listEntryHistoryResps   []*entryhistoryv1.ListEntryHistoryResponse
+}
+
+func (f *fakeEntryHistoryServer) ListEntryHistory(_ context.Context, req *entryhistoryv1.ListEntryHistoryRequest) (*entryhistoryv1.ListEntryHistoryResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	require.NotEmpty(f.t, f.listEntryHistoryResps, "unexpected ListEntryHistory call")
+	spiretest.AssertProtoEqual(f.t, f.expListEntryHistoryReqs[0], req)
+	resp := f.listEntryHistoryResps[0]
+	f.expListEntryHistoryReqs = f.expListEntryHistoryReqs[1:]
+	f.listEntryHistoryResps = f.listEntryHistoryResps[1:]
+	return resp, nil
+}
@@ -6,6 +6,8 @@ const (
 	createUsage = `Usage of entry create:
   -admin
     	If set, the SPIFFE ID in this entry will be granted access to the SPIRE Server's management APIs
+  -credentialProfile string
+    	Path to a JSON file with the credential profile of the resulting registration entry, customizing its X509-SVIDs
   -data string
     	Path to a file containing registration JSON (optional). If set to '-', read the JSON from stdin.
   -disableX509SVIDPrefetch
@@ -29,6 +31,8 @@ const (
     	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
   -node
     	If set, this entry will be applied to matching nodes rather than workloads
+  -notBefore int
+    	A time, from epoch in seconds, before which the resulting registration entry is not active
   -output value
     	Desired output format (pretty, json); default: pretty.
   -parentID string
@@ -69,6 +73,8 @@ const (
 	updateUsage = `Usage of entry update:
   -admin
     	If set, the SPIFFE ID in this entry will be granted access to the SPIRE Server's management APIs
+  -credentialProfile string
+    	Path to a JSON file with the credential profile of the resulting registration entry, customizing its X509-SVIDs. An empty profile clears it
   -data string
     	Path to a file containing registration JSON (optional). If set to '-', read the JSON from stdin.
   -disableX509SVIDPrefetch
@@ -90,6 +96,8 @@ const (
     	The lifetime, in seconds, for JWT-SVIDs issued based on this registration entry.
   -namedPipeName string
     	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
+  -notBefore value
+    	A time, from epoch in seconds, before which the resulting registration entry is not active. Set to 0 to clear it
   -output value
     	Desired output format (pretty, json); default: pretty.
   -parentID string
@@ -134,5 +142,31 @@ const (
     	A colon-delimited type:value selector. Can be used more than once
   -spiffeID string
     	The SPIFFE ID of the records to count
+`
+	historyUsage = `Usage of entry history:
+  -id string
+    	The ID of the registration entry to list the history of
+  -namedPipeName string
+    	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+`
+	applyUsage = `Usage of entry apply:
+  -dry-run
+    	Alias of -dryRun
+  -dryRun
+    	If set, print the changes that would be made without applying them
+  -f string
+    	Alias of -file
+  -file string
+    	Path to a YAML or JSON file containing the desired registration entries. If set to '-', read from stdin.
+  -namedPipeName string
+    	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
+  -output value
+    	Desired output format (pretty, json); default: pretty.
+  -owner string
+    	Label identifying the entries managed from the file. Entries created by this command get IDs prefixed with the label
+  -prune
+    	If set, entries owned by the label that are not in the file are deleted. Requires -owner
 `
 )
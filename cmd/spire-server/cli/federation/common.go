@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
 )
 
 // FederationRelationships type is used for parsing federation relationships from file
@@ -184,6 +186,24 @@ func printFederationRelationship(fr *types.FederationRelationship, printf func(f
 	}
 }
 
+func printFederationRelationshipStatus(status *federationstatusv1.FederationRelationshipStatus, printf func(format string, args ...any) error) {
+	_ = printf("Last refresh              : %s\n", formatStatusTime(status.LastRefresh))
+	_ = printf("Last refresh attempt      : %s\n", formatStatusTime(status.LastRefreshAttempt))
+	_ = printf("Next refresh              : %s\n", formatStatusTime(status.NextRefresh))
+	if status.LastError != "" {
+		_ = printf("Last refresh error        : %s\n", status.LastError)
+	}
+	_ = printf("Bundle sequence number    : %d\n", status.BundleSequenceNumber)
+	_ = printf("Endpoint cert expires at  : %s\n", formatStatusTime(status.EndpointCertificateExpiresAt))
+}
+
+func formatStatusTime(t int64) string {
+	if t == 0 {
+		return "unknown"
+	}
+	return time.Unix(t, 0).UTC().Format(time.RFC3339)
+}
+
 func appendConfigFlags(config *federationRelationshipConfig, f *flag.FlagSet) {
 	f.StringVar(&config.TrustDomain, "trustDomain", "", `Name of the trust domain to federate with (e.g., example.org)`)
 	f.StringVar(&config.BundleEndpointURL, "bundleEndpointURL", "", "URL of the SPIFFE bundle endpoint that provides the trust bundle (must use the HTTPS protocol)")
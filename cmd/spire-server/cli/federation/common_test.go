@@ -13,6 +13,7 @@ import (
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/pemutil"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
 	"github.com/spiffe/spire/test/clitest"
 	"github.com/spiffe/spire/test/fakes/fakeserverca"
 	"github.com/spiffe/spire/test/spiretest"
@@ -110,8 +111,9 @@ type cmdTest struct {
 	stdout *bytes.Buffer
 	stderr *bytes.Buffer
 
-	addr   string
-	server *fakeServer
+	addr         string
+	server       *fakeServer
+	statusServer *fakeStatusServer
 
 	client cli.Command
 }
@@ -205,6 +207,36 @@ func (f *fakeServer) BatchUpdateFederationRelationship(_ context.Context, req *t
 	return f.updateResp, nil
 }
 
+type fakeStatusServer struct {
+	federationstatusv1.UnimplementedFederationStatusServer
+
+	err      error
+	statuses []*federationstatusv1.FederationRelationshipStatus
+}
+
+func (f *fakeStatusServer) ListFederationRelationshipStatuses(context.Context, *federationstatusv1.ListFederationRelationshipStatusesRequest) (*federationstatusv1.ListFederationRelationshipStatusesResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &federationstatusv1.ListFederationRelationshipStatusesResponse{
+		Statuses: f.statuses,
+	}, nil
+}
+
+func (f *fakeStatusServer) GetFederationRelationshipStatus(_ context.Context, req *federationstatusv1.GetFederationRelationshipStatusRequest) (*federationstatusv1.FederationRelationshipStatus, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	for _, status := range f.statuses {
+		if status.TrustDomain == req.TrustDomain {
+			return status, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "federation relationship does not exist")
+}
+
 func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *cmdTest {
 	stdin := new(bytes.Buffer)
 	stdout := new(bytes.Buffer)
@@ -217,17 +249,20 @@ func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *cmdTe
 	})
 
 	server := &fakeServer{t: t}
+	statusServer := &fakeStatusServer{}
 	addr := spiretest.StartGRPCServer(t, func(s *grpc.Server) {
 		trustdomainv1.RegisterTrustDomainServer(s, server)
+		federationstatusv1.RegisterFederationStatusServer(s, statusServer)
 	})
 
 	test := &cmdTest{
-		addr:   clitest.GetAddr(addr),
-		stdin:  stdin,
-		stdout: stdout,
-		stderr: stderr,
-		server: server,
-		client: client,
+		addr:         clitest.GetAddr(addr),
+		stdin:        stdin,
+		stdout:       stdout,
+		stderr:       stderr,
+		server:       server,
+		statusServer: statusServer,
+		client:       client,
 	}
 
 	t.Cleanup(func() {
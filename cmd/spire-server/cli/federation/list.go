@@ -10,6 +10,7 @@ import (
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/cliprinter"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
 )
 
 func NewListCommand() cli.Command {
@@ -21,6 +22,8 @@ func newListCommand(env *commoncli.Env) cli.Command {
 }
 
 type listCommand struct {
+	// Whether to include the refresh status of the relationships
+	status  bool
 	env     *commoncli.Env
 	printer cliprinter.Printer
 }
@@ -34,6 +37,7 @@ func (c *listCommand) Synopsis() string {
 }
 
 func (c *listCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.status, "status", false, "Include the bundle refresh status of each federation relationship")
 	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintList)
 }
 
@@ -44,7 +48,15 @@ func (c *listCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient ut
 	if err != nil {
 		return fmt.Errorf("error listing federation relationship: %w", err)
 	}
-	return c.printer.PrintProto(resp)
+	if !c.status {
+		return c.printer.PrintProto(resp)
+	}
+
+	statusResp, err := serverClient.NewFederationStatusClient().ListFederationRelationshipStatuses(ctx, &federationstatusv1.ListFederationRelationshipStatusesRequest{})
+	if err != nil {
+		return fmt.Errorf("error listing federation relationship statuses: %w", err)
+	}
+	return c.printer.PrintProto(resp, statusResp)
 }
 
 func prettyPrintList(env *commoncli.Env, results ...any) error {
@@ -55,10 +67,24 @@ func prettyPrintList(env *commoncli.Env, results ...any) error {
 	msg := fmt.Sprintf("Found %v ", len(listResp.FederationRelationships))
 	msg = util.Pluralizer(msg, "federation relationship", "federation relationships", len(listResp.FederationRelationships))
 
+	statuses := make(map[string]*federationstatusv1.FederationRelationshipStatus)
+	if len(results) > 1 {
+		statusResp, ok := results[1].(*federationstatusv1.ListFederationRelationshipStatusesResponse)
+		if !ok {
+			return cliprinter.ErrInternalCustomPrettyFunc
+		}
+		for _, status := range statusResp.Statuses {
+			statuses[status.TrustDomain] = status
+		}
+	}
+
 	env.Println(msg)
 	for _, fr := range listResp.FederationRelationships {
 		env.Println()
 		printFederationRelationship(fr, env.Printf)
+		if status, ok := statuses[fr.TrustDomain]; ok {
+			printFederationRelationshipStatus(status, env.Printf)
+		}
 	}
 
 	return nil
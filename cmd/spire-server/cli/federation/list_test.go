@@ -6,6 +6,7 @@ import (
 
 	trustdomainv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/trustdomain/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -56,8 +57,10 @@ func TestList(t *testing.T) {
 
 		expectListReq *trustdomainv1.ListFederationRelationshipsRequest
 		listResp      *trustdomainv1.ListFederationRelationshipsResponse
+		statuses      []*federationstatusv1.FederationRelationshipStatus
 
 		serverErr error
+		statusErr error
 
 		expectOutPretty string
 		expectOutJSON   string
@@ -155,6 +158,104 @@ Endpoint SPIFFE ID        : spiffe://baz.test/id
   "next_page_token": ""
 }`,
 		},
+		{
+			name:          "multiple federations with status",
+			args:          []string{"-status"},
+			expectListReq: &trustdomainv1.ListFederationRelationshipsRequest{},
+			listResp: &trustdomainv1.ListFederationRelationshipsResponse{
+				FederationRelationships: []*types.FederationRelationship{
+					federation1,
+					federation3,
+				},
+			},
+			statuses: []*federationstatusv1.FederationRelationshipStatus{
+				{
+					TrustDomain:                  "foh.test",
+					LastRefresh:                  1700000000,
+					LastRefreshAttempt:           1700000000,
+					NextRefresh:                  1700000300,
+					BundleSequenceNumber:         3,
+					EndpointCertificateExpiresAt: 1700086400,
+				},
+				{
+					TrustDomain:        "baz.test",
+					LastRefreshAttempt: 1700000000,
+					NextRefresh:        1700000010,
+					LastError:          "oh no",
+				},
+			},
+			expectOutPretty: `Found 2 federation relationships
+
+Trust domain              : foh.test
+Bundle endpoint URL       : https://foo.test/endpoint
+Bundle endpoint profile   : https_web
+Last refresh              : 2023-11-14T22:13:20Z
+Last refresh attempt      : 2023-11-14T22:13:20Z
+Next refresh              : 2023-11-14T22:18:20Z
+Bundle sequence number    : 3
+Endpoint cert expires at  : 2023-11-15T22:13:20Z
+
+Trust domain              : baz.test
+Bundle endpoint URL       : https://baz.test/endpoint
+Bundle endpoint profile   : https_spiffe
+Endpoint SPIFFE ID        : spiffe://baz.test/id
+Last refresh              : unknown
+Last refresh attempt      : 2023-11-14T22:13:20Z
+Next refresh              : 2023-11-14T22:13:30Z
+Last refresh error        : oh no
+Bundle sequence number    : 0
+Endpoint cert expires at  : unknown
+`,
+			expectOutJSON: `[
+  {
+    "federation_relationships": [
+      {
+        "trust_domain": "foh.test",
+        "bundle_endpoint_url": "https://foo.test/endpoint",
+        "https_web": {}
+      },
+      {
+        "trust_domain": "baz.test",
+        "bundle_endpoint_url": "https://baz.test/endpoint",
+        "https_spiffe": {
+          "endpoint_spiffe_id": "spiffe://baz.test/id"
+        }
+      }
+    ],
+    "next_page_token": ""
+  },
+  {
+    "statuses": [
+      {
+        "trust_domain": "foh.test",
+        "last_refresh": "1700000000",
+        "last_refresh_attempt": "1700000000",
+        "next_refresh": "1700000300",
+        "last_error": "",
+        "bundle_sequence_number": "3",
+        "endpoint_certificate_expires_at": "1700086400"
+      },
+      {
+        "trust_domain": "baz.test",
+        "last_refresh": "0",
+        "last_refresh_attempt": "1700000000",
+        "next_refresh": "1700000010",
+        "last_error": "oh no",
+        "bundle_sequence_number": "0",
+        "endpoint_certificate_expires_at": "0"
+      }
+    ]
+  }
+]`,
+		},
+		{
+			name:          "status fails",
+			args:          []string{"-status"},
+			expectListReq: &trustdomainv1.ListFederationRelationshipsRequest{},
+			listResp:      &trustdomainv1.ListFederationRelationshipsResponse{},
+			statusErr:     status.Error(codes.Internal, "oh! no"),
+			expectErr:     "Error: error listing federation relationship statuses: rpc error: code = Internal desc = oh! no\n",
+		},
 		{
 			name:      "server fails",
 			serverErr: status.Error(codes.Internal, "oh! no"),
@@ -167,6 +268,8 @@ Endpoint SPIFFE ID        : spiffe://baz.test/id
 				test.server.err = tt.serverErr
 				test.server.expectListReq = tt.expectListReq
 				test.server.listResp = tt.listResp
+				test.statusServer.err = tt.statusErr
+				test.statusServer.statuses = tt.statuses
 				args := tt.args
 				args = append(args, "-output", format)
 
@@ -12,6 +12,7 @@ import (
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/cliprinter"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
 )
 
 func NewShowCommand() cli.Command {
@@ -25,8 +26,10 @@ func newShowCommand(env *commoncli.Env) cli.Command {
 type showCommand struct {
 	// Trust domain name of the federation relationship to show
 	trustDomain string
-	env         *commoncli.Env
-	printer     cliprinter.Printer
+	// Whether to include the refresh status of the relationship
+	status  bool
+	env     *commoncli.Env
+	printer cliprinter.Printer
 }
 
 func (c *showCommand) Name() string {
@@ -39,6 +42,7 @@ func (c *showCommand) Synopsis() string {
 
 func (c *showCommand) AppendFlags(f *flag.FlagSet) {
 	f.StringVar(&c.trustDomain, "trustDomain", "", "The trust domain name of the federation relationship to show")
+	f.BoolVar(&c.status, "status", false, "Include the bundle refresh status of the federation relationship")
 	cliprinter.AppendFlagWithCustomPretty(&c.printer, f, c.env, c.prettyPrintShow)
 }
 
@@ -55,8 +59,17 @@ func (c *showCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient ut
 	if err != nil {
 		return fmt.Errorf("error showing federation relationship: %w", err)
 	}
+	if !c.status {
+		return c.printer.PrintProto(fr)
+	}
 
-	return c.printer.PrintProto(fr)
+	status, err := serverClient.NewFederationStatusClient().GetFederationRelationshipStatus(ctx, &federationstatusv1.GetFederationRelationshipStatusRequest{
+		TrustDomain: c.trustDomain,
+	})
+	if err != nil {
+		return fmt.Errorf("error showing federation relationship status: %w", err)
+	}
+	return c.printer.PrintProto(fr, status)
 }
 
 func (c *showCommand) prettyPrintShow(env *commoncli.Env, results ...any) error {
@@ -66,6 +79,13 @@ func (c *showCommand) prettyPrintShow(env *commoncli.Env, results ...any) error
 	}
 	env.Printf("Found a federation relationship with trust domain %s:\n\n", c.trustDomain)
 	printFederationRelationship(fr, env.Printf)
+	if len(results) > 1 {
+		status, ok := results[1].(*federationstatusv1.FederationRelationshipStatus)
+		if !ok {
+			return cliprinter.ErrInternalCustomPrettyFunc
+		}
+		printFederationRelationshipStatus(status, env.Printf)
+	}
 
 	return nil
 }
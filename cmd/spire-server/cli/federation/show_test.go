@@ -6,6 +6,7 @@ import (
 
 	trustdomainv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/trustdomain/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -47,6 +48,7 @@ func TestShow(t *testing.T) {
 
 		req       *trustdomainv1.GetFederationRelationshipRequest
 		resp      *types.FederationRelationship
+		statuses  []*federationstatusv1.FederationRelationshipStatus
 		serverErr error
 
 		expectedStdoutPretty string
@@ -98,6 +100,56 @@ Endpoint SPIFFE ID        : spiffe://endpoint.test/id
   }
 }`,
 		},
+		{
+			name: "succeeds with status",
+			req:  &trustdomainv1.GetFederationRelationshipRequest{},
+			resp: fr1,
+			statuses: []*federationstatusv1.FederationRelationshipStatus{
+				{
+					TrustDomain:                  "example-1.test",
+					LastRefresh:                  1700000000,
+					LastRefreshAttempt:           1700000000,
+					NextRefresh:                  1700000300,
+					BundleSequenceNumber:         3,
+					EndpointCertificateExpiresAt: 1700086400,
+				},
+			},
+			args: []string{"-trustDomain", "example-1.test", "-status"},
+			expectedStdoutPretty: `Found a federation relationship with trust domain example-1.test:
+
+Trust domain              : example-1.test
+Bundle endpoint URL       : https://bundle-endpoint-1.test/endpoint
+Bundle endpoint profile   : https_web
+Last refresh              : 2023-11-14T22:13:20Z
+Last refresh attempt      : 2023-11-14T22:13:20Z
+Next refresh              : 2023-11-14T22:18:20Z
+Bundle sequence number    : 3
+Endpoint cert expires at  : 2023-11-15T22:13:20Z
+`,
+			expectedStdoutJSON: `[
+  {
+    "trust_domain": "example-1.test",
+    "bundle_endpoint_url": "https://bundle-endpoint-1.test/endpoint",
+    "https_web": {}
+  },
+  {
+    "trust_domain": "example-1.test",
+    "last_refresh": "1700000000",
+    "last_refresh_attempt": "1700000000",
+    "next_refresh": "1700000300",
+    "last_error": "",
+    "bundle_sequence_number": "3",
+    "endpoint_certificate_expires_at": "1700086400"
+  }
+]`,
+		},
+		{
+			name:           "status not found",
+			req:            &trustdomainv1.GetFederationRelationshipRequest{},
+			resp:           fr1,
+			args:           []string{"-trustDomain", "example-1.test", "-status"},
+			expectedStderr: "Error: error showing federation relationship status: rpc error: code = NotFound desc = federation relationship does not exist\n",
+		},
 		{
 			name:           "server fails",
 			args:           []string{"-trustDomain", "example-1.test"},
@@ -119,6 +171,7 @@ Endpoint SPIFFE ID        : spiffe://endpoint.test/id
 				test.server.err = tt.serverErr
 				test.server.expectShowReq = tt.req
 				test.server.showResp = tt.resp
+				test.statusServer.statuses = tt.statuses
 				args := tt.args
 				args = append(args, "-output", format)
 
@@ -42,6 +42,8 @@ const (
     	Desired output format (pretty, json); default: pretty.
   -socketPath string
     	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -status
+    	Include the bundle refresh status of each federation relationship
 `
 	refreshUsage = `Usage of federation refresh:
   -id string
@@ -60,6 +62,8 @@ const (
     	Desired output format (pretty, json); default: pretty.
   -socketPath string
     	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -status
+    	Include the bundle refresh status of the federation relationship
   -trustDomain string
     	The trust domain name of the federation relationship to show
 `
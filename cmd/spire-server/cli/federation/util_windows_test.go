@@ -36,6 +36,8 @@ const (
     	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
   -output value
     	Desired output format (pretty, json); default: pretty.
+  -status
+    	Include the bundle refresh status of each federation relationship
 `
 	refreshUsage = `Usage of federation refresh:
   -id string
@@ -50,6 +52,8 @@ const (
     	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
   -output value
     	Desired output format (pretty, json); default: pretty.
+  -status
+    	Include the bundle refresh status of the federation relationship
   -trustDomain string
     	The trust domain name of the federation relationship to show
 `
@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -28,6 +30,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
@@ -39,12 +42,14 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/tlspolicy"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/authorizedentries"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca/manager"
 	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
+	"github.com/spiffe/spire/pkg/server/tenant"
 )
 
 const (
@@ -52,6 +57,10 @@ const (
 
 	defaultConfigPath = "conf/server/server.conf"
 	defaultLogLevel   = "INFO"
+
+	// acmeCacheDir is the directory, under the data directory, where the
+	// bundle endpoint ACME certificates and keys are cached.
+	acmeCacheDir = "bundle-acme"
 )
 
 var defaultRateLimit = true
@@ -66,34 +75,40 @@ type Config struct {
 }
 
 type serverConfig struct {
-	AdminIDs                     []string           `hcl:"admin_ids"`
-	AgentTTL                     string             `hcl:"agent_ttl"`
-	AuditLogEnabled              bool               `hcl:"audit_log_enabled"`
-	BindAddress                  string             `hcl:"bind_address"`
-	BindPort                     int                `hcl:"bind_port"`
-	CAKeyType                    string             `hcl:"ca_key_type"`
-	CASubject                    *caSubjectConfig   `hcl:"ca_subject"`
-	CATTL                        string             `hcl:"ca_ttl"`
-	DataDir                      string             `hcl:"data_dir"`
-	DefaultX509SVIDTTL           string             `hcl:"default_x509_svid_ttl"`
-	DefaultJWTSVIDTTL            string             `hcl:"default_jwt_svid_ttl"`
-	Experimental                 experimentalConfig `hcl:"experimental"`
-	Federation                   *federationConfig  `hcl:"federation"`
-	DisableJWTSVIDs              bool               `hcl:"disable_jwt_svids"`
-	JWTIssuer                    string             `hcl:"jwt_issuer"`
-	JWTKeyType                   string             `hcl:"jwt_key_type"`
-	LogFile                      string             `hcl:"log_file"`
-	LogLevel                     string             `hcl:"log_level"`
-	LogFormat                    string             `hcl:"log_format"`
-	LogSourceLocation            bool               `hcl:"log_source_location"`
-	PruneAttestedNodesExpiredFor string             `hcl:"prune_attested_nodes_expired_for"`
-	PruneAttestedNodesBatchSize  int                `hcl:"prune_attested_nodes_batch_size"`
-	PruneNonReattestableNodes    bool               `hcl:"prune_tofu_nodes"`
-	ProxyProtocolTrustedCIDRs    []string           `hcl:"proxy_protocol_trusted_cidrs"`
-	RateLimit                    rateLimitConfig    `hcl:"ratelimit"`
-	SocketPath                   string             `hcl:"socket_path"`
-	TrustDomain                  string             `hcl:"trust_domain"`
-	MaxAttestedNodeInfoStaleness *string            `hcl:"max_attested_node_info_staleness"`
+	AdminIDs                     []string                       `hcl:"admin_ids"`
+	AgentTTL                     string                         `hcl:"agent_ttl"`
+	AuditLogEnabled              bool                           `hcl:"audit_log_enabled"`
+	BindAddress                  string                         `hcl:"bind_address"`
+	BindPort                     int                            `hcl:"bind_port"`
+	CAKeyType                    string                         `hcl:"ca_key_type"`
+	CASubject                    *caSubjectConfig               `hcl:"ca_subject"`
+	CATTL                        string                         `hcl:"ca_ttl"`
+	ClaimTemplates               map[string]claimTemplateConfig `hcl:"claim_template"`
+	CredentialProfile            *credentialProfileConfig       `hcl:"credential_profile"`
+	DataDir                      string                         `hcl:"data_dir"`
+	DefaultX509SVIDTTL           string                         `hcl:"default_x509_svid_ttl"`
+	DefaultJWTSVIDTTL            string                         `hcl:"default_jwt_svid_ttl"`
+	EntryHistoryRetention        string                         `hcl:"entry_history_retention"`
+	Experimental                 experimentalConfig             `hcl:"experimental"`
+	Federation                   *federationConfig              `hcl:"federation"`
+	DisableJWTSVIDs              bool                           `hcl:"disable_jwt_svids"`
+	JWTIssuer                    string                         `hcl:"jwt_issuer"`
+	JWTKeyType                   string                         `hcl:"jwt_key_type"`
+	LogFile                      string                         `hcl:"log_file"`
+	LogLevel                     string                         `hcl:"log_level"`
+	LogFormat                    string                         `hcl:"log_format"`
+	LogSourceLocation            bool                           `hcl:"log_source_location"`
+	PruneAttestedNodesExpiredFor string                         `hcl:"prune_attested_nodes_expired_for"`
+	PruneAttestedNodesBatchSize  int                            `hcl:"prune_attested_nodes_batch_size"`
+	PruneNonReattestableNodes    bool                           `hcl:"prune_tofu_nodes"`
+	ProxyProtocolTrustedCIDRs    []string                       `hcl:"proxy_protocol_trusted_cidrs"`
+	RateLimit                    rateLimitConfig                `hcl:"ratelimit"`
+	SocketPath                   string                         `hcl:"socket_path"`
+	TrustDomain                  string                         `hcl:"trust_domain"`
+	MaxAttestedNodeInfoStaleness *string                        `hcl:"max_attested_node_info_staleness"`
+
+	// Tenants are keyed by tenant name.
+	Tenants map[string]tenantConfig `hcl:"tenant"`
 
 	ConfigPath string
 	ExpandEnv  bool
@@ -124,6 +139,22 @@ type experimentalConfig struct {
 
 	NamedPipeName string `hcl:"named_pipe_name"`
 
+	// EntryTemplates are keyed by template name. They are rendered from the
+	// agent selectors only, hence the agent_ prefix, and placeholders on any
+	// other selector type are rejected.
+	EntryTemplates map[string]entryTemplateConfig `hcl:"agent_entry_template"`
+
+	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions"`
+}
+
+type entryTemplateConfig struct {
+	SPIFFEID           string                 `hcl:"spiffe_id"`
+	NodeSelectors      []string               `hcl:"node_selectors"`
+	Selectors          []string               `hcl:"selectors"`
+	DNSNames           []string               `hcl:"dns_names"`
+	X509SVIDTTL        int32                  `hcl:"x509_svid_ttl"`
+	JWTSVIDTTL         int32                  `hcl:"jwt_svid_ttl"`
+	Hint               string                 `hcl:"hint"`
 	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions"`
 }
 
@@ -134,6 +165,20 @@ type caSubjectConfig struct {
 	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions"`
 }
 
+type claimTemplateConfig struct {
+	Value              string                 `hcl:"value"`
+	SVIDTypes          []string               `hcl:"svid_types"`
+	Required           bool                   `hcl:"required"`
+	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions"`
+}
+
+type credentialProfileConfig struct {
+	AllowedFields            []string               `hcl:"allowed_fields"`
+	AllowedExtendedKeyUsages []string               `hcl:"allowed_extended_key_usages"`
+	AllowedExtensionOIDs     []string               `hcl:"allowed_extension_oids"`
+	UnusedKeyPositions       map[string][]token.Pos `hcl:",unusedKeyPositions"`
+}
+
 type federationConfig struct {
 	BundleEndpoint     *bundleEndpointConfig          `hcl:"bundle_endpoint"`
 	FederatesWith      map[string]federatesWithConfig `hcl:"federates_with"`
@@ -145,9 +190,17 @@ type bundleEndpointConfig struct {
 	Port        int    `hcl:"port"`
 	RefreshHint string `hcl:"refresh_hint"`
 
-	ACME    *bundleEndpointACMEConfig `hcl:"acme"`
-	Profile ast.Node                  `hcl:"profile"`
+	ACME          *bundleEndpointACMEConfig `hcl:"acme"`
+	Profile       ast.Node                  `hcl:"profile"`
+	OIDCDiscovery *oidcDiscoveryConfig      `hcl:"oidc_discovery"`
+
+	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions"`
+}
 
+type oidcDiscoveryConfig struct {
+	Issuer             string                 `hcl:"issuer"`
+	Domains            []string               `hcl:"domains"`
+	SVIDTypes          []string               `hcl:"svid_types"`
 	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions"`
 }
 
@@ -179,6 +232,13 @@ type bundleEndpointACMEConfig struct {
 	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions"`
 }
 
+type tenantConfig struct {
+	PathPrefix         string                 `hcl:"path_prefix"`
+	AdminIDs           []string               `hcl:"admin_ids"`
+	DNSNameSuffixes    []string               `hcl:"dns_name_suffixes"`
+	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions"`
+}
+
 type federatesWithConfig struct {
 	BundleEndpointURL     string                 `hcl:"bundle_endpoint_url"`
 	BundleEndpointProfile ast.Node               `hcl:"bundle_endpoint_profile"`
@@ -490,6 +550,18 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 					return nil, err
 				}
 			}
+
+			if c.Server.Federation.BundleEndpoint.OIDCDiscovery != nil {
+				oidcDiscovery, err := configToOIDCDiscoveryConfig(c.Server.Federation.BundleEndpoint.OIDCDiscovery, c.Server.JWTIssuer, c.Server.Experimental.WITIssuer)
+				if err != nil {
+					return nil, err
+				}
+				if sc.Federation.BundleEndpoint.ACME == nil && sc.Federation.BundleEndpoint.DiskCertManager == nil {
+					sc.Log.Warn("OIDC discovery is served by a bundle endpoint using the https_spiffe profile; " +
+						"relying parties such as cloud providers require a Web PKI certificate, which the https_web profile provides")
+				}
+				sc.Federation.BundleEndpoint.OIDCDiscovery = oidcDiscovery
+			}
 		}
 
 		federatesWith := map[spiffeid.TrustDomain]bundleClient.TrustDomainConfig{}
@@ -543,6 +615,34 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		sc.AdminIDs = append(sc.AdminIDs, id)
 	}
 
+	if cp := c.Server.CredentialProfile; cp != nil {
+		policy := credtemplate.CredentialProfilePolicy{
+			AllowedFields:            cp.AllowedFields,
+			AllowedExtendedKeyUsages: cp.AllowedExtendedKeyUsages,
+			AllowedExtensionOIDs:     cp.AllowedExtensionOIDs,
+		}
+		if err := credtemplate.ValidateCredentialProfilePolicy(policy); err != nil {
+			return nil, fmt.Errorf("invalid credential_profile configuration: %w", err)
+		}
+		sc.CredentialProfilePolicy = policy
+	}
+
+	if len(c.Server.ClaimTemplates) > 0 {
+		claimTemplates, err := parseClaimTemplates(c.Server.ClaimTemplates)
+		if err != nil {
+			return nil, err
+		}
+		sc.ClaimTemplates = claimTemplates
+	}
+
+	if len(c.Server.Tenants) > 0 {
+		tenants, err := parseTenants(sc.TrustDomain, c.Server.Tenants)
+		if err != nil {
+			return nil, err
+		}
+		sc.Tenants = tenants
+	}
+
 	if c.Server.AgentTTL != "" {
 		ttl, err := time.ParseDuration(c.Server.AgentTTL)
 		if err != nil {
@@ -576,6 +676,17 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		sc.JWTSVIDTTL = credtemplate.DefaultJWTSVIDTTL
 	}
 
+	if c.Server.EntryHistoryRetention != "" {
+		retention, err := time.ParseDuration(c.Server.EntryHistoryRetention)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse entry_history_retention: %w", err)
+		}
+		if retention <= 0 {
+			return nil, errors.New("entry_history_retention must be positive")
+		}
+		sc.EntryHistoryRetention = retention
+	}
+
 	if c.Server.CATTL != "" {
 		ttl, err := time.ParseDuration(c.Server.CATTL)
 		if err != nil {
@@ -699,6 +810,10 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 	if err != nil {
 		return nil, err
 	}
+	sc.KeyPaths, err = keyPaths(sc.DataDir, sc.PluginConfigs)
+	if err != nil {
+		return nil, err
+	}
 	sc.Telemetry = c.Telemetry
 	sc.HealthChecks = c.HealthChecks
 
@@ -784,6 +899,17 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 	}
 
 	sc.EventsBasedCache = c.Server.Experimental.EventsBasedCache
+
+	if len(c.Server.Experimental.EntryTemplates) > 0 {
+		if !c.Server.Experimental.EventsBasedCache {
+			return nil, errors.New("agent entry templates require the events based cache to be enabled")
+		}
+		sc.EntryTemplates, err = parseEntryTemplates(sc.TrustDomain, sc.PluginConfigs, c.Server.Experimental.EntryTemplates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	sc.AuthOpaPolicyEngineConfig = c.Server.Experimental.AuthOpaPolicyEngine
 
 	for _, f := range c.Server.Experimental.Flags {
@@ -847,16 +973,119 @@ func setBundleEndpointConfigProfile(config *bundleEndpointConfig, dataDir string
 	}
 }
 
+// configToOIDCDiscoveryConfig converts the OIDC discovery configuration. The
+// issuer advertised for each published SVID type must be the issuer that the
+// server sets in those SVIDs, or relying parties would reject them.
+func configToOIDCDiscoveryConfig(config *oidcDiscoveryConfig, jwtIssuer, witIssuer string) (*bundle.OIDCDiscoveryConfig, error) {
+	oidcDiscovery := new(bundle.OIDCDiscoveryConfig)
+	switch {
+	case config.Issuer != "" && len(config.Domains) > 0:
+		return nil, errors.New("OIDC discovery issuer and domains are mutually exclusive")
+	case config.Issuer != "":
+		issuer, err := url.Parse(config.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse OIDC discovery issuer %q: %w", config.Issuer, err)
+		}
+		if issuer.Scheme != "https" || issuer.Host == "" || issuer.User != nil || issuer.RawQuery != "" || issuer.Fragment != "" {
+			return nil, fmt.Errorf("OIDC discovery issuer %q must be an HTTPS URL without user info, query or fragment", config.Issuer)
+		}
+		if strings.HasSuffix(issuer.Path, "/") {
+			return nil, fmt.Errorf("OIDC discovery issuer %q cannot end with a slash", config.Issuer)
+		}
+		oidcDiscovery.Issuer = issuer
+	case len(config.Domains) == 0:
+		return nil, errors.New("OIDC discovery requires either an issuer or the domains the issuer can be derived from")
+	}
+	for _, domain := range config.Domains {
+		if domain == "" || strings.ContainsAny(domain, ":/") {
+			return nil, fmt.Errorf("OIDC discovery domain %q must be a host name", domain)
+		}
+	}
+	oidcDiscovery.Domains = config.Domains
+
+	svidTypes := config.SVIDTypes
+	if len(svidTypes) == 0 {
+		svidTypes = []string{"jwt"}
+	}
+	for _, svidType := range svidTypes {
+		switch svidType {
+		case "jwt":
+			oidcDiscovery.JWTAuthorities = true
+		case "wit":
+			oidcDiscovery.WITAuthorities = true
+		default:
+			return nil, fmt.Errorf("unknown OIDC discovery SVID type %q; expected \"jwt\" or \"wit\"", svidType)
+		}
+	}
+
+	if oidcDiscovery.JWTAuthorities {
+		if err := checkOIDCDiscoveryIssuer(oidcDiscovery, "jwt_issuer", jwtIssuer); err != nil {
+			return nil, err
+		}
+	}
+	if oidcDiscovery.WITAuthorities {
+		if err := checkOIDCDiscoveryIssuer(oidcDiscovery, "wit_issuer", witIssuer); err != nil {
+			return nil, err
+		}
+	}
+	return oidcDiscovery, nil
+}
+
+// checkOIDCDiscoveryIssuer checks that the issuer of the SVIDs, configured
+// with the given setting, is the issuer advertised by OIDC discovery: the
+// configured issuer, or one derived from the allowed domains.
+func checkOIDCDiscoveryIssuer(oidcDiscovery *bundle.OIDCDiscoveryConfig, setting, svidIssuer string) error {
+	if oidcDiscovery.Issuer != nil {
+		if svidIssuer != oidcDiscovery.Issuer.String() {
+			return fmt.Errorf("OIDC discovery issuer %q must be equal to %s %q", oidcDiscovery.Issuer, setting, svidIssuer)
+		}
+		return nil
+	}
+	u, err := url.Parse(svidIssuer)
+	if err == nil && u.Scheme == "https" && u.Path == "" && u.Port() == "" && slices.ContainsFunc(oidcDiscovery.Domains, func(domain string) bool {
+		return strings.EqualFold(domain, u.Host)
+	}) {
+		return nil
+	}
+	return fmt.Errorf("%s %q must be \"https://\" followed by one of the OIDC discovery domains", setting, svidIssuer)
+}
+
 func configToACMEConfig(acme *bundleEndpointACMEConfig, dataDir string) *bundle.ACMEConfig {
 	return &bundle.ACMEConfig{
 		DirectoryURL: acme.DirectoryURL,
 		DomainName:   acme.DomainName,
-		CacheDir:     filepath.Join(dataDir, "bundle-acme"),
+		CacheDir:     filepath.Join(dataDir, acmeCacheDir),
 		Email:        acme.Email,
 		ToSAccepted:  acme.ToSAccepted,
 	}
 }
 
+// keyPaths returns the paths of the files holding private keys, which are left
+// out of snapshots unless requested: the keys of the disk KeyManager and the
+// ACME cache of the bundle endpoint.
+func keyPaths(dataDir string, pluginConfigs catalog.PluginConfigs) ([]string, error) {
+	paths := []string{filepath.Join(dataDir, acmeCacheDir)}
+
+	pc, ok := pluginConfigs.Find("KeyManager", "disk")
+	if !ok || !pc.IsEnabled() || pc.IsExternal() || pc.DataSource == nil {
+		return paths, nil
+	}
+	data, err := pc.DataSource.Load()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load disk KeyManager configuration: %w", err)
+	}
+	config := new(struct {
+		KeysPath string `hcl:"keys_path"`
+	})
+	if err := hcl.Decode(config, data); err != nil {
+		return nil, fmt.Errorf("unable to decode disk KeyManager configuration: %w", err)
+	}
+	if config.KeysPath != "" {
+		paths = append(paths, config.KeysPath)
+	}
+	return paths, nil
+}
+
 func configToDiskCertManager(serviceCertFile *bundleEndpointServingCertFile, log logrus.FieldLogger) (*diskcertmanager.DiskCertManager, error) {
 	fileSyncInterval, err := time.ParseDuration(serviceCertFile.RawFileSyncInterval)
 	if err != nil {
@@ -927,6 +1156,108 @@ func parseBundleEndpointProfileASTNode(node ast.Node) (string, error) {
 	return data.String(), nil
 }
 
+func parseTenants(td spiffeid.TrustDomain, tenantConfigs map[string]tenantConfig) (*tenant.Set, error) {
+	names := make([]string, 0, len(tenantConfigs))
+	for name := range tenantConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	configs := make([]tenant.Config, 0, len(names))
+	for _, name := range names {
+		config := tenant.Config{
+			Name:            name,
+			PathPrefix:      tenantConfigs[name].PathPrefix,
+			DNSNameSuffixes: tenantConfigs[name].DNSNameSuffixes,
+		}
+		for _, adminID := range tenantConfigs[name].AdminIDs {
+			id, err := spiffeid.FromString(adminID)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse admin ID %q of tenant %q: %w", adminID, name, err)
+			}
+			config.AdminIDs = append(config.AdminIDs, id)
+		}
+		configs = append(configs, config)
+	}
+
+	tenants, err := tenant.NewSet(td, configs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant configuration: %w", err)
+	}
+	return tenants, nil
+}
+
+func parseEntryTemplates(td spiffeid.TrustDomain, pluginConfigs catalog.PluginConfigs, templateConfigs map[string]entryTemplateConfig) ([]*authorizedentries.EntryTemplate, error) {
+	// Node attestors name the selectors they produce after themselves, so
+	// their names are the types of the agent selectors.
+	var nodeSelectorTypes []string
+	nodeAttestors, _ := pluginConfigs.FilterByType("NodeAttestor")
+	for _, nodeAttestor := range nodeAttestors {
+		if nodeAttestor.IsEnabled() {
+			nodeSelectorTypes = append(nodeSelectorTypes, nodeAttestor.Name)
+		}
+	}
+
+	names := make([]string, 0, len(templateConfigs))
+	for name := range templateConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	templates := make([]*authorizedentries.EntryTemplate, 0, len(names))
+	for _, name := range names {
+		tc := templateConfigs[name]
+		config := authorizedentries.EntryTemplateConfig{
+			Name:              name,
+			SPIFFEID:          tc.SPIFFEID,
+			NodeSelectorTypes: nodeSelectorTypes,
+			Selectors:         tc.Selectors,
+			DNSNames:          tc.DNSNames,
+			X509SVIDTTL:       tc.X509SVIDTTL,
+			JWTSVIDTTL:        tc.JWTSVIDTTL,
+			Hint:              tc.Hint,
+		}
+		for _, nodeSelector := range tc.NodeSelectors {
+			selectorType, selectorValue, ok := strings.Cut(nodeSelector, ":")
+			if !ok || selectorType == "" || selectorValue == "" {
+				return nil, fmt.Errorf("invalid node selector %q of agent entry template %q: must be in type:value form", nodeSelector, name)
+			}
+			config.NodeSelectors = append(config.NodeSelectors, &types.Selector{Type: selectorType, Value: selectorValue})
+		}
+
+		template, err := authorizedentries.NewEntryTemplate(td, config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid agent entry template %q: %w", name, err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func parseClaimTemplates(templateConfigs map[string]claimTemplateConfig) ([]*credtemplate.ClaimTemplate, error) {
+	names := make([]string, 0, len(templateConfigs))
+	for name := range templateConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	templates := make([]*credtemplate.ClaimTemplate, 0, len(names))
+	for _, name := range names {
+		tc := templateConfigs[name]
+		template, err := credtemplate.NewClaimTemplate(credtemplate.ClaimTemplateConfig{
+			Name:      name,
+			Value:     tc.Value,
+			SVIDTypes: tc.SVIDTypes,
+			Required:  tc.Required,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid claim template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
 func validateConfig(c *Config) error {
 	if c.Server == nil {
 		return errors.New("server section must be configured")
@@ -1017,6 +1348,28 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 			detectedUnknown("ratelimit", rl.UnusedKeyPositions)
 		}
 
+		if cp := c.Server.CredentialProfile; cp != nil && len(cp.UnusedKeyPositions) != 0 {
+			detectedUnknown("credential_profile", cp.UnusedKeyPositions)
+		}
+
+		for name, tc := range c.Server.ClaimTemplates {
+			if len(tc.UnusedKeyPositions) != 0 {
+				detectedUnknown(fmt.Sprintf("claim template %q", name), tc.UnusedKeyPositions)
+			}
+		}
+
+		for name, tc := range c.Server.Tenants {
+			if len(tc.UnusedKeyPositions) != 0 {
+				detectedUnknown(fmt.Sprintf("tenant %q", name), tc.UnusedKeyPositions)
+			}
+		}
+
+		for name, tc := range c.Server.Experimental.EntryTemplates {
+			if len(tc.UnusedKeyPositions) != 0 {
+				detectedUnknown(fmt.Sprintf("agent entry template %q", name), tc.UnusedKeyPositions)
+			}
+		}
+
 		// TODO: Re-enable unused key detection for experimental config. See
 		// https://github.com/spiffe/spire/issues/1101 for more information
 		//
@@ -1040,6 +1393,10 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 				if bea := c.Server.Federation.BundleEndpoint.ACME; bea != nil && len(bea.UnusedKeyPositions) != 0 {
 					detectedUnknown("bundle endpoint ACME", bea.UnusedKeyPositions)
 				}
+
+				if od := c.Server.Federation.BundleEndpoint.OIDCDiscovery; od != nil && len(od.UnusedKeyPositions) != 0 {
+					detectedUnknown("bundle endpoint OIDC discovery", od.UnusedKeyPositions)
+				}
 			}
 
 			// TODO: Re-enable unused key detection for bundle endpoint profile config. See
@@ -3,6 +3,7 @@ package run
 import (
 	"crypto/x509/pkix"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -553,6 +554,35 @@ func TestNewServerConfig(t *testing.T) {
 				require.Equal(t, 0, c.PruneAttestedNodesBatchSize)
 			},
 		},
+		{
+			msg: "entry_history_retention should be correctly parsed",
+			input: func(c *Config) {
+				c.Server.EntryHistoryRetention = "168h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 168*time.Hour, c.EntryHistoryRetention)
+			},
+		},
+		{
+			msg:         "invalid entry_history_retention should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.EntryHistoryRetention = "forever"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "non-positive entry_history_retention should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.EntryHistoryRetention = "0s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "bind_address and bind_port should be correctly parsed",
 			input: func(c *Config) {
@@ -880,6 +910,202 @@ func TestNewServerConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "bundle endpoint has OIDC discovery",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Issuer:    "https://oidc.example.org",
+					SVIDTypes: []string{"jwt", "wit"},
+				}
+				c.Server.JWTIssuer = "https://oidc.example.org"
+				c.Server.Experimental.WITIssuer = "https://oidc.example.org"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, &bundle.OIDCDiscoveryConfig{
+					Issuer:         &url.URL{Scheme: "https", Host: "oidc.example.org"},
+					JWTAuthorities: true,
+					WITAuthorities: true,
+				}, c.Federation.BundleEndpoint.OIDCDiscovery)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery with domains",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Domains: []string{"oidc.example.org"},
+				}
+				c.Server.JWTIssuer = "https://oidc.example.org"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, &bundle.OIDCDiscoveryConfig{
+					Domains:        []string{"oidc.example.org"},
+					JWTAuthorities: true,
+				}, c.Federation.BundleEndpoint.OIDCDiscovery)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery with issuer path",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Issuer: "https://oidc.example.org/tenant-a",
+				}
+				c.Server.JWTIssuer = "https://oidc.example.org/tenant-a"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, &bundle.OIDCDiscoveryConfig{
+					Issuer:         &url.URL{Scheme: "https", Host: "oidc.example.org", Path: "/tenant-a"},
+					JWTAuthorities: true,
+				}, c.Federation.BundleEndpoint.OIDCDiscovery)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery with issuer path ending with a slash",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Issuer: "https://oidc.example.org/tenant-a/",
+				}
+				c.Server.JWTIssuer = "https://oidc.example.org/tenant-a/"
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery with issuer other than the JWT issuer",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Issuer: "https://oidc.example.org",
+				}
+				c.Server.JWTIssuer = "https://issuer.example.org"
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery for WIT-SVIDs without WIT issuer",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Issuer:    "https://oidc.example.org",
+					SVIDTypes: []string{"wit"},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery with JWT issuer outside of the domains",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Domains: []string{"oidc.example.org"},
+				}
+				c.Server.JWTIssuer = "https://oidc.example.org/path"
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery without issuer or domains",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery with issuer and domains",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Issuer:  "https://oidc.example.org",
+					Domains: []string{"oidc.example.org"},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery with invalid domain",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Domains: []string{"https://oidc.example.org"},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery with non-HTTPS issuer",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Issuer: "http://oidc.example.org",
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle endpoint has OIDC discovery with unknown SVID type",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: bundleEndpointProfileHTTPSWebACMETest(t),
+				}
+				c.Server.Federation.BundleEndpoint.OIDCDiscovery = &oidcDiscoveryConfig{
+					Issuer:    "https://oidc.example.org",
+					SVIDTypes: []string{"x509"},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "bundle endpoint does not have a default refresh hint",
 			input: func(c *Config) {
@@ -1367,6 +1593,226 @@ func TestNewServerConfig(t *testing.T) {
 				}, c.AdminIDs)
 			},
 		},
+		{
+			msg: "tenants are set",
+			input: func(c *Config) {
+				c.Server.Tenants = map[string]tenantConfig{
+					"team-a": {
+						PathPrefix: "/team-a",
+						AdminIDs:   []string{"spiffe://example.org/team-a/admin"},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				adminID := spiffeid.RequireFromString("spiffe://example.org/team-a/admin")
+				require.Equal(t, []spiffeid.ID{adminID}, c.Tenants.AdminIDs())
+				tenant, ok := c.Tenants.ForAdmin(adminID)
+				require.True(t, ok)
+				require.Equal(t, "team-a", tenant.Name())
+				require.Equal(t, "/team-a", tenant.PathPrefix())
+			},
+		},
+		{
+			msg: "tenant with invalid admin ID",
+			input: func(c *Config) {
+				c.Server.Tenants = map[string]tenantConfig{
+					"team-a": {
+						PathPrefix: "/team-a",
+						AdminIDs:   []string{"not-an-id"},
+					},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "tenants with overlapping path prefixes",
+			input: func(c *Config) {
+				c.Server.Tenants = map[string]tenantConfig{
+					"team-a": {
+						PathPrefix: "/team",
+						AdminIDs:   []string{"spiffe://example.org/team-a/admin"},
+					},
+					"team-b": {
+						PathPrefix: "/team/b",
+						AdminIDs:   []string{"spiffe://example.org/team-b/admin"},
+					},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "credential profile policy is set",
+			input: func(c *Config) {
+				c.Server.CredentialProfile = &credentialProfileConfig{
+					AllowedFields:            []string{"subject_organization", "extended_key_usages"},
+					AllowedExtendedKeyUsages: []string{"emailProtection"},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, credtemplate.CredentialProfilePolicy{
+					AllowedFields:            []string{"subject_organization", "extended_key_usages"},
+					AllowedExtendedKeyUsages: []string{"emailProtection"},
+				}, c.CredentialProfilePolicy)
+			},
+		},
+		{
+			msg: "credential profile policy with extension OIDs",
+			input: func(c *Config) {
+				c.Server.CredentialProfile = &credentialProfileConfig{
+					AllowedFields:        []string{"extensions"},
+					AllowedExtensionOIDs: []string{"1.2.3.4"},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, []string{"1.2.3.4"}, c.CredentialProfilePolicy.AllowedExtensionOIDs)
+			},
+		},
+		{
+			msg: "credential profile allowing a reserved extension OID",
+			input: func(c *Config) {
+				c.Server.CredentialProfile = &credentialProfileConfig{
+					AllowedFields:        []string{"extensions"},
+					AllowedExtensionOIDs: []string{"2.5.29.32"},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "credential profile allowing anyExtendedKeyUsage",
+			input: func(c *Config) {
+				c.Server.CredentialProfile = &credentialProfileConfig{
+					AllowedFields:            []string{"extended_key_usages"},
+					AllowedExtendedKeyUsages: []string{"2.5.29.37.0"},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "credential profile with unknown field",
+			input: func(c *Config) {
+				c.Server.CredentialProfile = &credentialProfileConfig{
+					AllowedFields: []string{"uri_sans"},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "claim templates are set",
+			input: func(c *Config) {
+				c.Server.ClaimTemplates = map[string]claimTemplateConfig{
+					"namespace": {Value: "{{k8s:ns}}", SVIDTypes: []string{"jwt"}, Required: true},
+					"cluster":   {Value: "{{ .NodeSelector `k8s_psat:cluster` }}"},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Len(t, c.ClaimTemplates, 2)
+				require.Equal(t, "cluster", c.ClaimTemplates[0].Name())
+				require.Equal(t, "namespace", c.ClaimTemplates[1].Name())
+			},
+		},
+		{
+			msg: "claim template with reserved claim",
+			input: func(c *Config) {
+				c.Server.ClaimTemplates = map[string]claimTemplateConfig{
+					"sub": {Value: "{{ .SPIFFEID }}"},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "agent entry templates are set",
+			input: func(c *Config) {
+				c.Plugins = pluginsConfig(`plugins { NodeAttestor "k8s_psat" { plugin_data {} } }`)
+				c.Server.Experimental.EventsBasedCache = true
+				c.Server.Experimental.EntryTemplates = map[string]entryTemplateConfig{
+					"ns-workloads": {
+						SPIFFEID:      "spiffe://example.org/ns/{{k8s_psat:agent_ns}}",
+						NodeSelectors: []string{"k8s_psat:cluster:prod"},
+						Selectors:     []string{"k8s:ns:{{k8s_psat:agent_ns}}"},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Len(t, c.EntryTemplates, 1)
+				require.Equal(t, "ns-workloads", c.EntryTemplates[0].Name())
+			},
+		},
+		{
+			msg: "agent entry templates require the events based cache",
+			input: func(c *Config) {
+				c.Server.Experimental.EntryTemplates = map[string]entryTemplateConfig{
+					"ns-workloads": {
+						SPIFFEID:  "spiffe://example.org/ns/{{k8s_psat:agent_ns}}",
+						Selectors: []string{"k8s:ns:{{k8s_psat:agent_ns}}"},
+					},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "agent entry template with invalid node selector",
+			input: func(c *Config) {
+				c.Server.Experimental.EventsBasedCache = true
+				c.Server.Experimental.EntryTemplates = map[string]entryTemplateConfig{
+					"ns-workloads": {
+						SPIFFEID:      "spiffe://example.org/ns/{{k8s_psat:agent_ns}}",
+						NodeSelectors: []string{"k8s_psat"},
+						Selectors:     []string{"k8s:ns:{{k8s_psat:agent_ns}}"},
+					},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "key paths hold the disk KeyManager keys",
+			input: func(c *Config) {
+				c.Plugins = pluginsConfig(`plugins { KeyManager "disk" { plugin_data { keys_path = "/run/spire/keys.json" } } }`)
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, []string{"bundle-acme", "/run/spire/keys.json"}, c.KeyPaths)
+			},
+		},
+		{
+			msg: "agent entry template with workload selector placeholder",
+			input: func(c *Config) {
+				c.Plugins = pluginsConfig(`plugins { NodeAttestor "k8s_psat" { plugin_data {} } }`)
+				c.Server.Experimental.EventsBasedCache = true
+				c.Server.Experimental.EntryTemplates = map[string]entryTemplateConfig{
+					"ns-workloads": {
+						SPIFFEID:  "spiffe://example.org/ns/{{k8s:ns}}",
+						Selectors: []string{"k8s:ns:{{k8s:ns}}"},
+					},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg:   "require PQ KEM is disabled (default)",
 			input: func(c *Config) {},
@@ -1422,6 +1868,15 @@ func defaultValidConfig() *Config {
 	return c
 }
 
+// pluginsConfig returns the plugins section of the given configuration.
+func pluginsConfig(config string) ast.Node {
+	c := new(Config)
+	if err := hcl.Decode(c, config); err != nil {
+		panic(err)
+	}
+	return c.Plugins
+}
+
 func TestValidateConfig(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -1574,6 +2029,26 @@ func TestWarnOnUnknownConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			msg:      "in credential_profile block",
+			confFile: "server_bad_credential_profile_block.conf",
+			expectedLogEntries: []logEntry{
+				{
+					section: "credential_profile",
+					keys:    "unknown_option1,unknown_option2",
+				},
+			},
+		},
+		{
+			msg:      "in claim_template block",
+			confFile: "server_bad_claim_template_block.conf",
+			expectedLogEntries: []logEntry{
+				{
+					section: `claim template "namespace"`,
+					keys:    "unknown_option1,unknown_option2",
+				},
+			},
+		},
 		{
 			msg:      "in ratelimit block",
 			confFile: "server_bad_ratelimit_block.conf",
@@ -1627,6 +2102,16 @@ func TestWarnOnUnknownConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			msg:      "in nested bundle_endpoint.oidc_discovery block",
+			confFile: "server_bad_nested_bundle_endpoint_oidc_discovery_block.conf",
+			expectedLogEntries: []logEntry{
+				{
+					section: "bundle endpoint OIDC discovery",
+					keys:    "unknown_option1,unknown_option2",
+				},
+			},
+		},
 		// TODO: Re-enable unused key detection for experimental config. See
 		// https://github.com/spiffe/spire/issues/1101 for more information
 		//
@@ -0,0 +1,85 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	adminv1 "github.com/spiffe/spire/proto/spire/server/admin"
+)
+
+// NewCreateCommand creates a new "snapshot create" subcommand.
+func NewCreateCommand() cli.Command {
+	return NewCreateCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewCreateCommandWithEnv creates a new "snapshot create" subcommand using
+// the environment specified.
+func NewCreateCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &createCommand{})
+}
+
+type createCommand struct {
+	output      string
+	includeKeys bool
+}
+
+func (*createCommand) Name() string {
+	return "snapshot create"
+}
+
+func (*createCommand) Synopsis() string {
+	return "Takes a signed snapshot of the server state"
+}
+
+func (c *createCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.output, "output", "", "Path of the snapshot to write; it must not exist")
+	fs.BoolVar(&c.includeKeys, "includeKeys", false, "Include the files holding private keys, such as the disk KeyManager keys, in the snapshot; it is not encrypted")
+}
+
+func (c *createCommand) Run(ctx context.Context, env *commoncli.Env, serverClient util.ServerClient) (err error) {
+	if c.output == "" {
+		return errors.New("an output path is required")
+	}
+
+	stream, err := serverClient.NewAdminClient().Snapshot(ctx, &adminv1.SnapshotRequest{IncludeKeys: c.includeKeys})
+	if err != nil {
+		return fmt.Errorf("unable to take snapshot: %w", err)
+	}
+
+	// The snapshot may hold the server keys, and holds the datastore
+	// contents in any case, so it is only made readable by the owner.
+	f, err := os.OpenFile(c.output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to create snapshot file: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("unable to close snapshot file: %w", closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(c.output)
+		}
+	}()
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("unable to take snapshot: %w", err)
+		}
+		if _, err := f.Write(resp.Chunk); err != nil {
+			return fmt.Errorf("unable to write snapshot file: %w", err)
+		}
+	}
+
+	return env.Printf("Snapshot written to %q\n", c.output)
+}
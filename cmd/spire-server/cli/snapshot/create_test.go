@@ -0,0 +1,149 @@
+package snapshot
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	adminv1 "github.com/spiffe/spire/proto/spire/server/admin"
+	"github.com/spiffe/spire/test/clitest"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateHelp(t *testing.T) {
+	cmd, _, stderr := setupCreateCommand(t, &fakeAdminServer{})
+
+	require.Equal(t, "flag: help requested", cmd.Help())
+	require.Contains(t, stderr.String(), "Usage of snapshot create:")
+}
+
+func TestCreateSynopsis(t *testing.T) {
+	cmd, _, _ := setupCreateCommand(t, &fakeAdminServer{})
+	require.Equal(t, "Takes a signed snapshot of the server state", cmd.Synopsis())
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "snapshot")
+	outputWithKeys := filepath.Join(dir, "snapshot-with-keys")
+
+	for _, tt := range []struct {
+		name         string
+		args         []string
+		chunks       [][]byte
+		err          error
+		expectCode   int
+		expectStdout string
+		expectStderr string
+		outputPath   string
+		expectOutput string
+	}{
+		{
+			name:         "missing output",
+			expectCode:   1,
+			expectStderr: "Error: an output path is required\n",
+		},
+		{
+			name:         "snapshot fails",
+			args:         []string{"-output", output},
+			err:          status.Error(codes.Internal, "oh no"),
+			expectCode:   1,
+			expectStderr: "Error: unable to take snapshot: rpc error: code = Internal desc = oh no\n",
+		},
+		{
+			name:         "success",
+			args:         []string{"-output", output},
+			chunks:       [][]byte{[]byte("snap"), []byte("shot")},
+			expectStdout: "Snapshot written to \"" + output + "\"\n",
+			expectOutput: "snapshot",
+		},
+		{
+			name:         "success with keys",
+			args:         []string{"-output", outputWithKeys, "-includeKeys"},
+			chunks:       [][]byte{[]byte("snapshot")},
+			expectStdout: "Snapshot written to \"" + outputWithKeys + "\"\n",
+			outputPath:   outputWithKeys,
+			expectOutput: "keys+snapshot",
+		},
+		{
+			name:         "output exists",
+			args:         []string{"-output", output},
+			chunks:       [][]byte{[]byte("other")},
+			expectCode:   1,
+			expectStderr: "Error: unable to create snapshot file: ",
+			expectOutput: "snapshot",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, stdout, stderr := setupCreateCommand(t, &fakeAdminServer{chunks: tt.chunks, err: tt.err})
+
+			code := cmd.Run(append([]string{clitest.AddrArg, cmd.addr}, tt.args...))
+			require.Equal(t, tt.expectCode, code)
+			require.Equal(t, tt.expectStdout, stdout.String())
+			require.Contains(t, stderr.String(), tt.expectStderr)
+
+			outputPath := output
+			if tt.outputPath != "" {
+				outputPath = tt.outputPath
+			}
+			if tt.expectOutput == "" {
+				require.NoFileExists(t, outputPath)
+				return
+			}
+			data, err := os.ReadFile(outputPath)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectOutput, string(data))
+			info, err := os.Stat(outputPath)
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		})
+	}
+}
+
+type createTest struct {
+	cli.Command
+	addr string
+}
+
+func setupCreateCommand(t *testing.T, server *fakeAdminServer) (*createTest, *bytes.Buffer, *bytes.Buffer) {
+	addr := spiretest.StartGRPCServer(t, func(s *grpc.Server) {
+		adminv1.RegisterAdminServer(s, server)
+	})
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := NewCreateCommandWithEnv(&commoncli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	return &createTest{Command: cmd, addr: clitest.GetAddr(addr)}, stdout, stderr
+}
+
+type fakeAdminServer struct {
+	adminv1.UnsafeAdminServer
+
+	chunks [][]byte
+	err    error
+}
+
+func (s *fakeAdminServer) Snapshot(req *adminv1.SnapshotRequest, stream adminv1.Admin_SnapshotServer) error {
+	if req.IncludeKeys {
+		if err := stream.Send(&adminv1.SnapshotResponse{Chunk: []byte("keys+")}); err != nil {
+			return err
+		}
+	}
+	for _, chunk := range s.chunks {
+		if err := stream.Send(&adminv1.SnapshotResponse{Chunk: chunk}); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
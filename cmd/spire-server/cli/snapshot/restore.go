@@ -0,0 +1,203 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/datastore/archive"
+	"github.com/spiffe/spire/pkg/server/snapshot"
+)
+
+// serverProbeTimeout is how long to wait for a running server to accept a
+// connection on the server API address.
+const serverProbeTimeout = 5 * time.Second
+
+func NewRestoreCommand() cli.Command {
+	return newRestoreCommand(commoncli.DefaultEnv)
+}
+
+func newRestoreCommand(env *commoncli.Env) *restoreCommand {
+	c := &restoreCommand{
+		env: env,
+	}
+
+	c.flags = flag.NewFlagSet("snapshot restore", flag.ContinueOnError)
+	c.flags.SetOutput(env.Stderr)
+	c.flags.StringVar(&c.configPath, "config", "conf/server/server.conf", "Path to the SPIRE server configuration file")
+	c.flags.BoolVar(&c.expandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+	c.flags.StringVar(&c.input, "input", "", "Path of the snapshot to restore")
+	c.flags.StringVar(&c.trustBundlePath, "trustBundle", "", "Path to a PEM bundle of the X.509 authorities trusted to sign the snapshot; required when the datastore has no bundle for the trust domain")
+	return c
+}
+
+type restoreCommand struct {
+	env   *commoncli.Env
+	flags *flag.FlagSet
+
+	configPath      string
+	expandEnv       bool
+	input           string
+	trustBundlePath string
+}
+
+func (c *restoreCommand) Help() string {
+	return c.flags.Parse([]string{"-h"}).Error()
+}
+
+func (c *restoreCommand) Synopsis() string {
+	return "Restores the server state from a snapshot, replacing the datastore contents"
+}
+
+func (c *restoreCommand) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if err := c.run(context.Background()); err != nil {
+		_ = c.env.ErrPrintln("Error: " + err.Error())
+		return 1
+	}
+	return 0
+}
+
+func (c *restoreCommand) run(ctx context.Context) error {
+	if c.input == "" {
+		return errors.New("an input path is required")
+	}
+
+	configArgs := []string{"-config", c.configPath}
+	if c.expandEnv {
+		configArgs = append(configArgs, "-expandEnv")
+	}
+	config, err := run.LoadConfig("snapshot restore", configArgs, []log.Option{log.WithOutputWriter(io.Discard)}, c.env.Stderr, false)
+	if err != nil {
+		return fmt.Errorf("unable to load server configuration: %w", err)
+	}
+
+	// Restoring replaces the datastore contents and the data directory
+	// files, such as the disk key manager keys, underneath a running
+	// server, so the server must be stopped first.
+	if err := checkServerStopped(ctx, config.BindLocalAddress); err != nil {
+		return err
+	}
+
+	f, err := os.Open(c.input)
+	if err != nil {
+		return fmt.Errorf("unable to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	ds, closer, err := catalog.LoadDataStore(ctx, catalog.Config{
+		Log:           config.Log,
+		Metrics:       telemetry.Blackhole{},
+		TrustDomain:   config.TrustDomain,
+		PluginConfigs: config.PluginConfigs,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to load datastore: %w", err)
+	}
+	defer closer.Close()
+
+	trustedAuthorities, err := c.loadTrustedAuthorities(ctx, ds, config.TrustDomain)
+	if err != nil {
+		return err
+	}
+
+	// The snapshot is verified in full before anything is applied.
+	header, err := snapshot.Verify(f, config.TrustDomain, trustedAuthorities)
+	if err != nil {
+		return fmt.Errorf("unable to verify snapshot: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	stats, err := snapshot.Restore(ctx, ds, header, config.DataDir, f)
+	if err != nil {
+		return fmt.Errorf("unable to restore snapshot: %w", err)
+	}
+
+	if err := c.env.Printf("Restored snapshot taken at %s (%d records unchanged, %d records deleted):\n", header.CreatedAt.Format(time.RFC3339), stats.Unchanged, stats.Deleted); err != nil {
+		return err
+	}
+	for _, kind := range []string{
+		archive.KindBundle,
+		archive.KindFederationRelationship,
+		archive.KindRegistrationEntry,
+		archive.KindAttestedNode,
+		archive.KindJoinToken,
+		archive.KindCAJournal,
+	} {
+		if err := c.env.Printf("%-25s %d\n", kind+":", stats.Records[kind]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkServerStopped returns an error if a server is listening on the server
+// API address from the configuration.
+func checkServerStopped(ctx context.Context, addr net.Addr) error {
+	if addr == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, serverProbeTimeout)
+	defer cancel()
+	conn, err := dialServer(ctx, addr)
+	if err != nil {
+		return nil
+	}
+	conn.Close()
+	return fmt.Errorf("a server is listening on %s; stop every server that uses the datastore before restoring a snapshot", addr)
+}
+
+// loadTrustedAuthorities returns the X.509 authorities trusted to sign the
+// snapshot. These are the authorities of the datastore bundle, or, when the
+// datastore has no bundle for the trust domain, the authorities in the trust
+// bundle provided by the operator.
+func (c *restoreCommand) loadTrustedAuthorities(ctx context.Context, ds datastore.DataStore, trustDomain spiffeid.TrustDomain) ([]*x509.Certificate, error) {
+	bundle, err := ds.FetchBundle(ctx, trustDomain.IDString())
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch trust domain bundle: %w", err)
+	}
+
+	switch {
+	case bundle != nil && c.trustBundlePath != "":
+		return nil, errors.New("a trust bundle cannot be provided when the datastore has a bundle for the trust domain")
+	case bundle != nil:
+		var authorities []*x509.Certificate
+		for _, rootCA := range bundle.RootCas {
+			authority, err := x509.ParseCertificate(rootCA.DerBytes)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse datastore X.509 authority: %w", err)
+			}
+			authorities = append(authorities, authority)
+		}
+		return authorities, nil
+	case c.trustBundlePath == "":
+		return nil, errors.New("a trust bundle (-trustBundle) is required since the datastore has no bundle for the trust domain")
+	}
+
+	authorities, err := pemutil.LoadCertificates(c.trustBundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load trust bundle: %w", err)
+	}
+	return authorities, nil
+}
@@ -0,0 +1,12 @@
+//go:build !windows
+
+package snapshot
+
+import (
+	"context"
+	"net"
+)
+
+func dialServer(ctx context.Context, addr net.Addr) (net.Conn, error) {
+	return (&net.Dialer{}).DialContext(ctx, addr.Network(), addr.String())
+}
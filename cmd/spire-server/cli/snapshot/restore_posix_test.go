@@ -0,0 +1,46 @@
+//go:build !windows
+
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestRestoreRefusesRunningServer(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	socketPath := filepath.Join(dir, "api.sock")
+	spiretest.ServeGRPCServerOnUDSSocket(t, grpc.NewServer(), socketPath)
+
+	configPath := filepath.Join(dir, "server.conf")
+	require.NoError(t, os.WriteFile(configPath, fmt.Appendf(nil, `
+server {
+	trust_domain = "example.org"
+	data_dir = %q
+	socket_path = %q
+}
+
+plugins {
+	DataStore "kv" {
+		plugin_data {
+			database_path = %q
+		}
+	}
+}
+`, filepath.Join(dir, "data"), socketPath, filepath.Join(dir, "datastore.db")), 0o600))
+
+	cmd, stdout, stderr := setupRestoreCommand()
+	code := cmd.Run([]string{"-config", configPath, "-input", filepath.Join(dir, "snapshot")})
+	_ = fflag.Unload()
+	require.Equal(t, 1, code)
+	require.Empty(t, stdout.String())
+	require.Equal(t, fmt.Sprintf("Error: a server is listening on %s; stop every server that uses the datastore before restoring a snapshot\n", socketPath), stderr.String())
+	require.NoFileExists(t, filepath.Join(dir, "datastore.db"))
+}
@@ -0,0 +1,211 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/datastore/kvstore"
+	"github.com/spiffe/spire/pkg/server/snapshot"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreHelp(t *testing.T) {
+	cmd, _, stderr := setupRestoreCommand()
+
+	require.Equal(t, "flag: help requested", cmd.Help())
+	require.Contains(t, stderr.String(), "Usage of snapshot restore:")
+}
+
+func TestRestoreSynopsis(t *testing.T) {
+	cmd, _, _ := setupRestoreCommand()
+	require.Equal(t, "Restores the server state from a snapshot, replacing the datastore contents", cmd.Synopsis())
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	dir := t.TempDir()
+	log, _ := test.NewNullLogger()
+
+	// Take a snapshot of a server
+	ca := testca.New(t, td)
+	src := kvstore.New(log)
+	require.NoError(t, src.Configure(ctx, fmt.Sprintf(`database_path = %q`, filepath.Join(dir, "source.db"))))
+	defer src.Close()
+	_, err := src.CreateBundle(ctx, &common.Bundle{
+		TrustDomainId: td.IDString(),
+		RootCas:       []*common.Certificate{{DerBytes: ca.X509Authorities()[0].Raw}},
+	})
+	require.NoError(t, err)
+	srcDataDir := filepath.Join(dir, "source")
+	require.NoError(t, os.Mkdir(srcDataDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDataDir, "keys.json"), []byte("keys"), 0o600))
+
+	trustBundlePath := filepath.Join(dir, "bundle.pem")
+	require.NoError(t, os.WriteFile(trustBundlePath, pemutil.EncodeCertificate(ca.X509Authorities()[0]), 0o600))
+	otherTrustBundlePath := filepath.Join(dir, "other-bundle.pem")
+	require.NoError(t, os.WriteFile(otherTrustBundlePath, pemutil.EncodeCertificate(testca.New(t, td).X509Authorities()[0]), 0o600))
+
+	snapshotPath := filepath.Join(dir, "snapshot")
+	f, err := os.Create(snapshotPath)
+	require.NoError(t, err)
+	svid := ca.CreateX509SVID(idutil.RequireServerID(td))
+	_, err = snapshot.Write(ctx, snapshot.Config{
+		TrustDomain: td,
+		DataStore:   src,
+		DataDir:     srcDataDir,
+		X509SVID:    svid.Certificates,
+		Key:         svid.PrivateKey,
+		Now:         func() time.Time { return createdAt },
+	}, f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	dataDir := filepath.Join(dir, "data")
+	socketPath := filepath.Join(dir, "api.sock")
+	kvPath := filepath.Join(dir, "datastore.db")
+	configPath := filepath.Join(dir, "server.conf")
+	require.NoError(t, os.WriteFile(configPath, fmt.Appendf(nil, `
+server {
+	trust_domain = "example.org"
+	data_dir = %q
+	socket_path = %q
+}
+
+plugins {
+	DataStore "kv" {
+		plugin_data {
+			database_path = %q
+		}
+	}
+}
+`, dataDir, socketPath, kvPath), 0o600))
+
+	otherConfigPath := filepath.Join(dir, "other.conf")
+	require.NoError(t, os.WriteFile(otherConfigPath, fmt.Appendf(nil, `
+server {
+	trust_domain = "other.org"
+	data_dir = %q
+	socket_path = %q
+}
+
+plugins {
+	DataStore "kv" {
+		plugin_data {
+			database_path = %q
+		}
+	}
+}
+`, dataDir, socketPath, kvPath), 0o600))
+
+	expectStats := `bundle:                   1
+federation_relationship:  0
+registration_entry:       0
+attested_node:            0
+join_token:               0
+ca_journal:               0
+`
+
+	for _, tt := range []struct {
+		name          string
+		args          []string
+		expectCode    int
+		expectStdout  string
+		expectStderr  string
+		expectRestore bool
+	}{
+		{
+			name:         "missing input",
+			args:         []string{"-config", configPath},
+			expectCode:   1,
+			expectStderr: "Error: an input path is required\n",
+		},
+		{
+			name:         "missing trust bundle",
+			args:         []string{"-config", configPath, "-input", snapshotPath},
+			expectCode:   1,
+			expectStderr: "Error: a trust bundle (-trustBundle) is required since the datastore has no bundle for the trust domain\n",
+		},
+		{
+			name:         "wrong trust domain",
+			args:         []string{"-config", otherConfigPath, "-input", snapshotPath, "-trustBundle", trustBundlePath},
+			expectCode:   1,
+			expectStderr: "Error: unable to verify snapshot: snapshot belongs to trust domain \"example.org\", not \"other.org\"\n",
+		},
+		{
+			name:         "untrusted signer",
+			args:         []string{"-config", configPath, "-input", snapshotPath, "-trustBundle", otherTrustBundlePath},
+			expectCode:   1,
+			expectStderr: "Error: unable to verify snapshot: snapshot signer X509-SVID does not chain to the trusted X.509 authorities: x509svid: could not verify leaf certificate: x509: certificate signed by unknown authority\n",
+		},
+		{
+			name:          "success",
+			args:          []string{"-config", configPath, "-input", snapshotPath, "-trustBundle", trustBundlePath},
+			expectStdout:  "Restored snapshot taken at 2026-01-02T03:04:05Z (0 records unchanged, 0 records deleted):\n" + expectStats,
+			expectRestore: true,
+		},
+		{
+			name:         "trust bundle with datastore bundle",
+			args:         []string{"-config", configPath, "-input", snapshotPath, "-trustBundle", trustBundlePath},
+			expectCode:   1,
+			expectStderr: "Error: a trust bundle cannot be provided when the datastore has a bundle for the trust domain\n",
+		},
+		{
+			name:          "restore again",
+			args:          []string{"-config", configPath, "-input", snapshotPath},
+			expectStdout:  "Restored snapshot taken at 2026-01-02T03:04:05Z (1 records unchanged, 0 records deleted):\n" + expectStats,
+			expectRestore: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, stdout, stderr := setupRestoreCommand()
+
+			code := cmd.Run(tt.args)
+			_ = fflag.Unload()
+			require.Equal(t, tt.expectCode, code)
+			require.Equal(t, tt.expectStdout, stdout.String())
+			require.Equal(t, tt.expectStderr, stderr.String())
+
+			if !tt.expectRestore {
+				return
+			}
+
+			keys, err := os.ReadFile(filepath.Join(dataDir, "keys.json"))
+			require.NoError(t, err)
+			require.Equal(t, "keys", string(keys))
+
+			ds := kvstore.New(log)
+			require.NoError(t, ds.Configure(ctx, fmt.Sprintf(`database_path = %q`, kvPath)))
+			defer ds.Close()
+			resp, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+			require.NoError(t, err)
+			require.Len(t, resp.Bundles, 1)
+		})
+	}
+}
+
+func setupRestoreCommand() (*restoreCommand, *bytes.Buffer, *bytes.Buffer) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newRestoreCommand(&commoncli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	return cmd, stdout, stderr
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package snapshot
+
+import (
+	"context"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+func dialServer(ctx context.Context, addr net.Addr) (net.Conn, error) {
+	return winio.DialPipeContext(ctx, addr.String())
+}
@@ -20,6 +20,10 @@ import (
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/jwtutil"
 	"github.com/spiffe/spire/pkg/common/pemutil"
+	adminv1 "github.com/spiffe/spire/proto/spire/server/admin"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/server/entryhistory"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -42,9 +46,13 @@ func NewGRPCClient(addr string) (*grpc.ClientConn, error) {
 
 type ServerClient interface {
 	Release()
+	NewAdminClient() adminv1.AdminClient
 	NewAgentClient() agentv1.AgentClient
 	NewBundleClient() bundlev1.BundleClient
 	NewEntryClient() entryv1.EntryClient
+	NewEntryExtClient() entryext.EntryClient
+	NewEntryHistoryClient() entryhistoryv1.EntryHistoryClient
+	NewFederationStatusClient() federationstatusv1.FederationStatusClient
 	NewLoggerClient() loggerv1.LoggerClient
 	NewSVIDClient() svidv1.SVIDClient
 	NewTrustDomainClient() trustdomainv1.TrustDomainClient
@@ -68,6 +76,10 @@ func (c *serverClient) Release() {
 	c.conn.Close()
 }
 
+func (c *serverClient) NewAdminClient() adminv1.AdminClient {
+	return adminv1.NewAdminClient(c.conn)
+}
+
 func (c *serverClient) NewAgentClient() agentv1.AgentClient {
 	return agentv1.NewAgentClient(c.conn)
 }
@@ -80,6 +92,18 @@ func (c *serverClient) NewEntryClient() entryv1.EntryClient {
 	return entryv1.NewEntryClient(c.conn)
 }
 
+func (c *serverClient) NewEntryExtClient() entryext.EntryClient {
+	return entryext.NewEntryClient(c.conn)
+}
+
+func (c *serverClient) NewEntryHistoryClient() entryhistoryv1.EntryHistoryClient {
+	return entryhistoryv1.NewEntryHistoryClient(c.conn)
+}
+
+func (c *serverClient) NewFederationStatusClient() federationstatusv1.FederationStatusClient {
+	return federationstatusv1.NewFederationStatusClient(c.conn)
+}
+
 func (c *serverClient) NewLoggerClient() loggerv1.LoggerClient {
 	return loggerv1.NewLoggerClient(c.conn)
 }
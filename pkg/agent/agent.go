@@ -295,15 +295,16 @@ func (a *Agent) Run(ctx context.Context) error {
 
 	if len(a.c.Broker.BindAddresses) != 0 {
 		brokerEndpoints, err := broker.New(&broker.Config{
-			BindAddrs:    a.c.Broker.BindAddresses,
-			Manager:      mgr,
-			Log:          a.c.Log,
-			Metrics:      metrics,
-			Attestor:     workloadAttestor,
-			Brokers:      a.c.Broker.Brokers,
-			SVIDSource:   liveAgentSVIDSource{m: mgr},
-			BundleSource: mgr.GetX509Bundle(),
-			TLSPolicy:    a.c.TLSPolicy,
+			BindAddrs:       a.c.Broker.BindAddresses,
+			Manager:         mgr,
+			Log:             a.c.Log,
+			Metrics:         metrics,
+			Attestor:        workloadAttestor,
+			Brokers:         a.c.Broker.Brokers,
+			SVIDSource:      liveAgentSVIDSource{m: mgr},
+			BundleSource:    mgr.GetX509Bundle(),
+			TLSPolicy:       a.c.TLSPolicy,
+			DisableWITSVIDs: a.c.DisableWITSVIDs,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create broker endpoints: %w", err)
@@ -411,6 +412,7 @@ func (a *Agent) newManager(ctx context.Context, sto storage.Storage, cat catalog
 		UseSyncAuthorizedEntries: a.c.UseSyncAuthorizedEntries,
 		X509SVIDCacheMaxSize:     a.c.X509SVIDCacheMaxSize,
 		JWTSVIDCacheMaxSize:      a.c.JWTSVIDCacheMaxSize,
+		DisableWITSVIDs:          a.c.DisableWITSVIDs,
 		SVIDStoreCache:           cache,
 		NodeAttestor:             na,
 		RotationStrategy:         rotationutil.NewRotationStrategy(a.c.AvailabilityTarget),
@@ -519,6 +521,7 @@ func (a *Agent) newEndpoints(metrics telemetry.Metrics, mgr manager.Manager, att
 		LogSelectors:                  a.c.LogSelectors,
 		TrustDomain:                   a.c.TrustDomain,
 		WorkloadAPIRateLimit:          a.c.WorkloadAPIRateLimit,
+		DisableWITSVIDs:               a.c.DisableWITSVIDs,
 	})
 }
 
@@ -533,6 +536,7 @@ func (a *Agent) newAdminEndpoints(metrics telemetry.Metrics, mgr manager.Manager
 		Uptime:              uptime.Uptime,
 		Attestor:            attestor,
 		AuthorizedDelegates: authorizedDelegates,
+		DisableWITSVIDs:     a.c.DisableWITSVIDs,
 	}
 
 	return admin_api.New(config)
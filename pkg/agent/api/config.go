@@ -33,6 +33,10 @@ type Config struct {
 	Attestor attestor.Attestor
 
 	AuthorizedDelegates []string
+
+	// DisableWITSVIDs disables the WIT-SVID and WIT bundle RPCs of the
+	// Delegated Identity API.
+	DisableWITSVIDs bool
 }
 
 func New(c *Config) *Endpoints {
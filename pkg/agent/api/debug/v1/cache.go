@@ -0,0 +1,90 @@
+package debug
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+)
+
+// extensionServer exposes the SPIRE extensions to the Debug API
+type extensionServer struct {
+	spiredebug.UnimplementedAPIServer
+
+	s *Service
+}
+
+// ListCachedEntries lists the registration entries in the agent cache
+func (e extensionServer) ListCachedEntries(context.Context, *spiredebug.ListCachedEntriesRequest) (*spiredebug.ListCachedEntriesResponse, error) {
+	var entries []*spiredebug.CachedEntry
+	for _, cachedEntry := range e.s.m.GetCachedEntries() {
+		entry := cachedEntry.Entry
+		entries = append(entries, &spiredebug.CachedEntry{
+			EntryId:        entry.EntryId,
+			SpiffeId:       entry.SpiffeId,
+			ParentId:       entry.ParentId,
+			Selectors:      entry.Selectors,
+			EntryExpiresAt: entry.EntryExpiry,
+			SvidStatus:     svidStatus(cachedEntry),
+			SvidExpiresAt:  unixOrZero(cachedEntry.SVIDExpiresAt),
+		})
+	}
+
+	return &spiredebug.ListCachedEntriesResponse{Entries: entries}, nil
+}
+
+// ListStaleEntries lists the cached entries waiting for a new SVID
+func (e extensionServer) ListStaleEntries(context.Context, *spiredebug.ListStaleEntriesRequest) (*spiredebug.ListStaleEntriesResponse, error) {
+	staleEntries := e.s.m.GetStaleEntries()
+	slices.SortFunc(staleEntries, func(a, b *cache.StaleEntry) int {
+		return strings.Compare(a.Entry.EntryId, b.Entry.EntryId)
+	})
+
+	var entries []*spiredebug.StaleEntry
+	for _, staleEntry := range staleEntries {
+		entries = append(entries, &spiredebug.StaleEntry{
+			EntryId:       staleEntry.Entry.EntryId,
+			SpiffeId:      staleEntry.Entry.SpiffeId,
+			SvidExpiresAt: unixOrZero(staleEntry.SVIDExpiresAt),
+		})
+	}
+
+	return &spiredebug.ListStaleEntriesResponse{Entries: entries}, nil
+}
+
+// GetSyncStatus gets the time and result of the last cache synchronization
+func (e extensionServer) GetSyncStatus(context.Context, *spiredebug.GetSyncStatusRequest) (*spiredebug.GetSyncStatusResponse, error) {
+	syncStatus := e.s.m.GetSyncStatus()
+
+	resp := &spiredebug.GetSyncStatusResponse{
+		LastSync:        unixOrZero(syncStatus.LastSync),
+		LastSyncAttempt: unixOrZero(syncStatus.LastSyncAttempt),
+	}
+	if syncStatus.LastError != nil {
+		resp.LastError = syncStatus.LastError.Error()
+	}
+
+	return resp, nil
+}
+
+func svidStatus(entry *cache.CachedEntry) spiredebug.CachedEntry_SVIDStatus {
+	switch {
+	case !entry.HasSVID:
+		return spiredebug.CachedEntry_MISSING
+	case entry.Stale:
+		return spiredebug.CachedEntry_STALE
+	default:
+		return spiredebug.CachedEntry_VALID
+	}
+}
+
+// unixOrZero returns the Unix time of t, or zero if t is not set
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,164 @@
+package debug_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCachedEntries(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	svidExpiresAt := time.Unix(1700000000, 0)
+	selectors := []*common.Selector{{Type: "unix", Value: "uid:1000"}}
+	test.m.cachedEntries = []*cache.CachedEntry{
+		{
+			Entry: &common.RegistrationEntry{
+				EntryId:     "entry-1",
+				SpiffeId:    "spiffe://example.org/one",
+				ParentId:    "spiffe://example.org/spire/agent/foo",
+				Selectors:   selectors,
+				EntryExpiry: 1800000000,
+			},
+		},
+		{
+			Entry: &common.RegistrationEntry{
+				EntryId:   "entry-2",
+				SpiffeId:  "spiffe://example.org/two",
+				ParentId:  "spiffe://example.org/spire/agent/foo",
+				Selectors: selectors,
+			},
+			HasSVID:       true,
+			SVIDExpiresAt: svidExpiresAt,
+		},
+		{
+			Entry: &common.RegistrationEntry{
+				EntryId:   "entry-3",
+				SpiffeId:  "spiffe://example.org/three",
+				ParentId:  "spiffe://example.org/spire/agent/foo",
+				Selectors: selectors,
+			},
+			HasSVID:       true,
+			SVIDExpiresAt: svidExpiresAt,
+			Stale:         true,
+		},
+	}
+
+	resp, err := test.extensionClient.ListCachedEntries(ctx, &spiredebug.ListCachedEntriesRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*spiredebug.CachedEntry{
+		{
+			EntryId:        "entry-1",
+			SpiffeId:       "spiffe://example.org/one",
+			ParentId:       "spiffe://example.org/spire/agent/foo",
+			Selectors:      selectors,
+			EntryExpiresAt: 1800000000,
+			SvidStatus:     spiredebug.CachedEntry_MISSING,
+		},
+		{
+			EntryId:       "entry-2",
+			SpiffeId:      "spiffe://example.org/two",
+			ParentId:      "spiffe://example.org/spire/agent/foo",
+			Selectors:     selectors,
+			SvidStatus:    spiredebug.CachedEntry_VALID,
+			SvidExpiresAt: svidExpiresAt.Unix(),
+		},
+		{
+			EntryId:       "entry-3",
+			SpiffeId:      "spiffe://example.org/three",
+			ParentId:      "spiffe://example.org/spire/agent/foo",
+			Selectors:     selectors,
+			SvidStatus:    spiredebug.CachedEntry_STALE,
+			SvidExpiresAt: svidExpiresAt.Unix(),
+		},
+	}, resp.Entries)
+}
+
+func TestListStaleEntries(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	svidExpiresAt := time.Unix(1700000000, 0)
+	test.m.staleEntries = []*cache.StaleEntry{
+		{
+			Entry: &common.RegistrationEntry{EntryId: "entry-2", SpiffeId: "spiffe://example.org/two"},
+		},
+		{
+			Entry:         &common.RegistrationEntry{EntryId: "entry-1", SpiffeId: "spiffe://example.org/one"},
+			SVIDExpiresAt: svidExpiresAt,
+		},
+	}
+
+	resp, err := test.extensionClient.ListStaleEntries(ctx, &spiredebug.ListStaleEntriesRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*spiredebug.StaleEntry{
+		{
+			EntryId:       "entry-1",
+			SpiffeId:      "spiffe://example.org/one",
+			SvidExpiresAt: svidExpiresAt.Unix(),
+		},
+		{
+			EntryId:  "entry-2",
+			SpiffeId: "spiffe://example.org/two",
+		},
+	}, resp.Entries)
+}
+
+func TestGetSyncStatus(t *testing.T) {
+	lastSync := time.Unix(1700000000, 0)
+	lastSyncAttempt := lastSync.Add(time.Minute)
+
+	for _, tt := range []struct {
+		name       string
+		syncStatus manager.SyncStatus
+		expectResp *spiredebug.GetSyncStatusResponse
+	}{
+		{
+			name:       "never synced",
+			expectResp: &spiredebug.GetSyncStatusResponse{},
+		},
+		{
+			name: "last sync succeeded",
+			syncStatus: manager.SyncStatus{
+				LastSync:        lastSyncAttempt,
+				LastSyncAttempt: lastSyncAttempt,
+			},
+			expectResp: &spiredebug.GetSyncStatusResponse{
+				LastSync:        lastSyncAttempt.Unix(),
+				LastSyncAttempt: lastSyncAttempt.Unix(),
+			},
+		},
+		{
+			name: "last sync failed",
+			syncStatus: manager.SyncStatus{
+				LastSync:        lastSync,
+				LastSyncAttempt: lastSyncAttempt,
+				LastError:       errors.New("oh no"),
+			},
+			expectResp: &spiredebug.GetSyncStatusResponse{
+				LastSync:        lastSync.Unix(),
+				LastSyncAttempt: lastSyncAttempt.Unix(),
+				LastError:       "oh no",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.m.syncStatus = tt.syncStatus
+
+			resp, err := test.extensionClient.GetSyncStatus(ctx, &spiredebug.GetSyncStatusRequest{})
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, tt.expectResp, resp)
+		})
+	}
+}
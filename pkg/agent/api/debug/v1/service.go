@@ -13,8 +13,10 @@ import (
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	debugv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/agent/debug/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	workloadattestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/common/util"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
 	"github.com/spiffe/spire/test/clock"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -25,13 +27,16 @@ const (
 	cacheExpiry = 5 * time.Second
 )
 
-// RegisterService registers debug service on provided server
+// RegisterService registers debug service, and its SPIRE extensions, on
+// provided server
 func RegisterService(s grpc.ServiceRegistrar, service *Service) {
 	debugv1.RegisterDebugServer(s, service)
+	spiredebug.RegisterAPIServer(s, extensionServer{s: service})
 }
 
 // Config configurations for debug service
 type Config struct {
+	Attestor    workloadattestor.Attestor
 	Clock       clock.Clock
 	Log         logrus.FieldLogger
 	Manager     manager.Manager
@@ -42,11 +47,12 @@ type Config struct {
 // New creates a new debug service
 func New(config Config) *Service {
 	return &Service{
-		clock:  config.Clock,
-		log:    config.Log,
-		m:      config.Manager,
-		td:     config.TrustDomain,
-		uptime: config.Uptime,
+		attestor: config.Attestor,
+		clock:    config.Clock,
+		log:      config.Log,
+		m:        config.Manager,
+		td:       config.TrustDomain,
+		uptime:   config.Uptime,
 	}
 }
 
@@ -54,11 +60,12 @@ func New(config Config) *Service {
 type Service struct {
 	debugv1.UnsafeDebugServer
 
-	clock  clock.Clock
-	log    logrus.FieldLogger
-	m      manager.Manager
-	td     spiffeid.TrustDomain
-	uptime func() time.Duration
+	attestor workloadattestor.Attestor
+	clock    clock.Clock
+	log      logrus.FieldLogger
+	m        manager.Manager
+	td       spiffeid.TrustDomain
+	uptime   func() time.Duration
 
 	getInfoResp getInfoResp
 }
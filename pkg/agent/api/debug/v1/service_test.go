@@ -15,9 +15,12 @@ import (
 	debugv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/agent/debug/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	debug "github.com/spiffe/spire/pkg/agent/api/debug/v1"
+	workloadattestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/svid"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/grpctest"
 	"github.com/spiffe/spire/test/spiretest"
@@ -232,13 +235,15 @@ func TestGetInfo(t *testing.T) {
 }
 
 type serviceTest struct {
-	client debugv1.DebugClient
-	done   func()
-
-	clk     *clock.Mock
-	logHook *test.Hook
-	m       *fakeManager
-	uptime  *fakeUptime
+	client          debugv1.DebugClient
+	extensionClient spiredebug.APIClient
+	done            func()
+
+	attestor *fakeAttestor
+	clk      *clock.Mock
+	logHook  *test.Hook
+	m        *fakeManager
+	uptime   *fakeUptime
 }
 
 func (s *serviceTest) Cleanup() {
@@ -247,6 +252,7 @@ func (s *serviceTest) Cleanup() {
 
 func setupServiceTest(t *testing.T) *serviceTest {
 	clk := clock.NewMock(t)
+	attestor := &fakeAttestor{}
 	manager := &fakeManager{}
 	log, logHook := test.NewNullLogger()
 	log.Level = logrus.DebugLevel
@@ -257,6 +263,7 @@ func setupServiceTest(t *testing.T) *serviceTest {
 	}
 
 	service := debug.New(debug.Config{
+		Attestor:    attestor,
 		Clock:       clk,
 		Log:         log,
 		Manager:     manager,
@@ -265,10 +272,11 @@ func setupServiceTest(t *testing.T) *serviceTest {
 	})
 
 	test := &serviceTest{
-		clk:     clk,
-		logHook: logHook,
-		m:       manager,
-		uptime:  fakeUptime,
+		attestor: attestor,
+		clk:      clk,
+		logHook:  logHook,
+		m:        manager,
+		uptime:   fakeUptime,
 	}
 
 	registerFn := func(s grpc.ServiceRegistrar) {
@@ -276,7 +284,9 @@ func setupServiceTest(t *testing.T) *serviceTest {
 	}
 	server := grpctest.StartServer(t, registerFn)
 	test.done = server.Stop
-	test.client = debugv1.NewDebugClient(server.NewGRPCClient(t))
+	conn := server.NewGRPCClient(t)
+	test.client = debugv1.NewDebugClient(conn)
+	test.extensionClient = spiredebug.NewAPIClient(conn)
 
 	return test
 }
@@ -291,6 +301,9 @@ type fakeManager struct {
 	jwtSvidCount           int
 	svidstoreX509SvidCount int
 	lastSync               time.Time
+	syncStatus             manager.SyncStatus
+	cachedEntries          []*cache.CachedEntry
+	staleEntries           []*cache.StaleEntry
 }
 
 func (m *fakeManager) GetCurrentCredentials() svid.State {
@@ -321,6 +334,29 @@ func (m *fakeManager) GetBundle() *cache.Bundle {
 	return m.bundle
 }
 
+func (m *fakeManager) GetSyncStatus() manager.SyncStatus {
+	return m.syncStatus
+}
+
+func (m *fakeManager) GetCachedEntries() []*cache.CachedEntry {
+	return m.cachedEntries
+}
+
+func (m *fakeManager) GetStaleEntries() []*cache.StaleEntry {
+	return m.staleEntries
+}
+
+type fakeAttestor struct {
+	workloadattestor.Attestor
+
+	selectors []*common.Selector
+	err       error
+}
+
+func (a *fakeAttestor) Attest(context.Context, int) ([]*common.Selector, error) {
+	return a.selectors, a.err
+}
+
 type fakeUptime struct {
 	start time.Time
 	clk   *clock.Mock
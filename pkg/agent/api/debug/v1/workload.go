@@ -0,0 +1,65 @@
+package debug
+
+import (
+	"context"
+
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExplainWorkload attests the workload with the given PID and reports which
+// cached entries match the attested selectors
+func (e extensionServer) ExplainWorkload(ctx context.Context, req *spiredebug.ExplainWorkloadRequest) (*spiredebug.ExplainWorkloadResponse, error) {
+	if req.Pid <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "pid must be greater than zero")
+	}
+
+	log := e.s.log.WithField(telemetry.PID, req.Pid)
+	selectors, err := e.s.attestor.Attest(ctx, int(req.Pid))
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		log.WithError(err).Error("Workload attestation failed")
+		return nil, status.Errorf(codes.Unavailable, "workload attestation failed: %v", err)
+	}
+
+	selectorSet := make(map[selector.Selector]struct{}, len(selectors))
+	for _, s := range selectors {
+		selectorSet[selector.Selector{Type: s.Type, Value: s.Value}] = struct{}{}
+	}
+
+	resp := &spiredebug.ExplainWorkloadResponse{
+		Selectors: selector.WithoutUnknown(selectors),
+	}
+	// Cached entries are sorted by entry ID, and so are the matches
+	for _, cachedEntry := range e.s.m.GetCachedEntries() {
+		entry := cachedEntry.Entry
+
+		// An entry matches when every one of its selectors, which may be
+		// selector expressions, is satisfied by the attested selectors
+		var unsatisfied []*common.Selector
+		for _, s := range entry.Selectors {
+			if !selector.Match(selector.Selector{Type: s.Type, Value: s.Value}, selectorSet) {
+				unsatisfied = append(unsatisfied, s)
+			}
+		}
+
+		match := &spiredebug.EntryMatch{
+			EntryId:              entry.EntryId,
+			SpiffeId:             entry.SpiffeId,
+			UnsatisfiedSelectors: unsatisfied,
+		}
+		if len(unsatisfied) == 0 {
+			resp.MatchedEntries = append(resp.MatchedEntries, match)
+		} else {
+			resp.UnmatchedEntries = append(resp.UnmatchedEntries, match)
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,145 @@
+package debug_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestExplainWorkload(t *testing.T) {
+	attested := []*common.Selector{
+		{Type: "unix", Value: "uid:1000"},
+		{Type: "k8s", Value: "ns:default"},
+		{Type: "k8s", Value: "pod-name:web-1"},
+	}
+	cachedEntries := []*cache.CachedEntry{
+		{
+			Entry: &common.RegistrationEntry{
+				EntryId:  "entry-1",
+				SpiffeId: "spiffe://example.org/plain",
+				Selectors: []*common.Selector{
+					{Type: "unix", Value: "uid:1000"},
+				},
+			},
+		},
+		{
+			Entry: &common.RegistrationEntry{
+				EntryId:  "entry-2",
+				SpiffeId: "spiffe://example.org/expressions",
+				Selectors: []*common.Selector{
+					{Type: "~k8s", Value: "pod-name:web-*"},
+					{Type: "!k8s", Value: "ns:kube-system"},
+				},
+			},
+		},
+		{
+			Entry: &common.RegistrationEntry{
+				EntryId:  "entry-3",
+				SpiffeId: "spiffe://example.org/other-uid",
+				Selectors: []*common.Selector{
+					{Type: "unix", Value: "uid:1000"},
+					{Type: "unix", Value: "gid:1000"},
+				},
+			},
+		},
+		{
+			Entry: &common.RegistrationEntry{
+				EntryId:  "entry-4",
+				SpiffeId: "spiffe://example.org/negated",
+				Selectors: []*common.Selector{
+					{Type: "!k8s", Value: "ns:default"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name       string
+		pid        int32
+		attestErr  error
+		expectCode codes.Code
+		expectMsg  string
+		expectLogs []spiretest.LogEntry
+		expectResp *spiredebug.ExplainWorkloadResponse
+	}{
+		{
+			name:       "missing pid",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "pid must be greater than zero",
+		},
+		{
+			name:       "attestation fails",
+			pid:        1234,
+			attestErr:  errors.New("oh no"),
+			expectCode: codes.Unavailable,
+			expectMsg:  "workload attestation failed: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Workload attestation failed",
+					Data: logrus.Fields{
+						telemetry.PID:   "1234",
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+		{
+			name: "success",
+			pid:  1234,
+			expectResp: &spiredebug.ExplainWorkloadResponse{
+				Selectors: attested,
+				MatchedEntries: []*spiredebug.EntryMatch{
+					{EntryId: "entry-1", SpiffeId: "spiffe://example.org/plain"},
+					{EntryId: "entry-2", SpiffeId: "spiffe://example.org/expressions"},
+				},
+				UnmatchedEntries: []*spiredebug.EntryMatch{
+					{
+						EntryId:  "entry-3",
+						SpiffeId: "spiffe://example.org/other-uid",
+						UnsatisfiedSelectors: []*common.Selector{
+							{Type: "unix", Value: "gid:1000"},
+						},
+					},
+					{
+						EntryId:  "entry-4",
+						SpiffeId: "spiffe://example.org/negated",
+						UnsatisfiedSelectors: []*common.Selector{
+							{Type: "!k8s", Value: "ns:default"},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			// The Unknown marker of a failed attestor is not exposed
+			test.attestor.selectors = append(slices.Clone(attested), &common.Selector{Type: "?docker"})
+			test.attestor.err = tt.attestErr
+			test.m.cachedEntries = cachedEntries
+
+			resp, err := test.extensionClient.ExplainWorkload(ctx, &spiredebug.ExplainWorkloadRequest{
+				Pid: tt.pid,
+			})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			if tt.expectCode != codes.OK {
+				require.Nil(t, resp)
+				return
+			}
+			spiretest.RequireProtoEqual(t, tt.expectResp, resp)
+		})
+	}
+}
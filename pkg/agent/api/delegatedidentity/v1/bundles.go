@@ -0,0 +1,151 @@
+package delegatedidentity
+
+import (
+	"bytes"
+	"context"
+	"maps"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/api/rpccontext"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	spiredelegatedidentity "github.com/spiffe/spire/proto/spire/agent/delegatedidentity"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// extensionServer exposes the SPIRE extensions to the Delegated Identity API.
+// It is a separate type from Service since both APIs have bundle RPCs with the
+// same names but different signatures.
+type extensionServer struct {
+	spiredelegatedidentity.UnimplementedAPIServer
+
+	s *Service
+}
+
+func (e extensionServer) SubscribeToX509Bundles(req *spiredelegatedidentity.SubscribeToX509BundlesRequest, stream spiredelegatedidentity.API_SubscribeToX509BundlesServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+
+	cachedSelectors, err := e.s.isCallerAuthorized(ctx, log, nil)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseTrustDomainFilter(log, req.TrustDomains)
+	if err != nil {
+		return err
+	}
+
+	return streamBundles(ctx, log, e.s, cachedSelectors, filter, e.s.manager.SubscribeToBundleChanges(),
+		func(bundle *cache.Bundle) ([]byte, error) {
+			return marshalBundle(bundle.X509Authorities()), nil
+		},
+		func(bundles map[string][]byte) error {
+			return stream.Send(&spiredelegatedidentity.SubscribeToX509BundlesResponse{CaCertificates: bundles})
+		})
+}
+
+func (e extensionServer) SubscribeToJWTBundles(req *spiredelegatedidentity.SubscribeToJWTBundlesRequest, stream spiredelegatedidentity.API_SubscribeToJWTBundlesServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+
+	cachedSelectors, err := e.s.isCallerAuthorized(ctx, log, nil)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseTrustDomainFilter(log, req.TrustDomains)
+	if err != nil {
+		return err
+	}
+
+	return streamBundles(ctx, log, e.s, cachedSelectors, filter, e.s.manager.SubscribeToBundleChanges(),
+		func(bundle *cache.Bundle) ([]byte, error) {
+			return bundleutil.Marshal(bundle, bundleutil.NoX509SVIDKeys(), bundleutil.StandardJWKS())
+		},
+		func(bundles map[string][]byte) error {
+			return stream.Send(&spiredelegatedidentity.SubscribeToJWTBundlesResponse{Bundles: bundles})
+		})
+}
+
+// parseTrustDomainFilter parses the trust domains requested by the delegate.
+// A nil filter matches all the trust domains.
+func parseTrustDomainFilter(log logrus.FieldLogger, trustDomains []string) (map[spiffeid.TrustDomain]struct{}, error) {
+	if len(trustDomains) == 0 {
+		return nil, nil
+	}
+
+	filter := make(map[spiffeid.TrustDomain]struct{}, len(trustDomains))
+	for _, trustDomain := range trustDomains {
+		td, err := spiffeid.TrustDomainFromString(trustDomain)
+		if err != nil {
+			log.WithField(telemetry.TrustDomainID, trustDomain).WithError(err).Error("Invalid argument; malformed trust domain")
+			return nil, status.Errorf(codes.InvalidArgument, "malformed trust domain %q: %v", trustDomain, err)
+		}
+		filter[td] = struct{}{}
+	}
+	return filter, nil
+}
+
+// bundleStream is implemented by the bundle streams of the agent cache.
+type bundleStream[B any] interface {
+	Value() map[spiffeid.TrustDomain]B
+	Changes() chan struct{}
+	Next() map[spiffeid.TrustDomain]B
+}
+
+// streamBundles sends the marshaled bundles of the trust domains in the
+// filter, and then sends them again each time they change. Changes to bundles
+// of other trust domains do not produce a response.
+func streamBundles[B any](ctx context.Context, log logrus.FieldLogger, s *Service, cachedSelectors []*common.Selector, filter map[spiffeid.TrustDomain]struct{}, subscriber bundleStream[B], marshal func(B) ([]byte, error), send func(map[string][]byte) error) error {
+	var last map[string][]byte
+	sendBundles := func(bundles map[spiffeid.TrustDomain]B) error {
+		// Rebuild the map each time, since the stream returns the full set of
+		// trust domains, and some of them may have been removed.
+		marshaled := make(map[string][]byte, len(bundles))
+		for td, bundle := range bundles {
+			if filter != nil {
+				if _, ok := filter[td]; !ok {
+					continue
+				}
+			}
+			bundleBytes, err := marshal(bundle)
+			if err != nil {
+				log.WithField(telemetry.TrustDomainID, td.IDString()).WithError(err).Error("Could not serialize bundle")
+				return status.Errorf(codes.Internal, "could not serialize bundle: %v", err)
+			}
+			marshaled[td.IDString()] = bundleBytes
+		}
+
+		if last != nil && maps.EqualFunc(last, marshaled, bytes.Equal) {
+			return nil
+		}
+		if err := send(marshaled); err != nil {
+			return err
+		}
+		last = marshaled
+		return nil
+	}
+
+	if err := sendBundles(subscriber.Value()); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-subscriber.Changes():
+			if _, err := s.isCallerAuthorized(ctx, log, cachedSelectors); err != nil {
+				return err
+			}
+			if err := sendBundles(subscriber.Next()); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
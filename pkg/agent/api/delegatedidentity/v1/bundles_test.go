@@ -0,0 +1,261 @@
+package delegatedidentity
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	spiredelegatedidentity "github.com/spiffe/spire/proto/spire/agent/delegatedidentity"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestExtensionSubscribeToX509Bundles(t *testing.T) {
+	ca := testca.New(t, trustDomain1)
+
+	x509SVID1 := ca.CreateX509SVID(id1)
+	rotatedBundle1 := spiffebundle.FromX509Authorities(trustDomain1, []*x509.Certificate{{Raw: []byte("CCC")}})
+
+	for _, tt := range []struct {
+		testName      string
+		authSpiffeID  []string
+		trustDomains  []string
+		attestErr     error
+		bundleUpdates []map[spiffeid.TrustDomain]*cache.Bundle
+		expectCode    codes.Code
+		expectMsg     string
+		expectResp    []*spiredelegatedidentity.SubscribeToX509BundlesResponse
+	}{
+		{
+			testName:   "Attest error",
+			attestErr:  errors.New("ohno"),
+			expectCode: codes.Unavailable,
+			expectMsg:  "workload attestation failed",
+		},
+		{
+			testName:     "Access to \"privileged\" admin API denied",
+			authSpiffeID: []string{"spiffe://example.org/one/wrong"},
+			expectCode:   codes.PermissionDenied,
+			expectMsg:    "caller not configured as an authorized delegate",
+		},
+		{
+			testName:     "malformed trust domain",
+			authSpiffeID: []string{"spiffe://example.org/one"},
+			trustDomains: []string{"Example.org"},
+			expectCode:   codes.InvalidArgument,
+			expectMsg:    `malformed trust domain "Example.org": trust domain characters are limited to lowercase letters, numbers, dots, dashes, and underscores`,
+		},
+		{
+			testName:     "all trust domains",
+			authSpiffeID: []string{"spiffe://example.org/one"},
+			bundleUpdates: []map[spiffeid.TrustDomain]*cache.Bundle{
+				{trustDomain1: bundle1, trustDomain2: bundle2},
+			},
+			expectResp: []*spiredelegatedidentity.SubscribeToX509BundlesResponse{
+				{
+					CaCertificates: map[string][]byte{
+						trustDomain1.IDString(): marshalBundle(bundle1.X509Authorities()),
+					},
+				},
+				{
+					CaCertificates: map[string][]byte{
+						trustDomain1.IDString(): marshalBundle(bundle1.X509Authorities()),
+						trustDomain2.IDString(): marshalBundle(bundle2.X509Authorities()),
+					},
+				},
+			},
+		},
+		{
+			testName:     "filtered trust domains",
+			authSpiffeID: []string{"spiffe://example.org/one"},
+			trustDomains: []string{"spiffe://example.org"},
+			bundleUpdates: []map[spiffeid.TrustDomain]*cache.Bundle{
+				{trustDomain1: bundle1, trustDomain2: bundle2},
+				{trustDomain1: rotatedBundle1, trustDomain2: bundle2},
+			},
+			expectResp: []*spiredelegatedidentity.SubscribeToX509BundlesResponse{
+				{
+					CaCertificates: map[string][]byte{
+						trustDomain1.IDString(): marshalBundle(bundle1.X509Authorities()),
+					},
+				},
+				// The update to the domain.test bundle is not sent
+				{
+					CaCertificates: map[string][]byte{
+						trustDomain1.IDString(): marshalBundle(rotatedBundle1.X509Authorities()),
+					},
+				},
+			},
+		},
+		{
+			testName:     "filtered trust domain without bundle",
+			authSpiffeID: []string{"spiffe://example.org/one"},
+			trustDomains: []string{"domain.test"},
+			bundleUpdates: []map[spiffeid.TrustDomain]*cache.Bundle{
+				{trustDomain1: bundle1, trustDomain2: bundle2},
+			},
+			expectResp: []*spiredelegatedidentity.SubscribeToX509BundlesResponse{
+				{},
+				{
+					CaCertificates: map[string][]byte{
+						trustDomain2.IDString(): marshalBundle(bundle2.X509Authorities()),
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.testName, func(t *testing.T) {
+			params := testParams{
+				CA:            ca,
+				Identities:    []cache.Identity{identityFromX509SVID(x509SVID1)},
+				AuthSpiffeID:  tt.authSpiffeID,
+				AttestErr:     tt.attestErr,
+				CacheUpdates:  map[spiffeid.TrustDomain]*cache.Bundle{trustDomain1: bundle1},
+				BundleUpdates: tt.bundleUpdates,
+			}
+			runExtensionTest(t, params,
+				func(ctx context.Context, client spiredelegatedidentity.APIClient) {
+					stream, err := client.SubscribeToX509Bundles(ctx, &spiredelegatedidentity.SubscribeToX509BundlesRequest{
+						TrustDomains: tt.trustDomains,
+					})
+					require.NoError(t, err)
+
+					if tt.expectCode != codes.OK {
+						_, err := stream.Recv()
+						spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+						return
+					}
+
+					for _, expectResp := range tt.expectResp {
+						resp, err := stream.Recv()
+						require.NoError(t, err)
+						spiretest.RequireProtoEqual(t, expectResp, resp)
+					}
+				})
+		})
+	}
+}
+
+func TestExtensionSubscribeToJWTBundles(t *testing.T) {
+	ca := testca.New(t, trustDomain1)
+
+	x509SVID1 := ca.CreateX509SVID(id1)
+	jwtBundle1 := spiffebundle.FromJWTAuthorities(trustDomain1, map[string]crypto.PublicKey{"kid1": testkey.NewEC256(t).Public()})
+	jwtBundle2 := spiffebundle.FromJWTAuthorities(trustDomain2, map[string]crypto.PublicKey{"kid2": testkey.NewEC256(t).Public()})
+	rotatedJWTBundle2 := spiffebundle.FromJWTAuthorities(trustDomain2, map[string]crypto.PublicKey{"kid3": testkey.NewEC256(t).Public()})
+	jwksJWTBundle1 := marshalJWKS(t, jwtBundle1)
+	jwksJWTBundle2 := marshalJWKS(t, jwtBundle2)
+	jwksRotatedJWTBundle2 := marshalJWKS(t, rotatedJWTBundle2)
+
+	for _, tt := range []struct {
+		testName      string
+		authSpiffeID  []string
+		trustDomains  []string
+		bundleUpdates []map[spiffeid.TrustDomain]*cache.Bundle
+		expectCode    codes.Code
+		expectMsg     string
+		expectResp    []*spiredelegatedidentity.SubscribeToJWTBundlesResponse
+	}{
+		{
+			testName:     "Access to \"privileged\" admin API denied",
+			authSpiffeID: []string{"spiffe://example.org/one/wrong"},
+			expectCode:   codes.PermissionDenied,
+			expectMsg:    "caller not configured as an authorized delegate",
+		},
+		{
+			testName:     "malformed trust domain",
+			authSpiffeID: []string{"spiffe://example.org/one"},
+			trustDomains: []string{"example.org", "Example.org"},
+			expectCode:   codes.InvalidArgument,
+			expectMsg:    `malformed trust domain "Example.org": trust domain characters are limited to lowercase letters, numbers, dots, dashes, and underscores`,
+		},
+		{
+			testName:     "all trust domains",
+			authSpiffeID: []string{"spiffe://example.org/one"},
+			bundleUpdates: []map[spiffeid.TrustDomain]*cache.Bundle{
+				{trustDomain1: jwtBundle1, trustDomain2: jwtBundle2},
+			},
+			expectResp: []*spiredelegatedidentity.SubscribeToJWTBundlesResponse{
+				{
+					Bundles: map[string][]byte{
+						trustDomain1.IDString(): jwksJWTBundle1,
+					},
+				},
+				{
+					Bundles: map[string][]byte{
+						trustDomain1.IDString(): jwksJWTBundle1,
+						trustDomain2.IDString(): jwksJWTBundle2,
+					},
+				},
+			},
+		},
+		{
+			testName:     "filtered trust domains",
+			authSpiffeID: []string{"spiffe://example.org/one"},
+			trustDomains: []string{"domain.test"},
+			bundleUpdates: []map[spiffeid.TrustDomain]*cache.Bundle{
+				{trustDomain1: jwtBundle1, trustDomain2: jwtBundle2},
+				{trustDomain1: jwtBundle1, trustDomain2: jwtBundle2, trustDomain3: jwtBundle1},
+				{trustDomain1: jwtBundle1, trustDomain2: rotatedJWTBundle2},
+			},
+			expectResp: []*spiredelegatedidentity.SubscribeToJWTBundlesResponse{
+				{},
+				{
+					Bundles: map[string][]byte{
+						trustDomain2.IDString(): jwksJWTBundle2,
+					},
+				},
+				// The update adding otherdomain.test is not sent
+				{
+					Bundles: map[string][]byte{
+						trustDomain2.IDString(): jwksRotatedJWTBundle2,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.testName, func(t *testing.T) {
+			params := testParams{
+				CA:            ca,
+				Identities:    []cache.Identity{identityFromX509SVID(x509SVID1)},
+				AuthSpiffeID:  tt.authSpiffeID,
+				CacheUpdates:  map[spiffeid.TrustDomain]*cache.Bundle{trustDomain1: jwtBundle1},
+				BundleUpdates: tt.bundleUpdates,
+			}
+			runExtensionTest(t, params,
+				func(ctx context.Context, client spiredelegatedidentity.APIClient) {
+					stream, err := client.SubscribeToJWTBundles(ctx, &spiredelegatedidentity.SubscribeToJWTBundlesRequest{
+						TrustDomains: tt.trustDomains,
+					})
+					require.NoError(t, err)
+
+					if tt.expectCode != codes.OK {
+						_, err := stream.Recv()
+						spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+						return
+					}
+
+					for _, expectResp := range tt.expectResp {
+						resp, err := stream.Recv()
+						require.NoError(t, err)
+						spiretest.RequireProtoEqual(t, expectResp, resp)
+					}
+				})
+		})
+	}
+}
+
+func marshalJWKS(t *testing.T, bundle *spiffebundle.Bundle) []byte {
+	jwksBytes, err := bundleutil.Marshal(bundle, bundleutil.NoX509SVIDKeys(), bundleutil.StandardJWKS())
+	require.NoError(t, err)
+	return jwksBytes
+}
@@ -20,19 +20,23 @@ import (
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/telemetry/agent/adminapi"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
+	spiredelegatedidentity "github.com/spiffe/spire/proto/spire/agent/delegatedidentity"
 	"github.com/spiffe/spire/proto/spire/common"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-// RegisterService registers the delegated identity service on the provided server
+// RegisterService registers the delegated identity service, along with the
+// SPIRE extensions to it, on the provided server
 func RegisterService(s *grpc.Server, service *Service) {
 	delegatedidentityv1.RegisterDelegatedIdentityServer(s, service)
+	spiredelegatedidentity.RegisterAPIServer(s, extensionServer{s: service})
 }
 
 type attestor interface {
@@ -45,6 +49,9 @@ type Config struct {
 	Manager             manager.Manager
 	Attestor            workloadattestor.Attestor
 	AuthorizedDelegates []string
+
+	// DisableWITSVIDs disables the WIT-SVID and WIT bundle RPCs.
+	DisableWITSVIDs bool
 }
 
 func New(config Config) *Service {
@@ -60,6 +67,7 @@ func New(config Config) *Service {
 		delegateWorkloadAttestor: config.Attestor,
 		metrics:                  config.Metrics,
 		authorizedDelegates:      AuthorizedDelegates,
+		disableWITSVIDs:          config.DisableWITSVIDs,
 	}
 }
 
@@ -74,6 +82,8 @@ type Service struct {
 
 	// SPIFFE IDs of delegates that are authorized to use this API
 	authorizedDelegates map[string]bool
+
+	disableWITSVIDs bool
 }
 
 // isCallerAuthorized attests the caller based on the authorized delegates map.
@@ -89,7 +99,7 @@ func (s *Service) isCallerAuthorized(ctx context.Context, log logrus.FieldLogger
 		}
 	}
 
-	log = log.WithField("delegate_selectors", callerSelectors)
+	log = log.WithField("delegate_selectors", selector.WithoutUnknown(callerSelectors))
 	entries := s.manager.MatchingRegistrationEntries(callerSelectors)
 	numRegisteredEntries := len(entries)
 
@@ -145,6 +155,12 @@ func (s *Service) constructValidSelectorsFromReq(ctx context.Context, log logrus
 			log.WithError(err).Error("Invalid argument; could not parse provided selectors")
 			return nil, status.Error(codes.InvalidArgument, "could not parse provided selectors")
 		}
+		for _, s := range selectors {
+			if selector.IsUnknown(selector.Selector{Type: s.Type, Value: s.Value}) {
+				log.WithField(telemetry.Selector, s.Type).Error("Invalid argument; provided selectors use a reserved type")
+				return nil, status.Errorf(codes.InvalidArgument, "selector type %q uses the reserved prefix %q", s.Type, selector.UnknownPrefix)
+			}
+		}
 	} else {
 		// Delegate authorized, use PID the delegate gave us to try and attest on-behalf-of
 		selectors, err = s.delegateWorkloadAttestor.Attest(ctx, int(reqPid))
@@ -186,8 +202,8 @@ func (s *Service) SubscribeToX509SVIDs(req *delegatedidentityv1.SubscribeToX509S
 	}
 
 	log.WithFields(logrus.Fields{
-		"delegate_selectors": cachedSelectors,
-		"request_selectors":  selectors,
+		"delegate_selectors": selector.WithoutUnknown(cachedSelectors),
+		"request_selectors":  selector.WithoutUnknown(selectors),
 	}).Debug("Subscribing to cache changes")
 
 	subscriber, err := s.manager.SubscribeToCacheChanges(ctx, selectors)
@@ -13,6 +13,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/exp/bundle/witbundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/jwtsvid"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
@@ -27,6 +28,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
+	spiredelegatedidentity "github.com/spiffe/spire/proto/spire/agent/delegatedidentity"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/spiffe/spire/test/spiretest"
@@ -621,6 +623,17 @@ func TestFetchJWTSVIDs(t *testing.T) {
 			expectCode: codes.InvalidArgument,
 			expectMsg:  "could not parse provided selectors",
 		},
+		{
+			testName:     "selectors type is reserved",
+			authSpiffeID: []string{"spiffe://example.org/one"},
+			selectors:    []*types.Selector{{Type: "?k8s", Value: "ns:default"}},
+			audience:     []string{"AUDIENCE"},
+			identities: []cache.Identity{
+				identities[0],
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `selector type "?k8s" uses the reserved prefix "?"`,
+		},
 		{
 			testName:     "delegate workload attest error",
 			authSpiffeID: []string{"spiffe://example.org/one"},
@@ -923,30 +936,49 @@ func TestSubscribeToJWTBundles(t *testing.T) {
 }
 
 type testParams struct {
-	CA           *testca.CA
-	Identities   []cache.Identity
-	Updates      []*cache.WorkloadUpdate
-	CacheUpdates map[spiffeid.TrustDomain]*cache.Bundle
-	JwtSVIDS     map[spiffeid.ID]*client.JWTSVID
-	AuthSpiffeID []string
-	AttestErr    error
-	DelegateErr  error
-	ManagerErr   error
-	Metrics      *fakemetrics.FakeMetrics
+	CA              *testca.CA
+	Identities      []cache.Identity
+	Updates         []*cache.WorkloadUpdate
+	CacheUpdates    map[spiffeid.TrustDomain]*cache.Bundle
+	BundleUpdates   []map[spiffeid.TrustDomain]*cache.Bundle
+	JwtSVIDS        map[spiffeid.ID]*client.JWTSVID
+	WITSVIDs        map[spiffeid.ID]*cache.WITSVID
+	WITBundles      []map[spiffeid.TrustDomain]*witbundle.Bundle
+	AuthSpiffeID    []string
+	AttestErr       error
+	DelegateErr     error
+	ManagerErr      error
+	Metrics         *fakemetrics.FakeMetrics
+	DisableWITSVIDs bool
 }
 
 func runTest(t *testing.T, params testParams, fn func(ctx context.Context, client delegatedidentityv1.DelegatedIdentityClient)) {
+	runTestWithConn(t, params, func(ctx context.Context, conn *grpc.ClientConn) {
+		fn(ctx, delegatedidentityv1.NewDelegatedIdentityClient(conn))
+	})
+}
+
+func runExtensionTest(t *testing.T, params testParams, fn func(ctx context.Context, client spiredelegatedidentity.APIClient)) {
+	runTestWithConn(t, params, func(ctx context.Context, conn *grpc.ClientConn) {
+		fn(ctx, spiredelegatedidentity.NewAPIClient(conn))
+	})
+}
+
+func runTestWithConn(t *testing.T, params testParams, fn func(ctx context.Context, conn *grpc.ClientConn)) {
 	log, _ := test.NewNullLogger()
 	log.Level = logrus.DebugLevel
 
 	manager := &FakeManager{
-		Manager:     nil,
-		ca:          params.CA,
-		identities:  params.Identities,
-		updates:     params.Updates,
-		cacheUpdate: params.CacheUpdates,
-		jwtSVIDs:    params.JwtSVIDS,
-		err:         params.ManagerErr,
+		Manager:       nil,
+		ca:            params.CA,
+		identities:    params.Identities,
+		updates:       params.Updates,
+		cacheUpdate:   params.CacheUpdates,
+		bundleUpdates: params.BundleUpdates,
+		jwtSVIDs:      params.JwtSVIDS,
+		witSVIDs:      params.WITSVIDs,
+		witBundles:    params.WITBundles,
+		err:           params.ManagerErr,
 	}
 
 	service := New(Config{
@@ -954,6 +986,7 @@ func runTest(t *testing.T, params testParams, fn func(ctx context.Context, clien
 		Manager:             manager,
 		Metrics:             params.Metrics,
 		AuthorizedDelegates: params.AuthSpiffeID,
+		DisableWITSVIDs:     params.DisableWITSVIDs,
 	})
 
 	service.peerAttestor = FakeAttestor{
@@ -970,7 +1003,7 @@ func runTest(t *testing.T, params testParams, fn func(ctx context.Context, clien
 		grpc.StreamInterceptor(streamInterceptor),
 	)
 
-	delegatedidentityv1.RegisterDelegatedIdentityServer(server, service)
+	RegisterService(server, service)
 	addr := spiretest.ServeGRPCServerOnTempUDSSocket(t, server)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
@@ -978,7 +1011,7 @@ func runTest(t *testing.T, params testParams, fn func(ctx context.Context, clien
 	conn, _ := grpc.NewClient("unix:"+addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
 	t.Cleanup(func() { conn.Close() })
 
-	fn(ctx, delegatedidentityv1.NewDelegatedIdentityClient(conn))
+	fn(ctx, conn)
 	cancel()
 	server.GracefulStop()
 }
@@ -1014,6 +1047,12 @@ type FakeManager struct {
 	updates     []*cache.WorkloadUpdate
 	cacheUpdate map[spiffeid.TrustDomain]*cache.Bundle
 
+	// bundleUpdates and witBundles are applied in order to the bundle
+	// caches after the subscription.
+	bundleUpdates []map[spiffeid.TrustDomain]*cache.Bundle
+	witSVIDs      map[spiffeid.ID]*cache.WITSVID
+	witBundles    []map[spiffeid.TrustDomain]*witbundle.Bundle
+
 	subscribers atomic.Int32
 	err         error
 }
@@ -1126,7 +1165,42 @@ func (m *FakeManager) SubscribeToBundleChanges() *cache.BundleStream {
 	myCache := newTestCache()
 	myCache.BundleCache.Update(m.cacheUpdate)
 
-	return myCache.BundleCache.SubscribeToBundleChanges()
+	stream := myCache.BundleCache.SubscribeToBundleChanges()
+	for _, update := range m.bundleUpdates {
+		myCache.BundleCache.Update(update)
+	}
+	return stream
+}
+
+func (m *FakeManager) FetchWITSVID(_ context.Context, entry *common.RegistrationEntry) (*cache.WITSVID, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	spiffeID, err := spiffeid.FromString(entry.SpiffeId)
+	if err != nil {
+		return nil, err
+	}
+
+	svid, ok := m.witSVIDs[spiffeID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return svid, nil
+}
+
+func (m *FakeManager) SubscribeToWITBundleChanges() *cache.WITBundleStream {
+	witBundleCache := cache.NewWITBundleCache()
+	if len(m.witBundles) == 0 {
+		return witBundleCache.SubscribeToWITBundleChanges()
+	}
+
+	witBundleCache.Update(m.witBundles[0])
+	stream := witBundleCache.SubscribeToWITBundleChanges()
+	for _, update := range m.witBundles[1:] {
+		witBundleCache.Update(update)
+	}
+	return stream
 }
 
 func newTestCache() *cache.LRUCache {
@@ -0,0 +1,114 @@
+package delegatedidentity
+
+import (
+	"context"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/exp/bundle/witbundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/agent/api/rpccontext"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	spiredelegatedidentity "github.com/spiffe/spire/proto/spire/agent/delegatedidentity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FetchWITSVIDs attests and authorizes the delegate, and then returns the
+// WIT-SVIDs for the selectors, or the PID, in the request, with the same
+// caveats as FetchJWTSVIDs.
+func (e extensionServer) FetchWITSVIDs(ctx context.Context, req *spiredelegatedidentity.FetchWITSVIDsRequest) (*spiredelegatedidentity.FetchWITSVIDsResponse, error) {
+	log := rpccontext.Logger(ctx)
+	if e.s.disableWITSVIDs {
+		return nil, status.Error(codes.Unimplemented, "WIT functionality is disabled")
+	}
+
+	if _, err := e.s.isCallerAuthorized(ctx, log, nil); err != nil {
+		return nil, err
+	}
+
+	reqSelectors := make([]*types.Selector, 0, len(req.Selectors))
+	for _, selector := range req.Selectors {
+		reqSelectors = append(reqSelectors, &types.Selector{Type: selector.Type, Value: selector.Value})
+	}
+
+	selectors, err := e.s.constructValidSelectorsFromReq(ctx, log, req.Pid, reqSelectors)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(spiredelegatedidentity.FetchWITSVIDsResponse)
+
+	entries := e.s.manager.MatchingRegistrationEntries(selectors)
+	for _, entry := range entries {
+		// Do not send admin nor downstream SVIDs to the caller
+		if entry.Admin || entry.Downstream {
+			continue
+		}
+
+		spiffeID, err := spiffeid.FromString(entry.SpiffeId)
+		if err != nil {
+			log.WithField(telemetry.SPIFFEID, entry.SpiffeId).WithError(err).Error("Invalid requested SPIFFE ID")
+			return nil, status.Errorf(codes.InvalidArgument, "invalid requested SPIFFE ID: %v", err)
+		}
+
+		loopLog := log.WithField(telemetry.SPIFFEID, spiffeID.String())
+
+		svid, err := e.s.manager.FetchWITSVID(ctx, entry)
+		if err != nil {
+			loopLog.WithError(err).Error("Could not fetch WIT-SVID")
+			return nil, status.Errorf(codes.Unavailable, "could not fetch WIT-SVID: %v", err)
+		}
+
+		keyData, err := svid.MarshalKey()
+		if err != nil {
+			loopLog.WithError(err).Error("Could not serialize WIT-SVID key")
+			return nil, status.Errorf(codes.Internal, "could not serialize response: %v", err)
+		}
+
+		resp.Svids = append(resp.Svids, &spiredelegatedidentity.WITSVID{
+			SpiffeId:   spiffeID.String(),
+			WitSvid:    svid.SVID.Token,
+			WitSvidKey: keyData,
+			ExpiresAt:  svid.SVID.ExpiresAt.Unix(),
+			IssuedAt:   svid.SVID.IssuedAt.Unix(),
+			Hint:       entry.Hint,
+		})
+
+		ttl := time.Until(svid.SVID.ExpiresAt)
+		loopLog.WithField(telemetry.TTL, ttl.Seconds()).Debug("Fetched WIT SVID")
+	}
+
+	if len(resp.Svids) == 0 {
+		logNoIdentityIssued(ctx, log)
+		return nil, status.Error(codes.PermissionDenied, "no identity issued")
+	}
+
+	return resp, nil
+}
+
+func (e extensionServer) SubscribeToWITBundles(req *spiredelegatedidentity.SubscribeToWITBundlesRequest, stream spiredelegatedidentity.API_SubscribeToWITBundlesServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+	if e.s.disableWITSVIDs {
+		return status.Error(codes.Unimplemented, "WIT functionality is disabled")
+	}
+
+	cachedSelectors, err := e.s.isCallerAuthorized(ctx, log, nil)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseTrustDomainFilter(log, req.TrustDomains)
+	if err != nil {
+		return err
+	}
+
+	return streamBundles(ctx, log, e.s, cachedSelectors, filter, e.s.manager.SubscribeToWITBundleChanges(),
+		func(bundle *witbundle.Bundle) ([]byte, error) {
+			return bundle.Marshal()
+		},
+		func(bundles map[string][]byte) error {
+			return stream.Send(&spiredelegatedidentity.SubscribeToWITBundlesResponse{Bundles: bundles})
+		})
+}
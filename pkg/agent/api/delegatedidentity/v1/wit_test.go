@@ -0,0 +1,314 @@
+package delegatedidentity
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/spiffe/go-spiffe/v2/exp/bundle/witbundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	spiredelegatedidentity "github.com/spiffe/spire/proto/spire/agent/delegatedidentity"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestFetchWITSVIDs(t *testing.T) {
+	ca := testca.New(t, trustDomain1)
+	x509SVID1 := ca.CreateX509SVID(id1)
+	x509SVID2 := ca.CreateX509SVID(id2)
+
+	key := testkey.NewEC256(t)
+	now := time.Now().Truncate(time.Second)
+	witSVIDs := map[spiffeid.ID]*cache.WITSVID{
+		id1: {
+			SVID:             &client.WITSVID{Token: "token-one", IssuedAt: now, ExpiresAt: now.Add(time.Hour)},
+			PrivateKey:       key,
+			SigningAlgorithm: "ES256",
+		},
+		id2: {
+			SVID:             &client.WITSVID{Token: "token-two", IssuedAt: now, ExpiresAt: now.Add(2 * time.Hour)},
+			PrivateKey:       key,
+			SigningAlgorithm: "ES256",
+		},
+	}
+	keyData, err := (&jose.JSONWebKey{Key: key, Algorithm: "ES256"}).MarshalJSON()
+	require.NoError(t, err)
+
+	identity2 := identityFromX509SVID(x509SVID2)
+	identity2.Entry.Hint = "internal"
+	adminIdentity := identityFromX509SVID(ca.CreateX509SVID(spiffeid.RequireFromPath(trustDomain1, "/admin")))
+	adminIdentity.Entry.Admin = true
+
+	selectors := []*common.Selector{{Type: "sa", Value: "foo"}}
+
+	for _, tt := range []struct {
+		testName        string
+		disableWITSVIDs bool
+		identities      []cache.Identity
+		authSpiffeID    []string
+		selectors       []*common.Selector
+		pid             int32
+		attestErr       error
+		delegateErr     error
+		managerErr      error
+		expectCode      codes.Code
+		expectMsg       string
+		expectSVIDs     []*spiredelegatedidentity.WITSVID
+	}{
+		{
+			testName:        "WIT disabled",
+			disableWITSVIDs: true,
+			identities:      []cache.Identity{identityFromX509SVID(x509SVID1)},
+			authSpiffeID:    []string{id1.String()},
+			selectors:       selectors,
+			expectCode:      codes.Unimplemented,
+			expectMsg:       "WIT functionality is disabled",
+		},
+		{
+			testName:   "attest error",
+			attestErr:  errors.New("ohno"),
+			selectors:  selectors,
+			expectCode: codes.Unavailable,
+			expectMsg:  "workload attestation failed",
+		},
+		{
+			testName:     "access to \"privileged\" admin API denied",
+			identities:   []cache.Identity{identityFromX509SVID(x509SVID1)},
+			authSpiffeID: []string{"spiffe://example.org/one/wrong"},
+			selectors:    selectors,
+			expectCode:   codes.PermissionDenied,
+			expectMsg:    "caller not configured as an authorized delegate",
+		},
+		{
+			testName:     "both selectors and PID",
+			identities:   []cache.Identity{identityFromX509SVID(x509SVID1)},
+			authSpiffeID: []string{id1.String()},
+			selectors:    selectors,
+			pid:          447,
+			expectCode:   codes.InvalidArgument,
+			expectMsg:    "must provide either selectors or non-zero PID, but not both",
+		},
+		{
+			testName:     "delegate attest error",
+			identities:   []cache.Identity{identityFromX509SVID(x509SVID1)},
+			authSpiffeID: []string{id1.String()},
+			pid:          447,
+			delegateErr:  errors.New("ohno"),
+			expectCode:   codes.Unavailable,
+			expectMsg:    "workload attestation failed",
+		},
+		{
+			testName:     "fetch error",
+			identities:   []cache.Identity{identityFromX509SVID(x509SVID1)},
+			authSpiffeID: []string{id1.String()},
+			selectors:    selectors,
+			managerErr:   errors.New("ohno"),
+			expectCode:   codes.Unavailable,
+			expectMsg:    "could not fetch WIT-SVID: ohno",
+		},
+		{
+			testName:     "no identity issued",
+			identities:   []cache.Identity{adminIdentity},
+			authSpiffeID: []string{adminIdentity.Entry.SpiffeId},
+			selectors:    selectors,
+			expectCode:   codes.PermissionDenied,
+			expectMsg:    "no identity issued",
+		},
+		{
+			testName:     "success with selectors",
+			identities:   []cache.Identity{identityFromX509SVID(x509SVID1), identity2, adminIdentity},
+			authSpiffeID: []string{id1.String()},
+			selectors:    selectors,
+			expectSVIDs: []*spiredelegatedidentity.WITSVID{
+				{
+					SpiffeId:   id1.String(),
+					WitSvid:    "token-one",
+					WitSvidKey: string(keyData),
+					ExpiresAt:  now.Add(time.Hour).Unix(),
+					IssuedAt:   now.Unix(),
+				},
+				{
+					SpiffeId:   id2.String(),
+					WitSvid:    "token-two",
+					WitSvidKey: string(keyData),
+					ExpiresAt:  now.Add(2 * time.Hour).Unix(),
+					IssuedAt:   now.Unix(),
+					Hint:       "internal",
+				},
+			},
+		},
+		{
+			testName:     "success with PID",
+			identities:   []cache.Identity{identityFromX509SVID(x509SVID1)},
+			authSpiffeID: []string{id1.String()},
+			pid:          447,
+			expectSVIDs: []*spiredelegatedidentity.WITSVID{
+				{
+					SpiffeId:   id1.String(),
+					WitSvid:    "token-one",
+					WitSvidKey: string(keyData),
+					ExpiresAt:  now.Add(time.Hour).Unix(),
+					IssuedAt:   now.Unix(),
+				},
+			},
+		},
+	} {
+		t.Run(tt.testName, func(t *testing.T) {
+			params := testParams{
+				CA:              ca,
+				Identities:      tt.identities,
+				AuthSpiffeID:    tt.authSpiffeID,
+				AttestErr:       tt.attestErr,
+				DelegateErr:     tt.delegateErr,
+				ManagerErr:      tt.managerErr,
+				WITSVIDs:        witSVIDs,
+				DisableWITSVIDs: tt.disableWITSVIDs,
+			}
+			runExtensionTest(t, params,
+				func(ctx context.Context, client spiredelegatedidentity.APIClient) {
+					resp, err := client.FetchWITSVIDs(ctx, &spiredelegatedidentity.FetchWITSVIDsRequest{
+						Selectors: tt.selectors,
+						Pid:       tt.pid,
+					})
+					spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+					if tt.expectCode != codes.OK {
+						require.Nil(t, resp)
+						return
+					}
+					spiretest.RequireProtoListEqual(t, tt.expectSVIDs, resp.Svids)
+				})
+		})
+	}
+}
+
+func TestSubscribeToWITBundles(t *testing.T) {
+	ca := testca.New(t, trustDomain1)
+	x509SVID1 := ca.CreateX509SVID(id1)
+
+	witBundle1 := witbundle.FromWITAuthorities(trustDomain1, map[string]crypto.PublicKey{"kid1": testkey.NewEC256(t).Public()})
+	witBundle2 := witbundle.FromWITAuthorities(trustDomain2, map[string]crypto.PublicKey{"kid2": testkey.NewEC256(t).Public()})
+	rotatedWITBundle2 := witbundle.FromWITAuthorities(trustDomain2, map[string]crypto.PublicKey{"kid3": testkey.NewEC256(t).Public()})
+	witJWKS1 := marshalWITBundle(t, witBundle1)
+	witJWKS2 := marshalWITBundle(t, witBundle2)
+	rotatedWITJWKS2 := marshalWITBundle(t, rotatedWITBundle2)
+
+	for _, tt := range []struct {
+		testName        string
+		disableWITSVIDs bool
+		authSpiffeID    []string
+		trustDomains    []string
+		witBundles      []map[spiffeid.TrustDomain]*witbundle.Bundle
+		expectCode      codes.Code
+		expectMsg       string
+		expectResp      []*spiredelegatedidentity.SubscribeToWITBundlesResponse
+	}{
+		{
+			testName:        "WIT disabled",
+			disableWITSVIDs: true,
+			authSpiffeID:    []string{id1.String()},
+			expectCode:      codes.Unimplemented,
+			expectMsg:       "WIT functionality is disabled",
+		},
+		{
+			testName:     "access to \"privileged\" admin API denied",
+			authSpiffeID: []string{"spiffe://example.org/one/wrong"},
+			expectCode:   codes.PermissionDenied,
+			expectMsg:    "caller not configured as an authorized delegate",
+		},
+		{
+			testName:     "malformed trust domain",
+			authSpiffeID: []string{id1.String()},
+			trustDomains: []string{"Example.org"},
+			expectCode:   codes.InvalidArgument,
+			expectMsg:    `malformed trust domain "Example.org": trust domain characters are limited to lowercase letters, numbers, dots, dashes, and underscores`,
+		},
+		{
+			testName:     "all trust domains",
+			authSpiffeID: []string{id1.String()},
+			witBundles: []map[spiffeid.TrustDomain]*witbundle.Bundle{
+				{trustDomain1: witBundle1},
+				{trustDomain1: witBundle1, trustDomain2: witBundle2},
+			},
+			expectResp: []*spiredelegatedidentity.SubscribeToWITBundlesResponse{
+				{
+					Bundles: map[string][]byte{
+						trustDomain1.IDString(): witJWKS1,
+					},
+				},
+				{
+					Bundles: map[string][]byte{
+						trustDomain1.IDString(): witJWKS1,
+						trustDomain2.IDString(): witJWKS2,
+					},
+				},
+			},
+		},
+		{
+			testName:     "filtered trust domains",
+			authSpiffeID: []string{id1.String()},
+			trustDomains: []string{"domain.test"},
+			witBundles: []map[spiffeid.TrustDomain]*witbundle.Bundle{
+				{trustDomain2: witBundle2},
+				{trustDomain1: witBundle1, trustDomain2: witBundle2},
+				{trustDomain1: witBundle1, trustDomain2: rotatedWITBundle2},
+			},
+			expectResp: []*spiredelegatedidentity.SubscribeToWITBundlesResponse{
+				{
+					Bundles: map[string][]byte{
+						trustDomain2.IDString(): witJWKS2,
+					},
+				},
+				// The update adding example.org is not sent
+				{
+					Bundles: map[string][]byte{
+						trustDomain2.IDString(): rotatedWITJWKS2,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.testName, func(t *testing.T) {
+			params := testParams{
+				CA:              ca,
+				Identities:      []cache.Identity{identityFromX509SVID(x509SVID1)},
+				AuthSpiffeID:    tt.authSpiffeID,
+				WITBundles:      tt.witBundles,
+				DisableWITSVIDs: tt.disableWITSVIDs,
+			}
+			runExtensionTest(t, params,
+				func(ctx context.Context, client spiredelegatedidentity.APIClient) {
+					stream, err := client.SubscribeToWITBundles(ctx, &spiredelegatedidentity.SubscribeToWITBundlesRequest{
+						TrustDomains: tt.trustDomains,
+					})
+					require.NoError(t, err)
+
+					if tt.expectCode != codes.OK {
+						_, err := stream.Recv()
+						spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+						return
+					}
+
+					for _, expectResp := range tt.expectResp {
+						resp, err := stream.Recv()
+						require.NoError(t, err)
+						spiretest.RequireProtoEqual(t, expectResp, resp)
+					}
+				})
+		})
+	}
+}
+
+func marshalWITBundle(t *testing.T, bundle *witbundle.Bundle) []byte {
+	jwksBytes, err := bundle.Marshal()
+	require.NoError(t, err)
+	return jwksBytes
+}
@@ -69,6 +69,7 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 func (e *Endpoints) registerDebugAPI(server *grpc.Server) {
 	clk := clock.New()
 	service := debugv1.New(debugv1.Config{
+		Attestor:    e.c.Attestor,
 		Clock:       clk,
 		Log:         e.c.Log.WithField(telemetry.SubsystemName, telemetry.DebugAPI),
 		Manager:     e.c.Manager,
@@ -94,6 +95,7 @@ func (e *Endpoints) registerDelegatedIdentityAPI(server *grpc.Server) {
 		AuthorizedDelegates: e.c.AuthorizedDelegates,
 		Metrics:             e.c.Metrics,
 		Log:                 e.c.Log.WithField(telemetry.SubsystemName, telemetry.DelegatedIdentityAPI),
+		DisableWITSVIDs:     e.c.DisableWITSVIDs,
 	})
 
 	delegatedidentityv1.RegisterService(server, service)
@@ -10,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_workload "github.com/spiffe/spire/pkg/common/telemetry/agent/workloadapi"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -52,7 +53,9 @@ type Config struct {
 
 // Attest invokes all workload attestor plugins against the provided PID. If some
 // attestors fail, the errors are logged and selectors from the failing plugins
-// are discarded. If all attestors fail, the combined error is returned.
+// are discarded. If all attestors fail, the combined error is returned. The
+// selectors hold Unknown markers for entry matching (see attest), which
+// callers remove with selector.WithoutUnknown before exposing the selectors.
 func (wla *attestor) Attest(ctx context.Context, pid int) ([]*common.Selector, error) {
 	log := wla.c.Log.WithField(telemetry.PID, pid)
 
@@ -67,7 +70,7 @@ func (wla *attestor) Attest(ctx context.Context, pid int) ([]*common.Selector, e
 			return nil, fmt.Errorf("workload attestor %q failed: %w", a.Name(), err)
 		}
 		return selectors, nil
-	}, nil, nil)
+	}, nil, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -77,12 +80,18 @@ func (wla *attestor) Attest(ctx context.Context, pid int) ([]*common.Selector, e
 	// hard-to-filter details if we're not careful (e.g. issue #1537). Only log
 	// if it is not the agent itself.
 	if pid != os.Getpid() {
-		log.WithField(telemetry.Selectors, selectors).Debug("PID attested to have selectors")
+		log.WithField(telemetry.Selectors, selector.WithoutUnknown(selectors)).Debug("PID attested to have selectors")
 	}
 
 	return selectors, nil
 }
 
+// AttestReference invokes all workload attestor plugins against the provided
+// workload reference. Plugins that do not support the reference are skipped,
+// and other failures are tolerated as for Attest, except for PermissionDenied:
+// a plugin denying the caller access to the referenced workload fails the
+// whole attestation, so that the caller is not handed the selectors that other
+// plugins produced for that same workload.
 func (wla *attestor) AttestReference(ctx context.Context, reference *anypb.Any) ([]*common.Selector, error) {
 	log := wla.c.Log.WithField(telemetry.ReferenceType, reference.GetTypeUrl())
 	selectors, err := wla.attest(ctx, func(a workloadattestor.WorkloadAttestor) ([]*common.Selector, error) {
@@ -101,15 +110,22 @@ func (wla *attestor) AttestReference(ctx context.Context, reference *anypb.Any)
 			return nil, fmt.Errorf("workload attestor %q failed: %w", a.Name(), err)
 		}
 		return selectors, nil
-	}, errReferenceUnsupported, status.Error(codes.Unimplemented, "no workload attestor handled reference"))
+	}, errReferenceUnsupported, status.Error(codes.Unimplemented, "no workload attestor handled reference"), isPermissionDenied)
 	if err != nil {
 		return nil, err
 	}
-	log.WithField(telemetry.Selectors, selectors).Debug("Reference attested to have selectors")
+	log.WithField(telemetry.Selectors, selector.WithoutUnknown(selectors)).Debug("Reference attested to have selectors")
 	return selectors, nil
 }
 
-func (wla *attestor) attest(ctx context.Context, attestFunc func(attestor workloadattestor.WorkloadAttestor) ([]*common.Selector, error), skippableErr error, allSkippedErr error) (_ []*common.Selector, retErr error) {
+// attest runs attestFunc against every workload attestor plugin and combines
+// the selectors. Errors matching skippableErr are ignored, and allSkippedErr
+// is returned when every plugin was skipped. An error for which isFatal
+// returns true fails the attestation regardless of the other plugins. For
+// every plugin that failed or was skipped, an Unknown marker of its selector
+// type is added to the selectors so that negated entry selectors on that type
+// do not match.
+func (wla *attestor) attest(ctx context.Context, attestFunc func(attestor workloadattestor.WorkloadAttestor) ([]*common.Selector, error), skippableErr error, allSkippedErr error, isFatal func(error) bool) (_ []*common.Selector, retErr error) {
 	counter := telemetry_workload.StartAttestationCall(wla.c.Metrics)
 	defer counter.Done(&retErr)
 
@@ -119,7 +135,7 @@ func (wla *attestor) attest(ctx context.Context, attestFunc func(attestor worklo
 	// wg.Wait() below, this guarantees plugin-level logs are flushed before
 	// we return to the caller.
 	sChan := make(chan []*common.Selector, len(plugins))
-	errChan := make(chan error, len(plugins))
+	errChan := make(chan pluginErr, len(plugins))
 
 	var wg sync.WaitGroup
 	for _, p := range plugins {
@@ -127,7 +143,7 @@ func (wla *attestor) attest(ctx context.Context, attestFunc func(attestor worklo
 			if selectors, err := attestFunc(p); err == nil {
 				sChan <- selectors
 			} else {
-				errChan <- err
+				errChan <- pluginErr{name: p.Name(), err: err}
 			}
 		})
 	}
@@ -135,6 +151,7 @@ func (wla *attestor) attest(ctx context.Context, attestFunc func(attestor worklo
 
 	// Collect the results
 	selectors := []*common.Selector{}
+	var unknown []*common.Selector
 	successes := 0
 	skipped := 0
 	var errs []error
@@ -144,16 +161,20 @@ func (wla *attestor) attest(ctx context.Context, attestFunc func(attestor worklo
 			successes++
 			selectors = append(selectors, s...)
 			wla.c.selectorHook(selectors)
-		case err := <-errChan:
+		case pe := <-errChan:
 			if ctx.Err() != nil {
 				wla.c.Log.WithError(ctx.Err()).Error("Timed out collecting selectors")
 				return nil, ctx.Err()
 			}
-			if skippableErr != nil && errors.Is(err, skippableErr) {
+			unknown = append(unknown, unknownSelector(pe.name))
+			if skippableErr != nil && errors.Is(pe.err, skippableErr) {
 				skipped++
 				continue
 			}
-			errs = append(errs, err)
+			if isFatal != nil && isFatal(pe.err) {
+				return nil, pe.err
+			}
+			errs = append(errs, pe.err)
 		case <-ctx.Done():
 			wla.c.Log.WithError(ctx.Err()).Error("Timed out collecting selectors")
 			return nil, ctx.Err()
@@ -174,5 +195,19 @@ func (wla *attestor) attest(ctx context.Context, attestFunc func(attestor worklo
 	}
 
 	telemetry_workload.AddDiscoveredSelectorsSample(wla.c.Metrics, float32(len(selectors)))
-	return selectors, nil
+	return append(selectors, unknown...), nil
+}
+
+type pluginErr struct {
+	name string
+	err  error
+}
+
+func unknownSelector(selectorType string) *common.Selector {
+	s := selector.Unknown(selectorType)
+	return &common.Selector{Type: s.Type, Value: s.Value}
+}
+
+func isPermissionDenied(err error) bool {
+	return status.Code(err) == codes.PermissionDenied
 }
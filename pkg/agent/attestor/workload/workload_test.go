@@ -84,10 +84,10 @@ func (s *WorkloadAttestorTestSuite) TestAttestWorkload() {
 	s.Nil(err)
 	spiretest.AssertProtoListEqual(s.T(), selectors1, selectors)
 
-	// attestor2 has selectors, attestor1 fails
+	// attestor2 has selectors, attestor1 fails and its type is unknown
 	selectors, err = s.attestor.Attest(ctx, 3)
 	s.Nil(err)
-	spiretest.AssertProtoListEqual(s.T(), selectors2, selectors)
+	spiretest.AssertProtoListEqual(s.T(), append(slices.Clone(selectors2), &common.Selector{Type: "?fake1"}), selectors)
 
 	// both have selectors
 	selectors, err = s.attestor.Attest(ctx, 4)
@@ -114,7 +114,7 @@ func (s *WorkloadAttestorTestSuite) TestAttestLogsOnPartialFailure() {
 
 	selectors, err := s.attestor.Attest(ctx, 3)
 	s.Nil(err)
-	spiretest.AssertProtoListEqual(s.T(), selectors2, selectors)
+	spiretest.AssertProtoListEqual(s.T(), append(slices.Clone(selectors2), &common.Selector{Type: "?fake1"}), selectors)
 	spiretest.AssertLogsAnyOrder(s.T(), s.loggerHook.AllEntries(), []spiretest.LogEntry{
 		{
 			Level:   logrus.ErrorLevel,
@@ -142,12 +142,25 @@ func (s *WorkloadAttestorTestSuite) TestAttestReferenceSkipsUnsupportedAttestors
 
 	selectors, err := s.attestor.AttestReference(ctx, &anypb.Any{TypeUrl: "type.googleapis.com/example.Reference"})
 	s.Require().NoError(err)
-	spiretest.AssertProtoListEqual(s.T(), selectors1, selectors)
+	spiretest.AssertProtoListEqual(s.T(), append(slices.Clone(selectors1), &common.Selector{Type: "?unix"}), selectors)
 	for _, entry := range s.loggerHook.AllEntries() {
 		s.NotEqual(logrus.ErrorLevel, entry.Level)
 	}
 }
 
+func (s *WorkloadAttestorTestSuite) TestAttestReferenceFailsWhenAnyAttestorDeniesAccess() {
+	s.catalog.SetWorkloadAttestors(
+		&referenceWorkloadAttestor{name: "systemd", selectors: selectors1},
+		&referenceWorkloadAttestor{name: "unix", err: status.Error(codes.PermissionDenied, "process 1 runs as a privileged user")},
+	)
+
+	selectors, err := s.attestor.AttestReference(ctx, &anypb.Any{TypeUrl: "type.googleapis.com/example.Reference"})
+	s.Require().Error(err)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.ErrorContains(err, `workload attestor "unix" failed: rpc error: code = PermissionDenied desc = process 1 runs as a privileged user`)
+	s.Empty(selectors)
+}
+
 func (s *WorkloadAttestorTestSuite) TestAttestReferenceReturnsUnimplementedWhenNoAttestorHandlesReference() {
 	s.catalog.SetWorkloadAttestors(
 		&referenceWorkloadAttestor{name: "unix", err: status.Error(codes.Unimplemented, "AttestReference not implemented")},
@@ -0,0 +1,310 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/exp/proto/spiffe/broker"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/api/rpccontext"
+	"github.com/spiffe/spire/pkg/agent/broker/brokercontext"
+	"github.com/spiffe/spire/pkg/agent/common/hintsfilter"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/agent/adminapi"
+	spirebroker "github.com/spiffe/spire/proto/spire/agent/broker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// extensionServer exposes the SPIRE extensions to the SPIFFE Broker API. It
+// is a separate type from Service since both generated APIs are named API
+// and their Unimplemented server types cannot be embedded side by side.
+type extensionServer struct {
+	spirebroker.UnimplementedAPIServer
+
+	s *Service
+}
+
+func (e extensionServer) SubscribeToX509SVIDs(stream spirebroker.API_SubscribeToX509SVIDsServer) error {
+	return e.s.SubscribeToX509SVIDs(stream)
+}
+
+// batchSubscription tracks a single workload reference subscribed on a
+// SubscribeToX509SVIDs stream.
+type batchSubscription struct {
+	id  string
+	log logrus.FieldLogger
+
+	// cancel aborts the attestation and cache subscription while they are
+	// still in progress.
+	cancel context.CancelFunc
+
+	// subscriber is nil until the reference has been attested and
+	// subscribed to cache changes.
+	subscriber cache.Subscriber
+
+	latency             *telemetry.Latency
+	receivedFirstUpdate bool
+}
+
+func (sub *batchSubscription) finish() {
+	sub.cancel()
+	if sub.subscriber != nil {
+		sub.subscriber.Finish()
+	}
+}
+
+type batchSubscribeResult struct {
+	sub        *batchSubscription
+	subscriber cache.Subscriber
+	err        error
+}
+
+// The first cases of the select in SubscribeToX509SVIDs are fixed. The cases
+// for the update channel of each subscribed reference follow them.
+const (
+	batchCaseDone = iota
+	batchCaseRequest
+	batchCaseRecvErr
+	batchCaseSubscribed
+	batchCaseFirstUpdate
+)
+
+// SubscribeToX509SVIDs serves X.509-SVID updates for many workload references
+// over a single stream. A single goroutine waits on the update channels of
+// every subscribed reference. Attestation and cache subscription, which may
+// block, happen in short lived goroutines per reference so that a slow
+// reference does not delay updates for the rest.
+func (s *Service) SubscribeToX509SVIDs(stream spirebroker.API_SubscribeToX509SVIDsServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+
+	peer, err := s.getCallerContext(ctx)
+	if err != nil {
+		return err
+	}
+	log = log.WithField("broker_peer", peer.String())
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	reqch := make(chan *spirebroker.SubscribeToX509SVIDsRequest, 1)
+	errch := make(chan error, 1)
+	resultch := make(chan batchSubscribeResult)
+
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if status.Code(err) == codes.Canceled || errors.Is(err, io.EOF) {
+					err = nil
+				}
+				errch <- err
+				return
+			}
+			select {
+			case reqch <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	subs := make(map[string]*batchSubscription)
+	defer func() {
+		for _, sub := range subs {
+			sub.finish()
+		}
+	}()
+
+	cases := []reflect.SelectCase{
+		batchCaseDone:       {Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		batchCaseRequest:    {Dir: reflect.SelectRecv, Chan: reflect.ValueOf(reqch)},
+		batchCaseRecvErr:    {Dir: reflect.SelectRecv, Chan: reflect.ValueOf(errch)},
+		batchCaseSubscribed: {Dir: reflect.SelectRecv, Chan: reflect.ValueOf(resultch)},
+	}
+	var caseSubs []*batchSubscription
+	rebuildCases := func() {
+		cases = cases[:batchCaseFirstUpdate]
+		caseSubs = caseSubs[:0]
+		for _, sub := range subs {
+			if sub.subscriber == nil {
+				continue
+			}
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(sub.subscriber.Updates())})
+			caseSubs = append(caseSubs, sub)
+		}
+	}
+
+	sendError := func(id string, err error) error {
+		st := status.Convert(err)
+		return stream.Send(&spirebroker.SubscribeToX509SVIDsResponse{
+			Id: id,
+			Result: &spirebroker.SubscribeToX509SVIDsResponse_Error{
+				Error: &spirebroker.Error{
+					Code:    int32(st.Code()),
+					Message: st.Message(),
+				},
+			},
+		})
+	}
+
+	for {
+		chosen, value, ok := reflect.Select(cases)
+		switch chosen {
+		case batchCaseDone:
+			return nil
+		case batchCaseRecvErr:
+			if err, _ := value.Interface().(error); err != nil {
+				return err
+			}
+			return nil
+		case batchCaseRequest:
+			req := value.Interface().(*spirebroker.SubscribeToX509SVIDsRequest)
+			for _, id := range req.Unsubscribe {
+				if sub, ok := subs[id]; ok {
+					sub.log.Debug("Unsubscribing workload reference")
+					sub.finish()
+					delete(subs, id)
+				}
+			}
+			for _, ref := range req.Subscribe {
+				sub, err := s.startBatchSubscription(ctx, log, peer, ref, subs, resultch)
+				if err != nil {
+					if err := sendError(ref.GetId(), err); err != nil {
+						return err
+					}
+					continue
+				}
+				subs[sub.id] = sub
+			}
+			rebuildCases()
+		case batchCaseSubscribed:
+			result := value.Interface().(batchSubscribeResult)
+			if subs[result.sub.id] != result.sub {
+				// The reference was unsubscribed while the subscription
+				// was in progress.
+				if result.subscriber != nil {
+					result.subscriber.Finish()
+				}
+				continue
+			}
+			if result.err != nil {
+				delete(subs, result.sub.id)
+				if err := sendError(result.sub.id, result.err); err != nil {
+					return err
+				}
+				continue
+			}
+			result.sub.subscriber = result.subscriber
+			rebuildCases()
+		default:
+			sub := caseSubs[chosen-batchCaseFirstUpdate]
+			if !ok {
+				// The subscriber was finished. This only happens when the
+				// reference is unsubscribed, which also rebuilds the cases.
+				continue
+			}
+			update := value.Interface().(*cache.WorkloadUpdate)
+			if err := sendBatchX509SVIDUpdate(sub, update, stream); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// startBatchSubscription validates and authorizes a tagged reference and
+// starts attesting it in the background. The result is delivered on
+// resultch.
+func (s *Service) startBatchSubscription(ctx context.Context, log logrus.FieldLogger, peer spiffeid.ID, ref *spirebroker.TaggedWorkloadReference, subs map[string]*batchSubscription, resultch chan<- batchSubscribeResult) (*batchSubscription, error) {
+	if ref.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reference ID must be provided")
+	}
+	if _, ok := subs[ref.Id]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "reference %q is already subscribed", ref.Id)
+	}
+	if err := s.authorizeReferenceType(ctx, peer, ref.GetReference()); err != nil {
+		return nil, err
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	sub := &batchSubscription{
+		id:      ref.Id,
+		log:     log.WithField("reference_id", ref.Id),
+		cancel:  cancel,
+		latency: adminapi.StartFirstX509SVIDUpdateLatency(s.metrics),
+	}
+
+	go func() {
+		var result batchSubscribeResult
+		result.sub = sub
+
+		selectors, err := s.constructValidSelectorsFromReference(brokercontext.WithCallerID(subCtx, peer), sub.log, &broker.WorkloadReference{Reference: ref.Reference})
+		if err == nil {
+			sub.log.WithField(telemetry.Selectors, selector.WithoutUnknown(selectors)).Debug("Subscribing to cache changes")
+			result.subscriber, err = s.manager.SubscribeToCacheChanges(subCtx, selectors)
+			if err != nil {
+				sub.log.WithError(err).Error("Subscribe to cache changes failed")
+				err = status.Errorf(codes.Unavailable, "subscribe to cache changes failed: %v", err)
+			}
+		}
+		result.err = err
+
+		select {
+		case resultch <- result:
+		case <-ctx.Done():
+			if result.subscriber != nil {
+				result.subscriber.Finish()
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+func sendBatchX509SVIDUpdate(sub *batchSubscription, update *cache.WorkloadUpdate, stream spirebroker.API_SubscribeToX509SVIDsServer) error {
+	update.Identities = hintsfilter.FilterIdentities(update.Identities, sub.log)
+	if len(update.Identities) > 0 && !sub.receivedFirstUpdate {
+		// emit latency metric for first update containing an SVID.
+		sub.latency.Measure()
+		sub.receivedFirstUpdate = true
+	}
+
+	resp, _, err := composeX509SVIDBySelectors(update)
+	if err != nil {
+		sub.log.WithError(err).Error("Could not serialize X.509 SVID response")
+		return status.Error(codes.Internal, "could not serialize response")
+	}
+
+	svids := make([]*spirebroker.X509SVID, 0, len(resp.Svids))
+	for _, svid := range resp.Svids {
+		svids = append(svids, &spirebroker.X509SVID{
+			SpiffeId:    svid.SpiffeId,
+			X509Svid:    svid.X509Svid,
+			X509SvidKey: svid.X509SvidKey,
+			Bundle:      svid.Bundle,
+			Hint:        svid.Hint,
+		})
+	}
+
+	if err := stream.Send(&spirebroker.SubscribeToX509SVIDsResponse{
+		Id: sub.id,
+		Result: &spirebroker.SubscribeToX509SVIDsResponse_Update{
+			Update: &spirebroker.X509SVIDUpdate{
+				Svids:            svids,
+				FederatedBundles: resp.FederatedBundles,
+			},
+		},
+	}); err != nil {
+		sub.log.WithError(err).Error("Failed to send X.509 SVID response")
+		return err
+	}
+
+	sub.log.WithField(telemetry.Count, len(svids)).Debug("Sent X.509 SVIDs for broker")
+	return nil
+}
@@ -0,0 +1,372 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/spire/pkg/agent/api/rpccontext"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	spirebroker "github.com/spiffe/spire/proto/spire/agent/broker"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	batchTD     = spiffeid.RequireTrustDomainFromString("example.org")
+	batchCaller = spiffeid.RequireFromPath(batchTD, "/broker")
+)
+
+func TestSubscribeToX509SVIDs(t *testing.T) {
+	ca := testca.New(t, batchTD)
+	svid1 := ca.CreateX509SVID(spiffeid.RequireFromPath(batchTD, "/one"))
+	svid2 := ca.CreateX509SVID(spiffeid.RequireFromPath(batchTD, "/two"))
+
+	m := newBatchManager()
+	stream := runBatchTest(t, m, nil)
+
+	stream.send(&spirebroker.SubscribeToX509SVIDsRequest{
+		Subscribe: []*spirebroker.TaggedWorkloadReference{
+			{Id: "a", Reference: &anypb.Any{TypeUrl: k8sType, Value: []byte("one")}},
+			{Id: "b", Reference: &anypb.Any{TypeUrl: k8sType, Value: []byte("two")}},
+		},
+	})
+
+	m.waitSubscribed(t, "one").update(batchUpdate(ca, svid1))
+	resp := stream.recv(t)
+	assert.Equal(t, "a", resp.Id)
+	requireBatchSVIDs(t, resp, svid1.ID)
+
+	m.waitSubscribed(t, "two").update(batchUpdate(ca, svid2))
+	resp = stream.recv(t)
+	assert.Equal(t, "b", resp.Id)
+	requireBatchSVIDs(t, resp, svid2.ID)
+	assert.Equal(t, x509util.DERFromCertificates(svid2.Certificates), resp.GetUpdate().Svids[0].X509Svid)
+	assert.Equal(t, marshalBundle(ca.X509Authorities()), resp.GetUpdate().Svids[0].Bundle)
+
+	// Updates keep flowing for each reference independently
+	m.waitSubscribed(t, "one").update(batchUpdate(ca, svid1, svid2))
+	resp = stream.recv(t)
+	assert.Equal(t, "a", resp.Id)
+	requireBatchSVIDs(t, resp, svid1.ID, svid2.ID)
+
+	// Unsubscribing finishes the cache subscription of the reference only
+	stream.send(&spirebroker.SubscribeToX509SVIDsRequest{Unsubscribe: []string{"a", "unknown"}})
+	m.waitFinished(t, "one")
+	m.waitSubscribed(t, "two").update(batchUpdate(ca, svid1))
+	resp = stream.recv(t)
+	assert.Equal(t, "b", resp.Id)
+	requireBatchSVIDs(t, resp, svid1.ID)
+
+	// The ID can be reused once unsubscribed
+	stream.send(&spirebroker.SubscribeToX509SVIDsRequest{
+		Subscribe: []*spirebroker.TaggedWorkloadReference{
+			{Id: "a", Reference: &anypb.Any{TypeUrl: k8sType, Value: []byte("three")}},
+		},
+	})
+	m.waitSubscribed(t, "three").update(batchUpdate(ca, svid2))
+	resp = stream.recv(t)
+	assert.Equal(t, "a", resp.Id)
+	requireBatchSVIDs(t, resp, svid2.ID)
+
+	// Closing the stream finishes every cache subscription
+	stream.closeSend()
+	stream.waitDone(t, codes.OK)
+	m.waitFinished(t, "two")
+	m.waitFinished(t, "three")
+}
+
+func TestSubscribeToX509SVIDsReferenceErrors(t *testing.T) {
+	m := newBatchManager()
+	stream := runBatchTest(t, m, map[spiffeid.ID]ReferenceTypePolicy{
+		batchCaller: {Types: map[string]ReferenceTypeAccess{k8sType: {}}},
+	})
+
+	stream.send(&spirebroker.SubscribeToX509SVIDsRequest{
+		Subscribe: []*spirebroker.TaggedWorkloadReference{
+			{Reference: &anypb.Any{TypeUrl: k8sType, Value: []byte("one")}},
+			{Id: "no-reference"},
+			{Id: "denied", Reference: &anypb.Any{TypeUrl: pidType, Value: []byte("one")}},
+			{Id: "attest-fails", Reference: &anypb.Any{TypeUrl: k8sType, Value: []byte("bad")}},
+			{Id: "ok", Reference: &anypb.Any{TypeUrl: k8sType, Value: []byte("one")}},
+			{Id: "ok", Reference: &anypb.Any{TypeUrl: k8sType, Value: []byte("two")}},
+		},
+	})
+
+	expectErrors := map[string]*spirebroker.Error{
+		"":             {Code: int32(codes.InvalidArgument), Message: "reference ID must be provided"},
+		"no-reference": {Code: int32(codes.InvalidArgument), Message: "workload reference must be provided"},
+		"denied":       {Code: int32(codes.PermissionDenied), Message: `broker "spiffe://example.org/broker" is not allowed to use reference type "type.googleapis.com/spiffe.broker.PIDReference"`},
+		"ok":           {Code: int32(codes.AlreadyExists), Message: `reference "ok" is already subscribed`},
+		"attest-fails": {Code: int32(codes.NotFound), Message: "workload not found"},
+	}
+	for len(expectErrors) > 0 {
+		resp := stream.recv(t)
+		require.Contains(t, expectErrors, resp.Id)
+		spiretest.AssertProtoEqual(t, expectErrors[resp.Id], resp.GetError())
+		delete(expectErrors, resp.Id)
+	}
+
+	// The stream keeps serving the references that succeeded
+	m.waitSubscribed(t, "one")
+	stream.closeSend()
+	stream.waitDone(t, codes.OK)
+	m.waitFinished(t, "one")
+}
+
+func TestSubscribeToX509SVIDsUnauthenticated(t *testing.T) {
+	service := New(Config{Manager: newBatchManager(), Attestor: batchAttestor{}})
+	log, _ := test.NewNullLogger()
+	stream := newBatchStream(rpccontext.WithLogger(context.Background(), log))
+	err := service.SubscribeToX509SVIDs(stream)
+	spiretest.RequireGRPCStatus(t, err, codes.Unauthenticated, "unable to determine caller identity")
+}
+
+func runBatchTest(t *testing.T, m *batchManager, policy map[spiffeid.ID]ReferenceTypePolicy) *batchStream {
+	log, _ := test.NewNullLogger()
+	service := New(Config{
+		Log:                           log,
+		Metrics:                       telemetry.Blackhole{},
+		Manager:                       m,
+		Attestor:                      batchAttestor{},
+		AllowedReferenceTypesByCaller: policy,
+	})
+
+	ctx, cancel := context.WithCancel(callerContext(log))
+	t.Cleanup(cancel)
+
+	stream := newBatchStream(ctx)
+	go func() {
+		stream.done <- service.SubscribeToX509SVIDs(stream)
+	}()
+	return stream
+}
+
+// callerContext returns a context for an RPC made over UDS by batchCaller.
+func callerContext(log logrus.FieldLogger) context.Context {
+	ctx := rpccontext.WithLogger(context.Background(), log)
+	return peer.NewContext(ctx, &peer.Peer{
+		Addr: &net.UnixAddr{Name: "/tmp/broker.sock", Net: "unix"},
+		AuthInfo: credentials.TLSInfo{
+			SPIFFEID: &url.URL{Scheme: "spiffe", Host: batchCaller.TrustDomain().Name(), Path: batchCaller.Path()},
+		},
+	})
+}
+
+func batchUpdate(ca *testca.CA, svids ...*x509svid.SVID) *cache.WorkloadUpdate {
+	update := &cache.WorkloadUpdate{Bundle: ca.Bundle()}
+	for _, svid := range svids {
+		update.Identities = append(update.Identities, cache.Identity{
+			Entry:      &common.RegistrationEntry{SpiffeId: svid.ID.String()},
+			PrivateKey: svid.PrivateKey,
+			SVID:       svid.Certificates,
+		})
+	}
+	return update
+}
+
+func requireBatchSVIDs(t *testing.T, resp *spirebroker.SubscribeToX509SVIDsResponse, ids ...spiffeid.ID) {
+	require.NotNil(t, resp.GetUpdate(), "expected update response")
+	var actual []string
+	for _, svid := range resp.GetUpdate().Svids {
+		actual = append(actual, svid.SpiffeId)
+	}
+	var expected []string
+	for _, id := range ids {
+		expected = append(expected, id.String())
+	}
+	require.Equal(t, expected, actual)
+}
+
+type batchAttestor struct{}
+
+func (batchAttestor) Attest(context.Context, int) ([]*common.Selector, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (batchAttestor) AttestReference(_ context.Context, ref *anypb.Any) ([]*common.Selector, error) {
+	if string(ref.Value) == "bad" {
+		return nil, status.Error(codes.NotFound, "workload not found")
+	}
+	return []*common.Selector{{Type: "ref", Value: string(ref.Value)}}, nil
+}
+
+type batchManager struct {
+	manager.Manager
+
+	mu          sync.Mutex
+	subscribers map[string]*batchSubscriber
+	changed     chan struct{}
+}
+
+func newBatchManager() *batchManager {
+	return &batchManager{
+		subscribers: make(map[string]*batchSubscriber),
+		changed:     make(chan struct{}, 1),
+	}
+}
+
+func (m *batchManager) SubscribeToCacheChanges(_ context.Context, selectors cache.Selectors) (cache.Subscriber, error) {
+	sub := &batchSubscriber{
+		m:       m,
+		value:   selectors[0].Value,
+		updates: make(chan *cache.WorkloadUpdate, 1),
+	}
+	m.mu.Lock()
+	m.subscribers[sub.value] = sub
+	m.mu.Unlock()
+	m.notify()
+	return sub, nil
+}
+
+func (m *batchManager) notify() {
+	select {
+	case m.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (m *batchManager) waitFor(t *testing.T, cond func() bool) {
+	timer := time.NewTimer(time.Minute)
+	defer timer.Stop()
+	for {
+		m.mu.Lock()
+		ok := cond()
+		m.mu.Unlock()
+		if ok {
+			return
+		}
+		select {
+		case <-m.changed:
+		case <-timer.C:
+			require.FailNow(t, "timed out waiting for subscriber")
+		}
+	}
+}
+
+func (m *batchManager) waitSubscribed(t *testing.T, value string) *batchSubscriber {
+	var sub *batchSubscriber
+	m.waitFor(t, func() bool {
+		sub = m.subscribers[value]
+		return sub != nil
+	})
+	return sub
+}
+
+func (m *batchManager) waitFinished(t *testing.T, value string) {
+	m.waitFor(t, func() bool {
+		sub := m.subscribers[value]
+		return sub != nil && sub.finished
+	})
+}
+
+type batchSubscriber struct {
+	m        *batchManager
+	value    string
+	updates  chan *cache.WorkloadUpdate
+	finished bool
+}
+
+func (s *batchSubscriber) update(update *cache.WorkloadUpdate) {
+	s.updates <- update
+}
+
+func (s *batchSubscriber) Updates() <-chan *cache.WorkloadUpdate {
+	return s.updates
+}
+
+func (s *batchSubscriber) Finish() {
+	s.m.mu.Lock()
+	s.finished = true
+	s.m.mu.Unlock()
+	s.m.notify()
+}
+
+type batchStream struct {
+	grpc.ServerStream
+
+	ctx   context.Context
+	reqs  chan *spirebroker.SubscribeToX509SVIDsRequest
+	resps chan *spirebroker.SubscribeToX509SVIDsResponse
+	done  chan error
+}
+
+func newBatchStream(ctx context.Context) *batchStream {
+	return &batchStream{
+		ctx:   ctx,
+		reqs:  make(chan *spirebroker.SubscribeToX509SVIDsRequest),
+		resps: make(chan *spirebroker.SubscribeToX509SVIDsResponse, 10),
+		done:  make(chan error, 1),
+	}
+}
+
+func (s *batchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *batchStream) Send(resp *spirebroker.SubscribeToX509SVIDsResponse) error {
+	s.resps <- resp
+	return nil
+}
+
+func (s *batchStream) Recv() (*spirebroker.SubscribeToX509SVIDsRequest, error) {
+	select {
+	case req, ok := <-s.reqs:
+		if !ok {
+			return nil, io.EOF
+		}
+		return req, nil
+	case <-s.ctx.Done():
+		return nil, status.FromContextError(s.ctx.Err()).Err()
+	}
+}
+
+func (s *batchStream) send(req *spirebroker.SubscribeToX509SVIDsRequest) {
+	s.reqs <- req
+}
+
+func (s *batchStream) closeSend() {
+	close(s.reqs)
+}
+
+func (s *batchStream) recv(t *testing.T) *spirebroker.SubscribeToX509SVIDsResponse {
+	select {
+	case resp := <-s.resps:
+		return resp
+	case err := <-s.done:
+		require.FailNow(t, "stream terminated unexpectedly", "err=%v", err)
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for response")
+	}
+	return nil
+}
+
+func (s *batchStream) waitDone(t *testing.T, code codes.Code) {
+	select {
+	case err := <-s.done:
+		require.Equal(t, code, status.Code(err), "err=%v", err)
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for stream to terminate")
+	}
+}
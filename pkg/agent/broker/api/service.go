@@ -19,9 +19,11 @@ import (
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/telemetry/agent/adminapi"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	spirebroker "github.com/spiffe/spire/proto/spire/agent/broker"
 	"github.com/spiffe/spire/proto/spire/common"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -31,9 +33,11 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
-// RegisterService registers the SPIFFE Broker API service on the provided server.
+// RegisterService registers the SPIFFE Broker API service, along with the
+// SPIRE extensions to it, on the provided server.
 func RegisterService(s *grpc.Server, service *Service) {
 	broker.RegisterAPIServer(s, service)
+	spirebroker.RegisterAPIServer(s, extensionServer{s: service})
 }
 
 type Config struct {
@@ -47,6 +51,9 @@ type Config struct {
 	// each type is also allowed over TCP. A caller missing from the map has
 	// no restriction over UDS, but remains denied over TCP.
 	AllowedReferenceTypesByCaller map[spiffeid.ID]ReferenceTypePolicy
+
+	// DisableWITSVIDs disables the WIT-SVID and WIT bundle RPCs.
+	DisableWITSVIDs bool
 }
 
 func New(config Config) *Service {
@@ -55,6 +62,7 @@ func New(config Config) *Service {
 		peerAttestor:                  config.Attestor,
 		metrics:                       config.Metrics,
 		allowedReferenceTypesByCaller: config.AllowedReferenceTypesByCaller,
+		disableWITSVIDs:               config.DisableWITSVIDs,
 	}
 }
 
@@ -86,6 +94,7 @@ type Service struct {
 	peerAttestor                  workloadattestor.Attestor
 	metrics                       telemetry.Metrics
 	allowedReferenceTypesByCaller map[spiffeid.ID]ReferenceTypePolicy
+	disableWITSVIDs               bool
 }
 
 // authorizeReferenceType applies the per-broker reference type policy. UDS
@@ -168,7 +177,7 @@ func (s *Service) SubscribeToX509SVID(req *broker.SubscribeToX509SVIDRequest, st
 		return err
 	}
 
-	log.WithField(telemetry.Selectors, selectors).Debug("Subscribing to cache changes")
+	log.WithField(telemetry.Selectors, selector.WithoutUnknown(selectors)).Debug("Subscribing to cache changes")
 
 	subscriber, err := s.manager.SubscribeToCacheChanges(ctx, selectors)
 	if err != nil {
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/exp/bundle/witbundle"
+	"github.com/spiffe/go-spiffe/v2/exp/proto/spiffe/broker"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/api/rpccontext"
+	"github.com/spiffe/spire/pkg/agent/broker/brokercontext"
+	"github.com/spiffe/spire/pkg/agent/common/hintsfilter"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	spirebroker "github.com/spiffe/spire/proto/spire/agent/broker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func (e extensionServer) FetchWITSVID(ctx context.Context, req *spirebroker.FetchWITSVIDRequest) (*spirebroker.FetchWITSVIDResponse, error) {
+	return e.s.FetchWITSVID(ctx, req)
+}
+
+func (e extensionServer) SubscribeToWITBundles(req *spirebroker.SubscribeToWITBundlesRequest, stream spirebroker.API_SubscribeToWITBundlesServer) error {
+	return e.s.SubscribeToWITBundles(req, stream)
+}
+
+func (s *Service) FetchWITSVID(ctx context.Context, req *spirebroker.FetchWITSVIDRequest) (*spirebroker.FetchWITSVIDResponse, error) {
+	log := rpccontext.Logger(ctx)
+	if s.disableWITSVIDs {
+		return nil, status.Error(codes.Unimplemented, "WIT functionality is disabled")
+	}
+
+	peer, err := s.getCallerContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log = log.WithField("broker_peer", peer.String())
+
+	if err := s.authorizeReferenceType(ctx, peer, req.GetReference()); err != nil {
+		return nil, err
+	}
+
+	selectors, err := s.constructValidSelectorsFromReference(brokercontext.WithCallerID(ctx, peer), log, &broker.WorkloadReference{Reference: req.Reference})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(spirebroker.FetchWITSVIDResponse)
+	entries := s.manager.MatchingRegistrationEntries(selectors)
+	entries = hintsfilter.FilterRegistrations(entries, log)
+	for _, entry := range entries {
+		if req.SpiffeId != "" && entry.SpiffeId != req.SpiffeId {
+			continue
+		}
+
+		spiffeID, err := spiffeid.FromString(entry.SpiffeId)
+		if err != nil {
+			log.WithField(telemetry.SPIFFEID, entry.SpiffeId).WithError(err).Error("Invalid requested SPIFFE ID")
+			return nil, status.Errorf(codes.InvalidArgument, "invalid requested SPIFFE ID: %v", err)
+		}
+
+		loopLog := log.WithField(telemetry.SPIFFEID, spiffeID.String())
+
+		svid, err := s.manager.FetchWITSVID(ctx, entry)
+		if err != nil {
+			loopLog.WithError(err).Error("Could not fetch WIT-SVID")
+			return nil, status.Errorf(codes.Unavailable, "could not fetch WIT-SVID: %v", err)
+		}
+
+		keyData, err := svid.MarshalKey()
+		if err != nil {
+			loopLog.WithError(err).Error("Could not serialize WIT-SVID key")
+			return nil, status.Errorf(codes.Internal, "could not serialize response: %v", err)
+		}
+
+		resp.Svids = append(resp.Svids, &spirebroker.WITSVID{
+			SpiffeId:   spiffeID.String(),
+			WitSvid:    svid.SVID.Token,
+			WitSvidKey: keyData,
+			Hint:       entry.Hint,
+		})
+
+		ttl := time.Until(svid.SVID.ExpiresAt)
+		loopLog.WithField(telemetry.TTL, ttl.Seconds()).Debug("Fetched WIT SVID")
+	}
+
+	if len(resp.Svids) == 0 {
+		log.Error("No identity issued")
+		return nil, status.Error(codes.PermissionDenied, "no identity issued")
+	}
+
+	return resp, nil
+}
+
+func (s *Service) SubscribeToWITBundles(req *spirebroker.SubscribeToWITBundlesRequest, stream spirebroker.API_SubscribeToWITBundlesServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+	if s.disableWITSVIDs {
+		return status.Error(codes.Unimplemented, "WIT functionality is disabled")
+	}
+
+	peer, err := s.getCallerContext(ctx)
+	if err != nil {
+		return err
+	}
+	log = log.WithField("broker_peer", peer.String())
+
+	if err := s.authorizeReferenceType(ctx, peer, req.GetReference()); err != nil {
+		return err
+	}
+
+	selectors, err := s.constructValidSelectorsFromReference(brokercontext.WithCallerID(ctx, peer), log, &broker.WorkloadReference{Reference: req.Reference})
+	if err != nil {
+		return err
+	}
+
+	// Like the Workload API FetchWITBundles RPC, only the bundles of the
+	// agent trust domain and of the trust domains the referenced workload
+	// federates with are returned, so the workload cache is subscribed to as
+	// well to learn which trust domains those are.
+	subscriber, err := s.manager.SubscribeToCacheChanges(ctx, selectors)
+	if err != nil {
+		log.WithError(err).Error("Subscribe to cache changes failed")
+		return err
+	}
+	defer subscriber.Finish()
+
+	witBundles := s.manager.SubscribeToWITBundleChanges()
+
+	var update *cache.WorkloadUpdate
+	var previousResp *spirebroker.SubscribeToWITBundlesResponse
+	for {
+		select {
+		case update = <-subscriber.Updates():
+		case <-witBundles.Changes():
+			witBundles.Next()
+			if update == nil {
+				// Nothing to send until the first workload update arrives
+				continue
+			}
+		case <-ctx.Done():
+			return nil
+		}
+
+		resp, err := composeWITBundlesResponse(update, witBundles.Value())
+		if err != nil {
+			log.WithError(err).Error("Could not serialize WIT bundle response")
+			return status.Errorf(codes.Internal, "could not serialize response: %v", err)
+		}
+		if proto.Equal(resp, previousResp) {
+			continue
+		}
+		if err := stream.Send(resp); err != nil {
+			log.WithError(err).Error("Failed to send WIT bundle response")
+			return err
+		}
+		previousResp = resp
+	}
+}
+
+func composeWITBundlesResponse(update *cache.WorkloadUpdate, witBundles map[spiffeid.TrustDomain]*witbundle.Bundle) (*spirebroker.SubscribeToWITBundlesResponse, error) {
+	if update.Bundle == nil {
+		// This should be purely defensive since the cache should always supply
+		// a bundle.
+		return nil, errors.New("bundle not available")
+	}
+
+	trustDomains := []spiffeid.TrustDomain{update.Bundle.TrustDomain()}
+	if update.HasIdentity() {
+		for td := range update.FederatedBundles {
+			trustDomains = append(trustDomains, td)
+		}
+	}
+
+	bundles := make(map[string]string, len(trustDomains))
+	for _, td := range trustDomains {
+		witBundle, ok := witBundles[td]
+		if !ok {
+			witBundle = witbundle.New(td)
+		}
+		jwksBytes, err := witBundle.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		bundles[td.IDString()] = string(jwksBytes)
+	}
+	return &spirebroker.SubscribeToWITBundlesResponse{Bundles: bundles}, nil
+}
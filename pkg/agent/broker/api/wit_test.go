@@ -0,0 +1,310 @@
+package api
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/exp/bundle/witbundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	spirebroker "github.com/spiffe/spire/proto/spire/agent/broker"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestFetchWITSVID(t *testing.T) {
+	key := testkey.NewEC256(t)
+	now := time.Now()
+	entries := []*common.RegistrationEntry{
+		{SpiffeId: "spiffe://example.org/one", Hint: "internal"},
+		{SpiffeId: "spiffe://example.org/two"},
+	}
+	witSVIDs := map[string]*cache.WITSVID{
+		"spiffe://example.org/one": {
+			SVID:             &client.WITSVID{Token: "token-one", IssuedAt: now, ExpiresAt: now.Add(time.Hour)},
+			PrivateKey:       key,
+			SigningAlgorithm: "ES256",
+		},
+		"spiffe://example.org/two": {
+			SVID:             &client.WITSVID{Token: "token-two", IssuedAt: now, ExpiresAt: now.Add(time.Hour)},
+			PrivateKey:       key,
+			SigningAlgorithm: "ES256",
+		},
+	}
+	keyData, err := (&jose.JSONWebKey{Key: key, Algorithm: "ES256"}).MarshalJSON()
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name            string
+		disableWITSVIDs bool
+		ref             *anypb.Any
+		spiffeID        string
+		entries         []*common.RegistrationEntry
+		fetchErr        error
+		expectCode      codes.Code
+		expectMsg       string
+		expectSVIDs     []*spirebroker.WITSVID
+	}{
+		{
+			name:            "WIT disabled",
+			disableWITSVIDs: true,
+			ref:             &anypb.Any{TypeUrl: k8sType, Value: []byte("one")},
+			expectCode:      codes.Unimplemented,
+			expectMsg:       "WIT functionality is disabled",
+		},
+		{
+			name:       "no reference",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "workload reference must be provided",
+		},
+		{
+			name:       "reference type not allowed",
+			ref:        &anypb.Any{TypeUrl: pidType, Value: []byte("one")},
+			expectCode: codes.PermissionDenied,
+			expectMsg:  `broker "spiffe://example.org/broker" is not allowed to use reference type "type.googleapis.com/spiffe.broker.PIDReference"`,
+		},
+		{
+			name:       "attestation fails",
+			ref:        &anypb.Any{TypeUrl: k8sType, Value: []byte("bad")},
+			expectCode: codes.NotFound,
+			expectMsg:  "workload not found",
+		},
+		{
+			name:       "no identity issued",
+			ref:        &anypb.Any{TypeUrl: k8sType, Value: []byte("one")},
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "no identity issued",
+		},
+		{
+			name:       "fetch fails",
+			ref:        &anypb.Any{TypeUrl: k8sType, Value: []byte("one")},
+			entries:    entries,
+			fetchErr:   errors.New("oh no"),
+			expectCode: codes.Unavailable,
+			expectMsg:  "could not fetch WIT-SVID: oh no",
+		},
+		{
+			name:    "all identities",
+			ref:     &anypb.Any{TypeUrl: k8sType, Value: []byte("one")},
+			entries: entries,
+			expectSVIDs: []*spirebroker.WITSVID{
+				{SpiffeId: "spiffe://example.org/one", WitSvid: "token-one", WitSvidKey: string(keyData), Hint: "internal"},
+				{SpiffeId: "spiffe://example.org/two", WitSvid: "token-two", WitSvidKey: string(keyData)},
+			},
+		},
+		{
+			name:     "requested identity",
+			ref:      &anypb.Any{TypeUrl: k8sType, Value: []byte("one")},
+			spiffeID: "spiffe://example.org/two",
+			entries:  entries,
+			expectSVIDs: []*spirebroker.WITSVID{
+				{SpiffeId: "spiffe://example.org/two", WitSvid: "token-two", WitSvidKey: string(keyData)},
+			},
+		},
+		{
+			name:       "requested identity not entitled",
+			ref:        &anypb.Any{TypeUrl: k8sType, Value: []byte("one")},
+			spiffeID:   "spiffe://example.org/three",
+			entries:    entries,
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "no identity issued",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			log, _ := test.NewNullLogger()
+			service := New(Config{
+				Log:      log,
+				Metrics:  telemetry.Blackhole{},
+				Manager:  &witManager{entries: tt.entries, svids: witSVIDs, err: tt.fetchErr},
+				Attestor: batchAttestor{},
+				AllowedReferenceTypesByCaller: map[spiffeid.ID]ReferenceTypePolicy{
+					batchCaller: {Types: map[string]ReferenceTypeAccess{k8sType: {}}},
+				},
+				DisableWITSVIDs: tt.disableWITSVIDs,
+			})
+
+			resp, err := service.FetchWITSVID(callerContext(log), &spirebroker.FetchWITSVIDRequest{
+				Reference: tt.ref,
+				SpiffeId:  tt.spiffeID,
+			})
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.AssertProtoListEqual(t, tt.expectSVIDs, resp.Svids)
+		})
+	}
+}
+
+func TestSubscribeToWITBundles(t *testing.T) {
+	td1 := spiffeid.RequireTrustDomainFromString("example.org")
+	td2 := spiffeid.RequireTrustDomainFromString("domain.test")
+	bundle1 := witbundle.FromWITAuthorities(td1, map[string]crypto.PublicKey{"kid1": testkey.NewEC256(t).Public()})
+	bundle2 := witbundle.FromWITAuthorities(td2, map[string]crypto.PublicKey{"kid2": testkey.NewEC256(t).Public()})
+	jwks1, err := bundle1.Marshal()
+	require.NoError(t, err)
+	jwks2, err := bundle2.Marshal()
+	require.NoError(t, err)
+	td3 := spiffeid.RequireTrustDomainFromString("other.test")
+	bundle3 := witbundle.FromWITAuthorities(td3, map[string]crypto.PublicKey{"kid3": testkey.NewEC256(t).Public()})
+	emptyJWKS2, err := witbundle.New(td2).Marshal()
+	require.NoError(t, err)
+
+	t.Run("WIT disabled", func(t *testing.T) {
+		log, _ := test.NewNullLogger()
+		service := New(Config{Log: log, Manager: &witManager{}, Attestor: batchAttestor{}, DisableWITSVIDs: true})
+		err := service.SubscribeToWITBundles(&spirebroker.SubscribeToWITBundlesRequest{
+			Reference: &anypb.Any{TypeUrl: k8sType, Value: []byte("one")},
+		}, newWITBundlesStream(callerContext(log)))
+		spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "WIT functionality is disabled")
+	})
+
+	t.Run("attestation fails", func(t *testing.T) {
+		log, _ := test.NewNullLogger()
+		service := New(Config{Log: log, Manager: &witManager{}, Attestor: batchAttestor{}})
+		err := service.SubscribeToWITBundles(&spirebroker.SubscribeToWITBundlesRequest{
+			Reference: &anypb.Any{TypeUrl: k8sType, Value: []byte("bad")},
+		}, newWITBundlesStream(callerContext(log)))
+		spiretest.RequireGRPCStatus(t, err, codes.NotFound, "workload not found")
+	})
+
+	t.Run("bundles are filtered and streamed as they change", func(t *testing.T) {
+		log, _ := test.NewNullLogger()
+		m := &witManager{
+			bundles: cache.NewWITBundleCache(),
+			updates: make(chan *cache.WorkloadUpdate, 1),
+		}
+		m.bundles.Update(map[spiffeid.TrustDomain]*witbundle.Bundle{td1: bundle1, td2: bundle2})
+		service := New(Config{Log: log, Manager: m, Attestor: batchAttestor{}})
+
+		ctx, cancel := context.WithCancel(callerContext(log))
+		defer cancel()
+		stream := newWITBundlesStream(ctx)
+		done := make(chan error, 1)
+		go func() {
+			done <- service.SubscribeToWITBundles(&spirebroker.SubscribeToWITBundlesRequest{
+				Reference: &anypb.Any{TypeUrl: k8sType, Value: []byte("one")},
+			}, stream)
+		}()
+
+		// Without an identity, only the agent trust domain bundle is sent
+		m.updates <- &cache.WorkloadUpdate{
+			Bundle:           spiffebundle.New(td1),
+			FederatedBundles: map[spiffeid.TrustDomain]*spiffebundle.Bundle{td2: spiffebundle.New(td2)},
+		}
+		resp := stream.recv(t)
+		assert.Equal(t, map[string]string{td1.IDString(): string(jwks1)}, resp.Bundles)
+
+		// Bundles of trust domains the workload federates with are sent once
+		// it has an identity
+		m.updates <- &cache.WorkloadUpdate{
+			Identities:       []cache.Identity{{Entry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/one"}}},
+			Bundle:           spiffebundle.New(td1),
+			FederatedBundles: map[spiffeid.TrustDomain]*spiffebundle.Bundle{td2: spiffebundle.New(td2)},
+		}
+		resp = stream.recv(t)
+		assert.Equal(t, map[string]string{td1.IDString(): string(jwks1), td2.IDString(): string(jwks2)}, resp.Bundles)
+
+		// Bundles of other trust domains are never sent
+		m.bundles.Update(map[spiffeid.TrustDomain]*witbundle.Bundle{td1: bundle1, td2: bundle2, td3: bundle3})
+		m.bundles.Update(map[spiffeid.TrustDomain]*witbundle.Bundle{td1: bundle1})
+		resp = stream.recv(t)
+		assert.Equal(t, map[string]string{td1.IDString(): string(jwks1), td2.IDString(): string(emptyJWKS2)}, resp.Bundles)
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+type witManager struct {
+	manager.Manager
+
+	entries []*common.RegistrationEntry
+	svids   map[string]*cache.WITSVID
+	bundles *cache.WITBundleCache
+	updates chan *cache.WorkloadUpdate
+	err     error
+}
+
+func (m *witManager) SubscribeToCacheChanges(context.Context, cache.Selectors) (cache.Subscriber, error) {
+	return witSubscriber{updates: m.updates}, nil
+}
+
+func (m *witManager) MatchingRegistrationEntries([]*common.Selector) []*common.RegistrationEntry {
+	return m.entries
+}
+
+func (m *witManager) FetchWITSVID(_ context.Context, entry *common.RegistrationEntry) (*cache.WITSVID, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	svid, ok := m.svids[entry.SpiffeId]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return svid, nil
+}
+
+func (m *witManager) SubscribeToWITBundleChanges() *cache.WITBundleStream {
+	return m.bundles.SubscribeToWITBundleChanges()
+}
+
+type witSubscriber struct {
+	updates chan *cache.WorkloadUpdate
+}
+
+func (s witSubscriber) Updates() <-chan *cache.WorkloadUpdate {
+	return s.updates
+}
+
+func (s witSubscriber) Finish() {}
+
+type witBundlesStream struct {
+	grpc.ServerStream
+
+	ctx   context.Context
+	resps chan *spirebroker.SubscribeToWITBundlesResponse
+}
+
+func newWITBundlesStream(ctx context.Context) *witBundlesStream {
+	return &witBundlesStream{
+		ctx:   ctx,
+		resps: make(chan *spirebroker.SubscribeToWITBundlesResponse, 10),
+	}
+}
+
+func (s *witBundlesStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *witBundlesStream) Send(resp *spirebroker.SubscribeToWITBundlesResponse) error {
+	s.resps <- resp
+	return nil
+}
+
+func (s *witBundlesStream) recv(t *testing.T) *spirebroker.SubscribeToWITBundlesResponse {
+	select {
+	case resp := <-s.resps:
+		return resp
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for response")
+	}
+	return nil
+}
@@ -55,6 +55,9 @@ type Config struct {
 	// TLSPolicy controls the post-quantum-safe TLS policy applied to the
 	// inbound mTLS listener.
 	TLSPolicy tlspolicy.Policy
+
+	// DisableWITSVIDs disables the WIT-SVID and WIT bundle RPCs.
+	DisableWITSVIDs bool
 }
 
 // Broker identifies a broker authorized to talk to the SPIFFE Broker API
@@ -214,6 +217,7 @@ func (e *Endpoints) registerBrokerAPI(server *grpc.Server) {
 		Metrics:                       e.c.Metrics,
 		Log:                           e.c.Log.WithField(telemetry.SubsystemName, telemetry.BrokerAPI),
 		AllowedReferenceTypesByCaller: buildAllowedReferenceTypeMap(e.c.Brokers),
+		DisableWITSVIDs:               e.c.DisableWITSVIDs,
 	})
 
 	brokerapi.RegisterService(server, service)
@@ -72,6 +72,12 @@ type JWTSVID struct {
 	ExpiresAt time.Time
 }
 
+type WITSVID struct {
+	Token     string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
 type SyncStats struct {
 	Entries SyncEntriesStats
 	Bundles SyncBundlesStats
@@ -94,6 +100,7 @@ type Client interface {
 	RenewSVID(ctx context.Context, csr []byte) (*X509SVID, error)
 	NewX509SVIDs(ctx context.Context, csrs map[string][]byte) (map[string]*X509SVID, error)
 	NewJWTSVID(ctx context.Context, entryID string, audience []string, hasCacheHit bool) (*JWTSVID, spiffeid.ID, error)
+	NewWITSVID(ctx context.Context, entryID string, publicKey []byte, signingAlgorithm string) (*WITSVID, spiffeid.ID, error)
 	PostStatus(ctx context.Context, agentVersion string) error
 
 	// Release releases any resources that were held by this Client, if any.
@@ -402,6 +409,72 @@ func (c *client) NewJWTSVID(ctx context.Context, entryID string, audience []stri
 	}, spiffeId, nil
 }
 
+func (c *client) NewWITSVID(ctx context.Context, entryID string, publicKey []byte, signingAlgorithm string) (*WITSVID, spiffeid.ID, error) {
+	c.c.RotMtx.RLock()
+	defer c.c.RotMtx.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	svidClient, connection, err := c.newSVIDClient()
+	if err != nil {
+		return nil, spiffeid.ID{}, err
+	}
+	defer connection.Release()
+
+	resp, err := svidClient.BatchNewWITSVID(ctx, &svidv1.BatchNewWITSVIDRequest{
+		Params: []*svidv1.NewWITSVIDParams{
+			{
+				EntryId:          entryID,
+				PublicKey:        publicKey,
+				SigningAlgorithm: signingAlgorithm,
+			},
+		},
+	})
+	if err != nil {
+		c.release(connection)
+		c.withErrorFields(err).Error("Failed to fetch WIT SVID")
+		return nil, spiffeid.ID{}, fmt.Errorf("failed to fetch WIT SVID: %w", err)
+	}
+
+	if len(resp.Results) != 1 {
+		return nil, spiffeid.ID{}, fmt.Errorf("expected 1 WIT-SVID result; got %d", len(resp.Results))
+	}
+
+	result := resp.Results[0]
+	if result.Status.GetCode() != int32(codes.OK) {
+		c.c.Log.WithFields(logrus.Fields{
+			telemetry.RegistrationID: entryID,
+			telemetry.Status:         result.Status.GetCode(),
+			telemetry.Error:          result.Status.GetMessage(),
+		}).Warn("Failed to mint WIT SVID")
+		return nil, spiffeid.ID{}, status.Error(codes.Code(result.Status.GetCode()), result.Status.GetMessage())
+	}
+
+	svid := result.Svid
+	switch {
+	case svid == nil:
+		return nil, spiffeid.ID{}, errors.New("WITSVID response missing SVID")
+	case svid.IssuedAt == 0:
+		return nil, spiffeid.ID{}, errors.New("WITSVID missing issued at")
+	case svid.ExpiresAt == 0:
+		return nil, spiffeid.ID{}, errors.New("WITSVID missing expires at")
+	case svid.IssuedAt > svid.ExpiresAt:
+		return nil, spiffeid.ID{}, errors.New("WITSVID issued after it has expired")
+	}
+
+	spiffeID, err := idutil.IDFromProto(svid.Id)
+	if err != nil {
+		return nil, spiffeid.ID{}, fmt.Errorf("could not parse WIT-SVID SPIFFE ID: %w", err)
+	}
+
+	return &WITSVID{
+		Token:     svid.Token,
+		IssuedAt:  time.Unix(svid.IssuedAt, 0).UTC(),
+		ExpiresAt: time.Unix(svid.ExpiresAt, 0).UTC(),
+	}, spiffeID, nil
+}
+
 // Release the underlying connection.
 func (c *client) Release() {
 	c.release(nil)
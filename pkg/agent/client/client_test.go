@@ -990,6 +990,112 @@ func TestFetchJWTSVID(t *testing.T) {
 	}
 }
 
+func TestFetchWITSVID(t *testing.T) {
+	client, tc := createClient(t)
+
+	issuedAt := time.Now().Unix()
+	expiresAt := time.Now().Add(time.Minute).Unix()
+	okStatus := &types.Status{Code: int32(codes.OK)}
+	for _, tt := range []struct {
+		name           string
+		result         *svidv1.BatchNewWITSVIDResponse_Result
+		fetchErr       error
+		err            string
+		expectSVID     *WITSVID
+		expectSPIFFEID spiffeid.ID
+	}{
+		{
+			name: "success",
+			result: &svidv1.BatchNewWITSVIDResponse_Result{
+				Status: okStatus,
+				Svid: &types.WITSVID{
+					Token: "token",
+					Id: &types.SPIFFEID{
+						TrustDomain: "example.org",
+						Path:        "/workload",
+					},
+					ExpiresAt: expiresAt,
+					IssuedAt:  issuedAt,
+				},
+			},
+			expectSVID: &WITSVID{
+				Token:     "token",
+				ExpiresAt: time.Unix(expiresAt, 0).UTC(),
+				IssuedAt:  time.Unix(issuedAt, 0).UTC(),
+			},
+			expectSPIFFEID: spiffeid.RequireFromString("spiffe://example.org/workload"),
+		},
+		{
+			name:     "client fails",
+			fetchErr: errors.New("client fails"),
+			err:      "failed to fetch WIT SVID: rpc error: code = Unknown desc = client fails",
+		},
+		{
+			name: "result status not ok",
+			result: &svidv1.BatchNewWITSVIDResponse_Result{
+				Status: &types.Status{Code: int32(codes.NotFound), Message: "entry not found"},
+			},
+			err: "rpc error: code = NotFound desc = entry not found",
+		},
+		{
+			name: "empty response",
+			result: &svidv1.BatchNewWITSVIDResponse_Result{
+				Status: okStatus,
+			},
+			err: "WITSVID response missing SVID",
+		},
+		{
+			name: "missing issuedAt",
+			result: &svidv1.BatchNewWITSVIDResponse_Result{
+				Status: okStatus,
+				Svid: &types.WITSVID{
+					Token:     "token",
+					ExpiresAt: expiresAt,
+				},
+			},
+			err: "WITSVID missing issued at",
+		},
+		{
+			name: "missing expiredAt",
+			result: &svidv1.BatchNewWITSVIDResponse_Result{
+				Status: okStatus,
+				Svid: &types.WITSVID{
+					Token:    "token",
+					IssuedAt: issuedAt,
+				},
+			},
+			err: "WITSVID missing expires at",
+		},
+		{
+			name: "issued after expired",
+			result: &svidv1.BatchNewWITSVIDResponse_Result{
+				Status: okStatus,
+				Svid: &types.WITSVID{
+					Token:     "token",
+					ExpiresAt: issuedAt,
+					IssuedAt:  expiresAt,
+				},
+			},
+			err: "WITSVID issued after it has expired",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tc.svidServer.witSVIDResult = tt.result
+			tc.svidServer.newWITSVID = tt.fetchErr
+			resp, spiffeID, err := client.NewWITSVID(ctx, "entry-id", []byte("public-key"), "ES256")
+			if tt.err != "" {
+				require.Nil(t, resp)
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectSVID, resp)
+			require.Equal(t, tt.expectSPIFFEID, spiffeID)
+		})
+	}
+}
+
 // createClient creates a sample client with mocked components for testing purposes
 func createClient(t *testing.T) (*client, *testServer) {
 	tc := &testServer{
@@ -1116,8 +1222,10 @@ type fakeSVIDServer struct {
 
 	batchSVIDErr    error
 	newJWTSVID      error
+	newWITSVID      error
 	x509SVIDs       map[string]*types.X509SVID
 	jwtSVID         *types.JWTSVID
+	witSVIDResult   *svidv1.BatchNewWITSVIDResponse_Result
 	simulateRelease func()
 }
 
@@ -1166,6 +1274,18 @@ func (c *fakeSVIDServer) NewJWTSVID(context.Context, *svidv1.NewJWTSVIDRequest)
 	}, nil
 }
 
+func (c *fakeSVIDServer) BatchNewWITSVID(_ context.Context, in *svidv1.BatchNewWITSVIDRequest) (*svidv1.BatchNewWITSVIDResponse, error) {
+	if c.newWITSVID != nil {
+		return nil, c.newWITSVID
+	}
+	if len(in.Params) != 1 || in.Params[0].SigningAlgorithm == "" || len(in.Params[0].PublicKey) == 0 {
+		return nil, errors.New("malformed param")
+	}
+	return &svidv1.BatchNewWITSVIDResponse{
+		Results: []*svidv1.BatchNewWITSVIDResponse_Result{c.witSVIDResult},
+	}, nil
+}
+
 type fakeAgentServer struct {
 	agentv1.UnimplementedAgentServer
 	err  error
@@ -87,6 +87,10 @@ type Config struct {
 	// JWTSVIDCacheMaxSize is a soft limit of max number of JWT-SVIDs that would be stored in cache
 	JWTSVIDCacheMaxSize int
 
+	// DisableWITSVIDs, if true, WIT-SVIDs and WIT bundles are not served to
+	// workloads
+	DisableWITSVIDs bool
+
 	// Trust domain and associated CA bundle
 	TrustDomain spiffeid.TrustDomain
 
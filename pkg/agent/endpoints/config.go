@@ -61,6 +61,10 @@ type Config struct {
 	// WorkloadAPIRateLimit configures per-selector-set rate limiting for Workload API and SDS methods.
 	WorkloadAPIRateLimit WorkloadAPIRateLimitConfig
 
+	// DisableWITSVIDs, if true, the Workload API does not serve WIT-SVIDs nor
+	// WIT bundles
+	DisableWITSVIDs bool
+
 	// Hooks used by the unit tests to assert that the configuration provided
 	// to each handler is correct and return fake handlers.
 	newWorkloadAPIServer func(workload.Config) workload_pb.SpiffeWorkloadAPIServer
@@ -36,10 +36,10 @@ func (m *connectionMetrics) Preprocess(ctx context.Context, _ string, _ any) (co
 		case middleware.EnvoySDSv3ServiceName:
 			sdsAPITelemetry.IncrSDSAPIConnectionCounter(m.metrics)
 			sdsAPITelemetry.SetSDSAPIConnectionTotalGauge(m.metrics, atomic.AddInt32(&m.sdsAPIConns, 1))
-		case middleware.DelegatedIdentityServiceName:
+		case middleware.DelegatedIdentityServiceName, middleware.DelegatedIdentityExtensionServiceName:
 			adminapi.IncrDelegatedIdentityAPIConnectionCounter(m.metrics)
 			adminapi.SetDelegatedIdentityAPIConnectionGauge(m.metrics, atomic.AddInt32(&m.delegatedIdentityAPIConns, 1))
-		case middleware.DebugServiceName:
+		case middleware.DebugServiceName, middleware.DebugExtensionServiceName:
 			adminapi.IncrDebugAPIConnectionCounter(m.metrics)
 			adminapi.SetDebugAPIConnectionGauge(m.metrics, atomic.AddInt32(&m.debugAPIConns, 1))
 		case middleware.AgentLoggerServiceName:
@@ -61,9 +61,9 @@ func (m *connectionMetrics) Postprocess(ctx context.Context, _ string, _ bool, _
 			workloadAPITelemetry.SetConnectionTotalGauge(m.metrics, atomic.AddInt32(&m.workloadAPIConns, -1))
 		case middleware.EnvoySDSv3ServiceName:
 			sdsAPITelemetry.SetSDSAPIConnectionTotalGauge(m.metrics, atomic.AddInt32(&m.sdsAPIConns, -1))
-		case middleware.DelegatedIdentityServiceName:
+		case middleware.DelegatedIdentityServiceName, middleware.DelegatedIdentityExtensionServiceName:
 			adminapi.SetDelegatedIdentityAPIConnectionGauge(m.metrics, atomic.AddInt32(&m.delegatedIdentityAPIConns, -1))
-		case middleware.DebugServiceName:
+		case middleware.DebugServiceName, middleware.DebugExtensionServiceName:
 			adminapi.SetDebugAPIConnectionGauge(m.metrics, atomic.AddInt32(&m.debugAPIConns, -1))
 		case middleware.AgentLoggerServiceName:
 			adminapi.SetLoggerAPIConnectionGauge(m.metrics, atomic.AddInt32(&m.loggerAPIConns, -1))
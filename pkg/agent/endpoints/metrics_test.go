@@ -14,6 +14,8 @@ import (
 	delegatedidentityv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/agent/delegatedidentity/v1"
 	loggerv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/agent/logger/v1"
 	"github.com/spiffe/spire/pkg/common/peertracker"
+	spiredebug "github.com/spiffe/spire/proto/spire/agent/debug"
+	spiredelegatedidentity "github.com/spiffe/spire/proto/spire/agent/delegatedidentity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -92,8 +94,10 @@ func TestAllAgentServicesHandledByConnectionMetrics(t *testing.T) {
 			secret_v3.RegisterSecretDiscoveryServiceServer(s, &secret_v3.UnimplementedSecretDiscoveryServiceServer{})
 			grpc_health_v1.RegisterHealthServer(s, &grpc_health_v1.UnimplementedHealthServer{})
 			debugv1.RegisterDebugServer(s, &fakeDebugServer{})
+			spiredebug.RegisterAPIServer(s, &fakeDebugExtensionServer{})
 			loggerv1.RegisterLoggerServer(s, &fakeLoggerServer{})
 			delegatedidentityv1.RegisterDelegatedIdentityServer(s, &fakeDelegatedIdentityServer{})
+			spiredelegatedidentity.RegisterAPIServer(s, &fakeDelegatedIdentityExtensionServer{})
 		},
 		grpctest.Middleware(Middleware(log, metrics)),
 	)
@@ -131,6 +135,13 @@ func TestAllAgentServicesHandledByConnectionMetrics(t *testing.T) {
 		assertNoMisconfigurationLog(t, hook)
 	})
 
+	t.Run("DebugExtension", func(t *testing.T) {
+		hook.Reset()
+		client := spiredebug.NewAPIClient(conn)
+		_, _ = client.GetSyncStatus(ctx, &spiredebug.GetSyncStatusRequest{})
+		assertNoMisconfigurationLog(t, hook)
+	})
+
 	t.Run("Logger", func(t *testing.T) {
 		hook.Reset()
 		client := loggerv1.NewLoggerClient(conn)
@@ -144,6 +155,13 @@ func TestAllAgentServicesHandledByConnectionMetrics(t *testing.T) {
 		_, _ = client.FetchJWTSVIDs(ctx, &delegatedidentityv1.FetchJWTSVIDsRequest{})
 		assertNoMisconfigurationLog(t, hook)
 	})
+
+	t.Run("DelegatedIdentityExtension", func(t *testing.T) {
+		hook.Reset()
+		client := spiredelegatedidentity.NewAPIClient(conn)
+		_, _ = client.FetchWITSVIDs(ctx, &spiredelegatedidentity.FetchWITSVIDsRequest{})
+		assertNoMisconfigurationLog(t, hook)
+	})
 }
 
 func assertNoMisconfigurationLog(t *testing.T, hook *test.Hook) {
@@ -253,10 +271,18 @@ type fakeDebugServer struct {
 	debugv1.UnimplementedDebugServer
 }
 
+type fakeDebugExtensionServer struct {
+	spiredebug.UnimplementedAPIServer
+}
+
 type fakeDelegatedIdentityServer struct {
 	delegatedidentityv1.UnimplementedDelegatedIdentityServer
 }
 
+type fakeDelegatedIdentityExtensionServer struct {
+	spiredelegatedidentity.UnimplementedAPIServer
+}
+
 type fakeLoggerServer struct {
 	loggerv1.UnimplementedLoggerServer
 }
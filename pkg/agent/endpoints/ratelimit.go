@@ -108,6 +108,7 @@ func NewWorkloadRateLimiter(cfg WorkloadAPIRateLimitConfig, log logrus.FieldLogg
 		{workload.MethodFetchX509Bundles, cfg.FetchX509Bundles},
 		{workload.MethodFetchJWTBundles, cfg.FetchJWTBundles},
 		{sdsv3.MethodStreamSecrets, cfg.StreamSecrets},
+		{sdsv3.MethodDeltaSecrets, cfg.StreamSecrets},
 		{sdsv3.MethodFetchSecrets, cfg.FetchSecrets},
 	}
 
@@ -260,12 +260,13 @@ func TestNewWorkloadRateLimiterAllSixMethods(t *testing.T) {
 	}
 	rl := NewWorkloadRateLimiter(cfg, log, metrics)
 	require.NotNil(t, rl)
-	assert.Len(t, rl.limiters, 6)
+	assert.Len(t, rl.limiters, 7)
 	assert.Contains(t, rl.limiters, workload.MethodFetchX509SVID)
 	assert.Contains(t, rl.limiters, workload.MethodFetchJWTSVID)
 	assert.Contains(t, rl.limiters, workload.MethodFetchX509Bundles)
 	assert.Contains(t, rl.limiters, workload.MethodFetchJWTBundles)
 	assert.Contains(t, rl.limiters, sdsv3.MethodStreamSecrets)
+	assert.Contains(t, rl.limiters, sdsv3.MethodDeltaSecrets)
 	assert.Contains(t, rl.limiters, sdsv3.MethodFetchSecrets)
 }
 
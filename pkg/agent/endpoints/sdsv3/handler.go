@@ -3,12 +3,14 @@ package sdsv3
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 
@@ -34,7 +36,12 @@ const (
 	disableSPIFFECertValidationKey = "disable_spiffe_cert_validation"
 
 	MethodStreamSecrets = "/envoy.service.secret.v3.SecretDiscoveryService/StreamSecrets"
+	MethodDeltaSecrets  = "/envoy.service.secret.v3.SecretDiscoveryService/DeltaSecrets"
 	MethodFetchSecrets  = "/envoy.service.secret.v3.SecretDiscoveryService/FetchSecrets"
+
+	// wildcardResourceName is the resource name used by incremental xDS
+	// clients to explicitly subscribe to all resources.
+	wildcardResourceName = "*"
 )
 
 // RateLimiter enforces per-selector-set rate limiting on SDS methods.
@@ -253,8 +260,366 @@ func subListChanged(oldSubs []string, newSubs []string) (b bool) {
 	return false
 }
 
-func (h *Handler) DeltaSecrets(secret_v3.SecretDiscoveryService_DeltaSecretsServer) error {
-	return status.Error(codes.Unimplemented, "Method is not implemented")
+func (h *Handler) DeltaSecrets(stream secret_v3.SecretDiscoveryService_DeltaSecretsServer) error {
+	log := rpccontext.Logger(stream.Context())
+
+	selectors, err := h.c.Attestor.Attest(stream.Context())
+	if err != nil {
+		log.WithError(err).Error("Failed to attest the workload")
+		return workloadAttestationFailedError(stream.Context())
+	}
+
+	if err := h.rateLimit(stream.Context(), MethodDeltaSecrets, selectors); err != nil {
+		return err
+	}
+
+	sub, err := h.c.Manager.SubscribeToCacheChanges(stream.Context(), selectors)
+	if err != nil {
+		log.WithError(err).Error("Subscribe to cache changes failed")
+		return err
+	}
+	defer sub.Finish()
+
+	updch := sub.Updates()
+	reqch := make(chan *discovery_v3.DeltaDiscoveryRequest, 1)
+	errch := make(chan error, 1)
+
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if status.Code(err) == codes.Canceled || errors.Is(err, io.EOF) {
+					err = nil
+				}
+				errch <- err
+				return
+			}
+			reqch <- req
+		}
+	}()
+
+	var versionCounter int64
+	versionInfo := strconv.FormatInt(versionCounter, 10)
+	state := newDeltaState()
+	var upd *cache.WorkloadUpdate
+	for {
+		select {
+		case newReq := <-reqch:
+			log.WithFields(logrus.Fields{
+				telemetry.ResourceNames: newReq.ResourceNamesSubscribe,
+				telemetry.Nonce:         newReq.ResponseNonce,
+			}).Debug("Received DeltaSecrets request")
+			h.triggerReceivedHook()
+
+			// If there's error detail, always log it
+			if newReq.ErrorDetail != nil {
+				log.WithFields(logrus.Fields{
+					telemetry.Nonce: newReq.ResponseNonce,
+					telemetry.Error: newReq.ErrorDetail.Message,
+				}).Error("Envoy reported errors applying secrets")
+			}
+
+			// Only subscriptions to new resources require sending updates.
+			// ACKs, NACKs and unsubscriptions are fully handled by updating
+			// the stream state.
+			if !state.apply(newReq) {
+				continue
+			}
+
+			if upd == nil {
+				// Workload update has not been received yet, defer sending updates until then
+				continue
+			}
+
+		case upd = <-updch:
+			versionCounter++
+			versionInfo = strconv.FormatInt(versionCounter, 10)
+			if !state.initialized {
+				// Nothing has been requested yet.
+				continue
+			}
+		case err := <-errch:
+			if err != nil {
+				log.WithError(err).Error("Received error from delta secrets server")
+			}
+			return err
+		}
+
+		resp, err := h.buildDeltaResponse(versionInfo, state, upd)
+		if err != nil {
+			log.WithError(err).Error("Error building delta secrets response")
+			return err
+		}
+		if resp == nil {
+			// The client already has the latest version of every resource
+			continue
+		}
+
+		log.WithFields(logrus.Fields{
+			telemetry.VersionInfo: resp.SystemVersionInfo,
+			telemetry.Nonce:       resp.Nonce,
+			telemetry.Count:       len(resp.Resources),
+		}).Debug("Sending DeltaSecrets response")
+		if err := stream.Send(resp); err != nil {
+			log.WithError(err).Error("Error sending secrets over delta stream")
+			return err
+		}
+	}
+}
+
+// deltaState tracks the resources a client subscribed to on an incremental
+// xDS stream, along with the version of each resource the client has.
+type deltaState struct {
+	initialized bool
+	typeURL     string
+	node        *core_v3.Node
+
+	// wildcard is true when the client is subscribed to every resource the
+	// workload is entitled to.
+	wildcard bool
+
+	// subscriptions holds the names of the resources the client explicitly
+	// subscribed to.
+	subscriptions map[string]bool
+
+	// versions holds the version of each resource the client acknowledged.
+	versions map[string]string
+
+	// pending holds the versions sent in the responses the client has not
+	// acknowledged yet, in the order they were sent. They are committed to
+	// versions when the client ACKs the response, and dropped when the client
+	// NACKs it, so the resources are sent again on the next update.
+	pending []pendingResponse
+
+	// notFound holds the names of the subscribed resources that were
+	// reported to the client as removed because the workload is not entitled
+	// to them.
+	notFound map[string]bool
+}
+
+// pendingResponse holds the resource versions sent in a response, keyed by
+// resource name. Removed resources have an empty version.
+type pendingResponse struct {
+	nonce    string
+	versions map[string]string
+}
+
+func newDeltaState() *deltaState {
+	return &deltaState{
+		subscriptions: make(map[string]bool),
+		versions:      make(map[string]string),
+		notFound:      make(map[string]bool),
+	}
+}
+
+// apply updates the state with the subscription changes in the request. It
+// returns true if a response with the resources the client is now subscribed
+// to must be evaluated.
+func (s *deltaState) apply(req *discovery_v3.DeltaDiscoveryRequest) bool {
+	sendUpdates := false
+	if !s.initialized {
+		s.initialized = true
+		s.typeURL = req.TypeUrl
+		s.node = req.Node
+		// A first request without subscriptions is a legacy wildcard
+		// subscription.
+		s.wildcard = len(req.ResourceNamesSubscribe) == 0
+		maps.Copy(s.versions, req.InitialResourceVersions)
+		sendUpdates = true
+	}
+
+	if req.ResponseNonce != "" {
+		s.settle(req.ResponseNonce, req.ErrorDetail == nil)
+	}
+
+	for _, name := range req.ResourceNamesSubscribe {
+		if name == wildcardResourceName {
+			sendUpdates = sendUpdates || !s.wildcard
+			s.wildcard = true
+			continue
+		}
+		if !s.subscriptions[name] {
+			s.subscriptions[name] = true
+			sendUpdates = true
+		}
+	}
+
+	for _, name := range req.ResourceNamesUnsubscribe {
+		if name == wildcardResourceName {
+			s.wildcard = false
+			continue
+		}
+		delete(s.subscriptions, name)
+		delete(s.notFound, name)
+		// Once unsubscribed, the client forgets about the resource, so it
+		// must be sent again if the client subscribes to it later.
+		s.forget(name)
+	}
+
+	if !s.wildcard {
+		// Resources that were only sent because of the wildcard subscription
+		// are dropped by the client when it unsubscribes from the wildcard.
+		for name := range s.known() {
+			if !s.subscriptions[name] {
+				s.forget(name)
+			}
+		}
+	}
+
+	return sendUpdates
+}
+
+// settle commits the versions sent in the response with the given nonce when
+// the client ACKs it, and drops them when the client NACKs it, so that the
+// client is assumed to have the versions it had before the response.
+func (s *deltaState) settle(nonce string, ack bool) {
+	for i, p := range s.pending {
+		if p.nonce != nonce {
+			continue
+		}
+		if ack {
+			for name, version := range p.versions {
+				if version == "" {
+					delete(s.versions, name)
+				} else {
+					s.versions[name] = version
+				}
+			}
+		}
+		s.pending = slices.Delete(s.pending, i, i+1)
+		return
+	}
+}
+
+// known returns the version of each resource the client has, assuming the
+// pending responses are going to be acknowledged.
+func (s *deltaState) known() map[string]string {
+	known := maps.Clone(s.versions)
+	for _, p := range s.pending {
+		for name, version := range p.versions {
+			if version == "" {
+				delete(known, name)
+			} else {
+				known[name] = version
+			}
+		}
+	}
+	return known
+}
+
+// forget removes the resource from the acknowledged and pending versions.
+func (s *deltaState) forget(name string) {
+	delete(s.versions, name)
+	for _, p := range s.pending {
+		delete(p.versions, name)
+	}
+}
+
+// buildDeltaResponse builds a response containing the resources that changed
+// since they were last sent to the client and the resources that are no
+// longer available. It returns nil if there is nothing to send.
+func (h *Handler) buildDeltaResponse(versionInfo string, state *deltaState, upd *cache.WorkloadUpdate) (*discovery_v3.DeltaDiscoveryResponse, error) {
+	var resources []*anypb.Any
+	if state.wildcard {
+		resp, err := h.buildResponse("", &discovery_v3.DiscoveryRequest{
+			TypeUrl: state.typeURL,
+			Node:    state.node,
+		}, upd)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resp.Resources...)
+	}
+
+	// Explicit subscriptions are resolved leniently, so that a resource the
+	// workload is no longer entitled to is reported as removed instead of
+	// failing the stream.
+	var missing map[string]bool
+	if len(state.subscriptions) > 0 {
+		subscribed, subscribedMissing, err := h.buildResources(&discovery_v3.DiscoveryRequest{
+			TypeUrl:       state.typeURL,
+			Node:          state.node,
+			ResourceNames: sortedNames(state.subscriptions),
+		}, upd)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, subscribed...)
+		missing = subscribedMissing
+	}
+
+	resp := &discovery_v3.DeltaDiscoveryResponse{
+		TypeUrl:           state.typeURL,
+		SystemVersionInfo: versionInfo,
+	}
+
+	known := state.known()
+	sent := make(map[string]string)
+	current := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		secret := new(tls_v3.Secret)
+		if err := resource.UnmarshalTo(secret); err != nil {
+			return nil, err
+		}
+		if current[secret.Name] {
+			// Already included through the wildcard subscription
+			continue
+		}
+		current[secret.Name] = true
+		delete(state.notFound, secret.Name)
+
+		version := resourceVersion(resource)
+		if known[secret.Name] == version {
+			continue
+		}
+		sent[secret.Name] = version
+		resp.Resources = append(resp.Resources, &discovery_v3.Resource{
+			Name:     secret.Name,
+			Version:  version,
+			Resource: resource,
+		})
+	}
+
+	for name := range known {
+		if !current[name] {
+			sent[name] = ""
+			resp.RemovedResources = append(resp.RemovedResources, name)
+			if missing[name] {
+				state.notFound[name] = true
+			}
+		}
+	}
+	// Subscribed resources the workload is not entitled to, and that the
+	// client never received, are reported once as removed so the client
+	// knows they do not exist.
+	for name := range missing {
+		if !state.notFound[name] {
+			state.notFound[name] = true
+			resp.RemovedResources = append(resp.RemovedResources, name)
+		}
+	}
+	sort.Strings(resp.RemovedResources)
+
+	if len(resp.Resources) == 0 && len(resp.RemovedResources) == 0 {
+		return nil, nil
+	}
+
+	var err error
+	if resp.Nonce, err = nextNonce(); err != nil {
+		return nil, err
+	}
+	state.pending = append(state.pending, pendingResponse{
+		nonce:    resp.Nonce,
+		versions: sent,
+	})
+	return resp, nil
+}
+
+// resourceVersion returns a version for the resource derived from its
+// content, so unchanged resources keep their version across updates.
+func resourceVersion(resource *anypb.Any) string {
+	sum := sha256.Sum256(resource.Value)
+	return hex.EncodeToString(sum[:8])
 }
 
 func workloadAttestationFailedError(ctx context.Context) error {
@@ -305,6 +670,22 @@ func (h *Handler) buildResponse(versionInfo string, req *discovery_v3.DiscoveryR
 		}
 	}
 
+	resources, missing, err := h.buildResources(req, upd)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "workload is not authorized for the requested identities %q", sortedNames(missing))
+	}
+	resp.Resources = resources
+
+	return resp, nil
+}
+
+// buildResources builds the resources requested by name, or every resource
+// the workload is entitled to when no names are requested. Requested names
+// the workload is not entitled to are returned as missing.
+func (h *Handler) buildResources(req *discovery_v3.DiscoveryRequest, upd *cache.WorkloadUpdate) (resources []*anypb.Any, missing map[string]bool, err error) {
 	// build a convenient set of names for lookups
 	names := make(map[string]bool)
 	for _, name := range req.ResourceNames {
@@ -316,7 +697,7 @@ func (h *Handler) buildResponse(versionInfo string, req *discovery_v3.DiscoveryR
 
 	builder, err := h.getValidationContextBuilder(req, upd)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// TODO: verify the type url
@@ -325,29 +706,29 @@ func (h *Handler) buildResponse(versionInfo string, req *discovery_v3.DiscoveryR
 		case returnAllEntries || names[upd.Bundle.TrustDomain().IDString()]:
 			validationContext, err := builder.buildOne(upd.Bundle.TrustDomain().IDString(), upd.Bundle.TrustDomain().IDString())
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			delete(names, upd.Bundle.TrustDomain().IDString())
-			resp.Resources = append(resp.Resources, validationContext)
+			resources = append(resources, validationContext)
 
 		case names[h.c.DefaultBundleName]:
 			validationContext, err := builder.buildOne(h.c.DefaultBundleName, upd.Bundle.TrustDomain().IDString())
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			delete(names, h.c.DefaultBundleName)
-			resp.Resources = append(resp.Resources, validationContext)
+			resources = append(resources, validationContext)
 
 		case names[h.c.DefaultAllBundlesName]:
 			validationContext, err := builder.buildAll(h.c.DefaultAllBundlesName)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			delete(names, h.c.DefaultAllBundlesName)
-			resp.Resources = append(resp.Resources, validationContext)
+			resources = append(resources, validationContext)
 		}
 	}
 
@@ -355,10 +736,10 @@ func (h *Handler) buildResponse(versionInfo string, req *discovery_v3.DiscoveryR
 		if returnAllEntries || names[federatedBundle.TrustDomain().IDString()] {
 			validationContext, err := builder.buildOne(td.IDString(), td.IDString())
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			delete(names, federatedBundle.TrustDomain().IDString())
-			resp.Resources = append(resp.Resources, validationContext)
+			resources = append(resources, validationContext)
 		}
 	}
 
@@ -367,25 +748,21 @@ func (h *Handler) buildResponse(versionInfo string, req *discovery_v3.DiscoveryR
 		case returnAllEntries || names[identity.Entry.SpiffeId]:
 			tlsCertificate, err := buildTLSCertificate(identity, "")
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			delete(names, identity.Entry.SpiffeId)
-			resp.Resources = append(resp.Resources, tlsCertificate)
+			resources = append(resources, tlsCertificate)
 		case i == 0 && names[h.c.DefaultSVIDName]:
 			tlsCertificate, err := buildTLSCertificate(identity, h.c.DefaultSVIDName)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			delete(names, h.c.DefaultSVIDName)
-			resp.Resources = append(resp.Resources, tlsCertificate)
+			resources = append(resources, tlsCertificate)
 		}
 	}
 
-	if len(names) > 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "workload is not authorized for the requested identities %q", sortedNames(names))
-	}
-
-	return resp, nil
+	return resources, names, nil
 }
 
 func (h *Handler) triggerReceivedHook() {
@@ -868,6 +868,264 @@ func TestStreamSecretsErrInSubscribeToCacheChanges(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+func TestDeltaSecrets(t *testing.T) {
+	test := setupTest(t)
+	defer test.server.Stop()
+
+	stream, err := test.handler.DeltaSecrets(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stream.CloseSend())
+	}()
+
+	// A first request without subscriptions is a wildcard subscription
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		Node: &core_v3.Node{
+			UserAgentVersionType: userAgentVersionTypeV17,
+		},
+	})
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.SystemVersionInfo)
+	require.NotEmpty(t, resp.Nonce)
+	require.Empty(t, resp.RemovedResources)
+	requireDeltaSecrets(t, resp, tdValidationContext, fedValidationContext, workloadTLSCertificate1)
+
+	// Ack the response
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResponseNonce: resp.Nonce,
+	})
+
+	// Only the rotated SVID is sent
+	test.setWorkloadUpdate(workloadCert2)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Empty(t, resp.RemovedResources)
+	requireDeltaSecrets(t, resp, workloadTLSCertificate2)
+
+	// Resources no longer available are removed
+	test.manager.SetWorkloadUpdate(&cache.WorkloadUpdate{
+		Identities: []cache.Identity{
+			{
+				Entry: &common.RegistrationEntry{
+					SpiffeId: "spiffe://domain.test/workload",
+				},
+				SVID:       []*x509.Certificate{workloadCert2},
+				PrivateKey: workloadKey,
+			},
+		},
+		Bundle: tdBundle,
+	})
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Empty(t, resp.Resources)
+	require.Equal(t, []string{"spiffe://otherdomain.test"}, resp.RemovedResources)
+}
+
+func TestDeltaSecretsNack(t *testing.T) {
+	test := setupTest(t)
+	defer test.server.Stop()
+
+	stream, err := test.handler.DeltaSecrets(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stream.CloseSend())
+	}()
+
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResourceNamesSubscribe: []string{"spiffe://domain.test/workload", "spiffe://domain.test"},
+		Node: &core_v3.Node{
+			UserAgentVersionType: userAgentVersionTypeV17,
+		},
+	})
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	requireDeltaSecrets(t, resp, tdValidationContext, workloadTLSCertificate1)
+
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResponseNonce: resp.Nonce,
+	})
+
+	test.setWorkloadUpdate(workloadCert2)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	requireDeltaSecrets(t, resp, workloadTLSCertificate2)
+
+	// Nack the rotated SVID
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResponseNonce: resp.Nonce,
+		ErrorDetail:   &status.Status{Message: "OHNO!"},
+	})
+
+	// The client still has the previous SVID, so the rotated SVID is sent
+	// again on the next update, while the acknowledged bundle is not
+	test.setWorkloadUpdate(workloadCert2)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Empty(t, resp.RemovedResources)
+	requireDeltaSecrets(t, resp, workloadTLSCertificate2)
+}
+
+func TestDeltaSecretsSubscriptions(t *testing.T) {
+	test := setupTest(t)
+	defer test.server.Stop()
+
+	stream, err := test.handler.DeltaSecrets(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stream.CloseSend())
+	}()
+
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResourceNamesSubscribe: []string{"spiffe://domain.test/workload"},
+		Node: &core_v3.Node{
+			UserAgentVersionType: userAgentVersionTypeV17,
+		},
+	})
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	requireDeltaSecrets(t, resp, workloadTLSCertificate1)
+
+	// Subscribing to another resource only sends the new resource
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResponseNonce:          resp.Nonce,
+		ResourceNamesSubscribe: []string{"spiffe://domain.test"},
+	})
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	requireDeltaSecrets(t, resp, tdValidationContext)
+
+	// Unsubscribed resources are not sent when they change
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResponseNonce:            resp.Nonce,
+		ResourceNamesUnsubscribe: []string{"spiffe://domain.test/workload"},
+	})
+	test.setWorkloadUpdate(workloadCert2)
+
+	// Subscribing again sends the current version of the resource
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResourceNamesSubscribe: []string{"spiffe://domain.test/workload"},
+	})
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	requireDeltaSecrets(t, resp, workloadTLSCertificate2)
+}
+
+func TestDeltaSecretsInitialResourceVersions(t *testing.T) {
+	test := setupTest(t)
+	defer test.server.Stop()
+
+	workloadResource, err := anypb.New(workloadTLSCertificate1)
+	require.NoError(t, err)
+
+	stream, err := test.handler.DeltaSecrets(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stream.CloseSend())
+	}()
+
+	// Resources the client already has are not sent again
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResourceNamesSubscribe: []string{"spiffe://domain.test/workload", "spiffe://domain.test"},
+		InitialResourceVersions: map[string]string{
+			"spiffe://domain.test/workload": resourceVersion(workloadResource),
+		},
+		Node: &core_v3.Node{
+			UserAgentVersionType: userAgentVersionTypeV17,
+		},
+	})
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	requireDeltaSecrets(t, resp, tdValidationContext)
+}
+
+func TestDeltaSecretsNotAuthorized(t *testing.T) {
+	test := setupTest(t)
+	defer test.server.Stop()
+
+	stream, err := test.handler.DeltaSecrets(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stream.CloseSend())
+	}()
+
+	// Resources the workload is not entitled to are reported as removed,
+	// without failing the stream
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResourceNamesSubscribe: []string{"spiffe://domain.test/other"},
+	})
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Empty(t, resp.Resources)
+	require.Equal(t, []string{"spiffe://domain.test/other"}, resp.RemovedResources)
+
+	// The stream keeps serving the other subscriptions
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResponseNonce:          resp.Nonce,
+		ResourceNamesSubscribe: []string{"spiffe://domain.test/workload"},
+	})
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Empty(t, resp.RemovedResources)
+	requireDeltaSecrets(t, resp, workloadTLSCertificate1)
+}
+
+func TestDeltaSecretsSubscribedResourceRemoved(t *testing.T) {
+	test := setupTest(t)
+	defer test.server.Stop()
+
+	stream, err := test.handler.DeltaSecrets(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stream.CloseSend())
+	}()
+
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResourceNamesSubscribe: []string{"spiffe://domain.test/workload", "spiffe://domain.test"},
+		Node: &core_v3.Node{
+			UserAgentVersionType: userAgentVersionTypeV17,
+		},
+	})
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	requireDeltaSecrets(t, resp, tdValidationContext, workloadTLSCertificate1)
+
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{
+		ResponseNonce: resp.Nonce,
+	})
+
+	// The explicitly subscribed SVID is no longer available to the workload
+	test.manager.SetWorkloadUpdate(&cache.WorkloadUpdate{
+		Bundle: tdBundle,
+	})
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Empty(t, resp.Resources)
+	require.Equal(t, []string{"spiffe://domain.test/workload"}, resp.RemovedResources)
+
+	// The SVID is sent again once it is available
+	test.setWorkloadUpdate(workloadCert2)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Empty(t, resp.RemovedResources)
+	requireDeltaSecrets(t, resp, workloadTLSCertificate2)
+}
+
+func TestDeltaSecretsErrInSubscribeToCacheChanges(t *testing.T) {
+	test := setupErrTest(t)
+	defer test.server.Stop()
+
+	stream, err := test.handler.DeltaSecrets(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stream.CloseSend())
+	}()
+
+	resp, err := stream.Recv()
+	require.Error(t, err)
+	require.Nil(t, resp)
+}
+
 func TestFetchSecrets(t *testing.T) {
 	for _, tt := range []struct {
 		name          string
@@ -1254,6 +1512,28 @@ func TestStreamSecretsRateLimit(t *testing.T) {
 	spiretest.RequireGRPCStatusContains(t, err, codes.Unavailable, "rate limit exceeded")
 }
 
+func TestDeltaSecretsRateLimit(t *testing.T) {
+	rl := newFakeRateLimiter(1)
+	test := setupTestWithConfigAsPID(t, Config{RateLimiter: rl}, os.Getpid()+1)
+	defer test.cleanup()
+
+	// First stream open is within the limit.
+	stream, err := test.handler.DeltaSecrets(context.Background())
+	require.NoError(t, err)
+	test.sendAndWaitDelta(stream, &discovery_v3.DeltaDiscoveryRequest{ResourceNamesSubscribe: []string{"default"}})
+	require.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	// Second stream open exhausts the limit.
+	stream2, err := test.handler.DeltaSecrets(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream2.Send(&discovery_v3.DeltaDiscoveryRequest{}))
+	_, err = stream2.Recv()
+	require.Error(t, err)
+	spiretest.RequireGRPCStatusContains(t, err, codes.Unavailable, "rate limit exceeded")
+}
+
 func TestFetchSecretsRateLimit(t *testing.T) {
 	rl := newFakeRateLimiter(1)
 	// Use a caller PID that is not the agent's own so the agent exemption does
@@ -1423,6 +1703,17 @@ func (h *handlerTest) sendAndWait(stream secret_v3.SecretDiscoveryService_Stream
 	}
 }
 
+func (h *handlerTest) sendAndWaitDelta(stream secret_v3.SecretDiscoveryService_DeltaSecretsClient, req *discovery_v3.DeltaDiscoveryRequest) {
+	require.NoError(h.t, stream.Send(req))
+	timer := time.NewTimer(time.Second)
+	defer timer.Stop()
+	select {
+	case <-h.received:
+	case <-timer.C:
+		assert.Fail(h.t, "timed out waiting for request to be received")
+	}
+}
+
 type FakeAttestor []*common.Selector
 
 func (a FakeAttestor) Attest(context.Context) ([]*common.Selector, error) {
@@ -1553,3 +1844,16 @@ func requireSecrets(t *testing.T, resp *discovery_v3.DiscoveryResponse, expected
 
 	spiretest.RequireProtoListEqual(t, expectedSecrets, actualSecrets)
 }
+
+func requireDeltaSecrets(t *testing.T, resp *discovery_v3.DeltaDiscoveryResponse, expectedSecrets ...*tls_v3.Secret) {
+	var actualSecrets []*tls_v3.Secret
+	for _, resource := range resp.Resources {
+		secret := new(tls_v3.Secret)
+		require.NoError(t, resource.Resource.UnmarshalTo(secret))
+		require.Equal(t, secret.Name, resource.Name)
+		require.Equal(t, resourceVersion(resource.Resource), resource.Version)
+		actualSecrets = append(actualSecrets, secret)
+	}
+
+	spiretest.RequireProtoListEqual(t, expectedSecrets, actualSecrets)
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/exp/bundle/witbundle"
 	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/agent/api/rpccontext"
@@ -37,6 +38,8 @@ type Manager interface {
 	MatchingRegistrationEntries(selectors []*common.Selector) []*common.RegistrationEntry
 	FetchJWTSVID(ctx context.Context, entry *common.RegistrationEntry, audience []string) (*client.JWTSVID, error)
 	FetchWorkloadUpdate([]*common.Selector) *cache.WorkloadUpdate
+	FetchWITSVID(ctx context.Context, entry *common.RegistrationEntry) (*cache.WITSVID, error)
+	SubscribeToWITBundleChanges() *cache.WITBundleStream
 }
 
 type Attestor interface {
@@ -53,6 +56,16 @@ const (
 	MethodFetchJWTSVID     = "/SpiffeWorkloadAPI/FetchJWTSVID"
 	MethodFetchX509Bundles = "/SpiffeWorkloadAPI/FetchX509Bundles"
 	MethodFetchJWTBundles  = "/SpiffeWorkloadAPI/FetchJWTBundles"
+	MethodFetchWITSVID     = "/SpiffeWorkloadAPI/FetchWITSVID"
+	MethodFetchWITBundles  = "/SpiffeWorkloadAPI/FetchWITBundles"
+)
+
+const (
+	// witSVIDMinRetryDelay and witSVIDMaxRetryDelay bound how often a
+	// FetchWITSVID stream checks back for renewed WIT-SVIDs once the ones it
+	// sent are past their refresh time.
+	witSVIDMinRetryDelay = 5 * time.Second
+	witSVIDMaxRetryDelay = 2 * time.Minute
 )
 
 type Config struct {
@@ -63,6 +76,7 @@ type Config struct {
 	AllowedForeignJWTClaims       map[string]struct{}
 	LogSelectors                  []string
 	TrustDomain                   spiffeid.TrustDomain
+	DisableWITSVIDs               bool
 }
 
 // Handler implements the Workload API interface
@@ -321,12 +335,118 @@ func (h *Handler) FetchX509Bundles(_ *workload.X509BundlesRequest, stream worklo
 	}
 }
 
-func (h *Handler) FetchWITSVID(_ *workload.WITSVIDRequest, stream workload.SpiffeWorkloadAPI_FetchWITSVIDServer) error {
-	return status.Error(codes.Unimplemented, "fetching WIT-SVIDs is not implemented")
+// FetchWITSVID processes request for WIT-SVIDs. A new response is sent each
+// time the workload identities change or the WIT-SVIDs are rotated.
+func (h *Handler) FetchWITSVID(req *workload.WITSVIDRequest, stream workload.SpiffeWorkloadAPI_FetchWITSVIDServer) error {
+	ctx := stream.Context()
+	start := time.Now()
+	log := rpccontext.Logger(ctx)
+
+	if h.c.DisableWITSVIDs {
+		return status.Error(codes.Unimplemented, "WIT functionality is disabled")
+	}
+
+	if req.SpiffeId != "" {
+		if _, err := spiffeid.FromString(req.SpiffeId); err != nil {
+			log.WithField(telemetry.SPIFFEID, req.SpiffeId).WithError(err).Error("Invalid requested SPIFFE ID")
+			return status.Errorf(codes.InvalidArgument, "invalid requested SPIFFE ID: %v", err)
+		}
+	}
+
+	selectors, err := h.c.Attestor.Attest(ctx)
+	if err != nil {
+		loggerWithContextInfo(ctx, log, start, err).Error("Workload attestation failed")
+		return workloadAttestationFailedError(ctx)
+	}
+
+	if err := h.rateLimit(ctx, MethodFetchWITSVID, selectors); err != nil {
+		return err
+	}
+
+	subscriber, err := h.c.Manager.SubscribeToCacheChanges(ctx, selectors)
+	if err != nil {
+		loggerWithContextInfo(ctx, log, start, err).Error("Subscribe to cache changes failed")
+		return err
+	}
+	defer subscriber.Finish()
+
+	// The rotation timer fires once the WIT-SVIDs sent to the workload are
+	// due for rotation, so that renewed WIT-SVIDs are pushed down the stream.
+	rotationTimer := time.NewTimer(0)
+	rotationTimer.Stop()
+	defer rotationTimer.Stop()
+
+	var identities []cache.Identity
+	var previousResp *workload.WITSVIDResponse
+	retryDelay := witSVIDMinRetryDelay
+	for {
+		select {
+		case update := <-subscriber.Updates():
+			identities = hintsfilter.FilterIdentities(update.Identities, log)
+		case <-rotationTimer.C:
+		case <-ctx.Done():
+			return nil
+		}
+
+		var refreshAt time.Time
+		previousResp, refreshAt, err = h.sendWITSVIDResponse(ctx, identities, req.SpiffeId, stream, selectors, log, previousResp, start)
+		if err != nil {
+			return err
+		}
+		var delay time.Duration
+		delay, retryDelay = witSVIDRotationDelay(time.Until(refreshAt), retryDelay)
+		rotationTimer.Reset(delay)
+	}
 }
 
+// FetchWITBundles processes request for WIT bundles
 func (h *Handler) FetchWITBundles(_ *workload.WITBundlesRequest, stream workload.SpiffeWorkloadAPI_FetchWITBundlesServer) error {
-	return status.Error(codes.Unimplemented, "fetching WIT bundles is not implemented")
+	ctx := stream.Context()
+	start := time.Now()
+	log := rpccontext.Logger(ctx)
+
+	if h.c.DisableWITSVIDs {
+		return status.Error(codes.Unimplemented, "WIT functionality is disabled")
+	}
+
+	selectors, err := h.c.Attestor.Attest(ctx)
+	if err != nil {
+		loggerWithContextInfo(ctx, log, start, err).Error("Workload attestation failed")
+		return workloadAttestationFailedError(ctx)
+	}
+
+	if err := h.rateLimit(ctx, MethodFetchWITBundles, selectors); err != nil {
+		return err
+	}
+
+	subscriber, err := h.c.Manager.SubscribeToCacheChanges(ctx, selectors)
+	if err != nil {
+		loggerWithContextInfo(ctx, log, start, err).Error("Subscribe to cache changes failed")
+		return err
+	}
+	defer subscriber.Finish()
+
+	witBundles := h.c.Manager.SubscribeToWITBundleChanges()
+
+	var update *cache.WorkloadUpdate
+	var previousResp *workload.WITBundlesResponse
+	for {
+		select {
+		case update = <-subscriber.Updates():
+		case <-witBundles.Changes():
+			witBundles.Next()
+			if update == nil {
+				// Nothing to send until the first workload update arrives
+				continue
+			}
+		case <-ctx.Done():
+			return nil
+		}
+
+		if previousResp, err = h.sendWITBundlesResponse(update, witBundles.Value(), stream, selectors, log, previousResp, start); err != nil {
+			return err
+		}
+	}
 }
 
 func workloadAttestationFailedError(ctx context.Context) error {
@@ -539,6 +659,133 @@ func composeJWTBundlesResponse(update *cache.WorkloadUpdate) (*workload.JWTBundl
 	}, nil
 }
 
+func (h *Handler) sendWITSVIDResponse(ctx context.Context, identities []cache.Identity, spiffeID string, stream workload.SpiffeWorkloadAPI_FetchWITSVIDServer, selectors []*common.Selector, log logrus.FieldLogger, previousResponse *workload.WITSVIDResponse, start time.Time) (*workload.WITSVIDResponse, time.Time, error) {
+	resp := &workload.WITSVIDResponse{}
+	var refreshAt time.Time
+	for _, identity := range identities {
+		if spiffeID != "" && identity.Entry.SpiffeId != spiffeID {
+			continue
+		}
+
+		loopLog := log.WithField(telemetry.SPIFFEID, identity.Entry.SpiffeId)
+		svid, err := h.c.Manager.FetchWITSVID(ctx, identity.Entry)
+		if err != nil {
+			loggerWithContextInfo(ctx, loopLog, start, err).Error("Could not fetch WIT-SVID")
+			return nil, time.Time{}, status.Errorf(codes.Unavailable, "could not fetch WIT-SVID: %v", err)
+		}
+
+		keyData, err := svid.MarshalKey()
+		if err != nil {
+			loopLog.WithError(err).Error("Could not serialize WIT-SVID key")
+			return nil, time.Time{}, status.Errorf(codes.Unavailable, "could not serialize response: %v", err)
+		}
+
+		resp.Svids = append(resp.Svids, &workload.WITSVID{
+			SpiffeId:   identity.Entry.SpiffeId,
+			WitSvid:    svid.SVID.Token,
+			WitSvidKey: keyData,
+			Hint:       identity.Entry.Hint,
+		})
+
+		if svidRefreshAt := witSVIDRefreshTime(svid); refreshAt.IsZero() || svidRefreshAt.Before(refreshAt) {
+			refreshAt = svidRefreshAt
+		}
+	}
+
+	if len(resp.Svids) == 0 {
+		h.logNoIdentityIssued(ctx, log, selectors, start)
+		return nil, time.Time{}, status.Error(codes.PermissionDenied, "no identity issued")
+	}
+
+	if proto.Equal(resp, previousResponse) {
+		return previousResponse, refreshAt, nil
+	}
+
+	if err := stream.Send(resp); err != nil {
+		loggerWithContextInfo(ctx, log, start, err).Error("Failed to send WIT-SVID response")
+		return nil, time.Time{}, err
+	}
+
+	log.WithFields(logrus.Fields{
+		telemetry.Registered: true,
+		telemetry.Count:      len(resp.Svids),
+	}).Debug("Fetched WIT-SVIDs")
+
+	return resp, refreshAt, nil
+}
+
+// witSVIDRefreshTime returns when a WIT-SVID sent to a workload should be
+// re-evaluated for rotation. The manager rotates WIT-SVIDs around the half of
+// their lifetime (with some jitter), so the stream checks back past that
+// point.
+func witSVIDRefreshTime(svid *cache.WITSVID) time.Time {
+	lifetime := svid.SVID.ExpiresAt.Sub(svid.SVID.IssuedAt)
+	return svid.SVID.IssuedAt.Add(lifetime * 6 / 10)
+}
+
+// witSVIDRotationDelay returns how long to wait before re-evaluating the
+// WIT-SVIDs sent to a workload, along with the retry delay to use next time.
+// When the refresh time has already passed, e.g. because the manager keeps
+// returning the cached WIT-SVID while renewal fails, the stream backs off
+// instead of asking the manager again right away.
+func witSVIDRotationDelay(untilRefresh, retryDelay time.Duration) (time.Duration, time.Duration) {
+	if untilRefresh > 0 {
+		return untilRefresh, witSVIDMinRetryDelay
+	}
+	return retryDelay, min(retryDelay*2, witSVIDMaxRetryDelay)
+}
+
+func (h *Handler) sendWITBundlesResponse(update *cache.WorkloadUpdate, witBundles map[spiffeid.TrustDomain]*witbundle.Bundle, stream workload.SpiffeWorkloadAPI_FetchWITBundlesServer, selectors []*common.Selector, log logrus.FieldLogger, previousResponse *workload.WITBundlesResponse, start time.Time) (*workload.WITBundlesResponse, error) {
+	ctx := stream.Context()
+	if !h.c.AllowUnauthenticatedVerifiers && !update.HasIdentity() {
+		h.logNoIdentityIssued(ctx, log, selectors, start)
+		return nil, status.Error(codes.PermissionDenied, "no identity issued")
+	}
+
+	resp, err := h.composeWITBundlesResponse(update, witBundles)
+	if err != nil {
+		log.WithError(err).Error("Could not serialize WIT bundle response")
+		return nil, status.Errorf(codes.Unavailable, "could not serialize response: %v", err)
+	}
+
+	if proto.Equal(resp, previousResponse) {
+		return previousResponse, nil
+	}
+
+	if err := stream.Send(resp); err != nil {
+		loggerWithContextInfo(ctx, log, start, err).Error("Failed to send WIT bundle response")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h *Handler) composeWITBundlesResponse(update *cache.WorkloadUpdate, witBundles map[spiffeid.TrustDomain]*witbundle.Bundle) (*workload.WITBundlesResponse, error) {
+	trustDomains := []spiffeid.TrustDomain{h.c.TrustDomain}
+	if update.HasIdentity() {
+		for td := range update.FederatedBundles {
+			trustDomains = append(trustDomains, td)
+		}
+	}
+
+	bundles := make(map[string]string, len(trustDomains))
+	for _, td := range trustDomains {
+		witBundle, ok := witBundles[td]
+		if !ok {
+			witBundle = witbundle.New(td)
+		}
+		jwksBytes, err := witBundle.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		bundles[td.IDString()] = string(jwksBytes)
+	}
+
+	return &workload.WITBundlesResponse{
+		Bundles: bundles,
+	}, nil
+}
+
 // isAgent returns true if the caller PID from the provided context is the
 // agent's process ID.
 func isAgent(ctx context.Context) bool {
@@ -12,9 +12,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-jose/go-jose/v4"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/exp/bundle/witbundle"
 	workloadPB "github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/jwtsvid"
@@ -30,6 +32,7 @@ import (
 	"github.com/spiffe/spire/test/grpctest"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/testca"
+	"github.com/spiffe/spire/test/testkey"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -1509,6 +1512,311 @@ func TestFetchJWTBundles_SpuriousUpdates(t *testing.T) {
 		})
 }
 
+func TestFetchWITSVID(t *testing.T) {
+	ca := testca.New(t, td)
+
+	x509SVID1 := ca.CreateX509SVID(workloadID)
+	x509SVID2 := ca.CreateX509SVID(spiffeid.RequireFromPath(td, "/another"))
+
+	identities := []cache.Identity{
+		identityFromX509SVID(x509SVID1, "id1"),
+		identityFromX509SVID(x509SVID2, "id2"),
+	}
+
+	witKey := testkey.NewEC256(t)
+	witKeyJWK, err := (&jose.JSONWebKey{Key: witKey, Algorithm: "ES256"}).MarshalJSON()
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name            string
+		spiffeID        string
+		identities      []cache.Identity
+		disableWITSVIDs bool
+		attestErr       error
+		witSVIDErr      error
+		expectCode      codes.Code
+		expectMsg       string
+		expectResp      *workloadPB.WITSVIDResponse
+		expectLogs      []spiretest.LogEntry
+	}{
+		{
+			name:            "WIT-SVIDs disabled",
+			identities:      identities,
+			disableWITSVIDs: true,
+			expectCode:      codes.Unimplemented,
+			expectMsg:       "WIT functionality is disabled",
+		},
+		{
+			name:       "spiffe_id set, but not a valid SPIFFE ID",
+			spiffeID:   "foo",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "invalid requested SPIFFE ID: scheme is missing or invalid",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid requested SPIFFE ID",
+					Data: logrus.Fields{
+						"service":       "WorkloadAPI",
+						"method":        "FetchWITSVID",
+						"spiffe_id":     "foo",
+						logrus.ErrorKey: "scheme is missing or invalid",
+					},
+				},
+			},
+		},
+		{
+			name:       "no identity issued",
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "no identity issued",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "No identity issued",
+					Data: logrus.Fields{
+						"registered": "false",
+						"service":    "WorkloadAPI",
+						"method":     "FetchWITSVID",
+					},
+				},
+			},
+		},
+		{
+			name:       "attest error",
+			attestErr:  errors.New("ohno"),
+			expectCode: codes.Unavailable,
+			expectMsg:  "workload attestation failed",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Workload attestation failed",
+					Data: logrus.Fields{
+						"service":       "WorkloadAPI",
+						"method":        "FetchWITSVID",
+						logrus.ErrorKey: "ohno",
+					},
+				},
+			},
+		},
+		{
+			name:       "fetch error",
+			identities: identities,
+			witSVIDErr: errors.New("ohno"),
+			expectCode: codes.Unavailable,
+			expectMsg:  "could not fetch WIT-SVID: ohno",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Could not fetch WIT-SVID",
+					Data: logrus.Fields{
+						"service":       "WorkloadAPI",
+						"method":        "FetchWITSVID",
+						"spiffe_id":     x509SVID1.ID.String(),
+						logrus.ErrorKey: "ohno",
+					},
+				},
+			},
+		},
+		{
+			name:       "success with all identities",
+			identities: identities,
+			expectCode: codes.OK,
+			expectResp: &workloadPB.WITSVIDResponse{
+				Svids: []*workloadPB.WITSVID{
+					{
+						SpiffeId:   x509SVID1.ID.String(),
+						WitSvid:    "wit-" + x509SVID1.ID.String(),
+						WitSvidKey: string(witKeyJWK),
+					},
+					{
+						SpiffeId:   x509SVID2.ID.String(),
+						WitSvid:    "wit-" + x509SVID2.ID.String(),
+						WitSvidKey: string(witKeyJWK),
+					},
+				},
+			},
+		},
+		{
+			name:       "success with requested SPIFFE ID",
+			spiffeID:   x509SVID2.ID.String(),
+			identities: identities,
+			expectCode: codes.OK,
+			expectResp: &workloadPB.WITSVIDResponse{
+				Svids: []*workloadPB.WITSVID{
+					{
+						SpiffeId:   x509SVID2.ID.String(),
+						WitSvid:    "wit-" + x509SVID2.ID.String(),
+						WitSvidKey: string(witKeyJWK),
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testParams{
+				CA:              ca,
+				Updates:         []*cache.WorkloadUpdate{{Identities: tt.identities}},
+				AttestErr:       tt.attestErr,
+				ExpectLogs:      tt.expectLogs,
+				DisableWITSVIDs: tt.disableWITSVIDs,
+				WITKey:          witKey,
+				WITSVIDErr:      tt.witSVIDErr,
+			}
+			runTest(t, params,
+				func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+					stream, err := client.FetchWITSVID(ctx, &workloadPB.WITSVIDRequest{SpiffeId: tt.spiffeID})
+					require.NoError(t, err)
+
+					resp, err := stream.Recv()
+					spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+					spiretest.RequireProtoEqual(t, tt.expectResp, resp)
+				})
+		})
+	}
+}
+
+func TestFetchWITSVIDBacksOffWhenRefreshIsPast(t *testing.T) {
+	ca := testca.New(t, td)
+	x509SVID := ca.CreateX509SVID(workloadID)
+
+	// The WIT-SVID returned by the manager is past its refresh time, as
+	// happens when the manager keeps returning the cached WIT-SVID because
+	// renewal fails.
+	fetches := new(atomic.Int32)
+	params := testParams{
+		CA:              ca,
+		Updates:         []*cache.WorkloadUpdate{{Identities: []cache.Identity{identityFromX509SVID(x509SVID, "id1")}}},
+		WITKey:          testkey.NewEC256(t),
+		WITSVIDIssuedAt: time.Now().Add(-50 * time.Minute),
+		WITSVIDFetches:  fetches,
+	}
+	runTest(t, params,
+		func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+			stream, err := client.FetchWITSVID(ctx, &workloadPB.WITSVIDRequest{})
+			require.NoError(t, err)
+
+			_, err = stream.Recv()
+			require.NoError(t, err)
+
+			// Without a retry delay the stream would fetch the WIT-SVID
+			// again in a tight loop.
+			time.Sleep(100 * time.Millisecond)
+			require.Equal(t, int32(1), fetches.Load())
+		})
+}
+
+func TestFetchWITBundles(t *testing.T) {
+	ca := testca.New(t, td)
+
+	x509SVID := ca.CreateX509SVID(workloadID)
+
+	witBundle := witbundle.FromWITAuthorities(td, map[string]crypto.PublicKey{
+		"kid": testkey.NewEC256(t).Public(),
+	})
+	witBundleJWKS, err := witBundle.Marshal()
+	require.NoError(t, err)
+
+	emptyWITBundleJWKS, err := witbundle.New(td2).Marshal()
+	require.NoError(t, err)
+
+	bundle := ca.Bundle()
+	federatedBundle := testca.New(t, td2).Bundle()
+
+	for _, tt := range []struct {
+		name                          string
+		updates                       []*cache.WorkloadUpdate
+		disableWITSVIDs               bool
+		allowUnauthenticatedVerifiers bool
+		expectCode                    codes.Code
+		expectMsg                     string
+		expectResp                    *workloadPB.WITBundlesResponse
+		expectLogs                    []spiretest.LogEntry
+	}{
+		{
+			name:            "WIT-SVIDs disabled",
+			updates:         []*cache.WorkloadUpdate{{}},
+			disableWITSVIDs: true,
+			expectCode:      codes.Unimplemented,
+			expectMsg:       "WIT functionality is disabled",
+		},
+		{
+			name:       "no identity issued",
+			updates:    []*cache.WorkloadUpdate{{}},
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "no identity issued",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "No identity issued",
+					Data: logrus.Fields{
+						"registered": "false",
+						"service":    "WorkloadAPI",
+						"method":     "FetchWITBundles",
+					},
+				},
+			},
+		},
+		{
+			name: "success",
+			updates: []*cache.WorkloadUpdate{
+				{
+					Identities: []cache.Identity{
+						identityFromX509SVID(x509SVID, "id1"),
+					},
+					Bundle: bundle,
+					FederatedBundles: map[spiffeid.TrustDomain]*spiffebundle.Bundle{
+						federatedBundle.TrustDomain(): federatedBundle,
+					},
+				},
+			},
+			expectCode: codes.OK,
+			expectResp: &workloadPB.WITBundlesResponse{
+				Bundles: map[string]string{
+					td.IDString():  string(witBundleJWKS),
+					td2.IDString(): string(emptyWITBundleJWKS),
+				},
+			},
+		},
+		{
+			name:                          "when allowed to fetch without identity",
+			allowUnauthenticatedVerifiers: true,
+			updates: []*cache.WorkloadUpdate{
+				{
+					Bundle: bundle,
+					FederatedBundles: map[spiffeid.TrustDomain]*spiffebundle.Bundle{
+						federatedBundle.TrustDomain(): federatedBundle,
+					},
+				},
+			},
+			expectCode: codes.OK,
+			expectResp: &workloadPB.WITBundlesResponse{
+				Bundles: map[string]string{
+					td.IDString(): string(witBundleJWKS),
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testParams{
+				CA:                            ca,
+				Updates:                       tt.updates,
+				ExpectLogs:                    tt.expectLogs,
+				AllowUnauthenticatedVerifiers: tt.allowUnauthenticatedVerifiers,
+				DisableWITSVIDs:               tt.disableWITSVIDs,
+				WITBundles:                    map[spiffeid.TrustDomain]*witbundle.Bundle{td: witBundle},
+			}
+			runTest(t, params,
+				func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+					stream, err := client.FetchWITBundles(ctx, &workloadPB.WITBundlesRequest{})
+					require.NoError(t, err)
+
+					resp, err := stream.Recv()
+					spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+					spiretest.RequireProtoEqual(t, tt.expectResp, resp)
+				})
+		})
+	}
+}
+
 func TestValidateJWTSVID(t *testing.T) {
 	ca := testca.New(t, td)
 	ca2 := testca.New(t, td2)
@@ -1802,16 +2110,30 @@ type testParams struct {
 	AllowUnauthenticatedVerifiers bool
 	AllowedForeignJWTClaims       map[string]struct{}
 	RateLimiter                   workload.RateLimiter
+	DisableWITSVIDs               bool
+	WITKey                        crypto.Signer
+	WITSVIDErr                    error
+	WITSVIDIssuedAt               time.Time
+	WITSVIDFetches                *atomic.Int32
+	WITBundles                    map[spiffeid.TrustDomain]*witbundle.Bundle
 }
 
 func runTest(t *testing.T, params testParams, fn func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient)) {
 	log, logHook := test.NewNullLogger()
 
 	manager := &FakeManager{
-		ca:         params.CA,
-		identities: params.Identities,
-		updates:    params.Updates,
-		err:        params.ManagerErr,
+		ca:          params.CA,
+		identities:  params.Identities,
+		updates:     params.Updates,
+		err:         params.ManagerErr,
+		witKey:      params.WITKey,
+		witErr:      params.WITSVIDErr,
+		witIssuedAt: params.WITSVIDIssuedAt,
+		witFetches:  params.WITSVIDFetches,
+		witBundles:  cache.NewWITBundleCache(),
+	}
+	if params.WITBundles != nil {
+		manager.witBundles.Update(params.WITBundles)
 	}
 
 	handler := workload.New(workload.Config{
@@ -1822,6 +2144,7 @@ func runTest(t *testing.T, params testParams, fn func(ctx context.Context, clien
 		AllowedForeignJWTClaims:       params.AllowedForeignJWTClaims,
 		RateLimiter:                   params.RateLimiter,
 		LogSelectors:                  params.LogSelectors,
+		DisableWITSVIDs:               params.DisableWITSVIDs,
 	})
 
 	server := grpctest.StartServer(t, func(s grpc.ServiceRegistrar) {
@@ -1858,6 +2181,11 @@ type FakeManager struct {
 	updates     []*cache.WorkloadUpdate
 	subscribers atomic.Int32
 	err         error
+	witKey      crypto.Signer
+	witErr      error
+	witIssuedAt time.Time
+	witFetches  *atomic.Int32
+	witBundles  *cache.WITBundleCache
 }
 
 func (m *FakeManager) MatchingRegistrationEntries([]*common.Selector) []*common.RegistrationEntry {
@@ -1883,6 +2211,32 @@ func (m *FakeManager) FetchJWTSVID(_ context.Context, entry *common.Registration
 	}, nil
 }
 
+func (m *FakeManager) FetchWITSVID(_ context.Context, entry *common.RegistrationEntry) (*cache.WITSVID, error) {
+	if m.witFetches != nil {
+		m.witFetches.Add(1)
+	}
+	if m.witErr != nil {
+		return nil, m.witErr
+	}
+	issuedAt := m.witIssuedAt
+	if issuedAt.IsZero() {
+		issuedAt = time.Now()
+	}
+	return &cache.WITSVID{
+		SVID: &client.WITSVID{
+			Token:     "wit-" + entry.SpiffeId,
+			IssuedAt:  issuedAt,
+			ExpiresAt: issuedAt.Add(time.Hour),
+		},
+		PrivateKey:       m.witKey,
+		SigningAlgorithm: "ES256",
+	}, nil
+}
+
+func (m *FakeManager) SubscribeToWITBundleChanges() *cache.WITBundleStream {
+	return m.witBundles.SubscribeToWITBundleChanges()
+}
+
 func (m *FakeManager) SubscribeToCacheChanges(context.Context, cache.Selectors) (cache.Subscriber, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -14,6 +14,7 @@ import (
 	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/backoff"
+	commonselector "github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	agentmetrics "github.com/spiffe/spire/pkg/common/telemetry/agent"
 	"github.com/spiffe/spire/pkg/common/x509util"
@@ -58,6 +59,18 @@ type StaleEntry struct {
 	SVIDExpiresAt time.Time
 }
 
+// CachedEntry holds a cached registration entry with the status of its SVID
+type CachedEntry struct {
+	// Entry cached registration entry
+	Entry *common.RegistrationEntry
+	// HasSVID is true if an SVID is cached for the entry
+	HasSVID bool
+	// SVIDs expiration time, zero if no SVID is cached
+	SVIDExpiresAt time.Time
+	// Stale is true if the entry requires a new SVID
+	Stale bool
+}
+
 // Cache caches each registration entry, bundles, and JWT SVIDs for the agent.
 // The signed X509-SVIDs for those entries are stored in LRU-like cache.
 // It allows subscriptions by (workload) selector sets and notifies subscribers when:
@@ -207,6 +220,30 @@ func (c *LRUCache) Entries() []*common.RegistrationEntry {
 	return out
 }
 
+// CachedEntries returns all the registration entries in the cache, sorted by
+// entry ID, along with the status of their SVIDs.
+func (c *LRUCache) CachedEntries() []*CachedEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make([]*CachedEntry, 0, len(c.records))
+	for entryID, record := range c.records {
+		cachedEntry := &CachedEntry{
+			Entry: record.entry,
+			Stale: c.staleEntries[entryID],
+		}
+		if svid, ok := c.svids[entryID]; ok {
+			cachedEntry.HasSVID = true
+			cachedEntry.SVIDExpiresAt = svid.Chain[0].NotAfter
+		}
+		out = append(out, cachedEntry)
+	}
+	sort.Slice(out, func(a, b int) bool {
+		return out[a].Entry.EntryId < out[b].Entry.EntryId
+	})
+	return out
+}
+
 func (c *LRUCache) CountX509SVIDs() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -247,7 +284,9 @@ func (c *LRUCache) NewSubscriber(selectors []*common.Selector) Subscriber {
 
 	sub := newLRUCacheSubscriber(c, selectors)
 	for s := range sub.set {
-		c.addSelectorIndexSub(s, sub)
+		for _, key := range commonselector.IndexKeys(commonselector.Selector(s)) {
+			c.addSelectorIndexSub(selector(key), sub)
+		}
 	}
 	// update lastAccessTimestamp of records containing provided selectors
 	c.updateLastAccessTimestamp(selectors)
@@ -323,9 +362,9 @@ func (c *LRUCache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.R
 			// built a set of selectors for the record being removed, drop the
 			// record for each selector index, and add the entry selectors to
 			// the notify set.
+			c.delSelectorIndicesEntry(record.entry, record)
 			notifySet, notifySetDone := allocSelectorSet(record.entry.Selectors...)
 			defer notifySetDone()
-			c.delSelectorIndicesRecord(notifySet, record)
 			notifySets = append(notifySets, notifySet)
 			delete(c.records, id)
 			delete(c.svids, id)
@@ -353,8 +392,9 @@ func (c *LRUCache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.R
 		// notify set.
 		c.diffSelectors(existingEntry, newEntry, selAdd, selRem)
 		selectorsChanged := len(selAdd) > 0 || len(selRem) > 0
-		c.addSelectorIndicesRecord(selAdd, record)
-		c.delSelectorIndicesRecord(selRem, record)
+		if selectorsChanged {
+			c.reindexRecord(existingEntry, record)
+		}
 
 		// Determine if there were changes to FederatesWith declarations or
 		// if any federated bundles related to the entry were updated.
@@ -711,7 +751,7 @@ func (c *LRUCache) subscribeToWorkloadUpdates(ctx context.Context, selectors Sel
 			}
 			return subscriber, nil
 		}
-		c.log.WithField(telemetry.Selectors, selectors).Info("Waiting for SVID to get cached")
+		c.log.WithField(telemetry.Selectors, commonselector.WithoutUnknown(selectors)).Info("Waiting for SVID to get cached")
 		// used for testing
 		if notifyCallbackFn != nil {
 			notifyCallbackFn()
@@ -764,8 +804,10 @@ func (c *LRUCache) syncSVIDsWithSubscribers() (map[string]struct{}, []recordAcce
 	//       so that SVID will be cached in next sync
 	// 2. get lastAccessTimestamp of each entry
 	for id, record := range c.records {
-		for _, sel := range record.entry.Selectors {
-			if index, ok := c.selectors[makeSelector(sel)]; ok && index != nil {
+		candidates, candidatesDone := allocSelectorSet()
+		candidates.MergeCandidates(record.entry.Selectors...)
+		for sel := range candidates {
+			if index, ok := c.selectors[sel]; ok && index != nil {
 				if len(index.subs) > 0 {
 					if _, ok := c.svids[record.entry.EntryId]; !ok {
 						c.staleEntries[id] = true
@@ -775,6 +817,7 @@ func (c *LRUCache) syncSVIDsWithSubscribers() (map[string]struct{}, []recordAcce
 				}
 			}
 		}
+		candidatesDone()
 		lastAccessTimestamps = append(lastAccessTimestamps, newRecordAccessEvent(record.lastAccessTimestamp, id))
 	}
 
@@ -862,8 +905,34 @@ func (c *LRUCache) addSelectorIndexRecord(s selector, record *lruCacheRecord) {
 	index.records[record] = struct{}{}
 }
 
-func (c *LRUCache) delSelectorIndicesRecord(selectors selectorSet, record *lruCacheRecord) {
-	for selector := range selectors {
+// reindexRecord updates the selector indices of the record after the
+// selectors of its entry changed. Records are indexed by the candidate
+// selectors of the entry, so that entries with selector expressions are found
+// through the plain selectors of workloads.
+func (c *LRUCache) reindexRecord(existingEntry *common.RegistrationEntry, record *lruCacheRecord) {
+	candidates, candidatesDone := allocSelectorSet()
+	defer candidatesDone()
+	candidates.MergeCandidates(record.entry.Selectors...)
+
+	if existingEntry != nil {
+		oldCandidates, oldCandidatesDone := allocSelectorSet()
+		defer oldCandidatesDone()
+		oldCandidates.MergeCandidates(existingEntry.Selectors...)
+		for selector := range oldCandidates {
+			if _, ok := candidates[selector]; !ok {
+				c.delSelectorIndexRecord(selector, record)
+			}
+		}
+	}
+
+	c.addSelectorIndicesRecord(candidates, record)
+}
+
+func (c *LRUCache) delSelectorIndicesEntry(entry *common.RegistrationEntry, record *lruCacheRecord) {
+	candidates, candidatesDone := allocSelectorSet()
+	defer candidatesDone()
+	candidates.MergeCandidates(entry.Selectors...)
+	for selector := range candidates {
 		c.delSelectorIndexRecord(selector, record)
 	}
 }
@@ -900,8 +969,10 @@ func (c *LRUCache) delSelectorIndexSub(s selector, sub *lruCacheSubscriber) {
 func (c *LRUCache) unsubscribe(sub *lruCacheSubscriber) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	for selector := range sub.set {
-		c.delSelectorIndexSub(selector, sub)
+	for s := range sub.set {
+		for _, key := range commonselector.IndexKeys(commonselector.Selector(s)) {
+			c.delSelectorIndexSub(selector(key), sub)
+		}
 	}
 }
 
@@ -920,7 +991,7 @@ func (c *LRUCache) notifyBySelectorSet(sets ...selectorSet) {
 		subs, subsDone := c.getSubscribers(set)
 		defer subsDone()
 		for sub := range subs {
-			if _, notified := notifiedSubs[sub]; !notified && sub.set.SuperSetOf(set) {
+			if _, notified := notifiedSubs[sub]; !notified && sub.set.MatchesSet(set) {
 				c.notify(sub)
 				notifiedSubs[sub] = struct{}{}
 			}
@@ -943,12 +1014,16 @@ func (c *LRUCache) allSubscribers() (lruCacheSubscriberSet, func()) {
 	return subs, subsDone
 }
 
+// getSubscribers returns the subscribers that may be interested in entries
+// with the given selectors, which may be selector expressions.
 func (c *LRUCache) getSubscribers(set selectorSet) (lruCacheSubscriberSet, func()) {
 	subs, subsDone := allocLRUCacheSubscriberSet()
 	for s := range set {
-		if index := c.getSelectorIndexForRead(s); index != nil {
-			for sub := range index.subs {
-				subs[sub] = struct{}{}
+		for _, candidate := range commonselector.Candidates(commonselector.Selector(s)) {
+			if index := c.getSelectorIndexForRead(selector(candidate)); index != nil {
+				for sub := range index.subs {
+					subs[sub] = struct{}{}
+				}
 			}
 		}
 	}
@@ -1033,21 +1108,21 @@ func (c *LRUCache) getRecordsForSelectors(set selectorSet) (lruCacheRecordSet, f
 	// that is a more expensive operation, and we could easily have duplicate
 	// entries to check.
 	records, recordsDone := allocLRUCacheRecordSet()
-	for selector := range set {
-		if index := c.getSelectorIndexForRead(selector); index != nil {
-			for record := range index.records {
-				records[record] = struct{}{}
+	for s := range set {
+		for _, key := range commonselector.IndexKeys(commonselector.Selector(s)) {
+			if index := c.getSelectorIndexForRead(selector(key)); index != nil {
+				for record := range index.records {
+					records[record] = struct{}{}
+				}
 			}
 		}
 	}
 
-	// Filter out records whose registration entry selectors are not within
-	// inside the selector set.
+	// Filter out records whose registration entry selectors are not
+	// satisfied by the selector set.
 	for record := range records {
-		for _, s := range record.entry.Selectors {
-			if !set.In(s) {
-				delete(records, record)
-			}
+		if !set.Matches(record.entry.Selectors...) {
+			delete(records, record)
 		}
 	}
 	return records, recordsDone
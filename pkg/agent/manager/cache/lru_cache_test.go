@@ -299,6 +299,117 @@ func TestLRUCacheSubscribersGetEntriesWithSelectorSubsets(t *testing.T) {
 	})
 }
 
+func TestLRUCacheSubscribersGetEntriesWithSelectorExpressions(t *testing.T) {
+	cache := newTestLRUCache(t)
+
+	subA := subscribeToWorkloadUpdates(t, cache, makeSelectors("A"))
+	defer subA.Finish()
+	subB := subscribeToWorkloadUpdates(t, cache, makeSelectors("B"))
+	defer subB.Finish()
+	subAC := subscribeToWorkloadUpdates(t, cache, makeSelectors("A", "C"))
+	defer subAC.Finish()
+	subD := subscribeToWorkloadUpdates(t, cache, makeSelectors("D"))
+	defer subD.Finish()
+	// the attestor of the "test" selectors failed for this subscriber, so
+	// negations on the type cannot be proven
+	subAUnknown := subscribeToWorkloadUpdates(t, cache, append(makeSelectors("A"), &common.Selector{Type: "?test"}))
+	defer subAUnknown.Finish()
+
+	initialUpdate := &WorkloadUpdate{Bundle: bundleV1}
+	assertWorkloadUpdateEqual(t, subA, initialUpdate)
+	assertWorkloadUpdateEqual(t, subB, initialUpdate)
+	assertWorkloadUpdateEqual(t, subAC, initialUpdate)
+	assertWorkloadUpdateEqual(t, subD, initialUpdate)
+	assertWorkloadUpdateEqual(t, subAUnknown, initialUpdate)
+
+	// create entry FOO that targets subscribers with (A) or (B), but not (C)
+	foo := makeRegistrationEntry("FOO")
+	foo.Selectors = []*common.Selector{
+		{Type: "|test", Value: "A|B"},
+		{Type: "!test", Value: "C"},
+	}
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo),
+	})
+
+	fooUpdate := &WorkloadUpdate{
+		Bundle:     bundleV1,
+		Identities: []Identity{{Entry: foo}},
+	}
+	assertWorkloadUpdateEqual(t, subA, fooUpdate)
+	assertWorkloadUpdateEqual(t, subB, fooUpdate)
+	assertNoWorkloadUpdate(t, subAC)
+	assertNoWorkloadUpdate(t, subD)
+	assertNoWorkloadUpdate(t, subAUnknown)
+
+	// drop the negation from FOO and make sure the subscriber with (A,C)
+	// gets FOO, even though the entry is still indexed by the same selectors
+	foo = makeRegistrationEntry("FOO")
+	foo.Selectors = []*common.Selector{
+		{Type: "|test", Value: "A|B"},
+	}
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo),
+	})
+
+	assertWorkloadUpdateEqual(t, subAC, &WorkloadUpdate{
+		Bundle:     bundleV1,
+		Identities: []Identity{{Entry: foo}},
+	})
+	assertWorkloadUpdateEqual(t, subAUnknown, &WorkloadUpdate{
+		Bundle:     bundleV1,
+		Identities: []Identity{{Entry: foo}},
+	})
+	assertNoWorkloadUpdate(t, subD)
+}
+
+func TestLRUCacheSubscribersGetEntriesWithSelectorPatterns(t *testing.T) {
+	cache := newTestLRUCache(t)
+
+	subA1 := subscribeToWorkloadUpdates(t, cache, makeSelectors("A1"))
+	defer subA1.Finish()
+	subA2B := subscribeToWorkloadUpdates(t, cache, makeSelectors("A2", "B"))
+	defer subA2B.Finish()
+	subB := subscribeToWorkloadUpdates(t, cache, makeSelectors("B"))
+	defer subB.Finish()
+
+	initialUpdate := &WorkloadUpdate{Bundle: bundleV1}
+	assertWorkloadUpdateEqual(t, subA1, initialUpdate)
+	assertWorkloadUpdateEqual(t, subA2B, initialUpdate)
+	assertWorkloadUpdateEqual(t, subB, initialUpdate)
+
+	// create entry FOO that targets subscribers with a selector matching A*
+	foo := makeRegistrationEntry("FOO")
+	foo.Selectors = []*common.Selector{{Type: "~test", Value: "A*"}}
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo),
+	})
+
+	fooUpdate := &WorkloadUpdate{
+		Bundle:     bundleV1,
+		Identities: []Identity{{Entry: foo}},
+	}
+	assertWorkloadUpdateEqual(t, subA1, fooUpdate)
+	assertWorkloadUpdateEqual(t, subA2B, fooUpdate)
+	assertNoWorkloadUpdate(t, subB)
+
+	// the entry is also found for workloads without a subscription
+	require.Equal(t, []*common.RegistrationEntry{foo}, cache.MatchingRegistrationEntries(makeSelectors("A3")))
+	require.Empty(t, cache.MatchingRegistrationEntries(makeSelectors("B")))
+}
+
 func TestLRUCacheSubscriberIsNotNotifiedIfNothingChanges(t *testing.T) {
 	cache := newTestLRUCache(t)
 
@@ -639,6 +750,34 @@ func TestLRUCacheGetStaleEntries(t *testing.T) {
 	assert.Empty(t, cache.GetStaleEntries())
 }
 
+func TestLRUCacheCachedEntries(t *testing.T) {
+	cache := newTestLRUCache(t)
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar),
+	}, nil)
+
+	// Both entries are stale since they do not have SVIDs cached
+	assert.Equal(t, []*CachedEntry{
+		{Entry: cache.records[bar.EntryId].entry, Stale: true},
+		{Entry: cache.records[foo.EntryId].entry, Stale: true},
+	}, cache.CachedEntries())
+
+	expiresAt := time.Now()
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: map[string]*X509SVID{
+			foo.EntryId: {Chain: []*x509.Certificate{{NotAfter: expiresAt}}},
+		},
+	})
+
+	assert.Equal(t, []*CachedEntry{
+		{Entry: cache.records[bar.EntryId].entry, Stale: true},
+		{Entry: cache.records[foo.EntryId].entry, HasSVID: true, SVIDExpiresAt: expiresAt},
+	}, cache.CachedEntries())
+}
+
 func TestLRUCacheSubscriberNotNotifiedOnDifferentSVIDChanges(t *testing.T) {
 	cache := newTestLRUCache(t)
 
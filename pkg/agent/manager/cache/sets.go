@@ -3,6 +3,7 @@ package cache
 import (
 	"sync"
 
+	commonselector "github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/proto/spire/common"
 )
 
@@ -97,18 +98,32 @@ func (set selectorSet) MergeSet(other selectorSet) {
 	}
 }
 
-func (set selectorSet) In(ss ...*common.Selector) bool {
+// MergeCandidates adds the index keys of the given entry selectors,
+// expanding selector expressions into their candidate selectors.
+func (set selectorSet) MergeCandidates(ss ...*common.Selector) {
 	for _, s := range ss {
-		if _, ok := set[makeSelector(s)]; !ok {
+		for _, candidate := range commonselector.Candidates(commonselector.Selector{Type: s.Type, Value: s.Value}) {
+			set[selector(candidate)] = struct{}{}
+		}
+	}
+}
+
+// Matches returns true if the set satisfies all of the given entry
+// selectors, which may be selector expressions.
+func (set selectorSet) Matches(ss ...*common.Selector) bool {
+	for _, s := range ss {
+		if !commonselector.Match(commonselector.Selector{Type: s.Type, Value: s.Value}, set) {
 			return false
 		}
 	}
 	return true
 }
 
-func (set selectorSet) SuperSetOf(other selectorSet) bool {
+// MatchesSet returns true if the set satisfies all of the entry selectors
+// in other, which may be selector expressions.
+func (set selectorSet) MatchesSet(other selectorSet) bool {
 	for k := range other {
-		if _, ok := set[k]; !ok {
+		if !commonselector.Match(commonselector.Selector(k), set) {
 			return false
 		}
 	}
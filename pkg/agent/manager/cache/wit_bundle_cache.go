@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"maps"
+
+	"github.com/spiffe/go-spiffe/v2/exp/bundle/witbundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/observer"
+)
+
+type WITBundleCache struct {
+	bundles observer.Property
+}
+
+func NewWITBundleCache() *WITBundleCache {
+	return &WITBundleCache{
+		bundles: observer.NewProperty(map[spiffeid.TrustDomain]*witbundle.Bundle{}),
+	}
+}
+
+// Update replaces the cached WIT bundles. Subscribers are only notified when
+// the WIT authorities of at least one trust domain changed.
+func (c *WITBundleCache) Update(bundles map[spiffeid.TrustDomain]*witbundle.Bundle) {
+	if witBundlesEqual(c.Bundles(), bundles) {
+		return
+	}
+
+	// the bundle map must be copied so that the source can be mutated
+	// afterward.
+	out := make(map[spiffeid.TrustDomain]*witbundle.Bundle, len(bundles))
+	maps.Copy(out, bundles)
+	c.bundles.Update(out)
+}
+
+func (c *WITBundleCache) Bundles() map[spiffeid.TrustDomain]*witbundle.Bundle {
+	return c.bundles.Value().(map[spiffeid.TrustDomain]*witbundle.Bundle)
+}
+
+func (c *WITBundleCache) SubscribeToWITBundleChanges() *WITBundleStream {
+	return &WITBundleStream{
+		stream: c.bundles.Observe(),
+	}
+}
+
+// WITBundleStream wraps an observer stream to provide a type safe interface
+type WITBundleStream struct {
+	stream observer.Stream
+}
+
+// Value returns the current value for this stream.
+func (b *WITBundleStream) Value() map[spiffeid.TrustDomain]*witbundle.Bundle {
+	return b.stream.Value().(map[spiffeid.TrustDomain]*witbundle.Bundle)
+}
+
+// Changes returns the channel that is closed when a new value is available.
+func (b *WITBundleStream) Changes() chan struct{} {
+	return b.stream.Changes()
+}
+
+// Next advances this stream to the next state.
+// You should never call this unless Changes channel is closed.
+func (b *WITBundleStream) Next() map[spiffeid.TrustDomain]*witbundle.Bundle {
+	value, _ := b.stream.Next().(map[spiffeid.TrustDomain]*witbundle.Bundle)
+	return value
+}
+
+func witBundlesEqual(a, b map[spiffeid.TrustDomain]*witbundle.Bundle) bool {
+	return maps.EqualFunc(a, b, func(x, y *witbundle.Bundle) bool {
+		return x.Equal(y)
+	})
+}
@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"container/list"
+	"crypto"
+	"sync"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/agent"
+)
+
+// WITSVID holds a WIT-SVID along with the private key it is bound to.
+type WITSVID struct {
+	SVID             *client.WITSVID
+	PrivateKey       crypto.Signer
+	SigningAlgorithm string
+
+	// RevisionNumber is the revision of the registration entry the WIT-SVID
+	// was issued for.
+	RevisionNumber int64
+}
+
+// MarshalKey returns the private key of the WIT-SVID as a JSON Web Key, as
+// served to workloads alongside the WIT-SVID.
+func (s *WITSVID) MarshalKey() (string, error) {
+	jwk := jose.JSONWebKey{
+		Key:       s.PrivateKey,
+		Algorithm: s.SigningAlgorithm,
+	}
+	keyData, err := jwk.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	return string(keyData), nil
+}
+
+type WITSVIDCache struct {
+	log     logrus.FieldLogger
+	metrics telemetry.Metrics
+	mu      sync.RWMutex
+
+	svids   map[string]*list.Element
+	lruList *list.List
+
+	// svidCacheMaxSize is a hard limit of max number of SVIDs that would be stored in cache
+	svidCacheMaxSize int
+}
+
+type witSvidElement struct {
+	entryID string
+	svid    *WITSVID
+}
+
+func NewWITSVIDCache(log logrus.FieldLogger, metrics telemetry.Metrics, svidCacheMaxSize int) *WITSVIDCache {
+	if svidCacheMaxSize <= 0 {
+		svidCacheMaxSize = DefaultSVIDCacheMaxSize
+	}
+	return &WITSVIDCache{
+		metrics:          metrics,
+		log:              log,
+		svids:            make(map[string]*list.Element),
+		lruList:          list.New(),
+		svidCacheMaxSize: svidCacheMaxSize,
+	}
+}
+
+func (c *WITSVIDCache) CountWITSVIDs() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.svids)
+}
+
+// GetWITSVID returns the cached WIT-SVID for the given registration entry.
+func (c *WITSVIDCache) GetWITSVID(entryID string) (*WITSVID, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	svidElement, ok := c.svids[entryID]
+	if !ok {
+		return nil, false
+	}
+	c.lruList.MoveToFront(svidElement)
+
+	return svidElement.Value.(witSvidElement).svid, true
+}
+
+// SetWITSVID adds or replaces the WIT-SVID cached for the given registration
+// entry, evicting the least recently used WIT-SVID if the cache is full.
+func (c *WITSVIDCache) SetWITSVID(entryID string, svid *WITSVID) {
+	defer func() { agent.SetWITSVIDCacheSize(c.metrics, c.CountWITSVIDs()) }()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element := witSvidElement{
+		entryID: entryID,
+		svid:    svid,
+	}
+
+	if svidElement, ok := c.svids[entryID]; ok {
+		svidElement.Value = element
+		c.lruList.MoveToFront(svidElement)
+		return
+	}
+
+	if len(c.svids) >= c.svidCacheMaxSize {
+		back := c.lruList.Back()
+		delete(c.svids, back.Value.(witSvidElement).entryID)
+		c.lruList.Remove(back)
+	}
+
+	c.svids[entryID] = c.lruList.PushFront(element)
+}
+
+// PruneWITSVIDs removes the WIT-SVIDs for entries that are no longer in the
+// given set of registration entry IDs.
+func (c *WITSVIDCache) PruneWITSVIDs(entryIDs map[string]struct{}) {
+	defer func() { agent.SetWITSVIDCacheSize(c.metrics, c.CountWITSVIDs()) }()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for entryID, element := range c.svids {
+		if _, ok := entryIDs[entryID]; !ok {
+			delete(c.svids, entryID)
+			c.lruList.Remove(element)
+		}
+	}
+}
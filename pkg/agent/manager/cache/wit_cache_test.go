@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/exp/bundle/witbundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWITSVIDCache(t *testing.T) {
+	now := time.Now()
+	witSVID1 := &WITSVID{
+		SVID:             &client.WITSVID{Token: "token-1", IssuedAt: now, ExpiresAt: now.Add(time.Minute)},
+		PrivateKey:       testkey.NewEC256(t),
+		SigningAlgorithm: "ES256",
+	}
+	witSVID2 := &WITSVID{
+		SVID:             &client.WITSVID{Token: "token-2", IssuedAt: now, ExpiresAt: now.Add(time.Minute)},
+		PrivateKey:       testkey.NewEC256(t),
+		SigningAlgorithm: "ES256",
+	}
+
+	log, _ := test.NewNullLogger()
+	fakeMetrics := fakemetrics.New()
+	cache := NewWITSVIDCache(log, fakeMetrics, 2)
+
+	// WIT-SVID is not cached
+	actual, ok := cache.GetWITSVID("entry-1")
+	assert.False(t, ok)
+	assert.Nil(t, actual)
+
+	// WIT-SVID is cached
+	cache.SetWITSVID("entry-1", witSVID1)
+	actual, ok = cache.GetWITSVID("entry-1")
+	assert.True(t, ok)
+	assert.Equal(t, witSVID1, actual)
+
+	// WIT-SVID is replaced
+	cache.SetWITSVID("entry-1", witSVID2)
+	actual, ok = cache.GetWITSVID("entry-1")
+	assert.True(t, ok)
+	assert.Equal(t, witSVID2, actual)
+	assert.Equal(t, 1, cache.CountWITSVIDs())
+
+	// Least recently used WIT-SVID is evicted once the cache is full
+	cache.SetWITSVID("entry-2", witSVID1)
+	_, _ = cache.GetWITSVID("entry-1")
+	cache.SetWITSVID("entry-3", witSVID1)
+	assert.Equal(t, 2, cache.CountWITSVIDs())
+	_, ok = cache.GetWITSVID("entry-2")
+	assert.False(t, ok)
+	_, ok = cache.GetWITSVID("entry-1")
+	assert.True(t, ok)
+
+	// WIT-SVIDs for removed entries are pruned
+	cache.PruneWITSVIDs(map[string]struct{}{"entry-3": {}})
+	assert.Equal(t, 1, cache.CountWITSVIDs())
+	_, ok = cache.GetWITSVID("entry-1")
+	assert.False(t, ok)
+	_, ok = cache.GetWITSVID("entry-3")
+	assert.True(t, ok)
+
+	lastMetric := fakeMetrics.AllMetrics()[len(fakeMetrics.AllMetrics())-1]
+	assert.Equal(t, fakemetrics.MetricItem{
+		Type: fakemetrics.SetGaugeType,
+		Key:  []string{telemetry.WITSVIDCacheSize},
+		Val:  1,
+	}, lastMetric)
+}
+
+func TestWITBundleCache(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+	key := testkey.NewEC256(t).Public()
+
+	cache := NewWITBundleCache()
+	stream := cache.SubscribeToWITBundleChanges()
+	require.Empty(t, stream.Value())
+
+	bundle := witbundle.FromWITAuthorities(td, map[string]crypto.PublicKey{"kid": key})
+	cache.Update(map[spiffeid.TrustDomain]*witbundle.Bundle{td: bundle})
+
+	select {
+	case <-stream.Changes():
+	default:
+		require.FailNow(t, "expected stream to be notified")
+	}
+	require.Equal(t, map[spiffeid.TrustDomain]*witbundle.Bundle{td: bundle}, stream.Next())
+
+	// Updating with equal content does not notify subscribers
+	cache.Update(map[spiffeid.TrustDomain]*witbundle.Bundle{td: bundle.Clone()})
+	select {
+	case <-stream.Changes():
+		require.FailNow(t, "unexpected stream notification")
+	default:
+	}
+}
@@ -45,6 +45,7 @@ type Config struct {
 	SVIDStoreCache           *storecache.Cache
 	X509SVIDCacheMaxSize     int
 	JWTSVIDCacheMaxSize      int
+	DisableWITSVIDs          bool
 	DisableLRUCache          bool
 	NodeAttestor             nodeattestor.NodeAttestor
 	RotationStrategy         *rotationutil.RotationStrategy
@@ -78,6 +79,10 @@ func newManager(c *Config) *manager {
 
 	jwtCache := managerCache.NewJWTSVIDCache(logger, c.Metrics, c.JWTSVIDCacheMaxSize)
 
+	// WIT-SVIDs are issued per registration entry, as X509-SVIDs are, so the
+	// same upper bound applies to both caches.
+	witCache := managerCache.NewWITSVIDCache(logger, c.Metrics, c.X509SVIDCacheMaxSize)
+
 	rotCfg := &svid.RotatorConfig{
 		SVIDKeyManager:   keymanager.ForSVID(c.Catalog.GetKeyManager()),
 		Log:              c.Log,
@@ -99,6 +104,8 @@ func newManager(c *Config) *manager {
 	m := &manager{
 		cache:          cache,
 		jwtCache:       jwtCache,
+		witCache:       witCache,
+		witBundleCache: managerCache.NewWITBundleCache(),
 		c:              c,
 		mtx:            new(sync.RWMutex),
 		svid:           svidRotator,
@@ -81,18 +81,42 @@ type Manager interface {
 	// is no JWT cached, the manager will get one signed upstream.
 	FetchJWTSVID(ctx context.Context, entry *common.RegistrationEntry, audience []string) (*client.JWTSVID, error)
 
+	// FetchWITSVID returns a WIT-SVID for the specified entry. If there is no
+	// WIT-SVID cached or the cached one is about to expire, the manager will
+	// get one signed upstream.
+	FetchWITSVID(ctx context.Context, entry *common.RegistrationEntry) (*cache.WITSVID, error)
+
+	// SubscribeToWITBundleChanges returns a new stream on which the WIT
+	// bundles are received each time they change.
+	SubscribeToWITBundleChanges() *cache.WITBundleStream
+
 	// CountX509SVIDs returns the amount of X509 SVIDs on memory
 	CountX509SVIDs() int
 
 	// CountJWTSVIDs returns the amount of JWT SVIDs on memory
 	CountJWTSVIDs() int
 
+	// CountWITSVIDs returns the amount of WIT SVIDs on memory
+	CountWITSVIDs() int
+
 	// CountSVIDStoreX509SVIDs returns the amount of x509 SVIDs on SVIDStore in-memory cache
 	CountSVIDStoreX509SVIDs() int
 
 	// GetLastSync returns the last successful rotation timestamp
 	GetLastSync() time.Time
 
+	// GetSyncStatus returns the time and result of the last synchronization
+	// with the server
+	GetSyncStatus() SyncStatus
+
+	// GetCachedEntries returns the registration entries in the workload
+	// cache, along with the status of their X509-SVIDs
+	GetCachedEntries() []*cache.CachedEntry
+
+	// GetStaleEntries returns the entries in the workload cache that require
+	// a new X509-SVID
+	GetStaleEntries() []*cache.StaleEntry
+
 	// GetBundle get latest cached bundle
 	GetBundle() *cache.Bundle
 
@@ -138,6 +162,9 @@ type Cache interface {
 	// Identities get all identities in cache
 	Identities() []cache.Identity
 
+	// CachedEntries get all registration entries with the status of their SVIDs
+	CachedEntries() []*cache.CachedEntry
+
 	X509Bundle() x509bundle.Source
 }
 
@@ -157,6 +184,20 @@ type JWTCache interface {
 	TaintJWTSVIDs(ctx context.Context, taintedJWTAuthorities map[string]struct{})
 }
 
+type WITCache interface {
+	// CountWITSVIDs in cache stored
+	CountWITSVIDs() int
+
+	// GetWITSVID provides the WIT-SVID cached for a registration entry
+	GetWITSVID(entryID string) (*cache.WITSVID, bool)
+
+	// SetWITSVID adds WIT-SVID to cache
+	SetWITSVID(entryID string, svid *cache.WITSVID)
+
+	// PruneWITSVIDs removes WIT-SVIDs of entries not included in entryIDs
+	PruneWITSVIDs(entryIDs map[string]struct{})
+}
+
 type manager struct {
 	c *Config
 
@@ -165,9 +206,11 @@ type manager struct {
 	// Protects multiple goroutines from requesting SVID signings at the same time
 	updateSVIDMu sync.RWMutex
 
-	cache    Cache
-	jwtCache JWTCache
-	svid     svid.Rotator
+	cache          Cache
+	jwtCache       JWTCache
+	witCache       WITCache
+	witBundleCache *cache.WITBundleCache
+	svid           svid.Rotator
 
 	storage storage.Storage
 
@@ -185,6 +228,10 @@ type manager struct {
 	// Saves last success sync
 	lastSync time.Time
 
+	// Saves last sync attempt and its error
+	lastSyncAttempt time.Time
+	lastSyncErr     error
+
 	// Cache for 'storable' SVIDs
 	svidStoreCache *storecache.Cache
 
@@ -305,6 +352,10 @@ func (m *manager) CountJWTSVIDs() int {
 	return m.jwtCache.CountJWTSVIDs()
 }
 
+func (m *manager) CountWITSVIDs() int {
+	return m.witCache.CountWITSVIDs()
+}
+
 func (m *manager) CountSVIDStoreX509SVIDs() int {
 	return m.svidStoreCache.CountX509SVIDs()
 }
@@ -357,6 +408,72 @@ func (m *manager) FetchJWTSVID(ctx context.Context, entry *common.RegistrationEn
 	return newSVID, nil
 }
 
+func (m *manager) FetchWITSVID(ctx context.Context, entry *common.RegistrationEntry) (*cache.WITSVID, error) {
+	spiffeID, err := spiffeid.FromString(entry.SpiffeId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPIFFE ID: %w", err)
+	}
+
+	now := m.clk.Now()
+	cachedSVID, ok := m.witCache.GetWITSVID(entry.EntryId)
+	if ok && cachedSVID.RevisionNumber != entry.RevisionNumber {
+		// The entry changed since the WIT-SVID was issued; the cached WIT-SVID
+		// may not reflect it anymore.
+		cachedSVID, ok = nil, false
+	}
+	if ok && !m.c.RotationStrategy.WITSVIDExpiresSoon(cachedSVID.SVID, now) {
+		return cachedSVID, nil
+	}
+
+	newSVID, err := m.newWITSVID(ctx, entry)
+	switch {
+	case err == nil:
+	case cachedSVID == nil:
+		return nil, err
+	case rotationutil.WITSVIDExpired(cachedSVID.SVID, now):
+		return nil, fmt.Errorf("unable to renew WIT-SVID for %q (err=%w)", spiffeID, err)
+	default:
+		m.c.Log.WithError(err).WithField(telemetry.SPIFFEID, spiffeID).Warn("Unable to renew WIT-SVID; returning cached copy")
+		return cachedSVID, nil
+	}
+
+	m.witCache.SetWITSVID(entry.EntryId, newSVID)
+	return newSVID, nil
+}
+
+func (m *manager) newWITSVID(ctx context.Context, entry *common.RegistrationEntry) (*cache.WITSVID, error) {
+	signingAlgorithm, err := m.c.WorkloadKeyType.SigningAlgorithm()
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := m.c.WorkloadKeyType.GenerateSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	svid, _, err := m.client.NewWITSVID(ctx, entry.EntryId, publicKey, signingAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cache.WITSVID{
+		SVID:             svid,
+		PrivateKey:       privateKey,
+		SigningAlgorithm: signingAlgorithm,
+		RevisionNumber:   entry.RevisionNumber,
+	}, nil
+}
+
+func (m *manager) SubscribeToWITBundleChanges() *cache.WITBundleStream {
+	return m.witBundleCache.SubscribeToWITBundleChanges()
+}
+
 func (m *manager) runSynchronizer(ctx context.Context) error {
 	syncInterval := min(m.synchronizeBackoff.NextBackOff(), defaultSyncInterval)
 	for {
@@ -453,6 +570,43 @@ func (m *manager) GetLastSync() time.Time {
 	return m.lastSync
 }
 
+// SyncStatus is the status of the synchronization with the server
+type SyncStatus struct {
+	// LastSync is the time of the last successful sync
+	LastSync time.Time
+	// LastSyncAttempt is the time of the last sync, successful or not
+	LastSyncAttempt time.Time
+	// LastError is the error of the last sync, nil if it succeeded
+	LastError error
+}
+
+func (m *manager) setLastSyncAttempt(err error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.lastSyncAttempt = m.clk.Now()
+	m.lastSyncErr = err
+}
+
+func (m *manager) GetSyncStatus() SyncStatus {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return SyncStatus{
+		LastSync:        m.lastSync,
+		LastSyncAttempt: m.lastSyncAttempt,
+		LastError:       m.lastSyncErr,
+	}
+}
+
+func (m *manager) GetCachedEntries() []*cache.CachedEntry {
+	return m.cache.CachedEntries()
+}
+
+func (m *manager) GetStaleEntries() []*cache.StaleEntry {
+	return m.cache.GetStaleEntries()
+}
+
 func (m *manager) GetBundle() *cache.Bundle {
 	m.mtx.RLock()
 	defer m.mtx.RUnlock()
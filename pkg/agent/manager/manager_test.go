@@ -690,6 +690,74 @@ func TestSynchronization(t *testing.T) {
 	require.Equal(t, clk.Now(), m.GetLastSync())
 }
 
+func TestSyncStatus(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	km := fakeagentkeymanager.New(t, dir)
+
+	clk := clock.NewMock(t)
+	var failSync atomic.Bool
+	api := newMockAPI(t, &mockAPIConfig{
+		km: km,
+		getAuthorizedEntries: func(*mockAPI, int32, *entryv1.GetAuthorizedEntriesRequest) (*entryv1.GetAuthorizedEntriesResponse, error) {
+			if failSync.Load() {
+				return nil, errors.New("oh no")
+			}
+			return makeGetAuthorizedEntriesResponse(t, "resp1", "resp2"), nil
+		},
+		batchNewX509SVIDEntries: func(*mockAPI, int32) []*common.RegistrationEntry {
+			return makeBatchNewX509SVIDEntries("resp1", "resp2")
+		},
+		svidTTL: 200,
+		clk:     clk,
+	})
+
+	baseSVID, baseSVIDKey := api.newSVID(joinTokenID, 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManager(km)
+
+	c := &Config{
+		ServerAddr:       api.addr,
+		SVID:             baseSVID,
+		SVIDKey:          baseSVIDKey,
+		Log:              testLogger,
+		TrustDomain:      trustDomain,
+		Storage:          openStorage(t, dir),
+		Bundle:           api.bundle,
+		Metrics:          &telemetry.Blackhole{},
+		RotationInterval: time.Hour,
+		SyncInterval:     time.Hour,
+		Clk:              clk,
+		Catalog:          cat,
+		WorkloadKeyType:  workloadkey.ECP256,
+		SVIDStoreCache:   storecache.New(&storecache.Config{TrustDomain: trustDomain, Log: testLogger}),
+		RotationStrategy: rotationutil.NewRotationStrategy(0),
+	}
+
+	m := newManager(c)
+	require.Equal(t, SyncStatus{}, m.GetSyncStatus())
+
+	require.NoError(t, m.Initialize(context.Background()))
+	lastSync := clk.Now()
+	require.Equal(t, SyncStatus{LastSync: lastSync, LastSyncAttempt: lastSync}, m.GetSyncStatus())
+
+	cachedEntries := m.GetCachedEntries()
+	require.Len(t, cachedEntries, 3)
+	for _, cachedEntry := range cachedEntries {
+		require.True(t, cachedEntry.HasSVID)
+		require.False(t, cachedEntry.Stale)
+	}
+	require.Empty(t, m.GetStaleEntries())
+
+	failSync.Store(true)
+	clk.Add(time.Second)
+	require.Error(t, m.synchronize(context.Background()))
+
+	syncStatus := m.GetSyncStatus()
+	require.Equal(t, lastSync, syncStatus.LastSync)
+	require.Equal(t, clk.Now(), syncStatus.LastSyncAttempt)
+	require.ErrorContains(t, syncStatus.LastError, "oh no")
+}
+
 func TestSynchronizationClearsStaleCacheEntries(t *testing.T) {
 	dir := spiretest.TempDir(t)
 	km := fakeagentkeymanager.New(t, dir)
@@ -1702,6 +1770,128 @@ func TestFetchJWTSVID(t *testing.T) {
 	require.Nil(t, svid)
 }
 
+func TestFetchWITSVID(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	km := fakeagentkeymanager.New(t, dir)
+
+	var witSVID *types.WITSVID
+
+	clk := clock.NewMock(t)
+	api := newMockAPI(t, &mockAPIConfig{
+		km: km,
+		getAuthorizedEntries: func(*mockAPI, int32, *entryv1.GetAuthorizedEntriesRequest) (*entryv1.GetAuthorizedEntriesResponse, error) {
+			return makeGetAuthorizedEntriesResponse(t, "resp1", "resp2"), nil
+		},
+		batchNewX509SVIDEntries: func(*mockAPI, int32) []*common.RegistrationEntry {
+			return makeBatchNewX509SVIDEntries("resp1", "resp2")
+		},
+		batchNewWITSVID: func(_ *mockAPI, req *svidv1.BatchNewWITSVIDRequest) (*svidv1.BatchNewWITSVIDResponse, error) {
+			require.Len(t, req.Params, 1)
+			require.Equal(t, "ES256", req.Params[0].SigningAlgorithm)
+			if witSVID == nil {
+				return nil, errors.New("ohno")
+			}
+			return &svidv1.BatchNewWITSVIDResponse{
+				Results: []*svidv1.BatchNewWITSVIDResponse_Result{
+					{Status: commonapi.OK(), Svid: witSVID},
+				},
+			}, nil
+		},
+		clk:     clk,
+		svidTTL: 200,
+	})
+
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManager(km)
+
+	baseSVID, baseSVIDKey := api.newSVID(joinTokenID, 1*time.Hour)
+
+	c := &Config{
+		ServerAddr:       api.addr,
+		SVID:             baseSVID,
+		SVIDKey:          baseSVIDKey,
+		Log:              testLogger,
+		TrustDomain:      trustDomain,
+		Storage:          openStorage(t, dir),
+		Bundle:           api.bundle,
+		Metrics:          &telemetry.Blackhole{},
+		Catalog:          cat,
+		Clk:              clk,
+		WorkloadKeyType:  workloadkey.ECP256,
+		SVIDStoreCache:   storecache.New(&storecache.Config{TrustDomain: trustDomain, Log: testLogger}),
+		RotationStrategy: rotationutil.NewRotationStrategy(0),
+	}
+
+	m := newManager(c)
+	require.NoError(t, m.Initialize(context.Background()))
+
+	testEntry := regEntriesMap["resp2"][0]
+	testEntrySPIFFEId, err := idutil.IDProtoFromString(testEntry.SpiffeId)
+	require.NoError(t, err)
+
+	// nothing in cache, fetch fails
+	svid, err := m.FetchWITSVID(context.Background(), testEntry)
+	require.Error(t, err)
+	require.Nil(t, svid)
+
+	// fetch succeeds
+	now := clk.Now()
+	witSVID = &types.WITSVID{
+		Token:     "A",
+		Id:        testEntrySPIFFEId,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Minute).Unix(),
+	}
+	svid, err = m.FetchWITSVID(context.Background(), testEntry)
+	require.NoError(t, err)
+	require.Equal(t, "A", svid.SVID.Token)
+	require.Equal(t, "ES256", svid.SigningAlgorithm)
+	require.NotNil(t, svid.PrivateKey)
+	keyA := svid.PrivateKey
+	require.Equal(t, 1, m.CountWITSVIDs())
+
+	// assert cached WIT-SVID is returned w/o trying to fetch (since cached version does not expire soon)
+	witSVID = &types.WITSVID{
+		Token:     "B",
+		Id:        testEntrySPIFFEId,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Minute).Unix(),
+	}
+	svid, err = m.FetchWITSVID(context.Background(), testEntry)
+	require.NoError(t, err)
+	require.Equal(t, "A", svid.SVID.Token)
+	require.Equal(t, keyA, svid.PrivateKey)
+
+	// expire the cached WIT-SVID soon and make sure a new one is fetched,
+	// bound to a new key
+	clk.Add(time.Second * 45)
+	now = clk.Now()
+	witSVID = &types.WITSVID{
+		Token:     "C",
+		Id:        testEntrySPIFFEId,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Minute).Unix(),
+	}
+	svid, err = m.FetchWITSVID(context.Background(), testEntry)
+	require.NoError(t, err)
+	require.Equal(t, "C", svid.SVID.Token)
+	require.NotEqual(t, keyA, svid.PrivateKey)
+
+	// expire the WIT-SVID soon, fail the fetch, and make sure cached WIT-SVID is returned
+	clk.Add(time.Second * 30)
+	witSVID = nil
+	svid, err = m.FetchWITSVID(context.Background(), testEntry)
+	require.NoError(t, err)
+	require.Equal(t, "C", svid.SVID.Token)
+
+	// now completely expire the WIT-SVID and make sure an error is returned,
+	// since the fetch fails and the cached version is expired.
+	clk.Add(time.Second * 30)
+	svid, err = m.FetchWITSVID(context.Background(), testEntry)
+	require.Error(t, err)
+	require.Nil(t, svid)
+}
+
 func TestFetchJWTSVIDWithJTIBypassesCache(t *testing.T) {
 	dir := spiretest.TempDir(t)
 	km := fakeagentkeymanager.New(t, dir)
@@ -1954,6 +2144,7 @@ type mockAPIConfig struct {
 	getAuthorizedEntries    func(api *mockAPI, count int32, req *entryv1.GetAuthorizedEntriesRequest) (*entryv1.GetAuthorizedEntriesResponse, error)
 	batchNewX509SVIDEntries func(api *mockAPI, count int32) []*common.RegistrationEntry
 	newJWTSVID              func(api *mockAPI, req *svidv1.NewJWTSVIDRequest) (*svidv1.NewJWTSVIDResponse, error)
+	batchNewWITSVID         func(api *mockAPI, req *svidv1.BatchNewWITSVIDRequest) (*svidv1.BatchNewWITSVIDResponse, error)
 
 	svidTTL int
 	clk     clock.Clock
@@ -2103,6 +2294,13 @@ func (h *mockAPI) NewJWTSVID(_ context.Context, req *svidv1.NewJWTSVIDRequest) (
 	return nil, errors.New("no FetchJWTSVID implementation for test")
 }
 
+func (h *mockAPI) BatchNewWITSVID(_ context.Context, req *svidv1.BatchNewWITSVIDRequest) (*svidv1.BatchNewWITSVIDResponse, error) {
+	if h.c.batchNewWITSVID != nil {
+		return h.c.batchNewWITSVID(h, req)
+	}
+	return nil, errors.New("no BatchNewWITSVID implementation for test")
+}
+
 func (h *mockAPI) GetBundle(context.Context, *bundlev1.GetBundleRequest) (*types.Bundle, error) {
 	bundle := bundleutil.BundleProtoFromRootCAs(h.bundle.TrustDomain().IDString(), h.bundle.X509Authorities())
 	if h.taintedX509Authority != nil {
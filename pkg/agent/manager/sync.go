@@ -10,6 +10,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/exp/bundle/witbundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
@@ -95,6 +96,10 @@ func (m *manager) processTaintedAuthorities(ctx context.Context, bundle *spiffeb
 // synchronize fetches the authorized entries from the server, updates the
 // cache, and fetches missing/expiring SVIDs.
 func (m *manager) synchronize(ctx context.Context) (err error) {
+	defer func() {
+		m.setLastSyncAttempt(err)
+	}()
+
 	cacheUpdate, storeUpdate, err := m.fetchEntries(ctx)
 	if err != nil {
 		return err
@@ -105,6 +110,14 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 		return err
 	}
 
+	// Drop the WIT-SVIDs of entries the agent is no longer entitled to. This
+	// must happen before the update is handed over to the cache.
+	entryIDs := make(map[string]struct{}, len(cacheUpdate.RegistrationEntries))
+	for entryID := range cacheUpdate.RegistrationEntries {
+		entryIDs[entryID] = struct{}{}
+	}
+	m.witCache.PruneWITSVIDs(entryIDs)
+
 	if err := m.updateCache(ctx, cacheUpdate, m.c.Log.WithField(telemetry.CacheType, telemetry_agent.CacheTypeWorkload), "", m.cache); err != nil {
 		return err
 	}
@@ -311,6 +324,14 @@ func (m *manager) fetchEntries(ctx context.Context) (_ *cache.UpdateEntries, _ *
 		return nil, nil, err
 	}
 
+	if !m.c.DisableWITSVIDs {
+		witBundles, err := parseWITBundles(update.Bundles)
+		if err != nil {
+			return nil, nil, err
+		}
+		m.witBundleCache.Update(witBundles)
+	}
+
 	// Get all Subject Key IDs and KeyIDs of tainted authorities
 	var taintedX509Authorities []string
 	taintedJWTAuthorities := make(map[string]struct{})
@@ -386,6 +407,22 @@ func parseBundles(bundles map[string]*common.Bundle) (map[spiffeid.TrustDomain]*
 	return out, nil
 }
 
+func parseWITBundles(bundles map[string]*common.Bundle) (map[spiffeid.TrustDomain]*witbundle.Bundle, error) {
+	out := make(map[spiffeid.TrustDomain]*witbundle.Bundle, len(bundles))
+	for _, bundle := range bundles {
+		td, err := spiffeid.TrustDomainFromString(bundle.TrustDomainId)
+		if err != nil {
+			return nil, err
+		}
+		witAuthorities, err := bundleutil.WITSigningKeysFromBundleProto(bundle)
+		if err != nil {
+			return nil, err
+		}
+		out[td] = witbundle.FromWITAuthorities(td, witAuthorities)
+	}
+	return out, nil
+}
+
 func getNewItemsFromSlice(current map[string]struct{}, items []string) []string {
 	var newItems []string
 	for _, subjectKeyID := range items {
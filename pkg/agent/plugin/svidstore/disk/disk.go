@@ -0,0 +1,452 @@
+package disk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/token"
+	svidstorev1 "github.com/spiffe/spire-plugin-sdk/proto/spire/plugin/agent/svidstore/v1"
+	configv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pluginconf"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "disk"
+
+	defaultCertFileName   = "svid.pem"
+	defaultKeyFileName    = "svid_key.pem"
+	defaultBundleFileName = "bundle.pem"
+
+	defaultCertFileMode = 0o644
+	defaultKeyFileMode  = 0o600
+
+	defaultReloadTimeout = 30 * time.Second
+)
+
+func BuiltIn() catalog.BuiltIn {
+	return builtin(New())
+}
+
+func builtin(p *DiskPlugin) catalog.BuiltIn {
+	return catalog.MakeBuiltIn(pluginName,
+		svidstorev1.SVIDStorePluginServer(p),
+		configv1.ConfigServiceServer(p),
+	)
+}
+
+func New() *DiskPlugin {
+	p := &DiskPlugin{}
+	p.hooks.chown = os.Chown
+	p.hooks.runCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		return exec.CommandContext(ctx, name, args...).CombinedOutput()
+	}
+
+	return p
+}
+
+type Configuration struct {
+	// Directory is the directory under which the SVIDs are stored. The
+	// directory of each SVID is set with the "name" selector.
+	Directory string `hcl:"directory" json:"directory"`
+
+	// ReloadCommands are the commands, by name, that entries can select with
+	// the "reload" selector to be run after their SVID is stored.
+	ReloadCommands map[string][]string `hcl:"reload_commands" json:"reload_commands,omitempty"`
+
+	// ReloadTimeout is the maximum time a reload command can run for.
+	ReloadTimeout string `hcl:"reload_timeout" json:"reload_timeout,omitempty"`
+
+	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions" json:",omitempty"`
+}
+
+type diskConfig struct {
+	directory      string
+	reloadCommands map[string][]string
+	reloadTimeout  time.Duration
+}
+
+func buildConfig(_ catalog.CoreConfig, hclText string, status *pluginconf.Status) *diskConfig {
+	newConfig := &Configuration{}
+	if err := hcl.Decode(newConfig, hclText); err != nil {
+		status.ReportErrorf("unable to decode configuration: %v", err)
+		return nil
+	}
+
+	pluginconf.ReportUnusedKeys(status, newConfig.UnusedKeyPositions)
+
+	if newConfig.Directory == "" {
+		status.ReportError("directory is required")
+	} else if !filepath.IsAbs(newConfig.Directory) {
+		status.ReportError("directory must be an absolute path")
+	}
+
+	for name, command := range newConfig.ReloadCommands {
+		if len(command) == 0 || command[0] == "" {
+			status.ReportErrorf("reload command %q is empty", name)
+		}
+	}
+
+	reloadTimeout := defaultReloadTimeout
+	if newConfig.ReloadTimeout != "" {
+		var err error
+		reloadTimeout, err = time.ParseDuration(newConfig.ReloadTimeout)
+		if err != nil {
+			status.ReportErrorf("invalid reload_timeout: %v", err)
+		}
+	}
+
+	return &diskConfig{
+		directory:      filepath.Clean(newConfig.Directory),
+		reloadCommands: newConfig.ReloadCommands,
+		reloadTimeout:  reloadTimeout,
+	}
+}
+
+type DiskPlugin struct {
+	svidstorev1.UnsafeSVIDStoreServer
+	configv1.UnsafeConfigServer
+
+	log    hclog.Logger
+	mtx    sync.RWMutex
+	config *diskConfig
+
+	hooks struct {
+		chown      func(name string, uid, gid int) error
+		runCommand func(ctx context.Context, name string, args ...string) ([]byte, error)
+	}
+}
+
+func (p *DiskPlugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+// Configure configures the DiskPlugin.
+func (p *DiskPlugin) Configure(_ context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	newConfig, _, err := pluginconf.Build(req, buildConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.config = newConfig
+
+	return &configv1.ConfigureResponse{}, nil
+}
+
+func (p *DiskPlugin) Validate(_ context.Context, req *configv1.ValidateRequest) (*configv1.ValidateResponse, error) {
+	_, notes, err := pluginconf.Build(req, buildConfig)
+
+	return &configv1.ValidateResponse{
+		Valid: err == nil,
+		Notes: notes,
+	}, nil
+}
+
+// PutX509SVID writes the specified X509-SVID, its private key and bundle to
+// the directory selected by the entry, and runs the selected reload command.
+func (p *DiskPlugin) PutX509SVID(ctx context.Context, req *svidstorev1.PutX509SVIDRequest) (*svidstorev1.PutX509SVIDResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	opt, err := optionsFromMetadata(req.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := svidstore.SecretFromProto(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse request: %v", err)
+	}
+
+	// The bundle file holds the bundle of the trust domain followed by the
+	// federated bundles, so workloads can validate peers of both.
+	bundle := data.Bundle
+	federatedTDs := make([]string, 0, len(data.FederatedBundles))
+	for td := range data.FederatedBundles {
+		federatedTDs = append(federatedTDs, td)
+	}
+	slices.Sort(federatedTDs)
+	for _, td := range federatedTDs {
+		bundle += data.FederatedBundles[td]
+	}
+
+	if err := os.MkdirAll(opt.dir, 0o755); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create directory: %v", err)
+	}
+
+	// The certificate is written last so that workloads watching it find the
+	// matching key and bundle in place when it changes.
+	for _, file := range []struct {
+		path string
+		data string
+		mode os.FileMode
+	}{
+		{path: opt.bundlePath(), data: bundle, mode: opt.certMode},
+		{path: opt.keyPath(), data: data.X509SVIDKey, mode: opt.keyMode},
+		{path: opt.certPath(), data: data.X509SVID, mode: opt.certMode},
+	} {
+		if err := p.atomicWrite(file.path, []byte(file.data), file.mode, opt.uid, opt.gid); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to write %q: %v", file.path, err)
+		}
+	}
+	p.log.With("directory", opt.dir).Debug("SVID written")
+
+	if opt.reloadCommand != nil {
+		if err := p.reload(ctx, opt.reloadCommand, config.reloadTimeout); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to run reload command %q: %v", opt.reloadName, err)
+		}
+		p.log.With("reload_command", opt.reloadName).Debug("Reload command run")
+	}
+
+	return &svidstorev1.PutX509SVIDResponse{}, nil
+}
+
+// DeleteX509SVID removes the files written for the SVID, and its directory if
+// it is left empty.
+func (p *DiskPlugin) DeleteX509SVID(_ context.Context, req *svidstorev1.DeleteX509SVIDRequest) (*svidstorev1.DeleteX509SVIDResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	opt, err := optionsFromMetadata(req.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range []string{opt.certPath(), opt.keyPath(), opt.bundlePath()} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, status.Errorf(codes.Internal, "failed to remove %q: %v", path, err)
+		}
+	}
+
+	if opt.dir != config.directory {
+		// Other files may have been added to the directory, in which case it
+		// is kept.
+		if err := os.Remove(opt.dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+			p.log.With("directory", opt.dir).Debug("SVID directory not removed", "error", err)
+		}
+	}
+
+	p.log.With("directory", opt.dir).Debug("SVID deleted")
+	return &svidstorev1.DeleteX509SVIDResponse{}, nil
+}
+
+func (p *DiskPlugin) getConfig() (*diskConfig, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	if p.config == nil {
+		return nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.config, nil
+}
+
+// atomicWrite writes the data to a temporary file in the directory of path,
+// sets its mode and ownership, and then renames it to path, so that readers
+// never see a partially written file.
+func (p *DiskPlugin) atomicWrite(path string, data []byte, mode os.FileMode, uid, gid int) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		return err
+	}
+	if uid != -1 || gid != -1 {
+		if err := p.hooks.chown(tmp.Name(), uid, gid); err != nil {
+			return err
+		}
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (p *DiskPlugin) reload(ctx context.Context, command []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output, err := p.hooks.runCommand(ctx, command[0], command[1:]...)
+	if err != nil {
+		if out := strings.TrimSpace(string(output)); out != "" {
+			return fmt.Errorf("%w: %s", err, out)
+		}
+		return err
+	}
+	return nil
+}
+
+type svidOptions struct {
+	dir            string
+	certFileName   string
+	keyFileName    string
+	bundleFileName string
+	certMode       os.FileMode
+	keyMode        os.FileMode
+	uid            int
+	gid            int
+	reloadName     string
+	reloadCommand  []string
+}
+
+func (o *svidOptions) certPath() string {
+	return filepath.Join(o.dir, o.certFileName)
+}
+
+func (o *svidOptions) keyPath() string {
+	return filepath.Join(o.dir, o.keyFileName)
+}
+
+func (o *svidOptions) bundlePath() string {
+	return filepath.Join(o.dir, o.bundleFileName)
+}
+
+func optionsFromMetadata(metadata []string, config *diskConfig) (*svidOptions, error) {
+	data, err := svidstore.ParseMetadata(metadata)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	name, ok := data["name"]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if !filepath.IsLocal(name) {
+		return nil, status.Errorf(codes.InvalidArgument, "name %q must be a relative path within the directory", name)
+	}
+
+	opt := &svidOptions{
+		dir:            filepath.Join(config.directory, name),
+		certFileName:   defaultCertFileName,
+		keyFileName:    defaultKeyFileName,
+		bundleFileName: defaultBundleFileName,
+		certMode:       defaultCertFileMode,
+		keyMode:        defaultKeyFileMode,
+		uid:            -1,
+		gid:            -1,
+	}
+
+	for key, fileName := range map[string]*string{
+		"cert_file_name":   &opt.certFileName,
+		"key_file_name":    &opt.keyFileName,
+		"bundle_file_name": &opt.bundleFileName,
+	} {
+		value, ok := data[key]
+		if !ok {
+			continue
+		}
+		if value == "" || value != filepath.Base(value) || !filepath.IsLocal(value) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s %q must be a file name", key, value)
+		}
+		*fileName = value
+	}
+	if opt.certFileName == opt.keyFileName || opt.certFileName == opt.bundleFileName || opt.keyFileName == opt.bundleFileName {
+		return nil, status.Error(codes.InvalidArgument, "certificate, key and bundle file names must be different")
+	}
+
+	// The certificate and bundle files are public, while the key file must
+	// stay private to the workload, so their modes are set separately.
+	for key, mode := range map[string]*os.FileMode{
+		"cert_mode": &opt.certMode,
+		"key_mode":  &opt.keyMode,
+	} {
+		value, ok := data[key]
+		if !ok {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 8, 32)
+		if err != nil || parsed > 0o777 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s %q: expected octal permission bits", key, value)
+		}
+		*mode = os.FileMode(parsed)
+	}
+
+	if value, ok := data["owner"]; ok {
+		opt.uid, err = lookupID(value, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+			return u.Uid, nil
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid owner %q: %v", value, err)
+		}
+	}
+
+	if value, ok := data["group"]; ok {
+		opt.gid, err = lookupID(value, func(name string) (string, error) {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return "", err
+			}
+			return g.Gid, nil
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid group %q: %v", value, err)
+		}
+	}
+
+	if value, ok := data["reload"]; ok {
+		command, ok := config.reloadCommands[value]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "reload command %q is not configured", value)
+		}
+		opt.reloadName = value
+		opt.reloadCommand = command
+	}
+
+	return opt, nil
+}
+
+// lookupID returns the numeric user or group ID, looking it up by name if it
+// is not numeric.
+func lookupID(value string, lookup func(name string) (string, error)) (int, error) {
+	if id, err := strconv.Atoi(value); err == nil {
+		if id < 0 {
+			return 0, errors.New("ID must not be negative")
+		}
+		return id, nil
+	}
+
+	id, err := lookup(value)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(id)
+}
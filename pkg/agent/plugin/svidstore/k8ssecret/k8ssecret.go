@@ -0,0 +1,323 @@
+package k8ssecret
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/token"
+	svidstorev1 "github.com/spiffe/spire-plugin-sdk/proto/spire/plugin/agent/svidstore/v1"
+	configv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/plugin/k8s/apiserver"
+	"github.com/spiffe/spire/pkg/common/pluginconf"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	pluginName = "k8s_secret"
+
+	// managedByLabel marks the secrets managed by SPIRE agents.
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "spire-agent"
+
+	// trustDomainAnnotation holds the trust domain of the SPIRE deployment
+	// that manages the secret. An annotation is used since trust domain names
+	// may not be valid label values.
+	trustDomainAnnotation = "spiffe.io/trust-domain"
+
+	// spiffeIDAnnotation holds the SPIFFE ID of the stored SVID.
+	spiffeIDAnnotation = "spiffe.io/spiffe-id"
+
+	// caCertKey is the key of the bundle in the secret data, as commonly used
+	// alongside the keys of kubernetes.io/tls secrets.
+	caCertKey = "ca.crt"
+)
+
+func BuiltIn() catalog.BuiltIn {
+	return builtin(New())
+}
+
+func builtin(p *SecretPlugin) catalog.BuiltIn {
+	return catalog.MakeBuiltIn(pluginName,
+		svidstorev1.SVIDStorePluginServer(p),
+		configv1.ConfigServiceServer(p),
+	)
+}
+
+func New() *SecretPlugin {
+	return newPlugin(apiserver.NewClientset)
+}
+
+func newPlugin(newClientset func(kubeConfigFilePath string) (kubernetes.Interface, error)) *SecretPlugin {
+	p := &SecretPlugin{}
+	p.hooks.newClientset = newClientset
+
+	return p
+}
+
+type Configuration struct {
+	// KubeConfigFilePath is the path to the kubeconfig file. If unset, the
+	// in-cluster configuration is used.
+	KubeConfigFilePath string `hcl:"kube_config_file_path" json:"kube_config_file_path"`
+
+	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions" json:",omitempty"`
+}
+
+func buildConfig(_ catalog.CoreConfig, hclText string, status *pluginconf.Status) *Configuration {
+	newConfig := &Configuration{}
+	if err := hcl.Decode(newConfig, hclText); err != nil {
+		status.ReportErrorf("unable to decode configuration: %v", err)
+		return nil
+	}
+
+	pluginconf.ReportUnusedKeys(status, newConfig.UnusedKeyPositions)
+
+	return newConfig
+}
+
+type SecretPlugin struct {
+	svidstorev1.UnsafeSVIDStoreServer
+	configv1.UnsafeConfigServer
+
+	log         hclog.Logger
+	mtx         sync.RWMutex
+	clientset   kubernetes.Interface
+	trustDomain string
+
+	hooks struct {
+		newClientset func(kubeConfigFilePath string) (kubernetes.Interface, error)
+	}
+}
+
+func (p *SecretPlugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+// Configure configures the SecretPlugin.
+func (p *SecretPlugin) Configure(_ context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	newConfig, _, err := pluginconf.Build(req, buildConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := p.hooks.newClientset(newConfig.KubeConfigFilePath)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create kubernetes clientset: %v", err)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.clientset = clientset
+	p.trustDomain = req.CoreConfiguration.TrustDomain
+
+	return &configv1.ConfigureResponse{}, nil
+}
+
+func (p *SecretPlugin) Validate(_ context.Context, req *configv1.ValidateRequest) (*configv1.ValidateResponse, error) {
+	_, notes, err := pluginconf.Build(req, buildConfig)
+
+	return &configv1.ValidateResponse{
+		Valid: err == nil,
+		Notes: notes,
+	}, nil
+}
+
+// PutX509SVID creates or updates the kubernetes.io/tls secret selected by the
+// entry with the specified X509-SVID.
+func (p *SecretPlugin) PutX509SVID(ctx context.Context, req *svidstorev1.PutX509SVIDRequest) (*svidstorev1.PutX509SVIDResponse, error) {
+	clientset, trustDomain, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	opt, err := optionsFromMetadata(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := svidstore.SecretFromProto(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse request: %v", err)
+	}
+
+	// ca.crt holds the bundle of the trust domain followed by the federated
+	// bundles, so workloads can validate peers of both.
+	caCert := data.Bundle
+	federatedTDs := make([]string, 0, len(data.FederatedBundles))
+	for td := range data.FederatedBundles {
+		federatedTDs = append(federatedTDs, td)
+	}
+	slices.Sort(federatedTDs)
+	for _, td := range federatedTDs {
+		caCert += data.FederatedBundles[td]
+	}
+
+	secretData := map[string][]byte{
+		corev1.TLSCertKey:       []byte(data.X509SVID),
+		corev1.TLSPrivateKeyKey: []byte(data.X509SVIDKey),
+		caCertKey:               []byte(caCert),
+	}
+
+	secrets := clientset.CoreV1().Secrets(opt.namespace)
+	secret, found, err := getSecret(ctx, secrets, opt.name, trustDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	log := p.log.With("namespace", opt.namespace).With("secret_name", opt.name)
+	if !found {
+		_, err := secrets.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      opt.name,
+				Namespace: opt.namespace,
+				Labels: map[string]string{
+					managedByLabel: managedByValue,
+				},
+				Annotations: map[string]string{
+					trustDomainAnnotation: trustDomain,
+					spiffeIDAnnotation:    data.SPIFFEID,
+				},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: secretData,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create secret: %v", err)
+		}
+		log.Debug("Secret created")
+		return &svidstorev1.PutX509SVIDResponse{}, nil
+	}
+
+	if secret.Type != corev1.SecretTypeTLS {
+		return nil, status.Errorf(codes.InvalidArgument, "secret has type %q; expected %q", secret.Type, corev1.SecretTypeTLS)
+	}
+
+	secret.Annotations[spiffeIDAnnotation] = data.SPIFFEID
+	secret.Data = secretData
+	if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update secret: %v", err)
+	}
+	log.Debug("Secret updated")
+
+	return &svidstorev1.PutX509SVIDResponse{}, nil
+}
+
+// DeleteX509SVID deletes the secret selected by the entry.
+func (p *SecretPlugin) DeleteX509SVID(ctx context.Context, req *svidstorev1.DeleteX509SVIDRequest) (*svidstorev1.DeleteX509SVIDResponse, error) {
+	clientset, trustDomain, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	opt, err := optionsFromMetadata(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := clientset.CoreV1().Secrets(opt.namespace)
+	secret, found, err := getSecret(ctx, secrets, opt.name, trustDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	log := p.log.With("namespace", opt.namespace).With("secret_name", opt.name)
+	if !found {
+		log.Debug("Secret to delete not found")
+		return &svidstorev1.DeleteX509SVIDResponse{}, nil
+	}
+
+	err = secrets.Delete(ctx, opt.name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{
+			UID:             &secret.UID,
+			ResourceVersion: &secret.ResourceVersion,
+		},
+	})
+	switch {
+	case err == nil:
+	case k8serrors.IsNotFound(err):
+		log.Debug("Secret to delete not found")
+		return &svidstorev1.DeleteX509SVIDResponse{}, nil
+	default:
+		return nil, status.Errorf(codes.Internal, "failed to delete secret: %v", err)
+	}
+
+	log.Debug("Secret deleted")
+	return &svidstorev1.DeleteX509SVIDResponse{}, nil
+}
+
+func (p *SecretPlugin) getClient() (kubernetes.Interface, string, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	if p.clientset == nil {
+		return nil, "", status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.clientset, p.trustDomain, nil
+}
+
+type secretGetter interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Secret, error)
+}
+
+// getSecret gets the secret and validates that it is managed by the SPIRE
+// deployment of the trust domain. It returns false if the secret is not found.
+func getSecret(ctx context.Context, secrets secretGetter, name, trustDomain string) (*corev1.Secret, bool, error) {
+	secret, err := secrets.Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+	case k8serrors.IsNotFound(err):
+		return nil, false, nil
+	default:
+		return nil, false, status.Errorf(codes.Internal, "failed to get secret: %v", err)
+	}
+
+	if secret.Labels[managedByLabel] != managedByValue || secret.Annotations[trustDomainAnnotation] != trustDomain {
+		return nil, false, status.Error(codes.InvalidArgument, "secret is not managed by this SPIRE deployment")
+	}
+	return secret, true, nil
+}
+
+type secretOptions struct {
+	namespace string
+	name      string
+}
+
+func optionsFromMetadata(metadata []string) (*secretOptions, error) {
+	data, err := svidstore.ParseMetadata(metadata)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	name, ok := data["name"]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid name %q: %s", name, strings.Join(errs, "; "))
+	}
+
+	namespace, ok := data["namespace"]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid namespace %q: %s", namespace, strings.Join(errs, "; "))
+	}
+
+	return &secretOptions{
+		namespace: namespace,
+		name:      name,
+	}, nil
+}
@@ -0,0 +1,401 @@
+package vaultkv
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/token"
+	vapi "github.com/hashicorp/vault/api"
+	svidstorev1 "github.com/spiffe/spire-plugin-sdk/proto/spire/plugin/agent/svidstore/v1"
+	configv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pluginconf"
+	"github.com/spiffe/spire/pkg/server/common/vault"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "vault_kv"
+
+	defaultKVMountPoint = "secret"
+	defaultKVVersion    = 2
+
+	// trustDomainKey is the key of the secret data that holds the trust
+	// domain of the SPIRE deployment that manages the secret.
+	trustDomainKey = "trustDomain"
+)
+
+func BuiltIn() catalog.BuiltIn {
+	return builtin(New())
+}
+
+func builtin(p *VaultKVPlugin) catalog.BuiltIn {
+	return catalog.MakeBuiltIn(pluginName,
+		svidstorev1.SVIDStorePluginServer(p),
+		configv1.ConfigServiceServer(p),
+	)
+}
+
+func New() *VaultKVPlugin {
+	p := &VaultKVPlugin{}
+	p.hooks.lookupEnv = os.LookupEnv
+
+	return p
+}
+
+type Configuration struct {
+	vault.BaseConfiguration `hcl:",squash"`
+
+	// KVMountPoint is the mount point of the KV secrets engine.
+	KVMountPoint string `hcl:"kv_mount_point" json:"kv_mount_point,omitempty"`
+
+	// KVVersion is the version of the KV secrets engine, 1 or 2.
+	KVVersion int `hcl:"kv_version" json:"kv_version,omitempty"`
+
+	// PathPrefix is prepended to the path of each SVID, so that entries
+	// cannot write outside of it.
+	PathPrefix string `hcl:"path_prefix" json:"path_prefix,omitempty"`
+
+	UnusedKeyPositions map[string][]token.Pos `hcl:",unusedKeyPositions" json:",omitempty"`
+}
+
+type kvConfig struct {
+	authMethod   vault.AuthMethod
+	base         vault.BaseConfiguration
+	kvMountPoint string
+	kvVersion    int
+	pathPrefix   string
+}
+
+func buildConfig(_ catalog.CoreConfig, hclText string, status *pluginconf.Status) *kvConfig {
+	newConfig := &Configuration{}
+	if err := hcl.Decode(newConfig, hclText); err != nil {
+		status.ReportErrorf("unable to decode configuration: %v", err)
+		return nil
+	}
+
+	pluginconf.ReportUnusedKeys(status, newConfig.UnusedKeyPositions)
+
+	authMethod, err := vault.ParseAuthMethod(&newConfig.BaseConfiguration)
+	if err != nil {
+		status.ReportError(statusMessage(err))
+	}
+
+	kvMountPoint := strings.Trim(newConfig.KVMountPoint, "/")
+	if kvMountPoint == "" {
+		kvMountPoint = defaultKVMountPoint
+	}
+
+	kvVersion := newConfig.KVVersion
+	switch kvVersion {
+	case 0:
+		kvVersion = defaultKVVersion
+	case 1, 2:
+	default:
+		status.ReportErrorf("kv_version must be 1 or 2; got %d", kvVersion)
+	}
+
+	pathPrefix := strings.Trim(newConfig.PathPrefix, "/")
+	if pathPrefix != "" && !fs.ValidPath(pathPrefix) {
+		status.ReportErrorf("invalid path_prefix %q", newConfig.PathPrefix)
+	}
+
+	return &kvConfig{
+		authMethod:   authMethod,
+		base:         newConfig.BaseConfiguration,
+		kvMountPoint: kvMountPoint,
+		kvVersion:    kvVersion,
+		pathPrefix:   pathPrefix,
+	}
+}
+
+type VaultKVPlugin struct {
+	svidstorev1.UnsafeSVIDStoreServer
+	configv1.UnsafeConfigServer
+
+	log         hclog.Logger
+	mtx         sync.RWMutex
+	config      *kvConfig
+	cc          *vault.ClientConfig
+	vc          *vault.Client
+	trustDomain string
+
+	hooks struct {
+		lookupEnv func(string) (string, bool)
+	}
+}
+
+func (p *VaultKVPlugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+// Configure configures the VaultKVPlugin.
+func (p *VaultKVPlugin) Configure(_ context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	newConfig, _, err := pluginconf.Build(req, buildConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if newConfig.base.InsecureSkipVerify {
+		p.log.Warn("TLS verification of Vault certificates is skipped. This is only recommended for test environments.")
+	}
+
+	cp, err := vault.GenClientParams(newConfig.authMethod, &newConfig.base, p.hooks.lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := vault.NewClientConfig(cp, p.log)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.config = newConfig
+	p.cc = cc
+	p.vc = nil // force re-authentication with the new config
+	p.trustDomain = req.CoreConfiguration.TrustDomain
+
+	return &configv1.ConfigureResponse{}, nil
+}
+
+func (p *VaultKVPlugin) Validate(_ context.Context, req *configv1.ValidateRequest) (*configv1.ValidateResponse, error) {
+	_, notes, err := pluginconf.Build(req, buildConfig)
+
+	return &configv1.ValidateResponse{
+		Valid: err == nil,
+		Notes: notes,
+	}, nil
+}
+
+// PutX509SVID writes the X509-SVID to the KV secret selected by the entry.
+func (p *VaultKVPlugin) PutX509SVID(ctx context.Context, req *svidstorev1.PutX509SVIDRequest) (*svidstorev1.PutX509SVIDResponse, error) {
+	kv, secretPath, trustDomain, err := p.getStore(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := svidstore.SecretFromProto(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse request: %v", err)
+	}
+
+	if _, err := getSecret(ctx, kv, secretPath, trustDomain); err != nil {
+		return nil, err
+	}
+
+	federatedBundles := make(map[string]any, len(data.FederatedBundles))
+	for td, bundle := range data.FederatedBundles {
+		federatedBundles[td] = bundle
+	}
+
+	if err := kv.Put(ctx, secretPath, map[string]any{
+		"spiffeID":         data.SPIFFEID,
+		"x509SVID":         data.X509SVID,
+		"x509SVIDKey":      data.X509SVIDKey,
+		"bundle":           data.Bundle,
+		"federatedBundles": federatedBundles,
+		trustDomainKey:     trustDomain,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to write secret: %v", err)
+	}
+
+	p.log.With("secret_path", secretPath).Debug("Secret written")
+	return &svidstorev1.PutX509SVIDResponse{}, nil
+}
+
+// DeleteX509SVID deletes the KV secret selected by the entry. On KV v2, all
+// the versions of the secret are deleted.
+func (p *VaultKVPlugin) DeleteX509SVID(ctx context.Context, req *svidstorev1.DeleteX509SVIDRequest) (*svidstorev1.DeleteX509SVIDResponse, error) {
+	kv, secretPath, trustDomain, err := p.getStore(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	log := p.log.With("secret_path", secretPath)
+	found, err := getSecret(ctx, kv, secretPath, trustDomain)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		log.Debug("Secret to delete not found")
+		return &svidstorev1.DeleteX509SVIDResponse{}, nil
+	}
+
+	if err := kv.Delete(ctx, secretPath); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete secret: %v", err)
+	}
+
+	log.Debug("Secret deleted")
+	return &svidstorev1.DeleteX509SVIDResponse{}, nil
+}
+
+// getStore returns the KV store, the path of the secret selected by the
+// entry and the trust domain of the agent.
+func (p *VaultKVPlugin) getStore(metadata []string) (kvStore, string, string, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.config == nil {
+		return nil, "", "", status.Error(codes.FailedPrecondition, "not configured")
+	}
+
+	secretPath, err := pathFromMetadata(metadata)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if p.config.pathPrefix != "" {
+		secretPath = path.Join(p.config.pathPrefix, secretPath)
+	}
+
+	if p.vc == nil {
+		if err := p.genVaultClient(); err != nil {
+			return nil, "", "", err
+		}
+	}
+
+	var kv kvStore
+	switch p.config.kvVersion {
+	case 1:
+		kv = kvV1{kv: p.vc.VaultClient().KVv1(p.config.kvMountPoint)}
+	default:
+		kv = kvV2{kv: p.vc.VaultClient().KVv2(p.config.kvMountPoint)}
+	}
+	return kv, secretPath, p.trustDomain, nil
+}
+
+// genVaultClient authenticates a new Vault client. It must be called with
+// the lock held.
+func (p *VaultKVPlugin) genVaultClient() error {
+	renewCh := make(chan struct{})
+	vc, err := p.cc.NewAuthenticatedClient(p.config.authMethod, renewCh)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to prepare authenticated client: %v", err)
+	}
+	p.vc = vc
+
+	// If renewCh is closed, the token has expired and cannot be renewed.
+	go func(myVC *vault.Client) {
+		<-renewCh
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+		if p.vc == myVC {
+			p.vc = nil
+			p.log.Debug("Going to re-authenticate to the Vault during the next operation")
+		}
+	}(vc)
+
+	return nil
+}
+
+// getSecret gets the secret and validates that it is managed by the SPIRE
+// deployment of the trust domain. It returns false if the secret is not found.
+func getSecret(ctx context.Context, kv kvStore, secretPath, trustDomain string) (bool, error) {
+	data, found, err := kv.Get(ctx, secretPath)
+	switch {
+	case err != nil:
+		return false, status.Errorf(codes.Internal, "failed to get secret: %v", err)
+	case !found:
+		return false, nil
+	}
+
+	if td, ok := data[trustDomainKey].(string); !ok || td != trustDomain {
+		return false, status.Error(codes.InvalidArgument, "secret is not managed by this SPIRE deployment")
+	}
+	return true, nil
+}
+
+func pathFromMetadata(metadata []string) (string, error) {
+	data, err := svidstore.ParseMetadata(metadata)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	secretPath, ok := data["path"]
+	if !ok {
+		return "", status.Error(codes.InvalidArgument, "path is required")
+	}
+	if secretPath == "." || !fs.ValidPath(secretPath) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid path %q: must be a relative path without \".\" or \"..\" elements", secretPath)
+	}
+
+	return secretPath, nil
+}
+
+// statusMessage returns the message of errors with a gRPC status.
+func statusMessage(err error) string {
+	if st, ok := status.FromError(err); ok {
+		return st.Message()
+	}
+	return err.Error()
+}
+
+// kvStore abstracts the differences between the versions of the KV secrets
+// engine.
+type kvStore interface {
+	Get(ctx context.Context, secretPath string) (map[string]any, bool, error)
+	Put(ctx context.Context, secretPath string, data map[string]any) error
+	Delete(ctx context.Context, secretPath string) error
+}
+
+type kvV1 struct {
+	kv *vapi.KVv1
+}
+
+func (s kvV1) Get(ctx context.Context, secretPath string) (map[string]any, bool, error) {
+	secret, err := s.kv.Get(ctx, secretPath)
+	switch {
+	case errors.Is(err, vapi.ErrSecretNotFound):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, err
+	}
+	return secret.Data, true, nil
+}
+
+func (s kvV1) Put(ctx context.Context, secretPath string, data map[string]any) error {
+	return s.kv.Put(ctx, secretPath, data)
+}
+
+func (s kvV1) Delete(ctx context.Context, secretPath string) error {
+	return s.kv.Delete(ctx, secretPath)
+}
+
+type kvV2 struct {
+	kv *vapi.KVv2
+}
+
+func (s kvV2) Get(ctx context.Context, secretPath string) (map[string]any, bool, error) {
+	secret, err := s.kv.Get(ctx, secretPath)
+	switch {
+	case errors.Is(err, vapi.ErrSecretNotFound):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, err
+	}
+	// The data of the latest version is nil when it has been deleted.
+	if secret.Data == nil {
+		return nil, false, nil
+	}
+	return secret.Data, true, nil
+}
+
+func (s kvV2) Put(ctx context.Context, secretPath string, data map[string]any) error {
+	_, err := s.kv.Put(ctx, secretPath, data)
+	return err
+}
+
+func (s kvV2) Delete(ctx context.Context, secretPath string) error {
+	return s.kv.DeleteMetadata(ctx, secretPath)
+}
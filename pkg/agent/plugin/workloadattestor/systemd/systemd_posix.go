@@ -9,9 +9,16 @@ import (
 
 	"github.com/godbus/dbus/v5"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/go-spiffe/v2/exp/proto/spiffe/broker"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	workloadattestorv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/plugin/agent/workloadattestor/v1"
+	configv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"github.com/spiffe/spire/pkg/agent/broker/brokercontext"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pluginconf"
 	"github.com/spiffe/spire/pkg/common/util"
+	spirebroker "github.com/spiffe/spire/proto/spire/agent/broker"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -20,14 +27,125 @@ const (
 	systemdDBusInterface      = "org.freedesktop.systemd1"
 	systemdDBusPath           = "/org/freedesktop/systemd1"
 	systemdGetUnitByPIDMethod = "org.freedesktop.systemd1.Manager.GetUnitByPID"
+	systemdGetUnitMethod      = "org.freedesktop.systemd1.Manager.GetUnit"
+
+	workloadPIDReferenceTypeURL = "type.googleapis.com/spiffe.broker.WorkloadPIDReference"
+	systemdUnitReferenceTypeURL = "type.googleapis.com/spire.agent.broker.SystemdUnitReference"
+
+	// unitReferenceScopeAllowedUnits limits brokers to the units listed in
+	// allowed_units.
+	unitReferenceScopeAllowedUnits = "allowed_units"
+
+	// unitReferenceScopeAny allows brokers to reference any unit.
+	unitReferenceScopeAny = "any"
 )
 
 func builtin(p *Plugin) catalog.BuiltIn {
 	return catalog.MakeBuiltIn(pluginName,
 		workloadattestorv1.WorkloadAttestorPluginServer(p),
+		configv1.ConfigServiceServer(p),
 	)
 }
 
+type Configuration struct {
+	// Experimental contains experimental configs.
+	Experimental *experimentalConfig `hcl:"experimental"`
+
+	// brokers holds the unit reference scope of each broker allowed to
+	// reference workloads through the SPIFFE Broker API, keyed by broker
+	// SPIFFE ID. It is nil when no broker configuration is provided.
+	brokers map[string]unitReferenceScope
+}
+
+type experimentalConfig struct {
+	// Broker contains SPIFFE Broker API-specific configuration.
+	Broker *brokerConfig `hcl:"broker"`
+}
+
+type brokerConfig struct {
+	Brokers []brokerEntryConfig `hcl:"brokers"`
+}
+
+type brokerEntryConfig struct {
+	ID                 string   `hcl:"id"`
+	UnitReferenceScope string   `hcl:"unit_reference_scope"`
+	AllowedUnits       []string `hcl:"allowed_units"`
+}
+
+// unitReferenceScope restricts the systemd units a broker may reference.
+// A nil allowedUnits set means any unit is allowed.
+type unitReferenceScope struct {
+	allowedUnits map[string]struct{}
+}
+
+func (s unitReferenceScope) allows(unit string) bool {
+	if s.allowedUnits == nil {
+		return true
+	}
+	_, ok := s.allowedUnits[unit]
+	return ok
+}
+
+func buildConfig(_ catalog.CoreConfig, hclText string, status *pluginconf.Status) *Configuration {
+	newConfig := new(Configuration)
+	if err := hcl.Decode(newConfig, hclText); err != nil {
+		status.ReportErrorf("failed to decode configuration: %v", err)
+		return nil
+	}
+
+	if newConfig.Experimental != nil && newConfig.Experimental.Broker != nil {
+		newConfig.brokers = buildBrokers(newConfig.Experimental.Broker, status)
+	}
+
+	return newConfig
+}
+
+func buildBrokers(config *brokerConfig, status *pluginconf.Status) map[string]unitReferenceScope {
+	const path = "experimental.broker"
+	if len(config.Brokers) == 0 {
+		status.ReportErrorf("%s.brokers: at least one broker is required", path)
+		return map[string]unitReferenceScope{}
+	}
+
+	brokers := make(map[string]unitReferenceScope, len(config.Brokers))
+	for i, b := range config.Brokers {
+		if b.ID == "" {
+			status.ReportErrorf("%s.brokers[%d].id: must be specified", path, i)
+			continue
+		}
+		if _, dup := brokers[b.ID]; dup {
+			status.ReportErrorf("%s.brokers[%s].id: duplicate broker id", path, b.ID)
+			continue
+		}
+		if _, err := spiffeid.FromString(b.ID); err != nil {
+			status.ReportErrorf("%s.brokers[%s].id: %v", path, b.ID, err)
+			continue
+		}
+
+		switch b.UnitReferenceScope {
+		case unitReferenceScopeAllowedUnits, "":
+			if len(b.AllowedUnits) == 0 {
+				status.ReportErrorf("%s.brokers[%s].allowed_units: at least one unit is required when unit_reference_scope is %q", path, b.ID, unitReferenceScopeAllowedUnits)
+				continue
+			}
+			allowedUnits := make(map[string]struct{}, len(b.AllowedUnits))
+			for _, unit := range b.AllowedUnits {
+				allowedUnits[unit] = struct{}{}
+			}
+			brokers[b.ID] = unitReferenceScope{allowedUnits: allowedUnits}
+		case unitReferenceScopeAny:
+			if len(b.AllowedUnits) != 0 {
+				status.ReportErrorf("%s.brokers[%s].allowed_units: cannot be set when unit_reference_scope is %q", path, b.ID, unitReferenceScopeAny)
+				continue
+			}
+			brokers[b.ID] = unitReferenceScope{}
+		default:
+			status.ReportErrorf("%s.brokers[%s].unit_reference_scope: unsupported value %q; must be one of [allowed_units, any]", path, b.ID, b.UnitReferenceScope)
+		}
+	}
+	return brokers
+}
+
 type DBusUnitInfo struct {
 	UnitID           string
 	UnitFragmentPath string
@@ -35,19 +153,25 @@ type DBusUnitInfo struct {
 
 type Plugin struct {
 	workloadattestorv1.UnsafeWorkloadAttestorServer
+	configv1.UnsafeConfigServer
 
 	log hclog.Logger
 
+	mu     sync.Mutex
+	config *Configuration
+
 	dbusMutex sync.Mutex
 	dbusConn  *dbus.Conn
 
-	// hook for tests
-	getUnitInfo func(ctx context.Context, p *Plugin, pid uint) (*DBusUnitInfo, error)
+	// hooks for tests
+	getUnitInfo       func(ctx context.Context, p *Plugin, pid uint) (*DBusUnitInfo, error)
+	getUnitInfoByName func(ctx context.Context, p *Plugin, name string) (*DBusUnitInfo, error)
 }
 
 func New() *Plugin {
 	p := &Plugin{}
 	p.getUnitInfo = getSystemdUnitInfo
+	p.getUnitInfoByName = getSystemdUnitInfoByName
 	return p
 }
 
@@ -65,21 +189,119 @@ func (p *Plugin) Attest(ctx context.Context, req *workloadattestorv1.AttestReque
 		return nil, err
 	}
 
-	var selectorValues []string
+	return &workloadattestorv1.AttestResponse{
+		SelectorValues: unitSelectorValues(uInfo),
+	}, nil
+}
 
-	selectorValues = append(selectorValues, makeSelectorValue("id", uInfo.UnitID))
-	selectorValues = append(selectorValues, makeSelectorValue("fragment_path", uInfo.UnitFragmentPath))
+// AttestReference attests WorkloadPIDReference and SystemdUnitReference
+// references. When the reference comes from a broker, the broker must be
+// configured under experimental.broker and the unit the reference resolves
+// to must be within the broker's unit_reference_scope. The only exception
+// are PID references when experimental.broker is not configured, which are
+// attested as by Attest. Other reference types are not handled by this
+// plugin.
+func (p *Plugin) AttestReference(ctx context.Context, req *workloadattestorv1.AttestReferenceRequest) (*workloadattestorv1.AttestReferenceResponse, error) {
+	reference := req.GetReference()
+	switch reference.GetTypeUrl() {
+	case workloadPIDReferenceTypeURL, systemdUnitReferenceTypeURL:
+	default:
+		return nil, status.Errorf(codes.Unimplemented, "unsupported reference type: %s", reference.GetTypeUrl())
+	}
 
-	return &workloadattestorv1.AttestResponse{
-		SelectorValues: selectorValues,
+	scope, err := p.getBrokerScopeIfPresent(ctx, reference.TypeUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	var uInfo *DBusUnitInfo
+	switch reference.TypeUrl {
+	case workloadPIDReferenceTypeURL:
+		var pidRef broker.WorkloadPIDReference
+		if err := reference.UnmarshalTo(&pidRef); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unable to unmarshal PID reference: %v", err)
+		}
+		pid, err := util.CheckedCast[uint](pidRef.Pid)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid value for PID: %v", err)
+		}
+		uInfo, err = p.getUnitInfo(ctx, p, pid)
+		if err != nil {
+			return nil, err
+		}
+	case systemdUnitReferenceTypeURL:
+		var unitRef spirebroker.SystemdUnitReference
+		if err := reference.UnmarshalTo(&unitRef); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unable to unmarshal systemd unit reference: %v", err)
+		}
+		if unitRef.Unit == "" {
+			return nil, status.Error(codes.InvalidArgument, "systemd unit reference is missing unit")
+		}
+		uInfo, err = p.getUnitInfoByName(ctx, p, unitRef.Unit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if scope != nil && !scope.allows(uInfo.UnitID) {
+		return nil, status.Errorf(codes.PermissionDenied, "unit %q is outside of the broker unit_reference_scope", uInfo.UnitID)
+	}
+
+	return &workloadattestorv1.AttestReferenceResponse{
+		SelectorValues: unitSelectorValues(uInfo),
 	}, nil
 }
 
-// AttestReference returns Unimplemented. This plugin does not handle
-// reference-based workload attestation; the host falls back to PID-based
-// Attest when the reference is a WorkloadPIDReference.
-func (p *Plugin) AttestReference(_ context.Context, _ *workloadattestorv1.AttestReferenceRequest) (*workloadattestorv1.AttestReferenceResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "AttestReference not implemented")
+func (p *Plugin) Configure(_ context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	newConfig, _, err := pluginconf.Build(req, buildConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.config = newConfig
+	p.mu.Unlock()
+
+	return &configv1.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) Validate(_ context.Context, req *configv1.ValidateRequest) (*configv1.ValidateResponse, error) {
+	_, notes, err := pluginconf.Build(req, buildConfig)
+
+	return &configv1.ValidateResponse{
+		Valid: err == nil,
+		Notes: notes,
+	}, nil
+}
+
+// getBrokerScopeIfPresent returns the unit reference scope of the broker
+// making the request, or nil if the request does not come from a broker.
+func (p *Plugin) getBrokerScopeIfPresent(ctx context.Context, typeURL string) (*unitReferenceScope, error) {
+	callerID, ok, err := brokercontext.CallerIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to determine broker caller identity: %v", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	p.mu.Lock()
+	config := p.config
+	p.mu.Unlock()
+	if config == nil || config.brokers == nil {
+		if typeURL == workloadPIDReferenceTypeURL {
+			// Without a broker configuration, brokers keep the access to PID
+			// references they had before this plugin handled references,
+			// when the host fell back to attesting the PID with Attest.
+			return nil, nil
+		}
+		return nil, status.Error(codes.FailedPrecondition, "broker configuration missing: systemd unit references require experimental.broker to be configured")
+	}
+	scope, ok := config.brokers[callerID.String()]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "broker %q is not configured", callerID.String())
+	}
+	return &scope, nil
 }
 
 func (p *Plugin) Close() error {
@@ -125,6 +347,40 @@ func getSystemdUnitInfo(ctx context.Context, p *Plugin, pid uint) (*DBusUnitInfo
 		return nil, status.Errorf(codes.Internal, "failed to get unit by pid %d: %v", pid, err)
 	}
 
+	return getUnitInfoFromPath(conn, unitPath)
+}
+
+func getSystemdUnitInfoByName(ctx context.Context, p *Plugin, name string) (*DBusUnitInfo, error) {
+	// We are not closing the connection here because it's closed when the Close() function is called as part of unloading the plugin.
+	conn, err := p.getDBusConn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to open dbus connection: %v", err)
+	}
+
+	// Get the unit for the given name from the systemd service. This fails
+	// if the unit is not loaded.
+	call := conn.Object(systemdDBusInterface, systemdDBusPath).CallWithContext(ctx, systemdGetUnitMethod, 0, name)
+
+	var unitPath dbus.ObjectPath
+	err = call.Store(&unitPath)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to get unit %q: %v", name, err)
+	}
+
+	// Only running units are attested, so that a broker cannot obtain
+	// identities on behalf of a service that is not running.
+	activeState, err := getStringProperty(conn.Object(systemdDBusInterface, unitPath), "ActiveState")
+	if err != nil {
+		return nil, err
+	}
+	if activeState != "active" {
+		return nil, status.Errorf(codes.FailedPrecondition, "unit %q is not active", name)
+	}
+
+	return getUnitInfoFromPath(conn, unitPath)
+}
+
+func getUnitInfoFromPath(conn *dbus.Conn, unitPath dbus.ObjectPath) (*DBusUnitInfo, error) {
 	obj := conn.Object(systemdDBusInterface, unitPath)
 
 	id, err := getStringProperty(obj, "Id")
@@ -151,6 +407,13 @@ func getStringProperty(obj dbus.BusObject, prop string) (string, error) {
 	return propVal, nil
 }
 
+func unitSelectorValues(uInfo *DBusUnitInfo) []string {
+	return []string{
+		makeSelectorValue("id", uInfo.UnitID),
+		makeSelectorValue("fragment_path", uInfo.UnitFragmentPath),
+	}
+}
+
 func makeSelectorValue(kind, value string) string {
 	return fmt.Sprintf("%s:%s", kind, value)
 }
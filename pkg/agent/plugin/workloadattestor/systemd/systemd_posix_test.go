@@ -8,12 +8,18 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/exp/proto/spiffe/broker"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/broker/brokercontext"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	spirebroker "github.com/spiffe/spire/proto/spire/agent/broker"
 	"github.com/spiffe/spire/test/plugintest"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 var (
@@ -46,7 +52,7 @@ func TestPlugin(t *testing.T) {
 	for _, testCase := range testCases {
 		log, logHook := test.NewNullLogger()
 		t.Run(testCase.name, func(t *testing.T) {
-			p := loadPlugin(t, log)
+			p := loadPlugin(t, log, "")
 			selectors, err := p.Attest(ctx, testCase.pid)
 			spiretest.RequireGRPCStatus(t, err, testCase.expectCode, testCase.expectMsg)
 			if testCase.expectCode != codes.OK {
@@ -68,11 +74,229 @@ func TestPlugin(t *testing.T) {
 	}
 }
 
-func loadPlugin(t *testing.T, log logrus.FieldLogger) workloadattestor.WorkloadAttestor {
+func TestAttestReference(t *testing.T) {
+	brokerConfig := `
+		experimental {
+			broker {
+				brokers = [
+					{
+						id = "spiffe://example.org/broker"
+						allowed_units = ["fake.service"]
+					},
+					{
+						id = "spiffe://example.org/any"
+						unit_reference_scope = "any"
+					}
+				]
+			}
+		}`
+	brokerCtx := func(id string) context.Context {
+		return brokercontext.WithCallerID(ctx, spiffeid.RequireFromString(id))
+	}
+	pidRef := func(pid int32) *anypb.Any {
+		ref, err := anypb.New(&broker.WorkloadPIDReference{Pid: pid})
+		require.NoError(t, err)
+		return ref
+	}
+	unitRef := func(unit string) *anypb.Any {
+		ref, err := anypb.New(&spirebroker.SystemdUnitReference{Unit: unit})
+		require.NoError(t, err)
+		return ref
+	}
+	fakeSelectorValues := []string{"id:fake.service", "fragment_path:/org/freedesktop/systemd1/unit/fake_2eservice"}
+	otherSelectorValues := []string{"id:other.service", "fragment_path:/org/freedesktop/systemd1/unit/other_2eservice"}
+
+	testCases := []struct {
+		name           string
+		ctx            context.Context
+		config         string
+		ref            *anypb.Any
+		selectorValues []string
+		expectCode     codes.Code
+		expectMsg      string
+	}{
+		{
+			name:           "PID reference without broker",
+			ctx:            ctx,
+			ref:            pidRef(1),
+			selectorValues: fakeSelectorValues,
+		},
+		{
+			name:           "unit reference without broker",
+			ctx:            ctx,
+			ref:            unitRef("other.service"),
+			selectorValues: otherSelectorValues,
+		},
+		{
+			name:       "unit reference without unit",
+			ctx:        ctx,
+			ref:        unitRef(""),
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "workloadattestor(systemd): systemd unit reference is missing unit",
+		},
+		{
+			name:       "unit is not active",
+			ctx:        ctx,
+			ref:        unitRef("inactive.service"),
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  `workloadattestor(systemd): unit "inactive.service" is not active`,
+		},
+		{
+			name:       "unsupported reference type",
+			ctx:        ctx,
+			ref:        &anypb.Any{TypeUrl: "type.googleapis.com/spiffe.broker.KubernetesObjectReference"},
+			expectCode: codes.Unimplemented,
+			expectMsg:  "workloadattestor(systemd): unsupported reference type: type.googleapis.com/spiffe.broker.KubernetesObjectReference",
+		},
+		{
+			name:       "unit reference without broker configuration",
+			ctx:        brokerCtx("spiffe://example.org/broker"),
+			ref:        unitRef("fake.service"),
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  "workloadattestor(systemd): broker configuration missing: systemd unit references require experimental.broker to be configured",
+		},
+		{
+			name:           "PID reference without broker configuration",
+			ctx:            brokerCtx("spiffe://example.org/broker"),
+			ref:            pidRef(1),
+			selectorValues: fakeSelectorValues,
+		},
+		{
+			name:       "broker not configured",
+			ctx:        brokerCtx("spiffe://example.org/other"),
+			config:     brokerConfig,
+			ref:        unitRef("fake.service"),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  `workloadattestor(systemd): broker "spiffe://example.org/other" is not configured`,
+		},
+		{
+			name:           "allowed unit by name",
+			ctx:            brokerCtx("spiffe://example.org/broker"),
+			config:         brokerConfig,
+			ref:            unitRef("fake.service"),
+			selectorValues: fakeSelectorValues,
+		},
+		{
+			name:           "allowed unit by PID",
+			ctx:            brokerCtx("spiffe://example.org/broker"),
+			config:         brokerConfig,
+			ref:            pidRef(1),
+			selectorValues: fakeSelectorValues,
+		},
+		{
+			name:       "unit not allowed by name",
+			ctx:        brokerCtx("spiffe://example.org/broker"),
+			config:     brokerConfig,
+			ref:        unitRef("other.service"),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  `workloadattestor(systemd): unit "other.service" is outside of the broker unit_reference_scope`,
+		},
+		{
+			name:       "unit not allowed by PID",
+			ctx:        brokerCtx("spiffe://example.org/broker"),
+			config:     brokerConfig,
+			ref:        pidRef(3),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  `workloadattestor(systemd): unit "other.service" is outside of the broker unit_reference_scope`,
+		},
+		{
+			name:           "any unit",
+			ctx:            brokerCtx("spiffe://example.org/any"),
+			config:         brokerConfig,
+			ref:            unitRef("other.service"),
+			selectorValues: otherSelectorValues,
+		},
+	}
+
+	for _, testCase := range testCases {
+		log, _ := test.NewNullLogger()
+		t.Run(testCase.name, func(t *testing.T) {
+			p := loadPlugin(t, log, testCase.config)
+			selectors, err := p.AttestReference(testCase.ctx, testCase.ref)
+			spiretest.RequireGRPCStatus(t, err, testCase.expectCode, testCase.expectMsg)
+			if testCase.expectCode != codes.OK {
+				require.Nil(t, selectors)
+				return
+			}
+
+			var selectorValues []string
+			for _, selector := range selectors {
+				require.Equal(t, "systemd", selector.Type)
+				selectorValues = append(selectorValues, selector.Value)
+			}
+			require.Equal(t, testCase.selectorValues, selectorValues)
+		})
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	testCases := []struct {
+		name      string
+		config    string
+		expectMsg string
+	}{
+		{
+			name: "no configuration",
+		},
+		{
+			name:   "valid brokers",
+			config: `experimental { broker { brokers = [ { id = "spiffe://example.org/broker" allowed_units = ["fake.service"] }, { id = "spiffe://example.org/any" unit_reference_scope = "any" } ] } }`,
+		},
+		{
+			name:      "no brokers",
+			config:    `experimental { broker { } }`,
+			expectMsg: "experimental.broker.brokers: at least one broker is required",
+		},
+		{
+			name:      "invalid broker id",
+			config:    `experimental { broker { brokers = [ { id = "broker" unit_reference_scope = "any" } ] } }`,
+			expectMsg: "experimental.broker.brokers[broker].id: scheme is missing or invalid",
+		},
+		{
+			name:      "allowed units missing",
+			config:    `experimental { broker { brokers = [ { id = "spiffe://example.org/broker" } ] } }`,
+			expectMsg: `experimental.broker.brokers[spiffe://example.org/broker].allowed_units: at least one unit is required when unit_reference_scope is "allowed_units"`,
+		},
+		{
+			name:      "allowed units with any scope",
+			config:    `experimental { broker { brokers = [ { id = "spiffe://example.org/broker" unit_reference_scope = "any" allowed_units = ["fake.service"] } ] } }`,
+			expectMsg: `experimental.broker.brokers[spiffe://example.org/broker].allowed_units: cannot be set when unit_reference_scope is "any"`,
+		},
+		{
+			name:      "unsupported scope",
+			config:    `experimental { broker { brokers = [ { id = "spiffe://example.org/broker" unit_reference_scope = "host" } ] } }`,
+			expectMsg: `experimental.broker.brokers[spiffe://example.org/broker].unit_reference_scope: unsupported value "host"; must be one of [allowed_units, any]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var err error
+			plugintest.Load(t, builtin(newPlugin()), new(workloadattestor.V1),
+				plugintest.CoreConfig(catalog.CoreConfig{
+					TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+				}),
+				plugintest.CaptureConfigureError(&err),
+				plugintest.Configure(testCase.config))
+			if testCase.expectMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			spiretest.RequireGRPCStatusContains(t, err, codes.InvalidArgument, testCase.expectMsg)
+		})
+	}
+}
+
+func loadPlugin(t *testing.T, log logrus.FieldLogger, config string) workloadattestor.WorkloadAttestor {
 	p := newPlugin()
 
 	v1 := new(workloadattestor.V1)
-	plugintest.Load(t, builtin(p), v1, plugintest.Log(log))
+	plugintest.Load(t, builtin(p), v1,
+		plugintest.Log(log),
+		plugintest.CoreConfig(catalog.CoreConfig{
+			TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+		}),
+		plugintest.Configure(config))
 	return v1
 }
 
@@ -84,9 +308,23 @@ func newPlugin() *Plugin {
 			return &DBusUnitInfo{"fake.service", "/org/freedesktop/systemd1/unit/fake_2eservice"}, nil
 		case 2:
 			return nil, status.Errorf(codes.Internal, "unknown process")
+		case 3:
+			return &DBusUnitInfo{"other.service", "/org/freedesktop/systemd1/unit/other_2eservice"}, nil
 		default:
 			return nil, status.Errorf(codes.Internal, "unhandled unit Id test case %d", pid)
 		}
 	}
+	p.getUnitInfoByName = func(ctx context.Context, p *Plugin, name string) (*DBusUnitInfo, error) {
+		switch name {
+		case "fake.service":
+			return &DBusUnitInfo{"fake.service", "/org/freedesktop/systemd1/unit/fake_2eservice"}, nil
+		case "other.service":
+			return &DBusUnitInfo{"other.service", "/org/freedesktop/systemd1/unit/other_2eservice"}, nil
+		case "inactive.service":
+			return nil, status.Errorf(codes.FailedPrecondition, "unit %q is not active", name)
+		default:
+			return nil, status.Errorf(codes.NotFound, "unhandled unit name test case %q", name)
+		}
+	}
 	return p
 }
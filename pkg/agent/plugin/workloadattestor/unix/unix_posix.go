@@ -17,8 +17,11 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
 	"github.com/shirou/gopsutil/v4/process"
+	"github.com/spiffe/go-spiffe/v2/exp/proto/spiffe/broker"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	workloadattestorv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/plugin/agent/workloadattestor/v1"
 	configv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"github.com/spiffe/spire/pkg/agent/broker/brokercontext"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/pluginconf"
 	"github.com/spiffe/spire/pkg/common/util"
@@ -26,6 +29,10 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const (
+	workloadPIDReferenceTypeURL = "type.googleapis.com/spiffe.broker.WorkloadPIDReference"
+)
+
 func builtin(p *Plugin) catalog.BuiltIn {
 	return catalog.MakeBuiltIn(pluginName,
 		workloadattestorv1.WorkloadAttestorPluginServer(p),
@@ -90,8 +97,42 @@ func (ps PSProcessInfo) Groups() ([]string, error) {
 type Configuration struct {
 	DiscoverWorkloadPath bool  `hcl:"discover_workload_path"`
 	WorkloadSizeLimit    int64 `hcl:"workload_size_limit"`
+
+	// Experimental contains experimental configs.
+	Experimental *experimentalConfig `hcl:"experimental"`
+
+	// brokers holds the PID reference scope of each broker allowed to
+	// reference workloads through the SPIFFE Broker API, keyed by broker
+	// SPIFFE ID. It is nil when no broker configuration is provided.
+	brokers map[string]pidReferenceScope
+}
+
+type experimentalConfig struct {
+	// Broker contains SPIFFE Broker API-specific configuration.
+	Broker *brokerConfig `hcl:"broker"`
+}
+
+type brokerConfig struct {
+	Brokers []brokerEntryConfig `hcl:"brokers"`
+}
+
+type brokerEntryConfig struct {
+	ID                string `hcl:"id"`
+	PIDReferenceScope string `hcl:"pid_reference_scope"`
 }
 
+type pidReferenceScope string
+
+const (
+	// pidReferenceScopeUnprivileged limits brokers to processes that run as
+	// neither root nor the same user as the agent.
+	pidReferenceScopeUnprivileged pidReferenceScope = "unprivileged"
+
+	// pidReferenceScopeAny allows brokers to reference any process on the
+	// host.
+	pidReferenceScopeAny pidReferenceScope = "any"
+)
+
 func buildConfig(coreConfig catalog.CoreConfig, hclText string, status *pluginconf.Status) *Configuration {
 	newConfig := new(Configuration)
 	if err := hcl.Decode(newConfig, hclText); err != nil {
@@ -99,9 +140,47 @@ func buildConfig(coreConfig catalog.CoreConfig, hclText string, status *pluginco
 		return nil
 	}
 
+	if newConfig.Experimental != nil && newConfig.Experimental.Broker != nil {
+		newConfig.brokers = buildBrokers(newConfig.Experimental.Broker, status)
+	}
+
 	return newConfig
 }
 
+func buildBrokers(config *brokerConfig, status *pluginconf.Status) map[string]pidReferenceScope {
+	const path = "experimental.broker"
+	if len(config.Brokers) == 0 {
+		status.ReportErrorf("%s.brokers: at least one broker is required", path)
+		return map[string]pidReferenceScope{}
+	}
+
+	brokers := make(map[string]pidReferenceScope, len(config.Brokers))
+	for i, b := range config.Brokers {
+		if b.ID == "" {
+			status.ReportErrorf("%s.brokers[%d].id: must be specified", path, i)
+			continue
+		}
+		if _, dup := brokers[b.ID]; dup {
+			status.ReportErrorf("%s.brokers[%s].id: duplicate broker id", path, b.ID)
+			continue
+		}
+		if _, err := spiffeid.FromString(b.ID); err != nil {
+			status.ReportErrorf("%s.brokers[%s].id: %v", path, b.ID, err)
+			continue
+		}
+
+		switch b.PIDReferenceScope {
+		case string(pidReferenceScopeUnprivileged), "":
+			brokers[b.ID] = pidReferenceScopeUnprivileged
+		case string(pidReferenceScopeAny):
+			brokers[b.ID] = pidReferenceScopeAny
+		default:
+			status.ReportErrorf("%s.brokers[%s].pid_reference_scope: unsupported value %q; must be one of [unprivileged, any]", path, b.ID, b.PIDReferenceScope)
+		}
+	}
+	return brokers
+}
+
 type Plugin struct {
 	workloadattestorv1.UnsafeWorkloadAttestorServer
 	configv1.UnsafeConfigServer
@@ -115,6 +194,7 @@ type Plugin struct {
 		newProcess      func(pid int32) (processInfo, error)
 		lookupUserByID  func(id string) (*user.User, error)
 		lookupGroupByID func(id string) (*user.Group, error)
+		geteuid         func() int
 	}
 }
 
@@ -123,6 +203,7 @@ func New() *Plugin {
 	p.hooks.newProcess = func(pid int32) (processInfo, error) { p, err := process.NewProcess(pid); return PSProcessInfo{p}, err }
 	p.hooks.lookupUserByID = user.LookupId
 	p.hooks.lookupGroupByID = user.LookupGroupId
+	p.hooks.geteuid = os.Geteuid
 	return p
 }
 
@@ -136,7 +217,77 @@ func (p *Plugin) Attest(_ context.Context, req *workloadattestorv1.AttestRequest
 		return nil, err
 	}
 
-	proc, err := p.hooks.newProcess(req.Pid)
+	selectorValues, err := p.attest(config, req.Pid, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workloadattestorv1.AttestResponse{
+		SelectorValues: selectorValues,
+	}, nil
+}
+
+// AttestReference attests WorkloadPIDReference references. When the
+// reference comes from a broker and experimental.broker is configured, the
+// broker must be listed there and the referenced process must be within the
+// broker's pid_reference_scope. Other reference types are not handled by
+// this plugin.
+func (p *Plugin) AttestReference(ctx context.Context, req *workloadattestorv1.AttestReferenceRequest) (*workloadattestorv1.AttestReferenceResponse, error) {
+	reference := req.GetReference()
+	if reference.GetTypeUrl() != workloadPIDReferenceTypeURL {
+		return nil, status.Errorf(codes.Unimplemented, "unsupported reference type: %s", reference.GetTypeUrl())
+	}
+
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := getBrokerScopeIfPresent(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var pidRef broker.WorkloadPIDReference
+	if err := reference.UnmarshalTo(&pidRef); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to unmarshal PID reference: %v", err)
+	}
+
+	selectorValues, err := p.attest(config, pidRef.Pid, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workloadattestorv1.AttestReferenceResponse{
+		SelectorValues: selectorValues,
+	}, nil
+}
+
+// getBrokerScopeIfPresent returns the PID reference scope of the broker
+// making the request, or nil if the request does not come from a broker.
+func getBrokerScopeIfPresent(ctx context.Context, config *Configuration) (*pidReferenceScope, error) {
+	callerID, ok, err := brokercontext.CallerIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to determine broker caller identity: %v", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+	if config.brokers == nil {
+		// Without a broker configuration, brokers keep the access they had
+		// before this plugin handled references, when the host fell back to
+		// attesting the referenced PID with Attest.
+		return nil, nil
+	}
+	scope, ok := config.brokers[callerID.String()]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "broker %q is not configured", callerID.String())
+	}
+	return &scope, nil
+}
+
+func (p *Plugin) attest(config *Configuration, pid int32, brokerScope *pidReferenceScope) ([]string, error) {
+	proc, err := p.hooks.newProcess(pid)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get process: %v", err)
 	}
@@ -147,6 +298,10 @@ func (p *Plugin) Attest(_ context.Context, req *workloadattestorv1.AttestRequest
 	if err != nil {
 		return nil, err
 	}
+	if brokerScope != nil && *brokerScope == pidReferenceScopeUnprivileged &&
+		(uid == "0" || uid == strconv.Itoa(p.hooks.geteuid())) {
+		return nil, status.Errorf(codes.PermissionDenied, "process %d runs as a privileged user, which is outside of the broker pid_reference_scope", pid)
+	}
 	selectorValues = append(selectorValues, makeSelectorValue("uid", uid))
 
 	if user, ok := p.getUserName(uid); ok {
@@ -200,16 +355,7 @@ func (p *Plugin) Attest(_ context.Context, req *workloadattestorv1.AttestRequest
 		}
 	}
 
-	return &workloadattestorv1.AttestResponse{
-		SelectorValues: selectorValues,
-	}, nil
-}
-
-// AttestReference returns Unimplemented. This plugin does not handle
-// reference-based workload attestation; the host falls back to PID-based
-// Attest when the reference is a WorkloadPIDReference.
-func (p *Plugin) AttestReference(_ context.Context, _ *workloadattestorv1.AttestReferenceRequest) (*workloadattestorv1.AttestReferenceResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "AttestReference not implemented")
+	return selectorValues, nil
 }
 
 func (p *Plugin) Configure(_ context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
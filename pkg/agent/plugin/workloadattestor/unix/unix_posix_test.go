@@ -14,16 +14,22 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/exp/proto/spiffe/broker"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/broker/brokercontext"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/test/plugintest"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
-var ctx = context.Background()
+var (
+	ctx          = context.Background()
+	testBrokerID = spiffeid.RequireFromString("spiffe://example.org/broker")
+)
 
 func TestPlugin(t *testing.T) {
 	spiretest.Run(t, new(Suite))
@@ -257,6 +263,170 @@ func (s *Suite) TestAttest() {
 	}
 }
 
+func (s *Suite) TestAttestReference() {
+	brokerConfig := func(scope string) string {
+		return fmt.Sprintf(`
+			experimental {
+				broker {
+					brokers = [
+						{
+							id = %q
+							pid_reference_scope = %q
+						}
+					]
+				}
+			}`, testBrokerID.String(), scope)
+	}
+	brokerCtx := brokercontext.WithCallerID(ctx, testBrokerID)
+	pidRef := func(pid int32) *anypb.Any {
+		ref, err := anypb.New(&broker.WorkloadPIDReference{Pid: pid})
+		s.Require().NoError(err)
+		return ref
+	}
+
+	testCases := []struct {
+		name           string
+		ctx            context.Context
+		config         string
+		ref            *anypb.Any
+		selectorValues []string
+		expectCode     codes.Code
+		expectMsg      string
+	}{
+		{
+			name:           "PID reference without broker",
+			ctx:            ctx,
+			ref:            pidRef(15),
+			selectorValues: []string{"uid:0", "gid:2000", "group:g2000"},
+		},
+		{
+			name:       "unsupported reference type",
+			ctx:        ctx,
+			ref:        &anypb.Any{TypeUrl: "type.googleapis.com/spiffe.broker.KubernetesObjectReference"},
+			expectCode: codes.Unimplemented,
+			expectMsg:  "workloadattestor(unix): unsupported reference type: type.googleapis.com/spiffe.broker.KubernetesObjectReference",
+		},
+		{
+			name:           "broker without broker configuration",
+			ctx:            brokerCtx,
+			ref:            pidRef(15),
+			selectorValues: []string{"uid:0", "gid:2000", "group:g2000"},
+		},
+		{
+			name:       "broker not configured",
+			ctx:        brokercontext.WithCallerID(ctx, spiffeid.RequireFromString("spiffe://example.org/other")),
+			config:     brokerConfig("any"),
+			ref:        pidRef(11),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  `workloadattestor(unix): broker "spiffe://example.org/other" is not configured`,
+		},
+		{
+			name:           "unprivileged scope allows unprivileged process",
+			ctx:            brokerCtx,
+			config:         brokerConfig(""),
+			ref:            pidRef(11),
+			selectorValues: []string{"uid:1000", "user:u1000", "gid:2000", "group:g2000"},
+		},
+		{
+			name:       "unprivileged scope denies root process",
+			ctx:        brokerCtx,
+			config:     brokerConfig("unprivileged"),
+			ref:        pidRef(15),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "workloadattestor(unix): process 15 runs as a privileged user, which is outside of the broker pid_reference_scope",
+		},
+		{
+			name:       "unprivileged scope denies agent user process",
+			ctx:        brokerCtx,
+			config:     brokerConfig("unprivileged"),
+			ref:        pidRef(3),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "workloadattestor(unix): process 3 runs as a privileged user, which is outside of the broker pid_reference_scope",
+		},
+		{
+			name:           "any scope allows root process",
+			ctx:            brokerCtx,
+			config:         brokerConfig("any"),
+			ref:            pidRef(15),
+			selectorValues: []string{"uid:0", "gid:2000", "group:g2000"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.T().Run(testCase.name, func(t *testing.T) {
+			p := s.loadPlugin(t, "example.org", testCase.config)
+			selectors, err := p.AttestReference(testCase.ctx, testCase.ref)
+			spiretest.RequireGRPCStatus(t, err, testCase.expectCode, testCase.expectMsg)
+			if testCase.expectCode != codes.OK {
+				require.Nil(t, selectors)
+				return
+			}
+
+			var selectorValues []string
+			for _, selector := range selectors {
+				require.Equal(t, "unix", selector.Type)
+				selectorValues = append(selectorValues, selector.Value)
+			}
+			require.Equal(t, testCase.selectorValues, selectorValues)
+		})
+	}
+}
+
+func (s *Suite) TestConfigureBroker() {
+	testCases := []struct {
+		name      string
+		config    string
+		expectMsg string
+	}{
+		{
+			name:   "valid broker",
+			config: `experimental { broker { brokers = [ { id = "spiffe://example.org/broker" pid_reference_scope = "any" } ] } }`,
+		},
+		{
+			name:      "no brokers",
+			config:    `experimental { broker { } }`,
+			expectMsg: "experimental.broker.brokers: at least one broker is required",
+		},
+		{
+			name:      "missing broker id",
+			config:    `experimental { broker { brokers = [ { pid_reference_scope = "any" } ] } }`,
+			expectMsg: "experimental.broker.brokers[0].id: must be specified",
+		},
+		{
+			name:      "invalid broker id",
+			config:    `experimental { broker { brokers = [ { id = "broker" } ] } }`,
+			expectMsg: "experimental.broker.brokers[broker].id: scheme is missing or invalid",
+		},
+		{
+			name:      "duplicate broker id",
+			config:    `experimental { broker { brokers = [ { id = "spiffe://example.org/broker" }, { id = "spiffe://example.org/broker" } ] } }`,
+			expectMsg: "experimental.broker.brokers[spiffe://example.org/broker].id: duplicate broker id",
+		},
+		{
+			name:      "unsupported scope",
+			config:    `experimental { broker { brokers = [ { id = "spiffe://example.org/broker" pid_reference_scope = "host" } ] } }`,
+			expectMsg: `experimental.broker.brokers[spiffe://example.org/broker].pid_reference_scope: unsupported value "host"; must be one of [unprivileged, any]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.T().Run(testCase.name, func(t *testing.T) {
+			var err error
+			plugintest.Load(t, builtin(s.newPlugin()), new(workloadattestor.V1),
+				plugintest.CoreConfig(catalog.CoreConfig{
+					TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+				}),
+				plugintest.CaptureConfigureError(&err),
+				plugintest.Configure(testCase.config))
+			if testCase.expectMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			spiretest.RequireGRPCStatusContains(t, err, codes.InvalidArgument, testCase.expectMsg)
+		})
+	}
+}
+
 func (s *Suite) writeFile(path string, data []byte) {
 	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, path), data, 0o600))
 }
@@ -281,6 +451,7 @@ func (s *Suite) newPlugin() *Plugin {
 	}
 	p.hooks.lookupUserByID = fakeLookupUserByID
 	p.hooks.lookupGroupByID = fakeLookupGroupByID
+	p.hooks.geteuid = func() int { return 1999 }
 	return p
 }
 
@@ -299,6 +470,8 @@ func (p fakeProcess) Uids() ([]uint32, error) {
 		return []uint32{1999}, nil
 	case 4, 5, 6, 7, 9, 10, 11, 12, 13, 14:
 		return []uint32{1000}, nil
+	case 15:
+		return []uint32{0}, nil
 	case 8:
 		return []uint32{1000, 1100}, nil
 	default:
@@ -314,7 +487,7 @@ func (p fakeProcess) Gids() ([]uint32, error) {
 		return nil, fmt.Errorf("unable to get GIDs for PID %d", p.pid)
 	case 6:
 		return []uint32{2999}, nil
-	case 3, 7, 9, 10, 11, 12, 13, 14:
+	case 3, 7, 9, 10, 11, 12, 13, 14, 15:
 		return []uint32{2000}, nil
 	case 8:
 		return []uint32{2000, 2100}, nil
@@ -50,3 +50,27 @@ func TestKeyTypeFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestSigningAlgorithm(t *testing.T) {
+	for _, tt := range []struct {
+		keyType   workloadkey.KeyType
+		expectAlg string
+		errMsg    string
+	}{
+		{keyType: workloadkey.ECP256, expectAlg: "ES256"},
+		{keyType: workloadkey.ECP384, expectAlg: "ES384"},
+		{keyType: workloadkey.RSA2048, expectAlg: "RS256"},
+		{keyType: workloadkey.KeyTypeUnset, errMsg: `unknown key type "UNSET"`},
+	} {
+		t.Run(tt.keyType.String(), func(t *testing.T) {
+			alg, err := tt.keyType.SigningAlgorithm()
+			if tt.errMsg != "" {
+				require.EqualError(t, err, tt.errMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectAlg, alg)
+		})
+	}
+}
@@ -13,23 +13,27 @@ import (
 const (
 	serverAPIPrefix = "spire.api.server."
 
-	WorkloadAPIServiceName             = "SpiffeWorkloadAPI"
-	WorkloadAPIServiceShortName        = "WorkloadAPI"
-	EnvoySDSv3ServiceName              = "envoy.service.secret.v3.SecretDiscoveryService"
-	EnvoySDSv3ServiceShortName         = "SDS.v3"
-	HealthServiceName                  = "grpc.health.v1.Health"
-	HealthServiceShortName             = "Health"
-	ServerLoggerServiceName            = "logger.v1.Logger"
-	AgentLoggerServiceName             = "spire.api.agent.logger.v1.Logger"
-	LoggerServiceShortName             = "Logger"
-	DebugServiceName                   = "spire.agent.debug.v1.Debug"
-	DebugServiceShortName              = "Debug"
-	DelegatedIdentityServiceName       = "spire.api.agent.delegatedidentity.v1.DelegatedIdentity"
-	DelegatedIdentityServiceShortName  = "DelegatedIdentity"
-	ServerReflectionServiceName        = "grpc.reflection.v1.ServerReflection"
-	ServerReflectionV1AlphaServiceName = "grpc.reflection.v1alpha.ServerReflection"
-	SubscribeToX509SVIDsMethodName     = "SubscribeToX509SVIDs"
-	SubscribeToX509SVIDsMetricKey      = "subscribe_to_x509_svids"
+	WorkloadAPIServiceName                     = "SpiffeWorkloadAPI"
+	WorkloadAPIServiceShortName                = "WorkloadAPI"
+	EnvoySDSv3ServiceName                      = "envoy.service.secret.v3.SecretDiscoveryService"
+	EnvoySDSv3ServiceShortName                 = "SDS.v3"
+	HealthServiceName                          = "grpc.health.v1.Health"
+	HealthServiceShortName                     = "Health"
+	ServerLoggerServiceName                    = "logger.v1.Logger"
+	AgentLoggerServiceName                     = "spire.api.agent.logger.v1.Logger"
+	LoggerServiceShortName                     = "Logger"
+	DebugServiceName                           = "spire.agent.debug.v1.Debug"
+	DebugServiceShortName                      = "Debug"
+	DebugExtensionServiceName                  = "spire.agent.debug.API"
+	DebugExtensionServiceShortName             = "DebugExtension"
+	DelegatedIdentityServiceName               = "spire.api.agent.delegatedidentity.v1.DelegatedIdentity"
+	DelegatedIdentityServiceShortName          = "DelegatedIdentity"
+	DelegatedIdentityExtensionServiceName      = "spire.agent.delegatedidentity.API"
+	DelegatedIdentityExtensionServiceShortName = "DelegatedIdentityExtension"
+	ServerReflectionServiceName                = "grpc.reflection.v1.ServerReflection"
+	ServerReflectionV1AlphaServiceName         = "grpc.reflection.v1alpha.ServerReflection"
+	SubscribeToX509SVIDsMethodName             = "SubscribeToX509SVIDs"
+	SubscribeToX509SVIDsMetricKey              = "subscribe_to_x509_svids"
 )
 
 var (
@@ -41,7 +45,9 @@ var (
 		ServerLoggerServiceName, LoggerServiceShortName,
 		AgentLoggerServiceName, LoggerServiceShortName,
 		DebugServiceName, DebugServiceShortName,
+		DebugExtensionServiceName, DebugExtensionServiceShortName,
 		DelegatedIdentityServiceName, DelegatedIdentityServiceShortName,
+		DelegatedIdentityExtensionServiceName, DelegatedIdentityExtensionServiceShortName,
 	)
 
 	// methodMetricKeyReplacer allows adding replacement for method names that
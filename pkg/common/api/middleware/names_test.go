@@ -56,6 +56,7 @@ func TestMakeMetricName(t *testing.T) {
 		{fullMethod: "/spire.api.server.debug.v1.Debug/GetInfo", service: "debug.v1.Debug", metricKey: []string{"debug", "v1", "debug", "get_info"}},
 		// agent debug
 		{fullMethod: "/spire.agent.debug.v1.Debug/GetInfo", service: "Debug", metricKey: []string{"debug", "get_info"}},
+		{fullMethod: "/spire.agent.debug.API/ExplainWorkload", service: "DebugExtension", metricKey: []string{"debug_extension", "explain_workload"}},
 		// server entry
 		{fullMethod: "/spire.api.server.entry.v1.Entry/BatchCreateEntry", service: "entry.v1.Entry", metricKey: []string{"entry", "v1", "entry", "batch_create_entry"}},
 		{fullMethod: "/spire.api.server.entry.v1.Entry/BatchDeleteEntry", service: "entry.v1.Entry", metricKey: []string{"entry", "v1", "entry", "batch_delete_entry"}},
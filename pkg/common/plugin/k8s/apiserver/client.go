@@ -29,7 +29,7 @@ type Client interface {
 type client struct {
 	kubeConfigFilePath string
 
-	// loadClientHook is used to inject a fake loadClient on tests
+	// loadClientHook is used to inject a fake NewClientset on tests
 	loadClientHook func(string) (kubernetes.Interface, error)
 }
 
@@ -40,7 +40,7 @@ type client struct {
 func New(kubeConfigFilePath string) Client {
 	return &client{
 		kubeConfigFilePath: kubeConfigFilePath,
-		loadClientHook:     loadClient,
+		loadClientHook:     NewClientset,
 	}
 }
 
@@ -135,7 +135,9 @@ func (c *client) ValidateToken(ctx context.Context, token string, audiences []st
 	return &resp.Status, nil
 }
 
-func loadClient(kubeConfigFilePath string) (kubernetes.Interface, error) {
+// NewClientset creates a clientset for the k8s API server, configured from the
+// given kubeConfigFilePath or, if empty, from the in-cluster configuration.
+func NewClientset(kubeConfigFilePath string) (kubernetes.Interface, error) {
 	var config *rest.Config
 	var err error
 
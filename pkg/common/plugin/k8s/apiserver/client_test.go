@@ -314,17 +314,17 @@ func (s *ClientSuite) TestValidateTokenSucceeds() {
 	s.True(status.Authenticated)
 }
 
-func (s *ClientSuite) TestLoadClientFailsIfConfigCannotBeCreated() {
+func (s *ClientSuite) TestNewClientsetFailsIfConfigCannotBeCreated() {
 	kubeConfigPath := filepath.Join(s.dir, "not-valid-config-path")
-	clientset, err := loadClient(kubeConfigPath)
+	clientset, err := NewClientset(kubeConfigPath)
 	s.AssertErrorContains(err, "unable to create client config")
 	s.Nil(clientset)
 }
 
-func (s *ClientSuite) TestLoadClientSucceeds() {
+func (s *ClientSuite) TestNewClientsetSucceeds() {
 	kubeConfigPath := filepath.Join(s.dir, "config")
 	s.createSampleKubeConfigFile(kubeConfigPath)
-	clientset, err := loadClient(kubeConfigPath)
+	clientset, err := NewClientset(kubeConfigPath)
 	s.NoError(err)
 	s.NotNil(clientset)
 }
@@ -164,3 +164,44 @@ func TestJWTSVIDExpiresSoon(t *testing.T) {
 		})
 	}
 }
+
+func TestWITSVIDExpiresSoon(t *testing.T) {
+	mockClk := clock.NewMock(t)
+
+	for _, tc := range []struct {
+		desc         string
+		token        *client.WITSVID
+		shouldRotate bool
+	}{
+		{
+			desc: "brand new token",
+			token: &client.WITSVID{
+				IssuedAt:  mockClk.Now(),
+				ExpiresAt: mockClk.Now().Add(time.Hour),
+			},
+			shouldRotate: false,
+		},
+		{
+			desc: "token that's almost expired",
+			token: &client.WITSVID{
+				IssuedAt:  mockClk.Now().Add(-1 * time.Hour),
+				ExpiresAt: mockClk.Now().Add(1 * time.Minute),
+			},
+			shouldRotate: true,
+		},
+		{
+			desc: "token that's already expired",
+			token: &client.WITSVID{
+				IssuedAt:  mockClk.Now().Add(-1 * time.Hour),
+				ExpiresAt: mockClk.Now().Add(-30 * time.Minute),
+			},
+			shouldRotate: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			rs := NewRotationStrategy(0)
+			actual := rs.WITSVIDExpiresSoon(tc.token, mockClk.Now())
+			assert.Equal(t, tc.shouldRotate, actual)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package selector
+
+import (
+	"path"
+	"strings"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Registration entry selectors can be expressions, which are encoded in the
+// selector type so that they are stored and transported as plain selectors:
+//
+//   - A type prefixed with NegationPrefix (e.g. "!k8s" with value
+//     "ns:kube-system") matches when the workload does NOT have the selector
+//     "k8s:ns:kube-system".
+//   - A type prefixed with AnyOfPrefix (e.g. "|k8s" with value
+//     "ns:default|ns:prod") matches when the workload has at least one of the
+//     selectors "k8s:ns:default" or "k8s:ns:prod". The alternatives are
+//     separated by AnyOfSeparator, which is written as AnyOfEscape followed
+//     by the separator when it is part of an alternative (see SplitAnyOf).
+//   - A type prefixed with PatternPrefix (e.g. "~k8s" with value
+//     "pod-name:web-*") matches when the workload has a selector of type "k8s"
+//     whose value matches the pattern. Patterns use the path.Match syntax.
+//
+// Prefixes can be combined, e.g. "!~k8s" with value "ns:kube-*" matches when
+// the workload has no "k8s" selector matching "ns:kube-*". The selectors of an
+// entry are still a conjunction of plain selectors and expressions.
+//
+// The selectors of a workload may in turn hold Unknown markers, with a type
+// prefixed with UnknownPrefix, for the selector types whose attestor failed or
+// skipped the workload. A negation cannot be proven on such a type, so it
+// does not match.
+const (
+	NegationPrefix = "!"
+	AnyOfPrefix    = "|"
+	AnyOfSeparator = "|"
+	AnyOfEscape    = `\`
+	PatternPrefix  = "~"
+	UnknownPrefix  = "?"
+)
+
+// Matchable is satisfied by the selector types that the entry caches use to
+// hold the selectors of workloads and nodes.
+type Matchable interface {
+	~struct {
+		Type  string
+		Value string
+	}
+}
+
+// IsExpression returns true if the selector is a selector expression rather
+// than a plain selector.
+func IsExpression(s Selector) bool {
+	return strings.HasPrefix(s.Type, NegationPrefix) ||
+		strings.HasPrefix(s.Type, AnyOfPrefix) ||
+		strings.HasPrefix(s.Type, PatternPrefix)
+}
+
+// Unknown returns the marker that a workload carries among its selectors
+// when the selectors of the given type could not be obtained.
+func Unknown(selectorType string) Selector {
+	return Selector{Type: UnknownPrefix + selectorType}
+}
+
+// IsUnknown returns true if the selector is an Unknown marker.
+func IsUnknown(s Selector) bool {
+	return strings.HasPrefix(s.Type, UnknownPrefix)
+}
+
+// WithoutUnknown returns the selectors without the Unknown markers. The
+// markers only take part in entry matching, so they are removed before the
+// selectors of a workload are logged or returned to callers.
+func WithoutUnknown(selectors []*common.Selector) []*common.Selector {
+	out := make([]*common.Selector, 0, len(selectors))
+	for _, s := range selectors {
+		if !IsUnknown(Selector{Type: s.Type, Value: s.Value}) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Match returns true if a workload with the given set of selectors satisfies
+// the entry selector s, which may be an expression.
+func Match[S Matchable](s Selector, set map[S]struct{}) bool {
+	switch {
+	case strings.HasPrefix(s.Type, NegationPrefix):
+		negated := Selector{Type: s.Type[len(NegationPrefix):], Value: s.Value}
+		if _, unknown := set[S(Unknown(baseType(negated.Type)))]; unknown {
+			return false
+		}
+		return !Match(negated, set)
+	case strings.HasPrefix(s.Type, AnyOfPrefix):
+		selectorType := s.Type[len(AnyOfPrefix):]
+		for _, value := range SplitAnyOf(s.Value) {
+			if Match(Selector{Type: selectorType, Value: value}, set) {
+				return true
+			}
+		}
+		return false
+	case strings.HasPrefix(s.Type, PatternPrefix):
+		selectorType := s.Type[len(PatternPrefix):]
+		for k := range set {
+			workloadSelector := Selector(k)
+			if workloadSelector.Type != selectorType {
+				continue
+			}
+			if matched, _ := path.Match(s.Value, workloadSelector.Value); matched {
+				return true
+			}
+		}
+		return false
+	default:
+		_, ok := set[S(s)]
+		return ok
+	}
+}
+
+// SplitAnyOf splits the value of an any-of selector into its alternatives.
+// AnyOfEscape followed by AnyOfSeparator or by another AnyOfEscape stands for
+// that character, so alternatives can hold the separator. AnyOfEscape before
+// any other character is kept as is, so that pattern escapes are preserved.
+func SplitAnyOf(value string) []string {
+	var values []string
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case strings.HasPrefix(value[i:], AnyOfEscape+AnyOfSeparator):
+			b.WriteString(AnyOfSeparator)
+			i += len(AnyOfEscape+AnyOfSeparator) - 1
+		case strings.HasPrefix(value[i:], AnyOfEscape+AnyOfEscape):
+			b.WriteString(AnyOfEscape)
+			i += len(AnyOfEscape+AnyOfEscape) - 1
+		case strings.HasPrefix(value[i:], AnyOfSeparator):
+			values = append(values, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return append(values, b.String())
+}
+
+// Candidates returns the index keys of which a workload must have at least
+// one to satisfy the entry selector s. Entries are indexed by these keys, and
+// looked up with the IndexKeys of the workload selectors. Negations have no
+// candidates.
+func Candidates(s Selector) []Selector {
+	switch {
+	case strings.HasPrefix(s.Type, NegationPrefix):
+		return nil
+	case strings.HasPrefix(s.Type, AnyOfPrefix):
+		selectorType := s.Type[len(AnyOfPrefix):]
+		var candidates []Selector
+		for _, value := range SplitAnyOf(s.Value) {
+			candidates = append(candidates, Candidates(Selector{Type: selectorType, Value: value})...)
+		}
+		return candidates
+	case strings.HasPrefix(s.Type, PatternPrefix):
+		return []Selector{patternKey(s.Type[len(PatternPrefix):])}
+	default:
+		return []Selector{s}
+	}
+}
+
+// IndexKeys returns the index keys of the entries that a workload with the
+// selector s may satisfy: the selector itself, and the key shared by all the
+// patterns on the selector type.
+func IndexKeys(s Selector) []Selector {
+	return []Selector{s, patternKey(s.Type)}
+}
+
+// baseType returns the selector type of an expression type, without the
+// expression prefixes.
+func baseType(selectorType string) string {
+	return strings.TrimLeft(selectorType, NegationPrefix+AnyOfPrefix+PatternPrefix)
+}
+
+// patternKey returns the index key of the patterns on the selector type. The
+// empty value keeps it apart from any valid selector.
+func patternKey(selectorType string) Selector {
+	return Selector{Type: PatternPrefix + selectorType}
+}
@@ -0,0 +1,142 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	workload := map[Selector]struct{}{
+		{Type: "k8s", Value: "ns:default"}:                {},
+		{Type: "k8s", Value: "sa:web"}:                    {},
+		{Type: "k8s", Value: "pod-name:web-7d9f"}:         {},
+		{Type: "unix", Value: "path:/opt/app/bin/server"}: {},
+		{Type: "docker", Value: "label:tier:a|b"}:         {},
+	}
+
+	tests := []struct {
+		name     string
+		selector Selector
+		match    bool
+	}{
+		{
+			name:     "plain selector present",
+			selector: Selector{Type: "k8s", Value: "ns:default"},
+			match:    true,
+		},
+		{
+			name:     "plain selector missing",
+			selector: Selector{Type: "k8s", Value: "ns:prod"},
+		},
+		{
+			name:     "negated selector missing",
+			selector: Selector{Type: "!k8s", Value: "ns:kube-system"},
+			match:    true,
+		},
+		{
+			name:     "negated selector present",
+			selector: Selector{Type: "!k8s", Value: "ns:default"},
+		},
+		{
+			name:     "any-of with a present alternative",
+			selector: Selector{Type: "|k8s", Value: "ns:prod|ns:default"},
+			match:    true,
+		},
+		{
+			name:     "any-of without present alternatives",
+			selector: Selector{Type: "|k8s", Value: "ns:prod|ns:staging"},
+		},
+		{
+			name:     "any-of with an escaped separator",
+			selector: Selector{Type: "|docker", Value: `label:tier:c|label:tier:a\|b`},
+			match:    true,
+		},
+		{
+			name:     "pattern with a matching selector",
+			selector: Selector{Type: "~k8s", Value: "pod-name:web-*"},
+			match:    true,
+		},
+		{
+			name:     "path pattern with a matching selector",
+			selector: Selector{Type: "~unix", Value: "path:/opt/app/bin/*"},
+			match:    true,
+		},
+		{
+			name:     "pattern does not cross path separators",
+			selector: Selector{Type: "~unix", Value: "path:/opt/*"},
+		},
+		{
+			name:     "pattern on another selector type",
+			selector: Selector{Type: "~unix", Value: "pod-name:web-*"},
+		},
+		{
+			name:     "negated pattern with a matching selector",
+			selector: Selector{Type: "!~k8s", Value: "ns:def*"},
+		},
+		{
+			name:     "any-of patterns",
+			selector: Selector{Type: "|~k8s", Value: "ns:prod-*|pod-name:web-*"},
+			match:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.match, Match(test.selector, workload))
+		})
+	}
+}
+
+func TestMatchUnknown(t *testing.T) {
+	// The k8s attestor failed for the workload
+	workload := map[Selector]struct{}{
+		{Type: "unix", Value: "uid:0"}: {},
+		Unknown("k8s"):                 {},
+	}
+
+	assert.True(t, Match(Selector{Type: "unix", Value: "uid:0"}, workload))
+	assert.False(t, Match(Selector{Type: "k8s", Value: "ns:default"}, workload))
+	assert.False(t, Match(Selector{Type: "!k8s", Value: "ns:kube-system"}, workload))
+	assert.False(t, Match(Selector{Type: "!~k8s", Value: "ns:kube-*"}, workload))
+	assert.False(t, Match(Selector{Type: "!|k8s", Value: "ns:kube-system|ns:kube-public"}, workload))
+	assert.True(t, Match(Selector{Type: "!docker", Value: "label:privileged:true"}, workload))
+}
+
+func TestSplitAnyOf(t *testing.T) {
+	assert.Equal(t, []string{"ns:a", "ns:b"}, SplitAnyOf("ns:a|ns:b"))
+	assert.Equal(t, []string{"label:a|b", "label:c"}, SplitAnyOf(`label:a\|b|label:c`))
+	assert.Equal(t, []string{`path:/a\`, "path:/b"}, SplitAnyOf(`path:/a\\|path:/b`))
+	assert.Equal(t, []string{`path:/a/\*`}, SplitAnyOf(`path:/a/\*`))
+	assert.Equal(t, []string{"ns:a", ""}, SplitAnyOf("ns:a|"))
+}
+
+func TestWithoutUnknown(t *testing.T) {
+	selectors := []*common.Selector{
+		{Type: "unix", Value: "uid:0"},
+		{Type: "?k8s"},
+		{Type: "docker", Value: "label:app:web"},
+	}
+	assert.Equal(t, []*common.Selector{
+		{Type: "unix", Value: "uid:0"},
+		{Type: "docker", Value: "label:app:web"},
+	}, WithoutUnknown(selectors))
+}
+
+func TestCandidates(t *testing.T) {
+	assert.Equal(t, []Selector{{Type: "k8s", Value: "ns:default"}}, Candidates(Selector{Type: "k8s", Value: "ns:default"}))
+	assert.Empty(t, Candidates(Selector{Type: "!k8s", Value: "ns:default"}))
+	assert.Equal(t, []Selector{
+		{Type: "k8s", Value: "ns:default"},
+		{Type: "k8s", Value: "ns:prod"},
+	}, Candidates(Selector{Type: "|k8s", Value: "ns:default|ns:prod"}))
+	assert.Equal(t, []Selector{{Type: "~k8s"}}, Candidates(Selector{Type: "~k8s", Value: "pod-name:web-*"}))
+}
+
+func TestIndexKeys(t *testing.T) {
+	assert.Equal(t, []Selector{
+		{Type: "k8s", Value: "pod-name:web-7d9f"},
+		{Type: "~k8s"},
+	}, IndexKeys(Selector{Type: "k8s", Value: "pod-name:web-7d9f"}))
+}
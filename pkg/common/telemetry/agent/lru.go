@@ -25,3 +25,7 @@ func SetSVIDMapSize(m telemetry.Metrics, svidMapSize int) {
 func SetJWTSVIDCacheSize(m telemetry.Metrics, cacheSize int) {
 	m.SetGauge([]string{telemetry.JWTSVIDCacheSize}, float32(cacheSize))
 }
+
+func SetWITSVIDCacheSize(m telemetry.Metrics, cacheSize int) {
+	m.SetGauge([]string{telemetry.WITSVIDCacheSize}, float32(cacheSize))
+}
@@ -34,6 +34,10 @@ const (
 	// to add clarity
 	Delete = "delete"
 
+	// Export functionality related to exporting some entity(ies); should be used with other tags
+	// to add clarity
+	Export = "export"
+
 	// Fetch functionality related to fetching some entity; should be used with other tags
 	// to add clarity
 	Fetch = "fetch"
@@ -72,6 +76,9 @@ const (
 	// Keys related to keys used on HCL
 	Keys = "keys"
 
+	// WITSVIDCacheSize is the gauge that holds the number of cached WIT-SVIDs
+	WITSVIDCacheSize = "wit_svid_cache_size"
+
 	// List functionality related to listing some objects; should be used
 	// with other tags to add clarity
 	List = "list"
@@ -305,6 +312,9 @@ const (
 	// EntryUpdated is the counter key for when an LRU cache entry is updated
 	EntryUpdated = "lru_cache_entry_update"
 
+	// EndpointCertificate tags the certificate presented by an endpoint
+	EndpointCertificate = "endpoint_certificate"
+
 	// EndpointSpiffeID tags endpoint SPIFFE ID
 	EndpointSpiffeID = "endpoint_spiffe_id"
 
@@ -399,6 +409,9 @@ const (
 	// Kid tags some key ID
 	Kid = "kid"
 
+	// LastRefresh tags the time of the last successful refresh of some entity
+	LastRefresh = "last_refresh"
+
 	// LaunchLogLevel log level when service started
 	LaunchLogLevel = "launch_log_level"
 
@@ -411,6 +424,9 @@ const (
 	// Mode tags a bundle deletion mode
 	Mode = "mode"
 
+	// NextRefresh tags the time of the next scheduled refresh of some entity
+	NextRefresh = "next_refresh"
+
 	// NewLogLevel tags a new log level
 	NewLogLevel = "new_log_level"
 
@@ -515,6 +531,9 @@ const (
 	// RegistrationEntryEvent is a notice a registration entry has been created, modified, or deleted
 	RegistrationEntryEvent = "registration_entry_event"
 
+	// RegistrationEntryChange is the recorded history of a registration entry mutation
+	RegistrationEntryChange = "registration_entry_change"
+
 	// RequestID tags a request identifier
 	RequestID = "request_id"
 
@@ -616,6 +635,9 @@ const (
 	// SyncEntriesTotal is the number of entries that were no longer on the server.
 	SyncEntriesDropped = "sync_entries_dropped"
 
+	// Tenant tags the name of the tenant of a caller
+	Tenant = "tenant"
+
 	// TTL functionality related to a time-to-live field; should be used
 	// with other tags to add clarity
 	TTL = "ttl"
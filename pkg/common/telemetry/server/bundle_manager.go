@@ -1,6 +1,8 @@
 package server
 
 import (
+	"time"
+
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
@@ -34,3 +36,44 @@ func StartBundleManagerFetchFederatedBundleCall(m telemetry.Metrics) *telemetry.
 }
 
 // End Call Counters
+
+// Gauge (remember previous value set)
+
+// SetBundleManagerFederatedBundleStatusGauges sets the gauges describing the
+// refresh status of a federated bundle: the time of the last successful
+// refresh, the time of the next scheduled refresh and the sequence number of
+// the bundle. Times are in seconds since the Unix epoch. The time of the last
+// successful refresh is not set until the bundle is first refreshed.
+func SetBundleManagerFederatedBundleStatusGauges(m telemetry.Metrics, trustDomain string, lastRefresh, nextRefresh time.Time, sequenceNumber uint64) {
+	labels := []telemetry.Label{
+		{Name: telemetry.TrustDomainID, Value: trustDomain},
+	}
+	if !lastRefresh.IsZero() {
+		m.SetPrecisionGaugeWithLabels(
+			[]string{telemetry.BundleManager, telemetry.FederatedBundle, telemetry.LastRefresh},
+			float64(lastRefresh.Unix()),
+			labels)
+	}
+	m.SetPrecisionGaugeWithLabels(
+		[]string{telemetry.BundleManager, telemetry.FederatedBundle, telemetry.NextRefresh},
+		float64(nextRefresh.Unix()),
+		labels)
+	m.SetPrecisionGaugeWithLabels(
+		[]string{telemetry.BundleManager, telemetry.FederatedBundle, telemetry.SequenceNumber},
+		float64(sequenceNumber),
+		labels)
+}
+
+// SetBundleManagerEndpointCertificateExpirationGauge sets the expiration time,
+// in seconds since the Unix epoch, of the certificate presented by the bundle
+// endpoint of a federated trust domain.
+func SetBundleManagerEndpointCertificateExpirationGauge(m telemetry.Metrics, trustDomain string, expiration time.Time) {
+	m.SetPrecisionGaugeWithLabels(
+		[]string{telemetry.BundleManager, telemetry.FederatedBundle, telemetry.EndpointCertificate, telemetry.Expiration},
+		float64(expiration.Unix()),
+		[]telemetry.Label{
+			{Name: telemetry.TrustDomainID, Value: trustDomain},
+		})
+}
+
+// End Gauge
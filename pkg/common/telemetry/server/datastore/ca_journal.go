@@ -22,6 +22,12 @@ func StartPruneCAJournalsCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.CAJournal, telemetry.Prune)
 }
 
+// StartListCAJournals return metric
+// for server's datastore, on listing CA journals.
+func StartListCAJournals(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.CAJournal, telemetry.List)
+}
+
 // StartListCAJournalsForTesting return metric
 // for server's datastore, on listing CA journals for testing.
 func StartListCAJournalsForTesting(m telemetry.Metrics) *telemetry.CallCounter {
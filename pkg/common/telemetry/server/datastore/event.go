@@ -34,6 +34,18 @@ func StartFetchRegistrationEntryEventCall(m telemetry.Metrics) *telemetry.CallCo
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.RegistrationEntryEvent, telemetry.Fetch)
 }
 
+// StartPruneRegistrationEntryChangesCall return metric
+// for server's datastore, on pruning registration entry changes.
+func StartPruneRegistrationEntryChangesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.RegistrationEntryChange, telemetry.Prune)
+}
+
+// StartListRegistrationEntryChangesCall return metric
+// for server's datastore, on listing registration entry changes.
+func StartListRegistrationEntryChangesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.RegistrationEntryChange, telemetry.List)
+}
+
 // StartListAttestedNodeEventsCall return metric
 // for server's datastore, on listing attested node events.
 func StartListAttestedNodeEventsCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -0,0 +1,11 @@
+package datastore
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// StartExportRecordsCall return metric
+// for server's datastore, on exporting all records.
+func StartExportRecordsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Export)
+}
@@ -25,6 +25,12 @@ func StartFetchJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.JoinToken, telemetry.Fetch)
 }
 
+// StartListJoinTokenCall return metric
+// for server's datastore, on listing join tokens.
+func StartListJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.JoinToken, telemetry.List)
+}
+
 // StartPruneJoinTokenCall return metric
 // for server's datastore, on pruning join tokens.
 func StartPruneJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
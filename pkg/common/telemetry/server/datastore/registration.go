@@ -37,6 +37,12 @@ func StartListRegistrationCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.RegistrationEntry, telemetry.List)
 }
 
+// StartActivateRegistrationCall return metric
+// for server's datastore, on activating registrations.
+func StartActivateRegistrationCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.RegistrationEntry, telemetry.Activate)
+}
+
 // StartPruneRegistrationCall return metric
 // for server's datastore, on pruning registrations.
 func StartPruneRegistrationCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -203,6 +203,12 @@ func (w metricsWrapper) ListBundles(ctx context.Context, req *datastore.ListBund
 	return w.ds.ListBundles(ctx, req)
 }
 
+func (w metricsWrapper) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (_ *datastore.ListJoinTokensResponse, err error) {
+	callCounter := StartListJoinTokenCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.ListJoinTokens(ctx, req)
+}
+
 func (w metricsWrapper) ListNodeSelectors(ctx context.Context, req *datastore.ListNodeSelectorsRequest) (_ *datastore.ListNodeSelectorsResponse, err error) {
 	callCounter := StartListNodeSelectorsCall(w.m)
 	defer callCounter.Done(&err)
@@ -215,6 +221,12 @@ func (w metricsWrapper) ListRegistrationEntries(ctx context.Context, req *datast
 	return w.ds.ListRegistrationEntries(ctx, req)
 }
 
+func (w metricsWrapper) ListRegistrationEntryChanges(ctx context.Context, req *datastore.ListRegistrationEntryChangesRequest) (_ *datastore.ListRegistrationEntryChangesResponse, err error) {
+	callCounter := StartListRegistrationEntryChangesCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.ListRegistrationEntryChanges(ctx, req)
+}
+
 func (w metricsWrapper) ListRegistrationEntryEvents(ctx context.Context, req *datastore.ListRegistrationEntryEventsRequest) (_ *datastore.ListRegistrationEntryEventsResponse, err error) {
 	callCounter := StartListRegistrationEntryEventsCall(w.m)
 	defer callCounter.Done(&err)
@@ -257,12 +269,24 @@ func (w metricsWrapper) PruneJoinTokens(ctx context.Context, expiresBefore time.
 	return w.ds.PruneJoinTokens(ctx, expiresBefore)
 }
 
+func (w metricsWrapper) ActivateRegistrationEntries(ctx context.Context, since, until time.Time) (err error) {
+	callCounter := StartActivateRegistrationCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.ActivateRegistrationEntries(ctx, since, until)
+}
+
 func (w metricsWrapper) PruneRegistrationEntries(ctx context.Context, expiresBefore time.Time) (err error) {
 	callCounter := StartPruneRegistrationCall(w.m)
 	defer callCounter.Done(&err)
 	return w.ds.PruneRegistrationEntries(ctx, expiresBefore)
 }
 
+func (w metricsWrapper) PruneRegistrationEntryChanges(ctx context.Context, olderThan time.Duration) (err error) {
+	callCounter := StartPruneRegistrationEntryChangesCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.PruneRegistrationEntryChanges(ctx, olderThan)
+}
+
 func (w metricsWrapper) PruneRegistrationEntryEvents(ctx context.Context, olderThan time.Duration) (err error) {
 	callCounter := StartPruneRegistrationEntryEventsCall(w.m)
 	defer callCounter.Done(&err)
@@ -347,12 +371,24 @@ func (w metricsWrapper) FetchCAJournal(ctx context.Context, activeX509AuthorityI
 	return w.ds.FetchCAJournal(ctx, activeX509AuthorityID)
 }
 
+func (w metricsWrapper) ListCAJournals(ctx context.Context, req *datastore.ListCAJournalsRequest) (_ *datastore.ListCAJournalsResponse, err error) {
+	callCounter := StartListCAJournals(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.ListCAJournals(ctx, req)
+}
+
 func (w metricsWrapper) ListCAJournalsForTesting(ctx context.Context) (_ []*datastore.CAJournal, err error) {
 	callCounter := StartListCAJournalsForTesting(w.m)
 	defer callCounter.Done(&err)
 	return w.ds.ListCAJournalsForTesting(ctx)
 }
 
+func (w metricsWrapper) ExportRecords(ctx context.Context, fn func(*datastore.ExportRecord) error) (err error) {
+	callCounter := StartExportRecordsCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.ExportRecords(ctx, fn)
+}
+
 func (w metricsWrapper) PruneCAJournals(ctx context.Context, allCAsExpireBefore int64) (err error) {
 	callCounter := StartPruneCAJournalsCall(w.m)
 	defer callCounter.Done(&err)
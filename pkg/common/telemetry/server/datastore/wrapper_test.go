@@ -37,6 +37,10 @@ func TestWithMetrics(t *testing.T) {
 		key        string
 		methodName string
 	}{
+		{
+			key:        "datastore.registration_entry.activate",
+			methodName: "ActivateRegistrationEntries",
+		},
 		{
 			key:        "datastore.bundle.append",
 			methodName: "AppendBundle",
@@ -165,6 +169,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.list",
 			methodName: "ListBundles",
 		},
+		{
+			key:        "datastore.join_token.list",
+			methodName: "ListJoinTokens",
+		},
 		{
 			key:        "datastore.node.selectors.list",
 			methodName: "ListNodeSelectors",
@@ -177,6 +185,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.registration_entry_event.list",
 			methodName: "ListRegistrationEntryEvents",
 		},
+		{
+			key:        "datastore.registration_entry_change.list",
+			methodName: "ListRegistrationEntryChanges",
+		},
 		{
 			key:        "datastore.federation_relationship.list",
 			methodName: "ListFederationRelationships",
@@ -201,6 +213,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.registration_entry.prune",
 			methodName: "PruneRegistrationEntries",
 		},
+		{
+			key:        "datastore.registration_entry_change.prune",
+			methodName: "PruneRegistrationEntryChanges",
+		},
 		{
 			key:        "datastore.registration_entry_event.prune",
 			methodName: "PruneRegistrationEntryEvents",
@@ -257,10 +273,18 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.ca_journal.prune",
 			methodName: "PruneCAJournals",
 		},
+		{
+			key:        "datastore.ca_journal.list",
+			methodName: "ListCAJournals",
+		},
 		{
 			key:        "datastore.ca_journal.list",
 			methodName: "ListCAJournalsForTesting",
 		},
+		{
+			key:        "datastore.export",
+			methodName: "ExportRecords",
+		},
 	} {
 		methodType, ok := wt.MethodByName(tt.methodName)
 		require.True(t, ok, "method %q does not exist on DataStore interface", tt.methodName)
@@ -472,6 +496,10 @@ func (ds *fakeDataStore) ListAttestedNodeEvents(context.Context, *datastore.List
 	return &datastore.ListAttestedNodeEventsResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) ListJoinTokens(context.Context, *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	return &datastore.ListJoinTokensResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) ListBundles(context.Context, *datastore.ListBundlesRequest) (*datastore.ListBundlesResponse, error) {
 	return &datastore.ListBundlesResponse{}, ds.err
 }
@@ -484,6 +512,10 @@ func (ds *fakeDataStore) ListRegistrationEntries(context.Context, *datastore.Lis
 	return &datastore.ListRegistrationEntriesResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) ListRegistrationEntryChanges(context.Context, *datastore.ListRegistrationEntryChangesRequest) (*datastore.ListRegistrationEntryChangesResponse, error) {
+	return &datastore.ListRegistrationEntryChangesResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) ListRegistrationEntryEvents(context.Context, *datastore.ListRegistrationEntryEventsRequest) (*datastore.ListRegistrationEntryEventsResponse, error) {
 	return &datastore.ListRegistrationEntryEventsResponse{}, ds.err
 }
@@ -500,10 +532,18 @@ func (ds *fakeDataStore) PruneJoinTokens(context.Context, time.Time) error {
 	return ds.err
 }
 
+func (ds *fakeDataStore) ActivateRegistrationEntries(context.Context, time.Time, time.Time) error {
+	return ds.err
+}
+
 func (ds *fakeDataStore) PruneRegistrationEntries(context.Context, time.Time) error {
 	return ds.err
 }
 
+func (ds *fakeDataStore) PruneRegistrationEntryChanges(context.Context, time.Duration) error {
+	return ds.err
+}
+
 func (ds *fakeDataStore) PruneRegistrationEntryEvents(context.Context, time.Duration) error {
 	return ds.err
 }
@@ -556,6 +596,10 @@ func (ds *fakeDataStore) FetchCAJournal(context.Context, string) (*datastore.CAJ
 	return &datastore.CAJournal{}, ds.err
 }
 
+func (ds *fakeDataStore) ListCAJournals(context.Context, *datastore.ListCAJournalsRequest) (*datastore.ListCAJournalsResponse, error) {
+	return &datastore.ListCAJournalsResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) ListCAJournalsForTesting(context.Context) ([]*datastore.CAJournal, error) {
 	return []*datastore.CAJournal{}, ds.err
 }
@@ -563,3 +607,7 @@ func (ds *fakeDataStore) ListCAJournalsForTesting(context.Context) ([]*datastore
 func (ds *fakeDataStore) PruneCAJournals(context.Context, int64) error {
 	return ds.err
 }
+
+func (ds *fakeDataStore) ExportRecords(context.Context, func(*datastore.ExportRecord) error) error {
+	return ds.err
+}
@@ -11,4 +11,16 @@ func StartRegistrationManagerPruneEntryCall(m telemetry.Metrics) *telemetry.Call
 	return telemetry.StartCall(m, telemetry.RegistrationEntry, telemetry.Manager, telemetry.Prune)
 }
 
+// StartRegistrationManagerPruneEntryHistoryCall returns metric for
+// for server registration manager entry history pruning
+func StartRegistrationManagerPruneEntryHistoryCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationEntryChange, telemetry.Manager, telemetry.Prune)
+}
+
+// StartRegistrationManagerActivateEntryCall returns metric for
+// for server registration manager entry activation
+func StartRegistrationManagerActivateEntryCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationEntry, telemetry.Manager, telemetry.Activate)
+}
+
 // End Call Counters
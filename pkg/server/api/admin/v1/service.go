@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"bufio"
+	"sync"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	commonapi "github.com/spiffe/spire/pkg/common/api"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/snapshot"
+	"github.com/spiffe/spire/pkg/server/svid"
+	adminv1 "github.com/spiffe/spire/proto/spire/server/admin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// snapshotChunkSize is the maximum size of each chunk of a snapshot
+	// streamed back to the caller.
+	snapshotChunkSize = 64 * 1024
+)
+
+// RegisterService registers the admin service on the gRPC server.
+func RegisterService(s grpc.ServiceRegistrar, service *Service) {
+	adminv1.RegisterAdminServer(s, service)
+}
+
+// Config is the service configuration
+type Config struct {
+	TrustDomain  spiffeid.TrustDomain
+	DataStore    datastore.DataStore
+	DataDir      string
+	SVIDObserver svid.Observer
+
+	// KeyPaths are the paths of the files holding private keys, which are
+	// only included in snapshots on request.
+	KeyPaths []string
+}
+
+// New creates a new admin service
+func New(config Config) *Service {
+	return &Service{
+		td:       config.TrustDomain,
+		ds:       config.DataStore,
+		dataDir:  config.DataDir,
+		keyPaths: config.KeyPaths,
+		so:       config.SVIDObserver,
+	}
+}
+
+// Service implements the v1 admin service
+type Service struct {
+	adminv1.UnsafeAdminServer
+
+	td       spiffeid.TrustDomain
+	ds       datastore.DataStore
+	dataDir  string
+	keyPaths []string
+	so       svid.Observer
+
+	snapshotMtx sync.Mutex
+}
+
+// Snapshot streams a signed snapshot of the server state.
+func (s *Service) Snapshot(req *adminv1.SnapshotRequest, stream adminv1.Admin_SnapshotServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+
+	if !s.snapshotMtx.TryLock() {
+		return commonapi.MakeErr(log, codes.Aborted, "a snapshot is already in progress", nil)
+	}
+	defer s.snapshotMtx.Unlock()
+
+	state := s.so.State()
+	w := bufio.NewWriterSize(chunkWriter{stream: stream}, snapshotChunkSize)
+	stats, err := snapshot.Write(ctx, snapshot.Config{
+		TrustDomain: s.td,
+		DataStore:   s.ds,
+		DataDir:     s.dataDir,
+		KeyPaths:    s.keyPaths,
+		IncludeKeys: req.IncludeKeys,
+		X509SVID:    state.SVID,
+		Key:         state.Key,
+	}, w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		return commonapi.MakeErr(log, codes.Internal, "failed to write snapshot", err)
+	}
+
+	for kind, count := range stats.Records {
+		log = log.WithField(kind, count)
+	}
+	log.WithField(telemetry.Status, "success").Info("Snapshot taken")
+	return nil
+}
+
+type chunkWriter struct {
+	stream adminv1.Admin_SnapshotServer
+}
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	// The buffer is reused by the caller, so the chunk must be copied before
+	// it is handed to the stream.
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+	if err := w.stream.Send(&adminv1.SnapshotResponse{Chunk: chunk}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
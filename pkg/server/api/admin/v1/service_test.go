@@ -0,0 +1,159 @@
+package admin_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	admin "github.com/spiffe/spire/pkg/server/api/admin/v1"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/snapshot"
+	"github.com/spiffe/spire/pkg/server/svid"
+	"github.com/spiffe/spire/proto/spire/common"
+	adminv1 "github.com/spiffe/spire/proto/spire/server/admin"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/grpctest"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ctx = context.Background()
+	td  = spiffeid.RequireTrustDomainFromString("example.org")
+)
+
+func TestSnapshot(t *testing.T) {
+	test := setupServiceTest(t)
+
+	stream, err := test.client.Snapshot(ctx, &adminv1.SnapshotRequest{})
+	require.NoError(t, err)
+	snap := readSnapshot(t, stream)
+
+	header, err := snapshot.Verify(bytes.NewReader(snap), td, test.ca.X509Authorities())
+	require.NoError(t, err)
+	require.Equal(t, td.Name(), header.TrustDomain)
+	require.NotContains(t, snapshotFiles(t, snap), "data_dir/keys.json")
+
+	spiretest.AssertLastLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Snapshot taken",
+			Data: logrus.Fields{
+				"bundle": "1",
+				"status": "success",
+			},
+		},
+	})
+}
+
+func TestSnapshotIncludeKeys(t *testing.T) {
+	test := setupServiceTest(t)
+
+	stream, err := test.client.Snapshot(ctx, &adminv1.SnapshotRequest{IncludeKeys: true})
+	require.NoError(t, err)
+	snap := readSnapshot(t, stream)
+
+	_, err = snapshot.Verify(bytes.NewReader(snap), td, test.ca.X509Authorities())
+	require.NoError(t, err)
+	require.Contains(t, snapshotFiles(t, snap), "data_dir/keys.json")
+}
+
+func TestSnapshotFailure(t *testing.T) {
+	test := setupServiceTest(t)
+	test.ds.SetNextError(errors.New("oh no"))
+
+	stream, err := test.client.Snapshot(ctx, &adminv1.SnapshotRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "failed to write snapshot: unable to export datastore records: oh no")
+}
+
+type serviceTest struct {
+	client  adminv1.AdminClient
+	ds      *fakedatastore.DataStore
+	ca      *testca.CA
+	logHook *test.Hook
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	ca := testca.New(t, td)
+	x509SVID := ca.CreateX509SVID(idutil.RequireServerID(td))
+
+	ds := fakedatastore.New(t)
+	_, err := ds.CreateBundle(ctx, &common.Bundle{
+		TrustDomainId: td.IDString(),
+		RootCas:       []*common.Certificate{{DerBytes: ca.X509Authorities()[0].Raw}},
+	})
+	require.NoError(t, err)
+
+	dataDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "keys.json"), []byte("keys"), 0o600))
+
+	log, logHook := test.NewNullLogger()
+	service := admin.New(admin.Config{
+		TrustDomain: td,
+		DataStore:   ds,
+		DataDir:     dataDir,
+		KeyPaths:    []string{filepath.Join(dataDir, "keys.json")},
+		SVIDObserver: svid.ObserverFunc(func() svid.State {
+			return svid.State{SVID: x509SVID.Certificates, Key: x509SVID.PrivateKey}
+		}),
+	})
+
+	registerFn := func(s grpc.ServiceRegistrar) {
+		admin.RegisterService(s, service)
+	}
+	overrideContext := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+	server := grpctest.StartServer(t, registerFn, grpctest.OverrideContext(overrideContext))
+	conn := server.NewGRPCClient(t)
+
+	return &serviceTest{
+		client:  adminv1.NewAdminClient(conn),
+		ds:      ds,
+		ca:      ca,
+		logHook: logHook,
+	}
+}
+
+func readSnapshot(t *testing.T, stream adminv1.Admin_SnapshotClient) []byte {
+	buf := new(bytes.Buffer)
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return buf.Bytes()
+		}
+		require.NoError(t, err)
+		buf.Write(resp.Chunk)
+	}
+}
+
+func snapshotFiles(t *testing.T, snap []byte) []string {
+	gr, err := gzip.NewReader(bytes.NewReader(snap))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	var names []string
+	for {
+		th, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return names
+		}
+		require.NoError(t, err)
+		names = append(names, th.Name)
+	}
+}
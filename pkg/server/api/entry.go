@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/pkg/common/protoutil"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -19,7 +21,8 @@ const (
 )
 
 type ReadOnlyEntry struct {
-	entry *types.Entry
+	entry             *types.Entry
+	credentialProfile *common.CredentialProfile
 }
 
 func NewReadOnlyEntry(entry *types.Entry) ReadOnlyEntry {
@@ -28,6 +31,16 @@ func NewReadOnlyEntry(entry *types.Entry) ReadOnlyEntry {
 	}
 }
 
+// NewReadOnlyEntryWithCredentialProfile returns a read-only entry that also
+// carries the credential profile of the entry, which the Entry type does not
+// define.
+func NewReadOnlyEntryWithCredentialProfile(entry *types.Entry, credentialProfile *common.CredentialProfile) ReadOnlyEntry {
+	return ReadOnlyEntry{
+		entry:             entry,
+		credentialProfile: credentialProfile,
+	}
+}
+
 func (e ReadOnlyEntry) GetId() string {
 	return e.entry.Id
 }
@@ -51,6 +64,21 @@ func (e *ReadOnlyEntry) GetDnsNames() []string {
 	return slices.Clone(e.entry.DnsNames)
 }
 
+func (e *ReadOnlyEntry) GetSelectors() []*types.Selector {
+	selectors := make([]*types.Selector, 0, len(e.entry.Selectors))
+	for _, selector := range e.entry.Selectors {
+		selectors = append(selectors, &types.Selector{
+			Type:  selector.Type,
+			Value: selector.Value,
+		})
+	}
+	return selectors
+}
+
+func (e *ReadOnlyEntry) GetHint() string {
+	return e.entry.Hint
+}
+
 func (e *ReadOnlyEntry) GetRevisionNumber() int64 {
 	return e.entry.RevisionNumber
 }
@@ -63,6 +91,11 @@ func (e *ReadOnlyEntry) GetAdditionalAttributes() *types.Entry_AdditionalAttribu
 	return e.entry.AdditionalAttributes
 }
 
+// GetCredentialProfile returns the credential profile of the entry, if any.
+func (e *ReadOnlyEntry) GetCredentialProfile() *common.CredentialProfile {
+	return e.credentialProfile
+}
+
 // Manually clone the entry instead of using the protobuf helpers
 // since those are two times slower.
 func (e *ReadOnlyEntry) Clone(mask *types.EntryMask) *types.Entry {
@@ -143,6 +176,15 @@ func (e *ReadOnlyEntry) Clone(mask *types.EntryMask) *types.Entry {
 	return clone
 }
 
+// RegistrationEntryIsActive returns true if the registration entry is past its
+// not-before time and has not expired at the given time.
+func RegistrationEntryIsActive(e *common.RegistrationEntry, now time.Time) bool {
+	if e.EntryExpiry != 0 && e.EntryExpiry <= now.Unix() {
+		return false
+	}
+	return e.NotBefore <= now.Unix()
+}
+
 // RegistrationEntriesToProto converts RegistrationEntry's into Entry's
 func RegistrationEntriesToProto(es []*common.RegistrationEntry) ([]*types.Entry, error) {
 	if es == nil {
@@ -315,6 +357,9 @@ func ProtoToRegistrationEntryWithMask(ctx context.Context, td spiffeid.TrustDoma
 		if err != nil {
 			return nil, err
 		}
+		if err := ValidateSelectorExpressions(selectors); err != nil {
+			return nil, err
+		}
 	}
 
 	var revisionNumber int64
@@ -368,3 +413,32 @@ func ProtoToRegistrationEntryWithMask(ctx context.Context, td spiffeid.TrustDoma
 		AdditionalAttributes: additionalAttributes,
 	}, nil
 }
+
+// RegistrationEntryExtensions returns the extensions of the registration
+// entry, which the Entry type does not define.
+func RegistrationEntryExtensions(e *common.RegistrationEntry) *entryext.EntryExtensions {
+	ext := &entryext.EntryExtensions{
+		CredentialProfile: e.GetAdditionalAttributes().GetCredentialProfile(),
+	}
+	if e.GetNotBefore() != 0 {
+		ext.NotBefore = &e.NotBefore
+	}
+	return ext
+}
+
+// ValidateEntryExtensions validates the extensions of an entry.
+func ValidateEntryExtensions(ext *entryext.EntryExtensions) error {
+	if ext.GetNotBefore() < 0 {
+		return fmt.Errorf("invalid not-before time %d: must not be negative", ext.GetNotBefore())
+	}
+	return nil
+}
+
+// NonEmptyCredentialProfile returns the profile, or nil if it is empty. An
+// empty profile clears the credential profile of an entry on update.
+func NonEmptyCredentialProfile(profile *common.CredentialProfile) *common.CredentialProfile {
+	if proto.Size(profile) == 0 {
+		return nil
+	}
+	return profile
+}
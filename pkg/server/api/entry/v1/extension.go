@@ -0,0 +1,139 @@
+package entry
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	commonapi "github.com/spiffe/spire/pkg/common/api"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// RegisterExtensionService registers the entry extension service on the gRPC
+// server.
+func RegisterExtensionService(s grpc.ServiceRegistrar, service *ExtensionService) {
+	entryext.RegisterEntryServer(s, service)
+}
+
+// ExtensionService manages entries along with the fields that the Entry type
+// of the SPIRE Server API does not define. Entries are created and updated
+// as by the entry service.
+type ExtensionService struct {
+	entryext.UnsafeEntryServer
+
+	s *Service
+}
+
+// NewExtensionService creates a new entry extension service on top of the
+// entry service.
+func NewExtensionService(service *Service) *ExtensionService {
+	return &ExtensionService{
+		s: service,
+	}
+}
+
+// BatchCreateEntry adds one or more entries, with their extensions, to the
+// server.
+func (e *ExtensionService) BatchCreateEntry(ctx context.Context, req *entryext.BatchCreateEntryRequest) (*entryext.BatchCreateEntryResponse, error) {
+	var results []*entryext.BatchCreateEntryResponse_Result
+	for _, eachEntry := range req.Entries {
+		r, regEntry := e.s.createEntry(ctx, eachEntry.GetEntry(), eachEntry.GetExtensions(), req.OutputMask)
+		results = append(results, &entryext.BatchCreateEntryResponse_Result{
+			Status: r.Status,
+			Entry:  extendedEntry(r.Entry, regEntry),
+		})
+		rpccontext.AuditRPCWithTypesStatus(ctx, r.Status, func() logrus.Fields {
+			return fieldsFromEntryProto(ctx, eachEntry.GetEntry(), nil)
+		})
+	}
+
+	return &entryext.BatchCreateEntryResponse{
+		Results: results,
+	}, nil
+}
+
+// BatchUpdateEntry updates one or more entries, and their extensions, in the
+// server.
+func (e *ExtensionService) BatchUpdateEntry(ctx context.Context, req *entryext.BatchUpdateEntryRequest) (*entryext.BatchUpdateEntryResponse, error) {
+	var results []*entryext.BatchUpdateEntryResponse_Result
+	for _, eachEntry := range req.Entries {
+		r, regEntry := e.s.updateEntry(ctx, eachEntry.GetEntry(), eachEntry.GetExtensions(), req.InputMask, req.OutputMask)
+		results = append(results, &entryext.BatchUpdateEntryResponse_Result{
+			Status: r.Status,
+			Entry:  extendedEntry(r.Entry, regEntry),
+		})
+		rpccontext.AuditRPCWithTypesStatus(ctx, r.Status, func() logrus.Fields {
+			return fieldsFromEntryProto(ctx, eachEntry.GetEntry(), req.InputMask)
+		})
+	}
+
+	return &entryext.BatchUpdateEntryResponse{
+		Results: results,
+	}, nil
+}
+
+// BatchGetEntryExtensions returns the extensions of one or more entries.
+func (e *ExtensionService) BatchGetEntryExtensions(ctx context.Context, req *entryext.BatchGetEntryExtensionsRequest) (*entryext.BatchGetEntryExtensionsResponse, error) {
+	var results []*entryext.BatchGetEntryExtensionsResponse_Result
+	for _, id := range req.Ids {
+		r := e.getEntryExtensions(ctx, id)
+		results = append(results, r)
+		rpccontext.AuditRPCWithTypesStatus(ctx, r.Status, func() logrus.Fields {
+			return logrus.Fields{telemetry.RegistrationID: id}
+		})
+	}
+
+	return &entryext.BatchGetEntryExtensionsResponse{
+		Results: results,
+	}, nil
+}
+
+func (e *ExtensionService) getEntryExtensions(ctx context.Context, id string) *entryext.BatchGetEntryExtensionsResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	if id == "" {
+		return &entryext.BatchGetEntryExtensionsResponse_Result{
+			Id:     id,
+			Status: commonapi.MakeStatus(log, codes.InvalidArgument, "missing ID", nil),
+		}
+	}
+	log = log.WithField(telemetry.RegistrationID, id)
+
+	regEntry, err := e.s.ds.FetchRegistrationEntry(ctx, id)
+	switch {
+	case err != nil:
+		return &entryext.BatchGetEntryExtensionsResponse_Result{
+			Id:     id,
+			Status: commonapi.MakeStatus(log, codes.Internal, "failed to fetch entry", err),
+		}
+	case regEntry == nil || !callerOwnsEntry(ctx, regEntry):
+		return &entryext.BatchGetEntryExtensionsResponse_Result{
+			Id:     id,
+			Status: commonapi.MakeStatus(log, codes.NotFound, "entry not found", nil),
+		}
+	}
+
+	return &entryext.BatchGetEntryExtensionsResponse_Result{
+		Id:         id,
+		Status:     commonapi.OK(),
+		Extensions: api.RegistrationEntryExtensions(regEntry),
+	}
+}
+
+// extendedEntry returns the entry of a result along with the extensions of
+// the registration entry, or nil if the result has no entry.
+func extendedEntry(entry *types.Entry, regEntry *common.RegistrationEntry) *entryext.ExtendedEntry {
+	if entry == nil {
+		return nil
+	}
+	return &entryext.ExtendedEntry{
+		Entry:      entry,
+		Extensions: api.RegistrationEntryExtensions(regEntry),
+	}
+}
@@ -13,11 +13,15 @@ import (
 	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	commonapi "github.com/spiffe/spire/pkg/common/api"
+	"github.com/spiffe/spire/pkg/common/protoutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/tenant"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -31,16 +35,21 @@ type Config struct {
 	EntryFetcher  api.AuthorizedEntryFetcher
 	DataStore     datastore.DataStore
 	EntryPageSize int
+
+	// CredentialProfilePolicy governs what the credential profiles of
+	// entries can set.
+	CredentialProfilePolicy credtemplate.CredentialProfilePolicy
 }
 
 // Service defines the v1 entry service.
 type Service struct {
 	entryv1.UnsafeEntryServer
 
-	td            spiffeid.TrustDomain
-	ds            datastore.DataStore
-	ef            api.AuthorizedEntryFetcher
-	entryPageSize int
+	td                      spiffeid.TrustDomain
+	ds                      datastore.DataStore
+	ef                      api.AuthorizedEntryFetcher
+	entryPageSize           int
+	credentialProfilePolicy credtemplate.CredentialProfilePolicy
 }
 
 // New creates a new v1 entry service.
@@ -53,6 +62,8 @@ func New(config Config) *Service {
 		ds:            config.DataStore,
 		ef:            config.EntryFetcher,
 		entryPageSize: config.EntryPageSize,
+
+		credentialProfilePolicy: config.CredentialProfilePolicy,
 	}
 }
 
@@ -125,7 +136,13 @@ func (s *Service) CountEntries(ctx context.Context, req *entryv1.CountEntriesReq
 		}
 	}
 
-	count, err := s.ds.CountRegistrationEntries(ctx, countReq)
+	var count int32
+	var err error
+	if t, ok := rpccontext.CallerTenant(ctx); ok {
+		count, err = s.countTenantEntries(ctx, t, countReq)
+	} else {
+		count, err = s.ds.CountRegistrationEntries(ctx, countReq)
+	}
 	if err != nil {
 		log := rpccontext.Logger(ctx)
 		return nil, commonapi.MakeErr(log, codes.Internal, "failed to count entries", err)
@@ -208,6 +225,10 @@ func (s *Service) ListEntries(ctx context.Context, req *entryv1.ListEntriesReque
 		}
 	}
 
+	if t, ok := rpccontext.CallerTenant(ctx); ok {
+		listReq.BySpiffeIDPrefix = t.IDPrefix()
+	}
+
 	dsResp, err := s.ds.ListRegistrationEntries(ctx, listReq)
 	if err != nil {
 		return nil, commonapi.MakeErr(log, codes.Internal, "failed to list entries", err)
@@ -218,7 +239,14 @@ func (s *Service) ListEntries(ctx context.Context, req *entryv1.ListEntriesReque
 		resp.NextPageToken = dsResp.Pagination.Token
 	}
 
+	// The datastore only returns the entries under the tenant path prefix to
+	// tenant admins. Entries under the prefix that the tenant does not own,
+	// e.g. admin entries, are left out, so those pages may be smaller than
+	// the requested page size.
 	for _, regEntry := range dsResp.Entries {
+		if !callerOwnsEntry(ctx, regEntry) {
+			continue
+		}
 		entry, err := api.RegistrationEntryToProto(regEntry)
 		if err != nil {
 			log.WithError(err).Errorf("Failed to convert entry: %q", regEntry.EntryId)
@@ -246,7 +274,7 @@ func (s *Service) GetEntry(ctx context.Context, req *entryv1.GetEntryRequest) (*
 		return nil, commonapi.MakeErr(log, codes.Internal, "failed to fetch entry", err)
 	}
 
-	if registrationEntry == nil {
+	if registrationEntry == nil || !callerOwnsEntry(ctx, registrationEntry) {
 		return nil, commonapi.MakeErr(log, codes.NotFound, "entry not found", nil)
 	}
 
@@ -264,7 +292,7 @@ func (s *Service) GetEntry(ctx context.Context, req *entryv1.GetEntryRequest) (*
 func (s *Service) BatchCreateEntry(ctx context.Context, req *entryv1.BatchCreateEntryRequest) (*entryv1.BatchCreateEntryResponse, error) {
 	var results []*entryv1.BatchCreateEntryResponse_Result
 	for _, eachEntry := range req.Entries {
-		r := s.createEntry(ctx, eachEntry, req.OutputMask)
+		r, _ := s.createEntry(ctx, eachEntry, nil, req.OutputMask)
 		results = append(results, r)
 		rpccontext.AuditRPCWithTypesStatus(ctx, r.Status, func() logrus.Fields {
 			return fieldsFromEntryProto(ctx, eachEntry, nil)
@@ -276,18 +304,42 @@ func (s *Service) BatchCreateEntry(ctx context.Context, req *entryv1.BatchCreate
 	}, nil
 }
 
-func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *types.EntryMask) *entryv1.BatchCreateEntryResponse_Result {
+// createEntry creates the entry with the given extensions, if any. Along with
+// the result, it returns the entry that was created or that already exists.
+func (s *Service) createEntry(ctx context.Context, e *types.Entry, ext *entryext.EntryExtensions, outputMask *types.EntryMask) (*entryv1.BatchCreateEntryResponse_Result, *common.RegistrationEntry) {
 	log := rpccontext.Logger(ctx)
 
 	cEntry, err := api.ProtoToRegistrationEntry(ctx, s.td, e)
 	if err != nil {
 		return &entryv1.BatchCreateEntryResponse_Result{
 			Status: commonapi.MakeStatus(log, codes.InvalidArgument, "failed to convert entry", err),
-		}
+		}, nil
+	}
+
+	if err := api.ValidateEntryExtensions(ext); err != nil {
+		return &entryv1.BatchCreateEntryResponse_Result{
+			Status: commonapi.MakeStatus(log, codes.InvalidArgument, "failed to convert entry", err),
+		}, nil
+	}
+	cEntry.NotBefore = ext.GetNotBefore()
+	if credentialProfile := api.NonEmptyCredentialProfile(ext.GetCredentialProfile()); credentialProfile != nil {
+		cEntry.AdditionalAttributes = withCredentialProfile(cEntry.AdditionalAttributes, credentialProfile)
+	}
+
+	if err := credtemplate.ValidateCredentialProfile(cEntry.GetAdditionalAttributes().GetCredentialProfile(), s.credentialProfilePolicy); err != nil {
+		return &entryv1.BatchCreateEntryResponse_Result{
+			Status: commonapi.MakeStatus(log, codes.InvalidArgument, "failed to convert entry", err),
+		}, nil
 	}
 
 	log = log.WithField(telemetry.SPIFFEID, cEntry.SpiffeId)
 
+	if !callerOwnsEntry(ctx, cEntry) {
+		return &entryv1.BatchCreateEntryResponse_Result{
+			Status: commonapi.MakeStatus(log, codes.PermissionDenied, "entry is outside of the caller tenant", nil),
+		}, nil
+	}
+
 	resultStatus := commonapi.OK()
 	regEntry, existing, err := s.ds.CreateOrReturnRegistrationEntry(ctx, cEntry)
 	switch {
@@ -298,7 +350,7 @@ func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *t
 		}
 		return &entryv1.BatchCreateEntryResponse_Result{
 			Status: commonapi.MakeStatus(log, statusCode, "failed to create entry", err),
-		}
+		}, nil
 	case existing:
 		resultStatus = commonapi.CreateStatus(codes.AlreadyExists, "similar entry already exists")
 	}
@@ -307,7 +359,7 @@ func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *t
 	if err != nil {
 		return &entryv1.BatchCreateEntryResponse_Result{
 			Status: commonapi.MakeStatus(log, codes.Internal, "failed to convert entry", err),
-		}
+		}, nil
 	}
 
 	applyMask(tEntry, outputMask)
@@ -315,7 +367,7 @@ func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *t
 	return &entryv1.BatchCreateEntryResponse_Result{
 		Status: resultStatus,
 		Entry:  tEntry,
-	}
+	}, regEntry
 }
 
 // BatchUpdateEntry updates one or more entries in the server.
@@ -323,7 +375,7 @@ func (s *Service) BatchUpdateEntry(ctx context.Context, req *entryv1.BatchUpdate
 	var results []*entryv1.BatchUpdateEntryResponse_Result
 
 	for _, eachEntry := range req.Entries {
-		e := s.updateEntry(ctx, eachEntry, req.InputMask, req.OutputMask)
+		e, _ := s.updateEntry(ctx, eachEntry, nil, req.InputMask, req.OutputMask)
 		results = append(results, e)
 		rpccontext.AuditRPCWithTypesStatus(ctx, e.Status, func() logrus.Fields {
 			return fieldsFromEntryProto(ctx, eachEntry, req.InputMask)
@@ -363,6 +415,22 @@ func (s *Service) deleteEntry(ctx context.Context, id string) *entryv1.BatchDele
 
 	log = log.WithField(telemetry.RegistrationID, id)
 
+	if _, ok := rpccontext.CallerTenant(ctx); ok {
+		existing, err := s.ds.FetchRegistrationEntry(ctx, id)
+		switch {
+		case err != nil:
+			return &entryv1.BatchDeleteEntryResponse_Result{
+				Id:     id,
+				Status: commonapi.MakeStatus(log, codes.Internal, "failed to fetch entry", err),
+			}
+		case existing == nil || !callerOwnsEntry(ctx, existing):
+			return &entryv1.BatchDeleteEntryResponse_Result{
+				Id:     id,
+				Status: commonapi.MakeStatus(log, codes.NotFound, "entry not found", nil),
+			}
+		}
+	}
+
 	_, err := s.ds.DeleteRegistrationEntry(ctx, id)
 	switch status.Code(err) {
 	case codes.OK:
@@ -638,7 +706,9 @@ func applyMask(e *types.Entry, mask *types.EntryMask) {
 	}
 }
 
-func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *types.EntryMask, outputMask *types.EntryMask) *entryv1.BatchUpdateEntryResponse_Result {
+// updateEntry updates the entry and the given extensions, if any. Along with
+// the result, it returns the updated entry.
+func (s *Service) updateEntry(ctx context.Context, e *types.Entry, ext *entryext.EntryExtensions, inputMask *types.EntryMask, outputMask *types.EntryMask) (*entryv1.BatchUpdateEntryResponse_Result, *common.RegistrationEntry) {
 	log := rpccontext.Logger(ctx)
 	log = log.WithField(telemetry.RegistrationID, e.Id)
 
@@ -646,27 +716,69 @@ func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *ty
 	if err != nil {
 		return &entryv1.BatchUpdateEntryResponse_Result{
 			Status: commonapi.MakeStatus(log, codes.InvalidArgument, "failed to convert entry", err),
-		}
+		}, nil
 	}
 
-	var mask *common.RegistrationEntryMask
-	if inputMask != nil {
-		mask = &common.RegistrationEntryMask{
-			SpiffeId:             inputMask.SpiffeId,
-			ParentId:             inputMask.ParentId,
-			FederatesWith:        inputMask.FederatesWith,
-			Admin:                inputMask.Admin,
-			Downstream:           inputMask.Downstream,
-			EntryExpiry:          inputMask.ExpiresAt,
-			DnsNames:             inputMask.DnsNames,
-			Selectors:            inputMask.Selectors,
-			StoreSvid:            inputMask.StoreSvid,
-			X509SvidTtl:          inputMask.X509SvidTtl,
-			JwtSvidTtl:           inputMask.JwtSvidTtl,
-			Hint:                 inputMask.Hint,
-			AdditionalAttributes: inputMask.AdditionalAttributes,
+	if err := api.ValidateEntryExtensions(ext); err != nil {
+		return &entryv1.BatchUpdateEntryResponse_Result{
+			Status: commonapi.MakeStatus(log, codes.InvalidArgument, "failed to convert entry", err),
+		}, nil
+	}
+	credentialProfile := api.NonEmptyCredentialProfile(ext.GetCredentialProfile())
+	if err := credtemplate.ValidateCredentialProfile(credentialProfile, s.credentialProfilePolicy); err != nil {
+		return &entryv1.BatchUpdateEntryResponse_Result{
+			Status: commonapi.MakeStatus(log, codes.InvalidArgument, "failed to convert entry", err),
+		}, nil
+	}
+	convEntry.NotBefore = ext.GetNotBefore()
+	convEntry.AdditionalAttributes = withCredentialProfile(convEntry.AdditionalAttributes, credentialProfile)
+
+	// The not-before time and the credential profile are only updated when
+	// they are set in the extensions, so that updates through the Entry API
+	// leave them as they are. The datastore keeps their current values in
+	// the update transaction.
+	if inputMask == nil {
+		inputMask = protoutil.AllTrueEntryMask
+	}
+	mask := &common.RegistrationEntryMask{
+		SpiffeId:             inputMask.SpiffeId,
+		ParentId:             inputMask.ParentId,
+		FederatesWith:        inputMask.FederatesWith,
+		Admin:                inputMask.Admin,
+		Downstream:           inputMask.Downstream,
+		EntryExpiry:          inputMask.ExpiresAt,
+		DnsNames:             inputMask.DnsNames,
+		Selectors:            inputMask.Selectors,
+		StoreSvid:            inputMask.StoreSvid,
+		X509SvidTtl:          inputMask.X509SvidTtl,
+		JwtSvidTtl:           inputMask.JwtSvidTtl,
+		Hint:                 inputMask.Hint,
+		AdditionalAttributes: inputMask.AdditionalAttributes,
+		NotBefore:            ext != nil && ext.NotBefore != nil,
+		CredentialProfile:    ext.GetCredentialProfile() != nil,
+	}
+
+	if _, ok := rpccontext.CallerTenant(ctx); ok {
+		// Tenant ownership is checked against the current value of the
+		// entry. A missing entry is reported as not found.
+		before, err := s.ds.FetchRegistrationEntry(ctx, convEntry.EntryId)
+		if err != nil {
+			return &entryv1.BatchUpdateEntryResponse_Result{
+				Status: commonapi.MakeStatus(log, codes.Internal, "failed to fetch entry", err),
+			}, nil
+		}
+		switch {
+		case before == nil || !callerOwnsEntry(ctx, before):
+			return &entryv1.BatchUpdateEntryResponse_Result{
+				Status: commonapi.MakeStatus(log, codes.NotFound, "entry not found", nil),
+			}, nil
+		case !callerOwnsEntry(ctx, ownershipAfterUpdate(before, convEntry, mask)):
+			return &entryv1.BatchUpdateEntryResponse_Result{
+				Status: commonapi.MakeStatus(log, codes.PermissionDenied, "entry is outside of the caller tenant", nil),
+			}, nil
 		}
 	}
+
 	dsEntry, err := s.ds.UpdateRegistrationEntry(ctx, convEntry, mask)
 	if err != nil {
 		statusCode := status.Code(err)
@@ -675,14 +787,14 @@ func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *ty
 		}
 		return &entryv1.BatchUpdateEntryResponse_Result{
 			Status: commonapi.MakeStatus(log, statusCode, "failed to update entry", err),
-		}
+		}, nil
 	}
 
 	tEntry, err := api.RegistrationEntryToProto(dsEntry)
 	if err != nil {
 		return &entryv1.BatchUpdateEntryResponse_Result{
 			Status: commonapi.MakeStatus(log, codes.Internal, "failed to convert entry in updateEntry", err),
-		}
+		}, nil
 	}
 
 	applyMask(tEntry, outputMask)
@@ -690,7 +802,99 @@ func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *ty
 	return &entryv1.BatchUpdateEntryResponse_Result{
 		Status: commonapi.OK(),
 		Entry:  tEntry,
+	}, dsEntry
+}
+
+// withCredentialProfile sets the credential profile in the additional
+// attributes, which are allocated if needed.
+func withCredentialProfile(attrs *common.RegistrationEntry_AdditionalAttributes, profile *common.CredentialProfile) *common.RegistrationEntry_AdditionalAttributes {
+	if profile == nil {
+		if attrs != nil {
+			attrs.CredentialProfile = nil
+		}
+		return attrs
+	}
+	if attrs == nil {
+		attrs = &common.RegistrationEntry_AdditionalAttributes{}
+	}
+	attrs.CredentialProfile = profile
+	return attrs
+}
+
+// callerOwnsEntry returns false if the caller is a tenant admin and the entry
+// does not belong to the tenant. Other callers own all entries.
+func callerOwnsEntry(ctx context.Context, e *common.RegistrationEntry) bool {
+	t, ok := rpccontext.CallerTenant(ctx)
+	return !ok || t.OwnsEntry(e)
+}
+
+// countTenantEntries counts the entries of a tenant. The datastore narrows
+// the entries down to the ones under the tenant path prefix, and the entries
+// the tenant owns are counted here, since ownership also depends on the
+// other fields of the entries.
+func (s *Service) countTenantEntries(ctx context.Context, t *tenant.Tenant, req *datastore.CountRegistrationEntriesRequest) (int32, error) {
+	listReq := &datastore.ListRegistrationEntriesRequest{
+		DataConsistency:  req.DataConsistency,
+		ByParentID:       req.ByParentID,
+		BySelectors:      req.BySelectors,
+		BySpiffeID:       req.BySpiffeID,
+		ByFederatesWith:  req.ByFederatesWith,
+		ByHint:           req.ByHint,
+		ByDownstream:     req.ByDownstream,
+		BySpiffeIDPrefix: t.IDPrefix(),
+		Pagination: &datastore.Pagination{
+			PageSize: int32(s.entryPageSize),
+		},
+	}
+
+	var count int32
+	for {
+		resp, err := s.ds.ListRegistrationEntries(ctx, listReq)
+		if err != nil {
+			return 0, err
+		}
+		for _, e := range resp.Entries {
+			if t.OwnsEntry(e) {
+				count++
+			}
+		}
+		if len(resp.Entries) == 0 || resp.Pagination == nil || resp.Pagination.Token == "" {
+			return count, nil
+		}
+		listReq.Pagination.Token = resp.Pagination.Token
+	}
+}
+
+// ownershipAfterUpdate returns the fields that decide the tenant of an entry,
+// as they will be once the update is applied.
+func ownershipAfterUpdate(before, update *common.RegistrationEntry, mask *common.RegistrationEntryMask) *common.RegistrationEntry {
+	after := &common.RegistrationEntry{
+		SpiffeId:      before.SpiffeId,
+		ParentId:      before.ParentId,
+		Admin:         before.Admin,
+		Downstream:    before.Downstream,
+		DnsNames:      before.DnsNames,
+		FederatesWith: before.FederatesWith,
+	}
+	if mask == nil || mask.SpiffeId {
+		after.SpiffeId = update.SpiffeId
+	}
+	if mask == nil || mask.ParentId {
+		after.ParentId = update.ParentId
+	}
+	if mask == nil || mask.Admin {
+		after.Admin = update.Admin
+	}
+	if mask == nil || mask.Downstream {
+		after.Downstream = update.Downstream
+	}
+	if mask == nil || mask.DnsNames {
+		after.DnsNames = update.DnsNames
+	}
+	if mask == nil || mask.FederatesWith {
+		after.FederatesWith = update.FederatesWith
 	}
+	return after
 }
 
 func fieldsFromEntryProto(ctx context.Context, proto *types.Entry, inputMask *types.EntryMask) logrus.Fields {
@@ -23,8 +23,11 @@ import (
 	"github.com/spiffe/spire/pkg/server/api/entry/v1"
 	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/tenant"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/grpctest"
 	"github.com/spiffe/spire/test/spiretest"
@@ -2561,29 +2564,29 @@ func TestBatchDeleteEntry(t *testing.T) {
 			expectDs: dsEntries,
 			expectResult: func(m map[string]*common.RegistrationEntry) ([]*entryv1.BatchDeleteEntryResponse_Result, []spiretest.LogEntry) {
 				return []*entryv1.BatchDeleteEntryResponse_Result{
-						{
-							Status: &types.Status{
-								Code:    int32(codes.InvalidArgument),
-								Message: "missing entry ID",
-							},
-						},
-					}, []spiretest.LogEntry{
-						{
-							Level:   logrus.ErrorLevel,
-							Message: "Invalid argument: missing entry ID",
+					{
+						Status: &types.Status{
+							Code:    int32(codes.InvalidArgument),
+							Message: "missing entry ID",
 						},
-						{
-							Level:   logrus.InfoLevel,
-							Message: "API accessed",
-							Data: logrus.Fields{
-								telemetry.Status:         "error",
-								telemetry.Type:           "audit",
-								telemetry.RegistrationID: "",
-								telemetry.StatusCode:     "InvalidArgument",
-								telemetry.StatusMessage:  "missing entry ID",
-							},
+					},
+				}, []spiretest.LogEntry{
+					{
+						Level:   logrus.ErrorLevel,
+						Message: "Invalid argument: missing entry ID",
+					},
+					{
+						Level:   logrus.InfoLevel,
+						Message: "API accessed",
+						Data: logrus.Fields{
+							telemetry.Status:         "error",
+							telemetry.Type:           "audit",
+							telemetry.RegistrationID: "",
+							telemetry.StatusCode:     "InvalidArgument",
+							telemetry.StatusMessage:  "missing entry ID",
 						},
-					}
+					},
+				}
 			},
 			ids: func(m map[string]*common.RegistrationEntry) []string {
 				return []string{""}
@@ -2595,34 +2598,34 @@ func TestBatchDeleteEntry(t *testing.T) {
 			expectDs: dsEntries,
 			expectResult: func(m map[string]*common.RegistrationEntry) ([]*entryv1.BatchDeleteEntryResponse_Result, []spiretest.LogEntry) {
 				return []*entryv1.BatchDeleteEntryResponse_Result{
-						{
-							Status: &types.Status{
-								Code:    int32(codes.Internal),
-								Message: "failed to delete entry: some error",
-							},
-							Id: m[fooSpiffeID].EntryId,
+					{
+						Status: &types.Status{
+							Code:    int32(codes.Internal),
+							Message: "failed to delete entry: some error",
 						},
-					}, []spiretest.LogEntry{
-						{
-							Level:   logrus.ErrorLevel,
-							Message: "Failed to delete entry",
-							Data: logrus.Fields{
-								telemetry.RegistrationID: m[fooSpiffeID].EntryId,
-								logrus.ErrorKey:          "some error",
-							},
+						Id: m[fooSpiffeID].EntryId,
+					},
+				}, []spiretest.LogEntry{
+					{
+						Level:   logrus.ErrorLevel,
+						Message: "Failed to delete entry",
+						Data: logrus.Fields{
+							telemetry.RegistrationID: m[fooSpiffeID].EntryId,
+							logrus.ErrorKey:          "some error",
 						},
-						{
-							Level:   logrus.InfoLevel,
-							Message: "API accessed",
-							Data: logrus.Fields{
-								telemetry.Status:         "error",
-								telemetry.Type:           "audit",
-								telemetry.RegistrationID: m[fooSpiffeID].EntryId,
-								telemetry.StatusCode:     "Internal",
-								telemetry.StatusMessage:  "failed to delete entry: some error",
-							},
+					},
+					{
+						Level:   logrus.InfoLevel,
+						Message: "API accessed",
+						Data: logrus.Fields{
+							telemetry.Status:         "error",
+							telemetry.Type:           "audit",
+							telemetry.RegistrationID: m[fooSpiffeID].EntryId,
+							telemetry.StatusCode:     "Internal",
+							telemetry.StatusMessage:  "failed to delete entry: some error",
 						},
-					}
+					},
+				}
 			},
 			ids: func(m map[string]*common.RegistrationEntry) []string {
 				return []string{m[fooSpiffeID].EntryId}
@@ -2633,33 +2636,33 @@ func TestBatchDeleteEntry(t *testing.T) {
 			expectDs: dsEntries,
 			expectResult: func(m map[string]*common.RegistrationEntry) ([]*entryv1.BatchDeleteEntryResponse_Result, []spiretest.LogEntry) {
 				return []*entryv1.BatchDeleteEntryResponse_Result{
-						{
-							Status: &types.Status{
-								Code:    int32(codes.NotFound),
-								Message: "entry not found",
-							},
-							Id: "invalid id",
+					{
+						Status: &types.Status{
+							Code:    int32(codes.NotFound),
+							Message: "entry not found",
 						},
-					}, []spiretest.LogEntry{
-						{
-							Level:   logrus.ErrorLevel,
-							Message: "Entry not found",
-							Data: logrus.Fields{
-								telemetry.RegistrationID: "invalid id",
-							},
+						Id: "invalid id",
+					},
+				}, []spiretest.LogEntry{
+					{
+						Level:   logrus.ErrorLevel,
+						Message: "Entry not found",
+						Data: logrus.Fields{
+							telemetry.RegistrationID: "invalid id",
 						},
-						{
-							Level:   logrus.InfoLevel,
-							Message: "API accessed",
-							Data: logrus.Fields{
-								telemetry.Status:         "error",
-								telemetry.Type:           "audit",
-								telemetry.RegistrationID: "invalid id",
-								telemetry.StatusCode:     "NotFound",
-								telemetry.StatusMessage:  "entry not found",
-							},
+					},
+					{
+						Level:   logrus.InfoLevel,
+						Message: "API accessed",
+						Data: logrus.Fields{
+							telemetry.Status:         "error",
+							telemetry.Type:           "audit",
+							telemetry.RegistrationID: "invalid id",
+							telemetry.StatusCode:     "NotFound",
+							telemetry.StatusMessage:  "entry not found",
 						},
-					}
+					},
+				}
 			},
 			ids: func(m map[string]*common.RegistrationEntry) []string {
 				return []string{"invalid id"}
@@ -4735,7 +4738,9 @@ func TestBatchUpdateEntry(t *testing.T) {
 				updateEntries[i].Id = createResp.Results[i].Entry.Id
 				spiffeToIDMap[createResp.Results[i].Entry.SpiffeId.Path] = createResp.Results[i].Entry.Id
 			}
-			ds.SetNextError(tt.dsError)
+			if tt.dsError != nil {
+				ds.SetNextError(tt.dsError)
+			}
 			// Clean creation logs
 			test.logHook.Reset()
 
@@ -4790,6 +4795,391 @@ func TestBatchUpdateEntry(t *testing.T) {
 	}
 }
 
+func TestEntryNotBefore(t *testing.T) {
+	ds := fakedatastore.New(t)
+	test := setupServiceTest(t, ds)
+	defer test.Cleanup()
+
+	withNotBefore := func(entry *types.Entry, notBefore int64) *entryext.ExtendedEntry {
+		return &entryext.ExtendedEntry{
+			Entry:      entry,
+			Extensions: &entryext.EntryExtensions{NotBefore: proto.Int64(notBefore)},
+		}
+	}
+	newEntry := func(id, path string) *types.Entry {
+		return &types.Entry{
+			Id:        id,
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: path},
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		}
+	}
+	fetchNotBefore := func(entryID string) int64 {
+		entry, err := ds.FetchRegistrationEntry(ctx, entryID)
+		require.NoError(t, err)
+		return entry.NotBefore
+	}
+
+	// Each entry carries its own not-before time
+	createResp, err := test.extClient.BatchCreateEntry(ctx, &entryext.BatchCreateEntryRequest{
+		Entries: []*entryext.ExtendedEntry{
+			withNotBefore(newEntry("", "/workload1"), 1000),
+			{Entry: newEntry("", "/workload2")},
+		},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), createResp.Results[0].Status)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), createResp.Results[1].Status)
+	entryID := createResp.Results[0].Entry.Entry.Id
+	require.Equal(t, int64(1000), fetchNotBefore(entryID))
+	require.Equal(t, int64(1000), createResp.Results[0].Entry.Extensions.GetNotBefore())
+	require.Zero(t, fetchNotBefore(createResp.Results[1].Entry.Entry.Id))
+	require.Nil(t, createResp.Results[1].Entry.Extensions.NotBefore)
+
+	// The not-before time is returned with the extensions of the entry
+	getResp, err := test.extClient.BatchGetEntryExtensions(ctx, &entryext.BatchGetEntryExtensionsRequest{
+		Ids: []string{entryID, createResp.Results[1].Entry.Entry.Id},
+	})
+	require.NoError(t, err)
+	require.Len(t, getResp.Results, 2)
+	spiretest.AssertProtoEqual(t, &entryext.EntryExtensions{NotBefore: proto.Int64(1000)}, getResp.Results[0].Extensions)
+	spiretest.AssertProtoEqual(t, &entryext.EntryExtensions{}, getResp.Results[1].Extensions)
+
+	// Updates through the Entry API keep the current not-before time
+	updateResp, err := test.client.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{
+		Entries: []*types.Entry{newEntry(entryID, "/workload1")},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), updateResp.Results[0].Status)
+	require.Equal(t, int64(1000), fetchNotBefore(entryID))
+
+	// Updates without the not-before time keep the current one
+	extUpdateResp, err := test.extClient.BatchUpdateEntry(ctx, &entryext.BatchUpdateEntryRequest{
+		Entries: []*entryext.ExtendedEntry{{Entry: newEntry(entryID, "/workload1")}},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), extUpdateResp.Results[0].Status)
+	require.Equal(t, int64(1000), fetchNotBefore(entryID))
+
+	// Updates with the not-before time replace it, even when masked
+	extUpdateResp, err = test.extClient.BatchUpdateEntry(ctx, &entryext.BatchUpdateEntryRequest{
+		Entries:   []*entryext.ExtendedEntry{withNotBefore(&types.Entry{Id: entryID}, 2000)},
+		InputMask: &types.EntryMask{},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), extUpdateResp.Results[0].Status)
+	require.Equal(t, int64(2000), extUpdateResp.Results[0].Entry.Extensions.GetNotBefore())
+	require.Equal(t, int64(2000), fetchNotBefore(entryID))
+
+	extUpdateResp, err = test.extClient.BatchUpdateEntry(ctx, &entryext.BatchUpdateEntryRequest{
+		Entries:   []*entryext.ExtendedEntry{withNotBefore(&types.Entry{Id: entryID}, 0)},
+		InputMask: &types.EntryMask{},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), extUpdateResp.Results[0].Status)
+	require.Zero(t, fetchNotBefore(entryID))
+
+	extUpdateResp, err = test.extClient.BatchUpdateEntry(ctx, &entryext.BatchUpdateEntryRequest{
+		Entries:   []*entryext.ExtendedEntry{withNotBefore(&types.Entry{Id: entryID}, -1)},
+		InputMask: &types.EntryMask{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(codes.InvalidArgument), extUpdateResp.Results[0].Status.Code)
+	require.Equal(t, "failed to convert entry: invalid not-before time -1: must not be negative", extUpdateResp.Results[0].Status.Message)
+	require.Nil(t, extUpdateResp.Results[0].Entry)
+}
+
+func TestEntryCredentialProfile(t *testing.T) {
+	ds := fakedatastore.New(t)
+	test := setupServiceTest(t, ds, withCredentialProfilePolicy(credtemplate.CredentialProfilePolicy{
+		AllowedFields: []string{
+			credtemplate.CredentialProfileSubjectOrganization,
+			credtemplate.CredentialProfileExtendedKeyUsages,
+		},
+		AllowedExtendedKeyUsages: []string{"emailProtection"},
+	}))
+	defer test.Cleanup()
+
+	withCredentialProfile := func(entry *types.Entry, profile *common.CredentialProfile) *entryext.ExtendedEntry {
+		return &entryext.ExtendedEntry{
+			Entry:      entry,
+			Extensions: &entryext.EntryExtensions{CredentialProfile: profile},
+		}
+	}
+	fetchAdditionalAttributes := func(entryID string) *common.RegistrationEntry_AdditionalAttributes {
+		entry, err := ds.FetchRegistrationEntry(ctx, entryID)
+		require.NoError(t, err)
+		return entry.AdditionalAttributes
+	}
+
+	profile := &common.CredentialProfile{
+		SubjectOrganization: []string{"ACME"},
+		ExtendedKeyUsages:   []string{"emailProtection"},
+	}
+	createResp, err := test.extClient.BatchCreateEntry(ctx, &entryext.BatchCreateEntryRequest{
+		Entries: []*entryext.ExtendedEntry{
+			withCredentialProfile(&types.Entry{
+				ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+			}, profile),
+			{
+				Entry: &types.Entry{
+					ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/other"},
+					Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), createResp.Results[0].Status)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), createResp.Results[1].Status)
+	entryID := createResp.Results[0].Entry.Entry.Id
+	spiretest.AssertProtoEqual(t, profile, fetchAdditionalAttributes(entryID).CredentialProfile)
+	spiretest.AssertProtoEqual(t, profile, createResp.Results[0].Entry.Extensions.CredentialProfile)
+
+	// The credential profile is set per entry
+	require.Nil(t, fetchAdditionalAttributes(createResp.Results[1].Entry.Entry.Id).GetCredentialProfile())
+
+	// The credential profile is returned with the extensions of the entry
+	getResp, err := test.extClient.BatchGetEntryExtensions(ctx, &entryext.BatchGetEntryExtensionsRequest{
+		Ids: []string{entryID, "missing"},
+	})
+	require.NoError(t, err)
+	require.Len(t, getResp.Results, 2)
+	spiretest.AssertProtoEqual(t, profile, getResp.Results[0].Extensions.CredentialProfile)
+	require.Equal(t, "missing", getResp.Results[1].Id)
+	require.Equal(t, int32(codes.NotFound), getResp.Results[1].Status.Code)
+
+	// Updates through the Entry API keep the current credential profile,
+	// including when the additional attributes are updated
+	updateResp, err := test.client.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{
+		Entries: []*types.Entry{
+			{
+				Id:        entryID,
+				ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1001"}},
+				AdditionalAttributes: &types.Entry_AdditionalAttributes{
+					JwtSvidIncludeJti: true,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), updateResp.Results[0].Status)
+	spiretest.AssertProtoEqual(t, &common.RegistrationEntry_AdditionalAttributes{
+		JwtSvidIncludeJti: true,
+		CredentialProfile: profile,
+	}, fetchAdditionalAttributes(entryID))
+
+	// Updates with the credential profile replace it, even when the
+	// additional attributes are masked
+	profile = &common.CredentialProfile{SubjectOrganization: []string{"Globex"}}
+	extUpdateResp, err := test.extClient.BatchUpdateEntry(ctx, &entryext.BatchUpdateEntryRequest{
+		Entries:   []*entryext.ExtendedEntry{withCredentialProfile(&types.Entry{Id: entryID}, profile)},
+		InputMask: &types.EntryMask{},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), extUpdateResp.Results[0].Status)
+	spiretest.AssertProtoEqual(t, &common.RegistrationEntry_AdditionalAttributes{
+		JwtSvidIncludeJti: true,
+		CredentialProfile: profile,
+	}, fetchAdditionalAttributes(entryID))
+
+	// An empty credential profile clears it
+	extUpdateResp, err = test.extClient.BatchUpdateEntry(ctx, &entryext.BatchUpdateEntryRequest{
+		Entries:   []*entryext.ExtendedEntry{withCredentialProfile(&types.Entry{Id: entryID}, &common.CredentialProfile{})},
+		InputMask: &types.EntryMask{},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, commonapi.OK(), extUpdateResp.Results[0].Status)
+	spiretest.AssertProtoEqual(t, &common.RegistrationEntry_AdditionalAttributes{
+		JwtSvidIncludeJti: true,
+	}, fetchAdditionalAttributes(entryID))
+
+	// Fields that are not allowed by the server configuration are rejected
+	extUpdateResp, err = test.extClient.BatchUpdateEntry(ctx, &entryext.BatchUpdateEntryRequest{
+		Entries: []*entryext.ExtendedEntry{withCredentialProfile(&types.Entry{Id: entryID}, &common.CredentialProfile{
+			SubjectOrganizationalUnit: []string{"Payments"},
+		})},
+		InputMask: &types.EntryMask{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(codes.InvalidArgument), extUpdateResp.Results[0].Status.Code)
+	require.Equal(t, `failed to convert entry: credential profile field "subject_organizational_unit" is not allowed by the server configuration`, extUpdateResp.Results[0].Status.Message)
+
+	// Extended key usages must be allowed by the server configuration
+	createResp, err = test.extClient.BatchCreateEntry(ctx, &entryext.BatchCreateEntryRequest{
+		Entries: []*entryext.ExtendedEntry{
+			withCredentialProfile(&types.Entry{
+				ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/another"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+			}, &common.CredentialProfile{
+				ExtendedKeyUsages: []string{"2.5.29.37.0"},
+			}),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(codes.InvalidArgument), createResp.Results[0].Status.Code)
+	require.Equal(t, `failed to convert entry: credential profile extended key usage "2.5.29.37.0" is not allowed by the server configuration`, createResp.Results[0].Status.Message)
+}
+
+func TestTenantScoping(t *testing.T) {
+	ds := fakedatastore.New(t)
+	test := setupServiceTest(t, ds)
+	defer test.Cleanup()
+
+	agentParent := "spiffe://example.org/spire/agent/x509pop/node"
+	// The entry of the other tenant comes first in the listing
+	entries := createTestEntries(t, ds,
+		&common.RegistrationEntry{
+			ParentId:  agentParent,
+			SpiffeId:  "spiffe://example.org/team-b/workload",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+		&common.RegistrationEntry{
+			ParentId:  agentParent,
+			SpiffeId:  "spiffe://example.org/team-a/workload",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+	)
+	entryA := entries["spiffe://example.org/team-a/workload"].EntryId
+	entryB := entries["spiffe://example.org/team-b/workload"].EntryId
+
+	tenants, err := tenant.NewSet(td, []tenant.Config{
+		{Name: "team-a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{agentID}, DNSNameSuffixes: []string{"team-a.example.org"}},
+	})
+	require.NoError(t, err)
+	test.tenant, _ = tenants.ForAdmin(agentID)
+
+	t.Run("list only returns tenant entries", func(t *testing.T) {
+		resp, err := test.client.ListEntries(ctx, &entryv1.ListEntriesRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 1)
+		require.Equal(t, entryA, resp.Entries[0].Id)
+	})
+
+	t.Run("list pages only hold tenant entries", func(t *testing.T) {
+		resp, err := test.client.ListEntries(ctx, &entryv1.ListEntriesRequest{PageSize: 1})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 1)
+		require.Equal(t, entryA, resp.Entries[0].Id)
+
+		resp, err = test.client.ListEntries(ctx, &entryv1.ListEntriesRequest{PageSize: 1, PageToken: resp.NextPageToken})
+		require.NoError(t, err)
+		require.Empty(t, resp.Entries)
+	})
+
+	t.Run("count only counts tenant entries", func(t *testing.T) {
+		resp, err := test.client.CountEntries(ctx, &entryv1.CountEntriesRequest{})
+		require.NoError(t, err)
+		require.Equal(t, int32(1), resp.Count)
+	})
+
+	t.Run("get entry of another tenant", func(t *testing.T) {
+		_, err := test.client.GetEntry(ctx, &entryv1.GetEntryRequest{Id: entryB})
+		spiretest.RequireGRPCStatus(t, err, codes.NotFound, "entry not found")
+
+		resp, err := test.client.GetEntry(ctx, &entryv1.GetEntryRequest{Id: entryA})
+		require.NoError(t, err)
+		require.Equal(t, entryA, resp.Id)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		resp, err := test.client.BatchCreateEntry(ctx, &entryv1.BatchCreateEntryRequest{
+			Entries: []*types.Entry{
+				{
+					ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/x509pop/node"},
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-a/other"},
+					Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				},
+				{
+					ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/x509pop/node"},
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-b/other"},
+					Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				},
+				{
+					ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/x509pop/node"},
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-a/admin"},
+					Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+					Admin:     true,
+				},
+				{
+					ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/x509pop/node"},
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-a/dns"},
+					Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+					DnsNames:  []string{"web.team-b.example.org"},
+				},
+				{
+					ParentId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/x509pop/node"},
+					SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-a/federated"},
+					Selectors:     []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+					FederatesWith: []string{"domain1.org"},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 5)
+		spiretest.AssertProtoEqual(t, commonapi.OK(), resp.Results[0].Status)
+		for _, result := range resp.Results[1:] {
+			require.Equal(t, int32(codes.PermissionDenied), result.Status.Code)
+			require.Equal(t, "entry is outside of the caller tenant", result.Status.Message)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		resp, err := test.client.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{
+			Entries: []*types.Entry{
+				{Id: entryA, X509SvidTtl: 60},
+				{Id: entryB, X509SvidTtl: 60},
+			},
+			InputMask: &types.EntryMask{X509SvidTtl: true},
+		})
+		require.NoError(t, err)
+		spiretest.AssertProtoEqual(t, commonapi.OK(), resp.Results[0].Status)
+		require.Equal(t, int32(codes.NotFound), resp.Results[1].Status.Code)
+
+		resp, err = test.client.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{
+			Entries: []*types.Entry{
+				{Id: entryA, SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-b/moved"}},
+			},
+			InputMask: &types.EntryMask{SpiffeId: true},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int32(codes.PermissionDenied), resp.Results[0].Status.Code)
+		require.Equal(t, "entry is outside of the caller tenant", resp.Results[0].Status.Message)
+
+		resp, err = test.client.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{
+			Entries: []*types.Entry{
+				{Id: entryA, DnsNames: []string{"web.team-a.example.org"}},
+				{Id: entryA, DnsNames: []string{"web.team-b.example.org"}},
+				{Id: entryA, FederatesWith: []string{"domain1.org"}},
+			},
+			InputMask: &types.EntryMask{DnsNames: true, FederatesWith: true},
+		})
+		require.NoError(t, err)
+		spiretest.AssertProtoEqual(t, commonapi.OK(), resp.Results[0].Status)
+		for _, result := range resp.Results[1:] {
+			require.Equal(t, int32(codes.PermissionDenied), result.Status.Code)
+			require.Equal(t, "entry is outside of the caller tenant", result.Status.Message)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := test.client.BatchDeleteEntry(ctx, &entryv1.BatchDeleteEntryRequest{Ids: []string{entryB, entryA}})
+		require.NoError(t, err)
+		require.Equal(t, int32(codes.NotFound), resp.Results[0].Status.Code)
+		spiretest.AssertProtoEqual(t, commonapi.OK(), resp.Results[1].Status)
+
+		test.tenant = nil
+		_, err = test.client.GetEntry(ctx, &entryv1.GetEntryRequest{Id: entryB})
+		require.NoError(t, err)
+	})
+}
+
 func createFederatedBundles(t *testing.T, ds datastore.DataStore) {
 	_, err := ds.CreateBundle(ctx, &common.Bundle{
 		TrustDomainId: federatedTd.IDString(),
@@ -4832,17 +5222,26 @@ func withEntryPageSize(v int) func(*serviceTestConfig) {
 	}
 }
 
+func withCredentialProfilePolicy(policy credtemplate.CredentialProfilePolicy) func(*serviceTestConfig) {
+	return func(config *serviceTestConfig) {
+		config.credentialProfilePolicy = policy
+	}
+}
+
 type serviceTestConfig struct {
-	entryPageSize int
+	entryPageSize           int
+	credentialProfilePolicy credtemplate.CredentialProfilePolicy
 }
 
 type serviceTest struct {
 	client       entryv1.EntryClient
+	extClient    entryext.EntryClient
 	ef           *entryFetcher
 	done         func()
 	ds           datastore.DataStore
 	logHook      *test.Hook
 	omitCallerID bool
+	tenant       *tenant.Tenant
 }
 
 func (s *serviceTest) Cleanup() {
@@ -4864,6 +5263,8 @@ func setupServiceTest(t *testing.T, ds datastore.DataStore, options ...serviceTe
 		DataStore:     ds,
 		EntryFetcher:  ef,
 		EntryPageSize: config.entryPageSize,
+
+		CredentialProfilePolicy: config.credentialProfilePolicy,
 	})
 
 	log, logHook := test.NewNullLogger()
@@ -4878,11 +5279,15 @@ func setupServiceTest(t *testing.T, ds datastore.DataStore, options ...serviceTe
 		if !test.omitCallerID {
 			ctx = rpccontext.WithCallerID(ctx, agentID)
 		}
+		if test.tenant != nil {
+			ctx = rpccontext.WithCallerTenant(ctx, test.tenant)
+		}
 		return ctx
 	}
 
 	server := grpctest.StartServer(t, func(s grpc.ServiceRegistrar) {
 		entry.RegisterService(s, service)
+		entry.RegisterExtensionService(s, entry.NewExtensionService(service))
 	},
 		grpctest.OverrideContext(overrideContext),
 		grpctest.Middleware(middleware.WithAuditLog(false)),
@@ -4891,6 +5296,7 @@ func setupServiceTest(t *testing.T, ds datastore.DataStore, options ...serviceTe
 	conn := server.NewGRPCClient(t)
 
 	test.client = entryv1.NewEntryClient(conn)
+	test.extClient = entryext.NewEntryClient(conn)
 	test.done = server.Stop
 
 	return test
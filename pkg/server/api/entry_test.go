@@ -13,6 +13,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/protoutil"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -113,6 +114,65 @@ func TestRegistrationEntryToProto(t *testing.T) {
 	}
 }
 
+func TestRegistrationEntryExtensions(t *testing.T) {
+	profile := &common.CredentialProfile{SubjectOrganization: []string{"ACME"}}
+	entry := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/foo",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		NotBefore: 1000,
+		AdditionalAttributes: &common.RegistrationEntry_AdditionalAttributes{
+			CredentialProfile: profile,
+		},
+	}
+	spiretest.AssertProtoEqual(t, &entryext.EntryExtensions{
+		NotBefore:         proto.Int64(1000),
+		CredentialProfile: profile,
+	}, api.RegistrationEntryExtensions(entry))
+
+	// The extensions are not carried by the Entry type
+	converted, err := api.RegistrationEntryToProto(entry)
+	require.NoError(t, err)
+	require.Empty(t, converted.ProtoReflect().GetUnknown())
+
+	// Entries without a not-before time or a credential profile have empty
+	// extensions
+	spiretest.AssertProtoEqual(t, &entryext.EntryExtensions{}, api.RegistrationEntryExtensions(&common.RegistrationEntry{}))
+}
+
+func TestValidateEntryExtensions(t *testing.T) {
+	require.NoError(t, api.ValidateEntryExtensions(nil))
+	require.NoError(t, api.ValidateEntryExtensions(&entryext.EntryExtensions{NotBefore: proto.Int64(1000)}))
+	require.EqualError(t, api.ValidateEntryExtensions(&entryext.EntryExtensions{NotBefore: proto.Int64(-1)}), "invalid not-before time -1: must not be negative")
+}
+
+func TestRegistrationEntryIsActive(t *testing.T) {
+	now := time.Unix(1000, 0)
+	require.True(t, api.RegistrationEntryIsActive(&common.RegistrationEntry{}, now))
+	require.True(t, api.RegistrationEntryIsActive(&common.RegistrationEntry{NotBefore: 1000, EntryExpiry: 1001}, now))
+	require.False(t, api.RegistrationEntryIsActive(&common.RegistrationEntry{NotBefore: 1001}, now))
+	require.False(t, api.RegistrationEntryIsActive(&common.RegistrationEntry{EntryExpiry: 1000}, now))
+	require.False(t, api.RegistrationEntryIsActive(&common.RegistrationEntry{EntryExpiry: 999}, now))
+}
+
+func TestNonEmptyCredentialProfile(t *testing.T) {
+	profile := &common.CredentialProfile{SubjectOrganization: []string{"ACME"}}
+	require.Equal(t, profile, api.NonEmptyCredentialProfile(profile))
+	require.Nil(t, api.NonEmptyCredentialProfile(&common.CredentialProfile{}))
+	require.Nil(t, api.NonEmptyCredentialProfile(nil))
+}
+
+func TestReadOnlyEntryCredentialProfile(t *testing.T) {
+	profile := &common.CredentialProfile{SubjectOrganization: []string{"ACME"}}
+	entry := &types.Entry{Id: "id"}
+
+	readOnly := api.NewReadOnlyEntryWithCredentialProfile(entry, profile)
+	require.Equal(t, profile, readOnly.GetCredentialProfile())
+
+	readOnly = api.NewReadOnlyEntry(entry)
+	require.Nil(t, readOnly.GetCredentialProfile())
+}
+
 func TestProtoToRegistrationEntryWithMask(t *testing.T) {
 	td := spiffeid.RequireTrustDomainFromString("example.org")
 	expiresAt := time.Now().Unix()
@@ -635,6 +695,28 @@ func TestProtoToRegistrationEntry(t *testing.T) {
 			},
 			err: "selector list is empty",
 		},
+		{
+			name: "only negated selectors",
+			entry: &types.Entry{
+				ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors: []*types.Selector{
+					{Type: "!unix", Value: "uid:0"},
+				},
+			},
+			err: "at least one selector must not be negated",
+		},
+		{
+			name: "too broad selector pattern",
+			entry: &types.Entry{
+				ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors: []*types.Selector{
+					{Type: "~unix", Value: "path:*"},
+				},
+			},
+			err: `pattern selector "~unix:path:*" is too broad`,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			entry, err := api.ProtoToRegistrationEntry(context.Background(), td, tt.entry)
@@ -0,0 +1,112 @@
+package entryhistory
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	commonapi "github.com/spiffe/spire/pkg/common/api"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/server/entryhistory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// defaultPageSize is the page size used when the request does not set one.
+	defaultPageSize = 100
+
+	// maxPageSize is the largest page size a request can set.
+	maxPageSize = 1000
+)
+
+// RegisterService registers the entry history service on the gRPC server.
+func RegisterService(s grpc.ServiceRegistrar, service *Service) {
+	entryhistoryv1.RegisterEntryHistoryServer(s, service)
+}
+
+// Config is the service configuration
+type Config struct {
+	DataStore datastore.DataStore
+}
+
+// New creates a new entry history service
+func New(config Config) *Service {
+	return &Service{
+		ds: config.DataStore,
+	}
+}
+
+// Service implements the v1 entry history service
+type Service struct {
+	entryhistoryv1.UnsafeEntryHistoryServer
+
+	ds datastore.DataStore
+}
+
+// ListEntryHistory lists the recorded changes of a registration entry.
+func (s *Service) ListEntryHistory(ctx context.Context, req *entryhistoryv1.ListEntryHistoryRequest) (*entryhistoryv1.ListEntryHistoryResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	if req.EntryId == "" {
+		return nil, commonapi.MakeErr(log, codes.InvalidArgument, "missing entry ID", nil)
+	}
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{telemetry.RegistrationID: req.EntryId})
+	log = log.WithField(telemetry.RegistrationID, req.EntryId)
+
+	pageSize := req.PageSize
+	switch {
+	case pageSize < 0:
+		return nil, commonapi.MakeErr(log, codes.InvalidArgument, "page size cannot be negative", nil)
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	listReq := &datastore.ListRegistrationEntryChangesRequest{
+		ByEntryID: req.EntryId,
+		Pagination: &datastore.Pagination{
+			PageSize: pageSize,
+			Token:    req.PageToken,
+		},
+	}
+
+	dsResp, err := s.ds.ListRegistrationEntryChanges(ctx, listReq)
+	if err != nil {
+		return nil, commonapi.MakeErr(log, codes.Internal, "failed to list entry changes", err)
+	}
+
+	resp := &entryhistoryv1.ListEntryHistoryResponse{}
+	if dsResp.Pagination != nil {
+		resp.NextPageToken = dsResp.Pagination.Token
+	}
+	for _, change := range dsResp.Changes {
+		resp.Changes = append(resp.Changes, &entryhistoryv1.EntryChange{
+			Operation: operationToProto(change.Operation),
+			Before:    change.Before,
+			After:     change.After,
+			CallerId:  change.CallerID,
+			ChangedAt: change.CreatedAt.Unix(),
+		})
+	}
+	rpccontext.AuditRPC(ctx)
+
+	return resp, nil
+}
+
+func operationToProto(operation datastore.RegistrationEntryOperation) entryhistoryv1.EntryChange_Operation {
+	switch operation {
+	case datastore.RegistrationEntryCreated:
+		return entryhistoryv1.EntryChange_CREATE
+	case datastore.RegistrationEntryUpdated:
+		return entryhistoryv1.EntryChange_UPDATE
+	case datastore.RegistrationEntryDeleted:
+		return entryhistoryv1.EntryChange_DELETE
+	case datastore.RegistrationEntryPruned:
+		return entryhistoryv1.EntryChange_PRUNE
+	default:
+		return entryhistoryv1.EntryChange_UNKNOWN
+	}
+}
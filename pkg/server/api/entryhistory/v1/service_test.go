@@ -0,0 +1,169 @@
+package entryhistory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	entryhistory "github.com/spiffe/spire/pkg/server/api/entryhistory/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/server/entryhistory"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/grpctest"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var ctx = context.Background()
+
+func TestListEntryHistory(t *testing.T) {
+	test := setupServiceTest(t)
+
+	entry, err := test.ds.CreateRegistrationEntry(datastore.WithCallerID(ctx, "spiffe://example.org/admin"), &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+	_, err = test.ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/other",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+	_, err = test.ds.DeleteRegistrationEntry(ctx, entry.EntryId)
+	require.NoError(t, err)
+
+	resp, err := test.client.ListEntryHistory(ctx, &entryhistoryv1.ListEntryHistoryRequest{
+		EntryId:  entry.EntryId,
+		PageSize: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Changes, 1)
+	require.NotEmpty(t, resp.NextPageToken)
+	require.NotZero(t, resp.Changes[0].ChangedAt)
+	resp.Changes[0].ChangedAt = 0
+	spiretest.RequireProtoEqual(t, &entryhistoryv1.EntryChange{
+		Operation: entryhistoryv1.EntryChange_CREATE,
+		After:     entry,
+		CallerId:  "spiffe://example.org/admin",
+	}, resp.Changes[0])
+
+	resp, err = test.client.ListEntryHistory(ctx, &entryhistoryv1.ListEntryHistoryRequest{
+		EntryId:   entry.EntryId,
+		PageSize:  1,
+		PageToken: resp.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Changes, 1)
+	require.Equal(t, entryhistoryv1.EntryChange_DELETE, resp.Changes[0].Operation)
+	spiretest.RequireProtoEqual(t, entry, resp.Changes[0].Before)
+	require.Nil(t, resp.Changes[0].After)
+	require.Empty(t, resp.Changes[0].CallerId)
+
+	spiretest.AssertLastLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "API accessed",
+			Data: logrus.Fields{
+				telemetry.Status:         "success",
+				telemetry.Type:           "audit",
+				telemetry.RegistrationID: entry.EntryId,
+			},
+		},
+	})
+}
+
+func TestListEntryHistoryDefaultPageSize(t *testing.T) {
+	test := setupServiceTest(t)
+
+	entry, err := test.ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+	for i := range 100 {
+		entry.X509SvidTtl = int32(i + 1)
+		_, err := test.ds.UpdateRegistrationEntry(ctx, entry, &common.RegistrationEntryMask{X509SvidTtl: true})
+		require.NoError(t, err)
+	}
+
+	resp, err := test.client.ListEntryHistory(ctx, &entryhistoryv1.ListEntryHistoryRequest{EntryId: entry.EntryId})
+	require.NoError(t, err)
+	require.Len(t, resp.Changes, 100)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	resp, err = test.client.ListEntryHistory(ctx, &entryhistoryv1.ListEntryHistoryRequest{
+		EntryId:   entry.EntryId,
+		PageToken: resp.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Changes, 1)
+	require.Equal(t, entryhistoryv1.EntryChange_UPDATE, resp.Changes[0].Operation)
+}
+
+func TestListEntryHistoryNegativePageSize(t *testing.T) {
+	test := setupServiceTest(t)
+
+	_, err := test.client.ListEntryHistory(ctx, &entryhistoryv1.ListEntryHistoryRequest{
+		EntryId:  "foo",
+		PageSize: -1,
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "page size cannot be negative")
+}
+
+func TestListEntryHistoryMissingEntryID(t *testing.T) {
+	test := setupServiceTest(t)
+
+	_, err := test.client.ListEntryHistory(ctx, &entryhistoryv1.ListEntryHistoryRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "missing entry ID")
+}
+
+func TestListEntryHistoryFailure(t *testing.T) {
+	test := setupServiceTest(t)
+	test.ds.SetNextError(errors.New("oh no"))
+
+	_, err := test.client.ListEntryHistory(ctx, &entryhistoryv1.ListEntryHistoryRequest{EntryId: "foo"})
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "failed to list entry changes: oh no")
+}
+
+type serviceTest struct {
+	client  entryhistoryv1.EntryHistoryClient
+	ds      *fakedatastore.DataStore
+	logHook *test.Hook
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	ds := fakedatastore.New(t)
+	service := entryhistory.New(entryhistory.Config{
+		DataStore: ds,
+	})
+
+	log, logHook := test.NewNullLogger()
+	registerFn := func(s grpc.ServiceRegistrar) {
+		entryhistory.RegisterService(s, service)
+	}
+	overrideContext := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+	server := grpctest.StartServer(t, registerFn,
+		grpctest.OverrideContext(overrideContext),
+		grpctest.Middleware(middleware.WithAuditLog(false)),
+	)
+	conn := server.NewGRPCClient(t)
+
+	return &serviceTest{
+		client:  entryhistoryv1.NewEntryHistoryClient(conn),
+		ds:      ds,
+		logHook: logHook,
+	}
+}
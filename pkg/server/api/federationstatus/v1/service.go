@@ -0,0 +1,101 @@
+package federationstatus
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	commonapi "github.com/spiffe/spire/pkg/common/api"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/bundle/client"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// StatusSource provides the refresh status of the federation relationships.
+type StatusSource interface {
+	FederationStatuses() []client.FederationStatus
+	FederationStatusFor(td spiffeid.TrustDomain) (client.FederationStatus, bool)
+}
+
+// RegisterService registers the federation status service on the gRPC server.
+func RegisterService(s grpc.ServiceRegistrar, service *Service) {
+	federationstatusv1.RegisterFederationStatusServer(s, service)
+}
+
+// Config is the service configuration
+type Config struct {
+	StatusSource StatusSource
+}
+
+// New creates a new federation status service
+func New(config Config) *Service {
+	return &Service{
+		source: config.StatusSource,
+	}
+}
+
+// Service implements the v1 federation status service
+type Service struct {
+	federationstatusv1.UnsafeFederationStatusServer
+
+	source StatusSource
+}
+
+// ListFederationRelationshipStatuses lists the refresh status of the
+// federation relationships.
+func (s *Service) ListFederationRelationshipStatuses(ctx context.Context, _ *federationstatusv1.ListFederationRelationshipStatusesRequest) (*federationstatusv1.ListFederationRelationshipStatusesResponse, error) {
+	resp := &federationstatusv1.ListFederationRelationshipStatusesResponse{}
+	for _, status := range s.source.FederationStatuses() {
+		resp.Statuses = append(resp.Statuses, statusToProto(status))
+	}
+	rpccontext.AuditRPC(ctx)
+
+	return resp, nil
+}
+
+// GetFederationRelationshipStatus gets the refresh status of a federation
+// relationship.
+func (s *Service) GetFederationRelationshipStatus(ctx context.Context, req *federationstatusv1.GetFederationRelationshipStatusRequest) (*federationstatusv1.FederationRelationshipStatus, error) {
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{telemetry.TrustDomainID: req.TrustDomain})
+
+	log := rpccontext.Logger(ctx)
+
+	td, err := spiffeid.TrustDomainFromString(req.TrustDomain)
+	if err != nil {
+		return nil, commonapi.MakeErr(log, codes.InvalidArgument, "failed to parse trust domain", err)
+	}
+
+	status, ok := s.source.FederationStatusFor(td)
+	if !ok {
+		return nil, commonapi.MakeErr(log, codes.NotFound, "federation relationship does not exist", nil)
+	}
+	rpccontext.AuditRPC(ctx)
+
+	return statusToProto(status), nil
+}
+
+func statusToProto(status client.FederationStatus) *federationstatusv1.FederationRelationshipStatus {
+	resp := &federationstatusv1.FederationRelationshipStatus{
+		TrustDomain:          status.TrustDomain.Name(),
+		BundleSequenceNumber: status.BundleSequenceNumber,
+	}
+	if status.LastError != nil {
+		resp.LastError = status.LastError.Error()
+	}
+	if !status.LastRefresh.IsZero() {
+		resp.LastRefresh = status.LastRefresh.Unix()
+	}
+	if !status.LastRefreshAttempt.IsZero() {
+		resp.LastRefreshAttempt = status.LastRefreshAttempt.Unix()
+	}
+	if !status.NextRefresh.IsZero() {
+		resp.NextRefresh = status.NextRefresh.Unix()
+	}
+	if !status.EndpointCertificateExpiresAt.IsZero() {
+		resp.EndpointCertificateExpiresAt = status.EndpointCertificateExpiresAt.Unix()
+	}
+	return resp
+}
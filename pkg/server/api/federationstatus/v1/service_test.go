@@ -0,0 +1,171 @@
+package federationstatus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	federationstatus "github.com/spiffe/spire/pkg/server/api/federationstatus/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/bundle/client"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
+	"github.com/spiffe/spire/test/grpctest"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ctx = context.Background()
+
+	now = time.Unix(1700000000, 0)
+	td1 = spiffeid.RequireTrustDomainFromString("domain1.test")
+	td2 = spiffeid.RequireTrustDomainFromString("domain2.test")
+
+	statuses = []client.FederationStatus{
+		{
+			TrustDomain:                  td1,
+			LastRefresh:                  now,
+			LastRefreshAttempt:           now,
+			NextRefresh:                  now.Add(time.Minute),
+			BundleSequenceNumber:         42,
+			EndpointCertificateExpiresAt: now.Add(time.Hour),
+		},
+		{
+			TrustDomain:        td2,
+			LastRefreshAttempt: now,
+			NextRefresh:        now.Add(time.Second),
+			LastError:          errors.New("oh no"),
+		},
+	}
+
+	status1 = &federationstatusv1.FederationRelationshipStatus{
+		TrustDomain:                  "domain1.test",
+		LastRefresh:                  now.Unix(),
+		LastRefreshAttempt:           now.Unix(),
+		NextRefresh:                  now.Add(time.Minute).Unix(),
+		BundleSequenceNumber:         42,
+		EndpointCertificateExpiresAt: now.Add(time.Hour).Unix(),
+	}
+	status2 = &federationstatusv1.FederationRelationshipStatus{
+		TrustDomain:        "domain2.test",
+		LastRefreshAttempt: now.Unix(),
+		NextRefresh:        now.Add(time.Second).Unix(),
+		LastError:          "oh no",
+	}
+)
+
+func TestListFederationRelationshipStatuses(t *testing.T) {
+	test := setupServiceTest(t)
+
+	resp, err := test.client.ListFederationRelationshipStatuses(ctx, &federationstatusv1.ListFederationRelationshipStatusesRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &federationstatusv1.ListFederationRelationshipStatusesResponse{
+		Statuses: []*federationstatusv1.FederationRelationshipStatus{status1, status2},
+	}, resp)
+}
+
+func TestGetFederationRelationshipStatus(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		trustDomain string
+		expectCode  codes.Code
+		expectMsg   string
+		expectResp  *federationstatusv1.FederationRelationshipStatus
+	}{
+		{
+			name:        "success",
+			trustDomain: "domain2.test",
+			expectResp:  status2,
+		},
+		{
+			name:        "invalid trust domain",
+			trustDomain: "not a trust domain",
+			expectCode:  codes.InvalidArgument,
+			expectMsg:   "failed to parse trust domain: trust domain characters are limited to lowercase letters, numbers, dots, dashes, and underscores",
+		},
+		{
+			name:        "not found",
+			trustDomain: "unknown.test",
+			expectCode:  codes.NotFound,
+			expectMsg:   "federation relationship does not exist",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+
+			resp, err := test.client.GetFederationRelationshipStatus(ctx, &federationstatusv1.GetFederationRelationshipStatusRequest{
+				TrustDomain: tt.trustDomain,
+			})
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, tt.expectResp, resp)
+
+			spiretest.AssertLastLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API accessed",
+					Data: logrus.Fields{
+						telemetry.Status:        "success",
+						telemetry.Type:          "audit",
+						telemetry.TrustDomainID: tt.trustDomain,
+					},
+				},
+			})
+		})
+	}
+}
+
+type fakeStatusSource struct{}
+
+func (fakeStatusSource) FederationStatuses() []client.FederationStatus {
+	return statuses
+}
+
+func (fakeStatusSource) FederationStatusFor(td spiffeid.TrustDomain) (client.FederationStatus, bool) {
+	for _, status := range statuses {
+		if status.TrustDomain == td {
+			return status, true
+		}
+	}
+	return client.FederationStatus{}, false
+}
+
+type serviceTest struct {
+	client  federationstatusv1.FederationStatusClient
+	logHook *test.Hook
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	service := federationstatus.New(federationstatus.Config{
+		StatusSource: fakeStatusSource{},
+	})
+
+	log, logHook := test.NewNullLogger()
+	registerFn := func(s grpc.ServiceRegistrar) {
+		federationstatus.RegisterService(s, service)
+	}
+	overrideContext := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+	server := grpctest.StartServer(t, registerFn,
+		grpctest.OverrideContext(overrideContext),
+		grpctest.Middleware(middleware.WithAuditLog(false)),
+	)
+	conn := server.NewGRPCClient(t)
+
+	return &serviceTest{
+		client:  federationstatusv1.NewFederationStatusClient(conn),
+		logHook: logHook,
+	}
+}
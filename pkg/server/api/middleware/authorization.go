@@ -11,16 +11,18 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
+	"github.com/spiffe/spire/pkg/server/tenant"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func WithAuthorization(authPolicyEngine *authpolicy.Engine, entryFetcher EntryFetcher, agentAuthorizer AgentAuthorizer, adminIDs []spiffeid.ID) middleware.Middleware {
+func WithAuthorization(authPolicyEngine *authpolicy.Engine, entryFetcher EntryFetcher, agentAuthorizer AgentAuthorizer, adminIDs []spiffeid.ID, tenants *tenant.Set) middleware.Middleware {
 	return &authorizationMiddleware{
 		authPolicyEngine: authPolicyEngine,
 		entryFetcher:     entryFetcher,
 		agentAuthorizer:  agentAuthorizer,
 		adminIDs:         adminIDSet(adminIDs),
+		tenants:          tenants,
 	}
 }
 
@@ -29,6 +31,7 @@ type authorizationMiddleware struct {
 	entryFetcher     EntryFetcher
 	agentAuthorizer  AgentAuthorizer
 	adminIDs         map[spiffeid.ID]struct{}
+	tenants          *tenant.Set
 }
 
 func (m *authorizationMiddleware) Preprocess(ctx context.Context, methodName string, req any) (context.Context, error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
+	"github.com/spiffe/spire/pkg/server/tenant"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -99,6 +100,15 @@ func (m *authorizationMiddleware) reconcileResult(ctx context.Context, res authp
 		}
 	}
 
+	// Check statically configured tenant admins. This happens after the admin
+	// checks so that admins are never scoped to a tenant.
+	if res.AllowIfTenantAdmin {
+		if ctx, ok := isTenantAdminViaConfig(ctx, m.tenants); ok {
+			ctx = setAuthorizationLogFields(ctx, "tenant_admin", "config")
+			return ctx, true, nil
+		}
+	}
+
 	if res.AllowIfAgent && !rpccontext.CallerIsLocal(ctx) {
 		if ctx, err := isAgent(ctx, m.agentAuthorizer); err != nil {
 			return ctx, false, err
@@ -119,6 +129,16 @@ func isAdminViaConfig(ctx context.Context, adminIDs map[spiffeid.ID]struct{}) (c
 	return ctx, false
 }
 
+func isTenantAdminViaConfig(ctx context.Context, tenants *tenant.Set) (context.Context, bool) {
+	if callerID, ok := rpccontext.CallerID(ctx); ok {
+		if t, ok := tenants.ForAdmin(callerID); ok {
+			ctx = rpccontext.WithLogger(ctx, rpccontext.Logger(ctx).WithField(telemetry.Tenant, t.Name()))
+			return rpccontext.WithCallerTenant(ctx, t), true
+		}
+	}
+	return ctx, false
+}
+
 func isAdminViaEntries(ctx context.Context, entries []*types.Entry) (context.Context, bool) {
 	for _, entry := range entries {
 		if entry.Admin {
@@ -17,6 +17,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
+	"github.com/spiffe/spire/pkg/server/tenant"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -109,10 +110,12 @@ func TestWithAuthorizationPreprocess(t *testing.T) {
 		agentAuthorizer middleware.AgentAuthorizer
 		entryFetcher    middleware.EntryFetcherFunc
 		adminIDs        []spiffeid.ID
+		tenants         *tenant.Set
 		authorizerErr   error
 		expectCode      codes.Code
 		expectMsg       string
 		expectDetails   []*types.PermissionDeniedDetails
+		expectTenant    string
 	}{
 		{
 			name:       "basic allow test",
@@ -181,6 +184,51 @@ func TestWithAuthorizationPreprocess(t *testing.T) {
 			expectCode: codes.PermissionDenied,
 			expectMsg:  fmt.Sprintf("authorization denied for method %s", fakeFullMethod),
 		},
+		{
+			name:       "allow_if_tenant_admin tenant admin caller test",
+			fullMethod: fakeFullMethod,
+			peer:       staticAdminPeer,
+			tenants:    tenants,
+			rego: simpleRego(map[string]bool{
+				"allow_if_tenant_admin": true,
+			}),
+			expectCode:   codes.OK,
+			expectTenant: "team-a",
+		},
+		{
+			name:       "allow_if_tenant_admin admin caller is not scoped test",
+			fullMethod: fakeFullMethod,
+			peer:       staticAdminPeer,
+			adminIDs:   []spiffeid.ID{staticAdminID},
+			tenants:    tenants,
+			rego: simpleRego(map[string]bool{
+				"allow_if_admin":        true,
+				"allow_if_tenant_admin": true,
+			}),
+			expectCode: codes.OK,
+		},
+		{
+			name:       "allow_if_tenant_admin non-tenant admin caller test",
+			fullMethod: fakeFullMethod,
+			peer:       mtlsPeer,
+			tenants:    tenants,
+			rego: simpleRego(map[string]bool{
+				"allow_if_tenant_admin": true,
+			}),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  fmt.Sprintf("authorization denied for method %s", fakeFullMethod),
+		},
+		{
+			name:       "allow_if_admin tenant admin caller test",
+			fullMethod: fakeFullMethod,
+			peer:       staticAdminPeer,
+			tenants:    tenants,
+			rego: simpleRego(map[string]bool{
+				"allow_if_admin": true,
+			}),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  fmt.Sprintf("authorization denied for method %s", fakeFullMethod),
+		},
 		{
 			name:       "allow_if_downstream downstream caller test",
 			fullMethod: fakeFullMethod,
@@ -331,7 +379,7 @@ func TestWithAuthorizationPreprocess(t *testing.T) {
 				tt.agentAuthorizer = noAgentAuthorizer
 			}
 
-			m := middleware.WithAuthorization(policyEngine, entryFetcherForTest(tt.entryFetcher), tt.agentAuthorizer, tt.adminIDs)
+			m := middleware.WithAuthorization(policyEngine, entryFetcherForTest(tt.entryFetcher), tt.agentAuthorizer, tt.adminIDs, tt.tenants)
 
 			// Set up the incoming context with a logger and optionally a peer.
 			log, _ := test.NewNullLogger()
@@ -367,6 +415,14 @@ func TestWithAuthorizationPreprocess(t *testing.T) {
 				return
 			}
 			require.NotNil(t, ctxOut, "returned context should have been non-nil on success")
+
+			callerTenant, ok := rpccontext.CallerTenant(ctxOut)
+			if tt.expectTenant == "" {
+				require.False(t, ok, "caller should not be scoped to a tenant")
+				return
+			}
+			require.True(t, ok, "caller should be scoped to a tenant")
+			require.Equal(t, tt.expectTenant, callerTenant.Name())
 		})
 	}
 }
@@ -376,7 +432,7 @@ func TestWithAuthorizationPostprocess(t *testing.T) {
 	ctx := context.Background()
 	policyEngine, err := authpolicy.DefaultAuthPolicy(ctx)
 	require.NoError(t, err, "failed to initialize policy engine")
-	m := middleware.WithAuthorization(policyEngine, entryFetcher, yesAgentAuthorizer, nil)
+	m := middleware.WithAuthorization(policyEngine, entryFetcher, yesAgentAuthorizer, nil, nil)
 
 	m.Postprocess(context.Background(), "", false, nil)
 	m.Postprocess(context.Background(), "", true, errors.New("ohno"))
@@ -392,6 +448,10 @@ var (
 
 	staticAdminID = spiffeid.RequireFromPath(td, "/static-admin")
 
+	tenants, _ = tenant.NewSet(td, []tenant.Config{
+		{Name: "team-a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{staticAdminID}},
+	})
+
 	nonAdminID = spiffeid.RequireFromPath(td, "/non-admin")
 
 	nonAdminEntries = []*types.Entry{
@@ -471,10 +531,11 @@ func simpleRego(m map[string]bool) string {
       "allow_if_admin": %t,
       "allow_if_local": %t,
       "allow_if_downstream": %t,
-      "allow_if_agent": %t
+      "allow_if_agent": %t,
+      "allow_if_tenant_admin": %t
     }`
 
-	return fmt.Sprintf(regoTemplate, m["allow"], m["allow_if_admin"], m["allow_if_local"], m["allow_if_downstream"], m["allow_if_agent"])
+	return fmt.Sprintf(regoTemplate, m["allow"], m["allow_if_admin"], m["allow_if_local"], m["allow_if_downstream"], m["allow_if_agent"], m["allow_if_tenant_admin"])
 }
 
 func condCheckRego(cond string) string {
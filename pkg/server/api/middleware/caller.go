@@ -5,6 +5,7 @@ import (
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
@@ -66,5 +67,8 @@ func tcpCallerContextFromPeer(ctx context.Context, p *peer.Peer) (context.Contex
 
 	ctx = rpccontext.WithCallerID(ctx, id)
 	ctx = rpccontext.WithCallerX509SVID(ctx, x509SVID)
+	// Attribute the registration entry changes made by the RPC to the caller
+	// in the entry history.
+	ctx = datastore.WithCallerID(ctx, id.String())
 	return ctx, nil
 }
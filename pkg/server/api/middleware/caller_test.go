@@ -10,6 +10,7 @@ import (
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
@@ -186,6 +187,12 @@ func TestCallerContextFromContext(t *testing.T) {
 			assert.Equal(t, !tt.expectCallerID.IsZero(), ok)
 			assert.Equal(t, tt.expectCallerID, callerID)
 
+			var expectDataStoreCallerID string
+			if !tt.expectCallerID.IsZero() {
+				expectDataStoreCallerID = tt.expectCallerID.String()
+			}
+			assert.Equal(t, expectDataStoreCallerID, datastore.CallerIDFromContext(ctxOut))
+
 			callerX509SVID, ok := rpccontext.CallerX509SVID(ctxOut)
 			assert.Equal(t, tt.expectCallerX509SVID != nil, ok)
 			assert.Equal(t, tt.expectCallerX509SVID, callerX509SVID)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/tenant"
 )
 
 type callerAddrKey struct{}
@@ -16,6 +17,7 @@ type callerDownstreamEntriesKey struct{}
 type callerAdminTagKey struct{}
 type callerLocalTagKey struct{}
 type callerAgentTagKey struct{}
+type callerTenantKey struct{}
 
 // WithCallerAddr returns a context with the given address.
 func WithCallerAddr(ctx context.Context, addr net.Addr) context.Context {
@@ -99,3 +101,16 @@ func CallerIsAgent(ctx context.Context) bool {
 	_, ok := ctx.Value(callerAgentTagKey{}).(struct{})
 	return ok
 }
+
+// WithCallerTenant returns a context where the caller is tagged as an admin
+// of the given tenant.
+func WithCallerTenant(ctx context.Context, t *tenant.Tenant) context.Context {
+	return context.WithValue(ctx, callerTenantKey{}, t)
+}
+
+// CallerTenant returns the tenant administered by the caller. If the caller
+// is not authorized as a tenant admin, it returns false.
+func CallerTenant(ctx context.Context) (*tenant.Tenant, bool) {
+	t, ok := ctx.Value(callerTenantKey{}).(*tenant.Tenant)
+	return t, ok
+}
@@ -3,12 +3,18 @@ package api
 import (
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/proto/spire/common"
 )
 
+// minPatternLiteralLength is the minimum number of literal characters of the
+// value that a pattern selector must start with, after the selector key.
+const minPatternLiteralLength = 3
+
 // SelectorsFromProto converts a slice of types.Selector to
 // a slice of common.Selector
 func SelectorsFromProto(proto []*types.Selector) ([]*common.Selector, error) {
@@ -51,3 +57,84 @@ func SelectorFieldFromProto(proto []*types.Selector) string {
 
 	return strings.Join(selectors, ",")
 }
+
+// ValidateSelectorExpressions validates the selectors of a registration entry.
+// Each expression must be well formed and, since entries are looked up by the
+// selectors workloads have, at least one selector must not be a negation.
+func ValidateSelectorExpressions(selectors []*common.Selector) error {
+	positive := false
+	for _, cs := range selectors {
+		s := *selector.New(cs)
+		if err := validateSelectorExpression(s, s); err != nil {
+			return err
+		}
+		if !strings.HasPrefix(s.Type, selector.NegationPrefix) {
+			positive = true
+		}
+	}
+	if len(selectors) > 0 && !positive {
+		return errors.New("at least one selector must not be negated")
+	}
+	return nil
+}
+
+func validateSelectorExpression(s, full selector.Selector) error {
+	switch {
+	case strings.HasPrefix(s.Type, selector.NegationPrefix):
+		if s.Type == selector.NegationPrefix {
+			return fmt.Errorf("negated selector %q is missing a type", full.Type+selector.Delimiter+full.Value)
+		}
+		return validateSelectorExpression(selector.Selector{Type: s.Type[len(selector.NegationPrefix):], Value: s.Value}, full)
+	case strings.HasPrefix(s.Type, selector.AnyOfPrefix):
+		if s.Type == selector.AnyOfPrefix {
+			return fmt.Errorf("any-of selector %q is missing a type", full.Type+selector.Delimiter+full.Value)
+		}
+		for _, value := range selector.SplitAnyOf(s.Value) {
+			if value == "" {
+				return fmt.Errorf("any-of selector %q has an empty value", full.Type+selector.Delimiter+full.Value)
+			}
+			if err := validateSelectorExpression(selector.Selector{Type: s.Type[len(selector.AnyOfPrefix):], Value: value}, full); err != nil {
+				return err
+			}
+		}
+		return nil
+	case strings.HasPrefix(s.Type, selector.PatternPrefix):
+		if s.Type == selector.PatternPrefix {
+			return fmt.Errorf("pattern selector %q is missing a type", full.Type+selector.Delimiter+full.Value)
+		}
+		return validateSelectorPattern(s.Value, full)
+	case strings.HasPrefix(s.Type, selector.UnknownPrefix):
+		return fmt.Errorf("selector %q uses the reserved type prefix %q", full.Type+selector.Delimiter+full.Value, selector.UnknownPrefix)
+	default:
+		return nil
+	}
+}
+
+// validateSelectorPattern rejects malformed patterns and patterns broad
+// enough to match unrelated workloads. The literal prefix of a pattern, up to
+// the first wildcard, must hold the selector key and either a full path
+// segment for path values, as in "path:/opt/app/*", or at least
+// minPatternLiteralLength characters otherwise, as in "pod-name:web-*".
+func validateSelectorPattern(pattern string, full selector.Selector) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("pattern selector %q is malformed: %w", full.Type+selector.Delimiter+full.Value, err)
+	}
+	wildcard := strings.IndexAny(pattern, `*?[\`)
+	if wildcard < 0 {
+		return fmt.Errorf("pattern selector %q has no wildcard; use a plain selector instead", full.Type+selector.Delimiter+full.Value)
+	}
+	key, literal, ok := strings.Cut(pattern[:wildcard], selector.Delimiter)
+	if !ok || key == "" {
+		return fmt.Errorf("pattern selector %q is too broad; it must start with a selector key, e.g. \"pod-name:web-*\"", full.Type+selector.Delimiter+full.Value)
+	}
+	if strings.HasPrefix(literal, "/") {
+		if dir, _ := path.Split(literal); strings.Trim(dir, "/") == "" {
+			return fmt.Errorf("pattern selector %q is too broad; a path pattern must start with at least one full path segment, e.g. \"path:/opt/app/*\"", full.Type+selector.Delimiter+full.Value)
+		}
+		return nil
+	}
+	if len(literal) < minPatternLiteralLength {
+		return fmt.Errorf("pattern selector %q is too broad; it must start with at least %d literal characters of the value, e.g. \"pod-name:web-*\"", full.Type+selector.Delimiter+full.Value, minPatternLiteralLength)
+	}
+	return nil
+}
@@ -88,3 +88,151 @@ func TestSelectorsFromProto(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSelectorExpressions(t *testing.T) {
+	tests := []struct {
+		name      string
+		selectors []*common.Selector
+		err       string
+	}{
+		{
+			name: "plain selectors",
+			selectors: []*common.Selector{
+				{Type: "k8s", Value: "ns:default"},
+			},
+		},
+		{
+			name: "expressions",
+			selectors: []*common.Selector{
+				{Type: "|k8s", Value: "ns:default|ns:prod"},
+				{Type: "!k8s", Value: "sa:default"},
+			},
+		},
+		{
+			name: "only negations",
+			selectors: []*common.Selector{
+				{Type: "!k8s", Value: "ns:default"},
+			},
+			err: "at least one selector must not be negated",
+		},
+		{
+			name: "negation without type",
+			selectors: []*common.Selector{
+				{Type: "k8s", Value: "ns:default"},
+				{Type: "!", Value: "ns:default"},
+			},
+			err: `negated selector "!:ns:default" is missing a type`,
+		},
+		{
+			name: "any-of without type",
+			selectors: []*common.Selector{
+				{Type: "|", Value: "ns:default"},
+			},
+			err: `any-of selector "|:ns:default" is missing a type`,
+		},
+		{
+			name: "any-of with empty alternative",
+			selectors: []*common.Selector{
+				{Type: "|k8s", Value: "ns:default||ns:prod"},
+			},
+			err: `any-of selector "|k8s:ns:default||ns:prod" has an empty value`,
+		},
+		{
+			name: "patterns",
+			selectors: []*common.Selector{
+				{Type: "~k8s", Value: "pod-name:web-*"},
+				{Type: "!~k8s", Value: "ns:kube-*"},
+				{Type: "|~unix", Value: "path:/opt/a/*|path:/opt/b/*"},
+			},
+		},
+		{
+			name: "pattern without type",
+			selectors: []*common.Selector{
+				{Type: "~", Value: "pod-name:web-*"},
+			},
+			err: `pattern selector "~:pod-name:web-*" is missing a type`,
+		},
+		{
+			name: "malformed pattern",
+			selectors: []*common.Selector{
+				{Type: "~k8s", Value: "pod-name:web-["},
+			},
+			err: `pattern selector "~k8s:pod-name:web-[" is malformed: syntax error in pattern`,
+		},
+		{
+			name: "pattern without wildcard",
+			selectors: []*common.Selector{
+				{Type: "~k8s", Value: "pod-name:web"},
+			},
+			err: `pattern selector "~k8s:pod-name:web" has no wildcard; use a plain selector instead`,
+		},
+		{
+			name: "pattern matching any value of a key",
+			selectors: []*common.Selector{
+				{Type: "~k8s", Value: "pod-name:*"},
+			},
+			err: `pattern selector "~k8s:pod-name:*" is too broad; it must start with at least 3 literal characters of the value, e.g. "pod-name:web-*"`,
+		},
+		{
+			name: "pattern with a short literal prefix",
+			selectors: []*common.Selector{
+				{Type: "~k8s", Value: "pod-name:a*"},
+			},
+			err: `pattern selector "~k8s:pod-name:a*" is too broad; it must start with at least 3 literal characters of the value, e.g. "pod-name:web-*"`,
+		},
+		{
+			name: "pattern without key",
+			selectors: []*common.Selector{
+				{Type: "~k8s", Value: "web-*"},
+			},
+			err: `pattern selector "~k8s:web-*" is too broad; it must start with a selector key, e.g. "pod-name:web-*"`,
+		},
+		{
+			name: "path patterns",
+			selectors: []*common.Selector{
+				{Type: "~unix", Value: "path:/opt/*"},
+				{Type: "~unix", Value: "path:/opt/app/b*"},
+			},
+		},
+		{
+			name: "path pattern matching any root path",
+			selectors: []*common.Selector{
+				{Type: "~unix", Value: "path:/*"},
+			},
+			err: `pattern selector "~unix:path:/*" is too broad; a path pattern must start with at least one full path segment, e.g. "path:/opt/app/*"`,
+		},
+		{
+			name: "path pattern with a partial first segment",
+			selectors: []*common.Selector{
+				{Type: "~unix", Value: "path:/usr*"},
+			},
+			err: `pattern selector "~unix:path:/usr*" is too broad; a path pattern must start with at least one full path segment, e.g. "path:/opt/app/*"`,
+		},
+		{
+			name: "too broad pattern in any-of",
+			selectors: []*common.Selector{
+				{Type: "|~k8s", Value: "ns:prod-*|*"},
+			},
+			err: `pattern selector "|~k8s:ns:prod-*|*" is too broad; it must start with a selector key, e.g. "pod-name:web-*"`,
+		},
+		{
+			name: "reserved unknown type",
+			selectors: []*common.Selector{
+				{Type: "k8s", Value: "ns:default"},
+				{Type: "!?k8s", Value: ""},
+			},
+			err: `selector "!?k8s:" uses the reserved type prefix "?"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := api.ValidateSelectorExpressions(test.selectors)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -25,7 +26,9 @@ import (
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -46,15 +49,20 @@ type Config struct {
 	ServerCA     ca.ServerCA
 	TrustDomain  spiffeid.TrustDomain
 	DataStore    datastore.DataStore
+
+	// CredentialProfiles enables applying the credential profiles of the
+	// registration entries to the X509-SVIDs.
+	CredentialProfiles bool
 }
 
 // New creates a new SVID service
 func New(config Config) *Service {
 	return &Service{
-		ca: config.ServerCA,
-		ef: config.EntryFetcher,
-		td: config.TrustDomain,
-		ds: config.DataStore,
+		ca:                 config.ServerCA,
+		ef:                 config.EntryFetcher,
+		td:                 config.TrustDomain,
+		ds:                 config.DataStore,
+		credentialProfiles: config.CredentialProfiles,
 	}
 }
 
@@ -67,6 +75,7 @@ type Service struct {
 	td                           spiffeid.TrustDomain
 	ds                           datastore.DataStore
 	useLegacyDownstreamX509CATTL bool
+	credentialProfiles           bool
 }
 
 func (s *Service) MintX509SVID(ctx context.Context, req *svidv1.MintX509SVIDRequest) (*svidv1.MintX509SVIDResponse, error) {
@@ -161,7 +170,7 @@ func (s *Service) MintJWTSVID(ctx context.Context, req *svidv1.MintJWTSVIDReques
 	}
 
 	rpccontext.AddRPCAuditFields(ctx, s.fieldsFromJWTSvidParams(ctx, req.Id, req.Audience, req.Ttl))
-	jwtsvid, err := s.mintJWTSVID(ctx, req.Id, req.Audience, req.Ttl, false)
+	jwtsvid, err := s.mintJWTSVID(ctx, req.Id, req.Audience, req.Ttl, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -243,6 +252,28 @@ func (s *Service) BatchNewX509SVID(ctx context.Context, req *svidv1.BatchNewX509
 	return &svidv1.BatchNewX509SVIDResponse{Results: results}, nil
 }
 
+// claimSource returns the data that claim templates are rendered with for the
+// SVIDs issued for the entry. The node selectors are those of the caller,
+// which is the agent the SVIDs are issued to.
+func (s *Service) claimSource(entry api.ReadOnlyEntry) *credtemplate.ClaimSource {
+	return &credtemplate.ClaimSource{
+		EntryID:   entry.GetId(),
+		Hint:      entry.GetHint(),
+		Selectors: entry.GetSelectors(),
+		NodeSelectors: func(ctx context.Context) ([]*types.Selector, error) {
+			callerID, ok := rpccontext.CallerID(ctx)
+			if !ok {
+				return nil, errors.New("caller ID missing from request context")
+			}
+			selectors, err := s.ds.GetNodeSelectors(ctx, callerID.String(), datastore.TolerateStale)
+			if err != nil {
+				return nil, err
+			}
+			return api.ProtoFromSelectors(selectors), nil
+		},
+	}
+}
+
 func (s *Service) findEntries(ctx context.Context, log logrus.FieldLogger, entries map[string]struct{}) (map[string]api.ReadOnlyEntry, error) {
 	callerID, ok := rpccontext.CallerID(ctx)
 	if !ok {
@@ -302,11 +333,18 @@ func (s *Service) newX509SVID(ctx context.Context, param *svidv1.NewX509SVIDPara
 	}
 	log = log.WithField(telemetry.SPIFFEID, spiffeID.String())
 
+	// The authorized entries carry the credential profile of the entry.
+	var credentialProfile *common.CredentialProfile
+	if s.credentialProfiles {
+		credentialProfile = entry.GetCredentialProfile()
+	}
+
 	x509Svid, err := s.ca.SignWorkloadX509SVID(ctx, ca.WorkloadX509SVIDParams{
-		SPIFFEID:  spiffeID,
-		PublicKey: csr.PublicKey,
-		DNSNames:  entry.GetDnsNames(),
-		TTL:       time.Duration(entry.GetX509SvidTtl()) * time.Second,
+		SPIFFEID:          spiffeID,
+		PublicKey:         csr.PublicKey,
+		DNSNames:          entry.GetDnsNames(),
+		TTL:               time.Duration(entry.GetX509SvidTtl()) * time.Second,
+		CredentialProfile: credentialProfile,
 	})
 	if err != nil {
 		return &svidv1.BatchNewX509SVIDResponse_Result{
@@ -329,7 +367,7 @@ func (s *Service) newX509SVID(ctx context.Context, param *svidv1.NewX509SVIDPara
 	}
 }
 
-func (s *Service) mintJWTSVID(ctx context.Context, protoID *types.SPIFFEID, audience []string, ttl int32, includeJTI bool) (*types.JWTSVID, error) {
+func (s *Service) mintJWTSVID(ctx context.Context, protoID *types.SPIFFEID, audience []string, ttl int32, includeJTI bool, claimSource *credtemplate.ClaimSource) (*types.JWTSVID, error) {
 	log := rpccontext.Logger(ctx)
 
 	id, err := api.TrustDomainWorkloadIDFromProto(ctx, s.td, protoID)
@@ -347,10 +385,11 @@ func (s *Service) mintJWTSVID(ctx context.Context, protoID *types.SPIFFEID, audi
 	}
 
 	token, err := s.ca.SignWorkloadJWTSVID(ctx, ca.WorkloadJWTSVIDParams{
-		SPIFFEID:   id,
-		TTL:        time.Duration(ttl) * time.Second,
-		Audience:   audience,
-		IncludeJTI: includeJTI,
+		SPIFFEID:    id,
+		TTL:         time.Duration(ttl) * time.Second,
+		Audience:    audience,
+		IncludeJTI:  includeJTI,
+		ClaimSource: claimSource,
 	})
 	if err != nil {
 		return nil, commonapi.MakeErr(log, codes.Internal, "failed to sign JWT-SVID", err)
@@ -408,7 +447,7 @@ func (s *Service) NewJWTSVID(ctx context.Context, req *svidv1.NewJWTSVIDRequest)
 	if attrs := entry.GetAdditionalAttributes(); attrs != nil {
 		includeJTI = attrs.GetJwtSvidIncludeJti()
 	}
-	jwtsvid, err := s.mintJWTSVID(ctx, entry.GetSpiffeId(), req.Audience, entry.GetJwtSvidTtl(), includeJTI)
+	jwtsvid, err := s.mintJWTSVID(ctx, entry.GetSpiffeId(), req.Audience, entry.GetJwtSvidTtl(), includeJTI, s.claimSource(entry))
 	if err != nil {
 		return nil, err
 	}
@@ -575,7 +614,8 @@ func (s *Service) newWITSVID(ctx context.Context, param *svidv1.NewWITSVIDParams
 			Key:       publicKey,
 		},
 		// TODO: add WIT specific TTL (https://github.com/spiffe/spire/issues/6535)
-		TTL: time.Duration(entry.GetX509SvidTtl()) * time.Second,
+		TTL:         time.Duration(entry.GetX509SvidTtl()) * time.Second,
+		ClaimSource: s.claimSource(entry),
 	})
 	if err != nil {
 		return &svidv1.BatchNewWITSVIDResponse_Result{
@@ -29,6 +29,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	svid "github.com/spiffe/spire/pkg/server/api/svid/v1"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
@@ -2751,6 +2752,124 @@ func BatchNewWITSVID(t *testing.T) {
 	}
 }
 
+func TestServiceBatchNewX509SVIDWithCredentialProfile(t *testing.T) {
+	test := setupServiceTestWithCAOptions(t, &fakeserverca.Options{
+		CredentialProfilePolicy: credtemplate.CredentialProfilePolicy{
+			AllowedFields: []string{
+				credtemplate.CredentialProfileSubjectOrganization,
+				credtemplate.CredentialProfileExtendedKeyUsages,
+			},
+			AllowedExtendedKeyUsages: []string{"emailProtection"},
+		},
+	})
+	defer test.Cleanup()
+	test.withCallerID = true
+
+	ctx := context.Background()
+
+	// The credential profile is read from the authorized entry, so the entry
+	// does not need to be in the datastore.
+	newX509SVID := func(t *testing.T, profile *common.CredentialProfile) (*x509.Certificate, *types.Status) {
+		entry, err := api.RegistrationEntryToProto(&common.RegistrationEntry{
+			EntryId:   "workload",
+			ParentId:  agentID.String(),
+			SpiffeId:  "spiffe://example.org/workload1",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		})
+		require.NoError(t, err)
+		test.ef.entries = []*types.Entry{entry}
+		test.ef.profiles = map[string]*common.CredentialProfile{entry.Id: profile}
+		test.rateLimiter.count = 1
+
+		resp, err := test.client.BatchNewX509SVID(ctx, &svidv1.BatchNewX509SVIDRequest{
+			Params: []*svidv1.NewX509SVIDParams{
+				{EntryId: entry.Id, Csr: createCSR(t, &x509.CertificateRequest{})},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		if resp.Results[0].Svid == nil {
+			return nil, resp.Results[0].Status
+		}
+
+		certChain, err := x509util.RawCertsToCertificates(resp.Results[0].Svid.CertChain)
+		require.NoError(t, err)
+		return certChain[0], resp.Results[0].Status
+	}
+
+	t.Run("profile applied", func(t *testing.T) {
+		svid, status := newX509SVID(t, &common.CredentialProfile{
+			SubjectOrganization: []string{"ACME"},
+			ExtendedKeyUsages:   []string{"emailProtection"},
+		})
+		spiretest.AssertProtoEqual(t, &types.Status{Code: int32(codes.OK), Message: "OK"}, status)
+		require.Equal(t, []string{"ACME"}, svid.Subject.Organization)
+		require.Contains(t, svid.ExtKeyUsage, x509.ExtKeyUsageEmailProtection)
+	})
+
+	t.Run("no profile", func(t *testing.T) {
+		svid, status := newX509SVID(t, nil)
+		spiretest.AssertProtoEqual(t, &types.Status{Code: int32(codes.OK), Message: "OK"}, status)
+		require.Equal(t, []string{"SPIRE"}, svid.Subject.Organization)
+	})
+
+	t.Run("extended key usage not allowed", func(t *testing.T) {
+		_, status := newX509SVID(t, &common.CredentialProfile{
+			ExtendedKeyUsages: []string{"timeStamping"},
+		})
+		require.Equal(t, int32(codes.Internal), status.Code)
+		require.Contains(t, status.Message, `credential profile extended key usage "timeStamping" is not allowed by the server configuration`)
+	})
+}
+
+func TestServiceNewJWTSVIDWithClaimTemplates(t *testing.T) {
+	var claimTemplates []*credtemplate.ClaimTemplate
+	for _, config := range []credtemplate.ClaimTemplateConfig{
+		{Name: "namespace", Value: "{{k8s:ns}}"},
+		{Name: "cluster", Value: "{{ .NodeSelector `k8s_psat:cluster` }}"},
+		{Name: "hint", Value: "{{ .Hint }}"},
+	} {
+		claimTemplate, err := credtemplate.NewClaimTemplate(config)
+		require.NoError(t, err)
+		claimTemplates = append(claimTemplates, claimTemplate)
+	}
+
+	test := setupServiceTestWithCAOptions(t, &fakeserverca.Options{
+		ClaimTemplates: claimTemplates,
+	})
+	defer test.Cleanup()
+	test.withCallerID = true
+	test.rateLimiter.count = 1
+
+	ctx := context.Background()
+	require.NoError(t, test.ds.SetNodeSelectors(ctx, agentID.String(), []*common.Selector{
+		{Type: "k8s_psat", Value: "cluster:east"},
+	}))
+
+	entry := &types.Entry{
+		Id:        "workload",
+		ParentId:  api.ProtoFromID(agentID),
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload1"},
+		Selectors: []*types.Selector{{Type: "k8s", Value: "ns:prod"}},
+	}
+	test.ef.entries = []*types.Entry{entry}
+
+	resp, err := test.client.NewJWTSVID(ctx, &svidv1.NewJWTSVIDRequest{
+		EntryId:  entry.Id,
+		Audience: []string{"AUDIENCE"},
+	})
+	require.NoError(t, err)
+
+	token, err := jwt.ParseSigned(resp.Svid.Token, jwtsvid.AllowedSignatureAlgorithms)
+	require.NoError(t, err)
+	claims := make(map[string]any)
+	require.NoError(t, token.UnsafeClaimsWithoutVerification(&claims))
+	require.Equal(t, "prod", claims["namespace"])
+	require.Equal(t, "east", claims["cluster"])
+	// The entry has no hint, so the claim is left out
+	require.NotContains(t, claims, "hint")
+}
+
 func TestNewDownstreamX509CA(t *testing.T) {
 	type downstreamCaTest struct {
 		name           string
@@ -3070,18 +3189,23 @@ func (c *serviceTest) Cleanup() {
 }
 
 func setupServiceTest(t *testing.T) *serviceTest {
+	return setupServiceTestWithCAOptions(t, &fakeserverca.Options{})
+}
+
+func setupServiceTestWithCAOptions(t *testing.T, caOptions *fakeserverca.Options) *serviceTest {
 	trustDomain := spiffeid.RequireTrustDomainFromString("example.org")
-	ca := fakeserverca.New(t, trustDomain, &fakeserverca.Options{})
+	ca := fakeserverca.New(t, trustDomain, caOptions)
 	ef := &entryFetcher{}
 	downstream := &entryFetcher{}
 	ds := fakedatastore.New(t)
 
 	rateLimiter := &fakeRateLimiter{}
 	service := svid.New(svid.Config{
-		EntryFetcher: ef,
-		ServerCA:     ca,
-		TrustDomain:  trustDomain,
-		DataStore:    ds,
+		EntryFetcher:       ef,
+		ServerCA:           ca,
+		TrustDomain:        trustDomain,
+		DataStore:          ds,
+		CredentialProfiles: caOptions.CredentialProfilePolicy.Enabled(),
 	})
 
 	log, logHook := test.NewNullLogger()
@@ -3189,8 +3313,9 @@ func verifyWITSVIDResponse(t *testing.T, svid *types.WITSVID, id spiffeid.ID, is
 }
 
 type entryFetcher struct {
-	err     string
-	entries []*types.Entry
+	err      string
+	entries  []*types.Entry
+	profiles map[string]*common.CredentialProfile
 }
 
 func (f *entryFetcher) LookupAuthorizedEntries(ctx context.Context, agentID spiffeid.ID, _ map[string]struct{}) (map[string]api.ReadOnlyEntry, error) {
@@ -3223,7 +3348,7 @@ func (f *entryFetcher) FetchAuthorizedEntries(ctx context.Context, agentID spiff
 
 	entries := []api.ReadOnlyEntry{}
 	for _, entry := range f.entries {
-		entries = append(entries, api.NewReadOnlyEntry(entry))
+		entries = append(entries, api.NewReadOnlyEntryWithCredentialProfile(entry, f.profiles[entry.Id]))
 	}
 
 	return entries, nil
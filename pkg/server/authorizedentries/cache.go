@@ -10,6 +10,7 @@ import (
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 const (
@@ -45,6 +46,12 @@ type Cache struct {
 
 	entriesByEntryID  map[string]*types.Entry
 	entriesByParentID map[string]map[string]*types.Entry
+
+	// The Entry type does not carry the credential profiles of entries,
+	// which are needed when signing their X509-SVIDs.
+	credentialProfilesByEntryID map[string]*common.CredentialProfile
+
+	templates []*EntryTemplate
 }
 
 func NewCache(clk clock.Clock, trustDomain string) *Cache {
@@ -57,6 +64,8 @@ func NewCache(clk clock.Clock, trustDomain string) *Cache {
 		aliasesBySelector: btree.NewG(aliasRecordDegree, aliasRecordBySelector),
 		entriesByEntryID:  make(map[string]*types.Entry),
 		entriesByParentID: make(map[string]map[string]*types.Entry),
+
+		credentialProfilesByEntryID: make(map[string]*common.CredentialProfile),
 	}
 }
 
@@ -85,6 +94,13 @@ func (c *Cache) LookupAuthorizedEntries(agentID spiffeid.ID, requestedEntries ma
 		c.addDescendants(foundEntries, alias.AliasID, requestedEntries, parentSeen)
 	}
 
+	for _, entry := range c.renderTemplates(agentID, agent.Selectors) {
+		if _, ok := requestedEntries[entry.Id]; ok {
+			foundEntries[entry.Id] = api.NewReadOnlyEntry(entry)
+		}
+		c.addDescendants(foundEntries, entry.SpiffeId.Path, requestedEntries, parentSeen)
+	}
+
 	return foundEntries
 }
 
@@ -112,10 +128,25 @@ func (c *Cache) GetAuthorizedEntries(agentID spiffeid.ID) []api.ReadOnlyEntry {
 		records = c.appendDescendents(records, alias.AliasID, parentSeen)
 	}
 
+	for _, entry := range c.renderTemplates(agentID, agent.Selectors) {
+		records = append(records, api.NewReadOnlyEntry(entry))
+		records = c.appendDescendents(records, entry.SpiffeId.Path, parentSeen)
+	}
+
 	return records
 }
 
-func (c *Cache) UpdateEntry(entry *types.Entry) {
+// SetEntryTemplates replaces the entry templates rendered for each agent.
+func (c *Cache) SetEntryTemplates(templates []*EntryTemplate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.templates = templates
+}
+
+// UpdateEntry adds or replaces the entry in the cache, along with its
+// credential profile, if any.
+func (c *Cache) UpdateEntry(entry *types.Entry, credentialProfile *common.CredentialProfile) {
 	// Ensure that the trust domain of the entry matches the expected trust domain.
 	// This allows us to use only the path component as a key in maps.
 	if entry.ParentId.TrustDomain != c.trustDomain {
@@ -129,7 +160,7 @@ func (c *Cache) UpdateEntry(entry *types.Entry) {
 	defer c.mu.Unlock()
 
 	c.removeEntry(entry.Id)
-	c.updateEntry(entry)
+	c.updateEntry(entry, credentialProfile)
 }
 
 func (c *Cache) RemoveEntry(entryID string) {
@@ -195,7 +226,7 @@ func (c *Cache) appendDescendents(records []api.ReadOnlyEntry, parentID string,
 
 	parentEntries := c.entriesByParentID[parentID]
 	for _, entry := range parentEntries {
-		records = append(records, api.NewReadOnlyEntry(entry))
+		records = append(records, c.readOnlyEntry(entry))
 		records = c.appendDescendents(records, entry.SpiffeId.Path, parentSeen)
 	}
 	return records
@@ -213,7 +244,7 @@ func (c *Cache) addDescendants(foundEntries map[string]api.ReadOnlyEntry, parent
 	parentEntries := c.entriesByParentID[parentID]
 	for _, entry := range parentEntries {
 		if _, ok := requestedEntries[entry.Id]; ok {
-			foundEntries[entry.Id] = api.NewReadOnlyEntry(entry)
+			foundEntries[entry.Id] = c.readOnlyEntry(entry)
 		}
 
 		if len(foundEntries) == len(requestedEntries) {
@@ -224,6 +255,23 @@ func (c *Cache) addDescendants(foundEntries map[string]api.ReadOnlyEntry, parent
 	}
 }
 
+func (c *Cache) readOnlyEntry(entry *types.Entry) api.ReadOnlyEntry {
+	return api.NewReadOnlyEntryWithCredentialProfile(entry, c.credentialProfilesByEntryID[entry.Id])
+}
+
+// renderTemplates renders the entry templates that apply to the agent. The
+// entries are rendered on each call so they always reflect the current agent
+// selectors.
+func (c *Cache) renderTemplates(agentID spiffeid.ID, agentSelectors selectorSet) []*types.Entry {
+	var entries []*types.Entry
+	for _, template := range c.templates {
+		if entry, ok := template.render(agentID, agentSelectors); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
 func (c *Cache) getAgentAliases(agentSelectors selectorSet) []aliasRecord {
 	// Keep track of which aliases have already been evaluated.
 	aliasesSeen := allocStringSet()
@@ -231,17 +279,17 @@ func (c *Cache) getAgentAliases(agentSelectors selectorSet) []aliasRecord {
 
 	// Figure out which aliases the agent belongs to.
 	var aliasIDs []aliasRecord
-	for agentSelector := range agentSelectors {
-		pivot := aliasRecord{Selector: agentSelector}
+	for indexKey := range indexKeys(agentSelectors) {
+		pivot := aliasRecord{Selector: indexKey}
 		c.aliasesBySelector.AscendGreaterOrEqual(pivot, func(record aliasRecord) bool {
-			if record.Selector != agentSelector {
+			if record.Selector != indexKey {
 				return false
 			}
 			if _, ok := aliasesSeen[record.EntryID]; ok {
 				return true
 			}
 			aliasesSeen[record.EntryID] = struct{}{}
-			if isSubset(record.AllSelectors, agentSelectors) {
+			if matchesAll(record.AllSelectors, agentSelectors) {
 				aliasIDs = append(aliasIDs, record)
 			}
 			return true
@@ -250,14 +298,14 @@ func (c *Cache) getAgentAliases(agentSelectors selectorSet) []aliasRecord {
 	return aliasIDs
 }
 
-func (c *Cache) updateEntry(entry *types.Entry) {
+func (c *Cache) updateEntry(entry *types.Entry, credentialProfile *common.CredentialProfile) {
 	if isNodeAlias(entry) {
 		ar := aliasRecord{
 			EntryID:      entry.Id,
 			AliasID:      entry.SpiffeId.Path,
 			AllSelectors: selectorSetFromProto(entry.Selectors),
 		}
-		for selector := range ar.AllSelectors {
+		for selector := range candidateSelectors(ar.AllSelectors) {
 			ar.Selector = selector
 			c.aliasesByEntryID.ReplaceOrInsert(ar)
 			c.aliasesBySelector.ReplaceOrInsert(ar)
@@ -272,12 +320,16 @@ func (c *Cache) updateEntry(entry *types.Entry) {
 		parentEntries = c.entriesByParentID[entry.ParentId.Path]
 	}
 	parentEntries[entry.Id] = entry
+	if credentialProfile != nil {
+		c.credentialProfilesByEntryID[entry.Id] = credentialProfile
+	}
 }
 
 func (c *Cache) removeEntry(entryID string) {
 	entry, ok := c.entriesByEntryID[entryID]
 	if ok {
 		delete(c.entriesByEntryID, entryID)
+		delete(c.credentialProfilesByEntryID, entryID)
 		parentEntries, ok := c.entriesByParentID[entry.ParentId.Path]
 		if ok {
 			delete(parentEntries, entryID)
@@ -15,6 +15,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/protoutil"
 	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
@@ -113,6 +114,42 @@ func TestGetAuthorizedEntries(t *testing.T) {
 		})
 	})
 
+	t.Run("indirectly via alias with selector expressions", func(t *testing.T) {
+		var (
+			aliasEntry = makeAlias(alias1,
+				&types.Selector{Type: "|S", Value: "1|2"},
+				&types.Selector{Type: "!S", Value: "3"})
+			workloadEntry = makeWorkload(alias1)
+		)
+
+		test := testCache().
+			withEntries(workloadEntry, aliasEntry).
+			withAgent(agent1, sel1).
+			withAgent(agent2, sel2).
+			withAgent(agent3, sel1, sel3)
+
+		// Agents with either selector get the workload entry, unless they
+		// have the negated selector.
+		test.assertAuthorizedEntries(t, agent1, workloadEntry)
+		test.assertAuthorizedEntries(t, agent2, workloadEntry)
+		test.assertAuthorizedEntries(t, agent3)
+	})
+
+	t.Run("indirectly via alias with selector pattern", func(t *testing.T) {
+		var (
+			aliasEntry    = makeAlias(alias1, &types.Selector{Type: "~S", Value: "cluster:prod-*"})
+			workloadEntry = makeWorkload(alias1)
+		)
+
+		test := testCache().
+			withEntries(workloadEntry, aliasEntry).
+			withAgent(agent1, &types.Selector{Type: "S", Value: "cluster:prod-east"}).
+			withAgent(agent2, &types.Selector{Type: "S", Value: "cluster:staging"})
+
+		test.assertAuthorizedEntries(t, agent1, workloadEntry)
+		test.assertAuthorizedEntries(t, agent2)
+	})
+
 	t.Run("alias removed", func(t *testing.T) {
 		var (
 			aliasEntry    = makeAlias(alias1, sel1, sel2)
@@ -171,6 +208,29 @@ func TestGetAuthorizedEntries(t *testing.T) {
 	})
 }
 
+func TestCacheCredentialProfiles(t *testing.T) {
+	profile := &common.CredentialProfile{SubjectOrganization: []string{"ACME"}}
+	workload := makeWorkload(agent1)
+
+	cache := NewCache(clock.NewMock(t), "domain.test")
+	cache.UpdateEntry(workload, profile)
+
+	entries := cache.GetAuthorizedEntries(agent1)
+	require.Len(t, entries, 1)
+	require.Equal(t, profile, entries[0].GetCredentialProfile())
+
+	found := cache.LookupAuthorizedEntries(agent1, map[string]struct{}{workload.Id: {}})
+	require.Len(t, found, 1)
+	foundEntry := found[workload.Id]
+	require.Equal(t, profile, foundEntry.GetCredentialProfile())
+
+	// Updating the entry without a profile clears it
+	cache.UpdateEntry(workload, nil)
+	entries = cache.GetAuthorizedEntries(agent1)
+	require.Len(t, entries, 1)
+	require.Nil(t, entries[0].GetCredentialProfile())
+}
+
 func TestCacheInternalStats(t *testing.T) {
 	// This test asserts that the internal indexes are properly maintained
 	// across various operations. The motivation is to ensure that as the cache
@@ -191,17 +251,17 @@ func TestCacheInternalStats(t *testing.T) {
 		entry2b.Id = entry2a.Id
 
 		cache := NewCache(clk, "domain.test")
-		cache.UpdateEntry(entry1)
+		cache.UpdateEntry(entry1, nil)
 		require.Equal(t, CacheStats{
 			EntriesByEntryID: 1,
 		}, cache.Stats())
 
-		cache.UpdateEntry(entry2a)
+		cache.UpdateEntry(entry2a, nil)
 		require.Equal(t, CacheStats{
 			EntriesByEntryID: 2,
 		}, cache.Stats())
 
-		cache.UpdateEntry(entry2b)
+		cache.UpdateEntry(entry2b, nil)
 		require.Equal(t, CacheStats{
 			EntriesByEntryID:  1,
 			AliasesByEntryID:  2, // one for each selector
@@ -299,7 +359,7 @@ func (a *cacheTest) hydrate(tb testing.TB) (*cacheTest, *Cache) {
 	clk := clock.NewMock(tb)
 	cache := NewCache(clk, "domain.test")
 	for _, entry := range a.entries {
-		cache.UpdateEntry(entry)
+		cache.UpdateEntry(entry, nil)
 	}
 	for agent, info := range a.agents {
 		cache.UpdateAgent(agent.String(), info.ExpiresAt, info.Selectors)
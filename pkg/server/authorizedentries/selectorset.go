@@ -2,6 +2,7 @@ package authorizedentries
 
 import (
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/selector"
 )
 
 type selectorSet map[Selector]struct{}
@@ -26,3 +27,38 @@ func isSubset(sub, whole selectorSet) bool {
 	}
 	return true
 }
+
+// Returns true if the selectors in whole satisfy all of the selectors in sub,
+// which may be selector expressions
+func matchesAll(sub, whole selectorSet) bool {
+	for s := range sub {
+		if !selector.Match(selector.Selector(s), whole) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the index keys of the selectors in set, expanding
+// selector expressions into their candidate selectors
+func candidateSelectors(set selectorSet) selectorSet {
+	candidates := make(selectorSet, len(set))
+	for s := range set {
+		for _, candidate := range selector.Candidates(selector.Selector(s)) {
+			candidates[Selector(candidate)] = struct{}{}
+		}
+	}
+	return candidates
+}
+
+// Returns the index keys under which the selectors in set may find the
+// aliases they satisfy
+func indexKeys(set selectorSet) selectorSet {
+	keys := make(selectorSet, 2*len(set))
+	for s := range set {
+		for _, key := range selector.IndexKeys(selector.Selector(s)) {
+			keys[Selector(key)] = struct{}{}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,294 @@
+package authorizedentries
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/agentpathtemplate"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"google.golang.org/protobuf/proto"
+)
+
+// templateEntryIDPrefix prefixes the IDs of entries rendered from templates
+// to set them apart from the IDs of persisted entries.
+const templateEntryIDPrefix = "template."
+
+var (
+	// templateNameRE restricts template names to characters that can be
+	// embedded in a rendered entry ID as-is.
+	templateNameRE = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+	// selectorPlaceholderRE matches the {{type:key}} shorthand, which is
+	// rewritten into a call to the Selector method of the template data.
+	selectorPlaceholderRE = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_-]+:[^\s{}"]+)\s*\}\}`)
+
+	// selectorCallRE matches the calls to the Selector method of the template
+	// data with a literal key, once the shorthand has been rewritten.
+	selectorCallRE = regexp.MustCompile(`\.Selector\s+"([^"]*)"`)
+)
+
+// EntryTemplateConfig is the configuration of an entry template.
+type EntryTemplateConfig struct {
+	// Name uniquely identifies the template.
+	Name string
+
+	// SPIFFEID is the SPIFFE ID template of the rendered entries.
+	SPIFFEID string
+
+	// NodeSelectors restricts the template to agents that have all of these
+	// selectors. When empty, the template applies to every agent.
+	NodeSelectors []*types.Selector
+
+	// NodeSelectorTypes are the types of the selectors that agents are
+	// attested with, which are the names of the node attestors. Placeholders
+	// may only refer to selectors of these types.
+	NodeSelectorTypes []string
+
+	// Selectors are the workload selectors of the rendered entries, in
+	// type:value form. The values may contain template placeholders.
+	Selectors []string
+
+	// DNSNames are the DNS names of the rendered entries. They may contain
+	// template placeholders.
+	DNSNames []string
+
+	X509SVIDTTL int32
+	JWTSVIDTTL  int32
+	Hint        string
+}
+
+// EntryTemplate renders a registration entry for each agent that matches the
+// template node selectors. Rendered entries are parented to the agent and are
+// never persisted.
+//
+// Templates use the agent path template syntax. In addition, the {{type:key}}
+// shorthand expands to the value of the agent selector of the given type whose
+// value starts with "key:", with that prefix removed. For example, with the
+// agent selector "k8s_psat:agent_ns:spire", {{k8s_psat:agent_ns}} expands to
+// "spire". An agent that lacks a referenced selector is not issued an entry
+// for the template.
+//
+// Only the agent selectors are available to templates, as the server does not
+// know the selectors of the workloads, so a template renders at most one entry
+// per agent. Placeholders on any other selector type are rejected.
+type EntryTemplate struct {
+	name          string
+	td            spiffeid.TrustDomain
+	nodeSelectors selectorSet
+	spiffeID      *agentpathtemplate.Template
+	selectors     []selectorTemplate
+	dnsNames      []*agentpathtemplate.Template
+	x509SVIDTTL   int32
+	jwtSVIDTTL    int32
+	hint          string
+}
+
+type selectorTemplate struct {
+	Type  string
+	Value *agentpathtemplate.Template
+}
+
+// NewEntryTemplate parses and validates an entry template for the given trust
+// domain.
+func NewEntryTemplate(td spiffeid.TrustDomain, config EntryTemplateConfig) (*EntryTemplate, error) {
+	if config.Name == "" {
+		return nil, errors.New("template name is required")
+	}
+	if !templateNameRE.MatchString(config.Name) {
+		return nil, fmt.Errorf("template name %q may only contain letters, numbers, underscores and dashes", config.Name)
+	}
+
+	if config.SPIFFEID == "" {
+		return nil, errors.New("SPIFFE ID is required")
+	}
+	if !strings.HasPrefix(config.SPIFFEID, "spiffe://"+td.Name()+"/") {
+		return nil, fmt.Errorf("SPIFFE ID %q must be a path in trust domain %q", config.SPIFFEID, td.Name())
+	}
+	nodeSelectorTypes := make(map[string]struct{}, len(config.NodeSelectorTypes))
+	for _, selectorType := range config.NodeSelectorTypes {
+		nodeSelectorTypes[selectorType] = struct{}{}
+	}
+
+	spiffeID, err := parseTemplate(config.SPIFFEID, nodeSelectorTypes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPIFFE ID: %w", err)
+	}
+
+	if len(config.Selectors) == 0 {
+		return nil, errors.New("at least one selector is required")
+	}
+	var selectors []selectorTemplate
+	for _, selector := range config.Selectors {
+		selectorType, selectorValue, ok := strings.Cut(selector, ":")
+		if !ok || selectorType == "" || selectorValue == "" {
+			return nil, fmt.Errorf("selector %q must be in type:value form", selector)
+		}
+		value, err := parseTemplate(selectorValue, nodeSelectorTypes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %w", selector, err)
+		}
+		selectors = append(selectors, selectorTemplate{Type: selectorType, Value: value})
+	}
+
+	var dnsNames []*agentpathtemplate.Template
+	for _, dnsName := range config.DNSNames {
+		tmpl, err := parseTemplate(dnsName, nodeSelectorTypes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DNS name %q: %w", dnsName, err)
+		}
+		dnsNames = append(dnsNames, tmpl)
+	}
+
+	if config.X509SVIDTTL < 0 {
+		return nil, errors.New("X509 SVID TTL cannot be negative")
+	}
+	if config.JWTSVIDTTL < 0 {
+		return nil, errors.New("JWT SVID TTL cannot be negative")
+	}
+
+	return &EntryTemplate{
+		name:          config.Name,
+		td:            td,
+		nodeSelectors: selectorSetFromProto(config.NodeSelectors),
+		spiffeID:      spiffeID,
+		selectors:     selectors,
+		dnsNames:      dnsNames,
+		x509SVIDTTL:   config.X509SVIDTTL,
+		jwtSVIDTTL:    config.JWTSVIDTTL,
+		hint:          config.Hint,
+	}, nil
+}
+
+// Name returns the name of the template.
+func (t *EntryTemplate) Name() string {
+	return t.name
+}
+
+// render renders the entry for the given agent. It returns false if the
+// template does not apply to the agent or cannot be rendered with the agent
+// selectors.
+func (t *EntryTemplate) render(agentID spiffeid.ID, agentSelectors selectorSet) (*types.Entry, bool) {
+	if !isSubset(t.nodeSelectors, agentSelectors) {
+		return nil, false
+	}
+
+	data := templateData{
+		TrustDomain:    t.td.Name(),
+		AgentPath:      agentID.Path(),
+		agentSelectors: agentSelectors,
+	}
+
+	rendered, err := t.spiffeID.Execute(data)
+	if err != nil {
+		return nil, false
+	}
+	spiffeID, err := spiffeid.FromString(rendered)
+	if err != nil || !spiffeID.MemberOf(t.td) || idutil.IsReservedPath(spiffeID.Path()) {
+		return nil, false
+	}
+
+	entry := &types.Entry{
+		Id:          t.entryID(agentID),
+		SpiffeId:    &types.SPIFFEID{TrustDomain: spiffeID.TrustDomain().Name(), Path: spiffeID.Path()},
+		ParentId:    &types.SPIFFEID{TrustDomain: agentID.TrustDomain().Name(), Path: agentID.Path()},
+		X509SvidTtl: t.x509SVIDTTL,
+		JwtSvidTtl:  t.jwtSVIDTTL,
+		Hint:        t.hint,
+	}
+	for _, selector := range t.selectors {
+		value, err := selector.Value.Execute(data)
+		if err != nil || value == "" {
+			return nil, false
+		}
+		entry.Selectors = append(entry.Selectors, &types.Selector{Type: selector.Type, Value: value})
+	}
+	for _, dnsName := range t.dnsNames {
+		value, err := dnsName.Execute(data)
+		if err != nil || value == "" {
+			return nil, false
+		}
+		entry.DnsNames = append(entry.DnsNames, value)
+	}
+
+	// The revision number tracks the rendered content so that agents pick
+	// up the new entry when the template or the agent selectors change.
+	entry.RevisionNumber = contentRevision(entry)
+	return entry, true
+}
+
+// entryID derives a stable entry ID from the template name and the agent ID.
+func (t *EntryTemplate) entryID(agentID spiffeid.ID) string {
+	sum := sha256.Sum256([]byte(agentID.String()))
+	return templateEntryIDPrefix + t.name + "." + hex.EncodeToString(sum[:8])
+}
+
+func contentRevision(entry *types.Entry) int64 {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(entry)
+	if err != nil {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return int64(h.Sum64() & math.MaxInt64)
+}
+
+// parseTemplate parses a template whose placeholders may only refer to the
+// agent selectors of the given types.
+func parseTemplate(text string, nodeSelectorTypes map[string]struct{}) (*agentpathtemplate.Template, error) {
+	text = selectorPlaceholderRE.ReplaceAllString(text, `{{ .Selector "$1" }}`)
+
+	calls := selectorCallRE.FindAllStringSubmatch(text, -1)
+	if len(calls) != strings.Count(text, ".Selector") {
+		return nil, errors.New("selector placeholders must use a literal key")
+	}
+	for _, call := range calls {
+		selectorType, _, _ := strings.Cut(call[1], ":")
+		if _, ok := nodeSelectorTypes[selectorType]; !ok {
+			return nil, fmt.Errorf("placeholder %q does not refer to an agent selector", call[1])
+		}
+	}
+
+	return agentpathtemplate.Parse(text)
+}
+
+// templateData is the data available to entry templates.
+type templateData struct {
+	TrustDomain string
+	AgentPath   string
+
+	agentSelectors selectorSet
+}
+
+// Selector returns the value of the agent selector identified by key, which
+// is the selector type followed by the leading components of the selector
+// value (e.g. "k8s_psat:agent_ns"). It fails if the agent has no such
+// selector, or more than one.
+func (d templateData) Selector(key string) (string, error) {
+	selectorType, prefix, _ := strings.Cut(key, ":")
+	prefix += ":"
+
+	var value string
+	found := false
+	for selector := range d.agentSelectors {
+		if selector.Type != selectorType || !strings.HasPrefix(selector.Value, prefix) {
+			continue
+		}
+		if found {
+			return "", fmt.Errorf("agent has more than one %q selector", key)
+		}
+		value = strings.TrimPrefix(selector.Value, prefix)
+		found = true
+	}
+	if !found || value == "" {
+		return "", fmt.Errorf("agent has no %q selector", key)
+	}
+	return value, nil
+}
@@ -0,0 +1,237 @@
+package authorizedentries
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	nsSelector  = &types.Selector{Type: "k8s_psat", Value: "agent_ns:spire"}
+	saSelector  = &types.Selector{Type: "k8s_psat", Value: "agent_sa:spire-agent"}
+	prodCluster = &types.Selector{Type: "k8s_psat", Value: "cluster:prod"}
+)
+
+func TestNewEntryTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		config    EntryTemplateConfig
+		expectErr string
+	}{
+		{
+			name:   "valid",
+			config: templateConfig(),
+		},
+		{
+			name: "missing name",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.Name = ""
+			}),
+			expectErr: "template name is required",
+		},
+		{
+			name: "invalid name",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.Name = "a.b"
+			}),
+			expectErr: `template name "a.b" may only contain letters, numbers, underscores and dashes`,
+		},
+		{
+			name: "missing SPIFFE ID",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.SPIFFEID = ""
+			}),
+			expectErr: "SPIFFE ID is required",
+		},
+		{
+			name: "SPIFFE ID in another trust domain",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.SPIFFEID = "spiffe://other.test/workload"
+			}),
+			expectErr: `SPIFFE ID "spiffe://other.test/workload" must be a path in trust domain "domain.test"`,
+		},
+		{
+			name: "malformed SPIFFE ID template",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.SPIFFEID = "spiffe://domain.test/{{ .AgentPath"
+			}),
+			expectErr: `invalid SPIFFE ID: template: agent-path:1: unclosed action`,
+		},
+		{
+			name: "no selectors",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.Selectors = nil
+			}),
+			expectErr: "at least one selector is required",
+		},
+		{
+			name: "malformed selector",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.Selectors = []string{"k8s"}
+			}),
+			expectErr: `selector "k8s" must be in type:value form`,
+		},
+		{
+			name: "workload selector placeholder",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.Selectors = []string{"k8s:ns:{{k8s:ns}}"}
+			}),
+			expectErr: `invalid selector "k8s:ns:{{k8s:ns}}": placeholder "k8s:ns" does not refer to an agent selector`,
+		},
+		{
+			name: "workload selector method call",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.DNSNames = []string{`{{ .Selector "k8s:sa" }}.svc`}
+			}),
+			expectErr: `invalid DNS name "{{ .Selector \"k8s:sa\" }}.svc": placeholder "k8s:sa" does not refer to an agent selector`,
+		},
+		{
+			name: "selector method call without a literal key",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.SPIFFEID = `spiffe://domain.test/{{ .Selector (print "k8s" ":ns") }}`
+			}),
+			expectErr: "invalid SPIFFE ID: selector placeholders must use a literal key",
+		},
+		{
+			name: "negative TTL",
+			config: modifyTemplateConfig(func(c *EntryTemplateConfig) {
+				c.X509SVIDTTL = -1
+			}),
+			expectErr: "X509 SVID TTL cannot be negative",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := NewEntryTemplate(td, tt.config)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.config.Name, template.Name())
+		})
+	}
+}
+
+func TestEntryTemplateRender(t *testing.T) {
+	template, err := NewEntryTemplate(td, templateConfig())
+	require.NoError(t, err)
+
+	t.Run("rendered from agent selectors", func(t *testing.T) {
+		entry, ok := template.render(agent1, selectorSetFromProto([]*types.Selector{nsSelector, saSelector, prodCluster}))
+		require.True(t, ok)
+		assert.True(t, strings.HasPrefix(entry.Id, "template.ns-workloads."))
+		spiretest.AssertProtoEqual(t, api.ProtoFromID(agent1), entry.ParentId)
+		spiretest.AssertProtoEqual(t, &types.SPIFFEID{TrustDomain: "domain.test", Path: "/ns/spire/sa/spire-agent"}, entry.SpiffeId)
+		spiretest.AssertProtoListEqual(t, []*types.Selector{{Type: "k8s", Value: "ns:spire"}, {Type: "k8s", Value: "sa:spire-agent"}}, entry.Selectors)
+		assert.Equal(t, []string{"spire-agent.spire.svc"}, entry.DnsNames)
+		assert.Equal(t, int32(60), entry.X509SvidTtl)
+		assert.NotZero(t, entry.RevisionNumber)
+
+		// Rendering is stable across calls but unique per agent.
+		again, ok := template.render(agent1, selectorSetFromProto([]*types.Selector{nsSelector, saSelector, prodCluster}))
+		require.True(t, ok)
+		assert.Equal(t, entry.Id, again.Id)
+		assert.Equal(t, entry.RevisionNumber, again.RevisionNumber)
+
+		other, ok := template.render(agent2, selectorSetFromProto([]*types.Selector{nsSelector, saSelector, prodCluster}))
+		require.True(t, ok)
+		assert.NotEqual(t, entry.Id, other.Id)
+	})
+
+	t.Run("revision changes with content", func(t *testing.T) {
+		entry1, ok := template.render(agent1, selectorSetFromProto([]*types.Selector{nsSelector, saSelector, prodCluster}))
+		require.True(t, ok)
+		entry2, ok := template.render(agent1, selectorSetFromProto([]*types.Selector{nsSelector, {Type: "k8s_psat", Value: "agent_sa:other"}, prodCluster}))
+		require.True(t, ok)
+		assert.Equal(t, entry1.Id, entry2.Id)
+		assert.NotEqual(t, entry1.RevisionNumber, entry2.RevisionNumber)
+	})
+
+	t.Run("agent does not match node selectors", func(t *testing.T) {
+		_, ok := template.render(agent1, selectorSetFromProto([]*types.Selector{nsSelector, saSelector}))
+		require.False(t, ok)
+	})
+
+	t.Run("agent lacks referenced selector", func(t *testing.T) {
+		_, ok := template.render(agent1, selectorSetFromProto([]*types.Selector{nsSelector, prodCluster}))
+		require.False(t, ok)
+	})
+
+	t.Run("agent has ambiguous selector", func(t *testing.T) {
+		_, ok := template.render(agent1, selectorSetFromProto([]*types.Selector{nsSelector, saSelector, prodCluster, {Type: "k8s_psat", Value: "agent_ns:other"}}))
+		require.False(t, ok)
+	})
+
+	t.Run("rendered SPIFFE ID is reserved", func(t *testing.T) {
+		template, err := NewEntryTemplate(td, modifyTemplateConfig(func(c *EntryTemplateConfig) {
+			c.SPIFFEID = "spiffe://domain.test/{{k8s_psat:agent_ns}}/x"
+		}))
+		require.NoError(t, err)
+		_, ok := template.render(agent1, selectorSetFromProto([]*types.Selector{{Type: "k8s_psat", Value: "agent_ns:spire"}, saSelector, prodCluster}))
+		require.False(t, ok)
+	})
+
+	t.Run("full template syntax", func(t *testing.T) {
+		template, err := NewEntryTemplate(td, modifyTemplateConfig(func(c *EntryTemplateConfig) {
+			c.SPIFFEID = `spiffe://domain.test/node{{ .AgentPath }}/{{ .Selector "k8s_psat:agent_ns" | upper }}`
+		}))
+		require.NoError(t, err)
+		entry, ok := template.render(agent1, selectorSetFromProto([]*types.Selector{nsSelector, saSelector, prodCluster}))
+		require.True(t, ok)
+		assert.Equal(t, "/node/spire/agent/1/SPIRE", entry.SpiffeId.Path)
+	})
+}
+
+func TestCacheEntryTemplates(t *testing.T) {
+	template, err := NewEntryTemplate(td, templateConfig())
+	require.NoError(t, err)
+
+	cache := NewCache(clock.NewMock(t), "domain.test")
+	cache.SetEntryTemplates([]*EntryTemplate{template})
+	cache.UpdateAgent(agent1.String(), now.Add(time.Hour), []*types.Selector{nsSelector, saSelector, prodCluster})
+	cache.UpdateAgent(agent2.String(), now.Add(time.Hour), []*types.Selector{nsSelector, saSelector})
+
+	rendered, ok := template.render(agent1, selectorSetFromProto([]*types.Selector{nsSelector, saSelector, prodCluster}))
+	require.True(t, ok)
+
+	// Entries parented to the rendered entry are authorized through it.
+	child := &types.Entry{
+		Id:        "child",
+		ParentId:  rendered.SpiffeId,
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "domain.test", Path: "/child"},
+		Selectors: []*types.Selector{{Type: "not", Value: "relevant"}},
+	}
+	cache.UpdateEntry(child, nil)
+
+	allEntries := map[string]*types.Entry{rendered.Id: rendered, child.Id: child}
+	assertAuthorizedEntries(t, cache, agent1, allEntries, rendered, child)
+	assertAuthorizedEntries(t, cache, agent2, allEntries)
+
+	cache.SetEntryTemplates(nil)
+	assertAuthorizedEntries(t, cache, agent1, allEntries)
+}
+
+func templateConfig() EntryTemplateConfig {
+	return EntryTemplateConfig{
+		Name:              "ns-workloads",
+		SPIFFEID:          "spiffe://domain.test/ns/{{k8s_psat:agent_ns}}/sa/{{k8s_psat:agent_sa}}",
+		NodeSelectors:     []*types.Selector{prodCluster},
+		NodeSelectorTypes: []string{"k8s_psat"},
+		Selectors:         []string{"k8s:ns:{{k8s_psat:agent_ns}}", "k8s:sa:{{k8s_psat:agent_sa}}"},
+		DNSNames:          []string{"{{k8s_psat:agent_sa}}.{{k8s_psat:agent_ns}}.svc"},
+		X509SVIDTTL:       60,
+	}
+}
+
+func modifyTemplateConfig(fn func(c *EntryTemplateConfig)) EntryTemplateConfig {
+	config := templateConfig()
+	fn(&config)
+	return config
+}
@@ -20,6 +20,10 @@ const (
 	allowIfDownstreamKey = "allow_if_downstream"
 	allowIfAgentKey      = "allow_if_agent"
 	allowIfLocalKey      = "allow_if_local"
+
+	// allowIfTenantAdminKey is optional so that policies written before
+	// tenants were introduced keep working.
+	allowIfTenantAdminKey = "allow_if_tenant_admin"
 )
 
 // Engine drives policy management.
@@ -59,6 +63,9 @@ type Result struct {
 	AllowIfLocal      bool `json:"allow_if_local"`
 	AllowIfDownstream bool `json:"allow_if_downstream"`
 	AllowIfAgent      bool `json:"allow_if_agent"`
+	// AllowIfTenantAdmin authorizes the call if the caller is an admin of a
+	// tenant. The call is then scoped to the entries of that tenant.
+	AllowIfTenantAdmin bool `json:"allow_if_tenant_admin"`
 }
 
 // NewEngineFromConfigOrDefault returns a new policy engine. Or if no
@@ -179,5 +186,11 @@ func (e *Engine) Eval(ctx context.Context, input Input) (result Result, err erro
 		return Result{}, err
 	}
 
+	if _, ok := resultMap[allowIfTenantAdminKey]; ok {
+		if result.AllowIfTenantAdmin, err = getBoolValue(allowIfTenantAdminKey); err != nil {
+			return Result{}, err
+		}
+	}
+
 	return result, nil
 }
@@ -12,6 +12,9 @@ package spire
 #   only if the caller has a downstream SPIFFE ID
 # - `allow_if_agent`: a boolean that if true, will authorize the call only if
 #   the caller is an agent
+# - `allow_if_tenant_admin`: a boolean that if true, will authorize the call
+#   only if the caller is a tenant admin. The call is then scoped to the
+#   entries of the tenant. This field is optional.
 
 result = {
   "allow": allow, 
@@ -19,6 +22,7 @@ result = {
   "allow_if_local": allow_if_local,
   "allow_if_downstream": allow_if_downstream,
   "allow_if_agent": allow_if_agent,
+  "allow_if_tenant_admin": allow_if_tenant_admin,
 }
 
 
@@ -28,6 +32,7 @@ default allow_if_admin = false
 default allow_if_downstream = false
 default allow_if_local = false
 default allow_if_agent = false
+default allow_if_tenant_admin = false
 default allow = false 
 
 
@@ -65,6 +70,14 @@ allow_if_agent = true if {
     r.allow_agent
 }
 
+# Tenant admin allow check
+allow_if_tenant_admin = true if { 
+    r := data.apis[_]
+    r.full_method == input.full_method 
+    
+    r.allow_tenant_admin
+}
+
 # Any allow check
 allow = true if { 
     r := data.apis[_]
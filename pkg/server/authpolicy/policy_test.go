@@ -92,6 +92,27 @@ func TestPolicy(t *testing.T) {
 				AllowIfAgent:      false,
 			},
 		},
+		{
+			name: "test tenant admin policy",
+			rego: `
+    package spire
+    result = {
+      "allow": false,
+      "allow_if_admin": false,
+      "allow_if_local": false,
+      "allow_if_downstream": false,
+      "allow_if_agent": false,
+      "allow_if_tenant_admin": true
+    }`,
+			jsonData: "{}",
+			input: authpolicy.Input{
+				Caller:     "some_caller",
+				FullMethod: "some_method",
+			},
+			expectResult: authpolicy.Result{
+				AllowIfTenantAdmin: true,
+			},
+		},
 		{
 			name:     "test condition policy baseline",
 			rego:     condCheckRego("1==2"),
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
@@ -50,11 +51,18 @@ type ClientConfig struct { //revive:disable-line:exported name stutter is intent
 // Client is used to fetch a bundle and metadata from a bundle endpoint
 type Client interface {
 	FetchBundle(context.Context) (*spiffebundle.Bundle, error)
+
+	// EndpointCertificateExpiresAt returns when the certificate presented by
+	// the bundle endpoint on the last successful fetch expires, or the zero
+	// time if no fetch has succeeded.
+	EndpointCertificateExpiresAt() time.Time
 }
 
 type client struct {
 	c      ClientConfig
 	client *http.Client
+
+	endpointCertificateExpiresAt time.Time
 }
 
 func NewClient(config ClientConfig) (Client, error) {
@@ -107,9 +115,16 @@ func (c *client) FetchBundle(context.Context) (*spiffebundle.Bundle, error) {
 		return nil, err
 	}
 
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		c.endpointCertificateExpiresAt = resp.TLS.PeerCertificates[0].NotAfter
+	}
 	return b, nil
 }
 
+func (c *client) EndpointCertificateExpiresAt() time.Time {
+	return c.endpointCertificateExpiresAt
+}
+
 func tryRead(r io.Reader) string {
 	b := make([]byte, 1024)
 	n, _ := r.Read(b)
@@ -146,9 +146,11 @@ func TestClient(t *testing.T) {
 			if testCase.fetchBundleErr != "" {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), testCase.fetchBundleErr)
+				require.True(t, client.EndpointCertificateExpiresAt().IsZero())
 				return
 			}
 			require.NoError(t, err)
+			require.True(t, serverCert.NotAfter.Equal(client.EndpointCertificateExpiresAt()))
 			require.NotNil(t, bundle)
 			require.Equal(t, trustDomain.IDString(), bundle.TrustDomain().IDString())
 			refreshHint, ok := bundle.RefreshHint()
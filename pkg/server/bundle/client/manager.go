@@ -3,6 +3,8 @@ package client
 import (
 	"context"
 	"maps"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -100,12 +102,46 @@ type Manager struct {
 	bundleRefreshedCh chan time.Duration
 }
 
+// FederationStatus is the refresh status of a federation relationship.
+type FederationStatus struct {
+	// TrustDomain is the federated trust domain.
+	TrustDomain spiffeid.TrustDomain
+
+	// LastRefresh is when the bundle was last successfully refreshed from the
+	// bundle endpoint. Zero if it never was.
+	LastRefresh time.Time
+
+	// LastRefreshAttempt is when the bundle was last refreshed, successfully
+	// or not. Zero if it never was.
+	LastRefreshAttempt time.Time
+
+	// NextRefresh is when the next refresh is scheduled. Zero if no refresh
+	// is scheduled yet.
+	NextRefresh time.Time
+
+	// LastError is the error of the last refresh attempt, if it failed.
+	LastError error
+
+	// BundleSequenceNumber is the sequence number of the bundle held by the
+	// server.
+	BundleSequenceNumber uint64
+
+	// EndpointCertificateExpiresAt is when the certificate presented by the
+	// bundle endpoint on the last successful refresh expires. Zero if
+	// unknown.
+	EndpointCertificateExpiresAt time.Time
+}
+
 type managedBundleUpdater struct {
 	BundleUpdater
 
+	clock  clock.Clock
 	wg     sync.WaitGroup
 	cancel context.CancelFunc
 	runCh  chan chan error
+
+	statusMtx sync.Mutex
+	status    FederationStatus
 }
 
 func (m *managedBundleUpdater) Stop() {
@@ -113,6 +149,43 @@ func (m *managedBundleUpdater) Stop() {
 	m.wg.Wait()
 }
 
+// UpdateBundle updates the bundle, recording the outcome in the status of the
+// federation relationship.
+func (m *managedBundleUpdater) UpdateBundle(ctx context.Context) (*spiffebundle.Bundle, *spiffebundle.Bundle, error) {
+	localBundle, endpointBundle, err := m.BundleUpdater.UpdateBundle(ctx)
+
+	m.statusMtx.Lock()
+	defer m.statusMtx.Unlock()
+	m.status.LastRefreshAttempt = m.clock.Now()
+	m.status.LastError = err
+	if err == nil {
+		m.status.LastRefresh = m.status.LastRefreshAttempt
+		m.status.EndpointCertificateExpiresAt = m.BundleUpdater.EndpointCertificateExpiresAt()
+	}
+	// The endpoint bundle is only returned when it replaced the local one.
+	currentBundle := endpointBundle
+	if currentBundle == nil {
+		currentBundle = localBundle
+	}
+	if currentBundle != nil {
+		m.status.BundleSequenceNumber, _ = currentBundle.SequenceNumber()
+	}
+	return localBundle, endpointBundle, err
+}
+
+func (m *managedBundleUpdater) setNextRefresh(nextRefresh time.Time) FederationStatus {
+	m.statusMtx.Lock()
+	defer m.statusMtx.Unlock()
+	m.status.NextRefresh = nextRefresh
+	return m.status
+}
+
+func (m *managedBundleUpdater) Status() FederationStatus {
+	m.statusMtx.Lock()
+	defer m.statusMtx.Unlock()
+	return m.status
+}
+
 func NewManager(config ManagerConfig) *Manager {
 	if config.Clock == nil {
 		config.Clock = clock.New()
@@ -185,6 +258,36 @@ func (m *Manager) RefreshBundleFor(ctx context.Context, td spiffeid.TrustDomain)
 	return true, err
 }
 
+// FederationStatuses returns the refresh status of the federation
+// relationships managed by the manager, sorted by trust domain name.
+func (m *Manager) FederationStatuses() []FederationStatus {
+	m.updatersMtx.RLock()
+	statuses := make([]FederationStatus, 0, len(m.updaters))
+	for _, updater := range m.updaters {
+		statuses = append(statuses, updater.Status())
+	}
+	m.updatersMtx.RUnlock()
+
+	slices.SortFunc(statuses, func(a, b FederationStatus) int {
+		return strings.Compare(a.TrustDomain.Name(), b.TrustDomain.Name())
+	})
+	return statuses
+}
+
+// FederationStatusFor returns the refresh status of the federation
+// relationship with the given trust domain. If the trust domain is not managed
+// by the manager, false is returned.
+func (m *Manager) FederationStatusFor(td spiffeid.TrustDomain) (FederationStatus, bool) {
+	m.updatersMtx.RLock()
+	updater, ok := m.updaters[td]
+	m.updatersMtx.RUnlock()
+
+	if !ok {
+		return FederationStatus{}, false
+	}
+	return updater.Status(), true
+}
+
 func (m *Manager) refreshConfigs(ctx context.Context) error {
 	m.configRefreshMtx.Lock()
 	defer m.configRefreshMtx.Unlock()
@@ -249,8 +352,10 @@ func (m *Manager) refreshConfigs(ctx context.Context) error {
 				TrustDomain:       td,
 				DataStore:         m.ds,
 			}),
+			clock:  m.clock,
 			cancel: cancel,
 			runCh:  make(chan chan error),
+			status: FederationStatus{TrustDomain: td},
 		}
 		m.updaters[td] = updater
 		updater.wg.Go(func() {
@@ -260,7 +365,7 @@ func (m *Manager) refreshConfigs(ctx context.Context) error {
 	return nil
 }
 
-func (m *Manager) runUpdater(ctx context.Context, trustDomain spiffeid.TrustDomain, updater BundleUpdater) {
+func (m *Manager) runUpdater(ctx context.Context, trustDomain spiffeid.TrustDomain, updater *managedBundleUpdater) {
 	// Initialize the timer. The initial duration does not matter since it will
 	// be reset with the actual refresh interval before first use.
 	timer := m.clock.Timer(time.Hour)
@@ -269,11 +374,18 @@ func (m *Manager) runUpdater(ctx context.Context, trustDomain spiffeid.TrustDoma
 	log := m.log.WithField("trust_domain", trustDomain.Name())
 	for {
 		nextRefresh := m.runUpdateOnce(ctx, log, trustDomain, updater)
+		nextRefreshAt := m.clock.Now().Add(nextRefresh)
 
 		log.WithFields(logrus.Fields{
-			"at": m.clock.Now().Add(nextRefresh).UTC().Format(time.RFC3339),
+			"at": nextRefreshAt.UTC().Format(time.RFC3339),
 		}).Debug("Scheduling next bundle refresh")
 
+		status := updater.setNextRefresh(nextRefreshAt)
+		telemetry_server.SetBundleManagerFederatedBundleStatusGauges(m.metrics, trustDomain.Name(), status.LastRefresh, status.NextRefresh, status.BundleSequenceNumber)
+		if !status.EndpointCertificateExpiresAt.IsZero() {
+			telemetry_server.SetBundleManagerEndpointCertificateExpirationGauge(m.metrics, trustDomain.Name(), status.EndpointCertificateExpiresAt)
+		}
+
 		// Notify the test hook
 		timer.Reset(nextRefresh)
 
@@ -235,6 +235,89 @@ func TestManagerConfigManualRefresh(t *testing.T) {
 	}, test.GetTrustDomainConfigs())
 }
 
+func TestManagerFederationStatus(t *testing.T) {
+	localBundle := spiffebundle.FromX509Authorities(trustDomain, []*x509.Certificate{createCACertificate(t, "local")})
+	localBundle.SetRefreshHint(time.Hour)
+	localBundle.SetSequenceNumber(1)
+
+	source := NewTrustDomainConfigSet(TrustDomainConfigMap{
+		trustDomain: TrustDomainConfig{
+			EndpointURL:     "https://example.org/bundle",
+			EndpointProfile: HTTPSWebProfile{},
+		},
+	})
+
+	test := newManagerTest(t, source,
+		func(spiffeid.TrustDomain) *spiffebundle.Bundle {
+			return localBundle
+		}, nil)
+
+	test.WaitForConfigRefresh()
+	test.WaitForBundleRefresh(calculateNextUpdate(localBundle))
+
+	// The fake updater fails to download the endpoint bundle
+	expectStatus := FederationStatus{
+		TrustDomain:          trustDomain,
+		LastRefreshAttempt:   test.clock.Now(),
+		NextRefresh:          test.clock.Now().Add(calculateNextUpdate(localBundle)),
+		LastError:            errors.New("OHNO"),
+		BundleSequenceNumber: 1,
+	}
+	status, ok := test.manager.FederationStatusFor(trustDomain)
+	require.True(t, ok)
+	require.Equal(t, expectStatus, status)
+	require.Equal(t, []FederationStatus{expectStatus}, test.manager.FederationStatuses())
+
+	_, ok = test.manager.FederationStatusFor(spiffeid.RequireTrustDomainFromString("unknown.test"))
+	require.False(t, ok)
+}
+
+func TestManagedBundleUpdaterStatus(t *testing.T) {
+	clk := clock.NewMock(t)
+	localBundle := spiffebundle.FromX509Authorities(trustDomain, []*x509.Certificate{createCACertificate(t, "local")})
+	localBundle.SetSequenceNumber(1)
+	endpointBundle := spiffebundle.FromX509Authorities(trustDomain, []*x509.Certificate{createCACertificate(t, "endpoint")})
+	endpointBundle.SetSequenceNumber(2)
+	certExpiresAt := clk.Now().Add(time.Hour)
+
+	fakeUpdater := newFakeBundleUpdater(BundleUpdaterConfig{TrustDomain: trustDomain})
+	fakeUpdater.SetBundles(localBundle, endpointBundle)
+	fakeUpdater.updateErr = nil
+	fakeUpdater.endpointCertificateExpiresAt = certExpiresAt
+	updater := &managedBundleUpdater{
+		BundleUpdater: fakeUpdater,
+		clock:         clk,
+		status:        FederationStatus{TrustDomain: trustDomain},
+	}
+
+	// A successful refresh records the sequence number of the endpoint bundle
+	_, _, err := updater.UpdateBundle(context.Background())
+	require.NoError(t, err)
+	refreshedAt := clk.Now()
+	require.Equal(t, FederationStatus{
+		TrustDomain:                  trustDomain,
+		LastRefresh:                  refreshedAt,
+		LastRefreshAttempt:           refreshedAt,
+		BundleSequenceNumber:         2,
+		EndpointCertificateExpiresAt: certExpiresAt,
+	}, updater.Status())
+
+	// A failed refresh keeps the time of the last successful one
+	clk.Add(time.Minute)
+	fakeUpdater.SetBundles(localBundle, nil)
+	fakeUpdater.updateErr = errors.New("OHNO")
+	_, _, err = updater.UpdateBundle(context.Background())
+	require.EqualError(t, err, "OHNO")
+	require.Equal(t, FederationStatus{
+		TrustDomain:                  trustDomain,
+		LastRefresh:                  refreshedAt,
+		LastRefreshAttempt:           clk.Now(),
+		LastError:                    errors.New("OHNO"),
+		BundleSequenceNumber:         1,
+		EndpointCertificateExpiresAt: certExpiresAt,
+	}, updater.Status())
+}
+
 type managerTest struct {
 	t                 *testing.T
 	clock             *clock.Mock
@@ -368,16 +451,19 @@ func (test *managerTest) bundleUpdaterFor(td spiffeid.TrustDomain) (*fakeBundleU
 }
 
 type fakeBundleUpdater struct {
-	mtx            sync.Mutex
-	localBundle    *spiffebundle.Bundle
-	endpointBundle *spiffebundle.Bundle
-	updateCount    int
-	config         BundleUpdaterConfig
+	mtx                          sync.Mutex
+	localBundle                  *spiffebundle.Bundle
+	endpointBundle               *spiffebundle.Bundle
+	updateErr                    error
+	updateCount                  int
+	config                       BundleUpdaterConfig
+	endpointCertificateExpiresAt time.Time
 }
 
 func newFakeBundleUpdater(config BundleUpdaterConfig) *fakeBundleUpdater {
 	return &fakeBundleUpdater{
-		config: config,
+		config:    config,
+		updateErr: errors.New("OHNO"),
 	}
 }
 
@@ -398,7 +484,13 @@ func (u *fakeBundleUpdater) UpdateBundle(context.Context) (*spiffebundle.Bundle,
 	u.mtx.Lock()
 	defer u.mtx.Unlock()
 	u.updateCount++
-	return u.localBundle, u.endpointBundle, errors.New("OHNO")
+	return u.localBundle, u.endpointBundle, u.updateErr
+}
+
+func (u *fakeBundleUpdater) EndpointCertificateExpiresAt() time.Time {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	return u.endpointCertificateExpiresAt
 }
 
 func (u *fakeBundleUpdater) GetTrustDomainConfig() TrustDomainConfig {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
@@ -38,6 +39,11 @@ type BundleUpdater interface {
 
 	// SetTrustDomainConfig sets the configuration for the updater
 	SetTrustDomainConfig(TrustDomainConfig) bool
+
+	// EndpointCertificateExpiresAt returns when the certificate presented by
+	// the bundle endpoint on the last successful download expires, or the
+	// zero time if unknown.
+	EndpointCertificateExpiresAt() time.Time
 }
 
 type bundleUpdater struct {
@@ -47,6 +53,9 @@ type bundleUpdater struct {
 
 	trustDomainConfigMtx sync.Mutex
 	trustDomainConfig    TrustDomainConfig
+
+	endpointCertificateMtx       sync.Mutex
+	endpointCertificateExpiresAt time.Time
 }
 
 func NewBundleUpdater(config BundleUpdaterConfig) BundleUpdater {
@@ -79,6 +88,10 @@ func (u *bundleUpdater) UpdateBundle(ctx context.Context) (*spiffebundle.Bundle,
 		return localFederatedBundleOrNil, nil, fmt.Errorf("failed to fetch federated bundle from endpoint: %w", err)
 	}
 
+	u.endpointCertificateMtx.Lock()
+	u.endpointCertificateExpiresAt = client.EndpointCertificateExpiresAt()
+	u.endpointCertificateMtx.Unlock()
+
 	if localFederatedBundleOrNil != nil && fetchedFederatedBundle.Equal(localFederatedBundleOrNil) {
 		return localFederatedBundleOrNil, nil, nil
 	}
@@ -112,6 +125,12 @@ func (u *bundleUpdater) SetTrustDomainConfig(trustDomainConfig TrustDomainConfig
 	return false
 }
 
+func (u *bundleUpdater) EndpointCertificateExpiresAt() time.Time {
+	u.endpointCertificateMtx.Lock()
+	defer u.endpointCertificateMtx.Unlock()
+	return u.endpointCertificateExpiresAt
+}
+
 func (u *bundleUpdater) newClient(ctx context.Context, trustDomainConfig TrustDomainConfig) (Client, error) {
 	clientConfig := ClientConfig{
 		TrustDomain: u.td,
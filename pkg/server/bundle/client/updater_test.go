@@ -64,7 +64,8 @@ func TestBundleUpdaterUpdateBundle(t *testing.T) {
 			endpointBundle: bundle2,
 			storedBundle:   bundle2,
 			client: fakeClient{
-				bundle: bundle2,
+				bundle:        bundle2,
+				certExpiresAt: time.Now().Add(time.Hour),
 			},
 		},
 		{
@@ -111,6 +112,7 @@ func TestBundleUpdaterUpdateBundle(t *testing.T) {
 				return
 			}
 			require.NoError(t, err)
+			require.Equal(t, testCase.client.certExpiresAt, updater.EndpointCertificateExpiresAt())
 			if testCase.localBundle != nil {
 				require.NotNil(t, localBundle)
 				localBundleProto, err := bundleutil.SPIFFEBundleToProto(testCase.localBundle)
@@ -180,14 +182,19 @@ func TestBundleUpdaterConfiguration(t *testing.T) {
 }
 
 type fakeClient struct {
-	bundle *spiffebundle.Bundle
-	err    error
+	bundle        *spiffebundle.Bundle
+	err           error
+	certExpiresAt time.Time
 }
 
 func (c fakeClient) FetchBundle(context.Context) (*spiffebundle.Bundle, error) {
 	return c.bundle, c.err
 }
 
+func (c fakeClient) EndpointCertificateExpiresAt() time.Time {
+	return c.certExpiresAt
+}
+
 func createCACertificate(t *testing.T, cn string) *x509.Certificate {
 	now := time.Now()
 	cert, _ := spiretest.SelfSignCertificate(t, &x509.Certificate{
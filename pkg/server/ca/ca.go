@@ -23,6 +23,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/credvalidator"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 const (
@@ -85,6 +86,10 @@ type WorkloadX509SVIDParams struct {
 
 	// Subject of the SVID. Default subject is used if it is empty.
 	Subject pkix.Name
+
+	// CredentialProfile of the registration entry the SVID is issued for,
+	// if any.
+	CredentialProfile *common.CredentialProfile
 }
 
 // WorkloadJWTSVIDParams are parameters relevant to workload JWT-SVID creation
@@ -102,6 +107,10 @@ type WorkloadJWTSVIDParams struct {
 	// IncludeJTI, when true, instructs the CA to include a unique "jti" (JWT ID)
 	// claim in the issued token.
 	IncludeJTI bool
+
+	// ClaimSource is the registration entry data that claim templates are
+	// rendered with, if any.
+	ClaimSource *credtemplate.ClaimSource
 }
 
 // WorkloadWITSVIDParams are parameters relevant to workload WIT-SVID creation
@@ -115,6 +124,10 @@ type WorkloadWITSVIDParams struct {
 
 	// PublicKey is used for the cnf claim
 	PublicKey jose.JSONWebKey
+
+	// ClaimSource is the registration entry data that claim templates are
+	// rendered with, if any.
+	ClaimSource *credtemplate.ClaimSource
 }
 
 type X509CA struct {
@@ -345,6 +358,8 @@ func (ca *CA) SignWorkloadX509SVID(ctx context.Context, params WorkloadX509SVIDP
 		DNSNames:    params.DNSNames,
 		TTL:         params.TTL,
 		Subject:     params.Subject,
+
+		CredentialProfile: params.CredentialProfile,
 	})
 	if err != nil {
 		return nil, err
@@ -374,6 +389,7 @@ func (ca *CA) SignWorkloadJWTSVID(ctx context.Context, params WorkloadJWTSVIDPar
 		TTL:           params.TTL,
 		ExpirationCap: jwtKey.NotAfter,
 		IncludeJTI:    params.IncludeJTI,
+		ClaimSource:   params.ClaimSource,
 	})
 	if err != nil {
 		return "", err
@@ -408,6 +424,7 @@ func (ca *CA) SignWorkloadWITSVID(ctx context.Context, params WorkloadWITSVIDPar
 		PublicKey:     params.PublicKey,
 		TTL:           params.TTL,
 		ExpirationCap: witKey.NotAfter,
+		ClaimSource:   params.ClaimSource,
 	})
 	if err != nil {
 		return "", err
@@ -6,7 +6,9 @@ import (
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 var (
@@ -56,6 +58,8 @@ type EntryIterator interface {
 	Next(ctx context.Context) bool
 	// Entry returns the next entry from the data source.
 	Entry() *types.Entry
+	// CredentialProfile returns the credential profile of the next entry, if any.
+	CredentialProfile() *common.CredentialProfile
 	// Err returns an error encountered when attempting to process entries from the data source.
 	Err() error
 }
@@ -91,6 +95,10 @@ type Agent struct {
 type FullEntryCache struct {
 	aliases map[string][]aliasEntry
 	entries map[string][]*types.Entry
+
+	// The Entry type does not carry the credential profiles of entries,
+	// which are needed when signing their X509-SVIDs.
+	credentialProfiles map[string]*common.CredentialProfile
 }
 
 type selectorSet map[Selector]struct{}
@@ -112,6 +120,7 @@ func Build(ctx context.Context, trustDomain string, entryIter EntryIterator, age
 	bysel := make(map[Selector][]aliasInfo)
 
 	entries := make(map[string][]*types.Entry)
+	credentialProfiles := make(map[string]*common.CredentialProfile)
 	for entryIter.Next(ctx) {
 		entry := entryIter.Entry()
 		if entry.ParentId.TrustDomain != trustDomain {
@@ -130,12 +139,15 @@ func Build(ctx context.Context, trustDomain string, entryIter EntryIterator, age
 				},
 				selectors: selectorSetFromProto(entry.Selectors),
 			}
-			for selector := range alias.selectors {
+			for selector := range candidateSelectors(alias.selectors) {
 				bysel[selector] = append(bysel[selector], alias)
 			}
 			continue
 		}
 		entries[parentID] = append(entries[parentID], entry)
+		if credentialProfile := entryIter.CredentialProfile(); credentialProfile != nil {
+			credentialProfiles[entry.Id] = credentialProfile
+		}
 	}
 	if err := entryIter.Err(); err != nil {
 		return nil, err
@@ -158,13 +170,15 @@ func Build(ctx context.Context, trustDomain string, entryIter EntryIterator, age
 		// add one twice.
 		clearStringSet(aliasSeen)
 		for s := range agentSelectors {
-			for _, alias := range bysel[s] {
-				if _, ok := aliasSeen[alias.entry.Id]; ok {
-					continue
-				}
-				aliasSeen[alias.entry.Id] = struct{}{}
-				if isSubset(alias.selectors, agentSelectors) {
-					aliases[agentID] = append(aliases[agentID], alias.aliasEntry)
+			for _, key := range selector.IndexKeys(selector.Selector(s)) {
+				for _, alias := range bysel[Selector(key)] {
+					if _, ok := aliasSeen[alias.entry.Id]; ok {
+						continue
+					}
+					aliasSeen[alias.entry.Id] = struct{}{}
+					if matchesAll(alias.selectors, agentSelectors) {
+						aliases[agentID] = append(aliases[agentID], alias.aliasEntry)
+					}
 				}
 			}
 		}
@@ -176,6 +190,8 @@ func Build(ctx context.Context, trustDomain string, entryIter EntryIterator, age
 	return &FullEntryCache{
 		aliases: aliases,
 		entries: entries,
+
+		credentialProfiles: credentialProfiles,
 	}, nil
 }
 
@@ -186,7 +202,7 @@ func (c *FullEntryCache) LookupAuthorizedEntries(agentID spiffeid.ID, requestedE
 	foundEntries := make(map[string]api.ReadOnlyEntry)
 	c.crawl(agentID.Path(), seen, func(entry *types.Entry) bool {
 		if _, ok := requestedEntries[entry.Id]; ok {
-			foundEntries[entry.Id] = api.NewReadOnlyEntry(entry)
+			foundEntries[entry.Id] = c.readOnlyEntry(entry)
 		}
 
 		return len(foundEntries) != len(requestedEntries)
@@ -202,13 +218,17 @@ func (c *FullEntryCache) GetAuthorizedEntries(agentID spiffeid.ID) []api.ReadOnl
 
 	foundEntries := []api.ReadOnlyEntry{}
 	c.crawl(agentID.Path(), seen, func(entry *types.Entry) bool {
-		foundEntries = append(foundEntries, api.NewReadOnlyEntry(entry))
+		foundEntries = append(foundEntries, c.readOnlyEntry(entry))
 		return true
 	})
 
 	return foundEntries
 }
 
+func (c *FullEntryCache) readOnlyEntry(entry *types.Entry) api.ReadOnlyEntry {
+	return api.NewReadOnlyEntryWithCredentialProfile(entry, c.credentialProfiles[entry.Id])
+}
+
 // Crawl the list of registration entries calling the visit function on all of them.
 // visit(entry) returns a boolean indicating if we should continue iterating (if true)
 // or if we should terminate the crawl (if false).
@@ -268,14 +288,25 @@ func clearStringSet(set stringSet) {
 	}
 }
 
-func isSubset(sub, whole selectorSet) bool {
-	if len(sub) > len(whole) {
-		return false
-	}
+// matchesAll returns true if the selectors in whole satisfy all of the
+// selectors in sub, which may be selector expressions.
+func matchesAll(sub, whole selectorSet) bool {
 	for s := range sub {
-		if _, ok := whole[s]; !ok {
+		if !selector.Match(selector.Selector(s), whole) {
 			return false
 		}
 	}
 	return true
 }
+
+// candidateSelectors returns the index keys of the selectors in
+// set, expanding selector expressions into their candidate selectors.
+func candidateSelectors(set selectorSet) selectorSet {
+	candidates := make(selectorSet, len(set))
+	for s := range set {
+		for _, candidate := range selector.Candidates(selector.Selector(s)) {
+			candidates[Selector(candidate)] = struct{}{}
+		}
+	}
+	return candidates
+}
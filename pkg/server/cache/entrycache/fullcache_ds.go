@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/pkg/server/api"
@@ -22,22 +23,26 @@ var (
 	listEntriesRequestPageSize int32 = 10000
 )
 
-// BuildFromDataStore builds a Cache using the provided datastore as the data source
-func BuildFromDataStore(ctx context.Context, trustDomain string, ds datastore.DataStore) (*FullEntryCache, error) {
-	return Build(ctx, trustDomain, makeEntryIteratorDS(ds), makeAgentIteratorDS(ds))
+// BuildFromDataStore builds a Cache using the provided datastore as the data
+// source. The clock determines which entries are active.
+func BuildFromDataStore(ctx context.Context, trustDomain string, ds datastore.DataStore, clk clock.Clock) (*FullEntryCache, error) {
+	return Build(ctx, trustDomain, makeEntryIteratorDS(ds, clk), makeAgentIteratorDS(ds))
 }
 
 type entryIteratorDS struct {
 	ds              datastore.DataStore
+	clk             clock.Clock
 	entries         []*types.Entry
+	profiles        []*common.CredentialProfile
 	next            int
 	err             error
 	paginationToken string
 }
 
-func makeEntryIteratorDS(ds datastore.DataStore) EntryIterator {
+func makeEntryIteratorDS(ds datastore.DataStore, clk clock.Clock) EntryIterator {
 	return &entryIteratorDS{
-		ds: ds,
+		ds:  ds,
+		clk: clk,
 	}
 }
 
@@ -69,6 +74,10 @@ func (it *entryIteratorDS) Next(ctx context.Context) bool {
 			it.err = err
 			return false
 		}
+		it.profiles = make([]*common.CredentialProfile, 0, len(resp.Entries))
+		for _, entry := range resp.Entries {
+			it.profiles = append(it.profiles, entry.GetAdditionalAttributes().GetCredentialProfile())
+		}
 	}
 	if it.next >= len(it.entries) {
 		return false
@@ -78,6 +87,7 @@ func (it *entryIteratorDS) Next(ctx context.Context) bool {
 }
 
 func (it *entryIteratorDS) filterEntries(in []*common.RegistrationEntry) []*common.RegistrationEntry {
+	now := it.clk.Now()
 	out := make([]*common.RegistrationEntry, 0, len(in))
 	for _, entry := range in {
 		// Filter out entries with invalid SPIFFE IDs. Operators are notified
@@ -89,6 +99,12 @@ func (it *entryIteratorDS) filterEntries(in []*common.RegistrationEntry) []*comm
 		if _, err := spiffeid.FromString(entry.ParentId); err != nil {
 			continue
 		}
+		// Filter out entries that are not active yet or have expired. The
+		// cache is rebuilt periodically, so entries are picked up once they
+		// activate.
+		if !api.RegistrationEntryIsActive(entry, now) {
+			continue
+		}
 		out = append(out, entry)
 	}
 	return out
@@ -98,6 +114,10 @@ func (it *entryIteratorDS) Entry() *types.Entry {
 	return it.entries[it.next-1]
 }
 
+func (it *entryIteratorDS) CredentialProfile() *common.CredentialProfile {
+	return it.profiles[it.next-1]
+}
+
 func (it *entryIteratorDS) Err() error {
 	return it.err
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,10 +20,11 @@ import (
 
 func TestEntryIteratorDS(t *testing.T) {
 	ds := fakedatastore.New(t)
+	clk := clock.NewMock(t)
 	ctx := context.Background()
 
 	t.Run("no entries", func(t *testing.T) {
-		it := makeEntryIteratorDS(ds)
+		it := makeEntryIteratorDS(ds, clk)
 		assert.False(t, it.Next(ctx))
 		assert.NoError(t, it.Err())
 	})
@@ -54,7 +56,7 @@ func TestEntryIteratorDS(t *testing.T) {
 	}
 
 	t.Run("existing entries - multiple pages", func(t *testing.T) {
-		it := makeEntryIteratorDS(ds)
+		it := makeEntryIteratorDS(ds, clk)
 		var entries []*types.Entry
 
 		for range numEntries {
@@ -72,7 +74,7 @@ func TestEntryIteratorDS(t *testing.T) {
 	})
 
 	t.Run("datastore error", func(t *testing.T) {
-		it := makeEntryIteratorDS(ds)
+		it := makeEntryIteratorDS(ds, clk)
 		for range listEntriesRequestPageSize {
 			assert.True(t, it.Next(ctx))
 			require.NoError(t, it.Err())
@@ -84,6 +86,48 @@ func TestEntryIteratorDS(t *testing.T) {
 		// it.Next() returns false after encountering an error on previous call to Next()
 		assert.False(t, it.Next(ctx))
 	})
+
+	t.Run("inactive entries", func(t *testing.T) {
+		ds := fakedatastore.New(t)
+		now := clk.Now()
+		pending := createRegistrationEntry(ctx, t, ds, &common.RegistrationEntry{
+			ParentId:  parentID,
+			SpiffeId:  spiffeIDPrefix + "-pending",
+			Selectors: selectors,
+			NotBefore: now.Add(time.Hour).Unix(),
+		})
+		createRegistrationEntry(ctx, t, ds, &common.RegistrationEntry{
+			ParentId:    parentID,
+			SpiffeId:    spiffeIDPrefix + "-expired",
+			Selectors:   selectors,
+			EntryExpiry: now.Add(-time.Hour).Unix(),
+		})
+		active := createRegistrationEntry(ctx, t, ds, &common.RegistrationEntry{
+			ParentId:    parentID,
+			SpiffeId:    spiffeIDPrefix + "-active",
+			Selectors:   selectors,
+			NotBefore:   now.Add(-time.Hour).Unix(),
+			EntryExpiry: now.Add(2 * time.Hour).Unix(),
+		})
+
+		listEntryIDs := func() []string {
+			var entryIDs []string
+			it := makeEntryIteratorDS(ds, clk)
+			for it.Next(ctx) {
+				entryIDs = append(entryIDs, it.Entry().Id)
+			}
+			require.NoError(t, it.Err())
+			return entryIDs
+		}
+
+		assert.ElementsMatch(t, []string{active.EntryId}, listEntryIDs())
+
+		clk.Add(time.Hour)
+		assert.ElementsMatch(t, []string{pending.EntryId, active.EntryId}, listEntryIDs())
+
+		clk.Add(time.Hour)
+		assert.ElementsMatch(t, []string{pending.EntryId}, listEntryIDs())
+	})
 }
 
 func TestAgentIteratorDS(t *testing.T) {
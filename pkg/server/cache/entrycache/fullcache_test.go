@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
@@ -125,7 +126,7 @@ func TestCache(t *testing.T) {
 	createAttestedNode(t, ds, node)
 	setNodeSelectors(ctx, t, ds, entryIDs[1], a1, b2)
 
-	cache, err := BuildFromDataStore(context.Background(), "example.org", ds)
+	cache, err := BuildFromDataStore(context.Background(), "example.org", ds, clock.New())
 	assert.NoError(t, err)
 
 	expected := entries[:3]
@@ -133,6 +134,43 @@ func TestCache(t *testing.T) {
 	assertAuthorizedEntries(t, cache, rootID, entries, expected...)
 }
 
+func TestCacheCredentialProfiles(t *testing.T) {
+	ds := fakedatastore.New(t)
+	ctx := context.Background()
+
+	agentID := spiffeid.RequireFromString("spiffe://example.org/agent")
+	profile := &common.CredentialProfile{SubjectOrganization: []string{"ACME"}}
+
+	withProfile := createRegistrationEntry(ctx, t, ds, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  "spiffe://example.org/with-profile",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+		AdditionalAttributes: &common.RegistrationEntry_AdditionalAttributes{
+			CredentialProfile: profile,
+		},
+	})
+	withoutProfile := createRegistrationEntry(ctx, t, ds, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  "spiffe://example.org/without-profile",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	})
+
+	cache, err := BuildFromDataStore(ctx, "example.org", ds, clock.New())
+	require.NoError(t, err)
+
+	profiles := make(map[string]*common.CredentialProfile)
+	for _, entry := range cache.GetAuthorizedEntries(agentID) {
+		profiles[entry.GetId()] = entry.GetCredentialProfile()
+	}
+	require.Len(t, profiles, 2)
+	spiretest.AssertProtoEqual(t, profile, profiles[withProfile.EntryId])
+	require.Nil(t, profiles[withoutProfile.EntryId])
+
+	found := cache.LookupAuthorizedEntries(agentID, map[string]struct{}{withProfile.EntryId: {}})
+	entry := found[withProfile.EntryId]
+	spiretest.AssertProtoEqual(t, profile, entry.GetCredentialProfile())
+}
+
 func TestCacheAfterRenamingTrustDomain(t *testing.T) {
 	ds := fakedatastore.New(t)
 	ctx := context.Background()
@@ -177,7 +215,7 @@ func TestCacheAfterRenamingTrustDomain(t *testing.T) {
 	b2 := &common.Selector{Type: "b", Value: "2"}
 	setNodeSelectors(ctx, t, ds, "spiffe://example2.org/agent", a1, b2)
 
-	cache, err := BuildFromDataStore(context.Background(), "example2.org", ds)
+	cache, err := BuildFromDataStore(context.Background(), "example2.org", ds, clock.New())
 	assert.NoError(t, err)
 
 	expected := entries[2:3]
@@ -258,7 +296,7 @@ func TestFullCacheNodeAliasing(t *testing.T) {
 	setNodeSelectors(ctx, t, ds, agentIDs[0].String(), s1, s2)
 	setNodeSelectors(ctx, t, ds, agentIDs[1].String(), s1, s3)
 
-	cache, err := BuildFromDataStore(context.Background(), "example.org", ds)
+	cache, err := BuildFromDataStore(context.Background(), "example.org", ds, clock.New())
 	assert.NoError(t, err)
 
 	assertAuthorizedEntries(t, cache, agentIDs[0], workloadEntries, workloadEntries[:2]...)
@@ -266,6 +304,56 @@ func TestFullCacheNodeAliasing(t *testing.T) {
 	assertAuthorizedEntries(t, cache, agentIDs[2], workloadEntries, workloadEntries[2])
 }
 
+func TestFullCacheNodeAliasingWithSelectorExpressions(t *testing.T) {
+	ds := fakedatastore.New(t)
+	ctx := context.Background()
+
+	const serverID = "spiffe://example.org/spire/server"
+	agentIDs := []spiffeid.ID{
+		spiffeid.RequireFromString("spiffe://example.org/spire/agent/agent1"),
+		spiffeid.RequireFromString("spiffe://example.org/spire/agent/agent2"),
+		spiffeid.RequireFromString("spiffe://example.org/spire/agent/agent3"),
+	}
+
+	// The alias matches agents with either s:1 or s:2, but not s:3
+	nodeAliasEntry := createRegistrationEntry(ctx, t, ds, &common.RegistrationEntry{
+		ParentId: serverID,
+		SpiffeId: "spiffe://example.org/alias",
+		Selectors: []*common.Selector{
+			{Type: "|s", Value: "1|2"},
+			{Type: "!s", Value: "3"},
+		},
+	})
+
+	workloadEntries := []*common.RegistrationEntry{
+		createRegistrationEntry(ctx, t, ds, &common.RegistrationEntry{
+			ParentId:  nodeAliasEntry.SpiffeId,
+			SpiffeId:  "spiffe://example.org/workload",
+			Selectors: []*common.Selector{{Type: "not", Value: "relevant"}},
+		}),
+	}
+
+	for i, agentID := range agentIDs {
+		createAttestedNode(t, ds, &common.AttestedNode{
+			SpiffeId:            agentID.String(),
+			AttestationDataType: testNodeAttestor,
+			CertSerialNumber:    strconv.Itoa(i),
+			CertNotAfter:        time.Now().Add(24 * time.Hour).Unix(),
+		})
+	}
+
+	setNodeSelectors(ctx, t, ds, agentIDs[0].String(), &common.Selector{Type: "s", Value: "1"})
+	setNodeSelectors(ctx, t, ds, agentIDs[1].String(), &common.Selector{Type: "s", Value: "2"}, &common.Selector{Type: "s", Value: "3"})
+	setNodeSelectors(ctx, t, ds, agentIDs[2].String(), &common.Selector{Type: "s", Value: "2"})
+
+	cache, err := BuildFromDataStore(context.Background(), "example.org", ds, clock.New())
+	assert.NoError(t, err)
+
+	assertAuthorizedEntries(t, cache, agentIDs[0], workloadEntries, workloadEntries[0])
+	assertAuthorizedEntries(t, cache, agentIDs[1], workloadEntries)
+	assertAuthorizedEntries(t, cache, agentIDs[2], workloadEntries, workloadEntries[0])
+}
+
 func TestFullCacheExcludesNodeSelectorMappedEntriesForExpiredAgents(t *testing.T) {
 	// This test verifies that the cache contains no workloads parented to alias entries
 	// that are only associated with an expired agent.
@@ -454,7 +542,7 @@ func TestFullCacheExcludesNodeSelectorMappedEntriesForExpiredAgents(t *testing.T
 		workloadEntries[i] = createRegistrationEntry(ctx, t, ds, workloadEntriesToCreate[i])
 	}
 
-	c, err := BuildFromDataStore(ctx, "example.org", ds)
+	c, err := BuildFromDataStore(ctx, "example.org", ds, clock.New())
 	require.NoError(t, err)
 	require.NotNil(t, c)
 
@@ -549,7 +637,7 @@ func BenchmarkBuildSQL(b *testing.B) {
 	}
 
 	for b.Loop() {
-		_, err := BuildFromDataStore(ctx, "example.org", ds)
+		_, err := BuildFromDataStore(ctx, "example.org", ds, clock.New())
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -561,8 +649,9 @@ func makeAgentID(i int) spiffeid.ID {
 }
 
 type entryIterator struct {
-	entries []*types.Entry
-	next    int
+	entries  []*types.Entry
+	profiles map[string]*common.CredentialProfile
+	next     int
 }
 
 func makeEntryIterator(entries []*types.Entry) *entryIterator {
@@ -583,6 +672,10 @@ func (it *entryIterator) Entry() *types.Entry {
 	return it.entries[it.next-1]
 }
 
+func (it *entryIterator) CredentialProfile() *common.CredentialProfile {
+	return it.profiles[it.entries[it.next-1].Id]
+}
+
 func (it *entryIterator) Err() error {
 	return nil
 }
@@ -628,6 +721,10 @@ func (e *errorEntryIterator) Entry() *types.Entry {
 	return nil
 }
 
+func (e *errorEntryIterator) CredentialProfile() *common.CredentialProfile {
+	return nil
+}
+
 type errorAgentIterator struct{}
 
 func (e *errorAgentIterator) Next(context.Context) bool {
@@ -929,7 +1026,7 @@ func setupLookupTest(tb testing.TB, count int) (*FullEntryCache, []string) {
 		entries = append(entries, entry.EntryId)
 	}
 
-	cache, err := BuildFromDataStore(ctx, "example.org", ds)
+	cache, err := BuildFromDataStore(ctx, "example.org", ds, clock.New())
 	assert.NoError(tb, err)
 
 	return cache, entries
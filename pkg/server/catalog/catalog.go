@@ -23,6 +23,7 @@ import (
 	km_telemetry "github.com/spiffe/spire/pkg/common/telemetry/server/keymanager"
 	"github.com/spiffe/spire/pkg/server/cache/dscache"
 	"github.com/spiffe/spire/pkg/server/datastore"
+	ds_kv "github.com/spiffe/spire/pkg/server/datastore/kvstore"
 	ds_sql "github.com/spiffe/spire/pkg/server/datastore/sqlstore"
 	"github.com/spiffe/spire/pkg/server/hostservice/agentstore"
 	"github.com/spiffe/spire/pkg/server/hostservice/identityprovider"
@@ -86,8 +87,24 @@ type Repository struct {
 	catalog  *catalog.Catalog
 }
 
+// builtInDataStore is a DataStore implementation that is compiled into the
+// server and configured directly instead of through the plugin catalog.
+type builtInDataStore interface {
+	datastore.DataStore
+	io.Closer
+	Configure(ctx context.Context, hclConfiguration string) error
+	Validate(ctx context.Context, coreConfig catalog.CoreConfig, configuration string) (*configv1.ValidateResponse, error)
+}
+
+// builtInDataStores holds the constructors for the built-in DataStore
+// implementations, keyed by plugin name.
+var builtInDataStores = map[string]func(log logrus.FieldLogger) builtInDataStore{
+	ds_sql.PluginName: func(log logrus.FieldLogger) builtInDataStore { return ds_sql.New(log) },
+	ds_kv.PluginName:  func(log logrus.FieldLogger) builtInDataStore { return ds_kv.New(log) },
+}
+
 type dsConfigurer struct {
-	ds *ds_sql.Plugin
+	ds builtInDataStore
 }
 
 func (c *dsConfigurer) Configure(ctx context.Context, _ catalog.CoreConfig, configuration string) error {
@@ -157,14 +174,15 @@ func Load(ctx context.Context, config Config) (_ *Repository, err error) {
 		TrustDomain: config.TrustDomain,
 	}
 
-	// Strip out the Datastore plugin configuration and load the SQL plugin
+	// Strip out the Datastore plugin configuration and load it separately
+	// from the rest of the plugins. The built-in SQL plugin is loaded
 	// directly. This allows us to bypass gRPC and get rid of response limits.
 	dataStoreConfigs, pluginConfigs := config.PluginConfigs.FilterByType(dataStoreType)
-	sqlDataStore, err := loadSQLDataStore(ctx, config, coreConfig, dataStoreConfigs)
+	dataStore, dsCloser, err := loadDataStore(ctx, config, coreConfig, dataStoreConfigs)
 	if err != nil {
 		return nil, err
 	}
-	repo.dsCloser = sqlDataStore
+	repo.dsCloser = dsCloser
 
 	repo.catalog, err = catalog.Load(ctx, catalog.Config{
 		Log:           config.Log,
@@ -180,7 +198,6 @@ func Load(ctx context.Context, config Config) (_ *Repository, err error) {
 		return nil, err
 	}
 
-	var dataStore datastore.DataStore = sqlDataStore
 	_ = config.HealthChecker.AddCheck("catalog.datastore", &datastore.Health{
 		DataStore: dataStore,
 	})
@@ -194,6 +211,19 @@ func Load(ctx context.Context, config Config) (_ *Repository, err error) {
 	return repo, nil
 }
 
+// LoadDataStore loads only the DataStore plugin from the configuration. It is
+// used by offline tooling that needs access to the datastore without running
+// the server. The returned closer must be closed when the DataStore is no
+// longer needed.
+func LoadDataStore(ctx context.Context, config Config) (datastore.DataStore, io.Closer, error) {
+	coreConfig := catalog.CoreConfig{
+		TrustDomain: config.TrustDomain,
+	}
+
+	dataStoreConfigs, _ := config.PluginConfigs.FilterByType(dataStoreType)
+	return loadDataStore(ctx, config, coreConfig, dataStoreConfigs)
+}
+
 func ValidateConfig(ctx context.Context, config Config) (pluginNotes map[string][]string, err error) {
 	if c, ok := config.PluginConfigs.Find(nodeAttestorType, jointoken.PluginName); ok && c.IsEnabled() && c.IsExternal() {
 		return nil, errors.New("the built-in join_token node attestor cannot be overridden by an external plugin")
@@ -212,16 +242,40 @@ func ValidateConfig(ctx context.Context, config Config) (pluginNotes map[string]
 
 	pluginNotes = make(map[string][]string)
 	dataStoreConfigs, pluginConfigs := config.PluginConfigs.FilterByType(dataStoreType)
-	datastorePluginId := fmt.Sprintf("%s \"%s\"", dataStoreType, "sql")
-	if len(dataStoreConfigs) == 0 {
+	datastorePluginName := ds_sql.PluginName
+	if len(dataStoreConfigs) > 0 {
+		datastorePluginName = dataStoreConfigs[0].Name
+	}
+	datastorePluginId := fmt.Sprintf("%s \"%s\"", dataStoreType, datastorePluginName)
+	switch {
+	case len(dataStoreConfigs) == 0:
 		pluginNotes[datastorePluginId] = append(pluginNotes[datastorePluginId], "'datastore' must be configured")
-	} else {
+	case dataStoreConfigs[0].IsExternal():
+		dsNotes, err := catalog.ValidatePluginConfigs(ctx, catalog.Config{
+			Log:           config.Log,
+			CoreConfig:    coreConfig,
+			PluginConfigs: dataStoreConfigs[:1],
+			HostServices: []pluginsdk.ServiceServer{
+				metricsv1.MetricsServiceServer(metricsservice.V1(config.Metrics)),
+			},
+		}, new(externalDataStoreRepository))
+		maps.Copy(pluginNotes, dsNotes)
+		if err != nil {
+			return pluginNotes, err
+		}
+	default:
 		dsConfigString, err := catalog.GetPluginConfigString(dataStoreConfigs[0])
 		if err != nil {
 			return nil, fmt.Errorf("failed to get DataStore configuration: %w", err)
 		}
 
-		ds := ds_sql.New(config.Log)
+		newDataStore, ok := builtInDataStores[dataStoreConfigs[0].Name]
+		if !ok {
+			pluginNotes[datastorePluginId] = append(pluginNotes[datastorePluginId], unknownBuiltInDataStoreError(dataStoreConfigs[0].Name).Error())
+			break
+		}
+
+		ds := newDataStore(config.Log)
 		resp, err := ds.Validate(ctx, coreConfig, dsConfigString)
 		if err != nil {
 			pluginNotes[datastorePluginId] = append(pluginNotes[datastorePluginId], err.Error())
@@ -251,34 +305,65 @@ func ValidateConfig(ctx context.Context, config Config) (pluginNotes map[string]
 	return pluginNotes, err
 }
 
-func loadSQLDataStore(ctx context.Context, config Config, coreConfig catalog.CoreConfig, datastoreConfigs catalog.PluginConfigs) (*ds_sql.Plugin, error) {
+func loadDataStore(ctx context.Context, config Config, coreConfig catalog.CoreConfig, datastoreConfigs catalog.PluginConfigs) (datastore.DataStore, io.Closer, error) {
 	switch {
 	case len(datastoreConfigs) == 0:
-		return nil, errors.New("expecting a DataStore plugin")
+		return nil, nil, errors.New("expecting a DataStore plugin")
 	case len(datastoreConfigs) > 1:
-		return nil, errors.New("only one DataStore plugin is allowed")
+		return nil, nil, errors.New("only one DataStore plugin is allowed")
 	}
 
-	sqlConfig := datastoreConfigs[0]
+	dsConfig := datastoreConfigs[0]
+	newDataStore, isBuiltIn := builtInDataStores[dsConfig.Name]
+	switch {
+	case dsConfig.IsExternal() && isBuiltIn:
+		return nil, nil, fmt.Errorf("the built-in %q DataStore cannot be overridden by an external plugin", dsConfig.Name)
+	case dsConfig.IsExternal():
+		return loadExternalDataStore(ctx, config, coreConfig, dsConfig)
+	case !isBuiltIn:
+		return nil, nil, unknownBuiltInDataStoreError(dsConfig.Name)
+	}
 
-	if sqlConfig.Name != ds_sql.PluginName {
-		return nil, fmt.Errorf("pluggability for the DataStore is deprecated; only the built-in %q plugin is supported", ds_sql.PluginName)
+	ds, err := loadBuiltInDataStore(ctx, config, coreConfig, dsConfig, newDataStore)
+	if err != nil {
+		return nil, nil, err
 	}
-	if sqlConfig.IsExternal() {
-		return nil, fmt.Errorf("pluggability for the DataStore is deprecated; only the built-in %q plugin is supported", ds_sql.PluginName)
+	return ds, ds, nil
+}
+
+func unknownBuiltInDataStoreError(name string) error {
+	return fmt.Errorf("unknown built-in DataStore plugin %q; only the built-in %q and %q plugins or external plugins are supported", name, ds_sql.PluginName, ds_kv.PluginName)
+}
+
+func loadExternalDataStore(ctx context.Context, config Config, coreConfig catalog.CoreConfig, dsConfig catalog.PluginConfig) (datastore.DataStore, io.Closer, error) {
+	dsRepo := new(externalDataStoreRepository)
+	dsCatalog, err := catalog.Load(ctx, catalog.Config{
+		Log:           config.Log,
+		CoreConfig:    coreConfig,
+		PluginConfigs: catalog.PluginConfigs{dsConfig},
+		HostServices: []pluginsdk.ServiceServer{
+			metricsv1.MetricsServiceServer(metricsservice.V1(config.Metrics)),
+		},
+	}, dsRepo)
+	if err != nil {
+		return nil, nil, err
 	}
-	if sqlConfig.DataSource == nil {
-		sqlConfig.DataSource = catalog.FixedData("")
+	return dsRepo.GetDataStore(), dsCatalog, nil
+}
+
+func loadBuiltInDataStore(ctx context.Context, config Config, coreConfig catalog.CoreConfig, dsConfig catalog.PluginConfig, newDataStore func(logrus.FieldLogger) builtInDataStore) (builtInDataStore, error) {
+	if dsConfig.DataSource == nil {
+		dsConfig.DataSource = catalog.FixedData("")
 	}
 
-	dsLog := config.Log.WithField(telemetry.SubsystemName, sqlConfig.Name)
-	ds := ds_sql.New(dsLog)
+	dsLog := config.Log.WithField(telemetry.SubsystemName, dsConfig.Name)
+	ds := newDataStore(dsLog)
 	dsConf := &dsConfigurer{ds: ds}
-	if _, err := catalog.ConfigurePlugin(ctx, coreConfig, dsConf, sqlConfig.DataSource, ""); err != nil {
+	if _, err := catalog.ConfigurePlugin(ctx, coreConfig, dsConf, dsConfig.DataSource, ""); err != nil {
 		return nil, err
 	}
 
-	if sqlConfig.DataSource.IsDynamic() {
+	if dsConfig.DataSource.IsDynamic() {
 		config.Log.Warn("DataStore is not reconfigurable even with a dynamic data source")
 	}
 
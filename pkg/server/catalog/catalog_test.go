@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	commoncatalog "github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/server/catalog"
@@ -33,7 +34,7 @@ func Test(t *testing.T) {
 			expectErr: "the built-in join_token node attestor cannot be overridden by an external plugin",
 		},
 		{
-			desc: "datastore cannot be overridden",
+			desc: "built-in sql datastore cannot be overridden",
 			prepareConfig: func(dir string, config *catalog.Config) {
 				for i, pluginConfig := range config.PluginConfigs {
 					if pluginConfig.Type == "DataStore" {
@@ -41,7 +42,32 @@ func Test(t *testing.T) {
 					}
 				}
 			},
-			expectErr: `pluggability for the DataStore is deprecated; only the built-in "sql" plugin is supported`,
+			expectErr: `the built-in "sql" DataStore cannot be overridden by an external plugin`,
+		},
+		{
+			desc: "built-in kv datastore",
+			prepareConfig: func(dir string, config *catalog.Config) {
+				config.TrustDomain = spiffeid.RequireTrustDomainFromString("example.org")
+				for i, pluginConfig := range config.PluginConfigs {
+					if pluginConfig.Type == "DataStore" {
+						config.PluginConfigs[i].Name = "kv"
+						config.PluginConfigs[i].DataSource = commoncatalog.FixedData(fmt.Sprintf(`
+						database_path = %q
+					`, filepath.Join(dir, "datastore.db")))
+					}
+				}
+			},
+		},
+		{
+			desc: "unknown built-in datastore",
+			prepareConfig: func(dir string, config *catalog.Config) {
+				for i, pluginConfig := range config.PluginConfigs {
+					if pluginConfig.Type == "DataStore" {
+						config.PluginConfigs[i].Name = "etcd"
+					}
+				}
+			},
+			expectErr: `unknown built-in DataStore plugin "etcd"; only the built-in "sql" and "kv" plugins or external plugins are supported`,
 		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
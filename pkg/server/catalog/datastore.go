@@ -0,0 +1,46 @@
+package catalog
+
+import (
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/datastore"
+)
+
+// externalDataStoreRepository is the repository used to load an external
+// DataStore plugin. The built-in "sql" and "kv" DataStores do not go through
+// the catalog (see loadBuiltInDataStore), so the repository has no built-ins.
+// It is loaded on its own, ahead of the rest of the plugins, so that it can be
+// closed after them.
+type externalDataStoreRepository struct {
+	datastore.Repository
+}
+
+func (repo *externalDataStoreRepository) Plugins() map[string]catalog.PluginRepo {
+	return map[string]catalog.PluginRepo{
+		dataStoreType: repo,
+	}
+}
+
+func (repo *externalDataStoreRepository) Services() []catalog.ServiceRepo {
+	return nil
+}
+
+func (repo *externalDataStoreRepository) Binder() any {
+	return repo.SetDataStore
+}
+
+func (repo *externalDataStoreRepository) Constraints() catalog.Constraints {
+	return catalog.ExactlyOne()
+}
+
+func (repo *externalDataStoreRepository) Versions() []catalog.Version {
+	return []catalog.Version{dataStoreV1{}}
+}
+
+func (repo *externalDataStoreRepository) BuiltIns() []catalog.BuiltIn {
+	return nil
+}
+
+type dataStoreV1 struct{}
+
+func (dataStoreV1) New() catalog.Facade { return new(datastore.V1) }
+func (dataStoreV1) Deprecated() bool    { return false }
@@ -12,11 +12,14 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/tlspolicy"
 	loggerv1 "github.com/spiffe/spire/pkg/server/api/logger/v1"
+	"github.com/spiffe/spire/pkg/server/authorizedentries"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
+	"github.com/spiffe/spire/pkg/server/tenant"
 )
 
 type Config struct {
@@ -46,6 +49,10 @@ type Config struct {
 	// Directory to store runtime data
 	DataDir string
 
+	// Paths of the files holding private keys, such as the keys of the disk
+	// KeyManager. They are only included in snapshots on request.
+	KeyPaths []string
+
 	// Trust domain
 	TrustDomain spiffeid.TrustDomain
 
@@ -124,6 +131,10 @@ type Config struct {
 	// EventTimeout controls how long to wait for an event before giving up
 	EventTimeout time.Duration
 
+	// EntryTemplates are rendered into authorized entries for each matching
+	// agent.
+	EntryTemplates []*authorizedentries.EntryTemplate
+
 	// AuthPolicyEngineConfig determines the config for authz policy
 	AuthOpaPolicyEngineConfig *authpolicy.OpaEngineConfig
 
@@ -131,9 +142,26 @@ type Config struct {
 	// X509-SVID, are granted admin rights.
 	AdminIDs []spiffeid.ID
 
+	// Tenants partition the registration entries between tenant admins.
+	Tenants *tenant.Set
+
+	// CredentialProfilePolicy governs what the credential profiles of
+	// registration entries can set. Credential profiles are disabled when it
+	// allows no field.
+	CredentialProfilePolicy credtemplate.CredentialProfilePolicy
+
+	// ClaimTemplates render custom claims into workload JWT-SVIDs and
+	// WIT-SVIDs.
+	ClaimTemplates []*credtemplate.ClaimTemplate
+
 	// TLSPolicy determines the policy settings to apply to all TLS connections.
 	TLSPolicy tlspolicy.Policy
 
+	// EntryHistoryRetention is how long registration entry changes are kept
+	// in the entry history before they are pruned. When zero, a default is
+	// used.
+	EntryHistoryRetention time.Duration
+
 	// PruneAttestedNodesExpiredFor enables periodic removal of attested nodes
 	// with X509-SVID expiration date further than a given time interval in the
 	// past. Non-reattestable nodes are not pruned by default. Banned nodes are
@@ -22,6 +22,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/plugin/credentialcomposer"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 const (
@@ -97,6 +98,9 @@ type WorkloadX509SVIDParams struct {
 	DNSNames    []string
 	TTL         time.Duration
 	Subject     pkix.Name
+	// CredentialProfile, when set, customizes the X509-SVID. It is validated
+	// against the credential profile policy of the configuration.
+	CredentialProfile *common.CredentialProfile
 }
 
 type WorkloadJWTSVIDParams struct {
@@ -107,6 +111,9 @@ type WorkloadJWTSVIDParams struct {
 	// IncludeJTI, when true, causes the issued JWT-SVID to include a unique "jti"
 	// (JWT ID) claim, making each token individually auditable.
 	IncludeJTI bool
+	// ClaimSource is the registration entry data that claim templates are
+	// rendered with, if any.
+	ClaimSource *ClaimSource
 }
 
 type WorkloadWITSVIDParams struct {
@@ -114,6 +121,7 @@ type WorkloadWITSVIDParams struct {
 	PublicKey     jose.JSONWebKey
 	TTL           time.Duration
 	ExpirationCap time.Time
+	ClaimSource   *ClaimSource
 }
 
 type Config struct {
@@ -131,6 +139,15 @@ type Config struct {
 	CredentialComposers []credentialcomposer.CredentialComposer
 	NewSerialNumber     func() (*big.Int, error)
 	TLSPolicy           tlspolicy.Policy
+
+	// CredentialProfilePolicy governs what the credential profiles of
+	// registration entries can set. Credential profiles are rejected when it
+	// allows no field.
+	CredentialProfilePolicy CredentialProfilePolicy
+
+	// ClaimTemplates render custom claims into workload JWT-SVIDs and
+	// WIT-SVIDs.
+	ClaimTemplates []*ClaimTemplate
 }
 
 type Builder struct {
@@ -173,6 +190,9 @@ func NewBuilder(config Config) (*Builder, error) {
 	if config.NewSerialNumber == nil {
 		config.NewSerialNumber = x509util.NewSerialNumber
 	}
+	if err := ValidateCredentialProfilePolicy(config.CredentialProfilePolicy); err != nil {
+		return nil, err
+	}
 
 	serverID, err := idutil.ServerID(config.TrustDomain)
 	if err != nil {
@@ -312,6 +332,15 @@ func (b *Builder) BuildWorkloadX509SVIDTemplate(ctx context.Context, params Work
 		tmpl.DNSNames = params.DNSNames
 	}
 
+	// The credential profile is applied before the credential composers so
+	// that they have the final say.
+	if params.CredentialProfile != nil {
+		if err := ValidateCredentialProfile(params.CredentialProfile, b.config.CredentialProfilePolicy); err != nil {
+			return nil, err
+		}
+		applyCredentialProfile(tmpl, params.CredentialProfile)
+	}
+
 	for _, cc := range b.config.CredentialComposers {
 		attributes, err := cc.ComposeWorkloadX509SVID(ctx, params.SPIFFEID, params.PublicKey, x509SVIDAttributesFromTemplate(tmpl))
 		if err != nil {
@@ -364,6 +393,12 @@ func (b *Builder) BuildWorkloadJWTSVIDClaims(ctx context.Context, params Workloa
 		attributes.Claims["iss"] = b.config.JWTIssuer
 	}
 
+	// Claim templates are rendered before the credential composers so that
+	// they have the final say.
+	if err := renderClaims(ctx, attributes.Claims, b.config.ClaimTemplates, ClaimSVIDTypeJWT, b.config.TrustDomain, params.SPIFFEID, params.ClaimSource); err != nil {
+		return nil, err
+	}
+
 	for _, cc := range b.config.CredentialComposers {
 		var err error
 		attributes, err = cc.ComposeWorkloadJWTSVID(ctx, params.SPIFFEID, attributes)
@@ -415,6 +450,10 @@ func (b *Builder) BuildWorkloadWITSVIDClaims(ctx context.Context, params Workloa
 		claims["iss"] = b.config.WITIssuer
 	}
 
+	if err := renderClaims(ctx, claims, b.config.ClaimTemplates, ClaimSVIDTypeWIT, b.config.TrustDomain, params.SPIFFEID, params.ClaimSource); err != nil {
+		return nil, err
+	}
+
 	return claims, nil
 }
 
@@ -8,6 +8,7 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -20,11 +21,13 @@ import (
 	"github.com/go-jose/go-jose/v4"
 	"github.com/go-jose/go-jose/v4/jwt"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	credentialcomposerv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/plugin/server/credentialcomposer/v1"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/plugin/credentialcomposer"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/plugintest"
 	"github.com/spiffe/spire/test/testkey"
@@ -32,6 +35,17 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var (
+	claimSource = &credtemplate.ClaimSource{
+		EntryID:   "ENTRYID",
+		Hint:      "HINT",
+		Selectors: []*types.Selector{{Type: "k8s", Value: "ns:prod"}, {Type: "k8s", Value: "sa:default"}},
+		NodeSelectors: func(context.Context) ([]*types.Selector, error) {
+			return []*types.Selector{{Type: "k8s_psat", Value: "cluster:east"}}, nil
+		},
+	}
+)
+
 var (
 	ctx              = context.Background()
 	now              = time.Now().Add(time.Hour).Truncate(time.Minute)
@@ -63,6 +77,108 @@ func TestNewBuilderRequiresTrustDomain(t *testing.T) {
 	assert.EqualError(t, err, "trust domain must be set")
 }
 
+func TestNewBuilderValidatesCredentialProfilePolicy(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		policy    credtemplate.CredentialProfilePolicy
+		expectErr string
+	}{
+		{
+			desc: "unknown field",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields: []string{"uri_sans"},
+			},
+			expectErr: `unknown credential profile field "uri_sans"; expected one of ["subject_organization" "subject_organizational_unit" "extended_key_usages" "extensions"]`,
+		},
+		{
+			desc: "extended key usages without allowlist",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields: []string{credtemplate.CredentialProfileExtendedKeyUsages},
+			},
+			expectErr: `credential profile field "extended_key_usages" requires the allowed extended key usages to be set`,
+		},
+		{
+			desc: "allowlist without extended key usages field",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields:            []string{credtemplate.CredentialProfileSubjectOrganization},
+				AllowedExtendedKeyUsages: []string{"emailProtection"},
+			},
+			expectErr: `allowed extended key usages require the "extended_key_usages" credential profile field to be allowed`,
+		},
+		{
+			desc: "invalid extended key usage",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields:            []string{credtemplate.CredentialProfileExtendedKeyUsages},
+				AllowedExtendedKeyUsages: []string{"codeSigning"},
+			},
+			expectErr: `invalid extended key usage "codeSigning": expected a dotted OID or one of ["clientAuth" "emailProtection" "serverAuth" "timeStamping"]`,
+		},
+		{
+			desc: "anyExtendedKeyUsage",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields:            []string{credtemplate.CredentialProfileExtendedKeyUsages},
+				AllowedExtendedKeyUsages: []string{"2.5.29.37.0"},
+			},
+			expectErr: `extended key usage "2.5.29.37.0" cannot be allowed: it is the anyExtendedKeyUsage extended key usage`,
+		},
+		{
+			desc: "codeSigning by OID",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields:            []string{credtemplate.CredentialProfileExtendedKeyUsages},
+				AllowedExtendedKeyUsages: []string{"1.3.6.1.5.5.7.3.3"},
+			},
+			expectErr: `extended key usage "1.3.6.1.5.5.7.3.3" cannot be allowed: it is the codeSigning extended key usage`,
+		},
+		{
+			desc: "extensions without allowlist",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields: []string{credtemplate.CredentialProfileExtensions},
+			},
+			expectErr: `credential profile field "extensions" requires the allowed extension OIDs to be set`,
+		},
+		{
+			desc: "allowlist without extensions field",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields:        []string{credtemplate.CredentialProfileSubjectOrganization},
+				AllowedExtensionOIDs: []string{"1.2.3.4"},
+			},
+			expectErr: `allowed extension OIDs require the "extensions" credential profile field to be allowed`,
+		},
+		{
+			desc: "invalid extension OID",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields:        []string{credtemplate.CredentialProfileExtensions},
+				AllowedExtensionOIDs: []string{"1.2.x"},
+			},
+			expectErr: `invalid extension OID: invalid OID "1.2.x"`,
+		},
+		{
+			desc: "certificate policies extension",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields:        []string{credtemplate.CredentialProfileExtensions},
+				AllowedExtensionOIDs: []string{"2.5.29.32"},
+			},
+			expectErr: `extension OID "2.5.29.32" cannot be allowed: it is the certificate policies extension`,
+		},
+		{
+			desc: "authority information access extension",
+			policy: credtemplate.CredentialProfilePolicy{
+				AllowedFields:        []string{credtemplate.CredentialProfileExtensions},
+				AllowedExtensionOIDs: []string{"1.3.6.1.5.5.7.1.1"},
+			},
+			expectErr: `extension OID "1.3.6.1.5.5.7.1.1" cannot be allowed: it is the authority information access extension`,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := credtemplate.NewBuilder(credtemplate.Config{
+				TrustDomain:             td,
+				CredentialProfilePolicy: tc.policy,
+			})
+			assert.EqualError(t, err, tc.expectErr)
+		})
+	}
+}
+
 func TestNewBuilderSetsDefaults(t *testing.T) {
 	builder, err := credtemplate.NewBuilder(credtemplate.Config{
 		TrustDomain: td,
@@ -969,6 +1085,121 @@ func TestBuildWorkloadX509SVIDTemplate(t *testing.T) {
 			},
 			expectErr: "oh no",
 		},
+		{
+			desc: "with credential profile",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.CredentialProfilePolicy = credtemplate.CredentialProfilePolicy{
+					AllowedFields: []string{
+						credtemplate.CredentialProfileSubjectOrganization,
+						credtemplate.CredentialProfileSubjectOrganizationalUnit,
+						credtemplate.CredentialProfileExtendedKeyUsages,
+						credtemplate.CredentialProfileExtensions,
+					},
+					AllowedExtendedKeyUsages: []string{"clientAuth", "emailProtection", "1.3.6.1.4.1.311.20.2.2"},
+					AllowedExtensionOIDs:     []string{"1.2.3.4"},
+				}
+			},
+			overrideParams: func(params *credtemplate.WorkloadX509SVIDParams) {
+				params.CredentialProfile = &common.CredentialProfile{
+					SubjectOrganization:       []string{"ACME"},
+					SubjectOrganizationalUnit: []string{"Payments"},
+					ExtendedKeyUsages:         []string{"clientAuth", "1.3.6.1.5.5.7.3.4", "1.3.6.1.4.1.311.20.2.2"},
+					Extensions:                []*common.X509Extension{{Oid: "1.2.3.4", Critical: true, Value: []byte{5, 0}}},
+				}
+			},
+			overrideExpected: func(expected *x509.Certificate) {
+				expected.Subject.Organization = []string{"ACME"}
+				expected.Subject.OrganizationalUnit = []string{"Payments"}
+				expected.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageEmailProtection}
+				expected.UnknownExtKeyUsage = []asn1.ObjectIdentifier{{1, 3, 6, 1, 4, 1, 311, 20, 2, 2}}
+				expected.ExtraExtensions = []pkix.Extension{{Id: makeOID(1, 2, 3, 4), Critical: true, Value: []byte{5, 0}}}
+			},
+		},
+		{
+			desc: "with credential profile and composer",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.CredentialProfilePolicy.AllowedFields = []string{credtemplate.CredentialProfileSubjectOrganization}
+				config.CredentialComposers = []credentialcomposer.CredentialComposer{fakeCC{id: []byte{1, 2, 3, 4}, onlyCommonName: true}}
+			},
+			overrideParams: func(params *credtemplate.WorkloadX509SVIDParams) {
+				params.CredentialProfile = &common.CredentialProfile{
+					SubjectOrganization: []string{"ACME"},
+				}
+			},
+			overrideExpected: func(expected *x509.Certificate) {
+				// The composer is handed the subject customized by the
+				// credential profile.
+				expected.Subject.Organization = []string{"ACME"}
+				expected.Subject.CommonName = "OVERRIDE-[1 2 3 4]"
+			},
+		},
+		{
+			desc: "credential profile field not allowed",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.CredentialProfilePolicy.AllowedFields = []string{credtemplate.CredentialProfileSubjectOrganization}
+			},
+			overrideParams: func(params *credtemplate.WorkloadX509SVIDParams) {
+				params.CredentialProfile = &common.CredentialProfile{
+					SubjectOrganization: []string{"ACME"},
+					ExtendedKeyUsages:   []string{"emailProtection"},
+				}
+			},
+			expectErr: `credential profile field "extended_key_usages" is not allowed by the server configuration`,
+		},
+		{
+			desc: "credential profile extended key usage not allowed",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.CredentialProfilePolicy = credtemplate.CredentialProfilePolicy{
+					AllowedFields:            []string{credtemplate.CredentialProfileExtendedKeyUsages},
+					AllowedExtendedKeyUsages: []string{"emailProtection"},
+				}
+			},
+			overrideParams: func(params *credtemplate.WorkloadX509SVIDParams) {
+				params.CredentialProfile = &common.CredentialProfile{
+					ExtendedKeyUsages: []string{"2.5.29.37.0"},
+				}
+			},
+			expectErr: `credential profile extended key usage "2.5.29.37.0" is not allowed by the server configuration`,
+		},
+		{
+			desc: "credential profile without allowed fields",
+			overrideParams: func(params *credtemplate.WorkloadX509SVIDParams) {
+				params.CredentialProfile = &common.CredentialProfile{
+					SubjectOrganization: []string{"ACME"},
+				}
+			},
+			expectErr: `credential profile field "subject_organization" is not allowed by the server configuration`,
+		},
+		{
+			desc: "credential profile extension not allowed",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.CredentialProfilePolicy = credtemplate.CredentialProfilePolicy{
+					AllowedFields:        []string{credtemplate.CredentialProfileExtensions},
+					AllowedExtensionOIDs: []string{"1.2.3.4"},
+				}
+			},
+			overrideParams: func(params *credtemplate.WorkloadX509SVIDParams) {
+				params.CredentialProfile = &common.CredentialProfile{
+					Extensions: []*common.X509Extension{{Oid: "1.2.3.5", Value: []byte{5, 0}}},
+				}
+			},
+			expectErr: `credential profile extension "1.2.3.5" is not allowed by the server configuration`,
+		},
+		{
+			desc: "credential profile with reserved extension",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.CredentialProfilePolicy = credtemplate.CredentialProfilePolicy{
+					AllowedFields:        []string{credtemplate.CredentialProfileExtensions},
+					AllowedExtensionOIDs: []string{"1.2.3.4"},
+				}
+			},
+			overrideParams: func(params *credtemplate.WorkloadX509SVIDParams) {
+				params.CredentialProfile = &common.CredentialProfile{
+					Extensions: []*common.X509Extension{{Oid: "2.5.29.17", Value: []byte{5, 0}}},
+				}
+			},
+			expectErr: `credential profile extension "2.5.29.17" cannot be set: it is the subject alternative name extension`,
+		},
 		{
 			desc: "real no-op composer",
 			overrideConfig: func(config *credtemplate.Config) {
@@ -1140,6 +1371,82 @@ func TestBuildWorkloadJWTSVIDClaims(t *testing.T) {
 			},
 			expectErr: "oh no",
 		},
+		{
+			desc: "with claim templates",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.ClaimTemplates = newClaimTemplates(t,
+					credtemplate.ClaimTemplateConfig{Name: "tenant", Value: "payments"},
+					credtemplate.ClaimTemplateConfig{Name: "namespace", Value: "{{k8s:ns}}"},
+					credtemplate.ClaimTemplateConfig{Name: "cluster", Value: "{{ .NodeSelector `k8s_psat:cluster` }}"},
+					credtemplate.ClaimTemplateConfig{Name: "entry", Value: "{{ .EntryID }}/{{ .Hint }}"},
+					credtemplate.ClaimTemplateConfig{Name: "id", Value: "{{ .TrustDomain }}:{{ .SPIFFEID }}"},
+					credtemplate.ClaimTemplateConfig{Name: "wit_only", Value: "wit", SVIDTypes: []string{credtemplate.ClaimSVIDTypeWIT}},
+				)
+			},
+			overrideParams: func(params *credtemplate.WorkloadJWTSVIDParams) {
+				params.ClaimSource = claimSource
+			},
+			overrideExpected: func(expected map[string]any) {
+				expected["tenant"] = "payments"
+				expected["namespace"] = "prod"
+				expected["cluster"] = "east"
+				expected["entry"] = "ENTRYID/HINT"
+				expected["id"] = "domain.test:" + workloadID.String()
+			},
+		},
+		{
+			desc: "claim template not rendered",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.ClaimTemplates = newClaimTemplates(t,
+					credtemplate.ClaimTemplateConfig{Name: "tenant", Value: "payments"},
+					credtemplate.ClaimTemplateConfig{Name: "namespace", Value: "{{k8s:ns}}"},
+					credtemplate.ClaimTemplateConfig{Name: "hint", Value: "{{ .Hint }}"},
+				)
+			},
+			overrideExpected: func(expected map[string]any) {
+				expected["tenant"] = "payments"
+			},
+		},
+		{
+			desc: "required claim template not rendered",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.ClaimTemplates = newClaimTemplates(t,
+					credtemplate.ClaimTemplateConfig{Name: "namespace", Value: "{{k8s:pod-name}}", Required: true},
+				)
+			},
+			overrideParams: func(params *credtemplate.WorkloadJWTSVIDParams) {
+				params.ClaimSource = claimSource
+			},
+			expectErr: `failed to render required claim "namespace": template: agent-path:1:3: executing "agent-path" at <.Selector>: error calling Selector: workload has no "k8s:pod-name" selector`,
+		},
+		{
+			desc: "claim template node selectors unavailable",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.ClaimTemplates = newClaimTemplates(t,
+					credtemplate.ClaimTemplateConfig{Name: "cluster", Value: "{{ .NodeSelector `k8s_psat:cluster` }}"},
+				)
+			},
+			overrideParams: func(params *credtemplate.WorkloadJWTSVIDParams) {
+				params.ClaimSource = &credtemplate.ClaimSource{
+					NodeSelectors: func(context.Context) ([]*types.Selector, error) {
+						return nil, errors.New("oh no")
+					},
+				}
+			},
+			expectErr: `failed to fetch node selectors for claim "cluster": oh no`,
+		},
+		{
+			desc: "claim template overridden by composer",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.ClaimTemplates = newClaimTemplates(t,
+					credtemplate.ClaimTemplateConfig{Name: "foo", Value: "TEMPLATE"},
+				)
+				config.CredentialComposers = []credentialcomposer.CredentialComposer{fakeCC{id: []byte{1, 2, 3, 4}, onlyFoo: true}}
+			},
+			overrideExpected: func(expected map[string]any) {
+				expected["foo"] = "VALUE-[1 2 3 4]"
+			},
+		},
 		{
 			desc: "real no-op composer",
 			overrideConfig: func(config *credtemplate.Config) {
@@ -1283,6 +1590,21 @@ func TestBuildWorkloadWITSVIDClaims(t *testing.T) {
 				expected["iss"] = "ISSUER"
 			},
 		},
+		{
+			desc: "with claim templates",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.ClaimTemplates = newClaimTemplates(t,
+					credtemplate.ClaimTemplateConfig{Name: "namespace", Value: "{{k8s:ns}}"},
+					credtemplate.ClaimTemplateConfig{Name: "jwt_only", Value: "jwt", SVIDTypes: []string{credtemplate.ClaimSVIDTypeJWT}},
+				)
+			},
+			overrideParams: func(params *credtemplate.WorkloadWITSVIDParams) {
+				params.ClaimSource = claimSource
+			},
+			overrideExpected: func(expected map[string]any) {
+				expected["namespace"] = "prod"
+			},
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			testBuilder(t, tc.overrideConfig, func(t *testing.T, credBuilder *credtemplate.Builder) {
@@ -1351,6 +1673,64 @@ func TestBuildWorkloadJWTSVIDClaimsIncludeJTI(t *testing.T) {
 	})
 }
 
+func TestNewClaimTemplate(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		config    credtemplate.ClaimTemplateConfig
+		expectErr string
+	}{
+		{
+			desc:   "valid",
+			config: credtemplate.ClaimTemplateConfig{Name: "namespace", Value: "{{k8s:ns}}", SVIDTypes: []string{"jwt", "wit"}},
+		},
+		{
+			desc:      "missing name",
+			config:    credtemplate.ClaimTemplateConfig{Value: "VALUE"},
+			expectErr: "claim name is required",
+		},
+		{
+			desc:      "reserved claim",
+			config:    credtemplate.ClaimTemplateConfig{Name: "aud", Value: "VALUE"},
+			expectErr: `claim "aud" is reserved and cannot be set`,
+		},
+		{
+			desc:      "missing value",
+			config:    credtemplate.ClaimTemplateConfig{Name: "namespace"},
+			expectErr: `claim "namespace" value is required`,
+		},
+		{
+			desc:      "invalid value",
+			config:    credtemplate.ClaimTemplateConfig{Name: "namespace", Value: "{{ .Selector "},
+			expectErr: `invalid claim "namespace" value: template: agent-path:1: unclosed action`,
+		},
+		{
+			desc:      "unknown SVID type",
+			config:    credtemplate.ClaimTemplateConfig{Name: "namespace", Value: "VALUE", SVIDTypes: []string{"x509"}},
+			expectErr: `claim "namespace" has unknown SVID type "x509"; expected "jwt" or "wit"`,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			template, err := credtemplate.NewClaimTemplate(tc.config)
+			if tc.expectErr != "" {
+				require.EqualError(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.config.Name, template.Name())
+		})
+	}
+}
+
+func newClaimTemplates(t *testing.T, configs ...credtemplate.ClaimTemplateConfig) []*credtemplate.ClaimTemplate {
+	var templates []*credtemplate.ClaimTemplate
+	for _, config := range configs {
+		template, err := credtemplate.NewClaimTemplate(config)
+		require.NoError(t, err)
+		templates = append(templates, template)
+	}
+	return templates
+}
+
 func testBuilder(t *testing.T, overrideConfig func(config *credtemplate.Config), fn func(*testing.T, *credtemplate.Builder)) {
 	config := credtemplate.Config{
 		TrustDomain:     td,
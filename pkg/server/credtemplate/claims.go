@@ -0,0 +1,218 @@
+package credtemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/agentpathtemplate"
+)
+
+// SVID types that claim templates apply to.
+const (
+	ClaimSVIDTypeJWT = "jwt"
+	ClaimSVIDTypeWIT = "wit"
+)
+
+var (
+	// reservedClaims are the registered claims set by SPIRE, or that would
+	// change how the token is validated. Claim templates cannot set them.
+	reservedClaims = []string{"aud", "cnf", "exp", "iat", "iss", "jti", "nbf", "sub"}
+
+	// claimSelectorPlaceholderRE matches the {{type:key}} shorthand, which is
+	// rewritten into a call to the Selector method of the template data.
+	claimSelectorPlaceholderRE = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_-]+:[^\s{}"]+)\s*\}\}`)
+)
+
+// ClaimTemplateConfig is the configuration of a claim template.
+type ClaimTemplateConfig struct {
+	// Name is the name of the claim.
+	Name string
+
+	// Value is the template of the claim value.
+	Value string
+
+	// SVIDTypes are the SVID types the claim is added to. When empty, the
+	// claim is added to both JWT-SVIDs and WIT-SVIDs.
+	SVIDTypes []string
+
+	// Required, when true, fails the issuance of SVIDs for which the claim
+	// cannot be rendered. Otherwise, the claim is left out.
+	Required bool
+}
+
+// ClaimTemplate renders a custom claim into workload JWT-SVIDs and WIT-SVIDs.
+// It is a built-in, declarative alternative to a CredentialComposer plugin,
+// with access to the registration entry the SVID is issued for.
+//
+// Templates use the agent path template syntax, with the .TrustDomain,
+// .SPIFFEID, .EntryID and .Hint fields, and the .Selector and .NodeSelector
+// methods returning the value of a workload or agent selector. In addition,
+// the {{type:key}} shorthand expands to the value of the workload selector of
+// the given type whose value starts with "key:", with that prefix removed.
+type ClaimTemplate struct {
+	name     string
+	value    *agentpathtemplate.Template
+	jwt      bool
+	wit      bool
+	required bool
+}
+
+// NewClaimTemplate parses and validates a claim template.
+func NewClaimTemplate(config ClaimTemplateConfig) (*ClaimTemplate, error) {
+	if config.Name == "" {
+		return nil, errors.New("claim name is required")
+	}
+	if slices.Contains(reservedClaims, config.Name) {
+		return nil, fmt.Errorf("claim %q is reserved and cannot be set", config.Name)
+	}
+	if config.Value == "" {
+		return nil, fmt.Errorf("claim %q value is required", config.Name)
+	}
+	value, err := agentpathtemplate.Parse(claimSelectorPlaceholderRE.ReplaceAllString(config.Value, `{{ .Selector "$1" }}`))
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim %q value: %w", config.Name, err)
+	}
+
+	t := &ClaimTemplate{
+		name:     config.Name,
+		value:    value,
+		required: config.Required,
+	}
+	if len(config.SVIDTypes) == 0 {
+		t.jwt, t.wit = true, true
+	}
+	for _, svidType := range config.SVIDTypes {
+		switch svidType {
+		case ClaimSVIDTypeJWT:
+			t.jwt = true
+		case ClaimSVIDTypeWIT:
+			t.wit = true
+		default:
+			return nil, fmt.Errorf("claim %q has unknown SVID type %q; expected %q or %q", config.Name, svidType, ClaimSVIDTypeJWT, ClaimSVIDTypeWIT)
+		}
+	}
+	return t, nil
+}
+
+// Name returns the name of the claim.
+func (t *ClaimTemplate) Name() string {
+	return t.name
+}
+
+// ClaimSource is the registration entry data that claim templates are
+// rendered with.
+type ClaimSource struct {
+	EntryID   string
+	Hint      string
+	Selectors []*types.Selector
+
+	// NodeSelectors returns the selectors of the agent the SVID is issued
+	// to. It is only called when a claim template references them.
+	NodeSelectors func(ctx context.Context) ([]*types.Selector, error)
+}
+
+// renderClaims adds the claims rendered from the templates that apply to the
+// given SVID type. Claims that cannot be rendered are left out unless they are
+// required.
+func renderClaims(ctx context.Context, claims map[string]any, templates []*ClaimTemplate, svidType string, td spiffeid.TrustDomain, id spiffeid.ID, source *ClaimSource) error {
+	data := &claimTemplateData{
+		TrustDomain: td.Name(),
+		SPIFFEID:    id.String(),
+		ctx:         ctx,
+		source:      source,
+	}
+	if source != nil {
+		data.EntryID = source.EntryID
+		data.Hint = source.Hint
+	}
+
+	for _, t := range templates {
+		if (svidType == ClaimSVIDTypeJWT && !t.jwt) || (svidType == ClaimSVIDTypeWIT && !t.wit) {
+			continue
+		}
+		value, err := t.value.Execute(data)
+		if data.nodeSelectorsErr != nil {
+			return fmt.Errorf("failed to fetch node selectors for claim %q: %w", t.name, data.nodeSelectorsErr)
+		}
+		if err == nil && value == "" {
+			err = errors.New("value is empty")
+		}
+		if err != nil {
+			if t.required {
+				return fmt.Errorf("failed to render required claim %q: %w", t.name, err)
+			}
+			continue
+		}
+		claims[t.name] = value
+	}
+	return nil
+}
+
+// claimTemplateData is the data available to claim templates.
+type claimTemplateData struct {
+	TrustDomain string
+	SPIFFEID    string
+	EntryID     string
+	Hint        string
+
+	ctx              context.Context
+	source           *ClaimSource
+	nodeSelectors    []*types.Selector
+	nodeSelectorsErr error
+	nodeSelectorsSet bool
+}
+
+// Selector returns the value of the workload selector identified by key, which
+// is the selector type followed by the leading components of the selector
+// value (e.g. "k8s:ns"). It fails if the entry has no such selector, or more
+// than one.
+func (d *claimTemplateData) Selector(key string) (string, error) {
+	if d.source == nil {
+		return "", errors.New("no registration entry")
+	}
+	return selectorValue("workload", d.source.Selectors, key)
+}
+
+// NodeSelector returns the value of the agent selector identified by key, in
+// the same form as for Selector.
+func (d *claimTemplateData) NodeSelector(key string) (string, error) {
+	if d.source == nil || d.source.NodeSelectors == nil {
+		return "", errors.New("no agent")
+	}
+	if !d.nodeSelectorsSet {
+		d.nodeSelectors, d.nodeSelectorsErr = d.source.NodeSelectors(d.ctx)
+		d.nodeSelectorsSet = true
+	}
+	if d.nodeSelectorsErr != nil {
+		return "", d.nodeSelectorsErr
+	}
+	return selectorValue("agent", d.nodeSelectors, key)
+}
+
+func selectorValue(kind string, selectors []*types.Selector, key string) (string, error) {
+	selectorType, prefix, _ := strings.Cut(key, ":")
+	prefix += ":"
+
+	var value string
+	found := false
+	for _, selector := range selectors {
+		if selector.Type != selectorType || !strings.HasPrefix(selector.Value, prefix) {
+			continue
+		}
+		if found {
+			return "", fmt.Errorf("%s has more than one %q selector", kind, key)
+		}
+		value = strings.TrimPrefix(selector.Value, prefix)
+		found = true
+	}
+	if !found || value == "" {
+		return "", fmt.Errorf("%s has no %q selector", kind, key)
+	}
+	return value, nil
+}
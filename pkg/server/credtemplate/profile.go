@@ -0,0 +1,288 @@
+package credtemplate
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Credential profile fields that can be allowed by the server configuration.
+const (
+	CredentialProfileSubjectOrganization       = "subject_organization"
+	CredentialProfileSubjectOrganizationalUnit = "subject_organizational_unit"
+	CredentialProfileExtendedKeyUsages         = "extended_key_usages"
+	CredentialProfileExtensions                = "extensions"
+)
+
+var (
+	credentialProfileFields = []string{
+		CredentialProfileSubjectOrganization,
+		CredentialProfileSubjectOrganizationalUnit,
+		CredentialProfileExtendedKeyUsages,
+		CredentialProfileExtensions,
+	}
+
+	// extKeyUsagesByName are the extended key usages that can be given by
+	// name. Any other extended key usage is given as a dotted OID.
+	extKeyUsagesByName = map[string]x509.ExtKeyUsage{
+		"serverAuth":      x509.ExtKeyUsageServerAuth,
+		"clientAuth":      x509.ExtKeyUsageClientAuth,
+		"emailProtection": x509.ExtKeyUsageEmailProtection,
+		"timeStamping":    x509.ExtKeyUsageTimeStamping,
+	}
+
+	extKeyUsageOIDs = map[x509.ExtKeyUsage]asn1.ObjectIdentifier{
+		x509.ExtKeyUsageServerAuth:      {1, 3, 6, 1, 5, 5, 7, 3, 1},
+		x509.ExtKeyUsageClientAuth:      {1, 3, 6, 1, 5, 5, 7, 3, 2},
+		x509.ExtKeyUsageEmailProtection: {1, 3, 6, 1, 5, 5, 7, 3, 4},
+		x509.ExtKeyUsageTimeStamping:    {1, 3, 6, 1, 5, 5, 7, 3, 8},
+	}
+
+	// forbiddenExtKeyUsageOIDs are the extended key usages that would make an
+	// X509-SVID trusted for far more than workload authentication. They
+	// cannot be allowed by the server configuration.
+	forbiddenExtKeyUsageOIDs = map[string]string{
+		"2.5.29.37.0":       "anyExtendedKeyUsage",
+		"1.3.6.1.5.5.7.3.3": "codeSigning",
+		"1.3.6.1.5.5.7.3.9": "OCSPSigning",
+	}
+
+	// reservedExtensionOIDs are the extensions that are either set by SPIRE on
+	// X509-SVIDs or that would change what the X509-SVID is trusted for.
+	// Credential profiles cannot set them.
+	reservedExtensionOIDs = map[string]string{
+		"2.5.29.14":         "subject key identifier",
+		"2.5.29.15":         "key usage",
+		"2.5.29.17":         "subject alternative name",
+		"2.5.29.18":         "issuer alternative name",
+		"2.5.29.19":         "basic constraints",
+		"2.5.29.30":         "name constraints",
+		"2.5.29.31":         "CRL distribution points",
+		"2.5.29.32":         "certificate policies",
+		"2.5.29.35":         "authority key identifier",
+		"2.5.29.36":         "policy constraints",
+		"2.5.29.37":         "extended key usage",
+		"2.5.29.54":         "inhibit anyPolicy",
+		"1.3.6.1.5.5.7.1.1": "authority information access",
+	}
+)
+
+// CredentialProfilePolicy is the server configuration that governs what the
+// credential profiles of registration entries can set.
+type CredentialProfilePolicy struct {
+	// AllowedFields are the credential profile fields that registration
+	// entries are allowed to set. Credential profiles are rejected when empty.
+	AllowedFields []string
+
+	// AllowedExtendedKeyUsages are the extended key usages, by name or as
+	// dotted OIDs, that credential profiles are allowed to add.
+	AllowedExtendedKeyUsages []string
+
+	// AllowedExtensionOIDs are the dotted OIDs of the custom extensions that
+	// credential profiles are allowed to set.
+	AllowedExtensionOIDs []string
+}
+
+// Enabled returns true if registration entries can set credential profiles.
+func (p CredentialProfilePolicy) Enabled() bool {
+	return len(p.AllowedFields) > 0
+}
+
+// ValidateCredentialProfilePolicy validates the credential profile policy of
+// the server configuration.
+func ValidateCredentialProfilePolicy(policy CredentialProfilePolicy) error {
+	for _, field := range policy.AllowedFields {
+		if !slices.Contains(credentialProfileFields, field) {
+			return fmt.Errorf("unknown credential profile field %q; expected one of %q", field, credentialProfileFields)
+		}
+	}
+
+	ekusAllowed := slices.Contains(policy.AllowedFields, CredentialProfileExtendedKeyUsages)
+	switch {
+	case ekusAllowed && len(policy.AllowedExtendedKeyUsages) == 0:
+		return fmt.Errorf("credential profile field %q requires the allowed extended key usages to be set", CredentialProfileExtendedKeyUsages)
+	case !ekusAllowed && len(policy.AllowedExtendedKeyUsages) > 0:
+		return fmt.Errorf("allowed extended key usages require the %q credential profile field to be allowed", CredentialProfileExtendedKeyUsages)
+	}
+	for _, eku := range policy.AllowedExtendedKeyUsages {
+		oid, err := parseExtKeyUsage(eku)
+		if err != nil {
+			return err
+		}
+		if name, ok := forbiddenExtKeyUsageOIDs[oid.String()]; ok {
+			return fmt.Errorf("extended key usage %q cannot be allowed: it is the %s extended key usage", eku, name)
+		}
+	}
+
+	extensionsAllowed := slices.Contains(policy.AllowedFields, CredentialProfileExtensions)
+	switch {
+	case extensionsAllowed && len(policy.AllowedExtensionOIDs) == 0:
+		return fmt.Errorf("credential profile field %q requires the allowed extension OIDs to be set", CredentialProfileExtensions)
+	case !extensionsAllowed && len(policy.AllowedExtensionOIDs) > 0:
+		return fmt.Errorf("allowed extension OIDs require the %q credential profile field to be allowed", CredentialProfileExtensions)
+	}
+	for _, s := range policy.AllowedExtensionOIDs {
+		oid, err := parseOID(s)
+		if err != nil {
+			return fmt.Errorf("invalid extension OID: %w", err)
+		}
+		if name, ok := reservedExtensionOIDs[oid.String()]; ok {
+			return fmt.Errorf("extension OID %q cannot be allowed: it is the %s extension", s, name)
+		}
+	}
+	return nil
+}
+
+// ValidateCredentialProfile validates a credential profile, including that it
+// only sets what the policy allows.
+func ValidateCredentialProfile(profile *common.CredentialProfile, policy CredentialProfilePolicy) error {
+	if profile == nil {
+		return nil
+	}
+
+	checkAllowed := func(field string, isSet bool) error {
+		if isSet && !slices.Contains(policy.AllowedFields, field) {
+			return fmt.Errorf("credential profile field %q is not allowed by the server configuration", field)
+		}
+		return nil
+	}
+	if err := errors.Join(
+		checkAllowed(CredentialProfileSubjectOrganization, len(profile.SubjectOrganization) > 0),
+		checkAllowed(CredentialProfileSubjectOrganizationalUnit, len(profile.SubjectOrganizationalUnit) > 0),
+		checkAllowed(CredentialProfileExtendedKeyUsages, len(profile.ExtendedKeyUsages) > 0),
+		checkAllowed(CredentialProfileExtensions, len(profile.Extensions) > 0),
+	); err != nil {
+		return err
+	}
+
+	if slices.Contains(profile.SubjectOrganization, "") {
+		return errors.New("credential profile subject organization cannot be empty")
+	}
+	if slices.Contains(profile.SubjectOrganizationalUnit, "") {
+		return errors.New("credential profile subject organizational unit cannot be empty")
+	}
+	if len(profile.ExtendedKeyUsages) > 0 {
+		allowed := make(map[string]struct{}, len(policy.AllowedExtendedKeyUsages))
+		for _, eku := range policy.AllowedExtendedKeyUsages {
+			if oid, err := parseExtKeyUsage(eku); err == nil {
+				allowed[oid.String()] = struct{}{}
+			}
+		}
+		for _, eku := range profile.ExtendedKeyUsages {
+			oid, err := parseExtKeyUsage(eku)
+			if err != nil {
+				return err
+			}
+			if _, ok := allowed[oid.String()]; !ok {
+				return fmt.Errorf("credential profile extended key usage %q is not allowed by the server configuration", eku)
+			}
+		}
+	}
+
+	allowedExtensions := make(map[string]struct{}, len(policy.AllowedExtensionOIDs))
+	for _, s := range policy.AllowedExtensionOIDs {
+		if oid, err := parseOID(s); err == nil {
+			allowedExtensions[oid.String()] = struct{}{}
+		}
+	}
+	seen := make(map[string]struct{})
+	for _, ext := range profile.Extensions {
+		oid, err := parseOID(ext.Oid)
+		if err != nil {
+			return fmt.Errorf("invalid credential profile extension: %w", err)
+		}
+		if name, ok := reservedExtensionOIDs[oid.String()]; ok {
+			return fmt.Errorf("credential profile extension %q cannot be set: it is the %s extension", ext.Oid, name)
+		}
+		if _, ok := allowedExtensions[oid.String()]; !ok {
+			return fmt.Errorf("credential profile extension %q is not allowed by the server configuration", ext.Oid)
+		}
+		if _, ok := seen[oid.String()]; ok {
+			return fmt.Errorf("credential profile extension %q is set more than once", ext.Oid)
+		}
+		seen[oid.String()] = struct{}{}
+		if len(ext.Value) == 0 {
+			return fmt.Errorf("credential profile extension %q has no value", ext.Oid)
+		}
+	}
+	return nil
+}
+
+// applyCredentialProfile applies a validated credential profile to an
+// X509-SVID template. The subject attributes of the profile replace the
+// configured ones, while the extended key usages and extensions are added to
+// those already in the template.
+func applyCredentialProfile(tmpl *x509.Certificate, profile *common.CredentialProfile) {
+	if len(profile.SubjectOrganization) > 0 {
+		tmpl.Subject.Organization = slices.Clone(profile.SubjectOrganization)
+	}
+	if len(profile.SubjectOrganizationalUnit) > 0 {
+		tmpl.Subject.OrganizationalUnit = slices.Clone(profile.SubjectOrganizationalUnit)
+	}
+	for _, eku := range profile.ExtendedKeyUsages {
+		oid, _ := parseExtKeyUsage(eku)
+		known, ok := extKeyUsageFromOID(oid)
+		switch {
+		case !ok:
+			tmpl.UnknownExtKeyUsage = append(tmpl.UnknownExtKeyUsage, oid)
+		case !slices.Contains(tmpl.ExtKeyUsage, known):
+			tmpl.ExtKeyUsage = append(tmpl.ExtKeyUsage, known)
+		}
+	}
+	for _, ext := range profile.Extensions {
+		oid, _ := parseOID(ext.Oid)
+		tmpl.ExtraExtensions = append(tmpl.ExtraExtensions, pkix.Extension{
+			Id:       oid,
+			Critical: ext.Critical,
+			Value:    slices.Clone(ext.Value),
+		})
+	}
+}
+
+// parseExtKeyUsage parses an extended key usage given either by name or as a
+// dotted OID.
+func parseExtKeyUsage(s string) (asn1.ObjectIdentifier, error) {
+	if eku, ok := extKeyUsagesByName[s]; ok {
+		return extKeyUsageOIDs[eku], nil
+	}
+	oid, err := parseOID(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid extended key usage %q: expected a dotted OID or one of %q", s, slices.Sorted(maps.Keys(extKeyUsagesByName)))
+	}
+	return oid, nil
+}
+
+// extKeyUsageFromOID returns the extended key usage known to the x509 package
+// for the OID, if any.
+func extKeyUsageFromOID(oid asn1.ObjectIdentifier) (x509.ExtKeyUsage, bool) {
+	for eku, ekuOID := range extKeyUsageOIDs {
+		if ekuOID.Equal(oid) {
+			return eku, true
+		}
+	}
+	return 0, false
+}
+
+func parseOID(s string) (asn1.ObjectIdentifier, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid OID %q", s)
+	}
+	oid := make(asn1.ObjectIdentifier, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid OID %q", s)
+		}
+		oid = append(oid, n)
+	}
+	return oid, nil
+}
@@ -0,0 +1,701 @@
+// Package archive exports the contents of a DataStore to a versioned archive
+// and imports or restores them into any DataStore implementation.
+//
+// An archive is a gzip-compressed stream of JSON values. The first value is
+// the archive header, which holds the archive version and the trust domain
+// the records belong to. Each following value is a single record. Records
+// are written in dependency order (bundles before the federation
+// relationships and registration entries that reference them), so an
+// archive can be imported in a single pass without buffering it in memory.
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Version is the version of the archive format written by Export.
+	Version = 1
+
+	// listPageSize is the page size used to list the records to delete from
+	// the datastore during a restore.
+	listPageSize = 1000
+)
+
+// Record kinds
+const (
+	KindBundle                 = "bundle"
+	KindFederationRelationship = "federation_relationship"
+	KindRegistrationEntry      = "registration_entry"
+	KindAttestedNode           = "attested_node"
+	KindJoinToken              = "join_token"
+	KindCAJournal              = "ca_journal"
+)
+
+// Header is the first value in an archive.
+type Header struct {
+	Version     int       `json:"version"`
+	TrustDomain string    `json:"trust_domain"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Stats holds the number of records of each kind that were exported or
+// imported. Imported records that were already present in the datastore
+// with the same content are counted as unchanged. Deleted counts the records
+// removed by Restore because they were not in the archive.
+type Stats struct {
+	Records   map[string]int
+	Unchanged int
+	Deleted   int
+}
+
+type record struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+type federationRelationshipRecord struct {
+	TrustDomain           string `json:"trust_domain"`
+	BundleEndpointURL     string `json:"bundle_endpoint_url"`
+	BundleEndpointProfile string `json:"bundle_endpoint_profile"`
+	EndpointSPIFFEID      string `json:"endpoint_spiffe_id,omitempty"`
+}
+
+type joinTokenRecord struct {
+	Token  string `json:"token"`
+	Expiry int64  `json:"expiry"`
+}
+
+type caJournalRecord struct {
+	ActiveX509AuthorityID string `json:"active_x509_authority_id"`
+	Data                  []byte `json:"data"`
+}
+
+// Export writes the contents of the datastore to w. Records are read in a
+// single read transaction, so the archive is a point-in-time view of the
+// datastore, and are streamed to the archive as they are read.
+func Export(ctx context.Context, ds datastore.DataStore, trustDomain spiffeid.TrustDomain, w io.Writer) (*Stats, error) {
+	gw := gzip.NewWriter(w)
+	enc := json.NewEncoder(gw)
+
+	if err := enc.Encode(&Header{
+		Version:     Version,
+		TrustDomain: trustDomain.Name(),
+		CreatedAt:   time.Now().UTC(),
+	}); err != nil {
+		return nil, fmt.Errorf("unable to write archive header: %w", err)
+	}
+
+	e := &exporter{
+		enc:   enc,
+		stats: &Stats{Records: make(map[string]int)},
+	}
+	if err := ds.ExportRecords(ctx, e.writeRecord); err != nil {
+		return nil, fmt.Errorf("unable to export datastore records: %w", err)
+	}
+
+	if err := gw.Close(); err != nil {
+		return nil, fmt.Errorf("unable to finish archive: %w", err)
+	}
+	return e.stats, nil
+}
+
+// Import loads the records in the archive read from r into the datastore.
+// The archive must have been exported for the given trust domain. Import is
+// idempotent: records that already exist are updated to match the archive,
+// so an interrupted import can be safely retried and importing the same
+// archive twice leaves the datastore unchanged. Records in the datastore that
+// are not in the archive are left in place.
+func Import(ctx context.Context, ds datastore.DataStore, trustDomain spiffeid.TrustDomain, r io.Reader) (*Stats, error) {
+	i := newImporter(ds, false)
+	if err := i.load(ctx, trustDomain, r); err != nil {
+		return nil, err
+	}
+	return i.stats, nil
+}
+
+// Restore replaces the contents of the datastore with the records in the
+// archive read from r. The archive must have been exported for the given
+// trust domain. Records are loaded as in Import, and then every registration
+// entry, attested node, join token, federation relationship and bundle that
+// is not in the archive is deleted. CA journals cannot be deleted through the
+// datastore, so journals that are not in the archive are left in place and
+// pruned by the server once their authorities expire.
+//
+// Restore is not atomic, but it is idempotent: an interrupted restore can be
+// safely retried. The datastore must not be in use by a running server.
+func Restore(ctx context.Context, ds datastore.DataStore, trustDomain spiffeid.TrustDomain, r io.Reader) (*Stats, error) {
+	i := newImporter(ds, true)
+	if err := i.load(ctx, trustDomain, r); err != nil {
+		return nil, err
+	}
+	if err := i.deleteUnrestored(ctx); err != nil {
+		return nil, err
+	}
+	return i.stats, nil
+}
+
+type exporter struct {
+	enc   *json.Encoder
+	stats *Stats
+}
+
+func (e *exporter) writeRecord(r *datastore.ExportRecord) error {
+	switch {
+	case r.Bundle != nil:
+		return e.writeProto(KindBundle, r.Bundle)
+	case r.FederationRelationship != nil:
+		fr := r.FederationRelationship
+		record := &federationRelationshipRecord{
+			TrustDomain:           fr.TrustDomain.Name(),
+			BundleEndpointURL:     fr.BundleEndpointURL.String(),
+			BundleEndpointProfile: string(fr.BundleEndpointProfile),
+		}
+		if !fr.EndpointSPIFFEID.IsZero() {
+			record.EndpointSPIFFEID = fr.EndpointSPIFFEID.String()
+		}
+		return e.write(KindFederationRelationship, record)
+	case r.RegistrationEntry != nil:
+		return e.writeProto(KindRegistrationEntry, r.RegistrationEntry)
+	case r.AttestedNode != nil:
+		return e.writeProto(KindAttestedNode, r.AttestedNode)
+	case r.JoinToken != nil:
+		return e.write(KindJoinToken, &joinTokenRecord{
+			Token:  r.JoinToken.Token,
+			Expiry: r.JoinToken.Expiry.Unix(),
+		})
+	case r.CAJournal != nil:
+		return e.write(KindCAJournal, &caJournalRecord{
+			ActiveX509AuthorityID: r.CAJournal.ActiveX509AuthorityID,
+			Data:                  r.CAJournal.Data,
+		})
+	default:
+		return errors.New("export record is empty")
+	}
+}
+
+func (e *exporter) writeProto(kind string, m proto.Message) error {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return e.writeRaw(kind, data)
+}
+
+func (e *exporter) write(kind string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return e.writeRaw(kind, data)
+}
+
+func (e *exporter) writeRaw(kind string, data []byte) error {
+	if err := e.enc.Encode(&record{Kind: kind, Data: data}); err != nil {
+		return fmt.Errorf("unable to write %s record: %w", kind, err)
+	}
+	e.stats.Records[kind]++
+	return nil
+}
+
+type importer struct {
+	ds    datastore.DataStore
+	stats *Stats
+
+	// restore is set when the datastore is being replaced by the archive, in
+	// which case the keys of the loaded records are tracked so that the
+	// records that are not in the archive can be deleted afterwards.
+	restore                 bool
+	bundles                 map[string]struct{}
+	federationRelationships map[spiffeid.TrustDomain]struct{}
+	entries                 map[string]struct{}
+	nodes                   map[string]struct{}
+	joinTokens              map[string]struct{}
+}
+
+func newImporter(ds datastore.DataStore, restore bool) *importer {
+	return &importer{
+		ds:                      ds,
+		stats:                   &Stats{Records: make(map[string]int)},
+		restore:                 restore,
+		bundles:                 make(map[string]struct{}),
+		federationRelationships: make(map[spiffeid.TrustDomain]struct{}),
+		entries:                 make(map[string]struct{}),
+		nodes:                   make(map[string]struct{}),
+		joinTokens:              make(map[string]struct{}),
+	}
+}
+
+func (i *importer) load(ctx context.Context, trustDomain spiffeid.TrustDomain, r io.Reader) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("unable to read archive: %w", err)
+	}
+	defer gr.Close()
+
+	dec := json.NewDecoder(gr)
+
+	header := new(Header)
+	if err := dec.Decode(header); err != nil {
+		return fmt.Errorf("unable to read archive header: %w", err)
+	}
+	switch {
+	case header.Version == 0:
+		return errors.New("archive header is missing the version")
+	case header.Version > Version:
+		return fmt.Errorf("unsupported archive version %d; the latest supported version is %d", header.Version, Version)
+	case header.TrustDomain != trustDomain.Name():
+		return fmt.Errorf("archive belongs to trust domain %q, not %q", header.TrustDomain, trustDomain.Name())
+	}
+
+	for {
+		r := new(record)
+		err := dec.Decode(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read archive record: %w", err)
+		}
+		if err := i.importRecord(ctx, r); err != nil {
+			return err
+		}
+	}
+}
+
+func (i *importer) importRecord(ctx context.Context, r *record) error {
+	var changed bool
+	var err error
+	switch r.Kind {
+	case KindBundle:
+		bundle := new(common.Bundle)
+		if err := protojson.Unmarshal(r.Data, bundle); err != nil {
+			return fmt.Errorf("invalid bundle record: %w", err)
+		}
+		changed, err = i.importBundle(ctx, bundle)
+	case KindFederationRelationship:
+		fr := new(federationRelationshipRecord)
+		if err := json.Unmarshal(r.Data, fr); err != nil {
+			return fmt.Errorf("invalid federation relationship record: %w", err)
+		}
+		changed, err = i.importFederationRelationship(ctx, fr)
+	case KindRegistrationEntry:
+		entry := new(common.RegistrationEntry)
+		if err := protojson.Unmarshal(r.Data, entry); err != nil {
+			return fmt.Errorf("invalid registration entry record: %w", err)
+		}
+		changed, err = i.importRegistrationEntry(ctx, entry)
+	case KindAttestedNode:
+		node := new(common.AttestedNode)
+		if err := protojson.Unmarshal(r.Data, node); err != nil {
+			return fmt.Errorf("invalid attested node record: %w", err)
+		}
+		changed, err = i.importAttestedNode(ctx, node)
+	case KindJoinToken:
+		token := new(joinTokenRecord)
+		if err := json.Unmarshal(r.Data, token); err != nil {
+			return fmt.Errorf("invalid join token record: %w", err)
+		}
+		changed, err = i.importJoinToken(ctx, token)
+	case KindCAJournal:
+		caJournal := new(caJournalRecord)
+		if err := json.Unmarshal(r.Data, caJournal); err != nil {
+			return fmt.Errorf("invalid CA journal record: %w", err)
+		}
+		changed, err = i.importCAJournal(ctx, caJournal)
+	default:
+		return fmt.Errorf("unknown archive record kind %q", r.Kind)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to import %s record: %w", r.Kind, err)
+	}
+
+	i.stats.Records[r.Kind]++
+	if !changed {
+		i.stats.Unchanged++
+	}
+	return nil
+}
+
+func (i *importer) importBundle(ctx context.Context, bundle *common.Bundle) (bool, error) {
+	i.bundles[bundle.TrustDomainId] = struct{}{}
+
+	existing, err := i.ds.FetchBundle(ctx, bundle.TrustDomainId)
+	if err != nil {
+		return false, err
+	}
+	if proto.Equal(existing, bundle) {
+		return false, nil
+	}
+	_, err = i.ds.SetBundle(ctx, bundle)
+	return err == nil, err
+}
+
+func (i *importer) importFederationRelationship(ctx context.Context, r *federationRelationshipRecord) (bool, error) {
+	fr, err := federationRelationshipFromRecord(r)
+	if err != nil {
+		return false, err
+	}
+	i.federationRelationships[fr.TrustDomain] = struct{}{}
+
+	existing, err := i.ds.FetchFederationRelationship(ctx, fr.TrustDomain)
+	switch {
+	case err != nil:
+		return false, err
+	case existing == nil:
+		_, err = i.ds.CreateFederationRelationship(ctx, fr)
+		return err == nil, err
+	case existing.BundleEndpointURL.String() == fr.BundleEndpointURL.String() &&
+		existing.BundleEndpointProfile == fr.BundleEndpointProfile &&
+		existing.EndpointSPIFFEID == fr.EndpointSPIFFEID:
+		return false, nil
+	default:
+		_, err = i.ds.UpdateFederationRelationship(ctx, fr, federationRelationshipMask)
+		return err == nil, err
+	}
+}
+
+func (i *importer) importRegistrationEntry(ctx context.Context, entry *common.RegistrationEntry) (bool, error) {
+	i.entries[entry.EntryId] = struct{}{}
+
+	existing, err := i.ds.FetchRegistrationEntry(ctx, entry.EntryId)
+	switch {
+	case err != nil:
+		return false, err
+	case existing == nil:
+		// An equivalent entry with a different ID may already exist. It is
+		// left untouched by an import, and replaced by the archived entry on
+		// a restore.
+		equivalent, found, err := i.ds.CreateOrReturnRegistrationEntry(ctx, entry)
+		if err != nil || !found || !i.restore {
+			return !found && err == nil, err
+		}
+		if _, err := i.ds.DeleteRegistrationEntry(ctx, equivalent.EntryId); err != nil {
+			return false, err
+		}
+		_, err = i.ds.CreateRegistrationEntry(ctx, entry)
+		return err == nil, err
+	case equalEntries(existing, entry):
+		return false, nil
+	default:
+		_, err = i.ds.UpdateRegistrationEntry(ctx, entry, registrationEntryMask)
+		return err == nil, err
+	}
+}
+
+func (i *importer) importAttestedNode(ctx context.Context, node *common.AttestedNode) (bool, error) {
+	selectors := node.Selectors
+	node.Selectors = nil
+	i.nodes[node.SpiffeId] = struct{}{}
+
+	existing, err := i.ds.FetchAttestedNode(ctx, node.SpiffeId)
+	if err != nil {
+		return false, err
+	}
+
+	changed := true
+	switch {
+	case existing == nil:
+		_, err = i.ds.CreateAttestedNode(ctx, node)
+	case proto.Equal(existing, node):
+		changed = false
+	default:
+		_, err = i.ds.UpdateAttestedNode(ctx, node, attestedNodeMask)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	existingSelectors, err := i.ds.GetNodeSelectors(ctx, node.SpiffeId, datastore.RequireCurrent)
+	if err != nil {
+		return false, err
+	}
+	if equalSelectors(existingSelectors, selectors) {
+		return changed, nil
+	}
+	if err := i.ds.SetNodeSelectors(ctx, node.SpiffeId, selectors); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (i *importer) importJoinToken(ctx context.Context, r *joinTokenRecord) (bool, error) {
+	token := &datastore.JoinToken{
+		Token:  r.Token,
+		Expiry: time.Unix(r.Expiry, 0),
+	}
+	i.joinTokens[token.Token] = struct{}{}
+
+	existing, err := i.ds.FetchJoinToken(ctx, token.Token)
+	switch {
+	case err != nil:
+		return false, err
+	case existing == nil:
+		return true, i.ds.CreateJoinToken(ctx, token)
+	case existing.Expiry.Equal(token.Expiry):
+		return false, nil
+	default:
+		// Join tokens cannot be updated, so they are re-created instead
+		if err := i.ds.DeleteJoinToken(ctx, token.Token); err != nil {
+			return false, err
+		}
+		return true, i.ds.CreateJoinToken(ctx, token)
+	}
+}
+
+func (i *importer) importCAJournal(ctx context.Context, r *caJournalRecord) (bool, error) {
+	existing, err := i.ds.FetchCAJournal(ctx, r.ActiveX509AuthorityID)
+	if err != nil {
+		return false, err
+	}
+
+	caJournal := &datastore.CAJournal{
+		ActiveX509AuthorityID: r.ActiveX509AuthorityID,
+		Data:                  r.Data,
+	}
+	if existing != nil {
+		if string(existing.Data) == string(r.Data) {
+			return false, nil
+		}
+		caJournal.ID = existing.ID
+	}
+	_, err = i.ds.SetCAJournal(ctx, caJournal)
+	return err == nil, err
+}
+
+// deleteUnrestored deletes the records that were not loaded from the archive.
+// Records are deleted in reverse dependency order, so that bundles are no
+// longer referenced by any entry or federation relationship when they are
+// deleted.
+func (i *importer) deleteUnrestored(ctx context.Context) error {
+	var entryIDs []string
+	if err := forEachPage(func(p *datastore.Pagination) (*datastore.Pagination, error) {
+		resp, err := i.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{Pagination: p})
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range resp.Entries {
+			if _, ok := i.entries[entry.EntryId]; !ok {
+				entryIDs = append(entryIDs, entry.EntryId)
+			}
+		}
+		return resp.Pagination, nil
+	}); err != nil {
+		return fmt.Errorf("unable to list registration entries: %w", err)
+	}
+	for _, entryID := range entryIDs {
+		if _, err := i.ds.DeleteRegistrationEntry(ctx, entryID); err != nil {
+			return fmt.Errorf("unable to delete registration entry %q: %w", entryID, err)
+		}
+		i.stats.Deleted++
+	}
+
+	var nodeIDs []string
+	if err := forEachPage(func(p *datastore.Pagination) (*datastore.Pagination, error) {
+		resp, err := i.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{Pagination: p})
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range resp.Nodes {
+			if _, ok := i.nodes[node.SpiffeId]; !ok {
+				nodeIDs = append(nodeIDs, node.SpiffeId)
+			}
+		}
+		return resp.Pagination, nil
+	}); err != nil {
+		return fmt.Errorf("unable to list attested nodes: %w", err)
+	}
+	for _, nodeID := range nodeIDs {
+		if _, err := i.ds.DeleteAttestedNode(ctx, nodeID); err != nil {
+			return fmt.Errorf("unable to delete attested node %q: %w", nodeID, err)
+		}
+		i.stats.Deleted++
+	}
+
+	var tokens []string
+	if err := forEachPage(func(p *datastore.Pagination) (*datastore.Pagination, error) {
+		resp, err := i.ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{Pagination: p})
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range resp.JoinTokens {
+			if _, ok := i.joinTokens[token.Token]; !ok {
+				tokens = append(tokens, token.Token)
+			}
+		}
+		return resp.Pagination, nil
+	}); err != nil {
+		return fmt.Errorf("unable to list join tokens: %w", err)
+	}
+	for _, token := range tokens {
+		if err := i.ds.DeleteJoinToken(ctx, token); err != nil {
+			return fmt.Errorf("unable to delete join token: %w", err)
+		}
+		i.stats.Deleted++
+	}
+
+	var trustDomains []spiffeid.TrustDomain
+	if err := forEachPage(func(p *datastore.Pagination) (*datastore.Pagination, error) {
+		resp, err := i.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{Pagination: p})
+		if err != nil {
+			return nil, err
+		}
+		for _, fr := range resp.FederationRelationships {
+			if _, ok := i.federationRelationships[fr.TrustDomain]; !ok {
+				trustDomains = append(trustDomains, fr.TrustDomain)
+			}
+		}
+		return resp.Pagination, nil
+	}); err != nil {
+		return fmt.Errorf("unable to list federation relationships: %w", err)
+	}
+	for _, td := range trustDomains {
+		if err := i.ds.DeleteFederationRelationship(ctx, td); err != nil {
+			return fmt.Errorf("unable to delete federation relationship with %q: %w", td, err)
+		}
+		i.stats.Deleted++
+	}
+
+	var trustDomainIDs []string
+	if err := forEachPage(func(p *datastore.Pagination) (*datastore.Pagination, error) {
+		resp, err := i.ds.ListBundles(ctx, &datastore.ListBundlesRequest{Pagination: p})
+		if err != nil {
+			return nil, err
+		}
+		for _, bundle := range resp.Bundles {
+			if _, ok := i.bundles[bundle.TrustDomainId]; !ok {
+				trustDomainIDs = append(trustDomainIDs, bundle.TrustDomainId)
+			}
+		}
+		return resp.Pagination, nil
+	}); err != nil {
+		return fmt.Errorf("unable to list bundles: %w", err)
+	}
+	for _, trustDomainID := range trustDomainIDs {
+		if err := i.ds.DeleteBundle(ctx, trustDomainID, datastore.Restrict); err != nil {
+			return fmt.Errorf("unable to delete bundle %q: %w", trustDomainID, err)
+		}
+		i.stats.Deleted++
+	}
+
+	return nil
+}
+
+var (
+	registrationEntryMask = &common.RegistrationEntryMask{
+		Selectors:            true,
+		ParentId:             true,
+		SpiffeId:             true,
+		X509SvidTtl:          true,
+		FederatesWith:        true,
+		Admin:                true,
+		Downstream:           true,
+		EntryExpiry:          true,
+		NotBefore:            true,
+		DnsNames:             true,
+		StoreSvid:            true,
+		JwtSvidTtl:           true,
+		Hint:                 true,
+		AdditionalAttributes: true,
+		CredentialProfile:    true,
+	}
+
+	attestedNodeMask = &common.AttestedNodeMask{
+		AttestationDataType: true,
+		CertSerialNumber:    true,
+		CertNotAfter:        true,
+		NewCertSerialNumber: true,
+		NewCertNotAfter:     true,
+		CanReattest:         true,
+		AgentVersion:        true,
+	}
+
+	// The trust domain bundle is imported from its own record
+	federationRelationshipMask = &types.FederationRelationshipMask{
+		BundleEndpointUrl:     true,
+		BundleEndpointProfile: true,
+	}
+)
+
+func federationRelationshipFromRecord(r *federationRelationshipRecord) (*datastore.FederationRelationship, error) {
+	td, err := spiffeid.TrustDomainFromString(r.TrustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust domain: %w", err)
+	}
+	bundleEndpointURL, err := url.Parse(r.BundleEndpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle endpoint URL: %w", err)
+	}
+
+	fr := &datastore.FederationRelationship{
+		TrustDomain:           td,
+		BundleEndpointURL:     bundleEndpointURL,
+		BundleEndpointProfile: datastore.BundleEndpointType(r.BundleEndpointProfile),
+	}
+	if r.EndpointSPIFFEID != "" {
+		fr.EndpointSPIFFEID, err = spiffeid.FromString(r.EndpointSPIFFEID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle endpoint SPIFFE ID: %w", err)
+		}
+	}
+	return fr, nil
+}
+
+// equalEntries returns true if the entries are the same, ignoring the
+// revision number and creation time, which are maintained by the datastore.
+func equalEntries(a, b *common.RegistrationEntry) bool {
+	a = proto.CloneOf(a)
+	b = proto.CloneOf(b)
+	a.RevisionNumber, b.RevisionNumber = 0, 0
+	a.CreatedAt, b.CreatedAt = 0, 0
+	return proto.Equal(a, b)
+}
+
+func equalSelectors(a, b []*common.Selector) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]int, len(a))
+	for _, s := range a {
+		set[s.Type+":"+s.Value]++
+	}
+	for _, s := range b {
+		key := s.Type + ":" + s.Value
+		if set[key] == 0 {
+			return false
+		}
+		set[key]--
+	}
+	return true
+}
+
+func forEachPage(list func(p *datastore.Pagination) (*datastore.Pagination, error)) error {
+	pagination := &datastore.Pagination{PageSize: listPageSize}
+	for {
+		next, err := list(pagination)
+		if err != nil {
+			return err
+		}
+		if next == nil || next.Token == "" || next.Token == pagination.Token {
+			return nil
+		}
+		pagination = &datastore.Pagination{
+			Token:    next.Token,
+			PageSize: listPageSize,
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package archive_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/datastore/archive"
+	"github.com/spiffe/spire/pkg/server/datastore/kvstore"
+	"github.com/spiffe/spire/pkg/server/datastore/sqlstore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ctx = context.Background()
+	td  = spiffeid.RequireTrustDomainFromString("example.org")
+)
+
+func TestExportImport(t *testing.T) {
+	src := newKVStore(t)
+	populate(t, src)
+
+	archiveData := new(bytes.Buffer)
+	stats, err := archive.Export(ctx, src, td, archiveData)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		archive.KindBundle:                 2,
+		archive.KindFederationRelationship: 1,
+		archive.KindRegistrationEntry:      3,
+		archive.KindAttestedNode:           1,
+		archive.KindJoinToken:              2,
+		archive.KindCAJournal:              1,
+	}, stats.Records)
+
+	for _, tt := range []struct {
+		name string
+		dst  datastore.DataStore
+	}{
+		{name: "kv", dst: newKVStore(t)},
+		{name: "sql", dst: newSQLStore(t)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := archive.Import(ctx, tt.dst, td, bytes.NewReader(archiveData.Bytes()))
+			require.NoError(t, err)
+			require.Equal(t, 10, countRecords(stats))
+			require.Zero(t, stats.Unchanged)
+			requireSameContents(t, src, tt.dst)
+
+			// Importing again does not change anything
+			stats, err = archive.Import(ctx, tt.dst, td, bytes.NewReader(archiveData.Bytes()))
+			require.NoError(t, err)
+			require.Equal(t, 10, stats.Unchanged)
+			requireSameContents(t, src, tt.dst)
+		})
+	}
+}
+
+func TestImportUpdatesExistingRecords(t *testing.T) {
+	src := newKVStore(t)
+	populate(t, src)
+
+	archiveData := new(bytes.Buffer)
+	_, err := archive.Export(ctx, src, td, archiveData)
+	require.NoError(t, err)
+
+	dst := newKVStore(t)
+	_, err = archive.Import(ctx, dst, td, bytes.NewReader(archiveData.Bytes()))
+	require.NoError(t, err)
+
+	// Drift the destination away from the archive
+	entries, err := dst.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	entry := entries.Entries[0]
+	entry.X509SvidTtl = 1234
+	_, err = dst.UpdateRegistrationEntry(ctx, entry, &common.RegistrationEntryMask{X509SvidTtl: true})
+	require.NoError(t, err)
+	require.NoError(t, dst.SetNodeSelectors(ctx, "spiffe://example.org/agent", nil))
+	require.NoError(t, dst.DeleteJoinToken(ctx, "token-1"))
+
+	stats, err := archive.Import(ctx, dst, td, bytes.NewReader(archiveData.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, 7, stats.Unchanged)
+
+	fetched, err := dst.FetchRegistrationEntry(ctx, entry.EntryId)
+	require.NoError(t, err)
+	require.NotEqual(t, int32(1234), fetched.X509SvidTtl)
+	requireSameContents(t, src, dst)
+}
+
+func TestRestoreReplacesContents(t *testing.T) {
+	src := newKVStore(t)
+	populate(t, src)
+
+	archiveData := new(bytes.Buffer)
+	_, err := archive.Export(ctx, src, td, archiveData)
+	require.NoError(t, err)
+
+	dst := newSQLStore(t)
+	_, err = archive.Restore(ctx, dst, td, bytes.NewReader(archiveData.Bytes()))
+	require.NoError(t, err)
+
+	// Re-create an archived entry under a different ID, and add records that
+	// are not in the archive
+	entries, err := dst.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	entry, err := dst.DeleteRegistrationEntry(ctx, entries.Entries[0].EntryId)
+	require.NoError(t, err)
+	archivedEntryID := entry.EntryId
+	entry.EntryId = ""
+	_, err = dst.CreateRegistrationEntry(ctx, entry)
+	require.NoError(t, err)
+	_, err = dst.CreateBundle(ctx, &common.Bundle{
+		TrustDomainId: "spiffe://other.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("other")}},
+	})
+	require.NoError(t, err)
+	_, err = dst.CreateFederationRelationship(ctx, &datastore.FederationRelationship{
+		TrustDomain:           spiffeid.RequireTrustDomainFromString("other.org"),
+		BundleEndpointURL:     &url.URL{Scheme: "https", Host: "other.org", Path: "/bundle"},
+		BundleEndpointProfile: datastore.BundleEndpointWeb,
+	})
+	require.NoError(t, err)
+	_, err = dst.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/other",
+		ParentId:      "spiffe://example.org/agent",
+		Selectors:     []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		FederatesWith: []string{"spiffe://other.org"},
+	})
+	require.NoError(t, err)
+	_, err = dst.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/other-agent",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "5678",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, dst.CreateJoinToken(ctx, &datastore.JoinToken{Token: "token-3", Expiry: time.Now().Add(time.Hour)}))
+
+	stats, err := archive.Restore(ctx, dst, td, bytes.NewReader(archiveData.Bytes()))
+	require.NoError(t, err)
+	// The re-created entry is replaced by the archived one, and the other
+	// five records are deleted
+	require.Equal(t, 9, stats.Unchanged)
+	require.Equal(t, 5, stats.Deleted)
+	requireSameContents(t, src, dst)
+
+	fetched, err := dst.FetchRegistrationEntry(ctx, archivedEntryID)
+	require.NoError(t, err)
+	require.NotNil(t, fetched)
+	entries, err = dst.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 3)
+	bundles, err := dst.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	require.NoError(t, err)
+	require.Len(t, bundles.Bundles, 2)
+	nodes, err := dst.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{})
+	require.NoError(t, err)
+	require.Len(t, nodes.Nodes, 1)
+}
+
+func TestImportValidatesHeader(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		header    string
+		expectErr string
+	}{
+		{
+			name:      "missing version",
+			header:    `{"trust_domain":"example.org"}`,
+			expectErr: "archive header is missing the version",
+		},
+		{
+			name:      "newer version",
+			header:    `{"version":2,"trust_domain":"example.org"}`,
+			expectErr: "unsupported archive version 2; the latest supported version is 1",
+		},
+		{
+			name:      "wrong trust domain",
+			header:    `{"version":1,"trust_domain":"other.org"}`,
+			expectErr: `archive belongs to trust domain "other.org", not "example.org"`,
+		},
+		{
+			name:      "unknown record kind",
+			header:    `{"version":1,"trust_domain":"example.org"}{"kind":"foo","data":{}}`,
+			expectErr: `unknown archive record kind "foo"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			archiveData := new(bytes.Buffer)
+			gw := gzip.NewWriter(archiveData)
+			_, err := gw.Write([]byte(tt.header))
+			require.NoError(t, err)
+			require.NoError(t, gw.Close())
+
+			_, err = archive.Import(ctx, newKVStore(t), td, archiveData)
+			require.EqualError(t, err, tt.expectErr)
+		})
+	}
+
+	_, err := archive.Import(ctx, newKVStore(t), td, bytes.NewReader([]byte("not an archive")))
+	require.ErrorContains(t, err, "unable to read archive")
+}
+
+func populate(t *testing.T, ds datastore.DataStore) {
+	_, err := ds.CreateBundle(ctx, &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("root")}},
+	})
+	require.NoError(t, err)
+
+	_, err = ds.CreateFederationRelationship(ctx, &datastore.FederationRelationship{
+		TrustDomain:           spiffeid.RequireTrustDomainFromString("federated.org"),
+		BundleEndpointURL:     &url.URL{Scheme: "https", Host: "federated.org", Path: "/bundle"},
+		BundleEndpointProfile: datastore.BundleEndpointSPIFFE,
+		EndpointSPIFFEID:      spiffeid.RequireFromString("spiffe://federated.org/bundle-endpoint"),
+		TrustDomainBundle: &common.Bundle{
+			TrustDomainId: "spiffe://federated.org",
+			RootCas:       []*common.Certificate{{DerBytes: []byte("federated")}},
+		},
+	})
+	require.NoError(t, err)
+
+	for i := range 3 {
+		_, err := ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+			SpiffeId:      fmt.Sprintf("spiffe://example.org/workload%d", i),
+			ParentId:      "spiffe://example.org/agent",
+			Selectors:     []*common.Selector{{Type: "unix", Value: fmt.Sprintf("uid:%d", i)}},
+			FederatesWith: []string{"spiffe://federated.org"},
+			X509SvidTtl:   60,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/agent",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+		CanReattest:         true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ds.SetNodeSelectors(ctx, "spiffe://example.org/agent", []*common.Selector{
+		{Type: "a", Value: "1"},
+		{Type: "b", Value: "2"},
+	}))
+
+	require.NoError(t, ds.CreateJoinToken(ctx, &datastore.JoinToken{Token: "token-1", Expiry: time.Now().Add(time.Hour)}))
+	require.NoError(t, ds.CreateJoinToken(ctx, &datastore.JoinToken{Token: "token-2", Expiry: time.Now().Add(time.Hour)}))
+
+	_, err = ds.SetCAJournal(ctx, &datastore.CAJournal{
+		ActiveX509AuthorityID: "authority",
+		Data:                  []byte("journal"),
+	})
+	require.NoError(t, err)
+}
+
+func requireSameContents(t *testing.T, expected, actual datastore.DataStore) {
+	expectedBundles, err := expected.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	require.NoError(t, err)
+	actualBundles, err := actual.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, expectedBundles.Bundles, actualBundles.Bundles)
+
+	expectedRelationships, err := expected.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	require.NoError(t, err)
+	actualRelationships, err := actual.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	require.NoError(t, err)
+	require.Len(t, actualRelationships.FederationRelationships, len(expectedRelationships.FederationRelationships))
+	for i, fr := range expectedRelationships.FederationRelationships {
+		require.Equal(t, fr.TrustDomain, actualRelationships.FederationRelationships[i].TrustDomain)
+		require.Equal(t, fr.BundleEndpointURL.String(), actualRelationships.FederationRelationships[i].BundleEndpointURL.String())
+		require.Equal(t, fr.BundleEndpointProfile, actualRelationships.FederationRelationships[i].BundleEndpointProfile)
+		require.Equal(t, fr.EndpointSPIFFEID, actualRelationships.FederationRelationships[i].EndpointSPIFFEID)
+	}
+
+	expectedEntries, err := expected.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	for _, entry := range expectedEntries.Entries {
+		actualEntry, err := actual.FetchRegistrationEntry(ctx, entry.EntryId)
+		require.NoError(t, err)
+		require.NotNil(t, actualEntry)
+		require.Equal(t, entry.SpiffeId, actualEntry.SpiffeId)
+		require.Equal(t, entry.ParentId, actualEntry.ParentId)
+		require.Equal(t, entry.X509SvidTtl, actualEntry.X509SvidTtl)
+		require.Equal(t, entry.FederatesWith, actualEntry.FederatesWith)
+		spiretest.RequireProtoListEqual(t, entry.Selectors, actualEntry.Selectors)
+	}
+
+	expectedNodes, err := expected.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{FetchSelectors: true})
+	require.NoError(t, err)
+	actualNodes, err := actual.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{FetchSelectors: true})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, expectedNodes.Nodes, actualNodes.Nodes)
+
+	expectedTokens, err := expected.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	require.NoError(t, err)
+	actualTokens, err := actual.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	require.NoError(t, err)
+	require.Len(t, actualTokens.JoinTokens, len(expectedTokens.JoinTokens))
+	for i, token := range expectedTokens.JoinTokens {
+		require.Equal(t, token.Token, actualTokens.JoinTokens[i].Token)
+		require.Equal(t, token.Expiry.Unix(), actualTokens.JoinTokens[i].Expiry.Unix())
+	}
+
+	expectedJournals, err := expected.ListCAJournalsForTesting(ctx)
+	require.NoError(t, err)
+	for _, caJournal := range expectedJournals {
+		actualJournal, err := actual.FetchCAJournal(ctx, caJournal.ActiveX509AuthorityID)
+		require.NoError(t, err)
+		require.NotNil(t, actualJournal)
+		require.Equal(t, caJournal.Data, actualJournal.Data)
+	}
+}
+
+func countRecords(stats *archive.Stats) int {
+	var n int
+	for _, count := range stats.Records {
+		n += count
+	}
+	return n
+}
+
+func newKVStore(t *testing.T) *kvstore.Plugin {
+	log, _ := test.NewNullLogger()
+	ds := kvstore.New(log)
+	require.NoError(t, ds.Configure(ctx, fmt.Sprintf(`database_path = %q`, filepath.Join(t.TempDir(), "datastore.db"))))
+	t.Cleanup(func() { ds.Close() })
+	return ds
+}
+
+func newSQLStore(t *testing.T) *sqlstore.Plugin {
+	log, _ := test.NewNullLogger()
+	ds := sqlstore.New(log)
+	require.NoError(t, ds.Configure(ctx, fmt.Sprintf(`
+		database_type = "sqlite3"
+		connection_string = %q
+	`, filepath.ToSlash(filepath.Join(t.TempDir(), "datastore.sqlite3")))))
+	t.Cleanup(func() { ds.Close() })
+	return ds
+}
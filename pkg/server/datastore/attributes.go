@@ -0,0 +1,34 @@
+package datastore
+
+import (
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// UpdateAdditionalAttributes returns the additional attributes of an entry
+// once the update is applied with the given mask. The credential profile is
+// kept in the additional attributes, but is masked separately, so that the
+// credential profile and the other attributes can be updated independently.
+// A nil mask updates both.
+func UpdateAdditionalAttributes(current, update *common.RegistrationEntry_AdditionalAttributes, mask *common.RegistrationEntryMask) *common.RegistrationEntry_AdditionalAttributes {
+	updateAttributes := mask == nil || mask.AdditionalAttributes
+	updateProfile := mask == nil || mask.CredentialProfile
+
+	attributes, profile := current, current.GetCredentialProfile()
+	if updateAttributes {
+		attributes = update
+	}
+	if updateProfile {
+		profile = update.GetCredentialProfile()
+	}
+	if attributes.GetCredentialProfile() == profile {
+		return attributes
+	}
+
+	attributes = proto.CloneOf(attributes)
+	if attributes == nil {
+		attributes = &common.RegistrationEntry_AdditionalAttributes{}
+	}
+	attributes.CredentialProfile = proto.CloneOf(profile)
+	return attributes
+}
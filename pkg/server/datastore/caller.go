@@ -0,0 +1,18 @@
+package datastore
+
+import "context"
+
+type callerIDKey struct{}
+
+// WithCallerID returns a context that attributes the registration entry
+// changes made with it to the caller with the given SPIFFE ID.
+func WithCallerID(ctx context.Context, callerID string) context.Context {
+	return context.WithValue(ctx, callerIDKey{}, callerID)
+}
+
+// CallerIDFromContext returns the SPIFFE ID of the caller set on the context
+// with WithCallerID, or an empty string if there is none.
+func CallerIDFromContext(ctx context.Context) string {
+	callerID, _ := ctx.Value(callerIDKey{}).(string)
+	return callerID
+}
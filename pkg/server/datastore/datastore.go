@@ -30,6 +30,7 @@ type DataStore interface {
 	RevokeJWTKey(ctx context.Context, trustDomainID string, authorityID string) (*common.PublicKey, error)
 
 	// Entries
+	ActivateRegistrationEntries(ctx context.Context, since, until time.Time) error
 	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (int32, error)
 	CreateRegistrationEntry(context.Context, *common.RegistrationEntry) (*common.RegistrationEntry, error)
 	CreateOrReturnRegistrationEntry(context.Context, *common.RegistrationEntry) (*common.RegistrationEntry, bool, error)
@@ -47,6 +48,10 @@ type DataStore interface {
 	CreateRegistrationEntryEventForTesting(ctx context.Context, event *RegistrationEntryEvent) error
 	DeleteRegistrationEntryEventForTesting(ctx context.Context, eventID uint) error
 
+	// Entries History
+	ListRegistrationEntryChanges(ctx context.Context, req *ListRegistrationEntryChangesRequest) (*ListRegistrationEntryChangesResponse, error)
+	PruneRegistrationEntryChanges(ctx context.Context, olderThan time.Duration) error
+
 	// Nodes
 	CountAttestedNodes(context.Context, *CountAttestedNodesRequest) (int32, error)
 	CreateAttestedNode(context.Context, *common.AttestedNode) (*common.AttestedNode, error)
@@ -75,6 +80,7 @@ type DataStore interface {
 	CreateJoinToken(context.Context, *JoinToken) error
 	DeleteJoinToken(ctx context.Context, token string) error
 	FetchJoinToken(ctx context.Context, token string) (*JoinToken, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
 	PruneJoinTokens(context.Context, time.Time) error
 
 	// Federation Relationships
@@ -88,7 +94,16 @@ type DataStore interface {
 	SetCAJournal(ctx context.Context, caJournal *CAJournal) (*CAJournal, error)
 	FetchCAJournal(ctx context.Context, activeX509AuthorityID string) (*CAJournal, error)
 	PruneCAJournals(ctx context.Context, allCAsExpireBefore int64) error
+	ListCAJournals(ctx context.Context, req *ListCAJournalsRequest) (*ListCAJournalsResponse, error)
 	ListCAJournalsForTesting(ctx context.Context) ([]*CAJournal, error)
+
+	// Export
+	// ExportRecords reads every record in a single read transaction, so that
+	// the records form a point-in-time view of the datastore, and calls fn
+	// for each of them in dependency order: bundles, federation
+	// relationships, registration entries, attested nodes (with their
+	// selectors), join tokens and CA journals.
+	ExportRecords(ctx context.Context, fn func(*ExportRecord) error) error
 }
 
 // DataConsistency indicates the required data consistency for a read operation.
@@ -200,6 +215,24 @@ type ListBundlesResponse struct {
 	Pagination *Pagination
 }
 
+type ListJoinTokensRequest struct {
+	Pagination *Pagination
+}
+
+type ListJoinTokensResponse struct {
+	JoinTokens []*JoinToken
+	Pagination *Pagination
+}
+
+type ListCAJournalsRequest struct {
+	Pagination *Pagination
+}
+
+type ListCAJournalsResponse struct {
+	CAJournals []*CAJournal
+	Pagination *Pagination
+}
+
 type ListNodeSelectorsRequest struct {
 	DataConsistency DataConsistency
 	ValidAt         time.Time
@@ -210,14 +243,15 @@ type ListNodeSelectorsResponse struct {
 }
 
 type ListRegistrationEntriesRequest struct {
-	DataConsistency DataConsistency
-	ByParentID      string
-	BySelectors     *BySelectors
-	BySpiffeID      string
-	Pagination      *Pagination
-	ByFederatesWith *ByFederatesWith
-	ByHint          string
-	ByDownstream    *bool
+	DataConsistency  DataConsistency
+	ByParentID       string
+	BySelectors      *BySelectors
+	BySpiffeID       string
+	Pagination       *Pagination
+	ByFederatesWith  *ByFederatesWith
+	ByHint           string
+	ByDownstream     *bool
+	BySpiffeIDPrefix string
 }
 
 type CAJournal struct {
@@ -226,6 +260,17 @@ type CAJournal struct {
 	ActiveX509AuthorityID string
 }
 
+// ExportRecord is a single record read by ExportRecords. Exactly one of the
+// fields is set.
+type ExportRecord struct {
+	Bundle                 *common.Bundle
+	FederationRelationship *FederationRelationship
+	RegistrationEntry      *common.RegistrationEntry
+	AttestedNode           *common.AttestedNode
+	JoinToken              *JoinToken
+	CAJournal              *CAJournal
+}
+
 type ListRegistrationEntriesResponse struct {
 	Entries    []*common.RegistrationEntry
 	Pagination *Pagination
@@ -246,6 +291,50 @@ type ListRegistrationEntryEventsResponse struct {
 	Events []RegistrationEntryEvent
 }
 
+// RegistrationEntryOperation is the kind of mutation recorded by a
+// RegistrationEntryChange.
+type RegistrationEntryOperation string
+
+const (
+	RegistrationEntryCreated RegistrationEntryOperation = "create"
+	RegistrationEntryUpdated RegistrationEntryOperation = "update"
+	RegistrationEntryDeleted RegistrationEntryOperation = "delete"
+	// RegistrationEntryPruned is recorded when an expired entry is deleted
+	// by PruneRegistrationEntries.
+	RegistrationEntryPruned RegistrationEntryOperation = "prune"
+)
+
+// RegistrationEntryChange records a single mutation of a registration entry.
+// Changes are recorded by the datastore in the same transaction as the
+// mutation. Unlike RegistrationEntryEvent, changes carry the entry values
+// before and after the mutation, and are kept until they are pruned by
+// PruneRegistrationEntryChanges.
+type RegistrationEntryChange struct {
+	// ID is assigned by the datastore and increases monotonically.
+	ID        uint
+	EntryID   string
+	Operation RegistrationEntryOperation
+	// Before is nil for created entries.
+	Before *common.RegistrationEntry
+	// After is nil for deleted entries.
+	After *common.RegistrationEntry
+	// CallerID is the SPIFFE ID of the caller that made the change (see
+	// WithCallerID). It is empty for callers on the local endpoint and for
+	// changes made by the server itself.
+	CallerID  string
+	CreatedAt time.Time
+}
+
+type ListRegistrationEntryChangesRequest struct {
+	ByEntryID  string
+	Pagination *Pagination
+}
+
+type ListRegistrationEntryChangesResponse struct {
+	Changes    []*RegistrationEntryChange
+	Pagination *Pagination
+}
+
 type ListFederationRelationshipsRequest struct {
 	Pagination *Pagination
 }
@@ -265,13 +354,14 @@ type CountAttestedNodesRequest struct {
 }
 
 type CountRegistrationEntriesRequest struct {
-	DataConsistency DataConsistency
-	ByParentID      string
-	BySelectors     *BySelectors
-	BySpiffeID      string
-	ByFederatesWith *ByFederatesWith
-	ByHint          string
-	ByDownstream    *bool
+	DataConsistency  DataConsistency
+	ByParentID       string
+	BySelectors      *BySelectors
+	BySpiffeID       string
+	ByFederatesWith  *ByFederatesWith
+	ByHint           string
+	ByDownstream     *bool
+	BySpiffeIDPrefix string
 }
 
 type BundleEndpointType string
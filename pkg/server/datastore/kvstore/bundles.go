@@ -0,0 +1,556 @@
+package kvstore
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/protoutil"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// CreateBundle stores the given bundle
+func (ds *Plugin) CreateBundle(_ context.Context, b *common.Bundle) (bundle *common.Bundle, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		bundle, err = createBundle(tx, b)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// UpdateBundle updates an existing bundle with the given CAs. Overwrites any
+// existing certificates.
+func (ds *Plugin) UpdateBundle(_ context.Context, b *common.Bundle, mask *common.BundleMask) (bundle *common.Bundle, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		bundle, err = updateBundle(tx, b, mask)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// SetBundle sets bundle contents. If no bundle exists for the trust domain, it is created.
+func (ds *Plugin) SetBundle(_ context.Context, b *common.Bundle) (bundle *common.Bundle, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		bundle, err = setBundle(tx, b)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// AppendBundle append bundle contents to the existing bundle (by trust domain). If no existing one is present, create it.
+func (ds *Plugin) AppendBundle(_ context.Context, b *common.Bundle) (bundle *common.Bundle, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		bundle, err = appendBundle(tx, b)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// DeleteBundle deletes the bundle with the matching TrustDomain. Any CACert data passed is ignored.
+func (ds *Plugin) DeleteBundle(ctx context.Context, trustDomainID string, mode datastore.DeleteMode) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return deleteBundle(tx, trustDomainID, mode, datastore.CallerIDFromContext(ctx))
+	})
+}
+
+// FetchBundle returns the bundle matching the specified Trust Domain.
+func (ds *Plugin) FetchBundle(_ context.Context, trustDomainID string) (resp *common.Bundle, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		resp, err = fetchBundle(tx, trustDomainID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CountBundles can be used to count all existing bundles.
+func (ds *Plugin) CountBundles(context.Context) (count int32, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		count, err = util.CheckedCast[int32](bundlesTable.count(tx))
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListBundles can be used to fetch all existing bundles.
+func (ds *Plugin) ListBundles(_ context.Context, req *datastore.ListBundlesRequest) (resp *datastore.ListBundlesResponse, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		resp, err = listBundles(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneBundle removes expired certs and keys from a bundle
+func (ds *Plugin) PruneBundle(_ context.Context, trustDomainID string, expiresBefore time.Time) (changed bool, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		changed, err = pruneBundle(tx, trustDomainID, expiresBefore, ds.log)
+		return err
+	}); err != nil {
+		return false, err
+	}
+	return changed, nil
+}
+
+// TaintX509CA taints an X.509 CA signed using the provided public key
+func (ds *Plugin) TaintX509CA(_ context.Context, trustDomainID string, subjectKeyIDToTaint string) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return taintX509CA(tx, trustDomainID, subjectKeyIDToTaint)
+	})
+}
+
+// RevokeX509CA removes a Root CA from the bundle
+func (ds *Plugin) RevokeX509CA(_ context.Context, trustDomainID string, subjectKeyIDToRevoke string) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return revokeX509CA(tx, trustDomainID, subjectKeyIDToRevoke)
+	})
+}
+
+// TaintJWTKey taints a JWT Authority key
+func (ds *Plugin) TaintJWTKey(_ context.Context, trustDomainID string, authorityID string) (taintedKey *common.PublicKey, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		taintedKey, err = taintJWTKey(tx, trustDomainID, authorityID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return taintedKey, nil
+}
+
+// RevokeJWTKey removes JWT key from the bundle
+func (ds *Plugin) RevokeJWTKey(_ context.Context, trustDomainID string, authorityID string) (revokedKey *common.PublicKey, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		revokedKey, err = revokeJWTKey(tx, trustDomainID, authorityID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return revokedKey, nil
+}
+
+func createBundle(tx *bolt.Tx, bundle *common.Bundle) (*common.Bundle, error) {
+	if bundle == nil {
+		return nil, newValidationError("missing bundle in request")
+	}
+	data, err := proto.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := bundlesTable.create(tx, bundle.TrustDomainId, data); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func updateBundle(tx *bolt.Tx, newBundle *common.Bundle, mask *common.BundleMask) (*common.Bundle, error) {
+	if newBundle == nil {
+		return nil, newValidationError("missing bundle in request")
+	}
+
+	id, bundle, err := getBundle(tx, newBundle.TrustDomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	if mask == nil {
+		mask = protoutil.AllTrueCommonBundleMask
+	}
+	if mask.RefreshHint {
+		bundle.RefreshHint = newBundle.RefreshHint
+	}
+	if mask.RootCas {
+		bundle.RootCas = newBundle.RootCas
+	}
+	if mask.JwtSigningKeys {
+		bundle.JwtSigningKeys = newBundle.JwtSigningKeys
+	}
+	if mask.WitSigningKeys {
+		bundle.WitSigningKeys = newBundle.WitSigningKeys
+	}
+	if mask.SequenceNumber {
+		bundle.SequenceNumber = newBundle.SequenceNumber
+	}
+
+	if err := putBundle(tx, id, bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func setBundle(tx *bolt.Tx, b *common.Bundle) (*common.Bundle, error) {
+	if b == nil {
+		return nil, newValidationError("missing bundle in request")
+	}
+	if bundlesTable.id(tx, b.TrustDomainId) == 0 {
+		return createBundle(tx, b)
+	}
+	return updateBundle(tx, b, nil)
+}
+
+func appendBundle(tx *bolt.Tx, b *common.Bundle) (*common.Bundle, error) {
+	if b == nil {
+		return nil, newValidationError("missing bundle in request")
+	}
+
+	id, bundle, err := getBundle(tx, b.TrustDomainId)
+	switch {
+	case errors.Is(err, errNotFound):
+		return createBundle(tx, b)
+	case err != nil:
+		return nil, err
+	}
+
+	bundle, changed := bundleutil.MergeBundles(bundle, b)
+	if changed {
+		bundle.SequenceNumber++
+		if err := putBundle(tx, id, bundle); err != nil {
+			return nil, err
+		}
+	}
+
+	return bundle, nil
+}
+
+func deleteBundle(tx *bolt.Tx, trustDomainID string, mode datastore.DeleteMode, callerID string) error {
+	if bundlesTable.id(tx, trustDomainID) == 0 {
+		return errNotFound
+	}
+
+	var federatedEntries []*entryRecord
+	if err := forEachEntry(tx, 0, func(record *entryRecord) error {
+		for _, td := range record.entry.FederatesWith {
+			if td == trustDomainID {
+				federatedEntries = append(federatedEntries, record)
+				break
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(federatedEntries) > 0 {
+		switch mode {
+		case datastore.Delete:
+			for _, record := range federatedEntries {
+				if err := registrationEntriesTable.delete(tx, record.entry.EntryId); err != nil {
+					return err
+				}
+				if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+					EntryID:   record.entry.EntryId,
+					Operation: datastore.RegistrationEntryDeleted,
+					CallerID:  callerID,
+					Before:    record.entry,
+				}); err != nil {
+					return err
+				}
+				if err := createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
+					EntryID: record.entry.EntryId,
+				}); err != nil {
+					return err
+				}
+			}
+		case datastore.Dissociate:
+			for _, record := range federatedEntries {
+				before := proto.Clone(record.entry).(*common.RegistrationEntry)
+				federatesWith := make([]string, 0, len(record.entry.FederatesWith))
+				for _, td := range record.entry.FederatesWith {
+					if td != trustDomainID {
+						federatesWith = append(federatesWith, td)
+					}
+				}
+				record.entry.FederatesWith = federatesWith
+				if err := putEntry(tx, record); err != nil {
+					return err
+				}
+				if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+					EntryID:   record.entry.EntryId,
+					Operation: datastore.RegistrationEntryUpdated,
+					CallerID:  callerID,
+					Before:    before,
+					After:     record.entry,
+				}); err != nil {
+					return err
+				}
+				if err := createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
+					EntryID: record.entry.EntryId,
+				}); err != nil {
+					return err
+				}
+			}
+		default:
+			return status.Newf(codes.FailedPrecondition, "datastore-kv: cannot delete bundle; federated with %d registration entries", len(federatedEntries)).Err()
+		}
+	}
+
+	return bundlesTable.delete(tx, trustDomainID)
+}
+
+// fetchBundle returns the bundle matching the specified Trust Domain, or nil
+// if there is no such bundle.
+func fetchBundle(tx *bolt.Tx, trustDomainID string) (*common.Bundle, error) {
+	_, bundle, err := getBundle(tx, trustDomainID)
+	switch {
+	case errors.Is(err, errNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func listBundles(tx *bolt.Tx, req *datastore.ListBundlesRequest) (*datastore.ListBundlesResponse, error) {
+	after, err := parsePagination(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(datastore.ListBundlesResponse)
+	var lastID uint64
+	if err := bundlesTable.forEach(tx, after, func(id uint64, value []byte) error {
+		bundle, err := unmarshalBundle(value)
+		if err != nil {
+			return err
+		}
+		resp.Bundles = append(resp.Bundles, bundle)
+		lastID = id
+		if req.Pagination != nil && len(resp.Bundles) >= int(req.Pagination.PageSize) {
+			return errStopIteration
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	resp.Pagination = nextPagination(req.Pagination, lastID)
+	return resp, nil
+}
+
+func pruneBundle(tx *bolt.Tx, trustDomainID string, expiry time.Time, log logrus.FieldLogger) (bool, error) {
+	id, currentBundle, err := getBundle(tx, trustDomainID)
+	switch {
+	case errors.Is(err, errNotFound):
+		// No bundle to prune
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("unable to fetch current bundle: %w", err)
+	}
+
+	newBundle, changed, err := bundleutil.PruneBundle(currentBundle, expiry, log)
+	if err != nil {
+		return false, fmt.Errorf("prune failed: %w", err)
+	}
+
+	// Update only if bundle was modified
+	if changed {
+		newBundle.SequenceNumber = currentBundle.SequenceNumber + 1
+		if err := putBundle(tx, id, newBundle); err != nil {
+			return false, fmt.Errorf("unable to write new bundle: %w", err)
+		}
+	}
+
+	return changed, nil
+}
+
+func taintX509CA(tx *bolt.Tx, trustDomainID string, subjectKeyIDToTaint string) error {
+	id, bundle, err := getBundle(tx, trustDomainID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, eachRootCA := range bundle.RootCas {
+		x509CA, err := x509.ParseCertificate(eachRootCA.DerBytes)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to parse rootCA: %v", err)
+		}
+
+		caSubjectKeyID := x509util.SubjectKeyIDToString(x509CA.SubjectKeyId)
+		if subjectKeyIDToTaint != caSubjectKeyID {
+			continue
+		}
+
+		if eachRootCA.TaintedKey {
+			return status.Errorf(codes.InvalidArgument, "root CA is already tainted")
+		}
+
+		found = true
+		eachRootCA.TaintedKey = true
+	}
+
+	if !found {
+		return status.Error(codes.NotFound, "no ca found with provided subject key ID")
+	}
+
+	bundle.SequenceNumber++
+	return putBundle(tx, id, bundle)
+}
+
+func revokeX509CA(tx *bolt.Tx, trustDomainID string, subjectKeyIDToRevoke string) error {
+	id, bundle, err := getBundle(tx, trustDomainID)
+	if err != nil {
+		return err
+	}
+
+	keyFound := false
+	var rootCAs []*common.Certificate
+	for _, ca := range bundle.RootCas {
+		cert, err := x509.ParseCertificate(ca.DerBytes)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to parse root CA: %v", err)
+		}
+
+		caSubjectKeyID := x509util.SubjectKeyIDToString(cert.SubjectKeyId)
+		if subjectKeyIDToRevoke == caSubjectKeyID {
+			if !ca.TaintedKey {
+				return status.Error(codes.InvalidArgument, "it is not possible to revoke an untainted root CA")
+			}
+			keyFound = true
+			continue
+		}
+
+		rootCAs = append(rootCAs, ca)
+	}
+
+	if !keyFound {
+		return status.Error(codes.NotFound, "no root CA found with provided subject key ID")
+	}
+
+	bundle.RootCas = rootCAs
+	bundle.SequenceNumber++
+
+	if err := putBundle(tx, id, bundle); err != nil {
+		return status.Errorf(codes.Internal, "failed to update bundle: %v", err)
+	}
+	return nil
+}
+
+func taintJWTKey(tx *bolt.Tx, trustDomainID string, authorityID string) (*common.PublicKey, error) {
+	id, bundle, err := getBundle(tx, trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	var taintedKey *common.PublicKey
+	for _, jwtKey := range bundle.JwtSigningKeys {
+		if jwtKey.Kid != authorityID {
+			continue
+		}
+
+		if jwtKey.TaintedKey {
+			return nil, status.Error(codes.InvalidArgument, "key is already tainted")
+		}
+
+		// Check if a JWT Key with the provided keyID was already
+		// tainted in this loop. This is purely defensive since we do not
+		// allow to have repeated key IDs.
+		if taintedKey != nil {
+			return nil, status.Error(codes.Internal, "another JWT Key found with the same KeyID")
+		}
+		taintedKey = jwtKey
+		jwtKey.TaintedKey = true
+	}
+
+	if taintedKey == nil {
+		return nil, status.Error(codes.NotFound, "no JWT Key found with provided key ID")
+	}
+
+	bundle.SequenceNumber++
+	if err := putBundle(tx, id, bundle); err != nil {
+		return nil, err
+	}
+	return taintedKey, nil
+}
+
+func revokeJWTKey(tx *bolt.Tx, trustDomainID string, authorityID string) (*common.PublicKey, error) {
+	id, bundle, err := getBundle(tx, trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	var publicKeys []*common.PublicKey
+	var revokedKey *common.PublicKey
+	for _, key := range bundle.JwtSigningKeys {
+		if key.Kid == authorityID {
+			// Check if a JWT Key with the provided keyID was already
+			// found in this loop. This is purely defensive since we do not
+			// allow to have repeated key IDs.
+			if revokedKey != nil {
+				return nil, status.Error(codes.Internal, "another key found with the same KeyID")
+			}
+
+			if !key.TaintedKey {
+				return nil, status.Error(codes.InvalidArgument, "it is not possible to revoke an untainted key")
+			}
+
+			revokedKey = key
+			continue
+		}
+		publicKeys = append(publicKeys, key)
+	}
+	bundle.JwtSigningKeys = publicKeys
+
+	if revokedKey == nil {
+		return nil, status.Error(codes.NotFound, "no JWT Key found with provided key ID")
+	}
+
+	bundle.SequenceNumber++
+	if err := putBundle(tx, id, bundle); err != nil {
+		return nil, err
+	}
+	return revokedKey, nil
+}
+
+// getBundle returns the bundle for the given trust domain along with its
+// internal ID. It fails with errNotFound if there is no such bundle.
+func getBundle(tx *bolt.Tx, trustDomainID string) (uint64, *common.Bundle, error) {
+	id, value := bundlesTable.get(tx, trustDomainID)
+	if value == nil {
+		return 0, nil, errNotFound
+	}
+	bundle, err := unmarshalBundle(value)
+	if err != nil {
+		return 0, nil, err
+	}
+	return id, bundle, nil
+}
+
+func putBundle(tx *bolt.Tx, id uint64, bundle *common.Bundle) error {
+	data, err := proto.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	return bundlesTable.update(tx, id, data)
+}
+
+func unmarshalBundle(value []byte) (*common.Bundle, error) {
+	bundle := new(common.Bundle)
+	if err := proto.Unmarshal(value, bundle); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal bundle: %w", err)
+	}
+	return bundle, nil
+}
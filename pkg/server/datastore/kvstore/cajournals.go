@@ -0,0 +1,213 @@
+package kvstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/private/server/journal"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// caJournalRecord is the stored representation of a CA journal
+type caJournalRecord struct {
+	Data                  []byte `json:"data"`
+	ActiveX509AuthorityID string `json:"active_x509_authority_id"`
+}
+
+// FetchCAJournal fetches the CA journal that has the given active X509
+// authority domain. If the CA journal is not found, nil is returned.
+func (ds *Plugin) FetchCAJournal(_ context.Context, activeX509AuthorityID string) (caJournal *datastore.CAJournal, err error) {
+	if activeX509AuthorityID == "" {
+		return nil, status.Error(codes.InvalidArgument, "active X509 authority ID is required")
+	}
+
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		return forEachCAJournal(tx, func(j *datastore.CAJournal) error {
+			if j.ActiveX509AuthorityID == activeX509AuthorityID {
+				caJournal = j
+				return errStopIteration
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return caJournal, nil
+}
+
+// ListCAJournals lists CA journals
+func (ds *Plugin) ListCAJournals(_ context.Context, req *datastore.ListCAJournalsRequest) (resp *datastore.ListCAJournalsResponse, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		resp, err = listCAJournals(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListCAJournalsForTesting returns all the CA journal records, and is meant to
+// be used in tests.
+func (ds *Plugin) ListCAJournalsForTesting(context.Context) (caJournals []*datastore.CAJournal, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		return forEachCAJournal(tx, func(j *datastore.CAJournal) error {
+			caJournals = append(caJournals, j)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return caJournals, nil
+}
+
+// SetCAJournal sets the content for the specified CA journal. If the CA journal
+// does not exist, it is created.
+func (ds *Plugin) SetCAJournal(_ context.Context, caJournal *datastore.CAJournal) (caj *datastore.CAJournal, err error) {
+	if caJournal == nil {
+		return nil, status.Error(codes.InvalidArgument, "ca journal is required")
+	}
+
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		caj, err = setCAJournal(tx, caJournal)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return caj, nil
+}
+
+// PruneCAJournals prunes the CA journals that have all of their authorities
+// expired.
+func (ds *Plugin) PruneCAJournals(_ context.Context, allAuthoritiesExpireBefore int64) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return ds.pruneCAJournals(tx, allAuthoritiesExpireBefore)
+	})
+}
+
+func (ds *Plugin) pruneCAJournals(tx *bolt.Tx, allAuthoritiesExpireBefore int64) error {
+	var stale []*datastore.CAJournal
+	if err := forEachCAJournal(tx, func(j *datastore.CAJournal) error {
+		entries := new(journal.Entries)
+		if err := proto.Unmarshal(j.Data, entries); err != nil {
+			return status.Errorf(codes.Internal, "unable to unmarshal entries from CA journal record: %v", err)
+		}
+
+		for _, x509CA := range entries.X509CAs {
+			if x509CA.NotAfter > allAuthoritiesExpireBefore {
+				return nil
+			}
+		}
+		for _, jwtKey := range entries.JwtKeys {
+			if jwtKey.NotAfter > allAuthoritiesExpireBefore {
+				return nil
+			}
+		}
+		stale = append(stale, j)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	b := tx.Bucket(caJournalsBucket)
+	for _, j := range stale {
+		if err := b.Delete(itob(uint64(j.ID))); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete CA journal: %v", err)
+		}
+		ds.log.WithFields(logrus.Fields{
+			telemetry.CAJournalID: j.ID,
+		}).Info("Pruned stale CA journal record")
+	}
+
+	return nil
+}
+
+func listCAJournals(tx *bolt.Tx, req *datastore.ListCAJournalsRequest) (*datastore.ListCAJournalsResponse, error) {
+	after, err := parsePagination(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListCAJournalsResponse{
+		CAJournals: []*datastore.CAJournal{},
+	}
+
+	var lastID uint64
+	c := tx.Bucket(caJournalsBucket).Cursor()
+	for k, v := c.Seek(itob(after + 1)); k != nil; k, v = c.Next() {
+		record := new(caJournalRecord)
+		if err := json.Unmarshal(v, record); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal CA journal: %w", err)
+		}
+		lastID = btoi(k)
+		resp.CAJournals = append(resp.CAJournals, &datastore.CAJournal{
+			ID:                    uint(lastID),
+			Data:                  record.Data,
+			ActiveX509AuthorityID: record.ActiveX509AuthorityID,
+		})
+		if req.Pagination != nil && len(resp.CAJournals) >= int(req.Pagination.PageSize) {
+			break
+		}
+	}
+
+	resp.Pagination = nextPagination(req.Pagination, lastID)
+	return resp, nil
+}
+
+func setCAJournal(tx *bolt.Tx, caJournal *datastore.CAJournal) (*datastore.CAJournal, error) {
+	data, err := json.Marshal(&caJournalRecord{
+		Data:                  caJournal.Data,
+		ActiveX509AuthorityID: caJournal.ActiveX509AuthorityID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b := tx.Bucket(caJournalsBucket)
+	id := uint64(caJournal.ID)
+	if id == 0 {
+		if id, err = b.NextSequence(); err != nil {
+			return nil, err
+		}
+	} else if b.Get(itob(id)) == nil {
+		// The CA journal is expected to exist when updating
+		return nil, errNotFound
+	}
+
+	if err := b.Put(itob(id), data); err != nil {
+		return nil, err
+	}
+
+	return &datastore.CAJournal{
+		ID:                    uint(id),
+		Data:                  caJournal.Data,
+		ActiveX509AuthorityID: caJournal.ActiveX509AuthorityID,
+	}, nil
+}
+
+func forEachCAJournal(tx *bolt.Tx, fn func(j *datastore.CAJournal) error) error {
+	c := tx.Bucket(caJournalsBucket).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		record := new(caJournalRecord)
+		if err := json.Unmarshal(v, record); err != nil {
+			return fmt.Errorf("unable to unmarshal CA journal: %w", err)
+		}
+		if err := fn(&datastore.CAJournal{
+			ID:                    uint(btoi(k)),
+			Data:                  record.Data,
+			ActiveX509AuthorityID: record.ActiveX509AuthorityID,
+		}); err != nil {
+			if err == errStopIteration { //nolint: errorlint // sentinel is never wrapped
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package kvstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+// changeRecord is the stored representation of a registration entry change.
+// The entry values are protobuf encoded.
+type changeRecord struct {
+	EntryID   string    `json:"entry_id"`
+	Operation string    `json:"operation"`
+	CallerID  string    `json:"caller_id,omitempty"`
+	Before    []byte    `json:"before,omitempty"`
+	After     []byte    `json:"after,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListRegistrationEntryChanges lists registration entry changes, oldest first
+func (ds *Plugin) ListRegistrationEntryChanges(_ context.Context, req *datastore.ListRegistrationEntryChangesRequest) (resp *datastore.ListRegistrationEntryChangesResponse, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		resp, err = listRegistrationEntryChanges(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneRegistrationEntryChanges deletes all registration entry changes older than a specified duration
+func (ds *Plugin) PruneRegistrationEntryChanges(_ context.Context, olderThan time.Duration) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return pruneRegistrationEntryChanges(tx, olderThan)
+	})
+}
+
+func createRegistrationEntryChange(tx *bolt.Tx, change *datastore.RegistrationEntryChange) error {
+	record := &changeRecord{
+		EntryID:   change.EntryID,
+		Operation: string(change.Operation),
+		CallerID:  change.CallerID,
+		CreatedAt: time.Now(),
+	}
+	var err error
+	if change.Before != nil {
+		if record.Before, err = proto.Marshal(change.Before); err != nil {
+			return err
+		}
+	}
+	if change.After != nil {
+		if record.After, err = proto.Marshal(change.After); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = putWithID(tx.Bucket(registrationEntryChangesBucket), 0, data)
+	return err
+}
+
+func listRegistrationEntryChanges(tx *bolt.Tx, req *datastore.ListRegistrationEntryChangesRequest) (*datastore.ListRegistrationEntryChangesResponse, error) {
+	after, err := parsePagination(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(datastore.ListRegistrationEntryChangesResponse)
+	var lastID uint64
+	c := tx.Bucket(registrationEntryChangesBucket).Cursor()
+	for k, v := c.Seek(itob(after + 1)); k != nil; k, v = c.Next() {
+		record := new(changeRecord)
+		if err := json.Unmarshal(v, record); err != nil {
+			return nil, err
+		}
+		if req.ByEntryID != "" && record.EntryID != req.ByEntryID {
+			continue
+		}
+		change, err := recordToRegistrationEntryChange(btoi(k), record)
+		if err != nil {
+			return nil, err
+		}
+		resp.Changes = append(resp.Changes, change)
+		lastID = btoi(k)
+		if req.Pagination != nil && len(resp.Changes) >= int(req.Pagination.PageSize) {
+			break
+		}
+	}
+
+	resp.Pagination = nextPagination(req.Pagination, lastID)
+	return resp, nil
+}
+
+func pruneRegistrationEntryChanges(tx *bolt.Tx, olderThan time.Duration) error {
+	threshold := time.Now().Add(-olderThan)
+
+	b := tx.Bucket(registrationEntryChangesBucket)
+	var expired [][]byte
+	if err := b.ForEach(func(k, v []byte) error {
+		record := new(changeRecord)
+		if err := json.Unmarshal(v, record); err != nil {
+			return err
+		}
+		if record.CreatedAt.Before(threshold) {
+			expired = append(expired, bytes.Clone(k))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, k := range expired {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordToRegistrationEntryChange(id uint64, record *changeRecord) (*datastore.RegistrationEntryChange, error) {
+	change := &datastore.RegistrationEntryChange{
+		ID:        uint(id),
+		EntryID:   record.EntryID,
+		Operation: datastore.RegistrationEntryOperation(record.Operation),
+		CallerID:  record.CallerID,
+		CreatedAt: record.CreatedAt,
+	}
+	if len(record.Before) > 0 {
+		change.Before = new(common.RegistrationEntry)
+		if err := proto.Unmarshal(record.Before, change.Before); err != nil {
+			return nil, err
+		}
+	}
+	if len(record.After) > 0 {
+		change.After = new(common.RegistrationEntry)
+		if err := proto.Unmarshal(record.After, change.After); err != nil {
+			return nil, err
+		}
+	}
+	return change, nil
+}
@@ -0,0 +1,797 @@
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+	"unicode"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var validEntryIDChars = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x002d, 0x002e, 1}, // - | .
+		{0x0030, 0x0039, 1}, // [0-9]
+		{0x0041, 0x005a, 1}, // [A-Z]
+		{0x005f, 0x005f, 1}, // _
+		{0x0061, 0x007a, 1}, // [a-z]
+	},
+	LatinOffset: 5,
+}
+
+// entryRecord is a registration entry along with its internal ID
+type entryRecord struct {
+	id    uint64
+	entry *common.RegistrationEntry
+}
+
+// entryFilter holds the filters shared by the list and count requests
+type entryFilter struct {
+	byParentID       string
+	bySelectors      *datastore.BySelectors
+	bySpiffeID       string
+	byFederatesWith  *datastore.ByFederatesWith
+	byHint           string
+	byDownstream     *bool
+	bySpiffeIDPrefix string
+}
+
+// CreateRegistrationEntry stores the given registration entry
+func (ds *Plugin) CreateRegistrationEntry(ctx context.Context, entry *common.RegistrationEntry) (*common.RegistrationEntry, error) {
+	out, _, err := ds.createOrReturnRegistrationEntry(ctx, entry)
+	return out, err
+}
+
+// CreateOrReturnRegistrationEntry stores the given registration entry. If an
+// entry already exists with the same (parentID, spiffeID, selector) tuple,
+// that entry is returned instead.
+func (ds *Plugin) CreateOrReturnRegistrationEntry(ctx context.Context, entry *common.RegistrationEntry) (*common.RegistrationEntry, bool, error) {
+	return ds.createOrReturnRegistrationEntry(ctx, entry)
+}
+
+func (ds *Plugin) createOrReturnRegistrationEntry(ctx context.Context, entry *common.RegistrationEntry) (registrationEntry *common.RegistrationEntry, existing bool, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		if err = validateRegistrationEntry(entry); err != nil {
+			return err
+		}
+
+		registrationEntry, err = lookupSimilarEntry(tx, entry)
+		if err != nil {
+			return err
+		}
+		if registrationEntry != nil {
+			existing = true
+			return nil
+		}
+
+		registrationEntry, err = createRegistrationEntry(tx, entry, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+			EntryID:   registrationEntry.EntryId,
+			Operation: datastore.RegistrationEntryCreated,
+			CallerID:  datastore.CallerIDFromContext(ctx),
+			After:     registrationEntry,
+		}); err != nil {
+			return err
+		}
+
+		return createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
+			EntryID: registrationEntry.EntryId,
+		})
+	}); err != nil {
+		return nil, false, err
+	}
+	return registrationEntry, existing, nil
+}
+
+// FetchRegistrationEntry fetches an existing registration by entry ID
+func (ds *Plugin) FetchRegistrationEntry(_ context.Context, entryID string) (entry *common.RegistrationEntry, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		record, err := getEntry(tx, entryID)
+		switch {
+		case errors.Is(err, errNotFound):
+			return nil
+		case err != nil:
+			return err
+		}
+		entry = record.entry
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// FetchRegistrationEntries fetches existing registrations by entry IDs
+func (ds *Plugin) FetchRegistrationEntries(_ context.Context, entryIDs []string) (entries map[string]*common.RegistrationEntry, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		entries = make(map[string]*common.RegistrationEntry, len(entryIDs))
+		for _, entryID := range entryIDs {
+			record, err := getEntry(tx, entryID)
+			switch {
+			case errors.Is(err, errNotFound):
+				continue
+			case err != nil:
+				return err
+			}
+			entries[entryID] = record.entry
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// CountRegistrationEntries counts all registrations
+func (ds *Plugin) CountRegistrationEntries(_ context.Context, req *datastore.CountRegistrationEntriesRequest) (count int32, err error) {
+	if req.BySelectors != nil && len(req.BySelectors.Selectors) == 0 {
+		return 0, status.Error(codes.InvalidArgument, "cannot list by empty selector set")
+	}
+
+	filter := entryFilter{
+		byParentID:       req.ByParentID,
+		bySelectors:      req.BySelectors,
+		bySpiffeID:       req.BySpiffeID,
+		byFederatesWith:  req.ByFederatesWith,
+		byHint:           req.ByHint,
+		byDownstream:     req.ByDownstream,
+		bySpiffeIDPrefix: req.BySpiffeIDPrefix,
+	}
+
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		n := 0
+		if err := forEachEntry(tx, 0, func(record *entryRecord) error {
+			if filter.matches(record.entry) {
+				n++
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		count, err = util.CheckedCast[int32](n)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListRegistrationEntries lists all registrations (pagination available)
+func (ds *Plugin) ListRegistrationEntries(_ context.Context, req *datastore.ListRegistrationEntriesRequest) (resp *datastore.ListRegistrationEntriesResponse, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		resp, err = listRegistrationEntries(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateRegistrationEntry updates an existing registration entry
+func (ds *Plugin) UpdateRegistrationEntry(ctx context.Context, e *common.RegistrationEntry, mask *common.RegistrationEntryMask) (entry *common.RegistrationEntry, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		var before *common.RegistrationEntry
+		before, entry, err = updateRegistrationEntry(tx, e, mask)
+		if err != nil {
+			return err
+		}
+
+		if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+			EntryID:   entry.EntryId,
+			Operation: datastore.RegistrationEntryUpdated,
+			CallerID:  datastore.CallerIDFromContext(ctx),
+			Before:    before,
+			After:     entry,
+		}); err != nil {
+			return err
+		}
+
+		return createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
+			EntryID: entry.EntryId,
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// DeleteRegistrationEntry deletes the given registration
+func (ds *Plugin) DeleteRegistrationEntry(ctx context.Context, entryID string) (entry *common.RegistrationEntry, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) error {
+		record, err := getEntry(tx, entryID)
+		if err != nil {
+			return err
+		}
+		if err := registrationEntriesTable.delete(tx, entryID); err != nil {
+			return err
+		}
+		entry = record.entry
+
+		if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+			EntryID:   entryID,
+			Operation: datastore.RegistrationEntryDeleted,
+			CallerID:  datastore.CallerIDFromContext(ctx),
+			Before:    entry,
+		}); err != nil {
+			return err
+		}
+
+		return createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
+			EntryID: entryID,
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ActivateRegistrationEntries creates a registration entry event for each
+// entry whose not-before time is after since and not after until, so the
+// entry caches pick up the entries that became active in that window. Entries
+// that already have an event created on or after their not-before time are
+// skipped, so servers sharing the datastore do not duplicate the events.
+func (ds *Plugin) ActivateRegistrationEntries(_ context.Context, since, until time.Time) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return activateRegistrationEntries(tx, since, until, ds.log)
+	})
+}
+
+// PruneRegistrationEntries takes a registration entry message, and deletes all entries which have expired
+// before the date in the message
+func (ds *Plugin) PruneRegistrationEntries(_ context.Context, expiresBefore time.Time) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return pruneRegistrationEntries(tx, expiresBefore, ds.log)
+	})
+}
+
+func createRegistrationEntry(tx *bolt.Tx, entry *common.RegistrationEntry, now time.Time) (*common.RegistrationEntry, error) {
+	entryID, err := createOrReturnEntryID(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	additionalAttributes, err := validateAdditionalAttributes(entry.AdditionalAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateFederatesWith(tx, entry.FederatesWith); err != nil {
+		return nil, err
+	}
+
+	stored := &common.RegistrationEntry{
+		EntryId:              entryID,
+		Selectors:            entry.Selectors,
+		SpiffeId:             entry.SpiffeId,
+		ParentId:             entry.ParentId,
+		X509SvidTtl:          entry.X509SvidTtl,
+		FederatesWith:        entry.FederatesWith,
+		Admin:                entry.Admin,
+		Downstream:           entry.Downstream,
+		EntryExpiry:          entry.EntryExpiry,
+		NotBefore:            entry.NotBefore,
+		DnsNames:             entry.DnsNames,
+		StoreSvid:            entry.StoreSvid,
+		JwtSvidTtl:           entry.JwtSvidTtl,
+		Hint:                 entry.Hint,
+		AdditionalAttributes: additionalAttributes,
+		CreatedAt:            roundedInSecondsUnix(now),
+	}
+
+	return insertEntry(tx, stored)
+}
+
+// insertEntry stores the given entry as-is, preserving its entry ID,
+// revision number and creation time.
+func insertEntry(tx *bolt.Tx, entry *common.RegistrationEntry) (*common.RegistrationEntry, error) {
+	data, err := proto.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := registrationEntriesTable.create(tx, entry.EntryId, data); err != nil {
+		return nil, err
+	}
+
+	// Round-trip through the stored representation so the returned entry
+	// matches exactly what later reads will return.
+	return unmarshalEntry(data)
+}
+
+func listRegistrationEntries(tx *bolt.Tx, req *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
+	after, err := parsePagination(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	if req.BySelectors != nil && len(req.BySelectors.Selectors) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selector set")
+	}
+
+	filter := entryFilter{
+		byParentID:       req.ByParentID,
+		bySelectors:      req.BySelectors,
+		bySpiffeID:       req.BySpiffeID,
+		byFederatesWith:  req.ByFederatesWith,
+		byHint:           req.ByHint,
+		byDownstream:     req.ByDownstream,
+		bySpiffeIDPrefix: req.BySpiffeIDPrefix,
+	}
+
+	resp := new(datastore.ListRegistrationEntriesResponse)
+	var lastID uint64
+	if err := forEachEntry(tx, after, func(record *entryRecord) error {
+		if !filter.matches(record.entry) {
+			return nil
+		}
+		resp.Entries = append(resp.Entries, record.entry)
+		lastID = record.id
+		if req.Pagination != nil && len(resp.Entries) >= int(req.Pagination.PageSize) {
+			return errStopIteration
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	resp.Pagination = nextPagination(req.Pagination, lastID)
+	return resp, nil
+}
+
+// updateRegistrationEntry updates the entry and returns its values before and
+// after the update.
+func updateRegistrationEntry(tx *bolt.Tx, e *common.RegistrationEntry, mask *common.RegistrationEntryMask) (*common.RegistrationEntry, *common.RegistrationEntry, error) {
+	if err := validateRegistrationEntryForUpdate(e, mask); err != nil {
+		return nil, nil, err
+	}
+
+	record, err := getEntry(tx, e.EntryId)
+	if err != nil {
+		return nil, nil, err
+	}
+	before := proto.Clone(record.entry).(*common.RegistrationEntry)
+	entry := record.entry
+
+	if mask == nil || mask.StoreSvid {
+		entry.StoreSvid = e.StoreSvid
+	}
+	if mask == nil || mask.Selectors {
+		entry.Selectors = e.Selectors
+	}
+
+	// Verify that final selectors contains the same 'type' when entry is used for store SVIDs
+	if entry.StoreSvid && !equalSelectorTypes(entry.Selectors) {
+		return nil, nil, newValidationError("invalid registration entry: selector types must be the same when store SVID is enabled")
+	}
+
+	if mask == nil || mask.DnsNames {
+		entry.DnsNames = e.DnsNames
+	}
+	if mask == nil || mask.SpiffeId {
+		entry.SpiffeId = e.SpiffeId
+	}
+	if mask == nil || mask.ParentId {
+		entry.ParentId = e.ParentId
+	}
+	if mask == nil || mask.X509SvidTtl {
+		entry.X509SvidTtl = e.X509SvidTtl
+	}
+	if mask == nil || mask.Admin {
+		entry.Admin = e.Admin
+	}
+	if mask == nil || mask.Downstream {
+		entry.Downstream = e.Downstream
+	}
+	if mask == nil || mask.EntryExpiry {
+		entry.EntryExpiry = e.EntryExpiry
+	}
+	if mask == nil || mask.NotBefore {
+		entry.NotBefore = e.NotBefore
+	}
+	if mask == nil || mask.JwtSvidTtl {
+		entry.JwtSvidTtl = e.JwtSvidTtl
+	}
+	if mask == nil || mask.Hint {
+		entry.Hint = e.Hint
+	}
+	if mask == nil || mask.AdditionalAttributes || mask.CredentialProfile {
+		additionalAttributes, err := validateAdditionalAttributes(datastore.UpdateAdditionalAttributes(before.AdditionalAttributes, e.AdditionalAttributes, mask))
+		if err != nil {
+			return nil, nil, err
+		}
+		entry.AdditionalAttributes = additionalAttributes
+	}
+	if mask == nil || mask.FederatesWith {
+		if err := validateFederatesWith(tx, e.FederatesWith); err != nil {
+			return nil, nil, err
+		}
+		entry.FederatesWith = e.FederatesWith
+	}
+
+	// Revision number is increased by 1 on every update call
+	entry.RevisionNumber++
+
+	if err := putEntry(tx, record); err != nil {
+		return nil, nil, err
+	}
+
+	// Re-read the entry so the returned value matches what later reads will
+	// return and does not alias the caller's slices.
+	updated, err := getEntry(tx, entry.EntryId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return before, updated.entry, nil
+}
+
+func activateRegistrationEntries(tx *bolt.Tx, since, until time.Time, logger logrus.FieldLogger) error {
+	activated := make(map[string]time.Time)
+	if err := forEachEntry(tx, 0, func(record *entryRecord) error {
+		if record.entry.NotBefore > since.Unix() && record.entry.NotBefore <= until.Unix() {
+			activated[record.entry.EntryId] = time.Unix(record.entry.NotBefore, 0)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(activated) == 0 {
+		return nil
+	}
+
+	// Every server runs the activation, so skip the entries that already
+	// have an event created on or after their not-before time.
+	if err := listEvents(tx, registrationEntryEventsBucket, 0, 0, func(_ uint64, record *eventRecord) {
+		if notBefore, ok := activated[record.Key]; ok && !record.CreatedAt.Before(notBefore) {
+			delete(activated, record.Key)
+		}
+	}); err != nil {
+		return err
+	}
+
+	for _, entryID := range slices.Sorted(maps.Keys(activated)) {
+		if err := createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
+			EntryID: entryID,
+		}); err != nil {
+			return err
+		}
+		logger.WithField(telemetry.RegistrationID, entryID).Debug("Activated registration entry")
+	}
+
+	return nil
+}
+
+func pruneRegistrationEntries(tx *bolt.Tx, expiresBefore time.Time, logger logrus.FieldLogger) error {
+	var expired []*common.RegistrationEntry
+	if err := forEachEntry(tx, 0, func(record *entryRecord) error {
+		if record.entry.EntryExpiry != 0 && record.entry.EntryExpiry < expiresBefore.Unix() {
+			expired = append(expired, record.entry)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, entry := range expired {
+		if err := registrationEntriesTable.delete(tx, entry.EntryId); err != nil {
+			return err
+		}
+		if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+			EntryID:   entry.EntryId,
+			Operation: datastore.RegistrationEntryPruned,
+			Before:    entry,
+		}); err != nil {
+			return err
+		}
+		if err := createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
+			EntryID: entry.EntryId,
+		}); err != nil {
+			return err
+		}
+		logger.WithFields(logrus.Fields{
+			telemetry.SPIFFEID:       entry.SpiffeId,
+			telemetry.ParentID:       entry.ParentId,
+			telemetry.RegistrationID: entry.EntryId,
+		}).Info("Pruned an expired registration")
+	}
+
+	return nil
+}
+
+// lookupSimilarEntry returns the entry with the same (parentID, spiffeID,
+// selectors) tuple as the given entry, if any.
+func lookupSimilarEntry(tx *bolt.Tx, entry *common.RegistrationEntry) (*common.RegistrationEntry, error) {
+	filter := entryFilter{
+		byParentID: entry.ParentId,
+		bySpiffeID: entry.SpiffeId,
+		bySelectors: &datastore.BySelectors{
+			Match:     datastore.Exact,
+			Selectors: entry.Selectors,
+		},
+	}
+
+	var similar *common.RegistrationEntry
+	if err := forEachEntry(tx, 0, func(record *entryRecord) error {
+		if filter.matches(record.entry) {
+			similar = record.entry
+			return errStopIteration
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return similar, nil
+}
+
+func (f *entryFilter) matches(entry *common.RegistrationEntry) bool {
+	if f.byParentID != "" && entry.ParentId != f.byParentID {
+		return false
+	}
+	if f.bySpiffeID != "" && entry.SpiffeId != f.bySpiffeID {
+		return false
+	}
+	if f.byHint != "" && entry.Hint != f.byHint {
+		return false
+	}
+	if f.bySpiffeIDPrefix != "" && !datastore.SpiffeIDHasPrefix(entry.SpiffeId, f.bySpiffeIDPrefix) {
+		return false
+	}
+	if f.byDownstream != nil && *f.byDownstream && !entry.Downstream {
+		return false
+	}
+	if f.bySelectors != nil && !datastore.MatchEntrySelectors(entry.Selectors, f.bySelectors) {
+		return false
+	}
+	if f.byFederatesWith != nil && len(f.byFederatesWith.TrustDomains) > 0 && !matchFederatesWith(entry.FederatesWith, f.byFederatesWith) {
+		return false
+	}
+	return true
+}
+
+// matchSelectors reports whether the given selectors satisfy the requested
+// match behavior.
+func matchSelectors(selectors []*common.Selector, by *datastore.BySelectors) bool {
+	type selectorKey struct {
+		Type  string
+		Value string
+	}
+	have := make(map[selectorKey]struct{}, len(selectors))
+	for _, s := range selectors {
+		have[selectorKey{Type: s.Type, Value: s.Value}] = struct{}{}
+	}
+	want := make(map[selectorKey]struct{}, len(by.Selectors))
+	for _, s := range by.Selectors {
+		want[selectorKey{Type: s.Type, Value: s.Value}] = struct{}{}
+	}
+	return matchSets(have, want, by.Match)
+}
+
+// matchFederatesWith reports whether the given federated trust domains
+// satisfy the requested match behavior.
+func matchFederatesWith(federatesWith []string, by *datastore.ByFederatesWith) bool {
+	have := make(map[string]struct{}, len(federatesWith))
+	for _, td := range federatesWith {
+		have[td] = struct{}{}
+	}
+	want := make(map[string]struct{}, len(by.TrustDomains))
+	for _, td := range by.TrustDomains {
+		want[td] = struct{}{}
+	}
+	return matchSets(have, want, by.Match)
+}
+
+// matchSets implements the datastore match behaviors over sets:
+//   - Exact: have and want are equal
+//   - Subset: have is a non-empty subset of want
+//   - Superset: have contains all of want
+//   - MatchAny: have and want intersect
+func matchSets[K comparable](have, want map[K]struct{}, match datastore.MatchBehavior) bool {
+	intersection := 0
+	for k := range have {
+		if _, ok := want[k]; ok {
+			intersection++
+		}
+	}
+
+	switch match {
+	case datastore.Exact:
+		return intersection == len(want) && intersection == len(have)
+	case datastore.Subset:
+		return intersection > 0 && intersection == len(have)
+	case datastore.Superset:
+		return intersection == len(want)
+	case datastore.MatchAny:
+		return intersection > 0
+	default:
+		return false
+	}
+}
+
+func forEachEntry(tx *bolt.Tx, after uint64, fn func(record *entryRecord) error) error {
+	return registrationEntriesTable.forEach(tx, after, func(id uint64, value []byte) error {
+		entry, err := unmarshalEntry(value)
+		if err != nil {
+			return err
+		}
+		return fn(&entryRecord{id: id, entry: entry})
+	})
+}
+
+// getEntry returns the entry with the given ID. It fails with errNotFound if
+// there is no such entry.
+func getEntry(tx *bolt.Tx, entryID string) (*entryRecord, error) {
+	id, value := registrationEntriesTable.get(tx, entryID)
+	if value == nil {
+		return nil, errNotFound
+	}
+	entry, err := unmarshalEntry(value)
+	if err != nil {
+		return nil, err
+	}
+	return &entryRecord{id: id, entry: entry}, nil
+}
+
+func putEntry(tx *bolt.Tx, record *entryRecord) error {
+	data, err := proto.Marshal(record.entry)
+	if err != nil {
+		return err
+	}
+	return registrationEntriesTable.update(tx, record.id, data)
+}
+
+func unmarshalEntry(value []byte) (*common.RegistrationEntry, error) {
+	entry := new(common.RegistrationEntry)
+	if err := proto.Unmarshal(value, entry); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal registration entry: %w", err)
+	}
+	if entry.Selectors == nil {
+		entry.Selectors = []*common.Selector{}
+	}
+	return entry, nil
+}
+
+func validateFederatesWith(tx *bolt.Tx, trustDomains []string) error {
+	for _, td := range trustDomains {
+		if bundlesTable.id(tx, td) == 0 {
+			return fmt.Errorf("unable to find federated bundle %q", td)
+		}
+	}
+	return nil
+}
+
+func validateAdditionalAttributes(additionalAttributes *common.RegistrationEntry_AdditionalAttributes) (*common.RegistrationEntry_AdditionalAttributes, error) {
+	if additionalAttributes == nil {
+		return nil, nil
+	}
+
+	marshaled, err := proto.Marshal(additionalAttributes)
+	if err != nil {
+		return nil, newValidationError("invalid additional attributes: %s", err)
+	}
+	if len(marshaled) > maxAdditionalAttributesSize {
+		return nil, newValidationError("invalid registration entry: additional attributes size exceeds the maximum allowed size of %d bytes", maxAdditionalAttributesSize)
+	}
+	if len(marshaled) == 0 {
+		// Empty attributes are not distinguishable from unset ones once
+		// stored; normalize them to nil.
+		return nil, nil
+	}
+	return additionalAttributes, nil
+}
+
+func validateRegistrationEntry(entry *common.RegistrationEntry) error {
+	if entry == nil {
+		return newValidationError("invalid request: missing registered entry")
+	}
+
+	if len(entry.Selectors) == 0 {
+		return newValidationError("invalid registration entry: missing selector list")
+	}
+
+	// In case of StoreSvid is set, all entries 'must' be the same type,
+	// it is done to avoid users to mix selectors from different platforms in
+	// entries with storable SVIDs
+	if entry.StoreSvid {
+		if entry.AdditionalAttributes.GetDisableX509SvidPrefetch() {
+			return newValidationError("specifying cache behaviour is incompatible with storable SVIDs")
+		}
+		if !equalSelectorTypes(entry.Selectors) {
+			return newValidationError("invalid registration entry: selector types must be the same when store SVID is enabled")
+		}
+	}
+
+	if len(entry.EntryId) > 255 {
+		return newValidationError("invalid registration entry: entry ID too long")
+	}
+
+	for _, e := range entry.EntryId {
+		if !unicode.In(e, validEntryIDChars) {
+			return newValidationError("invalid registration entry: entry ID contains invalid characters")
+		}
+	}
+
+	if len(entry.SpiffeId) == 0 {
+		return newValidationError("invalid registration entry: missing SPIFFE ID")
+	}
+
+	if entry.X509SvidTtl < 0 {
+		return newValidationError("invalid registration entry: X509SvidTtl is not set")
+	}
+
+	if entry.JwtSvidTtl < 0 {
+		return newValidationError("invalid registration entry: JwtSvidTtl is not set")
+	}
+
+	return nil
+}
+
+func validateRegistrationEntryForUpdate(entry *common.RegistrationEntry, mask *common.RegistrationEntryMask) error {
+	if entry == nil {
+		return newValidationError("invalid request: missing registered entry")
+	}
+
+	if (mask == nil || mask.Selectors) && len(entry.Selectors) == 0 {
+		return newValidationError("invalid registration entry: missing selector list")
+	}
+
+	if (mask == nil || mask.SpiffeId) && entry.SpiffeId == "" {
+		return newValidationError("invalid registration entry: missing SPIFFE ID")
+	}
+
+	if (mask == nil || mask.X509SvidTtl) && entry.X509SvidTtl < 0 {
+		return newValidationError("invalid registration entry: X509SvidTtl is not set")
+	}
+
+	if (mask == nil || mask.JwtSvidTtl) && entry.JwtSvidTtl < 0 {
+		return newValidationError("invalid registration entry: JwtSvidTtl is not set")
+	}
+
+	return nil
+}
+
+// equalSelectorTypes validates that all selectors has the same type
+func equalSelectorTypes(selectors []*common.Selector) bool {
+	typ := ""
+	for _, t := range selectors {
+		switch {
+		case typ == "":
+			typ = t.Type
+		case typ != t.Type:
+			return false
+		}
+	}
+	return true
+}
+
+func createOrReturnEntryID(entry *common.RegistrationEntry) (string, error) {
+	if entry.EntryId != "" {
+		return entry.EntryId, nil
+	}
+
+	u, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// roundedInSecondsUnix rounds the time to the nearest second, and return the
+// time in seconds since the unix epoch. This keeps creation timestamps
+// consistent with the SQL datastore.
+func roundedInSecondsUnix(t time.Time) int64 {
+	return t.Round(time.Second).Unix()
+}
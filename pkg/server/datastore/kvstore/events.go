@@ -0,0 +1,215 @@
+package kvstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/datastore"
+	bolt "go.etcd.io/bbolt"
+)
+
+// eventRecord is the stored representation of registration entry and
+// attested node events. Key holds the entry ID or the agent SPIFFE ID.
+type eventRecord struct {
+	Key       string    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListRegistrationEntryEvents lists all registration entry events
+func (ds *Plugin) ListRegistrationEntryEvents(_ context.Context, req *datastore.ListRegistrationEntryEventsRequest) (resp *datastore.ListRegistrationEntryEventsResponse, err error) {
+	resp = &datastore.ListRegistrationEntryEventsResponse{
+		Events: []datastore.RegistrationEntryEvent{},
+	}
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		return listEvents(tx, registrationEntryEventsBucket, req.GreaterThanEventID, req.LessThanEventID, func(id uint64, record *eventRecord) {
+			resp.Events = append(resp.Events, datastore.RegistrationEntryEvent{
+				EventID: uint(id),
+				EntryID: record.Key,
+			})
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneRegistrationEntryEvents deletes all registration entry events older than a specified duration (i.e. more than 24 hours old)
+func (ds *Plugin) PruneRegistrationEntryEvents(_ context.Context, olderThan time.Duration) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return pruneEvents(tx, registrationEntryEventsBucket, olderThan)
+	})
+}
+
+// FetchRegistrationEntryEvent fetches an existing registration entry event by event ID
+func (ds *Plugin) FetchRegistrationEntryEvent(_ context.Context, eventID uint) (event *datastore.RegistrationEntryEvent, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		record, err := fetchEvent(tx, registrationEntryEventsBucket, eventID)
+		if err != nil {
+			return err
+		}
+		event = &datastore.RegistrationEntryEvent{
+			EventID: eventID,
+			EntryID: record.Key,
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// CreateRegistrationEntryEventForTesting creates a registration entry event. Used for unit testing.
+func (ds *Plugin) CreateRegistrationEntryEventForTesting(_ context.Context, event *datastore.RegistrationEntryEvent) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return createRegistrationEntryEvent(tx, event)
+	})
+}
+
+// DeleteRegistrationEntryEventForTesting deletes the given registration entry event. Used for unit testing.
+func (ds *Plugin) DeleteRegistrationEntryEventForTesting(_ context.Context, eventID uint) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return tx.Bucket(registrationEntryEventsBucket).Delete(itob(uint64(eventID)))
+	})
+}
+
+// ListAttestedNodeEvents lists all attested node events
+func (ds *Plugin) ListAttestedNodeEvents(_ context.Context, req *datastore.ListAttestedNodeEventsRequest) (resp *datastore.ListAttestedNodeEventsResponse, err error) {
+	resp = &datastore.ListAttestedNodeEventsResponse{
+		Events: []datastore.AttestedNodeEvent{},
+	}
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		return listEvents(tx, attestedNodeEventsBucket, req.GreaterThanEventID, req.LessThanEventID, func(id uint64, record *eventRecord) {
+			resp.Events = append(resp.Events, datastore.AttestedNodeEvent{
+				EventID:  uint(id),
+				SpiffeID: record.Key,
+			})
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneAttestedNodeEvents deletes all attested node events older than a specified duration (i.e. more than 24 hours old)
+func (ds *Plugin) PruneAttestedNodeEvents(_ context.Context, olderThan time.Duration) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return pruneEvents(tx, attestedNodeEventsBucket, olderThan)
+	})
+}
+
+// FetchAttestedNodeEvent fetches an existing attested node event by event ID
+func (ds *Plugin) FetchAttestedNodeEvent(_ context.Context, eventID uint) (event *datastore.AttestedNodeEvent, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		record, err := fetchEvent(tx, attestedNodeEventsBucket, eventID)
+		if err != nil {
+			return err
+		}
+		event = &datastore.AttestedNodeEvent{
+			EventID:  eventID,
+			SpiffeID: record.Key,
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// CreateAttestedNodeEventForTesting creates an attested node event. Used for unit testing.
+func (ds *Plugin) CreateAttestedNodeEventForTesting(_ context.Context, event *datastore.AttestedNodeEvent) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return createAttestedNodeEvent(tx, event)
+	})
+}
+
+// DeleteAttestedNodeEventForTesting deletes an attested node event by event ID. Used for unit testing.
+func (ds *Plugin) DeleteAttestedNodeEventForTesting(_ context.Context, eventID uint) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return tx.Bucket(attestedNodeEventsBucket).Delete(itob(uint64(eventID)))
+	})
+}
+
+func createRegistrationEntryEvent(tx *bolt.Tx, event *datastore.RegistrationEntryEvent) error {
+	return createEvent(tx, registrationEntryEventsBucket, uint64(event.EventID), event.EntryID)
+}
+
+func createAttestedNodeEvent(tx *bolt.Tx, event *datastore.AttestedNodeEvent) error {
+	return createEvent(tx, attestedNodeEventsBucket, uint64(event.EventID), event.SpiffeID)
+}
+
+func createEvent(tx *bolt.Tx, bucket []byte, eventID uint64, key string) error {
+	data, err := json.Marshal(&eventRecord{
+		Key:       key,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = putWithID(tx.Bucket(bucket), eventID, data)
+	return err
+}
+
+func fetchEvent(tx *bolt.Tx, bucket []byte, eventID uint) (*eventRecord, error) {
+	value := tx.Bucket(bucket).Get(itob(uint64(eventID)))
+	if value == nil {
+		return nil, errNotFound
+	}
+	return unmarshalEvent(value)
+}
+
+func listEvents(tx *bolt.Tx, bucket []byte, greaterThanEventID, lessThanEventID uint, fn func(id uint64, record *eventRecord)) error {
+	if greaterThanEventID != 0 && lessThanEventID != 0 {
+		return errors.New("can't set both greater and less than event id")
+	}
+
+	c := tx.Bucket(bucket).Cursor()
+	for k, v := c.Seek(itob(uint64(greaterThanEventID) + 1)); k != nil; k, v = c.Next() {
+		id := btoi(k)
+		if lessThanEventID != 0 && id >= uint64(lessThanEventID) {
+			break
+		}
+		record, err := unmarshalEvent(v)
+		if err != nil {
+			return err
+		}
+		fn(id, record)
+	}
+	return nil
+}
+
+func pruneEvents(tx *bolt.Tx, bucket []byte, olderThan time.Duration) error {
+	threshold := time.Now().Add(-olderThan)
+
+	b := tx.Bucket(bucket)
+	var expired [][]byte
+	if err := b.ForEach(func(k, v []byte) error {
+		record, err := unmarshalEvent(v)
+		if err != nil {
+			return err
+		}
+		if record.CreatedAt.Before(threshold) {
+			expired = append(expired, bytes.Clone(k))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, k := range expired {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unmarshalEvent(value []byte) (*eventRecord, error) {
+	record := new(eventRecord)
+	if err := json.Unmarshal(value, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
@@ -0,0 +1,82 @@
+package kvstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ExportRecords reads every record in a single read transaction and calls fn
+// for each of them. Errors returned by fn are returned unmodified.
+func (ds *Plugin) ExportRecords(_ context.Context, fn func(*datastore.ExportRecord) error) error {
+	var fnErr error
+	err := ds.withReadTx(func(tx *bolt.Tx) error {
+		return exportRecords(tx, func(r *datastore.ExportRecord) error {
+			fnErr = fn(r)
+			return fnErr
+		})
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
+
+func exportRecords(tx *bolt.Tx, fn func(*datastore.ExportRecord) error) error {
+	if err := bundlesTable.forEach(tx, 0, func(_ uint64, value []byte) error {
+		bundle, err := unmarshalBundle(value)
+		if err != nil {
+			return err
+		}
+		return fn(&datastore.ExportRecord{Bundle: bundle})
+	}); err != nil {
+		return err
+	}
+
+	if err := federationRelationshipsTable.forEach(tx, 0, func(_ uint64, value []byte) error {
+		record, err := unmarshalFederationRecord(value)
+		if err != nil {
+			return err
+		}
+		fr, err := recordToFederationRelationship(tx, record)
+		if err != nil {
+			return err
+		}
+		return fn(&datastore.ExportRecord{FederationRelationship: fr})
+	}); err != nil {
+		return err
+	}
+
+	if err := forEachEntry(tx, 0, func(record *entryRecord) error {
+		return fn(&datastore.ExportRecord{RegistrationEntry: record.entry})
+	}); err != nil {
+		return err
+	}
+
+	if err := forEachAttestedNode(tx, 0, func(_ uint64, node *common.AttestedNode) error {
+		var err error
+		if node.Selectors, err = getNodeSelectors(tx, node.SpiffeId); err != nil {
+			return err
+		}
+		return fn(&datastore.ExportRecord{AttestedNode: node})
+	}); err != nil {
+		return err
+	}
+
+	c := tx.Bucket(joinTokensBucket).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := fn(&datastore.ExportRecord{JoinToken: &datastore.JoinToken{
+			Token:  string(k),
+			Expiry: time.Unix(int64(btoi(v)), 0), //nolint: gosec // expiry is always stored from a non-negative unix time
+		}}); err != nil {
+			return err
+		}
+	}
+
+	return forEachCAJournal(tx, func(j *datastore.CAJournal) error {
+		return fn(&datastore.ExportRecord{CAJournal: j})
+	})
+}
@@ -0,0 +1,292 @@
+package kvstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/protoutil"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// federationRecord is the stored representation of a federation
+// relationship. The trust domain bundle is stored in the bundles table.
+type federationRecord struct {
+	TrustDomain           string `json:"trust_domain"`
+	BundleEndpointURL     string `json:"bundle_endpoint_url"`
+	BundleEndpointProfile string `json:"bundle_endpoint_profile"`
+	EndpointSPIFFEID      string `json:"endpoint_spiffe_id,omitempty"`
+}
+
+// CreateFederationRelationship creates a new federation relationship. If the bundle endpoint
+// profile is 'https_spiffe' and the given federation relationship contains a bundle, the current
+// stored bundle is overridden.
+func (ds *Plugin) CreateFederationRelationship(_ context.Context, fr *datastore.FederationRelationship) (newFr *datastore.FederationRelationship, err error) {
+	if err := validateFederationRelationship(fr, protoutil.AllTrueFederationRelationshipMask); err != nil {
+		return nil, err
+	}
+
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		newFr, err = createFederationRelationship(tx, fr)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return newFr, nil
+}
+
+// DeleteFederationRelationship deletes the federation relationship to the
+// given trust domain. The associated trust bundle is not deleted.
+func (ds *Plugin) DeleteFederationRelationship(_ context.Context, trustDomain spiffeid.TrustDomain) error {
+	if trustDomain.IsZero() {
+		return status.Error(codes.InvalidArgument, "trust domain is required")
+	}
+
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return federationRelationshipsTable.delete(tx, trustDomain.Name())
+	})
+}
+
+// FetchFederationRelationship fetches the federation relationship that matches
+// the given trust domain. If the federation relationship is not found, nil is returned.
+func (ds *Plugin) FetchFederationRelationship(_ context.Context, trustDomain spiffeid.TrustDomain) (fr *datastore.FederationRelationship, err error) {
+	if trustDomain.IsZero() {
+		return nil, status.Error(codes.InvalidArgument, "trust domain is required")
+	}
+
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		_, record, err := getFederationRecord(tx, trustDomain.Name())
+		switch {
+		case errors.Is(err, errNotFound):
+			return nil
+		case err != nil:
+			return err
+		}
+		fr, err = recordToFederationRelationship(tx, record)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return fr, nil
+}
+
+// ListFederationRelationships can be used to list all existing federation relationships
+func (ds *Plugin) ListFederationRelationships(_ context.Context, req *datastore.ListFederationRelationshipsRequest) (resp *datastore.ListFederationRelationshipsResponse, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		resp, err = listFederationRelationships(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateFederationRelationship updates the given federation relationship.
+// Attributes are only updated if the correspondent mask value is set to true.
+func (ds *Plugin) UpdateFederationRelationship(_ context.Context, fr *datastore.FederationRelationship, mask *types.FederationRelationshipMask) (newFr *datastore.FederationRelationship, err error) {
+	if err := validateFederationRelationship(fr, mask); err != nil {
+		return nil, err
+	}
+
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		newFr, err = updateFederationRelationship(tx, fr, mask)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return newFr, nil
+}
+
+func createFederationRelationship(tx *bolt.Tx, fr *datastore.FederationRelationship) (*datastore.FederationRelationship, error) {
+	record := &federationRecord{
+		TrustDomain:           fr.TrustDomain.Name(),
+		BundleEndpointURL:     fr.BundleEndpointURL.String(),
+		BundleEndpointProfile: string(fr.BundleEndpointProfile),
+	}
+
+	if fr.BundleEndpointProfile == datastore.BundleEndpointSPIFFE {
+		record.EndpointSPIFFEID = fr.EndpointSPIFFEID.String()
+	}
+
+	if fr.TrustDomainBundle != nil {
+		// overwrite current bundle
+		if _, err := setBundle(tx, fr.TrustDomainBundle); err != nil {
+			return nil, fmt.Errorf("unable to set bundle: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := federationRelationshipsTable.create(tx, record.TrustDomain, data); err != nil {
+		return nil, err
+	}
+
+	return fr, nil
+}
+
+func listFederationRelationships(tx *bolt.Tx, req *datastore.ListFederationRelationshipsRequest) (*datastore.ListFederationRelationshipsResponse, error) {
+	after, err := parsePagination(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListFederationRelationshipsResponse{
+		FederationRelationships: []*datastore.FederationRelationship{},
+	}
+	var lastID uint64
+	if err := federationRelationshipsTable.forEach(tx, after, func(id uint64, value []byte) error {
+		record, err := unmarshalFederationRecord(value)
+		if err != nil {
+			return err
+		}
+		fr, err := recordToFederationRelationship(tx, record)
+		if err != nil {
+			return err
+		}
+		resp.FederationRelationships = append(resp.FederationRelationships, fr)
+		lastID = id
+		if req.Pagination != nil && len(resp.FederationRelationships) >= int(req.Pagination.PageSize) {
+			return errStopIteration
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	resp.Pagination = nextPagination(req.Pagination, lastID)
+	return resp, nil
+}
+
+func updateFederationRelationship(tx *bolt.Tx, fr *datastore.FederationRelationship, mask *types.FederationRelationshipMask) (*datastore.FederationRelationship, error) {
+	id, record, err := getFederationRecord(tx, fr.TrustDomain.Name())
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch federation relationship: %w", err)
+	}
+
+	if mask.BundleEndpointUrl {
+		record.BundleEndpointURL = fr.BundleEndpointURL.String()
+	}
+
+	if mask.BundleEndpointProfile {
+		record.BundleEndpointProfile = string(fr.BundleEndpointProfile)
+
+		if fr.BundleEndpointProfile == datastore.BundleEndpointSPIFFE {
+			record.EndpointSPIFFEID = fr.EndpointSPIFFEID.String()
+		}
+	}
+
+	if mask.TrustDomainBundle && fr.TrustDomainBundle != nil {
+		// overwrite current bundle
+		if _, err := setBundle(tx, fr.TrustDomainBundle); err != nil {
+			return nil, fmt.Errorf("unable to set bundle: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	if err := federationRelationshipsTable.update(tx, id, data); err != nil {
+		return nil, err
+	}
+
+	return recordToFederationRelationship(tx, record)
+}
+
+func validateFederationRelationship(fr *datastore.FederationRelationship, mask *types.FederationRelationshipMask) error {
+	if fr == nil {
+		return status.Error(codes.InvalidArgument, "federation relationship is nil")
+	}
+
+	if fr.TrustDomain.IsZero() {
+		return status.Error(codes.InvalidArgument, "trust domain is required")
+	}
+
+	if mask.BundleEndpointUrl && fr.BundleEndpointURL == nil {
+		return status.Error(codes.InvalidArgument, "bundle endpoint URL is required")
+	}
+
+	if mask.BundleEndpointProfile {
+		switch fr.BundleEndpointProfile {
+		case datastore.BundleEndpointWeb:
+		case datastore.BundleEndpointSPIFFE:
+			if fr.EndpointSPIFFEID.IsZero() {
+				return status.Error(codes.InvalidArgument, "bundle endpoint SPIFFE ID is required")
+			}
+		default:
+			return status.Errorf(codes.InvalidArgument, "unknown bundle endpoint profile type: %q", fr.BundleEndpointProfile)
+		}
+	}
+
+	return nil
+}
+
+func recordToFederationRelationship(tx *bolt.Tx, record *federationRecord) (*datastore.FederationRelationship, error) {
+	bundleEndpointURL, err := url.Parse(record.BundleEndpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse URL: %w", err)
+	}
+
+	td, err := spiffeid.TrustDomainFromString(record.TrustDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	fr := &datastore.FederationRelationship{
+		TrustDomain:           td,
+		BundleEndpointURL:     bundleEndpointURL,
+		BundleEndpointProfile: datastore.BundleEndpointType(record.BundleEndpointProfile),
+	}
+
+	switch fr.BundleEndpointProfile {
+	case datastore.BundleEndpointWeb:
+	case datastore.BundleEndpointSPIFFE:
+		endpointSPIFFEID, err := spiffeid.FromString(record.EndpointSPIFFEID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse bundle endpoint SPIFFE ID: %w", err)
+		}
+		fr.EndpointSPIFFEID = endpointSPIFFEID
+	default:
+		return nil, fmt.Errorf("unknown bundle endpoint profile type: %q", record.BundleEndpointProfile)
+	}
+
+	trustDomainBundle, err := fetchBundle(tx, td.IDString())
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch bundle: %w", err)
+	}
+	fr.TrustDomainBundle = trustDomainBundle
+
+	return fr, nil
+}
+
+// getFederationRecord returns the federation relationship record for the
+// given trust domain along with its internal ID. It fails with errNotFound if
+// there is no such record.
+func getFederationRecord(tx *bolt.Tx, trustDomain string) (uint64, *federationRecord, error) {
+	id, value := federationRelationshipsTable.get(tx, trustDomain)
+	if value == nil {
+		return 0, nil, errNotFound
+	}
+	record, err := unmarshalFederationRecord(value)
+	if err != nil {
+		return 0, nil, err
+	}
+	return id, record, nil
+}
+
+func unmarshalFederationRecord(value []byte) (*federationRecord, error) {
+	record := new(federationRecord)
+	if err := json.Unmarshal(value, record); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal federation relationship: %w", err)
+	}
+	return record, nil
+}
@@ -0,0 +1,138 @@
+package kvstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/datastore"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateJoinToken takes a Token message and stores it
+func (ds *Plugin) CreateJoinToken(_ context.Context, token *datastore.JoinToken) error {
+	if token == nil || token.Token == "" || token.Expiry.IsZero() {
+		return errors.New("token and expiry are required")
+	}
+
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return createJoinToken(tx, token)
+	})
+}
+
+// FetchJoinToken takes a Token message and returns one, populating the fields
+// we have knowledge of
+func (ds *Plugin) FetchJoinToken(_ context.Context, token string) (resp *datastore.JoinToken, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		value := tx.Bucket(joinTokensBucket).Get([]byte(token))
+		if value == nil {
+			return nil
+		}
+		resp = &datastore.JoinToken{
+			Token:  token,
+			Expiry: time.Unix(int64(btoi(value)), 0), //nolint: gosec // expiry is always stored from a non-negative unix time
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListJoinTokens lists join tokens
+func (ds *Plugin) ListJoinTokens(_ context.Context, req *datastore.ListJoinTokensRequest) (resp *datastore.ListJoinTokensResponse, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		resp, err = listJoinTokens(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteJoinToken deletes the given join token
+func (ds *Plugin) DeleteJoinToken(_ context.Context, token string) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		b := tx.Bucket(joinTokensBucket)
+		if b.Get([]byte(token)) == nil {
+			return errNotFound
+		}
+		return b.Delete([]byte(token))
+	})
+}
+
+// PruneJoinTokens takes a Token message, and deletes all tokens which have expired
+// before the date in the message
+func (ds *Plugin) PruneJoinTokens(_ context.Context, expiresBefore time.Time) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		b := tx.Bucket(joinTokensBucket)
+		var expired [][]byte
+		if err := b.ForEach(func(k, v []byte) error {
+			if int64(btoi(v)) < expiresBefore.Unix() { //nolint: gosec // expiry is always stored from a non-negative unix time
+				expired = append(expired, bytes.Clone(k))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, k := range expired {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func createJoinToken(tx *bolt.Tx, token *datastore.JoinToken) error {
+	b := tx.Bucket(joinTokensBucket)
+	if b.Get([]byte(token.Token)) != nil {
+		return errAlreadyExists
+	}
+	return b.Put([]byte(token.Token), itob(uint64(token.Expiry.Unix()))) //nolint: gosec // join token expiry is never before the unix epoch
+}
+
+// listJoinTokens lists join tokens in token order. Unlike the other tables,
+// join tokens are keyed directly by the token, so the pagination token is
+// the last join token returned.
+func listJoinTokens(tx *bolt.Tx, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	if req.Pagination != nil && req.Pagination.PageSize == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	}
+
+	resp := &datastore.ListJoinTokensResponse{
+		JoinTokens: []*datastore.JoinToken{},
+	}
+
+	c := tx.Bucket(joinTokensBucket).Cursor()
+	k, v := c.First()
+	if req.Pagination != nil && req.Pagination.Token != "" {
+		k, v = c.Seek([]byte(req.Pagination.Token))
+		if k != nil && string(k) == req.Pagination.Token {
+			k, v = c.Next()
+		}
+	}
+	for ; k != nil; k, v = c.Next() {
+		resp.JoinTokens = append(resp.JoinTokens, &datastore.JoinToken{
+			Token:  string(k),
+			Expiry: time.Unix(int64(btoi(v)), 0), //nolint: gosec // expiry is always stored from a non-negative unix time
+		})
+		if req.Pagination != nil && len(resp.JoinTokens) >= int(req.Pagination.PageSize) {
+			break
+		}
+	}
+
+	if req.Pagination != nil {
+		resp.Pagination = &datastore.Pagination{
+			PageSize: req.Pagination.PageSize,
+		}
+		if len(resp.JoinTokens) > 0 {
+			resp.Pagination.Token = resp.JoinTokens[len(resp.JoinTokens)-1].Token
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,328 @@
+// Package kvstore implements the DataStore interface on top of an embedded,
+// pure-Go key-value database (bbolt). It is intended for single-node and
+// edge deployments where running a SQL server is not desirable and CGO is
+// not available for SQLite.
+package kvstore
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl"
+	"github.com/sirupsen/logrus"
+	configv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	PluginName = "kv"
+
+	// schemaVersion is the version of the bucket layout written by this
+	// package. It is stored in the meta bucket so that future layouts can
+	// be migrated in place.
+	schemaVersion = 1
+
+	// defaultOpenTimeout is how long Configure waits to obtain the file lock
+	// on the database before failing. The lock is held by any other process
+	// (e.g. a running server) that has the database open.
+	defaultOpenTimeout = 5 * time.Second
+
+	// defaultPruneAttestedNodesBatchSize is the number of expired attested
+	// nodes pruned per call when no batch size (or a non-positive one) is
+	// provided.
+	defaultPruneAttestedNodesBatchSize = 1000
+
+	// Maximum size for additional attributes message in a registration entry
+	maxAdditionalAttributesSize = 65535
+)
+
+var (
+	metaBucket = []byte("meta")
+
+	schemaVersionKey = []byte("schema_version")
+
+	// errNotFound is returned when a record is not found. It is converted
+	// to a NotFound status at the transaction boundary.
+	errNotFound = errors.New("record not found")
+
+	// errAlreadyExists is returned when a record with the same key already
+	// exists. It is converted to an AlreadyExists status at the transaction
+	// boundary.
+	errAlreadyExists = errors.New("record already exists")
+)
+
+// Configuration is the configuration for the KV datastore
+type Configuration struct {
+	DatabasePath string `hcl:"database_path" json:"database_path"`
+	OpenTimeout  string `hcl:"open_timeout" json:"open_timeout"`
+
+	openTimeout time.Duration
+}
+
+// Plugin is a DataStore plugin implemented via an embedded key-value database
+type Plugin struct {
+	mu   sync.RWMutex
+	db   *bolt.DB
+	path string
+	log  logrus.FieldLogger
+}
+
+// New creates a new kv plugin struct. Configure must be called in order to
+// open the database.
+func New(log logrus.FieldLogger) *Plugin {
+	return &Plugin{
+		log: log,
+	}
+}
+
+// Configure parses the HCL configuration and opens the database. If the
+// database does not exist, it is created.
+func (ds *Plugin) Configure(_ context.Context, hclConfiguration string) error {
+	config, err := buildConfig(hclConfiguration)
+	if err != nil {
+		return err
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.db != nil && ds.path == config.DatabasePath {
+		return nil
+	}
+
+	db, err := openDB(config.DatabasePath, config.openTimeout)
+	if err != nil {
+		return err
+	}
+
+	if ds.db != nil {
+		if err := ds.db.Close(); err != nil {
+			ds.log.WithError(err).Warn("Failed to close previous database")
+		}
+	}
+
+	ds.db = db
+	ds.path = config.DatabasePath
+
+	ds.log.WithFields(logrus.Fields{
+		telemetry.Path:    config.DatabasePath,
+		telemetry.Version: schemaVersion,
+	}).Info("Opened KV database")
+	return nil
+}
+
+// Validate validates the HCL configuration without opening the database.
+func (ds *Plugin) Validate(_ context.Context, _ catalog.CoreConfig, configuration string) (*configv1.ValidateResponse, error) {
+	if _, err := buildConfig(configuration); err != nil {
+		return &configv1.ValidateResponse{
+			Notes: []string{err.Error()},
+		}, err
+	}
+
+	return &configv1.ValidateResponse{
+		Valid: true,
+	}, nil
+}
+
+// Close closes the underlying database.
+func (ds *Plugin) Close() error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.db == nil {
+		return nil
+	}
+	err := ds.db.Close()
+	ds.db = nil
+	return err
+}
+
+func buildConfig(hclConfiguration string) (*Configuration, error) {
+	config := new(Configuration)
+	if err := hcl.Decode(config, hclConfiguration); err != nil {
+		return nil, err
+	}
+
+	if config.DatabasePath == "" {
+		return nil, errors.New("database_path must be set")
+	}
+
+	config.openTimeout = defaultOpenTimeout
+	if config.OpenTimeout != "" {
+		openTimeout, err := time.ParseDuration(config.OpenTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid open_timeout: %w", err)
+		}
+		config.openTimeout = openTimeout
+	}
+
+	return config, nil
+}
+
+func openDB(path string, openTimeout time.Duration) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{
+		Timeout: openTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open KV database %q: %w", path, err)
+	}
+
+	if err := db.Update(initBuckets); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to initialize KV database %q: %w", path, err)
+	}
+
+	return db, nil
+}
+
+func initBuckets(tx *bolt.Tx) error {
+	meta, err := tx.CreateBucketIfNotExists(metaBucket)
+	if err != nil {
+		return err
+	}
+
+	if raw := meta.Get(schemaVersionKey); raw != nil {
+		version, err := strconv.Atoi(string(raw))
+		if err != nil {
+			return fmt.Errorf("invalid schema version %q: %w", raw, err)
+		}
+		if version > schemaVersion {
+			return fmt.Errorf("database schema version %d is newer than the supported version %d", version, schemaVersion)
+		}
+	}
+	if err := meta.Put(schemaVersionKey, []byte(strconv.Itoa(schemaVersion))); err != nil {
+		return err
+	}
+
+	for _, name := range allBuckets {
+		if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withReadTx runs the operation in a read-only transaction.
+func (ds *Plugin) withReadTx(op func(tx *bolt.Tx) error) error {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	if ds.db == nil {
+		return status.Error(codes.FailedPrecondition, "datastore-kv: database is not open")
+	}
+	return toGRPCStatus(ds.db.View(op))
+}
+
+// withWriteTx runs the operation in a read-write transaction. The database
+// only allows a single writer at a time, so read-modify-write operations are
+// serialized.
+func (ds *Plugin) withWriteTx(op func(tx *bolt.Tx) error) error {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	if ds.db == nil {
+		return status.Error(codes.FailedPrecondition, "datastore-kv: database is not open")
+	}
+	return toGRPCStatus(ds.db.Update(op))
+}
+
+// validationError is returned when the input to an operation is invalid. It
+// is converted to an InvalidArgument status at the transaction boundary.
+type validationError struct {
+	msg string
+}
+
+func newValidationError(fmtMsg string, args ...any) error {
+	return &validationError{msg: fmt.Sprintf(fmtMsg, args...)}
+}
+
+func (v *validationError) Error() string {
+	return "datastore-validation: " + v.msg
+}
+
+// toGRPCStatus converts the given error into a gRPC status. Errors that are
+// already a gRPC status are returned unmodified.
+func toGRPCStatus(err error) error {
+	type grpcStatusError interface {
+		error
+		GRPCStatus() *status.Status
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	var statusError grpcStatusError
+	if errors.As(err, &statusError) {
+		return statusError
+	}
+
+	var vErr *validationError
+	if errors.As(err, &vErr) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	code := codes.Unknown
+	switch {
+	case errors.Is(err, errNotFound):
+		code = codes.NotFound
+	case errors.Is(err, errAlreadyExists):
+		code = codes.AlreadyExists
+	}
+	return status.Error(code, "datastore-kv: "+err.Error())
+}
+
+func itob(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+func btoi(b []byte) uint64 {
+	return binary.BigEndian.Uint64(b)
+}
+
+// parsePagination validates the given pagination and returns the internal ID
+// after which results should start.
+func parsePagination(p *datastore.Pagination) (uint64, error) {
+	if p == nil {
+		return 0, nil
+	}
+	if p.PageSize == 0 {
+		return 0, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	}
+	if p.Token == "" {
+		return 0, nil
+	}
+	after, err := strconv.ParseUint(p.Token, 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "could not parse token '%v'", p.Token)
+	}
+	return after, nil
+}
+
+// nextPagination returns the pagination for the page following the one
+// ending at the given internal ID. A zero lastID (i.e. the page was empty)
+// yields an empty token.
+func nextPagination(p *datastore.Pagination, lastID uint64) *datastore.Pagination {
+	if p == nil {
+		return nil
+	}
+	next := &datastore.Pagination{
+		PageSize: p.PageSize,
+	}
+	if lastID != 0 {
+		next.Token = strconv.FormatUint(lastID, 10)
+	}
+	return next
+}
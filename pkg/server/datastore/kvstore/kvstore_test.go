@@ -0,0 +1,355 @@
+package kvstore
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/datastore/sqlstore"
+	datastoretest "github.com/spiffe/spire/pkg/server/datastore/test"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var ctx = context.Background()
+
+var _ datastore.DataStore = (*Plugin)(nil)
+
+func TestConformance(t *testing.T) {
+	datastoretest.Test(t, datastoretest.Config{
+		Create: func(t *testing.T) datastore.DataStore {
+			return newPlugin(t, filepath.Join(t.TempDir(), "datastore.db"))
+		},
+	})
+}
+
+func TestConfigure(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		config    string
+		expectErr string
+	}{
+		{
+			name:      "missing database path",
+			config:    ``,
+			expectErr: "database_path must be set",
+		},
+		{
+			name:      "invalid open timeout",
+			config:    `database_path = "datastore.db" open_timeout = "forever"`,
+			expectErr: "invalid open_timeout",
+		},
+		{
+			name:   "success",
+			config: `database_path = "datastore.db" open_timeout = "1s"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			log, _ := test.NewNullLogger()
+			ds := New(log)
+
+			resp, err := ds.Validate(ctx, catalog.CoreConfig{}, tt.config)
+			if tt.expectErr != "" {
+				require.ErrorContains(t, err, tt.expectErr)
+				require.False(t, resp.Valid)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, resp.Valid)
+		})
+	}
+}
+
+func TestNotConfigured(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	ds := New(log)
+
+	_, err := ds.FetchBundle(ctx, "spiffe://example.org")
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "datastore-kv: database is not open")
+}
+
+func TestPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "datastore.db")
+
+	ds := newPlugin(t, path)
+	entry, err := ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/workload",
+		ParentId:  "spiffe://example.org/agent",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ds.CreateJoinToken(ctx, &datastore.JoinToken{
+		Token:  "token",
+		Expiry: time.Now().Add(time.Hour),
+	}))
+	require.NoError(t, ds.Close())
+
+	ds = newPlugin(t, path)
+	fetched, err := ds.FetchRegistrationEntry(ctx, entry.EntryId)
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, entry, fetched)
+
+	token, err := ds.FetchJoinToken(ctx, "token")
+	require.NoError(t, err)
+	require.NotNil(t, token)
+}
+
+func TestDatabaseLocked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "datastore.db")
+	newPlugin(t, path)
+
+	log, _ := test.NewNullLogger()
+	ds := New(log)
+	err := ds.Configure(ctx, fmt.Sprintf(`
+		database_path = %q
+		open_timeout = "100ms"
+	`, path))
+	require.ErrorContains(t, err, "unable to open KV database")
+}
+
+func TestRegistrationEntryEvents(t *testing.T) {
+	ds := newPlugin(t, filepath.Join(t.TempDir(), "datastore.db"))
+
+	entry, err := ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/workload",
+		ParentId:  "spiffe://example.org/agent",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+
+	entry.X509SvidTtl = 60
+	_, err = ds.UpdateRegistrationEntry(ctx, entry, &common.RegistrationEntryMask{X509SvidTtl: true})
+	require.NoError(t, err)
+
+	_, err = ds.DeleteRegistrationEntry(ctx, entry.EntryId)
+	require.NoError(t, err)
+
+	resp, err := ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []datastore.RegistrationEntryEvent{
+		{EventID: 1, EntryID: entry.EntryId},
+		{EventID: 2, EntryID: entry.EntryId},
+		{EventID: 3, EntryID: entry.EntryId},
+	}, resp.Events)
+
+	resp, err = ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{
+		GreaterThanEventID: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []datastore.RegistrationEntryEvent{
+		{EventID: 2, EntryID: entry.EntryId},
+		{EventID: 3, EntryID: entry.EntryId},
+	}, resp.Events)
+
+	resp, err = ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{
+		LessThanEventID: 3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []datastore.RegistrationEntryEvent{
+		{EventID: 1, EntryID: entry.EntryId},
+		{EventID: 2, EntryID: entry.EntryId},
+	}, resp.Events)
+
+	_, err = ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{
+		GreaterThanEventID: 1,
+		LessThanEventID:    3,
+	})
+	require.EqualError(t, err, "rpc error: code = Unknown desc = datastore-kv: can't set both greater and less than event id")
+
+	event, err := ds.FetchRegistrationEntryEvent(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, &datastore.RegistrationEntryEvent{EventID: 2, EntryID: entry.EntryId}, event)
+
+	// Explicit event IDs leave gaps that later events skip over
+	require.NoError(t, ds.CreateRegistrationEntryEventForTesting(ctx, &datastore.RegistrationEntryEvent{
+		EventID: 10,
+		EntryID: "foo",
+	}))
+	require.NoError(t, ds.DeleteRegistrationEntryEventForTesting(ctx, 1))
+
+	resp, err = ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{
+		GreaterThanEventID: 3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []datastore.RegistrationEntryEvent{
+		{EventID: 10, EntryID: "foo"},
+	}, resp.Events)
+
+	require.NoError(t, ds.PruneRegistrationEntryEvents(ctx, time.Hour))
+	resp, err = ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 3)
+
+	require.NoError(t, ds.PruneRegistrationEntryEvents(ctx, -time.Hour))
+	resp, err = ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Events)
+}
+
+func TestAttestedNodeEvents(t *testing.T) {
+	ds := newPlugin(t, filepath.Join(t.TempDir(), "datastore.db"))
+
+	node, err := ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/agent",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ds.SetNodeSelectors(ctx, node.SpiffeId, []*common.Selector{{Type: "type", Value: "value"}}))
+
+	_, err = ds.UpdateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:         node.SpiffeId,
+		CertSerialNumber: "5678",
+	}, &common.AttestedNodeMask{CertSerialNumber: true})
+	require.NoError(t, err)
+
+	_, err = ds.DeleteAttestedNode(ctx, node.SpiffeId)
+	require.NoError(t, err)
+
+	resp, err := ds.ListAttestedNodeEvents(ctx, &datastore.ListAttestedNodeEventsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []datastore.AttestedNodeEvent{
+		{EventID: 1, SpiffeID: node.SpiffeId},
+		{EventID: 2, SpiffeID: node.SpiffeId},
+		{EventID: 3, SpiffeID: node.SpiffeId},
+		{EventID: 4, SpiffeID: node.SpiffeId},
+	}, resp.Events)
+
+	event, err := ds.FetchAttestedNodeEvent(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, &datastore.AttestedNodeEvent{EventID: 4, SpiffeID: node.SpiffeId}, event)
+
+	_, err = ds.FetchAttestedNodeEvent(ctx, 5)
+	spiretest.RequireGRPCStatus(t, err, codes.NotFound, "datastore-kv: record not found")
+
+	require.NoError(t, ds.PruneAttestedNodeEvents(ctx, -time.Hour))
+	resp, err = ds.ListAttestedNodeEvents(ctx, &datastore.ListAttestedNodeEventsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Events)
+}
+
+func TestParsePagination(t *testing.T) {
+	after, err := parsePagination(nil)
+	require.NoError(t, err)
+	assert.Zero(t, after)
+
+	// Tokens are 64-bit sequence IDs
+	after, err = parsePagination(&datastore.Pagination{Token: "4294967296", PageSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4294967296), after)
+
+	_, err = parsePagination(&datastore.Pagination{Token: "1"})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "cannot paginate with pagesize = 0")
+
+	_, err = parsePagination(&datastore.Pagination{Token: "-1", PageSize: 1})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "could not parse token '-1'")
+}
+
+func TestMigrateFromSQL(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	src := sqlstore.New(log)
+	require.NoError(t, src.Configure(ctx, fmt.Sprintf(`
+		database_type = "sqlite3"
+		connection_string = %q
+	`, filepath.ToSlash(filepath.Join(t.TempDir(), "datastore.sqlite3")))))
+	t.Cleanup(func() { src.Close() })
+
+	bundle, err := src.CreateBundle(ctx, &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("root")}},
+	})
+	require.NoError(t, err)
+	federatedBundle, err := src.CreateBundle(ctx, &common.Bundle{
+		TrustDomainId: "spiffe://federated.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("federated")}},
+	})
+	require.NoError(t, err)
+
+	var entries []*common.RegistrationEntry
+	for i := range 3 {
+		entry, err := src.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+			SpiffeId:      fmt.Sprintf("spiffe://example.org/workload%d", i),
+			ParentId:      "spiffe://example.org/agent",
+			Selectors:     []*common.Selector{{Type: "unix", Value: fmt.Sprintf("uid:%d", i)}},
+			FederatesWith: []string{"spiffe://federated.org"},
+		})
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	entry, err := src.UpdateRegistrationEntry(ctx, entries[0], &common.RegistrationEntryMask{X509SvidTtl: true})
+	require.NoError(t, err)
+	entries[0] = entry
+
+	node, err := src.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/agent",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	nodeSelectors := []*common.Selector{{Type: "type", Value: "value"}}
+	require.NoError(t, src.SetNodeSelectors(ctx, node.SpiffeId, nodeSelectors))
+
+	joinToken := &datastore.JoinToken{Token: "token", Expiry: time.Now().Add(time.Hour).Truncate(time.Second)}
+	require.NoError(t, src.CreateJoinToken(ctx, joinToken))
+
+	caJournal, err := src.SetCAJournal(ctx, &datastore.CAJournal{
+		ActiveX509AuthorityID: "authority",
+		Data:                  []byte("journal"),
+	})
+	require.NoError(t, err)
+
+	ds := newPlugin(t, filepath.Join(t.TempDir(), "datastore.db"))
+	require.NoError(t, ds.MigrateFrom(ctx, src))
+
+	bundles, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	require.NoError(t, err)
+	spiretest.AssertProtoListEqual(t, []*common.Bundle{bundle, federatedBundle}, bundles.Bundles)
+
+	for _, entry := range entries {
+		fetched, err := ds.FetchRegistrationEntry(ctx, entry.EntryId)
+		require.NoError(t, err)
+		spiretest.AssertProtoEqual(t, entry, fetched)
+	}
+
+	fetchedNode, err := ds.FetchAttestedNode(ctx, node.SpiffeId)
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, node, fetchedNode)
+
+	selectors, err := ds.GetNodeSelectors(ctx, node.SpiffeId, datastore.RequireCurrent)
+	require.NoError(t, err)
+	spiretest.AssertProtoListEqual(t, nodeSelectors, selectors)
+
+	fetchedJoinToken, err := ds.FetchJoinToken(ctx, joinToken.Token)
+	require.NoError(t, err)
+	require.NotNil(t, fetchedJoinToken)
+	assert.True(t, joinToken.Expiry.Equal(fetchedJoinToken.Expiry))
+
+	fetchedCAJournal, err := ds.FetchCAJournal(ctx, "authority")
+	require.NoError(t, err)
+	assert.Equal(t, caJournal, fetchedCAJournal)
+
+	// Migrating into a non-empty datastore fails
+	err = ds.MigrateFrom(ctx, src)
+	require.ErrorContains(t, err, "target datastore is not empty")
+}
+
+func newPlugin(t *testing.T, path string) *Plugin {
+	log, _ := test.NewNullLogger()
+	ds := New(log)
+	require.NoError(t, ds.Configure(ctx, fmt.Sprintf(`database_path = %q`, path)))
+	t.Cleanup(func() {
+		ds.Close()
+	})
+	return ds
+}
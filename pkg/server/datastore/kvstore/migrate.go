@@ -0,0 +1,193 @@
+package kvstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	bolt "go.etcd.io/bbolt"
+)
+
+// migratePageSize is the page size used to read records from the source
+// datastore during a migration.
+const migratePageSize = 1000
+
+// MigrateFrom copies the contents of the given datastore into this one. The
+// datastore must be empty. Registration entries keep their IDs, creation
+// times and revision numbers, and CA journals keep their IDs, so that a
+// server can be switched over without re-issuing identities.
+//
+// Registration entry and attested node events are not migrated since the
+// server rebuilds its caches from scratch on startup.
+// All records are written in a single transaction, so a failed migration
+// leaves the datastore empty.
+func (ds *Plugin) MigrateFrom(ctx context.Context, src datastore.DataStore) error {
+	bundles, err := migrateList(func(p *datastore.Pagination) ([]*common.Bundle, *datastore.Pagination, error) {
+		resp, err := src.ListBundles(ctx, &datastore.ListBundlesRequest{Pagination: p})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Bundles, resp.Pagination, nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list bundles: %w", err)
+	}
+
+	relationships, err := migrateList(func(p *datastore.Pagination) ([]*datastore.FederationRelationship, *datastore.Pagination, error) {
+		resp, err := src.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{Pagination: p})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.FederationRelationships, resp.Pagination, nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list federation relationships: %w", err)
+	}
+
+	entries, err := migrateList(func(p *datastore.Pagination) ([]*common.RegistrationEntry, *datastore.Pagination, error) {
+		resp, err := src.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{Pagination: p})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Entries, resp.Pagination, nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list registration entries: %w", err)
+	}
+
+	nodes, err := migrateList(func(p *datastore.Pagination) ([]*common.AttestedNode, *datastore.Pagination, error) {
+		resp, err := src.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+			Pagination:     p,
+			FetchSelectors: true,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Nodes, resp.Pagination, nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list attested nodes: %w", err)
+	}
+
+	joinTokens, err := migrateList(func(p *datastore.Pagination) ([]*datastore.JoinToken, *datastore.Pagination, error) {
+		resp, err := src.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{Pagination: p})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.JoinTokens, resp.Pagination, nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list join tokens: %w", err)
+	}
+
+	caJournals, err := migrateList(func(p *datastore.Pagination) ([]*datastore.CAJournal, *datastore.Pagination, error) {
+		resp, err := src.ListCAJournals(ctx, &datastore.ListCAJournalsRequest{Pagination: p})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.CAJournals, resp.Pagination, nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list CA journals: %w", err)
+	}
+
+	if err := ds.withWriteTx(func(tx *bolt.Tx) error {
+		if !isEmpty(tx) {
+			return errors.New("target datastore is not empty")
+		}
+
+		// Bundles must be migrated before registration entries and
+		// federation relationships, which reference them.
+		for _, bundle := range bundles {
+			if _, err := createBundle(tx, bundle); err != nil {
+				return fmt.Errorf("unable to migrate bundle %q: %w", bundle.TrustDomainId, err)
+			}
+		}
+		for _, fr := range relationships {
+			// The bundle has already been migrated
+			fr.TrustDomainBundle = nil
+			if _, err := createFederationRelationship(tx, fr); err != nil {
+				return fmt.Errorf("unable to migrate federation relationship %q: %w", fr.TrustDomain, err)
+			}
+		}
+		for _, entry := range entries {
+			if _, err := insertEntry(tx, entry); err != nil {
+				return fmt.Errorf("unable to migrate registration entry %q: %w", entry.EntryId, err)
+			}
+		}
+		for _, node := range nodes {
+			if _, err := createAttestedNode(tx, node); err != nil {
+				return fmt.Errorf("unable to migrate attested node %q: %w", node.SpiffeId, err)
+			}
+			if len(node.Selectors) > 0 {
+				if err := setNodeSelectors(tx, node.SpiffeId, node.Selectors); err != nil {
+					return fmt.Errorf("unable to migrate selectors for attested node %q: %w", node.SpiffeId, err)
+				}
+			}
+		}
+		for _, joinToken := range joinTokens {
+			if err := createJoinToken(tx, joinToken); err != nil {
+				return fmt.Errorf("unable to migrate join token: %w", err)
+			}
+		}
+		for _, caJournal := range caJournals {
+			data, err := json.Marshal(&caJournalRecord{
+				Data:                  caJournal.Data,
+				ActiveX509AuthorityID: caJournal.ActiveX509AuthorityID,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := putWithID(tx.Bucket(caJournalsBucket), uint64(caJournal.ID), data); err != nil {
+				return fmt.Errorf("unable to migrate CA journal %d: %w", caJournal.ID, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	ds.log.WithFields(logrus.Fields{
+		"bundles":                  len(bundles),
+		"federation_relationships": len(relationships),
+		"registration_entries":     len(entries),
+		"attested_nodes":           len(nodes),
+		"join_tokens":              len(joinTokens),
+		"ca_journals":              len(caJournals),
+	}).Info("Migrated datastore")
+	return nil
+}
+
+// migrateList collects all of the pages returned by the given list function.
+func migrateList[T any](list func(p *datastore.Pagination) ([]T, *datastore.Pagination, error)) ([]T, error) {
+	var all []T
+	pagination := &datastore.Pagination{PageSize: migratePageSize}
+	for {
+		items, next, err := list(pagination)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) == 0 || next == nil || next.Token == "" {
+			return all, nil
+		}
+		pagination = &datastore.Pagination{
+			Token:    next.Token,
+			PageSize: migratePageSize,
+		}
+	}
+}
+
+// isEmpty returns true if none of the record buckets hold any data.
+func isEmpty(tx *bolt.Tx) bool {
+	for _, name := range allBuckets {
+		if k, _ := tx.Bucket(name).Cursor().First(); k != nil {
+			return false
+		}
+	}
+	return true
+}
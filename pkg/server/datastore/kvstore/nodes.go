@@ -0,0 +1,563 @@
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/protoutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// nodeFilter holds the filters shared by the list and count requests
+type nodeFilter struct {
+	byAttestationType string
+	byBanned          *bool
+	byExpiresBefore   time.Time
+	bySelectorMatch   *datastore.BySelectors
+	bySpiffeIDs       map[string]struct{}
+	byCanReattest     *bool
+	validAt           time.Time
+}
+
+// CreateAttestedNode stores the given attested node
+func (ds *Plugin) CreateAttestedNode(_ context.Context, node *common.AttestedNode) (attestedNode *common.AttestedNode, err error) {
+	if node == nil {
+		return nil, newValidationError("invalid request: missing attested node")
+	}
+
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		attestedNode, err = createAttestedNode(tx, node)
+		if err != nil {
+			return err
+		}
+		return createAttestedNodeEvent(tx, &datastore.AttestedNodeEvent{
+			SpiffeID: node.SpiffeId,
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return attestedNode, nil
+}
+
+// FetchAttestedNode fetches an existing attested node by SPIFFE ID
+func (ds *Plugin) FetchAttestedNode(_ context.Context, spiffeID string) (attestedNode *common.AttestedNode, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		_, node, err := getAttestedNode(tx, spiffeID)
+		switch {
+		case errors.Is(err, errNotFound):
+			return nil
+		case err != nil:
+			return err
+		}
+		attestedNode = node
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return attestedNode, nil
+}
+
+// FetchAttestedNodes fetches existing attested nodes by SPIFFE IDs, including their selectors
+func (ds *Plugin) FetchAttestedNodes(_ context.Context, spiffeIDs []string) (nodes map[string]*common.AttestedNode, err error) {
+	nodes = make(map[string]*common.AttestedNode)
+	if len(spiffeIDs) == 0 {
+		return nodes, nil
+	}
+
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		for _, spiffeID := range spiffeIDs {
+			_, node, err := getAttestedNode(tx, spiffeID)
+			switch {
+			case errors.Is(err, errNotFound):
+				continue
+			case err != nil:
+				return err
+			}
+			if node.Selectors, err = getNodeSelectors(tx, spiffeID); err != nil {
+				return err
+			}
+			nodes[spiffeID] = node
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// CountAttestedNodes counts all attested nodes
+func (ds *Plugin) CountAttestedNodes(_ context.Context, req *datastore.CountAttestedNodesRequest) (count int32, err error) {
+	if req.BySelectorMatch != nil && len(req.BySelectorMatch.Selectors) == 0 {
+		return 0, status.Error(codes.InvalidArgument, "cannot list by empty selectors set")
+	}
+
+	filter := &nodeFilter{
+		byAttestationType: req.ByAttestationType,
+		byBanned:          req.ByBanned,
+		byExpiresBefore:   req.ByExpiresBefore,
+		bySelectorMatch:   req.BySelectorMatch,
+		byCanReattest:     req.ByCanReattest,
+	}
+
+	if err = ds.withReadTx(func(tx *bolt.Tx) error {
+		n := 0
+		if err := forEachAttestedNode(tx, 0, func(_ uint64, node *common.AttestedNode) error {
+			ok, err := filter.matches(tx, node)
+			if err != nil {
+				return err
+			}
+			if ok {
+				n++
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		count, err = util.CheckedCast[int32](n)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListAttestedNodes lists all attested nodes (pagination available)
+func (ds *Plugin) ListAttestedNodes(_ context.Context, req *datastore.ListAttestedNodesRequest) (resp *datastore.ListAttestedNodesResponse, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		resp, err = listAttestedNodes(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateAttestedNode updates the given node's cert serial and expiration.
+func (ds *Plugin) UpdateAttestedNode(_ context.Context, n *common.AttestedNode, mask *common.AttestedNodeMask) (node *common.AttestedNode, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		node, err = updateAttestedNode(tx, n, mask)
+		if err != nil {
+			return err
+		}
+		return createAttestedNodeEvent(tx, &datastore.AttestedNodeEvent{
+			SpiffeID: n.SpiffeId,
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// DeleteAttestedNode deletes the given attested node and the associated node selectors.
+func (ds *Plugin) DeleteAttestedNode(ctx context.Context, spiffeID string) (attestedNode *common.AttestedNode, err error) {
+	if err = ds.withWriteTx(func(tx *bolt.Tx) (err error) {
+		attestedNode, err = deleteAttestedNodeAndSelectors(tx, spiffeID, datastore.CallerIDFromContext(ctx), ds.log)
+		if err != nil {
+			return err
+		}
+		return createAttestedNodeEvent(tx, &datastore.AttestedNodeEvent{
+			SpiffeID: spiffeID,
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return attestedNode, nil
+}
+
+// PruneAttestedExpiredNodes deletes attested nodes with expiration time further than a given duration in the past.
+// Non-reattestable nodes are not deleted by default, and have to be included explicitly by setting
+// includeNonReattestable = true. Banned nodes are not deleted. At most batchSize nodes are pruned per call;
+// a non-positive batchSize falls back to the default.
+func (ds *Plugin) PruneAttestedExpiredNodes(_ context.Context, expiredBefore time.Time, includeNonReattestable bool, batchSize int) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		return pruneAttestedExpiredNodes(tx, expiredBefore, includeNonReattestable, batchSize, ds.log)
+	})
+}
+
+// SetNodeSelectors sets node (agent) selectors by SPIFFE ID, deleting old selectors first
+func (ds *Plugin) SetNodeSelectors(_ context.Context, spiffeID string, selectors []*common.Selector) error {
+	return ds.withWriteTx(func(tx *bolt.Tx) error {
+		if err := setNodeSelectors(tx, spiffeID, selectors); err != nil {
+			return err
+		}
+		return createAttestedNodeEvent(tx, &datastore.AttestedNodeEvent{
+			SpiffeID: spiffeID,
+		})
+	})
+}
+
+// GetNodeSelectors gets node (agent) selectors by SPIFFE ID
+func (ds *Plugin) GetNodeSelectors(_ context.Context, spiffeID string, _ datastore.DataConsistency) (selectors []*common.Selector, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		selectors, err = getNodeSelectors(tx, spiffeID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return selectors, nil
+}
+
+// ListNodeSelectors gets node (agent) selectors by SPIFFE ID
+func (ds *Plugin) ListNodeSelectors(_ context.Context, req *datastore.ListNodeSelectorsRequest) (resp *datastore.ListNodeSelectorsResponse, err error) {
+	if err = ds.withReadTx(func(tx *bolt.Tx) (err error) {
+		resp, err = listNodeSelectors(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func createAttestedNode(tx *bolt.Tx, node *common.AttestedNode) (*common.AttestedNode, error) {
+	stored := &common.AttestedNode{
+		SpiffeId:            node.SpiffeId,
+		AttestationDataType: node.AttestationDataType,
+		CertSerialNumber:    node.CertSerialNumber,
+		CertNotAfter:        node.CertNotAfter,
+		NewCertSerialNumber: node.NewCertSerialNumber,
+		NewCertNotAfter:     node.NewCertNotAfter,
+		CanReattest:         node.CanReattest,
+		AgentVersion:        node.AgentVersion,
+	}
+
+	data, err := proto.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := attestedNodesTable.create(tx, stored.SpiffeId, data); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func listAttestedNodes(tx *bolt.Tx, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	after, err := parsePagination(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	if req.BySelectorMatch != nil && len(req.BySelectorMatch.Selectors) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selectors set")
+	}
+
+	filter := &nodeFilter{
+		byAttestationType: req.ByAttestationType,
+		byBanned:          req.ByBanned,
+		byExpiresBefore:   req.ByExpiresBefore,
+		bySelectorMatch:   req.BySelectorMatch,
+		byCanReattest:     req.ByCanReattest,
+		validAt:           req.ValidAt,
+	}
+	if len(req.BySpiffeIDs) > 0 {
+		filter.bySpiffeIDs = make(map[string]struct{}, len(req.BySpiffeIDs))
+		for _, spiffeID := range req.BySpiffeIDs {
+			filter.bySpiffeIDs[spiffeID] = struct{}{}
+		}
+	}
+
+	resp := &datastore.ListAttestedNodesResponse{
+		Nodes: []*common.AttestedNode{},
+	}
+	var lastID uint64
+	if err := forEachAttestedNode(tx, after, func(id uint64, node *common.AttestedNode) error {
+		ok, err := filter.matches(tx, node)
+		if err != nil || !ok {
+			return err
+		}
+		if req.FetchSelectors {
+			if node.Selectors, err = getNodeSelectors(tx, node.SpiffeId); err != nil {
+				return err
+			}
+		}
+		resp.Nodes = append(resp.Nodes, node)
+		lastID = id
+		if req.Pagination != nil && len(resp.Nodes) >= int(req.Pagination.PageSize) {
+			return errStopIteration
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	resp.Pagination = nextPagination(req.Pagination, lastID)
+	return resp, nil
+}
+
+func (f *nodeFilter) matches(tx *bolt.Tx, node *common.AttestedNode) (bool, error) {
+	if f.bySpiffeIDs != nil {
+		if _, ok := f.bySpiffeIDs[node.SpiffeId]; !ok {
+			return false, nil
+		}
+	}
+	if f.byAttestationType != "" && node.AttestationDataType != f.byAttestationType {
+		return false, nil
+	}
+	if f.byBanned != nil && *f.byBanned != (node.CertSerialNumber == "") {
+		return false, nil
+	}
+	if f.byCanReattest != nil && *f.byCanReattest != node.CanReattest {
+		return false, nil
+	}
+	expiresAt := time.Unix(node.CertNotAfter, 0)
+	if !f.byExpiresBefore.IsZero() && !expiresAt.Before(f.byExpiresBefore) {
+		return false, nil
+	}
+	if !f.validAt.IsZero() && expiresAt.Before(f.validAt) {
+		return false, nil
+	}
+	if f.bySelectorMatch != nil {
+		selectors, err := getNodeSelectors(tx, node.SpiffeId)
+		if err != nil {
+			return false, err
+		}
+		if !matchSelectors(selectors, f.bySelectorMatch) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func updateAttestedNode(tx *bolt.Tx, n *common.AttestedNode, mask *common.AttestedNodeMask) (*common.AttestedNode, error) {
+	id, node, err := getAttestedNode(tx, n.SpiffeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if mask == nil {
+		mask = protoutil.AllTrueCommonAgentMask
+	}
+
+	if mask.CertNotAfter {
+		node.CertNotAfter = n.CertNotAfter
+	}
+	if mask.CertSerialNumber {
+		node.CertSerialNumber = n.CertSerialNumber
+	}
+	if mask.NewCertNotAfter {
+		node.NewCertNotAfter = n.NewCertNotAfter
+	}
+	if mask.NewCertSerialNumber {
+		node.NewCertSerialNumber = n.NewCertSerialNumber
+	}
+	if mask.CanReattest {
+		node.CanReattest = n.CanReattest
+	}
+	if mask.AgentVersion {
+		node.AgentVersion = n.AgentVersion
+	}
+
+	data, err := proto.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	if err := attestedNodesTable.update(tx, id, data); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func deleteAttestedNodeAndSelectors(tx *bolt.Tx, spiffeID, callerID string, logger logrus.FieldLogger) (*common.AttestedNode, error) {
+	_, node, err := getAttestedNode(tx, spiffeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cascade only for join-token-attested nodes, and only for entries that
+	// match the auto-alias shape written when a join token is used (single
+	// "spiffe_id" selector whose value is the parent SVID). This mirrors the
+	// behavior of the SQL datastore.
+	if node.AttestationDataType == "join_token" {
+		var aliases []*common.RegistrationEntry
+		if err := forEachEntry(tx, 0, func(record *entryRecord) error {
+			entry := record.entry
+			if entry.ParentId != spiffeID {
+				return nil
+			}
+			if len(entry.Selectors) != 1 || entry.Selectors[0].Type != "spiffe_id" || entry.Selectors[0].Value != entry.ParentId {
+				return nil
+			}
+			aliases = append(aliases, entry)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		for _, entry := range aliases {
+			if err := registrationEntriesTable.delete(tx, entry.EntryId); err != nil {
+				return nil, err
+			}
+			if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+				EntryID:   entry.EntryId,
+				Operation: datastore.RegistrationEntryDeleted,
+				CallerID:  callerID,
+				Before:    entry,
+			}); err != nil {
+				return nil, err
+			}
+			if err := createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
+				EntryID: entry.EntryId,
+			}); err != nil {
+				return nil, err
+			}
+			logger.WithFields(logrus.Fields{
+				telemetry.SPIFFEID:       entry.SpiffeId,
+				telemetry.ParentID:       entry.ParentId,
+				telemetry.RegistrationID: entry.EntryId,
+			}).Info("Cascade-deleted registration entry on attested node deletion")
+		}
+	}
+
+	if err := tx.Bucket(nodeSelectorsBucket).Delete([]byte(spiffeID)); err != nil {
+		return nil, err
+	}
+	if err := attestedNodesTable.delete(tx, spiffeID); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func pruneAttestedExpiredNodes(tx *bolt.Tx, expiredBefore time.Time, includeNonReattestable bool, batchSize int, logger logrus.FieldLogger) error {
+	if batchSize <= 0 {
+		batchSize = defaultPruneAttestedNodesBatchSize
+	}
+
+	var expired []string
+	if err := forEachAttestedNode(tx, 0, func(_ uint64, node *common.AttestedNode) error {
+		switch {
+		case !time.Unix(node.CertNotAfter, 0).Before(expiredBefore):
+		case !includeNonReattestable && !node.CanReattest:
+		case node.CertSerialNumber == "":
+			// Banned nodes are never pruned
+		default:
+			expired = append(expired, node.SpiffeId)
+		}
+		if len(expired) >= batchSize {
+			return errStopIteration
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var count int
+	defer func() { logger.WithField("count", count).Info("Pruned expired agents") }()
+
+	for _, spiffeID := range expired {
+		if _, err := deleteAttestedNodeAndSelectors(tx, spiffeID, "", logger); err != nil {
+			return err
+		}
+		count++
+
+		if err := createAttestedNodeEvent(tx, &datastore.AttestedNodeEvent{
+			SpiffeID: spiffeID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setNodeSelectors(tx *bolt.Tx, spiffeID string, selectors []*common.Selector) error {
+	b := tx.Bucket(nodeSelectorsBucket)
+	if len(selectors) == 0 {
+		return b.Delete([]byte(spiffeID))
+	}
+
+	data, err := proto.Marshal(&common.Selectors{Entries: selectors})
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(spiffeID), data)
+}
+
+func getNodeSelectors(tx *bolt.Tx, spiffeID string) ([]*common.Selector, error) {
+	value := tx.Bucket(nodeSelectorsBucket).Get([]byte(spiffeID))
+	if value == nil {
+		return nil, nil
+	}
+	return unmarshalSelectors(value)
+}
+
+func listNodeSelectors(tx *bolt.Tx, req *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	resp := &datastore.ListNodeSelectorsResponse{
+		Selectors: make(map[string][]*common.Selector),
+	}
+
+	if err := tx.Bucket(nodeSelectorsBucket).ForEach(func(k, v []byte) error {
+		spiffeID := string(k)
+		if !req.ValidAt.IsZero() {
+			_, node, err := getAttestedNode(tx, spiffeID)
+			switch {
+			case errors.Is(err, errNotFound):
+				return nil
+			case err != nil:
+				return err
+			}
+			if !time.Unix(node.CertNotAfter, 0).After(req.ValidAt) {
+				return nil
+			}
+		}
+
+		selectors, err := unmarshalSelectors(v)
+		if err != nil {
+			return err
+		}
+		resp.Selectors[spiffeID] = selectors
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func forEachAttestedNode(tx *bolt.Tx, after uint64, fn func(id uint64, node *common.AttestedNode) error) error {
+	return attestedNodesTable.forEach(tx, after, func(id uint64, value []byte) error {
+		node, err := unmarshalAttestedNode(value)
+		if err != nil {
+			return err
+		}
+		return fn(id, node)
+	})
+}
+
+// getAttestedNode returns the attested node with the given SPIFFE ID along
+// with its internal ID. It fails with errNotFound if there is no such node.
+func getAttestedNode(tx *bolt.Tx, spiffeID string) (uint64, *common.AttestedNode, error) {
+	id, value := attestedNodesTable.get(tx, spiffeID)
+	if value == nil {
+		return 0, nil, errNotFound
+	}
+	node, err := unmarshalAttestedNode(value)
+	if err != nil {
+		return 0, nil, err
+	}
+	return id, node, nil
+}
+
+func unmarshalAttestedNode(value []byte) (*common.AttestedNode, error) {
+	node := new(common.AttestedNode)
+	if err := proto.Unmarshal(value, node); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal attested node: %w", err)
+	}
+	return node, nil
+}
+
+func unmarshalSelectors(value []byte) ([]*common.Selector, error) {
+	selectors := new(common.Selectors)
+	if err := proto.Unmarshal(value, selectors); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal node selectors: %w", err)
+	}
+	return selectors.Entries, nil
+}
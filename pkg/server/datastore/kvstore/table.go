@@ -0,0 +1,178 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// table is a set of records addressed by a natural key (e.g. a trust domain
+// or SPIFFE ID). Records are stored in the data bucket keyed by an internal,
+// monotonically increasing ID so that iteration follows insertion order and
+// pagination tokens remain stable. The index bucket maps each natural key to
+// its internal ID.
+type table struct {
+	data  []byte
+	index []byte
+}
+
+var (
+	bundlesTable = table{
+		data:  []byte("bundles"),
+		index: []byte("bundles_by_trust_domain"),
+	}
+	attestedNodesTable = table{
+		data:  []byte("attested_nodes"),
+		index: []byte("attested_nodes_by_spiffe_id"),
+	}
+	registrationEntriesTable = table{
+		data:  []byte("registration_entries"),
+		index: []byte("registration_entries_by_entry_id"),
+	}
+	federationRelationshipsTable = table{
+		data:  []byte("federated_trust_domains"),
+		index: []byte("federated_trust_domains_by_trust_domain"),
+	}
+
+	// nodeSelectorsBucket maps a SPIFFE ID to its node selectors
+	nodeSelectorsBucket = []byte("node_selectors")
+
+	// joinTokensBucket maps a join token to its expiry
+	joinTokensBucket = []byte("join_tokens")
+
+	// caJournalsBucket maps the internal CA journal ID to the journal record
+	caJournalsBucket = []byte("ca_journals")
+
+	// registrationEntryEventsBucket and attestedNodeEventsBucket map the
+	// event ID to the event record
+	registrationEntryEventsBucket = []byte("registration_entry_events")
+	attestedNodeEventsBucket      = []byte("attested_node_events")
+
+	// registrationEntryChangesBucket maps the change ID to the registration
+	// entry change record
+	registrationEntryChangesBucket = []byte("registration_entry_changes")
+
+	allBuckets = [][]byte{
+		bundlesTable.data, bundlesTable.index,
+		attestedNodesTable.data, attestedNodesTable.index,
+		registrationEntriesTable.data, registrationEntriesTable.index,
+		federationRelationshipsTable.data, federationRelationshipsTable.index,
+		nodeSelectorsBucket,
+		joinTokensBucket,
+		caJournalsBucket,
+		registrationEntryEventsBucket,
+		attestedNodeEventsBucket,
+		registrationEntryChangesBucket,
+	}
+)
+
+// id returns the internal ID of the record with the given key, or zero if
+// there is no such record.
+func (t table) id(tx *bolt.Tx, key string) uint64 {
+	raw := tx.Bucket(t.index).Get([]byte(key))
+	if raw == nil {
+		return 0
+	}
+	return btoi(raw)
+}
+
+// get returns the record with the given key. A nil value is returned if the
+// record does not exist.
+func (t table) get(tx *bolt.Tx, key string) (uint64, []byte) {
+	id := t.id(tx, key)
+	if id == 0 {
+		return 0, nil
+	}
+	return id, tx.Bucket(t.data).Get(itob(id))
+}
+
+// create stores a new record with the given key. It fails with
+// errAlreadyExists if a record with the same key already exists.
+func (t table) create(tx *bolt.Tx, key string, value []byte) (uint64, error) {
+	if t.id(tx, key) != 0 {
+		return 0, errAlreadyExists
+	}
+
+	data := tx.Bucket(t.data)
+	id, err := data.NextSequence()
+	if err != nil {
+		return 0, err
+	}
+	if err := data.Put(itob(id), value); err != nil {
+		return 0, err
+	}
+	if err := tx.Bucket(t.index).Put([]byte(key), itob(id)); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// update overwrites the record with the given internal ID.
+func (t table) update(tx *bolt.Tx, id uint64, value []byte) error {
+	return tx.Bucket(t.data).Put(itob(id), value)
+}
+
+// delete removes the record with the given key. It fails with errNotFound if
+// there is no such record.
+func (t table) delete(tx *bolt.Tx, key string) error {
+	id := t.id(tx, key)
+	if id == 0 {
+		return errNotFound
+	}
+	if err := tx.Bucket(t.data).Delete(itob(id)); err != nil {
+		return err
+	}
+	return tx.Bucket(t.index).Delete([]byte(key))
+}
+
+// count returns the number of records in the table.
+func (t table) count(tx *bolt.Tx) int {
+	return tx.Bucket(t.index).Stats().KeyN
+}
+
+// errStopIteration can be returned from a forEach callback to stop the
+// iteration early without failing.
+var errStopIteration = errors.New("stop iteration")
+
+// forEach calls fn for each record with an internal ID greater than after,
+// in ascending ID order.
+func (t table) forEach(tx *bolt.Tx, after uint64, fn func(id uint64, value []byte) error) error {
+	c := tx.Bucket(t.data).Cursor()
+	for k, v := c.Seek(itob(after + 1)); k != nil; k, v = c.Next() {
+		if err := fn(btoi(k), v); err != nil {
+			if errors.Is(err, errStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// putWithID stores an event-like record under an explicit or generated ID.
+// If id is zero, the next ID in the bucket sequence is used. Otherwise the
+// sequence is advanced past the given ID so that generated IDs never collide
+// with explicitly assigned ones.
+func putWithID(b *bolt.Bucket, id uint64, value []byte) (uint64, error) {
+	if id == 0 {
+		next, err := b.NextSequence()
+		if err != nil {
+			return 0, err
+		}
+		id = next
+	} else {
+		if b.Get(itob(id)) != nil {
+			return 0, fmt.Errorf("%w: id %d", errAlreadyExists, id)
+		}
+		if id > b.Sequence() {
+			if err := b.SetSequence(id); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := b.Put(itob(id), value); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
@@ -11,3 +11,7 @@ func (repo *Repository) GetDataStore() DataStore {
 func (repo *Repository) SetDataStore(dataStore DataStore) {
 	repo.DataStore = dataStore
 }
+
+func (repo *Repository) Clear() {
+	repo.DataStore = nil
+}
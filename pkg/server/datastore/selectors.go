@@ -0,0 +1,67 @@
+package datastore
+
+import (
+	"strings"
+
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// MatchEntrySelectors returns true if a registration entry with the given
+// selectors satisfies the selector filter.
+//
+// Exact and Superset compare the selectors literally, so that entries holding
+// selector expressions can be looked up by the expressions themselves. Subset
+// and MatchAny take the filter selectors as the selectors of a workload, and
+// evaluate the entry selectors that are expressions against them (see
+// selector.Match), so that the filter returns the entries the workload would
+// be issued. An entry selector equal to a filter selector always matches.
+// Negations alone are not enough for MatchAny, since they hold for most
+// workloads.
+func MatchEntrySelectors(entrySelectors []*common.Selector, by *BySelectors) bool {
+	set := make(map[selector.Selector]struct{}, len(by.Selectors))
+	for _, s := range by.Selectors {
+		set[selector.Selector{Type: s.Type, Value: s.Value}] = struct{}{}
+	}
+
+	switch by.Match {
+	case Exact, Superset:
+		have := make(map[selector.Selector]struct{}, len(entrySelectors))
+		for _, s := range entrySelectors {
+			have[selector.Selector{Type: s.Type, Value: s.Value}] = struct{}{}
+		}
+		for s := range set {
+			if _, ok := have[s]; !ok {
+				return false
+			}
+		}
+		return by.Match == Superset || len(have) == len(set)
+	case Subset:
+		for _, s := range entrySelectors {
+			if !matchEntrySelector(selector.Selector{Type: s.Type, Value: s.Value}, set) {
+				return false
+			}
+		}
+		return len(entrySelectors) > 0
+	case MatchAny:
+		for _, s := range entrySelectors {
+			es := selector.Selector{Type: s.Type, Value: s.Value}
+			if _, ok := set[es]; ok {
+				return true
+			}
+			if !strings.HasPrefix(es.Type, selector.NegationPrefix) && matchEntrySelector(es, set) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func matchEntrySelector(s selector.Selector, set map[selector.Selector]struct{}) bool {
+	if _, ok := set[s]; ok {
+		return true
+	}
+	return selector.IsExpression(s) && selector.Match(s, set)
+}
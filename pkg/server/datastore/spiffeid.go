@@ -0,0 +1,10 @@
+package datastore
+
+import "strings"
+
+// SpiffeIDHasPrefix returns true if the SPIFFE ID is the prefix ID or its path
+// lives under the path of the prefix ID. It is the match used by the
+// BySpiffeIDPrefix filter of the registration entry requests.
+func SpiffeIDHasPrefix(spiffeID, prefix string) bool {
+	return spiffeID == prefix || strings.HasPrefix(spiffeID, prefix+"/")
+}
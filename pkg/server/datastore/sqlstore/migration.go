@@ -282,11 +282,15 @@ import (
 // | v1.15.0 |        |                                                                           |
 // | v1.15.1 |        |                                                                           |
 // | v1.15.2 |        |                                                                           |
+// |*********|********|***************************************************************************|
+// | v1.16.0 | 26     | Added registered_entries_changes table for registration entry history     |
+// |         |--------|---------------------------------------------------------------------------|
+// |         | 27     | Added not_before column and index to registered_entries table             |
 // ================================================================================================
 
 const (
 	// the latest schema version of the database in the code
-	latestSchemaVersion = 25
+	latestSchemaVersion = 27
 
 	// lastMinorReleaseSchemaVersion is the schema version supported by the
 	// last minor release. When the migrations are opportunistically pruned
@@ -440,6 +444,7 @@ func initDB(db *gorm.DB, dbType string, log logrus.FieldLogger) (err error) {
 		&NodeSelector{},
 		&RegisteredEntry{},
 		&RegisteredEntryEvent{},
+		&RegisteredEntryChange{},
 		&JoinToken{},
 		&Selector{},
 		&Migration{},
@@ -521,6 +526,10 @@ func migrateVersion(tx *gorm.DB, currVersion int, log logrus.FieldLogger) (versi
 		err = migrateToV24(tx)
 	case 24:
 		err = migrateToV25(tx)
+	case 25:
+		err = migrateToV26(tx)
+	case 26:
+		err = migrateToV27(tx)
 	default:
 		err = sqlcommon.NewSQLError("no migration support for unknown schema version %d", currVersion)
 	}
@@ -547,6 +556,22 @@ func migrateToV25(tx *gorm.DB) error {
 	return nil
 }
 
+func migrateToV26(tx *gorm.DB) error {
+	// Add registered_entries_changes table
+	if err := tx.AutoMigrate(&RegisteredEntryChange{}).Error; err != nil {
+		return sqlcommon.NewWrappedSQLError(err)
+	}
+	return nil
+}
+
+func migrateToV27(tx *gorm.DB) error {
+	// Add not_before column, and its index, to registered_entries table
+	if err := tx.AutoMigrate(&RegisteredEntry{}).Error; err != nil {
+		return sqlcommon.NewWrappedSQLError(err)
+	}
+	return nil
+}
+
 func addFederatedRegistrationEntriesRegisteredEntryIDIndex(tx *gorm.DB) error {
 	// GORM creates the federated_registration_entries implicitly with a primary
 	// key tuple (bundle_id, registered_entry_id). Unfortunately, MySQL5 does
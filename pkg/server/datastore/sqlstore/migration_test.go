@@ -98,6 +98,86 @@ var (
             CREATE INDEX idx_federated_registration_entries_registered_entry_id ON "federated_registration_entries"(registered_entry_id) ;
             COMMIT;
 		    `,
+		25: `
+		    PRAGMA foreign_keys=OFF;
+            BEGIN TRANSACTION;
+            CREATE TABLE IF NOT EXISTS "federated_registration_entries" ("bundle_id" integer,"registered_entry_id" integer, PRIMARY KEY ("bundle_id","registered_entry_id"));
+            CREATE TABLE IF NOT EXISTS "bundles" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"trust_domain" varchar(255) NOT NULL,"data" blob );
+            INSERT INTO bundles VALUES(1,'2026-02-18 10:42:12.139101+00:00','2026-02-18 10:42:12.140014+00:00','spiffe://example.org',X'0a147370696666653a2f2f6578616d706c652e6f726712df030adc03308201d83082015ea0030201020214449db4c88cda977653f4d5e4770aec9b4b1e970c300a06082a8648ce3d040304301e310b3009060355040613025553310f300d060355040a0c06535049464645301e170d3233303531353032303530365a170d3238303531333032303530365a301e310b3009060355040613025553310f300d060355040a0c065350494646453076301006072a8648ce3d020106052b8104002203620004f57073b72f16fdec785ebd117735018227bfa2475a51385e485d0f42f540693b1768fd49ef2bf40e195ac38e48ec2bfd1cfdb51ce98cc48959d177aab0e97db0ce47e7b1c1416bb46c83577f0e2375e1dd079be4d57c8dc81410c5e5294b1867a35d305b301d0603551d0e04160414928ae360c6aaa7cf6aff8d1716b0046aa61c10ff300f0603551d130101ff040530030101ff300e0603551d0f0101ff04040302010630190603551d1104123010860e7370696666653a2f2f6c6f63616c300a06082a8648ce3d0403040368003065023100e7843c85f844778a95c9cc1b2cdcce9bf1d0ae9d67d7e6b6c5cf3c894d37e8530f6a7711d4f2ea82c3833df5b2b6d75102300a2287548b879888c6bdf88dab55b8fc80ec490059f484b2c4177403997b463e9011b3da82f8a6e29254eee45a6293641a85010a5b3059301306072a8648ce3d020106082a8648ce3d03010703420004c5f71bf758cacd8d14b8cf7feac452344ef4e6179e90a7c9827119ec56812d37cf5da87c41c6e2aade917438e6e1e85511d70785d9eacceb5c97d49b9d876a4f122069484c38587a6433556936664b42576f686252454e6a626b62715945557665771884d2dbcc062801');
+            CREATE TABLE IF NOT EXISTS "attested_node_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"data_type" varchar(255),"serial_number" varchar(255),"expires_at" datetime,"new_serial_number" varchar(255),"new_expires_at" datetime,"can_reattest" bool,"agent_version" varchar(255) );
+            CREATE TABLE IF NOT EXISTS "attested_node_entries_events" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255) );
+            CREATE TABLE IF NOT EXISTS "node_resolver_map_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"type" varchar(255),"value" varchar(255) );
+            CREATE TABLE IF NOT EXISTS "registered_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"entry_id" varchar(255),"spiffe_id" varchar(255),"parent_id" varchar(255),"ttl" integer,"admin" bool,"downstream" bool,"expiry" bigint,"revision_number" bigint,"store_svid" bool,"hint" varchar(255),"jwt_svid_ttl" integer,"additional_attributes" blob );
+            CREATE TABLE IF NOT EXISTS "registered_entries_events" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"entry_id" varchar(255) );
+            CREATE TABLE IF NOT EXISTS "join_tokens" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"token" varchar(255),"expiry" bigint );
+            CREATE TABLE IF NOT EXISTS "selectors" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"type" varchar(255),"value" varchar(255) );
+            CREATE TABLE IF NOT EXISTS "migrations" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"version" integer,"code_version" varchar(255) );
+            INSERT INTO migrations VALUES(1,'2026-02-18 10:42:12.131652+00:00','2026-02-18 10:42:12.131652+00:00',25,'1.15.2-dev-27d2083');
+            CREATE TABLE IF NOT EXISTS "dns_names" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"value" varchar(255) );
+            CREATE TABLE IF NOT EXISTS "federated_trust_domains" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"trust_domain" varchar(255) NOT NULL,"bundle_endpoint_url" varchar(255),"bundle_endpoint_profile" varchar(255),"endpoint_spiffe_id" varchar(255),"implicit" bool );
+            CREATE TABLE IF NOT EXISTS "ca_journals" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"data" blob,"active_x509_authority_id" varchar(255),"active_jwt_authority_id" varchar(255) );
+            INSERT INTO ca_journals VALUES(1,'2026-02-18 10:42:12.139336+00:00','2026-02-18 10:42:12.140316+00:00',X'0a99090a01411084afd6cc061a97043082021330820199a003020102021100edc054dc96e559d730b03d4ed8325d8d300a06082a8648ce3d040303301e310b3009060355040613025553310f300d060355040a0c06535049464645301e170d3236303231383130343230325a170d3236303231393130343231325a3050310b3009060355040613025553310f300d060355040a13065350494646453130302e060355040513273235363631393333353330383839393338353431373438373938393233313537363633333937363059301306072a8648ce3d020106082a8648ce3d03010703420004259745e04850a4c2f6d6f3dd8414ae098d08c523f6de5d04ca2332aa12ae5bcf64c7f16fb4423c923eba1b7e88dfa21dee985e6643a8c45c2284b166919403f4a38185308182300e0603551d0f0101ff040403020106300f0603551d130101ff040530030101ff301d0603551d0e04160414d15f6be56f1b49cdbbeb148b14dd9663caab881d301f0603551d23041830168014928ae360c6aaa7cf6aff8d1716b0046aa61c10ff301f0603551d110418301686147370696666653a2f2f6578616d706c652e6f7267300a06082a8648ce3d0403030368003065023100e72005fbbd726232c3fde94c7da6fe7614377890e87a8391b6e499461be26825c1636d0c1a279be863d68e63b53b8d1f0230209e99243c0f6ed7db2c9e0b190e5abf1a86990761fa8459cee89f1c3ca79ce23c641fe066898b7818fdc1bd8abd027e2297043082021330820199a003020102021100edc054dc96e559d730b03d4ed8325d8d300a06082a8648ce3d040303301e310b3009060355040613025553310f300d060355040a0c06535049464645301e170d3236303231383130343230325a170d3236303231393130343231325a3050310b3009060355040613025553310f300d060355040a13065350494646453130302e060355040513273235363631393333353330383839393338353431373438373938393233313537363633333937363059301306072a8648ce3d020106082a8648ce3d03010703420004259745e04850a4c2f6d6f3dd8414ae098d08c523f6de5d04ca2332aa12ae5bcf64c7f16fb4423c923eba1b7e88dfa21dee985e6643a8c45c2284b166919403f4a38185308182300e0603551d0f0101ff040403020106300f0603551d130101ff040530030101ff301d0603551d0e04160414d15f6be56f1b49cdbbeb148b14dd9663caab881d301f0603551d23041830168014928ae360c6aaa7cf6aff8d1716b0046aa61c10ff301f0603551d110418301686147370696666653a2f2f6578616d706c652e6f7267300a06082a8648ce3d0403030368003065023100e72005fbbd726232c3fde94c7da6fe7614377890e87a8391b6e499461be26825c1636d0c1a279be863d68e63b53b8d1f0230209e99243c0f6ed7db2c9e0b190e5abf1a86990761fa8459cee89f1c3ca79ce23c641fe066898b7818fdc1bd8abd027e28033228643135663662653536663162343963646262656231343862313464643936363363616162383831643884d2dbcc0642283932386165333630633661616137636636616666386431373136623030343661613631633130666612b2010a01411084afd6cc061884d2dbcc06222069484c38587a6433556936664b42576f686252454e6a626b62715945557665772a5b3059301306072a8648ce3d020106082a8648ce3d03010703420004c5f71bf758cacd8d14b8cf7feac452344ef4e6179e90a7c9827119ec56812d37cf5da87c41c6e2aade917438e6e1e85511d70785d9eacceb5c97d49b9d876a4f30033a2069484c38587a6433556936664b42576f686252454e6a626b6271594555766577','d15f6be56f1b49cdbbeb148b14dd9663caab881d','');
+            INSERT INTO sqlite_sequence VALUES('migrations',1);
+            INSERT INTO sqlite_sequence VALUES('bundles',1);
+            INSERT INTO sqlite_sequence VALUES('ca_journals',1);
+            CREATE UNIQUE INDEX uix_bundles_trust_domain ON "bundles"(trust_domain) ;
+            CREATE INDEX idx_attested_node_entries_expires_at ON "attested_node_entries"(expires_at) ;
+            CREATE UNIQUE INDEX uix_attested_node_entries_spiffe_id ON "attested_node_entries"(spiffe_id) ;
+            CREATE UNIQUE INDEX idx_node_resolver_map ON "node_resolver_map_entries"(spiffe_id, "type", "value") ;
+            CREATE INDEX idx_registered_entries_hint ON "registered_entries"("hint") ;
+            CREATE INDEX idx_registered_entries_spiffe_id ON "registered_entries"(spiffe_id) ;
+            CREATE INDEX idx_registered_entries_parent_id ON "registered_entries"(parent_id) ;
+            CREATE INDEX idx_registered_entries_expiry ON "registered_entries"("expiry") ;
+            CREATE UNIQUE INDEX uix_registered_entries_entry_id ON "registered_entries"(entry_id) ;
+            CREATE UNIQUE INDEX uix_join_tokens_token ON "join_tokens"("token") ;
+            CREATE INDEX idx_selectors_type_value ON "selectors"("type", "value") ;
+            CREATE UNIQUE INDEX idx_selector_entry ON "selectors"(registered_entry_id, "type", "value") ;
+            CREATE UNIQUE INDEX idx_dns_entry ON "dns_names"(registered_entry_id, "value") ;
+            CREATE UNIQUE INDEX uix_federated_trust_domains_trust_domain ON "federated_trust_domains"(trust_domain) ;
+            CREATE INDEX idx_ca_journals_active_x509_authority_id ON "ca_journals"(active_x509_authority_id) ;
+            CREATE INDEX idx_ca_journals_active_jwt_authority_id ON "ca_journals"(active_jwt_authority_id) ;
+            CREATE INDEX idx_federated_registration_entries_registered_entry_id ON "federated_registration_entries"(registered_entry_id) ;
+            COMMIT;
+		    `,
+		26: `
+			PRAGMA foreign_keys=OFF;
+			BEGIN TRANSACTION;
+			CREATE TABLE IF NOT EXISTS "federated_registration_entries" ("bundle_id" integer,"registered_entry_id" integer, PRIMARY KEY ("bundle_id","registered_entry_id"));
+			CREATE TABLE IF NOT EXISTS "bundles" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"trust_domain" varchar(255) NOT NULL,"data" blob );
+			CREATE TABLE IF NOT EXISTS "attested_node_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"data_type" varchar(255),"serial_number" varchar(255),"expires_at" datetime,"new_serial_number" varchar(255),"new_expires_at" datetime,"can_reattest" bool,"agent_version" varchar(255) );
+			CREATE TABLE IF NOT EXISTS "attested_node_entries_events" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255) );
+			CREATE TABLE IF NOT EXISTS "node_resolver_map_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"type" varchar(255),"value" varchar(255) );
+			CREATE TABLE IF NOT EXISTS "registered_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"entry_id" varchar(255),"spiffe_id" varchar(255),"parent_id" varchar(255),"ttl" integer,"admin" bool,"downstream" bool,"expiry" bigint,"revision_number" bigint,"store_svid" bool,"hint" varchar(255),"jwt_svid_ttl" integer,"additional_attributes" blob );
+			CREATE TABLE IF NOT EXISTS "registered_entries_events" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"entry_id" varchar(255) );
+			CREATE TABLE IF NOT EXISTS "registered_entries_changes" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"entry_id" varchar(255),"operation" varchar(255),"caller_id" varchar(255),"before" blob,"after" blob );
+			CREATE TABLE IF NOT EXISTS "join_tokens" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"token" varchar(255),"expiry" bigint );
+			CREATE TABLE IF NOT EXISTS "selectors" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"type" varchar(255),"value" varchar(255) );
+			CREATE TABLE IF NOT EXISTS "migrations" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"version" integer,"code_version" varchar(255) );
+			INSERT INTO migrations VALUES(1,'2026-10-16 18:14:40.620195874+00:00','2026-10-16 18:14:40.620195874+00:00',26,'1.15.3-dev-unk');
+			CREATE TABLE IF NOT EXISTS "dns_names" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"value" varchar(255) );
+			CREATE TABLE IF NOT EXISTS "federated_trust_domains" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"trust_domain" varchar(255) NOT NULL,"bundle_endpoint_url" varchar(255),"bundle_endpoint_profile" varchar(255),"endpoint_spiffe_id" varchar(255),"implicit" bool );
+			CREATE TABLE IF NOT EXISTS "ca_journals" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"data" blob,"active_x509_authority_id" varchar(255),"active_jwt_authority_id" varchar(255) );
+			INSERT INTO sqlite_sequence VALUES('migrations',1);
+			CREATE UNIQUE INDEX uix_bundles_trust_domain ON "bundles"(trust_domain) ;
+			CREATE INDEX idx_attested_node_entries_expires_at ON "attested_node_entries"(expires_at) ;
+			CREATE UNIQUE INDEX uix_attested_node_entries_spiffe_id ON "attested_node_entries"(spiffe_id) ;
+			CREATE UNIQUE INDEX idx_node_resolver_map ON "node_resolver_map_entries"(spiffe_id, "type", "value") ;
+			CREATE INDEX idx_registered_entries_expiry ON "registered_entries"("expiry") ;
+			CREATE INDEX idx_registered_entries_hint ON "registered_entries"("hint") ;
+			CREATE INDEX idx_registered_entries_spiffe_id ON "registered_entries"(spiffe_id) ;
+			CREATE INDEX idx_registered_entries_parent_id ON "registered_entries"(parent_id) ;
+			CREATE UNIQUE INDEX uix_registered_entries_entry_id ON "registered_entries"(entry_id) ;
+			CREATE INDEX idx_registered_entries_changes_entry_id ON "registered_entries_changes"(entry_id) ;
+			CREATE UNIQUE INDEX uix_join_tokens_token ON "join_tokens"("token") ;
+			CREATE INDEX idx_selectors_type_value ON "selectors"("type", "value") ;
+			CREATE UNIQUE INDEX idx_selector_entry ON "selectors"(registered_entry_id, "type", "value") ;
+			CREATE UNIQUE INDEX idx_dns_entry ON "dns_names"(registered_entry_id, "value") ;
+			CREATE UNIQUE INDEX uix_federated_trust_domains_trust_domain ON "federated_trust_domains"(trust_domain) ;
+			CREATE INDEX idx_ca_journals_active_x509_authority_id ON "ca_journals"(active_x509_authority_id) ;
+			CREATE INDEX idx_ca_journals_active_jwt_authority_id ON "ca_journals"(active_jwt_authority_id) ;
+			CREATE INDEX idx_federated_registration_entries_registered_entry_id ON "federated_registration_entries"(registered_entry_id) ;
+			COMMIT;
+			`,
 	}
 )
 
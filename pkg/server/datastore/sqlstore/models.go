@@ -12,6 +12,11 @@ type Model struct {
 	UpdatedAt time.Time
 }
 
+// rowID returns the primary key of the row.
+func (m Model) rowID() uint {
+	return m.ID
+}
+
 // Bundle holds a trust bundle.
 type Bundle struct {
 	Model
@@ -84,6 +89,8 @@ type RegisteredEntry struct {
 	Downstream    bool
 	// (optional) expiry of this entry
 	Expiry int64 `gorm:"index"`
+	// (optional) time before which this entry is not active
+	NotBefore int64 `gorm:"index"`
 	// (optional) DNS entries
 	DNSList []DNSName
 
@@ -119,6 +126,23 @@ func (RegisteredEntryEvent) TableName() string {
 	return "registered_entries_events"
 }
 
+// RegisteredEntryChange holds the values of a registered entry before and
+// after a mutation
+type RegisteredEntryChange struct {
+	Model
+
+	EntryID   string `gorm:"index"`
+	Operation string
+	CallerID  string
+	Before    []byte `gorm:"size:16777215"` // make MySQL to use MEDIUMBLOB (max 16MB) - doesn't affect PostgreSQL/SQLite
+	After     []byte `gorm:"size:16777215"` // make MySQL to use MEDIUMBLOB (max 16MB) - doesn't affect PostgreSQL/SQLite
+}
+
+// TableName gets table name for RegisteredEntryChange
+func (RegisteredEntryChange) TableName() string {
+	return "registered_entries_changes"
+}
+
 // JoinToken holds a join token
 type JoinToken struct {
 	Model
@@ -76,6 +76,9 @@ const (
 	// nodes pruned per call when no batch size (or a non-positive one) is
 	// provided.
 	defaultPruneAttestedNodesBatchSize = 1000
+
+	// exportPageSize is the number of rows read per query by ExportRecords.
+	exportPageSize = 1000
 )
 
 type sqlDB struct {
@@ -166,7 +169,7 @@ func (ds *Plugin) AppendBundle(ctx context.Context, b *common.Bundle) (bundle *c
 // DeleteBundle deletes the bundle with the matching TrustDomain. Any CACert data passed is ignored.
 func (ds *Plugin) DeleteBundle(ctx context.Context, trustDomainID string, mode datastore.DeleteMode) (err error) {
 	return ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
-		err = deleteBundle(tx, trustDomainID, mode)
+		err = deleteBundle(tx, trustDomainID, mode, datastore.CallerIDFromContext(ctx))
 		return err
 	})
 }
@@ -354,7 +357,7 @@ func (ds *Plugin) UpdateAttestedNode(ctx context.Context, n *common.AttestedNode
 // DeleteAttestedNode deletes the given attested node and the associated node selectors.
 func (ds *Plugin) DeleteAttestedNode(ctx context.Context, spiffeID string) (attestedNode *common.AttestedNode, err error) {
 	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
-		attestedNode, err = deleteAttestedNodeAndSelectors(tx, spiffeID, ds.log)
+		attestedNode, err = deleteAttestedNodeAndSelectors(tx, spiffeID, datastore.CallerIDFromContext(ctx), ds.log)
 		if err != nil {
 			return err
 		}
@@ -484,7 +487,7 @@ func (ds *Plugin) createOrReturnRegistrationEntry(ctx context.Context,
 			existing = true
 			return nil
 		}
-		registrationEntry, err = createRegistrationEntry(tx, entry)
+		registrationEntry, err = createRegistrationEntry(tx, entry, datastore.CallerIDFromContext(ctx))
 		if err != nil {
 			return err
 		}
@@ -542,7 +545,7 @@ func (ds *Plugin) ListRegistrationEntries(ctx context.Context,
 // UpdateRegistrationEntry updates an existing registration entry
 func (ds *Plugin) UpdateRegistrationEntry(ctx context.Context, e *common.RegistrationEntry, mask *common.RegistrationEntryMask) (entry *common.RegistrationEntry, err error) {
 	if err = ds.withReadModifyWriteTx(ctx, func(tx *gorm.DB) (err error) {
-		entry, err = updateRegistrationEntry(tx, e, mask)
+		entry, err = updateRegistrationEntry(tx, e, mask, datastore.CallerIDFromContext(ctx))
 		if err != nil {
 			return err
 		}
@@ -561,7 +564,7 @@ func (ds *Plugin) DeleteRegistrationEntry(ctx context.Context,
 	entryID string,
 ) (registrationEntry *common.RegistrationEntry, err error) {
 	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
-		registrationEntry, err = deleteRegistrationEntry(tx, entryID)
+		registrationEntry, err = deleteRegistrationEntry(tx, entryID, datastore.CallerIDFromContext(ctx))
 		if err != nil {
 			return err
 		}
@@ -575,6 +578,18 @@ func (ds *Plugin) DeleteRegistrationEntry(ctx context.Context,
 	return registrationEntry, nil
 }
 
+// ActivateRegistrationEntries creates a registration entry event for each
+// entry whose not-before time is after since and not after until, so the
+// entry caches pick up the entries that became active in that window. Entries
+// that already have an event created on or after their not-before time are
+// skipped, so servers sharing the datastore do not duplicate the events.
+func (ds *Plugin) ActivateRegistrationEntries(ctx context.Context, since, until time.Time) (err error) {
+	return ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		err = activateRegistrationEntries(tx, since, until, ds.log)
+		return err
+	})
+}
+
 // PruneRegistrationEntries takes a registration entry message, and deletes all entries which have expired
 // before the date in the message
 func (ds *Plugin) PruneRegistrationEntries(ctx context.Context, expiresBefore time.Time) (err error) {
@@ -626,6 +641,25 @@ func (ds *Plugin) FetchRegistrationEntryEvent(ctx context.Context, eventID uint)
 	return event, nil
 }
 
+// ListRegistrationEntryChanges lists registration entry changes, oldest first
+func (ds *Plugin) ListRegistrationEntryChanges(ctx context.Context, req *datastore.ListRegistrationEntryChangesRequest) (resp *datastore.ListRegistrationEntryChangesResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listRegistrationEntryChanges(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneRegistrationEntryChanges deletes all registration entry changes older than a specified duration
+func (ds *Plugin) PruneRegistrationEntryChanges(ctx context.Context, olderThan time.Duration) (err error) {
+	return ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		err = pruneRegistrationEntryChanges(tx, olderThan)
+		return err
+	})
+}
+
 // CreateJoinToken takes a Token message and stores it
 func (ds *Plugin) CreateJoinToken(ctx context.Context, token *datastore.JoinToken) (err error) {
 	if token == nil || token.Token == "" || token.Expiry.IsZero() {
@@ -651,6 +685,17 @@ func (ds *Plugin) FetchJoinToken(ctx context.Context, token string) (resp *datas
 	return resp, nil
 }
 
+// ListJoinTokens lists join tokens
+func (ds *Plugin) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (resp *datastore.ListJoinTokensResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listJoinTokens(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // DeleteJoinToken deletes the given join token
 func (ds *Plugin) DeleteJoinToken(ctx context.Context, token string) (err error) {
 	return ds.withReadModifyWriteTx(ctx, func(tx *gorm.DB) (err error) {
@@ -762,6 +807,17 @@ func (ds *Plugin) FetchCAJournal(ctx context.Context, activeX509AuthorityID stri
 	return caJournal, nil
 }
 
+// ListCAJournals lists CA journals
+func (ds *Plugin) ListCAJournals(ctx context.Context, req *datastore.ListCAJournalsRequest) (resp *datastore.ListCAJournalsResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listCAJournals(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // ListCAJournalsForTesting returns all the CA journal records, and is meant to
 // be used in tests.
 func (ds *Plugin) ListCAJournalsForTesting(ctx context.Context) (caJournals []*datastore.CAJournal, err error) {
@@ -774,6 +830,22 @@ func (ds *Plugin) ListCAJournalsForTesting(ctx context.Context) (caJournals []*d
 	return caJournals, nil
 }
 
+// ExportRecords reads every record in a single read transaction and calls fn
+// for each of them. Errors returned by fn are returned unmodified.
+func (ds *Plugin) ExportRecords(ctx context.Context, fn func(*datastore.ExportRecord) error) error {
+	var fnErr error
+	err := ds.withSnapshotReadTx(ctx, func(tx *gorm.DB) error {
+		return exportRecords(tx, func(r *datastore.ExportRecord) error {
+			fnErr = fn(r)
+			return fnErr
+		})
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
+
 // SetCAJournal sets the content for the specified CA journal. If the CA journal
 // does not exist, it is created.
 func (ds *Plugin) SetCAJournal(ctx context.Context, caJournal *datastore.CAJournal) (caj *datastore.CAJournal, err error) {
@@ -1013,6 +1085,35 @@ func (ds *Plugin) withReadTx(ctx context.Context, op func(tx *gorm.DB) error) er
 	return ds.withTx(ctx, op, true)
 }
 
+// withSnapshotReadTx wraps the operation in a read-only transaction in which
+// every query observes the same snapshot of the database. SQLite transactions
+// always do, while PostgreSQL and MySQL require the REPEATABLE READ isolation
+// level for it.
+func (ds *Plugin) withSnapshotReadTx(ctx context.Context, op func(tx *gorm.DB) error) error {
+	ds.mu.Lock()
+	db := ds.db
+	ds.mu.Unlock()
+
+	var opts *sql.TxOptions
+	if !isSQLiteDbType(db.databaseType) {
+		opts = &sql.TxOptions{
+			Isolation: sql.LevelRepeatableRead,
+			ReadOnly:  true,
+		}
+	}
+
+	tx := db.BeginTx(ctx, opts)
+	if err := tx.Error; err != nil {
+		return sqlcommon.NewWrappedSQLError(err)
+	}
+
+	if err := op(tx); err != nil {
+		tx.Rollback()
+		return ds.gormToGRPCStatus(err)
+	}
+	return sqlcommon.NewWrappedSQLError(tx.Rollback().Error)
+}
+
 func (ds *Plugin) withTx(ctx context.Context, op func(tx *gorm.DB) error, readOnly bool) error {
 	ds.mu.Lock()
 	db := ds.db
@@ -1289,7 +1390,7 @@ func appendBundle(tx *gorm.DB, b *common.Bundle) (*common.Bundle, error) {
 	return bundle, nil
 }
 
-func deleteBundle(tx *gorm.DB, trustDomainID string, mode datastore.DeleteMode) error {
+func deleteBundle(tx *gorm.DB, trustDomainID string, mode datastore.DeleteMode, callerID string) error {
 	model := new(Bundle)
 	if err := tx.Find(model, "trust_domain = ?", trustDomainID).Error; err != nil {
 		return sqlcommon.NewWrappedSQLError(err)
@@ -1303,6 +1404,21 @@ func deleteBundle(tx *gorm.DB, trustDomainID string, mode datastore.DeleteMode)
 	}
 
 	if entriesCount > 0 {
+		// Load the federated entries to record their changes in the entry
+		// history.
+		var entries []RegisteredEntry
+		if err := entriesAssociation.Find(&entries).Error; err != nil {
+			return sqlcommon.NewWrappedSQLError(err)
+		}
+		befores := make([]*common.RegistrationEntry, 0, len(entries))
+		for _, entry := range entries {
+			before, err := modelToEntry(tx, entry)
+			if err != nil {
+				return err
+			}
+			befores = append(befores, before)
+		}
+
 		switch mode {
 		case datastore.Delete:
 			// TODO: figure out how to do this gracefully with GORM.
@@ -1315,10 +1431,35 @@ func deleteBundle(tx *gorm.DB, trustDomainID string, mode datastore.DeleteMode)
 					bundle_id = ?)`), model.ID).Error; err != nil {
 				return sqlcommon.NewWrappedSQLError(err)
 			}
+			for _, before := range befores {
+				if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+					EntryID:   before.EntryId,
+					Operation: datastore.RegistrationEntryDeleted,
+					CallerID:  callerID,
+					Before:    before,
+				}); err != nil {
+					return err
+				}
+			}
 		case datastore.Dissociate:
 			if err := entriesAssociation.Clear().Error; err != nil {
 				return sqlcommon.NewWrappedSQLError(err)
 			}
+			for i, entry := range entries {
+				after, err := modelToEntry(tx, entry)
+				if err != nil {
+					return err
+				}
+				if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+					EntryID:   entry.EntryID,
+					Operation: datastore.RegistrationEntryUpdated,
+					CallerID:  callerID,
+					Before:    befores[i],
+					After:     after,
+				}); err != nil {
+					return err
+				}
+			}
 		default:
 			return status.Newf(codes.FailedPrecondition, "datastore-sql: cannot delete bundle; federated with %d registration entries", entriesCount).Err()
 		}
@@ -1830,7 +1971,7 @@ func pruneAttestedExpiredNodes(tx *gorm.DB, expiredBefore time.Time, include boo
 	defer func() { logger.WithField("count", count).Info("Pruned expired agents") }()
 
 	for _, node := range expiredNodes {
-		_, err := deleteAttestedNodeAndSelectors(tx, node.SpiffeID, logger)
+		_, err := deleteAttestedNodeAndSelectors(tx, node.SpiffeID, "", logger)
 		if err != nil {
 			return err
 		}
@@ -2404,7 +2545,7 @@ func updateAttestedNode(tx *gorm.DB, n *common.AttestedNode, mask *common.Attest
 	return modelToAttestedNode(model), nil
 }
 
-func deleteAttestedNodeAndSelectors(tx *gorm.DB, spiffeID string, logger logrus.FieldLogger) (*common.AttestedNode, error) {
+func deleteAttestedNodeAndSelectors(tx *gorm.DB, spiffeID, callerID string, logger logrus.FieldLogger) (*common.AttestedNode, error) {
 	var (
 		nodeModel         AttestedNode
 		nodeSelectorModel NodeSelector
@@ -2433,9 +2574,21 @@ func deleteAttestedNodeAndSelectors(tx *gorm.DB, spiffeID string, logger logrus.
 			if len(selectors) != 1 || selectors[0].Type != "spiffe_id" || selectors[0].Value != entry.ParentID {
 				continue
 			}
+			before, err := modelToEntry(tx, entry)
+			if err != nil {
+				return nil, err
+			}
 			if err := deleteRegistrationEntrySupport(tx, entry); err != nil {
 				return nil, err
 			}
+			if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+				EntryID:   entry.EntryID,
+				Operation: datastore.RegistrationEntryDeleted,
+				CallerID:  callerID,
+				Before:    before,
+			}); err != nil {
+				return nil, err
+			}
 			if err := createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
 				EntryID: entry.EntryID,
 			}); err != nil {
@@ -2592,7 +2745,7 @@ func buildListNodeSelectorsQuery(req *datastore.ListNodeSelectorsRequest) (query
 	return sb.String(), args
 }
 
-func createRegistrationEntry(tx *gorm.DB, entry *common.RegistrationEntry) (*common.RegistrationEntry, error) {
+func createRegistrationEntry(tx *gorm.DB, entry *common.RegistrationEntry, callerID string) (*common.RegistrationEntry, error) {
 	entryID, err := createOrReturnEntryID(entry)
 	if err != nil {
 		return nil, err
@@ -2611,6 +2764,7 @@ func createRegistrationEntry(tx *gorm.DB, entry *common.RegistrationEntry) (*com
 		Admin:                entry.Admin,
 		Downstream:           entry.Downstream,
 		Expiry:               entry.EntryExpiry,
+		NotBefore:            entry.NotBefore,
 		StoreSvid:            entry.StoreSvid,
 		JWTSvidTTL:           entry.JwtSvidTtl,
 		Hint:                 entry.Hint,
@@ -2658,6 +2812,21 @@ func createRegistrationEntry(tx *gorm.DB, entry *common.RegistrationEntry) (*com
 		return nil, err
 	}
 
+	// The recorded entry is converted separately since recording it
+	// modifies the internal state of the message returned to the caller.
+	after, err := modelToEntry(tx, newRegisteredEntry)
+	if err != nil {
+		return nil, err
+	}
+	if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+		EntryID:   entryID,
+		Operation: datastore.RegistrationEntryCreated,
+		CallerID:  callerID,
+		After:     after,
+	}); err != nil {
+		return nil, err
+	}
+
 	return registrationEntry, nil
 }
 
@@ -2730,7 +2899,8 @@ SELECT
 	NULL AS dns_name,
 	revision_number,
 	jwt_svid_ttl AS reg_jwt_svid_ttl,
-	additional_attributes
+	additional_attributes,
+	not_before
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -2738,7 +2908,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2751,7 +2921,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2759,7 +2929,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2795,7 +2965,8 @@ SELECT
 	NULL AS dns_name,
 	revision_number,
 	jwt_svid_ttl AS reg_jwt_svid_ttl,
-	additional_attributes
+	additional_attributes,
+	not_before
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -2803,7 +2974,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2816,7 +2987,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2824,7 +2995,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2856,7 +3027,8 @@ SELECT
 	D.value AS dns_name,
 	E.revision_number,
 	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
-	E.additional_attributes AS additional_attributes
+	E.additional_attributes AS additional_attributes,
+	E.not_before AS not_before
 FROM
 	registered_entries E
 LEFT JOIN
@@ -2899,7 +3071,8 @@ SELECT
 	NULL AS dns_name,
 	revision_number,
 	jwt_svid_ttl AS reg_jwt_svid_ttl,
-	additional_attributes
+	additional_attributes,
+	not_before
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -2907,7 +3080,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION ALL
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2920,7 +3093,7 @@ WHERE
 UNION ALL
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2928,7 +3101,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION ALL
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2949,7 +3122,9 @@ func listRegistrationEntries(ctx context.Context, db *sqlDB, log logrus.FieldLog
 
 	// Exact/subset selector matching requires filtering out all registration
 	// entries returned by the query whose selectors are not fully represented
-	// in the request selectors. For this reason, it's possible that a paged
+	// in the request selectors. Subset/match-any matching also evaluates the
+	// selector expressions of the entries returned by the query, which holds
+	// every entry with an expression. For this reason, it's possible that a paged
 	// query returns rows that are completely filtered out. If that happens,
 	// keep querying until a page gets at least one result.
 	for {
@@ -2963,8 +3138,10 @@ func listRegistrationEntries(ctx context.Context, db *sqlDB, log logrus.FieldLog
 		}
 
 		switch req.BySelectors.Match {
-		case datastore.Exact, datastore.Subset:
+		case datastore.Exact:
 			resp.Entries = filterEntriesBySelectorSet(resp.Entries, req.BySelectors.Selectors)
+		case datastore.Subset, datastore.MatchAny:
+			resp.Entries = filterEntriesBySelectors(resp.Entries, req.BySelectors)
 		default:
 		}
 
@@ -3018,6 +3195,19 @@ func filterEntriesBySelectorSet(entries []*common.RegistrationEntry, selectors [
 	return filtered
 }
 
+// filterEntriesBySelectors filters out the entries that do not satisfy the
+// subset or match-any selector filter once their selector expressions are
+// evaluated.
+func filterEntriesBySelectors(entries []*common.RegistrationEntry, by *datastore.BySelectors) []*common.RegistrationEntry {
+	filtered := make([]*common.RegistrationEntry, 0, len(entries))
+	for _, entry := range entries {
+		if datastore.MatchEntrySelectors(entry.Selectors, by) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
 type queryContext interface {
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 }
@@ -3111,7 +3301,8 @@ SELECT
 	NULL AS dns_name,
 	revision_number,
 	jwt_svid_ttl AS reg_jwt_svid_ttl,
-	additional_attributes
+	additional_attributes,
+	not_before
 FROM
 	registered_entries
 `)
@@ -3130,7 +3321,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3145,7 +3336,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 `)
@@ -3156,7 +3347,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -3207,7 +3398,8 @@ SELECT
 	NULL AS dns_name,
 	revision_number,
 	jwt_svid_ttl AS reg_jwt_svid_ttl,
-	additional_attributes
+	additional_attributes,
+	not_before
 FROM
 	registered_entries
 `)
@@ -3225,7 +3417,7 @@ FROM
 UNION ALL
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3240,7 +3432,7 @@ ON
 UNION ALL
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 `)
@@ -3251,7 +3443,7 @@ FROM
 UNION ALL
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -3301,7 +3493,8 @@ SELECT
 	D.value AS dns_name,
 	E.revision_number,
 	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
-	E.additional_attributes AS additional_attributes
+	E.additional_attributes AS additional_attributes,
+	E.not_before AS not_before
 FROM
 	registered_entries E
 LEFT JOIN
@@ -3376,7 +3569,8 @@ SELECT
 	NULL AS dns_name,
 	revision_number,
 	jwt_svid_ttl AS reg_jwt_svid_ttl,
-	additional_attributes
+	additional_attributes,
+	not_before
 FROM
 	registered_entries
 `)
@@ -3394,7 +3588,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3409,7 +3603,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 `)
@@ -3420,7 +3614,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -3442,13 +3636,14 @@ func countRegistrationEntries(ctx context.Context, db *sqlDB, _ logrus.FieldLogg
 
 	var val int32
 	listReq := &datastore.ListRegistrationEntriesRequest{
-		DataConsistency: req.DataConsistency,
-		ByParentID:      req.ByParentID,
-		BySelectors:     req.BySelectors,
-		BySpiffeID:      req.BySpiffeID,
-		ByFederatesWith: req.ByFederatesWith,
-		ByHint:          req.ByHint,
-		ByDownstream:    req.ByDownstream,
+		DataConsistency:  req.DataConsistency,
+		ByParentID:       req.ByParentID,
+		BySelectors:      req.BySelectors,
+		BySpiffeID:       req.BySpiffeID,
+		ByFederatesWith:  req.ByFederatesWith,
+		ByHint:           req.ByHint,
+		ByDownstream:     req.ByDownstream,
+		BySpiffeIDPrefix: req.BySpiffeIDPrefix,
 		Pagination: &datastore.Pagination{
 			Token:    "",
 			PageSize: 1000,
@@ -3467,8 +3662,10 @@ func countRegistrationEntries(ctx context.Context, db *sqlDB, _ logrus.FieldLogg
 
 		if req.BySelectors != nil {
 			switch req.BySelectors.Match {
-			case datastore.Exact, datastore.Subset:
+			case datastore.Exact:
 				resp.Entries = filterEntriesBySelectorSet(resp.Entries, req.BySelectors.Selectors)
+			case datastore.Subset, datastore.MatchAny:
+				resp.Entries = filterEntriesBySelectors(resp.Entries, req.BySelectors)
 			default:
 			}
 		}
@@ -3634,29 +3831,37 @@ func appendListRegistrationEntriesFilterQuery(filterExp string, builder *strings
 		args = append(args, req.ByHint)
 	}
 
+	if req.BySpiffeIDPrefix != "" {
+		root.children = append(root.children, idFilterNode{
+			idColumn: "id",
+			query:    []string{"SELECT id AS e_id FROM registered_entries WHERE (spiffe_id = ? OR spiffe_id LIKE ? ESCAPE '!')"},
+		})
+		args = append(args, req.BySpiffeIDPrefix, escapeLike(req.BySpiffeIDPrefix)+"/%")
+	}
+
 	if req.BySelectors != nil && len(req.BySelectors.Selectors) > 0 {
 		switch req.BySelectors.Match {
 		case datastore.Subset, datastore.MatchAny:
 			// subset needs a union, so we need to group them and add the group
-			// as a child to the root.
-			if len(req.BySelectors.Selectors) < 2 {
-				root.children = append(root.children, idFilterNode{
+			// as a child to the root. The entries with selector expressions are
+			// part of the union too, since the expressions are only evaluated
+			// once the entries are fetched. The expression child takes no
+			// arguments, so it goes last.
+			group := idFilterNode{
+				idColumn: "e_id",
+				union:    true,
+			}
+			for range req.BySelectors.Selectors {
+				group.children = append(group.children, idFilterNode{
 					idColumn: "registered_entry_id",
 					query:    []string{"SELECT registered_entry_id AS e_id FROM selectors WHERE type = ? AND value = ?"},
 				})
-			} else {
-				group := idFilterNode{
-					idColumn: "e_id",
-					union:    true,
-				}
-				for range req.BySelectors.Selectors {
-					group.children = append(group.children, idFilterNode{
-						idColumn: "registered_entry_id",
-						query:    []string{"SELECT registered_entry_id AS e_id FROM selectors WHERE type = ? AND value = ?"},
-					})
-				}
-				root.children = append(root.children, group)
 			}
+			group.children = append(group.children, idFilterNode{
+				idColumn: "registered_entry_id",
+				query:    []string{"SELECT registered_entry_id AS e_id FROM selectors WHERE type LIKE '!%' OR type LIKE '|%' OR type LIKE '~%'"},
+			})
+			root.children = append(root.children, group)
 		case datastore.Exact, datastore.Superset:
 			// exact match does use an intersection, so we can just add these
 			// directly to the root idFilterNode, since it is already an intersection
@@ -3807,6 +4012,16 @@ func appendListRegistrationEntriesFilterQuery(filterExp string, builder *strings
 	return filtered, args, nil
 }
 
+// likeEscaper escapes the LIKE wildcards with the '!' escape character, which
+// works the same on every supported database.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// escapeLike escapes the value so it matches literally in a LIKE pattern
+// declared with ESCAPE '!'.
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
 func buildSliceArg(length int) string {
 	strBuilder := new(strings.Builder)
 	strBuilder.WriteString("(?")
@@ -3938,6 +4153,7 @@ type entryRow struct {
 	RevisionNumber       sql.NullInt64
 	RegJwtSvidTTL        sql.NullInt64
 	AdditionalAttributes sql.Null[[]byte]
+	NotBefore            sql.NullInt64
 }
 
 func scanEntryRow(rs *sql.Rows, r *entryRow) error {
@@ -3962,6 +4178,7 @@ func scanEntryRow(rs *sql.Rows, r *entryRow) error {
 		&r.RevisionNumber,
 		&r.RegJwtSvidTTL,
 		&r.AdditionalAttributes,
+		&r.NotBefore,
 	))
 }
 
@@ -3984,6 +4201,9 @@ func fillEntryFromRow(entry *common.RegistrationEntry, r *entryRow) error {
 	if r.Expiry.Valid {
 		entry.EntryExpiry = r.Expiry.Int64
 	}
+	if r.NotBefore.Valid {
+		entry.NotBefore = r.NotBefore.Int64
+	}
 	if r.StoreSvid.Valid {
 		entry.StoreSvid = r.StoreSvid.Bool
 	}
@@ -4053,7 +4273,7 @@ func applyPagination(p *datastore.Pagination, entryTx *gorm.DB) (*gorm.DB, error
 	return entryTx, nil
 }
 
-func updateRegistrationEntry(tx *gorm.DB, e *common.RegistrationEntry, mask *common.RegistrationEntryMask) (*common.RegistrationEntry, error) {
+func updateRegistrationEntry(tx *gorm.DB, e *common.RegistrationEntry, mask *common.RegistrationEntryMask, callerID string) (*common.RegistrationEntry, error) {
 	if err := validateRegistrationEntryForUpdate(e, mask); err != nil {
 		return nil, err
 	}
@@ -4063,6 +4283,10 @@ func updateRegistrationEntry(tx *gorm.DB, e *common.RegistrationEntry, mask *com
 	if err := tx.Find(&entry, "entry_id = ?", e.EntryId).Error; err != nil {
 		return nil, sqlcommon.NewWrappedSQLError(err)
 	}
+	before, err := modelToEntry(tx, entry)
+	if err != nil {
+		return nil, err
+	}
 	if mask == nil || mask.StoreSvid {
 		entry.StoreSvid = e.StoreSvid
 	}
@@ -4124,14 +4348,17 @@ func updateRegistrationEntry(tx *gorm.DB, e *common.RegistrationEntry, mask *com
 	if mask == nil || mask.EntryExpiry {
 		entry.Expiry = e.EntryExpiry
 	}
+	if mask == nil || mask.NotBefore {
+		entry.NotBefore = e.NotBefore
+	}
 	if mask == nil || mask.JwtSvidTtl {
 		entry.JWTSvidTTL = e.JwtSvidTtl
 	}
 	if mask == nil || mask.Hint {
 		entry.Hint = e.Hint
 	}
-	if mask == nil || mask.AdditionalAttributes {
-		AdditionalAttributes, err := marshalAndValidateAdditionalAttributes(e.AdditionalAttributes)
+	if mask == nil || mask.AdditionalAttributes || mask.CredentialProfile {
+		AdditionalAttributes, err := marshalAndValidateAdditionalAttributes(datastore.UpdateAdditionalAttributes(before.AdditionalAttributes, e.AdditionalAttributes, mask))
 		if err != nil {
 			return nil, err
 		}
@@ -4162,10 +4389,24 @@ func updateRegistrationEntry(tx *gorm.DB, e *common.RegistrationEntry, mask *com
 		return nil, err
 	}
 
+	after, err := modelToEntry(tx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+		EntryID:   entry.EntryID,
+		Operation: datastore.RegistrationEntryUpdated,
+		CallerID:  callerID,
+		Before:    before,
+		After:     after,
+	}); err != nil {
+		return nil, err
+	}
+
 	return returnEntry, nil
 }
 
-func deleteRegistrationEntry(tx *gorm.DB, entryID string) (*common.RegistrationEntry, error) {
+func deleteRegistrationEntry(tx *gorm.DB, entryID, callerID string) (*common.RegistrationEntry, error) {
 	entry := RegisteredEntry{}
 	if err := tx.Find(&entry, "entry_id = ?", entryID).Error; err != nil {
 		return nil, sqlcommon.NewWrappedSQLError(err)
@@ -4176,6 +4417,21 @@ func deleteRegistrationEntry(tx *gorm.DB, entryID string) (*common.RegistrationE
 		return nil, err
 	}
 
+	// The recorded entry is converted separately since recording it
+	// modifies the internal state of the message returned to the caller.
+	before, err := modelToEntry(tx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+		EntryID:   entryID,
+		Operation: datastore.RegistrationEntryDeleted,
+		CallerID:  callerID,
+		Before:    before,
+	}); err != nil {
+		return nil, err
+	}
+
 	err = deleteRegistrationEntrySupport(tx, entry)
 	if err != nil {
 		return nil, err
@@ -4213,9 +4469,20 @@ func pruneRegistrationEntries(tx *gorm.DB, expiresBefore time.Time, logger logru
 	}
 
 	for _, entry := range registrationEntries {
+		before, err := modelToEntry(tx, entry)
+		if err != nil {
+			return err
+		}
 		if err := deleteRegistrationEntrySupport(tx, entry); err != nil {
 			return err
 		}
+		if err := createRegistrationEntryChange(tx, &datastore.RegistrationEntryChange{
+			EntryID:   entry.EntryID,
+			Operation: datastore.RegistrationEntryPruned,
+			Before:    before,
+		}); err != nil {
+			return err
+		}
 		if err := createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
 			EntryID: entry.EntryID,
 		}); err != nil {
@@ -4231,6 +4498,33 @@ func pruneRegistrationEntries(tx *gorm.DB, expiresBefore time.Time, logger logru
 	return nil
 }
 
+func activateRegistrationEntries(tx *gorm.DB, since, until time.Time, logger logrus.FieldLogger) error {
+	var entries []RegisteredEntry
+	if err := tx.Select("entry_id, not_before").Where("not_before > ? AND not_before <= ?", since.Unix(), until.Unix()).Find(&entries).Error; err != nil {
+		return sqlcommon.NewWrappedSQLError(err)
+	}
+
+	for _, entry := range entries {
+		// Every server runs the activation, so skip the entries that already
+		// have an event created on or after their not-before time.
+		var count int
+		if err := tx.Model(&RegisteredEntryEvent{}).Where("entry_id = ? AND created_at >= ?", entry.EntryID, time.Unix(entry.NotBefore, 0)).Count(&count).Error; err != nil {
+			return sqlcommon.NewWrappedSQLError(err)
+		}
+		if count > 0 {
+			continue
+		}
+		if err := createRegistrationEntryEvent(tx, &datastore.RegistrationEntryEvent{
+			EntryID: entry.EntryID,
+		}); err != nil {
+			return err
+		}
+		logger.WithField(telemetry.RegistrationID, entry.EntryID).Debug("Activated registration entry")
+	}
+
+	return nil
+}
+
 func createRegistrationEntryEvent(tx *gorm.DB, event *datastore.RegistrationEntryEvent) error {
 	if err := tx.Create(&RegisteredEntryEvent{
 		Model: Model{
@@ -4325,6 +4619,103 @@ func buildListEventsQueryString(greaterThanEventID, lessThanEventID uint) (*stri
 	return query, id, nil
 }
 
+func createRegistrationEntryChange(tx *gorm.DB, change *datastore.RegistrationEntryChange) error {
+	model := RegisteredEntryChange{
+		EntryID:   change.EntryID,
+		Operation: string(change.Operation),
+		CallerID:  change.CallerID,
+	}
+	var err error
+	if change.Before != nil {
+		if model.Before, err = proto.Marshal(change.Before); err != nil {
+			return err
+		}
+	}
+	if change.After != nil {
+		if model.After, err = proto.Marshal(change.After); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Create(&model).Error; err != nil {
+		return sqlcommon.NewWrappedSQLError(err)
+	}
+
+	return nil
+}
+
+func listRegistrationEntryChanges(tx *gorm.DB, req *datastore.ListRegistrationEntryChangesRequest) (*datastore.ListRegistrationEntryChangesResponse, error) {
+	p := req.Pagination
+	var err error
+	if p != nil {
+		tx, err = applyPagination(p, tx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tx = tx.Order("id asc")
+	}
+	if req.ByEntryID != "" {
+		tx = tx.Where("entry_id = ?", req.ByEntryID)
+	}
+
+	var models []RegisteredEntryChange
+	if err := tx.Find(&models).Error; err != nil {
+		return nil, sqlcommon.NewWrappedSQLError(err)
+	}
+
+	if p != nil {
+		p.Token = ""
+		if len(models) > 0 {
+			p.Token = fmt.Sprint(models[len(models)-1].ID)
+		}
+	}
+
+	resp := &datastore.ListRegistrationEntryChangesResponse{
+		Changes:    make([]*datastore.RegistrationEntryChange, 0, len(models)),
+		Pagination: p,
+	}
+	for _, model := range models {
+		change, err := modelToRegistrationEntryChange(model)
+		if err != nil {
+			return nil, err
+		}
+		resp.Changes = append(resp.Changes, change)
+	}
+	return resp, nil
+}
+
+func pruneRegistrationEntryChanges(tx *gorm.DB, olderThan time.Duration) error {
+	if err := tx.Where("created_at < ?", time.Now().Add(-olderThan)).Delete(&RegisteredEntryChange{}).Error; err != nil {
+		return sqlcommon.NewWrappedSQLError(err)
+	}
+
+	return nil
+}
+
+func modelToRegistrationEntryChange(model RegisteredEntryChange) (*datastore.RegistrationEntryChange, error) {
+	change := &datastore.RegistrationEntryChange{
+		ID:        model.ID,
+		EntryID:   model.EntryID,
+		Operation: datastore.RegistrationEntryOperation(model.Operation),
+		CallerID:  model.CallerID,
+		CreatedAt: model.CreatedAt,
+	}
+	if len(model.Before) > 0 {
+		change.Before = new(common.RegistrationEntry)
+		if err := proto.Unmarshal(model.Before, change.Before); err != nil {
+			return nil, sqlcommon.NewSQLError("unable to unmarshal registration entry change: %s", err)
+		}
+	}
+	if len(model.After) > 0 {
+		change.After = new(common.RegistrationEntry)
+		if err := proto.Unmarshal(model.After, change.After); err != nil {
+			return nil, sqlcommon.NewSQLError("unable to unmarshal registration entry change: %s", err)
+		}
+	}
+	return change, nil
+}
+
 func createJoinToken(tx *gorm.DB, token *datastore.JoinToken) error {
 	t := JoinToken{
 		Token:  token.Token,
@@ -4350,6 +4741,38 @@ func fetchJoinToken(tx *gorm.DB, token string) (*datastore.JoinToken, error) {
 	return modelToJoinToken(model), nil
 }
 
+func listJoinTokens(tx *gorm.DB, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	p := req.Pagination
+	var err error
+	if p != nil {
+		tx, err = applyPagination(p, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var models []JoinToken
+	if err := tx.Find(&models).Error; err != nil {
+		return nil, sqlcommon.NewWrappedSQLError(err)
+	}
+
+	if p != nil {
+		p.Token = ""
+		if len(models) > 0 {
+			p.Token = fmt.Sprint(models[len(models)-1].ID)
+		}
+	}
+
+	resp := &datastore.ListJoinTokensResponse{
+		JoinTokens: make([]*datastore.JoinToken, 0, len(models)),
+		Pagination: p,
+	}
+	for _, model := range models {
+		resp.JoinTokens = append(resp.JoinTokens, modelToJoinToken(model))
+	}
+	return resp, nil
+}
+
 func deleteJoinToken(tx *gorm.DB, token string) error {
 	var model JoinToken
 	if err := tx.Find(&model, "token = ?", token).Error; err != nil {
@@ -4762,6 +5185,7 @@ func modelToEntry(tx *gorm.DB, model RegisteredEntry) (*common.RegistrationEntry
 		Admin:                model.Admin,
 		Downstream:           model.Downstream,
 		EntryExpiry:          model.Expiry,
+		NotBefore:            model.NotBefore,
 		DnsNames:             dnsList,
 		RevisionNumber:       model.RevisionNumber,
 		StoreSvid:            model.StoreSvid,
@@ -5017,6 +5441,38 @@ func fetchCAJournal(tx *gorm.DB, activeX509AuthorityID string) (*datastore.CAJou
 	return modelToCAJournal(model), nil
 }
 
+func listCAJournals(tx *gorm.DB, req *datastore.ListCAJournalsRequest) (*datastore.ListCAJournalsResponse, error) {
+	p := req.Pagination
+	var err error
+	if p != nil {
+		tx, err = applyPagination(p, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var models []CAJournal
+	if err := tx.Find(&models).Error; err != nil {
+		return nil, sqlcommon.NewWrappedSQLError(err)
+	}
+
+	if p != nil {
+		p.Token = ""
+		if len(models) > 0 {
+			p.Token = fmt.Sprint(models[len(models)-1].ID)
+		}
+	}
+
+	resp := &datastore.ListCAJournalsResponse{
+		CAJournals: make([]*datastore.CAJournal, 0, len(models)),
+		Pagination: p,
+	}
+	for _, model := range models {
+		resp.CAJournals = append(resp.CAJournals, modelToCAJournal(model))
+	}
+	return resp, nil
+}
+
 func listCAJournalsForTesting(tx *gorm.DB) (caJournals []*datastore.CAJournal, err error) {
 	var caJournalsModel []CAJournal
 	if err := tx.Find(&caJournalsModel).Error; err != nil {
@@ -5029,6 +5485,86 @@ func listCAJournalsForTesting(tx *gorm.DB) (caJournals []*datastore.CAJournal, e
 	return caJournals, nil
 }
 
+func exportRecords(tx *gorm.DB, fn func(*datastore.ExportRecord) error) error {
+	if err := forEachModel(tx, func(model Bundle) error {
+		bundle, err := modelToBundle(&model)
+		if err != nil {
+			return err
+		}
+		return fn(&datastore.ExportRecord{Bundle: bundle})
+	}); err != nil {
+		return err
+	}
+
+	if err := forEachModel(tx, func(model FederatedTrustDomain) error {
+		fr, err := modelToFederationRelationship(tx, &model)
+		if err != nil {
+			return err
+		}
+		return fn(&datastore.ExportRecord{FederationRelationship: fr})
+	}); err != nil {
+		return err
+	}
+
+	if err := forEachModel(tx, func(model RegisteredEntry) error {
+		entry, err := modelToEntry(tx, model)
+		if err != nil {
+			return err
+		}
+		return fn(&datastore.ExportRecord{RegistrationEntry: entry})
+	}); err != nil {
+		return err
+	}
+
+	if err := forEachModel(tx, func(model AttestedNode) error {
+		var selectors []NodeSelector
+		if err := tx.Where("spiffe_id = ?", model.SpiffeID).Order("id").Find(&selectors).Error; err != nil {
+			return sqlcommon.NewWrappedSQLError(err)
+		}
+		node := modelToAttestedNode(model)
+		for _, selector := range selectors {
+			node.Selectors = append(node.Selectors, &common.Selector{
+				Type:  selector.Type,
+				Value: selector.Value,
+			})
+		}
+		return fn(&datastore.ExportRecord{AttestedNode: node})
+	}); err != nil {
+		return err
+	}
+
+	if err := forEachModel(tx, func(model JoinToken) error {
+		return fn(&datastore.ExportRecord{JoinToken: modelToJoinToken(model)})
+	}); err != nil {
+		return err
+	}
+
+	return forEachModel(tx, func(model CAJournal) error {
+		return fn(&datastore.ExportRecord{CAJournal: modelToCAJournal(model)})
+	})
+}
+
+// forEachModel calls fn for every row of the table of model M, in ID order.
+// Rows are read in pages of exportPageSize rows.
+func forEachModel[M interface{ rowID() uint }](tx *gorm.DB, fn func(model M) error) error {
+	var lastID uint
+	for {
+		var models []M
+		if err := tx.Where("id > ?", lastID).Order("id asc").Limit(exportPageSize).Find(&models).Error; err != nil {
+			return sqlcommon.NewWrappedSQLError(err)
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		for _, model := range models {
+			if err := fn(model); err != nil {
+				return err
+			}
+		}
+		lastID = models[len(models)-1].rowID()
+	}
+}
+
 func updateCAJournal(tx *gorm.DB, caJournal *datastore.CAJournal) (*datastore.CAJournal, error) {
 	var model CAJournal
 	if err := tx.Find(&model, "id = ?", caJournal.ID).Error; err != nil {
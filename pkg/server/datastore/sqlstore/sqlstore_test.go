@@ -30,7 +30,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/pkg/server/datastore/sqlcommon"
-	"github.com/spiffe/spire/proto/private/server/journal"
+	datastoretest "github.com/spiffe/spire/pkg/server/datastore/test"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/spiretest"
@@ -65,6 +65,14 @@ func TestPlugin(t *testing.T) {
 	spiretest.Run(t, new(PluginSuite))
 }
 
+func TestConformance(t *testing.T) {
+	datastoretest.Test(t, datastoretest.Config{
+		Create: func(t *testing.T) datastore.DataStore {
+			return newConformancePlugin(t)
+		},
+	})
+}
+
 type PluginSuite struct {
 	spiretest.Suite
 
@@ -179,6 +187,47 @@ func (s *PluginSuite) newPlugin() *Plugin {
 	return ds
 }
 
+// newConformancePlugin returns a configured plugin for the DataStore
+// conformance suite, backed by the same database as the PluginSuite.
+func newConformancePlugin(t *testing.T) *Plugin {
+	log, _ := test.NewNullLogger()
+	ds := New(log)
+
+	var config string
+	switch TestDialect {
+	case "":
+		dbPath := filepath.ToSlash(filepath.Join(t.TempDir(), "db.sqlite3"))
+		config = fmt.Sprintf(`
+			database_type = "sqlite3"
+			connection_string = "%s"
+		`, dbPath)
+	case "mysql":
+		require.NotEmpty(t, TestConnString, "connection string must be set")
+		wipeMySQL(t, TestConnString)
+		config = fmt.Sprintf(`
+			database_type = "mysql"
+			connection_string = "%s"
+			ro_connection_string = "%s"
+		`, TestConnString, TestROConnString)
+	case "postgres":
+		require.NotEmpty(t, TestConnString, "connection string must be set")
+		wipePostgres(t, TestConnString)
+		config = fmt.Sprintf(`
+			database_type = "postgres"
+			connection_string = "%s"
+			ro_connection_string = "%s"
+		`, TestConnString, TestROConnString)
+	default:
+		require.FailNowf(t, "Unsupported external test dialect", "%q", TestDialect)
+	}
+
+	require.NoError(t, ds.Configure(ctx, config))
+	t.Cleanup(func() {
+		ds.Close()
+	})
+	return ds
+}
+
 func (s *PluginSuite) TestInvalidPluginConfiguration() {
 	err := s.ds.Configure(ctx, `
 		database_type = "wrong"
@@ -471,111 +520,6 @@ func (s *PluginSuite) TestListBundlesWithPagination() {
 	}
 }
 
-func (s *PluginSuite) TestCountBundles() {
-	// Count empty bundles
-	count, err := s.ds.CountBundles(ctx)
-	s.Require().NoError(err)
-	s.Require().Equal(int32(0), count)
-
-	// Create bundles
-	bundle1 := bundleutil.BundleProtoFromRootCA("spiffe://example.org", s.cert)
-	_, err = s.ds.CreateBundle(ctx, bundle1)
-	s.Require().NoError(err)
-
-	bundle2 := bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cacert)
-	_, err = s.ds.CreateBundle(ctx, bundle2)
-	s.Require().NoError(err)
-
-	bundle3 := bundleutil.BundleProtoFromRootCA("spiffe://bar", s.cert)
-	_, err = s.ds.CreateBundle(ctx, bundle3)
-	s.Require().NoError(err)
-
-	// Count all
-	count, err = s.ds.CountBundles(ctx)
-	s.Require().NoError(err)
-	s.Require().Equal(int32(3), count)
-}
-
-func (s *PluginSuite) TestCountAttestedNodes() {
-	// Count empty attested nodes
-	count, err := s.ds.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{})
-	s.Require().NoError(err)
-	s.Require().Equal(int32(0), count)
-
-	// Create attested nodes
-	node := &common.AttestedNode{
-		SpiffeId:            "spiffe://example.org/foo",
-		AttestationDataType: "t1",
-		CertSerialNumber:    "1234",
-		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
-	}
-	_, err = s.ds.CreateAttestedNode(ctx, node)
-	s.Require().NoError(err)
-
-	node2 := &common.AttestedNode{
-		SpiffeId:            "spiffe://example.org/bar",
-		AttestationDataType: "t2",
-		CertSerialNumber:    "5678",
-		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
-	}
-	_, err = s.ds.CreateAttestedNode(ctx, node2)
-	s.Require().NoError(err)
-
-	// Count all
-	count, err = s.ds.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{})
-	s.Require().NoError(err)
-	s.Require().Equal(int32(2), count)
-}
-
-func (s *PluginSuite) TestCountRegistrationEntries() {
-	// Count empty registration entries
-	count, err := s.ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
-	s.Require().NoError(err)
-	s.Require().Equal(int32(0), count)
-
-	// Create attested nodes
-	entry := &common.RegistrationEntry{
-		ParentId:  "spiffe://example.org/agent",
-		SpiffeId:  "spiffe://example.org/foo",
-		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
-	}
-	_, err = s.ds.CreateRegistrationEntry(ctx, entry)
-	s.Require().NoError(err)
-
-	entry2 := &common.RegistrationEntry{
-		ParentId:  "spiffe://example.org/agent",
-		SpiffeId:  "spiffe://example.org/bar",
-		Selectors: []*common.Selector{{Type: "a", Value: "2"}},
-	}
-	_, err = s.ds.CreateRegistrationEntry(ctx, entry2)
-	s.Require().NoError(err)
-
-	// Count all
-	count, err = s.ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
-	s.Require().NoError(err)
-	s.Require().Equal(int32(2), count)
-}
-
-func (s *PluginSuite) TestSetBundle() {
-	// create a couple of bundles for tests. the contents don't really matter
-	// as long as they are for the same trust domain but have different contents.
-	bundle := bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cert)
-	bundle2 := bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cacert)
-
-	// ensure the bundle does not exist (it shouldn't)
-	s.Require().Nil(s.fetchBundle("spiffe://foo"))
-
-	// set the bundle and make sure it is created
-	_, err := s.ds.SetBundle(ctx, bundle)
-	s.Require().NoError(err)
-	s.RequireProtoEqual(bundle, s.fetchBundle("spiffe://foo"))
-
-	// set the bundle and make sure it is updated
-	_, err = s.ds.SetBundle(ctx, bundle2)
-	s.Require().NoError(err)
-	s.RequireProtoEqual(bundle2, s.fetchBundle("spiffe://foo"))
-}
-
 func (s *PluginSuite) TestBundlePrune() {
 	// Setup
 	// Create new bundle with two cert (one valid and one expired)
@@ -908,29 +852,6 @@ func (s *PluginSuite) TestRevokeJWTKey() {
 	validateBundle(expectedJWTKeys, 1)
 }
 
-func (s *PluginSuite) TestCreateAttestedNode() {
-	node := &common.AttestedNode{
-		SpiffeId:            "foo",
-		AttestationDataType: "aws-tag",
-		CertSerialNumber:    "badcafe",
-		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
-	}
-
-	attestedNode, err := s.ds.CreateAttestedNode(ctx, node)
-	s.Require().NoError(err)
-	s.AssertProtoEqual(node, attestedNode)
-
-	attestedNode, err = s.ds.FetchAttestedNode(ctx, node.SpiffeId)
-	s.Require().NoError(err)
-	s.AssertProtoEqual(node, attestedNode)
-}
-
-func (s *PluginSuite) TestFetchAttestedNodeMissing() {
-	attestedNode, err := s.ds.FetchAttestedNode(ctx, "missing")
-	s.Require().NoError(err)
-	s.Require().Nil(attestedNode)
-}
-
 func (s *PluginSuite) TestFetchAttestedNodes() {
 	createNode := func(spiffeID string, selectors []*common.Selector) *common.AttestedNode {
 		node, err := s.ds.CreateAttestedNode(ctx, &common.AttestedNode{
@@ -2157,69 +2078,6 @@ func (s *PluginSuite) TestPruneAttestedNodeEvents() {
 	}
 }
 
-func (s *PluginSuite) TestNodeSelectors() {
-	foo1 := []*common.Selector{
-		{Type: "FOO1", Value: "1"},
-	}
-	foo2 := []*common.Selector{
-		{Type: "FOO2", Value: "1"},
-	}
-	bar := []*common.Selector{
-		{Type: "BAR", Value: "FIGHT"},
-	}
-
-	// assert there are no selectors for foo
-	selectors := s.getNodeSelectors("foo")
-	s.Require().Empty(selectors)
-	s.EventuallyWithT(func(collect *assert.CollectT) {
-		selectors, err := s.ds.GetNodeSelectors(ctx, "foo", datastore.TolerateStale)
-		require.NoError(collect, err)
-		assert.Len(collect, selectors, 0)
-	}, time.Second, 10*time.Millisecond)
-
-	// set selectors on foo and bar
-	s.setNodeSelectors("foo", foo1)
-	s.setNodeSelectors("bar", bar)
-
-	// get foo selectors
-	selectors = s.getNodeSelectors("foo")
-	s.RequireProtoListEqual(foo1, selectors)
-	s.EventuallyWithT(func(collect *assert.CollectT) {
-		selectors, err := s.ds.GetNodeSelectors(ctx, "foo", datastore.TolerateStale)
-		require.NoError(collect, err)
-		assert.True(collect, spiretest.CheckProtoListEqual(s.T(), foo1, selectors))
-	}, time.Second, 10*time.Millisecond)
-
-	// replace foo selectors
-	s.setNodeSelectors("foo", foo2)
-	selectors = s.getNodeSelectors("foo")
-	s.RequireProtoListEqual(foo2, selectors)
-	s.EventuallyWithT(func(collect *assert.CollectT) {
-		selectors, err := s.ds.GetNodeSelectors(ctx, "foo", datastore.TolerateStale)
-		require.NoError(collect, err)
-		assert.True(collect, spiretest.CheckProtoListEqual(s.T(), foo2, selectors))
-	}, time.Second, 10*time.Millisecond)
-
-	// delete foo selectors
-	s.setNodeSelectors("foo", []*common.Selector{})
-	selectors = s.getNodeSelectors("foo")
-	s.Require().Empty(selectors)
-	s.EventuallyWithT(func(collect *assert.CollectT) {
-		selectors, err := s.ds.GetNodeSelectors(ctx, "foo", datastore.TolerateStale)
-		require.NoError(collect, err)
-		assert.Len(collect, selectors, 0)
-	}, time.Second, 10*time.Millisecond)
-
-	// get bar selectors (make sure they weren't impacted by deleting foo)
-	selectors = s.getNodeSelectors("bar")
-	s.RequireProtoListEqual(bar, selectors)
-	s.EventuallyWithT(func(collect *assert.CollectT) {
-		selectors, err := s.ds.GetNodeSelectors(ctx, "bar", datastore.TolerateStale)
-		require.NoError(collect, err)
-		assert.True(collect, spiretest.CheckProtoListEqual(s.T(), bar, selectors))
-	}, time.Second, 10*time.Millisecond)
-}
-
 func (s *PluginSuite) TestListNodeSelectors() {
 	s.T().Run("no selectors exist", func(t *testing.T) {
 		req := &datastore.ListNodeSelectorsRequest{}
@@ -2593,12 +2451,6 @@ func (s *PluginSuite) TestFetchRegistrationEntry() {
 	}
 }
 
-func (s *PluginSuite) TestFetchRegistrationEntryDoesNotExist() {
-	fetchRegistrationEntry, err := s.ds.FetchRegistrationEntry(ctx, "does-not-exist")
-	s.Require().NoError(err)
-	s.Require().Nil(fetchRegistrationEntry)
-}
-
 func (s *PluginSuite) TestFetchRegistrationEntries() {
 	entry1, err := s.ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
 		Selectors: []*common.Selector{
@@ -2786,12 +2638,6 @@ func (s *PluginSuite) TestPruneRegistrationEntries() {
 	}
 }
 
-func (s *PluginSuite) TestFetchInexistentRegistrationEntry() {
-	fetchedRegistrationEntry, err := s.ds.FetchRegistrationEntry(ctx, "INEXISTENT")
-	s.Require().NoError(err)
-	s.Require().Nil(fetchedRegistrationEntry)
-}
-
 func (s *PluginSuite) TestListRegistrationEntries() {
 	// Connection is never used, each test creates new connection to a different database
 	s.ds.Close()
@@ -3660,6 +3506,7 @@ func (s *PluginSuite) TestUpdateRegistrationEntryWithMask() {
 		FederatesWith: []string{"spiffe://dom2.org"},
 		Admin:         false,
 		EntryExpiry:   1000,
+		NotBefore:     500,
 		DnsNames:      []string{"dns2"},
 		Downstream:    false,
 		StoreSvid:     true,
@@ -3867,6 +3714,19 @@ func (s *PluginSuite) TestUpdateRegistrationEntryWithMask() {
 			update: func(e *common.RegistrationEntry) { e.EntryExpiry = newEntry.EntryExpiry },
 			result: func(e *common.RegistrationEntry) {},
 		},
+		// NOTBEFORE FIELD -- This field isn't validated so we just check with good data
+		{
+			name:   "Update NotBefore, Good Data, Mask True",
+			mask:   &common.RegistrationEntryMask{NotBefore: true},
+			update: func(e *common.RegistrationEntry) { e.NotBefore = newEntry.NotBefore },
+			result: func(e *common.RegistrationEntry) { e.NotBefore = newEntry.NotBefore },
+		},
+		{
+			name:   "Update NotBefore, Good Data, Mask False",
+			mask:   &common.RegistrationEntryMask{NotBefore: false},
+			update: func(e *common.RegistrationEntry) { e.NotBefore = newEntry.NotBefore },
+			result: func(e *common.RegistrationEntry) {},
+		},
 		// DNSNAMES FIELD -- This field isn't validated so we just check with good data
 		{
 			name:   "Update DnsNames, Good Data, Mask True",
@@ -4805,103 +4665,6 @@ func (s *PluginSuite) TestPruneRegistrationEntryEvents() {
 	}
 }
 
-func (s *PluginSuite) TestCreateJoinToken() {
-	req := &datastore.JoinToken{
-		Token:  "foobar",
-		Expiry: time.Now().Truncate(time.Second),
-	}
-	err := s.ds.CreateJoinToken(ctx, req)
-	s.Require().NoError(err)
-
-	// Make sure we can't re-register
-	err = s.ds.CreateJoinToken(ctx, req)
-	s.NotNil(err)
-}
-
-func (s *PluginSuite) TestCreateAndFetchJoinToken() {
-	now := time.Now().Truncate(time.Second)
-	joinToken := &datastore.JoinToken{
-		Token:  "foobar",
-		Expiry: now,
-	}
-
-	err := s.ds.CreateJoinToken(ctx, joinToken)
-	s.Require().NoError(err)
-
-	res, err := s.ds.FetchJoinToken(ctx, joinToken.Token)
-	s.Require().NoError(err)
-	s.Equal("foobar", res.Token)
-	s.Equal(now, res.Expiry)
-}
-
-func (s *PluginSuite) TestDeleteJoinToken() {
-	now := time.Now().Truncate(time.Second)
-	joinToken1 := &datastore.JoinToken{
-		Token:  "foobar",
-		Expiry: now,
-	}
-
-	err := s.ds.CreateJoinToken(ctx, joinToken1)
-	s.Require().NoError(err)
-
-	joinToken2 := &datastore.JoinToken{
-		Token:  "batbaz",
-		Expiry: now,
-	}
-
-	err = s.ds.CreateJoinToken(ctx, joinToken2)
-	s.Require().NoError(err)
-
-	err = s.ds.DeleteJoinToken(ctx, joinToken1.Token)
-	s.Require().NoError(err)
-
-	// Should not be able to fetch after delete
-	resp, err := s.ds.FetchJoinToken(ctx, joinToken1.Token)
-	s.Require().NoError(err)
-	s.Nil(resp)
-
-	// Second token should still be present
-	resp, err = s.ds.FetchJoinToken(ctx, joinToken2.Token)
-	s.Require().NoError(err)
-	s.Equal(joinToken2, resp)
-}
-
-func (s *PluginSuite) TestPruneJoinTokens() {
-	now := time.Now().Truncate(time.Second)
-	joinToken := &datastore.JoinToken{
-		Token:  "foobar",
-		Expiry: now,
-	}
-
-	err := s.ds.CreateJoinToken(ctx, joinToken)
-	s.Require().NoError(err)
-
-	// Ensure we don't prune valid tokens, wind clock back 10s
-	err = s.ds.PruneJoinTokens(ctx, now.Add(-time.Second*10))
-	s.Require().NoError(err)
-
-	resp, err := s.ds.FetchJoinToken(ctx, joinToken.Token)
-	s.Require().NoError(err)
-	s.Equal("foobar", resp.Token)
-
-	// Ensure we don't prune on the exact ExpiresBefore
-	err = s.ds.PruneJoinTokens(ctx, now)
-	s.Require().NoError(err)
-
-	resp, err = s.ds.FetchJoinToken(ctx, joinToken.Token)
-	s.Require().NoError(err)
-	s.Require().NotNil(resp, "token was unexpectedly pruned")
-	s.Equal("foobar", resp.Token)
-
-	// Ensure we prune old tokens
-	err = s.ds.PruneJoinTokens(ctx, now.Add(time.Second*10))
-	s.Require().NoError(err)
-
-	resp, err = s.ds.FetchJoinToken(ctx, joinToken.Token)
-	s.Require().NoError(err)
-	s.Nil(resp)
-}
-
 func (s *PluginSuite) TestDeleteFederationRelationship() {
 	testCases := []struct {
 		name        string
@@ -5598,6 +5361,12 @@ func (s *PluginSuite) TestMigration() {
 			case 24:
 				// Migration from v24 to v25 adds additional_attributes column
 				prepareDB(true)
+			case 25:
+				// Migration from v25 to v26 adds registered_entries_changes table
+				prepareDB(true)
+			case 26:
+				// Migration from v26 to v27 adds not_before column to registered_entries
+				prepareDB(true)
 			default:
 				t.Fatalf("no migration test added for schema version %d", schemaVersion)
 			}
@@ -5729,55 +5498,6 @@ func (s *PluginSuite) TestFetchCAJournal() {
 	}
 }
 
-func (s *PluginSuite) TestPruneCAJournal() {
-	now := time.Now()
-	t := now.Add(time.Hour)
-	entries := &journal.Entries{
-		X509CAs: []*journal.X509CAEntry{
-			{
-				NotAfter: t.Add(-time.Hour * 6).Unix(),
-			},
-		},
-		JwtKeys: []*journal.JWTKeyEntry{
-			{
-				NotAfter: t.Add(time.Hour * 6).Unix(),
-			},
-		},
-	}
-
-	entriesBytes, err := proto.Marshal(entries)
-	s.Require().NoError(err)
-
-	// Store CA journal in datastore
-	caJournal, err := s.ds.SetCAJournal(ctx, &datastore.CAJournal{
-		ActiveX509AuthorityID: "x509-authority-1",
-		Data:                  entriesBytes,
-	})
-	s.Require().NoError(err)
-
-	// Run a PruneCAJournals operation specifying a time that is before the
-	// expiration of all the authorities. The CA journal should not be pruned.
-	s.Require().NoError(s.ds.PruneCAJournals(ctx, t.Add(-time.Hour*12).Unix()))
-	caj, err := s.ds.FetchCAJournal(ctx, "x509-authority-1")
-	s.Require().NoError(err)
-	s.Require().Equal(caJournal, caj)
-
-	// Run a PruneCAJournals operation specifying a time that is before the
-	// expiration of one of the authorities, but not all the authorities.
-	// The CA journal should not be pruned.
-	s.Require().NoError(s.ds.PruneCAJournals(ctx, t.Unix()))
-	caj, err = s.ds.FetchCAJournal(ctx, "x509-authority-1")
-	s.Require().NoError(err)
-	s.Require().Equal(caJournal, caj)
-
-	// Run a PruneCAJournals operation specifying a time that is after the
-	// expiration of all the authorities. The CA journal should be pruned.
-	s.Require().NoError(s.ds.PruneCAJournals(ctx, t.Add(time.Hour*12).Unix()))
-	caj, err = s.ds.FetchCAJournal(ctx, "x509-authority-1")
-	s.Require().NoError(err)
-	s.Require().Nil(caj)
-}
-
 func (s *PluginSuite) TestBuildQuestionsAndPlaceholders() {
 	for _, tt := range []struct {
 		name                 string
@@ -5820,12 +5540,6 @@ func (s *PluginSuite) getTestDataFromJSONFile(filePath string, jsonValue any) {
 	s.Require().NoError(err)
 }
 
-func (s *PluginSuite) fetchBundle(trustDomainID string) *common.Bundle {
-	bundle, err := s.ds.FetchBundle(ctx, trustDomainID)
-	s.Require().NoError(err)
-	return bundle
-}
-
 func (s *PluginSuite) createBundle(trustDomainID string) *common.Bundle {
 	bundle, err := s.ds.CreateBundle(ctx, bundleutil.BundleProtoFromRootCA(trustDomainID, s.cert))
 	s.Require().NoError(err)
@@ -5861,12 +5575,6 @@ func makeFederatedRegistrationEntry() *common.RegistrationEntry {
 	}
 }
 
-func (s *PluginSuite) getNodeSelectors(spiffeID string) []*common.Selector {
-	selectors, err := s.ds.GetNodeSelectors(ctx, spiffeID, datastore.RequireCurrent)
-	s.Require().NoError(err)
-	return selectors
-}
-
 func (s *PluginSuite) listNodeSelectors(req *datastore.ListNodeSelectorsRequest) *datastore.ListNodeSelectorsResponse {
 	resp, err := s.ds.ListNodeSelectors(ctx, req)
 	s.Require().NoError(err)
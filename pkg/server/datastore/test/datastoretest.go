@@ -0,0 +1,1380 @@
+package datastoretest
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/private/server/journal"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/spiretest"
+	testutil "github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var ctx = context.Background()
+
+// CreateFunc returns a new, empty DataStore for a single test. The DataStore
+// is expected to be cleaned up by the function (e.g. via t.Cleanup).
+type CreateFunc = func(t *testing.T) datastore.DataStore
+
+type Config struct {
+	Create CreateFunc
+}
+
+// Test runs the DataStore conformance suite against the DataStore
+// implementations returned by the Create function. It only exercises the
+// behavior defined by the DataStore interface, so it can be run against any
+// implementation, built-in or external.
+func Test(t *testing.T, config Config) {
+	certs := newTestCerts(t)
+
+	t.Run("bundles", func(t *testing.T) {
+		testBundles(t, config, certs)
+	})
+	t.Run("attested nodes", func(t *testing.T) {
+		testAttestedNodes(t, config)
+	})
+	t.Run("node selectors", func(t *testing.T) {
+		testNodeSelectors(t, config)
+	})
+	t.Run("registration entries", func(t *testing.T) {
+		testRegistrationEntries(t, config)
+	})
+	t.Run("join tokens", func(t *testing.T) {
+		testJoinTokens(t, config)
+	})
+	t.Run("federation relationships", func(t *testing.T) {
+		testFederationRelationships(t, config, certs)
+	})
+	t.Run("CA journals", func(t *testing.T) {
+		testCAJournals(t, config)
+	})
+	t.Run("export", func(t *testing.T) {
+		testExport(t, config, certs)
+	})
+}
+
+type testCerts struct {
+	cert   *x509.Certificate
+	cacert *x509.Certificate
+}
+
+func newTestCerts(t *testing.T) testCerts {
+	clk := clock.NewMock(t)
+	notAfter := clk.Now().Add(time.Hour)
+
+	caTemplate, err := testutil.NewCATemplate(clk, spiffeid.RequireTrustDomainFromString("foo"))
+	require.NoError(t, err)
+	caTemplate.NotAfter = notAfter
+	caTemplate.NotBefore = notAfter.Add(-time.Hour)
+	cacert, cakey, err := testutil.SelfSign(caTemplate)
+	require.NoError(t, err)
+
+	svidTemplate, err := testutil.NewSVIDTemplate(clk, "spiffe://foo/id1")
+	require.NoError(t, err)
+	svidTemplate.NotAfter = notAfter
+	svidTemplate.NotBefore = notAfter.Add(-time.Hour)
+	cert, _, err := testutil.Sign(svidTemplate, cacert, cakey)
+	require.NoError(t, err)
+
+	return testCerts{cert: cert, cacert: cacert}
+}
+
+func testBundles(t *testing.T, config Config, certs testCerts) {
+	t.Run("CRUD", func(t *testing.T) {
+		ds := config.Create(t)
+		bundle := bundleutil.BundleProtoFromRootCA("spiffe://foo", certs.cert)
+
+		// fetch non-existent
+		fb, err := ds.FetchBundle(ctx, "spiffe://foo")
+		require.NoError(t, err)
+		require.Nil(t, fb)
+
+		// update non-existent
+		_, err = ds.UpdateBundle(ctx, bundle, nil)
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		// delete non-existent
+		err = ds.DeleteBundle(ctx, "spiffe://foo", datastore.Restrict)
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		// create
+		_, err = ds.CreateBundle(ctx, bundle)
+		require.NoError(t, err)
+
+		// create again (constraint violation)
+		_, err = ds.CreateBundle(ctx, bundle)
+		require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+		// fetch
+		fb, err = ds.FetchBundle(ctx, "spiffe://foo")
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, bundle, fb)
+
+		// append
+		appendedBundle := bundleutil.BundleProtoFromRootCAs(bundle.TrustDomainId,
+			[]*x509.Certificate{certs.cert, certs.cacert})
+		appendedBundle.SequenceNumber++
+		ab, err := ds.AppendBundle(ctx, bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, certs.cacert))
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, appendedBundle, ab)
+
+		// append on a new bundle
+		bundle2 := bundleutil.BundleProtoFromRootCA("spiffe://bar", certs.cacert)
+		ab, err = ds.AppendBundle(ctx, bundle2)
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, bundle2, ab)
+
+		// update with mask
+		appendedBundle.RefreshHint = 60
+		updatedBundle, err := ds.UpdateBundle(ctx, appendedBundle, &common.BundleMask{
+			RefreshHint: true,
+		})
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, appendedBundle, updatedBundle)
+
+		// list
+		resp, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+		require.NoError(t, err)
+		spiretest.RequireProtoListEqual(t, []*common.Bundle{bundle2, appendedBundle}, sortBundles(resp.Bundles))
+
+		count, err := ds.CountBundles(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int32(2), count)
+
+		// delete
+		err = ds.DeleteBundle(ctx, "spiffe://foo", datastore.Restrict)
+		require.NoError(t, err)
+		fb, err = ds.FetchBundle(ctx, "spiffe://foo")
+		require.NoError(t, err)
+		require.Nil(t, fb)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		ds := config.Create(t)
+		bundle := bundleutil.BundleProtoFromRootCA("spiffe://foo", certs.cert)
+		bundle2 := bundleutil.BundleProtoFromRootCA("spiffe://foo", certs.cacert)
+
+		// set the bundle and make sure it is created
+		_, err := ds.SetBundle(ctx, bundle)
+		require.NoError(t, err)
+		fb, err := ds.FetchBundle(ctx, "spiffe://foo")
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, bundle, fb)
+
+		// set the bundle and make sure it is updated
+		_, err = ds.SetBundle(ctx, bundle2)
+		require.NoError(t, err)
+		fb, err = ds.FetchBundle(ctx, "spiffe://foo")
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, bundle2, fb)
+	})
+
+	t.Run("list with pagination", func(t *testing.T) {
+		ds := config.Create(t)
+		var expected []*common.Bundle
+		for _, td := range []string{"spiffe://a", "spiffe://b", "spiffe://c"} {
+			bundle, err := ds.CreateBundle(ctx, bundleutil.BundleProtoFromRootCA(td, certs.cert))
+			require.NoError(t, err)
+			expected = append(expected, bundle)
+		}
+
+		var actual []*common.Bundle
+		pagination := &datastore.Pagination{PageSize: 2}
+		for {
+			resp, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{Pagination: pagination})
+			require.NoError(t, err)
+			require.NotNil(t, resp.Pagination)
+			require.LessOrEqual(t, len(resp.Bundles), 2)
+			actual = append(actual, resp.Bundles...)
+			if resp.Pagination.Token == "" || len(resp.Bundles) == 0 {
+				break
+			}
+			pagination = resp.Pagination
+		}
+		spiretest.RequireProtoListEqual(t, expected, sortBundles(actual))
+	})
+
+	t.Run("delete restricted by registration entries", func(t *testing.T) {
+		ds := config.Create(t)
+		_, err := ds.CreateBundle(ctx, bundleutil.BundleProtoFromRootCA("spiffe://otherdomain.org", certs.cert))
+		require.NoError(t, err)
+		entry, err := ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+			SpiffeId:      "spiffe://example.org/foo",
+			ParentId:      "spiffe://example.org/bar",
+			Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+			FederatesWith: []string{"spiffe://otherdomain.org"},
+		})
+		require.NoError(t, err)
+
+		err = ds.DeleteBundle(ctx, "spiffe://otherdomain.org", datastore.Restrict)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		err = ds.DeleteBundle(ctx, "spiffe://otherdomain.org", datastore.Dissociate)
+		require.NoError(t, err)
+		fetched, err := ds.FetchRegistrationEntry(ctx, entry.EntryId)
+		require.NoError(t, err)
+		require.NotNil(t, fetched)
+		require.Empty(t, fetched.FederatesWith)
+	})
+
+	t.Run("taint and revoke X.509 authority", func(t *testing.T) {
+		ds := config.Create(t)
+		_, err := ds.CreateBundle(ctx, bundleutil.BundleProtoFromRootCAs("spiffe://foo",
+			[]*x509.Certificate{certs.cert, certs.cacert}))
+		require.NoError(t, err)
+
+		subjectKeyID := x509util.SubjectKeyIDToString(certs.cert.SubjectKeyId)
+
+		// Revoking an untainted authority fails
+		err = ds.RevokeX509CA(ctx, "spiffe://foo", subjectKeyID)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		// Tainting an unknown authority fails
+		err = ds.TaintX509CA(ctx, "spiffe://foo", "unknown")
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		require.NoError(t, ds.TaintX509CA(ctx, "spiffe://foo", subjectKeyID))
+
+		// Tainting twice fails
+		err = ds.TaintX509CA(ctx, "spiffe://foo", subjectKeyID)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		require.NoError(t, ds.RevokeX509CA(ctx, "spiffe://foo", subjectKeyID))
+
+		fb, err := ds.FetchBundle(ctx, "spiffe://foo")
+		require.NoError(t, err)
+		require.Len(t, fb.RootCas, 1)
+		require.Equal(t, certs.cacert.Raw, fb.RootCas[0].DerBytes)
+	})
+
+	t.Run("taint and revoke JWT authority", func(t *testing.T) {
+		ds := config.Create(t)
+		bundle := bundleutil.BundleProtoFromRootCA("spiffe://foo", certs.cert)
+		bundle.JwtSigningKeys = []*common.PublicKey{{Kid: "key1"}, {Kid: "key2"}}
+		_, err := ds.CreateBundle(ctx, bundle)
+		require.NoError(t, err)
+
+		// Revoking an untainted key fails
+		_, err = ds.RevokeJWTKey(ctx, "spiffe://foo", "key2")
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		publicKey, err := ds.TaintJWTKey(ctx, "spiffe://foo", "key2")
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, &common.PublicKey{Kid: "key2", TaintedKey: true}, publicKey)
+
+		publicKey, err = ds.RevokeJWTKey(ctx, "spiffe://foo", "key2")
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, &common.PublicKey{Kid: "key2", TaintedKey: true}, publicKey)
+
+		fb, err := ds.FetchBundle(ctx, "spiffe://foo")
+		require.NoError(t, err)
+		spiretest.RequireProtoListEqual(t, []*common.PublicKey{{Kid: "key1"}}, fb.JwtSigningKeys)
+	})
+}
+
+func testAttestedNodes(t *testing.T, config Config) {
+	t.Run("CRUD", func(t *testing.T) {
+		ds := config.Create(t)
+		node := &common.AttestedNode{
+			SpiffeId:            "spiffe://example.org/foo",
+			AttestationDataType: "aws-tag",
+			CertSerialNumber:    "badcafe",
+			CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+		}
+
+		// fetch non-existent
+		fetched, err := ds.FetchAttestedNode(ctx, node.SpiffeId)
+		require.NoError(t, err)
+		require.Nil(t, fetched)
+
+		created, err := ds.CreateAttestedNode(ctx, node)
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, node, created)
+
+		fetched, err = ds.FetchAttestedNode(ctx, node.SpiffeId)
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, node, fetched)
+
+		// update with mask
+		node.CertSerialNumber = "deadbeef"
+		node.AttestationDataType = "ignored"
+		updated, err := ds.UpdateAttestedNode(ctx, node, &common.AttestedNodeMask{CertSerialNumber: true})
+		require.NoError(t, err)
+		require.Equal(t, "deadbeef", updated.CertSerialNumber)
+		require.Equal(t, "aws-tag", updated.AttestationDataType)
+
+		// update non-existent
+		_, err = ds.UpdateAttestedNode(ctx, &common.AttestedNode{SpiffeId: "spiffe://example.org/missing"}, nil)
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		count, err := ds.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{})
+		require.NoError(t, err)
+		require.Equal(t, int32(1), count)
+
+		deleted, err := ds.DeleteAttestedNode(ctx, node.SpiffeId)
+		require.NoError(t, err)
+		require.Equal(t, node.SpiffeId, deleted.SpiffeId)
+
+		// delete again
+		_, err = ds.DeleteAttestedNode(ctx, node.SpiffeId)
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		fetched, err = ds.FetchAttestedNode(ctx, node.SpiffeId)
+		require.NoError(t, err)
+		require.Nil(t, fetched)
+	})
+
+	t.Run("fetch many", func(t *testing.T) {
+		ds := config.Create(t)
+		for _, id := range []string{"spiffe://example.org/a", "spiffe://example.org/b"} {
+			_, err := ds.CreateAttestedNode(ctx, &common.AttestedNode{
+				SpiffeId:            id,
+				AttestationDataType: "t",
+				CertSerialNumber:    "1234",
+				CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+			})
+			require.NoError(t, err)
+		}
+		require.NoError(t, ds.SetNodeSelectors(ctx, "spiffe://example.org/a", []*common.Selector{{Type: "a", Value: "1"}}))
+
+		nodes, err := ds.FetchAttestedNodes(ctx, []string{"spiffe://example.org/a", "spiffe://example.org/b", "spiffe://example.org/missing"})
+		require.NoError(t, err)
+		require.Len(t, nodes, 2)
+		require.Contains(t, nodes, "spiffe://example.org/b")
+		require.Contains(t, nodes, "spiffe://example.org/a")
+		spiretest.RequireProtoListEqual(t, []*common.Selector{{Type: "a", Value: "1"}}, nodes["spiffe://example.org/a"].Selectors)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		ds := config.Create(t)
+		for _, node := range []*common.AttestedNode{
+			{SpiffeId: "spiffe://example.org/a", AttestationDataType: "t1", CertSerialNumber: "1", CertNotAfter: time.Now().Add(time.Hour).Unix()},
+			{SpiffeId: "spiffe://example.org/b", AttestationDataType: "t2", CertSerialNumber: "2", CertNotAfter: time.Now().Add(time.Hour).Unix()},
+			{SpiffeId: "spiffe://example.org/c", AttestationDataType: "t1", CertSerialNumber: "3", CertNotAfter: time.Now().Add(-time.Hour).Unix()},
+		} {
+			_, err := ds.CreateAttestedNode(ctx, node)
+			require.NoError(t, err)
+		}
+
+		resp, err := ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{ByAttestationType: "t1"})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"spiffe://example.org/a", "spiffe://example.org/c"}, nodeIDs(resp.Nodes))
+
+		resp, err = ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{ByExpiresBefore: time.Now()})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"spiffe://example.org/c"}, nodeIDs(resp.Nodes))
+	})
+}
+
+func testNodeSelectors(t *testing.T, config Config) {
+	ds := config.Create(t)
+	foo1 := []*common.Selector{{Type: "FOO1", Value: "1"}}
+	foo2 := []*common.Selector{{Type: "FOO2", Value: "1"}}
+	bar := []*common.Selector{{Type: "BAR", Value: "FIGHT"}}
+
+	requireSelectors := func(spiffeID string, expected []*common.Selector) {
+		selectors, err := ds.GetNodeSelectors(ctx, spiffeID, datastore.RequireCurrent)
+		require.NoError(t, err)
+		if len(expected) == 0 {
+			require.Empty(t, selectors)
+			return
+		}
+		spiretest.RequireProtoListEqual(t, expected, selectors)
+	}
+
+	requireSelectors("foo", nil)
+
+	require.NoError(t, ds.SetNodeSelectors(ctx, "foo", foo1))
+	require.NoError(t, ds.SetNodeSelectors(ctx, "bar", bar))
+	requireSelectors("foo", foo1)
+
+	// replace foo selectors
+	require.NoError(t, ds.SetNodeSelectors(ctx, "foo", foo2))
+	requireSelectors("foo", foo2)
+
+	resp, err := ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Selectors, 2)
+	spiretest.RequireProtoListEqual(t, foo2, resp.Selectors["foo"])
+	spiretest.RequireProtoListEqual(t, bar, resp.Selectors["bar"])
+
+	// delete foo selectors
+	require.NoError(t, ds.SetNodeSelectors(ctx, "foo", []*common.Selector{}))
+	requireSelectors("foo", nil)
+
+	// make sure bar selectors weren't impacted by deleting foo
+	requireSelectors("bar", bar)
+}
+
+func testRegistrationEntries(t *testing.T, config Config) {
+	newEntry := func(spiffeID string, selectors ...*common.Selector) *common.RegistrationEntry {
+		return &common.RegistrationEntry{
+			SpiffeId:    spiffeID,
+			ParentId:    "spiffe://example.org/parent",
+			Selectors:   selectors,
+			X509SvidTtl: 1,
+			JwtSvidTtl:  1,
+		}
+	}
+
+	t.Run("CRUD", func(t *testing.T) {
+		ds := config.Create(t)
+		entry := newEntry("spiffe://example.org/foo", &common.Selector{Type: "a", Value: "1"})
+		entry.DnsNames = []string{"abcd.efg", "somehost"}
+		entry.Hint = "external"
+
+		// fetch non-existent
+		fetched, err := ds.FetchRegistrationEntry(ctx, "does-not-exist")
+		require.NoError(t, err)
+		require.Nil(t, fetched)
+
+		created, err := ds.CreateRegistrationEntry(ctx, entry)
+		require.NoError(t, err)
+		require.NotEmpty(t, created.EntryId)
+		require.NotZero(t, created.CreatedAt)
+		require.Equal(t, entry.SpiffeId, created.SpiffeId)
+		require.Equal(t, entry.DnsNames, created.DnsNames)
+		require.Equal(t, entry.Hint, created.Hint)
+
+		fetched, err = ds.FetchRegistrationEntry(ctx, created.EntryId)
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, created, fetched)
+
+		// update with mask
+		update := proto.Clone(created).(*common.RegistrationEntry)
+		update.X509SvidTtl = 60
+		update.Admin = true
+		updated, err := ds.UpdateRegistrationEntry(ctx, update, &common.RegistrationEntryMask{X509SvidTtl: true})
+		require.NoError(t, err)
+		require.Equal(t, int32(60), updated.X509SvidTtl)
+		require.False(t, updated.Admin)
+		require.Greater(t, updated.RevisionNumber, created.RevisionNumber)
+
+		// update non-existent
+		_, err = ds.UpdateRegistrationEntry(ctx, newEntry("spiffe://example.org/missing", &common.Selector{Type: "a", Value: "1"}), nil)
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		count, err := ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
+		require.NoError(t, err)
+		require.Equal(t, int32(1), count)
+
+		deleted, err := ds.DeleteRegistrationEntry(ctx, created.EntryId)
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, updated, deleted)
+
+		// delete again
+		_, err = ds.DeleteRegistrationEntry(ctx, created.EntryId)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("update masks credential profile separately", func(t *testing.T) {
+		ds := config.Create(t)
+		entry := newEntry("spiffe://example.org/foo", &common.Selector{Type: "a", Value: "1"})
+		entry.AdditionalAttributes = &common.RegistrationEntry_AdditionalAttributes{
+			DisableX509SvidPrefetch: true,
+			CredentialProfile:       &common.CredentialProfile{SubjectOrganization: []string{"org1"}},
+		}
+		created, err := ds.CreateRegistrationEntry(ctx, entry)
+		require.NoError(t, err)
+
+		// Updating the additional attributes keeps the credential profile
+		update := proto.Clone(created).(*common.RegistrationEntry)
+		update.AdditionalAttributes = &common.RegistrationEntry_AdditionalAttributes{JwtSvidIncludeJti: true}
+		updated, err := ds.UpdateRegistrationEntry(ctx, update, &common.RegistrationEntryMask{AdditionalAttributes: true})
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, &common.RegistrationEntry_AdditionalAttributes{
+			JwtSvidIncludeJti: true,
+			CredentialProfile: &common.CredentialProfile{SubjectOrganization: []string{"org1"}},
+		}, updated.AdditionalAttributes)
+
+		// Updating the credential profile keeps the other attributes
+		update = proto.Clone(updated).(*common.RegistrationEntry)
+		update.AdditionalAttributes = &common.RegistrationEntry_AdditionalAttributes{
+			CredentialProfile: &common.CredentialProfile{SubjectOrganization: []string{"org2"}},
+		}
+		updated, err = ds.UpdateRegistrationEntry(ctx, update, &common.RegistrationEntryMask{CredentialProfile: true})
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, &common.RegistrationEntry_AdditionalAttributes{
+			JwtSvidIncludeJti: true,
+			CredentialProfile: &common.CredentialProfile{SubjectOrganization: []string{"org2"}},
+		}, updated.AdditionalAttributes)
+
+		// Clearing the credential profile
+		update = proto.Clone(updated).(*common.RegistrationEntry)
+		update.AdditionalAttributes = nil
+		updated, err = ds.UpdateRegistrationEntry(ctx, update, &common.RegistrationEntryMask{CredentialProfile: true})
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, &common.RegistrationEntry_AdditionalAttributes{
+			JwtSvidIncludeJti: true,
+		}, updated.AdditionalAttributes)
+
+		fetched, err := ds.FetchRegistrationEntry(ctx, created.EntryId)
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, updated, fetched)
+	})
+
+	t.Run("create invalid", func(t *testing.T) {
+		ds := config.Create(t)
+		for _, entry := range []*common.RegistrationEntry{
+			nil,
+			newEntry("spiffe://example.org/foo"),
+			newEntry("", &common.Selector{Type: "a", Value: "1"}),
+		} {
+			_, err := ds.CreateRegistrationEntry(ctx, entry)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	t.Run("create or return", func(t *testing.T) {
+		ds := config.Create(t)
+		entry := newEntry("spiffe://example.org/foo", &common.Selector{Type: "a", Value: "1"})
+
+		created, exists, err := ds.CreateOrReturnRegistrationEntry(ctx, entry)
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		similar, exists, err := ds.CreateOrReturnRegistrationEntry(ctx, entry)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, created.EntryId, similar.EntryId)
+	})
+
+	t.Run("fetch many", func(t *testing.T) {
+		ds := config.Create(t)
+		entry1, err := ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/1", &common.Selector{Type: "a", Value: "1"}))
+		require.NoError(t, err)
+		entry2, err := ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/2", &common.Selector{Type: "a", Value: "2"}))
+		require.NoError(t, err)
+
+		entries, err := ds.FetchRegistrationEntries(ctx, []string{entry1.EntryId, entry2.EntryId, "does-not-exist"})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		spiretest.RequireProtoEqual(t, entry1, entries[entry1.EntryId])
+		spiretest.RequireProtoEqual(t, entry2, entries[entry2.EntryId])
+	})
+
+	t.Run("list", func(t *testing.T) {
+		ds := config.Create(t)
+		a1 := &common.Selector{Type: "a", Value: "1"}
+		b2 := &common.Selector{Type: "b", Value: "2"}
+		c3 := &common.Selector{Type: "c", Value: "3"}
+		entryA, err := ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/a", a1))
+		require.NoError(t, err)
+		entryAB, err := ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/ab", a1, b2))
+		require.NoError(t, err)
+		entryC := newEntry("spiffe://example.org/c", c3)
+		entryC.ParentId = "spiffe://example.org/other"
+		entryC, err = ds.CreateRegistrationEntry(ctx, entryC)
+		require.NoError(t, err)
+
+		for _, tt := range []struct {
+			name   string
+			req    *datastore.ListRegistrationEntriesRequest
+			expect []*common.RegistrationEntry
+		}{
+			{
+				name:   "all",
+				req:    &datastore.ListRegistrationEntriesRequest{},
+				expect: []*common.RegistrationEntry{entryA, entryAB, entryC},
+			},
+			{
+				name:   "by parent ID",
+				req:    &datastore.ListRegistrationEntriesRequest{ByParentID: "spiffe://example.org/other"},
+				expect: []*common.RegistrationEntry{entryC},
+			},
+			{
+				name:   "by SPIFFE ID",
+				req:    &datastore.ListRegistrationEntriesRequest{BySpiffeID: "spiffe://example.org/ab"},
+				expect: []*common.RegistrationEntry{entryAB},
+			},
+			{
+				name: "by selectors exact",
+				req: &datastore.ListRegistrationEntriesRequest{
+					BySelectors: &datastore.BySelectors{Selectors: []*common.Selector{a1}, Match: datastore.Exact},
+				},
+				expect: []*common.RegistrationEntry{entryA},
+			},
+			{
+				name: "by selectors subset",
+				req: &datastore.ListRegistrationEntriesRequest{
+					BySelectors: &datastore.BySelectors{Selectors: []*common.Selector{a1, b2}, Match: datastore.Subset},
+				},
+				expect: []*common.RegistrationEntry{entryA, entryAB},
+			},
+			{
+				name: "by selectors superset",
+				req: &datastore.ListRegistrationEntriesRequest{
+					BySelectors: &datastore.BySelectors{Selectors: []*common.Selector{a1}, Match: datastore.Superset},
+				},
+				expect: []*common.RegistrationEntry{entryA, entryAB},
+			},
+			{
+				name: "by selectors match any",
+				req: &datastore.ListRegistrationEntriesRequest{
+					BySelectors: &datastore.BySelectors{Selectors: []*common.Selector{b2, c3}, Match: datastore.MatchAny},
+				},
+				expect: []*common.RegistrationEntry{entryAB, entryC},
+			},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				resp, err := ds.ListRegistrationEntries(ctx, tt.req)
+				require.NoError(t, err)
+				spiretest.RequireProtoListEqual(t, sortEntries(tt.expect), sortEntries(resp.Entries))
+			})
+		}
+
+		t.Run("with pagination", func(t *testing.T) {
+			var actual []*common.RegistrationEntry
+			pagination := &datastore.Pagination{PageSize: 2}
+			for {
+				resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{Pagination: pagination})
+				require.NoError(t, err)
+				require.NotNil(t, resp.Pagination)
+				require.LessOrEqual(t, len(resp.Entries), 2)
+				actual = append(actual, resp.Entries...)
+				if resp.Pagination.Token == "" || len(resp.Entries) == 0 {
+					break
+				}
+				pagination = resp.Pagination
+			}
+			spiretest.RequireProtoListEqual(t, sortEntries([]*common.RegistrationEntry{entryA, entryAB, entryC}), sortEntries(actual))
+		})
+	})
+
+	t.Run("list by selector expressions", func(t *testing.T) {
+		ds := config.Create(t)
+		a1 := &common.Selector{Type: "a", Value: "1"}
+		b2 := &common.Selector{Type: "b", Value: "2"}
+		notB3 := &common.Selector{Type: "!b", Value: "3"}
+		anyOfB := &common.Selector{Type: "|b", Value: `2|3\|4`}
+		patternC := &common.Selector{Type: "~c", Value: "x-*"}
+		entryNotB, err := ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/not-b", notB3, a1))
+		require.NoError(t, err)
+		entryAnyOf, err := ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/any-of", anyOfB))
+		require.NoError(t, err)
+		entryPattern, err := ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/pattern", patternC))
+		require.NoError(t, err)
+
+		for _, tt := range []struct {
+			name   string
+			by     *datastore.BySelectors
+			expect []*common.RegistrationEntry
+		}{
+			{
+				name:   "subset",
+				by:     &datastore.BySelectors{Selectors: []*common.Selector{a1, b2}, Match: datastore.Subset},
+				expect: []*common.RegistrationEntry{entryNotB, entryAnyOf},
+			},
+			{
+				name:   "subset with negated selector",
+				by:     &datastore.BySelectors{Selectors: []*common.Selector{a1, b2, {Type: "b", Value: "3"}}, Match: datastore.Subset},
+				expect: []*common.RegistrationEntry{entryAnyOf},
+			},
+			{
+				name:   "subset with escaped separator",
+				by:     &datastore.BySelectors{Selectors: []*common.Selector{{Type: "b", Value: "3|4"}}, Match: datastore.Subset},
+				expect: []*common.RegistrationEntry{entryAnyOf},
+			},
+			{
+				name:   "match any",
+				by:     &datastore.BySelectors{Selectors: []*common.Selector{{Type: "c", Value: "x-1"}}, Match: datastore.MatchAny},
+				expect: []*common.RegistrationEntry{entryPattern},
+			},
+			{
+				name: "match any does not match on negation alone",
+				by:   &datastore.BySelectors{Selectors: []*common.Selector{{Type: "d", Value: "4"}}, Match: datastore.MatchAny},
+			},
+			{
+				name:   "exact compares expressions literally",
+				by:     &datastore.BySelectors{Selectors: []*common.Selector{anyOfB}, Match: datastore.Exact},
+				expect: []*common.RegistrationEntry{entryAnyOf},
+			},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{BySelectors: tt.by})
+				require.NoError(t, err)
+				spiretest.RequireProtoListEqual(t, sortEntries(tt.expect), sortEntries(resp.Entries))
+
+				count, err := ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{BySelectors: tt.by})
+				require.NoError(t, err)
+				require.Equal(t, int32(len(tt.expect)), count)
+			})
+		}
+	})
+
+	t.Run("list by SPIFFE ID prefix", func(t *testing.T) {
+		ds := config.Create(t)
+		a1 := &common.Selector{Type: "a", Value: "1"}
+		var expect []*common.RegistrationEntry
+		for _, spiffeID := range []string{
+			"spiffe://example.org/team_a",
+			"spiffe://example.org/team_a/foo",
+			"spiffe://example.org/team_a/foo/bar",
+		} {
+			entry, err := ds.CreateRegistrationEntry(ctx, newEntry(spiffeID, a1))
+			require.NoError(t, err)
+			expect = append(expect, entry)
+		}
+		for _, spiffeID := range []string{
+			"spiffe://example.org/team_ab",
+			"spiffe://example.org/teamXa/foo",
+			"spiffe://example.org/other/team_a",
+			"spiffe://other.org/team_a/foo",
+		} {
+			_, err := ds.CreateRegistrationEntry(ctx, newEntry(spiffeID, a1))
+			require.NoError(t, err)
+		}
+
+		resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			BySpiffeIDPrefix: "spiffe://example.org/team_a",
+		})
+		require.NoError(t, err)
+		spiretest.RequireProtoListEqual(t, sortEntries(expect), sortEntries(resp.Entries))
+
+		count, err := ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{
+			BySpiffeIDPrefix: "spiffe://example.org/team_a",
+		})
+		require.NoError(t, err)
+		require.Equal(t, int32(len(expect)), count)
+
+		// The prefix combines with the other filters
+		resp, err = ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			BySpiffeIDPrefix: "spiffe://example.org/team_a",
+			BySpiffeID:       "spiffe://example.org/team_a/foo",
+			Pagination:       &datastore.Pagination{PageSize: 2},
+		})
+		require.NoError(t, err)
+		spiretest.RequireProtoListEqual(t, expect[1:2], resp.Entries)
+
+		// Pages are filled with the matching entries only
+		resp, err = ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			BySpiffeIDPrefix: "spiffe://example.org/team_a",
+			Pagination:       &datastore.Pagination{PageSize: 3},
+		})
+		require.NoError(t, err)
+		spiretest.RequireProtoListEqual(t, sortEntries(expect), sortEntries(resp.Entries))
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		ds := config.Create(t)
+		now := time.Now()
+		entry := newEntry("spiffe://example.org/foo", &common.Selector{Type: "a", Value: "1"})
+		entry.EntryExpiry = now.Unix()
+		created, err := ds.CreateRegistrationEntry(ctx, entry)
+		require.NoError(t, err)
+
+		// Entries are not pruned before they expire
+		require.NoError(t, ds.PruneRegistrationEntries(ctx, now.Add(-time.Second)))
+		fetched, err := ds.FetchRegistrationEntry(ctx, created.EntryId)
+		require.NoError(t, err)
+		require.NotNil(t, fetched)
+
+		require.NoError(t, ds.PruneRegistrationEntries(ctx, now.Add(time.Second)))
+		fetched, err = ds.FetchRegistrationEntry(ctx, created.EntryId)
+		require.NoError(t, err)
+		require.Nil(t, fetched)
+	})
+
+	t.Run("events", func(t *testing.T) {
+		ds := config.Create(t)
+		created, err := ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/foo", &common.Selector{Type: "a", Value: "1"}))
+		require.NoError(t, err)
+		_, err = ds.DeleteRegistrationEntry(ctx, created.EntryId)
+		require.NoError(t, err)
+
+		resp, err := ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Events, 2)
+		for _, event := range resp.Events {
+			require.Equal(t, created.EntryId, event.EntryID)
+		}
+		require.Less(t, resp.Events[0].EventID, resp.Events[1].EventID)
+
+		event, err := ds.FetchRegistrationEntryEvent(ctx, resp.Events[1].EventID)
+		require.NoError(t, err)
+		require.Equal(t, &resp.Events[1], event)
+	})
+
+	t.Run("activate", func(t *testing.T) {
+		ds := config.Create(t)
+		notBefore := time.Now().Add(-10 * time.Second).Truncate(time.Second)
+		active, err := ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/active", &common.Selector{Type: "a", Value: "1"}))
+		require.NoError(t, err)
+		entry := newEntry("spiffe://example.org/pending", &common.Selector{Type: "a", Value: "2"})
+		entry.NotBefore = notBefore.Unix()
+		pending, err := ds.CreateRegistrationEntry(ctx, entry)
+		require.NoError(t, err)
+
+		listEvents := func() []datastore.RegistrationEntryEvent {
+			resp, err := ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{})
+			require.NoError(t, err)
+			return resp.Events
+		}
+		listEntryIDs := func() []string {
+			var entryIDs []string
+			for _, event := range listEvents() {
+				entryIDs = append(entryIDs, event.EntryID)
+			}
+			return entryIDs
+		}
+		require.Equal(t, []string{active.EntryId, pending.EntryId}, listEntryIDs())
+
+		// The creation event covers entries created after their not-before time
+		require.NoError(t, ds.ActivateRegistrationEntries(ctx, notBefore.Add(-time.Second), notBefore))
+		require.Equal(t, []string{active.EntryId, pending.EntryId}, listEntryIDs())
+
+		// Drop the creation event, as if the entry was created before its
+		// not-before time
+		require.NoError(t, ds.DeleteRegistrationEntryEventForTesting(ctx, listEvents()[1].EventID))
+		require.Equal(t, []string{active.EntryId}, listEntryIDs())
+
+		// No event is created for entries outside of the window
+		require.NoError(t, ds.ActivateRegistrationEntries(ctx, notBefore.Add(-2*time.Second), notBefore.Add(-time.Second)))
+		require.NoError(t, ds.ActivateRegistrationEntries(ctx, notBefore, notBefore.Add(time.Second)))
+		require.Equal(t, []string{active.EntryId}, listEntryIDs())
+
+		require.NoError(t, ds.ActivateRegistrationEntries(ctx, notBefore.Add(-time.Second), notBefore))
+		require.Equal(t, []string{active.EntryId, pending.EntryId}, listEntryIDs())
+
+		// Activating the same window again, as every server sharing the
+		// datastore does, does not create another event
+		require.NoError(t, ds.ActivateRegistrationEntries(ctx, notBefore.Add(-time.Second), notBefore))
+		require.Equal(t, []string{active.EntryId, pending.EntryId}, listEntryIDs())
+	})
+
+	t.Run("history", func(t *testing.T) {
+		ds := config.Create(t)
+		adminCtx := datastore.WithCallerID(ctx, "spiffe://example.org/admin")
+
+		created, err := ds.CreateRegistrationEntry(adminCtx, newEntry("spiffe://example.org/foo", &common.Selector{Type: "a", Value: "1"}))
+		require.NoError(t, err)
+		_, err = ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/bar", &common.Selector{Type: "a", Value: "1"}))
+		require.NoError(t, err)
+
+		// Returning an existing entry is not a change
+		_, existing, err := ds.CreateOrReturnRegistrationEntry(ctx, newEntry("spiffe://example.org/foo", &common.Selector{Type: "a", Value: "1"}))
+		require.NoError(t, err)
+		require.True(t, existing)
+
+		update := proto.Clone(created).(*common.RegistrationEntry)
+		update.X509SvidTtl = 60
+		updated, err := ds.UpdateRegistrationEntry(ctx, update, &common.RegistrationEntryMask{X509SvidTtl: true})
+		require.NoError(t, err)
+		_, err = ds.DeleteRegistrationEntry(adminCtx, created.EntryId)
+		require.NoError(t, err)
+
+		resp, err := ds.ListRegistrationEntryChanges(ctx, &datastore.ListRegistrationEntryChangesRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Changes, 4)
+
+		resp, err = ds.ListRegistrationEntryChanges(ctx, &datastore.ListRegistrationEntryChangesRequest{ByEntryID: created.EntryId})
+		require.NoError(t, err)
+		require.Len(t, resp.Changes, 3)
+		for _, change := range resp.Changes {
+			require.NotZero(t, change.ID)
+			require.False(t, change.CreatedAt.IsZero())
+			require.Equal(t, created.EntryId, change.EntryID)
+		}
+
+		require.Equal(t, datastore.RegistrationEntryCreated, resp.Changes[0].Operation)
+		require.Equal(t, "spiffe://example.org/admin", resp.Changes[0].CallerID)
+		require.Nil(t, resp.Changes[0].Before)
+		spiretest.RequireProtoEqual(t, created, resp.Changes[0].After)
+
+		require.Equal(t, datastore.RegistrationEntryUpdated, resp.Changes[1].Operation)
+		require.Empty(t, resp.Changes[1].CallerID)
+		spiretest.RequireProtoEqual(t, created, resp.Changes[1].Before)
+		spiretest.RequireProtoEqual(t, updated, resp.Changes[1].After)
+
+		require.Equal(t, datastore.RegistrationEntryDeleted, resp.Changes[2].Operation)
+		require.Equal(t, "spiffe://example.org/admin", resp.Changes[2].CallerID)
+		spiretest.RequireProtoEqual(t, updated, resp.Changes[2].Before)
+		require.Nil(t, resp.Changes[2].After)
+
+		// Changes are listed oldest first when paginating
+		var operations []datastore.RegistrationEntryOperation
+		pagination := &datastore.Pagination{PageSize: 2}
+		for {
+			resp, err := ds.ListRegistrationEntryChanges(ctx, &datastore.ListRegistrationEntryChangesRequest{
+				ByEntryID:  created.EntryId,
+				Pagination: pagination,
+			})
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(resp.Changes), 2)
+			for _, change := range resp.Changes {
+				operations = append(operations, change.Operation)
+			}
+			if resp.Pagination.Token == "" || len(resp.Changes) == 0 {
+				break
+			}
+			pagination = resp.Pagination
+		}
+		require.Equal(t, []datastore.RegistrationEntryOperation{
+			datastore.RegistrationEntryCreated,
+			datastore.RegistrationEntryUpdated,
+			datastore.RegistrationEntryDeleted,
+		}, operations)
+	})
+
+	t.Run("history of pruned entries", func(t *testing.T) {
+		ds := config.Create(t)
+
+		entry := newEntry("spiffe://example.org/foo", &common.Selector{Type: "a", Value: "1"})
+		entry.EntryExpiry = time.Now().Add(-time.Hour).Unix()
+		expired, err := ds.CreateRegistrationEntry(ctx, entry)
+		require.NoError(t, err)
+
+		require.NoError(t, ds.PruneRegistrationEntries(ctx, time.Now()))
+
+		resp, err := ds.ListRegistrationEntryChanges(ctx, &datastore.ListRegistrationEntryChangesRequest{ByEntryID: expired.EntryId})
+		require.NoError(t, err)
+		require.Len(t, resp.Changes, 2)
+		require.Equal(t, datastore.RegistrationEntryPruned, resp.Changes[1].Operation)
+		require.Empty(t, resp.Changes[1].CallerID)
+		spiretest.RequireProtoEqual(t, expired, resp.Changes[1].Before)
+		require.Nil(t, resp.Changes[1].After)
+	})
+
+	t.Run("prune history", func(t *testing.T) {
+		ds := config.Create(t)
+
+		_, err := ds.CreateRegistrationEntry(ctx, newEntry("spiffe://example.org/foo", &common.Selector{Type: "a", Value: "1"}))
+		require.NoError(t, err)
+
+		require.NoError(t, ds.PruneRegistrationEntryChanges(ctx, time.Hour))
+		resp, err := ds.ListRegistrationEntryChanges(ctx, &datastore.ListRegistrationEntryChangesRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Changes, 1)
+
+		require.NoError(t, ds.PruneRegistrationEntryChanges(ctx, -time.Hour))
+		resp, err = ds.ListRegistrationEntryChanges(ctx, &datastore.ListRegistrationEntryChangesRequest{})
+		require.NoError(t, err)
+		require.Empty(t, resp.Changes)
+	})
+}
+
+func testJoinTokens(t *testing.T, config Config) {
+	t.Run("CRUD", func(t *testing.T) {
+		ds := config.Create(t)
+		now := time.Now().Truncate(time.Second)
+		joinToken1 := &datastore.JoinToken{Token: "foobar", Expiry: now}
+		joinToken2 := &datastore.JoinToken{Token: "batbaz", Expiry: now}
+
+		require.NoError(t, ds.CreateJoinToken(ctx, joinToken1))
+		require.NoError(t, ds.CreateJoinToken(ctx, joinToken2))
+
+		// Make sure we can't re-register
+		require.Error(t, ds.CreateJoinToken(ctx, joinToken1))
+
+		resp, err := ds.FetchJoinToken(ctx, joinToken1.Token)
+		require.NoError(t, err)
+		require.Equal(t, "foobar", resp.Token)
+		require.True(t, now.Equal(resp.Expiry), "expected expiry %s; got %s", now, resp.Expiry)
+
+		require.NoError(t, ds.DeleteJoinToken(ctx, joinToken1.Token))
+
+		// Should not be able to fetch after delete
+		resp, err = ds.FetchJoinToken(ctx, joinToken1.Token)
+		require.NoError(t, err)
+		require.Nil(t, resp)
+
+		// Second token should still be present
+		resp, err = ds.FetchJoinToken(ctx, joinToken2.Token)
+		require.NoError(t, err)
+		require.Equal(t, joinToken2.Token, resp.Token)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		ds := config.Create(t)
+		now := time.Now().Truncate(time.Second)
+
+		resp, err := ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+		require.NoError(t, err)
+		require.Empty(t, resp.JoinTokens)
+
+		var expected []string
+		for i := range 5 {
+			token := fmt.Sprintf("token-%d", i)
+			require.NoError(t, ds.CreateJoinToken(ctx, &datastore.JoinToken{Token: token, Expiry: now}))
+			expected = append(expected, token)
+		}
+
+		resp, err = ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+		require.NoError(t, err)
+		require.ElementsMatch(t, expected, joinTokenValues(resp.JoinTokens))
+		for _, joinToken := range resp.JoinTokens {
+			require.True(t, now.Equal(joinToken.Expiry), "expected expiry %s; got %s", now, joinToken.Expiry)
+		}
+
+		// Page through all of the tokens
+		var listed []string
+		pagination := &datastore.Pagination{PageSize: 2}
+		for {
+			resp, err = ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{Pagination: pagination})
+			require.NoError(t, err)
+			require.NotNil(t, resp.Pagination)
+			require.LessOrEqual(t, len(resp.JoinTokens), 2)
+			if len(resp.JoinTokens) == 0 {
+				break
+			}
+			listed = append(listed, joinTokenValues(resp.JoinTokens)...)
+			pagination = resp.Pagination
+		}
+		require.ElementsMatch(t, expected, listed)
+
+		_, err = ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{
+			Pagination: &datastore.Pagination{PageSize: 0},
+		})
+		spiretest.RequireGRPCStatusContains(t, err, codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		ds := config.Create(t)
+		now := time.Now().Truncate(time.Second)
+		require.NoError(t, ds.CreateJoinToken(ctx, &datastore.JoinToken{Token: "foobar", Expiry: now}))
+
+		// Ensure we don't prune valid tokens or on the exact ExpiresBefore
+		require.NoError(t, ds.PruneJoinTokens(ctx, now.Add(-time.Second*10)))
+		require.NoError(t, ds.PruneJoinTokens(ctx, now))
+		resp, err := ds.FetchJoinToken(ctx, "foobar")
+		require.NoError(t, err)
+		require.NotNil(t, resp, "token was unexpectedly pruned")
+
+		// Ensure we prune old tokens
+		require.NoError(t, ds.PruneJoinTokens(ctx, now.Add(time.Second*10)))
+		resp, err = ds.FetchJoinToken(ctx, "foobar")
+		require.NoError(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func testFederationRelationships(t *testing.T, config Config, certs testCerts) {
+	td := spiffeid.RequireTrustDomainFromString("federated-td-web.org")
+	tdSPIFFE := spiffeid.RequireTrustDomainFromString("federated-td-spiffe.org")
+
+	t.Run("CRUD", func(t *testing.T) {
+		ds := config.Create(t)
+
+		// fetch non-existent
+		fr, err := ds.FetchFederationRelationship(ctx, td)
+		require.NoError(t, err)
+		require.Nil(t, fr)
+
+		webFR := &datastore.FederationRelationship{
+			TrustDomain:           td,
+			BundleEndpointURL:     requireURL(t, "https://federated-td-web.org/bundleendpoint"),
+			BundleEndpointProfile: datastore.BundleEndpointWeb,
+		}
+		created, err := ds.CreateFederationRelationship(ctx, webFR)
+		require.NoError(t, err)
+		requireFederationRelationship(t, webFR, created)
+
+		// create again
+		_, err = ds.CreateFederationRelationship(ctx, webFR)
+		require.Error(t, err)
+
+		spiffeFR := &datastore.FederationRelationship{
+			TrustDomain:           tdSPIFFE,
+			BundleEndpointURL:     requireURL(t, "https://federated-td-spiffe.org/bundleendpoint"),
+			BundleEndpointProfile: datastore.BundleEndpointSPIFFE,
+			EndpointSPIFFEID:      spiffeid.RequireFromString("spiffe://federated-td-spiffe.org/federated-server"),
+			TrustDomainBundle:     bundleutil.BundleProtoFromRootCA(tdSPIFFE.IDString(), certs.cert),
+		}
+		created, err = ds.CreateFederationRelationship(ctx, spiffeFR)
+		require.NoError(t, err)
+		requireFederationRelationship(t, spiffeFR, created)
+
+		// The trust domain bundle is stored along with the relationship
+		bundle, err := ds.FetchBundle(ctx, tdSPIFFE.IDString())
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, spiffeFR.TrustDomainBundle, bundle)
+
+		fr, err = ds.FetchFederationRelationship(ctx, tdSPIFFE)
+		require.NoError(t, err)
+		requireFederationRelationship(t, spiffeFR, fr)
+
+		resp, err := ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.FederationRelationships, 2)
+
+		// update with mask
+		webFR.BundleEndpointURL = requireURL(t, "https://federated-td-web.org/other")
+		updated, err := ds.UpdateFederationRelationship(ctx, webFR, &types.FederationRelationshipMask{BundleEndpointUrl: true})
+		require.NoError(t, err)
+		requireFederationRelationship(t, webFR, updated)
+
+		// update non-existent
+		_, err = ds.UpdateFederationRelationship(ctx, &datastore.FederationRelationship{
+			TrustDomain:           spiffeid.RequireTrustDomainFromString("non-existent-td.org"),
+			BundleEndpointURL:     requireURL(t, "https://non-existent-td.org/bundleendpoint"),
+			BundleEndpointProfile: datastore.BundleEndpointWeb,
+		}, &types.FederationRelationshipMask{})
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		require.NoError(t, ds.DeleteFederationRelationship(ctx, td))
+		fr, err = ds.FetchFederationRelationship(ctx, td)
+		require.NoError(t, err)
+		require.Nil(t, fr)
+
+		// delete non-existent
+		err = ds.DeleteFederationRelationship(ctx, td)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ds := config.Create(t)
+
+		_, err := ds.CreateFederationRelationship(ctx, nil)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = ds.FetchFederationRelationship(ctx, spiffeid.TrustDomain{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		err = ds.DeleteFederationRelationship(ctx, spiffeid.TrustDomain{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func testCAJournals(t *testing.T, config Config) {
+	t.Run("set and fetch", func(t *testing.T) {
+		ds := config.Create(t)
+
+		_, err := ds.SetCAJournal(ctx, nil)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		// update non-existent
+		_, err = ds.SetCAJournal(ctx, &datastore.CAJournal{
+			ID:                    999,
+			Data:                  []byte("test data"),
+			ActiveX509AuthorityID: "x509-authority-id",
+		})
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		caJournal, err := ds.SetCAJournal(ctx, &datastore.CAJournal{
+			Data:                  []byte("test data"),
+			ActiveX509AuthorityID: "x509-authority-id",
+		})
+		require.NoError(t, err)
+		require.NotZero(t, caJournal.ID)
+
+		fetched, err := ds.FetchCAJournal(ctx, "x509-authority-id")
+		require.NoError(t, err)
+		require.Equal(t, caJournal, fetched)
+
+		fetched, err = ds.FetchCAJournal(ctx, "non-existent-x509-authority-id")
+		require.NoError(t, err)
+		require.Nil(t, fetched)
+
+		_, err = ds.FetchCAJournal(ctx, "")
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		// update the existing journal
+		caJournal.Data = []byte("updated data")
+		updated, err := ds.SetCAJournal(ctx, caJournal)
+		require.NoError(t, err)
+		require.Equal(t, caJournal, updated)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		ds := config.Create(t)
+
+		resp, err := ds.ListCAJournals(ctx, &datastore.ListCAJournalsRequest{})
+		require.NoError(t, err)
+		require.Empty(t, resp.CAJournals)
+
+		var expected []*datastore.CAJournal
+		for i := range 5 {
+			caJournal, err := ds.SetCAJournal(ctx, &datastore.CAJournal{
+				Data:                  []byte(fmt.Sprintf("data-%d", i)),
+				ActiveX509AuthorityID: fmt.Sprintf("x509-authority-%d", i),
+			})
+			require.NoError(t, err)
+			expected = append(expected, caJournal)
+		}
+
+		resp, err = ds.ListCAJournals(ctx, &datastore.ListCAJournalsRequest{})
+		require.NoError(t, err)
+		require.Equal(t, expected, resp.CAJournals)
+
+		// Page through all of the CA journals
+		var listed []*datastore.CAJournal
+		pagination := &datastore.Pagination{PageSize: 2}
+		for {
+			resp, err = ds.ListCAJournals(ctx, &datastore.ListCAJournalsRequest{Pagination: pagination})
+			require.NoError(t, err)
+			require.NotNil(t, resp.Pagination)
+			require.LessOrEqual(t, len(resp.CAJournals), 2)
+			if len(resp.CAJournals) == 0 {
+				break
+			}
+			listed = append(listed, resp.CAJournals...)
+			pagination = resp.Pagination
+		}
+		require.Equal(t, expected, listed)
+
+		_, err = ds.ListCAJournals(ctx, &datastore.ListCAJournalsRequest{
+			Pagination: &datastore.Pagination{PageSize: 0},
+		})
+		spiretest.RequireGRPCStatusContains(t, err, codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		ds := config.Create(t)
+		now := time.Now().Add(time.Hour)
+		entriesBytes, err := proto.Marshal(&journal.Entries{
+			X509CAs: []*journal.X509CAEntry{{NotAfter: now.Add(-time.Hour * 6).Unix()}},
+			JwtKeys: []*journal.JWTKeyEntry{{NotAfter: now.Add(time.Hour * 6).Unix()}},
+		})
+		require.NoError(t, err)
+
+		caJournal, err := ds.SetCAJournal(ctx, &datastore.CAJournal{
+			ActiveX509AuthorityID: "x509-authority-1",
+			Data:                  entriesBytes,
+		})
+		require.NoError(t, err)
+
+		// The CA journal is not pruned while any authority is unexpired
+		require.NoError(t, ds.PruneCAJournals(ctx, now.Unix()))
+		fetched, err := ds.FetchCAJournal(ctx, "x509-authority-1")
+		require.NoError(t, err)
+		require.Equal(t, caJournal, fetched)
+
+		require.NoError(t, ds.PruneCAJournals(ctx, now.Add(time.Hour*12).Unix()))
+		fetched, err = ds.FetchCAJournal(ctx, "x509-authority-1")
+		require.NoError(t, err)
+		require.Nil(t, fetched)
+	})
+}
+
+func testExport(t *testing.T, config Config, certs testCerts) {
+	td := spiffeid.RequireTrustDomainFromString("federated-td.org")
+
+	ds := config.Create(t)
+
+	bundle, err := ds.CreateBundle(ctx, bundleutil.BundleProtoFromRootCA("spiffe://example.org", certs.cacert))
+	require.NoError(t, err)
+	fr, err := ds.CreateFederationRelationship(ctx, &datastore.FederationRelationship{
+		TrustDomain:           td,
+		BundleEndpointURL:     requireURL(t, "https://federated-td.org/bundleendpoint"),
+		BundleEndpointProfile: datastore.BundleEndpointWeb,
+		TrustDomainBundle:     bundleutil.BundleProtoFromRootCA(td.IDString(), certs.cert),
+	})
+	require.NoError(t, err)
+	entry, err := ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/foo",
+		ParentId:      "spiffe://example.org/parent",
+		Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+		FederatesWith: []string{td.IDString()},
+		X509SvidTtl:   1,
+		JwtSvidTtl:    1,
+	})
+	require.NoError(t, err)
+	node, err := ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/node",
+		AttestationDataType: "test",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	node.Selectors = []*common.Selector{{Type: "b", Value: "2"}}
+	require.NoError(t, ds.SetNodeSelectors(ctx, node.SpiffeId, node.Selectors))
+	joinToken := &datastore.JoinToken{Token: "token", Expiry: time.Now().Add(time.Hour).Truncate(time.Second)}
+	require.NoError(t, ds.CreateJoinToken(ctx, joinToken))
+	caJournal, err := ds.SetCAJournal(ctx, &datastore.CAJournal{
+		Data:                  []byte("data"),
+		ActiveX509AuthorityID: "x509-authority-id",
+	})
+	require.NoError(t, err)
+
+	var records []*datastore.ExportRecord
+	require.NoError(t, ds.ExportRecords(ctx, func(record *datastore.ExportRecord) error {
+		records = append(records, record)
+		return nil
+	}))
+
+	// Records are exported in dependency order, and bundles in the order
+	// they were created.
+	require.Len(t, records, 7)
+	spiretest.RequireProtoEqual(t, bundle, records[0].Bundle)
+	spiretest.RequireProtoEqual(t, fr.TrustDomainBundle, records[1].Bundle)
+	requireFederationRelationship(t, fr, records[2].FederationRelationship)
+	spiretest.RequireProtoEqual(t, entry, records[3].RegistrationEntry)
+	spiretest.RequireProtoEqual(t, node, records[4].AttestedNode)
+	require.Equal(t, joinToken.Token, records[5].JoinToken.Token)
+	require.True(t, joinToken.Expiry.Equal(records[5].JoinToken.Expiry), "expected expiry %s; got %s", joinToken.Expiry, records[5].JoinToken.Expiry)
+	require.Equal(t, caJournal, records[6].CAJournal)
+
+	// Errors returned by the callback stop the export and are returned as is
+	errStop := errors.New("stop")
+	calls := 0
+	err = ds.ExportRecords(ctx, func(*datastore.ExportRecord) error {
+		calls++
+		return errStop
+	})
+	require.Equal(t, errStop, err)
+	require.Equal(t, 1, calls)
+}
+
+func requireFederationRelationship(t *testing.T, expected, actual *datastore.FederationRelationship) {
+	require.NotNil(t, actual)
+	assert.Equal(t, expected.TrustDomain, actual.TrustDomain)
+	assert.Equal(t, expected.BundleEndpointURL.String(), actual.BundleEndpointURL.String())
+	assert.Equal(t, expected.BundleEndpointProfile, actual.BundleEndpointProfile)
+	assert.Equal(t, expected.EndpointSPIFFEID, actual.EndpointSPIFFEID)
+	if expected.TrustDomainBundle != nil {
+		spiretest.AssertProtoEqual(t, expected.TrustDomainBundle, actual.TrustDomainBundle)
+	}
+}
+
+func requireURL(t *testing.T, s string) *url.URL {
+	u, err := url.Parse(s)
+	require.NoError(t, err)
+	return u
+}
+
+func sortBundles(bundles []*common.Bundle) []*common.Bundle {
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].TrustDomainId < bundles[j].TrustDomainId
+	})
+	return bundles
+}
+
+func sortEntries(entries []*common.RegistrationEntry) []*common.RegistrationEntry {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].SpiffeId < entries[j].SpiffeId
+	})
+	return entries
+}
+
+func nodeIDs(nodes []*common.AttestedNode) []string {
+	var ids []string
+	for _, node := range nodes {
+		ids = append(ids, node.SpiffeId)
+	}
+	return ids
+}
+
+func joinTokenValues(joinTokens []*datastore.JoinToken) []string {
+	var tokens []string
+	for _, joinToken := range joinTokens {
+		tokens = append(tokens, joinToken.Token)
+	}
+	return tokens
+}
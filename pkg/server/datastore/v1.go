@@ -0,0 +1,759 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/plugin"
+	"github.com/spiffe/spire/proto/spire/common"
+	datastorev1 "github.com/spiffe/spire/proto/spire/plugin/server/datastore/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// V1 is the facade for external DataStore plugins implementing the v1
+// DataStore plugin interface.
+type V1 struct {
+	plugin.Facade
+	datastorev1.DataStorePluginClient
+}
+
+var _ DataStore = (*V1)(nil)
+
+func (v1 *V1) AppendBundle(ctx context.Context, bundle *common.Bundle) (*common.Bundle, error) {
+	resp, err := v1.DataStorePluginClient.AppendBundle(ctx, &datastorev1.AppendBundleRequest{
+		Bundle: bundle,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Bundle, nil
+}
+
+func (v1 *V1) CountBundles(ctx context.Context) (int32, error) {
+	resp, err := v1.DataStorePluginClient.CountBundles(ctx, &datastorev1.CountBundlesRequest{})
+	if err != nil {
+		return 0, v1.WrapErr(err)
+	}
+	return resp.Count, nil
+}
+
+func (v1 *V1) CreateBundle(ctx context.Context, bundle *common.Bundle) (*common.Bundle, error) {
+	resp, err := v1.DataStorePluginClient.CreateBundle(ctx, &datastorev1.CreateBundleRequest{
+		Bundle: bundle,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Bundle, nil
+}
+
+func (v1 *V1) DeleteBundle(ctx context.Context, trustDomainID string, mode DeleteMode) error {
+	_, err := v1.DataStorePluginClient.DeleteBundle(ctx, &datastorev1.DeleteBundleRequest{
+		TrustDomainId: trustDomainID,
+		Mode:          datastorev1.DeleteMode(mode),
+		CallerId:      CallerIDFromContext(ctx),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) FetchBundle(ctx context.Context, trustDomainID string) (*common.Bundle, error) {
+	resp, err := v1.DataStorePluginClient.FetchBundle(ctx, &datastorev1.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Bundle, nil
+}
+
+func (v1 *V1) ListBundles(ctx context.Context, req *ListBundlesRequest) (*ListBundlesResponse, error) {
+	resp, err := v1.DataStorePluginClient.ListBundles(ctx, &datastorev1.ListBundlesRequest{
+		Pagination: paginationToV1(req.Pagination),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return &ListBundlesResponse{
+		Bundles:    resp.Bundles,
+		Pagination: paginationFromV1(resp.Pagination),
+	}, nil
+}
+
+func (v1 *V1) PruneBundle(ctx context.Context, trustDomainID string, expiresBefore time.Time) (bool, error) {
+	resp, err := v1.DataStorePluginClient.PruneBundle(ctx, &datastorev1.PruneBundleRequest{
+		TrustDomainId: trustDomainID,
+		ExpiresBefore: timeToV1(expiresBefore),
+	})
+	if err != nil {
+		return false, v1.WrapErr(err)
+	}
+	return resp.Changed, nil
+}
+
+func (v1 *V1) SetBundle(ctx context.Context, bundle *common.Bundle) (*common.Bundle, error) {
+	resp, err := v1.DataStorePluginClient.SetBundle(ctx, &datastorev1.SetBundleRequest{
+		Bundle: bundle,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Bundle, nil
+}
+
+func (v1 *V1) UpdateBundle(ctx context.Context, bundle *common.Bundle, mask *common.BundleMask) (*common.Bundle, error) {
+	resp, err := v1.DataStorePluginClient.UpdateBundle(ctx, &datastorev1.UpdateBundleRequest{
+		Bundle: bundle,
+		Mask:   mask,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Bundle, nil
+}
+
+func (v1 *V1) TaintX509CA(ctx context.Context, trustDomainID string, subjectKeyIDToTaint string) error {
+	_, err := v1.DataStorePluginClient.TaintX509CA(ctx, &datastorev1.TaintX509CARequest{
+		TrustDomainId: trustDomainID,
+		SubjectKeyId:  subjectKeyIDToTaint,
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) RevokeX509CA(ctx context.Context, trustDomainID string, subjectKeyIDToRevoke string) error {
+	_, err := v1.DataStorePluginClient.RevokeX509CA(ctx, &datastorev1.RevokeX509CARequest{
+		TrustDomainId: trustDomainID,
+		SubjectKeyId:  subjectKeyIDToRevoke,
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) TaintJWTKey(ctx context.Context, trustDomainID string, authorityID string) (*common.PublicKey, error) {
+	resp, err := v1.DataStorePluginClient.TaintJWTKey(ctx, &datastorev1.TaintJWTKeyRequest{
+		TrustDomainId: trustDomainID,
+		AuthorityId:   authorityID,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.PublicKey, nil
+}
+
+func (v1 *V1) RevokeJWTKey(ctx context.Context, trustDomainID string, authorityID string) (*common.PublicKey, error) {
+	resp, err := v1.DataStorePluginClient.RevokeJWTKey(ctx, &datastorev1.RevokeJWTKeyRequest{
+		TrustDomainId: trustDomainID,
+		AuthorityId:   authorityID,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.PublicKey, nil
+}
+
+func (v1 *V1) CountRegistrationEntries(ctx context.Context, req *CountRegistrationEntriesRequest) (int32, error) {
+	resp, err := v1.DataStorePluginClient.CountRegistrationEntries(ctx, &datastorev1.CountRegistrationEntriesRequest{
+		DataConsistency:  datastorev1.DataConsistency(req.DataConsistency),
+		ByParentId:       req.ByParentID,
+		BySelectors:      bySelectorsToV1(req.BySelectors),
+		BySpiffeId:       req.BySpiffeID,
+		ByFederatesWith:  byFederatesWithToV1(req.ByFederatesWith),
+		ByHint:           req.ByHint,
+		ByDownstream:     req.ByDownstream,
+		BySpiffeIdPrefix: req.BySpiffeIDPrefix,
+	})
+	if err != nil {
+		return 0, v1.WrapErr(err)
+	}
+	return resp.Count, nil
+}
+
+func (v1 *V1) CreateRegistrationEntry(ctx context.Context, entry *common.RegistrationEntry) (*common.RegistrationEntry, error) {
+	resp, err := v1.DataStorePluginClient.CreateRegistrationEntry(ctx, &datastorev1.CreateRegistrationEntryRequest{
+		Entry:    entry,
+		CallerId: CallerIDFromContext(ctx),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Entry, nil
+}
+
+func (v1 *V1) CreateOrReturnRegistrationEntry(ctx context.Context, entry *common.RegistrationEntry) (*common.RegistrationEntry, bool, error) {
+	resp, err := v1.DataStorePluginClient.CreateOrReturnRegistrationEntry(ctx, &datastorev1.CreateOrReturnRegistrationEntryRequest{
+		Entry:    entry,
+		CallerId: CallerIDFromContext(ctx),
+	})
+	if err != nil {
+		return nil, false, v1.WrapErr(err)
+	}
+	return resp.Entry, resp.Existing, nil
+}
+
+func (v1 *V1) DeleteRegistrationEntry(ctx context.Context, entryID string) (*common.RegistrationEntry, error) {
+	resp, err := v1.DataStorePluginClient.DeleteRegistrationEntry(ctx, &datastorev1.DeleteRegistrationEntryRequest{
+		EntryId:  entryID,
+		CallerId: CallerIDFromContext(ctx),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Entry, nil
+}
+
+func (v1 *V1) FetchRegistrationEntry(ctx context.Context, entryID string) (*common.RegistrationEntry, error) {
+	resp, err := v1.DataStorePluginClient.FetchRegistrationEntry(ctx, &datastorev1.FetchRegistrationEntryRequest{
+		EntryId: entryID,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Entry, nil
+}
+
+func (v1 *V1) FetchRegistrationEntries(ctx context.Context, entryIDs []string) (map[string]*common.RegistrationEntry, error) {
+	resp, err := v1.DataStorePluginClient.FetchRegistrationEntries(ctx, &datastorev1.FetchRegistrationEntriesRequest{
+		EntryIds: entryIDs,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	entries := resp.Entries
+	if entries == nil {
+		entries = make(map[string]*common.RegistrationEntry)
+	}
+	return entries, nil
+}
+
+func (v1 *V1) ListRegistrationEntries(ctx context.Context, req *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error) {
+	resp, err := v1.DataStorePluginClient.ListRegistrationEntries(ctx, &datastorev1.ListRegistrationEntriesRequest{
+		DataConsistency:  datastorev1.DataConsistency(req.DataConsistency),
+		ByParentId:       req.ByParentID,
+		BySelectors:      bySelectorsToV1(req.BySelectors),
+		BySpiffeId:       req.BySpiffeID,
+		Pagination:       paginationToV1(req.Pagination),
+		ByFederatesWith:  byFederatesWithToV1(req.ByFederatesWith),
+		ByHint:           req.ByHint,
+		ByDownstream:     req.ByDownstream,
+		BySpiffeIdPrefix: req.BySpiffeIDPrefix,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return &ListRegistrationEntriesResponse{
+		Entries:    resp.Entries,
+		Pagination: paginationFromV1(resp.Pagination),
+	}, nil
+}
+
+func (v1 *V1) ActivateRegistrationEntries(ctx context.Context, since, until time.Time) error {
+	_, err := v1.DataStorePluginClient.ActivateRegistrationEntries(ctx, &datastorev1.ActivateRegistrationEntriesRequest{
+		Since: timeToV1(since),
+		Until: timeToV1(until),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) PruneRegistrationEntries(ctx context.Context, expiresBefore time.Time) error {
+	_, err := v1.DataStorePluginClient.PruneRegistrationEntries(ctx, &datastorev1.PruneRegistrationEntriesRequest{
+		ExpiresBefore: timeToV1(expiresBefore),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) UpdateRegistrationEntry(ctx context.Context, entry *common.RegistrationEntry, mask *common.RegistrationEntryMask) (*common.RegistrationEntry, error) {
+	resp, err := v1.DataStorePluginClient.UpdateRegistrationEntry(ctx, &datastorev1.UpdateRegistrationEntryRequest{
+		Entry:    entry,
+		Mask:     mask,
+		CallerId: CallerIDFromContext(ctx),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Entry, nil
+}
+
+func (v1 *V1) ListRegistrationEntryEvents(ctx context.Context, req *ListRegistrationEntryEventsRequest) (*ListRegistrationEntryEventsResponse, error) {
+	resp, err := v1.DataStorePluginClient.ListRegistrationEntryEvents(ctx, &datastorev1.ListRegistrationEntryEventsRequest{
+		DataConsistency:    datastorev1.DataConsistency(req.DataConsistency),
+		GreaterThanEventId: uint64(req.GreaterThanEventID),
+		LessThanEventId:    uint64(req.LessThanEventID),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	events := make([]RegistrationEntryEvent, 0, len(resp.Events))
+	for _, event := range resp.Events {
+		events = append(events, *registrationEntryEventFromV1(event))
+	}
+	return &ListRegistrationEntryEventsResponse{
+		Events: events,
+	}, nil
+}
+
+func (v1 *V1) PruneRegistrationEntryEvents(ctx context.Context, olderThan time.Duration) error {
+	_, err := v1.DataStorePluginClient.PruneRegistrationEntryEvents(ctx, &datastorev1.PruneRegistrationEntryEventsRequest{
+		OlderThan: durationpb.New(olderThan),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) FetchRegistrationEntryEvent(ctx context.Context, eventID uint) (*RegistrationEntryEvent, error) {
+	resp, err := v1.DataStorePluginClient.FetchRegistrationEntryEvent(ctx, &datastorev1.FetchRegistrationEntryEventRequest{
+		EventId: uint64(eventID),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return registrationEntryEventFromV1(resp.Event), nil
+}
+
+func (v1 *V1) CreateRegistrationEntryEventForTesting(ctx context.Context, event *RegistrationEntryEvent) error {
+	_, err := v1.DataStorePluginClient.CreateRegistrationEntryEventForTesting(ctx, &datastorev1.CreateRegistrationEntryEventForTestingRequest{
+		Event: registrationEntryEventToV1(event),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) DeleteRegistrationEntryEventForTesting(ctx context.Context, eventID uint) error {
+	_, err := v1.DataStorePluginClient.DeleteRegistrationEntryEventForTesting(ctx, &datastorev1.DeleteRegistrationEntryEventForTestingRequest{
+		EventId: uint64(eventID),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) ListRegistrationEntryChanges(ctx context.Context, req *ListRegistrationEntryChangesRequest) (*ListRegistrationEntryChangesResponse, error) {
+	resp, err := v1.DataStorePluginClient.ListRegistrationEntryChanges(ctx, &datastorev1.ListRegistrationEntryChangesRequest{
+		ByEntryId:  req.ByEntryID,
+		Pagination: paginationToV1(req.Pagination),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	changes := make([]*RegistrationEntryChange, 0, len(resp.Changes))
+	for _, pbChange := range resp.Changes {
+		changes = append(changes, registrationEntryChangeFromV1(pbChange))
+	}
+	return &ListRegistrationEntryChangesResponse{
+		Changes:    changes,
+		Pagination: paginationFromV1(resp.Pagination),
+	}, nil
+}
+
+func (v1 *V1) PruneRegistrationEntryChanges(ctx context.Context, olderThan time.Duration) error {
+	_, err := v1.DataStorePluginClient.PruneRegistrationEntryChanges(ctx, &datastorev1.PruneRegistrationEntryChangesRequest{
+		OlderThan: durationpb.New(olderThan),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) CountAttestedNodes(ctx context.Context, req *CountAttestedNodesRequest) (int32, error) {
+	resp, err := v1.DataStorePluginClient.CountAttestedNodes(ctx, &datastorev1.CountAttestedNodesRequest{
+		ByAttestationType: req.ByAttestationType,
+		ByBanned:          req.ByBanned,
+		ByExpiresBefore:   timeToV1(req.ByExpiresBefore),
+		BySelectorMatch:   bySelectorsToV1(req.BySelectorMatch),
+		FetchSelectors:    req.FetchSelectors,
+		ByCanReattest:     req.ByCanReattest,
+	})
+	if err != nil {
+		return 0, v1.WrapErr(err)
+	}
+	return resp.Count, nil
+}
+
+func (v1 *V1) CreateAttestedNode(ctx context.Context, node *common.AttestedNode) (*common.AttestedNode, error) {
+	resp, err := v1.DataStorePluginClient.CreateAttestedNode(ctx, &datastorev1.CreateAttestedNodeRequest{
+		Node: node,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Node, nil
+}
+
+func (v1 *V1) DeleteAttestedNode(ctx context.Context, spiffeID string) (*common.AttestedNode, error) {
+	resp, err := v1.DataStorePluginClient.DeleteAttestedNode(ctx, &datastorev1.DeleteAttestedNodeRequest{
+		SpiffeId: spiffeID,
+		CallerId: CallerIDFromContext(ctx),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Node, nil
+}
+
+func (v1 *V1) FetchAttestedNode(ctx context.Context, spiffeID string) (*common.AttestedNode, error) {
+	resp, err := v1.DataStorePluginClient.FetchAttestedNode(ctx, &datastorev1.FetchAttestedNodeRequest{
+		SpiffeId: spiffeID,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Node, nil
+}
+
+func (v1 *V1) FetchAttestedNodes(ctx context.Context, spiffeIDs []string) (map[string]*common.AttestedNode, error) {
+	resp, err := v1.DataStorePluginClient.FetchAttestedNodes(ctx, &datastorev1.FetchAttestedNodesRequest{
+		SpiffeIds: spiffeIDs,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	nodes := resp.Nodes
+	if nodes == nil {
+		nodes = make(map[string]*common.AttestedNode)
+	}
+	return nodes, nil
+}
+
+func (v1 *V1) ListAttestedNodes(ctx context.Context, req *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error) {
+	resp, err := v1.DataStorePluginClient.ListAttestedNodes(ctx, &datastorev1.ListAttestedNodesRequest{
+		ByAttestationType: req.ByAttestationType,
+		ByBanned:          req.ByBanned,
+		ByExpiresBefore:   timeToV1(req.ByExpiresBefore),
+		BySelectorMatch:   bySelectorsToV1(req.BySelectorMatch),
+		BySpiffeIds:       req.BySpiffeIDs,
+		FetchSelectors:    req.FetchSelectors,
+		Pagination:        paginationToV1(req.Pagination),
+		ByCanReattest:     req.ByCanReattest,
+		ValidAt:           timeToV1(req.ValidAt),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return &ListAttestedNodesResponse{
+		Nodes:      resp.Nodes,
+		Pagination: paginationFromV1(resp.Pagination),
+	}, nil
+}
+
+func (v1 *V1) UpdateAttestedNode(ctx context.Context, node *common.AttestedNode, mask *common.AttestedNodeMask) (*common.AttestedNode, error) {
+	resp, err := v1.DataStorePluginClient.UpdateAttestedNode(ctx, &datastorev1.UpdateAttestedNodeRequest{
+		Node: node,
+		Mask: mask,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Node, nil
+}
+
+func (v1 *V1) PruneAttestedExpiredNodes(ctx context.Context, expiredBefore time.Time, includeNonReattestable bool, batchSize int) error {
+	_, err := v1.DataStorePluginClient.PruneAttestedExpiredNodes(ctx, &datastorev1.PruneAttestedExpiredNodesRequest{
+		ExpiredBefore:          timeToV1(expiredBefore),
+		IncludeNonReattestable: includeNonReattestable,
+		BatchSize:              int32(batchSize), //nolint: gosec // batch sizes are small
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) ListAttestedNodeEvents(ctx context.Context, req *ListAttestedNodeEventsRequest) (*ListAttestedNodeEventsResponse, error) {
+	resp, err := v1.DataStorePluginClient.ListAttestedNodeEvents(ctx, &datastorev1.ListAttestedNodeEventsRequest{
+		DataConsistency:    datastorev1.DataConsistency(req.DataConsistency),
+		GreaterThanEventId: uint64(req.GreaterThanEventID),
+		LessThanEventId:    uint64(req.LessThanEventID),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	events := make([]AttestedNodeEvent, 0, len(resp.Events))
+	for _, event := range resp.Events {
+		events = append(events, *attestedNodeEventFromV1(event))
+	}
+	return &ListAttestedNodeEventsResponse{
+		Events: events,
+	}, nil
+}
+
+func (v1 *V1) PruneAttestedNodeEvents(ctx context.Context, olderThan time.Duration) error {
+	_, err := v1.DataStorePluginClient.PruneAttestedNodeEvents(ctx, &datastorev1.PruneAttestedNodeEventsRequest{
+		OlderThan: durationpb.New(olderThan),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) FetchAttestedNodeEvent(ctx context.Context, eventID uint) (*AttestedNodeEvent, error) {
+	resp, err := v1.DataStorePluginClient.FetchAttestedNodeEvent(ctx, &datastorev1.FetchAttestedNodeEventRequest{
+		EventId: uint64(eventID),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return attestedNodeEventFromV1(resp.Event), nil
+}
+
+func (v1 *V1) CreateAttestedNodeEventForTesting(ctx context.Context, event *AttestedNodeEvent) error {
+	_, err := v1.DataStorePluginClient.CreateAttestedNodeEventForTesting(ctx, &datastorev1.CreateAttestedNodeEventForTestingRequest{
+		Event: attestedNodeEventToV1(event),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) DeleteAttestedNodeEventForTesting(ctx context.Context, eventID uint) error {
+	_, err := v1.DataStorePluginClient.DeleteAttestedNodeEventForTesting(ctx, &datastorev1.DeleteAttestedNodeEventForTestingRequest{
+		EventId: uint64(eventID),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) GetNodeSelectors(ctx context.Context, spiffeID string, dataConsistency DataConsistency) ([]*common.Selector, error) {
+	resp, err := v1.DataStorePluginClient.GetNodeSelectors(ctx, &datastorev1.GetNodeSelectorsRequest{
+		SpiffeId:        spiffeID,
+		DataConsistency: datastorev1.DataConsistency(dataConsistency),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return resp.Selectors, nil
+}
+
+func (v1 *V1) ListNodeSelectors(ctx context.Context, req *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error) {
+	resp, err := v1.DataStorePluginClient.ListNodeSelectors(ctx, &datastorev1.ListNodeSelectorsRequest{
+		DataConsistency: datastorev1.DataConsistency(req.DataConsistency),
+		ValidAt:         timeToV1(req.ValidAt),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	selectors := make(map[string][]*common.Selector, len(resp.Selectors))
+	for spiffeID, nodeSelectors := range resp.Selectors {
+		selectors[spiffeID] = nodeSelectors.GetEntries()
+	}
+	return &ListNodeSelectorsResponse{
+		Selectors: selectors,
+	}, nil
+}
+
+func (v1 *V1) SetNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) error {
+	_, err := v1.DataStorePluginClient.SetNodeSelectors(ctx, &datastorev1.SetNodeSelectorsRequest{
+		SpiffeId:  spiffeID,
+		Selectors: selectors,
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) CreateJoinToken(ctx context.Context, token *JoinToken) error {
+	_, err := v1.DataStorePluginClient.CreateJoinToken(ctx, &datastorev1.CreateJoinTokenRequest{
+		JoinToken: joinTokenToV1(token),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) DeleteJoinToken(ctx context.Context, token string) error {
+	_, err := v1.DataStorePluginClient.DeleteJoinToken(ctx, &datastorev1.DeleteJoinTokenRequest{
+		Token: token,
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) FetchJoinToken(ctx context.Context, token string) (*JoinToken, error) {
+	resp, err := v1.DataStorePluginClient.FetchJoinToken(ctx, &datastorev1.FetchJoinTokenRequest{
+		Token: token,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return joinTokenFromV1(resp.JoinToken), nil
+}
+
+func (v1 *V1) ListJoinTokens(ctx context.Context, req *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	resp, err := v1.DataStorePluginClient.ListJoinTokens(ctx, &datastorev1.ListJoinTokensRequest{
+		Pagination: paginationToV1(req.Pagination),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	tokens := make([]*JoinToken, 0, len(resp.JoinTokens))
+	for _, pbToken := range resp.JoinTokens {
+		tokens = append(tokens, joinTokenFromV1(pbToken))
+	}
+	return &ListJoinTokensResponse{
+		JoinTokens: tokens,
+		Pagination: paginationFromV1(resp.Pagination),
+	}, nil
+}
+
+func (v1 *V1) PruneJoinTokens(ctx context.Context, expiresBefore time.Time) error {
+	_, err := v1.DataStorePluginClient.PruneJoinTokens(ctx, &datastorev1.PruneJoinTokensRequest{
+		ExpiresBefore: timeToV1(expiresBefore),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) CreateFederationRelationship(ctx context.Context, fr *FederationRelationship) (*FederationRelationship, error) {
+	resp, err := v1.DataStorePluginClient.CreateFederationRelationship(ctx, &datastorev1.CreateFederationRelationshipRequest{
+		FederationRelationship: federationRelationshipToV1(fr),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return v1.federationRelationshipFromV1(resp.FederationRelationship)
+}
+
+func (v1 *V1) FetchFederationRelationship(ctx context.Context, trustDomain spiffeid.TrustDomain) (*FederationRelationship, error) {
+	resp, err := v1.DataStorePluginClient.FetchFederationRelationship(ctx, &datastorev1.FetchFederationRelationshipRequest{
+		TrustDomain: trustDomain.Name(),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return v1.federationRelationshipFromV1(resp.FederationRelationship)
+}
+
+func (v1 *V1) ListFederationRelationships(ctx context.Context, req *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error) {
+	resp, err := v1.DataStorePluginClient.ListFederationRelationships(ctx, &datastorev1.ListFederationRelationshipsRequest{
+		Pagination: paginationToV1(req.Pagination),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	frs := make([]*FederationRelationship, 0, len(resp.FederationRelationships))
+	for _, pbFR := range resp.FederationRelationships {
+		fr, err := v1.federationRelationshipFromV1(pbFR)
+		if err != nil {
+			return nil, err
+		}
+		frs = append(frs, fr)
+	}
+	return &ListFederationRelationshipsResponse{
+		FederationRelationships: frs,
+		Pagination:              paginationFromV1(resp.Pagination),
+	}, nil
+}
+
+func (v1 *V1) DeleteFederationRelationship(ctx context.Context, trustDomain spiffeid.TrustDomain) error {
+	_, err := v1.DataStorePluginClient.DeleteFederationRelationship(ctx, &datastorev1.DeleteFederationRelationshipRequest{
+		TrustDomain: trustDomain.Name(),
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) UpdateFederationRelationship(ctx context.Context, fr *FederationRelationship, mask *types.FederationRelationshipMask) (*FederationRelationship, error) {
+	var pbMask *datastorev1.FederationRelationshipMask
+	if mask != nil {
+		pbMask = &datastorev1.FederationRelationshipMask{
+			BundleEndpointUrl:     mask.BundleEndpointUrl,
+			BundleEndpointProfile: mask.BundleEndpointProfile,
+			TrustDomainBundle:     mask.TrustDomainBundle,
+		}
+	}
+	resp, err := v1.DataStorePluginClient.UpdateFederationRelationship(ctx, &datastorev1.UpdateFederationRelationshipRequest{
+		FederationRelationship: federationRelationshipToV1(fr),
+		Mask:                   pbMask,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return v1.federationRelationshipFromV1(resp.FederationRelationship)
+}
+
+func (v1 *V1) SetCAJournal(ctx context.Context, caJournal *CAJournal) (*CAJournal, error) {
+	resp, err := v1.DataStorePluginClient.SetCAJournal(ctx, &datastorev1.SetCAJournalRequest{
+		CaJournal: caJournalToV1(caJournal),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return caJournalFromV1(resp.CaJournal), nil
+}
+
+func (v1 *V1) FetchCAJournal(ctx context.Context, activeX509AuthorityID string) (*CAJournal, error) {
+	resp, err := v1.DataStorePluginClient.FetchCAJournal(ctx, &datastorev1.FetchCAJournalRequest{
+		ActiveX509AuthorityId: activeX509AuthorityID,
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	return caJournalFromV1(resp.CaJournal), nil
+}
+
+func (v1 *V1) PruneCAJournals(ctx context.Context, allCAsExpireBefore int64) error {
+	_, err := v1.DataStorePluginClient.PruneCAJournals(ctx, &datastorev1.PruneCAJournalsRequest{
+		AllCasExpireBefore: allCAsExpireBefore,
+	})
+	return v1.WrapErr(err)
+}
+
+func (v1 *V1) ListCAJournals(ctx context.Context, req *ListCAJournalsRequest) (*ListCAJournalsResponse, error) {
+	resp, err := v1.DataStorePluginClient.ListCAJournals(ctx, &datastorev1.ListCAJournalsRequest{
+		Pagination: paginationToV1(req.Pagination),
+	})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	caJournals := make([]*CAJournal, 0, len(resp.CaJournals))
+	for _, caJournal := range resp.CaJournals {
+		caJournals = append(caJournals, caJournalFromV1(caJournal))
+	}
+	return &ListCAJournalsResponse{
+		CAJournals: caJournals,
+		Pagination: paginationFromV1(resp.Pagination),
+	}, nil
+}
+
+func (v1 *V1) ListCAJournalsForTesting(ctx context.Context) ([]*CAJournal, error) {
+	resp, err := v1.DataStorePluginClient.ListCAJournalsForTesting(ctx, &datastorev1.ListCAJournalsForTestingRequest{})
+	if err != nil {
+		return nil, v1.WrapErr(err)
+	}
+	caJournals := make([]*CAJournal, 0, len(resp.CaJournals))
+	for _, caJournal := range resp.CaJournals {
+		caJournals = append(caJournals, caJournalFromV1(caJournal))
+	}
+	return caJournals, nil
+}
+
+func (v1 *V1) ExportRecords(ctx context.Context, fn func(*ExportRecord) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := v1.DataStorePluginClient.ExportRecords(ctx, &datastorev1.ExportRecordsRequest{})
+	if err != nil {
+		return v1.WrapErr(err)
+	}
+	for {
+		resp, err := stream.Recv()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return v1.WrapErr(err)
+		}
+
+		record := new(ExportRecord)
+		switch r := resp.Record.(type) {
+		case *datastorev1.ExportRecordsResponse_Bundle:
+			record.Bundle = r.Bundle
+		case *datastorev1.ExportRecordsResponse_FederationRelationship:
+			record.FederationRelationship, err = v1.federationRelationshipFromV1(r.FederationRelationship)
+			if err != nil {
+				return err
+			}
+		case *datastorev1.ExportRecordsResponse_RegistrationEntry:
+			record.RegistrationEntry = r.RegistrationEntry
+		case *datastorev1.ExportRecordsResponse_AttestedNode:
+			record.AttestedNode = r.AttestedNode
+		case *datastorev1.ExportRecordsResponse_JoinToken:
+			record.JoinToken = joinTokenFromV1(r.JoinToken)
+		case *datastorev1.ExportRecordsResponse_CaJournal:
+			record.CAJournal = caJournalFromV1(r.CaJournal)
+		default:
+			return v1.Errorf(codes.Internal, "plugin returned an export record of unexpected type %T", resp.Record)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+func (v1 *V1) federationRelationshipFromV1(pbFR *datastorev1.FederationRelationship) (*FederationRelationship, error) {
+	fr, err := federationRelationshipFromV1(pbFR)
+	if err != nil {
+		return nil, v1.Errorf(codes.Internal, "plugin returned invalid federation relationship: %v", err)
+	}
+	return fr, nil
+}
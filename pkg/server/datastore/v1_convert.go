@@ -0,0 +1,247 @@
+package datastore
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	datastorev1 "github.com/spiffe/spire/proto/spire/plugin/server/datastore/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func timeToV1(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func timeFromV1(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func paginationToV1(pagination *Pagination) *datastorev1.Pagination {
+	if pagination == nil {
+		return nil
+	}
+	return &datastorev1.Pagination{
+		Token:    pagination.Token,
+		PageSize: pagination.PageSize,
+	}
+}
+
+func paginationFromV1(pagination *datastorev1.Pagination) *Pagination {
+	if pagination == nil {
+		return nil
+	}
+	return &Pagination{
+		Token:    pagination.Token,
+		PageSize: pagination.PageSize,
+	}
+}
+
+func bySelectorsToV1(bySelectors *BySelectors) *datastorev1.BySelectors {
+	if bySelectors == nil {
+		return nil
+	}
+	return &datastorev1.BySelectors{
+		Selectors: bySelectors.Selectors,
+		Match:     datastorev1.MatchBehavior(bySelectors.Match),
+	}
+}
+
+func bySelectorsFromV1(bySelectors *datastorev1.BySelectors) *BySelectors {
+	if bySelectors == nil {
+		return nil
+	}
+	return &BySelectors{
+		Selectors: bySelectors.Selectors,
+		Match:     MatchBehavior(bySelectors.Match),
+	}
+}
+
+func byFederatesWithToV1(byFederatesWith *ByFederatesWith) *datastorev1.ByFederatesWith {
+	if byFederatesWith == nil {
+		return nil
+	}
+	return &datastorev1.ByFederatesWith{
+		TrustDomains: byFederatesWith.TrustDomains,
+		Match:        datastorev1.MatchBehavior(byFederatesWith.Match),
+	}
+}
+
+func byFederatesWithFromV1(byFederatesWith *datastorev1.ByFederatesWith) *ByFederatesWith {
+	if byFederatesWith == nil {
+		return nil
+	}
+	return &ByFederatesWith{
+		TrustDomains: byFederatesWith.TrustDomains,
+		Match:        MatchBehavior(byFederatesWith.Match),
+	}
+}
+
+func registrationEntryEventToV1(event *RegistrationEntryEvent) *datastorev1.RegistrationEntryEvent {
+	if event == nil {
+		return nil
+	}
+	return &datastorev1.RegistrationEntryEvent{
+		EventId: uint64(event.EventID),
+		EntryId: event.EntryID,
+	}
+}
+
+func registrationEntryEventFromV1(event *datastorev1.RegistrationEntryEvent) *RegistrationEntryEvent {
+	if event == nil {
+		return nil
+	}
+	return &RegistrationEntryEvent{
+		EventID: uint(event.EventId),
+		EntryID: event.EntryId,
+	}
+}
+
+func registrationEntryChangeToV1(change *RegistrationEntryChange) *datastorev1.RegistrationEntryChange {
+	if change == nil {
+		return nil
+	}
+	return &datastorev1.RegistrationEntryChange{
+		Id:        uint64(change.ID),
+		EntryId:   change.EntryID,
+		Operation: string(change.Operation),
+		Before:    change.Before,
+		After:     change.After,
+		CallerId:  change.CallerID,
+		CreatedAt: timeToV1(change.CreatedAt),
+	}
+}
+
+func registrationEntryChangeFromV1(change *datastorev1.RegistrationEntryChange) *RegistrationEntryChange {
+	if change == nil {
+		return nil
+	}
+	return &RegistrationEntryChange{
+		ID:        uint(change.Id),
+		EntryID:   change.EntryId,
+		Operation: RegistrationEntryOperation(change.Operation),
+		Before:    change.Before,
+		After:     change.After,
+		CallerID:  change.CallerId,
+		CreatedAt: timeFromV1(change.CreatedAt),
+	}
+}
+
+func attestedNodeEventToV1(event *AttestedNodeEvent) *datastorev1.AttestedNodeEvent {
+	if event == nil {
+		return nil
+	}
+	return &datastorev1.AttestedNodeEvent{
+		EventId:  uint64(event.EventID),
+		SpiffeId: event.SpiffeID,
+	}
+}
+
+func attestedNodeEventFromV1(event *datastorev1.AttestedNodeEvent) *AttestedNodeEvent {
+	if event == nil {
+		return nil
+	}
+	return &AttestedNodeEvent{
+		EventID:  uint(event.EventId),
+		SpiffeID: event.SpiffeId,
+	}
+}
+
+func joinTokenToV1(token *JoinToken) *datastorev1.JoinToken {
+	if token == nil {
+		return nil
+	}
+	return &datastorev1.JoinToken{
+		Token:  token.Token,
+		Expiry: timeToV1(token.Expiry),
+	}
+}
+
+func joinTokenFromV1(token *datastorev1.JoinToken) *JoinToken {
+	if token == nil {
+		return nil
+	}
+	return &JoinToken{
+		Token:  token.Token,
+		Expiry: timeFromV1(token.Expiry),
+	}
+}
+
+func caJournalToV1(caJournal *CAJournal) *datastorev1.CAJournal {
+	if caJournal == nil {
+		return nil
+	}
+	return &datastorev1.CAJournal{
+		Id:                    uint64(caJournal.ID),
+		Data:                  caJournal.Data,
+		ActiveX509AuthorityId: caJournal.ActiveX509AuthorityID,
+	}
+}
+
+func caJournalFromV1(caJournal *datastorev1.CAJournal) *CAJournal {
+	if caJournal == nil {
+		return nil
+	}
+	return &CAJournal{
+		ID:                    uint(caJournal.Id),
+		Data:                  caJournal.Data,
+		ActiveX509AuthorityID: caJournal.ActiveX509AuthorityId,
+	}
+}
+
+func federationRelationshipToV1(fr *FederationRelationship) *datastorev1.FederationRelationship {
+	if fr == nil {
+		return nil
+	}
+	pbFR := &datastorev1.FederationRelationship{
+		TrustDomain:           fr.TrustDomain.Name(),
+		BundleEndpointProfile: string(fr.BundleEndpointProfile),
+		TrustDomainBundle:     fr.TrustDomainBundle,
+	}
+	if fr.BundleEndpointURL != nil {
+		pbFR.BundleEndpointUrl = fr.BundleEndpointURL.String()
+	}
+	if !fr.EndpointSPIFFEID.IsZero() {
+		pbFR.EndpointSpiffeId = fr.EndpointSPIFFEID.String()
+	}
+	return pbFR
+}
+
+func federationRelationshipFromV1(pbFR *datastorev1.FederationRelationship) (*FederationRelationship, error) {
+	if pbFR == nil {
+		return nil, nil
+	}
+	fr := &FederationRelationship{
+		BundleEndpointProfile: BundleEndpointType(pbFR.BundleEndpointProfile),
+		TrustDomainBundle:     pbFR.TrustDomainBundle,
+	}
+	if pbFR.TrustDomain != "" {
+		td, err := spiffeid.TrustDomainFromString(pbFR.TrustDomain)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trust domain: %w", err)
+		}
+		fr.TrustDomain = td
+	}
+	if pbFR.BundleEndpointUrl != "" {
+		bundleEndpointURL, err := url.Parse(pbFR.BundleEndpointUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle endpoint URL: %w", err)
+		}
+		fr.BundleEndpointURL = bundleEndpointURL
+	}
+	if pbFR.EndpointSpiffeId != "" {
+		endpointSPIFFEID, err := spiffeid.FromString(pbFR.EndpointSpiffeId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint SPIFFE ID: %w", err)
+		}
+		fr.EndpointSPIFFEID = endpointSPIFFEID
+	}
+	return fr, nil
+}
@@ -0,0 +1,670 @@
+package datastore
+
+import (
+	"context"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	datastorev1 "github.com/spiffe/spire/proto/spire/plugin/server/datastore/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// V1Server returns a v1 DataStore plugin service server backed by the given
+// DataStore. It allows a DataStore implementation written in Go to be served
+// as an external plugin, e.g.:
+//
+//	pluginmain.Serve(
+//		datastorev1.DataStorePluginServer(datastore.V1Server(ds)),
+//		configv1.ConfigServiceServer(ds),
+//	)
+func V1Server(ds DataStore) datastorev1.DataStoreServer {
+	return &v1Server{ds: ds}
+}
+
+type v1Server struct {
+	datastorev1.UnsafeDataStoreServer
+
+	ds DataStore
+}
+
+func (s *v1Server) AppendBundle(ctx context.Context, req *datastorev1.AppendBundleRequest) (*datastorev1.AppendBundleResponse, error) {
+	bundle, err := s.ds.AppendBundle(ctx, req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.AppendBundleResponse{Bundle: bundle}, nil
+}
+
+func (s *v1Server) CountBundles(ctx context.Context, _ *datastorev1.CountBundlesRequest) (*datastorev1.CountBundlesResponse, error) {
+	count, err := s.ds.CountBundles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.CountBundlesResponse{Count: count}, nil
+}
+
+func (s *v1Server) CreateBundle(ctx context.Context, req *datastorev1.CreateBundleRequest) (*datastorev1.CreateBundleResponse, error) {
+	bundle, err := s.ds.CreateBundle(ctx, req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.CreateBundleResponse{Bundle: bundle}, nil
+}
+
+func (s *v1Server) DeleteBundle(ctx context.Context, req *datastorev1.DeleteBundleRequest) (*datastorev1.DeleteBundleResponse, error) {
+	if err := s.ds.DeleteBundle(WithCallerID(ctx, req.CallerId), req.TrustDomainId, DeleteMode(req.Mode)); err != nil {
+		return nil, err
+	}
+	return &datastorev1.DeleteBundleResponse{}, nil
+}
+
+func (s *v1Server) FetchBundle(ctx context.Context, req *datastorev1.FetchBundleRequest) (*datastorev1.FetchBundleResponse, error) {
+	bundle, err := s.ds.FetchBundle(ctx, req.TrustDomainId)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.FetchBundleResponse{Bundle: bundle}, nil
+}
+
+func (s *v1Server) ListBundles(ctx context.Context, req *datastorev1.ListBundlesRequest) (*datastorev1.ListBundlesResponse, error) {
+	resp, err := s.ds.ListBundles(ctx, &ListBundlesRequest{
+		Pagination: paginationFromV1(req.Pagination),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.ListBundlesResponse{
+		Bundles:    resp.Bundles,
+		Pagination: paginationToV1(resp.Pagination),
+	}, nil
+}
+
+func (s *v1Server) PruneBundle(ctx context.Context, req *datastorev1.PruneBundleRequest) (*datastorev1.PruneBundleResponse, error) {
+	changed, err := s.ds.PruneBundle(ctx, req.TrustDomainId, timeFromV1(req.ExpiresBefore))
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.PruneBundleResponse{Changed: changed}, nil
+}
+
+func (s *v1Server) SetBundle(ctx context.Context, req *datastorev1.SetBundleRequest) (*datastorev1.SetBundleResponse, error) {
+	bundle, err := s.ds.SetBundle(ctx, req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.SetBundleResponse{Bundle: bundle}, nil
+}
+
+func (s *v1Server) UpdateBundle(ctx context.Context, req *datastorev1.UpdateBundleRequest) (*datastorev1.UpdateBundleResponse, error) {
+	bundle, err := s.ds.UpdateBundle(ctx, req.Bundle, req.Mask)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.UpdateBundleResponse{Bundle: bundle}, nil
+}
+
+func (s *v1Server) TaintX509CA(ctx context.Context, req *datastorev1.TaintX509CARequest) (*datastorev1.TaintX509CAResponse, error) {
+	if err := s.ds.TaintX509CA(ctx, req.TrustDomainId, req.SubjectKeyId); err != nil {
+		return nil, err
+	}
+	return &datastorev1.TaintX509CAResponse{}, nil
+}
+
+func (s *v1Server) RevokeX509CA(ctx context.Context, req *datastorev1.RevokeX509CARequest) (*datastorev1.RevokeX509CAResponse, error) {
+	if err := s.ds.RevokeX509CA(ctx, req.TrustDomainId, req.SubjectKeyId); err != nil {
+		return nil, err
+	}
+	return &datastorev1.RevokeX509CAResponse{}, nil
+}
+
+func (s *v1Server) TaintJWTKey(ctx context.Context, req *datastorev1.TaintJWTKeyRequest) (*datastorev1.TaintJWTKeyResponse, error) {
+	publicKey, err := s.ds.TaintJWTKey(ctx, req.TrustDomainId, req.AuthorityId)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.TaintJWTKeyResponse{PublicKey: publicKey}, nil
+}
+
+func (s *v1Server) RevokeJWTKey(ctx context.Context, req *datastorev1.RevokeJWTKeyRequest) (*datastorev1.RevokeJWTKeyResponse, error) {
+	publicKey, err := s.ds.RevokeJWTKey(ctx, req.TrustDomainId, req.AuthorityId)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.RevokeJWTKeyResponse{PublicKey: publicKey}, nil
+}
+
+func (s *v1Server) CountRegistrationEntries(ctx context.Context, req *datastorev1.CountRegistrationEntriesRequest) (*datastorev1.CountRegistrationEntriesResponse, error) {
+	count, err := s.ds.CountRegistrationEntries(ctx, &CountRegistrationEntriesRequest{
+		DataConsistency:  DataConsistency(req.DataConsistency),
+		ByParentID:       req.ByParentId,
+		BySelectors:      bySelectorsFromV1(req.BySelectors),
+		BySpiffeID:       req.BySpiffeId,
+		ByFederatesWith:  byFederatesWithFromV1(req.ByFederatesWith),
+		ByHint:           req.ByHint,
+		ByDownstream:     req.ByDownstream,
+		BySpiffeIDPrefix: req.BySpiffeIdPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.CountRegistrationEntriesResponse{Count: count}, nil
+}
+
+func (s *v1Server) CreateRegistrationEntry(ctx context.Context, req *datastorev1.CreateRegistrationEntryRequest) (*datastorev1.CreateRegistrationEntryResponse, error) {
+	entry, err := s.ds.CreateRegistrationEntry(WithCallerID(ctx, req.CallerId), req.Entry)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.CreateRegistrationEntryResponse{Entry: entry}, nil
+}
+
+func (s *v1Server) CreateOrReturnRegistrationEntry(ctx context.Context, req *datastorev1.CreateOrReturnRegistrationEntryRequest) (*datastorev1.CreateOrReturnRegistrationEntryResponse, error) {
+	entry, existing, err := s.ds.CreateOrReturnRegistrationEntry(WithCallerID(ctx, req.CallerId), req.Entry)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.CreateOrReturnRegistrationEntryResponse{Entry: entry, Existing: existing}, nil
+}
+
+func (s *v1Server) DeleteRegistrationEntry(ctx context.Context, req *datastorev1.DeleteRegistrationEntryRequest) (*datastorev1.DeleteRegistrationEntryResponse, error) {
+	entry, err := s.ds.DeleteRegistrationEntry(WithCallerID(ctx, req.CallerId), req.EntryId)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.DeleteRegistrationEntryResponse{Entry: entry}, nil
+}
+
+func (s *v1Server) FetchRegistrationEntry(ctx context.Context, req *datastorev1.FetchRegistrationEntryRequest) (*datastorev1.FetchRegistrationEntryResponse, error) {
+	entry, err := s.ds.FetchRegistrationEntry(ctx, req.EntryId)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.FetchRegistrationEntryResponse{Entry: entry}, nil
+}
+
+func (s *v1Server) FetchRegistrationEntries(ctx context.Context, req *datastorev1.FetchRegistrationEntriesRequest) (*datastorev1.FetchRegistrationEntriesResponse, error) {
+	entries, err := s.ds.FetchRegistrationEntries(ctx, req.EntryIds)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.FetchRegistrationEntriesResponse{Entries: entries}, nil
+}
+
+func (s *v1Server) ListRegistrationEntries(ctx context.Context, req *datastorev1.ListRegistrationEntriesRequest) (*datastorev1.ListRegistrationEntriesResponse, error) {
+	resp, err := s.ds.ListRegistrationEntries(ctx, &ListRegistrationEntriesRequest{
+		DataConsistency:  DataConsistency(req.DataConsistency),
+		ByParentID:       req.ByParentId,
+		BySelectors:      bySelectorsFromV1(req.BySelectors),
+		BySpiffeID:       req.BySpiffeId,
+		Pagination:       paginationFromV1(req.Pagination),
+		ByFederatesWith:  byFederatesWithFromV1(req.ByFederatesWith),
+		ByHint:           req.ByHint,
+		ByDownstream:     req.ByDownstream,
+		BySpiffeIDPrefix: req.BySpiffeIdPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.ListRegistrationEntriesResponse{
+		Entries:    resp.Entries,
+		Pagination: paginationToV1(resp.Pagination),
+	}, nil
+}
+
+func (s *v1Server) ActivateRegistrationEntries(ctx context.Context, req *datastorev1.ActivateRegistrationEntriesRequest) (*datastorev1.ActivateRegistrationEntriesResponse, error) {
+	if err := s.ds.ActivateRegistrationEntries(ctx, timeFromV1(req.Since), timeFromV1(req.Until)); err != nil {
+		return nil, err
+	}
+	return &datastorev1.ActivateRegistrationEntriesResponse{}, nil
+}
+
+func (s *v1Server) PruneRegistrationEntries(ctx context.Context, req *datastorev1.PruneRegistrationEntriesRequest) (*datastorev1.PruneRegistrationEntriesResponse, error) {
+	if err := s.ds.PruneRegistrationEntries(ctx, timeFromV1(req.ExpiresBefore)); err != nil {
+		return nil, err
+	}
+	return &datastorev1.PruneRegistrationEntriesResponse{}, nil
+}
+
+func (s *v1Server) UpdateRegistrationEntry(ctx context.Context, req *datastorev1.UpdateRegistrationEntryRequest) (*datastorev1.UpdateRegistrationEntryResponse, error) {
+	entry, err := s.ds.UpdateRegistrationEntry(WithCallerID(ctx, req.CallerId), req.Entry, req.Mask)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.UpdateRegistrationEntryResponse{Entry: entry}, nil
+}
+
+func (s *v1Server) ListRegistrationEntryEvents(ctx context.Context, req *datastorev1.ListRegistrationEntryEventsRequest) (*datastorev1.ListRegistrationEntryEventsResponse, error) {
+	resp, err := s.ds.ListRegistrationEntryEvents(ctx, &ListRegistrationEntryEventsRequest{
+		DataConsistency:    DataConsistency(req.DataConsistency),
+		GreaterThanEventID: uint(req.GreaterThanEventId),
+		LessThanEventID:    uint(req.LessThanEventId),
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*datastorev1.RegistrationEntryEvent, 0, len(resp.Events))
+	for i := range resp.Events {
+		events = append(events, registrationEntryEventToV1(&resp.Events[i]))
+	}
+	return &datastorev1.ListRegistrationEntryEventsResponse{Events: events}, nil
+}
+
+func (s *v1Server) PruneRegistrationEntryEvents(ctx context.Context, req *datastorev1.PruneRegistrationEntryEventsRequest) (*datastorev1.PruneRegistrationEntryEventsResponse, error) {
+	if err := s.ds.PruneRegistrationEntryEvents(ctx, req.OlderThan.AsDuration()); err != nil {
+		return nil, err
+	}
+	return &datastorev1.PruneRegistrationEntryEventsResponse{}, nil
+}
+
+func (s *v1Server) FetchRegistrationEntryEvent(ctx context.Context, req *datastorev1.FetchRegistrationEntryEventRequest) (*datastorev1.FetchRegistrationEntryEventResponse, error) {
+	event, err := s.ds.FetchRegistrationEntryEvent(ctx, uint(req.EventId))
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.FetchRegistrationEntryEventResponse{Event: registrationEntryEventToV1(event)}, nil
+}
+
+func (s *v1Server) CreateRegistrationEntryEventForTesting(ctx context.Context, req *datastorev1.CreateRegistrationEntryEventForTestingRequest) (*datastorev1.CreateRegistrationEntryEventForTestingResponse, error) {
+	if err := s.ds.CreateRegistrationEntryEventForTesting(ctx, registrationEntryEventFromV1(req.Event)); err != nil {
+		return nil, err
+	}
+	return &datastorev1.CreateRegistrationEntryEventForTestingResponse{}, nil
+}
+
+func (s *v1Server) DeleteRegistrationEntryEventForTesting(ctx context.Context, req *datastorev1.DeleteRegistrationEntryEventForTestingRequest) (*datastorev1.DeleteRegistrationEntryEventForTestingResponse, error) {
+	if err := s.ds.DeleteRegistrationEntryEventForTesting(ctx, uint(req.EventId)); err != nil {
+		return nil, err
+	}
+	return &datastorev1.DeleteRegistrationEntryEventForTestingResponse{}, nil
+}
+
+func (s *v1Server) ListRegistrationEntryChanges(ctx context.Context, req *datastorev1.ListRegistrationEntryChangesRequest) (*datastorev1.ListRegistrationEntryChangesResponse, error) {
+	resp, err := s.ds.ListRegistrationEntryChanges(ctx, &ListRegistrationEntryChangesRequest{
+		ByEntryID:  req.ByEntryId,
+		Pagination: paginationFromV1(req.Pagination),
+	})
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*datastorev1.RegistrationEntryChange, 0, len(resp.Changes))
+	for _, change := range resp.Changes {
+		changes = append(changes, registrationEntryChangeToV1(change))
+	}
+	return &datastorev1.ListRegistrationEntryChangesResponse{
+		Changes:    changes,
+		Pagination: paginationToV1(resp.Pagination),
+	}, nil
+}
+
+func (s *v1Server) PruneRegistrationEntryChanges(ctx context.Context, req *datastorev1.PruneRegistrationEntryChangesRequest) (*datastorev1.PruneRegistrationEntryChangesResponse, error) {
+	if err := s.ds.PruneRegistrationEntryChanges(ctx, req.OlderThan.AsDuration()); err != nil {
+		return nil, err
+	}
+	return &datastorev1.PruneRegistrationEntryChangesResponse{}, nil
+}
+
+func (s *v1Server) CountAttestedNodes(ctx context.Context, req *datastorev1.CountAttestedNodesRequest) (*datastorev1.CountAttestedNodesResponse, error) {
+	count, err := s.ds.CountAttestedNodes(ctx, &CountAttestedNodesRequest{
+		ByAttestationType: req.ByAttestationType,
+		ByBanned:          req.ByBanned,
+		ByExpiresBefore:   timeFromV1(req.ByExpiresBefore),
+		BySelectorMatch:   bySelectorsFromV1(req.BySelectorMatch),
+		FetchSelectors:    req.FetchSelectors,
+		ByCanReattest:     req.ByCanReattest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.CountAttestedNodesResponse{Count: count}, nil
+}
+
+func (s *v1Server) CreateAttestedNode(ctx context.Context, req *datastorev1.CreateAttestedNodeRequest) (*datastorev1.CreateAttestedNodeResponse, error) {
+	node, err := s.ds.CreateAttestedNode(ctx, req.Node)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.CreateAttestedNodeResponse{Node: node}, nil
+}
+
+func (s *v1Server) DeleteAttestedNode(ctx context.Context, req *datastorev1.DeleteAttestedNodeRequest) (*datastorev1.DeleteAttestedNodeResponse, error) {
+	node, err := s.ds.DeleteAttestedNode(WithCallerID(ctx, req.CallerId), req.SpiffeId)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.DeleteAttestedNodeResponse{Node: node}, nil
+}
+
+func (s *v1Server) FetchAttestedNode(ctx context.Context, req *datastorev1.FetchAttestedNodeRequest) (*datastorev1.FetchAttestedNodeResponse, error) {
+	node, err := s.ds.FetchAttestedNode(ctx, req.SpiffeId)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.FetchAttestedNodeResponse{Node: node}, nil
+}
+
+func (s *v1Server) FetchAttestedNodes(ctx context.Context, req *datastorev1.FetchAttestedNodesRequest) (*datastorev1.FetchAttestedNodesResponse, error) {
+	nodes, err := s.ds.FetchAttestedNodes(ctx, req.SpiffeIds)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.FetchAttestedNodesResponse{Nodes: nodes}, nil
+}
+
+func (s *v1Server) ListAttestedNodes(ctx context.Context, req *datastorev1.ListAttestedNodesRequest) (*datastorev1.ListAttestedNodesResponse, error) {
+	resp, err := s.ds.ListAttestedNodes(ctx, &ListAttestedNodesRequest{
+		ByAttestationType: req.ByAttestationType,
+		ByBanned:          req.ByBanned,
+		ByExpiresBefore:   timeFromV1(req.ByExpiresBefore),
+		BySelectorMatch:   bySelectorsFromV1(req.BySelectorMatch),
+		BySpiffeIDs:       req.BySpiffeIds,
+		FetchSelectors:    req.FetchSelectors,
+		Pagination:        paginationFromV1(req.Pagination),
+		ByCanReattest:     req.ByCanReattest,
+		ValidAt:           timeFromV1(req.ValidAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.ListAttestedNodesResponse{
+		Nodes:      resp.Nodes,
+		Pagination: paginationToV1(resp.Pagination),
+	}, nil
+}
+
+func (s *v1Server) UpdateAttestedNode(ctx context.Context, req *datastorev1.UpdateAttestedNodeRequest) (*datastorev1.UpdateAttestedNodeResponse, error) {
+	node, err := s.ds.UpdateAttestedNode(ctx, req.Node, req.Mask)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.UpdateAttestedNodeResponse{Node: node}, nil
+}
+
+func (s *v1Server) PruneAttestedExpiredNodes(ctx context.Context, req *datastorev1.PruneAttestedExpiredNodesRequest) (*datastorev1.PruneAttestedExpiredNodesResponse, error) {
+	if err := s.ds.PruneAttestedExpiredNodes(ctx, timeFromV1(req.ExpiredBefore), req.IncludeNonReattestable, int(req.BatchSize)); err != nil {
+		return nil, err
+	}
+	return &datastorev1.PruneAttestedExpiredNodesResponse{}, nil
+}
+
+func (s *v1Server) ListAttestedNodeEvents(ctx context.Context, req *datastorev1.ListAttestedNodeEventsRequest) (*datastorev1.ListAttestedNodeEventsResponse, error) {
+	resp, err := s.ds.ListAttestedNodeEvents(ctx, &ListAttestedNodeEventsRequest{
+		DataConsistency:    DataConsistency(req.DataConsistency),
+		GreaterThanEventID: uint(req.GreaterThanEventId),
+		LessThanEventID:    uint(req.LessThanEventId),
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*datastorev1.AttestedNodeEvent, 0, len(resp.Events))
+	for i := range resp.Events {
+		events = append(events, attestedNodeEventToV1(&resp.Events[i]))
+	}
+	return &datastorev1.ListAttestedNodeEventsResponse{Events: events}, nil
+}
+
+func (s *v1Server) PruneAttestedNodeEvents(ctx context.Context, req *datastorev1.PruneAttestedNodeEventsRequest) (*datastorev1.PruneAttestedNodeEventsResponse, error) {
+	if err := s.ds.PruneAttestedNodeEvents(ctx, req.OlderThan.AsDuration()); err != nil {
+		return nil, err
+	}
+	return &datastorev1.PruneAttestedNodeEventsResponse{}, nil
+}
+
+func (s *v1Server) FetchAttestedNodeEvent(ctx context.Context, req *datastorev1.FetchAttestedNodeEventRequest) (*datastorev1.FetchAttestedNodeEventResponse, error) {
+	event, err := s.ds.FetchAttestedNodeEvent(ctx, uint(req.EventId))
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.FetchAttestedNodeEventResponse{Event: attestedNodeEventToV1(event)}, nil
+}
+
+func (s *v1Server) CreateAttestedNodeEventForTesting(ctx context.Context, req *datastorev1.CreateAttestedNodeEventForTestingRequest) (*datastorev1.CreateAttestedNodeEventForTestingResponse, error) {
+	if err := s.ds.CreateAttestedNodeEventForTesting(ctx, attestedNodeEventFromV1(req.Event)); err != nil {
+		return nil, err
+	}
+	return &datastorev1.CreateAttestedNodeEventForTestingResponse{}, nil
+}
+
+func (s *v1Server) DeleteAttestedNodeEventForTesting(ctx context.Context, req *datastorev1.DeleteAttestedNodeEventForTestingRequest) (*datastorev1.DeleteAttestedNodeEventForTestingResponse, error) {
+	if err := s.ds.DeleteAttestedNodeEventForTesting(ctx, uint(req.EventId)); err != nil {
+		return nil, err
+	}
+	return &datastorev1.DeleteAttestedNodeEventForTestingResponse{}, nil
+}
+
+func (s *v1Server) GetNodeSelectors(ctx context.Context, req *datastorev1.GetNodeSelectorsRequest) (*datastorev1.GetNodeSelectorsResponse, error) {
+	selectors, err := s.ds.GetNodeSelectors(ctx, req.SpiffeId, DataConsistency(req.DataConsistency))
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.GetNodeSelectorsResponse{Selectors: selectors}, nil
+}
+
+func (s *v1Server) ListNodeSelectors(ctx context.Context, req *datastorev1.ListNodeSelectorsRequest) (*datastorev1.ListNodeSelectorsResponse, error) {
+	resp, err := s.ds.ListNodeSelectors(ctx, &ListNodeSelectorsRequest{
+		DataConsistency: DataConsistency(req.DataConsistency),
+		ValidAt:         timeFromV1(req.ValidAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+	selectors := make(map[string]*common.Selectors, len(resp.Selectors))
+	for spiffeID, nodeSelectors := range resp.Selectors {
+		selectors[spiffeID] = &common.Selectors{Entries: nodeSelectors}
+	}
+	return &datastorev1.ListNodeSelectorsResponse{Selectors: selectors}, nil
+}
+
+func (s *v1Server) SetNodeSelectors(ctx context.Context, req *datastorev1.SetNodeSelectorsRequest) (*datastorev1.SetNodeSelectorsResponse, error) {
+	if err := s.ds.SetNodeSelectors(ctx, req.SpiffeId, req.Selectors); err != nil {
+		return nil, err
+	}
+	return &datastorev1.SetNodeSelectorsResponse{}, nil
+}
+
+func (s *v1Server) CreateJoinToken(ctx context.Context, req *datastorev1.CreateJoinTokenRequest) (*datastorev1.CreateJoinTokenResponse, error) {
+	if err := s.ds.CreateJoinToken(ctx, joinTokenFromV1(req.JoinToken)); err != nil {
+		return nil, err
+	}
+	return &datastorev1.CreateJoinTokenResponse{}, nil
+}
+
+func (s *v1Server) DeleteJoinToken(ctx context.Context, req *datastorev1.DeleteJoinTokenRequest) (*datastorev1.DeleteJoinTokenResponse, error) {
+	if err := s.ds.DeleteJoinToken(ctx, req.Token); err != nil {
+		return nil, err
+	}
+	return &datastorev1.DeleteJoinTokenResponse{}, nil
+}
+
+func (s *v1Server) FetchJoinToken(ctx context.Context, req *datastorev1.FetchJoinTokenRequest) (*datastorev1.FetchJoinTokenResponse, error) {
+	token, err := s.ds.FetchJoinToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.FetchJoinTokenResponse{JoinToken: joinTokenToV1(token)}, nil
+}
+
+func (s *v1Server) ListJoinTokens(ctx context.Context, req *datastorev1.ListJoinTokensRequest) (*datastorev1.ListJoinTokensResponse, error) {
+	resp, err := s.ds.ListJoinTokens(ctx, &ListJoinTokensRequest{
+		Pagination: paginationFromV1(req.Pagination),
+	})
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]*datastorev1.JoinToken, 0, len(resp.JoinTokens))
+	for _, token := range resp.JoinTokens {
+		tokens = append(tokens, joinTokenToV1(token))
+	}
+	return &datastorev1.ListJoinTokensResponse{
+		JoinTokens: tokens,
+		Pagination: paginationToV1(resp.Pagination),
+	}, nil
+}
+
+func (s *v1Server) PruneJoinTokens(ctx context.Context, req *datastorev1.PruneJoinTokensRequest) (*datastorev1.PruneJoinTokensResponse, error) {
+	if err := s.ds.PruneJoinTokens(ctx, timeFromV1(req.ExpiresBefore)); err != nil {
+		return nil, err
+	}
+	return &datastorev1.PruneJoinTokensResponse{}, nil
+}
+
+func (s *v1Server) CreateFederationRelationship(ctx context.Context, req *datastorev1.CreateFederationRelationshipRequest) (*datastorev1.CreateFederationRelationshipResponse, error) {
+	fr, err := federationRelationshipFromV1(req.FederationRelationship)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid federation relationship: %v", err)
+	}
+	fr, err = s.ds.CreateFederationRelationship(ctx, fr)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.CreateFederationRelationshipResponse{FederationRelationship: federationRelationshipToV1(fr)}, nil
+}
+
+func (s *v1Server) FetchFederationRelationship(ctx context.Context, req *datastorev1.FetchFederationRelationshipRequest) (*datastorev1.FetchFederationRelationshipResponse, error) {
+	td, err := trustDomainFromV1(req.TrustDomain)
+	if err != nil {
+		return nil, err
+	}
+	fr, err := s.ds.FetchFederationRelationship(ctx, td)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.FetchFederationRelationshipResponse{FederationRelationship: federationRelationshipToV1(fr)}, nil
+}
+
+func (s *v1Server) ListFederationRelationships(ctx context.Context, req *datastorev1.ListFederationRelationshipsRequest) (*datastorev1.ListFederationRelationshipsResponse, error) {
+	resp, err := s.ds.ListFederationRelationships(ctx, &ListFederationRelationshipsRequest{
+		Pagination: paginationFromV1(req.Pagination),
+	})
+	if err != nil {
+		return nil, err
+	}
+	frs := make([]*datastorev1.FederationRelationship, 0, len(resp.FederationRelationships))
+	for _, fr := range resp.FederationRelationships {
+		frs = append(frs, federationRelationshipToV1(fr))
+	}
+	return &datastorev1.ListFederationRelationshipsResponse{
+		FederationRelationships: frs,
+		Pagination:              paginationToV1(resp.Pagination),
+	}, nil
+}
+
+func (s *v1Server) DeleteFederationRelationship(ctx context.Context, req *datastorev1.DeleteFederationRelationshipRequest) (*datastorev1.DeleteFederationRelationshipResponse, error) {
+	td, err := trustDomainFromV1(req.TrustDomain)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ds.DeleteFederationRelationship(ctx, td); err != nil {
+		return nil, err
+	}
+	return &datastorev1.DeleteFederationRelationshipResponse{}, nil
+}
+
+func (s *v1Server) UpdateFederationRelationship(ctx context.Context, req *datastorev1.UpdateFederationRelationshipRequest) (*datastorev1.UpdateFederationRelationshipResponse, error) {
+	fr, err := federationRelationshipFromV1(req.FederationRelationship)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid federation relationship: %v", err)
+	}
+	var mask *types.FederationRelationshipMask
+	if req.Mask != nil {
+		mask = &types.FederationRelationshipMask{
+			BundleEndpointUrl:     req.Mask.BundleEndpointUrl,
+			BundleEndpointProfile: req.Mask.BundleEndpointProfile,
+			TrustDomainBundle:     req.Mask.TrustDomainBundle,
+		}
+	}
+	fr, err = s.ds.UpdateFederationRelationship(ctx, fr, mask)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.UpdateFederationRelationshipResponse{FederationRelationship: federationRelationshipToV1(fr)}, nil
+}
+
+func (s *v1Server) SetCAJournal(ctx context.Context, req *datastorev1.SetCAJournalRequest) (*datastorev1.SetCAJournalResponse, error) {
+	caJournal, err := s.ds.SetCAJournal(ctx, caJournalFromV1(req.CaJournal))
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.SetCAJournalResponse{CaJournal: caJournalToV1(caJournal)}, nil
+}
+
+func (s *v1Server) FetchCAJournal(ctx context.Context, req *datastorev1.FetchCAJournalRequest) (*datastorev1.FetchCAJournalResponse, error) {
+	caJournal, err := s.ds.FetchCAJournal(ctx, req.ActiveX509AuthorityId)
+	if err != nil {
+		return nil, err
+	}
+	return &datastorev1.FetchCAJournalResponse{CaJournal: caJournalToV1(caJournal)}, nil
+}
+
+func (s *v1Server) PruneCAJournals(ctx context.Context, req *datastorev1.PruneCAJournalsRequest) (*datastorev1.PruneCAJournalsResponse, error) {
+	if err := s.ds.PruneCAJournals(ctx, req.AllCasExpireBefore); err != nil {
+		return nil, err
+	}
+	return &datastorev1.PruneCAJournalsResponse{}, nil
+}
+
+func (s *v1Server) ListCAJournals(ctx context.Context, req *datastorev1.ListCAJournalsRequest) (*datastorev1.ListCAJournalsResponse, error) {
+	resp, err := s.ds.ListCAJournals(ctx, &ListCAJournalsRequest{
+		Pagination: paginationFromV1(req.Pagination),
+	})
+	if err != nil {
+		return nil, err
+	}
+	pbCAJournals := make([]*datastorev1.CAJournal, 0, len(resp.CAJournals))
+	for _, caJournal := range resp.CAJournals {
+		pbCAJournals = append(pbCAJournals, caJournalToV1(caJournal))
+	}
+	return &datastorev1.ListCAJournalsResponse{
+		CaJournals: pbCAJournals,
+		Pagination: paginationToV1(resp.Pagination),
+	}, nil
+}
+
+func (s *v1Server) ListCAJournalsForTesting(ctx context.Context, _ *datastorev1.ListCAJournalsForTestingRequest) (*datastorev1.ListCAJournalsForTestingResponse, error) {
+	caJournals, err := s.ds.ListCAJournalsForTesting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pbCAJournals := make([]*datastorev1.CAJournal, 0, len(caJournals))
+	for _, caJournal := range caJournals {
+		pbCAJournals = append(pbCAJournals, caJournalToV1(caJournal))
+	}
+	return &datastorev1.ListCAJournalsForTestingResponse{CaJournals: pbCAJournals}, nil
+}
+
+func (s *v1Server) ExportRecords(_ *datastorev1.ExportRecordsRequest, stream datastorev1.DataStore_ExportRecordsServer) error {
+	return s.ds.ExportRecords(stream.Context(), func(record *ExportRecord) error {
+		resp := new(datastorev1.ExportRecordsResponse)
+		switch {
+		case record.Bundle != nil:
+			resp.Record = &datastorev1.ExportRecordsResponse_Bundle{Bundle: record.Bundle}
+		case record.FederationRelationship != nil:
+			resp.Record = &datastorev1.ExportRecordsResponse_FederationRelationship{FederationRelationship: federationRelationshipToV1(record.FederationRelationship)}
+		case record.RegistrationEntry != nil:
+			resp.Record = &datastorev1.ExportRecordsResponse_RegistrationEntry{RegistrationEntry: record.RegistrationEntry}
+		case record.AttestedNode != nil:
+			resp.Record = &datastorev1.ExportRecordsResponse_AttestedNode{AttestedNode: record.AttestedNode}
+		case record.JoinToken != nil:
+			resp.Record = &datastorev1.ExportRecordsResponse_JoinToken{JoinToken: joinTokenToV1(record.JoinToken)}
+		case record.CAJournal != nil:
+			resp.Record = &datastorev1.ExportRecordsResponse_CaJournal{CaJournal: caJournalToV1(record.CAJournal)}
+		default:
+			return status.Error(codes.Internal, "export record is empty")
+		}
+		return stream.Send(resp)
+	})
+}
+
+// trustDomainFromV1 parses a trust domain name. An empty name is passed to
+// the DataStore as the zero trust domain so that it can report the error.
+func trustDomainFromV1(name string) (spiffeid.TrustDomain, error) {
+	if name == "" {
+		return spiffeid.TrustDomain{}, nil
+	}
+	td, err := spiffeid.TrustDomainFromString(name)
+	if err != nil {
+		return spiffeid.TrustDomain{}, status.Errorf(codes.InvalidArgument, "invalid trust domain: %v", err)
+	}
+	return td, nil
+}
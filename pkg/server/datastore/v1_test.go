@@ -0,0 +1,40 @@
+package datastore_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/datastore/sqlstore"
+	datastoretest "github.com/spiffe/spire/pkg/server/datastore/test"
+	datastorev1 "github.com/spiffe/spire/proto/spire/plugin/server/datastore/v1"
+	"github.com/spiffe/spire/test/plugintest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestV1(t *testing.T) {
+	// Run the conformance suite against the SQL datastore served over the
+	// plugin interface to exercise the conversions on both sides.
+	datastoretest.Test(t, datastoretest.Config{
+		Create: func(t *testing.T) datastore.DataStore {
+			log, _ := test.NewNullLogger()
+			sqlStore := sqlstore.New(log)
+			err := sqlStore.Configure(context.Background(), fmt.Sprintf(`
+				database_type = "sqlite3"
+				connection_string = %q
+			`, filepath.ToSlash(filepath.Join(t.TempDir(), "db.sqlite3"))))
+			require.NoError(t, err)
+			t.Cleanup(func() { sqlStore.Close() })
+
+			server := datastorev1.DataStorePluginServer(datastore.V1Server(sqlStore))
+
+			v1 := new(datastore.V1)
+			plugintest.Load(t, catalog.MakeBuiltIn("test", server), v1)
+			return v1
+		},
+	})
+}
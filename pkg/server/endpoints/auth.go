@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -67,7 +68,7 @@ func (e *Endpoints) bundleGetter(ctx context.Context, td spiffeid.TrustDomain) (
 func (e *Endpoints) serverSpiffeVerificationFunc(bundleSource x509bundle.Source) func(_ [][]byte, _ [][]*x509.Certificate) error {
 	verifyPeerCertificate := tlsconfig.VerifyPeerCertificate(
 		bundleSource,
-		tlsconfig.AdaptMatcher(matchMemberOrOneOf(e.TrustDomain, e.AdminIDs...)),
+		tlsconfig.AdaptMatcher(matchMemberOrOneOf(e.TrustDomain, slices.Concat(e.AdminIDs, e.Tenants.AdminIDs())...)),
 	)
 
 	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
@@ -80,7 +81,7 @@ func (e *Endpoints) serverSpiffeVerificationFunc(bundleSource x509bundle.Source)
 }
 
 // matchMemberOrOneOf is a custom spiffeid.Matcher which will validate that the peerSpiffeID belongs to the server
-// trust domain or if it is included in the admin_ids configuration permissive list.
+// trust domain or if it is included in the admin_ids (or tenant admin_ids) configuration permissive list.
 func matchMemberOrOneOf(trustDomain spiffeid.TrustDomain, adminIds ...spiffeid.ID) spiffeid.Matcher {
 	permissiveIDsSet := make(map[spiffeid.ID]struct{})
 	for _, adminID := range adminIds {
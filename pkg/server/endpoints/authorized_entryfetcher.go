@@ -30,6 +30,7 @@ type AuthorizedEntryFetcherEventsConfig struct {
 	ds                      datastore.DataStore
 	nodeCache               *nodecache.Cache
 	metrics                 telemetry.Metrics
+	entryTemplates          []*authorizedentries.EntryTemplate
 }
 
 type AuthorizedEntryFetcherEvents struct {
@@ -143,7 +144,7 @@ func (a *AuthorizedEntryFetcherEvents) updateCache(ctx context.Context) error {
 }
 
 func (a *AuthorizedEntryFetcherEvents) reloadCache(ctx context.Context) error {
-	cache := authorizedentries.NewCache(a.c.clk, a.trustDomain)
+	cache := a.newCache()
 
 	if err := a.registrationEntries.loadCache(ctx, cache); err != nil {
 		return err
@@ -164,7 +165,7 @@ func (a *AuthorizedEntryFetcherEvents) reloadCache(ctx context.Context) error {
 }
 
 func (a *AuthorizedEntryFetcherEvents) buildCache(ctx context.Context) error {
-	cache := authorizedentries.NewCache(a.c.clk, a.trustDomain)
+	cache := a.newCache()
 
 	registrationEntries, err := buildRegistrationEntriesCache(ctx, a.c.log, a.c.metrics, a.c.ds, a.c.clk, cache, pageSize, a.c.cacheReloadInterval, a.c.eventTimeout)
 	if err != nil {
@@ -186,6 +187,12 @@ func (a *AuthorizedEntryFetcherEvents) buildCache(ctx context.Context) error {
 	return nil
 }
 
+func (a *AuthorizedEntryFetcherEvents) newCache() *authorizedentries.Cache {
+	cache := authorizedentries.NewCache(a.c.clk, a.trustDomain)
+	cache.SetEntryTemplates(a.c.entryTemplates)
+	return cache
+}
+
 func (a *AuthorizedEntryFetcherEvents) startTickers() (*clock.Ticker, *clock.Ticker) {
 	cacheReloadTicker := a.c.clk.Ticker(a.c.cacheReloadInterval)
 	fullCacheReloadTicker := a.c.clk.Ticker(a.c.fullCacheReloadInterval)
@@ -15,6 +15,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/authorizedentries"
 	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -156,16 +157,29 @@ func (a *registrationEntries) loadCache(ctx context.Context, cache *authorizeden
 			break
 		}
 
-		entries, err := api.RegistrationEntriesToProto(resp.Entries)
-		if err != nil {
-			return fmt.Errorf("failed to convert registration entries: %w", err)
+		for _, commonEntry := range a.filterInactiveEntries(resp.Entries) {
+			entry, err := api.RegistrationEntryToProto(commonEntry)
+			if err != nil {
+				return fmt.Errorf("failed to convert registration entries: %w", err)
+			}
+			cache.UpdateEntry(entry, commonEntry.GetAdditionalAttributes().GetCredentialProfile())
 		}
+	}
+	return nil
+}
 
-		for _, entry := range entries {
-			cache.UpdateEntry(entry)
+// filterInactiveEntries filters out the entries that are not active yet or
+// have expired. The registration manager creates an event for the entries as
+// they become active, which adds them to the cache.
+func (a *registrationEntries) filterInactiveEntries(in []*common.RegistrationEntry) []*common.RegistrationEntry {
+	now := a.clk.Now()
+	out := make([]*common.RegistrationEntry, 0, len(in))
+	for _, entry := range in {
+		if api.RegistrationEntryIsActive(entry, now) {
+			out = append(out, entry)
 		}
 	}
-	return nil
+	return out
 }
 
 // buildRegistrationEntriesCache Fetches all registration entries and adds them to the cache
@@ -239,6 +253,12 @@ func (a *registrationEntries) updateCachedEntries(ctx context.Context) error {
 				continue
 			}
 
+			if !api.RegistrationEntryIsActive(commonEntry, a.clk.Now()) {
+				a.cache.RemoveEntry(entryId)
+				delete(a.fetchEntries, entryId)
+				continue
+			}
+
 			entry, err := api.RegistrationEntryToProto(commonEntry)
 			if err != nil {
 				a.cache.RemoveEntry(entryId)
@@ -247,7 +267,7 @@ func (a *registrationEntries) updateCachedEntries(ctx context.Context) error {
 				continue
 			}
 
-			a.cache.UpdateEntry(entry)
+			a.cache.UpdateEntry(entry, commonEntry.GetAdditionalAttributes().GetCredentialProfile())
 			delete(a.fetchEntries, entryId)
 		}
 	}
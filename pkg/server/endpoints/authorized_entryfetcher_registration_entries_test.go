@@ -1940,6 +1940,49 @@ func TestUpdateRegistrationEntriesCache(t *testing.T) {
 	}
 }
 
+func TestRegistrationEntryActivation(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	notBefore := now.Add(time.Hour)
+	pendingEntry := &common.RegistrationEntry{
+		EntryId:  "6837984a-bc44-462b-9ca6-5cd59be35066",
+		ParentId: "spiffe://example.org/test_node_1",
+		SpiffeId: "spiffe://example.org/test_job_1",
+		Selectors: []*common.Selector{
+			{Type: "testjob", Value: "1"},
+		},
+		NotBefore: notBefore.Unix(),
+	}
+	expiredEntry := &common.RegistrationEntry{
+		EntryId:  "354c16f4-4e61-4c17-8596-7baa7744d504",
+		ParentId: "spiffe://example.org/test_node_1",
+		SpiffeId: "spiffe://example.org/test_job_4",
+		Selectors: []*common.Selector{
+			{Type: "testjob", Value: "4"},
+		},
+		EntryExpiry: now.Add(-time.Hour).Unix(),
+	}
+
+	scenario := NewEntryScenario(t, &entryScenarioSetup{
+		pageSize:            1024,
+		registrationEntries: append(slices.Clone(defaultRegistrationEntries), pendingEntry, expiredEntry),
+	})
+	registeredEntries, err := scenario.buildRegistrationEntriesCache()
+	require.NoError(t, err)
+
+	// The entries that are not active yet or have expired are not loaded
+	require.Equal(t, 2, registeredEntries.cache.Stats().EntriesByEntryID)
+
+	// The entry is not added to the cache when the not-before time is
+	// reached, but when the activation event is seen.
+	scenario.clk.Set(notBefore)
+	require.NoError(t, registeredEntries.updateCache(scenario.ctx))
+	require.Equal(t, 2, registeredEntries.cache.Stats().EntriesByEntryID)
+
+	require.NoError(t, scenario.ds.ActivateRegistrationEntries(scenario.ctx, now, notBefore))
+	require.NoError(t, registeredEntries.updateCache(scenario.ctx))
+	require.Equal(t, 3, registeredEntries.cache.Stats().EntriesByEntryID)
+}
+
 type entryScenario struct {
 	ctx      context.Context
 	log      *logrus.Logger
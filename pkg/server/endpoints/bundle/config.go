@@ -18,4 +18,8 @@ type EndpointConfig struct {
 	DiskCertManager *diskcertmanager.DiskCertManager
 
 	RefreshHint time.Duration
+
+	// OIDCDiscovery, if set, enables serving the OIDC discovery document and
+	// the JWKS of the local trust domain alongside the bundle.
+	OIDCDiscovery *OIDCDiscoveryConfig
 }
@@ -0,0 +1,167 @@
+package bundle
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	oidcKeysPath      = "/keys"
+)
+
+// OIDCDiscoveryConfig is the configuration of the OIDC discovery documents
+// served by the bundle endpoint.
+type OIDCDiscoveryConfig struct {
+	// Issuer is the issuer advertised in the discovery document. If unset,
+	// the issuer is derived from the host of the request, which must be one
+	// of Domains. The discovery document and the JWKS are served under the
+	// issuer path, as relying parties look them up there.
+	Issuer *url.URL
+
+	// Domains are the hosts the issuer can be derived from when Issuer is
+	// unset. Requests for any other host are rejected, since the host is
+	// supplied by the client.
+	Domains []string
+
+	// JWTAuthorities and WITAuthorities select the authorities, by the type
+	// of SVID they sign, that are published in the JWKS.
+	JWTAuthorities bool
+	WITAuthorities bool
+}
+
+// KeysGetter returns the local bundle, including the WIT authorities, which
+// SPIFFE bundles do not carry.
+type KeysGetter interface {
+	GetKeys(ctx context.Context) (*common.Bundle, error)
+}
+
+type KeysGetterFunc func(ctx context.Context) (*common.Bundle, error)
+
+func (fn KeysGetterFunc) GetKeys(ctx context.Context) (*common.Bundle, error) {
+	return fn(ctx)
+}
+
+func (s *Server) serveOIDCDiscovery(w http.ResponseWriter, req *http.Request) {
+	issuer := s.c.OIDCDiscovery.Issuer
+	if issuer == nil {
+		if !s.isOIDCDomainAllowed(req.Host) {
+			http.Error(w, "400 domain not allowed", http.StatusBadRequest)
+			return
+		}
+		issuer = &url.URL{Scheme: "https", Host: req.Host}
+	}
+	jwksURI := issuer.JoinPath(oidcKeysPath)
+
+	doc := struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+
+		// The following are required fields, set based on SPIRE capabilities.
+		AuthorizationEndpoint            string   `json:"authorization_endpoint"`
+		ResponseTypesSupported           []string `json:"response_types_supported"`
+		SubjectTypesSupported            []string `json:"subject_types_supported"`
+		IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	}{
+		Issuer:  issuer.String(),
+		JWKSURI: jwksURI.String(),
+
+		AuthorizationEndpoint:            "",
+		ResponseTypesSupported:           []string{"id_token"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{"RS256", "ES256", "ES384"},
+	}
+
+	s.writeJSON(w, doc)
+}
+
+// oidcPath returns the request path of an OIDC discovery resource, under the
+// path of the configured issuer if any.
+func (s *Server) oidcPath(resourcePath string) string {
+	if s.c.OIDCDiscovery.Issuer == nil {
+		return resourcePath
+	}
+	return strings.TrimSuffix(s.c.OIDCDiscovery.Issuer.Path, "/") + resourcePath
+}
+
+// isOIDCDomainAllowed returns whether the issuer can be derived from the given
+// request host, which may be in host or host:port form.
+func (s *Server) isOIDCDomainAllowed(host string) bool {
+	domain, _, err := net.SplitHostPort(host)
+	if err != nil {
+		domain = host
+	}
+	for _, allowed := range s.c.OIDCDiscovery.Domains {
+		if strings.EqualFold(domain, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) serveOIDCKeys(w http.ResponseWriter, req *http.Request) {
+	b, err := s.c.KeysGetter.GetKeys(req.Context())
+	if err != nil {
+		s.c.Log.WithError(err).Error("Unable to retrieve local bundle")
+		http.Error(w, "500 unable to retrieve local bundle", http.StatusInternalServerError)
+		return
+	}
+
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	if s.c.OIDCDiscovery.JWTAuthorities {
+		jwks.Keys = s.appendJWKs(jwks.Keys, b.JwtSigningKeys)
+	}
+	if s.c.OIDCDiscovery.WITAuthorities {
+		jwks.Keys = s.appendJWKs(jwks.Keys, b.WitSigningKeys)
+	}
+
+	s.writeJSON(w, jwks)
+}
+
+func (s *Server) appendJWKs(jwks []jose.JSONWebKey, keys []*common.PublicKey) []jose.JSONWebKey {
+	for _, key := range keys {
+		log := s.c.Log.WithFields(logrus.Fields{
+			telemetry.Kid: key.Kid,
+		})
+		publicKey, err := x509.ParsePKIXPublicKey(key.PkixBytes)
+		if err != nil {
+			log.WithError(err).Error("Failed to parse authority public key")
+			continue
+		}
+		alg, err := cryptoutil.JoseAlgFromPublicKey(publicKey)
+		if err != nil {
+			log.WithError(err).Error("Failed to get public key algorithm")
+			continue
+		}
+		jwks = append(jwks, jose.JSONWebKey{
+			Key:       publicKey,
+			KeyID:     key.Kid,
+			Algorithm: string(alg),
+			Use:       "sig",
+		})
+	}
+	return jwks
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, v any) {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		s.c.Log.WithError(err).Error("Unable to marshal OIDC discovery document")
+		http.Error(w, "500 unable to marshal OIDC discovery document", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(jsonBytes)
+}
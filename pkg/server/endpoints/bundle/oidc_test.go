@@ -0,0 +1,196 @@
+package bundle
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCDiscovery(t *testing.T) {
+	serverCert, serverKey := createServerCertificate(t)
+
+	jwtKey := testkey.NewEC256(t)
+	jwtPKIX, err := x509.MarshalPKIXPublicKey(jwtKey.Public())
+	require.NoError(t, err)
+	witKey := testkey.NewEC384(t)
+	witPKIX, err := x509.MarshalPKIXPublicKey(witKey.Public())
+	require.NoError(t, err)
+
+	commonBundle := &common.Bundle{
+		TrustDomainId:  "spiffe://domain.test",
+		JwtSigningKeys: []*common.PublicKey{{Kid: "jwt-kid", PkixBytes: jwtPKIX}},
+		WitSigningKeys: []*common.PublicKey{{Kid: "wit-kid", PkixBytes: witPKIX}},
+	}
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(serverCert)
+	client := http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    rootCAs,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		path          string
+		oidcDiscovery *OIDCDiscoveryConfig
+		bundle        *common.Bundle
+		status        int
+		expectDoc     func(addr string) map[string]any
+		expectKIDs    []string
+	}{
+		{
+			name:          "discovery document with configured issuer",
+			path:          "/.well-known/openid-configuration",
+			oidcDiscovery: &OIDCDiscoveryConfig{Issuer: &url.URL{Scheme: "https", Host: "oidc.example.org"}, JWTAuthorities: true},
+			status:        http.StatusOK,
+			expectDoc: func(string) map[string]any {
+				return map[string]any{
+					"issuer":   "https://oidc.example.org",
+					"jwks_uri": "https://oidc.example.org/keys",
+				}
+			},
+		},
+		{
+			name:          "discovery document under the issuer path",
+			path:          "/tenant-a/.well-known/openid-configuration",
+			oidcDiscovery: &OIDCDiscoveryConfig{Issuer: &url.URL{Scheme: "https", Host: "oidc.example.org", Path: "/tenant-a"}, JWTAuthorities: true},
+			status:        http.StatusOK,
+			expectDoc: func(string) map[string]any {
+				return map[string]any{
+					"issuer":   "https://oidc.example.org/tenant-a",
+					"jwks_uri": "https://oidc.example.org/tenant-a/keys",
+				}
+			},
+		},
+		{
+			name:          "keys under the issuer path",
+			path:          "/tenant-a/keys",
+			oidcDiscovery: &OIDCDiscoveryConfig{Issuer: &url.URL{Scheme: "https", Host: "oidc.example.org", Path: "/tenant-a"}, JWTAuthorities: true},
+			bundle:        commonBundle,
+			status:        http.StatusOK,
+			expectKIDs:    []string{"jwt-kid"},
+		},
+		{
+			name:          "discovery document outside of the issuer path",
+			path:          "/.well-known/openid-configuration",
+			oidcDiscovery: &OIDCDiscoveryConfig{Issuer: &url.URL{Scheme: "https", Host: "oidc.example.org", Path: "/tenant-a"}, JWTAuthorities: true},
+			status:        http.StatusNotFound,
+		},
+		{
+			name:          "discovery document with issuer from request",
+			path:          "/.well-known/openid-configuration",
+			oidcDiscovery: &OIDCDiscoveryConfig{Domains: []string{"127.0.0.1"}, JWTAuthorities: true},
+			status:        http.StatusOK,
+			expectDoc: func(addr string) map[string]any {
+				return map[string]any{
+					"issuer":   "https://" + addr,
+					"jwks_uri": "https://" + addr + "/keys",
+				}
+			},
+		},
+		{
+			name:          "discovery document with request host not allowed",
+			path:          "/.well-known/openid-configuration",
+			oidcDiscovery: &OIDCDiscoveryConfig{Domains: []string{"oidc.example.org"}, JWTAuthorities: true},
+			status:        http.StatusBadRequest,
+		},
+		{
+			name:          "JWT authorities",
+			path:          "/keys",
+			oidcDiscovery: &OIDCDiscoveryConfig{JWTAuthorities: true},
+			bundle:        commonBundle,
+			status:        http.StatusOK,
+			expectKIDs:    []string{"jwt-kid"},
+		},
+		{
+			name:          "WIT authorities",
+			path:          "/keys",
+			oidcDiscovery: &OIDCDiscoveryConfig{WITAuthorities: true},
+			bundle:        commonBundle,
+			status:        http.StatusOK,
+			expectKIDs:    []string{"wit-kid"},
+		},
+		{
+			name:          "JWT and WIT authorities",
+			path:          "/keys",
+			oidcDiscovery: &OIDCDiscoveryConfig{JWTAuthorities: true, WITAuthorities: true},
+			bundle:        commonBundle,
+			status:        http.StatusOK,
+			expectKIDs:    []string{"jwt-kid", "wit-kid"},
+		},
+		{
+			name:          "fail to retrieve keys",
+			path:          "/keys",
+			oidcDiscovery: &OIDCDiscoveryConfig{JWTAuthorities: true},
+			status:        http.StatusInternalServerError,
+		},
+		{
+			name:   "not enabled",
+			path:   "/.well-known/openid-configuration",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bundle := spiffebundle.New(spiffeid.RequireTrustDomainFromString("domain.test"))
+			addr, done := newTestServerWithConfig(t, ServerConfig{
+				Getter:        testGetter(bundle),
+				ServerAuth:    testSPIFFEAuth(serverCert, serverKey),
+				OIDCDiscovery: testCase.oidcDiscovery,
+				KeysGetter: KeysGetterFunc(func(context.Context) (*common.Bundle, error) {
+					if testCase.bundle == nil {
+						return nil, errors.New("no bundle configured")
+					}
+					return testCase.bundle, nil
+				}),
+			})
+			defer done()
+
+			resp, err := client.Get(fmt.Sprintf("https://%s%s", addr, testCase.path))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, testCase.status, resp.StatusCode)
+
+			switch {
+			case testCase.expectDoc != nil:
+				doc := make(map[string]any)
+				require.NoError(t, json.Unmarshal(body, &doc))
+				for key, value := range testCase.expectDoc(addr.String()) {
+					require.Equal(t, value, doc[key], key)
+				}
+				require.Equal(t, []any{"id_token"}, doc["response_types_supported"])
+			case testCase.expectKIDs != nil:
+				var jwks jose.JSONWebKeySet
+				require.NoError(t, json.Unmarshal(body, &jwks))
+				var kids []string
+				for _, key := range jwks.Keys {
+					kids = append(kids, key.KeyID)
+					require.Equal(t, "sig", key.Use)
+					require.NotEmpty(t, key.Algorithm)
+				}
+				require.Equal(t, testCase.expectKIDs, kids)
+			}
+		})
+	}
+}
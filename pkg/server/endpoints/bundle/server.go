@@ -36,6 +36,11 @@ type ServerConfig struct {
 	RefreshHint time.Duration
 	TLSPolicy   tlspolicy.Policy
 
+	// OIDCDiscovery, if set, enables serving the OIDC discovery document and
+	// the JWKS, whose keys are returned by KeysGetter.
+	OIDCDiscovery *OIDCDiscoveryConfig
+	KeysGetter    KeysGetter
+
 	// test hooks
 	listen func(network, address string) (net.Listener, error)
 }
@@ -101,6 +106,16 @@ func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if s.c.OIDCDiscovery != nil {
+		switch req.URL.Path {
+		case s.oidcPath(oidcDiscoveryPath):
+			s.serveOIDCDiscovery(w, req)
+			return
+		case s.oidcPath(oidcKeysPath):
+			s.serveOIDCKeys(w, req)
+			return
+		}
+	}
 	if req.URL.Path != "/" {
 		http.NotFound(w, req)
 		return
@@ -359,6 +359,14 @@ func TestACMEAuth(t *testing.T) {
 }
 
 func newTestServer(t *testing.T, getter Getter, serverAuth ServerAuth, refreshHint time.Duration) (net.Addr, func()) {
+	return newTestServerWithConfig(t, ServerConfig{
+		Getter:      getter,
+		ServerAuth:  serverAuth,
+		RefreshHint: refreshHint,
+	})
+}
+
+func newTestServerWithConfig(t *testing.T, config ServerConfig) (net.Addr, func()) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	addrCh := make(chan net.Addr, 1)
@@ -372,14 +380,10 @@ func newTestServer(t *testing.T, getter Getter, serverAuth ServerAuth, refreshHi
 	}
 
 	log, _ := test.NewNullLogger()
-	server := NewServer(ServerConfig{
-		Log:         log,
-		Address:     "localhost:0",
-		Getter:      getter,
-		ServerAuth:  serverAuth,
-		listen:      listen,
-		RefreshHint: refreshHint,
-	})
+	config.Log = log
+	config.Address = "localhost:0"
+	config.listen = listen
+	server := NewServer(config)
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -16,23 +16,30 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/tlspolicy"
 	"github.com/spiffe/spire/pkg/server/api"
+	adminv1 "github.com/spiffe/spire/pkg/server/api/admin/v1"
 	agentv1 "github.com/spiffe/spire/pkg/server/api/agent/v1"
 	bundlev1 "github.com/spiffe/spire/pkg/server/api/bundle/v1"
 	debugv1 "github.com/spiffe/spire/pkg/server/api/debug/v1"
 	entryv1 "github.com/spiffe/spire/pkg/server/api/entry/v1"
+	entryhistoryv1 "github.com/spiffe/spire/pkg/server/api/entryhistory/v1"
+	federationstatusv1 "github.com/spiffe/spire/pkg/server/api/federationstatus/v1"
 	healthv1 "github.com/spiffe/spire/pkg/server/api/health/v1"
 	localauthorityv1 "github.com/spiffe/spire/pkg/server/api/localauthority/v1"
 	loggerv1 "github.com/spiffe/spire/pkg/server/api/logger/v1"
 	svidv1 "github.com/spiffe/spire/pkg/server/api/svid/v1"
 	trustdomainv1 "github.com/spiffe/spire/pkg/server/api/trustdomain/v1"
+	"github.com/spiffe/spire/pkg/server/authorizedentries"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/ca/manager"
 	"github.com/spiffe/spire/pkg/server/cache/dscache"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
 	"github.com/spiffe/spire/pkg/server/svid"
+	"github.com/spiffe/spire/pkg/server/tenant"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 // Config is a configuration for endpoints
@@ -49,6 +56,13 @@ type Config struct {
 	// The server's configured trust domain. Used for validation, server SVID, etc.
 	TrustDomain spiffeid.TrustDomain
 
+	// The server's data directory. Its artifacts are included in snapshots.
+	DataDir string
+
+	// Paths of the files holding private keys, which are only included in
+	// snapshots on request.
+	KeyPaths []string
+
 	// Plugin catalog
 	Catalog catalog.Catalog
 
@@ -97,6 +111,14 @@ type Config struct {
 	// EventTimeout controls how long to wait for an event before giving up
 	EventTimeout time.Duration
 
+	// EntryTemplates are rendered into authorized entries for each matching
+	// agent. They are only supported by the events based cache.
+	EntryTemplates []*authorizedentries.EntryTemplate
+
+	// CredentialProfilePolicy governs what the credential profiles of
+	// registration entries can set.
+	CredentialProfilePolicy credtemplate.CredentialProfilePolicy
+
 	AuditLogEnabled bool
 
 	// ProxyProtocolTrustedCIDRs is a list of trusted CIDRs for PROXY protocol.
@@ -108,6 +130,10 @@ type Config struct {
 	// X509-SVID, are granted admin rights.
 	AdminIDs []spiffeid.ID
 
+	// Tenants partition the registration entries. Tenant admins are granted
+	// access to the Entry API, scoped to the entries of their tenant.
+	Tenants *tenant.Set
+
 	BundleManager *bundle_client.Manager
 
 	// TLSPolicy determines the post-quantum-safe policy used for all TLS
@@ -145,30 +171,53 @@ func (c *Config) maybeMakeBundleEndpointServer() (Server, func(context.Context)
 	}
 
 	ds := c.Catalog.GetDataStore()
+	fetchBundle := func(ctx context.Context) (*common.Bundle, error) {
+		commonBundle, err := ds.FetchBundle(dscache.WithCache(ctx), c.TrustDomain.IDString())
+		if err != nil {
+			return nil, err
+		}
+		if commonBundle == nil {
+			return nil, errors.New("trust domain bundle not found")
+		}
+		return commonBundle, nil
+	}
 	return bundle.NewServer(bundle.ServerConfig{
 		Log:     c.Log.WithField(telemetry.SubsystemName, "bundle_endpoint"),
 		Address: c.BundleEndpoint.Address.String(),
 		Getter: bundle.GetterFunc(func(ctx context.Context) (*spiffebundle.Bundle, error) {
-			commonBundle, err := ds.FetchBundle(dscache.WithCache(ctx), c.TrustDomain.IDString())
+			commonBundle, err := fetchBundle(ctx)
 			if err != nil {
 				return nil, err
 			}
-			if commonBundle == nil {
-				return nil, errors.New("trust domain bundle not found")
-			}
 			return bundleutil.SPIFFEBundleFromProto(commonBundle)
 		}),
-		RefreshHint: c.BundleEndpoint.RefreshHint,
-		ServerAuth:  serverAuth,
-		TLSPolicy:   c.TLSPolicy,
+		RefreshHint:   c.BundleEndpoint.RefreshHint,
+		ServerAuth:    serverAuth,
+		TLSPolicy:     c.TLSPolicy,
+		OIDCDiscovery: c.BundleEndpoint.OIDCDiscovery,
+		KeysGetter:    bundle.KeysGetterFunc(fetchBundle),
 	}), certificateReloadTask
 }
 
 func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServers {
 	ds := c.Catalog.GetDataStore()
 	upstreamPublisher := UpstreamPublisher(c.AuthorityManager)
+	entryServer := entryv1.New(entryv1.Config{
+		TrustDomain:  c.TrustDomain,
+		DataStore:    ds,
+		EntryFetcher: entryFetcher,
+
+		CredentialProfilePolicy: c.CredentialProfilePolicy,
+	})
 
 	return APIServers{
+		AdminServer: adminv1.New(adminv1.Config{
+			TrustDomain:  c.TrustDomain,
+			DataStore:    ds,
+			DataDir:      c.DataDir,
+			KeyPaths:     c.KeyPaths,
+			SVIDObserver: c.SVIDObserver,
+		}),
 		AgentServer: agentv1.New(agentv1.Config{
 			DataStore:               ds,
 			ServerCA:                c.ServerCA,
@@ -189,10 +238,13 @@ func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServ
 			SVIDObserver: c.SVIDObserver,
 			Uptime:       c.Uptime,
 		}),
-		EntryServer: entryv1.New(entryv1.Config{
-			TrustDomain:  c.TrustDomain,
-			DataStore:    ds,
-			EntryFetcher: entryFetcher,
+		EntryServer:    entryServer,
+		EntryExtServer: entryv1.NewExtensionService(entryServer),
+		EntryHistoryServer: entryhistoryv1.New(entryhistoryv1.Config{
+			DataStore: ds,
+		}),
+		FederationStatusServer: federationstatusv1.New(federationstatusv1.Config{
+			StatusSource: c.BundleManager,
 		}),
 		HealthServer: healthv1.New(healthv1.Config{
 			TrustDomain: c.TrustDomain,
@@ -206,6 +258,8 @@ func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServ
 			EntryFetcher: entryFetcher,
 			ServerCA:     c.ServerCA,
 			DataStore:    ds,
+
+			CredentialProfiles: c.CredentialProfilePolicy.Enabled(),
 		}),
 		TrustDomainServer: trustdomainv1.New(trustdomainv1.Config{
 			TrustDomain:     c.TrustDomain,
@@ -42,6 +42,11 @@ import (
 	"github.com/spiffe/spire/pkg/server/authpolicy"
 	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/pkg/server/svid"
+	"github.com/spiffe/spire/pkg/server/tenant"
+	adminv1 "github.com/spiffe/spire/proto/spire/server/admin"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/server/entryhistory"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
 )
 
 const (
@@ -101,6 +106,7 @@ type Endpoints struct {
 	ProxyProtocolTrustedCIDRs    []string
 	AuthPolicyEngine             *authpolicy.Engine
 	AdminIDs                     []spiffeid.ID
+	Tenants                      *tenant.Set
 	TLSPolicy                    tlspolicy.Policy
 	MaxAttestedNodeInfoStaleness time.Duration
 	nodeCache                    api.AttestedNodeCache
@@ -112,15 +118,19 @@ type Endpoints struct {
 }
 
 type APIServers struct {
-	AgentServer          agentv1.AgentServer
-	BundleServer         bundlev1.BundleServer
-	DebugServer          debugv1_pb.DebugServer
-	EntryServer          entryv1.EntryServer
-	HealthServer         grpc_health_v1.HealthServer
-	LoggerServer         loggerv1.LoggerServer
-	SVIDServer           svidv1.SVIDServer
-	TrustDomainServer    trustdomainv1.TrustDomainServer
-	LocalAUthorityServer localauthorityv1.LocalAuthorityServer
+	AdminServer            adminv1.AdminServer
+	AgentServer            agentv1.AgentServer
+	BundleServer           bundlev1.BundleServer
+	DebugServer            debugv1_pb.DebugServer
+	EntryServer            entryv1.EntryServer
+	EntryExtServer         entryext.EntryServer
+	EntryHistoryServer     entryhistoryv1.EntryHistoryServer
+	FederationStatusServer federationstatusv1.FederationStatusServer
+	HealthServer           grpc_health_v1.HealthServer
+	LoggerServer           loggerv1.LoggerServer
+	SVIDServer             svidv1.SVIDServer
+	TrustDomainServer      trustdomainv1.TrustDomainServer
+	LocalAUthorityServer   localauthorityv1.LocalAuthorityServer
 }
 
 // RateLimitConfig holds rate limiting configurations.
@@ -182,6 +192,7 @@ func New(ctx context.Context, c Config) (*Endpoints, error) {
 			fullCacheReloadInterval: c.FullCacheReloadInterval,
 			pruneEventsOlderThan:    c.PruneEventsOlderThan,
 			eventTimeout:            c.EventTimeout,
+			entryTemplates:          c.EntryTemplates,
 		})
 		if err != nil {
 			return nil, err
@@ -194,7 +205,7 @@ func New(ctx context.Context, c Config) (*Endpoints, error) {
 		buildCacheFn := func(ctx context.Context) (_ entrycache.Cache, err error) {
 			call := telemetry.StartCall(c.Metrics, telemetry.Entry, telemetry.Cache, telemetry.Reload)
 			defer call.Done(&err)
-			return entrycache.BuildFromDataStore(ctx, c.TrustDomain.String(), c.Catalog.GetDataStore())
+			return entrycache.BuildFromDataStore(ctx, c.TrustDomain.String(), c.Catalog.GetDataStore(), c.Clock)
 		}
 
 		efFullCache, err := NewAuthorizedEntryFetcherWithFullCache(ctx, buildCacheFn, c.Log, c.Clock, ds, c.CacheReloadInterval, c.PruneEventsOlderThan)
@@ -230,6 +241,7 @@ func New(ctx context.Context, c Config) (*Endpoints, error) {
 		ProxyProtocolTrustedCIDRs:    c.ProxyProtocolTrustedCIDRs,
 		AuthPolicyEngine:             c.AuthPolicyEngine,
 		AdminIDs:                     c.AdminIDs,
+		Tenants:                      c.Tenants,
 		TLSPolicy:                    c.TLSPolicy,
 		MaxAttestedNodeInfoStaleness: c.MaxAttestedNodeInfoStaleness,
 		nodeCache:                    nodeCache,
@@ -260,6 +272,12 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	bundlev1.RegisterBundleServer(udsServer, e.APIServers.BundleServer)
 	entryv1.RegisterEntryServer(tcpServer, e.APIServers.EntryServer)
 	entryv1.RegisterEntryServer(udsServer, e.APIServers.EntryServer)
+	entryext.RegisterEntryServer(tcpServer, e.APIServers.EntryExtServer)
+	entryext.RegisterEntryServer(udsServer, e.APIServers.EntryExtServer)
+	entryhistoryv1.RegisterEntryHistoryServer(tcpServer, e.APIServers.EntryHistoryServer)
+	entryhistoryv1.RegisterEntryHistoryServer(udsServer, e.APIServers.EntryHistoryServer)
+	federationstatusv1.RegisterFederationStatusServer(tcpServer, e.APIServers.FederationStatusServer)
+	federationstatusv1.RegisterFederationStatusServer(udsServer, e.APIServers.FederationStatusServer)
 	svidv1.RegisterSVIDServer(tcpServer, e.APIServers.SVIDServer)
 	svidv1.RegisterSVIDServer(udsServer, e.APIServers.SVIDServer)
 	trustdomainv1.RegisterTrustDomainServer(tcpServer, e.APIServers.TrustDomainServer)
@@ -268,6 +286,7 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	localauthorityv1.RegisterLocalAuthorityServer(udsServer, e.APIServers.LocalAUthorityServer)
 
 	// UDS only
+	adminv1.RegisterAdminServer(udsServer, e.APIServers.AdminServer)
 	loggerv1.RegisterLoggerServer(udsServer, e.APIServers.LoggerServer)
 	grpc_health_v1.RegisterHealthServer(udsServer, e.APIServers.HealthServer)
 	debugv1_pb.RegisterDebugServer(udsServer, e.APIServers.DebugServer)
@@ -482,7 +501,7 @@ func (e *Endpoints) getTLSConfig(ctx context.Context) func(*tls.ClientHelloInfo)
 func (e *Endpoints) makeInterceptors() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
 	log := e.Log.WithField(telemetry.SubsystemName, "api")
 
-	return middleware.Interceptors(Middleware(log, e.Metrics, e.DataStore, e.nodeCache, e.MaxAttestedNodeInfoStaleness, clock.New(), e.RateLimit, e.AuthPolicyEngine, e.AuditLogEnabled, e.AdminIDs))
+	return middleware.Interceptors(Middleware(log, e.Metrics, e.DataStore, e.nodeCache, e.MaxAttestedNodeInfoStaleness, clock.New(), e.RateLimit, e.AuthPolicyEngine, e.AuditLogEnabled, e.AdminIDs, e.Tenants))
 }
 
 func (e *Endpoints) triggerListeningHook() {
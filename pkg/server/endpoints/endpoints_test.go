@@ -36,6 +36,10 @@ import (
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
 	"github.com/spiffe/spire/pkg/server/svid"
 	"github.com/spiffe/spire/proto/spire/common"
+	adminv1 "github.com/spiffe/spire/proto/spire/server/admin"
+	"github.com/spiffe/spire/proto/spire/server/entryext"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/server/entryhistory"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/server/federationstatus"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/fakes/fakemetrics"
@@ -115,10 +119,14 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, localAddr, endpoints.LocalAddr)
 	assert.Equal(t, svidObserver, endpoints.SVIDObserver)
 	assert.Equal(t, testTD, endpoints.TrustDomain)
+	assert.NotNil(t, endpoints.APIServers.AdminServer)
 	assert.NotNil(t, endpoints.APIServers.AgentServer)
 	assert.NotNil(t, endpoints.APIServers.BundleServer)
 	assert.NotNil(t, endpoints.APIServers.DebugServer)
 	assert.NotNil(t, endpoints.APIServers.EntryServer)
+	assert.NotNil(t, endpoints.APIServers.EntryExtServer)
+	assert.NotNil(t, endpoints.APIServers.EntryHistoryServer)
+	assert.NotNil(t, endpoints.APIServers.FederationStatusServer)
 	assert.NotNil(t, endpoints.APIServers.HealthServer)
 	assert.NotNil(t, endpoints.APIServers.LoggerServer)
 	assert.NotNil(t, endpoints.APIServers.SVIDServer)
@@ -198,7 +206,7 @@ func TestListenAndServe(t *testing.T) {
 	clk := clock.NewMock(t)
 
 	buildCacheFn := func(ctx context.Context) (entrycache.Cache, error) {
-		return entrycache.BuildFromDataStore(ctx, testTD.String(), ds)
+		return entrycache.BuildFromDataStore(ctx, testTD.String(), ds, clk)
 	}
 
 	// Prime the datastore with the:
@@ -225,15 +233,19 @@ func TestListenAndServe(t *testing.T) {
 		DataStore:    ds,
 		BundleCache:  bundle.NewCache(ds, clk),
 		APIServers: APIServers{
-			AgentServer:          agentServer{},
-			BundleServer:         bundleServer{},
-			DebugServer:          debugServer{},
-			EntryServer:          entryServer{},
-			HealthServer:         healthServer{},
-			LoggerServer:         loggerServer{},
-			SVIDServer:           svidServer{},
-			TrustDomainServer:    trustDomainServer{},
-			LocalAUthorityServer: localAuthorityServer{},
+			AdminServer:            adminServer{},
+			AgentServer:            agentServer{},
+			BundleServer:           bundleServer{},
+			DebugServer:            debugServer{},
+			EntryServer:            entryServer{},
+			EntryExtServer:         entryExtServer{},
+			EntryHistoryServer:     entryHistoryServer{},
+			FederationStatusServer: federationStatusServer{},
+			HealthServer:           healthServer{},
+			LoggerServer:           loggerServer{},
+			SVIDServer:             svidServer{},
+			TrustDomainServer:      trustDomainServer{},
+			LocalAUthorityServer:   localAuthorityServer{},
 		},
 		BundleEndpointServer:         bundleEndpointServer,
 		Log:                          log,
@@ -336,6 +348,9 @@ func TestListenAndServe(t *testing.T) {
 		downstream:     downstreamConn,
 	}
 
+	t.Run("Admin", func(t *testing.T) {
+		testAdminAPI(ctx, t, conns)
+	})
 	t.Run("Agent", func(t *testing.T) {
 		testAgentAPI(ctx, t, conns)
 	})
@@ -351,9 +366,18 @@ func TestListenAndServe(t *testing.T) {
 	t.Run("Bundle", func(t *testing.T) {
 		testBundleAPI(ctx, t, conns)
 	})
+	t.Run("EntryHistory", func(t *testing.T) {
+		testEntryHistoryAPI(ctx, t, conns)
+	})
+	t.Run("FederationStatus", func(t *testing.T) {
+		testFederationStatusAPI(ctx, t, conns)
+	})
 	t.Run("Entry", func(t *testing.T) {
 		testEntryAPI(ctx, t, conns)
 	})
+	t.Run("EntryExt", func(t *testing.T) {
+		testEntryExtAPI(ctx, t, conns)
+	})
 	t.Run("SVID", func(t *testing.T) {
 		testSVIDAPI(ctx, t, conns)
 	})
@@ -595,6 +619,34 @@ func testLoggerAPI(ctx context.Context, t *testing.T, conns testConns) {
 	})
 }
 
+func testAdminAPI(ctx context.Context, t *testing.T, conns testConns) {
+	t.Run("Local", func(t *testing.T) {
+		testAuthorization(ctx, t, adminv1.NewAdminClient(conns.local), map[string]bool{
+			"Snapshot": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		assertServiceUnavailable(ctx, t, adminv1.NewAdminClient(conns.noAuth))
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		assertServiceUnavailable(ctx, t, adminv1.NewAdminClient(conns.agent))
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		assertServiceUnavailable(ctx, t, adminv1.NewAdminClient(conns.admin))
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		assertServiceUnavailable(ctx, t, adminv1.NewAdminClient(conns.federatedAdmin))
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		assertServiceUnavailable(ctx, t, adminv1.NewAdminClient(conns.downstream))
+	})
+}
+
 func testDebugAPI(ctx context.Context, t *testing.T, conns testConns) {
 	t.Run("Local", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(conns.local), map[string]bool{
@@ -801,6 +853,138 @@ func testEntryAPI(ctx context.Context, t *testing.T, conns testConns) {
 	})
 }
 
+func testEntryExtAPI(ctx context.Context, t *testing.T, conns testConns) {
+	t.Run("Local", func(t *testing.T) {
+		testAuthorization(ctx, t, entryext.NewEntryClient(conns.local), map[string]bool{
+			"BatchCreateEntry":        true,
+			"BatchUpdateEntry":        true,
+			"BatchGetEntryExtensions": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, entryext.NewEntryClient(conns.noAuth), map[string]bool{
+			"BatchCreateEntry":        false,
+			"BatchUpdateEntry":        false,
+			"BatchGetEntryExtensions": false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, entryext.NewEntryClient(conns.agent), map[string]bool{
+			"BatchCreateEntry":        false,
+			"BatchUpdateEntry":        false,
+			"BatchGetEntryExtensions": false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, entryext.NewEntryClient(conns.admin), map[string]bool{
+			"BatchCreateEntry":        true,
+			"BatchUpdateEntry":        true,
+			"BatchGetEntryExtensions": true,
+		})
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, entryext.NewEntryClient(conns.federatedAdmin), map[string]bool{
+			"BatchCreateEntry":        true,
+			"BatchUpdateEntry":        true,
+			"BatchGetEntryExtensions": true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, entryext.NewEntryClient(conns.downstream), map[string]bool{
+			"BatchCreateEntry":        false,
+			"BatchUpdateEntry":        false,
+			"BatchGetEntryExtensions": false,
+		})
+	})
+}
+
+func testEntryHistoryAPI(ctx context.Context, t *testing.T, conns testConns) {
+	t.Run("Local", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(conns.local), map[string]bool{
+			"ListEntryHistory": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(conns.noAuth), map[string]bool{
+			"ListEntryHistory": false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(conns.agent), map[string]bool{
+			"ListEntryHistory": false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(conns.admin), map[string]bool{
+			"ListEntryHistory": true,
+		})
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(conns.federatedAdmin), map[string]bool{
+			"ListEntryHistory": true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(conns.downstream), map[string]bool{
+			"ListEntryHistory": false,
+		})
+	})
+}
+
+func testFederationStatusAPI(ctx context.Context, t *testing.T, conns testConns) {
+	t.Run("Local", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(conns.local), map[string]bool{
+			"ListFederationRelationshipStatuses": true,
+			"GetFederationRelationshipStatus":    true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(conns.noAuth), map[string]bool{
+			"ListFederationRelationshipStatuses": false,
+			"GetFederationRelationshipStatus":    false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(conns.agent), map[string]bool{
+			"ListFederationRelationshipStatuses": false,
+			"GetFederationRelationshipStatus":    false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(conns.admin), map[string]bool{
+			"ListFederationRelationshipStatuses": true,
+			"GetFederationRelationshipStatus":    true,
+		})
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(conns.federatedAdmin), map[string]bool{
+			"ListFederationRelationshipStatuses": true,
+			"GetFederationRelationshipStatus":    true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(conns.downstream), map[string]bool{
+			"ListFederationRelationshipStatuses": false,
+			"GetFederationRelationshipStatus":    false,
+		})
+	})
+}
+
 func testSVIDAPI(ctx context.Context, t *testing.T, conns testConns) {
 	t.Run("Local", func(t *testing.T) {
 		testAuthorization(ctx, t, svidv1.NewSVIDClient(conns.local), map[string]bool{
@@ -1330,6 +1514,50 @@ func (bundleServer) BatchDeleteFederatedBundle(_ context.Context, _ *bundlev1.Ba
 	return &bundlev1.BatchDeleteFederatedBundleResponse{}, nil
 }
 
+type adminServer struct {
+	adminv1.UnsafeAdminServer
+}
+
+func (adminServer) Snapshot(_ *adminv1.SnapshotRequest, stream adminv1.Admin_SnapshotServer) error {
+	return stream.Send(&adminv1.SnapshotResponse{})
+}
+
+type entryExtServer struct {
+	entryext.UnsafeEntryServer
+}
+
+func (entryExtServer) BatchCreateEntry(context.Context, *entryext.BatchCreateEntryRequest) (*entryext.BatchCreateEntryResponse, error) {
+	return &entryext.BatchCreateEntryResponse{}, nil
+}
+
+func (entryExtServer) BatchUpdateEntry(context.Context, *entryext.BatchUpdateEntryRequest) (*entryext.BatchUpdateEntryResponse, error) {
+	return &entryext.BatchUpdateEntryResponse{}, nil
+}
+
+func (entryExtServer) BatchGetEntryExtensions(context.Context, *entryext.BatchGetEntryExtensionsRequest) (*entryext.BatchGetEntryExtensionsResponse, error) {
+	return &entryext.BatchGetEntryExtensionsResponse{}, nil
+}
+
+type entryHistoryServer struct {
+	entryhistoryv1.UnsafeEntryHistoryServer
+}
+
+func (entryHistoryServer) ListEntryHistory(context.Context, *entryhistoryv1.ListEntryHistoryRequest) (*entryhistoryv1.ListEntryHistoryResponse, error) {
+	return &entryhistoryv1.ListEntryHistoryResponse{}, nil
+}
+
+type federationStatusServer struct {
+	federationstatusv1.UnsafeFederationStatusServer
+}
+
+func (federationStatusServer) ListFederationRelationshipStatuses(context.Context, *federationstatusv1.ListFederationRelationshipStatusesRequest) (*federationstatusv1.ListFederationRelationshipStatusesResponse, error) {
+	return &federationstatusv1.ListFederationRelationshipStatusesResponse{}, nil
+}
+
+func (federationStatusServer) GetFederationRelationshipStatus(context.Context, *federationstatusv1.GetFederationRelationshipStatusRequest) (*federationstatusv1.FederationRelationshipStatus, error) {
+	return &federationstatusv1.FederationRelationshipStatus{}, nil
+}
+
 type debugServer struct {
 	debugv1.UnsafeDebugServer
 }
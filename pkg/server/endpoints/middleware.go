@@ -18,17 +18,18 @@ import (
 	"github.com/spiffe/spire/pkg/server/authpolicy"
 	"github.com/spiffe/spire/pkg/server/ca/manager"
 	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/tenant"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func Middleware(log logrus.FieldLogger, metrics telemetry.Metrics, ds datastore.DataStore, nodeCache api.AttestedNodeCache, maxAttestedNodeInfoStaleness time.Duration, clk clock.Clock, rlConf RateLimitConfig, policyEngine *authpolicy.Engine, auditLogEnabled bool, adminIDs []spiffeid.ID) middleware.Middleware {
+func Middleware(log logrus.FieldLogger, metrics telemetry.Metrics, ds datastore.DataStore, nodeCache api.AttestedNodeCache, maxAttestedNodeInfoStaleness time.Duration, clk clock.Clock, rlConf RateLimitConfig, policyEngine *authpolicy.Engine, auditLogEnabled bool, adminIDs []spiffeid.ID, tenants *tenant.Set) middleware.Middleware {
 	chain := []middleware.Middleware{
 		middleware.WithLogger(log),
 		middleware.WithMetrics(metrics),
-		middleware.WithAuthorization(policyEngine, EntryFetcher(ds), AgentAuthorizer(ds, nodeCache, maxAttestedNodeInfoStaleness, clk), adminIDs),
+		middleware.WithAuthorization(policyEngine, EntryFetcher(ds), AgentAuthorizer(ds, nodeCache, maxAttestedNodeInfoStaleness, clk), adminIDs, tenants),
 		middleware.WithRateLimits(RateLimits(rlConf), metrics),
 	}
 
@@ -153,70 +154,77 @@ func RateLimits(config RateLimitConfig) map[string]api.RateLimiter {
 	postStatusLimit := middleware.PerIPLimit(limits.PostStatusLimitPerIP)
 
 	return map[string]api.RateLimiter{
-		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                    noLimit,
-		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                     noLimit,
-		"/spire.api.server.svid.v1.SVID/MintWITSVID":                                     noLimit,
-		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":                                csrLimit,
-		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                                      jsrLimit,
-		"/spire.api.server.svid.v1.SVID/BatchNewWITSVID":                                 wsrLimit,
-		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                             csrLimit,
-		"/spire.api.server.bundle.v1.Bundle/GetBundle":                                   noLimit,
-		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                                noLimit,
-		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                         pushKeyLimit,
-		"/spire.api.server.bundle.v1.Bundle/PublishWITAuthority":                         pushKeyLimit,
-		"/spire.api.server.bundle.v1.Bundle/CountBundles":                                noLimit,
-		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":                        noLimit,
-		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":                          noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                  noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                  noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                     noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                  noLimit,
-		"/spire.api.server.debug.v1.Debug/GetInfo":                                       noLimit,
-		"/spire.api.server.entry.v1.Entry/CountEntries":                                  noLimit,
-		"/spire.api.server.entry.v1.Entry/ListEntries":                                   noLimit,
-		"/spire.api.server.entry.v1.Entry/GetEntry":                                      noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                              noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                              noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                              noLimit,
-		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":                          noLimit,
-		"/spire.api.server.entry.v1.Entry/SyncAuthorizedEntries":                         noLimit,
-		"/spire.api.server.logger.v1.Logger/GetLogger":                                   noLimit,
-		"/spire.api.server.logger.v1.Logger/SetLogLevel":                                 noLimit,
-		"/spire.api.server.logger.v1.Logger/ResetLogLevel":                               noLimit,
-		"/spire.api.server.agent.v1.Agent/CountAgents":                                   noLimit,
-		"/spire.api.server.agent.v1.Agent/ListAgents":                                    noLimit,
-		"/spire.api.server.agent.v1.Agent/GetAgent":                                      noLimit,
-		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                   noLimit,
-		"/spire.api.server.agent.v1.Agent/BanAgent":                                      noLimit,
-		"/spire.api.server.agent.v1.Agent/AttestAgent":                                   attestLimit,
-		"/spire.api.server.agent.v1.Agent/RenewAgent":                                    csrLimit,
-		"/spire.api.server.agent.v1.Agent/PostStatus":                                    postStatusLimit,
-		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                               noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships":       noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship":         noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship": noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship": noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship": noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/RefreshBundle":                     noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/GetJWTAuthorityState":        noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareJWTAuthority":         noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateJWTAuthority":        noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority":           noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority":          noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/GetX509AuthorityState":       noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareX509Authority":        noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateX509Authority":       noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority":          noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509UpstreamAuthority":  noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority":         noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509UpstreamAuthority": noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/GetWITAuthorityState":        noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareWITAuthority":         noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateWITAuthority":        noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/TaintWITAuthority":           noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeWITAuthority":          noLimit,
-		"/grpc.health.v1.Health/Check":                                                   noLimit,
-		"/grpc.health.v1.Health/List":                                                    noLimit,
-		"/grpc.health.v1.Health/Watch":                                                   noLimit,
+		"/spire.server.admin.Admin/Snapshot":                                                 noLimit,
+		"/spire.server.entryext.Entry/BatchCreateEntry":                                      noLimit,
+		"/spire.server.entryext.Entry/BatchUpdateEntry":                                      noLimit,
+		"/spire.server.entryext.Entry/BatchGetEntryExtensions":                               noLimit,
+		"/spire.server.entryhistory.EntryHistory/ListEntryHistory":                           noLimit,
+		"/spire.server.federationstatus.FederationStatus/ListFederationRelationshipStatuses": noLimit,
+		"/spire.server.federationstatus.FederationStatus/GetFederationRelationshipStatus":    noLimit,
+		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                        noLimit,
+		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                         noLimit,
+		"/spire.api.server.svid.v1.SVID/MintWITSVID":                                         noLimit,
+		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":                                    csrLimit,
+		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                                          jsrLimit,
+		"/spire.api.server.svid.v1.SVID/BatchNewWITSVID":                                     wsrLimit,
+		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                                 csrLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetBundle":                                       noLimit,
+		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                                    noLimit,
+		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                             pushKeyLimit,
+		"/spire.api.server.bundle.v1.Bundle/PublishWITAuthority":                             pushKeyLimit,
+		"/spire.api.server.bundle.v1.Bundle/CountBundles":                                    noLimit,
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":                            noLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":                              noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                      noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                      noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                         noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                      noLimit,
+		"/spire.api.server.debug.v1.Debug/GetInfo":                                           noLimit,
+		"/spire.api.server.entry.v1.Entry/CountEntries":                                      noLimit,
+		"/spire.api.server.entry.v1.Entry/ListEntries":                                       noLimit,
+		"/spire.api.server.entry.v1.Entry/GetEntry":                                          noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                                  noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                                  noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                                  noLimit,
+		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":                              noLimit,
+		"/spire.api.server.entry.v1.Entry/SyncAuthorizedEntries":                             noLimit,
+		"/spire.api.server.logger.v1.Logger/GetLogger":                                       noLimit,
+		"/spire.api.server.logger.v1.Logger/SetLogLevel":                                     noLimit,
+		"/spire.api.server.logger.v1.Logger/ResetLogLevel":                                   noLimit,
+		"/spire.api.server.agent.v1.Agent/CountAgents":                                       noLimit,
+		"/spire.api.server.agent.v1.Agent/ListAgents":                                        noLimit,
+		"/spire.api.server.agent.v1.Agent/GetAgent":                                          noLimit,
+		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                       noLimit,
+		"/spire.api.server.agent.v1.Agent/BanAgent":                                          noLimit,
+		"/spire.api.server.agent.v1.Agent/AttestAgent":                                       attestLimit,
+		"/spire.api.server.agent.v1.Agent/RenewAgent":                                        csrLimit,
+		"/spire.api.server.agent.v1.Agent/PostStatus":                                        postStatusLimit,
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                                   noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships":           noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship":             noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship":     noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship":     noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship":     noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/RefreshBundle":                         noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetJWTAuthorityState":            noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareJWTAuthority":             noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateJWTAuthority":            noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority":               noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority":              noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetX509AuthorityState":           noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareX509Authority":            noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateX509Authority":           noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority":              noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509UpstreamAuthority":      noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority":             noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509UpstreamAuthority":     noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetWITAuthorityState":            noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareWITAuthority":             noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateWITAuthority":            noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintWITAuthority":               noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeWITAuthority":              noLimit,
+		"/grpc.health.v1.Health/Check":                                                       noLimit,
+		"/grpc.health.v1.Health/List":                                                        noLimit,
+		"/grpc.health.v1.Health/Watch":                                                       noLimit,
 	}
 }
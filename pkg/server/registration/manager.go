@@ -2,17 +2,22 @@ package registration
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/datastore"
 )
 
 const (
-	_pruningCadence = 5 * time.Minute
+	_pruningCadence    = 5 * time.Minute
+	_activationCadence = 5 * time.Second
+
+	_defaultEntryHistoryRetention = 30 * 24 * time.Hour
 )
 
 // ManagerConfig is the config for the registration manager
@@ -23,6 +28,10 @@ type ManagerConfig struct {
 	Metrics telemetry.Metrics
 
 	Clock clock.Clock
+
+	// EntryHistoryRetention is how long registration entry changes are kept
+	// before they are pruned. Defaults to 30 days.
+	EntryHistoryRetention time.Duration
 }
 
 // Manager is the manager of registrations
@@ -37,6 +46,9 @@ func NewManager(c ManagerConfig) *Manager {
 	if c.Clock == nil {
 		c.Clock = clock.New()
 	}
+	if c.EntryHistoryRetention == 0 {
+		c.EntryHistoryRetention = _defaultEntryHistoryRetention
+	}
 
 	return &Manager{
 		c:       c,
@@ -47,7 +59,11 @@ func NewManager(c ManagerConfig) *Manager {
 
 // Run runs the registration manager
 func (m *Manager) Run(ctx context.Context) error {
-	return m.pruneEvery(ctx)
+	err := util.RunTasks(ctx, m.pruneEvery, m.activateEvery)
+	if errors.Is(err, context.Canceled) {
+		err = nil
+	}
+	return err
 }
 
 func (m *Manager) pruneEvery(ctx context.Context) error {
@@ -61,6 +77,9 @@ func (m *Manager) pruneEvery(ctx context.Context) error {
 			if err := m.prune(ctx); err != nil && ctx.Err() == nil {
 				m.log.WithError(err).Error("Failed pruning registration entries")
 			}
+			if err := m.pruneEntryHistory(ctx); err != nil && ctx.Err() == nil {
+				m.log.WithError(err).Error("Failed pruning registration entry history")
+			}
 		case <-ctx.Done():
 			return nil
 		}
@@ -74,3 +93,48 @@ func (m *Manager) prune(ctx context.Context) (err error) {
 	err = m.c.DataStore.PruneRegistrationEntries(ctx, m.c.Clock.Now())
 	return err
 }
+
+func (m *Manager) pruneEntryHistory(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneEntryHistoryCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	err = m.c.DataStore.PruneRegistrationEntryChanges(ctx, m.c.EntryHistoryRetention)
+	return err
+}
+
+// activateEvery creates a registration entry event for the entries whose
+// not-before time passed since the last run, so that the entry caches of
+// every server pick them up as they become active.
+func (m *Manager) activateEvery(ctx context.Context) error {
+	ticker := m.c.Clock.Ticker(_activationCadence)
+	defer ticker.Stop()
+
+	// Start one period back so that entries activating while the entry
+	// caches are being built are not missed.
+	since := m.c.Clock.Now().Add(-_activationCadence)
+	for {
+		select {
+		case <-ticker.C:
+			until := m.c.Clock.Now()
+			if err := m.activate(ctx, since, until); err != nil {
+				// Log an error on failure unless we're shutting down. The
+				// same window is retried on the next tick.
+				if ctx.Err() == nil {
+					m.c.Log.WithError(err).WithField(telemetry.RetryInterval, _activationCadence).Error("Failed activating registration entries")
+				}
+				continue
+			}
+			since = until
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m *Manager) activate(ctx context.Context, since, until time.Time) (err error) {
+	counter := telemetry_server.StartRegistrationManagerActivateEntryCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	err = m.c.DataStore.ActivateRegistrationEntries(ctx, since, until)
+	return err
+}
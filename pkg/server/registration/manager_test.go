@@ -45,6 +45,7 @@ func (s *ManagerSuite) TestPruning() {
 
 	done := s.setupAndRunManager(ctx)
 	defer done()
+	s.clock.WaitForTickerMulti(time.Minute, 2, "waiting for the pruning and activation tickers")
 
 	// expires right on the pruning time
 	entry1 := &common.RegistrationEntry{
@@ -133,12 +134,85 @@ func (s *ManagerSuite) TestPruning() {
 	}, 1*time.Second, 100*time.Millisecond, "Expected all entries to have been pruned")
 }
 
+func (s *ManagerSuite) TestEntryHistoryPruning() {
+	ctx := s.T().Context()
+
+	_, err := s.ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		ParentId:  "spiffe://test.test/testA",
+		SpiffeId:  "spiffe://test.test/testA/test1",
+		Selectors: []*common.Selector{{Type: "type", Value: "value"}},
+	})
+	s.Require().NoError(err)
+
+	// The datastore prunes changes by wall clock time, so a negative
+	// retention prunes the change on the first tick.
+	done := s.setupAndRunManagerWithRetention(ctx, -time.Hour)
+	defer done()
+
+	s.clock.WaitForTickerMulti(time.Minute, 2, "waiting for the pruning and activation tickers")
+	s.clock.Add(_pruningCadence)
+	s.Require().EventuallyWithT(func(c *assert.CollectT) {
+		resp, err := s.ds.ListRegistrationEntryChanges(ctx, &datastore.ListRegistrationEntryChangesRequest{})
+		require.NoError(c, err)
+		require.Empty(c, resp.Changes)
+	}, 1*time.Second, 100*time.Millisecond, "Expected the entry history to have been pruned")
+}
+
+func (s *ManagerSuite) TestActivation() {
+	ctx := s.T().Context()
+
+	done := s.setupAndRunManager(ctx)
+	defer done()
+	s.clock.WaitForTickerMulti(time.Minute, 2, "waiting for the pruning and activation tickers")
+
+	entry, err := s.ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		ParentId:  "spiffe://test.test/testA",
+		SpiffeId:  "spiffe://test.test/testA/test1",
+		Selectors: []*common.Selector{{Type: "type", Value: "value"}},
+		NotBefore: s.clock.Now().Add(_activationCadence + time.Second).Unix(),
+	})
+	s.Require().NoError(err)
+
+	listEventEntryIDs := func(c require.TestingT) []string {
+		resp, err := s.ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{})
+		require.NoError(c, err)
+		var entryIDs []string
+		for _, event := range resp.Events {
+			entryIDs = append(entryIDs, event.EntryID)
+		}
+		return entryIDs
+	}
+
+	// not active yet
+	s.clock.Add(_activationCadence)
+	s.Require().EventuallyWithT(func(c *assert.CollectT) {
+		require.Equal(c, []string{entry.EntryId}, listEventEntryIDs(c))
+	}, 1*time.Second, 100*time.Millisecond, "Expected only the creation event")
+
+	// activated
+	s.clock.Add(_activationCadence)
+	s.Require().EventuallyWithT(func(c *assert.CollectT) {
+		require.Equal(c, []string{entry.EntryId, entry.EntryId}, listEventEntryIDs(c))
+	}, 1*time.Second, 100*time.Millisecond, "Expected an activation event")
+
+	// the activation event is only created once
+	s.clock.Add(_activationCadence)
+	s.Require().Never(func() bool {
+		return len(listEventEntryIDs(s.T())) != 2
+	}, 500*time.Millisecond, 100*time.Millisecond, "Expected no further activation event")
+}
+
 func (s *ManagerSuite) setupAndRunManager(ctx context.Context) func() {
+	return s.setupAndRunManagerWithRetention(ctx, 0)
+}
+
+func (s *ManagerSuite) setupAndRunManagerWithRetention(ctx context.Context, entryHistoryRetention time.Duration) func() {
 	s.m = NewManager(ManagerConfig{
-		Clock:     s.clock,
-		DataStore: s.ds,
-		Log:       s.log,
-		Metrics:   s.metrics,
+		Clock:                 s.clock,
+		DataStore:             s.ds,
+		Log:                   s.log,
+		Metrics:               s.metrics,
+		EntryHistoryRetention: entryHistoryRetention,
 	})
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -375,6 +375,9 @@ func (s *Server) newCredBuilder(cat catalog.Catalog) (*credtemplate.Builder, err
 		WITIssuer:           s.config.WITIssuer,
 		CredentialComposers: cat.GetCredentialComposers(),
 		TLSPolicy:           s.config.TLSPolicy,
+
+		CredentialProfilePolicy: s.config.CredentialProfilePolicy,
+		ClaimTemplates:          s.config.ClaimTemplates,
 	})
 }
 
@@ -435,9 +438,10 @@ func (s *Server) newCASync(ctx context.Context, healthChecker health.Checker, ca
 
 func (s *Server) newRegistrationManager(cat catalog.Catalog, metrics telemetry.Metrics) *registration.Manager {
 	registrationManager := registration.NewManager(registration.ManagerConfig{
-		DataStore: cat.GetDataStore(),
-		Log:       s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
-		Metrics:   metrics,
+		DataStore:             cat.GetDataStore(),
+		Log:                   s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
+		Metrics:               metrics,
+		EntryHistoryRetention: s.config.EntryHistoryRetention,
 	})
 	return registrationManager
 }
@@ -475,6 +479,8 @@ func (s *Server) newEndpointsServer(ctx context.Context, catalog catalog.Catalog
 		LocalAddr:                    s.config.BindLocalAddress,
 		SVIDObserver:                 svidObserver,
 		TrustDomain:                  s.config.TrustDomain,
+		DataDir:                      s.config.DataDir,
+		KeyPaths:                     s.config.KeyPaths,
 		Catalog:                      catalog,
 		ServerCA:                     serverCA,
 		Log:                          s.config.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
@@ -489,11 +495,14 @@ func (s *Server) newEndpointsServer(ctx context.Context, catalog catalog.Catalog
 		EventsBasedCache:             s.config.EventsBasedCache,
 		PruneEventsOlderThan:         s.config.PruneEventsOlderThan,
 		EventTimeout:                 s.config.EventTimeout,
+		EntryTemplates:               s.config.EntryTemplates,
+		CredentialProfilePolicy:      s.config.CredentialProfilePolicy,
 		AuditLogEnabled:              s.config.AuditLogEnabled,
 		ProxyProtocolTrustedCIDRs:    s.config.ProxyProtocolTrustedCIDRs,
 		AuthPolicyEngine:             authPolicyEngine,
 		BundleManager:                bundleManager,
 		AdminIDs:                     s.config.AdminIDs,
+		Tenants:                      s.config.Tenants,
 		MaxAttestedNodeInfoStaleness: s.config.MaxAttestedNodeInfoStaleness,
 		AgentSpiffeIdAsSelector:      s.config.Experimental.AgentSpiffeIdAsSelector,
 	}
@@ -0,0 +1,568 @@
+// Package snapshot writes and restores signed snapshots of the server state.
+//
+// A snapshot is a gzip-compressed tar stream. The first file is the snapshot
+// header. It is followed by the datastore contents, written in the format of
+// the archive package, and by the artifacts found in the server data
+// directory. The last file holds the SHA-256 digest of every other file,
+// signed with the X509-SVID of the server that took the snapshot.
+//
+// Snapshots are signed but not encrypted. The files holding private keys are
+// left out unless they are explicitly requested, in which case the snapshot
+// holds the keys in the clear.
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/datastore/archive"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	// Version is the version of the snapshot format written by Write.
+	Version = 1
+
+	headerName    = "snapshot.json"
+	datastoreName = "datastore.archive"
+	dataDirPrefix = "data_dir/"
+	signatureName = "signature.json"
+)
+
+// dataDirExcludedSuffixes holds the suffixes of the data directory files
+// that are not included in a snapshot. These are the files of the built-in
+// datastores, which are captured through the DataStore interface instead,
+// since copying them while in use does not produce a consistent copy.
+var dataDirExcludedSuffixes = []string{
+	".db",
+	".sqlite3",
+	"-journal",
+	"-shm",
+	"-wal",
+}
+
+// Header is the first file in a snapshot.
+type Header struct {
+	Version     int       `json:"version"`
+	TrustDomain string    `json:"trust_domain"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// X509Authorities holds the X.509 authorities (DER encoded) of the
+	// trust domain bundle at the time the snapshot was taken.
+	X509Authorities [][]byte `json:"x509_authorities"`
+}
+
+type signature struct {
+	Digests   []digest `json:"digests"`
+	X509SVID  [][]byte `json:"x509_svid"`
+	Signature []byte   `json:"signature"`
+}
+
+type digest struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+}
+
+// Config is the configuration used to write a snapshot.
+type Config struct {
+	TrustDomain spiffeid.TrustDomain
+	DataStore   datastore.DataStore
+	DataDir     string
+
+	// KeyPaths are the paths of the files holding private keys, such as the
+	// keys of the disk KeyManager. The data directory files at these paths,
+	// or under them for directories, are only written when IncludeKeys is
+	// set.
+	KeyPaths    []string
+	IncludeKeys bool
+
+	// X509SVID and Key are the X509-SVID and private key of the server,
+	// used to sign the snapshot.
+	X509SVID []*x509.Certificate
+	Key      crypto.Signer
+
+	Now func() time.Time
+}
+
+// Write writes a snapshot of the server state to w.
+//
+// The datastore contents are read first, followed by the data directory. The
+// CA manager persists new keys before recording them in the CA journal, so
+// reading in this order guarantees that every key referenced by the captured
+// journal is present in the captured data directory.
+//
+// The datastore is read in a single read transaction, so the snapshot holds a
+// point-in-time image of the datastore. The X.509 authorities recorded in the
+// snapshot header are taken from the trust domain bundle in that image.
+func Write(ctx context.Context, config Config, w io.Writer) (*archive.Stats, error) {
+	if len(config.X509SVID) == 0 || config.Key == nil {
+		return nil, errors.New("an X509-SVID is required to sign the snapshot")
+	}
+	now := time.Now
+	if config.Now != nil {
+		now = config.Now
+	}
+
+	// The tar header of each file holds its size, so the datastore archive
+	// is spooled to a temporary file before being written.
+	spool, err := os.CreateTemp("", "spire-snapshot-*")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temporary file: %w", err)
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	ds := &bundleCapturingDataStore{
+		DataStore:     config.DataStore,
+		trustDomainID: config.TrustDomain.IDString(),
+	}
+	stats, err := archive.Export(ctx, ds, config.TrustDomain, spool)
+	if err != nil {
+		return nil, err
+	}
+	if ds.bundle == nil {
+		return nil, errors.New("trust domain bundle not found")
+	}
+
+	header := Header{
+		Version:     Version,
+		TrustDomain: config.TrustDomain.Name(),
+		CreatedAt:   now().UTC(),
+	}
+	for _, rootCA := range ds.bundle.RootCas {
+		header.X509Authorities = append(header.X509Authorities, rootCA.DerBytes)
+	}
+
+	gw := gzip.NewWriter(w)
+	sw := &writer{tw: tar.NewWriter(gw), now: header.CreatedAt}
+
+	headerData, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if err := sw.writeFile(headerName, headerData); err != nil {
+		return nil, err
+	}
+	if err := sw.writeSpool(datastoreName, spool); err != nil {
+		return nil, err
+	}
+	if config.DataDir != "" {
+		var keyPaths []string
+		if !config.IncludeKeys {
+			keyPaths = config.KeyPaths
+		}
+		if err := sw.writeDataDir(config.DataDir, keyPaths); err != nil {
+			return nil, err
+		}
+	}
+	if err := sw.writeSignature(config.X509SVID, config.Key); err != nil {
+		return nil, err
+	}
+
+	if err := sw.tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// bundleCapturingDataStore captures the trust domain bundle while the
+// datastore records are exported, so that the snapshot header and the
+// datastore contents come from the same read transaction.
+type bundleCapturingDataStore struct {
+	datastore.DataStore
+	trustDomainID string
+	bundle        *common.Bundle
+}
+
+func (ds *bundleCapturingDataStore) ExportRecords(ctx context.Context, fn func(*datastore.ExportRecord) error) error {
+	return ds.DataStore.ExportRecords(ctx, func(r *datastore.ExportRecord) error {
+		if r.Bundle != nil && r.Bundle.TrustDomainId == ds.trustDomainID {
+			ds.bundle = r.Bundle
+		}
+		return fn(r)
+	})
+}
+
+type writer struct {
+	tw      *tar.Writer
+	now     time.Time
+	digests []digest
+}
+
+func (w *writer) writeFile(name string, data []byte) error {
+	return w.write(name, int64(len(data)), bytes.NewReader(data))
+}
+
+func (w *writer) writeSpool(name string, f *os.File) error {
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return w.write(name, size, f)
+}
+
+// writeDataDir writes the files of the data directory, except for those at
+// or under the excluded paths.
+func (w *writer) writeDataDir(dataDir string, excludedPaths []string) error {
+	dataDir, err := filepath.Abs(dataDir)
+	if err != nil {
+		return err
+	}
+	excluded := make(map[string]struct{}, len(excludedPaths))
+	for _, p := range excludedPaths {
+		p, err := filepath.Abs(p)
+		if err != nil {
+			return err
+		}
+		excluded[p] = struct{}{}
+	}
+
+	return filepath.WalkDir(dataDir, func(p string, d fs.DirEntry, err error) error {
+		if _, ok := excluded[p]; ok {
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch {
+		case err != nil:
+			return err
+		case !d.Type().IsRegular() || isExcludedDataDirFile(d.Name()):
+			return nil
+		}
+
+		rel, err := filepath.Rel(dataDir, p)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return fmt.Errorf("unable to read data directory file: %w", err)
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		return w.write(dataDirPrefix+filepath.ToSlash(rel), info.Size(), f)
+	})
+}
+
+func (w *writer) writeSignature(x509SVID []*x509.Certificate, key crypto.Signer) error {
+	signed, err := json.Marshal(w.digests)
+	if err != nil {
+		return err
+	}
+	hashed := sha256.Sum256(signed)
+	sig, err := key.Sign(rand.Reader, hashed[:], crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("unable to sign snapshot: %w", err)
+	}
+
+	data, err := json.Marshal(signature{
+		Digests:   w.digests,
+		X509SVID:  x509util.RawCertsFromCertificates(x509SVID),
+		Signature: sig,
+	})
+	if err != nil {
+		return err
+	}
+	return w.write(signatureName, int64(len(data)), bytes.NewReader(data))
+}
+
+func (w *writer) write(name string, size int64, r io.Reader) error {
+	if err := w.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o600,
+		ModTime:  w.now,
+	}); err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(w.tw, io.TeeReader(io.LimitReader(r, size), h))
+	switch {
+	case err != nil:
+		return fmt.Errorf("unable to write %s to snapshot: %w", name, err)
+	case n != size:
+		return fmt.Errorf("unable to write %s to snapshot: file changed size while being read", name)
+	}
+
+	if name != signatureName {
+		w.digests = append(w.digests, digest{Name: name, SHA256: hex.EncodeToString(h.Sum(nil))})
+	}
+	return nil
+}
+
+func isExcludedDataDirFile(name string) bool {
+	for _, suffix := range dataDirExcludedSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify reads the snapshot from r and verifies that it is intact, that it
+// belongs to the given trust domain, and that it was signed by a server of
+// that trust domain whose X509-SVID chains up to one of the trusted X.509
+// authorities. The trusted authorities must come from the datastore the
+// snapshot is restored into, or from the operator, and never from the
+// snapshot itself. The authority the signer chains up to must also be one of
+// the X.509 authorities recorded in the snapshot, so that restoring it keeps
+// trust continuity with the trusted authorities. It returns the snapshot
+// header.
+func Verify(r io.Reader, trustDomain spiffeid.TrustDomain, trustedAuthorities []*x509.Certificate) (*Header, error) {
+	var (
+		header  *Header
+		sig     *signature
+		digests []digest
+	)
+	err := readSnapshot(r, func(name string, r io.Reader) error {
+		if sig != nil {
+			return fmt.Errorf("unexpected file %q after the snapshot signature", name)
+		}
+
+		h := sha256.New()
+		switch name {
+		case headerName:
+			if header != nil {
+				return errors.New("snapshot has more than one header")
+			}
+			header = new(Header)
+			if err := json.NewDecoder(io.TeeReader(r, h)).Decode(header); err != nil {
+				return fmt.Errorf("malformed snapshot header: %w", err)
+			}
+			if err := checkHeader(header, trustDomain); err != nil {
+				return err
+			}
+		case signatureName:
+			sig = new(signature)
+			if err := json.NewDecoder(r).Decode(sig); err != nil {
+				return fmt.Errorf("malformed snapshot signature: %w", err)
+			}
+			return nil
+		default:
+			if header == nil {
+				return errors.New("snapshot header is missing")
+			}
+		}
+
+		if _, err := io.Copy(h, r); err != nil {
+			return err
+		}
+		digests = append(digests, digest{Name: name, SHA256: hex.EncodeToString(h.Sum(nil))})
+		return nil
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case header == nil:
+		return nil, errors.New("snapshot header is missing")
+	case sig == nil:
+		return nil, errors.New("snapshot is not signed")
+	}
+
+	if err := checkDigests(sig.Digests, digests); err != nil {
+		return nil, err
+	}
+	if err := checkSignature(header, sig, trustDomain, trustedAuthorities); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// Restore restores a snapshot that was previously checked with Verify. The
+// contents of ds are replaced with the datastore contents of the snapshot
+// using archive.Restore, and the data directory artifacts are written into
+// dataDir, overwriting existing files. No server may be running against ds or
+// dataDir while the snapshot is restored.
+func Restore(ctx context.Context, ds datastore.DataStore, header *Header, dataDir string, r io.Reader) (*archive.Stats, error) {
+	trustDomain, err := spiffeid.TrustDomainFromString(header.TrustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot trust domain: %w", err)
+	}
+
+	var stats *archive.Stats
+	err = readSnapshot(r, func(name string, r io.Reader) error {
+		switch {
+		case name == datastoreName:
+			var err error
+			stats, err = archive.Restore(ctx, ds, trustDomain, r)
+			return err
+		case strings.HasPrefix(name, dataDirPrefix):
+			return restoreDataDirFile(dataDir, strings.TrimPrefix(name, dataDirPrefix), r)
+		default:
+			return nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		return nil, errors.New("snapshot does not contain the datastore contents")
+	}
+	return stats, nil
+}
+
+func readSnapshot(r io.Reader, fn func(name string, r io.Reader) error) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("unable to read snapshot: %w", err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		th, err := tr.Next()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return fmt.Errorf("unable to read snapshot: %w", err)
+		case th.Typeflag != tar.TypeReg:
+			return fmt.Errorf("unexpected snapshot entry %q", th.Name)
+		}
+		if err := fn(th.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func checkHeader(header *Header, trustDomain spiffeid.TrustDomain) error {
+	switch {
+	case header.Version == 0:
+		return errors.New("snapshot header is missing the version")
+	case header.Version > Version:
+		return fmt.Errorf("unsupported snapshot version %d; the latest supported version is %d", header.Version, Version)
+	case header.TrustDomain != trustDomain.Name():
+		return fmt.Errorf("snapshot belongs to trust domain %q, not %q", header.TrustDomain, trustDomain.Name())
+	case len(header.X509Authorities) == 0:
+		return errors.New("snapshot header is missing the X.509 authorities")
+	}
+	return nil
+}
+
+func checkDigests(signed, actual []digest) error {
+	if len(signed) != len(actual) {
+		return errors.New("snapshot contents do not match the signed digests")
+	}
+	for i := range signed {
+		if signed[i] != actual[i] {
+			return fmt.Errorf("snapshot file %q does not match the signed digest", actual[i].Name)
+		}
+	}
+	return nil
+}
+
+func checkSignature(header *Header, sig *signature, trustDomain spiffeid.TrustDomain, trustedAuthorities []*x509.Certificate) error {
+	if len(trustedAuthorities) == 0 {
+		return errors.New("no trusted X.509 authorities to verify the snapshot signer X509-SVID")
+	}
+	chain, err := x509util.RawCertsToCertificates(sig.X509SVID)
+	if err != nil {
+		return fmt.Errorf("malformed snapshot signer X509-SVID: %w", err)
+	}
+	if len(chain) == 0 {
+		return errors.New("snapshot signer X509-SVID is missing")
+	}
+
+	id, verifiedChains, err := x509svid.Verify(chain, x509bundle.FromX509Authorities(trustDomain, trustedAuthorities))
+	if err != nil {
+		return fmt.Errorf("snapshot signer X509-SVID does not chain to the trusted X.509 authorities: %w", err)
+	}
+	if serverID := idutil.RequireServerID(trustDomain); id != serverID {
+		return fmt.Errorf("snapshot was signed by %q, not by the server %q", id, serverID)
+	}
+	if !hasAuthority(header.X509Authorities, verifiedChains) {
+		return errors.New("snapshot X.509 authorities are not continuous with the trusted X.509 authorities: the authority of the snapshot signer is missing")
+	}
+
+	var algorithm x509.SignatureAlgorithm
+	switch chain[0].PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		algorithm = x509.ECDSAWithSHA256
+	case *rsa.PublicKey:
+		algorithm = x509.SHA256WithRSA
+	default:
+		return fmt.Errorf("unsupported snapshot signer key type %T", chain[0].PublicKey)
+	}
+
+	signed, err := json.Marshal(sig.Digests)
+	if err != nil {
+		return err
+	}
+	if err := chain[0].CheckSignature(algorithm, signed, sig.Signature); err != nil {
+		return fmt.Errorf("invalid snapshot signature: %w", err)
+	}
+	return nil
+}
+
+// hasAuthority returns true if the root of any of the verified chains is one
+// of the given authorities.
+func hasAuthority(authorities [][]byte, verifiedChains [][]*x509.Certificate) bool {
+	for _, verifiedChain := range verifiedChains {
+		root := verifiedChain[len(verifiedChain)-1]
+		for _, authority := range authorities {
+			if bytes.Equal(root.Raw, authority) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func restoreDataDirFile(dataDir, name string, r io.Reader) error {
+	if !filepath.IsLocal(filepath.FromSlash(name)) || path.Clean(name) != name {
+		return fmt.Errorf("invalid data directory file name %q in snapshot", name)
+	}
+	p := filepath.Join(dataDir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return fmt.Errorf("unable to create data directory: %w", err)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("unable to read data directory file %q from snapshot: %w", name, err)
+	}
+	if err := diskutil.AtomicWritePrivateFile(p, data); err != nil {
+		return fmt.Errorf("unable to restore data directory file %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package snapshot_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/datastore/kvstore"
+	"github.com/spiffe/spire/pkg/server/snapshot"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ctx = context.Background()
+	td  = spiffeid.RequireTrustDomainFromString("example.org")
+	now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+func TestWriteAndRestore(t *testing.T) {
+	ca := testca.New(t, td)
+	src, dataDir := setupServer(t, ca)
+
+	snap := writeSnapshot(t, src, dataDir, ca)
+
+	header, err := snapshot.Verify(bytes.NewReader(snap), td, ca.X509Authorities())
+	require.NoError(t, err)
+	require.Equal(t, snapshot.Version, header.Version)
+	require.Equal(t, "example.org", header.TrustDomain)
+	require.Equal(t, now, header.CreatedAt)
+
+	// Records that are not in the snapshot are removed by the restore
+	dst := newDataStore(t)
+	_, err = dst.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/stale",
+		ParentId:  "spiffe://example.org/agent",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+
+	restoreDir := t.TempDir()
+	stats, err := snapshot.Restore(ctx, dst, header, restoreDir, bytes.NewReader(snap))
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Records["registration_entry"])
+	require.Equal(t, 1, stats.Records["ca_journal"])
+	require.Equal(t, 1, stats.Deleted)
+
+	entries, err := dst.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 1)
+	caJournal, err := dst.FetchCAJournal(ctx, "authority")
+	require.NoError(t, err)
+	require.Equal(t, []byte("journal"), caJournal.Data)
+
+	keys, err := os.ReadFile(filepath.Join(restoreDir, "keys.json"))
+	require.NoError(t, err)
+	require.Equal(t, "keys", string(keys))
+	nested, err := os.ReadFile(filepath.Join(restoreDir, "nested", "file"))
+	require.NoError(t, err)
+	require.Equal(t, "nested", string(nested))
+	require.NoFileExists(t, filepath.Join(restoreDir, "datastore.sqlite3-wal"))
+
+	// Restoring on top of the restored state is idempotent
+	stats, err = snapshot.Restore(ctx, dst, header, restoreDir, bytes.NewReader(snap))
+	require.NoError(t, err)
+	require.Equal(t, 3, stats.Unchanged)
+	require.Zero(t, stats.Deleted)
+}
+
+func TestWriteKeys(t *testing.T) {
+	ca := testca.New(t, td)
+	src, dataDir := setupServer(t, ca)
+	svid := ca.CreateX509SVID(idutil.RequireServerID(td))
+
+	write := func(includeKeys bool) []string {
+		buf := new(bytes.Buffer)
+		_, err := snapshot.Write(ctx, snapshot.Config{
+			TrustDomain: td,
+			DataStore:   src,
+			DataDir:     dataDir,
+			KeyPaths:    []string{filepath.Join(dataDir, "keys.json"), filepath.Join(dataDir, "nested")},
+			IncludeKeys: includeKeys,
+			X509SVID:    svid.Certificates,
+			Key:         svid.PrivateKey,
+		}, buf)
+		require.NoError(t, err)
+
+		var names []string
+		rewriteSnapshot(t, buf.Bytes(), func(name string, data []byte) []byte {
+			names = append(names, name)
+			return data
+		})
+		return names
+	}
+
+	// Key files are left out by default
+	require.Equal(t, []string{"snapshot.json", "datastore.archive", "signature.json"}, write(false))
+
+	require.Equal(t, []string{"snapshot.json", "datastore.archive", "data_dir/keys.json", "data_dir/nested/file", "signature.json"}, write(true))
+}
+
+func TestVerify(t *testing.T) {
+	ca := testca.New(t, td)
+	src, dataDir := setupServer(t, ca)
+	snap := writeSnapshot(t, src, dataDir, ca)
+
+	t.Run("wrong trust domain", func(t *testing.T) {
+		_, err := snapshot.Verify(bytes.NewReader(snap), spiffeid.RequireTrustDomainFromString("other.org"), ca.X509Authorities())
+		require.EqualError(t, err, `snapshot belongs to trust domain "example.org", not "other.org"`)
+	})
+
+	t.Run("tampered file", func(t *testing.T) {
+		tampered := rewriteSnapshot(t, snap, func(name string, data []byte) []byte {
+			if name == "data_dir/keys.json" {
+				return []byte("other keys")
+			}
+			return data
+		})
+		_, err := snapshot.Verify(bytes.NewReader(tampered), td, ca.X509Authorities())
+		require.EqualError(t, err, `snapshot file "data_dir/keys.json" does not match the signed digest`)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		tampered := rewriteSnapshot(t, snap, func(name string, data []byte) []byte {
+			if name == "data_dir/keys.json" {
+				return nil
+			}
+			return data
+		})
+		_, err := snapshot.Verify(bytes.NewReader(tampered), td, ca.X509Authorities())
+		require.EqualError(t, err, "snapshot contents do not match the signed digests")
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		tampered := rewriteSnapshot(t, snap, func(name string, data []byte) []byte {
+			if name == "signature.json" {
+				return nil
+			}
+			return data
+		})
+		_, err := snapshot.Verify(bytes.NewReader(tampered), td, ca.X509Authorities())
+		require.EqualError(t, err, "snapshot is not signed")
+	})
+
+	t.Run("no trusted authorities", func(t *testing.T) {
+		_, err := snapshot.Verify(bytes.NewReader(snap), td, nil)
+		require.EqualError(t, err, "no trusted X.509 authorities to verify the snapshot signer X509-SVID")
+	})
+
+	t.Run("forged by another CA", func(t *testing.T) {
+		// The snapshot carries the authorities of the forging CA, which
+		// must not be trusted.
+		otherCA := testca.New(t, td)
+		otherSrc, otherDataDir := setupServer(t, otherCA)
+		forged := writeSnapshot(t, otherSrc, otherDataDir, otherCA)
+		_, err := snapshot.Verify(bytes.NewReader(forged), td, ca.X509Authorities())
+		require.ErrorContains(t, err, "snapshot signer X509-SVID does not chain to the trusted X.509 authorities")
+	})
+
+	t.Run("trusted authority missing from the snapshot", func(t *testing.T) {
+		otherCA := testca.New(t, td)
+		otherSnap := writeSnapshot(t, src, dataDir, otherCA)
+		_, err := snapshot.Verify(bytes.NewReader(otherSnap), td, otherCA.X509Authorities())
+		require.EqualError(t, err, "snapshot X.509 authorities are not continuous with the trusted X.509 authorities: the authority of the snapshot signer is missing")
+	})
+
+	t.Run("signed by a workload", func(t *testing.T) {
+		svid := ca.CreateX509SVID(spiffeid.RequireFromPath(td, "/workload"))
+		buf := new(bytes.Buffer)
+		_, err := snapshot.Write(ctx, snapshot.Config{
+			TrustDomain: td,
+			DataStore:   src,
+			X509SVID:    svid.Certificates,
+			Key:         svid.PrivateKey,
+		}, buf)
+		require.NoError(t, err)
+		_, err = snapshot.Verify(buf, td, ca.X509Authorities())
+		require.EqualError(t, err, `snapshot was signed by "spiffe://example.org/workload", not by the server "spiffe://example.org/spire/server"`)
+	})
+
+	t.Run("not a snapshot", func(t *testing.T) {
+		_, err := snapshot.Verify(bytes.NewReader([]byte("not a snapshot")), td, ca.X509Authorities())
+		require.ErrorContains(t, err, "unable to read snapshot")
+	})
+}
+
+func setupServer(t *testing.T, ca *testca.CA) (datastore.DataStore, string) {
+	ds := newDataStore(t)
+	_, err := ds.CreateBundle(ctx, &common.Bundle{
+		TrustDomainId: td.IDString(),
+		RootCas:       []*common.Certificate{{DerBytes: ca.X509Authorities()[0].Raw}},
+	})
+	require.NoError(t, err)
+	_, err = ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/workload",
+		ParentId:  "spiffe://example.org/agent",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+	_, err = ds.SetCAJournal(ctx, &datastore.CAJournal{
+		ActiveX509AuthorityID: "authority",
+		Data:                  []byte("journal"),
+	})
+	require.NoError(t, err)
+
+	dataDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "keys.json"), []byte("keys"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dataDir, "nested"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "nested", "file"), []byte("nested"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "datastore.sqlite3-wal"), []byte("wal"), 0o600))
+	return ds, dataDir
+}
+
+func writeSnapshot(t *testing.T, ds datastore.DataStore, dataDir string, ca *testca.CA) []byte {
+	svid := ca.CreateX509SVID(idutil.RequireServerID(td))
+	buf := new(bytes.Buffer)
+	_, err := snapshot.Write(ctx, snapshot.Config{
+		TrustDomain: td,
+		DataStore:   ds,
+		DataDir:     dataDir,
+		X509SVID:    svid.Certificates,
+		Key:         svid.PrivateKey,
+		Now:         func() time.Time { return now },
+	}, buf)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+// rewriteSnapshot rewrites the files of a snapshot. Files for which fn
+// returns nil are dropped.
+func rewriteSnapshot(t *testing.T, snap []byte, fn func(name string, data []byte) []byte) []byte {
+	gr, err := gzip.NewReader(bytes.NewReader(snap))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for {
+		th, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+
+		data = fn(th.Name, data)
+		if data == nil {
+			continue
+		}
+		th.Size = int64(len(data))
+		require.NoError(t, tw.WriteHeader(th))
+		_, err = tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func newDataStore(t *testing.T) datastore.DataStore {
+	log, _ := test.NewNullLogger()
+	ds := kvstore.New(log)
+	require.NoError(t, ds.Configure(ctx, fmt.Sprintf(`database_path = %q`, filepath.Join(t.TempDir(), "datastore.db"))))
+	t.Cleanup(func() { ds.Close() })
+	return ds
+}
@@ -0,0 +1,231 @@
+// Package tenant partitions the registration entries of a trust domain
+// between tenants. A tenant owns the entries whose SPIFFE ID lives under the
+// tenant path prefix and whose DNS names live under the tenant DNS name
+// suffixes, and the tenant admins may only manage those entries through the
+// Entry API.
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Config is the configuration of a single tenant.
+type Config struct {
+	// Name identifies the tenant in logs and errors.
+	Name string
+
+	// PathPrefix is the SPIFFE ID path owned by the tenant, e.g. "/team-a".
+	PathPrefix string
+
+	// AdminIDs are the callers allowed to manage the tenant entries.
+	AdminIDs []spiffeid.ID
+
+	// DNSNameSuffixes are the DNS domains owned by the tenant, e.g.
+	// "team-a.example.org". Tenant entries may only have DNS names in these
+	// domains. When empty, tenant entries may not have DNS names.
+	DNSNameSuffixes []string
+}
+
+// Tenant is a validated tenant of a trust domain.
+type Tenant struct {
+	name            string
+	td              spiffeid.TrustDomain
+	pathPrefix      string
+	dnsNameSuffixes []string
+}
+
+// Name returns the tenant name.
+func (t *Tenant) Name() string {
+	return t.name
+}
+
+// PathPrefix returns the SPIFFE ID path owned by the tenant.
+func (t *Tenant) PathPrefix() string {
+	return t.pathPrefix
+}
+
+// IDPrefix returns the SPIFFE ID of the tenant path prefix. The tenant may
+// only own this ID and the IDs under it.
+func (t *Tenant) IDPrefix() string {
+	return t.td.IDString() + t.pathPrefix
+}
+
+// OwnsID returns true if the ID is a member of the trust domain and its path
+// is the tenant path prefix or lives under it.
+func (t *Tenant) OwnsID(id spiffeid.ID) bool {
+	if !id.MemberOf(t.td) {
+		return false
+	}
+	path := id.Path()
+	return path == t.pathPrefix || strings.HasPrefix(path, t.pathPrefix+"/")
+}
+
+// OwnsDNSName returns true if the DNS name is one of the tenant DNS name
+// suffixes or lives under one of them.
+func (t *Tenant) OwnsDNSName(dnsName string) bool {
+	dnsName = strings.ToLower(dnsName)
+	for _, suffix := range t.dnsNameSuffixes {
+		if dnsName == suffix || strings.HasSuffix(dnsName, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnsEntry returns true if the entry belongs to the tenant. The SPIFFE ID
+// and the DNS names of the entry must be owned by the tenant. The parent ID
+// must be owned by the tenant as well, or be the ID of an agent of the trust
+// domain. Admin, downstream and federated entries grant access beyond the
+// tenant and are never owned by one.
+func (t *Tenant) OwnsEntry(e *common.RegistrationEntry) bool {
+	if e.Admin || e.Downstream || len(e.FederatesWith) > 0 {
+		return false
+	}
+	for _, dnsName := range e.DnsNames {
+		if !t.OwnsDNSName(dnsName) {
+			return false
+		}
+	}
+	spiffeID, err := spiffeid.FromString(e.SpiffeId)
+	if err != nil || !t.OwnsID(spiffeID) {
+		return false
+	}
+	parentID, err := spiffeid.FromString(e.ParentId)
+	if err != nil {
+		return false
+	}
+	return t.OwnsID(parentID) || (parentID.MemberOf(t.td) && idutil.IsAgentPath(parentID.Path()))
+}
+
+// Set is the set of tenants of a trust domain. The zero value and a nil Set
+// have no tenants.
+type Set struct {
+	tenants map[spiffeid.ID]*Tenant
+}
+
+// NewSet validates the tenant configurations and returns the set of tenants.
+// Tenant path prefixes may not overlap and an admin ID may only belong to
+// one tenant.
+func NewSet(td spiffeid.TrustDomain, configs []Config) (*Set, error) {
+	set := &Set{
+		tenants: make(map[spiffeid.ID]*Tenant),
+	}
+
+	var tenants []*Tenant
+	for _, config := range configs {
+		if config.Name == "" {
+			return nil, errors.New("tenant name is required")
+		}
+		if err := validatePathPrefix(config.PathPrefix); err != nil {
+			return nil, fmt.Errorf("invalid path prefix for tenant %q: %w", config.Name, err)
+		}
+		if len(config.AdminIDs) == 0 {
+			return nil, fmt.Errorf("tenant %q has no admin IDs", config.Name)
+		}
+
+		tenant := &Tenant{
+			name:       config.Name,
+			td:         td,
+			pathPrefix: config.PathPrefix,
+		}
+		for _, suffix := range config.DNSNameSuffixes {
+			if err := validateDNSNameSuffix(suffix); err != nil {
+				return nil, fmt.Errorf("invalid DNS name suffix %q for tenant %q: %w", suffix, config.Name, err)
+			}
+			tenant.dnsNameSuffixes = append(tenant.dnsNameSuffixes, strings.ToLower(suffix))
+		}
+		for _, other := range tenants {
+			if other.name == tenant.name {
+				return nil, fmt.Errorf("tenant %q is configured more than once", tenant.name)
+			}
+			if prefixesOverlap(other.pathPrefix, tenant.pathPrefix) {
+				return nil, fmt.Errorf("path prefix %q of tenant %q overlaps with path prefix %q of tenant %q", tenant.pathPrefix, tenant.name, other.pathPrefix, other.name)
+			}
+			if tenant.ownsDNSNameSuffixOf(other) || other.ownsDNSNameSuffixOf(tenant) {
+				return nil, fmt.Errorf("DNS name suffixes of tenant %q overlap with DNS name suffixes of tenant %q", tenant.name, other.name)
+			}
+		}
+		tenants = append(tenants, tenant)
+
+		for _, adminID := range config.AdminIDs {
+			if other, ok := set.tenants[adminID]; ok {
+				return nil, fmt.Errorf("admin ID %q belongs to both tenant %q and tenant %q", adminID, other.name, tenant.name)
+			}
+			set.tenants[adminID] = tenant
+		}
+	}
+
+	return set, nil
+}
+
+// ForAdmin returns the tenant administered by the given ID, if any.
+func (s *Set) ForAdmin(id spiffeid.ID) (*Tenant, bool) {
+	if s == nil {
+		return nil, false
+	}
+	tenant, ok := s.tenants[id]
+	return tenant, ok
+}
+
+// AdminIDs returns the admin IDs of all the tenants, sorted.
+func (s *Set) AdminIDs() []spiffeid.ID {
+	if s == nil {
+		return nil
+	}
+	ids := make([]spiffeid.ID, 0, len(s.tenants))
+	for id := range s.tenants {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	return ids
+}
+
+func validatePathPrefix(prefix string) error {
+	switch {
+	case prefix == "":
+		return errors.New("path prefix is required")
+	case prefix == "/":
+		return errors.New("path prefix cannot be the root path")
+	case idutil.IsReservedPath(prefix):
+		return errors.New("path prefix cannot be under the reserved /spire path")
+	}
+	return spiffeid.ValidatePath(prefix)
+}
+
+func validateDNSNameSuffix(suffix string) error {
+	switch {
+	case suffix == "":
+		return errors.New("suffix cannot be empty")
+	case strings.HasPrefix(suffix, ".") || strings.HasSuffix(suffix, "."):
+		return errors.New("suffix cannot start or end with a dot")
+	case strings.Contains(suffix, "*"):
+		return errors.New("suffix cannot contain wildcards")
+	case !strings.Contains(suffix, "."):
+		return errors.New("suffix must have at least two labels")
+	}
+	return nil
+}
+
+// ownsDNSNameSuffixOf returns true if one of the DNS name suffixes of the
+// other tenant is owned by this tenant.
+func (t *Tenant) ownsDNSNameSuffixOf(other *Tenant) bool {
+	for _, suffix := range other.dnsNameSuffixes {
+		if t.OwnsDNSName(suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func prefixesOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
@@ -0,0 +1,213 @@
+package tenant_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/tenant"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	td     = spiffeid.RequireTrustDomainFromString("example.org")
+	adminA = spiffeid.RequireFromPath(td, "/admin-a")
+	adminB = spiffeid.RequireFromPath(td, "/admin-b")
+)
+
+func TestNewSet(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		configs   []tenant.Config
+		expectErr string
+	}{
+		{
+			name: "no tenants",
+		},
+		{
+			name: "valid tenants",
+			configs: []tenant.Config{
+				{Name: "a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}},
+				{Name: "b", PathPrefix: "/team-b", AdminIDs: []spiffeid.ID{adminB}},
+			},
+		},
+		{
+			name:      "missing name",
+			configs:   []tenant.Config{{PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}}},
+			expectErr: "tenant name is required",
+		},
+		{
+			name:      "missing path prefix",
+			configs:   []tenant.Config{{Name: "a", AdminIDs: []spiffeid.ID{adminA}}},
+			expectErr: `invalid path prefix for tenant "a": path prefix is required`,
+		},
+		{
+			name:      "root path prefix",
+			configs:   []tenant.Config{{Name: "a", PathPrefix: "/", AdminIDs: []spiffeid.ID{adminA}}},
+			expectErr: `invalid path prefix for tenant "a": path prefix cannot be the root path`,
+		},
+		{
+			name:      "reserved path prefix",
+			configs:   []tenant.Config{{Name: "a", PathPrefix: "/spire/agent", AdminIDs: []spiffeid.ID{adminA}}},
+			expectErr: `invalid path prefix for tenant "a": path prefix cannot be under the reserved /spire path`,
+		},
+		{
+			name:      "invalid path prefix",
+			configs:   []tenant.Config{{Name: "a", PathPrefix: "/team-a/", AdminIDs: []spiffeid.ID{adminA}}},
+			expectErr: `invalid path prefix for tenant "a": path cannot have a trailing slash`,
+		},
+		{
+			name:      "no admin IDs",
+			configs:   []tenant.Config{{Name: "a", PathPrefix: "/team-a"}},
+			expectErr: `tenant "a" has no admin IDs`,
+		},
+		{
+			name: "duplicate name",
+			configs: []tenant.Config{
+				{Name: "a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}},
+				{Name: "a", PathPrefix: "/team-b", AdminIDs: []spiffeid.ID{adminB}},
+			},
+			expectErr: `tenant "a" is configured more than once`,
+		},
+		{
+			name: "overlapping path prefixes",
+			configs: []tenant.Config{
+				{Name: "a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}},
+				{Name: "b", PathPrefix: "/team-a/sub", AdminIDs: []spiffeid.ID{adminB}},
+			},
+			expectErr: `path prefix "/team-a/sub" of tenant "b" overlaps with path prefix "/team-a" of tenant "a"`,
+		},
+		{
+			name:      "empty DNS name suffix",
+			configs:   []tenant.Config{{Name: "a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}, DNSNameSuffixes: []string{""}}},
+			expectErr: `invalid DNS name suffix "" for tenant "a": suffix cannot be empty`,
+		},
+		{
+			name:      "wildcard DNS name suffix",
+			configs:   []tenant.Config{{Name: "a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}, DNSNameSuffixes: []string{"*.example.org"}}},
+			expectErr: `invalid DNS name suffix "*.example.org" for tenant "a": suffix cannot contain wildcards`,
+		},
+		{
+			name:      "top-level DNS name suffix",
+			configs:   []tenant.Config{{Name: "a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}, DNSNameSuffixes: []string{"org"}}},
+			expectErr: `invalid DNS name suffix "org" for tenant "a": suffix must have at least two labels`,
+		},
+		{
+			name: "overlapping DNS name suffixes",
+			configs: []tenant.Config{
+				{Name: "a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}, DNSNameSuffixes: []string{"team-a.example.org"}},
+				{Name: "b", PathPrefix: "/team-b", AdminIDs: []spiffeid.ID{adminB}, DNSNameSuffixes: []string{"example.org"}},
+			},
+			expectErr: `DNS name suffixes of tenant "b" overlap with DNS name suffixes of tenant "a"`,
+		},
+		{
+			name: "shared admin ID",
+			configs: []tenant.Config{
+				{Name: "a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}},
+				{Name: "b", PathPrefix: "/team-b", AdminIDs: []spiffeid.ID{adminA}},
+			},
+			expectErr: `admin ID "spiffe://example.org/admin-a" belongs to both tenant "a" and tenant "b"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := tenant.NewSet(td, tt.configs)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, set.AdminIDs(), len(tt.configs))
+		})
+	}
+}
+
+func TestForAdmin(t *testing.T) {
+	set, err := tenant.NewSet(td, []tenant.Config{
+		{Name: "a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}},
+	})
+	require.NoError(t, err)
+
+	a, ok := set.ForAdmin(adminA)
+	require.True(t, ok)
+	require.Equal(t, "a", a.Name())
+	require.Equal(t, "/team-a", a.PathPrefix())
+	require.Equal(t, "spiffe://example.org/team-a", a.IDPrefix())
+
+	_, ok = set.ForAdmin(adminB)
+	require.False(t, ok)
+
+	var nilSet *tenant.Set
+	_, ok = nilSet.ForAdmin(adminA)
+	require.False(t, ok)
+	require.Empty(t, nilSet.AdminIDs())
+}
+
+func TestOwnsEntry(t *testing.T) {
+	set, err := tenant.NewSet(td, []tenant.Config{
+		{Name: "a", PathPrefix: "/team-a", AdminIDs: []spiffeid.ID{adminA}, DNSNameSuffixes: []string{"Team-A.example.org"}},
+	})
+	require.NoError(t, err)
+	a, _ := set.ForAdmin(adminA)
+
+	for _, tt := range []struct {
+		name   string
+		entry  *common.RegistrationEntry
+		expect bool
+	}{
+		{
+			name:   "workload under the prefix parented by an agent",
+			entry:  &common.RegistrationEntry{SpiffeId: "spiffe://example.org/team-a/workload", ParentId: "spiffe://example.org/spire/agent/x509pop/node"},
+			expect: true,
+		},
+		{
+			name:   "workload at the prefix parented by a tenant node",
+			entry:  &common.RegistrationEntry{SpiffeId: "spiffe://example.org/team-a", ParentId: "spiffe://example.org/team-a/node"},
+			expect: true,
+		},
+		{
+			name:  "workload outside of the prefix",
+			entry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/team-ab/workload", ParentId: "spiffe://example.org/spire/agent/x509pop/node"},
+		},
+		{
+			name:  "workload in another trust domain",
+			entry: &common.RegistrationEntry{SpiffeId: "spiffe://other.org/team-a/workload", ParentId: "spiffe://other.org/spire/agent/x509pop/node"},
+		},
+		{
+			name:  "parented by another tenant",
+			entry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/team-a/workload", ParentId: "spiffe://example.org/team-b/node"},
+		},
+		{
+			name:  "parented by the server",
+			entry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/team-a/node", ParentId: "spiffe://example.org/spire/server"},
+		},
+		{
+			name:  "admin entry",
+			entry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/team-a/workload", ParentId: "spiffe://example.org/spire/agent/x509pop/node", Admin: true},
+		},
+		{
+			name:  "downstream entry",
+			entry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/team-a/workload", ParentId: "spiffe://example.org/spire/agent/x509pop/node", Downstream: true},
+		},
+		{
+			name:   "DNS names under the tenant suffixes",
+			entry:  &common.RegistrationEntry{SpiffeId: "spiffe://example.org/team-a/workload", ParentId: "spiffe://example.org/spire/agent/x509pop/node", DnsNames: []string{"team-a.example.org", "web.team-a.example.org", "*.TEAM-A.example.org"}},
+			expect: true,
+		},
+		{
+			name:  "DNS name outside of the tenant suffixes",
+			entry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/team-a/workload", ParentId: "spiffe://example.org/spire/agent/x509pop/node", DnsNames: []string{"web.team-a.example.org", "web.xteam-a.example.org"}},
+		},
+		{
+			name:  "federated entry",
+			entry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/team-a/workload", ParentId: "spiffe://example.org/spire/agent/x509pop/node", FederatesWith: []string{"spiffe://other.org"}},
+		},
+		{
+			name:  "malformed SPIFFE ID",
+			entry: &common.RegistrationEntry{SpiffeId: "team-a", ParentId: "spiffe://example.org/spire/agent/x509pop/node"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, a.OwnsEntry(tt.entry))
+		})
+	}
+}
@@ -0,0 +1,834 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        v7.35.0
+// source: spire/agent/broker/broker.proto
+
+package broker
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The TaggedWorkloadReference message associates a workload reference with
+// an ID chosen by the caller.
+type TaggedWorkloadReference struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The caller chosen ID of the reference. MUST be unique among
+	// the references subscribed on the stream.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Required. The reference to the workload, using the same types accepted
+	// by the SPIFFE Broker API WorkloadReference message.
+	Reference     *anypb.Any `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaggedWorkloadReference) Reset() {
+	*x = TaggedWorkloadReference{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaggedWorkloadReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaggedWorkloadReference) ProtoMessage() {}
+
+func (x *TaggedWorkloadReference) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaggedWorkloadReference.ProtoReflect.Descriptor instead.
+func (*TaggedWorkloadReference) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TaggedWorkloadReference) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaggedWorkloadReference) GetReference() *anypb.Any {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+type SubscribeToX509SVIDsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. References to start receiving X.509-SVID updates for.
+	Subscribe []*TaggedWorkloadReference `protobuf:"bytes,1,rep,name=subscribe,proto3" json:"subscribe,omitempty"`
+	// Optional. IDs of previously subscribed references to stop receiving
+	// X.509-SVID updates for. Unknown IDs are ignored.
+	Unsubscribe   []string `protobuf:"bytes,2,rep,name=unsubscribe,proto3" json:"unsubscribe,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToX509SVIDsRequest) Reset() {
+	*x = SubscribeToX509SVIDsRequest{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToX509SVIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToX509SVIDsRequest) ProtoMessage() {}
+
+func (x *SubscribeToX509SVIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToX509SVIDsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToX509SVIDsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubscribeToX509SVIDsRequest) GetSubscribe() []*TaggedWorkloadReference {
+	if x != nil {
+		return x.Subscribe
+	}
+	return nil
+}
+
+func (x *SubscribeToX509SVIDsRequest) GetUnsubscribe() []string {
+	if x != nil {
+		return x.Unsubscribe
+	}
+	return nil
+}
+
+type SubscribeToX509SVIDsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the reference this response pertains to.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*SubscribeToX509SVIDsResponse_Update
+	//	*SubscribeToX509SVIDsResponse_Error
+	Result        isSubscribeToX509SVIDsResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToX509SVIDsResponse) Reset() {
+	*x = SubscribeToX509SVIDsResponse{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToX509SVIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToX509SVIDsResponse) ProtoMessage() {}
+
+func (x *SubscribeToX509SVIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToX509SVIDsResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeToX509SVIDsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscribeToX509SVIDsResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubscribeToX509SVIDsResponse) GetResult() isSubscribeToX509SVIDsResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *SubscribeToX509SVIDsResponse) GetUpdate() *X509SVIDUpdate {
+	if x != nil {
+		if x, ok := x.Result.(*SubscribeToX509SVIDsResponse_Update); ok {
+			return x.Update
+		}
+	}
+	return nil
+}
+
+func (x *SubscribeToX509SVIDsResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*SubscribeToX509SVIDsResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isSubscribeToX509SVIDsResponse_Result interface {
+	isSubscribeToX509SVIDsResponse_Result()
+}
+
+type SubscribeToX509SVIDsResponse_Update struct {
+	// The X.509-SVIDs and bundles for the referenced workload.
+	Update *X509SVIDUpdate `protobuf:"bytes,2,opt,name=update,proto3,oneof"`
+}
+
+type SubscribeToX509SVIDsResponse_Error struct {
+	// The error that caused the reference to be unsubscribed.
+	Error *Error `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*SubscribeToX509SVIDsResponse_Update) isSubscribeToX509SVIDsResponse_Result() {}
+
+func (*SubscribeToX509SVIDsResponse_Error) isSubscribeToX509SVIDsResponse_Result() {}
+
+// The X509SVIDUpdate message has the same contents as the SPIFFE Broker API
+// SubscribeToX509SVIDResponse message.
+type X509SVIDUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. A list of X509SVID messages, each of which includes a single
+	// SPIFFE Verifiable Identity Document, along with its private key and
+	// bundle.
+	Svids []*X509SVID `protobuf:"bytes,1,rep,name=svids,proto3" json:"svids,omitempty"`
+	// Optional. CA certificate bundles belonging to foreign trust domains
+	// that the workload should trust, keyed by the SPIFFE ID of the foreign
+	// trust domain. Bundles are ASN.1 DER encoded.
+	FederatedBundles map[string][]byte `protobuf:"bytes,2,rep,name=federated_bundles,json=federatedBundles,proto3" json:"federated_bundles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *X509SVIDUpdate) Reset() {
+	*x = X509SVIDUpdate{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *X509SVIDUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*X509SVIDUpdate) ProtoMessage() {}
+
+func (x *X509SVIDUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use X509SVIDUpdate.ProtoReflect.Descriptor instead.
+func (*X509SVIDUpdate) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *X509SVIDUpdate) GetSvids() []*X509SVID {
+	if x != nil {
+		return x.Svids
+	}
+	return nil
+}
+
+func (x *X509SVIDUpdate) GetFederatedBundles() map[string][]byte {
+	if x != nil {
+		return x.FederatedBundles
+	}
+	return nil
+}
+
+// The X509SVID message carries a single SVID and all associated information,
+// including the X.509 bundle for the trust domain.
+type X509SVID struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The SPIFFE ID of the SVID in this entry.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Required. ASN.1 DER encoded certificate chain. MAY include
+	// intermediates, the leaf certificate (or SVID itself) MUST come first.
+	X509Svid []byte `protobuf:"bytes,2,opt,name=x509_svid,json=x509Svid,proto3" json:"x509_svid,omitempty"`
+	// Required. ASN.1 DER encoded PKCS#8 private key. MUST be unencrypted.
+	X509SvidKey []byte `protobuf:"bytes,3,opt,name=x509_svid_key,json=x509SvidKey,proto3" json:"x509_svid_key,omitempty"`
+	// Required. ASN.1 DER encoded X.509 bundle for the trust domain.
+	Bundle []byte `protobuf:"bytes,4,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// Optional. An operator-specified string used to provide guidance on how
+	// this identity should be used by a workload when more than one SVID is
+	// returned.
+	Hint          string `protobuf:"bytes,5,opt,name=hint,proto3" json:"hint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *X509SVID) Reset() {
+	*x = X509SVID{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *X509SVID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*X509SVID) ProtoMessage() {}
+
+func (x *X509SVID) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use X509SVID.ProtoReflect.Descriptor instead.
+func (*X509SVID) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *X509SVID) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+func (x *X509SVID) GetX509Svid() []byte {
+	if x != nil {
+		return x.X509Svid
+	}
+	return nil
+}
+
+func (x *X509SVID) GetX509SvidKey() []byte {
+	if x != nil {
+		return x.X509SvidKey
+	}
+	return nil
+}
+
+func (x *X509SVID) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *X509SVID) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+// The Error message describes why a reference could not be served.
+type Error struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The gRPC status code.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The error message.
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type FetchWITSVIDRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The reference to the workload, using the same types accepted
+	// by the SPIFFE Broker API WorkloadReference message.
+	Reference *anypb.Any `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	// Optional. The requested SPIFFE ID for the WIT-SVID. If unset, all
+	// WIT-SVIDs that the referenced workload is entitled to are returned.
+	SpiffeId      string `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchWITSVIDRequest) Reset() {
+	*x = FetchWITSVIDRequest{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchWITSVIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchWITSVIDRequest) ProtoMessage() {}
+
+func (x *FetchWITSVIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchWITSVIDRequest.ProtoReflect.Descriptor instead.
+func (*FetchWITSVIDRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FetchWITSVIDRequest) GetReference() *anypb.Any {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+func (x *FetchWITSVIDRequest) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+type FetchWITSVIDResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The list of returned WIT-SVIDs.
+	Svids         []*WITSVID `protobuf:"bytes,1,rep,name=svids,proto3" json:"svids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchWITSVIDResponse) Reset() {
+	*x = FetchWITSVIDResponse{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchWITSVIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchWITSVIDResponse) ProtoMessage() {}
+
+func (x *FetchWITSVIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchWITSVIDResponse.ProtoReflect.Descriptor instead.
+func (*FetchWITSVIDResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FetchWITSVIDResponse) GetSvids() []*WITSVID {
+	if x != nil {
+		return x.Svids
+	}
+	return nil
+}
+
+// The WITSVID message conveys a single WIT-SVID along with the private key it
+// is bound to.
+type WITSVID struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The SPIFFE ID of the WIT-SVID.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Required. Encoded WIT-SVID using JWS Compact Serialization.
+	WitSvid string `protobuf:"bytes,2,opt,name=wit_svid,json=witSvid,proto3" json:"wit_svid,omitempty"`
+	// Required. JWK-encoded private key bound to this WIT-SVID.
+	WitSvidKey string `protobuf:"bytes,3,opt,name=wit_svid_key,json=witSvidKey,proto3" json:"wit_svid_key,omitempty"`
+	// Optional. An operator-specified string used to provide guidance on how
+	// this identity should be used by a workload when more than one SVID is
+	// returned.
+	Hint          string `protobuf:"bytes,4,opt,name=hint,proto3" json:"hint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WITSVID) Reset() {
+	*x = WITSVID{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WITSVID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WITSVID) ProtoMessage() {}
+
+func (x *WITSVID) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WITSVID.ProtoReflect.Descriptor instead.
+func (*WITSVID) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WITSVID) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+func (x *WITSVID) GetWitSvid() string {
+	if x != nil {
+		return x.WitSvid
+	}
+	return ""
+}
+
+func (x *WITSVID) GetWitSvidKey() string {
+	if x != nil {
+		return x.WitSvidKey
+	}
+	return ""
+}
+
+func (x *WITSVID) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+type SubscribeToWITBundlesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The reference to the workload, using the same types accepted
+	// by the SPIFFE Broker API WorkloadReference message.
+	Reference     *anypb.Any `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToWITBundlesRequest) Reset() {
+	*x = SubscribeToWITBundlesRequest{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToWITBundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToWITBundlesRequest) ProtoMessage() {}
+
+func (x *SubscribeToWITBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToWITBundlesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToWITBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubscribeToWITBundlesRequest) GetReference() *anypb.Any {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+type SubscribeToWITBundlesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. JWK encoded WIT bundles, keyed by the SPIFFE ID of the trust
+	// domain.
+	Bundles       map[string]string `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToWITBundlesResponse) Reset() {
+	*x = SubscribeToWITBundlesResponse{}
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToWITBundlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToWITBundlesResponse) ProtoMessage() {}
+
+func (x *SubscribeToWITBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_broker_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToWITBundlesResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeToWITBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_broker_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubscribeToWITBundlesResponse) GetBundles() map[string]string {
+	if x != nil {
+		return x.Bundles
+	}
+	return nil
+}
+
+var File_spire_agent_broker_broker_proto protoreflect.FileDescriptor
+
+const file_spire_agent_broker_broker_proto_rawDesc = "" +
+	"\n" +
+	"\x1fspire/agent/broker/broker.proto\x12\x12spire.agent.broker\x1a\x19google/protobuf/any.proto\"]\n" +
+	"\x17TaggedWorkloadReference\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x122\n" +
+	"\treference\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\treference\"\x8a\x01\n" +
+	"\x1bSubscribeToX509SVIDsRequest\x12I\n" +
+	"\tsubscribe\x18\x01 \x03(\v2+.spire.agent.broker.TaggedWorkloadReferenceR\tsubscribe\x12 \n" +
+	"\vunsubscribe\x18\x02 \x03(\tR\vunsubscribe\"\xa9\x01\n" +
+	"\x1cSubscribeToX509SVIDsResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12<\n" +
+	"\x06update\x18\x02 \x01(\v2\".spire.agent.broker.X509SVIDUpdateH\x00R\x06update\x121\n" +
+	"\x05error\x18\x03 \x01(\v2\x19.spire.agent.broker.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\xf0\x01\n" +
+	"\x0eX509SVIDUpdate\x122\n" +
+	"\x05svids\x18\x01 \x03(\v2\x1c.spire.agent.broker.X509SVIDR\x05svids\x12e\n" +
+	"\x11federated_bundles\x18\x02 \x03(\v28.spire.agent.broker.X509SVIDUpdate.FederatedBundlesEntryR\x10federatedBundles\x1aC\n" +
+	"\x15FederatedBundlesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\x94\x01\n" +
+	"\bX509SVID\x12\x1b\n" +
+	"\tspiffe_id\x18\x01 \x01(\tR\bspiffeId\x12\x1b\n" +
+	"\tx509_svid\x18\x02 \x01(\fR\bx509Svid\x12\"\n" +
+	"\rx509_svid_key\x18\x03 \x01(\fR\vx509SvidKey\x12\x16\n" +
+	"\x06bundle\x18\x04 \x01(\fR\x06bundle\x12\x12\n" +
+	"\x04hint\x18\x05 \x01(\tR\x04hint\"5\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"f\n" +
+	"\x13FetchWITSVIDRequest\x122\n" +
+	"\treference\x18\x01 \x01(\v2\x14.google.protobuf.AnyR\treference\x12\x1b\n" +
+	"\tspiffe_id\x18\x02 \x01(\tR\bspiffeId\"I\n" +
+	"\x14FetchWITSVIDResponse\x121\n" +
+	"\x05svids\x18\x01 \x03(\v2\x1b.spire.agent.broker.WITSVIDR\x05svids\"w\n" +
+	"\aWITSVID\x12\x1b\n" +
+	"\tspiffe_id\x18\x01 \x01(\tR\bspiffeId\x12\x19\n" +
+	"\bwit_svid\x18\x02 \x01(\tR\awitSvid\x12 \n" +
+	"\fwit_svid_key\x18\x03 \x01(\tR\n" +
+	"witSvidKey\x12\x12\n" +
+	"\x04hint\x18\x04 \x01(\tR\x04hint\"R\n" +
+	"\x1cSubscribeToWITBundlesRequest\x122\n" +
+	"\treference\x18\x01 \x01(\v2\x14.google.protobuf.AnyR\treference\"\xb5\x01\n" +
+	"\x1dSubscribeToWITBundlesResponse\x12X\n" +
+	"\abundles\x18\x01 \x03(\v2>.spire.agent.broker.SubscribeToWITBundlesResponse.BundlesEntryR\abundles\x1a:\n" +
+	"\fBundlesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xe7\x02\n" +
+	"\x03API\x12}\n" +
+	"\x14SubscribeToX509SVIDs\x12/.spire.agent.broker.SubscribeToX509SVIDsRequest\x1a0.spire.agent.broker.SubscribeToX509SVIDsResponse(\x010\x01\x12a\n" +
+	"\fFetchWITSVID\x12'.spire.agent.broker.FetchWITSVIDRequest\x1a(.spire.agent.broker.FetchWITSVIDResponse\x12~\n" +
+	"\x15SubscribeToWITBundles\x120.spire.agent.broker.SubscribeToWITBundlesRequest\x1a1.spire.agent.broker.SubscribeToWITBundlesResponse0\x01B2Z0github.com/spiffe/spire/proto/spire/agent/brokerb\x06proto3"
+
+var (
+	file_spire_agent_broker_broker_proto_rawDescOnce sync.Once
+	file_spire_agent_broker_broker_proto_rawDescData []byte
+)
+
+func file_spire_agent_broker_broker_proto_rawDescGZIP() []byte {
+	file_spire_agent_broker_broker_proto_rawDescOnce.Do(func() {
+		file_spire_agent_broker_broker_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spire_agent_broker_broker_proto_rawDesc), len(file_spire_agent_broker_broker_proto_rawDesc)))
+	})
+	return file_spire_agent_broker_broker_proto_rawDescData
+}
+
+var file_spire_agent_broker_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_spire_agent_broker_broker_proto_goTypes = []any{
+	(*TaggedWorkloadReference)(nil),       // 0: spire.agent.broker.TaggedWorkloadReference
+	(*SubscribeToX509SVIDsRequest)(nil),   // 1: spire.agent.broker.SubscribeToX509SVIDsRequest
+	(*SubscribeToX509SVIDsResponse)(nil),  // 2: spire.agent.broker.SubscribeToX509SVIDsResponse
+	(*X509SVIDUpdate)(nil),                // 3: spire.agent.broker.X509SVIDUpdate
+	(*X509SVID)(nil),                      // 4: spire.agent.broker.X509SVID
+	(*Error)(nil),                         // 5: spire.agent.broker.Error
+	(*FetchWITSVIDRequest)(nil),           // 6: spire.agent.broker.FetchWITSVIDRequest
+	(*FetchWITSVIDResponse)(nil),          // 7: spire.agent.broker.FetchWITSVIDResponse
+	(*WITSVID)(nil),                       // 8: spire.agent.broker.WITSVID
+	(*SubscribeToWITBundlesRequest)(nil),  // 9: spire.agent.broker.SubscribeToWITBundlesRequest
+	(*SubscribeToWITBundlesResponse)(nil), // 10: spire.agent.broker.SubscribeToWITBundlesResponse
+	nil,                                   // 11: spire.agent.broker.X509SVIDUpdate.FederatedBundlesEntry
+	nil,                                   // 12: spire.agent.broker.SubscribeToWITBundlesResponse.BundlesEntry
+	(*anypb.Any)(nil),                     // 13: google.protobuf.Any
+}
+var file_spire_agent_broker_broker_proto_depIdxs = []int32{
+	13, // 0: spire.agent.broker.TaggedWorkloadReference.reference:type_name -> google.protobuf.Any
+	0,  // 1: spire.agent.broker.SubscribeToX509SVIDsRequest.subscribe:type_name -> spire.agent.broker.TaggedWorkloadReference
+	3,  // 2: spire.agent.broker.SubscribeToX509SVIDsResponse.update:type_name -> spire.agent.broker.X509SVIDUpdate
+	5,  // 3: spire.agent.broker.SubscribeToX509SVIDsResponse.error:type_name -> spire.agent.broker.Error
+	4,  // 4: spire.agent.broker.X509SVIDUpdate.svids:type_name -> spire.agent.broker.X509SVID
+	11, // 5: spire.agent.broker.X509SVIDUpdate.federated_bundles:type_name -> spire.agent.broker.X509SVIDUpdate.FederatedBundlesEntry
+	13, // 6: spire.agent.broker.FetchWITSVIDRequest.reference:type_name -> google.protobuf.Any
+	8,  // 7: spire.agent.broker.FetchWITSVIDResponse.svids:type_name -> spire.agent.broker.WITSVID
+	13, // 8: spire.agent.broker.SubscribeToWITBundlesRequest.reference:type_name -> google.protobuf.Any
+	12, // 9: spire.agent.broker.SubscribeToWITBundlesResponse.bundles:type_name -> spire.agent.broker.SubscribeToWITBundlesResponse.BundlesEntry
+	1,  // 10: spire.agent.broker.API.SubscribeToX509SVIDs:input_type -> spire.agent.broker.SubscribeToX509SVIDsRequest
+	6,  // 11: spire.agent.broker.API.FetchWITSVID:input_type -> spire.agent.broker.FetchWITSVIDRequest
+	9,  // 12: spire.agent.broker.API.SubscribeToWITBundles:input_type -> spire.agent.broker.SubscribeToWITBundlesRequest
+	2,  // 13: spire.agent.broker.API.SubscribeToX509SVIDs:output_type -> spire.agent.broker.SubscribeToX509SVIDsResponse
+	7,  // 14: spire.agent.broker.API.FetchWITSVID:output_type -> spire.agent.broker.FetchWITSVIDResponse
+	10, // 15: spire.agent.broker.API.SubscribeToWITBundles:output_type -> spire.agent.broker.SubscribeToWITBundlesResponse
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_spire_agent_broker_broker_proto_init() }
+func file_spire_agent_broker_broker_proto_init() {
+	if File_spire_agent_broker_broker_proto != nil {
+		return
+	}
+	file_spire_agent_broker_broker_proto_msgTypes[2].OneofWrappers = []any{
+		(*SubscribeToX509SVIDsResponse_Update)(nil),
+		(*SubscribeToX509SVIDsResponse_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_agent_broker_broker_proto_rawDesc), len(file_spire_agent_broker_broker_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spire_agent_broker_broker_proto_goTypes,
+		DependencyIndexes: file_spire_agent_broker_broker_proto_depIdxs,
+		MessageInfos:      file_spire_agent_broker_broker_proto_msgTypes,
+	}.Build()
+	File_spire_agent_broker_broker_proto = out.File
+	file_spire_agent_broker_broker_proto_goTypes = nil
+	file_spire_agent_broker_broker_proto_depIdxs = nil
+}
@@ -0,0 +1,273 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v7.35.0
+// source: spire/agent/broker/broker.proto
+
+package broker
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	API_SubscribeToX509SVIDs_FullMethodName  = "/spire.agent.broker.API/SubscribeToX509SVIDs"
+	API_FetchWITSVID_FullMethodName          = "/spire.agent.broker.API/FetchWITSVID"
+	API_SubscribeToWITBundles_FullMethodName = "/spire.agent.broker.API/SubscribeToWITBundles"
+)
+
+// APIClient is the client API for API service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type APIClient interface {
+	// Subscribe to X.509-SVID updates for many workloads over a single
+	// stream. The caller adds and removes workload references at any time by
+	// sending requests on the stream. Each response is tagged with the ID the
+	// caller chose for the reference it pertains to. Failing to subscribe a
+	// reference does not terminate the stream; instead, a response carrying
+	// the error is sent for that reference.
+	SubscribeToX509SVIDs(ctx context.Context, opts ...grpc.CallOption) (API_SubscribeToX509SVIDsClient, error)
+	// Fetch WIT-SVIDs for all SPIFFE identities the referenced workload is
+	// entitled to. If an optional SPIFFE ID is requested, only the WIT-SVID
+	// for that SPIFFE ID is returned. WIT support must be enabled on the
+	// agent.
+	FetchWITSVID(ctx context.Context, in *FetchWITSVIDRequest, opts ...grpc.CallOption) (*FetchWITSVIDResponse, error)
+	// Fetch the WIT bundles of the agent trust domain and of the trust domains
+	// the referenced workload federates with, formatted as JWKS documents,
+	// keyed by the SPIFFE ID of the trust domain. As this information
+	// changes, subsequent messages will be streamed from the server. WIT
+	// support must be enabled on the agent.
+	SubscribeToWITBundles(ctx context.Context, in *SubscribeToWITBundlesRequest, opts ...grpc.CallOption) (API_SubscribeToWITBundlesClient, error)
+}
+
+type aPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAPIClient(cc grpc.ClientConnInterface) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) SubscribeToX509SVIDs(ctx context.Context, opts ...grpc.CallOption) (API_SubscribeToX509SVIDsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[0], API_SubscribeToX509SVIDs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPISubscribeToX509SVIDsClient{stream}
+	return x, nil
+}
+
+type API_SubscribeToX509SVIDsClient interface {
+	Send(*SubscribeToX509SVIDsRequest) error
+	Recv() (*SubscribeToX509SVIDsResponse, error)
+	grpc.ClientStream
+}
+
+type aPISubscribeToX509SVIDsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPISubscribeToX509SVIDsClient) Send(m *SubscribeToX509SVIDsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aPISubscribeToX509SVIDsClient) Recv() (*SubscribeToX509SVIDsResponse, error) {
+	m := new(SubscribeToX509SVIDsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) FetchWITSVID(ctx context.Context, in *FetchWITSVIDRequest, opts ...grpc.CallOption) (*FetchWITSVIDResponse, error) {
+	out := new(FetchWITSVIDResponse)
+	err := c.cc.Invoke(ctx, API_FetchWITSVID_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SubscribeToWITBundles(ctx context.Context, in *SubscribeToWITBundlesRequest, opts ...grpc.CallOption) (API_SubscribeToWITBundlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[1], API_SubscribeToWITBundles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPISubscribeToWITBundlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_SubscribeToWITBundlesClient interface {
+	Recv() (*SubscribeToWITBundlesResponse, error)
+	grpc.ClientStream
+}
+
+type aPISubscribeToWITBundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPISubscribeToWITBundlesClient) Recv() (*SubscribeToWITBundlesResponse, error) {
+	m := new(SubscribeToWITBundlesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// APIServer is the server API for API service.
+// All implementations must embed UnimplementedAPIServer
+// for forward compatibility
+type APIServer interface {
+	// Subscribe to X.509-SVID updates for many workloads over a single
+	// stream. The caller adds and removes workload references at any time by
+	// sending requests on the stream. Each response is tagged with the ID the
+	// caller chose for the reference it pertains to. Failing to subscribe a
+	// reference does not terminate the stream; instead, a response carrying
+	// the error is sent for that reference.
+	SubscribeToX509SVIDs(API_SubscribeToX509SVIDsServer) error
+	// Fetch WIT-SVIDs for all SPIFFE identities the referenced workload is
+	// entitled to. If an optional SPIFFE ID is requested, only the WIT-SVID
+	// for that SPIFFE ID is returned. WIT support must be enabled on the
+	// agent.
+	FetchWITSVID(context.Context, *FetchWITSVIDRequest) (*FetchWITSVIDResponse, error)
+	// Fetch the WIT bundles of the agent trust domain and of the trust domains
+	// the referenced workload federates with, formatted as JWKS documents,
+	// keyed by the SPIFFE ID of the trust domain. As this information
+	// changes, subsequent messages will be streamed from the server. WIT
+	// support must be enabled on the agent.
+	SubscribeToWITBundles(*SubscribeToWITBundlesRequest, API_SubscribeToWITBundlesServer) error
+	mustEmbedUnimplementedAPIServer()
+}
+
+// UnimplementedAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedAPIServer struct {
+}
+
+func (UnimplementedAPIServer) SubscribeToX509SVIDs(API_SubscribeToX509SVIDsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToX509SVIDs not implemented")
+}
+func (UnimplementedAPIServer) FetchWITSVID(context.Context, *FetchWITSVIDRequest) (*FetchWITSVIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchWITSVID not implemented")
+}
+func (UnimplementedAPIServer) SubscribeToWITBundles(*SubscribeToWITBundlesRequest, API_SubscribeToWITBundlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToWITBundles not implemented")
+}
+func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
+
+// UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APIServer will
+// result in compilation errors.
+type UnsafeAPIServer interface {
+	mustEmbedUnimplementedAPIServer()
+}
+
+func RegisterAPIServer(s grpc.ServiceRegistrar, srv APIServer) {
+	s.RegisterService(&API_ServiceDesc, srv)
+}
+
+func _API_SubscribeToX509SVIDs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(APIServer).SubscribeToX509SVIDs(&aPISubscribeToX509SVIDsServer{stream})
+}
+
+type API_SubscribeToX509SVIDsServer interface {
+	Send(*SubscribeToX509SVIDsResponse) error
+	Recv() (*SubscribeToX509SVIDsRequest, error)
+	grpc.ServerStream
+}
+
+type aPISubscribeToX509SVIDsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPISubscribeToX509SVIDsServer) Send(m *SubscribeToX509SVIDsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aPISubscribeToX509SVIDsServer) Recv() (*SubscribeToX509SVIDsRequest, error) {
+	m := new(SubscribeToX509SVIDsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _API_FetchWITSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchWITSVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).FetchWITSVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_FetchWITSVID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).FetchWITSVID(ctx, req.(*FetchWITSVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SubscribeToWITBundles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeToWITBundlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).SubscribeToWITBundles(m, &aPISubscribeToWITBundlesServer{stream})
+}
+
+type API_SubscribeToWITBundlesServer interface {
+	Send(*SubscribeToWITBundlesResponse) error
+	grpc.ServerStream
+}
+
+type aPISubscribeToWITBundlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPISubscribeToWITBundlesServer) Send(m *SubscribeToWITBundlesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// API_ServiceDesc is the grpc.ServiceDesc for API service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var API_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.agent.broker.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FetchWITSVID",
+			Handler:    _API_FetchWITSVID_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeToX509SVIDs",
+			Handler:       _API_SubscribeToX509SVIDs_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeToWITBundles",
+			Handler:       _API_SubscribeToWITBundles_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spire/agent/broker/broker.proto",
+}
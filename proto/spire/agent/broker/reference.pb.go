@@ -0,0 +1,126 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        v7.35.0
+// source: spire/agent/broker/reference.proto
+
+package broker
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The SystemdUnitReference message conveys a reference to a workload by the
+// name of the systemd unit it runs as, on the same host as the agent.
+type SystemdUnitReference struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The name of the systemd unit, including the unit type suffix
+	// (e.g. `nginx.service`).
+	Unit          string `protobuf:"bytes,1,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemdUnitReference) Reset() {
+	*x = SystemdUnitReference{}
+	mi := &file_spire_agent_broker_reference_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemdUnitReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemdUnitReference) ProtoMessage() {}
+
+func (x *SystemdUnitReference) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_broker_reference_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemdUnitReference.ProtoReflect.Descriptor instead.
+func (*SystemdUnitReference) Descriptor() ([]byte, []int) {
+	return file_spire_agent_broker_reference_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SystemdUnitReference) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+var File_spire_agent_broker_reference_proto protoreflect.FileDescriptor
+
+const file_spire_agent_broker_reference_proto_rawDesc = "" +
+	"\n" +
+	"\"spire/agent/broker/reference.proto\x12\x12spire.agent.broker\"*\n" +
+	"\x14SystemdUnitReference\x12\x12\n" +
+	"\x04unit\x18\x01 \x01(\tR\x04unitB2Z0github.com/spiffe/spire/proto/spire/agent/brokerb\x06proto3"
+
+var (
+	file_spire_agent_broker_reference_proto_rawDescOnce sync.Once
+	file_spire_agent_broker_reference_proto_rawDescData []byte
+)
+
+func file_spire_agent_broker_reference_proto_rawDescGZIP() []byte {
+	file_spire_agent_broker_reference_proto_rawDescOnce.Do(func() {
+		file_spire_agent_broker_reference_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spire_agent_broker_reference_proto_rawDesc), len(file_spire_agent_broker_reference_proto_rawDesc)))
+	})
+	return file_spire_agent_broker_reference_proto_rawDescData
+}
+
+var file_spire_agent_broker_reference_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_spire_agent_broker_reference_proto_goTypes = []any{
+	(*SystemdUnitReference)(nil), // 0: spire.agent.broker.SystemdUnitReference
+}
+var file_spire_agent_broker_reference_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_spire_agent_broker_reference_proto_init() }
+func file_spire_agent_broker_reference_proto_init() {
+	if File_spire_agent_broker_reference_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_agent_broker_reference_proto_rawDesc), len(file_spire_agent_broker_reference_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_spire_agent_broker_reference_proto_goTypes,
+		DependencyIndexes: file_spire_agent_broker_reference_proto_depIdxs,
+		MessageInfos:      file_spire_agent_broker_reference_proto_msgTypes,
+	}.Build()
+	File_spire_agent_broker_reference_proto = out.File
+	file_spire_agent_broker_reference_proto_goTypes = nil
+	file_spire_agent_broker_reference_proto_depIdxs = nil
+}
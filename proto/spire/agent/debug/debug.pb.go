@@ -0,0 +1,815 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        v7.35.0
+// source: spire/agent/debug/debug.proto
+
+package debug
+
+import (
+	common "github.com/spiffe/spire/proto/spire/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CachedEntry_SVIDStatus int32
+
+const (
+	CachedEntry_UNKNOWN CachedEntry_SVIDStatus = 0
+	// No X509-SVID is cached for the entry.
+	CachedEntry_MISSING CachedEntry_SVIDStatus = 1
+	// The cached X509-SVID is up to date.
+	CachedEntry_VALID CachedEntry_SVIDStatus = 2
+	// The cached X509-SVID is waiting to be renewed, e.g. because it is
+	// about to expire, the entry changed or its signing authority was
+	// tainted.
+	CachedEntry_STALE CachedEntry_SVIDStatus = 3
+)
+
+// Enum value maps for CachedEntry_SVIDStatus.
+var (
+	CachedEntry_SVIDStatus_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "MISSING",
+		2: "VALID",
+		3: "STALE",
+	}
+	CachedEntry_SVIDStatus_value = map[string]int32{
+		"UNKNOWN": 0,
+		"MISSING": 1,
+		"VALID":   2,
+		"STALE":   3,
+	}
+)
+
+func (x CachedEntry_SVIDStatus) Enum() *CachedEntry_SVIDStatus {
+	p := new(CachedEntry_SVIDStatus)
+	*p = x
+	return p
+}
+
+func (x CachedEntry_SVIDStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CachedEntry_SVIDStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_spire_agent_debug_debug_proto_enumTypes[0].Descriptor()
+}
+
+func (CachedEntry_SVIDStatus) Type() protoreflect.EnumType {
+	return &file_spire_agent_debug_debug_proto_enumTypes[0]
+}
+
+func (x CachedEntry_SVIDStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CachedEntry_SVIDStatus.Descriptor instead.
+func (CachedEntry_SVIDStatus) EnumDescriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type ListCachedEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCachedEntriesRequest) Reset() {
+	*x = ListCachedEntriesRequest{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCachedEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCachedEntriesRequest) ProtoMessage() {}
+
+func (x *ListCachedEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCachedEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCachedEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{0}
+}
+
+type ListCachedEntriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The cached entries.
+	Entries       []*CachedEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCachedEntriesResponse) Reset() {
+	*x = ListCachedEntriesResponse{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCachedEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCachedEntriesResponse) ProtoMessage() {}
+
+func (x *ListCachedEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCachedEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCachedEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListCachedEntriesResponse) GetEntries() []*CachedEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type CachedEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the registration entry.
+	EntryId string `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// The SPIFFE ID of the registration entry.
+	SpiffeId string `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// The parent ID of the registration entry.
+	ParentId string `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// The selectors of the registration entry.
+	Selectors []*common.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// When the registration entry expires, in seconds since the Unix epoch.
+	// Zero if it does not expire.
+	EntryExpiresAt int64 `protobuf:"varint,5,opt,name=entry_expires_at,json=entryExpiresAt,proto3" json:"entry_expires_at,omitempty"`
+	// The status of the X509-SVID cached for the entry.
+	SvidStatus CachedEntry_SVIDStatus `protobuf:"varint,6,opt,name=svid_status,json=svidStatus,proto3,enum=spire.agent.debug.CachedEntry_SVIDStatus" json:"svid_status,omitempty"`
+	// When the cached X509-SVID expires, in seconds since the Unix epoch.
+	// Zero if no X509-SVID is cached.
+	SvidExpiresAt int64 `protobuf:"varint,7,opt,name=svid_expires_at,json=svidExpiresAt,proto3" json:"svid_expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CachedEntry) Reset() {
+	*x = CachedEntry{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CachedEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedEntry) ProtoMessage() {}
+
+func (x *CachedEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedEntry.ProtoReflect.Descriptor instead.
+func (*CachedEntry) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CachedEntry) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *CachedEntry) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+func (x *CachedEntry) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CachedEntry) GetSelectors() []*common.Selector {
+	if x != nil {
+		return x.Selectors
+	}
+	return nil
+}
+
+func (x *CachedEntry) GetEntryExpiresAt() int64 {
+	if x != nil {
+		return x.EntryExpiresAt
+	}
+	return 0
+}
+
+func (x *CachedEntry) GetSvidStatus() CachedEntry_SVIDStatus {
+	if x != nil {
+		return x.SvidStatus
+	}
+	return CachedEntry_UNKNOWN
+}
+
+func (x *CachedEntry) GetSvidExpiresAt() int64 {
+	if x != nil {
+		return x.SvidExpiresAt
+	}
+	return 0
+}
+
+type ListStaleEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStaleEntriesRequest) Reset() {
+	*x = ListStaleEntriesRequest{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStaleEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStaleEntriesRequest) ProtoMessage() {}
+
+func (x *ListStaleEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStaleEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListStaleEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{3}
+}
+
+type ListStaleEntriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The stale entries.
+	Entries       []*StaleEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStaleEntriesResponse) Reset() {
+	*x = ListStaleEntriesResponse{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStaleEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStaleEntriesResponse) ProtoMessage() {}
+
+func (x *ListStaleEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStaleEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListStaleEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListStaleEntriesResponse) GetEntries() []*StaleEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type StaleEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the registration entry.
+	EntryId string `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// The SPIFFE ID of the registration entry.
+	SpiffeId string `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// When the cached X509-SVID expires, in seconds since the Unix epoch.
+	// Zero if no X509-SVID is cached.
+	SvidExpiresAt int64 `protobuf:"varint,3,opt,name=svid_expires_at,json=svidExpiresAt,proto3" json:"svid_expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StaleEntry) Reset() {
+	*x = StaleEntry{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StaleEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StaleEntry) ProtoMessage() {}
+
+func (x *StaleEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StaleEntry.ProtoReflect.Descriptor instead.
+func (*StaleEntry) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StaleEntry) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *StaleEntry) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+func (x *StaleEntry) GetSvidExpiresAt() int64 {
+	if x != nil {
+		return x.SvidExpiresAt
+	}
+	return 0
+}
+
+type GetSyncStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncStatusRequest) Reset() {
+	*x = GetSyncStatusRequest{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncStatusRequest) ProtoMessage() {}
+
+func (x *GetSyncStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetSyncStatusRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{6}
+}
+
+type GetSyncStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When the cache was last successfully synchronized, in seconds since the
+	// Unix epoch. Zero if it never was.
+	LastSync int64 `protobuf:"varint,1,opt,name=last_sync,json=lastSync,proto3" json:"last_sync,omitempty"`
+	// When the cache was last synchronized, successfully or not, in seconds
+	// since the Unix epoch. Zero if it never was.
+	LastSyncAttempt int64 `protobuf:"varint,2,opt,name=last_sync_attempt,json=lastSyncAttempt,proto3" json:"last_sync_attempt,omitempty"`
+	// The error of the last synchronization attempt. Empty if it succeeded.
+	LastError     string `protobuf:"bytes,3,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncStatusResponse) Reset() {
+	*x = GetSyncStatusResponse{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncStatusResponse) ProtoMessage() {}
+
+func (x *GetSyncStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetSyncStatusResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetSyncStatusResponse) GetLastSync() int64 {
+	if x != nil {
+		return x.LastSync
+	}
+	return 0
+}
+
+func (x *GetSyncStatusResponse) GetLastSyncAttempt() int64 {
+	if x != nil {
+		return x.LastSyncAttempt
+	}
+	return 0
+}
+
+func (x *GetSyncStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type ExplainWorkloadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The PID of the workload to attest.
+	Pid           int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainWorkloadRequest) Reset() {
+	*x = ExplainWorkloadRequest{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainWorkloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainWorkloadRequest) ProtoMessage() {}
+
+func (x *ExplainWorkloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainWorkloadRequest.ProtoReflect.Descriptor instead.
+func (*ExplainWorkloadRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExplainWorkloadRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+type ExplainWorkloadResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The selectors attested for the workload.
+	Selectors []*common.Selector `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// The cached entries whose selectors are all satisfied by the attested
+	// selectors, sorted by entry ID.
+	MatchedEntries []*EntryMatch `protobuf:"bytes,2,rep,name=matched_entries,json=matchedEntries,proto3" json:"matched_entries,omitempty"`
+	// The cached entries with selectors that are not satisfied by the
+	// attested selectors, sorted by entry ID.
+	UnmatchedEntries []*EntryMatch `protobuf:"bytes,3,rep,name=unmatched_entries,json=unmatchedEntries,proto3" json:"unmatched_entries,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExplainWorkloadResponse) Reset() {
+	*x = ExplainWorkloadResponse{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainWorkloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainWorkloadResponse) ProtoMessage() {}
+
+func (x *ExplainWorkloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainWorkloadResponse.ProtoReflect.Descriptor instead.
+func (*ExplainWorkloadResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExplainWorkloadResponse) GetSelectors() []*common.Selector {
+	if x != nil {
+		return x.Selectors
+	}
+	return nil
+}
+
+func (x *ExplainWorkloadResponse) GetMatchedEntries() []*EntryMatch {
+	if x != nil {
+		return x.MatchedEntries
+	}
+	return nil
+}
+
+func (x *ExplainWorkloadResponse) GetUnmatchedEntries() []*EntryMatch {
+	if x != nil {
+		return x.UnmatchedEntries
+	}
+	return nil
+}
+
+type EntryMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the registration entry.
+	EntryId string `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// The SPIFFE ID of the registration entry.
+	SpiffeId string `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// The selectors of the registration entry, which may be selector
+	// expressions, that the selectors attested for the workload do not
+	// satisfy. Empty for matched entries.
+	UnsatisfiedSelectors []*common.Selector `protobuf:"bytes,3,rep,name=unsatisfied_selectors,json=unsatisfiedSelectors,proto3" json:"unsatisfied_selectors,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *EntryMatch) Reset() {
+	*x = EntryMatch{}
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryMatch) ProtoMessage() {}
+
+func (x *EntryMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_debug_debug_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryMatch.ProtoReflect.Descriptor instead.
+func (*EntryMatch) Descriptor() ([]byte, []int) {
+	return file_spire_agent_debug_debug_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EntryMatch) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *EntryMatch) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+func (x *EntryMatch) GetUnsatisfiedSelectors() []*common.Selector {
+	if x != nil {
+		return x.UnsatisfiedSelectors
+	}
+	return nil
+}
+
+var File_spire_agent_debug_debug_proto protoreflect.FileDescriptor
+
+const file_spire_agent_debug_debug_proto_rawDesc = "" +
+	"\n" +
+	"\x1dspire/agent/debug/debug.proto\x12\x11spire.agent.debug\x1a\x19spire/common/common.proto\"\x1a\n" +
+	"\x18ListCachedEntriesRequest\"U\n" +
+	"\x19ListCachedEntriesResponse\x128\n" +
+	"\aentries\x18\x01 \x03(\v2\x1e.spire.agent.debug.CachedEntryR\aentries\"\xf4\x02\n" +
+	"\vCachedEntry\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12\x1b\n" +
+	"\tspiffe_id\x18\x02 \x01(\tR\bspiffeId\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\x124\n" +
+	"\tselectors\x18\x04 \x03(\v2\x16.spire.common.SelectorR\tselectors\x12(\n" +
+	"\x10entry_expires_at\x18\x05 \x01(\x03R\x0eentryExpiresAt\x12J\n" +
+	"\vsvid_status\x18\x06 \x01(\x0e2).spire.agent.debug.CachedEntry.SVIDStatusR\n" +
+	"svidStatus\x12&\n" +
+	"\x0fsvid_expires_at\x18\a \x01(\x03R\rsvidExpiresAt\"<\n" +
+	"\n" +
+	"SVIDStatus\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\v\n" +
+	"\aMISSING\x10\x01\x12\t\n" +
+	"\x05VALID\x10\x02\x12\t\n" +
+	"\x05STALE\x10\x03\"\x19\n" +
+	"\x17ListStaleEntriesRequest\"S\n" +
+	"\x18ListStaleEntriesResponse\x127\n" +
+	"\aentries\x18\x01 \x03(\v2\x1d.spire.agent.debug.StaleEntryR\aentries\"l\n" +
+	"\n" +
+	"StaleEntry\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12\x1b\n" +
+	"\tspiffe_id\x18\x02 \x01(\tR\bspiffeId\x12&\n" +
+	"\x0fsvid_expires_at\x18\x03 \x01(\x03R\rsvidExpiresAt\"\x16\n" +
+	"\x14GetSyncStatusRequest\"\x7f\n" +
+	"\x15GetSyncStatusResponse\x12\x1b\n" +
+	"\tlast_sync\x18\x01 \x01(\x03R\blastSync\x12*\n" +
+	"\x11last_sync_attempt\x18\x02 \x01(\x03R\x0flastSyncAttempt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x03 \x01(\tR\tlastError\"*\n" +
+	"\x16ExplainWorkloadRequest\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\"\xe3\x01\n" +
+	"\x17ExplainWorkloadResponse\x124\n" +
+	"\tselectors\x18\x01 \x03(\v2\x16.spire.common.SelectorR\tselectors\x12F\n" +
+	"\x0fmatched_entries\x18\x02 \x03(\v2\x1d.spire.agent.debug.EntryMatchR\x0ematchedEntries\x12J\n" +
+	"\x11unmatched_entries\x18\x03 \x03(\v2\x1d.spire.agent.debug.EntryMatchR\x10unmatchedEntries\"\x91\x01\n" +
+	"\n" +
+	"EntryMatch\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12\x1b\n" +
+	"\tspiffe_id\x18\x02 \x01(\tR\bspiffeId\x12K\n" +
+	"\x15unsatisfied_selectors\x18\x03 \x03(\v2\x16.spire.common.SelectorR\x14unsatisfiedSelectors2\xb0\x03\n" +
+	"\x03API\x12n\n" +
+	"\x11ListCachedEntries\x12+.spire.agent.debug.ListCachedEntriesRequest\x1a,.spire.agent.debug.ListCachedEntriesResponse\x12k\n" +
+	"\x10ListStaleEntries\x12*.spire.agent.debug.ListStaleEntriesRequest\x1a+.spire.agent.debug.ListStaleEntriesResponse\x12b\n" +
+	"\rGetSyncStatus\x12'.spire.agent.debug.GetSyncStatusRequest\x1a(.spire.agent.debug.GetSyncStatusResponse\x12h\n" +
+	"\x0fExplainWorkload\x12).spire.agent.debug.ExplainWorkloadRequest\x1a*.spire.agent.debug.ExplainWorkloadResponseB1Z/github.com/spiffe/spire/proto/spire/agent/debugb\x06proto3"
+
+var (
+	file_spire_agent_debug_debug_proto_rawDescOnce sync.Once
+	file_spire_agent_debug_debug_proto_rawDescData []byte
+)
+
+func file_spire_agent_debug_debug_proto_rawDescGZIP() []byte {
+	file_spire_agent_debug_debug_proto_rawDescOnce.Do(func() {
+		file_spire_agent_debug_debug_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spire_agent_debug_debug_proto_rawDesc), len(file_spire_agent_debug_debug_proto_rawDesc)))
+	})
+	return file_spire_agent_debug_debug_proto_rawDescData
+}
+
+var file_spire_agent_debug_debug_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_spire_agent_debug_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_spire_agent_debug_debug_proto_goTypes = []any{
+	(CachedEntry_SVIDStatus)(0),       // 0: spire.agent.debug.CachedEntry.SVIDStatus
+	(*ListCachedEntriesRequest)(nil),  // 1: spire.agent.debug.ListCachedEntriesRequest
+	(*ListCachedEntriesResponse)(nil), // 2: spire.agent.debug.ListCachedEntriesResponse
+	(*CachedEntry)(nil),               // 3: spire.agent.debug.CachedEntry
+	(*ListStaleEntriesRequest)(nil),   // 4: spire.agent.debug.ListStaleEntriesRequest
+	(*ListStaleEntriesResponse)(nil),  // 5: spire.agent.debug.ListStaleEntriesResponse
+	(*StaleEntry)(nil),                // 6: spire.agent.debug.StaleEntry
+	(*GetSyncStatusRequest)(nil),      // 7: spire.agent.debug.GetSyncStatusRequest
+	(*GetSyncStatusResponse)(nil),     // 8: spire.agent.debug.GetSyncStatusResponse
+	(*ExplainWorkloadRequest)(nil),    // 9: spire.agent.debug.ExplainWorkloadRequest
+	(*ExplainWorkloadResponse)(nil),   // 10: spire.agent.debug.ExplainWorkloadResponse
+	(*EntryMatch)(nil),                // 11: spire.agent.debug.EntryMatch
+	(*common.Selector)(nil),           // 12: spire.common.Selector
+}
+var file_spire_agent_debug_debug_proto_depIdxs = []int32{
+	3,  // 0: spire.agent.debug.ListCachedEntriesResponse.entries:type_name -> spire.agent.debug.CachedEntry
+	12, // 1: spire.agent.debug.CachedEntry.selectors:type_name -> spire.common.Selector
+	0,  // 2: spire.agent.debug.CachedEntry.svid_status:type_name -> spire.agent.debug.CachedEntry.SVIDStatus
+	6,  // 3: spire.agent.debug.ListStaleEntriesResponse.entries:type_name -> spire.agent.debug.StaleEntry
+	12, // 4: spire.agent.debug.ExplainWorkloadResponse.selectors:type_name -> spire.common.Selector
+	11, // 5: spire.agent.debug.ExplainWorkloadResponse.matched_entries:type_name -> spire.agent.debug.EntryMatch
+	11, // 6: spire.agent.debug.ExplainWorkloadResponse.unmatched_entries:type_name -> spire.agent.debug.EntryMatch
+	12, // 7: spire.agent.debug.EntryMatch.unsatisfied_selectors:type_name -> spire.common.Selector
+	1,  // 8: spire.agent.debug.API.ListCachedEntries:input_type -> spire.agent.debug.ListCachedEntriesRequest
+	4,  // 9: spire.agent.debug.API.ListStaleEntries:input_type -> spire.agent.debug.ListStaleEntriesRequest
+	7,  // 10: spire.agent.debug.API.GetSyncStatus:input_type -> spire.agent.debug.GetSyncStatusRequest
+	9,  // 11: spire.agent.debug.API.ExplainWorkload:input_type -> spire.agent.debug.ExplainWorkloadRequest
+	2,  // 12: spire.agent.debug.API.ListCachedEntries:output_type -> spire.agent.debug.ListCachedEntriesResponse
+	5,  // 13: spire.agent.debug.API.ListStaleEntries:output_type -> spire.agent.debug.ListStaleEntriesResponse
+	8,  // 14: spire.agent.debug.API.GetSyncStatus:output_type -> spire.agent.debug.GetSyncStatusResponse
+	10, // 15: spire.agent.debug.API.ExplainWorkload:output_type -> spire.agent.debug.ExplainWorkloadResponse
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_spire_agent_debug_debug_proto_init() }
+func file_spire_agent_debug_debug_proto_init() {
+	if File_spire_agent_debug_debug_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_agent_debug_debug_proto_rawDesc), len(file_spire_agent_debug_debug_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spire_agent_debug_debug_proto_goTypes,
+		DependencyIndexes: file_spire_agent_debug_debug_proto_depIdxs,
+		EnumInfos:         file_spire_agent_debug_debug_proto_enumTypes,
+		MessageInfos:      file_spire_agent_debug_debug_proto_msgTypes,
+	}.Build()
+	File_spire_agent_debug_debug_proto = out.File
+	file_spire_agent_debug_debug_proto_goTypes = nil
+	file_spire_agent_debug_debug_proto_depIdxs = nil
+}
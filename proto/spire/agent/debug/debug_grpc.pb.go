@@ -0,0 +1,236 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v7.35.0
+// source: spire/agent/debug/debug.proto
+
+package debug
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	API_ListCachedEntries_FullMethodName = "/spire.agent.debug.API/ListCachedEntries"
+	API_ListStaleEntries_FullMethodName  = "/spire.agent.debug.API/ListStaleEntries"
+	API_GetSyncStatus_FullMethodName     = "/spire.agent.debug.API/GetSyncStatus"
+	API_ExplainWorkload_FullMethodName   = "/spire.agent.debug.API/ExplainWorkload"
+)
+
+// APIClient is the client API for API service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type APIClient interface {
+	// Lists the registration entries in the agent cache, sorted by entry ID,
+	// along with the status of their X509-SVIDs.
+	ListCachedEntries(ctx context.Context, in *ListCachedEntriesRequest, opts ...grpc.CallOption) (*ListCachedEntriesResponse, error)
+	// Lists the cached entries that are waiting for a new X509-SVID, sorted
+	// by entry ID.
+	ListStaleEntries(ctx context.Context, in *ListStaleEntriesRequest, opts ...grpc.CallOption) (*ListStaleEntriesResponse, error)
+	// Gets the time and result of the last synchronization of the agent
+	// cache with SPIRE Server.
+	GetSyncStatus(ctx context.Context, in *GetSyncStatusRequest, opts ...grpc.CallOption) (*GetSyncStatusResponse, error)
+	// Attests the workload with the given PID, and reports which cached
+	// entries match the attested selectors, and which do not.
+	ExplainWorkload(ctx context.Context, in *ExplainWorkloadRequest, opts ...grpc.CallOption) (*ExplainWorkloadResponse, error)
+}
+
+type aPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAPIClient(cc grpc.ClientConnInterface) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) ListCachedEntries(ctx context.Context, in *ListCachedEntriesRequest, opts ...grpc.CallOption) (*ListCachedEntriesResponse, error) {
+	out := new(ListCachedEntriesResponse)
+	err := c.cc.Invoke(ctx, API_ListCachedEntries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListStaleEntries(ctx context.Context, in *ListStaleEntriesRequest, opts ...grpc.CallOption) (*ListStaleEntriesResponse, error) {
+	out := new(ListStaleEntriesResponse)
+	err := c.cc.Invoke(ctx, API_ListStaleEntries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetSyncStatus(ctx context.Context, in *GetSyncStatusRequest, opts ...grpc.CallOption) (*GetSyncStatusResponse, error) {
+	out := new(GetSyncStatusResponse)
+	err := c.cc.Invoke(ctx, API_GetSyncStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ExplainWorkload(ctx context.Context, in *ExplainWorkloadRequest, opts ...grpc.CallOption) (*ExplainWorkloadResponse, error) {
+	out := new(ExplainWorkloadResponse)
+	err := c.cc.Invoke(ctx, API_ExplainWorkload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// APIServer is the server API for API service.
+// All implementations must embed UnimplementedAPIServer
+// for forward compatibility
+type APIServer interface {
+	// Lists the registration entries in the agent cache, sorted by entry ID,
+	// along with the status of their X509-SVIDs.
+	ListCachedEntries(context.Context, *ListCachedEntriesRequest) (*ListCachedEntriesResponse, error)
+	// Lists the cached entries that are waiting for a new X509-SVID, sorted
+	// by entry ID.
+	ListStaleEntries(context.Context, *ListStaleEntriesRequest) (*ListStaleEntriesResponse, error)
+	// Gets the time and result of the last synchronization of the agent
+	// cache with SPIRE Server.
+	GetSyncStatus(context.Context, *GetSyncStatusRequest) (*GetSyncStatusResponse, error)
+	// Attests the workload with the given PID, and reports which cached
+	// entries match the attested selectors, and which do not.
+	ExplainWorkload(context.Context, *ExplainWorkloadRequest) (*ExplainWorkloadResponse, error)
+	mustEmbedUnimplementedAPIServer()
+}
+
+// UnimplementedAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedAPIServer struct {
+}
+
+func (UnimplementedAPIServer) ListCachedEntries(context.Context, *ListCachedEntriesRequest) (*ListCachedEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCachedEntries not implemented")
+}
+func (UnimplementedAPIServer) ListStaleEntries(context.Context, *ListStaleEntriesRequest) (*ListStaleEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStaleEntries not implemented")
+}
+func (UnimplementedAPIServer) GetSyncStatus(context.Context, *GetSyncStatusRequest) (*GetSyncStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSyncStatus not implemented")
+}
+func (UnimplementedAPIServer) ExplainWorkload(context.Context, *ExplainWorkloadRequest) (*ExplainWorkloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainWorkload not implemented")
+}
+func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
+
+// UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APIServer will
+// result in compilation errors.
+type UnsafeAPIServer interface {
+	mustEmbedUnimplementedAPIServer()
+}
+
+func RegisterAPIServer(s grpc.ServiceRegistrar, srv APIServer) {
+	s.RegisterService(&API_ServiceDesc, srv)
+}
+
+func _API_ListCachedEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCachedEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListCachedEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListCachedEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListCachedEntries(ctx, req.(*ListCachedEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListStaleEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStaleEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListStaleEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListStaleEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListStaleEntries(ctx, req.(*ListStaleEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSyncStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetSyncStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetSyncStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetSyncStatus(ctx, req.(*GetSyncStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ExplainWorkload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainWorkloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ExplainWorkload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ExplainWorkload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ExplainWorkload(ctx, req.(*ExplainWorkloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// API_ServiceDesc is the grpc.ServiceDesc for API service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var API_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.agent.debug.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCachedEntries",
+			Handler:    _API_ListCachedEntries_Handler,
+		},
+		{
+			MethodName: "ListStaleEntries",
+			Handler:    _API_ListStaleEntries_Handler,
+		},
+		{
+			MethodName: "GetSyncStatus",
+			Handler:    _API_GetSyncStatus_Handler,
+		},
+		{
+			MethodName: "ExplainWorkload",
+			Handler:    _API_ExplainWorkload_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/agent/debug/debug.proto",
+}
@@ -0,0 +1,612 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        v7.35.0
+// source: spire/agent/delegatedidentity/delegatedidentity.proto
+
+package delegatedidentity
+
+import (
+	common "github.com/spiffe/spire/proto/spire/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FetchWITSVIDsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Selectors describing the workload to fetch WIT-SVIDs for. Mutually
+	// exclusive with `pid`.
+	Selectors []*common.Selector `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// PID of the workload to fetch WIT-SVIDs for. The agent attests the
+	// workload on behalf of the delegate. Mutually exclusive with
+	// `selectors`.
+	Pid           int32 `protobuf:"varint,2,opt,name=pid,proto3" json:"pid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchWITSVIDsRequest) Reset() {
+	*x = FetchWITSVIDsRequest{}
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchWITSVIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchWITSVIDsRequest) ProtoMessage() {}
+
+func (x *FetchWITSVIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchWITSVIDsRequest.ProtoReflect.Descriptor instead.
+func (*FetchWITSVIDsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FetchWITSVIDsRequest) GetSelectors() []*common.Selector {
+	if x != nil {
+		return x.Selectors
+	}
+	return nil
+}
+
+func (x *FetchWITSVIDsRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+type FetchWITSVIDsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The list of returned WIT-SVIDs.
+	Svids         []*WITSVID `protobuf:"bytes,1,rep,name=svids,proto3" json:"svids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchWITSVIDsResponse) Reset() {
+	*x = FetchWITSVIDsResponse{}
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchWITSVIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchWITSVIDsResponse) ProtoMessage() {}
+
+func (x *FetchWITSVIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchWITSVIDsResponse.ProtoReflect.Descriptor instead.
+func (*FetchWITSVIDsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FetchWITSVIDsResponse) GetSvids() []*WITSVID {
+	if x != nil {
+		return x.Svids
+	}
+	return nil
+}
+
+// The WITSVID message conveys a single WIT-SVID along with the private key it
+// is bound to.
+type WITSVID struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The SPIFFE ID of the WIT-SVID.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Encoded WIT-SVID using JWS Compact Serialization.
+	WitSvid string `protobuf:"bytes,2,opt,name=wit_svid,json=witSvid,proto3" json:"wit_svid,omitempty"`
+	// JWK-encoded private key bound to this WIT-SVID.
+	WitSvidKey string `protobuf:"bytes,3,opt,name=wit_svid_key,json=witSvidKey,proto3" json:"wit_svid_key,omitempty"`
+	// Expiration timestamp (seconds since Unix epoch).
+	ExpiresAt int64 `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Issuance timestamp (seconds since Unix epoch).
+	IssuedAt int64 `protobuf:"varint,5,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	// An operator-specified string used to provide guidance on how this
+	// identity should be used by a workload when more than one SVID is
+	// returned.
+	Hint          string `protobuf:"bytes,6,opt,name=hint,proto3" json:"hint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WITSVID) Reset() {
+	*x = WITSVID{}
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WITSVID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WITSVID) ProtoMessage() {}
+
+func (x *WITSVID) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WITSVID.ProtoReflect.Descriptor instead.
+func (*WITSVID) Descriptor() ([]byte, []int) {
+	return file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WITSVID) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+func (x *WITSVID) GetWitSvid() string {
+	if x != nil {
+		return x.WitSvid
+	}
+	return ""
+}
+
+func (x *WITSVID) GetWitSvidKey() string {
+	if x != nil {
+		return x.WitSvidKey
+	}
+	return ""
+}
+
+func (x *WITSVID) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *WITSVID) GetIssuedAt() int64 {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return 0
+}
+
+func (x *WITSVID) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+type SubscribeToX509BundlesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. The trust domains (e.g. "example.org") to receive bundles
+	// for. If empty, the bundles of all the trust domains are sent.
+	TrustDomains  []string `protobuf:"bytes,1,rep,name=trust_domains,json=trustDomains,proto3" json:"trust_domains,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToX509BundlesRequest) Reset() {
+	*x = SubscribeToX509BundlesRequest{}
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToX509BundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToX509BundlesRequest) ProtoMessage() {}
+
+func (x *SubscribeToX509BundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToX509BundlesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToX509BundlesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SubscribeToX509BundlesRequest) GetTrustDomains() []string {
+	if x != nil {
+		return x.TrustDomains
+	}
+	return nil
+}
+
+type SubscribeToX509BundlesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ASN.1 DER encoded X.509 bundles, keyed by the SPIFFE ID of the trust
+	// domain.
+	CaCertificates map[string][]byte `protobuf:"bytes,1,rep,name=ca_certificates,json=caCertificates,proto3" json:"ca_certificates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubscribeToX509BundlesResponse) Reset() {
+	*x = SubscribeToX509BundlesResponse{}
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToX509BundlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToX509BundlesResponse) ProtoMessage() {}
+
+func (x *SubscribeToX509BundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToX509BundlesResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeToX509BundlesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SubscribeToX509BundlesResponse) GetCaCertificates() map[string][]byte {
+	if x != nil {
+		return x.CaCertificates
+	}
+	return nil
+}
+
+type SubscribeToJWTBundlesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. The trust domains (e.g. "example.org") to receive bundles
+	// for. If empty, the bundles of all the trust domains are sent.
+	TrustDomains  []string `protobuf:"bytes,1,rep,name=trust_domains,json=trustDomains,proto3" json:"trust_domains,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToJWTBundlesRequest) Reset() {
+	*x = SubscribeToJWTBundlesRequest{}
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToJWTBundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToJWTBundlesRequest) ProtoMessage() {}
+
+func (x *SubscribeToJWTBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToJWTBundlesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToJWTBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubscribeToJWTBundlesRequest) GetTrustDomains() []string {
+	if x != nil {
+		return x.TrustDomains
+	}
+	return nil
+}
+
+type SubscribeToJWTBundlesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JWKS encoded JWT bundles, keyed by the SPIFFE ID of the trust domain.
+	Bundles       map[string][]byte `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToJWTBundlesResponse) Reset() {
+	*x = SubscribeToJWTBundlesResponse{}
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToJWTBundlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToJWTBundlesResponse) ProtoMessage() {}
+
+func (x *SubscribeToJWTBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToJWTBundlesResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeToJWTBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SubscribeToJWTBundlesResponse) GetBundles() map[string][]byte {
+	if x != nil {
+		return x.Bundles
+	}
+	return nil
+}
+
+type SubscribeToWITBundlesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. The trust domains (e.g. "example.org") to receive bundles
+	// for. If empty, the bundles of all the trust domains are sent.
+	TrustDomains  []string `protobuf:"bytes,1,rep,name=trust_domains,json=trustDomains,proto3" json:"trust_domains,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToWITBundlesRequest) Reset() {
+	*x = SubscribeToWITBundlesRequest{}
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToWITBundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToWITBundlesRequest) ProtoMessage() {}
+
+func (x *SubscribeToWITBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToWITBundlesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToWITBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SubscribeToWITBundlesRequest) GetTrustDomains() []string {
+	if x != nil {
+		return x.TrustDomains
+	}
+	return nil
+}
+
+type SubscribeToWITBundlesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JWKS encoded WIT bundles, keyed by the SPIFFE ID of the trust domain.
+	Bundles       map[string][]byte `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToWITBundlesResponse) Reset() {
+	*x = SubscribeToWITBundlesResponse{}
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToWITBundlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToWITBundlesResponse) ProtoMessage() {}
+
+func (x *SubscribeToWITBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToWITBundlesResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeToWITBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SubscribeToWITBundlesResponse) GetBundles() map[string][]byte {
+	if x != nil {
+		return x.Bundles
+	}
+	return nil
+}
+
+var File_spire_agent_delegatedidentity_delegatedidentity_proto protoreflect.FileDescriptor
+
+const file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDesc = "" +
+	"\n" +
+	"5spire/agent/delegatedidentity/delegatedidentity.proto\x12\x1dspire.agent.delegatedidentity\x1a\x19spire/common/common.proto\"^\n" +
+	"\x14FetchWITSVIDsRequest\x124\n" +
+	"\tselectors\x18\x01 \x03(\v2\x16.spire.common.SelectorR\tselectors\x12\x10\n" +
+	"\x03pid\x18\x02 \x01(\x05R\x03pid\"U\n" +
+	"\x15FetchWITSVIDsResponse\x12<\n" +
+	"\x05svids\x18\x01 \x03(\v2&.spire.agent.delegatedidentity.WITSVIDR\x05svids\"\xb3\x01\n" +
+	"\aWITSVID\x12\x1b\n" +
+	"\tspiffe_id\x18\x01 \x01(\tR\bspiffeId\x12\x19\n" +
+	"\bwit_svid\x18\x02 \x01(\tR\awitSvid\x12 \n" +
+	"\fwit_svid_key\x18\x03 \x01(\tR\n" +
+	"witSvidKey\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\x12\x1b\n" +
+	"\tissued_at\x18\x05 \x01(\x03R\bissuedAt\x12\x12\n" +
+	"\x04hint\x18\x06 \x01(\tR\x04hint\"D\n" +
+	"\x1dSubscribeToX509BundlesRequest\x12#\n" +
+	"\rtrust_domains\x18\x01 \x03(\tR\ftrustDomains\"\xdf\x01\n" +
+	"\x1eSubscribeToX509BundlesResponse\x12z\n" +
+	"\x0fca_certificates\x18\x01 \x03(\v2Q.spire.agent.delegatedidentity.SubscribeToX509BundlesResponse.CaCertificatesEntryR\x0ecaCertificates\x1aA\n" +
+	"\x13CaCertificatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"C\n" +
+	"\x1cSubscribeToJWTBundlesRequest\x12#\n" +
+	"\rtrust_domains\x18\x01 \x03(\tR\ftrustDomains\"\xc0\x01\n" +
+	"\x1dSubscribeToJWTBundlesResponse\x12c\n" +
+	"\abundles\x18\x01 \x03(\v2I.spire.agent.delegatedidentity.SubscribeToJWTBundlesResponse.BundlesEntryR\abundles\x1a:\n" +
+	"\fBundlesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"C\n" +
+	"\x1cSubscribeToWITBundlesRequest\x12#\n" +
+	"\rtrust_domains\x18\x01 \x03(\tR\ftrustDomains\"\xc0\x01\n" +
+	"\x1dSubscribeToWITBundlesResponse\x12c\n" +
+	"\abundles\x18\x01 \x03(\v2I.spire.agent.delegatedidentity.SubscribeToWITBundlesResponse.BundlesEntryR\abundles\x1a:\n" +
+	"\fBundlesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x012\xc9\x04\n" +
+	"\x03API\x12z\n" +
+	"\rFetchWITSVIDs\x123.spire.agent.delegatedidentity.FetchWITSVIDsRequest\x1a4.spire.agent.delegatedidentity.FetchWITSVIDsResponse\x12\x97\x01\n" +
+	"\x16SubscribeToX509Bundles\x12<.spire.agent.delegatedidentity.SubscribeToX509BundlesRequest\x1a=.spire.agent.delegatedidentity.SubscribeToX509BundlesResponse0\x01\x12\x94\x01\n" +
+	"\x15SubscribeToJWTBundles\x12;.spire.agent.delegatedidentity.SubscribeToJWTBundlesRequest\x1a<.spire.agent.delegatedidentity.SubscribeToJWTBundlesResponse0\x01\x12\x94\x01\n" +
+	"\x15SubscribeToWITBundles\x12;.spire.agent.delegatedidentity.SubscribeToWITBundlesRequest\x1a<.spire.agent.delegatedidentity.SubscribeToWITBundlesResponse0\x01B=Z;github.com/spiffe/spire/proto/spire/agent/delegatedidentityb\x06proto3"
+
+var (
+	file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescOnce sync.Once
+	file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescData []byte
+)
+
+func file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescGZIP() []byte {
+	file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescOnce.Do(func() {
+		file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDesc), len(file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDesc)))
+	})
+	return file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDescData
+}
+
+var file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_spire_agent_delegatedidentity_delegatedidentity_proto_goTypes = []any{
+	(*FetchWITSVIDsRequest)(nil),           // 0: spire.agent.delegatedidentity.FetchWITSVIDsRequest
+	(*FetchWITSVIDsResponse)(nil),          // 1: spire.agent.delegatedidentity.FetchWITSVIDsResponse
+	(*WITSVID)(nil),                        // 2: spire.agent.delegatedidentity.WITSVID
+	(*SubscribeToX509BundlesRequest)(nil),  // 3: spire.agent.delegatedidentity.SubscribeToX509BundlesRequest
+	(*SubscribeToX509BundlesResponse)(nil), // 4: spire.agent.delegatedidentity.SubscribeToX509BundlesResponse
+	(*SubscribeToJWTBundlesRequest)(nil),   // 5: spire.agent.delegatedidentity.SubscribeToJWTBundlesRequest
+	(*SubscribeToJWTBundlesResponse)(nil),  // 6: spire.agent.delegatedidentity.SubscribeToJWTBundlesResponse
+	(*SubscribeToWITBundlesRequest)(nil),   // 7: spire.agent.delegatedidentity.SubscribeToWITBundlesRequest
+	(*SubscribeToWITBundlesResponse)(nil),  // 8: spire.agent.delegatedidentity.SubscribeToWITBundlesResponse
+	nil,                                    // 9: spire.agent.delegatedidentity.SubscribeToX509BundlesResponse.CaCertificatesEntry
+	nil,                                    // 10: spire.agent.delegatedidentity.SubscribeToJWTBundlesResponse.BundlesEntry
+	nil,                                    // 11: spire.agent.delegatedidentity.SubscribeToWITBundlesResponse.BundlesEntry
+	(*common.Selector)(nil),                // 12: spire.common.Selector
+}
+var file_spire_agent_delegatedidentity_delegatedidentity_proto_depIdxs = []int32{
+	12, // 0: spire.agent.delegatedidentity.FetchWITSVIDsRequest.selectors:type_name -> spire.common.Selector
+	2,  // 1: spire.agent.delegatedidentity.FetchWITSVIDsResponse.svids:type_name -> spire.agent.delegatedidentity.WITSVID
+	9,  // 2: spire.agent.delegatedidentity.SubscribeToX509BundlesResponse.ca_certificates:type_name -> spire.agent.delegatedidentity.SubscribeToX509BundlesResponse.CaCertificatesEntry
+	10, // 3: spire.agent.delegatedidentity.SubscribeToJWTBundlesResponse.bundles:type_name -> spire.agent.delegatedidentity.SubscribeToJWTBundlesResponse.BundlesEntry
+	11, // 4: spire.agent.delegatedidentity.SubscribeToWITBundlesResponse.bundles:type_name -> spire.agent.delegatedidentity.SubscribeToWITBundlesResponse.BundlesEntry
+	0,  // 5: spire.agent.delegatedidentity.API.FetchWITSVIDs:input_type -> spire.agent.delegatedidentity.FetchWITSVIDsRequest
+	3,  // 6: spire.agent.delegatedidentity.API.SubscribeToX509Bundles:input_type -> spire.agent.delegatedidentity.SubscribeToX509BundlesRequest
+	5,  // 7: spire.agent.delegatedidentity.API.SubscribeToJWTBundles:input_type -> spire.agent.delegatedidentity.SubscribeToJWTBundlesRequest
+	7,  // 8: spire.agent.delegatedidentity.API.SubscribeToWITBundles:input_type -> spire.agent.delegatedidentity.SubscribeToWITBundlesRequest
+	1,  // 9: spire.agent.delegatedidentity.API.FetchWITSVIDs:output_type -> spire.agent.delegatedidentity.FetchWITSVIDsResponse
+	4,  // 10: spire.agent.delegatedidentity.API.SubscribeToX509Bundles:output_type -> spire.agent.delegatedidentity.SubscribeToX509BundlesResponse
+	6,  // 11: spire.agent.delegatedidentity.API.SubscribeToJWTBundles:output_type -> spire.agent.delegatedidentity.SubscribeToJWTBundlesResponse
+	8,  // 12: spire.agent.delegatedidentity.API.SubscribeToWITBundles:output_type -> spire.agent.delegatedidentity.SubscribeToWITBundlesResponse
+	9,  // [9:13] is the sub-list for method output_type
+	5,  // [5:9] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_spire_agent_delegatedidentity_delegatedidentity_proto_init() }
+func file_spire_agent_delegatedidentity_delegatedidentity_proto_init() {
+	if File_spire_agent_delegatedidentity_delegatedidentity_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDesc), len(file_spire_agent_delegatedidentity_delegatedidentity_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spire_agent_delegatedidentity_delegatedidentity_proto_goTypes,
+		DependencyIndexes: file_spire_agent_delegatedidentity_delegatedidentity_proto_depIdxs,
+		MessageInfos:      file_spire_agent_delegatedidentity_delegatedidentity_proto_msgTypes,
+	}.Build()
+	File_spire_agent_delegatedidentity_delegatedidentity_proto = out.File
+	file_spire_agent_delegatedidentity_delegatedidentity_proto_goTypes = nil
+	file_spire_agent_delegatedidentity_delegatedidentity_proto_depIdxs = nil
+}
@@ -0,0 +1,328 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v7.35.0
+// source: spire/agent/delegatedidentity/delegatedidentity.proto
+
+package delegatedidentity
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	API_FetchWITSVIDs_FullMethodName          = "/spire.agent.delegatedidentity.API/FetchWITSVIDs"
+	API_SubscribeToX509Bundles_FullMethodName = "/spire.agent.delegatedidentity.API/SubscribeToX509Bundles"
+	API_SubscribeToJWTBundles_FullMethodName  = "/spire.agent.delegatedidentity.API/SubscribeToJWTBundles"
+	API_SubscribeToWITBundles_FullMethodName  = "/spire.agent.delegatedidentity.API/SubscribeToWITBundles"
+)
+
+// APIClient is the client API for API service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type APIClient interface {
+	// Fetch WIT-SVIDs for all SPIFFE identities the workload is entitled to.
+	// The workload is identified by the selectors or the PID in the request,
+	// as in the Delegated Identity API FetchJWTSVIDs RPC. WIT support must be
+	// enabled on the agent.
+	FetchWITSVIDs(ctx context.Context, in *FetchWITSVIDsRequest, opts ...grpc.CallOption) (*FetchWITSVIDsResponse, error)
+	// Subscribe to X.509 bundles, optionally restricted to a set of trust
+	// domains. A response is sent each time the bundles of the subscribed
+	// trust domains change.
+	SubscribeToX509Bundles(ctx context.Context, in *SubscribeToX509BundlesRequest, opts ...grpc.CallOption) (API_SubscribeToX509BundlesClient, error)
+	// Subscribe to JWT bundles, optionally restricted to a set of trust
+	// domains. A response is sent each time the bundles of the subscribed
+	// trust domains change.
+	SubscribeToJWTBundles(ctx context.Context, in *SubscribeToJWTBundlesRequest, opts ...grpc.CallOption) (API_SubscribeToJWTBundlesClient, error)
+	// Subscribe to WIT bundles, optionally restricted to a set of trust
+	// domains. A response is sent each time the bundles of the subscribed
+	// trust domains change. WIT support must be enabled on the agent.
+	SubscribeToWITBundles(ctx context.Context, in *SubscribeToWITBundlesRequest, opts ...grpc.CallOption) (API_SubscribeToWITBundlesClient, error)
+}
+
+type aPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAPIClient(cc grpc.ClientConnInterface) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) FetchWITSVIDs(ctx context.Context, in *FetchWITSVIDsRequest, opts ...grpc.CallOption) (*FetchWITSVIDsResponse, error) {
+	out := new(FetchWITSVIDsResponse)
+	err := c.cc.Invoke(ctx, API_FetchWITSVIDs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SubscribeToX509Bundles(ctx context.Context, in *SubscribeToX509BundlesRequest, opts ...grpc.CallOption) (API_SubscribeToX509BundlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[0], API_SubscribeToX509Bundles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPISubscribeToX509BundlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_SubscribeToX509BundlesClient interface {
+	Recv() (*SubscribeToX509BundlesResponse, error)
+	grpc.ClientStream
+}
+
+type aPISubscribeToX509BundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPISubscribeToX509BundlesClient) Recv() (*SubscribeToX509BundlesResponse, error) {
+	m := new(SubscribeToX509BundlesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) SubscribeToJWTBundles(ctx context.Context, in *SubscribeToJWTBundlesRequest, opts ...grpc.CallOption) (API_SubscribeToJWTBundlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[1], API_SubscribeToJWTBundles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPISubscribeToJWTBundlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_SubscribeToJWTBundlesClient interface {
+	Recv() (*SubscribeToJWTBundlesResponse, error)
+	grpc.ClientStream
+}
+
+type aPISubscribeToJWTBundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPISubscribeToJWTBundlesClient) Recv() (*SubscribeToJWTBundlesResponse, error) {
+	m := new(SubscribeToJWTBundlesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) SubscribeToWITBundles(ctx context.Context, in *SubscribeToWITBundlesRequest, opts ...grpc.CallOption) (API_SubscribeToWITBundlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[2], API_SubscribeToWITBundles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPISubscribeToWITBundlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_SubscribeToWITBundlesClient interface {
+	Recv() (*SubscribeToWITBundlesResponse, error)
+	grpc.ClientStream
+}
+
+type aPISubscribeToWITBundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPISubscribeToWITBundlesClient) Recv() (*SubscribeToWITBundlesResponse, error) {
+	m := new(SubscribeToWITBundlesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// APIServer is the server API for API service.
+// All implementations must embed UnimplementedAPIServer
+// for forward compatibility
+type APIServer interface {
+	// Fetch WIT-SVIDs for all SPIFFE identities the workload is entitled to.
+	// The workload is identified by the selectors or the PID in the request,
+	// as in the Delegated Identity API FetchJWTSVIDs RPC. WIT support must be
+	// enabled on the agent.
+	FetchWITSVIDs(context.Context, *FetchWITSVIDsRequest) (*FetchWITSVIDsResponse, error)
+	// Subscribe to X.509 bundles, optionally restricted to a set of trust
+	// domains. A response is sent each time the bundles of the subscribed
+	// trust domains change.
+	SubscribeToX509Bundles(*SubscribeToX509BundlesRequest, API_SubscribeToX509BundlesServer) error
+	// Subscribe to JWT bundles, optionally restricted to a set of trust
+	// domains. A response is sent each time the bundles of the subscribed
+	// trust domains change.
+	SubscribeToJWTBundles(*SubscribeToJWTBundlesRequest, API_SubscribeToJWTBundlesServer) error
+	// Subscribe to WIT bundles, optionally restricted to a set of trust
+	// domains. A response is sent each time the bundles of the subscribed
+	// trust domains change. WIT support must be enabled on the agent.
+	SubscribeToWITBundles(*SubscribeToWITBundlesRequest, API_SubscribeToWITBundlesServer) error
+	mustEmbedUnimplementedAPIServer()
+}
+
+// UnimplementedAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedAPIServer struct {
+}
+
+func (UnimplementedAPIServer) FetchWITSVIDs(context.Context, *FetchWITSVIDsRequest) (*FetchWITSVIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchWITSVIDs not implemented")
+}
+func (UnimplementedAPIServer) SubscribeToX509Bundles(*SubscribeToX509BundlesRequest, API_SubscribeToX509BundlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToX509Bundles not implemented")
+}
+func (UnimplementedAPIServer) SubscribeToJWTBundles(*SubscribeToJWTBundlesRequest, API_SubscribeToJWTBundlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToJWTBundles not implemented")
+}
+func (UnimplementedAPIServer) SubscribeToWITBundles(*SubscribeToWITBundlesRequest, API_SubscribeToWITBundlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToWITBundles not implemented")
+}
+func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
+
+// UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APIServer will
+// result in compilation errors.
+type UnsafeAPIServer interface {
+	mustEmbedUnimplementedAPIServer()
+}
+
+func RegisterAPIServer(s grpc.ServiceRegistrar, srv APIServer) {
+	s.RegisterService(&API_ServiceDesc, srv)
+}
+
+func _API_FetchWITSVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchWITSVIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).FetchWITSVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_FetchWITSVIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).FetchWITSVIDs(ctx, req.(*FetchWITSVIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SubscribeToX509Bundles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeToX509BundlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).SubscribeToX509Bundles(m, &aPISubscribeToX509BundlesServer{stream})
+}
+
+type API_SubscribeToX509BundlesServer interface {
+	Send(*SubscribeToX509BundlesResponse) error
+	grpc.ServerStream
+}
+
+type aPISubscribeToX509BundlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPISubscribeToX509BundlesServer) Send(m *SubscribeToX509BundlesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_SubscribeToJWTBundles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeToJWTBundlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).SubscribeToJWTBundles(m, &aPISubscribeToJWTBundlesServer{stream})
+}
+
+type API_SubscribeToJWTBundlesServer interface {
+	Send(*SubscribeToJWTBundlesResponse) error
+	grpc.ServerStream
+}
+
+type aPISubscribeToJWTBundlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPISubscribeToJWTBundlesServer) Send(m *SubscribeToJWTBundlesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_SubscribeToWITBundles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeToWITBundlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).SubscribeToWITBundles(m, &aPISubscribeToWITBundlesServer{stream})
+}
+
+type API_SubscribeToWITBundlesServer interface {
+	Send(*SubscribeToWITBundlesResponse) error
+	grpc.ServerStream
+}
+
+type aPISubscribeToWITBundlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPISubscribeToWITBundlesServer) Send(m *SubscribeToWITBundlesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// API_ServiceDesc is the grpc.ServiceDesc for API service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var API_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.agent.delegatedidentity.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FetchWITSVIDs",
+			Handler:    _API_FetchWITSVIDs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeToX509Bundles",
+			Handler:       _API_SubscribeToX509Bundles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeToJWTBundles",
+			Handler:       _API_SubscribeToJWTBundles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeToWITBundles",
+			Handler:       _API_SubscribeToWITBundles_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spire/agent/delegatedidentity/delegatedidentity.proto",
+}
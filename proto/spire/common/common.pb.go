@@ -61,9 +61,9 @@ func (*Empty) Descriptor() ([]byte, []int) {
 // * A type which contains attestation data for specific platform.
 type AttestationData struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// * Type of attestation to perform.
+	//* Type of attestation to perform.
 	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	// * The attestation data.
+	//* The attestation data.
 	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -117,10 +117,10 @@ func (x *AttestationData) GetData() []byte {
 // entry is matched.
 type Selector struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// * A selector type represents the type of attestation used in attesting
-	// the entity (Eg: AWS, K8).
+	//* A selector type represents the type of attestation used in attesting
+	//the entity (Eg: AWS, K8).
 	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	// * The value to be attested.
+	//* The value to be attested.
 	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -173,7 +173,7 @@ func (x *Selector) GetValue() string {
 // * Represents a type with a list of Selector.
 type Selectors struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// * A list of Selector.
+	//* A list of Selector.
 	Entries       []*Selector `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -338,45 +338,48 @@ func (x *AttestedNode) GetAgentVersion() string {
 // manage the various registered nodes and workloads that are controlled by it.
 type RegistrationEntry struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// * A list of selectors.
+	//* A list of selectors.
 	Selectors []*Selector `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
-	// * The SPIFFE ID of an entity that is authorized to attest the validity
-	// of a selector
+	//* The SPIFFE ID of an entity that is authorized to attest the validity
+	//of a selector
 	ParentId string `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	// * The SPIFFE ID is a structured string used to identify a resource or
-	// caller. It is defined as a URI comprising a “trust domain” and an
-	// associated path.
+	//* The SPIFFE ID is a structured string used to identify a resource or
+	//caller. It is defined as a URI comprising a “trust domain” and an
+	//associated path.
 	SpiffeId string `protobuf:"bytes,3,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
-	// * Time to live for X509-SVIDs generated from this entry. Was previously called 'ttl'.
+	//* Time to live for X509-SVIDs generated from this entry. Was previously called 'ttl'.
 	X509SvidTtl int32 `protobuf:"varint,4,opt,name=x509_svid_ttl,json=x509SvidTtl,proto3" json:"x509_svid_ttl,omitempty"`
-	// * A list of federated trust domain SPIFFE IDs.
+	//* A list of federated trust domain SPIFFE IDs.
 	FederatesWith []string `protobuf:"bytes,5,rep,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
-	// * Entry ID
+	//* Entry ID
 	EntryId string `protobuf:"bytes,6,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
-	// * whether the workload is an admin workload. Admin workloads
-	// can use their SVID's to authenticate with the Server APIs, for
-	// example.
+	//* whether the workload is an admin workload. Admin workloads
+	//can use their SVID's to authenticate with the Server APIs, for
+	//example.
 	Admin bool `protobuf:"varint,7,opt,name=admin,proto3" json:"admin,omitempty"`
-	// * To enable signing CA CSR in upstream spire server
+	//* To enable signing CA CSR in upstream spire server
 	Downstream bool `protobuf:"varint,8,opt,name=downstream,proto3" json:"downstream,omitempty"`
-	// * Expiration of this entry, in seconds from epoch
+	//* Expiration of this entry, in seconds from epoch
 	EntryExpiry int64 `protobuf:"varint,9,opt,name=entryExpiry,proto3" json:"entryExpiry,omitempty"`
-	// * DNS entries
+	//* DNS entries
 	DnsNames []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
-	// * Revision number is bumped every time the entry is updated
+	//* Revision number is bumped every time the entry is updated
 	RevisionNumber int64 `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
-	// * Determines if the issued SVID must be stored through an SVIDStore plugin
+	//* Determines if the issued SVID must be stored through an SVIDStore plugin
 	StoreSvid bool `protobuf:"varint,12,opt,name=store_svid,json=storeSvid,proto3" json:"store_svid,omitempty"`
-	// * Time to live for JWT-SVIDs generated from this entry, if set will override ttl field.
+	//* Time to live for JWT-SVIDs generated from this entry, if set will override ttl field.
 	JwtSvidTtl int32 `protobuf:"varint,13,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
-	// * An operator-specified string used to provide guidance on how this
-	// identity should be used by a workload when more than one SVID is returned.
+	//* An operator-specified string used to provide guidance on how this
+	//identity should be used by a workload when more than one SVID is returned.
 	Hint string `protobuf:"bytes,14,opt,name=hint,proto3" json:"hint,omitempty"`
-	// * Time of creation, in seconds from epoch
+	//* Time of creation, in seconds from epoch
 	CreatedAt            int64                                   `protobuf:"varint,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	AdditionalAttributes *RegistrationEntry_AdditionalAttributes `protobuf:"bytes,16,opt,name=additional_attributes,json=additionalAttributes,proto3,oneof" json:"additional_attributes,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	//* Time before which this entry is not active, in seconds from epoch.
+	//Zero means the entry is active as soon as it is created.
+	NotBefore     int64 `protobuf:"varint,17,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegistrationEntry) Reset() {
@@ -521,6 +524,153 @@ func (x *RegistrationEntry) GetAdditionalAttributes() *RegistrationEntry_Additio
 	return nil
 }
 
+func (x *RegistrationEntry) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+// * CredentialProfile declares customizations of the X509-SVIDs issued for a
+// registration entry, as an alternative to a CredentialComposer plugin.
+type CredentialProfile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	//* Organization (O) values of the subject.
+	SubjectOrganization []string `protobuf:"bytes,1,rep,name=subject_organization,json=subjectOrganization,proto3" json:"subject_organization,omitempty"`
+	//* Organizational unit (OU) values of the subject.
+	SubjectOrganizationalUnit []string `protobuf:"bytes,2,rep,name=subject_organizational_unit,json=subjectOrganizationalUnit,proto3" json:"subject_organizational_unit,omitempty"`
+	//* Extended key usages added to the serverAuth and clientAuth ones, either
+	//by name (e.g. "emailProtection") or as a dotted OID. They must be allowed
+	//by the server configuration.
+	ExtendedKeyUsages []string `protobuf:"bytes,3,rep,name=extended_key_usages,json=extendedKeyUsages,proto3" json:"extended_key_usages,omitempty"`
+	//* Custom extensions.
+	Extensions    []*X509Extension `protobuf:"bytes,4,rep,name=extensions,proto3" json:"extensions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CredentialProfile) Reset() {
+	*x = CredentialProfile{}
+	mi := &file_spire_common_common_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialProfile) ProtoMessage() {}
+
+func (x *CredentialProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_common_common_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialProfile.ProtoReflect.Descriptor instead.
+func (*CredentialProfile) Descriptor() ([]byte, []int) {
+	return file_spire_common_common_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CredentialProfile) GetSubjectOrganization() []string {
+	if x != nil {
+		return x.SubjectOrganization
+	}
+	return nil
+}
+
+func (x *CredentialProfile) GetSubjectOrganizationalUnit() []string {
+	if x != nil {
+		return x.SubjectOrganizationalUnit
+	}
+	return nil
+}
+
+func (x *CredentialProfile) GetExtendedKeyUsages() []string {
+	if x != nil {
+		return x.ExtendedKeyUsages
+	}
+	return nil
+}
+
+func (x *CredentialProfile) GetExtensions() []*X509Extension {
+	if x != nil {
+		return x.Extensions
+	}
+	return nil
+}
+
+// * X509Extension is a custom X.509 certificate extension.
+type X509Extension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	//* The dotted OID of the extension.
+	Oid string `protobuf:"bytes,1,opt,name=oid,proto3" json:"oid,omitempty"`
+	//* Whether the extension is critical.
+	Critical bool `protobuf:"varint,2,opt,name=critical,proto3" json:"critical,omitempty"`
+	//* The DER encoded value of the extension.
+	Value         []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *X509Extension) Reset() {
+	*x = X509Extension{}
+	mi := &file_spire_common_common_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *X509Extension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*X509Extension) ProtoMessage() {}
+
+func (x *X509Extension) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_common_common_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use X509Extension.ProtoReflect.Descriptor instead.
+func (*X509Extension) Descriptor() ([]byte, []int) {
+	return file_spire_common_common_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *X509Extension) GetOid() string {
+	if x != nil {
+		return x.Oid
+	}
+	return ""
+}
+
+func (x *X509Extension) GetCritical() bool {
+	if x != nil {
+		return x.Critical
+	}
+	return false
+}
+
+func (x *X509Extension) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
 // * The RegistrationEntryMask is used to update only selected fields of the RegistrationEntry
 type RegistrationEntryMask struct {
 	state                protoimpl.MessageState `protogen:"open.v1"`
@@ -538,13 +688,18 @@ type RegistrationEntryMask struct {
 	JwtSvidTtl           bool                   `protobuf:"varint,12,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
 	Hint                 bool                   `protobuf:"varint,13,opt,name=hint,proto3" json:"hint,omitempty"`
 	AdditionalAttributes bool                   `protobuf:"varint,14,opt,name=additional_attributes,json=additionalAttributes,proto3" json:"additional_attributes,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	NotBefore            bool                   `protobuf:"varint,15,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	//* The credential profile is kept in the additional attributes, but is
+	//masked separately: additional_attributes updates the other attributes,
+	//and credential_profile the credential profile.
+	CredentialProfile bool `protobuf:"varint,16,opt,name=credential_profile,json=credentialProfile,proto3" json:"credential_profile,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *RegistrationEntryMask) Reset() {
 	*x = RegistrationEntryMask{}
-	mi := &file_spire_common_common_proto_msgTypes[6]
+	mi := &file_spire_common_common_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -556,7 +711,7 @@ func (x *RegistrationEntryMask) String() string {
 func (*RegistrationEntryMask) ProtoMessage() {}
 
 func (x *RegistrationEntryMask) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_common_common_proto_msgTypes[6]
+	mi := &file_spire_common_common_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -569,7 +724,7 @@ func (x *RegistrationEntryMask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationEntryMask.ProtoReflect.Descriptor instead.
 func (*RegistrationEntryMask) Descriptor() ([]byte, []int) {
-	return file_spire_common_common_proto_rawDescGZIP(), []int{6}
+	return file_spire_common_common_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RegistrationEntryMask) GetSelectors() bool {
@@ -670,10 +825,24 @@ func (x *RegistrationEntryMask) GetAdditionalAttributes() bool {
 	return false
 }
 
+func (x *RegistrationEntryMask) GetNotBefore() bool {
+	if x != nil {
+		return x.NotBefore
+	}
+	return false
+}
+
+func (x *RegistrationEntryMask) GetCredentialProfile() bool {
+	if x != nil {
+		return x.CredentialProfile
+	}
+	return false
+}
+
 // * A list of registration entries.
 type RegistrationEntries struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// * A list of RegistrationEntry.
+	//* A list of RegistrationEntry.
 	Entries       []*RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -681,7 +850,7 @@ type RegistrationEntries struct {
 
 func (x *RegistrationEntries) Reset() {
 	*x = RegistrationEntries{}
-	mi := &file_spire_common_common_proto_msgTypes[7]
+	mi := &file_spire_common_common_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -693,7 +862,7 @@ func (x *RegistrationEntries) String() string {
 func (*RegistrationEntries) ProtoMessage() {}
 
 func (x *RegistrationEntries) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_common_common_proto_msgTypes[7]
+	mi := &file_spire_common_common_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -706,7 +875,7 @@ func (x *RegistrationEntries) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationEntries.ProtoReflect.Descriptor instead.
 func (*RegistrationEntries) Descriptor() ([]byte, []int) {
-	return file_spire_common_common_proto_rawDescGZIP(), []int{7}
+	return file_spire_common_common_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RegistrationEntries) GetEntries() []*RegistrationEntry {
@@ -727,7 +896,7 @@ type Certificate struct {
 
 func (x *Certificate) Reset() {
 	*x = Certificate{}
-	mi := &file_spire_common_common_proto_msgTypes[8]
+	mi := &file_spire_common_common_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -739,7 +908,7 @@ func (x *Certificate) String() string {
 func (*Certificate) ProtoMessage() {}
 
 func (x *Certificate) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_common_common_proto_msgTypes[8]
+	mi := &file_spire_common_common_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -752,7 +921,7 @@ func (x *Certificate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Certificate.ProtoReflect.Descriptor instead.
 func (*Certificate) Descriptor() ([]byte, []int) {
-	return file_spire_common_common_proto_rawDescGZIP(), []int{8}
+	return file_spire_common_common_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Certificate) GetDerBytes() []byte {
@@ -772,13 +941,13 @@ func (x *Certificate) GetTaintedKey() bool {
 // * PublicKey represents a PKIX encoded public key
 type PublicKey struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// * PKIX encoded key data
+	//* PKIX encoded key data
 	PkixBytes []byte `protobuf:"bytes,1,opt,name=pkix_bytes,json=pkixBytes,proto3" json:"pkix_bytes,omitempty"`
-	// * key identifier
+	//* key identifier
 	Kid string `protobuf:"bytes,2,opt,name=kid,proto3" json:"kid,omitempty"`
-	// * not after (seconds since unix epoch, 0 means "never expires")
+	//* not after (seconds since unix epoch, 0 means "never expires")
 	NotAfter int64 `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
-	// * whether the key is tainted
+	//* whether the key is tainted
 	TaintedKey    bool `protobuf:"varint,4,opt,name=tainted_key,json=taintedKey,proto3" json:"tainted_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -786,7 +955,7 @@ type PublicKey struct {
 
 func (x *PublicKey) Reset() {
 	*x = PublicKey{}
-	mi := &file_spire_common_common_proto_msgTypes[9]
+	mi := &file_spire_common_common_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -798,7 +967,7 @@ func (x *PublicKey) String() string {
 func (*PublicKey) ProtoMessage() {}
 
 func (x *PublicKey) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_common_common_proto_msgTypes[9]
+	mi := &file_spire_common_common_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -811,7 +980,7 @@ func (x *PublicKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicKey.ProtoReflect.Descriptor instead.
 func (*PublicKey) Descriptor() ([]byte, []int) {
-	return file_spire_common_common_proto_rawDescGZIP(), []int{9}
+	return file_spire_common_common_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PublicKey) GetPkixBytes() []byte {
@@ -844,19 +1013,19 @@ func (x *PublicKey) GetTaintedKey() bool {
 
 type Bundle struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// * the SPIFFE ID of the trust domain the bundle belongs to
+	//* the SPIFFE ID of the trust domain the bundle belongs to
 	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
-	// * list of root CA certificates
+	//* list of root CA certificates
 	RootCas []*Certificate `protobuf:"bytes,2,rep,name=root_cas,json=rootCas,proto3" json:"root_cas,omitempty"`
-	// * list of JWT signing keys
+	//* list of JWT signing keys
 	JwtSigningKeys []*PublicKey `protobuf:"bytes,3,rep,name=jwt_signing_keys,json=jwtSigningKeys,proto3" json:"jwt_signing_keys,omitempty"`
-	// * refresh hint is a hint, in seconds, on how often a bundle consumer
+	//* refresh hint is a hint, in seconds, on how often a bundle consumer
 	// should poll for bundle updates
 	RefreshHint int64 `protobuf:"varint,4,opt,name=refresh_hint,json=refreshHint,proto3" json:"refresh_hint,omitempty"`
-	// * sequence number is a monotonically increasing number that is
+	//* sequence number is a monotonically increasing number that is
 	// incremented every time the bundle is updated
 	SequenceNumber uint64 `protobuf:"varint,5,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
-	// * list of WIT signing keys
+	//* list of WIT signing keys
 	WitSigningKeys []*PublicKey `protobuf:"bytes,6,rep,name=wit_signing_keys,json=witSigningKeys,proto3" json:"wit_signing_keys,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
@@ -864,7 +1033,7 @@ type Bundle struct {
 
 func (x *Bundle) Reset() {
 	*x = Bundle{}
-	mi := &file_spire_common_common_proto_msgTypes[10]
+	mi := &file_spire_common_common_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -876,7 +1045,7 @@ func (x *Bundle) String() string {
 func (*Bundle) ProtoMessage() {}
 
 func (x *Bundle) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_common_common_proto_msgTypes[10]
+	mi := &file_spire_common_common_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -889,7 +1058,7 @@ func (x *Bundle) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Bundle.ProtoReflect.Descriptor instead.
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return file_spire_common_common_proto_rawDescGZIP(), []int{10}
+	return file_spire_common_common_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Bundle) GetTrustDomainId() string {
@@ -948,7 +1117,7 @@ type BundleMask struct {
 
 func (x *BundleMask) Reset() {
 	*x = BundleMask{}
-	mi := &file_spire_common_common_proto_msgTypes[11]
+	mi := &file_spire_common_common_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -960,7 +1129,7 @@ func (x *BundleMask) String() string {
 func (*BundleMask) ProtoMessage() {}
 
 func (x *BundleMask) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_common_common_proto_msgTypes[11]
+	mi := &file_spire_common_common_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -973,7 +1142,7 @@ func (x *BundleMask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BundleMask.ProtoReflect.Descriptor instead.
 func (*BundleMask) Descriptor() ([]byte, []int) {
-	return file_spire_common_common_proto_rawDescGZIP(), []int{11}
+	return file_spire_common_common_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BundleMask) GetRootCas() bool {
@@ -1033,7 +1202,7 @@ type AttestedNodeMask struct {
 
 func (x *AttestedNodeMask) Reset() {
 	*x = AttestedNodeMask{}
-	mi := &file_spire_common_common_proto_msgTypes[12]
+	mi := &file_spire_common_common_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1045,7 +1214,7 @@ func (x *AttestedNodeMask) String() string {
 func (*AttestedNodeMask) ProtoMessage() {}
 
 func (x *AttestedNodeMask) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_common_common_proto_msgTypes[12]
+	mi := &file_spire_common_common_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1058,7 +1227,7 @@ func (x *AttestedNodeMask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttestedNodeMask.ProtoReflect.Descriptor instead.
 func (*AttestedNodeMask) Descriptor() ([]byte, []int) {
-	return file_spire_common_common_proto_rawDescGZIP(), []int{12}
+	return file_spire_common_common_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AttestedNodeMask) GetAttestationDataType() bool {
@@ -1118,24 +1287,27 @@ func (x *AttestedNodeMask) GetAgentVersion() bool {
 // attributes in the datastore.
 type RegistrationEntry_AdditionalAttributes struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// * Flag indicating whether the agent should prefetch and cache X509 SVID.
-	// Can be set to `true` if the workload is unlikely to request an X509 SVID.
-	// This is meant to prevent unnecessary effort spent on generating SVIDs of types,
-	// which are unlikely to be needed.
+	//* Flag indicating whether the agent should prefetch and cache X509 SVID.
+	//Can be set to `true` if the workload is unlikely to request an X509 SVID.
+	//This is meant to prevent unnecessary effort spent on generating SVIDs of types,
+	//which are unlikely to be needed.
 	DisableX509SvidPrefetch bool `protobuf:"varint,1,opt,name=disable_x509_svid_prefetch,json=disableX509SvidPrefetch,proto3" json:"disable_x509_svid_prefetch,omitempty"`
-	// * Flag indicating whether JWT-SVIDs issued for this entry should include
-	// a "jti" (JWT ID) claim. When true, the agent bypasses the JWT-SVID cache so
-	// each request yields a fresh token with a unique JTI — useful for audit trails
-	// and replay protection. When false (default), behavior is backwards compatible:
-	// no JTI claim, caching enabled.
+	//* Flag indicating whether JWT-SVIDs issued for this entry should include
+	//a "jti" (JWT ID) claim. When true, the agent bypasses the JWT-SVID cache so
+	//each request yields a fresh token with a unique JTI — useful for audit trails
+	//and replay protection. When false (default), behavior is backwards compatible:
+	//no JTI claim, caching enabled.
 	JwtSvidIncludeJti bool `protobuf:"varint,2,opt,name=jwt_svid_include_jti,json=jwtSvidIncludeJti,proto3" json:"jwt_svid_include_jti,omitempty"`
+	//* Customizes the X509-SVIDs issued for the entry. The fields that can
+	//be set are limited by the server configuration.
+	CredentialProfile *CredentialProfile `protobuf:"bytes,3,opt,name=credential_profile,json=credentialProfile,proto3" json:"credential_profile,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *RegistrationEntry_AdditionalAttributes) Reset() {
 	*x = RegistrationEntry_AdditionalAttributes{}
-	mi := &file_spire_common_common_proto_msgTypes[13]
+	mi := &file_spire_common_common_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1147,7 +1319,7 @@ func (x *RegistrationEntry_AdditionalAttributes) String() string {
 func (*RegistrationEntry_AdditionalAttributes) ProtoMessage() {}
 
 func (x *RegistrationEntry_AdditionalAttributes) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_common_common_proto_msgTypes[13]
+	mi := &file_spire_common_common_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1177,6 +1349,13 @@ func (x *RegistrationEntry_AdditionalAttributes) GetJwtSvidIncludeJti() bool {
 	return false
 }
 
+func (x *RegistrationEntry_AdditionalAttributes) GetCredentialProfile() *CredentialProfile {
+	if x != nil {
+		return x.CredentialProfile
+	}
+	return nil
+}
+
 var File_spire_common_common_proto protoreflect.FileDescriptor
 
 const file_spire_common_common_proto_rawDesc = "" +
@@ -1200,7 +1379,7 @@ const file_spire_common_common_proto_rawDesc = "" +
 	"\x12new_cert_not_after\x18\x06 \x01(\x03R\x0fnewCertNotAfter\x124\n" +
 	"\tselectors\x18\a \x03(\v2\x16.spire.common.SelectorR\tselectors\x12!\n" +
 	"\fcan_reattest\x18\b \x01(\bR\vcanReattest\x12#\n" +
-	"\ragent_version\x18\t \x01(\tR\fagentVersion\"\x8c\x06\n" +
+	"\ragent_version\x18\t \x01(\tR\fagentVersion\"\xfb\x06\n" +
 	"\x11RegistrationEntry\x124\n" +
 	"\tselectors\x18\x01 \x03(\v2\x16.spire.common.SelectorR\tselectors\x12\x1b\n" +
 	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12\x1b\n" +
@@ -1223,11 +1402,25 @@ const file_spire_common_common_proto_rawDesc = "" +
 	"\x04hint\x18\x0e \x01(\tR\x04hint\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x0f \x01(\x03R\tcreatedAt\x12n\n" +
-	"\x15additional_attributes\x18\x10 \x01(\v24.spire.common.RegistrationEntry.AdditionalAttributesH\x00R\x14additionalAttributes\x88\x01\x01\x1a\x84\x01\n" +
+	"\x15additional_attributes\x18\x10 \x01(\v24.spire.common.RegistrationEntry.AdditionalAttributesH\x00R\x14additionalAttributes\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"not_before\x18\x11 \x01(\x03R\tnotBefore\x1a\xd4\x01\n" +
 	"\x14AdditionalAttributes\x12;\n" +
 	"\x1adisable_x509_svid_prefetch\x18\x01 \x01(\bR\x17disableX509SvidPrefetch\x12/\n" +
-	"\x14jwt_svid_include_jti\x18\x02 \x01(\bR\x11jwtSvidIncludeJtiB\x18\n" +
-	"\x16_additional_attributes\"\xd4\x03\n" +
+	"\x14jwt_svid_include_jti\x18\x02 \x01(\bR\x11jwtSvidIncludeJti\x12N\n" +
+	"\x12credential_profile\x18\x03 \x01(\v2\x1f.spire.common.CredentialProfileR\x11credentialProfileB\x18\n" +
+	"\x16_additional_attributes\"\xf3\x01\n" +
+	"\x11CredentialProfile\x121\n" +
+	"\x14subject_organization\x18\x01 \x03(\tR\x13subjectOrganization\x12>\n" +
+	"\x1bsubject_organizational_unit\x18\x02 \x03(\tR\x19subjectOrganizationalUnit\x12.\n" +
+	"\x13extended_key_usages\x18\x03 \x03(\tR\x11extendedKeyUsages\x12;\n" +
+	"\n" +
+	"extensions\x18\x04 \x03(\v2\x1b.spire.common.X509ExtensionR\n" +
+	"extensions\"S\n" +
+	"\rX509Extension\x12\x10\n" +
+	"\x03oid\x18\x01 \x01(\tR\x03oid\x12\x1a\n" +
+	"\bcritical\x18\x02 \x01(\bR\bcritical\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\fR\x05value\"\xa2\x04\n" +
 	"\x15RegistrationEntryMask\x12\x1c\n" +
 	"\tselectors\x18\x01 \x01(\bR\tselectors\x12\x1b\n" +
 	"\tparent_id\x18\x02 \x01(\bR\bparentId\x12\x1b\n" +
@@ -1247,7 +1440,10 @@ const file_spire_common_common_proto_rawDesc = "" +
 	"\fjwt_svid_ttl\x18\f \x01(\bR\n" +
 	"jwtSvidTtl\x12\x12\n" +
 	"\x04hint\x18\r \x01(\bR\x04hint\x123\n" +
-	"\x15additional_attributes\x18\x0e \x01(\bR\x14additionalAttributes\"P\n" +
+	"\x15additional_attributes\x18\x0e \x01(\bR\x14additionalAttributes\x12\x1d\n" +
+	"\n" +
+	"not_before\x18\x0f \x01(\bR\tnotBefore\x12-\n" +
+	"\x12credential_profile\x18\x10 \x01(\bR\x11credentialProfile\"P\n" +
 	"\x13RegistrationEntries\x129\n" +
 	"\aentries\x18\x01 \x03(\v2\x1f.spire.common.RegistrationEntryR\aentries\"K\n" +
 	"\vCertificate\x12\x1b\n" +
@@ -1297,7 +1493,7 @@ func file_spire_common_common_proto_rawDescGZIP() []byte {
 	return file_spire_common_common_proto_rawDescData
 }
 
-var file_spire_common_common_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_spire_common_common_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_spire_common_common_proto_goTypes = []any{
 	(*Empty)(nil),                                  // 0: spire.common.Empty
 	(*AttestationData)(nil),                        // 1: spire.common.AttestationData
@@ -1305,29 +1501,33 @@ var file_spire_common_common_proto_goTypes = []any{
 	(*Selectors)(nil),                              // 3: spire.common.Selectors
 	(*AttestedNode)(nil),                           // 4: spire.common.AttestedNode
 	(*RegistrationEntry)(nil),                      // 5: spire.common.RegistrationEntry
-	(*RegistrationEntryMask)(nil),                  // 6: spire.common.RegistrationEntryMask
-	(*RegistrationEntries)(nil),                    // 7: spire.common.RegistrationEntries
-	(*Certificate)(nil),                            // 8: spire.common.Certificate
-	(*PublicKey)(nil),                              // 9: spire.common.PublicKey
-	(*Bundle)(nil),                                 // 10: spire.common.Bundle
-	(*BundleMask)(nil),                             // 11: spire.common.BundleMask
-	(*AttestedNodeMask)(nil),                       // 12: spire.common.AttestedNodeMask
-	(*RegistrationEntry_AdditionalAttributes)(nil), // 13: spire.common.RegistrationEntry.AdditionalAttributes
+	(*CredentialProfile)(nil),                      // 6: spire.common.CredentialProfile
+	(*X509Extension)(nil),                          // 7: spire.common.X509Extension
+	(*RegistrationEntryMask)(nil),                  // 8: spire.common.RegistrationEntryMask
+	(*RegistrationEntries)(nil),                    // 9: spire.common.RegistrationEntries
+	(*Certificate)(nil),                            // 10: spire.common.Certificate
+	(*PublicKey)(nil),                              // 11: spire.common.PublicKey
+	(*Bundle)(nil),                                 // 12: spire.common.Bundle
+	(*BundleMask)(nil),                             // 13: spire.common.BundleMask
+	(*AttestedNodeMask)(nil),                       // 14: spire.common.AttestedNodeMask
+	(*RegistrationEntry_AdditionalAttributes)(nil), // 15: spire.common.RegistrationEntry.AdditionalAttributes
 }
 var file_spire_common_common_proto_depIdxs = []int32{
 	2,  // 0: spire.common.Selectors.entries:type_name -> spire.common.Selector
 	2,  // 1: spire.common.AttestedNode.selectors:type_name -> spire.common.Selector
 	2,  // 2: spire.common.RegistrationEntry.selectors:type_name -> spire.common.Selector
-	13, // 3: spire.common.RegistrationEntry.additional_attributes:type_name -> spire.common.RegistrationEntry.AdditionalAttributes
-	5,  // 4: spire.common.RegistrationEntries.entries:type_name -> spire.common.RegistrationEntry
-	8,  // 5: spire.common.Bundle.root_cas:type_name -> spire.common.Certificate
-	9,  // 6: spire.common.Bundle.jwt_signing_keys:type_name -> spire.common.PublicKey
-	9,  // 7: spire.common.Bundle.wit_signing_keys:type_name -> spire.common.PublicKey
-	8,  // [8:8] is the sub-list for method output_type
-	8,  // [8:8] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	15, // 3: spire.common.RegistrationEntry.additional_attributes:type_name -> spire.common.RegistrationEntry.AdditionalAttributes
+	7,  // 4: spire.common.CredentialProfile.extensions:type_name -> spire.common.X509Extension
+	5,  // 5: spire.common.RegistrationEntries.entries:type_name -> spire.common.RegistrationEntry
+	10, // 6: spire.common.Bundle.root_cas:type_name -> spire.common.Certificate
+	11, // 7: spire.common.Bundle.jwt_signing_keys:type_name -> spire.common.PublicKey
+	11, // 8: spire.common.Bundle.wit_signing_keys:type_name -> spire.common.PublicKey
+	6,  // 9: spire.common.RegistrationEntry.AdditionalAttributes.credential_profile:type_name -> spire.common.CredentialProfile
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_spire_common_common_proto_init() }
@@ -1342,7 +1542,7 @@ func file_spire_common_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_common_common_proto_rawDesc), len(file_spire_common_common_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
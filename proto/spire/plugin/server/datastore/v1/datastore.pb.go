@@ -0,0 +1,7636 @@
+// A DataStore plugin stores and retrieves the data SPIRE Server relies on,
+// e.g. bundles, attested nodes, registration entries and join tokens.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        v7.35.0
+// source: spire/plugin/server/datastore/v1/datastore.proto
+
+package datastorev1
+
+import (
+	common "github.com/spiffe/spire/proto/spire/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DataConsistency indicates the required data consistency for a read
+// operation.
+type DataConsistency int32
+
+const (
+	// Require data from a primary database instance.
+	DataConsistency_REQUIRE_CURRENT DataConsistency = 0
+	// Allow access from available secondary database instances. Data
+	// staleness may be observed in the responses.
+	DataConsistency_TOLERATE_STALE DataConsistency = 1
+)
+
+// Enum value maps for DataConsistency.
+var (
+	DataConsistency_name = map[int32]string{
+		0: "REQUIRE_CURRENT",
+		1: "TOLERATE_STALE",
+	}
+	DataConsistency_value = map[string]int32{
+		"REQUIRE_CURRENT": 0,
+		"TOLERATE_STALE":  1,
+	}
+)
+
+func (x DataConsistency) Enum() *DataConsistency {
+	p := new(DataConsistency)
+	*p = x
+	return p
+}
+
+func (x DataConsistency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataConsistency) Descriptor() protoreflect.EnumDescriptor {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_enumTypes[0].Descriptor()
+}
+
+func (DataConsistency) Type() protoreflect.EnumType {
+	return &file_spire_plugin_server_datastore_v1_datastore_proto_enumTypes[0]
+}
+
+func (x DataConsistency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataConsistency.Descriptor instead.
+func (DataConsistency) EnumDescriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{0}
+}
+
+// DeleteMode defines the behavior when deleting a bundle that registration
+// entries federate with.
+type DeleteMode int32
+
+const (
+	// Restrict the bundle from being deleted in the presence of associated
+	// entries.
+	DeleteMode_RESTRICT DeleteMode = 0
+	// Delete the bundle and associated entries.
+	DeleteMode_DELETE DeleteMode = 1
+	// Delete the bundle and dissociate associated entries.
+	DeleteMode_DISSOCIATE DeleteMode = 2
+)
+
+// Enum value maps for DeleteMode.
+var (
+	DeleteMode_name = map[int32]string{
+		0: "RESTRICT",
+		1: "DELETE",
+		2: "DISSOCIATE",
+	}
+	DeleteMode_value = map[string]int32{
+		"RESTRICT":   0,
+		"DELETE":     1,
+		"DISSOCIATE": 2,
+	}
+)
+
+func (x DeleteMode) Enum() *DeleteMode {
+	p := new(DeleteMode)
+	*p = x
+	return p
+}
+
+func (x DeleteMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeleteMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_enumTypes[1].Descriptor()
+}
+
+func (DeleteMode) Type() protoreflect.EnumType {
+	return &file_spire_plugin_server_datastore_v1_datastore_proto_enumTypes[1]
+}
+
+func (x DeleteMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeleteMode.Descriptor instead.
+func (DeleteMode) EnumDescriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{1}
+}
+
+type MatchBehavior int32
+
+const (
+	MatchBehavior_MATCH_EXACT    MatchBehavior = 0
+	MatchBehavior_MATCH_SUBSET   MatchBehavior = 1
+	MatchBehavior_MATCH_SUPERSET MatchBehavior = 2
+	MatchBehavior_MATCH_ANY      MatchBehavior = 3
+)
+
+// Enum value maps for MatchBehavior.
+var (
+	MatchBehavior_name = map[int32]string{
+		0: "MATCH_EXACT",
+		1: "MATCH_SUBSET",
+		2: "MATCH_SUPERSET",
+		3: "MATCH_ANY",
+	}
+	MatchBehavior_value = map[string]int32{
+		"MATCH_EXACT":    0,
+		"MATCH_SUBSET":   1,
+		"MATCH_SUPERSET": 2,
+		"MATCH_ANY":      3,
+	}
+)
+
+func (x MatchBehavior) Enum() *MatchBehavior {
+	p := new(MatchBehavior)
+	*p = x
+	return p
+}
+
+func (x MatchBehavior) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchBehavior) Descriptor() protoreflect.EnumDescriptor {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_enumTypes[2].Descriptor()
+}
+
+func (MatchBehavior) Type() protoreflect.EnumType {
+	return &file_spire_plugin_server_datastore_v1_datastore_proto_enumTypes[2]
+}
+
+func (x MatchBehavior) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchBehavior.Descriptor instead.
+func (MatchBehavior) EnumDescriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{2}
+}
+
+type Pagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Pagination) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Pagination) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type BySelectors struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Selectors     []*common.Selector     `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	Match         MatchBehavior          `protobuf:"varint,2,opt,name=match,proto3,enum=spire.plugin.server.datastore.v1.MatchBehavior" json:"match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BySelectors) Reset() {
+	*x = BySelectors{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BySelectors) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BySelectors) ProtoMessage() {}
+
+func (x *BySelectors) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BySelectors.ProtoReflect.Descriptor instead.
+func (*BySelectors) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BySelectors) GetSelectors() []*common.Selector {
+	if x != nil {
+		return x.Selectors
+	}
+	return nil
+}
+
+func (x *BySelectors) GetMatch() MatchBehavior {
+	if x != nil {
+		return x.Match
+	}
+	return MatchBehavior_MATCH_EXACT
+}
+
+type ByFederatesWith struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomains  []string               `protobuf:"bytes,1,rep,name=trust_domains,json=trustDomains,proto3" json:"trust_domains,omitempty"`
+	Match         MatchBehavior          `protobuf:"varint,2,opt,name=match,proto3,enum=spire.plugin.server.datastore.v1.MatchBehavior" json:"match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ByFederatesWith) Reset() {
+	*x = ByFederatesWith{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ByFederatesWith) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ByFederatesWith) ProtoMessage() {}
+
+func (x *ByFederatesWith) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ByFederatesWith.ProtoReflect.Descriptor instead.
+func (*ByFederatesWith) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ByFederatesWith) GetTrustDomains() []string {
+	if x != nil {
+		return x.TrustDomains
+	}
+	return nil
+}
+
+func (x *ByFederatesWith) GetMatch() MatchBehavior {
+	if x != nil {
+		return x.Match
+	}
+	return MatchBehavior_MATCH_EXACT
+}
+
+type JoinToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Expiry        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinToken) Reset() {
+	*x = JoinToken{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinToken) ProtoMessage() {}
+
+func (x *JoinToken) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinToken.ProtoReflect.Descriptor instead.
+func (*JoinToken) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JoinToken) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *JoinToken) GetExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+type RegistrationEntryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EntryId       string                 `protobuf:"bytes,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrationEntryEvent) Reset() {
+	*x = RegistrationEntryEvent{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationEntryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationEntryEvent) ProtoMessage() {}
+
+func (x *RegistrationEntryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationEntryEvent.ProtoReflect.Descriptor instead.
+func (*RegistrationEntryEvent) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RegistrationEntryEvent) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *RegistrationEntryEvent) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+type RegistrationEntryChange struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Id            uint64                    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EntryId       string                    `protobuf:"bytes,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Operation     string                    `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	Before        *common.RegistrationEntry `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`
+	After         *common.RegistrationEntry `protobuf:"bytes,5,opt,name=after,proto3" json:"after,omitempty"`
+	CallerId      string                    `protobuf:"bytes,6,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp    `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrationEntryChange) Reset() {
+	*x = RegistrationEntryChange{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationEntryChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationEntryChange) ProtoMessage() {}
+
+func (x *RegistrationEntryChange) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationEntryChange.ProtoReflect.Descriptor instead.
+func (*RegistrationEntryChange) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RegistrationEntryChange) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RegistrationEntryChange) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *RegistrationEntryChange) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *RegistrationEntryChange) GetBefore() *common.RegistrationEntry {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *RegistrationEntryChange) GetAfter() *common.RegistrationEntry {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *RegistrationEntryChange) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+func (x *RegistrationEntryChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AttestedNodeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	SpiffeId      string                 `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttestedNodeEvent) Reset() {
+	*x = AttestedNodeEvent{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttestedNodeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttestedNodeEvent) ProtoMessage() {}
+
+func (x *AttestedNodeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttestedNodeEvent.ProtoReflect.Descriptor instead.
+func (*AttestedNodeEvent) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AttestedNodeEvent) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *AttestedNodeEvent) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+type FederationRelationship struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomain           string                 `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain,proto3" json:"trust_domain,omitempty"`
+	BundleEndpointUrl     string                 `protobuf:"bytes,2,opt,name=bundle_endpoint_url,json=bundleEndpointUrl,proto3" json:"bundle_endpoint_url,omitempty"`
+	BundleEndpointProfile string                 `protobuf:"bytes,3,opt,name=bundle_endpoint_profile,json=bundleEndpointProfile,proto3" json:"bundle_endpoint_profile,omitempty"`
+	TrustDomainBundle     *common.Bundle         `protobuf:"bytes,4,opt,name=trust_domain_bundle,json=trustDomainBundle,proto3" json:"trust_domain_bundle,omitempty"`
+	// Only used for the "https_spiffe" bundle endpoint profile.
+	EndpointSpiffeId string `protobuf:"bytes,5,opt,name=endpoint_spiffe_id,json=endpointSpiffeId,proto3" json:"endpoint_spiffe_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FederationRelationship) Reset() {
+	*x = FederationRelationship{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FederationRelationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FederationRelationship) ProtoMessage() {}
+
+func (x *FederationRelationship) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FederationRelationship.ProtoReflect.Descriptor instead.
+func (*FederationRelationship) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FederationRelationship) GetTrustDomain() string {
+	if x != nil {
+		return x.TrustDomain
+	}
+	return ""
+}
+
+func (x *FederationRelationship) GetBundleEndpointUrl() string {
+	if x != nil {
+		return x.BundleEndpointUrl
+	}
+	return ""
+}
+
+func (x *FederationRelationship) GetBundleEndpointProfile() string {
+	if x != nil {
+		return x.BundleEndpointProfile
+	}
+	return ""
+}
+
+func (x *FederationRelationship) GetTrustDomainBundle() *common.Bundle {
+	if x != nil {
+		return x.TrustDomainBundle
+	}
+	return nil
+}
+
+func (x *FederationRelationship) GetEndpointSpiffeId() string {
+	if x != nil {
+		return x.EndpointSpiffeId
+	}
+	return ""
+}
+
+type FederationRelationshipMask struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	BundleEndpointUrl     bool                   `protobuf:"varint,1,opt,name=bundle_endpoint_url,json=bundleEndpointUrl,proto3" json:"bundle_endpoint_url,omitempty"`
+	BundleEndpointProfile bool                   `protobuf:"varint,2,opt,name=bundle_endpoint_profile,json=bundleEndpointProfile,proto3" json:"bundle_endpoint_profile,omitempty"`
+	TrustDomainBundle     bool                   `protobuf:"varint,3,opt,name=trust_domain_bundle,json=trustDomainBundle,proto3" json:"trust_domain_bundle,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *FederationRelationshipMask) Reset() {
+	*x = FederationRelationshipMask{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FederationRelationshipMask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FederationRelationshipMask) ProtoMessage() {}
+
+func (x *FederationRelationshipMask) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FederationRelationshipMask.ProtoReflect.Descriptor instead.
+func (*FederationRelationshipMask) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FederationRelationshipMask) GetBundleEndpointUrl() bool {
+	if x != nil {
+		return x.BundleEndpointUrl
+	}
+	return false
+}
+
+func (x *FederationRelationshipMask) GetBundleEndpointProfile() bool {
+	if x != nil {
+		return x.BundleEndpointProfile
+	}
+	return false
+}
+
+func (x *FederationRelationshipMask) GetTrustDomainBundle() bool {
+	if x != nil {
+		return x.TrustDomainBundle
+	}
+	return false
+}
+
+type CAJournal struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data                  []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	ActiveX509AuthorityId string                 `protobuf:"bytes,3,opt,name=active_x509_authority_id,json=activeX509AuthorityId,proto3" json:"active_x509_authority_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CAJournal) Reset() {
+	*x = CAJournal{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CAJournal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CAJournal) ProtoMessage() {}
+
+func (x *CAJournal) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CAJournal.ProtoReflect.Descriptor instead.
+func (*CAJournal) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CAJournal) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CAJournal) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CAJournal) GetActiveX509AuthorityId() string {
+	if x != nil {
+		return x.ActiveX509AuthorityId
+	}
+	return ""
+}
+
+type AppendBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *common.Bundle         `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendBundleRequest) Reset() {
+	*x = AppendBundleRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendBundleRequest) ProtoMessage() {}
+
+func (x *AppendBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendBundleRequest.ProtoReflect.Descriptor instead.
+func (*AppendBundleRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AppendBundleRequest) GetBundle() *common.Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type AppendBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *common.Bundle         `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendBundleResponse) Reset() {
+	*x = AppendBundleResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendBundleResponse) ProtoMessage() {}
+
+func (x *AppendBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendBundleResponse.ProtoReflect.Descriptor instead.
+func (*AppendBundleResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AppendBundleResponse) GetBundle() *common.Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type CountBundlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountBundlesRequest) Reset() {
+	*x = CountBundlesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountBundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountBundlesRequest) ProtoMessage() {}
+
+func (x *CountBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountBundlesRequest.ProtoReflect.Descriptor instead.
+func (*CountBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{12}
+}
+
+type CountBundlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountBundlesResponse) Reset() {
+	*x = CountBundlesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountBundlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountBundlesResponse) ProtoMessage() {}
+
+func (x *CountBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountBundlesResponse.ProtoReflect.Descriptor instead.
+func (*CountBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CountBundlesResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CreateBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *common.Bundle         `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBundleRequest) Reset() {
+	*x = CreateBundleRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBundleRequest) ProtoMessage() {}
+
+func (x *CreateBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBundleRequest.ProtoReflect.Descriptor instead.
+func (*CreateBundleRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateBundleRequest) GetBundle() *common.Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type CreateBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *common.Bundle         `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBundleResponse) Reset() {
+	*x = CreateBundleResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBundleResponse) ProtoMessage() {}
+
+func (x *CreateBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBundleResponse.ProtoReflect.Descriptor instead.
+func (*CreateBundleResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateBundleResponse) GetBundle() *common.Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type DeleteBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomainId string                 `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	Mode          DeleteMode             `protobuf:"varint,2,opt,name=mode,proto3,enum=spire.plugin.server.datastore.v1.DeleteMode" json:"mode,omitempty"`
+	// The SPIFFE ID of the caller making the change, recorded in the entry
+	// history. Empty for callers on the local endpoint.
+	CallerId      string `protobuf:"bytes,3,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBundleRequest) Reset() {
+	*x = DeleteBundleRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBundleRequest) ProtoMessage() {}
+
+func (x *DeleteBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBundleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBundleRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteBundleRequest) GetTrustDomainId() string {
+	if x != nil {
+		return x.TrustDomainId
+	}
+	return ""
+}
+
+func (x *DeleteBundleRequest) GetMode() DeleteMode {
+	if x != nil {
+		return x.Mode
+	}
+	return DeleteMode_RESTRICT
+}
+
+func (x *DeleteBundleRequest) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+type DeleteBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBundleResponse) Reset() {
+	*x = DeleteBundleResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBundleResponse) ProtoMessage() {}
+
+func (x *DeleteBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBundleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBundleResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{17}
+}
+
+type FetchBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomainId string                 `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchBundleRequest) Reset() {
+	*x = FetchBundleRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchBundleRequest) ProtoMessage() {}
+
+func (x *FetchBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchBundleRequest.ProtoReflect.Descriptor instead.
+func (*FetchBundleRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FetchBundleRequest) GetTrustDomainId() string {
+	if x != nil {
+		return x.TrustDomainId
+	}
+	return ""
+}
+
+type FetchBundleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset if the bundle does not exist.
+	Bundle        *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchBundleResponse) Reset() {
+	*x = FetchBundleResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchBundleResponse) ProtoMessage() {}
+
+func (x *FetchBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchBundleResponse.ProtoReflect.Descriptor instead.
+func (*FetchBundleResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FetchBundleResponse) GetBundle() *common.Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type ListBundlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *Pagination            `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBundlesRequest) Reset() {
+	*x = ListBundlesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBundlesRequest) ProtoMessage() {}
+
+func (x *ListBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBundlesRequest.ProtoReflect.Descriptor instead.
+func (*ListBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListBundlesRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListBundlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundles       []*common.Bundle       `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBundlesResponse) Reset() {
+	*x = ListBundlesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBundlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBundlesResponse) ProtoMessage() {}
+
+func (x *ListBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBundlesResponse.ProtoReflect.Descriptor instead.
+func (*ListBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListBundlesResponse) GetBundles() []*common.Bundle {
+	if x != nil {
+		return x.Bundles
+	}
+	return nil
+}
+
+func (x *ListBundlesResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type PruneBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomainId string                 `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	ExpiresBefore *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneBundleRequest) Reset() {
+	*x = PruneBundleRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneBundleRequest) ProtoMessage() {}
+
+func (x *PruneBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneBundleRequest.ProtoReflect.Descriptor instead.
+func (*PruneBundleRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PruneBundleRequest) GetTrustDomainId() string {
+	if x != nil {
+		return x.TrustDomainId
+	}
+	return ""
+}
+
+func (x *PruneBundleRequest) GetExpiresBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresBefore
+	}
+	return nil
+}
+
+type PruneBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changed       bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneBundleResponse) Reset() {
+	*x = PruneBundleResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneBundleResponse) ProtoMessage() {}
+
+func (x *PruneBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneBundleResponse.ProtoReflect.Descriptor instead.
+func (*PruneBundleResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PruneBundleResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+type SetBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *common.Bundle         `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBundleRequest) Reset() {
+	*x = SetBundleRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBundleRequest) ProtoMessage() {}
+
+func (x *SetBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBundleRequest.ProtoReflect.Descriptor instead.
+func (*SetBundleRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetBundleRequest) GetBundle() *common.Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type SetBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *common.Bundle         `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBundleResponse) Reset() {
+	*x = SetBundleResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBundleResponse) ProtoMessage() {}
+
+func (x *SetBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBundleResponse.ProtoReflect.Descriptor instead.
+func (*SetBundleResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetBundleResponse) GetBundle() *common.Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type UpdateBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *common.Bundle         `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	Mask          *common.BundleMask     `protobuf:"bytes,2,opt,name=mask,proto3" json:"mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBundleRequest) Reset() {
+	*x = UpdateBundleRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBundleRequest) ProtoMessage() {}
+
+func (x *UpdateBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBundleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBundleRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateBundleRequest) GetBundle() *common.Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *UpdateBundleRequest) GetMask() *common.BundleMask {
+	if x != nil {
+		return x.Mask
+	}
+	return nil
+}
+
+type UpdateBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *common.Bundle         `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBundleResponse) Reset() {
+	*x = UpdateBundleResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBundleResponse) ProtoMessage() {}
+
+func (x *UpdateBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBundleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBundleResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateBundleResponse) GetBundle() *common.Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type TaintX509CARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomainId string                 `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	SubjectKeyId  string                 `protobuf:"bytes,2,opt,name=subject_key_id,json=subjectKeyId,proto3" json:"subject_key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaintX509CARequest) Reset() {
+	*x = TaintX509CARequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaintX509CARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaintX509CARequest) ProtoMessage() {}
+
+func (x *TaintX509CARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaintX509CARequest.ProtoReflect.Descriptor instead.
+func (*TaintX509CARequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TaintX509CARequest) GetTrustDomainId() string {
+	if x != nil {
+		return x.TrustDomainId
+	}
+	return ""
+}
+
+func (x *TaintX509CARequest) GetSubjectKeyId() string {
+	if x != nil {
+		return x.SubjectKeyId
+	}
+	return ""
+}
+
+type TaintX509CAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaintX509CAResponse) Reset() {
+	*x = TaintX509CAResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaintX509CAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaintX509CAResponse) ProtoMessage() {}
+
+func (x *TaintX509CAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaintX509CAResponse.ProtoReflect.Descriptor instead.
+func (*TaintX509CAResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{29}
+}
+
+type RevokeX509CARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomainId string                 `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	SubjectKeyId  string                 `protobuf:"bytes,2,opt,name=subject_key_id,json=subjectKeyId,proto3" json:"subject_key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeX509CARequest) Reset() {
+	*x = RevokeX509CARequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeX509CARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeX509CARequest) ProtoMessage() {}
+
+func (x *RevokeX509CARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeX509CARequest.ProtoReflect.Descriptor instead.
+func (*RevokeX509CARequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RevokeX509CARequest) GetTrustDomainId() string {
+	if x != nil {
+		return x.TrustDomainId
+	}
+	return ""
+}
+
+func (x *RevokeX509CARequest) GetSubjectKeyId() string {
+	if x != nil {
+		return x.SubjectKeyId
+	}
+	return ""
+}
+
+type RevokeX509CAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeX509CAResponse) Reset() {
+	*x = RevokeX509CAResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeX509CAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeX509CAResponse) ProtoMessage() {}
+
+func (x *RevokeX509CAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeX509CAResponse.ProtoReflect.Descriptor instead.
+func (*RevokeX509CAResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{31}
+}
+
+type TaintJWTKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomainId string                 `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	AuthorityId   string                 `protobuf:"bytes,2,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaintJWTKeyRequest) Reset() {
+	*x = TaintJWTKeyRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaintJWTKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaintJWTKeyRequest) ProtoMessage() {}
+
+func (x *TaintJWTKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaintJWTKeyRequest.ProtoReflect.Descriptor instead.
+func (*TaintJWTKeyRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *TaintJWTKeyRequest) GetTrustDomainId() string {
+	if x != nil {
+		return x.TrustDomainId
+	}
+	return ""
+}
+
+func (x *TaintJWTKeyRequest) GetAuthorityId() string {
+	if x != nil {
+		return x.AuthorityId
+	}
+	return ""
+}
+
+type TaintJWTKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     *common.PublicKey      `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaintJWTKeyResponse) Reset() {
+	*x = TaintJWTKeyResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaintJWTKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaintJWTKeyResponse) ProtoMessage() {}
+
+func (x *TaintJWTKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaintJWTKeyResponse.ProtoReflect.Descriptor instead.
+func (*TaintJWTKeyResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TaintJWTKeyResponse) GetPublicKey() *common.PublicKey {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+type RevokeJWTKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomainId string                 `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	AuthorityId   string                 `protobuf:"bytes,2,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeJWTKeyRequest) Reset() {
+	*x = RevokeJWTKeyRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeJWTKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeJWTKeyRequest) ProtoMessage() {}
+
+func (x *RevokeJWTKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeJWTKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeJWTKeyRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RevokeJWTKeyRequest) GetTrustDomainId() string {
+	if x != nil {
+		return x.TrustDomainId
+	}
+	return ""
+}
+
+func (x *RevokeJWTKeyRequest) GetAuthorityId() string {
+	if x != nil {
+		return x.AuthorityId
+	}
+	return ""
+}
+
+type RevokeJWTKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     *common.PublicKey      `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeJWTKeyResponse) Reset() {
+	*x = RevokeJWTKeyResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeJWTKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeJWTKeyResponse) ProtoMessage() {}
+
+func (x *RevokeJWTKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeJWTKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeJWTKeyResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RevokeJWTKeyResponse) GetPublicKey() *common.PublicKey {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+type ActivateRegistrationEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivateRegistrationEntriesRequest) Reset() {
+	*x = ActivateRegistrationEntriesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivateRegistrationEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateRegistrationEntriesRequest) ProtoMessage() {}
+
+func (x *ActivateRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateRegistrationEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ActivateRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ActivateRegistrationEntriesRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ActivateRegistrationEntriesRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+type ActivateRegistrationEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivateRegistrationEntriesResponse) Reset() {
+	*x = ActivateRegistrationEntriesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivateRegistrationEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateRegistrationEntriesResponse) ProtoMessage() {}
+
+func (x *ActivateRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateRegistrationEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ActivateRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{37}
+}
+
+type CountRegistrationEntriesRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DataConsistency  DataConsistency        `protobuf:"varint,1,opt,name=data_consistency,json=dataConsistency,proto3,enum=spire.plugin.server.datastore.v1.DataConsistency" json:"data_consistency,omitempty"`
+	ByParentId       string                 `protobuf:"bytes,2,opt,name=by_parent_id,json=byParentId,proto3" json:"by_parent_id,omitempty"`
+	BySelectors      *BySelectors           `protobuf:"bytes,3,opt,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
+	BySpiffeId       string                 `protobuf:"bytes,4,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
+	ByFederatesWith  *ByFederatesWith       `protobuf:"bytes,5,opt,name=by_federates_with,json=byFederatesWith,proto3" json:"by_federates_with,omitempty"`
+	ByHint           string                 `protobuf:"bytes,6,opt,name=by_hint,json=byHint,proto3" json:"by_hint,omitempty"`
+	ByDownstream     *bool                  `protobuf:"varint,7,opt,name=by_downstream,json=byDownstream,proto3,oneof" json:"by_downstream,omitempty"`
+	BySpiffeIdPrefix string                 `protobuf:"bytes,8,opt,name=by_spiffe_id_prefix,json=bySpiffeIdPrefix,proto3" json:"by_spiffe_id_prefix,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CountRegistrationEntriesRequest) Reset() {
+	*x = CountRegistrationEntriesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountRegistrationEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountRegistrationEntriesRequest) ProtoMessage() {}
+
+func (x *CountRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountRegistrationEntriesRequest.ProtoReflect.Descriptor instead.
+func (*CountRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CountRegistrationEntriesRequest) GetDataConsistency() DataConsistency {
+	if x != nil {
+		return x.DataConsistency
+	}
+	return DataConsistency_REQUIRE_CURRENT
+}
+
+func (x *CountRegistrationEntriesRequest) GetByParentId() string {
+	if x != nil {
+		return x.ByParentId
+	}
+	return ""
+}
+
+func (x *CountRegistrationEntriesRequest) GetBySelectors() *BySelectors {
+	if x != nil {
+		return x.BySelectors
+	}
+	return nil
+}
+
+func (x *CountRegistrationEntriesRequest) GetBySpiffeId() string {
+	if x != nil {
+		return x.BySpiffeId
+	}
+	return ""
+}
+
+func (x *CountRegistrationEntriesRequest) GetByFederatesWith() *ByFederatesWith {
+	if x != nil {
+		return x.ByFederatesWith
+	}
+	return nil
+}
+
+func (x *CountRegistrationEntriesRequest) GetByHint() string {
+	if x != nil {
+		return x.ByHint
+	}
+	return ""
+}
+
+func (x *CountRegistrationEntriesRequest) GetByDownstream() bool {
+	if x != nil && x.ByDownstream != nil {
+		return *x.ByDownstream
+	}
+	return false
+}
+
+func (x *CountRegistrationEntriesRequest) GetBySpiffeIdPrefix() string {
+	if x != nil {
+		return x.BySpiffeIdPrefix
+	}
+	return ""
+}
+
+type CountRegistrationEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountRegistrationEntriesResponse) Reset() {
+	*x = CountRegistrationEntriesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountRegistrationEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountRegistrationEntriesResponse) ProtoMessage() {}
+
+func (x *CountRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountRegistrationEntriesResponse.ProtoReflect.Descriptor instead.
+func (*CountRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CountRegistrationEntriesResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CreateRegistrationEntryRequest struct {
+	state protoimpl.MessageState    `protogen:"open.v1"`
+	Entry *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// The SPIFFE ID of the caller making the change, recorded in the entry
+	// history. Empty for callers on the local endpoint.
+	CallerId      string `protobuf:"bytes,2,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRegistrationEntryRequest) Reset() {
+	*x = CreateRegistrationEntryRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRegistrationEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRegistrationEntryRequest) ProtoMessage() {}
+
+func (x *CreateRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRegistrationEntryRequest.ProtoReflect.Descriptor instead.
+func (*CreateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreateRegistrationEntryRequest) GetEntry() *common.RegistrationEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *CreateRegistrationEntryRequest) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+type CreateRegistrationEntryResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Entry         *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRegistrationEntryResponse) Reset() {
+	*x = CreateRegistrationEntryResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRegistrationEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRegistrationEntryResponse) ProtoMessage() {}
+
+func (x *CreateRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRegistrationEntryResponse.ProtoReflect.Descriptor instead.
+func (*CreateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreateRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type CreateOrReturnRegistrationEntryRequest struct {
+	state protoimpl.MessageState    `protogen:"open.v1"`
+	Entry *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// The SPIFFE ID of the caller making the change, recorded in the entry
+	// history. Empty for callers on the local endpoint.
+	CallerId      string `protobuf:"bytes,2,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrReturnRegistrationEntryRequest) Reset() {
+	*x = CreateOrReturnRegistrationEntryRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrReturnRegistrationEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrReturnRegistrationEntryRequest) ProtoMessage() {}
+
+func (x *CreateOrReturnRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrReturnRegistrationEntryRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrReturnRegistrationEntryRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateOrReturnRegistrationEntryRequest) GetEntry() *common.RegistrationEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *CreateOrReturnRegistrationEntryRequest) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+type CreateOrReturnRegistrationEntryResponse struct {
+	state protoimpl.MessageState    `protogen:"open.v1"`
+	Entry *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// True if a similar entry already existed and was returned instead of
+	// creating a new one.
+	Existing      bool `protobuf:"varint,2,opt,name=existing,proto3" json:"existing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrReturnRegistrationEntryResponse) Reset() {
+	*x = CreateOrReturnRegistrationEntryResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrReturnRegistrationEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrReturnRegistrationEntryResponse) ProtoMessage() {}
+
+func (x *CreateOrReturnRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrReturnRegistrationEntryResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrReturnRegistrationEntryResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateOrReturnRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *CreateOrReturnRegistrationEntryResponse) GetExisting() bool {
+	if x != nil {
+		return x.Existing
+	}
+	return false
+}
+
+type DeleteRegistrationEntryRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EntryId string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// The SPIFFE ID of the caller making the change, recorded in the entry
+	// history. Empty for callers on the local endpoint.
+	CallerId      string `protobuf:"bytes,2,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRegistrationEntryRequest) Reset() {
+	*x = DeleteRegistrationEntryRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRegistrationEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRegistrationEntryRequest) ProtoMessage() {}
+
+func (x *DeleteRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRegistrationEntryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRegistrationEntryRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeleteRegistrationEntryRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *DeleteRegistrationEntryRequest) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+type DeleteRegistrationEntryResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Entry         *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRegistrationEntryResponse) Reset() {
+	*x = DeleteRegistrationEntryResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRegistrationEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRegistrationEntryResponse) ProtoMessage() {}
+
+func (x *DeleteRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRegistrationEntryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRegistrationEntryResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type FetchRegistrationEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchRegistrationEntryRequest) Reset() {
+	*x = FetchRegistrationEntryRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchRegistrationEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRegistrationEntryRequest) ProtoMessage() {}
+
+func (x *FetchRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRegistrationEntryRequest.ProtoReflect.Descriptor instead.
+func (*FetchRegistrationEntryRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *FetchRegistrationEntryRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+type FetchRegistrationEntryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset if the entry does not exist.
+	Entry         *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchRegistrationEntryResponse) Reset() {
+	*x = FetchRegistrationEntryResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchRegistrationEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRegistrationEntryResponse) ProtoMessage() {}
+
+func (x *FetchRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRegistrationEntryResponse.ProtoReflect.Descriptor instead.
+func (*FetchRegistrationEntryResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *FetchRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type FetchRegistrationEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryIds      []string               `protobuf:"bytes,1,rep,name=entry_ids,json=entryIds,proto3" json:"entry_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchRegistrationEntriesRequest) Reset() {
+	*x = FetchRegistrationEntriesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchRegistrationEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRegistrationEntriesRequest) ProtoMessage() {}
+
+func (x *FetchRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRegistrationEntriesRequest.ProtoReflect.Descriptor instead.
+func (*FetchRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *FetchRegistrationEntriesRequest) GetEntryIds() []string {
+	if x != nil {
+		return x.EntryIds
+	}
+	return nil
+}
+
+type FetchRegistrationEntriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entries keyed by entry ID. IDs with no existing entry are omitted.
+	Entries       map[string]*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchRegistrationEntriesResponse) Reset() {
+	*x = FetchRegistrationEntriesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchRegistrationEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRegistrationEntriesResponse) ProtoMessage() {}
+
+func (x *FetchRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRegistrationEntriesResponse.ProtoReflect.Descriptor instead.
+func (*FetchRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *FetchRegistrationEntriesResponse) GetEntries() map[string]*common.RegistrationEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ListRegistrationEntriesRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DataConsistency  DataConsistency        `protobuf:"varint,1,opt,name=data_consistency,json=dataConsistency,proto3,enum=spire.plugin.server.datastore.v1.DataConsistency" json:"data_consistency,omitempty"`
+	ByParentId       string                 `protobuf:"bytes,2,opt,name=by_parent_id,json=byParentId,proto3" json:"by_parent_id,omitempty"`
+	BySelectors      *BySelectors           `protobuf:"bytes,3,opt,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
+	BySpiffeId       string                 `protobuf:"bytes,4,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
+	Pagination       *Pagination            `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	ByFederatesWith  *ByFederatesWith       `protobuf:"bytes,6,opt,name=by_federates_with,json=byFederatesWith,proto3" json:"by_federates_with,omitempty"`
+	ByHint           string                 `protobuf:"bytes,7,opt,name=by_hint,json=byHint,proto3" json:"by_hint,omitempty"`
+	ByDownstream     *bool                  `protobuf:"varint,8,opt,name=by_downstream,json=byDownstream,proto3,oneof" json:"by_downstream,omitempty"`
+	BySpiffeIdPrefix string                 `protobuf:"bytes,9,opt,name=by_spiffe_id_prefix,json=bySpiffeIdPrefix,proto3" json:"by_spiffe_id_prefix,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListRegistrationEntriesRequest) Reset() {
+	*x = ListRegistrationEntriesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRegistrationEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRegistrationEntriesRequest) ProtoMessage() {}
+
+func (x *ListRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRegistrationEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListRegistrationEntriesRequest) GetDataConsistency() DataConsistency {
+	if x != nil {
+		return x.DataConsistency
+	}
+	return DataConsistency_REQUIRE_CURRENT
+}
+
+func (x *ListRegistrationEntriesRequest) GetByParentId() string {
+	if x != nil {
+		return x.ByParentId
+	}
+	return ""
+}
+
+func (x *ListRegistrationEntriesRequest) GetBySelectors() *BySelectors {
+	if x != nil {
+		return x.BySelectors
+	}
+	return nil
+}
+
+func (x *ListRegistrationEntriesRequest) GetBySpiffeId() string {
+	if x != nil {
+		return x.BySpiffeId
+	}
+	return ""
+}
+
+func (x *ListRegistrationEntriesRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListRegistrationEntriesRequest) GetByFederatesWith() *ByFederatesWith {
+	if x != nil {
+		return x.ByFederatesWith
+	}
+	return nil
+}
+
+func (x *ListRegistrationEntriesRequest) GetByHint() string {
+	if x != nil {
+		return x.ByHint
+	}
+	return ""
+}
+
+func (x *ListRegistrationEntriesRequest) GetByDownstream() bool {
+	if x != nil && x.ByDownstream != nil {
+		return *x.ByDownstream
+	}
+	return false
+}
+
+func (x *ListRegistrationEntriesRequest) GetBySpiffeIdPrefix() string {
+	if x != nil {
+		return x.BySpiffeIdPrefix
+	}
+	return ""
+}
+
+type ListRegistrationEntriesResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Entries       []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Pagination    *Pagination                 `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRegistrationEntriesResponse) Reset() {
+	*x = ListRegistrationEntriesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRegistrationEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRegistrationEntriesResponse) ProtoMessage() {}
+
+func (x *ListRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRegistrationEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListRegistrationEntriesResponse) GetEntries() []*common.RegistrationEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListRegistrationEntriesResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type PruneRegistrationEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpiresBefore *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneRegistrationEntriesRequest) Reset() {
+	*x = PruneRegistrationEntriesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneRegistrationEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneRegistrationEntriesRequest) ProtoMessage() {}
+
+func (x *PruneRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneRegistrationEntriesRequest.ProtoReflect.Descriptor instead.
+func (*PruneRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *PruneRegistrationEntriesRequest) GetExpiresBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresBefore
+	}
+	return nil
+}
+
+type PruneRegistrationEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneRegistrationEntriesResponse) Reset() {
+	*x = PruneRegistrationEntriesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneRegistrationEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneRegistrationEntriesResponse) ProtoMessage() {}
+
+func (x *PruneRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneRegistrationEntriesResponse.ProtoReflect.Descriptor instead.
+func (*PruneRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{53}
+}
+
+type UpdateRegistrationEntryRequest struct {
+	state protoimpl.MessageState        `protogen:"open.v1"`
+	Entry *common.RegistrationEntry     `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	Mask  *common.RegistrationEntryMask `protobuf:"bytes,2,opt,name=mask,proto3" json:"mask,omitempty"`
+	// The SPIFFE ID of the caller making the change, recorded in the entry
+	// history. Empty for callers on the local endpoint.
+	CallerId      string `protobuf:"bytes,3,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRegistrationEntryRequest) Reset() {
+	*x = UpdateRegistrationEntryRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRegistrationEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRegistrationEntryRequest) ProtoMessage() {}
+
+func (x *UpdateRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRegistrationEntryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *UpdateRegistrationEntryRequest) GetEntry() *common.RegistrationEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *UpdateRegistrationEntryRequest) GetMask() *common.RegistrationEntryMask {
+	if x != nil {
+		return x.Mask
+	}
+	return nil
+}
+
+func (x *UpdateRegistrationEntryRequest) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+type UpdateRegistrationEntryResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Entry         *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRegistrationEntryResponse) Reset() {
+	*x = UpdateRegistrationEntryResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRegistrationEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRegistrationEntryResponse) ProtoMessage() {}
+
+func (x *UpdateRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRegistrationEntryResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *UpdateRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type ListRegistrationEntryEventsRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DataConsistency    DataConsistency        `protobuf:"varint,1,opt,name=data_consistency,json=dataConsistency,proto3,enum=spire.plugin.server.datastore.v1.DataConsistency" json:"data_consistency,omitempty"`
+	GreaterThanEventId uint64                 `protobuf:"varint,2,opt,name=greater_than_event_id,json=greaterThanEventId,proto3" json:"greater_than_event_id,omitempty"`
+	LessThanEventId    uint64                 `protobuf:"varint,3,opt,name=less_than_event_id,json=lessThanEventId,proto3" json:"less_than_event_id,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListRegistrationEntryEventsRequest) Reset() {
+	*x = ListRegistrationEntryEventsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRegistrationEntryEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRegistrationEntryEventsRequest) ProtoMessage() {}
+
+func (x *ListRegistrationEntryEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRegistrationEntryEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListRegistrationEntryEventsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListRegistrationEntryEventsRequest) GetDataConsistency() DataConsistency {
+	if x != nil {
+		return x.DataConsistency
+	}
+	return DataConsistency_REQUIRE_CURRENT
+}
+
+func (x *ListRegistrationEntryEventsRequest) GetGreaterThanEventId() uint64 {
+	if x != nil {
+		return x.GreaterThanEventId
+	}
+	return 0
+}
+
+func (x *ListRegistrationEntryEventsRequest) GetLessThanEventId() uint64 {
+	if x != nil {
+		return x.LessThanEventId
+	}
+	return 0
+}
+
+type ListRegistrationEntryEventsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Events        []*RegistrationEntryEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRegistrationEntryEventsResponse) Reset() {
+	*x = ListRegistrationEntryEventsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRegistrationEntryEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRegistrationEntryEventsResponse) ProtoMessage() {}
+
+func (x *ListRegistrationEntryEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRegistrationEntryEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListRegistrationEntryEventsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListRegistrationEntryEventsResponse) GetEvents() []*RegistrationEntryEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type PruneRegistrationEntryEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OlderThan     *durationpb.Duration   `protobuf:"bytes,1,opt,name=older_than,json=olderThan,proto3" json:"older_than,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneRegistrationEntryEventsRequest) Reset() {
+	*x = PruneRegistrationEntryEventsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneRegistrationEntryEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneRegistrationEntryEventsRequest) ProtoMessage() {}
+
+func (x *PruneRegistrationEntryEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneRegistrationEntryEventsRequest.ProtoReflect.Descriptor instead.
+func (*PruneRegistrationEntryEventsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *PruneRegistrationEntryEventsRequest) GetOlderThan() *durationpb.Duration {
+	if x != nil {
+		return x.OlderThan
+	}
+	return nil
+}
+
+type PruneRegistrationEntryEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneRegistrationEntryEventsResponse) Reset() {
+	*x = PruneRegistrationEntryEventsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneRegistrationEntryEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneRegistrationEntryEventsResponse) ProtoMessage() {}
+
+func (x *PruneRegistrationEntryEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneRegistrationEntryEventsResponse.ProtoReflect.Descriptor instead.
+func (*PruneRegistrationEntryEventsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{59}
+}
+
+type FetchRegistrationEntryEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchRegistrationEntryEventRequest) Reset() {
+	*x = FetchRegistrationEntryEventRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchRegistrationEntryEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRegistrationEntryEventRequest) ProtoMessage() {}
+
+func (x *FetchRegistrationEntryEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRegistrationEntryEventRequest.ProtoReflect.Descriptor instead.
+func (*FetchRegistrationEntryEventRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *FetchRegistrationEntryEventRequest) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type FetchRegistrationEntryEventResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Event         *RegistrationEntryEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchRegistrationEntryEventResponse) Reset() {
+	*x = FetchRegistrationEntryEventResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchRegistrationEntryEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRegistrationEntryEventResponse) ProtoMessage() {}
+
+func (x *FetchRegistrationEntryEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRegistrationEntryEventResponse.ProtoReflect.Descriptor instead.
+func (*FetchRegistrationEntryEventResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *FetchRegistrationEntryEventResponse) GetEvent() *RegistrationEntryEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type CreateRegistrationEntryEventForTestingRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Event         *RegistrationEntryEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRegistrationEntryEventForTestingRequest) Reset() {
+	*x = CreateRegistrationEntryEventForTestingRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRegistrationEntryEventForTestingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRegistrationEntryEventForTestingRequest) ProtoMessage() {}
+
+func (x *CreateRegistrationEntryEventForTestingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRegistrationEntryEventForTestingRequest.ProtoReflect.Descriptor instead.
+func (*CreateRegistrationEntryEventForTestingRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CreateRegistrationEntryEventForTestingRequest) GetEvent() *RegistrationEntryEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type CreateRegistrationEntryEventForTestingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRegistrationEntryEventForTestingResponse) Reset() {
+	*x = CreateRegistrationEntryEventForTestingResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRegistrationEntryEventForTestingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRegistrationEntryEventForTestingResponse) ProtoMessage() {}
+
+func (x *CreateRegistrationEntryEventForTestingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRegistrationEntryEventForTestingResponse.ProtoReflect.Descriptor instead.
+func (*CreateRegistrationEntryEventForTestingResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{63}
+}
+
+type DeleteRegistrationEntryEventForTestingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRegistrationEntryEventForTestingRequest) Reset() {
+	*x = DeleteRegistrationEntryEventForTestingRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRegistrationEntryEventForTestingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRegistrationEntryEventForTestingRequest) ProtoMessage() {}
+
+func (x *DeleteRegistrationEntryEventForTestingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRegistrationEntryEventForTestingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRegistrationEntryEventForTestingRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *DeleteRegistrationEntryEventForTestingRequest) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type DeleteRegistrationEntryEventForTestingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRegistrationEntryEventForTestingResponse) Reset() {
+	*x = DeleteRegistrationEntryEventForTestingResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRegistrationEntryEventForTestingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRegistrationEntryEventForTestingResponse) ProtoMessage() {}
+
+func (x *DeleteRegistrationEntryEventForTestingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRegistrationEntryEventForTestingResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRegistrationEntryEventForTestingResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{65}
+}
+
+type ListRegistrationEntryChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ByEntryId     string                 `protobuf:"bytes,1,opt,name=by_entry_id,json=byEntryId,proto3" json:"by_entry_id,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRegistrationEntryChangesRequest) Reset() {
+	*x = ListRegistrationEntryChangesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRegistrationEntryChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRegistrationEntryChangesRequest) ProtoMessage() {}
+
+func (x *ListRegistrationEntryChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRegistrationEntryChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListRegistrationEntryChangesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListRegistrationEntryChangesRequest) GetByEntryId() string {
+	if x != nil {
+		return x.ByEntryId
+	}
+	return ""
+}
+
+func (x *ListRegistrationEntryChangesRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListRegistrationEntryChangesResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Changes       []*RegistrationEntryChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	Pagination    *Pagination                `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRegistrationEntryChangesResponse) Reset() {
+	*x = ListRegistrationEntryChangesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRegistrationEntryChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRegistrationEntryChangesResponse) ProtoMessage() {}
+
+func (x *ListRegistrationEntryChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRegistrationEntryChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListRegistrationEntryChangesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListRegistrationEntryChangesResponse) GetChanges() []*RegistrationEntryChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ListRegistrationEntryChangesResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type PruneRegistrationEntryChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OlderThan     *durationpb.Duration   `protobuf:"bytes,1,opt,name=older_than,json=olderThan,proto3" json:"older_than,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneRegistrationEntryChangesRequest) Reset() {
+	*x = PruneRegistrationEntryChangesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneRegistrationEntryChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneRegistrationEntryChangesRequest) ProtoMessage() {}
+
+func (x *PruneRegistrationEntryChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneRegistrationEntryChangesRequest.ProtoReflect.Descriptor instead.
+func (*PruneRegistrationEntryChangesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *PruneRegistrationEntryChangesRequest) GetOlderThan() *durationpb.Duration {
+	if x != nil {
+		return x.OlderThan
+	}
+	return nil
+}
+
+type PruneRegistrationEntryChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneRegistrationEntryChangesResponse) Reset() {
+	*x = PruneRegistrationEntryChangesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneRegistrationEntryChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneRegistrationEntryChangesResponse) ProtoMessage() {}
+
+func (x *PruneRegistrationEntryChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneRegistrationEntryChangesResponse.ProtoReflect.Descriptor instead.
+func (*PruneRegistrationEntryChangesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{69}
+}
+
+type CountAttestedNodesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ByAttestationType string                 `protobuf:"bytes,1,opt,name=by_attestation_type,json=byAttestationType,proto3" json:"by_attestation_type,omitempty"`
+	ByBanned          *bool                  `protobuf:"varint,2,opt,name=by_banned,json=byBanned,proto3,oneof" json:"by_banned,omitempty"`
+	ByExpiresBefore   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	BySelectorMatch   *BySelectors           `protobuf:"bytes,4,opt,name=by_selector_match,json=bySelectorMatch,proto3" json:"by_selector_match,omitempty"`
+	FetchSelectors    bool                   `protobuf:"varint,5,opt,name=fetch_selectors,json=fetchSelectors,proto3" json:"fetch_selectors,omitempty"`
+	ByCanReattest     *bool                  `protobuf:"varint,6,opt,name=by_can_reattest,json=byCanReattest,proto3,oneof" json:"by_can_reattest,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CountAttestedNodesRequest) Reset() {
+	*x = CountAttestedNodesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountAttestedNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountAttestedNodesRequest) ProtoMessage() {}
+
+func (x *CountAttestedNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountAttestedNodesRequest.ProtoReflect.Descriptor instead.
+func (*CountAttestedNodesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CountAttestedNodesRequest) GetByAttestationType() string {
+	if x != nil {
+		return x.ByAttestationType
+	}
+	return ""
+}
+
+func (x *CountAttestedNodesRequest) GetByBanned() bool {
+	if x != nil && x.ByBanned != nil {
+		return *x.ByBanned
+	}
+	return false
+}
+
+func (x *CountAttestedNodesRequest) GetByExpiresBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ByExpiresBefore
+	}
+	return nil
+}
+
+func (x *CountAttestedNodesRequest) GetBySelectorMatch() *BySelectors {
+	if x != nil {
+		return x.BySelectorMatch
+	}
+	return nil
+}
+
+func (x *CountAttestedNodesRequest) GetFetchSelectors() bool {
+	if x != nil {
+		return x.FetchSelectors
+	}
+	return false
+}
+
+func (x *CountAttestedNodesRequest) GetByCanReattest() bool {
+	if x != nil && x.ByCanReattest != nil {
+		return *x.ByCanReattest
+	}
+	return false
+}
+
+type CountAttestedNodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountAttestedNodesResponse) Reset() {
+	*x = CountAttestedNodesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountAttestedNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountAttestedNodesResponse) ProtoMessage() {}
+
+func (x *CountAttestedNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountAttestedNodesResponse.ProtoReflect.Descriptor instead.
+func (*CountAttestedNodesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CountAttestedNodesResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CreateAttestedNodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *common.AttestedNode   `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAttestedNodeRequest) Reset() {
+	*x = CreateAttestedNodeRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAttestedNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAttestedNodeRequest) ProtoMessage() {}
+
+func (x *CreateAttestedNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAttestedNodeRequest.ProtoReflect.Descriptor instead.
+func (*CreateAttestedNodeRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CreateAttestedNodeRequest) GetNode() *common.AttestedNode {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+type CreateAttestedNodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *common.AttestedNode   `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAttestedNodeResponse) Reset() {
+	*x = CreateAttestedNodeResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAttestedNodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAttestedNodeResponse) ProtoMessage() {}
+
+func (x *CreateAttestedNodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAttestedNodeResponse.ProtoReflect.Descriptor instead.
+func (*CreateAttestedNodeResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CreateAttestedNodeResponse) GetNode() *common.AttestedNode {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+type DeleteAttestedNodeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SpiffeId string                 `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// The SPIFFE ID of the caller making the change, recorded in the entry
+	// history. Empty for callers on the local endpoint.
+	CallerId      string `protobuf:"bytes,2,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttestedNodeRequest) Reset() {
+	*x = DeleteAttestedNodeRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttestedNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttestedNodeRequest) ProtoMessage() {}
+
+func (x *DeleteAttestedNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttestedNodeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttestedNodeRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *DeleteAttestedNodeRequest) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+func (x *DeleteAttestedNodeRequest) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+type DeleteAttestedNodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *common.AttestedNode   `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttestedNodeResponse) Reset() {
+	*x = DeleteAttestedNodeResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttestedNodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttestedNodeResponse) ProtoMessage() {}
+
+func (x *DeleteAttestedNodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttestedNodeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAttestedNodeResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *DeleteAttestedNodeResponse) GetNode() *common.AttestedNode {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+type FetchAttestedNodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpiffeId      string                 `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchAttestedNodeRequest) Reset() {
+	*x = FetchAttestedNodeRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchAttestedNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchAttestedNodeRequest) ProtoMessage() {}
+
+func (x *FetchAttestedNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchAttestedNodeRequest.ProtoReflect.Descriptor instead.
+func (*FetchAttestedNodeRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *FetchAttestedNodeRequest) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+type FetchAttestedNodeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset if the node does not exist.
+	Node          *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchAttestedNodeResponse) Reset() {
+	*x = FetchAttestedNodeResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchAttestedNodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchAttestedNodeResponse) ProtoMessage() {}
+
+func (x *FetchAttestedNodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchAttestedNodeResponse.ProtoReflect.Descriptor instead.
+func (*FetchAttestedNodeResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *FetchAttestedNodeResponse) GetNode() *common.AttestedNode {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+type FetchAttestedNodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpiffeIds     []string               `protobuf:"bytes,1,rep,name=spiffe_ids,json=spiffeIds,proto3" json:"spiffe_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchAttestedNodesRequest) Reset() {
+	*x = FetchAttestedNodesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchAttestedNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchAttestedNodesRequest) ProtoMessage() {}
+
+func (x *FetchAttestedNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchAttestedNodesRequest.ProtoReflect.Descriptor instead.
+func (*FetchAttestedNodesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *FetchAttestedNodesRequest) GetSpiffeIds() []string {
+	if x != nil {
+		return x.SpiffeIds
+	}
+	return nil
+}
+
+type FetchAttestedNodesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Nodes, with selectors, keyed by SPIFFE ID. IDs with no existing node
+	// are omitted.
+	Nodes         map[string]*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchAttestedNodesResponse) Reset() {
+	*x = FetchAttestedNodesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchAttestedNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchAttestedNodesResponse) ProtoMessage() {}
+
+func (x *FetchAttestedNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchAttestedNodesResponse.ProtoReflect.Descriptor instead.
+func (*FetchAttestedNodesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *FetchAttestedNodesResponse) GetNodes() map[string]*common.AttestedNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type ListAttestedNodesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ByAttestationType string                 `protobuf:"bytes,1,opt,name=by_attestation_type,json=byAttestationType,proto3" json:"by_attestation_type,omitempty"`
+	ByBanned          *bool                  `protobuf:"varint,2,opt,name=by_banned,json=byBanned,proto3,oneof" json:"by_banned,omitempty"`
+	ByExpiresBefore   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	BySelectorMatch   *BySelectors           `protobuf:"bytes,4,opt,name=by_selector_match,json=bySelectorMatch,proto3" json:"by_selector_match,omitempty"`
+	BySpiffeIds       []string               `protobuf:"bytes,5,rep,name=by_spiffe_ids,json=bySpiffeIds,proto3" json:"by_spiffe_ids,omitempty"`
+	FetchSelectors    bool                   `protobuf:"varint,6,opt,name=fetch_selectors,json=fetchSelectors,proto3" json:"fetch_selectors,omitempty"`
+	Pagination        *Pagination            `protobuf:"bytes,7,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	ByCanReattest     *bool                  `protobuf:"varint,8,opt,name=by_can_reattest,json=byCanReattest,proto3,oneof" json:"by_can_reattest,omitempty"`
+	ValidAt           *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=valid_at,json=validAt,proto3" json:"valid_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListAttestedNodesRequest) Reset() {
+	*x = ListAttestedNodesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttestedNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttestedNodesRequest) ProtoMessage() {}
+
+func (x *ListAttestedNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttestedNodesRequest.ProtoReflect.Descriptor instead.
+func (*ListAttestedNodesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ListAttestedNodesRequest) GetByAttestationType() string {
+	if x != nil {
+		return x.ByAttestationType
+	}
+	return ""
+}
+
+func (x *ListAttestedNodesRequest) GetByBanned() bool {
+	if x != nil && x.ByBanned != nil {
+		return *x.ByBanned
+	}
+	return false
+}
+
+func (x *ListAttestedNodesRequest) GetByExpiresBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ByExpiresBefore
+	}
+	return nil
+}
+
+func (x *ListAttestedNodesRequest) GetBySelectorMatch() *BySelectors {
+	if x != nil {
+		return x.BySelectorMatch
+	}
+	return nil
+}
+
+func (x *ListAttestedNodesRequest) GetBySpiffeIds() []string {
+	if x != nil {
+		return x.BySpiffeIds
+	}
+	return nil
+}
+
+func (x *ListAttestedNodesRequest) GetFetchSelectors() bool {
+	if x != nil {
+		return x.FetchSelectors
+	}
+	return false
+}
+
+func (x *ListAttestedNodesRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListAttestedNodesRequest) GetByCanReattest() bool {
+	if x != nil && x.ByCanReattest != nil {
+		return *x.ByCanReattest
+	}
+	return false
+}
+
+func (x *ListAttestedNodesRequest) GetValidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidAt
+	}
+	return nil
+}
+
+type ListAttestedNodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttestedNodesResponse) Reset() {
+	*x = ListAttestedNodesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttestedNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttestedNodesResponse) ProtoMessage() {}
+
+func (x *ListAttestedNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttestedNodesResponse.ProtoReflect.Descriptor instead.
+func (*ListAttestedNodesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ListAttestedNodesResponse) GetNodes() []*common.AttestedNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *ListAttestedNodesResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type UpdateAttestedNodeRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Node          *common.AttestedNode     `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Mask          *common.AttestedNodeMask `protobuf:"bytes,2,opt,name=mask,proto3" json:"mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAttestedNodeRequest) Reset() {
+	*x = UpdateAttestedNodeRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAttestedNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAttestedNodeRequest) ProtoMessage() {}
+
+func (x *UpdateAttestedNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAttestedNodeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAttestedNodeRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *UpdateAttestedNodeRequest) GetNode() *common.AttestedNode {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *UpdateAttestedNodeRequest) GetMask() *common.AttestedNodeMask {
+	if x != nil {
+		return x.Mask
+	}
+	return nil
+}
+
+type UpdateAttestedNodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *common.AttestedNode   `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAttestedNodeResponse) Reset() {
+	*x = UpdateAttestedNodeResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAttestedNodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAttestedNodeResponse) ProtoMessage() {}
+
+func (x *UpdateAttestedNodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAttestedNodeResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAttestedNodeResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *UpdateAttestedNodeResponse) GetNode() *common.AttestedNode {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+type PruneAttestedExpiredNodesRequest struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	ExpiredBefore          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=expired_before,json=expiredBefore,proto3" json:"expired_before,omitempty"`
+	IncludeNonReattestable bool                   `protobuf:"varint,2,opt,name=include_non_reattestable,json=includeNonReattestable,proto3" json:"include_non_reattestable,omitempty"`
+	BatchSize              int32                  `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *PruneAttestedExpiredNodesRequest) Reset() {
+	*x = PruneAttestedExpiredNodesRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneAttestedExpiredNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneAttestedExpiredNodesRequest) ProtoMessage() {}
+
+func (x *PruneAttestedExpiredNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneAttestedExpiredNodesRequest.ProtoReflect.Descriptor instead.
+func (*PruneAttestedExpiredNodesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *PruneAttestedExpiredNodesRequest) GetExpiredBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiredBefore
+	}
+	return nil
+}
+
+func (x *PruneAttestedExpiredNodesRequest) GetIncludeNonReattestable() bool {
+	if x != nil {
+		return x.IncludeNonReattestable
+	}
+	return false
+}
+
+func (x *PruneAttestedExpiredNodesRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type PruneAttestedExpiredNodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneAttestedExpiredNodesResponse) Reset() {
+	*x = PruneAttestedExpiredNodesResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneAttestedExpiredNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneAttestedExpiredNodesResponse) ProtoMessage() {}
+
+func (x *PruneAttestedExpiredNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneAttestedExpiredNodesResponse.ProtoReflect.Descriptor instead.
+func (*PruneAttestedExpiredNodesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{85}
+}
+
+type ListAttestedNodeEventsRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DataConsistency    DataConsistency        `protobuf:"varint,1,opt,name=data_consistency,json=dataConsistency,proto3,enum=spire.plugin.server.datastore.v1.DataConsistency" json:"data_consistency,omitempty"`
+	GreaterThanEventId uint64                 `protobuf:"varint,2,opt,name=greater_than_event_id,json=greaterThanEventId,proto3" json:"greater_than_event_id,omitempty"`
+	LessThanEventId    uint64                 `protobuf:"varint,3,opt,name=less_than_event_id,json=lessThanEventId,proto3" json:"less_than_event_id,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListAttestedNodeEventsRequest) Reset() {
+	*x = ListAttestedNodeEventsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttestedNodeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttestedNodeEventsRequest) ProtoMessage() {}
+
+func (x *ListAttestedNodeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttestedNodeEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttestedNodeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ListAttestedNodeEventsRequest) GetDataConsistency() DataConsistency {
+	if x != nil {
+		return x.DataConsistency
+	}
+	return DataConsistency_REQUIRE_CURRENT
+}
+
+func (x *ListAttestedNodeEventsRequest) GetGreaterThanEventId() uint64 {
+	if x != nil {
+		return x.GreaterThanEventId
+	}
+	return 0
+}
+
+func (x *ListAttestedNodeEventsRequest) GetLessThanEventId() uint64 {
+	if x != nil {
+		return x.LessThanEventId
+	}
+	return 0
+}
+
+type ListAttestedNodeEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AttestedNodeEvent   `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttestedNodeEventsResponse) Reset() {
+	*x = ListAttestedNodeEventsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttestedNodeEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttestedNodeEventsResponse) ProtoMessage() {}
+
+func (x *ListAttestedNodeEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttestedNodeEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttestedNodeEventsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ListAttestedNodeEventsResponse) GetEvents() []*AttestedNodeEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type PruneAttestedNodeEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OlderThan     *durationpb.Duration   `protobuf:"bytes,1,opt,name=older_than,json=olderThan,proto3" json:"older_than,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneAttestedNodeEventsRequest) Reset() {
+	*x = PruneAttestedNodeEventsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneAttestedNodeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneAttestedNodeEventsRequest) ProtoMessage() {}
+
+func (x *PruneAttestedNodeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneAttestedNodeEventsRequest.ProtoReflect.Descriptor instead.
+func (*PruneAttestedNodeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *PruneAttestedNodeEventsRequest) GetOlderThan() *durationpb.Duration {
+	if x != nil {
+		return x.OlderThan
+	}
+	return nil
+}
+
+type PruneAttestedNodeEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneAttestedNodeEventsResponse) Reset() {
+	*x = PruneAttestedNodeEventsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneAttestedNodeEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneAttestedNodeEventsResponse) ProtoMessage() {}
+
+func (x *PruneAttestedNodeEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneAttestedNodeEventsResponse.ProtoReflect.Descriptor instead.
+func (*PruneAttestedNodeEventsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{89}
+}
+
+type FetchAttestedNodeEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchAttestedNodeEventRequest) Reset() {
+	*x = FetchAttestedNodeEventRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchAttestedNodeEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchAttestedNodeEventRequest) ProtoMessage() {}
+
+func (x *FetchAttestedNodeEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchAttestedNodeEventRequest.ProtoReflect.Descriptor instead.
+func (*FetchAttestedNodeEventRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *FetchAttestedNodeEventRequest) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type FetchAttestedNodeEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *AttestedNodeEvent     `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchAttestedNodeEventResponse) Reset() {
+	*x = FetchAttestedNodeEventResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchAttestedNodeEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchAttestedNodeEventResponse) ProtoMessage() {}
+
+func (x *FetchAttestedNodeEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchAttestedNodeEventResponse.ProtoReflect.Descriptor instead.
+func (*FetchAttestedNodeEventResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *FetchAttestedNodeEventResponse) GetEvent() *AttestedNodeEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type CreateAttestedNodeEventForTestingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *AttestedNodeEvent     `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAttestedNodeEventForTestingRequest) Reset() {
+	*x = CreateAttestedNodeEventForTestingRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAttestedNodeEventForTestingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAttestedNodeEventForTestingRequest) ProtoMessage() {}
+
+func (x *CreateAttestedNodeEventForTestingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAttestedNodeEventForTestingRequest.ProtoReflect.Descriptor instead.
+func (*CreateAttestedNodeEventForTestingRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *CreateAttestedNodeEventForTestingRequest) GetEvent() *AttestedNodeEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type CreateAttestedNodeEventForTestingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAttestedNodeEventForTestingResponse) Reset() {
+	*x = CreateAttestedNodeEventForTestingResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAttestedNodeEventForTestingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAttestedNodeEventForTestingResponse) ProtoMessage() {}
+
+func (x *CreateAttestedNodeEventForTestingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAttestedNodeEventForTestingResponse.ProtoReflect.Descriptor instead.
+func (*CreateAttestedNodeEventForTestingResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{93}
+}
+
+type DeleteAttestedNodeEventForTestingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttestedNodeEventForTestingRequest) Reset() {
+	*x = DeleteAttestedNodeEventForTestingRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttestedNodeEventForTestingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttestedNodeEventForTestingRequest) ProtoMessage() {}
+
+func (x *DeleteAttestedNodeEventForTestingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttestedNodeEventForTestingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttestedNodeEventForTestingRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *DeleteAttestedNodeEventForTestingRequest) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type DeleteAttestedNodeEventForTestingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttestedNodeEventForTestingResponse) Reset() {
+	*x = DeleteAttestedNodeEventForTestingResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttestedNodeEventForTestingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttestedNodeEventForTestingResponse) ProtoMessage() {}
+
+func (x *DeleteAttestedNodeEventForTestingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttestedNodeEventForTestingResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAttestedNodeEventForTestingResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{95}
+}
+
+type GetNodeSelectorsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SpiffeId        string                 `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	DataConsistency DataConsistency        `protobuf:"varint,2,opt,name=data_consistency,json=dataConsistency,proto3,enum=spire.plugin.server.datastore.v1.DataConsistency" json:"data_consistency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetNodeSelectorsRequest) Reset() {
+	*x = GetNodeSelectorsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNodeSelectorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeSelectorsRequest) ProtoMessage() {}
+
+func (x *GetNodeSelectorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeSelectorsRequest.ProtoReflect.Descriptor instead.
+func (*GetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *GetNodeSelectorsRequest) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+func (x *GetNodeSelectorsRequest) GetDataConsistency() DataConsistency {
+	if x != nil {
+		return x.DataConsistency
+	}
+	return DataConsistency_REQUIRE_CURRENT
+}
+
+type GetNodeSelectorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Selectors     []*common.Selector     `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNodeSelectorsResponse) Reset() {
+	*x = GetNodeSelectorsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNodeSelectorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeSelectorsResponse) ProtoMessage() {}
+
+func (x *GetNodeSelectorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeSelectorsResponse.ProtoReflect.Descriptor instead.
+func (*GetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *GetNodeSelectorsResponse) GetSelectors() []*common.Selector {
+	if x != nil {
+		return x.Selectors
+	}
+	return nil
+}
+
+type ListNodeSelectorsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DataConsistency DataConsistency        `protobuf:"varint,1,opt,name=data_consistency,json=dataConsistency,proto3,enum=spire.plugin.server.datastore.v1.DataConsistency" json:"data_consistency,omitempty"`
+	ValidAt         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=valid_at,json=validAt,proto3" json:"valid_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListNodeSelectorsRequest) Reset() {
+	*x = ListNodeSelectorsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNodeSelectorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNodeSelectorsRequest) ProtoMessage() {}
+
+func (x *ListNodeSelectorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNodeSelectorsRequest.ProtoReflect.Descriptor instead.
+func (*ListNodeSelectorsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *ListNodeSelectorsRequest) GetDataConsistency() DataConsistency {
+	if x != nil {
+		return x.DataConsistency
+	}
+	return DataConsistency_REQUIRE_CURRENT
+}
+
+func (x *ListNodeSelectorsRequest) GetValidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidAt
+	}
+	return nil
+}
+
+type ListNodeSelectorsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Selectors keyed by node SPIFFE ID.
+	Selectors     map[string]*common.Selectors `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNodeSelectorsResponse) Reset() {
+	*x = ListNodeSelectorsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNodeSelectorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNodeSelectorsResponse) ProtoMessage() {}
+
+func (x *ListNodeSelectorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNodeSelectorsResponse.ProtoReflect.Descriptor instead.
+func (*ListNodeSelectorsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *ListNodeSelectorsResponse) GetSelectors() map[string]*common.Selectors {
+	if x != nil {
+		return x.Selectors
+	}
+	return nil
+}
+
+type SetNodeSelectorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpiffeId      string                 `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	Selectors     []*common.Selector     `protobuf:"bytes,2,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNodeSelectorsRequest) Reset() {
+	*x = SetNodeSelectorsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNodeSelectorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNodeSelectorsRequest) ProtoMessage() {}
+
+func (x *SetNodeSelectorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNodeSelectorsRequest.ProtoReflect.Descriptor instead.
+func (*SetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *SetNodeSelectorsRequest) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+func (x *SetNodeSelectorsRequest) GetSelectors() []*common.Selector {
+	if x != nil {
+		return x.Selectors
+	}
+	return nil
+}
+
+type SetNodeSelectorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNodeSelectorsResponse) Reset() {
+	*x = SetNodeSelectorsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNodeSelectorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNodeSelectorsResponse) ProtoMessage() {}
+
+func (x *SetNodeSelectorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNodeSelectorsResponse.ProtoReflect.Descriptor instead.
+func (*SetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{101}
+}
+
+type CreateJoinTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JoinToken     *JoinToken             `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJoinTokenRequest) Reset() {
+	*x = CreateJoinTokenRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJoinTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJoinTokenRequest) ProtoMessage() {}
+
+func (x *CreateJoinTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJoinTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *CreateJoinTokenRequest) GetJoinToken() *JoinToken {
+	if x != nil {
+		return x.JoinToken
+	}
+	return nil
+}
+
+type CreateJoinTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJoinTokenResponse) Reset() {
+	*x = CreateJoinTokenResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJoinTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJoinTokenResponse) ProtoMessage() {}
+
+func (x *CreateJoinTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJoinTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{103}
+}
+
+type DeleteJoinTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJoinTokenRequest) Reset() {
+	*x = DeleteJoinTokenRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJoinTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJoinTokenRequest) ProtoMessage() {}
+
+func (x *DeleteJoinTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJoinTokenRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *DeleteJoinTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type DeleteJoinTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJoinTokenResponse) Reset() {
+	*x = DeleteJoinTokenResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJoinTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJoinTokenResponse) ProtoMessage() {}
+
+func (x *DeleteJoinTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJoinTokenResponse.ProtoReflect.Descriptor instead.
+func (*DeleteJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{105}
+}
+
+type FetchJoinTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchJoinTokenRequest) Reset() {
+	*x = FetchJoinTokenRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchJoinTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchJoinTokenRequest) ProtoMessage() {}
+
+func (x *FetchJoinTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchJoinTokenRequest.ProtoReflect.Descriptor instead.
+func (*FetchJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *FetchJoinTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type FetchJoinTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset if the token does not exist.
+	JoinToken     *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchJoinTokenResponse) Reset() {
+	*x = FetchJoinTokenResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchJoinTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchJoinTokenResponse) ProtoMessage() {}
+
+func (x *FetchJoinTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchJoinTokenResponse.ProtoReflect.Descriptor instead.
+func (*FetchJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *FetchJoinTokenResponse) GetJoinToken() *JoinToken {
+	if x != nil {
+		return x.JoinToken
+	}
+	return nil
+}
+
+type ListJoinTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *Pagination            `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJoinTokensRequest) Reset() {
+	*x = ListJoinTokensRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJoinTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinTokensRequest) ProtoMessage() {}
+
+func (x *ListJoinTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *ListJoinTokensRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListJoinTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JoinTokens    []*JoinToken           `protobuf:"bytes,1,rep,name=join_tokens,json=joinTokens,proto3" json:"join_tokens,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJoinTokensResponse) Reset() {
+	*x = ListJoinTokensResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJoinTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinTokensResponse) ProtoMessage() {}
+
+func (x *ListJoinTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *ListJoinTokensResponse) GetJoinTokens() []*JoinToken {
+	if x != nil {
+		return x.JoinTokens
+	}
+	return nil
+}
+
+func (x *ListJoinTokensResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type PruneJoinTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpiresBefore *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneJoinTokensRequest) Reset() {
+	*x = PruneJoinTokensRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneJoinTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneJoinTokensRequest) ProtoMessage() {}
+
+func (x *PruneJoinTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneJoinTokensRequest.ProtoReflect.Descriptor instead.
+func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *PruneJoinTokensRequest) GetExpiresBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresBefore
+	}
+	return nil
+}
+
+type PruneJoinTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneJoinTokensResponse) Reset() {
+	*x = PruneJoinTokensResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneJoinTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneJoinTokensResponse) ProtoMessage() {}
+
+func (x *PruneJoinTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneJoinTokensResponse.ProtoReflect.Descriptor instead.
+func (*PruneJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{111}
+}
+
+type CreateFederationRelationshipRequest struct {
+	state                  protoimpl.MessageState  `protogen:"open.v1"`
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateFederationRelationshipRequest) Reset() {
+	*x = CreateFederationRelationshipRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFederationRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFederationRelationshipRequest) ProtoMessage() {}
+
+func (x *CreateFederationRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFederationRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*CreateFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *CreateFederationRelationshipRequest) GetFederationRelationship() *FederationRelationship {
+	if x != nil {
+		return x.FederationRelationship
+	}
+	return nil
+}
+
+type CreateFederationRelationshipResponse struct {
+	state                  protoimpl.MessageState  `protogen:"open.v1"`
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateFederationRelationshipResponse) Reset() {
+	*x = CreateFederationRelationshipResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFederationRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFederationRelationshipResponse) ProtoMessage() {}
+
+func (x *CreateFederationRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFederationRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*CreateFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *CreateFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if x != nil {
+		return x.FederationRelationship
+	}
+	return nil
+}
+
+type FetchFederationRelationshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomain   string                 `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain,proto3" json:"trust_domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchFederationRelationshipRequest) Reset() {
+	*x = FetchFederationRelationshipRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchFederationRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchFederationRelationshipRequest) ProtoMessage() {}
+
+func (x *FetchFederationRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchFederationRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*FetchFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *FetchFederationRelationshipRequest) GetTrustDomain() string {
+	if x != nil {
+		return x.TrustDomain
+	}
+	return ""
+}
+
+type FetchFederationRelationshipResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset if the relationship does not exist.
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *FetchFederationRelationshipResponse) Reset() {
+	*x = FetchFederationRelationshipResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchFederationRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchFederationRelationshipResponse) ProtoMessage() {}
+
+func (x *FetchFederationRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchFederationRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*FetchFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *FetchFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if x != nil {
+		return x.FederationRelationship
+	}
+	return nil
+}
+
+type ListFederationRelationshipsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *Pagination            `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFederationRelationshipsRequest) Reset() {
+	*x = ListFederationRelationshipsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFederationRelationshipsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFederationRelationshipsRequest) ProtoMessage() {}
+
+func (x *ListFederationRelationshipsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFederationRelationshipsRequest.ProtoReflect.Descriptor instead.
+func (*ListFederationRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ListFederationRelationshipsRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListFederationRelationshipsResponse struct {
+	state                   protoimpl.MessageState    `protogen:"open.v1"`
+	FederationRelationships []*FederationRelationship `protobuf:"bytes,1,rep,name=federation_relationships,json=federationRelationships,proto3" json:"federation_relationships,omitempty"`
+	Pagination              *Pagination               `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ListFederationRelationshipsResponse) Reset() {
+	*x = ListFederationRelationshipsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFederationRelationshipsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFederationRelationshipsResponse) ProtoMessage() {}
+
+func (x *ListFederationRelationshipsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFederationRelationshipsResponse.ProtoReflect.Descriptor instead.
+func (*ListFederationRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ListFederationRelationshipsResponse) GetFederationRelationships() []*FederationRelationship {
+	if x != nil {
+		return x.FederationRelationships
+	}
+	return nil
+}
+
+func (x *ListFederationRelationshipsResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type DeleteFederationRelationshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustDomain   string                 `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain,proto3" json:"trust_domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFederationRelationshipRequest) Reset() {
+	*x = DeleteFederationRelationshipRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFederationRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFederationRelationshipRequest) ProtoMessage() {}
+
+func (x *DeleteFederationRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFederationRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *DeleteFederationRelationshipRequest) GetTrustDomain() string {
+	if x != nil {
+		return x.TrustDomain
+	}
+	return ""
+}
+
+type DeleteFederationRelationshipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFederationRelationshipResponse) Reset() {
+	*x = DeleteFederationRelationshipResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFederationRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFederationRelationshipResponse) ProtoMessage() {}
+
+func (x *DeleteFederationRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFederationRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{119}
+}
+
+type UpdateFederationRelationshipRequest struct {
+	state                  protoimpl.MessageState      `protogen:"open.v1"`
+	FederationRelationship *FederationRelationship     `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	Mask                   *FederationRelationshipMask `protobuf:"bytes,2,opt,name=mask,proto3" json:"mask,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UpdateFederationRelationshipRequest) Reset() {
+	*x = UpdateFederationRelationshipRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFederationRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFederationRelationshipRequest) ProtoMessage() {}
+
+func (x *UpdateFederationRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFederationRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *UpdateFederationRelationshipRequest) GetFederationRelationship() *FederationRelationship {
+	if x != nil {
+		return x.FederationRelationship
+	}
+	return nil
+}
+
+func (x *UpdateFederationRelationshipRequest) GetMask() *FederationRelationshipMask {
+	if x != nil {
+		return x.Mask
+	}
+	return nil
+}
+
+type UpdateFederationRelationshipResponse struct {
+	state                  protoimpl.MessageState  `protogen:"open.v1"`
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UpdateFederationRelationshipResponse) Reset() {
+	*x = UpdateFederationRelationshipResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFederationRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFederationRelationshipResponse) ProtoMessage() {}
+
+func (x *UpdateFederationRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFederationRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*UpdateFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *UpdateFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if x != nil {
+		return x.FederationRelationship
+	}
+	return nil
+}
+
+type SetCAJournalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaJournal     *CAJournal             `protobuf:"bytes,1,opt,name=ca_journal,json=caJournal,proto3" json:"ca_journal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCAJournalRequest) Reset() {
+	*x = SetCAJournalRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCAJournalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCAJournalRequest) ProtoMessage() {}
+
+func (x *SetCAJournalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCAJournalRequest.ProtoReflect.Descriptor instead.
+func (*SetCAJournalRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *SetCAJournalRequest) GetCaJournal() *CAJournal {
+	if x != nil {
+		return x.CaJournal
+	}
+	return nil
+}
+
+type SetCAJournalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaJournal     *CAJournal             `protobuf:"bytes,1,opt,name=ca_journal,json=caJournal,proto3" json:"ca_journal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCAJournalResponse) Reset() {
+	*x = SetCAJournalResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCAJournalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCAJournalResponse) ProtoMessage() {}
+
+func (x *SetCAJournalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCAJournalResponse.ProtoReflect.Descriptor instead.
+func (*SetCAJournalResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *SetCAJournalResponse) GetCaJournal() *CAJournal {
+	if x != nil {
+		return x.CaJournal
+	}
+	return nil
+}
+
+type FetchCAJournalRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ActiveX509AuthorityId string                 `protobuf:"bytes,1,opt,name=active_x509_authority_id,json=activeX509AuthorityId,proto3" json:"active_x509_authority_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *FetchCAJournalRequest) Reset() {
+	*x = FetchCAJournalRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchCAJournalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchCAJournalRequest) ProtoMessage() {}
+
+func (x *FetchCAJournalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchCAJournalRequest.ProtoReflect.Descriptor instead.
+func (*FetchCAJournalRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *FetchCAJournalRequest) GetActiveX509AuthorityId() string {
+	if x != nil {
+		return x.ActiveX509AuthorityId
+	}
+	return ""
+}
+
+type FetchCAJournalResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset if the journal does not exist.
+	CaJournal     *CAJournal `protobuf:"bytes,1,opt,name=ca_journal,json=caJournal,proto3" json:"ca_journal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchCAJournalResponse) Reset() {
+	*x = FetchCAJournalResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchCAJournalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchCAJournalResponse) ProtoMessage() {}
+
+func (x *FetchCAJournalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchCAJournalResponse.ProtoReflect.Descriptor instead.
+func (*FetchCAJournalResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *FetchCAJournalResponse) GetCaJournal() *CAJournal {
+	if x != nil {
+		return x.CaJournal
+	}
+	return nil
+}
+
+type PruneCAJournalsRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AllCasExpireBefore int64                  `protobuf:"varint,1,opt,name=all_cas_expire_before,json=allCasExpireBefore,proto3" json:"all_cas_expire_before,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PruneCAJournalsRequest) Reset() {
+	*x = PruneCAJournalsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneCAJournalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneCAJournalsRequest) ProtoMessage() {}
+
+func (x *PruneCAJournalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneCAJournalsRequest.ProtoReflect.Descriptor instead.
+func (*PruneCAJournalsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *PruneCAJournalsRequest) GetAllCasExpireBefore() int64 {
+	if x != nil {
+		return x.AllCasExpireBefore
+	}
+	return 0
+}
+
+type PruneCAJournalsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneCAJournalsResponse) Reset() {
+	*x = PruneCAJournalsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneCAJournalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneCAJournalsResponse) ProtoMessage() {}
+
+func (x *PruneCAJournalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneCAJournalsResponse.ProtoReflect.Descriptor instead.
+func (*PruneCAJournalsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{127}
+}
+
+type ListCAJournalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *Pagination            `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCAJournalsRequest) Reset() {
+	*x = ListCAJournalsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCAJournalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCAJournalsRequest) ProtoMessage() {}
+
+func (x *ListCAJournalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCAJournalsRequest.ProtoReflect.Descriptor instead.
+func (*ListCAJournalsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *ListCAJournalsRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListCAJournalsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaJournals    []*CAJournal           `protobuf:"bytes,1,rep,name=ca_journals,json=caJournals,proto3" json:"ca_journals,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCAJournalsResponse) Reset() {
+	*x = ListCAJournalsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCAJournalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCAJournalsResponse) ProtoMessage() {}
+
+func (x *ListCAJournalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCAJournalsResponse.ProtoReflect.Descriptor instead.
+func (*ListCAJournalsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *ListCAJournalsResponse) GetCaJournals() []*CAJournal {
+	if x != nil {
+		return x.CaJournals
+	}
+	return nil
+}
+
+func (x *ListCAJournalsResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListCAJournalsForTestingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCAJournalsForTestingRequest) Reset() {
+	*x = ListCAJournalsForTestingRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCAJournalsForTestingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCAJournalsForTestingRequest) ProtoMessage() {}
+
+func (x *ListCAJournalsForTestingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCAJournalsForTestingRequest.ProtoReflect.Descriptor instead.
+func (*ListCAJournalsForTestingRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{130}
+}
+
+type ListCAJournalsForTestingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaJournals    []*CAJournal           `protobuf:"bytes,1,rep,name=ca_journals,json=caJournals,proto3" json:"ca_journals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCAJournalsForTestingResponse) Reset() {
+	*x = ListCAJournalsForTestingResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCAJournalsForTestingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCAJournalsForTestingResponse) ProtoMessage() {}
+
+func (x *ListCAJournalsForTestingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCAJournalsForTestingResponse.ProtoReflect.Descriptor instead.
+func (*ListCAJournalsForTestingResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *ListCAJournalsForTestingResponse) GetCaJournals() []*CAJournal {
+	if x != nil {
+		return x.CaJournals
+	}
+	return nil
+}
+
+type ExportRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRecordsRequest) Reset() {
+	*x = ExportRecordsRequest{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRecordsRequest) ProtoMessage() {}
+
+func (x *ExportRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ExportRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{132}
+}
+
+type ExportRecordsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Record:
+	//
+	//	*ExportRecordsResponse_Bundle
+	//	*ExportRecordsResponse_FederationRelationship
+	//	*ExportRecordsResponse_RegistrationEntry
+	//	*ExportRecordsResponse_AttestedNode
+	//	*ExportRecordsResponse_JoinToken
+	//	*ExportRecordsResponse_CaJournal
+	Record        isExportRecordsResponse_Record `protobuf_oneof:"record"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRecordsResponse) Reset() {
+	*x = ExportRecordsResponse{}
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRecordsResponse) ProtoMessage() {}
+
+func (x *ExportRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ExportRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *ExportRecordsResponse) GetRecord() isExportRecordsResponse_Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *ExportRecordsResponse) GetBundle() *common.Bundle {
+	if x != nil {
+		if x, ok := x.Record.(*ExportRecordsResponse_Bundle); ok {
+			return x.Bundle
+		}
+	}
+	return nil
+}
+
+func (x *ExportRecordsResponse) GetFederationRelationship() *FederationRelationship {
+	if x != nil {
+		if x, ok := x.Record.(*ExportRecordsResponse_FederationRelationship); ok {
+			return x.FederationRelationship
+		}
+	}
+	return nil
+}
+
+func (x *ExportRecordsResponse) GetRegistrationEntry() *common.RegistrationEntry {
+	if x != nil {
+		if x, ok := x.Record.(*ExportRecordsResponse_RegistrationEntry); ok {
+			return x.RegistrationEntry
+		}
+	}
+	return nil
+}
+
+func (x *ExportRecordsResponse) GetAttestedNode() *common.AttestedNode {
+	if x != nil {
+		if x, ok := x.Record.(*ExportRecordsResponse_AttestedNode); ok {
+			return x.AttestedNode
+		}
+	}
+	return nil
+}
+
+func (x *ExportRecordsResponse) GetJoinToken() *JoinToken {
+	if x != nil {
+		if x, ok := x.Record.(*ExportRecordsResponse_JoinToken); ok {
+			return x.JoinToken
+		}
+	}
+	return nil
+}
+
+func (x *ExportRecordsResponse) GetCaJournal() *CAJournal {
+	if x != nil {
+		if x, ok := x.Record.(*ExportRecordsResponse_CaJournal); ok {
+			return x.CaJournal
+		}
+	}
+	return nil
+}
+
+type isExportRecordsResponse_Record interface {
+	isExportRecordsResponse_Record()
+}
+
+type ExportRecordsResponse_Bundle struct {
+	Bundle *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3,oneof"`
+}
+
+type ExportRecordsResponse_FederationRelationship struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,2,opt,name=federation_relationship,json=federationRelationship,proto3,oneof"`
+}
+
+type ExportRecordsResponse_RegistrationEntry struct {
+	RegistrationEntry *common.RegistrationEntry `protobuf:"bytes,3,opt,name=registration_entry,json=registrationEntry,proto3,oneof"`
+}
+
+type ExportRecordsResponse_AttestedNode struct {
+	AttestedNode *common.AttestedNode `protobuf:"bytes,4,opt,name=attested_node,json=attestedNode,proto3,oneof"`
+}
+
+type ExportRecordsResponse_JoinToken struct {
+	JoinToken *JoinToken `protobuf:"bytes,5,opt,name=join_token,json=joinToken,proto3,oneof"`
+}
+
+type ExportRecordsResponse_CaJournal struct {
+	CaJournal *CAJournal `protobuf:"bytes,6,opt,name=ca_journal,json=caJournal,proto3,oneof"`
+}
+
+func (*ExportRecordsResponse_Bundle) isExportRecordsResponse_Record() {}
+
+func (*ExportRecordsResponse_FederationRelationship) isExportRecordsResponse_Record() {}
+
+func (*ExportRecordsResponse_RegistrationEntry) isExportRecordsResponse_Record() {}
+
+func (*ExportRecordsResponse_AttestedNode) isExportRecordsResponse_Record() {}
+
+func (*ExportRecordsResponse_JoinToken) isExportRecordsResponse_Record() {}
+
+func (*ExportRecordsResponse_CaJournal) isExportRecordsResponse_Record() {}
+
+var File_spire_plugin_server_datastore_v1_datastore_proto protoreflect.FileDescriptor
+
+const file_spire_plugin_server_datastore_v1_datastore_proto_rawDesc = "" +
+	"\n" +
+	"0spire/plugin/server/datastore/v1/datastore.proto\x12 spire.plugin.server.datastore.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19spire/common/common.proto\"?\n" +
+	"\n" +
+	"Pagination\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x8a\x01\n" +
+	"\vBySelectors\x124\n" +
+	"\tselectors\x18\x01 \x03(\v2\x16.spire.common.SelectorR\tselectors\x12E\n" +
+	"\x05match\x18\x02 \x01(\x0e2/.spire.plugin.server.datastore.v1.MatchBehaviorR\x05match\"}\n" +
+	"\x0fByFederatesWith\x12#\n" +
+	"\rtrust_domains\x18\x01 \x03(\tR\ftrustDomains\x12E\n" +
+	"\x05match\x18\x02 \x01(\x0e2/.spire.plugin.server.datastore.v1.MatchBehaviorR\x05match\"U\n" +
+	"\tJoinToken\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x122\n" +
+	"\x06expiry\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06expiry\"N\n" +
+	"\x16RegistrationEntryEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x19\n" +
+	"\bentry_id\x18\x02 \x01(\tR\aentryId\"\xaa\x02\n" +
+	"\x17RegistrationEntryChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x19\n" +
+	"\bentry_id\x18\x02 \x01(\tR\aentryId\x12\x1c\n" +
+	"\toperation\x18\x03 \x01(\tR\toperation\x127\n" +
+	"\x06before\x18\x04 \x01(\v2\x1f.spire.common.RegistrationEntryR\x06before\x125\n" +
+	"\x05after\x18\x05 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05after\x12\x1b\n" +
+	"\tcaller_id\x18\x06 \x01(\tR\bcallerId\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"K\n" +
+	"\x11AttestedNodeEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x1b\n" +
+	"\tspiffe_id\x18\x02 \x01(\tR\bspiffeId\"\x97\x02\n" +
+	"\x16FederationRelationship\x12!\n" +
+	"\ftrust_domain\x18\x01 \x01(\tR\vtrustDomain\x12.\n" +
+	"\x13bundle_endpoint_url\x18\x02 \x01(\tR\x11bundleEndpointUrl\x126\n" +
+	"\x17bundle_endpoint_profile\x18\x03 \x01(\tR\x15bundleEndpointProfile\x12D\n" +
+	"\x13trust_domain_bundle\x18\x04 \x01(\v2\x14.spire.common.BundleR\x11trustDomainBundle\x12,\n" +
+	"\x12endpoint_spiffe_id\x18\x05 \x01(\tR\x10endpointSpiffeId\"\xb4\x01\n" +
+	"\x1aFederationRelationshipMask\x12.\n" +
+	"\x13bundle_endpoint_url\x18\x01 \x01(\bR\x11bundleEndpointUrl\x126\n" +
+	"\x17bundle_endpoint_profile\x18\x02 \x01(\bR\x15bundleEndpointProfile\x12.\n" +
+	"\x13trust_domain_bundle\x18\x03 \x01(\bR\x11trustDomainBundle\"h\n" +
+	"\tCAJournal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x127\n" +
+	"\x18active_x509_authority_id\x18\x03 \x01(\tR\x15activeX509AuthorityId\"C\n" +
+	"\x13AppendBundleRequest\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.spire.common.BundleR\x06bundle\"D\n" +
+	"\x14AppendBundleResponse\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.spire.common.BundleR\x06bundle\"\x15\n" +
+	"\x13CountBundlesRequest\",\n" +
+	"\x14CountBundlesResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"C\n" +
+	"\x13CreateBundleRequest\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.spire.common.BundleR\x06bundle\"D\n" +
+	"\x14CreateBundleResponse\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.spire.common.BundleR\x06bundle\"\x9c\x01\n" +
+	"\x13DeleteBundleRequest\x12&\n" +
+	"\x0ftrust_domain_id\x18\x01 \x01(\tR\rtrustDomainId\x12@\n" +
+	"\x04mode\x18\x02 \x01(\x0e2,.spire.plugin.server.datastore.v1.DeleteModeR\x04mode\x12\x1b\n" +
+	"\tcaller_id\x18\x03 \x01(\tR\bcallerId\"\x16\n" +
+	"\x14DeleteBundleResponse\"<\n" +
+	"\x12FetchBundleRequest\x12&\n" +
+	"\x0ftrust_domain_id\x18\x01 \x01(\tR\rtrustDomainId\"C\n" +
+	"\x13FetchBundleResponse\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.spire.common.BundleR\x06bundle\"b\n" +
+	"\x12ListBundlesRequest\x12L\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"\x93\x01\n" +
+	"\x13ListBundlesResponse\x12.\n" +
+	"\abundles\x18\x01 \x03(\v2\x14.spire.common.BundleR\abundles\x12L\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"\x7f\n" +
+	"\x12PruneBundleRequest\x12&\n" +
+	"\x0ftrust_domain_id\x18\x01 \x01(\tR\rtrustDomainId\x12A\n" +
+	"\x0eexpires_before\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rexpiresBefore\"/\n" +
+	"\x13PruneBundleResponse\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\"@\n" +
+	"\x10SetBundleRequest\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.spire.common.BundleR\x06bundle\"A\n" +
+	"\x11SetBundleResponse\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.spire.common.BundleR\x06bundle\"q\n" +
+	"\x13UpdateBundleRequest\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.spire.common.BundleR\x06bundle\x12,\n" +
+	"\x04mask\x18\x02 \x01(\v2\x18.spire.common.BundleMaskR\x04mask\"D\n" +
+	"\x14UpdateBundleResponse\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.spire.common.BundleR\x06bundle\"b\n" +
+	"\x12TaintX509CARequest\x12&\n" +
+	"\x0ftrust_domain_id\x18\x01 \x01(\tR\rtrustDomainId\x12$\n" +
+	"\x0esubject_key_id\x18\x02 \x01(\tR\fsubjectKeyId\"\x15\n" +
+	"\x13TaintX509CAResponse\"c\n" +
+	"\x13RevokeX509CARequest\x12&\n" +
+	"\x0ftrust_domain_id\x18\x01 \x01(\tR\rtrustDomainId\x12$\n" +
+	"\x0esubject_key_id\x18\x02 \x01(\tR\fsubjectKeyId\"\x16\n" +
+	"\x14RevokeX509CAResponse\"_\n" +
+	"\x12TaintJWTKeyRequest\x12&\n" +
+	"\x0ftrust_domain_id\x18\x01 \x01(\tR\rtrustDomainId\x12!\n" +
+	"\fauthority_id\x18\x02 \x01(\tR\vauthorityId\"M\n" +
+	"\x13TaintJWTKeyResponse\x126\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\v2\x17.spire.common.PublicKeyR\tpublicKey\"`\n" +
+	"\x13RevokeJWTKeyRequest\x12&\n" +
+	"\x0ftrust_domain_id\x18\x01 \x01(\tR\rtrustDomainId\x12!\n" +
+	"\fauthority_id\x18\x02 \x01(\tR\vauthorityId\"N\n" +
+	"\x14RevokeJWTKeyResponse\x126\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\v2\x17.spire.common.PublicKeyR\tpublicKey\"\x88\x01\n" +
+	"\"ActivateRegistrationEntriesRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\"%\n" +
+	"#ActivateRegistrationEntriesResponse\"\xf8\x03\n" +
+	"\x1fCountRegistrationEntriesRequest\x12\\\n" +
+	"\x10data_consistency\x18\x01 \x01(\x0e21.spire.plugin.server.datastore.v1.DataConsistencyR\x0fdataConsistency\x12 \n" +
+	"\fby_parent_id\x18\x02 \x01(\tR\n" +
+	"byParentId\x12P\n" +
+	"\fby_selectors\x18\x03 \x01(\v2-.spire.plugin.server.datastore.v1.BySelectorsR\vbySelectors\x12 \n" +
+	"\fby_spiffe_id\x18\x04 \x01(\tR\n" +
+	"bySpiffeId\x12]\n" +
+	"\x11by_federates_with\x18\x05 \x01(\v21.spire.plugin.server.datastore.v1.ByFederatesWithR\x0fbyFederatesWith\x12\x17\n" +
+	"\aby_hint\x18\x06 \x01(\tR\x06byHint\x12(\n" +
+	"\rby_downstream\x18\a \x01(\bH\x00R\fbyDownstream\x88\x01\x01\x12-\n" +
+	"\x13by_spiffe_id_prefix\x18\b \x01(\tR\x10bySpiffeIdPrefixB\x10\n" +
+	"\x0e_by_downstream\"8\n" +
+	" CountRegistrationEntriesResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"t\n" +
+	"\x1eCreateRegistrationEntryRequest\x125\n" +
+	"\x05entry\x18\x01 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05entry\x12\x1b\n" +
+	"\tcaller_id\x18\x02 \x01(\tR\bcallerId\"X\n" +
+	"\x1fCreateRegistrationEntryResponse\x125\n" +
+	"\x05entry\x18\x01 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05entry\"|\n" +
+	"&CreateOrReturnRegistrationEntryRequest\x125\n" +
+	"\x05entry\x18\x01 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05entry\x12\x1b\n" +
+	"\tcaller_id\x18\x02 \x01(\tR\bcallerId\"|\n" +
+	"'CreateOrReturnRegistrationEntryResponse\x125\n" +
+	"\x05entry\x18\x01 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05entry\x12\x1a\n" +
+	"\bexisting\x18\x02 \x01(\bR\bexisting\"X\n" +
+	"\x1eDeleteRegistrationEntryRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12\x1b\n" +
+	"\tcaller_id\x18\x02 \x01(\tR\bcallerId\"X\n" +
+	"\x1fDeleteRegistrationEntryResponse\x125\n" +
+	"\x05entry\x18\x01 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05entry\":\n" +
+	"\x1dFetchRegistrationEntryRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\"W\n" +
+	"\x1eFetchRegistrationEntryResponse\x125\n" +
+	"\x05entry\x18\x01 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05entry\">\n" +
+	"\x1fFetchRegistrationEntriesRequest\x12\x1b\n" +
+	"\tentry_ids\x18\x01 \x03(\tR\bentryIds\"\xea\x01\n" +
+	" FetchRegistrationEntriesResponse\x12i\n" +
+	"\aentries\x18\x01 \x03(\v2O.spire.plugin.server.datastore.v1.FetchRegistrationEntriesResponse.EntriesEntryR\aentries\x1a[\n" +
+	"\fEntriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x125\n" +
+	"\x05value\x18\x02 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05value:\x028\x01\"\xc5\x04\n" +
+	"\x1eListRegistrationEntriesRequest\x12\\\n" +
+	"\x10data_consistency\x18\x01 \x01(\x0e21.spire.plugin.server.datastore.v1.DataConsistencyR\x0fdataConsistency\x12 \n" +
+	"\fby_parent_id\x18\x02 \x01(\tR\n" +
+	"byParentId\x12P\n" +
+	"\fby_selectors\x18\x03 \x01(\v2-.spire.plugin.server.datastore.v1.BySelectorsR\vbySelectors\x12 \n" +
+	"\fby_spiffe_id\x18\x04 \x01(\tR\n" +
+	"bySpiffeId\x12L\n" +
+	"\n" +
+	"pagination\x18\x05 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\x12]\n" +
+	"\x11by_federates_with\x18\x06 \x01(\v21.spire.plugin.server.datastore.v1.ByFederatesWithR\x0fbyFederatesWith\x12\x17\n" +
+	"\aby_hint\x18\a \x01(\tR\x06byHint\x12(\n" +
+	"\rby_downstream\x18\b \x01(\bH\x00R\fbyDownstream\x88\x01\x01\x12-\n" +
+	"\x13by_spiffe_id_prefix\x18\t \x01(\tR\x10bySpiffeIdPrefixB\x10\n" +
+	"\x0e_by_downstream\"\xaa\x01\n" +
+	"\x1fListRegistrationEntriesResponse\x129\n" +
+	"\aentries\x18\x01 \x03(\v2\x1f.spire.common.RegistrationEntryR\aentries\x12L\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"d\n" +
+	"\x1fPruneRegistrationEntriesRequest\x12A\n" +
+	"\x0eexpires_before\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\rexpiresBefore\"\"\n" +
+	" PruneRegistrationEntriesResponse\"\xad\x01\n" +
+	"\x1eUpdateRegistrationEntryRequest\x125\n" +
+	"\x05entry\x18\x01 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05entry\x127\n" +
+	"\x04mask\x18\x02 \x01(\v2#.spire.common.RegistrationEntryMaskR\x04mask\x12\x1b\n" +
+	"\tcaller_id\x18\x03 \x01(\tR\bcallerId\"X\n" +
+	"\x1fUpdateRegistrationEntryResponse\x125\n" +
+	"\x05entry\x18\x01 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05entry\"\xe2\x01\n" +
+	"\"ListRegistrationEntryEventsRequest\x12\\\n" +
+	"\x10data_consistency\x18\x01 \x01(\x0e21.spire.plugin.server.datastore.v1.DataConsistencyR\x0fdataConsistency\x121\n" +
+	"\x15greater_than_event_id\x18\x02 \x01(\x04R\x12greaterThanEventId\x12+\n" +
+	"\x12less_than_event_id\x18\x03 \x01(\x04R\x0flessThanEventId\"w\n" +
+	"#ListRegistrationEntryEventsResponse\x12P\n" +
+	"\x06events\x18\x01 \x03(\v28.spire.plugin.server.datastore.v1.RegistrationEntryEventR\x06events\"_\n" +
+	"#PruneRegistrationEntryEventsRequest\x128\n" +
+	"\n" +
+	"older_than\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\tolderThan\"&\n" +
+	"$PruneRegistrationEntryEventsResponse\"?\n" +
+	"\"FetchRegistrationEntryEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\"u\n" +
+	"#FetchRegistrationEntryEventResponse\x12N\n" +
+	"\x05event\x18\x01 \x01(\v28.spire.plugin.server.datastore.v1.RegistrationEntryEventR\x05event\"\x7f\n" +
+	"-CreateRegistrationEntryEventForTestingRequest\x12N\n" +
+	"\x05event\x18\x01 \x01(\v28.spire.plugin.server.datastore.v1.RegistrationEntryEventR\x05event\"0\n" +
+	".CreateRegistrationEntryEventForTestingResponse\"J\n" +
+	"-DeleteRegistrationEntryEventForTestingRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\"0\n" +
+	".DeleteRegistrationEntryEventForTestingResponse\"\x93\x01\n" +
+	"#ListRegistrationEntryChangesRequest\x12\x1e\n" +
+	"\vby_entry_id\x18\x01 \x01(\tR\tbyEntryId\x12L\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"\xc9\x01\n" +
+	"$ListRegistrationEntryChangesResponse\x12S\n" +
+	"\achanges\x18\x01 \x03(\v29.spire.plugin.server.datastore.v1.RegistrationEntryChangeR\achanges\x12L\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"`\n" +
+	"$PruneRegistrationEntryChangesRequest\x128\n" +
+	"\n" +
+	"older_than\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\tolderThan\"'\n" +
+	"%PruneRegistrationEntryChangesResponse\"\x88\x03\n" +
+	"\x19CountAttestedNodesRequest\x12.\n" +
+	"\x13by_attestation_type\x18\x01 \x01(\tR\x11byAttestationType\x12 \n" +
+	"\tby_banned\x18\x02 \x01(\bH\x00R\bbyBanned\x88\x01\x01\x12F\n" +
+	"\x11by_expires_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0fbyExpiresBefore\x12Y\n" +
+	"\x11by_selector_match\x18\x04 \x01(\v2-.spire.plugin.server.datastore.v1.BySelectorsR\x0fbySelectorMatch\x12'\n" +
+	"\x0ffetch_selectors\x18\x05 \x01(\bR\x0efetchSelectors\x12+\n" +
+	"\x0fby_can_reattest\x18\x06 \x01(\bH\x01R\rbyCanReattest\x88\x01\x01B\f\n" +
+	"\n" +
+	"_by_bannedB\x12\n" +
+	"\x10_by_can_reattest\"2\n" +
+	"\x1aCountAttestedNodesResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"K\n" +
+	"\x19CreateAttestedNodeRequest\x12.\n" +
+	"\x04node\x18\x01 \x01(\v2\x1a.spire.common.AttestedNodeR\x04node\"L\n" +
+	"\x1aCreateAttestedNodeResponse\x12.\n" +
+	"\x04node\x18\x01 \x01(\v2\x1a.spire.common.AttestedNodeR\x04node\"U\n" +
+	"\x19DeleteAttestedNodeRequest\x12\x1b\n" +
+	"\tspiffe_id\x18\x01 \x01(\tR\bspiffeId\x12\x1b\n" +
+	"\tcaller_id\x18\x02 \x01(\tR\bcallerId\"L\n" +
+	"\x1aDeleteAttestedNodeResponse\x12.\n" +
+	"\x04node\x18\x01 \x01(\v2\x1a.spire.common.AttestedNodeR\x04node\"7\n" +
+	"\x18FetchAttestedNodeRequest\x12\x1b\n" +
+	"\tspiffe_id\x18\x01 \x01(\tR\bspiffeId\"K\n" +
+	"\x19FetchAttestedNodeResponse\x12.\n" +
+	"\x04node\x18\x01 \x01(\v2\x1a.spire.common.AttestedNodeR\x04node\":\n" +
+	"\x19FetchAttestedNodesRequest\x12\x1d\n" +
+	"\n" +
+	"spiffe_ids\x18\x01 \x03(\tR\tspiffeIds\"\xd1\x01\n" +
+	"\x1aFetchAttestedNodesResponse\x12]\n" +
+	"\x05nodes\x18\x01 \x03(\v2G.spire.plugin.server.datastore.v1.FetchAttestedNodesResponse.NodesEntryR\x05nodes\x1aT\n" +
+	"\n" +
+	"NodesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.spire.common.AttestedNodeR\x05value:\x028\x01\"\xb0\x04\n" +
+	"\x18ListAttestedNodesRequest\x12.\n" +
+	"\x13by_attestation_type\x18\x01 \x01(\tR\x11byAttestationType\x12 \n" +
+	"\tby_banned\x18\x02 \x01(\bH\x00R\bbyBanned\x88\x01\x01\x12F\n" +
+	"\x11by_expires_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0fbyExpiresBefore\x12Y\n" +
+	"\x11by_selector_match\x18\x04 \x01(\v2-.spire.plugin.server.datastore.v1.BySelectorsR\x0fbySelectorMatch\x12\"\n" +
+	"\rby_spiffe_ids\x18\x05 \x03(\tR\vbySpiffeIds\x12'\n" +
+	"\x0ffetch_selectors\x18\x06 \x01(\bR\x0efetchSelectors\x12L\n" +
+	"\n" +
+	"pagination\x18\a \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\x12+\n" +
+	"\x0fby_can_reattest\x18\b \x01(\bH\x01R\rbyCanReattest\x88\x01\x01\x125\n" +
+	"\bvalid_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\avalidAtB\f\n" +
+	"\n" +
+	"_by_bannedB\x12\n" +
+	"\x10_by_can_reattest\"\x9b\x01\n" +
+	"\x19ListAttestedNodesResponse\x120\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x1a.spire.common.AttestedNodeR\x05nodes\x12L\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"\x7f\n" +
+	"\x19UpdateAttestedNodeRequest\x12.\n" +
+	"\x04node\x18\x01 \x01(\v2\x1a.spire.common.AttestedNodeR\x04node\x122\n" +
+	"\x04mask\x18\x02 \x01(\v2\x1e.spire.common.AttestedNodeMaskR\x04mask\"L\n" +
+	"\x1aUpdateAttestedNodeResponse\x12.\n" +
+	"\x04node\x18\x01 \x01(\v2\x1a.spire.common.AttestedNodeR\x04node\"\xbe\x01\n" +
+	" PruneAttestedExpiredNodesRequest\x12A\n" +
+	"\x0eexpired_before\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\rexpiredBefore\x128\n" +
+	"\x18include_non_reattestable\x18\x02 \x01(\bR\x16includeNonReattestable\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x03 \x01(\x05R\tbatchSize\"#\n" +
+	"!PruneAttestedExpiredNodesResponse\"\xdd\x01\n" +
+	"\x1dListAttestedNodeEventsRequest\x12\\\n" +
+	"\x10data_consistency\x18\x01 \x01(\x0e21.spire.plugin.server.datastore.v1.DataConsistencyR\x0fdataConsistency\x121\n" +
+	"\x15greater_than_event_id\x18\x02 \x01(\x04R\x12greaterThanEventId\x12+\n" +
+	"\x12less_than_event_id\x18\x03 \x01(\x04R\x0flessThanEventId\"m\n" +
+	"\x1eListAttestedNodeEventsResponse\x12K\n" +
+	"\x06events\x18\x01 \x03(\v23.spire.plugin.server.datastore.v1.AttestedNodeEventR\x06events\"Z\n" +
+	"\x1ePruneAttestedNodeEventsRequest\x128\n" +
+	"\n" +
+	"older_than\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\tolderThan\"!\n" +
+	"\x1fPruneAttestedNodeEventsResponse\":\n" +
+	"\x1dFetchAttestedNodeEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\"k\n" +
+	"\x1eFetchAttestedNodeEventResponse\x12I\n" +
+	"\x05event\x18\x01 \x01(\v23.spire.plugin.server.datastore.v1.AttestedNodeEventR\x05event\"u\n" +
+	"(CreateAttestedNodeEventForTestingRequest\x12I\n" +
+	"\x05event\x18\x01 \x01(\v23.spire.plugin.server.datastore.v1.AttestedNodeEventR\x05event\"+\n" +
+	")CreateAttestedNodeEventForTestingResponse\"E\n" +
+	"(DeleteAttestedNodeEventForTestingRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\"+\n" +
+	")DeleteAttestedNodeEventForTestingResponse\"\x94\x01\n" +
+	"\x17GetNodeSelectorsRequest\x12\x1b\n" +
+	"\tspiffe_id\x18\x01 \x01(\tR\bspiffeId\x12\\\n" +
+	"\x10data_consistency\x18\x02 \x01(\x0e21.spire.plugin.server.datastore.v1.DataConsistencyR\x0fdataConsistency\"P\n" +
+	"\x18GetNodeSelectorsResponse\x124\n" +
+	"\tselectors\x18\x01 \x03(\v2\x16.spire.common.SelectorR\tselectors\"\xaf\x01\n" +
+	"\x18ListNodeSelectorsRequest\x12\\\n" +
+	"\x10data_consistency\x18\x01 \x01(\x0e21.spire.plugin.server.datastore.v1.DataConsistencyR\x0fdataConsistency\x125\n" +
+	"\bvalid_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\avalidAt\"\xdc\x01\n" +
+	"\x19ListNodeSelectorsResponse\x12h\n" +
+	"\tselectors\x18\x01 \x03(\v2J.spire.plugin.server.datastore.v1.ListNodeSelectorsResponse.SelectorsEntryR\tselectors\x1aU\n" +
+	"\x0eSelectorsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.spire.common.SelectorsR\x05value:\x028\x01\"l\n" +
+	"\x17SetNodeSelectorsRequest\x12\x1b\n" +
+	"\tspiffe_id\x18\x01 \x01(\tR\bspiffeId\x124\n" +
+	"\tselectors\x18\x02 \x03(\v2\x16.spire.common.SelectorR\tselectors\"\x1a\n" +
+	"\x18SetNodeSelectorsResponse\"d\n" +
+	"\x16CreateJoinTokenRequest\x12J\n" +
+	"\n" +
+	"join_token\x18\x01 \x01(\v2+.spire.plugin.server.datastore.v1.JoinTokenR\tjoinToken\"\x19\n" +
+	"\x17CreateJoinTokenResponse\".\n" +
+	"\x16DeleteJoinTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x19\n" +
+	"\x17DeleteJoinTokenResponse\"-\n" +
+	"\x15FetchJoinTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"d\n" +
+	"\x16FetchJoinTokenResponse\x12J\n" +
+	"\n" +
+	"join_token\x18\x01 \x01(\v2+.spire.plugin.server.datastore.v1.JoinTokenR\tjoinToken\"e\n" +
+	"\x15ListJoinTokensRequest\x12L\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"\xb4\x01\n" +
+	"\x16ListJoinTokensResponse\x12L\n" +
+	"\vjoin_tokens\x18\x01 \x03(\v2+.spire.plugin.server.datastore.v1.JoinTokenR\n" +
+	"joinTokens\x12L\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"[\n" +
+	"\x16PruneJoinTokensRequest\x12A\n" +
+	"\x0eexpires_before\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\rexpiresBefore\"\x19\n" +
+	"\x17PruneJoinTokensResponse\"\x98\x01\n" +
+	"#CreateFederationRelationshipRequest\x12q\n" +
+	"\x17federation_relationship\x18\x01 \x01(\v28.spire.plugin.server.datastore.v1.FederationRelationshipR\x16federationRelationship\"\x99\x01\n" +
+	"$CreateFederationRelationshipResponse\x12q\n" +
+	"\x17federation_relationship\x18\x01 \x01(\v28.spire.plugin.server.datastore.v1.FederationRelationshipR\x16federationRelationship\"G\n" +
+	"\"FetchFederationRelationshipRequest\x12!\n" +
+	"\ftrust_domain\x18\x01 \x01(\tR\vtrustDomain\"\x98\x01\n" +
+	"#FetchFederationRelationshipResponse\x12q\n" +
+	"\x17federation_relationship\x18\x01 \x01(\v28.spire.plugin.server.datastore.v1.FederationRelationshipR\x16federationRelationship\"r\n" +
+	"\"ListFederationRelationshipsRequest\x12L\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"\xe8\x01\n" +
+	"#ListFederationRelationshipsResponse\x12s\n" +
+	"\x18federation_relationships\x18\x01 \x03(\v28.spire.plugin.server.datastore.v1.FederationRelationshipR\x17federationRelationships\x12L\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"H\n" +
+	"#DeleteFederationRelationshipRequest\x12!\n" +
+	"\ftrust_domain\x18\x01 \x01(\tR\vtrustDomain\"&\n" +
+	"$DeleteFederationRelationshipResponse\"\xea\x01\n" +
+	"#UpdateFederationRelationshipRequest\x12q\n" +
+	"\x17federation_relationship\x18\x01 \x01(\v28.spire.plugin.server.datastore.v1.FederationRelationshipR\x16federationRelationship\x12P\n" +
+	"\x04mask\x18\x02 \x01(\v2<.spire.plugin.server.datastore.v1.FederationRelationshipMaskR\x04mask\"\x99\x01\n" +
+	"$UpdateFederationRelationshipResponse\x12q\n" +
+	"\x17federation_relationship\x18\x01 \x01(\v28.spire.plugin.server.datastore.v1.FederationRelationshipR\x16federationRelationship\"a\n" +
+	"\x13SetCAJournalRequest\x12J\n" +
+	"\n" +
+	"ca_journal\x18\x01 \x01(\v2+.spire.plugin.server.datastore.v1.CAJournalR\tcaJournal\"b\n" +
+	"\x14SetCAJournalResponse\x12J\n" +
+	"\n" +
+	"ca_journal\x18\x01 \x01(\v2+.spire.plugin.server.datastore.v1.CAJournalR\tcaJournal\"P\n" +
+	"\x15FetchCAJournalRequest\x127\n" +
+	"\x18active_x509_authority_id\x18\x01 \x01(\tR\x15activeX509AuthorityId\"d\n" +
+	"\x16FetchCAJournalResponse\x12J\n" +
+	"\n" +
+	"ca_journal\x18\x01 \x01(\v2+.spire.plugin.server.datastore.v1.CAJournalR\tcaJournal\"K\n" +
+	"\x16PruneCAJournalsRequest\x121\n" +
+	"\x15all_cas_expire_before\x18\x01 \x01(\x03R\x12allCasExpireBefore\"\x19\n" +
+	"\x17PruneCAJournalsResponse\"e\n" +
+	"\x15ListCAJournalsRequest\x12L\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"\xb4\x01\n" +
+	"\x16ListCAJournalsResponse\x12L\n" +
+	"\vca_journals\x18\x01 \x03(\v2+.spire.plugin.server.datastore.v1.CAJournalR\n" +
+	"caJournals\x12L\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2,.spire.plugin.server.datastore.v1.PaginationR\n" +
+	"pagination\"!\n" +
+	"\x1fListCAJournalsForTestingRequest\"p\n" +
+	" ListCAJournalsForTestingResponse\x12L\n" +
+	"\vca_journals\x18\x01 \x03(\v2+.spire.plugin.server.datastore.v1.CAJournalR\n" +
+	"caJournals\"\x16\n" +
+	"\x14ExportRecordsRequest\"\xf7\x03\n" +
+	"\x15ExportRecordsResponse\x12.\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.spire.common.BundleH\x00R\x06bundle\x12s\n" +
+	"\x17federation_relationship\x18\x02 \x01(\v28.spire.plugin.server.datastore.v1.FederationRelationshipH\x00R\x16federationRelationship\x12P\n" +
+	"\x12registration_entry\x18\x03 \x01(\v2\x1f.spire.common.RegistrationEntryH\x00R\x11registrationEntry\x12A\n" +
+	"\rattested_node\x18\x04 \x01(\v2\x1a.spire.common.AttestedNodeH\x00R\fattestedNode\x12L\n" +
+	"\n" +
+	"join_token\x18\x05 \x01(\v2+.spire.plugin.server.datastore.v1.JoinTokenH\x00R\tjoinToken\x12L\n" +
+	"\n" +
+	"ca_journal\x18\x06 \x01(\v2+.spire.plugin.server.datastore.v1.CAJournalH\x00R\tcaJournalB\b\n" +
+	"\x06record*:\n" +
+	"\x0fDataConsistency\x12\x13\n" +
+	"\x0fREQUIRE_CURRENT\x10\x00\x12\x12\n" +
+	"\x0eTOLERATE_STALE\x10\x01*6\n" +
+	"\n" +
+	"DeleteMode\x12\f\n" +
+	"\bRESTRICT\x10\x00\x12\n" +
+	"\n" +
+	"\x06DELETE\x10\x01\x12\x0e\n" +
+	"\n" +
+	"DISSOCIATE\x10\x02*U\n" +
+	"\rMatchBehavior\x12\x0f\n" +
+	"\vMATCH_EXACT\x10\x00\x12\x10\n" +
+	"\fMATCH_SUBSET\x10\x01\x12\x12\n" +
+	"\x0eMATCH_SUPERSET\x10\x02\x12\r\n" +
+	"\tMATCH_ANY\x10\x032\xd2I\n" +
+	"\tDataStore\x12}\n" +
+	"\fAppendBundle\x125.spire.plugin.server.datastore.v1.AppendBundleRequest\x1a6.spire.plugin.server.datastore.v1.AppendBundleResponse\x12}\n" +
+	"\fCountBundles\x125.spire.plugin.server.datastore.v1.CountBundlesRequest\x1a6.spire.plugin.server.datastore.v1.CountBundlesResponse\x12}\n" +
+	"\fCreateBundle\x125.spire.plugin.server.datastore.v1.CreateBundleRequest\x1a6.spire.plugin.server.datastore.v1.CreateBundleResponse\x12}\n" +
+	"\fDeleteBundle\x125.spire.plugin.server.datastore.v1.DeleteBundleRequest\x1a6.spire.plugin.server.datastore.v1.DeleteBundleResponse\x12z\n" +
+	"\vFetchBundle\x124.spire.plugin.server.datastore.v1.FetchBundleRequest\x1a5.spire.plugin.server.datastore.v1.FetchBundleResponse\x12z\n" +
+	"\vListBundles\x124.spire.plugin.server.datastore.v1.ListBundlesRequest\x1a5.spire.plugin.server.datastore.v1.ListBundlesResponse\x12z\n" +
+	"\vPruneBundle\x124.spire.plugin.server.datastore.v1.PruneBundleRequest\x1a5.spire.plugin.server.datastore.v1.PruneBundleResponse\x12t\n" +
+	"\tSetBundle\x122.spire.plugin.server.datastore.v1.SetBundleRequest\x1a3.spire.plugin.server.datastore.v1.SetBundleResponse\x12}\n" +
+	"\fUpdateBundle\x125.spire.plugin.server.datastore.v1.UpdateBundleRequest\x1a6.spire.plugin.server.datastore.v1.UpdateBundleResponse\x12z\n" +
+	"\vTaintX509CA\x124.spire.plugin.server.datastore.v1.TaintX509CARequest\x1a5.spire.plugin.server.datastore.v1.TaintX509CAResponse\x12}\n" +
+	"\fRevokeX509CA\x125.spire.plugin.server.datastore.v1.RevokeX509CARequest\x1a6.spire.plugin.server.datastore.v1.RevokeX509CAResponse\x12z\n" +
+	"\vTaintJWTKey\x124.spire.plugin.server.datastore.v1.TaintJWTKeyRequest\x1a5.spire.plugin.server.datastore.v1.TaintJWTKeyResponse\x12}\n" +
+	"\fRevokeJWTKey\x125.spire.plugin.server.datastore.v1.RevokeJWTKeyRequest\x1a6.spire.plugin.server.datastore.v1.RevokeJWTKeyResponse\x12\xaa\x01\n" +
+	"\x1bActivateRegistrationEntries\x12D.spire.plugin.server.datastore.v1.ActivateRegistrationEntriesRequest\x1aE.spire.plugin.server.datastore.v1.ActivateRegistrationEntriesResponse\x12\xa1\x01\n" +
+	"\x18CountRegistrationEntries\x12A.spire.plugin.server.datastore.v1.CountRegistrationEntriesRequest\x1aB.spire.plugin.server.datastore.v1.CountRegistrationEntriesResponse\x12\x9e\x01\n" +
+	"\x17CreateRegistrationEntry\x12@.spire.plugin.server.datastore.v1.CreateRegistrationEntryRequest\x1aA.spire.plugin.server.datastore.v1.CreateRegistrationEntryResponse\x12\xb6\x01\n" +
+	"\x1fCreateOrReturnRegistrationEntry\x12H.spire.plugin.server.datastore.v1.CreateOrReturnRegistrationEntryRequest\x1aI.spire.plugin.server.datastore.v1.CreateOrReturnRegistrationEntryResponse\x12\x9e\x01\n" +
+	"\x17DeleteRegistrationEntry\x12@.spire.plugin.server.datastore.v1.DeleteRegistrationEntryRequest\x1aA.spire.plugin.server.datastore.v1.DeleteRegistrationEntryResponse\x12\x9b\x01\n" +
+	"\x16FetchRegistrationEntry\x12?.spire.plugin.server.datastore.v1.FetchRegistrationEntryRequest\x1a@.spire.plugin.server.datastore.v1.FetchRegistrationEntryResponse\x12\xa1\x01\n" +
+	"\x18FetchRegistrationEntries\x12A.spire.plugin.server.datastore.v1.FetchRegistrationEntriesRequest\x1aB.spire.plugin.server.datastore.v1.FetchRegistrationEntriesResponse\x12\x9e\x01\n" +
+	"\x17ListRegistrationEntries\x12@.spire.plugin.server.datastore.v1.ListRegistrationEntriesRequest\x1aA.spire.plugin.server.datastore.v1.ListRegistrationEntriesResponse\x12\xa1\x01\n" +
+	"\x18PruneRegistrationEntries\x12A.spire.plugin.server.datastore.v1.PruneRegistrationEntriesRequest\x1aB.spire.plugin.server.datastore.v1.PruneRegistrationEntriesResponse\x12\x9e\x01\n" +
+	"\x17UpdateRegistrationEntry\x12@.spire.plugin.server.datastore.v1.UpdateRegistrationEntryRequest\x1aA.spire.plugin.server.datastore.v1.UpdateRegistrationEntryResponse\x12\xaa\x01\n" +
+	"\x1bListRegistrationEntryEvents\x12D.spire.plugin.server.datastore.v1.ListRegistrationEntryEventsRequest\x1aE.spire.plugin.server.datastore.v1.ListRegistrationEntryEventsResponse\x12\xad\x01\n" +
+	"\x1cPruneRegistrationEntryEvents\x12E.spire.plugin.server.datastore.v1.PruneRegistrationEntryEventsRequest\x1aF.spire.plugin.server.datastore.v1.PruneRegistrationEntryEventsResponse\x12\xaa\x01\n" +
+	"\x1bFetchRegistrationEntryEvent\x12D.spire.plugin.server.datastore.v1.FetchRegistrationEntryEventRequest\x1aE.spire.plugin.server.datastore.v1.FetchRegistrationEntryEventResponse\x12\xcb\x01\n" +
+	"&CreateRegistrationEntryEventForTesting\x12O.spire.plugin.server.datastore.v1.CreateRegistrationEntryEventForTestingRequest\x1aP.spire.plugin.server.datastore.v1.CreateRegistrationEntryEventForTestingResponse\x12\xcb\x01\n" +
+	"&DeleteRegistrationEntryEventForTesting\x12O.spire.plugin.server.datastore.v1.DeleteRegistrationEntryEventForTestingRequest\x1aP.spire.plugin.server.datastore.v1.DeleteRegistrationEntryEventForTestingResponse\x12\xad\x01\n" +
+	"\x1cListRegistrationEntryChanges\x12E.spire.plugin.server.datastore.v1.ListRegistrationEntryChangesRequest\x1aF.spire.plugin.server.datastore.v1.ListRegistrationEntryChangesResponse\x12\xb0\x01\n" +
+	"\x1dPruneRegistrationEntryChanges\x12F.spire.plugin.server.datastore.v1.PruneRegistrationEntryChangesRequest\x1aG.spire.plugin.server.datastore.v1.PruneRegistrationEntryChangesResponse\x12\x8f\x01\n" +
+	"\x12CountAttestedNodes\x12;.spire.plugin.server.datastore.v1.CountAttestedNodesRequest\x1a<.spire.plugin.server.datastore.v1.CountAttestedNodesResponse\x12\x8f\x01\n" +
+	"\x12CreateAttestedNode\x12;.spire.plugin.server.datastore.v1.CreateAttestedNodeRequest\x1a<.spire.plugin.server.datastore.v1.CreateAttestedNodeResponse\x12\x8f\x01\n" +
+	"\x12DeleteAttestedNode\x12;.spire.plugin.server.datastore.v1.DeleteAttestedNodeRequest\x1a<.spire.plugin.server.datastore.v1.DeleteAttestedNodeResponse\x12\x8c\x01\n" +
+	"\x11FetchAttestedNode\x12:.spire.plugin.server.datastore.v1.FetchAttestedNodeRequest\x1a;.spire.plugin.server.datastore.v1.FetchAttestedNodeResponse\x12\x8f\x01\n" +
+	"\x12FetchAttestedNodes\x12;.spire.plugin.server.datastore.v1.FetchAttestedNodesRequest\x1a<.spire.plugin.server.datastore.v1.FetchAttestedNodesResponse\x12\x8c\x01\n" +
+	"\x11ListAttestedNodes\x12:.spire.plugin.server.datastore.v1.ListAttestedNodesRequest\x1a;.spire.plugin.server.datastore.v1.ListAttestedNodesResponse\x12\x8f\x01\n" +
+	"\x12UpdateAttestedNode\x12;.spire.plugin.server.datastore.v1.UpdateAttestedNodeRequest\x1a<.spire.plugin.server.datastore.v1.UpdateAttestedNodeResponse\x12\xa4\x01\n" +
+	"\x19PruneAttestedExpiredNodes\x12B.spire.plugin.server.datastore.v1.PruneAttestedExpiredNodesRequest\x1aC.spire.plugin.server.datastore.v1.PruneAttestedExpiredNodesResponse\x12\x9b\x01\n" +
+	"\x16ListAttestedNodeEvents\x12?.spire.plugin.server.datastore.v1.ListAttestedNodeEventsRequest\x1a@.spire.plugin.server.datastore.v1.ListAttestedNodeEventsResponse\x12\x9e\x01\n" +
+	"\x17PruneAttestedNodeEvents\x12@.spire.plugin.server.datastore.v1.PruneAttestedNodeEventsRequest\x1aA.spire.plugin.server.datastore.v1.PruneAttestedNodeEventsResponse\x12\x9b\x01\n" +
+	"\x16FetchAttestedNodeEvent\x12?.spire.plugin.server.datastore.v1.FetchAttestedNodeEventRequest\x1a@.spire.plugin.server.datastore.v1.FetchAttestedNodeEventResponse\x12\xbc\x01\n" +
+	"!CreateAttestedNodeEventForTesting\x12J.spire.plugin.server.datastore.v1.CreateAttestedNodeEventForTestingRequest\x1aK.spire.plugin.server.datastore.v1.CreateAttestedNodeEventForTestingResponse\x12\xbc\x01\n" +
+	"!DeleteAttestedNodeEventForTesting\x12J.spire.plugin.server.datastore.v1.DeleteAttestedNodeEventForTestingRequest\x1aK.spire.plugin.server.datastore.v1.DeleteAttestedNodeEventForTestingResponse\x12\x89\x01\n" +
+	"\x10GetNodeSelectors\x129.spire.plugin.server.datastore.v1.GetNodeSelectorsRequest\x1a:.spire.plugin.server.datastore.v1.GetNodeSelectorsResponse\x12\x8c\x01\n" +
+	"\x11ListNodeSelectors\x12:.spire.plugin.server.datastore.v1.ListNodeSelectorsRequest\x1a;.spire.plugin.server.datastore.v1.ListNodeSelectorsResponse\x12\x89\x01\n" +
+	"\x10SetNodeSelectors\x129.spire.plugin.server.datastore.v1.SetNodeSelectorsRequest\x1a:.spire.plugin.server.datastore.v1.SetNodeSelectorsResponse\x12\x86\x01\n" +
+	"\x0fCreateJoinToken\x128.spire.plugin.server.datastore.v1.CreateJoinTokenRequest\x1a9.spire.plugin.server.datastore.v1.CreateJoinTokenResponse\x12\x86\x01\n" +
+	"\x0fDeleteJoinToken\x128.spire.plugin.server.datastore.v1.DeleteJoinTokenRequest\x1a9.spire.plugin.server.datastore.v1.DeleteJoinTokenResponse\x12\x83\x01\n" +
+	"\x0eFetchJoinToken\x127.spire.plugin.server.datastore.v1.FetchJoinTokenRequest\x1a8.spire.plugin.server.datastore.v1.FetchJoinTokenResponse\x12\x83\x01\n" +
+	"\x0eListJoinTokens\x127.spire.plugin.server.datastore.v1.ListJoinTokensRequest\x1a8.spire.plugin.server.datastore.v1.ListJoinTokensResponse\x12\x86\x01\n" +
+	"\x0fPruneJoinTokens\x128.spire.plugin.server.datastore.v1.PruneJoinTokensRequest\x1a9.spire.plugin.server.datastore.v1.PruneJoinTokensResponse\x12\xad\x01\n" +
+	"\x1cCreateFederationRelationship\x12E.spire.plugin.server.datastore.v1.CreateFederationRelationshipRequest\x1aF.spire.plugin.server.datastore.v1.CreateFederationRelationshipResponse\x12\xaa\x01\n" +
+	"\x1bFetchFederationRelationship\x12D.spire.plugin.server.datastore.v1.FetchFederationRelationshipRequest\x1aE.spire.plugin.server.datastore.v1.FetchFederationRelationshipResponse\x12\xaa\x01\n" +
+	"\x1bListFederationRelationships\x12D.spire.plugin.server.datastore.v1.ListFederationRelationshipsRequest\x1aE.spire.plugin.server.datastore.v1.ListFederationRelationshipsResponse\x12\xad\x01\n" +
+	"\x1cDeleteFederationRelationship\x12E.spire.plugin.server.datastore.v1.DeleteFederationRelationshipRequest\x1aF.spire.plugin.server.datastore.v1.DeleteFederationRelationshipResponse\x12\xad\x01\n" +
+	"\x1cUpdateFederationRelationship\x12E.spire.plugin.server.datastore.v1.UpdateFederationRelationshipRequest\x1aF.spire.plugin.server.datastore.v1.UpdateFederationRelationshipResponse\x12}\n" +
+	"\fSetCAJournal\x125.spire.plugin.server.datastore.v1.SetCAJournalRequest\x1a6.spire.plugin.server.datastore.v1.SetCAJournalResponse\x12\x83\x01\n" +
+	"\x0eFetchCAJournal\x127.spire.plugin.server.datastore.v1.FetchCAJournalRequest\x1a8.spire.plugin.server.datastore.v1.FetchCAJournalResponse\x12\x86\x01\n" +
+	"\x0fPruneCAJournals\x128.spire.plugin.server.datastore.v1.PruneCAJournalsRequest\x1a9.spire.plugin.server.datastore.v1.PruneCAJournalsResponse\x12\x83\x01\n" +
+	"\x0eListCAJournals\x127.spire.plugin.server.datastore.v1.ListCAJournalsRequest\x1a8.spire.plugin.server.datastore.v1.ListCAJournalsResponse\x12\xa1\x01\n" +
+	"\x18ListCAJournalsForTesting\x12A.spire.plugin.server.datastore.v1.ListCAJournalsForTestingRequest\x1aB.spire.plugin.server.datastore.v1.ListCAJournalsForTestingResponse\x12\x82\x01\n" +
+	"\rExportRecords\x126.spire.plugin.server.datastore.v1.ExportRecordsRequest\x1a7.spire.plugin.server.datastore.v1.ExportRecordsResponse0\x01BLZJgithub.com/spiffe/spire/proto/spire/plugin/server/datastore/v1;datastorev1b\x06proto3"
+
+var (
+	file_spire_plugin_server_datastore_v1_datastore_proto_rawDescOnce sync.Once
+	file_spire_plugin_server_datastore_v1_datastore_proto_rawDescData []byte
+)
+
+func file_spire_plugin_server_datastore_v1_datastore_proto_rawDescGZIP() []byte {
+	file_spire_plugin_server_datastore_v1_datastore_proto_rawDescOnce.Do(func() {
+		file_spire_plugin_server_datastore_v1_datastore_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spire_plugin_server_datastore_v1_datastore_proto_rawDesc), len(file_spire_plugin_server_datastore_v1_datastore_proto_rawDesc)))
+	})
+	return file_spire_plugin_server_datastore_v1_datastore_proto_rawDescData
+}
+
+var file_spire_plugin_server_datastore_v1_datastore_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes = make([]protoimpl.MessageInfo, 137)
+var file_spire_plugin_server_datastore_v1_datastore_proto_goTypes = []any{
+	(DataConsistency)(0),                                   // 0: spire.plugin.server.datastore.v1.DataConsistency
+	(DeleteMode)(0),                                        // 1: spire.plugin.server.datastore.v1.DeleteMode
+	(MatchBehavior)(0),                                     // 2: spire.plugin.server.datastore.v1.MatchBehavior
+	(*Pagination)(nil),                                     // 3: spire.plugin.server.datastore.v1.Pagination
+	(*BySelectors)(nil),                                    // 4: spire.plugin.server.datastore.v1.BySelectors
+	(*ByFederatesWith)(nil),                                // 5: spire.plugin.server.datastore.v1.ByFederatesWith
+	(*JoinToken)(nil),                                      // 6: spire.plugin.server.datastore.v1.JoinToken
+	(*RegistrationEntryEvent)(nil),                         // 7: spire.plugin.server.datastore.v1.RegistrationEntryEvent
+	(*RegistrationEntryChange)(nil),                        // 8: spire.plugin.server.datastore.v1.RegistrationEntryChange
+	(*AttestedNodeEvent)(nil),                              // 9: spire.plugin.server.datastore.v1.AttestedNodeEvent
+	(*FederationRelationship)(nil),                         // 10: spire.plugin.server.datastore.v1.FederationRelationship
+	(*FederationRelationshipMask)(nil),                     // 11: spire.plugin.server.datastore.v1.FederationRelationshipMask
+	(*CAJournal)(nil),                                      // 12: spire.plugin.server.datastore.v1.CAJournal
+	(*AppendBundleRequest)(nil),                            // 13: spire.plugin.server.datastore.v1.AppendBundleRequest
+	(*AppendBundleResponse)(nil),                           // 14: spire.plugin.server.datastore.v1.AppendBundleResponse
+	(*CountBundlesRequest)(nil),                            // 15: spire.plugin.server.datastore.v1.CountBundlesRequest
+	(*CountBundlesResponse)(nil),                           // 16: spire.plugin.server.datastore.v1.CountBundlesResponse
+	(*CreateBundleRequest)(nil),                            // 17: spire.plugin.server.datastore.v1.CreateBundleRequest
+	(*CreateBundleResponse)(nil),                           // 18: spire.plugin.server.datastore.v1.CreateBundleResponse
+	(*DeleteBundleRequest)(nil),                            // 19: spire.plugin.server.datastore.v1.DeleteBundleRequest
+	(*DeleteBundleResponse)(nil),                           // 20: spire.plugin.server.datastore.v1.DeleteBundleResponse
+	(*FetchBundleRequest)(nil),                             // 21: spire.plugin.server.datastore.v1.FetchBundleRequest
+	(*FetchBundleResponse)(nil),                            // 22: spire.plugin.server.datastore.v1.FetchBundleResponse
+	(*ListBundlesRequest)(nil),                             // 23: spire.plugin.server.datastore.v1.ListBundlesRequest
+	(*ListBundlesResponse)(nil),                            // 24: spire.plugin.server.datastore.v1.ListBundlesResponse
+	(*PruneBundleRequest)(nil),                             // 25: spire.plugin.server.datastore.v1.PruneBundleRequest
+	(*PruneBundleResponse)(nil),                            // 26: spire.plugin.server.datastore.v1.PruneBundleResponse
+	(*SetBundleRequest)(nil),                               // 27: spire.plugin.server.datastore.v1.SetBundleRequest
+	(*SetBundleResponse)(nil),                              // 28: spire.plugin.server.datastore.v1.SetBundleResponse
+	(*UpdateBundleRequest)(nil),                            // 29: spire.plugin.server.datastore.v1.UpdateBundleRequest
+	(*UpdateBundleResponse)(nil),                           // 30: spire.plugin.server.datastore.v1.UpdateBundleResponse
+	(*TaintX509CARequest)(nil),                             // 31: spire.plugin.server.datastore.v1.TaintX509CARequest
+	(*TaintX509CAResponse)(nil),                            // 32: spire.plugin.server.datastore.v1.TaintX509CAResponse
+	(*RevokeX509CARequest)(nil),                            // 33: spire.plugin.server.datastore.v1.RevokeX509CARequest
+	(*RevokeX509CAResponse)(nil),                           // 34: spire.plugin.server.datastore.v1.RevokeX509CAResponse
+	(*TaintJWTKeyRequest)(nil),                             // 35: spire.plugin.server.datastore.v1.TaintJWTKeyRequest
+	(*TaintJWTKeyResponse)(nil),                            // 36: spire.plugin.server.datastore.v1.TaintJWTKeyResponse
+	(*RevokeJWTKeyRequest)(nil),                            // 37: spire.plugin.server.datastore.v1.RevokeJWTKeyRequest
+	(*RevokeJWTKeyResponse)(nil),                           // 38: spire.plugin.server.datastore.v1.RevokeJWTKeyResponse
+	(*ActivateRegistrationEntriesRequest)(nil),             // 39: spire.plugin.server.datastore.v1.ActivateRegistrationEntriesRequest
+	(*ActivateRegistrationEntriesResponse)(nil),            // 40: spire.plugin.server.datastore.v1.ActivateRegistrationEntriesResponse
+	(*CountRegistrationEntriesRequest)(nil),                // 41: spire.plugin.server.datastore.v1.CountRegistrationEntriesRequest
+	(*CountRegistrationEntriesResponse)(nil),               // 42: spire.plugin.server.datastore.v1.CountRegistrationEntriesResponse
+	(*CreateRegistrationEntryRequest)(nil),                 // 43: spire.plugin.server.datastore.v1.CreateRegistrationEntryRequest
+	(*CreateRegistrationEntryResponse)(nil),                // 44: spire.plugin.server.datastore.v1.CreateRegistrationEntryResponse
+	(*CreateOrReturnRegistrationEntryRequest)(nil),         // 45: spire.plugin.server.datastore.v1.CreateOrReturnRegistrationEntryRequest
+	(*CreateOrReturnRegistrationEntryResponse)(nil),        // 46: spire.plugin.server.datastore.v1.CreateOrReturnRegistrationEntryResponse
+	(*DeleteRegistrationEntryRequest)(nil),                 // 47: spire.plugin.server.datastore.v1.DeleteRegistrationEntryRequest
+	(*DeleteRegistrationEntryResponse)(nil),                // 48: spire.plugin.server.datastore.v1.DeleteRegistrationEntryResponse
+	(*FetchRegistrationEntryRequest)(nil),                  // 49: spire.plugin.server.datastore.v1.FetchRegistrationEntryRequest
+	(*FetchRegistrationEntryResponse)(nil),                 // 50: spire.plugin.server.datastore.v1.FetchRegistrationEntryResponse
+	(*FetchRegistrationEntriesRequest)(nil),                // 51: spire.plugin.server.datastore.v1.FetchRegistrationEntriesRequest
+	(*FetchRegistrationEntriesResponse)(nil),               // 52: spire.plugin.server.datastore.v1.FetchRegistrationEntriesResponse
+	(*ListRegistrationEntriesRequest)(nil),                 // 53: spire.plugin.server.datastore.v1.ListRegistrationEntriesRequest
+	(*ListRegistrationEntriesResponse)(nil),                // 54: spire.plugin.server.datastore.v1.ListRegistrationEntriesResponse
+	(*PruneRegistrationEntriesRequest)(nil),                // 55: spire.plugin.server.datastore.v1.PruneRegistrationEntriesRequest
+	(*PruneRegistrationEntriesResponse)(nil),               // 56: spire.plugin.server.datastore.v1.PruneRegistrationEntriesResponse
+	(*UpdateRegistrationEntryRequest)(nil),                 // 57: spire.plugin.server.datastore.v1.UpdateRegistrationEntryRequest
+	(*UpdateRegistrationEntryResponse)(nil),                // 58: spire.plugin.server.datastore.v1.UpdateRegistrationEntryResponse
+	(*ListRegistrationEntryEventsRequest)(nil),             // 59: spire.plugin.server.datastore.v1.ListRegistrationEntryEventsRequest
+	(*ListRegistrationEntryEventsResponse)(nil),            // 60: spire.plugin.server.datastore.v1.ListRegistrationEntryEventsResponse
+	(*PruneRegistrationEntryEventsRequest)(nil),            // 61: spire.plugin.server.datastore.v1.PruneRegistrationEntryEventsRequest
+	(*PruneRegistrationEntryEventsResponse)(nil),           // 62: spire.plugin.server.datastore.v1.PruneRegistrationEntryEventsResponse
+	(*FetchRegistrationEntryEventRequest)(nil),             // 63: spire.plugin.server.datastore.v1.FetchRegistrationEntryEventRequest
+	(*FetchRegistrationEntryEventResponse)(nil),            // 64: spire.plugin.server.datastore.v1.FetchRegistrationEntryEventResponse
+	(*CreateRegistrationEntryEventForTestingRequest)(nil),  // 65: spire.plugin.server.datastore.v1.CreateRegistrationEntryEventForTestingRequest
+	(*CreateRegistrationEntryEventForTestingResponse)(nil), // 66: spire.plugin.server.datastore.v1.CreateRegistrationEntryEventForTestingResponse
+	(*DeleteRegistrationEntryEventForTestingRequest)(nil),  // 67: spire.plugin.server.datastore.v1.DeleteRegistrationEntryEventForTestingRequest
+	(*DeleteRegistrationEntryEventForTestingResponse)(nil), // 68: spire.plugin.server.datastore.v1.DeleteRegistrationEntryEventForTestingResponse
+	(*ListRegistrationEntryChangesRequest)(nil),            // 69: spire.plugin.server.datastore.v1.ListRegistrationEntryChangesRequest
+	(*ListRegistrationEntryChangesResponse)(nil),           // 70: spire.plugin.server.datastore.v1.ListRegistrationEntryChangesResponse
+	(*PruneRegistrationEntryChangesRequest)(nil),           // 71: spire.plugin.server.datastore.v1.PruneRegistrationEntryChangesRequest
+	(*PruneRegistrationEntryChangesResponse)(nil),          // 72: spire.plugin.server.datastore.v1.PruneRegistrationEntryChangesResponse
+	(*CountAttestedNodesRequest)(nil),                      // 73: spire.plugin.server.datastore.v1.CountAttestedNodesRequest
+	(*CountAttestedNodesResponse)(nil),                     // 74: spire.plugin.server.datastore.v1.CountAttestedNodesResponse
+	(*CreateAttestedNodeRequest)(nil),                      // 75: spire.plugin.server.datastore.v1.CreateAttestedNodeRequest
+	(*CreateAttestedNodeResponse)(nil),                     // 76: spire.plugin.server.datastore.v1.CreateAttestedNodeResponse
+	(*DeleteAttestedNodeRequest)(nil),                      // 77: spire.plugin.server.datastore.v1.DeleteAttestedNodeRequest
+	(*DeleteAttestedNodeResponse)(nil),                     // 78: spire.plugin.server.datastore.v1.DeleteAttestedNodeResponse
+	(*FetchAttestedNodeRequest)(nil),                       // 79: spire.plugin.server.datastore.v1.FetchAttestedNodeRequest
+	(*FetchAttestedNodeResponse)(nil),                      // 80: spire.plugin.server.datastore.v1.FetchAttestedNodeResponse
+	(*FetchAttestedNodesRequest)(nil),                      // 81: spire.plugin.server.datastore.v1.FetchAttestedNodesRequest
+	(*FetchAttestedNodesResponse)(nil),                     // 82: spire.plugin.server.datastore.v1.FetchAttestedNodesResponse
+	(*ListAttestedNodesRequest)(nil),                       // 83: spire.plugin.server.datastore.v1.ListAttestedNodesRequest
+	(*ListAttestedNodesResponse)(nil),                      // 84: spire.plugin.server.datastore.v1.ListAttestedNodesResponse
+	(*UpdateAttestedNodeRequest)(nil),                      // 85: spire.plugin.server.datastore.v1.UpdateAttestedNodeRequest
+	(*UpdateAttestedNodeResponse)(nil),                     // 86: spire.plugin.server.datastore.v1.UpdateAttestedNodeResponse
+	(*PruneAttestedExpiredNodesRequest)(nil),               // 87: spire.plugin.server.datastore.v1.PruneAttestedExpiredNodesRequest
+	(*PruneAttestedExpiredNodesResponse)(nil),              // 88: spire.plugin.server.datastore.v1.PruneAttestedExpiredNodesResponse
+	(*ListAttestedNodeEventsRequest)(nil),                  // 89: spire.plugin.server.datastore.v1.ListAttestedNodeEventsRequest
+	(*ListAttestedNodeEventsResponse)(nil),                 // 90: spire.plugin.server.datastore.v1.ListAttestedNodeEventsResponse
+	(*PruneAttestedNodeEventsRequest)(nil),                 // 91: spire.plugin.server.datastore.v1.PruneAttestedNodeEventsRequest
+	(*PruneAttestedNodeEventsResponse)(nil),                // 92: spire.plugin.server.datastore.v1.PruneAttestedNodeEventsResponse
+	(*FetchAttestedNodeEventRequest)(nil),                  // 93: spire.plugin.server.datastore.v1.FetchAttestedNodeEventRequest
+	(*FetchAttestedNodeEventResponse)(nil),                 // 94: spire.plugin.server.datastore.v1.FetchAttestedNodeEventResponse
+	(*CreateAttestedNodeEventForTestingRequest)(nil),       // 95: spire.plugin.server.datastore.v1.CreateAttestedNodeEventForTestingRequest
+	(*CreateAttestedNodeEventForTestingResponse)(nil),      // 96: spire.plugin.server.datastore.v1.CreateAttestedNodeEventForTestingResponse
+	(*DeleteAttestedNodeEventForTestingRequest)(nil),       // 97: spire.plugin.server.datastore.v1.DeleteAttestedNodeEventForTestingRequest
+	(*DeleteAttestedNodeEventForTestingResponse)(nil),      // 98: spire.plugin.server.datastore.v1.DeleteAttestedNodeEventForTestingResponse
+	(*GetNodeSelectorsRequest)(nil),                        // 99: spire.plugin.server.datastore.v1.GetNodeSelectorsRequest
+	(*GetNodeSelectorsResponse)(nil),                       // 100: spire.plugin.server.datastore.v1.GetNodeSelectorsResponse
+	(*ListNodeSelectorsRequest)(nil),                       // 101: spire.plugin.server.datastore.v1.ListNodeSelectorsRequest
+	(*ListNodeSelectorsResponse)(nil),                      // 102: spire.plugin.server.datastore.v1.ListNodeSelectorsResponse
+	(*SetNodeSelectorsRequest)(nil),                        // 103: spire.plugin.server.datastore.v1.SetNodeSelectorsRequest
+	(*SetNodeSelectorsResponse)(nil),                       // 104: spire.plugin.server.datastore.v1.SetNodeSelectorsResponse
+	(*CreateJoinTokenRequest)(nil),                         // 105: spire.plugin.server.datastore.v1.CreateJoinTokenRequest
+	(*CreateJoinTokenResponse)(nil),                        // 106: spire.plugin.server.datastore.v1.CreateJoinTokenResponse
+	(*DeleteJoinTokenRequest)(nil),                         // 107: spire.plugin.server.datastore.v1.DeleteJoinTokenRequest
+	(*DeleteJoinTokenResponse)(nil),                        // 108: spire.plugin.server.datastore.v1.DeleteJoinTokenResponse
+	(*FetchJoinTokenRequest)(nil),                          // 109: spire.plugin.server.datastore.v1.FetchJoinTokenRequest
+	(*FetchJoinTokenResponse)(nil),                         // 110: spire.plugin.server.datastore.v1.FetchJoinTokenResponse
+	(*ListJoinTokensRequest)(nil),                          // 111: spire.plugin.server.datastore.v1.ListJoinTokensRequest
+	(*ListJoinTokensResponse)(nil),                         // 112: spire.plugin.server.datastore.v1.ListJoinTokensResponse
+	(*PruneJoinTokensRequest)(nil),                         // 113: spire.plugin.server.datastore.v1.PruneJoinTokensRequest
+	(*PruneJoinTokensResponse)(nil),                        // 114: spire.plugin.server.datastore.v1.PruneJoinTokensResponse
+	(*CreateFederationRelationshipRequest)(nil),            // 115: spire.plugin.server.datastore.v1.CreateFederationRelationshipRequest
+	(*CreateFederationRelationshipResponse)(nil),           // 116: spire.plugin.server.datastore.v1.CreateFederationRelationshipResponse
+	(*FetchFederationRelationshipRequest)(nil),             // 117: spire.plugin.server.datastore.v1.FetchFederationRelationshipRequest
+	(*FetchFederationRelationshipResponse)(nil),            // 118: spire.plugin.server.datastore.v1.FetchFederationRelationshipResponse
+	(*ListFederationRelationshipsRequest)(nil),             // 119: spire.plugin.server.datastore.v1.ListFederationRelationshipsRequest
+	(*ListFederationRelationshipsResponse)(nil),            // 120: spire.plugin.server.datastore.v1.ListFederationRelationshipsResponse
+	(*DeleteFederationRelationshipRequest)(nil),            // 121: spire.plugin.server.datastore.v1.DeleteFederationRelationshipRequest
+	(*DeleteFederationRelationshipResponse)(nil),           // 122: spire.plugin.server.datastore.v1.DeleteFederationRelationshipResponse
+	(*UpdateFederationRelationshipRequest)(nil),            // 123: spire.plugin.server.datastore.v1.UpdateFederationRelationshipRequest
+	(*UpdateFederationRelationshipResponse)(nil),           // 124: spire.plugin.server.datastore.v1.UpdateFederationRelationshipResponse
+	(*SetCAJournalRequest)(nil),                            // 125: spire.plugin.server.datastore.v1.SetCAJournalRequest
+	(*SetCAJournalResponse)(nil),                           // 126: spire.plugin.server.datastore.v1.SetCAJournalResponse
+	(*FetchCAJournalRequest)(nil),                          // 127: spire.plugin.server.datastore.v1.FetchCAJournalRequest
+	(*FetchCAJournalResponse)(nil),                         // 128: spire.plugin.server.datastore.v1.FetchCAJournalResponse
+	(*PruneCAJournalsRequest)(nil),                         // 129: spire.plugin.server.datastore.v1.PruneCAJournalsRequest
+	(*PruneCAJournalsResponse)(nil),                        // 130: spire.plugin.server.datastore.v1.PruneCAJournalsResponse
+	(*ListCAJournalsRequest)(nil),                          // 131: spire.plugin.server.datastore.v1.ListCAJournalsRequest
+	(*ListCAJournalsResponse)(nil),                         // 132: spire.plugin.server.datastore.v1.ListCAJournalsResponse
+	(*ListCAJournalsForTestingRequest)(nil),                // 133: spire.plugin.server.datastore.v1.ListCAJournalsForTestingRequest
+	(*ListCAJournalsForTestingResponse)(nil),               // 134: spire.plugin.server.datastore.v1.ListCAJournalsForTestingResponse
+	(*ExportRecordsRequest)(nil),                           // 135: spire.plugin.server.datastore.v1.ExportRecordsRequest
+	(*ExportRecordsResponse)(nil),                          // 136: spire.plugin.server.datastore.v1.ExportRecordsResponse
+	nil,                                                    // 137: spire.plugin.server.datastore.v1.FetchRegistrationEntriesResponse.EntriesEntry
+	nil,                                                    // 138: spire.plugin.server.datastore.v1.FetchAttestedNodesResponse.NodesEntry
+	nil,                                                    // 139: spire.plugin.server.datastore.v1.ListNodeSelectorsResponse.SelectorsEntry
+	(*common.Selector)(nil),                                // 140: spire.common.Selector
+	(*timestamppb.Timestamp)(nil),                          // 141: google.protobuf.Timestamp
+	(*common.RegistrationEntry)(nil),                       // 142: spire.common.RegistrationEntry
+	(*common.Bundle)(nil),                                  // 143: spire.common.Bundle
+	(*common.BundleMask)(nil),                              // 144: spire.common.BundleMask
+	(*common.PublicKey)(nil),                               // 145: spire.common.PublicKey
+	(*common.RegistrationEntryMask)(nil),                   // 146: spire.common.RegistrationEntryMask
+	(*durationpb.Duration)(nil),                            // 147: google.protobuf.Duration
+	(*common.AttestedNode)(nil),                            // 148: spire.common.AttestedNode
+	(*common.AttestedNodeMask)(nil),                        // 149: spire.common.AttestedNodeMask
+	(*common.Selectors)(nil),                               // 150: spire.common.Selectors
+}
+var file_spire_plugin_server_datastore_v1_datastore_proto_depIdxs = []int32{
+	140, // 0: spire.plugin.server.datastore.v1.BySelectors.selectors:type_name -> spire.common.Selector
+	2,   // 1: spire.plugin.server.datastore.v1.BySelectors.match:type_name -> spire.plugin.server.datastore.v1.MatchBehavior
+	2,   // 2: spire.plugin.server.datastore.v1.ByFederatesWith.match:type_name -> spire.plugin.server.datastore.v1.MatchBehavior
+	141, // 3: spire.plugin.server.datastore.v1.JoinToken.expiry:type_name -> google.protobuf.Timestamp
+	142, // 4: spire.plugin.server.datastore.v1.RegistrationEntryChange.before:type_name -> spire.common.RegistrationEntry
+	142, // 5: spire.plugin.server.datastore.v1.RegistrationEntryChange.after:type_name -> spire.common.RegistrationEntry
+	141, // 6: spire.plugin.server.datastore.v1.RegistrationEntryChange.created_at:type_name -> google.protobuf.Timestamp
+	143, // 7: spire.plugin.server.datastore.v1.FederationRelationship.trust_domain_bundle:type_name -> spire.common.Bundle
+	143, // 8: spire.plugin.server.datastore.v1.AppendBundleRequest.bundle:type_name -> spire.common.Bundle
+	143, // 9: spire.plugin.server.datastore.v1.AppendBundleResponse.bundle:type_name -> spire.common.Bundle
+	143, // 10: spire.plugin.server.datastore.v1.CreateBundleRequest.bundle:type_name -> spire.common.Bundle
+	143, // 11: spire.plugin.server.datastore.v1.CreateBundleResponse.bundle:type_name -> spire.common.Bundle
+	1,   // 12: spire.plugin.server.datastore.v1.DeleteBundleRequest.mode:type_name -> spire.plugin.server.datastore.v1.DeleteMode
+	143, // 13: spire.plugin.server.datastore.v1.FetchBundleResponse.bundle:type_name -> spire.common.Bundle
+	3,   // 14: spire.plugin.server.datastore.v1.ListBundlesRequest.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	143, // 15: spire.plugin.server.datastore.v1.ListBundlesResponse.bundles:type_name -> spire.common.Bundle
+	3,   // 16: spire.plugin.server.datastore.v1.ListBundlesResponse.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	141, // 17: spire.plugin.server.datastore.v1.PruneBundleRequest.expires_before:type_name -> google.protobuf.Timestamp
+	143, // 18: spire.plugin.server.datastore.v1.SetBundleRequest.bundle:type_name -> spire.common.Bundle
+	143, // 19: spire.plugin.server.datastore.v1.SetBundleResponse.bundle:type_name -> spire.common.Bundle
+	143, // 20: spire.plugin.server.datastore.v1.UpdateBundleRequest.bundle:type_name -> spire.common.Bundle
+	144, // 21: spire.plugin.server.datastore.v1.UpdateBundleRequest.mask:type_name -> spire.common.BundleMask
+	143, // 22: spire.plugin.server.datastore.v1.UpdateBundleResponse.bundle:type_name -> spire.common.Bundle
+	145, // 23: spire.plugin.server.datastore.v1.TaintJWTKeyResponse.public_key:type_name -> spire.common.PublicKey
+	145, // 24: spire.plugin.server.datastore.v1.RevokeJWTKeyResponse.public_key:type_name -> spire.common.PublicKey
+	141, // 25: spire.plugin.server.datastore.v1.ActivateRegistrationEntriesRequest.since:type_name -> google.protobuf.Timestamp
+	141, // 26: spire.plugin.server.datastore.v1.ActivateRegistrationEntriesRequest.until:type_name -> google.protobuf.Timestamp
+	0,   // 27: spire.plugin.server.datastore.v1.CountRegistrationEntriesRequest.data_consistency:type_name -> spire.plugin.server.datastore.v1.DataConsistency
+	4,   // 28: spire.plugin.server.datastore.v1.CountRegistrationEntriesRequest.by_selectors:type_name -> spire.plugin.server.datastore.v1.BySelectors
+	5,   // 29: spire.plugin.server.datastore.v1.CountRegistrationEntriesRequest.by_federates_with:type_name -> spire.plugin.server.datastore.v1.ByFederatesWith
+	142, // 30: spire.plugin.server.datastore.v1.CreateRegistrationEntryRequest.entry:type_name -> spire.common.RegistrationEntry
+	142, // 31: spire.plugin.server.datastore.v1.CreateRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
+	142, // 32: spire.plugin.server.datastore.v1.CreateOrReturnRegistrationEntryRequest.entry:type_name -> spire.common.RegistrationEntry
+	142, // 33: spire.plugin.server.datastore.v1.CreateOrReturnRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
+	142, // 34: spire.plugin.server.datastore.v1.DeleteRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
+	142, // 35: spire.plugin.server.datastore.v1.FetchRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
+	137, // 36: spire.plugin.server.datastore.v1.FetchRegistrationEntriesResponse.entries:type_name -> spire.plugin.server.datastore.v1.FetchRegistrationEntriesResponse.EntriesEntry
+	0,   // 37: spire.plugin.server.datastore.v1.ListRegistrationEntriesRequest.data_consistency:type_name -> spire.plugin.server.datastore.v1.DataConsistency
+	4,   // 38: spire.plugin.server.datastore.v1.ListRegistrationEntriesRequest.by_selectors:type_name -> spire.plugin.server.datastore.v1.BySelectors
+	3,   // 39: spire.plugin.server.datastore.v1.ListRegistrationEntriesRequest.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	5,   // 40: spire.plugin.server.datastore.v1.ListRegistrationEntriesRequest.by_federates_with:type_name -> spire.plugin.server.datastore.v1.ByFederatesWith
+	142, // 41: spire.plugin.server.datastore.v1.ListRegistrationEntriesResponse.entries:type_name -> spire.common.RegistrationEntry
+	3,   // 42: spire.plugin.server.datastore.v1.ListRegistrationEntriesResponse.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	141, // 43: spire.plugin.server.datastore.v1.PruneRegistrationEntriesRequest.expires_before:type_name -> google.protobuf.Timestamp
+	142, // 44: spire.plugin.server.datastore.v1.UpdateRegistrationEntryRequest.entry:type_name -> spire.common.RegistrationEntry
+	146, // 45: spire.plugin.server.datastore.v1.UpdateRegistrationEntryRequest.mask:type_name -> spire.common.RegistrationEntryMask
+	142, // 46: spire.plugin.server.datastore.v1.UpdateRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
+	0,   // 47: spire.plugin.server.datastore.v1.ListRegistrationEntryEventsRequest.data_consistency:type_name -> spire.plugin.server.datastore.v1.DataConsistency
+	7,   // 48: spire.plugin.server.datastore.v1.ListRegistrationEntryEventsResponse.events:type_name -> spire.plugin.server.datastore.v1.RegistrationEntryEvent
+	147, // 49: spire.plugin.server.datastore.v1.PruneRegistrationEntryEventsRequest.older_than:type_name -> google.protobuf.Duration
+	7,   // 50: spire.plugin.server.datastore.v1.FetchRegistrationEntryEventResponse.event:type_name -> spire.plugin.server.datastore.v1.RegistrationEntryEvent
+	7,   // 51: spire.plugin.server.datastore.v1.CreateRegistrationEntryEventForTestingRequest.event:type_name -> spire.plugin.server.datastore.v1.RegistrationEntryEvent
+	3,   // 52: spire.plugin.server.datastore.v1.ListRegistrationEntryChangesRequest.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	8,   // 53: spire.plugin.server.datastore.v1.ListRegistrationEntryChangesResponse.changes:type_name -> spire.plugin.server.datastore.v1.RegistrationEntryChange
+	3,   // 54: spire.plugin.server.datastore.v1.ListRegistrationEntryChangesResponse.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	147, // 55: spire.plugin.server.datastore.v1.PruneRegistrationEntryChangesRequest.older_than:type_name -> google.protobuf.Duration
+	141, // 56: spire.plugin.server.datastore.v1.CountAttestedNodesRequest.by_expires_before:type_name -> google.protobuf.Timestamp
+	4,   // 57: spire.plugin.server.datastore.v1.CountAttestedNodesRequest.by_selector_match:type_name -> spire.plugin.server.datastore.v1.BySelectors
+	148, // 58: spire.plugin.server.datastore.v1.CreateAttestedNodeRequest.node:type_name -> spire.common.AttestedNode
+	148, // 59: spire.plugin.server.datastore.v1.CreateAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
+	148, // 60: spire.plugin.server.datastore.v1.DeleteAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
+	148, // 61: spire.plugin.server.datastore.v1.FetchAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
+	138, // 62: spire.plugin.server.datastore.v1.FetchAttestedNodesResponse.nodes:type_name -> spire.plugin.server.datastore.v1.FetchAttestedNodesResponse.NodesEntry
+	141, // 63: spire.plugin.server.datastore.v1.ListAttestedNodesRequest.by_expires_before:type_name -> google.protobuf.Timestamp
+	4,   // 64: spire.plugin.server.datastore.v1.ListAttestedNodesRequest.by_selector_match:type_name -> spire.plugin.server.datastore.v1.BySelectors
+	3,   // 65: spire.plugin.server.datastore.v1.ListAttestedNodesRequest.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	141, // 66: spire.plugin.server.datastore.v1.ListAttestedNodesRequest.valid_at:type_name -> google.protobuf.Timestamp
+	148, // 67: spire.plugin.server.datastore.v1.ListAttestedNodesResponse.nodes:type_name -> spire.common.AttestedNode
+	3,   // 68: spire.plugin.server.datastore.v1.ListAttestedNodesResponse.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	148, // 69: spire.plugin.server.datastore.v1.UpdateAttestedNodeRequest.node:type_name -> spire.common.AttestedNode
+	149, // 70: spire.plugin.server.datastore.v1.UpdateAttestedNodeRequest.mask:type_name -> spire.common.AttestedNodeMask
+	148, // 71: spire.plugin.server.datastore.v1.UpdateAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
+	141, // 72: spire.plugin.server.datastore.v1.PruneAttestedExpiredNodesRequest.expired_before:type_name -> google.protobuf.Timestamp
+	0,   // 73: spire.plugin.server.datastore.v1.ListAttestedNodeEventsRequest.data_consistency:type_name -> spire.plugin.server.datastore.v1.DataConsistency
+	9,   // 74: spire.plugin.server.datastore.v1.ListAttestedNodeEventsResponse.events:type_name -> spire.plugin.server.datastore.v1.AttestedNodeEvent
+	147, // 75: spire.plugin.server.datastore.v1.PruneAttestedNodeEventsRequest.older_than:type_name -> google.protobuf.Duration
+	9,   // 76: spire.plugin.server.datastore.v1.FetchAttestedNodeEventResponse.event:type_name -> spire.plugin.server.datastore.v1.AttestedNodeEvent
+	9,   // 77: spire.plugin.server.datastore.v1.CreateAttestedNodeEventForTestingRequest.event:type_name -> spire.plugin.server.datastore.v1.AttestedNodeEvent
+	0,   // 78: spire.plugin.server.datastore.v1.GetNodeSelectorsRequest.data_consistency:type_name -> spire.plugin.server.datastore.v1.DataConsistency
+	140, // 79: spire.plugin.server.datastore.v1.GetNodeSelectorsResponse.selectors:type_name -> spire.common.Selector
+	0,   // 80: spire.plugin.server.datastore.v1.ListNodeSelectorsRequest.data_consistency:type_name -> spire.plugin.server.datastore.v1.DataConsistency
+	141, // 81: spire.plugin.server.datastore.v1.ListNodeSelectorsRequest.valid_at:type_name -> google.protobuf.Timestamp
+	139, // 82: spire.plugin.server.datastore.v1.ListNodeSelectorsResponse.selectors:type_name -> spire.plugin.server.datastore.v1.ListNodeSelectorsResponse.SelectorsEntry
+	140, // 83: spire.plugin.server.datastore.v1.SetNodeSelectorsRequest.selectors:type_name -> spire.common.Selector
+	6,   // 84: spire.plugin.server.datastore.v1.CreateJoinTokenRequest.join_token:type_name -> spire.plugin.server.datastore.v1.JoinToken
+	6,   // 85: spire.plugin.server.datastore.v1.FetchJoinTokenResponse.join_token:type_name -> spire.plugin.server.datastore.v1.JoinToken
+	3,   // 86: spire.plugin.server.datastore.v1.ListJoinTokensRequest.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	6,   // 87: spire.plugin.server.datastore.v1.ListJoinTokensResponse.join_tokens:type_name -> spire.plugin.server.datastore.v1.JoinToken
+	3,   // 88: spire.plugin.server.datastore.v1.ListJoinTokensResponse.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	141, // 89: spire.plugin.server.datastore.v1.PruneJoinTokensRequest.expires_before:type_name -> google.protobuf.Timestamp
+	10,  // 90: spire.plugin.server.datastore.v1.CreateFederationRelationshipRequest.federation_relationship:type_name -> spire.plugin.server.datastore.v1.FederationRelationship
+	10,  // 91: spire.plugin.server.datastore.v1.CreateFederationRelationshipResponse.federation_relationship:type_name -> spire.plugin.server.datastore.v1.FederationRelationship
+	10,  // 92: spire.plugin.server.datastore.v1.FetchFederationRelationshipResponse.federation_relationship:type_name -> spire.plugin.server.datastore.v1.FederationRelationship
+	3,   // 93: spire.plugin.server.datastore.v1.ListFederationRelationshipsRequest.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	10,  // 94: spire.plugin.server.datastore.v1.ListFederationRelationshipsResponse.federation_relationships:type_name -> spire.plugin.server.datastore.v1.FederationRelationship
+	3,   // 95: spire.plugin.server.datastore.v1.ListFederationRelationshipsResponse.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	10,  // 96: spire.plugin.server.datastore.v1.UpdateFederationRelationshipRequest.federation_relationship:type_name -> spire.plugin.server.datastore.v1.FederationRelationship
+	11,  // 97: spire.plugin.server.datastore.v1.UpdateFederationRelationshipRequest.mask:type_name -> spire.plugin.server.datastore.v1.FederationRelationshipMask
+	10,  // 98: spire.plugin.server.datastore.v1.UpdateFederationRelationshipResponse.federation_relationship:type_name -> spire.plugin.server.datastore.v1.FederationRelationship
+	12,  // 99: spire.plugin.server.datastore.v1.SetCAJournalRequest.ca_journal:type_name -> spire.plugin.server.datastore.v1.CAJournal
+	12,  // 100: spire.plugin.server.datastore.v1.SetCAJournalResponse.ca_journal:type_name -> spire.plugin.server.datastore.v1.CAJournal
+	12,  // 101: spire.plugin.server.datastore.v1.FetchCAJournalResponse.ca_journal:type_name -> spire.plugin.server.datastore.v1.CAJournal
+	3,   // 102: spire.plugin.server.datastore.v1.ListCAJournalsRequest.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	12,  // 103: spire.plugin.server.datastore.v1.ListCAJournalsResponse.ca_journals:type_name -> spire.plugin.server.datastore.v1.CAJournal
+	3,   // 104: spire.plugin.server.datastore.v1.ListCAJournalsResponse.pagination:type_name -> spire.plugin.server.datastore.v1.Pagination
+	12,  // 105: spire.plugin.server.datastore.v1.ListCAJournalsForTestingResponse.ca_journals:type_name -> spire.plugin.server.datastore.v1.CAJournal
+	143, // 106: spire.plugin.server.datastore.v1.ExportRecordsResponse.bundle:type_name -> spire.common.Bundle
+	10,  // 107: spire.plugin.server.datastore.v1.ExportRecordsResponse.federation_relationship:type_name -> spire.plugin.server.datastore.v1.FederationRelationship
+	142, // 108: spire.plugin.server.datastore.v1.ExportRecordsResponse.registration_entry:type_name -> spire.common.RegistrationEntry
+	148, // 109: spire.plugin.server.datastore.v1.ExportRecordsResponse.attested_node:type_name -> spire.common.AttestedNode
+	6,   // 110: spire.plugin.server.datastore.v1.ExportRecordsResponse.join_token:type_name -> spire.plugin.server.datastore.v1.JoinToken
+	12,  // 111: spire.plugin.server.datastore.v1.ExportRecordsResponse.ca_journal:type_name -> spire.plugin.server.datastore.v1.CAJournal
+	142, // 112: spire.plugin.server.datastore.v1.FetchRegistrationEntriesResponse.EntriesEntry.value:type_name -> spire.common.RegistrationEntry
+	148, // 113: spire.plugin.server.datastore.v1.FetchAttestedNodesResponse.NodesEntry.value:type_name -> spire.common.AttestedNode
+	150, // 114: spire.plugin.server.datastore.v1.ListNodeSelectorsResponse.SelectorsEntry.value:type_name -> spire.common.Selectors
+	13,  // 115: spire.plugin.server.datastore.v1.DataStore.AppendBundle:input_type -> spire.plugin.server.datastore.v1.AppendBundleRequest
+	15,  // 116: spire.plugin.server.datastore.v1.DataStore.CountBundles:input_type -> spire.plugin.server.datastore.v1.CountBundlesRequest
+	17,  // 117: spire.plugin.server.datastore.v1.DataStore.CreateBundle:input_type -> spire.plugin.server.datastore.v1.CreateBundleRequest
+	19,  // 118: spire.plugin.server.datastore.v1.DataStore.DeleteBundle:input_type -> spire.plugin.server.datastore.v1.DeleteBundleRequest
+	21,  // 119: spire.plugin.server.datastore.v1.DataStore.FetchBundle:input_type -> spire.plugin.server.datastore.v1.FetchBundleRequest
+	23,  // 120: spire.plugin.server.datastore.v1.DataStore.ListBundles:input_type -> spire.plugin.server.datastore.v1.ListBundlesRequest
+	25,  // 121: spire.plugin.server.datastore.v1.DataStore.PruneBundle:input_type -> spire.plugin.server.datastore.v1.PruneBundleRequest
+	27,  // 122: spire.plugin.server.datastore.v1.DataStore.SetBundle:input_type -> spire.plugin.server.datastore.v1.SetBundleRequest
+	29,  // 123: spire.plugin.server.datastore.v1.DataStore.UpdateBundle:input_type -> spire.plugin.server.datastore.v1.UpdateBundleRequest
+	31,  // 124: spire.plugin.server.datastore.v1.DataStore.TaintX509CA:input_type -> spire.plugin.server.datastore.v1.TaintX509CARequest
+	33,  // 125: spire.plugin.server.datastore.v1.DataStore.RevokeX509CA:input_type -> spire.plugin.server.datastore.v1.RevokeX509CARequest
+	35,  // 126: spire.plugin.server.datastore.v1.DataStore.TaintJWTKey:input_type -> spire.plugin.server.datastore.v1.TaintJWTKeyRequest
+	37,  // 127: spire.plugin.server.datastore.v1.DataStore.RevokeJWTKey:input_type -> spire.plugin.server.datastore.v1.RevokeJWTKeyRequest
+	39,  // 128: spire.plugin.server.datastore.v1.DataStore.ActivateRegistrationEntries:input_type -> spire.plugin.server.datastore.v1.ActivateRegistrationEntriesRequest
+	41,  // 129: spire.plugin.server.datastore.v1.DataStore.CountRegistrationEntries:input_type -> spire.plugin.server.datastore.v1.CountRegistrationEntriesRequest
+	43,  // 130: spire.plugin.server.datastore.v1.DataStore.CreateRegistrationEntry:input_type -> spire.plugin.server.datastore.v1.CreateRegistrationEntryRequest
+	45,  // 131: spire.plugin.server.datastore.v1.DataStore.CreateOrReturnRegistrationEntry:input_type -> spire.plugin.server.datastore.v1.CreateOrReturnRegistrationEntryRequest
+	47,  // 132: spire.plugin.server.datastore.v1.DataStore.DeleteRegistrationEntry:input_type -> spire.plugin.server.datastore.v1.DeleteRegistrationEntryRequest
+	49,  // 133: spire.plugin.server.datastore.v1.DataStore.FetchRegistrationEntry:input_type -> spire.plugin.server.datastore.v1.FetchRegistrationEntryRequest
+	51,  // 134: spire.plugin.server.datastore.v1.DataStore.FetchRegistrationEntries:input_type -> spire.plugin.server.datastore.v1.FetchRegistrationEntriesRequest
+	53,  // 135: spire.plugin.server.datastore.v1.DataStore.ListRegistrationEntries:input_type -> spire.plugin.server.datastore.v1.ListRegistrationEntriesRequest
+	55,  // 136: spire.plugin.server.datastore.v1.DataStore.PruneRegistrationEntries:input_type -> spire.plugin.server.datastore.v1.PruneRegistrationEntriesRequest
+	57,  // 137: spire.plugin.server.datastore.v1.DataStore.UpdateRegistrationEntry:input_type -> spire.plugin.server.datastore.v1.UpdateRegistrationEntryRequest
+	59,  // 138: spire.plugin.server.datastore.v1.DataStore.ListRegistrationEntryEvents:input_type -> spire.plugin.server.datastore.v1.ListRegistrationEntryEventsRequest
+	61,  // 139: spire.plugin.server.datastore.v1.DataStore.PruneRegistrationEntryEvents:input_type -> spire.plugin.server.datastore.v1.PruneRegistrationEntryEventsRequest
+	63,  // 140: spire.plugin.server.datastore.v1.DataStore.FetchRegistrationEntryEvent:input_type -> spire.plugin.server.datastore.v1.FetchRegistrationEntryEventRequest
+	65,  // 141: spire.plugin.server.datastore.v1.DataStore.CreateRegistrationEntryEventForTesting:input_type -> spire.plugin.server.datastore.v1.CreateRegistrationEntryEventForTestingRequest
+	67,  // 142: spire.plugin.server.datastore.v1.DataStore.DeleteRegistrationEntryEventForTesting:input_type -> spire.plugin.server.datastore.v1.DeleteRegistrationEntryEventForTestingRequest
+	69,  // 143: spire.plugin.server.datastore.v1.DataStore.ListRegistrationEntryChanges:input_type -> spire.plugin.server.datastore.v1.ListRegistrationEntryChangesRequest
+	71,  // 144: spire.plugin.server.datastore.v1.DataStore.PruneRegistrationEntryChanges:input_type -> spire.plugin.server.datastore.v1.PruneRegistrationEntryChangesRequest
+	73,  // 145: spire.plugin.server.datastore.v1.DataStore.CountAttestedNodes:input_type -> spire.plugin.server.datastore.v1.CountAttestedNodesRequest
+	75,  // 146: spire.plugin.server.datastore.v1.DataStore.CreateAttestedNode:input_type -> spire.plugin.server.datastore.v1.CreateAttestedNodeRequest
+	77,  // 147: spire.plugin.server.datastore.v1.DataStore.DeleteAttestedNode:input_type -> spire.plugin.server.datastore.v1.DeleteAttestedNodeRequest
+	79,  // 148: spire.plugin.server.datastore.v1.DataStore.FetchAttestedNode:input_type -> spire.plugin.server.datastore.v1.FetchAttestedNodeRequest
+	81,  // 149: spire.plugin.server.datastore.v1.DataStore.FetchAttestedNodes:input_type -> spire.plugin.server.datastore.v1.FetchAttestedNodesRequest
+	83,  // 150: spire.plugin.server.datastore.v1.DataStore.ListAttestedNodes:input_type -> spire.plugin.server.datastore.v1.ListAttestedNodesRequest
+	85,  // 151: spire.plugin.server.datastore.v1.DataStore.UpdateAttestedNode:input_type -> spire.plugin.server.datastore.v1.UpdateAttestedNodeRequest
+	87,  // 152: spire.plugin.server.datastore.v1.DataStore.PruneAttestedExpiredNodes:input_type -> spire.plugin.server.datastore.v1.PruneAttestedExpiredNodesRequest
+	89,  // 153: spire.plugin.server.datastore.v1.DataStore.ListAttestedNodeEvents:input_type -> spire.plugin.server.datastore.v1.ListAttestedNodeEventsRequest
+	91,  // 154: spire.plugin.server.datastore.v1.DataStore.PruneAttestedNodeEvents:input_type -> spire.plugin.server.datastore.v1.PruneAttestedNodeEventsRequest
+	93,  // 155: spire.plugin.server.datastore.v1.DataStore.FetchAttestedNodeEvent:input_type -> spire.plugin.server.datastore.v1.FetchAttestedNodeEventRequest
+	95,  // 156: spire.plugin.server.datastore.v1.DataStore.CreateAttestedNodeEventForTesting:input_type -> spire.plugin.server.datastore.v1.CreateAttestedNodeEventForTestingRequest
+	97,  // 157: spire.plugin.server.datastore.v1.DataStore.DeleteAttestedNodeEventForTesting:input_type -> spire.plugin.server.datastore.v1.DeleteAttestedNodeEventForTestingRequest
+	99,  // 158: spire.plugin.server.datastore.v1.DataStore.GetNodeSelectors:input_type -> spire.plugin.server.datastore.v1.GetNodeSelectorsRequest
+	101, // 159: spire.plugin.server.datastore.v1.DataStore.ListNodeSelectors:input_type -> spire.plugin.server.datastore.v1.ListNodeSelectorsRequest
+	103, // 160: spire.plugin.server.datastore.v1.DataStore.SetNodeSelectors:input_type -> spire.plugin.server.datastore.v1.SetNodeSelectorsRequest
+	105, // 161: spire.plugin.server.datastore.v1.DataStore.CreateJoinToken:input_type -> spire.plugin.server.datastore.v1.CreateJoinTokenRequest
+	107, // 162: spire.plugin.server.datastore.v1.DataStore.DeleteJoinToken:input_type -> spire.plugin.server.datastore.v1.DeleteJoinTokenRequest
+	109, // 163: spire.plugin.server.datastore.v1.DataStore.FetchJoinToken:input_type -> spire.plugin.server.datastore.v1.FetchJoinTokenRequest
+	111, // 164: spire.plugin.server.datastore.v1.DataStore.ListJoinTokens:input_type -> spire.plugin.server.datastore.v1.ListJoinTokensRequest
+	113, // 165: spire.plugin.server.datastore.v1.DataStore.PruneJoinTokens:input_type -> spire.plugin.server.datastore.v1.PruneJoinTokensRequest
+	115, // 166: spire.plugin.server.datastore.v1.DataStore.CreateFederationRelationship:input_type -> spire.plugin.server.datastore.v1.CreateFederationRelationshipRequest
+	117, // 167: spire.plugin.server.datastore.v1.DataStore.FetchFederationRelationship:input_type -> spire.plugin.server.datastore.v1.FetchFederationRelationshipRequest
+	119, // 168: spire.plugin.server.datastore.v1.DataStore.ListFederationRelationships:input_type -> spire.plugin.server.datastore.v1.ListFederationRelationshipsRequest
+	121, // 169: spire.plugin.server.datastore.v1.DataStore.DeleteFederationRelationship:input_type -> spire.plugin.server.datastore.v1.DeleteFederationRelationshipRequest
+	123, // 170: spire.plugin.server.datastore.v1.DataStore.UpdateFederationRelationship:input_type -> spire.plugin.server.datastore.v1.UpdateFederationRelationshipRequest
+	125, // 171: spire.plugin.server.datastore.v1.DataStore.SetCAJournal:input_type -> spire.plugin.server.datastore.v1.SetCAJournalRequest
+	127, // 172: spire.plugin.server.datastore.v1.DataStore.FetchCAJournal:input_type -> spire.plugin.server.datastore.v1.FetchCAJournalRequest
+	129, // 173: spire.plugin.server.datastore.v1.DataStore.PruneCAJournals:input_type -> spire.plugin.server.datastore.v1.PruneCAJournalsRequest
+	131, // 174: spire.plugin.server.datastore.v1.DataStore.ListCAJournals:input_type -> spire.plugin.server.datastore.v1.ListCAJournalsRequest
+	133, // 175: spire.plugin.server.datastore.v1.DataStore.ListCAJournalsForTesting:input_type -> spire.plugin.server.datastore.v1.ListCAJournalsForTestingRequest
+	135, // 176: spire.plugin.server.datastore.v1.DataStore.ExportRecords:input_type -> spire.plugin.server.datastore.v1.ExportRecordsRequest
+	14,  // 177: spire.plugin.server.datastore.v1.DataStore.AppendBundle:output_type -> spire.plugin.server.datastore.v1.AppendBundleResponse
+	16,  // 178: spire.plugin.server.datastore.v1.DataStore.CountBundles:output_type -> spire.plugin.server.datastore.v1.CountBundlesResponse
+	18,  // 179: spire.plugin.server.datastore.v1.DataStore.CreateBundle:output_type -> spire.plugin.server.datastore.v1.CreateBundleResponse
+	20,  // 180: spire.plugin.server.datastore.v1.DataStore.DeleteBundle:output_type -> spire.plugin.server.datastore.v1.DeleteBundleResponse
+	22,  // 181: spire.plugin.server.datastore.v1.DataStore.FetchBundle:output_type -> spire.plugin.server.datastore.v1.FetchBundleResponse
+	24,  // 182: spire.plugin.server.datastore.v1.DataStore.ListBundles:output_type -> spire.plugin.server.datastore.v1.ListBundlesResponse
+	26,  // 183: spire.plugin.server.datastore.v1.DataStore.PruneBundle:output_type -> spire.plugin.server.datastore.v1.PruneBundleResponse
+	28,  // 184: spire.plugin.server.datastore.v1.DataStore.SetBundle:output_type -> spire.plugin.server.datastore.v1.SetBundleResponse
+	30,  // 185: spire.plugin.server.datastore.v1.DataStore.UpdateBundle:output_type -> spire.plugin.server.datastore.v1.UpdateBundleResponse
+	32,  // 186: spire.plugin.server.datastore.v1.DataStore.TaintX509CA:output_type -> spire.plugin.server.datastore.v1.TaintX509CAResponse
+	34,  // 187: spire.plugin.server.datastore.v1.DataStore.RevokeX509CA:output_type -> spire.plugin.server.datastore.v1.RevokeX509CAResponse
+	36,  // 188: spire.plugin.server.datastore.v1.DataStore.TaintJWTKey:output_type -> spire.plugin.server.datastore.v1.TaintJWTKeyResponse
+	38,  // 189: spire.plugin.server.datastore.v1.DataStore.RevokeJWTKey:output_type -> spire.plugin.server.datastore.v1.RevokeJWTKeyResponse
+	40,  // 190: spire.plugin.server.datastore.v1.DataStore.ActivateRegistrationEntries:output_type -> spire.plugin.server.datastore.v1.ActivateRegistrationEntriesResponse
+	42,  // 191: spire.plugin.server.datastore.v1.DataStore.CountRegistrationEntries:output_type -> spire.plugin.server.datastore.v1.CountRegistrationEntriesResponse
+	44,  // 192: spire.plugin.server.datastore.v1.DataStore.CreateRegistrationEntry:output_type -> spire.plugin.server.datastore.v1.CreateRegistrationEntryResponse
+	46,  // 193: spire.plugin.server.datastore.v1.DataStore.CreateOrReturnRegistrationEntry:output_type -> spire.plugin.server.datastore.v1.CreateOrReturnRegistrationEntryResponse
+	48,  // 194: spire.plugin.server.datastore.v1.DataStore.DeleteRegistrationEntry:output_type -> spire.plugin.server.datastore.v1.DeleteRegistrationEntryResponse
+	50,  // 195: spire.plugin.server.datastore.v1.DataStore.FetchRegistrationEntry:output_type -> spire.plugin.server.datastore.v1.FetchRegistrationEntryResponse
+	52,  // 196: spire.plugin.server.datastore.v1.DataStore.FetchRegistrationEntries:output_type -> spire.plugin.server.datastore.v1.FetchRegistrationEntriesResponse
+	54,  // 197: spire.plugin.server.datastore.v1.DataStore.ListRegistrationEntries:output_type -> spire.plugin.server.datastore.v1.ListRegistrationEntriesResponse
+	56,  // 198: spire.plugin.server.datastore.v1.DataStore.PruneRegistrationEntries:output_type -> spire.plugin.server.datastore.v1.PruneRegistrationEntriesResponse
+	58,  // 199: spire.plugin.server.datastore.v1.DataStore.UpdateRegistrationEntry:output_type -> spire.plugin.server.datastore.v1.UpdateRegistrationEntryResponse
+	60,  // 200: spire.plugin.server.datastore.v1.DataStore.ListRegistrationEntryEvents:output_type -> spire.plugin.server.datastore.v1.ListRegistrationEntryEventsResponse
+	62,  // 201: spire.plugin.server.datastore.v1.DataStore.PruneRegistrationEntryEvents:output_type -> spire.plugin.server.datastore.v1.PruneRegistrationEntryEventsResponse
+	64,  // 202: spire.plugin.server.datastore.v1.DataStore.FetchRegistrationEntryEvent:output_type -> spire.plugin.server.datastore.v1.FetchRegistrationEntryEventResponse
+	66,  // 203: spire.plugin.server.datastore.v1.DataStore.CreateRegistrationEntryEventForTesting:output_type -> spire.plugin.server.datastore.v1.CreateRegistrationEntryEventForTestingResponse
+	68,  // 204: spire.plugin.server.datastore.v1.DataStore.DeleteRegistrationEntryEventForTesting:output_type -> spire.plugin.server.datastore.v1.DeleteRegistrationEntryEventForTestingResponse
+	70,  // 205: spire.plugin.server.datastore.v1.DataStore.ListRegistrationEntryChanges:output_type -> spire.plugin.server.datastore.v1.ListRegistrationEntryChangesResponse
+	72,  // 206: spire.plugin.server.datastore.v1.DataStore.PruneRegistrationEntryChanges:output_type -> spire.plugin.server.datastore.v1.PruneRegistrationEntryChangesResponse
+	74,  // 207: spire.plugin.server.datastore.v1.DataStore.CountAttestedNodes:output_type -> spire.plugin.server.datastore.v1.CountAttestedNodesResponse
+	76,  // 208: spire.plugin.server.datastore.v1.DataStore.CreateAttestedNode:output_type -> spire.plugin.server.datastore.v1.CreateAttestedNodeResponse
+	78,  // 209: spire.plugin.server.datastore.v1.DataStore.DeleteAttestedNode:output_type -> spire.plugin.server.datastore.v1.DeleteAttestedNodeResponse
+	80,  // 210: spire.plugin.server.datastore.v1.DataStore.FetchAttestedNode:output_type -> spire.plugin.server.datastore.v1.FetchAttestedNodeResponse
+	82,  // 211: spire.plugin.server.datastore.v1.DataStore.FetchAttestedNodes:output_type -> spire.plugin.server.datastore.v1.FetchAttestedNodesResponse
+	84,  // 212: spire.plugin.server.datastore.v1.DataStore.ListAttestedNodes:output_type -> spire.plugin.server.datastore.v1.ListAttestedNodesResponse
+	86,  // 213: spire.plugin.server.datastore.v1.DataStore.UpdateAttestedNode:output_type -> spire.plugin.server.datastore.v1.UpdateAttestedNodeResponse
+	88,  // 214: spire.plugin.server.datastore.v1.DataStore.PruneAttestedExpiredNodes:output_type -> spire.plugin.server.datastore.v1.PruneAttestedExpiredNodesResponse
+	90,  // 215: spire.plugin.server.datastore.v1.DataStore.ListAttestedNodeEvents:output_type -> spire.plugin.server.datastore.v1.ListAttestedNodeEventsResponse
+	92,  // 216: spire.plugin.server.datastore.v1.DataStore.PruneAttestedNodeEvents:output_type -> spire.plugin.server.datastore.v1.PruneAttestedNodeEventsResponse
+	94,  // 217: spire.plugin.server.datastore.v1.DataStore.FetchAttestedNodeEvent:output_type -> spire.plugin.server.datastore.v1.FetchAttestedNodeEventResponse
+	96,  // 218: spire.plugin.server.datastore.v1.DataStore.CreateAttestedNodeEventForTesting:output_type -> spire.plugin.server.datastore.v1.CreateAttestedNodeEventForTestingResponse
+	98,  // 219: spire.plugin.server.datastore.v1.DataStore.DeleteAttestedNodeEventForTesting:output_type -> spire.plugin.server.datastore.v1.DeleteAttestedNodeEventForTestingResponse
+	100, // 220: spire.plugin.server.datastore.v1.DataStore.GetNodeSelectors:output_type -> spire.plugin.server.datastore.v1.GetNodeSelectorsResponse
+	102, // 221: spire.plugin.server.datastore.v1.DataStore.ListNodeSelectors:output_type -> spire.plugin.server.datastore.v1.ListNodeSelectorsResponse
+	104, // 222: spire.plugin.server.datastore.v1.DataStore.SetNodeSelectors:output_type -> spire.plugin.server.datastore.v1.SetNodeSelectorsResponse
+	106, // 223: spire.plugin.server.datastore.v1.DataStore.CreateJoinToken:output_type -> spire.plugin.server.datastore.v1.CreateJoinTokenResponse
+	108, // 224: spire.plugin.server.datastore.v1.DataStore.DeleteJoinToken:output_type -> spire.plugin.server.datastore.v1.DeleteJoinTokenResponse
+	110, // 225: spire.plugin.server.datastore.v1.DataStore.FetchJoinToken:output_type -> spire.plugin.server.datastore.v1.FetchJoinTokenResponse
+	112, // 226: spire.plugin.server.datastore.v1.DataStore.ListJoinTokens:output_type -> spire.plugin.server.datastore.v1.ListJoinTokensResponse
+	114, // 227: spire.plugin.server.datastore.v1.DataStore.PruneJoinTokens:output_type -> spire.plugin.server.datastore.v1.PruneJoinTokensResponse
+	116, // 228: spire.plugin.server.datastore.v1.DataStore.CreateFederationRelationship:output_type -> spire.plugin.server.datastore.v1.CreateFederationRelationshipResponse
+	118, // 229: spire.plugin.server.datastore.v1.DataStore.FetchFederationRelationship:output_type -> spire.plugin.server.datastore.v1.FetchFederationRelationshipResponse
+	120, // 230: spire.plugin.server.datastore.v1.DataStore.ListFederationRelationships:output_type -> spire.plugin.server.datastore.v1.ListFederationRelationshipsResponse
+	122, // 231: spire.plugin.server.datastore.v1.DataStore.DeleteFederationRelationship:output_type -> spire.plugin.server.datastore.v1.DeleteFederationRelationshipResponse
+	124, // 232: spire.plugin.server.datastore.v1.DataStore.UpdateFederationRelationship:output_type -> spire.plugin.server.datastore.v1.UpdateFederationRelationshipResponse
+	126, // 233: spire.plugin.server.datastore.v1.DataStore.SetCAJournal:output_type -> spire.plugin.server.datastore.v1.SetCAJournalResponse
+	128, // 234: spire.plugin.server.datastore.v1.DataStore.FetchCAJournal:output_type -> spire.plugin.server.datastore.v1.FetchCAJournalResponse
+	130, // 235: spire.plugin.server.datastore.v1.DataStore.PruneCAJournals:output_type -> spire.plugin.server.datastore.v1.PruneCAJournalsResponse
+	132, // 236: spire.plugin.server.datastore.v1.DataStore.ListCAJournals:output_type -> spire.plugin.server.datastore.v1.ListCAJournalsResponse
+	134, // 237: spire.plugin.server.datastore.v1.DataStore.ListCAJournalsForTesting:output_type -> spire.plugin.server.datastore.v1.ListCAJournalsForTestingResponse
+	136, // 238: spire.plugin.server.datastore.v1.DataStore.ExportRecords:output_type -> spire.plugin.server.datastore.v1.ExportRecordsResponse
+	177, // [177:239] is the sub-list for method output_type
+	115, // [115:177] is the sub-list for method input_type
+	115, // [115:115] is the sub-list for extension type_name
+	115, // [115:115] is the sub-list for extension extendee
+	0,   // [0:115] is the sub-list for field type_name
+}
+
+func init() { file_spire_plugin_server_datastore_v1_datastore_proto_init() }
+func file_spire_plugin_server_datastore_v1_datastore_proto_init() {
+	if File_spire_plugin_server_datastore_v1_datastore_proto != nil {
+		return
+	}
+	file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[38].OneofWrappers = []any{}
+	file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[50].OneofWrappers = []any{}
+	file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[70].OneofWrappers = []any{}
+	file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[80].OneofWrappers = []any{}
+	file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes[133].OneofWrappers = []any{
+		(*ExportRecordsResponse_Bundle)(nil),
+		(*ExportRecordsResponse_FederationRelationship)(nil),
+		(*ExportRecordsResponse_RegistrationEntry)(nil),
+		(*ExportRecordsResponse_AttestedNode)(nil),
+		(*ExportRecordsResponse_JoinToken)(nil),
+		(*ExportRecordsResponse_CaJournal)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_plugin_server_datastore_v1_datastore_proto_rawDesc), len(file_spire_plugin_server_datastore_v1_datastore_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   137,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spire_plugin_server_datastore_v1_datastore_proto_goTypes,
+		DependencyIndexes: file_spire_plugin_server_datastore_v1_datastore_proto_depIdxs,
+		EnumInfos:         file_spire_plugin_server_datastore_v1_datastore_proto_enumTypes,
+		MessageInfos:      file_spire_plugin_server_datastore_v1_datastore_proto_msgTypes,
+	}.Build()
+	File_spire_plugin_server_datastore_v1_datastore_proto = out.File
+	file_spire_plugin_server_datastore_v1_datastore_proto_goTypes = nil
+	file_spire_plugin_server_datastore_v1_datastore_proto_depIdxs = nil
+}
@@ -0,0 +1,2429 @@
+// A DataStore plugin stores and retrieves the data SPIRE Server relies on,
+// e.g. bundles, attested nodes, registration entries and join tokens.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v7.35.0
+// source: spire/plugin/server/datastore/v1/datastore.proto
+
+package datastorev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	DataStore_AppendBundle_FullMethodName                           = "/spire.plugin.server.datastore.v1.DataStore/AppendBundle"
+	DataStore_CountBundles_FullMethodName                           = "/spire.plugin.server.datastore.v1.DataStore/CountBundles"
+	DataStore_CreateBundle_FullMethodName                           = "/spire.plugin.server.datastore.v1.DataStore/CreateBundle"
+	DataStore_DeleteBundle_FullMethodName                           = "/spire.plugin.server.datastore.v1.DataStore/DeleteBundle"
+	DataStore_FetchBundle_FullMethodName                            = "/spire.plugin.server.datastore.v1.DataStore/FetchBundle"
+	DataStore_ListBundles_FullMethodName                            = "/spire.plugin.server.datastore.v1.DataStore/ListBundles"
+	DataStore_PruneBundle_FullMethodName                            = "/spire.plugin.server.datastore.v1.DataStore/PruneBundle"
+	DataStore_SetBundle_FullMethodName                              = "/spire.plugin.server.datastore.v1.DataStore/SetBundle"
+	DataStore_UpdateBundle_FullMethodName                           = "/spire.plugin.server.datastore.v1.DataStore/UpdateBundle"
+	DataStore_TaintX509CA_FullMethodName                            = "/spire.plugin.server.datastore.v1.DataStore/TaintX509CA"
+	DataStore_RevokeX509CA_FullMethodName                           = "/spire.plugin.server.datastore.v1.DataStore/RevokeX509CA"
+	DataStore_TaintJWTKey_FullMethodName                            = "/spire.plugin.server.datastore.v1.DataStore/TaintJWTKey"
+	DataStore_RevokeJWTKey_FullMethodName                           = "/spire.plugin.server.datastore.v1.DataStore/RevokeJWTKey"
+	DataStore_ActivateRegistrationEntries_FullMethodName            = "/spire.plugin.server.datastore.v1.DataStore/ActivateRegistrationEntries"
+	DataStore_CountRegistrationEntries_FullMethodName               = "/spire.plugin.server.datastore.v1.DataStore/CountRegistrationEntries"
+	DataStore_CreateRegistrationEntry_FullMethodName                = "/spire.plugin.server.datastore.v1.DataStore/CreateRegistrationEntry"
+	DataStore_CreateOrReturnRegistrationEntry_FullMethodName        = "/spire.plugin.server.datastore.v1.DataStore/CreateOrReturnRegistrationEntry"
+	DataStore_DeleteRegistrationEntry_FullMethodName                = "/spire.plugin.server.datastore.v1.DataStore/DeleteRegistrationEntry"
+	DataStore_FetchRegistrationEntry_FullMethodName                 = "/spire.plugin.server.datastore.v1.DataStore/FetchRegistrationEntry"
+	DataStore_FetchRegistrationEntries_FullMethodName               = "/spire.plugin.server.datastore.v1.DataStore/FetchRegistrationEntries"
+	DataStore_ListRegistrationEntries_FullMethodName                = "/spire.plugin.server.datastore.v1.DataStore/ListRegistrationEntries"
+	DataStore_PruneRegistrationEntries_FullMethodName               = "/spire.plugin.server.datastore.v1.DataStore/PruneRegistrationEntries"
+	DataStore_UpdateRegistrationEntry_FullMethodName                = "/spire.plugin.server.datastore.v1.DataStore/UpdateRegistrationEntry"
+	DataStore_ListRegistrationEntryEvents_FullMethodName            = "/spire.plugin.server.datastore.v1.DataStore/ListRegistrationEntryEvents"
+	DataStore_PruneRegistrationEntryEvents_FullMethodName           = "/spire.plugin.server.datastore.v1.DataStore/PruneRegistrationEntryEvents"
+	DataStore_FetchRegistrationEntryEvent_FullMethodName            = "/spire.plugin.server.datastore.v1.DataStore/FetchRegistrationEntryEvent"
+	DataStore_CreateRegistrationEntryEventForTesting_FullMethodName = "/spire.plugin.server.datastore.v1.DataStore/CreateRegistrationEntryEventForTesting"
+	DataStore_DeleteRegistrationEntryEventForTesting_FullMethodName = "/spire.plugin.server.datastore.v1.DataStore/DeleteRegistrationEntryEventForTesting"
+	DataStore_ListRegistrationEntryChanges_FullMethodName           = "/spire.plugin.server.datastore.v1.DataStore/ListRegistrationEntryChanges"
+	DataStore_PruneRegistrationEntryChanges_FullMethodName          = "/spire.plugin.server.datastore.v1.DataStore/PruneRegistrationEntryChanges"
+	DataStore_CountAttestedNodes_FullMethodName                     = "/spire.plugin.server.datastore.v1.DataStore/CountAttestedNodes"
+	DataStore_CreateAttestedNode_FullMethodName                     = "/spire.plugin.server.datastore.v1.DataStore/CreateAttestedNode"
+	DataStore_DeleteAttestedNode_FullMethodName                     = "/spire.plugin.server.datastore.v1.DataStore/DeleteAttestedNode"
+	DataStore_FetchAttestedNode_FullMethodName                      = "/spire.plugin.server.datastore.v1.DataStore/FetchAttestedNode"
+	DataStore_FetchAttestedNodes_FullMethodName                     = "/spire.plugin.server.datastore.v1.DataStore/FetchAttestedNodes"
+	DataStore_ListAttestedNodes_FullMethodName                      = "/spire.plugin.server.datastore.v1.DataStore/ListAttestedNodes"
+	DataStore_UpdateAttestedNode_FullMethodName                     = "/spire.plugin.server.datastore.v1.DataStore/UpdateAttestedNode"
+	DataStore_PruneAttestedExpiredNodes_FullMethodName              = "/spire.plugin.server.datastore.v1.DataStore/PruneAttestedExpiredNodes"
+	DataStore_ListAttestedNodeEvents_FullMethodName                 = "/spire.plugin.server.datastore.v1.DataStore/ListAttestedNodeEvents"
+	DataStore_PruneAttestedNodeEvents_FullMethodName                = "/spire.plugin.server.datastore.v1.DataStore/PruneAttestedNodeEvents"
+	DataStore_FetchAttestedNodeEvent_FullMethodName                 = "/spire.plugin.server.datastore.v1.DataStore/FetchAttestedNodeEvent"
+	DataStore_CreateAttestedNodeEventForTesting_FullMethodName      = "/spire.plugin.server.datastore.v1.DataStore/CreateAttestedNodeEventForTesting"
+	DataStore_DeleteAttestedNodeEventForTesting_FullMethodName      = "/spire.plugin.server.datastore.v1.DataStore/DeleteAttestedNodeEventForTesting"
+	DataStore_GetNodeSelectors_FullMethodName                       = "/spire.plugin.server.datastore.v1.DataStore/GetNodeSelectors"
+	DataStore_ListNodeSelectors_FullMethodName                      = "/spire.plugin.server.datastore.v1.DataStore/ListNodeSelectors"
+	DataStore_SetNodeSelectors_FullMethodName                       = "/spire.plugin.server.datastore.v1.DataStore/SetNodeSelectors"
+	DataStore_CreateJoinToken_FullMethodName                        = "/spire.plugin.server.datastore.v1.DataStore/CreateJoinToken"
+	DataStore_DeleteJoinToken_FullMethodName                        = "/spire.plugin.server.datastore.v1.DataStore/DeleteJoinToken"
+	DataStore_FetchJoinToken_FullMethodName                         = "/spire.plugin.server.datastore.v1.DataStore/FetchJoinToken"
+	DataStore_ListJoinTokens_FullMethodName                         = "/spire.plugin.server.datastore.v1.DataStore/ListJoinTokens"
+	DataStore_PruneJoinTokens_FullMethodName                        = "/spire.plugin.server.datastore.v1.DataStore/PruneJoinTokens"
+	DataStore_CreateFederationRelationship_FullMethodName           = "/spire.plugin.server.datastore.v1.DataStore/CreateFederationRelationship"
+	DataStore_FetchFederationRelationship_FullMethodName            = "/spire.plugin.server.datastore.v1.DataStore/FetchFederationRelationship"
+	DataStore_ListFederationRelationships_FullMethodName            = "/spire.plugin.server.datastore.v1.DataStore/ListFederationRelationships"
+	DataStore_DeleteFederationRelationship_FullMethodName           = "/spire.plugin.server.datastore.v1.DataStore/DeleteFederationRelationship"
+	DataStore_UpdateFederationRelationship_FullMethodName           = "/spire.plugin.server.datastore.v1.DataStore/UpdateFederationRelationship"
+	DataStore_SetCAJournal_FullMethodName                           = "/spire.plugin.server.datastore.v1.DataStore/SetCAJournal"
+	DataStore_FetchCAJournal_FullMethodName                         = "/spire.plugin.server.datastore.v1.DataStore/FetchCAJournal"
+	DataStore_PruneCAJournals_FullMethodName                        = "/spire.plugin.server.datastore.v1.DataStore/PruneCAJournals"
+	DataStore_ListCAJournals_FullMethodName                         = "/spire.plugin.server.datastore.v1.DataStore/ListCAJournals"
+	DataStore_ListCAJournalsForTesting_FullMethodName               = "/spire.plugin.server.datastore.v1.DataStore/ListCAJournalsForTesting"
+	DataStore_ExportRecords_FullMethodName                          = "/spire.plugin.server.datastore.v1.DataStore/ExportRecords"
+)
+
+// DataStoreClient is the client API for DataStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DataStoreClient interface {
+	// Bundles
+	AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*AppendBundleResponse, error)
+	CountBundles(ctx context.Context, in *CountBundlesRequest, opts ...grpc.CallOption) (*CountBundlesResponse, error)
+	CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error)
+	DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error)
+	FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error)
+	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error)
+	PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error)
+	SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error)
+	UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error)
+	// Keys
+	TaintX509CA(ctx context.Context, in *TaintX509CARequest, opts ...grpc.CallOption) (*TaintX509CAResponse, error)
+	RevokeX509CA(ctx context.Context, in *RevokeX509CARequest, opts ...grpc.CallOption) (*RevokeX509CAResponse, error)
+	TaintJWTKey(ctx context.Context, in *TaintJWTKeyRequest, opts ...grpc.CallOption) (*TaintJWTKeyResponse, error)
+	RevokeJWTKey(ctx context.Context, in *RevokeJWTKeyRequest, opts ...grpc.CallOption) (*RevokeJWTKeyResponse, error)
+	// Entries
+	ActivateRegistrationEntries(ctx context.Context, in *ActivateRegistrationEntriesRequest, opts ...grpc.CallOption) (*ActivateRegistrationEntriesResponse, error)
+	CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest, opts ...grpc.CallOption) (*CountRegistrationEntriesResponse, error)
+	CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error)
+	CreateOrReturnRegistrationEntry(ctx context.Context, in *CreateOrReturnRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateOrReturnRegistrationEntryResponse, error)
+	DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error)
+	FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error)
+	FetchRegistrationEntries(ctx context.Context, in *FetchRegistrationEntriesRequest, opts ...grpc.CallOption) (*FetchRegistrationEntriesResponse, error)
+	ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error)
+	PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error)
+	UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error)
+	// Entries Events
+	ListRegistrationEntryEvents(ctx context.Context, in *ListRegistrationEntryEventsRequest, opts ...grpc.CallOption) (*ListRegistrationEntryEventsResponse, error)
+	PruneRegistrationEntryEvents(ctx context.Context, in *PruneRegistrationEntryEventsRequest, opts ...grpc.CallOption) (*PruneRegistrationEntryEventsResponse, error)
+	FetchRegistrationEntryEvent(ctx context.Context, in *FetchRegistrationEntryEventRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryEventResponse, error)
+	CreateRegistrationEntryEventForTesting(ctx context.Context, in *CreateRegistrationEntryEventForTestingRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryEventForTestingResponse, error)
+	DeleteRegistrationEntryEventForTesting(ctx context.Context, in *DeleteRegistrationEntryEventForTestingRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryEventForTestingResponse, error)
+	// Entries History
+	ListRegistrationEntryChanges(ctx context.Context, in *ListRegistrationEntryChangesRequest, opts ...grpc.CallOption) (*ListRegistrationEntryChangesResponse, error)
+	PruneRegistrationEntryChanges(ctx context.Context, in *PruneRegistrationEntryChangesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntryChangesResponse, error)
+	// Nodes
+	CountAttestedNodes(ctx context.Context, in *CountAttestedNodesRequest, opts ...grpc.CallOption) (*CountAttestedNodesResponse, error)
+	CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error)
+	DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error)
+	FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error)
+	FetchAttestedNodes(ctx context.Context, in *FetchAttestedNodesRequest, opts ...grpc.CallOption) (*FetchAttestedNodesResponse, error)
+	ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error)
+	UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error)
+	PruneAttestedExpiredNodes(ctx context.Context, in *PruneAttestedExpiredNodesRequest, opts ...grpc.CallOption) (*PruneAttestedExpiredNodesResponse, error)
+	// Nodes Events
+	ListAttestedNodeEvents(ctx context.Context, in *ListAttestedNodeEventsRequest, opts ...grpc.CallOption) (*ListAttestedNodeEventsResponse, error)
+	PruneAttestedNodeEvents(ctx context.Context, in *PruneAttestedNodeEventsRequest, opts ...grpc.CallOption) (*PruneAttestedNodeEventsResponse, error)
+	FetchAttestedNodeEvent(ctx context.Context, in *FetchAttestedNodeEventRequest, opts ...grpc.CallOption) (*FetchAttestedNodeEventResponse, error)
+	CreateAttestedNodeEventForTesting(ctx context.Context, in *CreateAttestedNodeEventForTestingRequest, opts ...grpc.CallOption) (*CreateAttestedNodeEventForTestingResponse, error)
+	DeleteAttestedNodeEventForTesting(ctx context.Context, in *DeleteAttestedNodeEventForTestingRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeEventForTestingResponse, error)
+	// Node selectors
+	GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error)
+	ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest, opts ...grpc.CallOption) (*ListNodeSelectorsResponse, error)
+	SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error)
+	// Tokens
+	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error)
+	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
+	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
+	ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error)
+	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
+	// Federation Relationships
+	CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest, opts ...grpc.CallOption) (*CreateFederationRelationshipResponse, error)
+	FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest, opts ...grpc.CallOption) (*FetchFederationRelationshipResponse, error)
+	ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error)
+	DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*DeleteFederationRelationshipResponse, error)
+	UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*UpdateFederationRelationshipResponse, error)
+	// CA Journals
+	SetCAJournal(ctx context.Context, in *SetCAJournalRequest, opts ...grpc.CallOption) (*SetCAJournalResponse, error)
+	FetchCAJournal(ctx context.Context, in *FetchCAJournalRequest, opts ...grpc.CallOption) (*FetchCAJournalResponse, error)
+	PruneCAJournals(ctx context.Context, in *PruneCAJournalsRequest, opts ...grpc.CallOption) (*PruneCAJournalsResponse, error)
+	ListCAJournals(ctx context.Context, in *ListCAJournalsRequest, opts ...grpc.CallOption) (*ListCAJournalsResponse, error)
+	ListCAJournalsForTesting(ctx context.Context, in *ListCAJournalsForTestingRequest, opts ...grpc.CallOption) (*ListCAJournalsForTestingResponse, error)
+	// ExportRecords streams every record in the datastore, read in a single
+	// read transaction, one record per response. Records are sent in
+	// dependency order: bundles, federation relationships, registration
+	// entries, attested nodes (with their selectors), join tokens and CA
+	// journals.
+	ExportRecords(ctx context.Context, in *ExportRecordsRequest, opts ...grpc.CallOption) (DataStore_ExportRecordsClient, error)
+}
+
+type dataStoreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDataStoreClient(cc grpc.ClientConnInterface) DataStoreClient {
+	return &dataStoreClient{cc}
+}
+
+func (c *dataStoreClient) AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*AppendBundleResponse, error) {
+	out := new(AppendBundleResponse)
+	err := c.cc.Invoke(ctx, DataStore_AppendBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CountBundles(ctx context.Context, in *CountBundlesRequest, opts ...grpc.CallOption) (*CountBundlesResponse, error) {
+	out := new(CountBundlesResponse)
+	err := c.cc.Invoke(ctx, DataStore_CountBundles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error) {
+	out := new(CreateBundleResponse)
+	err := c.cc.Invoke(ctx, DataStore_CreateBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error) {
+	out := new(DeleteBundleResponse)
+	err := c.cc.Invoke(ctx, DataStore_DeleteBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error) {
+	out := new(FetchBundleResponse)
+	err := c.cc.Invoke(ctx, DataStore_FetchBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error) {
+	out := new(ListBundlesResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListBundles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error) {
+	out := new(PruneBundleResponse)
+	err := c.cc.Invoke(ctx, DataStore_PruneBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error) {
+	out := new(SetBundleResponse)
+	err := c.cc.Invoke(ctx, DataStore_SetBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error) {
+	out := new(UpdateBundleResponse)
+	err := c.cc.Invoke(ctx, DataStore_UpdateBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) TaintX509CA(ctx context.Context, in *TaintX509CARequest, opts ...grpc.CallOption) (*TaintX509CAResponse, error) {
+	out := new(TaintX509CAResponse)
+	err := c.cc.Invoke(ctx, DataStore_TaintX509CA_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) RevokeX509CA(ctx context.Context, in *RevokeX509CARequest, opts ...grpc.CallOption) (*RevokeX509CAResponse, error) {
+	out := new(RevokeX509CAResponse)
+	err := c.cc.Invoke(ctx, DataStore_RevokeX509CA_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) TaintJWTKey(ctx context.Context, in *TaintJWTKeyRequest, opts ...grpc.CallOption) (*TaintJWTKeyResponse, error) {
+	out := new(TaintJWTKeyResponse)
+	err := c.cc.Invoke(ctx, DataStore_TaintJWTKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) RevokeJWTKey(ctx context.Context, in *RevokeJWTKeyRequest, opts ...grpc.CallOption) (*RevokeJWTKeyResponse, error) {
+	out := new(RevokeJWTKeyResponse)
+	err := c.cc.Invoke(ctx, DataStore_RevokeJWTKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ActivateRegistrationEntries(ctx context.Context, in *ActivateRegistrationEntriesRequest, opts ...grpc.CallOption) (*ActivateRegistrationEntriesResponse, error) {
+	out := new(ActivateRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, DataStore_ActivateRegistrationEntries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest, opts ...grpc.CallOption) (*CountRegistrationEntriesResponse, error) {
+	out := new(CountRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, DataStore_CountRegistrationEntries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error) {
+	out := new(CreateRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, DataStore_CreateRegistrationEntry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateOrReturnRegistrationEntry(ctx context.Context, in *CreateOrReturnRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateOrReturnRegistrationEntryResponse, error) {
+	out := new(CreateOrReturnRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, DataStore_CreateOrReturnRegistrationEntry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error) {
+	out := new(DeleteRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, DataStore_DeleteRegistrationEntry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error) {
+	out := new(FetchRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, DataStore_FetchRegistrationEntry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchRegistrationEntries(ctx context.Context, in *FetchRegistrationEntriesRequest, opts ...grpc.CallOption) (*FetchRegistrationEntriesResponse, error) {
+	out := new(FetchRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, DataStore_FetchRegistrationEntries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error) {
+	out := new(ListRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListRegistrationEntries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error) {
+	out := new(PruneRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, DataStore_PruneRegistrationEntries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error) {
+	out := new(UpdateRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, DataStore_UpdateRegistrationEntry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListRegistrationEntryEvents(ctx context.Context, in *ListRegistrationEntryEventsRequest, opts ...grpc.CallOption) (*ListRegistrationEntryEventsResponse, error) {
+	out := new(ListRegistrationEntryEventsResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListRegistrationEntryEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneRegistrationEntryEvents(ctx context.Context, in *PruneRegistrationEntryEventsRequest, opts ...grpc.CallOption) (*PruneRegistrationEntryEventsResponse, error) {
+	out := new(PruneRegistrationEntryEventsResponse)
+	err := c.cc.Invoke(ctx, DataStore_PruneRegistrationEntryEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchRegistrationEntryEvent(ctx context.Context, in *FetchRegistrationEntryEventRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryEventResponse, error) {
+	out := new(FetchRegistrationEntryEventResponse)
+	err := c.cc.Invoke(ctx, DataStore_FetchRegistrationEntryEvent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateRegistrationEntryEventForTesting(ctx context.Context, in *CreateRegistrationEntryEventForTestingRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryEventForTestingResponse, error) {
+	out := new(CreateRegistrationEntryEventForTestingResponse)
+	err := c.cc.Invoke(ctx, DataStore_CreateRegistrationEntryEventForTesting_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteRegistrationEntryEventForTesting(ctx context.Context, in *DeleteRegistrationEntryEventForTestingRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryEventForTestingResponse, error) {
+	out := new(DeleteRegistrationEntryEventForTestingResponse)
+	err := c.cc.Invoke(ctx, DataStore_DeleteRegistrationEntryEventForTesting_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListRegistrationEntryChanges(ctx context.Context, in *ListRegistrationEntryChangesRequest, opts ...grpc.CallOption) (*ListRegistrationEntryChangesResponse, error) {
+	out := new(ListRegistrationEntryChangesResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListRegistrationEntryChanges_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneRegistrationEntryChanges(ctx context.Context, in *PruneRegistrationEntryChangesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntryChangesResponse, error) {
+	out := new(PruneRegistrationEntryChangesResponse)
+	err := c.cc.Invoke(ctx, DataStore_PruneRegistrationEntryChanges_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CountAttestedNodes(ctx context.Context, in *CountAttestedNodesRequest, opts ...grpc.CallOption) (*CountAttestedNodesResponse, error) {
+	out := new(CountAttestedNodesResponse)
+	err := c.cc.Invoke(ctx, DataStore_CountAttestedNodes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error) {
+	out := new(CreateAttestedNodeResponse)
+	err := c.cc.Invoke(ctx, DataStore_CreateAttestedNode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error) {
+	out := new(DeleteAttestedNodeResponse)
+	err := c.cc.Invoke(ctx, DataStore_DeleteAttestedNode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error) {
+	out := new(FetchAttestedNodeResponse)
+	err := c.cc.Invoke(ctx, DataStore_FetchAttestedNode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchAttestedNodes(ctx context.Context, in *FetchAttestedNodesRequest, opts ...grpc.CallOption) (*FetchAttestedNodesResponse, error) {
+	out := new(FetchAttestedNodesResponse)
+	err := c.cc.Invoke(ctx, DataStore_FetchAttestedNodes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error) {
+	out := new(ListAttestedNodesResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListAttestedNodes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error) {
+	out := new(UpdateAttestedNodeResponse)
+	err := c.cc.Invoke(ctx, DataStore_UpdateAttestedNode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneAttestedExpiredNodes(ctx context.Context, in *PruneAttestedExpiredNodesRequest, opts ...grpc.CallOption) (*PruneAttestedExpiredNodesResponse, error) {
+	out := new(PruneAttestedExpiredNodesResponse)
+	err := c.cc.Invoke(ctx, DataStore_PruneAttestedExpiredNodes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListAttestedNodeEvents(ctx context.Context, in *ListAttestedNodeEventsRequest, opts ...grpc.CallOption) (*ListAttestedNodeEventsResponse, error) {
+	out := new(ListAttestedNodeEventsResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListAttestedNodeEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneAttestedNodeEvents(ctx context.Context, in *PruneAttestedNodeEventsRequest, opts ...grpc.CallOption) (*PruneAttestedNodeEventsResponse, error) {
+	out := new(PruneAttestedNodeEventsResponse)
+	err := c.cc.Invoke(ctx, DataStore_PruneAttestedNodeEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchAttestedNodeEvent(ctx context.Context, in *FetchAttestedNodeEventRequest, opts ...grpc.CallOption) (*FetchAttestedNodeEventResponse, error) {
+	out := new(FetchAttestedNodeEventResponse)
+	err := c.cc.Invoke(ctx, DataStore_FetchAttestedNodeEvent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateAttestedNodeEventForTesting(ctx context.Context, in *CreateAttestedNodeEventForTestingRequest, opts ...grpc.CallOption) (*CreateAttestedNodeEventForTestingResponse, error) {
+	out := new(CreateAttestedNodeEventForTestingResponse)
+	err := c.cc.Invoke(ctx, DataStore_CreateAttestedNodeEventForTesting_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteAttestedNodeEventForTesting(ctx context.Context, in *DeleteAttestedNodeEventForTestingRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeEventForTestingResponse, error) {
+	out := new(DeleteAttestedNodeEventForTestingResponse)
+	err := c.cc.Invoke(ctx, DataStore_DeleteAttestedNodeEventForTesting_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error) {
+	out := new(GetNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, DataStore_GetNodeSelectors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest, opts ...grpc.CallOption) (*ListNodeSelectorsResponse, error) {
+	out := new(ListNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListNodeSelectors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error) {
+	out := new(SetNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, DataStore_SetNodeSelectors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error) {
+	out := new(CreateJoinTokenResponse)
+	err := c.cc.Invoke(ctx, DataStore_CreateJoinToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error) {
+	out := new(DeleteJoinTokenResponse)
+	err := c.cc.Invoke(ctx, DataStore_DeleteJoinToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error) {
+	out := new(FetchJoinTokenResponse)
+	err := c.cc.Invoke(ctx, DataStore_FetchJoinToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error) {
+	out := new(ListJoinTokensResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListJoinTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
+	out := new(PruneJoinTokensResponse)
+	err := c.cc.Invoke(ctx, DataStore_PruneJoinTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest, opts ...grpc.CallOption) (*CreateFederationRelationshipResponse, error) {
+	out := new(CreateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, DataStore_CreateFederationRelationship_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest, opts ...grpc.CallOption) (*FetchFederationRelationshipResponse, error) {
+	out := new(FetchFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, DataStore_FetchFederationRelationship_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error) {
+	out := new(ListFederationRelationshipsResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListFederationRelationships_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*DeleteFederationRelationshipResponse, error) {
+	out := new(DeleteFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, DataStore_DeleteFederationRelationship_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*UpdateFederationRelationshipResponse, error) {
+	out := new(UpdateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, DataStore_UpdateFederationRelationship_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) SetCAJournal(ctx context.Context, in *SetCAJournalRequest, opts ...grpc.CallOption) (*SetCAJournalResponse, error) {
+	out := new(SetCAJournalResponse)
+	err := c.cc.Invoke(ctx, DataStore_SetCAJournal_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchCAJournal(ctx context.Context, in *FetchCAJournalRequest, opts ...grpc.CallOption) (*FetchCAJournalResponse, error) {
+	out := new(FetchCAJournalResponse)
+	err := c.cc.Invoke(ctx, DataStore_FetchCAJournal_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneCAJournals(ctx context.Context, in *PruneCAJournalsRequest, opts ...grpc.CallOption) (*PruneCAJournalsResponse, error) {
+	out := new(PruneCAJournalsResponse)
+	err := c.cc.Invoke(ctx, DataStore_PruneCAJournals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListCAJournals(ctx context.Context, in *ListCAJournalsRequest, opts ...grpc.CallOption) (*ListCAJournalsResponse, error) {
+	out := new(ListCAJournalsResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListCAJournals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListCAJournalsForTesting(ctx context.Context, in *ListCAJournalsForTestingRequest, opts ...grpc.CallOption) (*ListCAJournalsForTestingResponse, error) {
+	out := new(ListCAJournalsForTestingResponse)
+	err := c.cc.Invoke(ctx, DataStore_ListCAJournalsForTesting_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ExportRecords(ctx context.Context, in *ExportRecordsRequest, opts ...grpc.CallOption) (DataStore_ExportRecordsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DataStore_ServiceDesc.Streams[0], DataStore_ExportRecords_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dataStoreExportRecordsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DataStore_ExportRecordsClient interface {
+	Recv() (*ExportRecordsResponse, error)
+	grpc.ClientStream
+}
+
+type dataStoreExportRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *dataStoreExportRecordsClient) Recv() (*ExportRecordsResponse, error) {
+	m := new(ExportRecordsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DataStoreServer is the server API for DataStore service.
+// All implementations must embed UnimplementedDataStoreServer
+// for forward compatibility
+type DataStoreServer interface {
+	// Bundles
+	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
+	CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error)
+	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error)
+	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
+	SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error)
+	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
+	// Keys
+	TaintX509CA(context.Context, *TaintX509CARequest) (*TaintX509CAResponse, error)
+	RevokeX509CA(context.Context, *RevokeX509CARequest) (*RevokeX509CAResponse, error)
+	TaintJWTKey(context.Context, *TaintJWTKeyRequest) (*TaintJWTKeyResponse, error)
+	RevokeJWTKey(context.Context, *RevokeJWTKeyRequest) (*RevokeJWTKeyResponse, error)
+	// Entries
+	ActivateRegistrationEntries(context.Context, *ActivateRegistrationEntriesRequest) (*ActivateRegistrationEntriesResponse, error)
+	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error)
+	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
+	CreateOrReturnRegistrationEntry(context.Context, *CreateOrReturnRegistrationEntryRequest) (*CreateOrReturnRegistrationEntryResponse, error)
+	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
+	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
+	FetchRegistrationEntries(context.Context, *FetchRegistrationEntriesRequest) (*FetchRegistrationEntriesResponse, error)
+	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
+	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
+	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
+	// Entries Events
+	ListRegistrationEntryEvents(context.Context, *ListRegistrationEntryEventsRequest) (*ListRegistrationEntryEventsResponse, error)
+	PruneRegistrationEntryEvents(context.Context, *PruneRegistrationEntryEventsRequest) (*PruneRegistrationEntryEventsResponse, error)
+	FetchRegistrationEntryEvent(context.Context, *FetchRegistrationEntryEventRequest) (*FetchRegistrationEntryEventResponse, error)
+	CreateRegistrationEntryEventForTesting(context.Context, *CreateRegistrationEntryEventForTestingRequest) (*CreateRegistrationEntryEventForTestingResponse, error)
+	DeleteRegistrationEntryEventForTesting(context.Context, *DeleteRegistrationEntryEventForTestingRequest) (*DeleteRegistrationEntryEventForTestingResponse, error)
+	// Entries History
+	ListRegistrationEntryChanges(context.Context, *ListRegistrationEntryChangesRequest) (*ListRegistrationEntryChangesResponse, error)
+	PruneRegistrationEntryChanges(context.Context, *PruneRegistrationEntryChangesRequest) (*PruneRegistrationEntryChangesResponse, error)
+	// Nodes
+	CountAttestedNodes(context.Context, *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error)
+	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
+	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
+	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
+	FetchAttestedNodes(context.Context, *FetchAttestedNodesRequest) (*FetchAttestedNodesResponse, error)
+	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
+	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
+	PruneAttestedExpiredNodes(context.Context, *PruneAttestedExpiredNodesRequest) (*PruneAttestedExpiredNodesResponse, error)
+	// Nodes Events
+	ListAttestedNodeEvents(context.Context, *ListAttestedNodeEventsRequest) (*ListAttestedNodeEventsResponse, error)
+	PruneAttestedNodeEvents(context.Context, *PruneAttestedNodeEventsRequest) (*PruneAttestedNodeEventsResponse, error)
+	FetchAttestedNodeEvent(context.Context, *FetchAttestedNodeEventRequest) (*FetchAttestedNodeEventResponse, error)
+	CreateAttestedNodeEventForTesting(context.Context, *CreateAttestedNodeEventForTestingRequest) (*CreateAttestedNodeEventForTestingResponse, error)
+	DeleteAttestedNodeEventForTesting(context.Context, *DeleteAttestedNodeEventForTestingRequest) (*DeleteAttestedNodeEventForTestingResponse, error)
+	// Node selectors
+	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
+	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
+	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
+	// Tokens
+	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
+	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
+	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
+	// Federation Relationships
+	CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error)
+	FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error)
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error)
+	UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error)
+	// CA Journals
+	SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error)
+	FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error)
+	PruneCAJournals(context.Context, *PruneCAJournalsRequest) (*PruneCAJournalsResponse, error)
+	ListCAJournals(context.Context, *ListCAJournalsRequest) (*ListCAJournalsResponse, error)
+	ListCAJournalsForTesting(context.Context, *ListCAJournalsForTestingRequest) (*ListCAJournalsForTestingResponse, error)
+	// ExportRecords streams every record in the datastore, read in a single
+	// read transaction, one record per response. Records are sent in
+	// dependency order: bundles, federation relationships, registration
+	// entries, attested nodes (with their selectors), join tokens and CA
+	// journals.
+	ExportRecords(*ExportRecordsRequest, DataStore_ExportRecordsServer) error
+	mustEmbedUnimplementedDataStoreServer()
+}
+
+// UnimplementedDataStoreServer must be embedded to have forward compatible implementations.
+type UnimplementedDataStoreServer struct {
+}
+
+func (UnimplementedDataStoreServer) AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendBundle not implemented")
+}
+func (UnimplementedDataStoreServer) CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountBundles not implemented")
+}
+func (UnimplementedDataStoreServer) CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBundle not implemented")
+}
+func (UnimplementedDataStoreServer) DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBundle not implemented")
+}
+func (UnimplementedDataStoreServer) FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchBundle not implemented")
+}
+func (UnimplementedDataStoreServer) ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBundles not implemented")
+}
+func (UnimplementedDataStoreServer) PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneBundle not implemented")
+}
+func (UnimplementedDataStoreServer) SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBundle not implemented")
+}
+func (UnimplementedDataStoreServer) UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBundle not implemented")
+}
+func (UnimplementedDataStoreServer) TaintX509CA(context.Context, *TaintX509CARequest) (*TaintX509CAResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TaintX509CA not implemented")
+}
+func (UnimplementedDataStoreServer) RevokeX509CA(context.Context, *RevokeX509CARequest) (*RevokeX509CAResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeX509CA not implemented")
+}
+func (UnimplementedDataStoreServer) TaintJWTKey(context.Context, *TaintJWTKeyRequest) (*TaintJWTKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TaintJWTKey not implemented")
+}
+func (UnimplementedDataStoreServer) RevokeJWTKey(context.Context, *RevokeJWTKeyRequest) (*RevokeJWTKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeJWTKey not implemented")
+}
+func (UnimplementedDataStoreServer) ActivateRegistrationEntries(context.Context, *ActivateRegistrationEntriesRequest) (*ActivateRegistrationEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateRegistrationEntries not implemented")
+}
+func (UnimplementedDataStoreServer) CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountRegistrationEntries not implemented")
+}
+func (UnimplementedDataStoreServer) CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRegistrationEntry not implemented")
+}
+func (UnimplementedDataStoreServer) CreateOrReturnRegistrationEntry(context.Context, *CreateOrReturnRegistrationEntryRequest) (*CreateOrReturnRegistrationEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrReturnRegistrationEntry not implemented")
+}
+func (UnimplementedDataStoreServer) DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRegistrationEntry not implemented")
+}
+func (UnimplementedDataStoreServer) FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchRegistrationEntry not implemented")
+}
+func (UnimplementedDataStoreServer) FetchRegistrationEntries(context.Context, *FetchRegistrationEntriesRequest) (*FetchRegistrationEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchRegistrationEntries not implemented")
+}
+func (UnimplementedDataStoreServer) ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRegistrationEntries not implemented")
+}
+func (UnimplementedDataStoreServer) PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneRegistrationEntries not implemented")
+}
+func (UnimplementedDataStoreServer) UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRegistrationEntry not implemented")
+}
+func (UnimplementedDataStoreServer) ListRegistrationEntryEvents(context.Context, *ListRegistrationEntryEventsRequest) (*ListRegistrationEntryEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRegistrationEntryEvents not implemented")
+}
+func (UnimplementedDataStoreServer) PruneRegistrationEntryEvents(context.Context, *PruneRegistrationEntryEventsRequest) (*PruneRegistrationEntryEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneRegistrationEntryEvents not implemented")
+}
+func (UnimplementedDataStoreServer) FetchRegistrationEntryEvent(context.Context, *FetchRegistrationEntryEventRequest) (*FetchRegistrationEntryEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchRegistrationEntryEvent not implemented")
+}
+func (UnimplementedDataStoreServer) CreateRegistrationEntryEventForTesting(context.Context, *CreateRegistrationEntryEventForTestingRequest) (*CreateRegistrationEntryEventForTestingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRegistrationEntryEventForTesting not implemented")
+}
+func (UnimplementedDataStoreServer) DeleteRegistrationEntryEventForTesting(context.Context, *DeleteRegistrationEntryEventForTestingRequest) (*DeleteRegistrationEntryEventForTestingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRegistrationEntryEventForTesting not implemented")
+}
+func (UnimplementedDataStoreServer) ListRegistrationEntryChanges(context.Context, *ListRegistrationEntryChangesRequest) (*ListRegistrationEntryChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRegistrationEntryChanges not implemented")
+}
+func (UnimplementedDataStoreServer) PruneRegistrationEntryChanges(context.Context, *PruneRegistrationEntryChangesRequest) (*PruneRegistrationEntryChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneRegistrationEntryChanges not implemented")
+}
+func (UnimplementedDataStoreServer) CountAttestedNodes(context.Context, *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountAttestedNodes not implemented")
+}
+func (UnimplementedDataStoreServer) CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAttestedNode not implemented")
+}
+func (UnimplementedDataStoreServer) DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttestedNode not implemented")
+}
+func (UnimplementedDataStoreServer) FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchAttestedNode not implemented")
+}
+func (UnimplementedDataStoreServer) FetchAttestedNodes(context.Context, *FetchAttestedNodesRequest) (*FetchAttestedNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchAttestedNodes not implemented")
+}
+func (UnimplementedDataStoreServer) ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttestedNodes not implemented")
+}
+func (UnimplementedDataStoreServer) UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAttestedNode not implemented")
+}
+func (UnimplementedDataStoreServer) PruneAttestedExpiredNodes(context.Context, *PruneAttestedExpiredNodesRequest) (*PruneAttestedExpiredNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneAttestedExpiredNodes not implemented")
+}
+func (UnimplementedDataStoreServer) ListAttestedNodeEvents(context.Context, *ListAttestedNodeEventsRequest) (*ListAttestedNodeEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttestedNodeEvents not implemented")
+}
+func (UnimplementedDataStoreServer) PruneAttestedNodeEvents(context.Context, *PruneAttestedNodeEventsRequest) (*PruneAttestedNodeEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneAttestedNodeEvents not implemented")
+}
+func (UnimplementedDataStoreServer) FetchAttestedNodeEvent(context.Context, *FetchAttestedNodeEventRequest) (*FetchAttestedNodeEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchAttestedNodeEvent not implemented")
+}
+func (UnimplementedDataStoreServer) CreateAttestedNodeEventForTesting(context.Context, *CreateAttestedNodeEventForTestingRequest) (*CreateAttestedNodeEventForTestingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAttestedNodeEventForTesting not implemented")
+}
+func (UnimplementedDataStoreServer) DeleteAttestedNodeEventForTesting(context.Context, *DeleteAttestedNodeEventForTestingRequest) (*DeleteAttestedNodeEventForTestingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttestedNodeEventForTesting not implemented")
+}
+func (UnimplementedDataStoreServer) GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeSelectors not implemented")
+}
+func (UnimplementedDataStoreServer) ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNodeSelectors not implemented")
+}
+func (UnimplementedDataStoreServer) SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetNodeSelectors not implemented")
+}
+func (UnimplementedDataStoreServer) CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateJoinToken not implemented")
+}
+func (UnimplementedDataStoreServer) DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJoinToken not implemented")
+}
+func (UnimplementedDataStoreServer) FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchJoinToken not implemented")
+}
+func (UnimplementedDataStoreServer) ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJoinTokens not implemented")
+}
+func (UnimplementedDataStoreServer) PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneJoinTokens not implemented")
+}
+func (UnimplementedDataStoreServer) CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFederationRelationship not implemented")
+}
+func (UnimplementedDataStoreServer) FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchFederationRelationship not implemented")
+}
+func (UnimplementedDataStoreServer) ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederationRelationships not implemented")
+}
+func (UnimplementedDataStoreServer) DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFederationRelationship not implemented")
+}
+func (UnimplementedDataStoreServer) UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFederationRelationship not implemented")
+}
+func (UnimplementedDataStoreServer) SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCAJournal not implemented")
+}
+func (UnimplementedDataStoreServer) FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchCAJournal not implemented")
+}
+func (UnimplementedDataStoreServer) PruneCAJournals(context.Context, *PruneCAJournalsRequest) (*PruneCAJournalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneCAJournals not implemented")
+}
+func (UnimplementedDataStoreServer) ListCAJournals(context.Context, *ListCAJournalsRequest) (*ListCAJournalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCAJournals not implemented")
+}
+func (UnimplementedDataStoreServer) ListCAJournalsForTesting(context.Context, *ListCAJournalsForTestingRequest) (*ListCAJournalsForTestingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCAJournalsForTesting not implemented")
+}
+func (UnimplementedDataStoreServer) ExportRecords(*ExportRecordsRequest, DataStore_ExportRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportRecords not implemented")
+}
+func (UnimplementedDataStoreServer) mustEmbedUnimplementedDataStoreServer() {}
+
+// UnsafeDataStoreServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DataStoreServer will
+// result in compilation errors.
+type UnsafeDataStoreServer interface {
+	mustEmbedUnimplementedDataStoreServer()
+}
+
+func RegisterDataStoreServer(s grpc.ServiceRegistrar, srv DataStoreServer) {
+	s.RegisterService(&DataStore_ServiceDesc, srv)
+}
+
+func _DataStore_AppendBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).AppendBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_AppendBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).AppendBundle(ctx, req.(*AppendBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CountBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CountBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CountBundles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CountBundles(ctx, req.(*CountBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CreateBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateBundle(ctx, req.(*CreateBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_DeleteBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteBundle(ctx, req.(*DeleteBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_FetchBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchBundle(ctx, req.(*FetchBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListBundles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListBundles(ctx, req.(*ListBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_PruneBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneBundle(ctx, req.(*PruneBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_SetBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).SetBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_SetBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).SetBundle(ctx, req.(*SetBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_UpdateBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateBundle(ctx, req.(*UpdateBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_TaintX509CA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaintX509CARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).TaintX509CA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_TaintX509CA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).TaintX509CA(ctx, req.(*TaintX509CARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_RevokeX509CA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeX509CARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).RevokeX509CA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_RevokeX509CA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).RevokeX509CA(ctx, req.(*RevokeX509CARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_TaintJWTKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaintJWTKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).TaintJWTKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_TaintJWTKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).TaintJWTKey(ctx, req.(*TaintJWTKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_RevokeJWTKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeJWTKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).RevokeJWTKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_RevokeJWTKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).RevokeJWTKey(ctx, req.(*RevokeJWTKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ActivateRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateRegistrationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ActivateRegistrationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ActivateRegistrationEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ActivateRegistrationEntries(ctx, req.(*ActivateRegistrationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CountRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountRegistrationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CountRegistrationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CountRegistrationEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CountRegistrationEntries(ctx, req.(*CountRegistrationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRegistrationEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateRegistrationEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CreateRegistrationEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateRegistrationEntry(ctx, req.(*CreateRegistrationEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateOrReturnRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrReturnRegistrationEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateOrReturnRegistrationEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CreateOrReturnRegistrationEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateOrReturnRegistrationEntry(ctx, req.(*CreateOrReturnRegistrationEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRegistrationEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteRegistrationEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_DeleteRegistrationEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteRegistrationEntry(ctx, req.(*DeleteRegistrationEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRegistrationEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchRegistrationEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_FetchRegistrationEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchRegistrationEntry(ctx, req.(*FetchRegistrationEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRegistrationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchRegistrationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_FetchRegistrationEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchRegistrationEntries(ctx, req.(*FetchRegistrationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRegistrationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListRegistrationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListRegistrationEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListRegistrationEntries(ctx, req.(*ListRegistrationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRegistrationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneRegistrationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_PruneRegistrationEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneRegistrationEntries(ctx, req.(*PruneRegistrationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRegistrationEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateRegistrationEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_UpdateRegistrationEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateRegistrationEntry(ctx, req.(*UpdateRegistrationEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListRegistrationEntryEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRegistrationEntryEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListRegistrationEntryEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListRegistrationEntryEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListRegistrationEntryEvents(ctx, req.(*ListRegistrationEntryEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneRegistrationEntryEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRegistrationEntryEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneRegistrationEntryEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_PruneRegistrationEntryEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneRegistrationEntryEvents(ctx, req.(*PruneRegistrationEntryEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchRegistrationEntryEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRegistrationEntryEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchRegistrationEntryEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_FetchRegistrationEntryEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchRegistrationEntryEvent(ctx, req.(*FetchRegistrationEntryEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateRegistrationEntryEventForTesting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRegistrationEntryEventForTestingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateRegistrationEntryEventForTesting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CreateRegistrationEntryEventForTesting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateRegistrationEntryEventForTesting(ctx, req.(*CreateRegistrationEntryEventForTestingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteRegistrationEntryEventForTesting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRegistrationEntryEventForTestingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteRegistrationEntryEventForTesting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_DeleteRegistrationEntryEventForTesting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteRegistrationEntryEventForTesting(ctx, req.(*DeleteRegistrationEntryEventForTestingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListRegistrationEntryChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRegistrationEntryChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListRegistrationEntryChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListRegistrationEntryChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListRegistrationEntryChanges(ctx, req.(*ListRegistrationEntryChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneRegistrationEntryChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRegistrationEntryChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneRegistrationEntryChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_PruneRegistrationEntryChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneRegistrationEntryChanges(ctx, req.(*PruneRegistrationEntryChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CountAttestedNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountAttestedNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CountAttestedNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CountAttestedNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CountAttestedNodes(ctx, req.(*CountAttestedNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAttestedNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateAttestedNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CreateAttestedNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateAttestedNode(ctx, req.(*CreateAttestedNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttestedNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteAttestedNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_DeleteAttestedNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteAttestedNode(ctx, req.(*DeleteAttestedNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchAttestedNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchAttestedNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_FetchAttestedNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchAttestedNode(ctx, req.(*FetchAttestedNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchAttestedNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchAttestedNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchAttestedNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_FetchAttestedNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchAttestedNodes(ctx, req.(*FetchAttestedNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListAttestedNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttestedNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListAttestedNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListAttestedNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListAttestedNodes(ctx, req.(*ListAttestedNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAttestedNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateAttestedNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_UpdateAttestedNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateAttestedNode(ctx, req.(*UpdateAttestedNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneAttestedExpiredNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneAttestedExpiredNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneAttestedExpiredNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_PruneAttestedExpiredNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneAttestedExpiredNodes(ctx, req.(*PruneAttestedExpiredNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListAttestedNodeEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttestedNodeEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListAttestedNodeEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListAttestedNodeEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListAttestedNodeEvents(ctx, req.(*ListAttestedNodeEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneAttestedNodeEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneAttestedNodeEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneAttestedNodeEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_PruneAttestedNodeEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneAttestedNodeEvents(ctx, req.(*PruneAttestedNodeEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchAttestedNodeEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchAttestedNodeEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchAttestedNodeEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_FetchAttestedNodeEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchAttestedNodeEvent(ctx, req.(*FetchAttestedNodeEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateAttestedNodeEventForTesting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAttestedNodeEventForTestingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateAttestedNodeEventForTesting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CreateAttestedNodeEventForTesting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateAttestedNodeEventForTesting(ctx, req.(*CreateAttestedNodeEventForTestingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteAttestedNodeEventForTesting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttestedNodeEventForTestingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteAttestedNodeEventForTesting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_DeleteAttestedNodeEventForTesting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteAttestedNodeEventForTesting(ctx, req.(*DeleteAttestedNodeEventForTestingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_GetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).GetNodeSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_GetNodeSelectors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).GetNodeSelectors(ctx, req.(*GetNodeSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodeSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListNodeSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListNodeSelectors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListNodeSelectors(ctx, req.(*ListNodeSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_SetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNodeSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).SetNodeSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_SetNodeSelectors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).SetNodeSelectors(ctx, req.(*SetNodeSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CreateJoinToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateJoinToken(ctx, req.(*CreateJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_DeleteJoinToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteJoinToken(ctx, req.(*DeleteJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_FetchJoinToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchJoinToken(ctx, req.(*FetchJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListJoinTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListJoinTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListJoinTokens(ctx, req.(*ListJoinTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneJoinTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneJoinTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_PruneJoinTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneJoinTokens(ctx, req.(*PruneJoinTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_CreateFederationRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateFederationRelationship(ctx, req.(*CreateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_FetchFederationRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchFederationRelationship(ctx, req.(*FetchFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListFederationRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederationRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListFederationRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListFederationRelationships_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListFederationRelationships(ctx, req.(*ListFederationRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_DeleteFederationRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteFederationRelationship(ctx, req.(*DeleteFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_UpdateFederationRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateFederationRelationship(ctx, req.(*UpdateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_SetCAJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCAJournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).SetCAJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_SetCAJournal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).SetCAJournal(ctx, req.(*SetCAJournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchCAJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchCAJournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchCAJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_FetchCAJournal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchCAJournal(ctx, req.(*FetchCAJournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneCAJournals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneCAJournalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneCAJournals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_PruneCAJournals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneCAJournals(ctx, req.(*PruneCAJournalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListCAJournals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCAJournalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListCAJournals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListCAJournals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListCAJournals(ctx, req.(*ListCAJournalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListCAJournalsForTesting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCAJournalsForTestingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListCAJournalsForTesting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataStore_ListCAJournalsForTesting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListCAJournalsForTesting(ctx, req.(*ListCAJournalsForTestingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ExportRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataStoreServer).ExportRecords(m, &dataStoreExportRecordsServer{stream})
+}
+
+type DataStore_ExportRecordsServer interface {
+	Send(*ExportRecordsResponse) error
+	grpc.ServerStream
+}
+
+type dataStoreExportRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *dataStoreExportRecordsServer) Send(m *ExportRecordsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// DataStore_ServiceDesc is the grpc.ServiceDesc for DataStore service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DataStore_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.plugin.server.datastore.v1.DataStore",
+	HandlerType: (*DataStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AppendBundle",
+			Handler:    _DataStore_AppendBundle_Handler,
+		},
+		{
+			MethodName: "CountBundles",
+			Handler:    _DataStore_CountBundles_Handler,
+		},
+		{
+			MethodName: "CreateBundle",
+			Handler:    _DataStore_CreateBundle_Handler,
+		},
+		{
+			MethodName: "DeleteBundle",
+			Handler:    _DataStore_DeleteBundle_Handler,
+		},
+		{
+			MethodName: "FetchBundle",
+			Handler:    _DataStore_FetchBundle_Handler,
+		},
+		{
+			MethodName: "ListBundles",
+			Handler:    _DataStore_ListBundles_Handler,
+		},
+		{
+			MethodName: "PruneBundle",
+			Handler:    _DataStore_PruneBundle_Handler,
+		},
+		{
+			MethodName: "SetBundle",
+			Handler:    _DataStore_SetBundle_Handler,
+		},
+		{
+			MethodName: "UpdateBundle",
+			Handler:    _DataStore_UpdateBundle_Handler,
+		},
+		{
+			MethodName: "TaintX509CA",
+			Handler:    _DataStore_TaintX509CA_Handler,
+		},
+		{
+			MethodName: "RevokeX509CA",
+			Handler:    _DataStore_RevokeX509CA_Handler,
+		},
+		{
+			MethodName: "TaintJWTKey",
+			Handler:    _DataStore_TaintJWTKey_Handler,
+		},
+		{
+			MethodName: "RevokeJWTKey",
+			Handler:    _DataStore_RevokeJWTKey_Handler,
+		},
+		{
+			MethodName: "ActivateRegistrationEntries",
+			Handler:    _DataStore_ActivateRegistrationEntries_Handler,
+		},
+		{
+			MethodName: "CountRegistrationEntries",
+			Handler:    _DataStore_CountRegistrationEntries_Handler,
+		},
+		{
+			MethodName: "CreateRegistrationEntry",
+			Handler:    _DataStore_CreateRegistrationEntry_Handler,
+		},
+		{
+			MethodName: "CreateOrReturnRegistrationEntry",
+			Handler:    _DataStore_CreateOrReturnRegistrationEntry_Handler,
+		},
+		{
+			MethodName: "DeleteRegistrationEntry",
+			Handler:    _DataStore_DeleteRegistrationEntry_Handler,
+		},
+		{
+			MethodName: "FetchRegistrationEntry",
+			Handler:    _DataStore_FetchRegistrationEntry_Handler,
+		},
+		{
+			MethodName: "FetchRegistrationEntries",
+			Handler:    _DataStore_FetchRegistrationEntries_Handler,
+		},
+		{
+			MethodName: "ListRegistrationEntries",
+			Handler:    _DataStore_ListRegistrationEntries_Handler,
+		},
+		{
+			MethodName: "PruneRegistrationEntries",
+			Handler:    _DataStore_PruneRegistrationEntries_Handler,
+		},
+		{
+			MethodName: "UpdateRegistrationEntry",
+			Handler:    _DataStore_UpdateRegistrationEntry_Handler,
+		},
+		{
+			MethodName: "ListRegistrationEntryEvents",
+			Handler:    _DataStore_ListRegistrationEntryEvents_Handler,
+		},
+		{
+			MethodName: "PruneRegistrationEntryEvents",
+			Handler:    _DataStore_PruneRegistrationEntryEvents_Handler,
+		},
+		{
+			MethodName: "FetchRegistrationEntryEvent",
+			Handler:    _DataStore_FetchRegistrationEntryEvent_Handler,
+		},
+		{
+			MethodName: "CreateRegistrationEntryEventForTesting",
+			Handler:    _DataStore_CreateRegistrationEntryEventForTesting_Handler,
+		},
+		{
+			MethodName: "DeleteRegistrationEntryEventForTesting",
+			Handler:    _DataStore_DeleteRegistrationEntryEventForTesting_Handler,
+		},
+		{
+			MethodName: "ListRegistrationEntryChanges",
+			Handler:    _DataStore_ListRegistrationEntryChanges_Handler,
+		},
+		{
+			MethodName: "PruneRegistrationEntryChanges",
+			Handler:    _DataStore_PruneRegistrationEntryChanges_Handler,
+		},
+		{
+			MethodName: "CountAttestedNodes",
+			Handler:    _DataStore_CountAttestedNodes_Handler,
+		},
+		{
+			MethodName: "CreateAttestedNode",
+			Handler:    _DataStore_CreateAttestedNode_Handler,
+		},
+		{
+			MethodName: "DeleteAttestedNode",
+			Handler:    _DataStore_DeleteAttestedNode_Handler,
+		},
+		{
+			MethodName: "FetchAttestedNode",
+			Handler:    _DataStore_FetchAttestedNode_Handler,
+		},
+		{
+			MethodName: "FetchAttestedNodes",
+			Handler:    _DataStore_FetchAttestedNodes_Handler,
+		},
+		{
+			MethodName: "ListAttestedNodes",
+			Handler:    _DataStore_ListAttestedNodes_Handler,
+		},
+		{
+			MethodName: "UpdateAttestedNode",
+			Handler:    _DataStore_UpdateAttestedNode_Handler,
+		},
+		{
+			MethodName: "PruneAttestedExpiredNodes",
+			Handler:    _DataStore_PruneAttestedExpiredNodes_Handler,
+		},
+		{
+			MethodName: "ListAttestedNodeEvents",
+			Handler:    _DataStore_ListAttestedNodeEvents_Handler,
+		},
+		{
+			MethodName: "PruneAttestedNodeEvents",
+			Handler:    _DataStore_PruneAttestedNodeEvents_Handler,
+		},
+		{
+			MethodName: "FetchAttestedNodeEvent",
+			Handler:    _DataStore_FetchAttestedNodeEvent_Handler,
+		},
+		{
+			MethodName: "CreateAttestedNodeEventForTesting",
+			Handler:    _DataStore_CreateAttestedNodeEventForTesting_Handler,
+		},
+		{
+			MethodName: "DeleteAttestedNodeEventForTesting",
+			Handler:    _DataStore_DeleteAttestedNodeEventForTesting_Handler,
+		},
+		{
+			MethodName: "GetNodeSelectors",
+			Handler:    _DataStore_GetNodeSelectors_Handler,
+		},
+		{
+			MethodName: "ListNodeSelectors",
+			Handler:    _DataStore_ListNodeSelectors_Handler,
+		},
+		{
+			MethodName: "SetNodeSelectors",
+			Handler:    _DataStore_SetNodeSelectors_Handler,
+		},
+		{
+			MethodName: "CreateJoinToken",
+			Handler:    _DataStore_CreateJoinToken_Handler,
+		},
+		{
+			MethodName: "DeleteJoinToken",
+			Handler:    _DataStore_DeleteJoinToken_Handler,
+		},
+		{
+			MethodName: "FetchJoinToken",
+			Handler:    _DataStore_FetchJoinToken_Handler,
+		},
+		{
+			MethodName: "ListJoinTokens",
+			Handler:    _DataStore_ListJoinTokens_Handler,
+		},
+		{
+			MethodName: "PruneJoinTokens",
+			Handler:    _DataStore_PruneJoinTokens_Handler,
+		},
+		{
+			MethodName: "CreateFederationRelationship",
+			Handler:    _DataStore_CreateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "FetchFederationRelationship",
+			Handler:    _DataStore_FetchFederationRelationship_Handler,
+		},
+		{
+			MethodName: "ListFederationRelationships",
+			Handler:    _DataStore_ListFederationRelationships_Handler,
+		},
+		{
+			MethodName: "DeleteFederationRelationship",
+			Handler:    _DataStore_DeleteFederationRelationship_Handler,
+		},
+		{
+			MethodName: "UpdateFederationRelationship",
+			Handler:    _DataStore_UpdateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "SetCAJournal",
+			Handler:    _DataStore_SetCAJournal_Handler,
+		},
+		{
+			MethodName: "FetchCAJournal",
+			Handler:    _DataStore_FetchCAJournal_Handler,
+		},
+		{
+			MethodName: "PruneCAJournals",
+			Handler:    _DataStore_PruneCAJournals_Handler,
+		},
+		{
+			MethodName: "ListCAJournals",
+			Handler:    _DataStore_ListCAJournals_Handler,
+		},
+		{
+			MethodName: "ListCAJournalsForTesting",
+			Handler:    _DataStore_ListCAJournalsForTesting_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportRecords",
+			Handler:       _DataStore_ExportRecords_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spire/plugin/server/datastore/v1/datastore.proto",
+}
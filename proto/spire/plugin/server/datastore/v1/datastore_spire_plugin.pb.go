@@ -0,0 +1,50 @@
+// Code generated by protoc-gen-go-spire. DO NOT EDIT.
+
+package datastorev1
+
+import (
+	pluginsdk "github.com/spiffe/spire-plugin-sdk/pluginsdk"
+	grpc "google.golang.org/grpc"
+)
+
+func DataStorePluginServer(server DataStoreServer) pluginsdk.PluginServer {
+	return dataStorePluginServer{DataStoreServer: server}
+}
+
+type dataStorePluginServer struct {
+	DataStoreServer
+}
+
+func (s dataStorePluginServer) Type() string {
+	return "DataStore"
+}
+
+func (s dataStorePluginServer) GRPCServiceName() string {
+	return "spire.plugin.server.datastore.v1.DataStore"
+}
+
+func (s dataStorePluginServer) RegisterServer(server *grpc.Server) interface{} {
+	RegisterDataStoreServer(server, s.DataStoreServer)
+	return s.DataStoreServer
+}
+
+type DataStorePluginClient struct {
+	DataStoreClient
+}
+
+func (s DataStorePluginClient) Type() string {
+	return "DataStore"
+}
+
+func (c *DataStorePluginClient) IsInitialized() bool {
+	return c.DataStoreClient != nil
+}
+
+func (c *DataStorePluginClient) GRPCServiceName() string {
+	return "spire.plugin.server.datastore.v1.DataStore"
+}
+
+func (c *DataStorePluginClient) InitClient(conn grpc.ClientConnInterface) interface{} {
+	c.DataStoreClient = NewDataStoreClient(conn)
+	return c.DataStoreClient
+}
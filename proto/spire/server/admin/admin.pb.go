@@ -0,0 +1,176 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        v7.35.0
+// source: spire/server/admin/admin.proto
+
+package admin
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SnapshotRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Includes the files holding private keys in the snapshot. The snapshot
+	// is not encrypted, so it must then be stored as securely as the keys.
+	IncludeKeys   bool `protobuf:"varint,1,opt,name=include_keys,json=includeKeys,proto3" json:"include_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	mi := &file_spire_server_admin_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_admin_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_admin_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SnapshotRequest) GetIncludeKeys() bool {
+	if x != nil {
+		return x.IncludeKeys
+	}
+	return false
+}
+
+type SnapshotResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The next chunk of the snapshot.
+	Chunk         []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotResponse) Reset() {
+	*x = SnapshotResponse{}
+	mi := &file_spire_server_admin_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotResponse) ProtoMessage() {}
+
+func (x *SnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_admin_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_admin_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SnapshotResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+var File_spire_server_admin_admin_proto protoreflect.FileDescriptor
+
+const file_spire_server_admin_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x1espire/server/admin/admin.proto\x12\x12spire.server.admin\"4\n" +
+	"\x0fSnapshotRequest\x12!\n" +
+	"\finclude_keys\x18\x01 \x01(\bR\vincludeKeys\"(\n" +
+	"\x10SnapshotResponse\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk2`\n" +
+	"\x05Admin\x12W\n" +
+	"\bSnapshot\x12#.spire.server.admin.SnapshotRequest\x1a$.spire.server.admin.SnapshotResponse0\x01B2Z0github.com/spiffe/spire/proto/spire/server/adminb\x06proto3"
+
+var (
+	file_spire_server_admin_admin_proto_rawDescOnce sync.Once
+	file_spire_server_admin_admin_proto_rawDescData []byte
+)
+
+func file_spire_server_admin_admin_proto_rawDescGZIP() []byte {
+	file_spire_server_admin_admin_proto_rawDescOnce.Do(func() {
+		file_spire_server_admin_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spire_server_admin_admin_proto_rawDesc), len(file_spire_server_admin_admin_proto_rawDesc)))
+	})
+	return file_spire_server_admin_admin_proto_rawDescData
+}
+
+var file_spire_server_admin_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_spire_server_admin_admin_proto_goTypes = []any{
+	(*SnapshotRequest)(nil),  // 0: spire.server.admin.SnapshotRequest
+	(*SnapshotResponse)(nil), // 1: spire.server.admin.SnapshotResponse
+}
+var file_spire_server_admin_admin_proto_depIdxs = []int32{
+	0, // 0: spire.server.admin.Admin.Snapshot:input_type -> spire.server.admin.SnapshotRequest
+	1, // 1: spire.server.admin.Admin.Snapshot:output_type -> spire.server.admin.SnapshotResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_spire_server_admin_admin_proto_init() }
+func file_spire_server_admin_admin_proto_init() {
+	if File_spire_server_admin_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_server_admin_admin_proto_rawDesc), len(file_spire_server_admin_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spire_server_admin_admin_proto_goTypes,
+		DependencyIndexes: file_spire_server_admin_admin_proto_depIdxs,
+		MessageInfos:      file_spire_server_admin_admin_proto_msgTypes,
+	}.Build()
+	File_spire_server_admin_admin_proto = out.File
+	file_spire_server_admin_admin_proto_goTypes = nil
+	file_spire_server_admin_admin_proto_depIdxs = nil
+}
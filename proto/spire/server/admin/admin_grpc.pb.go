@@ -0,0 +1,154 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v7.35.0
+// source: spire/server/admin/admin.proto
+
+package admin
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Admin_Snapshot_FullMethodName = "/spire.server.admin.Admin/Snapshot"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	// Produces a signed snapshot of the server state. The snapshot holds the
+	// contents of the datastore, including CA journals, and the artifacts
+	// found in the server data directory. Files holding private keys, such
+	// as the keys of the disk KeyManager, are only included when requested.
+	// The snapshot is signed but not encrypted. The datastore is read in a
+	// single read transaction, so the snapshot is a point-in-time image of
+	// the datastore. The snapshot is streamed back in chunks that must be
+	// concatenated, in order, by the caller. Only one snapshot is taken at a
+	// time.
+	Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (Admin_SnapshotClient, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (Admin_SnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[0], Admin_Snapshot_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminSnapshotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Admin_SnapshotClient interface {
+	Recv() (*SnapshotResponse, error)
+	grpc.ClientStream
+}
+
+type adminSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminSnapshotClient) Recv() (*SnapshotResponse, error) {
+	m := new(SnapshotResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility
+type AdminServer interface {
+	// Produces a signed snapshot of the server state. The snapshot holds the
+	// contents of the datastore, including CA journals, and the artifacts
+	// found in the server data directory. Files holding private keys, such
+	// as the keys of the disk KeyManager, are only included when requested.
+	// The snapshot is signed but not encrypted. The datastore is read in a
+	// single read transaction, so the snapshot is a point-in-time image of
+	// the datastore. The snapshot is streamed back in chunks that must be
+	// concatenated, in order, by the caller. Only one snapshot is taken at a
+	// time.
+	Snapshot(*SnapshotRequest, Admin_SnapshotServer) error
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (UnimplementedAdminServer) Snapshot(*SnapshotRequest, Admin_SnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method Snapshot not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_Snapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).Snapshot(m, &adminSnapshotServer{stream})
+}
+
+type Admin_SnapshotServer interface {
+	Send(*SnapshotResponse) error
+	grpc.ServerStream
+}
+
+type adminSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminSnapshotServer) Send(m *SnapshotResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.server.admin.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Snapshot",
+			Handler:       _Admin_Snapshot_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spire/server/admin/admin.proto",
+}
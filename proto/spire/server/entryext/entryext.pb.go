@@ -0,0 +1,747 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        v7.35.0
+// source: spire/server/entryext/entryext.proto
+
+package entryext
+
+import (
+	types "github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	common "github.com/spiffe/spire/proto/spire/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EntryExtensions holds the registration entry fields that the Entry type of
+// the SPIRE Server API does not define.
+type EntryExtensions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Time before which the entry is not active, in seconds since the Unix
+	// epoch. When unset in an update, the not-before time of the entry is
+	// left as is.
+	NotBefore *int64 `protobuf:"varint,1,opt,name=not_before,json=notBefore,proto3,oneof" json:"not_before,omitempty"`
+	// Customizes the X509-SVIDs issued for the entry. When unset in an
+	// update, the credential profile of the entry is left as is. An empty
+	// profile clears it.
+	CredentialProfile *common.CredentialProfile `protobuf:"bytes,2,opt,name=credential_profile,json=credentialProfile,proto3" json:"credential_profile,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *EntryExtensions) Reset() {
+	*x = EntryExtensions{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryExtensions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryExtensions) ProtoMessage() {}
+
+func (x *EntryExtensions) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryExtensions.ProtoReflect.Descriptor instead.
+func (*EntryExtensions) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EntryExtensions) GetNotBefore() int64 {
+	if x != nil && x.NotBefore != nil {
+		return *x.NotBefore
+	}
+	return 0
+}
+
+func (x *EntryExtensions) GetCredentialProfile() *common.CredentialProfile {
+	if x != nil {
+		return x.CredentialProfile
+	}
+	return nil
+}
+
+type ExtendedEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The registration entry.
+	Entry *types.Entry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// The extensions of the registration entry.
+	Extensions    *EntryExtensions `protobuf:"bytes,2,opt,name=extensions,proto3" json:"extensions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtendedEntry) Reset() {
+	*x = ExtendedEntry{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendedEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendedEntry) ProtoMessage() {}
+
+func (x *ExtendedEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendedEntry.ProtoReflect.Descriptor instead.
+func (*ExtendedEntry) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExtendedEntry) GetEntry() *types.Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *ExtendedEntry) GetExtensions() *EntryExtensions {
+	if x != nil {
+		return x.Extensions
+	}
+	return nil
+}
+
+type BatchCreateEntryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The entries to be created. If no entry ID is provided, one will be
+	// generated.
+	Entries []*ExtendedEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// An output mask indicating the entry fields set in the response. The
+	// extensions are always set.
+	OutputMask    *types.EntryMask `protobuf:"bytes,2,opt,name=output_mask,json=outputMask,proto3" json:"output_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateEntryRequest) Reset() {
+	*x = BatchCreateEntryRequest{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateEntryRequest) ProtoMessage() {}
+
+func (x *BatchCreateEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateEntryRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateEntryRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchCreateEntryRequest) GetEntries() []*ExtendedEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *BatchCreateEntryRequest) GetOutputMask() *types.EntryMask {
+	if x != nil {
+		return x.OutputMask
+	}
+	return nil
+}
+
+type BatchCreateEntryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Result for each entry in the request (order is maintained).
+	Results       []*BatchCreateEntryResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateEntryResponse) Reset() {
+	*x = BatchCreateEntryResponse{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateEntryResponse) ProtoMessage() {}
+
+func (x *BatchCreateEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateEntryResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateEntryResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchCreateEntryResponse) GetResults() []*BatchCreateEntryResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BatchUpdateEntryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The entries to be updated.
+	Entries []*ExtendedEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// An input mask indicating what entry fields should be updated.
+	InputMask *types.EntryMask `protobuf:"bytes,2,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
+	// An output mask indicating the entry fields set in the response. The
+	// extensions are always set.
+	OutputMask    *types.EntryMask `protobuf:"bytes,3,opt,name=output_mask,json=outputMask,proto3" json:"output_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateEntryRequest) Reset() {
+	*x = BatchUpdateEntryRequest{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateEntryRequest) ProtoMessage() {}
+
+func (x *BatchUpdateEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateEntryRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateEntryRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchUpdateEntryRequest) GetEntries() []*ExtendedEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *BatchUpdateEntryRequest) GetInputMask() *types.EntryMask {
+	if x != nil {
+		return x.InputMask
+	}
+	return nil
+}
+
+func (x *BatchUpdateEntryRequest) GetOutputMask() *types.EntryMask {
+	if x != nil {
+		return x.OutputMask
+	}
+	return nil
+}
+
+type BatchUpdateEntryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Result for each entry in the request (order is maintained).
+	Results       []*BatchUpdateEntryResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateEntryResponse) Reset() {
+	*x = BatchUpdateEntryResponse{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateEntryResponse) ProtoMessage() {}
+
+func (x *BatchUpdateEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateEntryResponse.ProtoReflect.Descriptor instead.
+func (*BatchUpdateEntryResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchUpdateEntryResponse) GetResults() []*BatchUpdateEntryResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BatchGetEntryExtensionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IDs of the entries.
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetEntryExtensionsRequest) Reset() {
+	*x = BatchGetEntryExtensionsRequest{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetEntryExtensionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetEntryExtensionsRequest) ProtoMessage() {}
+
+func (x *BatchGetEntryExtensionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetEntryExtensionsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetEntryExtensionsRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchGetEntryExtensionsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetEntryExtensionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Result for each ID in the request (order is maintained).
+	Results       []*BatchGetEntryExtensionsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetEntryExtensionsResponse) Reset() {
+	*x = BatchGetEntryExtensionsResponse{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetEntryExtensionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetEntryExtensionsResponse) ProtoMessage() {}
+
+func (x *BatchGetEntryExtensionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetEntryExtensionsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetEntryExtensionsResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchGetEntryExtensionsResponse) GetResults() []*BatchGetEntryExtensionsResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BatchCreateEntryResponse_Result struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The status of creating the entry. If status code will be
+	// ALREADY_EXISTS if a similar entry already exists.
+	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The entry that was created (.e.g status code is OK) or that already
+	// exists (i.e. status code is ALREADY_EXISTS).
+	Entry         *ExtendedEntry `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateEntryResponse_Result) Reset() {
+	*x = BatchCreateEntryResponse_Result{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateEntryResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateEntryResponse_Result) ProtoMessage() {}
+
+func (x *BatchCreateEntryResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateEntryResponse_Result.ProtoReflect.Descriptor instead.
+func (*BatchCreateEntryResponse_Result) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *BatchCreateEntryResponse_Result) GetStatus() *types.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *BatchCreateEntryResponse_Result) GetEntry() *ExtendedEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type BatchUpdateEntryResponse_Result struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The status of updating the entry.
+	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The entry that was updated. Only set if the status is OK.
+	Entry         *ExtendedEntry `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateEntryResponse_Result) Reset() {
+	*x = BatchUpdateEntryResponse_Result{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateEntryResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateEntryResponse_Result) ProtoMessage() {}
+
+func (x *BatchUpdateEntryResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateEntryResponse_Result.ProtoReflect.Descriptor instead.
+func (*BatchUpdateEntryResponse_Result) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *BatchUpdateEntryResponse_Result) GetStatus() *types.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *BatchUpdateEntryResponse_Result) GetEntry() *ExtendedEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type BatchGetEntryExtensionsResponse_Result struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The status of getting the extensions of the entry.
+	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The ID of the entry.
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// The extensions of the entry. Only set if the status is OK.
+	Extensions    *EntryExtensions `protobuf:"bytes,3,opt,name=extensions,proto3" json:"extensions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetEntryExtensionsResponse_Result) Reset() {
+	*x = BatchGetEntryExtensionsResponse_Result{}
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetEntryExtensionsResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetEntryExtensionsResponse_Result) ProtoMessage() {}
+
+func (x *BatchGetEntryExtensionsResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryext_entryext_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetEntryExtensionsResponse_Result.ProtoReflect.Descriptor instead.
+func (*BatchGetEntryExtensionsResponse_Result) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryext_entryext_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *BatchGetEntryExtensionsResponse_Result) GetStatus() *types.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *BatchGetEntryExtensionsResponse_Result) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchGetEntryExtensionsResponse_Result) GetExtensions() *EntryExtensions {
+	if x != nil {
+		return x.Extensions
+	}
+	return nil
+}
+
+var File_spire_server_entryext_entryext_proto protoreflect.FileDescriptor
+
+const file_spire_server_entryext_entryext_proto_rawDesc = "" +
+	"\n" +
+	"$spire/server/entryext/entryext.proto\x12\x15spire.server.entryext\x1a\x1bspire/api/types/entry.proto\x1a\x1cspire/api/types/status.proto\x1a\x19spire/common/common.proto\"\x94\x01\n" +
+	"\x0fEntryExtensions\x12\"\n" +
+	"\n" +
+	"not_before\x18\x01 \x01(\x03H\x00R\tnotBefore\x88\x01\x01\x12N\n" +
+	"\x12credential_profile\x18\x02 \x01(\v2\x1f.spire.common.CredentialProfileR\x11credentialProfileB\r\n" +
+	"\v_not_before\"\x85\x01\n" +
+	"\rExtendedEntry\x12,\n" +
+	"\x05entry\x18\x01 \x01(\v2\x16.spire.api.types.EntryR\x05entry\x12F\n" +
+	"\n" +
+	"extensions\x18\x02 \x01(\v2&.spire.server.entryext.EntryExtensionsR\n" +
+	"extensions\"\x96\x01\n" +
+	"\x17BatchCreateEntryRequest\x12>\n" +
+	"\aentries\x18\x01 \x03(\v2$.spire.server.entryext.ExtendedEntryR\aentries\x12;\n" +
+	"\voutput_mask\x18\x02 \x01(\v2\x1a.spire.api.types.EntryMaskR\n" +
+	"outputMask\"\xe3\x01\n" +
+	"\x18BatchCreateEntryResponse\x12P\n" +
+	"\aresults\x18\x01 \x03(\v26.spire.server.entryext.BatchCreateEntryResponse.ResultR\aresults\x1au\n" +
+	"\x06Result\x12/\n" +
+	"\x06status\x18\x01 \x01(\v2\x17.spire.api.types.StatusR\x06status\x12:\n" +
+	"\x05entry\x18\x02 \x01(\v2$.spire.server.entryext.ExtendedEntryR\x05entry\"\xd1\x01\n" +
+	"\x17BatchUpdateEntryRequest\x12>\n" +
+	"\aentries\x18\x01 \x03(\v2$.spire.server.entryext.ExtendedEntryR\aentries\x129\n" +
+	"\n" +
+	"input_mask\x18\x02 \x01(\v2\x1a.spire.api.types.EntryMaskR\tinputMask\x12;\n" +
+	"\voutput_mask\x18\x03 \x01(\v2\x1a.spire.api.types.EntryMaskR\n" +
+	"outputMask\"\xe3\x01\n" +
+	"\x18BatchUpdateEntryResponse\x12P\n" +
+	"\aresults\x18\x01 \x03(\v26.spire.server.entryext.BatchUpdateEntryResponse.ResultR\aresults\x1au\n" +
+	"\x06Result\x12/\n" +
+	"\x06status\x18\x01 \x01(\v2\x17.spire.api.types.StatusR\x06status\x12:\n" +
+	"\x05entry\x18\x02 \x01(\v2$.spire.server.entryext.ExtendedEntryR\x05entry\"2\n" +
+	"\x1eBatchGetEntryExtensionsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"\x8e\x02\n" +
+	"\x1fBatchGetEntryExtensionsResponse\x12W\n" +
+	"\aresults\x18\x01 \x03(\v2=.spire.server.entryext.BatchGetEntryExtensionsResponse.ResultR\aresults\x1a\x91\x01\n" +
+	"\x06Result\x12/\n" +
+	"\x06status\x18\x01 \x01(\v2\x17.spire.api.types.StatusR\x06status\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12F\n" +
+	"\n" +
+	"extensions\x18\x03 \x01(\v2&.spire.server.entryext.EntryExtensionsR\n" +
+	"extensions2\xfc\x02\n" +
+	"\x05Entry\x12s\n" +
+	"\x10BatchCreateEntry\x12..spire.server.entryext.BatchCreateEntryRequest\x1a/.spire.server.entryext.BatchCreateEntryResponse\x12s\n" +
+	"\x10BatchUpdateEntry\x12..spire.server.entryext.BatchUpdateEntryRequest\x1a/.spire.server.entryext.BatchUpdateEntryResponse\x12\x88\x01\n" +
+	"\x17BatchGetEntryExtensions\x125.spire.server.entryext.BatchGetEntryExtensionsRequest\x1a6.spire.server.entryext.BatchGetEntryExtensionsResponseB5Z3github.com/spiffe/spire/proto/spire/server/entryextb\x06proto3"
+
+var (
+	file_spire_server_entryext_entryext_proto_rawDescOnce sync.Once
+	file_spire_server_entryext_entryext_proto_rawDescData []byte
+)
+
+func file_spire_server_entryext_entryext_proto_rawDescGZIP() []byte {
+	file_spire_server_entryext_entryext_proto_rawDescOnce.Do(func() {
+		file_spire_server_entryext_entryext_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spire_server_entryext_entryext_proto_rawDesc), len(file_spire_server_entryext_entryext_proto_rawDesc)))
+	})
+	return file_spire_server_entryext_entryext_proto_rawDescData
+}
+
+var file_spire_server_entryext_entryext_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_spire_server_entryext_entryext_proto_goTypes = []any{
+	(*EntryExtensions)(nil),                        // 0: spire.server.entryext.EntryExtensions
+	(*ExtendedEntry)(nil),                          // 1: spire.server.entryext.ExtendedEntry
+	(*BatchCreateEntryRequest)(nil),                // 2: spire.server.entryext.BatchCreateEntryRequest
+	(*BatchCreateEntryResponse)(nil),               // 3: spire.server.entryext.BatchCreateEntryResponse
+	(*BatchUpdateEntryRequest)(nil),                // 4: spire.server.entryext.BatchUpdateEntryRequest
+	(*BatchUpdateEntryResponse)(nil),               // 5: spire.server.entryext.BatchUpdateEntryResponse
+	(*BatchGetEntryExtensionsRequest)(nil),         // 6: spire.server.entryext.BatchGetEntryExtensionsRequest
+	(*BatchGetEntryExtensionsResponse)(nil),        // 7: spire.server.entryext.BatchGetEntryExtensionsResponse
+	(*BatchCreateEntryResponse_Result)(nil),        // 8: spire.server.entryext.BatchCreateEntryResponse.Result
+	(*BatchUpdateEntryResponse_Result)(nil),        // 9: spire.server.entryext.BatchUpdateEntryResponse.Result
+	(*BatchGetEntryExtensionsResponse_Result)(nil), // 10: spire.server.entryext.BatchGetEntryExtensionsResponse.Result
+	(*common.CredentialProfile)(nil),               // 11: spire.common.CredentialProfile
+	(*types.Entry)(nil),                            // 12: spire.api.types.Entry
+	(*types.EntryMask)(nil),                        // 13: spire.api.types.EntryMask
+	(*types.Status)(nil),                           // 14: spire.api.types.Status
+}
+var file_spire_server_entryext_entryext_proto_depIdxs = []int32{
+	11, // 0: spire.server.entryext.EntryExtensions.credential_profile:type_name -> spire.common.CredentialProfile
+	12, // 1: spire.server.entryext.ExtendedEntry.entry:type_name -> spire.api.types.Entry
+	0,  // 2: spire.server.entryext.ExtendedEntry.extensions:type_name -> spire.server.entryext.EntryExtensions
+	1,  // 3: spire.server.entryext.BatchCreateEntryRequest.entries:type_name -> spire.server.entryext.ExtendedEntry
+	13, // 4: spire.server.entryext.BatchCreateEntryRequest.output_mask:type_name -> spire.api.types.EntryMask
+	8,  // 5: spire.server.entryext.BatchCreateEntryResponse.results:type_name -> spire.server.entryext.BatchCreateEntryResponse.Result
+	1,  // 6: spire.server.entryext.BatchUpdateEntryRequest.entries:type_name -> spire.server.entryext.ExtendedEntry
+	13, // 7: spire.server.entryext.BatchUpdateEntryRequest.input_mask:type_name -> spire.api.types.EntryMask
+	13, // 8: spire.server.entryext.BatchUpdateEntryRequest.output_mask:type_name -> spire.api.types.EntryMask
+	9,  // 9: spire.server.entryext.BatchUpdateEntryResponse.results:type_name -> spire.server.entryext.BatchUpdateEntryResponse.Result
+	10, // 10: spire.server.entryext.BatchGetEntryExtensionsResponse.results:type_name -> spire.server.entryext.BatchGetEntryExtensionsResponse.Result
+	14, // 11: spire.server.entryext.BatchCreateEntryResponse.Result.status:type_name -> spire.api.types.Status
+	1,  // 12: spire.server.entryext.BatchCreateEntryResponse.Result.entry:type_name -> spire.server.entryext.ExtendedEntry
+	14, // 13: spire.server.entryext.BatchUpdateEntryResponse.Result.status:type_name -> spire.api.types.Status
+	1,  // 14: spire.server.entryext.BatchUpdateEntryResponse.Result.entry:type_name -> spire.server.entryext.ExtendedEntry
+	14, // 15: spire.server.entryext.BatchGetEntryExtensionsResponse.Result.status:type_name -> spire.api.types.Status
+	0,  // 16: spire.server.entryext.BatchGetEntryExtensionsResponse.Result.extensions:type_name -> spire.server.entryext.EntryExtensions
+	2,  // 17: spire.server.entryext.Entry.BatchCreateEntry:input_type -> spire.server.entryext.BatchCreateEntryRequest
+	4,  // 18: spire.server.entryext.Entry.BatchUpdateEntry:input_type -> spire.server.entryext.BatchUpdateEntryRequest
+	6,  // 19: spire.server.entryext.Entry.BatchGetEntryExtensions:input_type -> spire.server.entryext.BatchGetEntryExtensionsRequest
+	3,  // 20: spire.server.entryext.Entry.BatchCreateEntry:output_type -> spire.server.entryext.BatchCreateEntryResponse
+	5,  // 21: spire.server.entryext.Entry.BatchUpdateEntry:output_type -> spire.server.entryext.BatchUpdateEntryResponse
+	7,  // 22: spire.server.entryext.Entry.BatchGetEntryExtensions:output_type -> spire.server.entryext.BatchGetEntryExtensionsResponse
+	20, // [20:23] is the sub-list for method output_type
+	17, // [17:20] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_spire_server_entryext_entryext_proto_init() }
+func file_spire_server_entryext_entryext_proto_init() {
+	if File_spire_server_entryext_entryext_proto != nil {
+		return
+	}
+	file_spire_server_entryext_entryext_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_server_entryext_entryext_proto_rawDesc), len(file_spire_server_entryext_entryext_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spire_server_entryext_entryext_proto_goTypes,
+		DependencyIndexes: file_spire_server_entryext_entryext_proto_depIdxs,
+		MessageInfos:      file_spire_server_entryext_entryext_proto_msgTypes,
+	}.Build()
+	File_spire_server_entryext_entryext_proto = out.File
+	file_spire_server_entryext_entryext_proto_goTypes = nil
+	file_spire_server_entryext_entryext_proto_depIdxs = nil
+}
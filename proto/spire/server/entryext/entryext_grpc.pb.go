@@ -0,0 +1,195 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v7.35.0
+// source: spire/server/entryext/entryext.proto
+
+package entryext
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Entry_BatchCreateEntry_FullMethodName        = "/spire.server.entryext.Entry/BatchCreateEntry"
+	Entry_BatchUpdateEntry_FullMethodName        = "/spire.server.entryext.Entry/BatchUpdateEntry"
+	Entry_BatchGetEntryExtensions_FullMethodName = "/spire.server.entryext.Entry/BatchGetEntryExtensions"
+)
+
+// EntryClient is the client API for Entry service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EntryClient interface {
+	// Creates registration entries with their extensions. Entries are
+	// created as by the BatchCreateEntry RPC of the Entry API.
+	BatchCreateEntry(ctx context.Context, in *BatchCreateEntryRequest, opts ...grpc.CallOption) (*BatchCreateEntryResponse, error)
+	// Updates registration entries and their extensions. Entries are updated
+	// as by the BatchUpdateEntry RPC of the Entry API. The extensions that
+	// are unset are left as they are.
+	BatchUpdateEntry(ctx context.Context, in *BatchUpdateEntryRequest, opts ...grpc.CallOption) (*BatchUpdateEntryResponse, error)
+	// Gets the extensions of registration entries.
+	BatchGetEntryExtensions(ctx context.Context, in *BatchGetEntryExtensionsRequest, opts ...grpc.CallOption) (*BatchGetEntryExtensionsResponse, error)
+}
+
+type entryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEntryClient(cc grpc.ClientConnInterface) EntryClient {
+	return &entryClient{cc}
+}
+
+func (c *entryClient) BatchCreateEntry(ctx context.Context, in *BatchCreateEntryRequest, opts ...grpc.CallOption) (*BatchCreateEntryResponse, error) {
+	out := new(BatchCreateEntryResponse)
+	err := c.cc.Invoke(ctx, Entry_BatchCreateEntry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryClient) BatchUpdateEntry(ctx context.Context, in *BatchUpdateEntryRequest, opts ...grpc.CallOption) (*BatchUpdateEntryResponse, error) {
+	out := new(BatchUpdateEntryResponse)
+	err := c.cc.Invoke(ctx, Entry_BatchUpdateEntry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryClient) BatchGetEntryExtensions(ctx context.Context, in *BatchGetEntryExtensionsRequest, opts ...grpc.CallOption) (*BatchGetEntryExtensionsResponse, error) {
+	out := new(BatchGetEntryExtensionsResponse)
+	err := c.cc.Invoke(ctx, Entry_BatchGetEntryExtensions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EntryServer is the server API for Entry service.
+// All implementations must embed UnimplementedEntryServer
+// for forward compatibility
+type EntryServer interface {
+	// Creates registration entries with their extensions. Entries are
+	// created as by the BatchCreateEntry RPC of the Entry API.
+	BatchCreateEntry(context.Context, *BatchCreateEntryRequest) (*BatchCreateEntryResponse, error)
+	// Updates registration entries and their extensions. Entries are updated
+	// as by the BatchUpdateEntry RPC of the Entry API. The extensions that
+	// are unset are left as they are.
+	BatchUpdateEntry(context.Context, *BatchUpdateEntryRequest) (*BatchUpdateEntryResponse, error)
+	// Gets the extensions of registration entries.
+	BatchGetEntryExtensions(context.Context, *BatchGetEntryExtensionsRequest) (*BatchGetEntryExtensionsResponse, error)
+	mustEmbedUnimplementedEntryServer()
+}
+
+// UnimplementedEntryServer must be embedded to have forward compatible implementations.
+type UnimplementedEntryServer struct {
+}
+
+func (UnimplementedEntryServer) BatchCreateEntry(context.Context, *BatchCreateEntryRequest) (*BatchCreateEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateEntry not implemented")
+}
+func (UnimplementedEntryServer) BatchUpdateEntry(context.Context, *BatchUpdateEntryRequest) (*BatchUpdateEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateEntry not implemented")
+}
+func (UnimplementedEntryServer) BatchGetEntryExtensions(context.Context, *BatchGetEntryExtensionsRequest) (*BatchGetEntryExtensionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetEntryExtensions not implemented")
+}
+func (UnimplementedEntryServer) mustEmbedUnimplementedEntryServer() {}
+
+// UnsafeEntryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EntryServer will
+// result in compilation errors.
+type UnsafeEntryServer interface {
+	mustEmbedUnimplementedEntryServer()
+}
+
+func RegisterEntryServer(s grpc.ServiceRegistrar, srv EntryServer) {
+	s.RegisterService(&Entry_ServiceDesc, srv)
+}
+
+func _Entry_BatchCreateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).BatchCreateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Entry_BatchCreateEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).BatchCreateEntry(ctx, req.(*BatchCreateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entry_BatchUpdateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).BatchUpdateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Entry_BatchUpdateEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).BatchUpdateEntry(ctx, req.(*BatchUpdateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entry_BatchGetEntryExtensions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetEntryExtensionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).BatchGetEntryExtensions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Entry_BatchGetEntryExtensions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).BatchGetEntryExtensions(ctx, req.(*BatchGetEntryExtensionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Entry_ServiceDesc is the grpc.ServiceDesc for Entry service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Entry_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.server.entryext.Entry",
+	HandlerType: (*EntryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BatchCreateEntry",
+			Handler:    _Entry_BatchCreateEntry_Handler,
+		},
+		{
+			MethodName: "BatchUpdateEntry",
+			Handler:    _Entry_BatchUpdateEntry_Handler,
+		},
+		{
+			MethodName: "BatchGetEntryExtensions",
+			Handler:    _Entry_BatchGetEntryExtensions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/server/entryext/entryext.proto",
+}
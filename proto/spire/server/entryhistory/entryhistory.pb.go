@@ -0,0 +1,372 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        v7.35.0
+// source: spire/server/entryhistory/entryhistory.proto
+
+package entryhistory
+
+import (
+	common "github.com/spiffe/spire/proto/spire/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EntryChange_Operation int32
+
+const (
+	EntryChange_UNKNOWN EntryChange_Operation = 0
+	EntryChange_CREATE  EntryChange_Operation = 1
+	EntryChange_UPDATE  EntryChange_Operation = 2
+	EntryChange_DELETE  EntryChange_Operation = 3
+	// The entry expired and was pruned by the server.
+	EntryChange_PRUNE EntryChange_Operation = 4
+)
+
+// Enum value maps for EntryChange_Operation.
+var (
+	EntryChange_Operation_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "CREATE",
+		2: "UPDATE",
+		3: "DELETE",
+		4: "PRUNE",
+	}
+	EntryChange_Operation_value = map[string]int32{
+		"UNKNOWN": 0,
+		"CREATE":  1,
+		"UPDATE":  2,
+		"DELETE":  3,
+		"PRUNE":   4,
+	}
+)
+
+func (x EntryChange_Operation) Enum() *EntryChange_Operation {
+	p := new(EntryChange_Operation)
+	*p = x
+	return p
+}
+
+func (x EntryChange_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntryChange_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_spire_server_entryhistory_entryhistory_proto_enumTypes[0].Descriptor()
+}
+
+func (EntryChange_Operation) Type() protoreflect.EnumType {
+	return &file_spire_server_entryhistory_entryhistory_proto_enumTypes[0]
+}
+
+func (x EntryChange_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntryChange_Operation.Descriptor instead.
+func (EntryChange_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_spire_server_entryhistory_entryhistory_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type ListEntryHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the registration entry.
+	EntryId string `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// The maximum number of results to return. The server may further
+	// constrain this value, or if zero, choose its own.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token value returned from a previous request, if any.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntryHistoryRequest) Reset() {
+	*x = ListEntryHistoryRequest{}
+	mi := &file_spire_server_entryhistory_entryhistory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntryHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntryHistoryRequest) ProtoMessage() {}
+
+func (x *ListEntryHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryhistory_entryhistory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntryHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListEntryHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryhistory_entryhistory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListEntryHistoryRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *ListEntryHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListEntryHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListEntryHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The changes of the entry.
+	Changes []*EntryChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	// The page token for the next request. Empty if there are no more
+	// results.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntryHistoryResponse) Reset() {
+	*x = ListEntryHistoryResponse{}
+	mi := &file_spire_server_entryhistory_entryhistory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntryHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntryHistoryResponse) ProtoMessage() {}
+
+func (x *ListEntryHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryhistory_entryhistory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntryHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListEntryHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryhistory_entryhistory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListEntryHistoryResponse) GetChanges() []*EntryChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ListEntryHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type EntryChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The kind of mutation.
+	Operation EntryChange_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=spire.server.entryhistory.EntryChange_Operation" json:"operation,omitempty"`
+	// The entry before the change. Unset for created entries.
+	Before *common.RegistrationEntry `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	// The entry after the change. Unset for deleted and pruned entries.
+	After *common.RegistrationEntry `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	// The SPIFFE ID of the caller that made the change. Empty for callers on
+	// the local endpoint and for changes made by the server itself.
+	CallerId string `protobuf:"bytes,4,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"`
+	// When the change was made, in seconds since the Unix epoch.
+	ChangedAt     int64 `protobuf:"varint,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryChange) Reset() {
+	*x = EntryChange{}
+	mi := &file_spire_server_entryhistory_entryhistory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryChange) ProtoMessage() {}
+
+func (x *EntryChange) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_entryhistory_entryhistory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryChange.ProtoReflect.Descriptor instead.
+func (*EntryChange) Descriptor() ([]byte, []int) {
+	return file_spire_server_entryhistory_entryhistory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EntryChange) GetOperation() EntryChange_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return EntryChange_UNKNOWN
+}
+
+func (x *EntryChange) GetBefore() *common.RegistrationEntry {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *EntryChange) GetAfter() *common.RegistrationEntry {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *EntryChange) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+func (x *EntryChange) GetChangedAt() int64 {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return 0
+}
+
+var File_spire_server_entryhistory_entryhistory_proto protoreflect.FileDescriptor
+
+const file_spire_server_entryhistory_entryhistory_proto_rawDesc = "" +
+	"\n" +
+	",spire/server/entryhistory/entryhistory.proto\x12\x19spire.server.entryhistory\x1a\x19spire/common/common.proto\"p\n" +
+	"\x17ListEntryHistoryRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x84\x01\n" +
+	"\x18ListEntryHistoryResponse\x12@\n" +
+	"\achanges\x18\x01 \x03(\v2&.spire.server.entryhistory.EntryChangeR\achanges\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd2\x02\n" +
+	"\vEntryChange\x12N\n" +
+	"\toperation\x18\x01 \x01(\x0e20.spire.server.entryhistory.EntryChange.OperationR\toperation\x127\n" +
+	"\x06before\x18\x02 \x01(\v2\x1f.spire.common.RegistrationEntryR\x06before\x125\n" +
+	"\x05after\x18\x03 \x01(\v2\x1f.spire.common.RegistrationEntryR\x05after\x12\x1b\n" +
+	"\tcaller_id\x18\x04 \x01(\tR\bcallerId\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\x03R\tchangedAt\"G\n" +
+	"\tOperation\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\n" +
+	"\n" +
+	"\x06CREATE\x10\x01\x12\n" +
+	"\n" +
+	"\x06UPDATE\x10\x02\x12\n" +
+	"\n" +
+	"\x06DELETE\x10\x03\x12\t\n" +
+	"\x05PRUNE\x10\x042\x8b\x01\n" +
+	"\fEntryHistory\x12{\n" +
+	"\x10ListEntryHistory\x122.spire.server.entryhistory.ListEntryHistoryRequest\x1a3.spire.server.entryhistory.ListEntryHistoryResponseB9Z7github.com/spiffe/spire/proto/spire/server/entryhistoryb\x06proto3"
+
+var (
+	file_spire_server_entryhistory_entryhistory_proto_rawDescOnce sync.Once
+	file_spire_server_entryhistory_entryhistory_proto_rawDescData []byte
+)
+
+func file_spire_server_entryhistory_entryhistory_proto_rawDescGZIP() []byte {
+	file_spire_server_entryhistory_entryhistory_proto_rawDescOnce.Do(func() {
+		file_spire_server_entryhistory_entryhistory_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spire_server_entryhistory_entryhistory_proto_rawDesc), len(file_spire_server_entryhistory_entryhistory_proto_rawDesc)))
+	})
+	return file_spire_server_entryhistory_entryhistory_proto_rawDescData
+}
+
+var file_spire_server_entryhistory_entryhistory_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_spire_server_entryhistory_entryhistory_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_spire_server_entryhistory_entryhistory_proto_goTypes = []any{
+	(EntryChange_Operation)(0),       // 0: spire.server.entryhistory.EntryChange.Operation
+	(*ListEntryHistoryRequest)(nil),  // 1: spire.server.entryhistory.ListEntryHistoryRequest
+	(*ListEntryHistoryResponse)(nil), // 2: spire.server.entryhistory.ListEntryHistoryResponse
+	(*EntryChange)(nil),              // 3: spire.server.entryhistory.EntryChange
+	(*common.RegistrationEntry)(nil), // 4: spire.common.RegistrationEntry
+}
+var file_spire_server_entryhistory_entryhistory_proto_depIdxs = []int32{
+	3, // 0: spire.server.entryhistory.ListEntryHistoryResponse.changes:type_name -> spire.server.entryhistory.EntryChange
+	0, // 1: spire.server.entryhistory.EntryChange.operation:type_name -> spire.server.entryhistory.EntryChange.Operation
+	4, // 2: spire.server.entryhistory.EntryChange.before:type_name -> spire.common.RegistrationEntry
+	4, // 3: spire.server.entryhistory.EntryChange.after:type_name -> spire.common.RegistrationEntry
+	1, // 4: spire.server.entryhistory.EntryHistory.ListEntryHistory:input_type -> spire.server.entryhistory.ListEntryHistoryRequest
+	2, // 5: spire.server.entryhistory.EntryHistory.ListEntryHistory:output_type -> spire.server.entryhistory.ListEntryHistoryResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_spire_server_entryhistory_entryhistory_proto_init() }
+func file_spire_server_entryhistory_entryhistory_proto_init() {
+	if File_spire_server_entryhistory_entryhistory_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_server_entryhistory_entryhistory_proto_rawDesc), len(file_spire_server_entryhistory_entryhistory_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spire_server_entryhistory_entryhistory_proto_goTypes,
+		DependencyIndexes: file_spire_server_entryhistory_entryhistory_proto_depIdxs,
+		EnumInfos:         file_spire_server_entryhistory_entryhistory_proto_enumTypes,
+		MessageInfos:      file_spire_server_entryhistory_entryhistory_proto_msgTypes,
+	}.Build()
+	File_spire_server_entryhistory_entryhistory_proto = out.File
+	file_spire_server_entryhistory_entryhistory_proto_goTypes = nil
+	file_spire_server_entryhistory_entryhistory_proto_depIdxs = nil
+}
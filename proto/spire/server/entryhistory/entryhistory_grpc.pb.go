@@ -0,0 +1,115 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v7.35.0
+// source: spire/server/entryhistory/entryhistory.proto
+
+package entryhistory
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	EntryHistory_ListEntryHistory_FullMethodName = "/spire.server.entryhistory.EntryHistory/ListEntryHistory"
+)
+
+// EntryHistoryClient is the client API for EntryHistory service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EntryHistoryClient interface {
+	// Lists the changes of a registration entry, oldest first. Changes are
+	// kept after the entry is deleted, until they are older than the entry
+	// history retention period of the server.
+	ListEntryHistory(ctx context.Context, in *ListEntryHistoryRequest, opts ...grpc.CallOption) (*ListEntryHistoryResponse, error)
+}
+
+type entryHistoryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEntryHistoryClient(cc grpc.ClientConnInterface) EntryHistoryClient {
+	return &entryHistoryClient{cc}
+}
+
+func (c *entryHistoryClient) ListEntryHistory(ctx context.Context, in *ListEntryHistoryRequest, opts ...grpc.CallOption) (*ListEntryHistoryResponse, error) {
+	out := new(ListEntryHistoryResponse)
+	err := c.cc.Invoke(ctx, EntryHistory_ListEntryHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EntryHistoryServer is the server API for EntryHistory service.
+// All implementations must embed UnimplementedEntryHistoryServer
+// for forward compatibility
+type EntryHistoryServer interface {
+	// Lists the changes of a registration entry, oldest first. Changes are
+	// kept after the entry is deleted, until they are older than the entry
+	// history retention period of the server.
+	ListEntryHistory(context.Context, *ListEntryHistoryRequest) (*ListEntryHistoryResponse, error)
+	mustEmbedUnimplementedEntryHistoryServer()
+}
+
+// UnimplementedEntryHistoryServer must be embedded to have forward compatible implementations.
+type UnimplementedEntryHistoryServer struct {
+}
+
+func (UnimplementedEntryHistoryServer) ListEntryHistory(context.Context, *ListEntryHistoryRequest) (*ListEntryHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntryHistory not implemented")
+}
+func (UnimplementedEntryHistoryServer) mustEmbedUnimplementedEntryHistoryServer() {}
+
+// UnsafeEntryHistoryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EntryHistoryServer will
+// result in compilation errors.
+type UnsafeEntryHistoryServer interface {
+	mustEmbedUnimplementedEntryHistoryServer()
+}
+
+func RegisterEntryHistoryServer(s grpc.ServiceRegistrar, srv EntryHistoryServer) {
+	s.RegisterService(&EntryHistory_ServiceDesc, srv)
+}
+
+func _EntryHistory_ListEntryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntryHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryHistoryServer).ListEntryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryHistory_ListEntryHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryHistoryServer).ListEntryHistory(ctx, req.(*ListEntryHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EntryHistory_ServiceDesc is the grpc.ServiceDesc for EntryHistory service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EntryHistory_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.server.entryhistory.EntryHistory",
+	HandlerType: (*EntryHistoryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListEntryHistory",
+			Handler:    _EntryHistory_ListEntryHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/server/entryhistory/entryhistory.proto",
+}
@@ -0,0 +1,332 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        v7.35.0
+// source: spire/server/federationstatus/federationstatus.proto
+
+package federationstatus
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListFederationRelationshipStatusesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFederationRelationshipStatusesRequest) Reset() {
+	*x = ListFederationRelationshipStatusesRequest{}
+	mi := &file_spire_server_federationstatus_federationstatus_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFederationRelationshipStatusesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFederationRelationshipStatusesRequest) ProtoMessage() {}
+
+func (x *ListFederationRelationshipStatusesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_federationstatus_federationstatus_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFederationRelationshipStatusesRequest.ProtoReflect.Descriptor instead.
+func (*ListFederationRelationshipStatusesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_federationstatus_federationstatus_proto_rawDescGZIP(), []int{0}
+}
+
+type ListFederationRelationshipStatusesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The status of the federation relationships.
+	Statuses      []*FederationRelationshipStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFederationRelationshipStatusesResponse) Reset() {
+	*x = ListFederationRelationshipStatusesResponse{}
+	mi := &file_spire_server_federationstatus_federationstatus_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFederationRelationshipStatusesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFederationRelationshipStatusesResponse) ProtoMessage() {}
+
+func (x *ListFederationRelationshipStatusesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_federationstatus_federationstatus_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFederationRelationshipStatusesResponse.ProtoReflect.Descriptor instead.
+func (*ListFederationRelationshipStatusesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_federationstatus_federationstatus_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListFederationRelationshipStatusesResponse) GetStatuses() []*FederationRelationshipStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type GetFederationRelationshipStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The trust domain name of the federation relationship.
+	TrustDomain   string `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain,proto3" json:"trust_domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFederationRelationshipStatusRequest) Reset() {
+	*x = GetFederationRelationshipStatusRequest{}
+	mi := &file_spire_server_federationstatus_federationstatus_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFederationRelationshipStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFederationRelationshipStatusRequest) ProtoMessage() {}
+
+func (x *GetFederationRelationshipStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_federationstatus_federationstatus_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFederationRelationshipStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetFederationRelationshipStatusRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_federationstatus_federationstatus_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetFederationRelationshipStatusRequest) GetTrustDomain() string {
+	if x != nil {
+		return x.TrustDomain
+	}
+	return ""
+}
+
+type FederationRelationshipStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The trust domain name of the federation relationship.
+	TrustDomain string `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain,proto3" json:"trust_domain,omitempty"`
+	// When the bundle was last successfully refreshed from the bundle
+	// endpoint, in seconds since the Unix epoch. Zero if it never was.
+	LastRefresh int64 `protobuf:"varint,2,opt,name=last_refresh,json=lastRefresh,proto3" json:"last_refresh,omitempty"`
+	// When the bundle was last refreshed, successfully or not, in seconds
+	// since the Unix epoch. Zero if it never was.
+	LastRefreshAttempt int64 `protobuf:"varint,3,opt,name=last_refresh_attempt,json=lastRefreshAttempt,proto3" json:"last_refresh_attempt,omitempty"`
+	// When the next refresh is scheduled, in seconds since the Unix epoch.
+	// Zero if no refresh is scheduled yet.
+	NextRefresh int64 `protobuf:"varint,4,opt,name=next_refresh,json=nextRefresh,proto3" json:"next_refresh,omitempty"`
+	// The error of the last refresh attempt. Empty if it succeeded.
+	LastError string `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// The sequence number of the bundle held by the server. Zero if the
+	// server holds no bundle or the bundle has no sequence number.
+	BundleSequenceNumber uint64 `protobuf:"varint,6,opt,name=bundle_sequence_number,json=bundleSequenceNumber,proto3" json:"bundle_sequence_number,omitempty"`
+	// When the certificate presented by the bundle endpoint on the last
+	// successful refresh expires, in seconds since the Unix epoch. Zero if
+	// unknown.
+	EndpointCertificateExpiresAt int64 `protobuf:"varint,7,opt,name=endpoint_certificate_expires_at,json=endpointCertificateExpiresAt,proto3" json:"endpoint_certificate_expires_at,omitempty"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *FederationRelationshipStatus) Reset() {
+	*x = FederationRelationshipStatus{}
+	mi := &file_spire_server_federationstatus_federationstatus_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FederationRelationshipStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FederationRelationshipStatus) ProtoMessage() {}
+
+func (x *FederationRelationshipStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_federationstatus_federationstatus_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FederationRelationshipStatus.ProtoReflect.Descriptor instead.
+func (*FederationRelationshipStatus) Descriptor() ([]byte, []int) {
+	return file_spire_server_federationstatus_federationstatus_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FederationRelationshipStatus) GetTrustDomain() string {
+	if x != nil {
+		return x.TrustDomain
+	}
+	return ""
+}
+
+func (x *FederationRelationshipStatus) GetLastRefresh() int64 {
+	if x != nil {
+		return x.LastRefresh
+	}
+	return 0
+}
+
+func (x *FederationRelationshipStatus) GetLastRefreshAttempt() int64 {
+	if x != nil {
+		return x.LastRefreshAttempt
+	}
+	return 0
+}
+
+func (x *FederationRelationshipStatus) GetNextRefresh() int64 {
+	if x != nil {
+		return x.NextRefresh
+	}
+	return 0
+}
+
+func (x *FederationRelationshipStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *FederationRelationshipStatus) GetBundleSequenceNumber() uint64 {
+	if x != nil {
+		return x.BundleSequenceNumber
+	}
+	return 0
+}
+
+func (x *FederationRelationshipStatus) GetEndpointCertificateExpiresAt() int64 {
+	if x != nil {
+		return x.EndpointCertificateExpiresAt
+	}
+	return 0
+}
+
+var File_spire_server_federationstatus_federationstatus_proto protoreflect.FileDescriptor
+
+const file_spire_server_federationstatus_federationstatus_proto_rawDesc = "" +
+	"\n" +
+	"4spire/server/federationstatus/federationstatus.proto\x12\x1dspire.server.federationstatus\"+\n" +
+	")ListFederationRelationshipStatusesRequest\"\x85\x01\n" +
+	"*ListFederationRelationshipStatusesResponse\x12W\n" +
+	"\bstatuses\x18\x01 \x03(\v2;.spire.server.federationstatus.FederationRelationshipStatusR\bstatuses\"K\n" +
+	"&GetFederationRelationshipStatusRequest\x12!\n" +
+	"\ftrust_domain\x18\x01 \x01(\tR\vtrustDomain\"\xd5\x02\n" +
+	"\x1cFederationRelationshipStatus\x12!\n" +
+	"\ftrust_domain\x18\x01 \x01(\tR\vtrustDomain\x12!\n" +
+	"\flast_refresh\x18\x02 \x01(\x03R\vlastRefresh\x120\n" +
+	"\x14last_refresh_attempt\x18\x03 \x01(\x03R\x12lastRefreshAttempt\x12!\n" +
+	"\fnext_refresh\x18\x04 \x01(\x03R\vnextRefresh\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x05 \x01(\tR\tlastError\x124\n" +
+	"\x16bundle_sequence_number\x18\x06 \x01(\x04R\x14bundleSequenceNumber\x12E\n" +
+	"\x1fendpoint_certificate_expires_at\x18\a \x01(\x03R\x1cendpointCertificateExpiresAt2\xf6\x02\n" +
+	"\x10FederationStatus\x12\xb9\x01\n" +
+	"\"ListFederationRelationshipStatuses\x12H.spire.server.federationstatus.ListFederationRelationshipStatusesRequest\x1aI.spire.server.federationstatus.ListFederationRelationshipStatusesResponse\x12\xa5\x01\n" +
+	"\x1fGetFederationRelationshipStatus\x12E.spire.server.federationstatus.GetFederationRelationshipStatusRequest\x1a;.spire.server.federationstatus.FederationRelationshipStatusB=Z;github.com/spiffe/spire/proto/spire/server/federationstatusb\x06proto3"
+
+var (
+	file_spire_server_federationstatus_federationstatus_proto_rawDescOnce sync.Once
+	file_spire_server_federationstatus_federationstatus_proto_rawDescData []byte
+)
+
+func file_spire_server_federationstatus_federationstatus_proto_rawDescGZIP() []byte {
+	file_spire_server_federationstatus_federationstatus_proto_rawDescOnce.Do(func() {
+		file_spire_server_federationstatus_federationstatus_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spire_server_federationstatus_federationstatus_proto_rawDesc), len(file_spire_server_federationstatus_federationstatus_proto_rawDesc)))
+	})
+	return file_spire_server_federationstatus_federationstatus_proto_rawDescData
+}
+
+var file_spire_server_federationstatus_federationstatus_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_spire_server_federationstatus_federationstatus_proto_goTypes = []any{
+	(*ListFederationRelationshipStatusesRequest)(nil),  // 0: spire.server.federationstatus.ListFederationRelationshipStatusesRequest
+	(*ListFederationRelationshipStatusesResponse)(nil), // 1: spire.server.federationstatus.ListFederationRelationshipStatusesResponse
+	(*GetFederationRelationshipStatusRequest)(nil),     // 2: spire.server.federationstatus.GetFederationRelationshipStatusRequest
+	(*FederationRelationshipStatus)(nil),               // 3: spire.server.federationstatus.FederationRelationshipStatus
+}
+var file_spire_server_federationstatus_federationstatus_proto_depIdxs = []int32{
+	3, // 0: spire.server.federationstatus.ListFederationRelationshipStatusesResponse.statuses:type_name -> spire.server.federationstatus.FederationRelationshipStatus
+	0, // 1: spire.server.federationstatus.FederationStatus.ListFederationRelationshipStatuses:input_type -> spire.server.federationstatus.ListFederationRelationshipStatusesRequest
+	2, // 2: spire.server.federationstatus.FederationStatus.GetFederationRelationshipStatus:input_type -> spire.server.federationstatus.GetFederationRelationshipStatusRequest
+	1, // 3: spire.server.federationstatus.FederationStatus.ListFederationRelationshipStatuses:output_type -> spire.server.federationstatus.ListFederationRelationshipStatusesResponse
+	3, // 4: spire.server.federationstatus.FederationStatus.GetFederationRelationshipStatus:output_type -> spire.server.federationstatus.FederationRelationshipStatus
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_spire_server_federationstatus_federationstatus_proto_init() }
+func file_spire_server_federationstatus_federationstatus_proto_init() {
+	if File_spire_server_federationstatus_federationstatus_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_server_federationstatus_federationstatus_proto_rawDesc), len(file_spire_server_federationstatus_federationstatus_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spire_server_federationstatus_federationstatus_proto_goTypes,
+		DependencyIndexes: file_spire_server_federationstatus_federationstatus_proto_depIdxs,
+		MessageInfos:      file_spire_server_federationstatus_federationstatus_proto_msgTypes,
+	}.Build()
+	File_spire_server_federationstatus_federationstatus_proto = out.File
+	file_spire_server_federationstatus_federationstatus_proto_goTypes = nil
+	file_spire_server_federationstatus_federationstatus_proto_depIdxs = nil
+}
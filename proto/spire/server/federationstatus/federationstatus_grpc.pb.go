@@ -0,0 +1,156 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v7.35.0
+// source: spire/server/federationstatus/federationstatus.proto
+
+package federationstatus
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	FederationStatus_ListFederationRelationshipStatuses_FullMethodName = "/spire.server.federationstatus.FederationStatus/ListFederationRelationshipStatuses"
+	FederationStatus_GetFederationRelationshipStatus_FullMethodName    = "/spire.server.federationstatus.FederationStatus/GetFederationRelationshipStatus"
+)
+
+// FederationStatusClient is the client API for FederationStatus service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FederationStatusClient interface {
+	// Lists the status of the federation relationships managed by the
+	// server, including those set in the server configuration, sorted by
+	// trust domain name.
+	ListFederationRelationshipStatuses(ctx context.Context, in *ListFederationRelationshipStatusesRequest, opts ...grpc.CallOption) (*ListFederationRelationshipStatusesResponse, error)
+	// Gets the status of the federation relationship with a trust domain.
+	// Fails with NotFound if the trust domain is not managed by the server.
+	GetFederationRelationshipStatus(ctx context.Context, in *GetFederationRelationshipStatusRequest, opts ...grpc.CallOption) (*FederationRelationshipStatus, error)
+}
+
+type federationStatusClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFederationStatusClient(cc grpc.ClientConnInterface) FederationStatusClient {
+	return &federationStatusClient{cc}
+}
+
+func (c *federationStatusClient) ListFederationRelationshipStatuses(ctx context.Context, in *ListFederationRelationshipStatusesRequest, opts ...grpc.CallOption) (*ListFederationRelationshipStatusesResponse, error) {
+	out := new(ListFederationRelationshipStatusesResponse)
+	err := c.cc.Invoke(ctx, FederationStatus_ListFederationRelationshipStatuses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationStatusClient) GetFederationRelationshipStatus(ctx context.Context, in *GetFederationRelationshipStatusRequest, opts ...grpc.CallOption) (*FederationRelationshipStatus, error) {
+	out := new(FederationRelationshipStatus)
+	err := c.cc.Invoke(ctx, FederationStatus_GetFederationRelationshipStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FederationStatusServer is the server API for FederationStatus service.
+// All implementations must embed UnimplementedFederationStatusServer
+// for forward compatibility
+type FederationStatusServer interface {
+	// Lists the status of the federation relationships managed by the
+	// server, including those set in the server configuration, sorted by
+	// trust domain name.
+	ListFederationRelationshipStatuses(context.Context, *ListFederationRelationshipStatusesRequest) (*ListFederationRelationshipStatusesResponse, error)
+	// Gets the status of the federation relationship with a trust domain.
+	// Fails with NotFound if the trust domain is not managed by the server.
+	GetFederationRelationshipStatus(context.Context, *GetFederationRelationshipStatusRequest) (*FederationRelationshipStatus, error)
+	mustEmbedUnimplementedFederationStatusServer()
+}
+
+// UnimplementedFederationStatusServer must be embedded to have forward compatible implementations.
+type UnimplementedFederationStatusServer struct {
+}
+
+func (UnimplementedFederationStatusServer) ListFederationRelationshipStatuses(context.Context, *ListFederationRelationshipStatusesRequest) (*ListFederationRelationshipStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederationRelationshipStatuses not implemented")
+}
+func (UnimplementedFederationStatusServer) GetFederationRelationshipStatus(context.Context, *GetFederationRelationshipStatusRequest) (*FederationRelationshipStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFederationRelationshipStatus not implemented")
+}
+func (UnimplementedFederationStatusServer) mustEmbedUnimplementedFederationStatusServer() {}
+
+// UnsafeFederationStatusServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FederationStatusServer will
+// result in compilation errors.
+type UnsafeFederationStatusServer interface {
+	mustEmbedUnimplementedFederationStatusServer()
+}
+
+func RegisterFederationStatusServer(s grpc.ServiceRegistrar, srv FederationStatusServer) {
+	s.RegisterService(&FederationStatus_ServiceDesc, srv)
+}
+
+func _FederationStatus_ListFederationRelationshipStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederationRelationshipStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationStatusServer).ListFederationRelationshipStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationStatus_ListFederationRelationshipStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationStatusServer).ListFederationRelationshipStatuses(ctx, req.(*ListFederationRelationshipStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationStatus_GetFederationRelationshipStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFederationRelationshipStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationStatusServer).GetFederationRelationshipStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationStatus_GetFederationRelationshipStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationStatusServer).GetFederationRelationshipStatus(ctx, req.(*GetFederationRelationshipStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FederationStatus_ServiceDesc is the grpc.ServiceDesc for FederationStatus service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FederationStatus_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.server.federationstatus.FederationStatus",
+	HandlerType: (*FederationStatusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFederationRelationshipStatuses",
+			Handler:    _FederationStatus_ListFederationRelationshipStatuses_Handler,
+		},
+		{
+			MethodName: "GetFederationRelationshipStatus",
+			Handler:    _FederationStatus_GetFederationRelationshipStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/server/federationstatus/federationstatus.proto",
+}
@@ -333,6 +333,13 @@ func (s *DataStore) DeleteRegistrationEntry(ctx context.Context, entryID string)
 	return s.ds.DeleteRegistrationEntry(ctx, entryID)
 }
 
+func (s *DataStore) ActivateRegistrationEntries(ctx context.Context, since, until time.Time) error {
+	if err := s.getNextError(); err != nil {
+		return err
+	}
+	return s.ds.ActivateRegistrationEntries(ctx, since, until)
+}
+
 func (s *DataStore) PruneRegistrationEntries(ctx context.Context, expiresBefore time.Time) error {
 	if err := s.getNextError(); err != nil {
 		return err
@@ -375,6 +382,20 @@ func (s *DataStore) FetchRegistrationEntryEvent(ctx context.Context, eventID uin
 	return s.ds.FetchRegistrationEntryEvent(ctx, eventID)
 }
 
+func (s *DataStore) ListRegistrationEntryChanges(ctx context.Context, req *datastore.ListRegistrationEntryChangesRequest) (*datastore.ListRegistrationEntryChangesResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.ListRegistrationEntryChanges(ctx, req)
+}
+
+func (s *DataStore) PruneRegistrationEntryChanges(ctx context.Context, olderThan time.Duration) error {
+	if err := s.getNextError(); err != nil {
+		return err
+	}
+	return s.ds.PruneRegistrationEntryChanges(ctx, olderThan)
+}
+
 func (s *DataStore) CreateJoinToken(ctx context.Context, token *datastore.JoinToken) error {
 	if err := s.getNextError(); err != nil {
 		return err
@@ -396,6 +417,13 @@ func (s *DataStore) DeleteJoinToken(ctx context.Context, token string) error {
 	return s.ds.DeleteJoinToken(ctx, token)
 }
 
+func (s *DataStore) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.ListJoinTokens(ctx, req)
+}
+
 func (s *DataStore) PruneJoinTokens(ctx context.Context, expiresBefore time.Time) error {
 	if err := s.getNextError(); err != nil {
 		return err
@@ -445,6 +473,13 @@ func (s *DataStore) FetchCAJournal(ctx context.Context, activeX509AuthorityID st
 	return s.ds.FetchCAJournal(ctx, activeX509AuthorityID)
 }
 
+func (s *DataStore) ListCAJournals(ctx context.Context, req *datastore.ListCAJournalsRequest) (*datastore.ListCAJournalsResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.ListCAJournals(ctx, req)
+}
+
 func (s *DataStore) ListCAJournalsForTesting(ctx context.Context) ([]*datastore.CAJournal, error) {
 	if err := s.getNextError(); err != nil {
 		return nil, err
@@ -452,6 +487,13 @@ func (s *DataStore) ListCAJournalsForTesting(ctx context.Context) ([]*datastore.
 	return s.ds.ListCAJournalsForTesting(ctx)
 }
 
+func (s *DataStore) ExportRecords(ctx context.Context, fn func(*datastore.ExportRecord) error) error {
+	if err := s.getNextError(); err != nil {
+		return err
+	}
+	return s.ds.ExportRecords(ctx, fn)
+}
+
 func (s *DataStore) SetCAJournal(ctx context.Context, caJournal *datastore.CAJournal) (*datastore.CAJournal, error) {
 	if err := s.getNextError(); err != nil {
 		return nil, err
@@ -31,6 +31,14 @@ type Options struct {
 	WITSVIDTTL      time.Duration
 	DisableJWTSVIDs bool
 	DisableWITSVIDs bool
+
+	// CredentialProfilePolicy governs the credential profiles allowed when
+	// signing workload X509-SVIDs.
+	CredentialProfilePolicy credtemplate.CredentialProfilePolicy
+
+	// ClaimTemplates render custom claims into workload JWT-SVIDs and
+	// WIT-SVIDs.
+	ClaimTemplates []*credtemplate.ClaimTemplate
 }
 
 type CA struct {
@@ -76,6 +84,9 @@ func New(t *testing.T, trustDomain spiffeid.TrustDomain, options *Options) *CA {
 		X509SVIDTTL:  options.X509SVIDTTL,
 		JWTSVIDTTL:   options.JWTSVIDTTL,
 		WITSVIDTTL:   options.WITSVIDTTL,
+
+		CredentialProfilePolicy: options.CredentialProfilePolicy,
+		ClaimTemplates:          options.ClaimTemplates,
 	})
 	require.NoError(t, err)
 
@@ -32,6 +32,8 @@ type WorkloadAPI struct {
 	fetchJWTSVIDRequest    FakeRequest
 	fetchJWTBundlesRequest FakeRequest
 	validateJWTRequest     FakeRequest
+	fetchWITSVIDRequest    FakeRequest
+	fetchWITBundlesRequest FakeRequest
 }
 
 func New(t *testing.T, responses ...*FakeRequest) *WorkloadAPI {
@@ -51,6 +53,10 @@ func New(t *testing.T, responses ...*FakeRequest) *WorkloadAPI {
 			w.fetchJWTBundlesRequest = *response
 		case *workload.ValidateJWTSVIDResponse:
 			w.validateJWTRequest = *response
+		case *workload.WITSVIDResponse:
+			w.fetchWITSVIDRequest = *response
+		case *workload.WITBundlesResponse:
+			w.fetchWITBundlesRequest = *response
 		default:
 			require.FailNow(t, "unexpected result type %T", response.Resp)
 		}
@@ -148,6 +154,54 @@ func (w *WorkloadAPI) ValidateJWTSVID(_ context.Context, req *workload.ValidateJ
 	return nil, nil
 }
 
+func (w *WorkloadAPI) FetchWITSVID(req *workload.WITSVIDRequest, stream workload.SpiffeWorkloadAPI_FetchWITSVIDServer) error {
+	if err := checkSecurityHeader(stream.Context()); err != nil {
+		return err
+	}
+
+	if w.fetchWITSVIDRequest.Err != nil {
+		return w.fetchWITSVIDRequest.Err
+	}
+
+	if request, ok := w.fetchWITSVIDRequest.Req.(*workload.WITSVIDRequest); ok {
+		spiretest.AssertProtoEqual(w.t, request, req)
+	} else {
+		require.FailNow(w.t, fmt.Sprintf("unexpected message type %T", w.fetchWITSVIDRequest.Req))
+	}
+
+	if response, ok := w.fetchWITSVIDRequest.Resp.(*workload.WITSVIDResponse); ok {
+		_ = stream.Send(response)
+		<-stream.Context().Done()
+	} else {
+		require.FailNow(w.t, fmt.Sprintf("unexpected message type %T", w.fetchWITSVIDRequest.Resp))
+	}
+	return nil
+}
+
+func (w *WorkloadAPI) FetchWITBundles(req *workload.WITBundlesRequest, stream workload.SpiffeWorkloadAPI_FetchWITBundlesServer) error {
+	if err := checkSecurityHeader(stream.Context()); err != nil {
+		return err
+	}
+
+	if w.fetchWITBundlesRequest.Err != nil {
+		return w.fetchWITBundlesRequest.Err
+	}
+
+	if request, ok := w.fetchWITBundlesRequest.Req.(*workload.WITBundlesRequest); ok {
+		spiretest.AssertProtoEqual(w.t, request, req)
+	} else {
+		require.FailNow(w.t, fmt.Sprintf("unexpected message type %T", w.fetchWITBundlesRequest.Req))
+	}
+
+	if response, ok := w.fetchWITBundlesRequest.Resp.(*workload.WITBundlesResponse); ok {
+		_ = stream.Send(response)
+		<-stream.Context().Done()
+	} else {
+		require.FailNow(w.t, fmt.Sprintf("unexpected message type %T", w.fetchWITBundlesRequest.Resp))
+	}
+	return nil
+}
+
 func checkSecurityHeader(ctx context.Context) error {
 	// Ensure security header is sent
 	md, ok := metadata.FromIncomingContext(ctx)